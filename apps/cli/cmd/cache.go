@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/janitor"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+	transcriptionSvc "github.com/Taichi-iskw/yt-lang/internal/service/transcription"
+)
+
+// cacheCmd represents the cache command
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage local caches and temporary files",
+	Long: `Manage the files yt-lang keeps on local disk: cached audio, yt-dlp caches and the
+temporary directories of running commands.
+Temporary directories are registered in ~/.yt-lang/state while in use; leftovers of crashed
+commands are removed automatically when the next command starts.`,
+}
+
+// cachePruneCmd removes leftovers and evicts cached files beyond the size limit
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove leftover temp files and evict cached files beyond the size limit",
+	Long: `Remove temporary directories of commands that are no longer running (or that are older
+than 24 hours), partial artifact files of writes that never finished, and then the least
+recently used cached files until the caches fit the size limit.
+The size limit is --max-size, or storage.max_cache_size of config.yaml. With --temp only
+leftover temp files are removed.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tempOnly, _ := cmd.Flags().GetBool("temp")
+		maxSize, _ := cmd.Flags().GetString("max-size")
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		result, err := janitor.PruneTemp()
+		if err != nil {
+			return fmt.Errorf("failed to prune temp directories: %w", err)
+		}
+
+		artifactDir, err := config.LocalArtifactDir(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to resolve artifact directory: %w", err)
+		}
+		if artifactDir != "" {
+			partial, err := janitor.PrunePartialArtifacts(artifactDir)
+			if err != nil {
+				return err
+			}
+			result.Removed = append(result.Removed, partial.Removed...)
+			result.Bytes += partial.Bytes
+		}
+
+		if !tempOnly {
+			if maxSize == "" {
+				maxSize = cfg.Storage.MaxCacheSize
+			}
+			if maxSize != "" {
+				evicted, err := enforceCacheSize(cfg, maxSize)
+				if err != nil {
+					return err
+				}
+				result.Removed = append(result.Removed, evicted.Removed...)
+				result.Bytes += evicted.Bytes
+			}
+		}
+
+		for _, path := range result.Removed {
+			fmt.Printf("Removed %s\n", path)
+		}
+		fmt.Printf("Pruned %d file(s) and directories, freed %s\n", len(result.Removed), janitor.FormatSize(result.Bytes))
+		return nil
+	},
+}
+
+// cacheTranscodeCmd re-encodes cached audio to a smaller format
+var cacheTranscodeCmd = &cobra.Command{
+	Use:   "transcode [VIDEO_ID...]",
+	Short: "Re-encode cached audio to a smaller format",
+	Long: `Re-encode the cached audio of the given videos, or of every saved video, with ffmpeg to reclaim
+disk space on large caches. Speech stays transcribable at low bitrates: Opus at 32k mono is a
+fraction of the size of YouTube's audio, and later transcriptions restore the re-encoded file
+instead of downloading again. Audio already in the target format, or that would not get smaller,
+is kept as is.
+
+The SHA-256 and size of the original audio are kept next to the re-encoded file
+(audio/VIDEO_ID.source.json in the artifact store), so re-encoded audio can still be traced to
+what YouTube served. Re-encoding is lossy: the original audio is gone afterwards.
+
+Examples:
+  yt-lang cache transcode --to opus --bitrate 32k
+  yt-lang cache transcode dQw4w9WgXcQ --to mp3 --bitrate 48k
+  yt-lang cache transcode --dry-run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var opts transcriptionSvc.TranscodeOptions
+		opts.Format, _ = cmd.Flags().GetString("to")
+		opts.Bitrate, _ = cmd.Flags().GetString("bitrate")
+		opts.DryRun, _ = cmd.Flags().GetBool("dry-run")
+		if err := opts.Validate(); err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		connectCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		dbPool, err := config.NewDatabasePool(connectCtx, cfg)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		store, err := config.NewArtifactStore(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to open artifact store: %w", err)
+		}
+
+		return handler.TranscodeCachedAudio(ctx, cmd.OutOrStdout(), video.NewRepository(dbPool), transcriptionSvc.NewAudioTranscoder(store), args, opts)
+	},
+}
+
+// enforceCacheSize evicts least recently used cached files until the caches fit maxSize
+func enforceCacheSize(cfg *config.Config, maxSize string) (*janitor.PruneResult, error) {
+	maxBytes, err := janitor.ParseSize(maxSize)
+	if err != nil {
+		return nil, err
+	}
+	dirs, err := config.CacheDirs(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve cache directories: %w", err)
+	}
+	return janitor.EnforceMaxSize(dirs, maxBytes)
+}
+
+// startupCleanup registers temp directories of this process and removes leftovers of crashed
+// ones. Cleanup never fails a command: problems surface in cache prune instead.
+func startupCleanup(cmd *cobra.Command) {
+	stateDir, err := config.GetStateDir()
+	if err != nil {
+		return
+	}
+	janitor.SetStateDir(stateDir)
+
+	// cache prune reports what it removes, so leave the leftovers to it
+	if cmd == cachePruneCmd {
+		return
+	}
+	janitor.PruneTemp()
+
+	// Without a config file there are no size limits to enforce
+	if cfg, err := config.NewConfig(); err == nil && cfg.Storage.MaxCacheSize != "" {
+		enforceCacheSize(cfg, cfg.Storage.MaxCacheSize)
+	}
+}
+
+func init() {
+	cachePruneCmd.Flags().Bool("temp", false, "Only remove leftover temp directories and partial artifacts")
+	cachePruneCmd.Flags().String("max-size", "", "Size limit of the caches, e.g. 20GB (default: storage.max_cache_size)")
+
+	cacheTranscodeCmd.Flags().String("to", "opus", "Format to re-encode to: opus, mp3")
+	cacheTranscodeCmd.Flags().String("bitrate", transcriptionSvc.DefaultTranscodeBitrate, "Audio bitrate, e.g. 32k")
+	cacheTranscodeCmd.Flags().Bool("dry-run", false, "Show the cached audio that would be re-encoded without changing it")
+
+	cacheCmd.AddCommand(cachePruneCmd)
+	cacheCmd.AddCommand(cacheTranscodeCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
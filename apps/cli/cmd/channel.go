@@ -8,11 +8,13 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
 	"github.com/Taichi-iskw/yt-lang/internal/config"
 	"github.com/Taichi-iskw/yt-lang/internal/repository/channel"
 	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
 	"github.com/Taichi-iskw/yt-lang/internal/service/common"
 	youtubeSvc "github.com/Taichi-iskw/yt-lang/internal/service/youtube"
+	"github.com/Taichi-iskw/yt-lang/internal/tmpl"
 )
 
 // channelCmd represents the channel command
@@ -92,29 +94,31 @@ var channelSaveCmd = &cobra.Command{
 			videoRepo,
 		)
 
-		// Save channel info
-		channel, err := youtubeService.SaveChannelInfo(ctx, channelURL)
-		if err != nil {
-			return fmt.Errorf("failed to save channel info: %w", err)
-		}
-
-		// Display result as JSON
-		result, err := json.MarshalIndent(channel, "", "  ")
-		if err != nil {
-			return fmt.Errorf("failed to format result: %w", err)
-		}
-
-		fmt.Printf("Channel saved successfully:\n%s\n", string(result))
-		return nil
+		return handler.SaveChannel(ctx, cmd.OutOrStdout(), youtubeService, channelURL)
 	},
 }
 
+// channelPorcelainFields are the channel fields of channel list --porcelain, in column order
+var channelPorcelainFields = []string{"ID", "Name", "URL"}
+
 // channelListCmd lists all saved channels
 var channelListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all saved channels",
-	Long:  `List all channels saved in the database.`,
+	Long: `List all channels saved in the database.
+--template renders each channel with a Go template instead of JSON, one per line
+(e.g. --template '{{.ID}}\t{{.Name}}'; {{json .}} shows every field).
+--porcelain prints ID, name and URL tab-separated for scripts, with no header, in a column
+order that never changes; -z ends each channel with NUL instead of a newline.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		text, _ := cmd.Flags().GetString("template")
+		porcelain, _ := cmd.Flags().GetBool("porcelain")
+		nul, _ := cmd.Flags().GetBool("null")
+		rendered, err := tmpl.FromFlags(text, porcelain, nul, channelPorcelainFields)
+		if err != nil {
+			return err
+		}
+
 		// Create service with timeout context
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
@@ -147,26 +151,11 @@ var channelListCmd = &cobra.Command{
 		limit, _ := cmd.Flags().GetInt("limit")
 		offset, _ := cmd.Flags().GetInt("offset")
 
-		// List channels
-		channels, err := youtubeService.ListChannels(ctx, limit, offset)
-		if err != nil {
-			return fmt.Errorf("failed to list channels: %w", err)
-		}
-
-		// Check if no channels found
-		if len(channels) == 0 {
-			fmt.Println("No channels found in the database.")
-			return nil
-		}
-
-		// Display result as JSON
-		result, err := json.MarshalIndent(channels, "", "  ")
-		if err != nil {
-			return fmt.Errorf("failed to format result: %w", err)
-		}
-
-		fmt.Printf("Found %d channel(s):\n%s\n", len(channels), string(result))
-		return nil
+		return handler.ListChannels(ctx, cmd.OutOrStdout(), youtubeService, handler.ListChannelsOptions{
+			Limit:    limit,
+			Offset:   offset,
+			Template: rendered,
+		})
 	},
 }
 
@@ -174,6 +163,9 @@ func init() {
 	// Add pagination flags to list command
 	channelListCmd.Flags().Int("limit", 10, "Maximum number of channels to retrieve")
 	channelListCmd.Flags().Int("offset", 0, "Number of channels to skip")
+	channelListCmd.Flags().String("template", "", "Render each channel with a Go template (e.g. '{{.ID}} {{.Name}}')")
+	channelListCmd.Flags().Bool("porcelain", false, "Print stable tab-separated fields for scripts: id, name, url")
+	channelListCmd.Flags().BoolP("null", "z", false, "End each --porcelain record with NUL instead of a newline")
 
 	channelCmd.AddCommand(channelInfoCmd)
 	channelCmd.AddCommand(channelSaveCmd)
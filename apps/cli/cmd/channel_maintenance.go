@@ -0,0 +1,364 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/lock"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/channel"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+	"github.com/Taichi-iskw/yt-lang/internal/schedule"
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+	"github.com/Taichi-iskw/yt-lang/internal/service/langprofile"
+	youtubeSvc "github.com/Taichi-iskw/yt-lang/internal/service/youtube"
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
+)
+
+// channelRefreshCmd updates a saved channel's name and URL from YouTube
+var channelRefreshCmd = &cobra.Command{
+	Use:   "refresh [CHANNEL_ID]",
+	Short: "Update a saved channel's name and URL from YouTube",
+	Long: `Re-read the name and URL of a saved channel from YouTube.
+Channels change their names and handles over time; refresh keeps the saved row current.
+If another saved channel already has the new URL, it is a stale duplicate: combine them with channel merge.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		channelID := args[0]
+
+		// Create service with timeout context
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		youtubeService := youtubeSvc.NewYouTubeServiceWithDetector(
+			common.NewCmdRunner(),
+			channel.NewRepository(dbPool),
+			video.NewRepository(dbPool),
+			ytdlp.DefaultDetector(),
+		)
+
+		return handler.RefreshChannel(ctx, cmd.OutOrStdout(), youtubeService, channelID)
+	},
+}
+
+// channelMergeCmd moves a duplicate channel's videos into another channel and deletes it
+var channelMergeCmd = &cobra.Command{
+	Use:   "merge [FROM_CHANNEL_ID] [INTO_CHANNEL_ID]",
+	Short: "Move a duplicate channel's videos into another channel",
+	Long: `Re-point every video of FROM_CHANNEL_ID to INTO_CHANNEL_ID and delete FROM_CHANNEL_ID,
+in one transaction. Transcriptions and translations stay with their videos.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fromID, intoID := args[0], args[1]
+
+		// Get confirmation flag
+		force, _ := cmd.Flags().GetBool("force")
+
+		if !force {
+			fmt.Printf("Are you sure you want to merge channel '%s' into '%s' and delete '%s'? [y/N]: ", fromID, intoID, fromID)
+			var response string
+			fmt.Scanln(&response)
+			if response != "y" && response != "Y" {
+				fmt.Println("Merge cancelled.")
+				return nil
+			}
+		}
+
+		// Create service with timeout context
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		// Lock both channels so no running sync adds videos to the channel being deleted
+		youtubeService := youtubeSvc.NewLockingService(
+			youtubeSvc.NewYouTubeServiceWithRepositories(
+				common.NewCmdRunner(),
+				channel.NewRepository(dbPool),
+				video.NewRepository(dbPool),
+			),
+			lock.NewPostgresLocker(dbPool.Pool),
+		)
+
+		return handler.MergeChannels(ctx, cmd.OutOrStdout(), youtubeService, fromID, intoID)
+	},
+}
+
+// channelSyncCmd saves a channel's new videos and checks its video count against YouTube
+var channelSyncCmd = &cobra.Command{
+	Use:   "sync [CHANNEL_ID]",
+	Short: "Save a channel's new videos and check its video count against YouTube",
+	Long: `Save the videos of a saved channel like video save, then compare the number of uploads YouTube
+reports for the channel with the number of available saved videos. A large difference is flagged:
+more saved videos than reported suggests deleted videos, fewer suggests gaps in earlier fetches.
+
+Use --reconcile to fetch the full video list instead: missing videos are saved, and saved videos
+no longer listed are marked unavailable like video verify does.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		channelID := args[0]
+		reconcile, _ := cmd.Flags().GetBool("reconcile")
+
+		var opts youtubeSvc.FetchOptions
+		if publishedAfter, _ := cmd.Flags().GetString("published-after"); publishedAfter != "" {
+			if reconcile {
+				return fmt.Errorf("--published-after cannot be used with --reconcile, which fetches the full video list")
+			}
+			date, err := time.Parse("2006-01-02", publishedAfter)
+			if err != nil {
+				return fmt.Errorf("invalid --published-after %q (expected YYYY-MM-DD)", publishedAfter)
+			}
+			opts.PublishedAfter = date
+		}
+
+		// Listing large channels can take a while
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		hooks, err := config.NewHookDispatcher(cfg)
+		if err != nil {
+			return err
+		}
+
+		// Locked per channel like video save and video verify
+		youtubeService := youtubeSvc.NewHookedService(
+			youtubeSvc.NewLockingService(
+				youtubeSvc.NewYouTubeServiceWithDetector(
+					common.NewCmdRunner(),
+					channel.NewRepository(dbPool),
+					video.NewRepository(dbPool),
+					ytdlp.DefaultDetector(),
+				),
+				lock.NewPostgresLocker(dbPool.Pool),
+			),
+			hooks,
+		)
+
+		if reconcile {
+			result, err := youtubeService.ReconcileChannelVideos(ctx, channelID)
+			if err != nil {
+				return fmt.Errorf("failed to reconcile videos: %w", err)
+			}
+			fmt.Printf("Reconciled channel %s: %d video(s) added, %d checked, %d changed (%d unavailable)\n",
+				channelID, len(result.Added), result.Verify.Checked, result.Verify.Changed, result.Verify.Unavailable)
+		} else {
+			opts.OnChanged = func(changes []*model.VideoChange) {
+				handler.PrintChangedVideos(os.Stdout, changes)
+			}
+			videos, err := youtubeService.SaveChannelVideos(ctx, channelID, opts)
+			if err != nil {
+				return fmt.Errorf("failed to save videos: %w", err)
+			}
+			fmt.Printf("Synced channel %s: %d video(s) fetched\n", channelID, len(videos))
+		}
+
+		// The sync itself succeeded; a failed count check is only worth a warning
+		drift, err := youtubeService.CheckVideoCount(ctx, channelID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to check video count: %v\n", err)
+			return nil
+		}
+
+		fmt.Printf("Videos: %d saved and available, %d reported by YouTube\n", drift.Stored, drift.Reported)
+		switch {
+		case !drift.Drifted:
+		case drift.Difference > 0:
+			fmt.Fprintf(os.Stderr, "Warning: %d more saved videos than YouTube reports; some may have been deleted. Run with --reconcile to mark them unavailable\n", drift.Difference)
+		case reconcile:
+			fmt.Fprintf(os.Stderr, "Warning: %d fewer saved videos than YouTube reports, even after reconciling; the channel may have members-only or unlisted uploads\n", -drift.Difference)
+		default:
+			fmt.Fprintf(os.Stderr, "Warning: %d fewer saved videos than YouTube reports; earlier fetches may have missed some. Run with --reconcile to fetch the full list\n", -drift.Difference)
+		}
+		return nil
+	},
+}
+
+// channelScheduleCmd sets how often watch run syncs a channel
+var channelScheduleCmd = &cobra.Command{
+	Use:   "schedule [CHANNEL_ID]",
+	Short: "Set how often watch run syncs a channel",
+	Long: `Set the sync schedule of a saved channel: an interval with --every, or a five-field cron
+expression with --cron (minute, hour, day of month, month, day of week; @hourly, @daily and
+@weekly also work). Schedules are stored in the database, so every machine running watch run
+against it syncs the same channels. Intervals count from the start of the last sync; a channel
+that never synced is due right away. Without flags the current schedule is shown; --off removes it.
+See watch status for the last and next run of every scheduled channel.
+
+Examples:
+  yt-lang channel schedule UCxxx --every 12h
+  yt-lang channel schedule UCxxx --cron "0 6 * * 1-5"
+  yt-lang channel schedule UCxxx --off`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		channelID := args[0]
+		every, _ := cmd.Flags().GetDuration("every")
+		cronExpr, _ := cmd.Flags().GetString("cron")
+		off, _ := cmd.Flags().GetBool("off")
+
+		var spec string
+		switch {
+		case every != 0 && cronExpr != "":
+			return fmt.Errorf("--every and --cron cannot be used together")
+		case off && (every != 0 || cronExpr != ""):
+			return fmt.Errorf("--off cannot be used with --every or --cron")
+		case every != 0:
+			spec = schedule.Every(every)
+		case cronExpr != "":
+			spec = cronExpr
+		}
+		if spec != "" {
+			if _, err := schedule.Parse(spec); err != nil {
+				return err
+			}
+		}
+
+		// Create service with timeout context
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		scheduleRepo := channel.NewScheduleRepository(dbPool)
+		switch {
+		case off:
+			if err := scheduleRepo.Delete(ctx, channelID); err != nil {
+				return fmt.Errorf("failed to remove schedule: %w", err)
+			}
+			fmt.Printf("Removed the schedule of channel %s\n", channelID)
+		case spec != "":
+			if _, err := channel.NewRepository(dbPool).GetByID(ctx, channelID); err != nil {
+				return fmt.Errorf("failed to get channel: %w", err)
+			}
+			if err := scheduleRepo.Set(ctx, channelID, spec); err != nil {
+				return fmt.Errorf("failed to set schedule: %w", err)
+			}
+			fmt.Printf("Channel %s syncs on schedule %q; run watch run to sync it\n", channelID, spec)
+		default:
+			schedules, err := scheduleRepo.List(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to list schedules: %w", err)
+			}
+			for _, s := range schedules {
+				if s.ChannelID == channelID {
+					fmt.Printf("Channel %s syncs on schedule %q\n", channelID, s.Spec)
+					return nil
+				}
+			}
+			fmt.Printf("Channel %s has no schedule; set one with --every or --cron\n", channelID)
+		}
+		return nil
+	},
+}
+
+// channelLanguagesCmd infers a channel's spoken languages from its transcriptions
+var channelLanguagesCmd = &cobra.Command{
+	Use:   "languages [CHANNEL_ID]",
+	Short: "Infer a channel's languages from its transcriptions",
+	Long: `Count the spoken languages of the channel's completed transcriptions (the detected language of
+auto-detected ones) and store those spoken in at least --min-share of its transcribed videos on the
+channel. When a channel has exactly one language, transcription create and create-batch use it
+instead of auto detection unless --language is given. Run it again as more videos are transcribed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		channelID := args[0]
+		minShare, _ := cmd.Flags().GetFloat64("min-share")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		// Create service with timeout context
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		profileService := langprofile.NewProfileService(
+			channel.NewRepository(dbPool),
+			video.NewRepository(dbPool),
+			transcription.NewRepository(dbPool),
+		)
+
+		return handler.InferChannelLanguages(ctx, cmd.OutOrStdout(), profileService, channelID, langprofile.InferOptions{MinShare: minShare, DryRun: dryRun})
+	},
+}
+
+func init() {
+	channelLanguagesCmd.Flags().Float64("min-share", langprofile.DefaultMinShare, "Share of transcribed videos a language needs to be stored (0-1)")
+	channelLanguagesCmd.Flags().Bool("dry-run", false, "Show the profile without storing it")
+	channelMergeCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+	channelSyncCmd.Flags().String("published-after", "", "Only save videos uploaded on or after this date (YYYY-MM-DD)")
+	channelSyncCmd.Flags().Bool("reconcile", false, "Fetch the full video list, save missing videos and mark removed ones unavailable")
+	channelScheduleCmd.Flags().Duration("every", 0, "Sync the channel at this interval (e.g. 12h)")
+	channelScheduleCmd.Flags().String("cron", "", "Sync the channel at the times of this cron expression (e.g. \"0 6 * * *\")")
+	channelScheduleCmd.Flags().Bool("off", false, "Remove the channel's schedule")
+
+	channelCmd.AddCommand(channelRefreshCmd)
+	channelCmd.AddCommand(channelMergeCmd)
+	channelCmd.AddCommand(channelSyncCmd)
+	channelCmd.AddCommand(channelScheduleCmd)
+	channelCmd.AddCommand(channelLanguagesCmd)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/i18n"
 )
 
 // configCmd represents the config command
@@ -54,7 +55,7 @@ var configShowCmd = &cobra.Command{
 			return err
 		}
 
-		fmt.Printf("Configuration file: %s\n\n", configPath)
+		fmt.Printf("%s\n\n", i18n.Tf("msg.config_file", "Configuration file: {{.Path}}", map[string]any{"Path": configPath}))
 
 		// Load and display current config
 		cfg, err := config.NewConfig()
@@ -63,6 +64,8 @@ var configShowCmd = &cobra.Command{
 		}
 
 		fmt.Printf("DATABASE_URL: %s\n", cfg.DatabaseURL)
+		fmt.Println(i18n.Tf("msg.config_workspace", "Workspace: {{.Workspace}}", map[string]any{"Workspace": cfg.ActiveWorkspace()}))
+		fmt.Println(i18n.Tf("msg.config_language", "Language: {{.Language}}", map[string]any{"Language": i18n.Language()}))
 
 		return nil
 	},
@@ -0,0 +1,89 @@
+//go:build dev
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/service/devseed"
+)
+
+// devCmd groups development helpers; it is only built with -tags dev
+var devCmd = &cobra.Command{
+	Use:   "dev",
+	Short: "Development helpers",
+	Long: `Helpers for developing yt-lang itself. They are only part of builds made with
+-tags dev, e.g. go run -tags dev . dev seed.`,
+}
+
+// devSeedCmd fills the active workspace with sample data
+var devSeedCmd = &cobra.Command{
+	Use:   "seed",
+	Short: "Populate the database with sample channels, videos, transcriptions and translations",
+	Long: `Write a generated sample library into the active workspace, so exports, search and
+other features can be developed without YouTube or whisper. Most videos get a completed
+transcription translated between Japanese and English, some partly approved; a few
+transcriptions failed and a few videos are marked unavailable.
+Seeded channels have IDs starting with ` + devseed.ChannelIDPrefix + ` and are replaced on every run;
+--clear only removes them. Use a separate workspace to keep them apart from real data.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clearOnly, _ := cmd.Flags().GetBool("clear")
+		opts := devseed.Options{}
+		opts.Channels, _ = cmd.Flags().GetInt("channels")
+		opts.VideosPerChannel, _ = cmd.Flags().GetInt("videos")
+		opts.SegmentsPerVideo, _ = cmd.Flags().GetInt("segments")
+		opts.Seed, _ = cmd.Flags().GetInt64("seed")
+
+		// Create service with timeout context
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		seeder := devseed.NewSeeder(dbPool)
+		if clearOnly {
+			removed, err := seeder.Clear(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to clear seed data: %w", err)
+			}
+			fmt.Printf("Removed %d seeded channel(s) from workspace %s\n", removed, cfg.ActiveWorkspace())
+			return nil
+		}
+
+		result, err := seeder.Seed(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to seed database: %w", err)
+		}
+		fmt.Printf("Seeded workspace %s: %d channels, %d videos, %d transcriptions, %d segments, %d translations\n",
+			cfg.ActiveWorkspace(), result.Channels, result.Videos, result.Transcriptions, result.Segments, result.Translations)
+		return nil
+	},
+}
+
+func init() {
+	devSeedCmd.Flags().Int("channels", devseed.DefaultChannels, "Number of channels")
+	devSeedCmd.Flags().Int("videos", devseed.DefaultVideosPerChannel, "Videos per channel")
+	devSeedCmd.Flags().Int("segments", devseed.DefaultSegmentsPerVideo, "Segments per transcription")
+	devSeedCmd.Flags().Int64("seed", 1, "Random seed; the same seed generates the same data")
+	devSeedCmd.Flags().Bool("clear", false, "Only remove seeded channels")
+
+	devCmd.AddCommand(devSeedCmd)
+	rootCmd.AddCommand(devCmd)
+}
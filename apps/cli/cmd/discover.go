@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/channel"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+	youtubeSvc "github.com/Taichi-iskw/yt-lang/internal/service/youtube"
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
+)
+
+// discoverCmd searches YouTube for videos and channels to add to the library
+var discoverCmd = &cobra.Command{
+	Use:   "discover [QUERY]",
+	Short: "Search YouTube for videos and channels to add to the library",
+	Long: `Search YouTube with yt-dlp (ytsearch) and list the matching videos with their duration,
+views and upload date, followed by the channels that uploaded them and whether they are saved.
+Nothing is saved unless --save is given: it then asks which results to save. Chosen videos
+are saved under their own channels, creating the channels that are not saved yet.
+yt-dlp pauses --sleep between its requests, and at most 100 results are fetched per search.
+
+Examples:
+  yt-lang discover "spanish cooking" --limit 20
+  yt-lang discover "japanese podcast" --save`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var opts handler.DiscoverOptions
+		opts.Search.Limit, _ = cmd.Flags().GetInt("limit")
+		opts.Search.Sleep, _ = cmd.Flags().GetDuration("sleep")
+		opts.Format, _ = cmd.Flags().GetString("format")
+		if opts.Format != "table" && opts.Format != "json" {
+			return fmt.Errorf("unsupported format: %s (supported: table, json)", opts.Format)
+		}
+		if save, _ := cmd.Flags().GetBool("save"); save {
+			opts.In = cmd.InOrStdin()
+		}
+
+		// Searches are paged, and the user takes a while to pick results
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		hooks, err := config.NewHookDispatcher(cfg)
+		if err != nil {
+			return err
+		}
+
+		youtubeService := youtubeSvc.NewHookedService(
+			youtubeSvc.NewYouTubeServiceWithDetector(
+				common.NewCmdRunner(),
+				channel.NewRepository(dbPool),
+				video.NewRepository(dbPool),
+				ytdlp.DefaultDetector(),
+			),
+			hooks,
+		)
+
+		return handler.Discover(ctx, cmd.OutOrStdout(), youtubeService, args[0], opts)
+	},
+}
+
+func init() {
+	discoverCmd.Flags().Int("limit", youtubeSvc.DefaultDiscoverLimit, fmt.Sprintf("Number of search results (at most %d)", youtubeSvc.MaxDiscoverLimit))
+	discoverCmd.Flags().Duration("sleep", youtubeSvc.DefaultDiscoverSleep, "Pause between yt-dlp requests (0 disables)")
+	discoverCmd.Flags().Bool("save", false, "Choose results to save after listing them")
+	discoverCmd.Flags().String("format", "table", "Output format: table, json")
+	rootCmd.AddCommand(discoverCmd)
+}
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
+)
+
+// doctorCmd inspects the external tools the pipeline depends on
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Inspect external tools and the features they support",
+	Long: `Inspect the external tools yt-lang runs and report their version, location and the
+optional features the installed version supports. Features missing from older versions
+are worked around automatically; versions below the supported minimum are reported as problems.
+
+Tools installed outside PATH are run from the paths set under tools in the config file.
+Exits with an error when yt-dlp is missing or outdated, when a configured path is not an
+executable, or when a tool selected with --tool is missing.
+
+Supported tools: ` + strings.Join(common.Tools, ", "),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tool, _ := cmd.Flags().GetString("tool")
+		format, _ := cmd.Flags().GetString("format")
+
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unsupported format: %s (supported: table, json)", format)
+		}
+		if tool != "" && !common.IsTool(tool) {
+			return fmt.Errorf("unsupported tool: %s (supported: %s)", tool, strings.Join(common.Tools, ", "))
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		var tools []string
+		for _, t := range common.Tools {
+			if t != ytdlp.Binary && (tool == "" || tool == t) {
+				tools = append(tools, t)
+			}
+		}
+		statuses := checkTools(tools)
+
+		var problems []string
+		for _, status := range statuses {
+			if status.Error != "" && (status.Configured != "" || tool != "") {
+				problems = append(problems, status.Tool)
+			}
+		}
+
+		report := map[string]any{}
+		if tool == "" || tool == ytdlp.Binary {
+			// The outdated warning is part of the report, not a separate message
+			detector := ytdlp.NewDetectorWithWarnings(common.NewCmdRunner(), io.Discard)
+			info, err := detector.Detect(ctx)
+			if err != nil || info.Outdated {
+				problems = append([]string{ytdlp.Binary}, problems...)
+			}
+
+			if format == "json" {
+				report = map[string]any{"tool": ytdlp.Binary, "info": info, "network": doctorNetworkArgs()}
+				if err != nil {
+					report["error"] = err.Error()
+				}
+			} else {
+				printYtDlpInfo(info, err)
+				if args := doctorNetworkArgs(); len(args) > 0 {
+					fmt.Printf("   network: %s\n", strings.Join(args, " "))
+				}
+			}
+		}
+
+		if format == "json" {
+			if len(statuses) > 0 {
+				report["tools"] = statuses
+			}
+			data, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format JSON: %w", err)
+			}
+			fmt.Println(string(data))
+		} else {
+			printToolStatuses(statuses)
+		}
+
+		if len(problems) > 0 {
+			// The report already explains the problem; usage would only bury it
+			cmd.SilenceUsage = true
+			return fmt.Errorf("doctor found problems with %s", strings.Join(problems, ", "))
+		}
+		return nil
+	},
+}
+
+// toolStatus is where an external tool was found, or why it was not
+type toolStatus struct {
+	Tool       string `json:"tool"`
+	Path       string `json:"path,omitempty"`       // Binary that is run
+	Configured string `json:"configured,omitempty"` // Path set under tools in the config file
+	Error      string `json:"error,omitempty"`
+}
+
+// checkTools looks up the binary of each tool at its configured path or in PATH
+func checkTools(tools []string) []toolStatus {
+	statuses := make([]toolStatus, 0, len(tools))
+	for _, tool := range tools {
+		status := toolStatus{Tool: tool}
+		if path := common.ToolPath(tool); path != tool {
+			status.Configured = path
+		}
+		path, err := common.LookTool(tool)
+		if err != nil {
+			status.Error = err.Error()
+		}
+		status.Path = path
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// printToolStatuses prints one line per tool: its path, or why it was not found. Tools
+// missing from PATH are only warned about, as each is needed by some commands only.
+func printToolStatuses(statuses []toolStatus) {
+	for _, status := range statuses {
+		switch {
+		case status.Error == "":
+			fmt.Printf("✅ %s %s\n", status.Tool, status.Path)
+		case status.Configured != "":
+			fmt.Printf("❌ %s: %s\n", status.Tool, status.Error)
+		default:
+			fmt.Printf("⚠️  %s: %s (set its path under tools in the config file if it is installed elsewhere)\n", status.Tool, status.Error)
+		}
+	}
+}
+
+// printYtDlpInfo prints the detected yt-dlp version and one line per optional feature
+func printYtDlpInfo(info *ytdlp.Info, err error) {
+	if err != nil {
+		fmt.Printf("❌ %s: %v\n", ytdlp.Binary, err)
+		return
+	}
+
+	icon := "✅"
+	if info.Outdated {
+		icon = "❌"
+	}
+	fmt.Printf("%s %s %s (minimum %s)\n", icon, ytdlp.Binary, info.Version, info.MinimumVersion)
+	if info.Path != "" {
+		fmt.Printf("   path: %s\n", info.Path)
+	}
+	if info.Outdated {
+		fmt.Println("   outdated: update with `yt-dlp -U` or your package manager")
+	}
+
+	fmt.Println("   features:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, capability := range ytdlp.Capabilities {
+		supported := "no, using fallback"
+		if info.Supports(capability) {
+			supported = "yes"
+		}
+		fmt.Fprintf(w, "     %s\t%s\tsince %s\n", capability, supported, ytdlp.CapabilitySince(capability))
+	}
+	w.Flush()
+}
+
+// doctorNetworkArgs returns the network arguments passed to yt-dlp, with the proxy password masked
+func doctorNetworkArgs() []string {
+	network := ytdlp.Network()
+	network.Proxy = network.RedactedProxy()
+	args := network.Args()
+	if args == nil {
+		args = []string{}
+	}
+	return args
+}
+
+func init() {
+	doctorCmd.Flags().String("tool", "", "Only inspect this tool ("+strings.Join(common.Tools, ", ")+")")
+	doctorCmd.Flags().String("format", "table", "Output format: table, json")
+
+	rootCmd.AddCommand(doctorCmd)
+}
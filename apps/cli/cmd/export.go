@@ -0,0 +1,457 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/internal/artifact"
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/channel"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/translation"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+	exportSvc "github.com/Taichi-iskw/yt-lang/internal/service/export"
+	translationSvc "github.com/Taichi-iskw/yt-lang/internal/service/translation"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export stored data to files",
+	Long:  `Export transcriptions and other stored data to files for archiving.`,
+}
+
+// exportTranscriptsCmd exports all transcriptions of a channel
+var exportTranscriptsCmd = &cobra.Command{
+	Use:   "transcripts",
+	Short: "Export all transcriptions of a channel to a directory",
+	Long: `Export every completed transcription of a channel's videos, one file per transcription,
+into DIR/CHANNEL_ID/. Files are named "<title> [<video id>].<lang>.<ext>".
+Subtitle formats (srt, vtt) follow the cue constraints of --style.
+The article format re-chunks segments into sentences and paragraphs for reading; --timestamps
+adds [MM:SS] marks at the start of each paragraph or sentence.
+A content hash manifest is kept in the output directory so unchanged files are skipped on re-export.
+With --to-storage the files go to the artifact storage configured in config.yaml (local or S3).`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		channelID, _ := cmd.Flags().GetString("channel")
+		format, _ := cmd.Flags().GetString("format")
+		dir, _ := cmd.Flags().GetString("dir")
+		language, _ := cmd.Flags().GetString("lang")
+		style, _ := cmd.Flags().GetString("style")
+		includeUnavailable, _ := cmd.Flags().GetBool("include-unavailable")
+		toStorage, _ := cmd.Flags().GetBool("to-storage")
+		timestamps, _ := cmd.Flags().GetString("timestamps")
+		if timestamps != exportSvc.TimestampsNone && format != exportSvc.FormatArticle {
+			return fmt.Errorf("--timestamps only applies to --format %s", exportSvc.FormatArticle)
+		}
+
+		rules, err := config.ResolveSubtitleRules(style)
+		if err != nil {
+			return err
+		}
+
+		// Create service with timeout context
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		// Write to the configured artifact storage instead of --dir when requested
+		var store artifact.Store
+		if toStorage {
+			if store, err = config.NewArtifactStore(cfg); err != nil {
+				return err
+			}
+		}
+
+		// Create export service with repositories
+		exportService := exportSvc.NewExportService(
+			video.NewRepository(dbPool),
+			transcription.NewRepository(dbPool),
+			transcription.NewSegmentRepository(dbPool),
+		)
+
+		result, err := exportService.ExportChannelTranscripts(ctx, exportSvc.TranscriptExportOptions{
+			ChannelID: channelID,
+			Format:    format,
+			Dir:       dir,
+			Store:     store,
+			Language:  language,
+			Subtitles: rules,
+			VTT:       cfg.Subtitles.VTT,
+
+			Timestamps:         timestamps,
+			IncludeUnavailable: includeUnavailable,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to export transcripts: %w", err)
+		}
+
+		for _, name := range result.Written {
+			fmt.Printf("wrote   %s\n", name)
+		}
+		for _, name := range result.Skipped {
+			fmt.Printf("skipped %s (unchanged)\n", name)
+		}
+		fmt.Printf("Exported to %s: %d written, %d unchanged\n", result.Dir, len(result.Written), len(result.Skipped))
+		return nil
+	},
+}
+
+// exportDatasetCmd exports aligned transcription/translation pairs for MT training
+var exportDatasetCmd = &cobra.Command{
+	Use:   "dataset",
+	Short: "Export aligned source/target segment pairs as JSONL",
+	Long: `Export the segments of a channel's completed transcriptions paired with their
+translations, one JSON object per line, for machine translation fine-tuning or evaluation.
+--langs SRC:TGT selects transcriptions spoken in SRC and translations into TGT.
+When several translations exist for a segment, an approved one (accepted or edited in
+translation interactive) is preferred, otherwise the newest is used. Plain translations are
+paired unless --style selects a variant made with translation create --style.
+
+Formats:
+  jsonl   {"source", "target", "source_lang", "target_lang", "video_id", "segment_index"}
+  openai  {"messages": [system, user, assistant]} chat fine-tuning examples
+
+--min-confidence compares against the whisper avg_logprob of each segment (e.g. -0.5).
+--min-ratio/--max-ratio bound the target/source length in characters.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		channelID, _ := cmd.Flags().GetString("channel")
+		format, _ := cmd.Flags().GetString("format")
+		pair, _ := cmd.Flags().GetString("pair")
+		langs, _ := cmd.Flags().GetString("langs")
+		minRatio, _ := cmd.Flags().GetFloat64("min-ratio")
+		maxRatio, _ := cmd.Flags().GetFloat64("max-ratio")
+		approvedOnly, _ := cmd.Flags().GetBool("approved-only")
+		style, _ := cmd.Flags().GetString("style")
+		includeUnavailable, _ := cmd.Flags().GetBool("include-unavailable")
+		output, _ := cmd.Flags().GetString("output")
+
+		if err := translationSvc.ValidateStyle(style); err != nil {
+			return err
+		}
+		if pair != "transcription:translation" {
+			return fmt.Errorf("unsupported --pair %q (only transcription:translation is supported)", pair)
+		}
+		sourceLang, targetLang, ok := strings.Cut(langs, ":")
+		if !ok || sourceLang == "" || targetLang == "" {
+			return fmt.Errorf("invalid --langs %q (expected SRC:TGT, e.g. en:ja)", langs)
+		}
+
+		opts := exportSvc.DatasetExportOptions{
+			ChannelID:      channelID,
+			Format:         format,
+			SourceLanguage: sourceLang,
+			TargetLanguage: targetLang,
+			MinLengthRatio: minRatio,
+			MaxLengthRatio: maxRatio,
+			ApprovedOnly:   approvedOnly,
+			Style:          style,
+
+			IncludeUnavailable: includeUnavailable,
+		}
+		if cmd.Flags().Changed("min-confidence") {
+			minConfidence, _ := cmd.Flags().GetFloat64("min-confidence")
+			opts.MinConfidence = &minConfidence
+		}
+
+		// Create service with timeout context
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		// Write to stdout unless an output file is given
+		var w io.Writer = os.Stdout
+		if output != "" {
+			file, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer file.Close()
+			w = file
+		}
+
+		exportService := exportSvc.NewExportServiceWithTranslations(
+			video.NewRepository(dbPool),
+			transcription.NewRepository(dbPool),
+			transcription.NewSegmentRepository(dbPool),
+			translation.NewRepository(dbPool),
+		)
+
+		result, err := exportService.ExportDataset(ctx, opts, w)
+		if err != nil {
+			return fmt.Errorf("failed to export dataset: %w", err)
+		}
+
+		// Report on stderr so stdout stays valid JSONL
+		fmt.Fprintf(os.Stderr, "Exported %d pairs (%d segments untranslated, %d below confidence, %d outside length ratio)\n",
+			result.Pairs, result.Untranslated, result.LowConfidence, result.RatioOutOfRange)
+		return nil
+	},
+}
+
+// exportSubtitlesCmd exports a video's transcription and translations as side-car subtitle files
+var exportSubtitlesCmd = &cobra.Command{
+	Use:   "subtitles [VIDEO_ID]",
+	Short: "Export a video's subtitles in several languages",
+	Long: `Write one subtitle file per language of a video into --dir, named "<video id>.<lang>.<ext>"
+so players pick them up next to the media file. "original" in --langs is the transcription
+(named after its spoken language); every other entry is a stored translation, whose cues keep
+the timing of the original segments. Nothing is written if any language is missing.
+Use --source-lang when the video has transcriptions in several languages.
+
+WebVTT files start with a NOTE naming the video and transcription they were made from, carry
+the cue settings and STYLE block of subtitles.vtt in the config file, and mark the speaker of
+each cue with a voice tag (<v SPEAKER_00>) when the transcription was diarized.
+
+--format json-timed writes a single "<video id>.timed.json" for web players instead: every
+cue carries its start and end in seconds, the original text and the translation of at most
+one translation language in --langs. Add --words for estimated per-word timing.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		videoID := args[0]
+		langs, _ := cmd.Flags().GetString("langs")
+		sourceLang, _ := cmd.Flags().GetString("source-lang")
+		format, _ := cmd.Flags().GetString("format")
+		dir, _ := cmd.Flags().GetString("dir")
+		style, _ := cmd.Flags().GetString("style")
+		approvedOnly, _ := cmd.Flags().GetBool("approved-only")
+		words, _ := cmd.Flags().GetBool("words")
+
+		var languages []string
+		for _, language := range strings.Split(langs, ",") {
+			if language = strings.TrimSpace(language); language != "" {
+				languages = append(languages, language)
+			}
+		}
+
+		rules, err := config.ResolveSubtitleRules(style)
+		if err != nil {
+			return err
+		}
+
+		// Create service with timeout context
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		exportService := exportSvc.NewExportServiceWithTranslations(
+			video.NewRepository(dbPool),
+			transcription.NewRepository(dbPool),
+			transcription.NewSegmentRepository(dbPool),
+			translation.NewRepository(dbPool),
+		)
+
+		result, err := exportService.ExportVideoSubtitles(ctx, exportSvc.SubtitleExportOptions{
+			VideoID:        videoID,
+			Languages:      languages,
+			SourceLanguage: sourceLang,
+			Format:         format,
+			Dir:            dir,
+			Subtitles:      rules,
+			VTT:            cfg.Subtitles.VTT,
+			ApprovedOnly:   approvedOnly,
+			Words:          words,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to export subtitles: %w", err)
+		}
+
+		for _, name := range result.Written {
+			fmt.Printf("wrote   %s\n", name)
+		}
+		fmt.Printf("Exported %d subtitle file(s) to %s\n", len(result.Written), result.Dir)
+		return nil
+	},
+}
+
+// exportEPUBCmd exports a channel's transcriptions and translations as a bilingual e-book
+var exportEPUBCmd = &cobra.Command{
+	Use:   "epub",
+	Short: "Export a channel's transcriptions with translations as an EPUB e-book",
+	Long: `Write an EPUB e-book with one chapter per transcribed video of a channel, in upload order,
+to study the content offline on an e-reader. Each segment becomes a paragraph in the spoken
+language followed by its translations.
+
+--langs lists the spoken language of the transcriptions first, then the translation languages.
+When several translations exist for a segment, an approved one (accepted or edited in
+translation interactive) is preferred, otherwise the newest is used. Videos without a
+completed transcription in the spoken language are skipped. The book is titled after the
+channel unless --title is given.
+
+Examples:
+  yt-lang export epub --channel UCxxx --langs en,ja --output channel.epub
+  yt-lang export epub --channel UCxxx --langs es,ja,en --title "Cocina" --approved-only`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		channelID, _ := cmd.Flags().GetString("channel")
+		langs, _ := cmd.Flags().GetString("langs")
+		title, _ := cmd.Flags().GetString("title")
+		output, _ := cmd.Flags().GetString("output")
+		approvedOnly, _ := cmd.Flags().GetBool("approved-only")
+		includeUnavailable, _ := cmd.Flags().GetBool("include-unavailable")
+
+		var languages []string
+		for _, language := range strings.Split(langs, ",") {
+			if language = strings.TrimSpace(language); language != "" {
+				languages = append(languages, language)
+			}
+		}
+		if len(languages) < 2 {
+			return fmt.Errorf("invalid --langs %q (expected the spoken language and at least one translation language, e.g. en,ja)", langs)
+		}
+		if output == "" {
+			output = channelID + ".epub"
+		}
+
+		// Create service with timeout context
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		if title == "" {
+			ch, err := channel.NewRepository(dbPool).GetByID(ctx, channelID)
+			if err != nil {
+				return fmt.Errorf("failed to get channel: %w", err)
+			}
+			title = ch.Name
+		}
+
+		exportService := exportSvc.NewExportServiceWithTranslations(
+			video.NewRepository(dbPool),
+			transcription.NewRepository(dbPool),
+			transcription.NewSegmentRepository(dbPool),
+			translation.NewRepository(dbPool),
+		)
+
+		file, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		result, err := exportService.ExportEPUB(ctx, exportSvc.EPUBExportOptions{
+			ChannelID:    channelID,
+			Title:        title,
+			Languages:    languages,
+			ApprovedOnly: approvedOnly,
+
+			IncludeUnavailable: includeUnavailable,
+		}, file)
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = closeErr
+		}
+		if err != nil {
+			// Don't leave a truncated book behind
+			os.Remove(output)
+			return fmt.Errorf("failed to export e-book: %w", err)
+		}
+
+		fmt.Printf("Exported %d chapter(s) with %d paragraphs to %s", result.Chapters, result.Segments, output)
+		if result.Untranslated > 0 || result.Skipped > 0 {
+			fmt.Printf(" (%d translations missing, %d videos without a %s transcription)", result.Untranslated, result.Skipped, languages[0])
+		}
+		fmt.Println()
+		return nil
+	},
+}
+
+func init() {
+	exportTranscriptsCmd.Flags().String("channel", "", "Channel ID whose transcriptions are exported (required)")
+	exportTranscriptsCmd.Flags().String("format", "srt", "Output format: srt, vtt, text, json, article")
+	exportTranscriptsCmd.Flags().String("timestamps", exportSvc.TimestampsNone, "Inline timestamps in article output: none, paragraph, sentence")
+	exportTranscriptsCmd.Flags().String("dir", ".", "Output directory")
+	exportTranscriptsCmd.Flags().String("lang", "", "Only export transcriptions in this language")
+	exportTranscriptsCmd.Flags().String("style", "", "Subtitle style for srt/vtt (default, netflix, or a style from the config file)")
+	exportTranscriptsCmd.Flags().Bool("include-unavailable", false, "Also export videos marked unavailable by video verify")
+	exportTranscriptsCmd.Flags().Bool("to-storage", false, "Write to the configured artifact storage (exports/CHANNEL_ID/) instead of --dir")
+	exportTranscriptsCmd.MarkFlagRequired("channel")
+
+	exportDatasetCmd.Flags().String("channel", "", "Channel ID whose videos are exported (required)")
+	exportDatasetCmd.Flags().String("format", "jsonl", "Output format: jsonl, openai")
+	exportDatasetCmd.Flags().String("pair", "transcription:translation", "Kinds of text paired as source:target")
+	exportDatasetCmd.Flags().String("langs", "en:ja", "Source and target languages as SRC:TGT")
+	exportDatasetCmd.Flags().Float64("min-confidence", 0, "Drop segments whose confidence (avg_logprob) is below this value")
+	exportDatasetCmd.Flags().Float64("min-ratio", 0, "Drop pairs whose target/source length ratio is below this value (0 disables)")
+	exportDatasetCmd.Flags().Float64("max-ratio", 0, "Drop pairs whose target/source length ratio is above this value (0 disables)")
+	exportDatasetCmd.Flags().Bool("approved-only", false, "Only use translations approved in translation interactive")
+	exportDatasetCmd.Flags().String("style", "", "Translation style variant to pair (simple, formal, casual; default: plain translations)")
+	exportDatasetCmd.Flags().Bool("include-unavailable", false, "Also export videos marked unavailable by video verify")
+	exportDatasetCmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
+	exportDatasetCmd.MarkFlagRequired("channel")
+
+	exportSubtitlesCmd.Flags().String("langs", exportSvc.OriginalLanguage, "Comma-separated languages: original for the transcription, others for translations (e.g. original,ja,ko)")
+	exportSubtitlesCmd.Flags().String("source-lang", "", "Language of the transcription to use when the video has several")
+	exportSubtitlesCmd.Flags().String("format", "srt", "Output format: srt, vtt, json-timed")
+	exportSubtitlesCmd.Flags().String("dir", ".", "Output directory")
+	exportSubtitlesCmd.Flags().String("style", "", "Subtitle style (default, netflix, or a style from the config file)")
+	exportSubtitlesCmd.Flags().Bool("approved-only", false, "Only use translations approved in translation interactive")
+	exportSubtitlesCmd.Flags().Bool("words", false, "json-timed: add estimated per-word timing to each cue")
+
+	exportEPUBCmd.Flags().String("channel", "", "Channel ID whose videos are exported (required)")
+	exportEPUBCmd.Flags().String("langs", "en,ja", "Spoken language followed by translation languages, comma-separated")
+	exportEPUBCmd.Flags().String("title", "", "Book title (default: the channel name)")
+	exportEPUBCmd.Flags().StringP("output", "o", "", "Output file (default: CHANNEL_ID.epub)")
+	exportEPUBCmd.Flags().Bool("approved-only", false, "Only use translations approved in translation interactive")
+	exportEPUBCmd.Flags().Bool("include-unavailable", false, "Also export videos marked unavailable by video verify")
+	exportEPUBCmd.MarkFlagRequired("channel")
+
+	exportCmd.AddCommand(exportTranscriptsCmd)
+	exportCmd.AddCommand(exportDatasetCmd)
+	exportCmd.AddCommand(exportSubtitlesCmd)
+	exportCmd.AddCommand(exportEPUBCmd)
+	rootCmd.AddCommand(exportCmd)
+}
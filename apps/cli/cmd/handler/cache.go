@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Taichi-iskw/yt-lang/internal/janitor"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	transcriptionSvc "github.com/Taichi-iskw/yt-lang/internal/service/transcription"
+)
+
+// transcodePageSize is the number of videos listed at a time when every video is checked
+const transcodePageSize = 500
+
+// AllVideoLister lists every saved video of the workspace
+type AllVideoLister interface {
+	List(ctx context.Context, limit, offset int) ([]*model.Video, error)
+}
+
+// TranscodeCachedAudio re-encodes the cached audio of videoIDs, or of every saved video when
+// none are given, and reports the disk space reclaimed. A video that fails is reported and the
+// others are still re-encoded; the error counts the failures.
+func TranscodeCachedAudio(ctx context.Context, out io.Writer, videos AllVideoLister, transcoder transcriptionSvc.AudioTranscoder, videoIDs []string, opts transcriptionSvc.TranscodeOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	if len(videoIDs) == 0 {
+		for offset := 0; ; offset += transcodePageSize {
+			page, err := videos.List(ctx, transcodePageSize, offset)
+			if err != nil {
+				return fmt.Errorf("failed to list videos: %w", err)
+			}
+			for _, v := range page {
+				videoIDs = append(videoIDs, v.ID)
+			}
+			if len(page) < transcodePageSize {
+				break
+			}
+		}
+	}
+
+	var transcoded, failed int
+	var before, after int64
+	for _, videoID := range videoIDs {
+		result, err := transcoder.Transcode(ctx, videoID, opts)
+		if err != nil {
+			failed++
+			fmt.Fprintf(out, "❌ %s: %v\n", videoID, err)
+			continue
+		}
+		switch {
+		case result.FromKey == "":
+			// Most videos have no cached audio; listing them would bury the rest
+		case result.Skipped != "":
+			fmt.Fprintf(out, "⏭️  %s: skipped, %s\n", videoID, result.Skipped)
+		case opts.DryRun:
+			transcoded++
+			before += result.Before
+			fmt.Fprintf(out, "%s: %s (%s) would be re-encoded as %s\n", videoID, result.FromKey, janitor.FormatSize(result.Before), result.ToKey)
+		default:
+			transcoded++
+			before += result.Before
+			after += result.After
+			fmt.Fprintf(out, "✅ %s: %s (%s) -> %s (%s)\n", videoID, result.FromKey, janitor.FormatSize(result.Before), result.ToKey, janitor.FormatSize(result.After))
+		}
+	}
+
+	if opts.DryRun {
+		fmt.Fprintf(out, "%d cached audio file(s) would be re-encoded as %s at %s (%s now)\n", transcoded, opts.Format, opts.Bitrate, janitor.FormatSize(before))
+	} else {
+		fmt.Fprintf(out, "Re-encoded %d cached audio file(s) as %s at %s, freed %s\n", transcoded, opts.Format, opts.Bitrate, janitor.FormatSize(before-after))
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to re-encode the audio of %d video(s)", failed)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	transcriptionSvc "github.com/Taichi-iskw/yt-lang/internal/service/transcription"
+)
+
+// fakeAllVideos implements AllVideoLister
+type fakeAllVideos struct {
+	ids []string
+}
+
+func (f *fakeAllVideos) List(ctx context.Context, limit, offset int) ([]*model.Video, error) {
+	var videos []*model.Video
+	for i := offset; i < len(f.ids) && i < offset+limit; i++ {
+		videos = append(videos, &model.Video{ID: f.ids[i]})
+	}
+	return videos, nil
+}
+
+// fakeTranscoder returns fixed results by video ID
+type fakeTranscoder struct {
+	results map[string]*transcriptionSvc.TranscodeResult
+}
+
+func (f *fakeTranscoder) Transcode(ctx context.Context, videoID string, opts transcriptionSvc.TranscodeOptions) (*transcriptionSvc.TranscodeResult, error) {
+	if result, ok := f.results[videoID]; ok {
+		return result, nil
+	}
+	return nil, errors.New("ffmpeg failed")
+}
+
+func TestTranscodeCachedAudio(t *testing.T) {
+	transcoder := &fakeTranscoder{results: map[string]*transcriptionSvc.TranscodeResult{
+		"done":     {VideoID: "done", FromKey: "audio/done.m4a", ToKey: "audio/done.opus", Before: 4 << 20, After: 1 << 20},
+		"opus":     {VideoID: "opus", FromKey: "audio/opus.opus", ToKey: "audio/opus.opus", Skipped: "already opus"},
+		"uncached": {VideoID: "uncached", ToKey: "audio/uncached.opus", Skipped: "no cached audio"},
+	}}
+	opts := transcriptionSvc.TranscodeOptions{Format: "opus", Bitrate: "32k"}
+
+	var out bytes.Buffer
+	require.NoError(t, TranscodeCachedAudio(context.Background(), &out, &fakeAllVideos{ids: []string{"done", "opus", "uncached"}}, transcoder, nil, opts))
+	assert.Contains(t, out.String(), "✅ done: audio/done.m4a (4.0 MB) -> audio/done.opus (1.0 MB)")
+	assert.Contains(t, out.String(), "opus: skipped, already opus")
+	assert.NotContains(t, out.String(), "uncached")
+	assert.Contains(t, out.String(), "Re-encoded 1 cached audio file(s) as opus at 32k, freed 3.0 MB")
+
+	out.Reset()
+	err := TranscodeCachedAudio(context.Background(), &out, &fakeAllVideos{}, transcoder, []string{"done", "broken"}, opts)
+	assert.EqualError(t, err, "failed to re-encode the audio of 1 video(s)")
+	assert.Contains(t, out.String(), "❌ broken: ffmpeg failed")
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/i18n"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/service/langprofile"
+	youtubeSvc "github.com/Taichi-iskw/yt-lang/internal/service/youtube"
+	"github.com/Taichi-iskw/yt-lang/internal/tmpl"
+)
+
+// ChannelSaver saves channels fetched from YouTube
+type ChannelSaver interface {
+	SaveChannelInfo(ctx context.Context, channelURL string) (*model.Channel, error)
+}
+
+// ChannelLister lists saved channels
+type ChannelLister interface {
+	ListChannels(ctx context.Context, limit, offset int) ([]*model.Channel, error)
+	CountChannels(ctx context.Context) (int, error)
+}
+
+// ChannelRefresher updates saved channels from YouTube
+type ChannelRefresher interface {
+	RefreshChannel(ctx context.Context, channelID string) (*youtubeSvc.ChannelRefresh, error)
+}
+
+// ChannelMerger merges duplicate channels
+type ChannelMerger interface {
+	MergeChannels(ctx context.Context, fromID, intoID string) (*youtubeSvc.ChannelMerge, error)
+}
+
+// ChannelProfiler infers the languages of channels
+type ChannelProfiler interface {
+	InferChannel(ctx context.Context, channelID string, opts langprofile.InferOptions) (*langprofile.Profile, error)
+}
+
+// SaveChannel saves the channel at channelURL and prints it as JSON
+func SaveChannel(ctx context.Context, out io.Writer, service ChannelSaver, channelURL string) error {
+	channel, err := service.SaveChannelInfo(ctx, channelURL)
+	if err != nil {
+		return fmt.Errorf("failed to save channel info: %w", err)
+	}
+
+	result, err := json.MarshalIndent(channel, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format result: %w", err)
+	}
+
+	fmt.Fprintf(out, "%s\n%s\n", i18n.T("msg.channel_saved", "Channel saved successfully:"), string(result))
+	return nil
+}
+
+// ListChannelsOptions configures ListChannels
+type ListChannelsOptions struct {
+	Limit    int
+	Offset   int
+	Template *tmpl.Template // Renders each channel instead of the JSON output
+}
+
+// channelListResult is the JSON output of channel list
+type channelListResult struct {
+	model.Page
+	Channels []*model.Channel `json:"channels"`
+}
+
+// ListChannels prints a page of saved channels as JSON with pagination metadata
+func ListChannels(ctx context.Context, out io.Writer, service ChannelLister, opts ListChannelsOptions) error {
+	channels, err := service.ListChannels(ctx, opts.Limit, opts.Offset)
+	if err != nil {
+		return fmt.Errorf("failed to list channels: %w", err)
+	}
+
+	if opts.Template != nil {
+		for _, c := range channels {
+			if err := opts.Template.Execute(out, c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(channels) == 0 {
+		fmt.Fprintln(out, i18n.T("msg.no_channels", "No channels found in the database."))
+		return nil
+	}
+
+	total, err := service.CountChannels(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to count channels: %w", err)
+	}
+
+	result, err := json.MarshalIndent(channelListResult{
+		Page:     model.NewPage(total, opts.Limit, opts.Offset),
+		Channels: channels,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format result: %w", err)
+	}
+
+	fmt.Fprintf(out, "%s\n%s\n", i18n.Tf("msg.channels_found", "Found {{.Count}} channel(s):", map[string]any{"Count": len(channels)}), string(result))
+	return nil
+}
+
+// RefreshChannel updates a saved channel from YouTube and prints what changed
+func RefreshChannel(ctx context.Context, out io.Writer, service ChannelRefresher, channelID string) error {
+	result, err := service.RefreshChannel(ctx, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to refresh channel: %w", err)
+	}
+
+	if !result.Changed {
+		fmt.Fprintf(out, "Channel %s is up to date (%s, %s)\n", channelID, result.Current.Name, result.Current.URL)
+		return nil
+	}
+
+	fmt.Fprintf(out, "Channel %s refreshed:\n", channelID)
+	if result.Previous.Name != result.Current.Name {
+		fmt.Fprintf(out, "  name: %s -> %s\n", result.Previous.Name, result.Current.Name)
+	}
+	if result.Previous.URL != result.Current.URL {
+		fmt.Fprintf(out, "  url:  %s -> %s\n", result.Previous.URL, result.Current.URL)
+	}
+	return nil
+}
+
+// MergeChannels moves the videos of channel fromID into intoID and deletes fromID
+func MergeChannels(ctx context.Context, out io.Writer, service ChannelMerger, fromID, intoID string) error {
+	result, err := service.MergeChannels(ctx, fromID, intoID)
+	if err != nil {
+		return fmt.Errorf("failed to merge channels: %w", err)
+	}
+
+	fmt.Fprintf(out, "Merged channel %s into %s: %d video(s) moved, %s deleted\n", result.From, result.Into, result.MovedVideos, result.From)
+	return nil
+}
+
+// InferChannelLanguages infers and stores the languages of a channel, printing its profile
+func InferChannelLanguages(ctx context.Context, out io.Writer, service ChannelProfiler, channelID string, opts langprofile.InferOptions) error {
+	profile, err := service.InferChannel(ctx, channelID, opts)
+	if err != nil {
+		return fmt.Errorf("failed to infer channel languages: %w", err)
+	}
+
+	fmt.Fprintf(out, "Channel %s: %d transcribed video(s)\n", channelID, profile.Videos)
+	for _, count := range profile.Counts {
+		fmt.Fprintf(out, "  %-6s %5d  %5.1f%%\n", count.Language, count.Videos, count.Share*100)
+	}
+	languages := strings.Join(profile.Languages, ", ")
+	if languages == "" {
+		languages = "none"
+	}
+	if profile.Stored {
+		fmt.Fprintf(out, "Stored languages: %s\n", languages)
+	} else {
+		fmt.Fprintf(out, "DRY RUN: Would store languages: %s\n", languages)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/service/langprofile"
+	youtubeSvc "github.com/Taichi-iskw/yt-lang/internal/service/youtube"
+	"github.com/Taichi-iskw/yt-lang/internal/tmpl"
+)
+
+// fakeChannels implements the channel interfaces over a fixed list
+type fakeChannels struct {
+	channels []*model.Channel
+	refresh  *youtubeSvc.ChannelRefresh
+}
+
+func (f *fakeChannels) ListChannels(ctx context.Context, limit, offset int) ([]*model.Channel, error) {
+	return f.channels[min(offset, len(f.channels)):min(offset+limit, len(f.channels))], nil
+}
+
+func (f *fakeChannels) CountChannels(ctx context.Context) (int, error) {
+	return len(f.channels), nil
+}
+
+func (f *fakeChannels) RefreshChannel(ctx context.Context, channelID string) (*youtubeSvc.ChannelRefresh, error) {
+	return f.refresh, nil
+}
+
+func (f *fakeChannels) InferChannel(ctx context.Context, channelID string, opts langprofile.InferOptions) (*langprofile.Profile, error) {
+	return &langprofile.Profile{
+		ChannelID: channelID,
+		Videos:    4,
+		Counts:    []langprofile.LanguageCount{{Language: "ja", Videos: 3, Share: 0.75}, {Language: "en", Videos: 1, Share: 0.25}},
+		Languages: []string{"ja"},
+		Stored:    !opts.DryRun,
+	}, nil
+}
+
+func TestListChannels(t *testing.T) {
+	service := &fakeChannels{channels: []*model.Channel{
+		{ID: "UC1", Name: "First", URL: "https://www.youtube.com/@first"},
+		{ID: "UC2", Name: "Second", URL: "https://www.youtube.com/@second"},
+	}}
+
+	var out bytes.Buffer
+	require.NoError(t, ListChannels(context.Background(), &out, service, ListChannelsOptions{Limit: 1}))
+	assert.Contains(t, out.String(), "Found 1 channel(s):")
+	assert.Contains(t, out.String(), `"total": 2`)
+	assert.Contains(t, out.String(), `"id": "UC1"`)
+	assert.NotContains(t, out.String(), "UC2")
+
+	template, err := tmpl.Parse("{{.ID}} {{.Name}}")
+	require.NoError(t, err)
+	out.Reset()
+	require.NoError(t, ListChannels(context.Background(), &out, service, ListChannelsOptions{Limit: 10, Template: template}))
+	assert.Equal(t, "UC1 First\nUC2 Second\n", out.String())
+
+	out.Reset()
+	require.NoError(t, ListChannels(context.Background(), &out, &fakeChannels{}, ListChannelsOptions{Limit: 10}))
+	assert.Equal(t, "No channels found in the database.\n", out.String())
+}
+
+func TestRefreshChannel(t *testing.T) {
+	service := &fakeChannels{refresh: &youtubeSvc.ChannelRefresh{
+		Previous: &model.Channel{ID: "UC1", Name: "Old", URL: "https://www.youtube.com/@first"},
+		Current:  &model.Channel{ID: "UC1", Name: "New", URL: "https://www.youtube.com/@first"},
+		Changed:  true,
+	}}
+
+	var out bytes.Buffer
+	require.NoError(t, RefreshChannel(context.Background(), &out, service, "UC1"))
+	assert.Equal(t, "Channel UC1 refreshed:\n  name: Old -> New\n", out.String())
+}
+
+func TestInferChannelLanguages(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, InferChannelLanguages(context.Background(), &out, &fakeChannels{}, "UC1", langprofile.InferOptions{DryRun: true}))
+	assert.Equal(t, "Channel UC1: 4 transcribed video(s)\n  ja         3   75.0%\n  en         1   25.0%\nDRY RUN: Would store languages: ja\n", out.String())
+}
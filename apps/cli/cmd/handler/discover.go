@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	youtubeSvc "github.com/Taichi-iskw/yt-lang/internal/service/youtube"
+)
+
+// Discoverer searches YouTube and saves chosen search results
+type Discoverer interface {
+	Discover(ctx context.Context, query string, opts youtubeSvc.DiscoverOptions) (*youtubeSvc.Discovery, error)
+	SaveDiscovered(ctx context.Context, videos []*youtubeSvc.DiscoveredVideo) (*youtubeSvc.ListImport, error)
+}
+
+// DiscoverOptions configures Discover
+type DiscoverOptions struct {
+	Search youtubeSvc.DiscoverOptions
+	Format string    // table (default) or json
+	In     io.Reader // When set, the user picks results to save from this input
+}
+
+// Discover searches YouTube, prints the results and, with an input, saves the results the user picks
+func Discover(ctx context.Context, out io.Writer, service Discoverer, query string, opts DiscoverOptions) error {
+	discovery, err := service.Discover(ctx, query, opts.Search)
+	if err != nil {
+		return fmt.Errorf("failed to search YouTube: %w", err)
+	}
+
+	if opts.Format == "json" {
+		data, err := json.MarshalIndent(discovery, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format result: %w", err)
+		}
+		fmt.Fprintln(out, string(data))
+	} else {
+		printDiscovery(out, discovery)
+	}
+
+	if opts.In == nil || len(discovery.Videos) == 0 {
+		return nil
+	}
+
+	fmt.Fprint(out, "\nSave which videos? (e.g. 1,3-5 or all; empty saves nothing): ")
+	var answer string
+	if scanner := bufio.NewScanner(opts.In); scanner.Scan() {
+		answer = scanner.Text()
+	}
+	picked, err := ParseSelection(answer, len(discovery.Videos))
+	if err != nil {
+		return err
+	}
+	if len(picked) == 0 {
+		fmt.Fprintln(out, "Nothing saved.")
+		return nil
+	}
+
+	selected := make([]*youtubeSvc.DiscoveredVideo, 0, len(picked))
+	for _, i := range picked {
+		selected = append(selected, discovery.Videos[i-1])
+	}
+	result, err := service.SaveDiscovered(ctx, selected)
+	if err != nil {
+		return fmt.Errorf("failed to save videos: %w", err)
+	}
+	fmt.Fprintf(out, "Saved %d video(s) (%d new channel(s))\n", len(result.Videos), len(result.CreatedChannels))
+	return nil
+}
+
+// printDiscovery prints the numbered search results and the channels that uploaded them
+func printDiscovery(out io.Writer, discovery *youtubeSvc.Discovery) {
+	if len(discovery.Videos) == 0 {
+		fmt.Fprintf(out, "No videos found for %q\n", discovery.Query)
+		return
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tVIDEO ID\tDURATION\tVIEWS\tUPLOADED\tCHANNEL\tTITLE")
+	for i, result := range discovery.Videos {
+		v := result.Video
+		uploaded, views := "-", "-"
+		if v.UploadDate != nil {
+			uploaded = v.UploadDate.Format("2006-01-02")
+		}
+		if result.ViewCount > 0 {
+			views = strconv.FormatInt(result.ViewCount, 10)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, v.ID, FormatDuration(v.Duration), views, uploaded, Truncate(result.Channel.Name, 24), v.Title)
+	}
+	w.Flush()
+
+	fmt.Fprintln(out)
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHANNEL ID\tRESULTS\tSAVED\tNAME")
+	for _, found := range discovery.Channels {
+		saved := "no"
+		if found.Saved {
+			saved = "yes"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", found.Channel.ID, found.Results, saved, found.Channel.Name)
+	}
+	w.Flush()
+}
+
+// ParseSelection parses a list of 1-based item numbers and ranges such as "1,3-5" (or "all") for a
+// list of n items, returning the numbers in the order given without repeats. Empty input selects nothing.
+func ParseSelection(input string, n int) ([]int, error) {
+	input = strings.TrimSpace(input)
+	if strings.EqualFold(input, "all") {
+		all := make([]int, n)
+		for i := range all {
+			all[i] = i + 1
+		}
+		return all, nil
+	}
+
+	var picked []int
+	seen := make(map[int]bool)
+	for _, part := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' }) {
+		from, to, isRange := strings.Cut(part, "-")
+		first, err := strconv.Atoi(from)
+		last := first
+		if err == nil && isRange {
+			last, err = strconv.Atoi(to)
+		}
+		if err != nil || first < 1 || last < first || last > n {
+			return nil, fmt.Errorf("invalid selection %q (use numbers from 1 to %d, e.g. 1,3-5)", part, n)
+		}
+		for i := first; i <= last; i++ {
+			if !seen[i] {
+				seen[i] = true
+				picked = append(picked, i)
+			}
+		}
+	}
+	return picked, nil
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	youtubeSvc "github.com/Taichi-iskw/yt-lang/internal/service/youtube"
+)
+
+// fakeDiscoverer returns a fixed discovery and records saved results
+type fakeDiscoverer struct {
+	discovery *youtubeSvc.Discovery
+	saved     []*youtubeSvc.DiscoveredVideo
+}
+
+func (f *fakeDiscoverer) Discover(ctx context.Context, query string, opts youtubeSvc.DiscoverOptions) (*youtubeSvc.Discovery, error) {
+	return f.discovery, nil
+}
+
+func (f *fakeDiscoverer) SaveDiscovered(ctx context.Context, videos []*youtubeSvc.DiscoveredVideo) (*youtubeSvc.ListImport, error) {
+	f.saved = videos
+	result := &youtubeSvc.ListImport{}
+	for _, v := range videos {
+		result.Videos = append(result.Videos, v.Video)
+	}
+	return result, nil
+}
+
+func newFakeDiscoverer() *fakeDiscoverer {
+	cook := &model.Channel{ID: "UCcook", Name: "Cocina"}
+	return &fakeDiscoverer{discovery: &youtubeSvc.Discovery{
+		Query: "spanish cooking",
+		Videos: []*youtubeSvc.DiscoveredVideo{
+			{Video: &model.Video{ID: "video1", Title: "Tortilla", Duration: 600}, Channel: cook, ViewCount: 12000},
+			{Video: &model.Video{ID: "video2", Title: "Paella"}, Channel: cook},
+			{Video: &model.Video{ID: "video3", Title: "Gazpacho"}, Channel: cook},
+		},
+		Channels: []*youtubeSvc.DiscoveredChannel{{Channel: cook, Results: 3, Saved: true}},
+	}}
+}
+
+func TestDiscover(t *testing.T) {
+	service := newFakeDiscoverer()
+	var out bytes.Buffer
+
+	require.NoError(t, Discover(context.Background(), &out, service, "spanish cooking", DiscoverOptions{In: strings.NewReader("3,1\n")}))
+	assert.Contains(t, out.String(), "1  video1    10:00     12000")
+	assert.Contains(t, out.String(), "UCcook      3        yes    Cocina")
+	assert.Contains(t, out.String(), "Saved 2 video(s)")
+	require.Len(t, service.saved, 2)
+	assert.Equal(t, "video3", service.saved[0].Video.ID)
+	assert.Equal(t, "video1", service.saved[1].Video.ID)
+
+	// Without an input nothing is saved
+	service = newFakeDiscoverer()
+	out.Reset()
+	require.NoError(t, Discover(context.Background(), &out, service, "spanish cooking", DiscoverOptions{Format: "json"}))
+	assert.Contains(t, out.String(), `"query": "spanish cooking"`)
+	assert.Nil(t, service.saved)
+}
+
+func TestParseSelection(t *testing.T) {
+	picked, err := ParseSelection("2, 4-5 2", 5)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 4, 5}, picked)
+
+	picked, err = ParseSelection("ALL", 3)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, picked)
+
+	picked, err = ParseSelection(" ", 3)
+	require.NoError(t, err)
+	assert.Empty(t, picked)
+
+	for _, input := range []string{"0", "4", "3-1", "a", "1-x"} {
+		_, err := ParseSelection(input, 3)
+		assert.Error(t, err, input)
+	}
+}
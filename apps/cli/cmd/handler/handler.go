@@ -0,0 +1,27 @@
+// Package handler holds the logic of the CLI commands. The cobra commands parse flags and build
+// services against the database; handlers receive those services as narrow interfaces and write
+// their output to an io.Writer, so they can be tested with fakes and without a database.
+package handler
+
+import "fmt"
+
+// Truncate shortens s to maxLen characters, marking the cut with "..."
+func Truncate(s string, maxLen int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
+		return s
+	}
+	return string(runes[:maxLen]) + "..."
+}
+
+// FormatDuration formats seconds as H:MM:SS (or M:SS), "-" when unknown
+func FormatDuration(seconds float64) string {
+	if seconds <= 0 {
+		return "-"
+	}
+	total := int(seconds + 0.5)
+	if total >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", total/3600, total/60%60, total%60)
+	}
+	return fmt.Sprintf("%d:%02d", total/60, total%60)
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/Taichi-iskw/yt-lang/internal/janitor"
+	"github.com/Taichi-iskw/yt-lang/internal/job"
+)
+
+// JobWorkspaces lists and removes the workspaces of resumable jobs
+type JobWorkspaces interface {
+	List() ([]*job.Workspace, error)
+	Clean(all bool) (*janitor.PruneResult, error)
+}
+
+// ListJobs prints the job workspaces, oldest first, with the last step each job completed
+func ListJobs(out io.Writer, workspaces JobWorkspaces) error {
+	list, err := workspaces.List()
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		fmt.Fprintln(out, "No job workspaces")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "JOB\tSTARTED\tUPDATED\tSTATUS\tLAST STEP\tSIZE")
+	for _, ws := range list {
+		status, started, updated := "resumable", "-", "-"
+		switch {
+		case ws.CreatedAt.IsZero():
+			status = "broken"
+		case ws.Finished():
+			status = "finished"
+		}
+		if !ws.CreatedAt.IsZero() {
+			started = ws.CreatedAt.Local().Format("2006-01-02 15:04")
+			updated = ws.UpdatedAt.Local().Format("2006-01-02 15:04")
+		}
+		lastStep := ws.LastStep()
+		if lastStep == "" {
+			lastStep = "-"
+		}
+		size, _ := ws.Size()
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", ws.ID, started, updated, status, lastStep, janitor.FormatSize(size))
+	}
+	return w.Flush()
+}
+
+// CleanJobs removes the workspaces of finished jobs, or with all of every job, and reports the
+// space freed
+func CleanJobs(out io.Writer, workspaces JobWorkspaces, all bool) error {
+	result, err := workspaces.Clean(all)
+	if err != nil {
+		return fmt.Errorf("failed to clean job workspaces: %w", err)
+	}
+	for _, path := range result.Removed {
+		fmt.Fprintf(out, "Removed %s\n", path)
+	}
+	fmt.Fprintf(out, "Removed %d job workspace(s), freed %s\n", len(result.Removed), janitor.FormatSize(result.Bytes))
+	return nil
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/job"
+)
+
+func TestListJobs(t *testing.T) {
+	root := t.TempDir()
+	workspaces := job.NewWorkspaces(root)
+
+	var out bytes.Buffer
+	require.NoError(t, ListJobs(&out, workspaces))
+	assert.Equal(t, "No job workspaces\n", out.String())
+
+	resumable, err := workspaces.Open("transcribe-abc123-en")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(resumable.Path("audio.m4a"), make([]byte, 2048), 0644))
+	require.NoError(t, resumable.Complete("audio", "audio.m4a"))
+	require.NoError(t, resumable.Complete("record", "t-1"))
+	finished, err := workspaces.Open("transcribe-def456-ja")
+	require.NoError(t, err)
+	require.NoError(t, finished.Finish())
+	require.NoError(t, os.Mkdir(filepath.Join(root, "broken"), 0755))
+
+	out.Reset()
+	require.NoError(t, ListJobs(&out, workspaces))
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 4)
+	assert.Regexp(t, `^JOB\s+STARTED\s+UPDATED\s+STATUS\s+LAST STEP\s+SIZE$`, string(lines[0]))
+	assert.Regexp(t, `^broken\s+-\s+-\s+broken\s+-\s+0 B$`, string(lines[1]))
+	assert.Regexp(t, `^transcribe-abc123-en\s+.+\s+resumable\s+record\s+\d`, string(lines[2]))
+	assert.Regexp(t, `^transcribe-def456-ja\s+.+\s+finished\s+-\s+`, string(lines[3]))
+}
+
+func TestCleanJobs(t *testing.T) {
+	workspaces := job.NewWorkspaces(t.TempDir())
+	finished, err := workspaces.Open("finished")
+	require.NoError(t, err)
+	require.NoError(t, finished.Finish())
+	unfinished, err := workspaces.Open("unfinished")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, CleanJobs(&out, workspaces, false))
+	assert.Contains(t, out.String(), "Removed "+finished.Dir+"\n")
+	assert.Contains(t, out.String(), "Removed 1 job workspace(s)")
+	assert.DirExists(t, unfinished.Dir)
+
+	out.Reset()
+	require.NoError(t, CleanJobs(&out, workspaces, true))
+	assert.Contains(t, out.String(), "Removed 1 job workspace(s)")
+	assert.NoDirExists(t, unfinished.Dir)
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/Taichi-iskw/yt-lang/internal/subtitle"
+)
+
+// LintResult is the lint report of one subtitle file or transcription
+type LintResult struct {
+	Name string `json:"name"` // File path or transcription ID
+	*subtitle.LintReport
+}
+
+// WriteLintResults prints the problems found in each linted subtitle source, as a table or JSON.
+// It reports whether every source passed.
+func WriteLintResults(out io.Writer, results []LintResult, format string) (bool, error) {
+	passed := true
+	for _, result := range results {
+		passed = passed && result.OK()
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return false, fmt.Errorf("failed to format result: %w", err)
+		}
+		fmt.Fprintln(out, string(data))
+		return passed, nil
+	}
+
+	for _, result := range results {
+		if result.OK() {
+			fmt.Fprintf(out, "✅ %s: %d cue(s), no problems\n", result.Name, result.Cues)
+			continue
+		}
+
+		fmt.Fprintf(out, "❌ %s: %d problem(s) in %d cue(s)\n", result.Name, len(result.Issues), result.Cues)
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		for _, issue := range result.Issues {
+			where := ""
+			switch {
+			case issue.Cue > 0:
+				where = fmt.Sprintf("cue %d", issue.Cue)
+			case issue.Line > 0:
+				where = fmt.Sprintf("line %d", issue.Line)
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", where, issue.Time, issue.Code, issue.Message)
+		}
+		w.Flush()
+	}
+	return passed, nil
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/subtitle"
+)
+
+func TestWriteLintResults(t *testing.T) {
+	cues := []subtitle.Cue{
+		{Start: 0, End: 3 * time.Second, Lines: []string{"Hola"}},
+		{Start: 2 * time.Second, End: 4 * time.Second, Lines: []string{"Adiós"}},
+	}
+	results := []LintResult{
+		{Name: "clean.srt", LintReport: subtitle.LintCues(cues[:1], subtitle.LintOptions{})},
+		{Name: "broken.srt", LintReport: subtitle.LintCues(cues, subtitle.LintOptions{})},
+	}
+
+	var out bytes.Buffer
+	passed, err := WriteLintResults(&out, results, "table")
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Contains(t, out.String(), "✅ clean.srt: 1 cue(s), no problems")
+	assert.Contains(t, out.String(), "❌ broken.srt: 1 problem(s) in 2 cue(s)")
+	assert.Contains(t, out.String(), "  cue 2  00:00:02,000  overlap  starts 1s before the previous cue ends (00:00:03,000)")
+
+	out.Reset()
+	passed, err = WriteLintResults(&out, results[:1], "json")
+	require.NoError(t, err)
+	assert.True(t, passed)
+	assert.Contains(t, out.String(), `"name": "clean.srt"`)
+	assert.Contains(t, out.String(), `"issues": []`)
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// RunLister lists batch runs
+type RunLister interface {
+	List(ctx context.Context, limit int) ([]*model.Run, error)
+}
+
+// RunGetter retrieves a batch run with its items
+type RunGetter interface {
+	GetByID(ctx context.Context, id string) (*model.Run, error)
+}
+
+// RunRecorder records the progress of a batch run
+type RunRecorder interface {
+	StartItem(ctx context.Context, runID string, position int) error
+	FinishItem(ctx context.Context, runID string, position int, status string, resultID, errorMessage *string) error
+	SetStatus(ctx context.Context, id string, status string) error
+}
+
+// RunBatch tells ProcessRun what the items of a run are and how to process one
+type RunBatch struct {
+	Noun string // The items, plural, e.g. "videos"
+	Verb string // What is done to an item, e.g. "transcribe"
+	Done string // Verb in the past tense, e.g. "Transcribed"
+
+	// Check, when set, runs before each item; when it fails the run stops there
+	Check func() error
+	// Process processes an item, returning the ID of what it produced
+	Process func(ctx context.Context, item *model.RunItem) (string, error)
+}
+
+// ProcessRun processes the items of a run in turn, recording the outcome of each. A failing item
+// is reported and the next one processed; failing to record progress only warns.
+func ProcessRun(ctx context.Context, out io.Writer, recorder RunRecorder, run *model.Run, items []*model.RunItem, batch RunBatch) error {
+	record := func(err error) {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to record run progress: %v\n", err)
+		}
+	}
+
+	failed := 0
+	for i, item := range items {
+		if batch.Check != nil {
+			if err := batch.Check(); err != nil {
+				record(recorder.SetStatus(ctx, run.ID, model.RunStatusFailed))
+				return fmt.Errorf("stopped after %d of %d %s: %w; continue with --resume %s", i, len(items), batch.Noun, err, run.ID)
+			}
+		}
+		fmt.Fprintf(out, "[%d/%d] %s %s\n", i+1, len(items), item.ItemID, item.Title)
+		record(recorder.StartItem(ctx, run.ID, item.Position))
+
+		resultID, err := batch.Process(ctx, item)
+		if err != nil {
+			failed++
+			fmt.Fprintf(out, "  ❌ %v\n", err)
+			message := err.Error()
+			record(recorder.FinishItem(ctx, run.ID, item.Position, model.RunStatusFailed, nil, &message))
+			continue
+		}
+		fmt.Fprintf(out, "  ✅ %s\n", resultID)
+		record(recorder.FinishItem(ctx, run.ID, item.Position, model.RunStatusCompleted, &resultID, nil))
+	}
+
+	status := model.RunStatusCompleted
+	if failed > 0 {
+		status = model.RunStatusFailed
+	}
+	record(recorder.SetStatus(ctx, run.ID, status))
+
+	fmt.Fprintf(out, "\n%s %d of %d %s (run %s)\n", batch.Done, len(items)-failed, len(items), batch.Noun, run.ID)
+	if failed > 0 {
+		return fmt.Errorf("%d %s failed to %s; retry them with --resume %s", failed, batch.Noun, batch.Verb, run.ID)
+	}
+	return nil
+}
+
+// ListRuns prints the most recent batch runs, newest first
+func ListRuns(ctx context.Context, out io.Writer, lister RunLister, limit int, format string) error {
+	runs, err := lister.List(ctx, limit)
+	if err != nil {
+		return fmt.Errorf("failed to list runs: %w", err)
+	}
+
+	if format == "json" {
+		return writeRunJSON(out, runs)
+	}
+	if len(runs) == 0 {
+		fmt.Fprintln(out, "No runs recorded")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RUN\tSTARTED\tCOMMAND\tTARGET\tSTATUS\tDONE\tFAILED")
+	for _, run := range runs {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d/%d\t%d\n",
+			run.ID, run.CreatedAt.Local().Format("2006-01-02 15:04"), run.Command, run.Target, run.Status, run.Completed, run.Total, run.Failed)
+	}
+	return w.Flush()
+}
+
+// ShowRun prints a batch run with the status, timing and outcome of each item, and how to resume
+// it when items did not complete
+func ShowRun(ctx context.Context, out io.Writer, getter RunGetter, id string, format string) error {
+	run, err := getter.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get run: %w", err)
+	}
+
+	if format == "json" {
+		return writeRunJSON(out, run)
+	}
+
+	fmt.Fprintf(out, "Run: %s\n", run.ID)
+	fmt.Fprintf(out, "Command: %s %s\n", run.Command, run.Target)
+	for _, option := range []struct{ key, label string }{{"language", "Language"}, {"model", "Model"}} {
+		if value := run.Options[option.key]; value != "" {
+			fmt.Fprintf(out, "%s: %s\n", option.label, value)
+		}
+	}
+	fmt.Fprintf(out, "Status: %s (%d/%d done, %d failed)\n", run.Status, run.Completed, run.Total, run.Failed)
+	fmt.Fprintf(out, "Started: %s\n", run.CreatedAt.Format(time.RFC3339))
+	if run.FinishedAt != nil {
+		fmt.Fprintf(out, "Finished: %s (%s)\n", run.FinishedAt.Format(time.RFC3339), run.FinishedAt.Sub(run.CreatedAt).Round(time.Second))
+	}
+
+	fmt.Fprintln(out)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tITEM\tSTATUS\tTIME\tRESULT\tTITLE")
+	for _, item := range run.Items {
+		elapsed := "-"
+		if d := item.Duration(); d > 0 {
+			elapsed = FormatDuration(d.Seconds())
+		}
+		result := "-"
+		switch {
+		case item.ErrorMessage != nil:
+			result = Truncate(*item.ErrorMessage, 60)
+		case item.ResultID != nil:
+			result = *item.ResultID
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", item.Position+1, item.ItemID, item.Status, elapsed, result, Truncate(item.Title, 40))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if unfinished := run.Unfinished(); len(unfinished) > 0 {
+		switch run.Command {
+		case model.RunCommandCreateBatch:
+			fmt.Fprintf(out, "\n%d item(s) did not complete. Resume with: yt-lang transcription create-batch --resume %s\n", len(unfinished), run.ID)
+		case model.RunCommandTranslateChapters:
+			fmt.Fprintf(out, "\n%d item(s) did not complete. Resume with: yt-lang translation create --resume %s\n", len(unfinished), run.ID)
+		}
+	}
+	return nil
+}
+
+// writeRunJSON writes runs or a run manifest as indented JSON
+func writeRunJSON(out io.Writer, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format result: %w", err)
+	}
+	fmt.Fprintln(out, string(data))
+	return nil
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// fakeRuns implements RunLister and RunGetter
+type fakeRuns struct {
+	runs []*model.Run
+}
+
+func (f *fakeRuns) List(ctx context.Context, limit int) ([]*model.Run, error) {
+	return f.runs, nil
+}
+
+func (f *fakeRuns) GetByID(ctx context.Context, id string) (*model.Run, error) {
+	return f.runs[0], nil
+}
+
+func newFakeRuns() *fakeRuns {
+	started := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	finished := started.Add(90 * time.Second)
+	transcriptionID := "trans-1"
+	failure := "whisper transcription failed"
+	return &fakeRuns{runs: []*model.Run{{
+		ID:         "run-1",
+		Command:    model.RunCommandCreateBatch,
+		Target:     "UC123",
+		Options:    map[string]string{"language": "ja", "model": "base"},
+		Status:     model.RunStatusFailed,
+		CreatedAt:  started,
+		FinishedAt: &finished,
+		Total:      3,
+		Completed:  1,
+		Failed:     1,
+		Items: []*model.RunItem{
+			{Position: 0, ItemID: "video1", Title: "First", Status: model.RunStatusCompleted, ResultID: &transcriptionID, StartedAt: &started, FinishedAt: &finished},
+			{Position: 1, ItemID: "video2", Title: "Second", Status: model.RunStatusFailed, ErrorMessage: &failure},
+			{Position: 2, ItemID: "video3", Title: "Third", Status: model.RunStatusPending},
+		},
+	}}}
+}
+
+func TestListRuns(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, ListRuns(context.Background(), &out, newFakeRuns(), 20, "table"))
+	assert.Contains(t, out.String(), "transcription create-batch  UC123   failed  1/3   1")
+
+	out.Reset()
+	require.NoError(t, ListRuns(context.Background(), &out, &fakeRuns{}, 20, "table"))
+	assert.Equal(t, "No runs recorded\n", out.String())
+}
+
+func TestShowRun(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, ShowRun(context.Background(), &out, newFakeRuns(), "run-1", "table"))
+	assert.Contains(t, out.String(), "Status: failed (1/3 done, 1 failed)")
+	assert.Contains(t, out.String(), "1  video1  completed  1:30  trans-1")
+	assert.Contains(t, out.String(), "2  video2  failed     -     whisper transcription failed")
+	assert.Contains(t, out.String(), "2 item(s) did not complete. Resume with: yt-lang transcription create-batch --resume run-1")
+
+	out.Reset()
+	require.NoError(t, ShowRun(context.Background(), &out, newFakeRuns(), "run-1", "json"))
+	assert.Contains(t, out.String(), `"item_id": "video2"`)
+}
+
+// fakeRecorder records item outcomes and the run status
+type fakeRecorder struct {
+	started  []int
+	finished map[int]string
+	results  map[int]string
+	status   string
+}
+
+func (f *fakeRecorder) StartItem(ctx context.Context, runID string, position int) error {
+	f.started = append(f.started, position)
+	return nil
+}
+
+func (f *fakeRecorder) FinishItem(ctx context.Context, runID string, position int, status string, resultID, errorMessage *string) error {
+	f.finished[position] = status
+	if resultID != nil {
+		f.results[position] = *resultID
+	}
+	return nil
+}
+
+func (f *fakeRecorder) SetStatus(ctx context.Context, id string, status string) error {
+	f.status = status
+	return nil
+}
+
+func TestProcessRun(t *testing.T) {
+	items := []*model.RunItem{{Position: 0, ItemID: "video1", Title: "First"}, {Position: 1, ItemID: "video2"}, {Position: 2, ItemID: "video3"}}
+	batch := RunBatch{
+		Noun: "videos",
+		Verb: "transcribe",
+		Done: "Transcribed",
+		Process: func(ctx context.Context, item *model.RunItem) (string, error) {
+			if item.ItemID == "video2" {
+				return "", errors.New("download failed")
+			}
+			return "trans-" + item.ItemID, nil
+		},
+	}
+
+	t.Run("failed items are reported and the run goes on", func(t *testing.T) {
+		recorder := &fakeRecorder{finished: map[int]string{}, results: map[int]string{}}
+		var out bytes.Buffer
+		err := ProcessRun(context.Background(), &out, recorder, &model.Run{ID: "run-1"}, items, batch)
+		assert.EqualError(t, err, "1 videos failed to transcribe; retry them with --resume run-1")
+
+		assert.Equal(t, map[int]string{0: model.RunStatusCompleted, 1: model.RunStatusFailed, 2: model.RunStatusCompleted}, recorder.finished)
+		assert.Equal(t, map[int]string{0: "trans-video1", 2: "trans-video3"}, recorder.results)
+		assert.Equal(t, model.RunStatusFailed, recorder.status)
+		assert.Contains(t, out.String(), "[1/3] video1 First\n  ✅ trans-video1\n[2/3] video2 \n  ❌ download failed\n")
+		assert.Contains(t, out.String(), "Transcribed 2 of 3 videos (run run-1)")
+	})
+
+	t.Run("a failed check stops the run", func(t *testing.T) {
+		recorder := &fakeRecorder{finished: map[int]string{}, results: map[int]string{}}
+		checked := 0
+		batch := batch
+		batch.Check = func() error {
+			if checked++; checked > 1 {
+				return errors.New("disk almost full")
+			}
+			return nil
+		}
+		err := ProcessRun(context.Background(), io.Discard, recorder, &model.Run{ID: "run-1"}, items, batch)
+		assert.EqualError(t, err, "stopped after 1 of 3 videos: disk almost full; continue with --resume run-1")
+		assert.Equal(t, []int{0}, recorder.started)
+		assert.Equal(t, model.RunStatusFailed, recorder.status)
+	})
+}
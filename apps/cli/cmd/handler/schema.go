@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// SchemaDumper reads the data model from the database
+type SchemaDumper interface {
+	Dump(ctx context.Context) (*model.Schema, error)
+}
+
+// DumpSchema prints the tables of the database with their columns and keys, as JSON or as a
+// table with one row per column
+func DumpSchema(ctx context.Context, out io.Writer, dumper SchemaDumper, format string) error {
+	schema, err := dumper.Dump(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read schema: %w", err)
+	}
+
+	if format == "json" {
+		return writeRunJSON(out, schema)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TABLE\tCOLUMN\tTYPE\tNULL\tDEFAULT\tKEY")
+	for _, table := range schema.Tables {
+		for _, column := range table.Columns {
+			nullable, def := "no", "-"
+			if column.Nullable {
+				nullable = "yes"
+			}
+			if column.Default != nil {
+				def = *column.Default
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", table.Name, column.Name, column.Type, nullable, def, columnKeys(table, column.Name))
+		}
+	}
+	return w.Flush()
+}
+
+// columnKeys describes the keys a column is part of, e.g. "PK, FK channels.id"
+func columnKeys(table *model.SchemaTable, column string) string {
+	var keys []string
+	if slices.Contains(table.PrimaryKey, column) {
+		keys = append(keys, "PK")
+	}
+	for _, fk := range table.ForeignKeys {
+		if i := slices.Index(fk.Columns, column); i >= 0 && i < len(fk.ReferencedColumns) {
+			keys = append(keys, fmt.Sprintf("FK %s.%s", fk.ReferencedTable, fk.ReferencedColumns[i]))
+		}
+	}
+	if len(keys) == 0 {
+		return "-"
+	}
+	return strings.Join(keys, ", ")
+}
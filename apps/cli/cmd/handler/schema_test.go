@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// fakeSchema implements SchemaDumper
+type fakeSchema struct {
+	schema *model.Schema
+}
+
+func (f *fakeSchema) Dump(ctx context.Context) (*model.Schema, error) {
+	return f.schema, nil
+}
+
+func newFakeSchema() *fakeSchema {
+	now := "now()"
+	return &fakeSchema{schema: &model.Schema{Tables: []*model.SchemaTable{{
+		Name: "videos",
+		Columns: []*model.SchemaColumn{
+			{Name: "id", Type: "character varying(255)"},
+			{Name: "channel_id", Type: "character varying(255)", Nullable: true},
+			{Name: "created_at", Type: "timestamp with time zone", Nullable: true, Default: &now},
+		},
+		PrimaryKey: []string{"id"},
+		ForeignKeys: []*model.SchemaForeignKey{{
+			Name: "fk_videos_channel_id", Columns: []string{"channel_id"},
+			ReferencedTable: "channels", ReferencedColumns: []string{"id"}, OnDelete: "CASCADE",
+		}},
+	}}}}
+}
+
+func TestDumpSchema(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		fake := newFakeSchema()
+		var out bytes.Buffer
+		require.NoError(t, DumpSchema(context.Background(), &out, fake, "json"))
+
+		var decoded model.Schema
+		require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+		assert.Equal(t, fake.schema, &decoded)
+		assert.Contains(t, out.String(), `"referenced_table": "channels"`)
+	})
+
+	t.Run("table", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, DumpSchema(context.Background(), &out, newFakeSchema(), "table"))
+
+		lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+		require.Len(t, lines, 4)
+		assert.Regexp(t, `^TABLE\s+COLUMN\s+TYPE\s+NULL\s+DEFAULT\s+KEY$`, string(lines[0]))
+		assert.Regexp(t, `^videos\s+id\s+character varying\(255\)\s+no\s+-\s+PK$`, string(lines[1]))
+		assert.Regexp(t, `^videos\s+channel_id\s+character varying\(255\)\s+yes\s+-\s+FK channels\.id$`, string(lines[2]))
+		assert.Regexp(t, `^videos\s+created_at\s+timestamp with time zone\s+yes\s+now\(\)\s+-$`, string(lines[3]))
+	})
+}
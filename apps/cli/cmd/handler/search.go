@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	embeddingSvc "github.com/Taichi-iskw/yt-lang/internal/service/embedding"
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
+)
+
+// SemanticSearcher finds segments by meaning
+type SemanticSearcher interface {
+	Search(ctx context.Context, query string, opts embeddingSvc.SearchOptions) ([]*model.SegmentMatch, error)
+}
+
+// EmbeddingIndexer computes the segment embeddings semantic search needs
+type EmbeddingIndexer interface {
+	Index(ctx context.Context, transcriptionID string, progress func(indexed int)) (int, error)
+}
+
+// SearchSemantic prints the segments closest in meaning to query, as a table or JSON
+func SearchSemantic(ctx context.Context, out io.Writer, searcher SemanticSearcher, query string, opts embeddingSvc.SearchOptions, format string) error {
+	matches, err := searcher.Search(ctx, query, opts)
+	if err != nil {
+		return fmt.Errorf("failed to search segments: %w", err)
+	}
+
+	if format == "json" {
+		if matches == nil {
+			matches = []*model.SegmentMatch{}
+		}
+		data, err := json.MarshalIndent(matches, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format result: %w", err)
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+
+	if len(matches) == 0 {
+		fmt.Fprintln(out, "No segments found; compute segment embeddings with search index")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SCORE\tVIDEO\tTIME\tLANG\tTEXT")
+	for _, match := range matches {
+		start := "-"
+		if d, err := timecode.ParseInterval(match.Segment.StartTime); err == nil {
+			start = formatViewTime(d)
+		}
+		text := strings.Join(strings.Fields(match.Segment.Text), " ")
+		fmt.Fprintf(w, "%.2f\t%s\t%s\t%s\t%s\n", 1-match.Distance, match.VideoID, start, match.Language, Truncate(text, 70))
+	}
+	return w.Flush()
+}
+
+// IndexEmbeddings computes the missing segment embeddings, of one transcription when
+// transcriptionID is set, showing the running total
+func IndexEmbeddings(ctx context.Context, out io.Writer, indexer EmbeddingIndexer, transcriptionID string) error {
+	indexed, err := indexer.Index(ctx, transcriptionID, func(indexed int) {
+		fmt.Fprintf(out, "\rEmbedded %d segment(s)", indexed)
+	})
+	if indexed > 0 {
+		// End the progress line, which holds the total
+		fmt.Fprintln(out)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to compute embeddings: %w", err)
+	}
+	if indexed == 0 {
+		fmt.Fprintln(out, "All segments already have embeddings")
+	}
+	return nil
+}
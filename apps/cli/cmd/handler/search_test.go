@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	embeddingSvc "github.com/Taichi-iskw/yt-lang/internal/service/embedding"
+)
+
+// fakeSemanticSearcher returns fixed matches and records the options it was given
+type fakeSemanticSearcher struct {
+	matches []*model.SegmentMatch
+	opts    embeddingSvc.SearchOptions
+}
+
+func (f *fakeSemanticSearcher) Search(ctx context.Context, query string, opts embeddingSvc.SearchOptions) ([]*model.SegmentMatch, error) {
+	f.opts = opts
+	return f.matches, nil
+}
+
+// fakeEmbeddingIndexer reports progress in batches of two up to total
+type fakeEmbeddingIndexer struct {
+	total int
+	err   error
+}
+
+func (f *fakeEmbeddingIndexer) Index(ctx context.Context, transcriptionID string, progress func(indexed int)) (int, error) {
+	indexed := 0
+	for indexed < f.total {
+		indexed = min(indexed+2, f.total)
+		progress(indexed)
+	}
+	return indexed, f.err
+}
+
+func TestSearchSemantic(t *testing.T) {
+	searcher := &fakeSemanticSearcher{matches: []*model.SegmentMatch{
+		{
+			Segment:  model.TranscriptionSegment{StartTime: "00:01:02.500", Text: "Ayer  comí\npaella"},
+			VideoID:  "vid-1",
+			Language: "es",
+			Distance: 0.18,
+		},
+		{
+			Segment:  model.TranscriptionSegment{StartTime: "01:00:00", Text: "Fuimos al cine"},
+			VideoID:  "vid-2",
+			Language: "es",
+			Distance: 0.3,
+		},
+	}}
+	opts := embeddingSvc.SearchOptions{Language: "es", Limit: 5}
+
+	var out bytes.Buffer
+	require.NoError(t, SearchSemantic(context.Background(), &out, searcher, "past tense", opts, "table"))
+	assert.Equal(t, opts, searcher.opts)
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 3)
+	assert.Regexp(t, `^SCORE\s+VIDEO\s+TIME\s+LANG\s+TEXT$`, string(lines[0]))
+	assert.Regexp(t, `^0\.82\s+vid-1\s+00:01:02\s+es\s+Ayer comí paella$`, string(lines[1]))
+	assert.Regexp(t, `^0\.70\s+vid-2\s+01:00:00\s+es\s+Fuimos al cine$`, string(lines[2]))
+
+	out.Reset()
+	require.NoError(t, SearchSemantic(context.Background(), &out, searcher, "past tense", opts, "json"))
+	var decoded []*model.SegmentMatch
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, searcher.matches[1].VideoID, decoded[1].VideoID)
+
+	searcher.matches = nil
+	out.Reset()
+	require.NoError(t, SearchSemantic(context.Background(), &out, searcher, "past tense", opts, "table"))
+	assert.Contains(t, out.String(), "search index")
+	out.Reset()
+	require.NoError(t, SearchSemantic(context.Background(), &out, searcher, "past tense", opts, "json"))
+	assert.Equal(t, "[]\n", out.String())
+}
+
+func TestIndexEmbeddings(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, IndexEmbeddings(context.Background(), &out, &fakeEmbeddingIndexer{total: 3}, ""))
+	assert.Equal(t, "\rEmbedded 2 segment(s)\rEmbedded 3 segment(s)\n", out.String())
+
+	out.Reset()
+	require.NoError(t, IndexEmbeddings(context.Background(), &out, &fakeEmbeddingIndexer{}, ""))
+	assert.Equal(t, "All segments already have embeddings\n", out.String())
+
+	out.Reset()
+	err := IndexEmbeddings(context.Background(), &out, &fakeEmbeddingIndexer{total: 2, err: assert.AnError}, "")
+	assert.ErrorContains(t, err, "failed to compute embeddings")
+	assert.Equal(t, "\rEmbedded 2 segment(s)\n", out.String())
+}
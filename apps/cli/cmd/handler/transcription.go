@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+	transcriptionSvc "github.com/Taichi-iskw/yt-lang/internal/service/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/tmpl"
+)
+
+// TranscriptionLister lists the transcriptions of a video
+type TranscriptionLister interface {
+	ListTranscriptions(ctx context.Context, videoID string) ([]*model.Transcription, error)
+	CountTranscriptions(ctx context.Context, videoID string) (int, error)
+}
+
+// StaleTranscriptionLister lists the transcriptions whose video changed on YouTube
+type StaleTranscriptionLister interface {
+	ListStaleTranscriptions(ctx context.Context, videoID string) ([]*model.Transcription, error)
+}
+
+// TranscriptionDeleter deletes transcriptions
+type TranscriptionDeleter interface {
+	DeleteTranscription(ctx context.Context, id string) error
+}
+
+// TranscriptionPlanner plans batch transcriptions
+type TranscriptionPlanner interface {
+	PlanTranscriptionCandidates(ctx context.Context, filter video.CandidateFilter) ([]*video.PlannedVideo, error)
+}
+
+// PlanOptions configures PlanTranscriptionBatch
+type PlanOptions struct {
+	Model  string // Whisper model the estimates are made for
+	Routed bool   // The model is picked per video by the routing rules; Model is their default
+}
+
+// skipReasons describes why batch transcription leaves a video out
+var skipReasons = map[string]string{
+	video.SkipUnavailable:     "unavailable",
+	video.SkipDuplicate:       "re-upload of another video",
+	video.SkipTranscribed:     "already transcribed",
+	video.SkipTooShort:        "shorter than --min-duration",
+	video.SkipTooLong:         "longer than --max-duration or unknown duration",
+	video.SkipPublishedBefore: "uploaded before --published-after or on an unknown date",
+	video.SkipLimit:           "past --limit",
+}
+
+// skipOrder is the order skip reasons are summarized in, that of the checks
+var skipOrder = []string{video.SkipUnavailable, video.SkipDuplicate, video.SkipTranscribed, video.SkipTooShort, video.SkipTooLong, video.SkipPublishedBefore, video.SkipLimit}
+
+// PlanTranscriptionBatch prints which videos of a channel transcription create-batch would
+// transcribe, with their duration and estimated whisper time, and why it skips the others.
+// Nothing is transcribed.
+func PlanTranscriptionBatch(ctx context.Context, out io.Writer, planner TranscriptionPlanner, filter video.CandidateFilter, opts PlanOptions) error {
+	planned, err := planner.PlanTranscriptionCandidates(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if len(planned) == 0 {
+		fmt.Fprintf(out, "No saved videos for channel: %s\n", filter.ChannelID)
+		return nil
+	}
+
+	modelNote := opts.Model
+	if opts.Routed {
+		modelNote = fmt.Sprintf("picked by routing rules; estimates use %s", opts.Model)
+	}
+	fmt.Fprintf(out, "PLAN: channel %s, language %s, model %s\n\n", filter.ChannelID, filter.Language, modelNote)
+
+	var audio, estimate time.Duration
+	selected := 0
+	skipped := map[string]int{}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VIDEO\tDURATION\tESTIMATE\tPLAN\tTITLE")
+	for _, p := range planned {
+		action, whisperTime := "transcribe", "-"
+		if p.Skip != "" {
+			skipped[p.Skip]++
+			action = "skip: " + skipReasons[p.Skip]
+		} else {
+			selected++
+			duration := time.Duration(p.Video.Duration * float64(time.Second))
+			audio += duration
+			if t := transcriptionSvc.EstimateWhisperTime(opts.Model, duration); t > 0 {
+				estimate += t
+				whisperTime = FormatDuration(t.Seconds())
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", p.Video.ID, FormatDuration(p.Video.Duration), whisperTime, action, Truncate(p.Video.Title, 50))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nWould transcribe %d of %d video(s): %s of audio, about %s of whisper time\n",
+		selected, len(planned), FormatDuration(audio.Seconds()), FormatDuration(estimate.Seconds()))
+	if len(skipped) > 0 {
+		var reasons []string
+		for _, reason := range skipOrder {
+			if skipped[reason] > 0 {
+				reasons = append(reasons, fmt.Sprintf("%d %s", skipped[reason], skipReasons[reason]))
+			}
+		}
+		fmt.Fprintf(out, "Skipped: %s\n", strings.Join(reasons, ", "))
+	}
+	return nil
+}
+
+// ListTranscriptions prints the transcriptions of a video, or renders each with template when set
+func ListTranscriptions(ctx context.Context, out io.Writer, service TranscriptionLister, videoID string, template *tmpl.Template) error {
+	results, err := service.ListTranscriptions(ctx, videoID)
+	if err != nil {
+		return err
+	}
+
+	if template != nil {
+		for _, t := range results {
+			if err := template.Execute(out, t); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintf(out, "No transcriptions found for video: %s\n", videoID)
+		return nil
+	}
+
+	fmt.Fprintf(out, "Transcriptions for video %s (%d found):\n\n", videoID, len(results))
+	for _, t := range results {
+		fmt.Fprintf(out, "ID: %s\n", t.ID)
+		fmt.Fprintf(out, "Language: %s\n", t.Language)
+		fmt.Fprintf(out, "Status: %s\n", t.Status)
+		if t.DetectedLanguage != nil {
+			fmt.Fprintf(out, "Detected Language: %s\n", *t.DetectedLanguage)
+		}
+		fmt.Fprintf(out, "Created: %s\n", t.CreatedAt.Format(time.RFC3339))
+		if t.CompletedAt != nil {
+			fmt.Fprintf(out, "Completed: %s\n", t.CompletedAt.Format(time.RFC3339))
+		}
+		fmt.Fprintln(out, "---")
+	}
+
+	total, err := service.CountTranscriptions(ctx, videoID)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, model.NewPage(total, len(results), 0).Footer(len(results)))
+	return nil
+}
+
+// ListStaleTranscriptions prints the transcriptions whose video changed on YouTube since they
+// were made, of one video or of all when videoID is empty, or renders each with template when set
+func ListStaleTranscriptions(ctx context.Context, out io.Writer, service StaleTranscriptionLister, videoID string, template *tmpl.Template) error {
+	results, err := service.ListStaleTranscriptions(ctx, videoID)
+	if err != nil {
+		return err
+	}
+
+	if template != nil {
+		for _, t := range results {
+			if err := template.Execute(out, t); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintln(out, "No stale transcriptions found")
+		return nil
+	}
+
+	fmt.Fprintf(out, "Stale transcriptions (%d found):\n\n", len(results))
+	for _, t := range results {
+		fmt.Fprintf(out, "ID: %s\n", t.ID)
+		fmt.Fprintf(out, "Video: %s\n", t.VideoID)
+		fmt.Fprintf(out, "Language: %s\n", t.Language)
+		if t.StaleReason != nil {
+			fmt.Fprintf(out, "Reason: %s\n", *t.StaleReason)
+		}
+		if t.StaleAt != nil {
+			fmt.Fprintf(out, "Detected: %s\n", t.StaleAt.Format(time.RFC3339))
+		}
+		fmt.Fprintln(out, "---")
+	}
+	fmt.Fprintln(out, "Delete a stale transcription and run transcription create again to transcribe the video as it is now.")
+	return nil
+}
+
+// DeleteTranscription deletes a transcription and its segments
+func DeleteTranscription(ctx context.Context, out io.Writer, service TranscriptionDeleter, transcriptionID string) error {
+	if err := service.DeleteTranscription(ctx, transcriptionID); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "✅ Transcription '%s' deleted successfully.\n", transcriptionID)
+	return nil
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+)
+
+// fakeTranscriptions implements TranscriptionLister and TranscriptionDeleter
+type fakeTranscriptions struct {
+	transcriptions []*model.Transcription
+	deleted        []string
+}
+
+func (f *fakeTranscriptions) ListTranscriptions(ctx context.Context, videoID string) ([]*model.Transcription, error) {
+	return f.transcriptions, nil
+}
+
+func (f *fakeTranscriptions) CountTranscriptions(ctx context.Context, videoID string) (int, error) {
+	return len(f.transcriptions), nil
+}
+
+func (f *fakeTranscriptions) DeleteTranscription(ctx context.Context, id string) error {
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func TestListTranscriptions(t *testing.T) {
+	english := "en"
+	service := &fakeTranscriptions{transcriptions: []*model.Transcription{
+		{ID: "t1", Language: "auto", Status: "completed", DetectedLanguage: &english, CreatedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)},
+	}}
+
+	var out bytes.Buffer
+	require.NoError(t, ListTranscriptions(context.Background(), &out, service, "vid1", nil))
+	assert.Equal(t, "Transcriptions for video vid1 (1 found):\n\n"+
+		"ID: t1\nLanguage: auto\nStatus: completed\nDetected Language: en\nCreated: 2024-05-01T12:00:00Z\n---\n"+
+		"Showing 1-1 of 1 (page 1 of 1)\n", out.String())
+
+	out.Reset()
+	require.NoError(t, ListTranscriptions(context.Background(), &out, &fakeTranscriptions{}, "vid2", nil))
+	assert.Equal(t, "No transcriptions found for video: vid2\n", out.String())
+}
+
+// fakeStaleTranscriptions implements StaleTranscriptionLister
+type fakeStaleTranscriptions []*model.Transcription
+
+func (f fakeStaleTranscriptions) ListStaleTranscriptions(ctx context.Context, videoID string) ([]*model.Transcription, error) {
+	return f, nil
+}
+
+func TestListStaleTranscriptions(t *testing.T) {
+	reason := "title, duration changed on YouTube"
+	staleAt := time.Date(2024, 6, 1, 8, 30, 0, 0, time.UTC)
+	service := fakeStaleTranscriptions{
+		{ID: "t1", VideoID: "vid1", Language: "es", Status: "completed", StaleAt: &staleAt, StaleReason: &reason},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, ListStaleTranscriptions(context.Background(), &out, service, "", nil))
+	assert.Equal(t, "Stale transcriptions (1 found):\n\n"+
+		"ID: t1\nVideo: vid1\nLanguage: es\nReason: title, duration changed on YouTube\nDetected: 2024-06-01T08:30:00Z\n---\n"+
+		"Delete a stale transcription and run transcription create again to transcribe the video as it is now.\n", out.String())
+
+	out.Reset()
+	require.NoError(t, ListStaleTranscriptions(context.Background(), &out, fakeStaleTranscriptions{}, "", nil))
+	assert.Equal(t, "No stale transcriptions found\n", out.String())
+}
+
+func TestDeleteTranscription(t *testing.T) {
+	service := &fakeTranscriptions{}
+
+	var out bytes.Buffer
+	require.NoError(t, DeleteTranscription(context.Background(), &out, service, "t1"))
+	assert.Equal(t, []string{"t1"}, service.deleted)
+	assert.Contains(t, out.String(), "Transcription 't1' deleted successfully")
+}
+
+// fakePlanner returns a fixed plan
+type fakePlanner struct {
+	planned []*video.PlannedVideo
+}
+
+func (f *fakePlanner) PlanTranscriptionCandidates(ctx context.Context, filter video.CandidateFilter) ([]*video.PlannedVideo, error) {
+	return f.planned, nil
+}
+
+func TestPlanTranscriptionBatch(t *testing.T) {
+	planner := &fakePlanner{planned: []*video.PlannedVideo{
+		{Video: &model.Video{ID: "video1", Title: "Tortilla", Duration: 600}},
+		{Video: &model.Video{ID: "video2", Title: "Paella", Duration: 300}, Skip: video.SkipTranscribed},
+		{Video: &model.Video{ID: "video3", Title: "Gazpacho", Duration: 900}},
+		{Video: &model.Video{ID: "video4", Title: "Churros"}, Skip: video.SkipTooLong},
+	}}
+
+	var out bytes.Buffer
+	filter := video.CandidateFilter{ChannelID: "UCcook", Language: "es"}
+	require.NoError(t, PlanTranscriptionBatch(context.Background(), &out, planner, filter, PlanOptions{Model: "base"}))
+	assert.Contains(t, out.String(), "PLAN: channel UCcook, language es, model base")
+	assert.Contains(t, out.String(), "video1  10:00     2:00      transcribe")
+	assert.Contains(t, out.String(), "video2  5:00      -         skip: already transcribed")
+	assert.Contains(t, out.String(), "Would transcribe 2 of 4 video(s): 25:00 of audio, about 5:00 of whisper time")
+	assert.Contains(t, out.String(), "Skipped: 1 already transcribed, 1 longer than --max-duration or unknown duration")
+
+	out.Reset()
+	require.NoError(t, PlanTranscriptionBatch(context.Background(), &out, &fakePlanner{}, filter, PlanOptions{Model: "base"}))
+	assert.Equal(t, "No saved videos for channel: UCcook\n", out.String())
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/tmpl"
+)
+
+// TranslationLister lists the translations of a transcription
+type TranslationLister interface {
+	ListTranslations(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error)
+	CountTranslations(ctx context.Context, transcriptionID string) (int, error)
+}
+
+// TranslationDeleter deletes translations
+type TranslationDeleter interface {
+	DeleteTranslation(ctx context.Context, id string) error
+}
+
+// ListTranslationsOptions configures ListTranslations
+type ListTranslationsOptions struct {
+	Limit    int
+	Offset   int
+	Template *tmpl.Template // Renders each translation instead of the summary
+}
+
+// ListTranslations prints a page of a transcription's translations with a preview of each
+func ListTranslations(ctx context.Context, out io.Writer, service TranslationLister, transcriptionID string, opts ListTranslationsOptions) error {
+	translations, err := service.ListTranslations(ctx, transcriptionID, opts.Limit, opts.Offset)
+	if err != nil {
+		return fmt.Errorf("failed to list translations: %w", err)
+	}
+
+	if opts.Template != nil {
+		for _, translation := range translations {
+			if err := opts.Template.Execute(out, translation); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(translations) == 0 {
+		fmt.Fprintln(out, "No translations found for transcription", transcriptionID)
+		return nil
+	}
+
+	total, err := service.CountTranslations(ctx, transcriptionID)
+	if err != nil {
+		return fmt.Errorf("failed to count translations: %w", err)
+	}
+
+	fmt.Fprintf(out, "Translations for transcription %s:\n\n", transcriptionID)
+	for _, translation := range translations {
+		fmt.Fprintf(out, "ID: %d\n", translation.ID)
+		fmt.Fprintf(out, "Target Language: %s\n", translation.TargetLanguage)
+		fmt.Fprintf(out, "Source: %s\n", translation.Source)
+		fmt.Fprintf(out, "Created: %s\n", translation.CreatedAt.Format("2006-01-02 15:04:05"))
+		fmt.Fprintf(out, "Content Preview: %s\n", Truncate(translation.TranslatedText, 100))
+		fmt.Fprintln(out, "---")
+	}
+	fmt.Fprintln(out, model.NewPage(total, opts.Limit, opts.Offset).Footer(len(translations)))
+	return nil
+}
+
+// DeleteTranslation deletes a translation
+func DeleteTranslation(ctx context.Context, out io.Writer, service TranslationDeleter, translationID string) error {
+	if err := service.DeleteTranslation(ctx, translationID); err != nil {
+		return fmt.Errorf("failed to delete translation: %w", err)
+	}
+
+	fmt.Fprintf(out, "Translation %s deleted successfully\n", translationID)
+	return nil
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/janitor"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	translationSvc "github.com/Taichi-iskw/yt-lang/internal/service/translation"
+	"github.com/Taichi-iskw/yt-lang/internal/service/tts"
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
+)
+
+// TranslationLocator finds the transcription a stored translation belongs to
+type TranslationLocator interface {
+	Get(ctx context.Context, id int) (*model.Translation, error)
+	GetTranscriptionID(ctx context.Context, id int) (string, error)
+}
+
+// AlignedTranslationReader reads the translation of a transcription segment by segment, the
+// variant selected with translationSvc.WithSource and WithStyle
+type AlignedTranslationReader interface {
+	GetAlignedTranslation(ctx context.Context, transcriptionID string, targetLang string) ([]*translationSvc.TranslationSegment, error)
+}
+
+// ReadAloudOptions configures ReadAloud
+type ReadAloudOptions struct {
+	Voice  string            // Voice to read with; the translation's language when empty
+	Voices map[string]string // Backend voice per voice (tts.voices); other voices are passed as they are
+	Output string            // Audio file written; its extension selects the format
+	MaxGap time.Duration     // Longest silence kept between segments; 0 keeps the original gaps
+
+	// Encode converts the WAV rendering to the format of outputs other than .wav
+	Encode func(ctx context.Context, wavPath, outputPath string) error
+}
+
+// ReadAloud reads the translation a stored translation belongs to (its transcription in its
+// language, source and style) aloud into opts.Output, each segment at its time in the video
+func ReadAloud(ctx context.Context, out io.Writer, locator TranslationLocator, reader AlignedTranslationReader, backend tts.Backend, translationID string, opts ReadAloudOptions) error {
+	id, err := strconv.Atoi(translationID)
+	if err != nil {
+		return fmt.Errorf("invalid translation ID: %s", translationID)
+	}
+	translation, err := locator.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get translation: %w", err)
+	}
+	transcriptionID, err := locator.GetTranscriptionID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get the transcription of translation %d: %w", id, err)
+	}
+	variant := translationSvc.WithStyle(translationSvc.WithSource(ctx, translation.Source), translation.Style)
+	aligned, err := reader.GetAlignedTranslation(variant, transcriptionID, translation.TargetLanguage)
+	if err != nil {
+		return err
+	}
+
+	segments := make([]tts.Segment, len(aligned))
+	for i, segment := range aligned {
+		start, err := timecode.ParseInterval(segment.StartTime)
+		if err != nil {
+			return fmt.Errorf("invalid start of segment %d: %w", segment.SegmentIndex, err)
+		}
+		segments[i] = tts.Segment{Start: start, Text: segment.TranslatedText}
+	}
+
+	voice := opts.Voice
+	if voice == "" {
+		voice = translation.TargetLanguage
+	}
+	if mapped, ok := opts.Voices[voice]; ok {
+		voice = mapped
+	}
+
+	// Other formats are encoded from a WAV rendering
+	wavPath := opts.Output
+	encode := !strings.EqualFold(filepath.Ext(opts.Output), ".wav")
+	if encode {
+		dir, err := janitor.MkdirTemp("yt-lang-tts-*")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		defer janitor.RemoveTemp(dir)
+		wavPath = filepath.Join(dir, "translation.wav")
+	}
+
+	file, err := os.Create(wavPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	report, err := tts.Render(ctx, backend, segments, tts.Options{Voice: voice, MaxGap: opts.MaxGap}, file)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write output file: %w", closeErr)
+	}
+	if err != nil {
+		return err
+	}
+	if encode {
+		if err := opts.Encode(ctx, wavPath, opts.Output); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(out, "Read %d segments of the %s translation aloud (%s) to %s\n",
+		report.Segments, translation.TargetLanguage, timecode.FormatInterval(report.Duration), opts.Output)
+	if report.Skipped > 0 {
+		fmt.Fprintf(out, "Skipped %d segments without text\n", report.Skipped)
+	}
+	if report.Overruns > 0 {
+		fmt.Fprintf(out, "%d segments started late: the previous segment was still being read\n", report.Overruns)
+	}
+	return nil
+}
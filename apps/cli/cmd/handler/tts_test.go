@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	translationSvc "github.com/Taichi-iskw/yt-lang/internal/service/translation"
+)
+
+// fakeTranslations serves translation 7, a Japanese translation of transcription trans-1, and
+// translation 9, a post-edited casual one
+type fakeTranslations struct{}
+
+func (fakeTranslations) Get(ctx context.Context, id int) (*model.Translation, error) {
+	switch id {
+	case 7:
+		return &model.Translation{ID: id, TargetLanguage: "ja"}, nil
+	case 9:
+		return &model.Translation{ID: id, TargetLanguage: "ja", Source: translationSvc.SourcePolished, Style: translationSvc.StyleCasual}, nil
+	}
+	return nil, errors.New("translation not found")
+}
+
+func (fakeTranslations) GetTranscriptionID(ctx context.Context, id int) (string, error) {
+	return "trans-1", nil
+}
+
+func (fakeTranslations) GetAlignedTranslation(ctx context.Context, transcriptionID string, targetLang string) ([]*translationSvc.TranslationSegment, error) {
+	if transcriptionID != "trans-1" || targetLang != "ja" {
+		return nil, errors.New("unexpected translation")
+	}
+	return []*translationSvc.TranslationSegment{
+		{SegmentIndex: 0, StartTime: "00:00:00.500", TranslatedText: "こんにちは。"},
+		{SegmentIndex: 1, StartTime: "00:00:02", TranslatedText: ""},
+		{SegmentIndex: 2, StartTime: "00:00:03", TranslatedText: "さようなら。"},
+	}, nil
+}
+
+// variantSegments serves one segment of trans-1
+type variantSegments struct {
+	translationSvc.TranscriptionRepository
+}
+
+func (variantSegments) GetSegments(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error) {
+	return []*model.TranscriptionSegment{{ID: "seg-1", StartTime: "00:00:01", Text: "Hello"}}, nil
+}
+
+// variantRows serves the segment of variantSegments translated by several sources in several styles
+type variantRows struct {
+	translationSvc.TranslationRepository
+}
+
+func (variantRows) ListByTranscriptionID(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error) {
+	if offset > 0 {
+		return nil, nil
+	}
+	return []*model.Translation{
+		{TranscriptionSegmentID: "seg-1", TargetLanguage: "ja", TranslatedText: "やあ", Source: translationSvc.ProviderManual, Style: translationSvc.StyleCasual},
+		{TranscriptionSegmentID: "seg-1", TargetLanguage: "ja", TranslatedText: "こんにちは", Source: translationSvc.SourcePolished},
+		{TranscriptionSegmentID: "seg-1", TargetLanguage: "ja", TranslatedText: "よっ", Source: translationSvc.SourcePolished, Style: translationSvc.StyleCasual},
+	}, nil
+}
+
+// fakeSpeaker speaks every text for one second of 8 kHz mono 16-bit PCM
+type fakeSpeaker struct {
+	voices []string
+	texts  []string
+}
+
+func (f *fakeSpeaker) Synthesize(ctx context.Context, text, voice string) ([]byte, error) {
+	f.voices = append(f.voices, voice)
+	f.texts = append(f.texts, text)
+	const sampleRate, dataSize = 8000, 16000
+
+	var clip bytes.Buffer
+	clip.WriteString("RIFF")
+	binary.Write(&clip, binary.LittleEndian, uint32(36+dataSize))
+	clip.WriteString("WAVEfmt ")
+	for _, field := range []any{uint32(16), uint16(1), uint16(1), uint32(sampleRate), uint32(2 * sampleRate), uint16(2), uint16(16)} {
+		binary.Write(&clip, binary.LittleEndian, field)
+	}
+	clip.WriteString("data")
+	binary.Write(&clip, binary.LittleEndian, uint32(dataSize))
+	clip.Write(bytes.Repeat([]byte{0x11}, dataSize))
+	return clip.Bytes(), nil
+}
+
+func TestReadAloud(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("wav output", func(t *testing.T) {
+		speaker := &fakeSpeaker{}
+		output := filepath.Join(t.TempDir(), "out.wav")
+		var out bytes.Buffer
+		err := ReadAloud(ctx, &out, fakeTranslations{}, fakeTranslations{}, speaker, "7", ReadAloudOptions{
+			Voices: map[string]string{"ja": "ja_JP-test.onnx"},
+			Output: output,
+		})
+		require.NoError(t, err)
+
+		// The voice defaults to the translation's language, mapped through the voices
+		assert.Equal(t, []string{"ja_JP-test.onnx", "ja_JP-test.onnx"}, speaker.voices)
+		assert.Contains(t, out.String(), "Read 2 segments of the ja translation aloud (00:00:04.000) to "+output)
+		assert.Contains(t, out.String(), "Skipped 1 segments without text")
+
+		info, err := os.Stat(output)
+		require.NoError(t, err)
+		assert.Equal(t, int64(44+4*16000), info.Size())
+	})
+
+	t.Run("other formats are encoded", func(t *testing.T) {
+		speaker := &fakeSpeaker{}
+		output := filepath.Join(t.TempDir(), "out.mp3")
+		var encoded string
+		err := ReadAloud(ctx, &bytes.Buffer{}, fakeTranslations{}, fakeTranslations{}, speaker, "7", ReadAloudOptions{
+			Voice:  "ja-JP",
+			Output: output,
+			Encode: func(ctx context.Context, wavPath, outputPath string) error {
+				assert.Equal(t, output, outputPath)
+				_, err := os.Stat(wavPath)
+				encoded = wavPath
+				return err
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "ja-JP", speaker.voices[0])
+		assert.Equal(t, ".wav", filepath.Ext(encoded))
+
+		// The WAV rendering is removed once encoded
+		_, err = os.Stat(encoded)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("the source and style of the translation are read", func(t *testing.T) {
+		speaker := &fakeSpeaker{}
+		reader := translationSvc.NewTranslationService(variantSegments{}, variantRows{}, nil, nil)
+		err := ReadAloud(ctx, &bytes.Buffer{}, fakeTranslations{}, reader, speaker, "9", ReadAloudOptions{
+			Output: filepath.Join(t.TempDir(), "out.wav"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"よっ"}, speaker.texts)
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		err := ReadAloud(ctx, &bytes.Buffer{}, fakeTranslations{}, fakeTranslations{}, &fakeSpeaker{}, "abc", ReadAloudOptions{Output: "out.wav"})
+		assert.ErrorContains(t, err, "invalid translation ID")
+	})
+
+	t.Run("unknown translation", func(t *testing.T) {
+		err := ReadAloud(ctx, &bytes.Buffer{}, fakeTranslations{}, fakeTranslations{}, &fakeSpeaker{}, "8", ReadAloudOptions{Output: "out.wav"})
+		assert.ErrorContains(t, err, "failed to get translation")
+	})
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// UsageSummarizer sums up the recorded runs of each command
+type UsageSummarizer interface {
+	Summary(ctx context.Context, since time.Time) ([]*model.CommandUsageStats, error)
+}
+
+// ShowUsage prints the recorded runs of each command since a time (all runs when zero), the
+// commands taking the most time first. enabled tells whether runs are being recorded, for the
+// hint printed when none are.
+func ShowUsage(ctx context.Context, out io.Writer, summarizer UsageSummarizer, since time.Time, enabled bool, format string) error {
+	stats, err := summarizer.Summary(ctx, since)
+	if err != nil {
+		return fmt.Errorf("failed to summarize usage: %w", err)
+	}
+
+	if format == "json" {
+		return writeRunJSON(out, stats)
+	}
+	if !enabled {
+		defer fmt.Fprintln(out, "\nUsage stats are off; set usage_stats.enabled: true in the config file to record commands")
+	}
+	if len(stats) == 0 {
+		fmt.Fprintln(out, "No command runs recorded")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "COMMAND\tRUNS\tFAILED\tTOTAL\tMEDIAN\tLONGEST\tLAST RUN")
+	for _, s := range stats {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\t%s\n",
+			s.Command, s.Runs, s.Failed, formatUsageTime(s.TotalSeconds), formatUsageTime(s.MedianSeconds),
+			formatUsageTime(s.LongestSeconds), s.LastRunAt.Local().Format("2006-01-02 15:04"))
+	}
+	return w.Flush()
+}
+
+// formatUsageTime formats seconds like FormatDuration, with tenths below a minute so quick
+// commands don't all show 0:00
+func formatUsageTime(seconds float64) string {
+	if seconds < 60 {
+		return fmt.Sprintf("%.1fs", seconds)
+	}
+	return FormatDuration(seconds)
+}
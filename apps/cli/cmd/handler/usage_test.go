@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// fakeUsage implements UsageSummarizer
+type fakeUsage struct {
+	stats []*model.CommandUsageStats
+	since time.Time
+}
+
+func (f *fakeUsage) Summary(ctx context.Context, since time.Time) ([]*model.CommandUsageStats, error) {
+	f.since = since
+	return f.stats, nil
+}
+
+func TestShowUsage(t *testing.T) {
+	since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(2024, 5, 3, 12, 0, 0, 0, time.UTC)
+	usage := &fakeUsage{stats: []*model.CommandUsageStats{
+		{Command: "transcription create", Runs: 4, Failed: 1, TotalSeconds: 1800, MedianSeconds: 420, LongestSeconds: 700, LastRunAt: last},
+		{Command: "video list", Runs: 10, TotalSeconds: 5, MedianSeconds: 0.4, LongestSeconds: 1.2, LastRunAt: last},
+	}}
+
+	t.Run("table", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, ShowUsage(context.Background(), &out, usage, since, true, "table"))
+		assert.Equal(t, since, usage.since)
+
+		lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+		require.Len(t, lines, 3)
+		assert.Regexp(t, `^COMMAND\s+RUNS\s+FAILED\s+TOTAL\s+MEDIAN\s+LONGEST\s+LAST RUN$`, string(lines[0]))
+		assert.Regexp(t, `^transcription create\s+4\s+1\s+30:00\s+7:00\s+11:40\s+`, string(lines[1]))
+		assert.Regexp(t, `^video list\s+10\s+0\s+5\.0s\s+0\.4s\s+1\.2s\s+`, string(lines[2]))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, ShowUsage(context.Background(), &out, usage, since, true, "json"))
+
+		var decoded []*model.CommandUsageStats
+		require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+		assert.Equal(t, usage.stats, decoded)
+	})
+
+	t.Run("hints at the config when recording is off", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, ShowUsage(context.Background(), &out, &fakeUsage{}, time.Time{}, false, "table"))
+		assert.Contains(t, out.String(), "No command runs recorded")
+		assert.Contains(t, out.String(), "usage_stats.enabled: true")
+	})
+}
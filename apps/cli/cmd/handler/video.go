@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	statusSvc "github.com/Taichi-iskw/yt-lang/internal/service/status"
+	"github.com/Taichi-iskw/yt-lang/internal/tmpl"
+)
+
+// VideoLister lists saved videos
+type VideoLister interface {
+	ListVideos(ctx context.Context, channelID string, limit, offset int) ([]*model.Video, error)
+	IterateVideos(ctx context.Context, channelID string, fn func(video *model.Video) error) error
+	CountVideosByChannel(ctx context.Context, channelID string) (int, error)
+	ListRatedVideos(ctx context.Context, channelID string, minRating int) ([]*model.Video, error)
+}
+
+// VideoStatuser derives the workflow status of videos
+type VideoStatuser interface {
+	GetVideoStatuses(ctx context.Context, videos []*model.Video) ([]*statusSvc.VideoStatus, error)
+}
+
+// TaggedVideoFinder finds videos by topic tag
+type TaggedVideoFinder interface {
+	VideosByTag(ctx context.Context, tag string) ([]*model.Video, error)
+}
+
+// ListVideosOptions configures ListVideos
+type ListVideosOptions struct {
+	ChannelID string // Optional with MinRating
+	MinRating int    // List only videos rated at least this, best rated first
+	Tag       string // List only videos tagged with this topic across channels, best match first
+	Limit     int
+	Offset    int
+	All       bool              // Every video of the channel instead of one page
+	Format    string            // json, csv or tsv
+	Columns   string            // csv/tsv columns
+	Template  *tmpl.Template    // Renders each video instead of the JSON output
+	Status    VideoStatuser     // Attaches each video's workflow status to the JSON output when set
+	Tags      TaggedVideoFinder // Finds the videos of Tag
+	Info      io.Writer         // Receives messages such as "No videos found", kept out of the listing; defaults to stderr
+}
+
+// videoListResult is the JSON output of video list
+type videoListResult struct {
+	model.Page
+	Videos any `json:"videos"` // Videos, or video statuses with --with-status
+}
+
+// ListVideos writes the videos of a channel as JSON with pagination metadata, as csv/tsv rows or
+// rendered by a template. Rows and templates are streamed as videos are loaded.
+func ListVideos(ctx context.Context, w io.Writer, service VideoLister, opts ListVideosOptions) error {
+	// eachVideo visits the requested page, or with All every page of the channel
+	eachVideo := func(fn func(video *model.Video) error) error {
+		if opts.Tag != "" || opts.MinRating != 0 {
+			var videos []*model.Video
+			var err error
+			if opts.Tag != "" {
+				videos, err = opts.Tags.VideosByTag(ctx, opts.Tag)
+			} else {
+				videos, err = service.ListRatedVideos(ctx, opts.ChannelID, opts.MinRating)
+			}
+			if err != nil {
+				return err
+			}
+			for _, video := range videos {
+				if err := fn(video); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if opts.All {
+			return service.IterateVideos(ctx, opts.ChannelID, fn)
+		}
+		videos, err := service.ListVideos(ctx, opts.ChannelID, opts.Limit, opts.Offset)
+		if err != nil {
+			return err
+		}
+		for _, video := range videos {
+			if err := fn(video); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if opts.Template != nil {
+		if err := eachVideo(func(video *model.Video) error { return opts.Template.Execute(w, video) }); err != nil {
+			return fmt.Errorf("failed to list videos: %w", err)
+		}
+		return nil
+	}
+	if opts.Format != "json" {
+		rows, err := newVideoRowWriter(w, opts.Format, opts.Columns)
+		if err != nil {
+			return err
+		}
+		if err := eachVideo(rows.WriteVideo); err != nil {
+			return fmt.Errorf("failed to list videos: %w", err)
+		}
+		return rows.Close()
+	}
+
+	videos := []*model.Video{}
+	err := eachVideo(func(video *model.Video) error {
+		videos = append(videos, video)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list videos: %w", err)
+	}
+
+	if len(videos) == 0 {
+		info := opts.Info
+		if info == nil {
+			info = os.Stderr
+		}
+		switch {
+		case opts.Tag != "":
+			fmt.Fprintf(info, "No videos tagged %q\n", opts.Tag)
+		case opts.MinRating != 0:
+			fmt.Fprintf(info, "No videos rated %d or higher\n", opts.MinRating)
+		default:
+			fmt.Fprintf(info, "No videos found for channel ID: %s\n", opts.ChannelID)
+		}
+		return nil
+	}
+
+	// All, Tag and MinRating return a single page holding every video
+	page := model.NewPage(len(videos), len(videos), 0)
+	if !opts.All && opts.Tag == "" && opts.MinRating == 0 {
+		total, err := service.CountVideosByChannel(ctx, opts.ChannelID)
+		if err != nil {
+			return fmt.Errorf("failed to count videos: %w", err)
+		}
+		page = model.NewPage(total, opts.Limit, opts.Offset)
+	}
+
+	var result any = videos
+	if opts.Status != nil {
+		if result, err = opts.Status.GetVideoStatuses(ctx, videos); err != nil {
+			return fmt.Errorf("failed to get video status: %w", err)
+		}
+	}
+
+	data, err := json.MarshalIndent(videoListResult{Page: page, Videos: result}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format result: %w", err)
+	}
+
+	if opts.Tag != "" {
+		fmt.Fprintf(w, "Found %d video(s) tagged %q:\n%s\n", len(videos), opts.Tag, string(data))
+		return nil
+	}
+	if opts.ChannelID == "" {
+		fmt.Fprintf(w, "Found %d video(s) rated %d or higher:\n%s\n", len(videos), opts.MinRating, string(data))
+		return nil
+	}
+	fmt.Fprintf(w, "Found %d video(s) for channel %s:\n%s\n", len(videos), opts.ChannelID, string(data))
+	return nil
+}
+
+// PrintChangedVideos reports the saved videos a sync found changed on YouTube, whose
+// transcriptions were marked stale
+func PrintChangedVideos(w io.Writer, changes []*model.VideoChange) {
+	for _, change := range changes {
+		fmt.Fprintf(w, "⚠️  Video %s (%s) changed on YouTube: %s; its transcriptions are listed by transcription list --stale\n",
+			change.VideoID, change.Title, strings.Join(change.Fields, ", "))
+	}
+}
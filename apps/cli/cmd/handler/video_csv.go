@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// DefaultVideoColumns are the columns written by video list --format csv/tsv without --columns
+const DefaultVideoColumns = "id,title,duration,url"
+
+// videoColumns maps each column accepted by --columns to its value
+var videoColumns = map[string]func(v *model.Video) string{
+	"id":         func(v *model.Video) string { return v.ID },
+	"channel_id": func(v *model.Video) string { return v.ChannelID },
+	"title":      func(v *model.Video) string { return v.Title },
+	"url":        func(v *model.Video) string { return v.URL },
+	"duration":   func(v *model.Video) string { return strconv.FormatFloat(v.Duration, 'f', -1, 64) },
+	"status": func(v *model.Video) string {
+		if v.Status == "" {
+			return model.VideoStatusAvailable
+		}
+		return v.Status
+	},
+	"upload_date": func(v *model.Video) string {
+		if v.UploadDate == nil {
+			return ""
+		}
+		return v.UploadDate.Format("2006-01-02")
+	},
+	"rating": func(v *model.Video) string {
+		if v.Rating == nil {
+			return ""
+		}
+		return strconv.Itoa(*v.Rating)
+	},
+	"note": func(v *model.Video) string {
+		if v.Note == nil {
+			return ""
+		}
+		return *v.Note
+	},
+}
+
+// videoColumnNames lists the columns in the order shown in help and errors
+var videoColumnNames = []string{"id", "channel_id", "title", "url", "duration", "status", "upload_date", "rating", "note"}
+
+// videoRowWriter writes videos as CSV or TSV rows with a header line
+type videoRowWriter struct {
+	w       *csv.Writer
+	columns []string
+}
+
+// newVideoRowWriter validates the comma-separated column list and writes the header row
+func newVideoRowWriter(out io.Writer, format, columns string) (*videoRowWriter, error) {
+	w := csv.NewWriter(out)
+	if format == "tsv" {
+		w.Comma = '\t'
+	}
+
+	var names []string
+	for _, name := range strings.Split(columns, ",") {
+		name = strings.TrimSpace(name)
+		if _, ok := videoColumns[name]; !ok {
+			return nil, fmt.Errorf("unknown column %q (supported: %s)", name, strings.Join(videoColumnNames, ", "))
+		}
+		names = append(names, name)
+	}
+
+	if err := w.Write(names); err != nil {
+		return nil, err
+	}
+	return &videoRowWriter{w: w, columns: names}, nil
+}
+
+// WriteVideo writes one video row
+func (r *videoRowWriter) WriteVideo(video *model.Video) error {
+	record := make([]string, len(r.columns))
+	for i, name := range r.columns {
+		record[i] = videoColumns[name](video)
+	}
+	return r.w.Write(record)
+}
+
+// Close flushes buffered rows
+func (r *videoRowWriter) Close() error {
+	r.w.Flush()
+	return r.w.Error()
+}
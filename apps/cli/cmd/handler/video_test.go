@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	statusSvc "github.com/Taichi-iskw/yt-lang/internal/service/status"
+)
+
+// fakeVideos implements VideoLister over a fixed list
+type fakeVideos struct {
+	videos []*model.Video
+}
+
+func (f *fakeVideos) ListVideos(ctx context.Context, channelID string, limit, offset int) ([]*model.Video, error) {
+	return f.videos[min(offset, len(f.videos)):min(offset+limit, len(f.videos))], nil
+}
+
+func (f *fakeVideos) IterateVideos(ctx context.Context, channelID string, fn func(video *model.Video) error) error {
+	for _, video := range f.videos {
+		if err := fn(video); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeVideos) CountVideosByChannel(ctx context.Context, channelID string) (int, error) {
+	return len(f.videos), nil
+}
+
+func (f *fakeVideos) ListRatedVideos(ctx context.Context, channelID string, minRating int) ([]*model.Video, error) {
+	var rated []*model.Video
+	for _, video := range f.videos {
+		if video.Rating != nil && *video.Rating >= minRating {
+			rated = append(rated, video)
+		}
+	}
+	return rated, nil
+}
+
+// fakeStatuses marks every video as fetched
+type fakeStatuses struct{}
+
+func (fakeStatuses) GetVideoStatuses(ctx context.Context, videos []*model.Video) ([]*statusSvc.VideoStatus, error) {
+	statuses := make([]*statusSvc.VideoStatus, len(videos))
+	for i, video := range videos {
+		statuses[i] = &statusSvc.VideoStatus{Video: video, Stage: statusSvc.StageFetched}
+	}
+	return statuses, nil
+}
+
+// fakeTags tags the second video only
+type fakeTags struct{}
+
+func (fakeTags) VideosByTag(ctx context.Context, tag string) ([]*model.Video, error) {
+	if tag != "comma" {
+		return nil, nil
+	}
+	return []*model.Video{{ID: "v2", ChannelID: "UC1", Title: "Second, with comma"}}, nil
+}
+
+func newFakeVideos() *fakeVideos {
+	four := 4
+	return &fakeVideos{videos: []*model.Video{
+		{ID: "v1", ChannelID: "UC1", Title: "First", Duration: 61.5, Rating: &four},
+		{ID: "v2", ChannelID: "UC1", Title: "Second, with comma", Duration: 30},
+	}}
+}
+
+func TestListVideos(t *testing.T) {
+	tests := []struct {
+		name string
+		opts ListVideosOptions
+		want []string
+	}{
+		{
+			name: "json page",
+			opts: ListVideosOptions{ChannelID: "UC1", Limit: 1, Format: "json"},
+			want: []string{"Found 1 video(s) for channel UC1:", `"total": 2`, `"next_offset": 1`, `"id": "v1"`},
+		},
+		{
+			name: "rated",
+			opts: ListVideosOptions{MinRating: 4, Format: "json"},
+			want: []string{"Found 1 video(s) rated 4 or higher:", `"rating": 4`},
+		},
+		{
+			name: "tagged",
+			opts: ListVideosOptions{Tag: "comma", Format: "json", Tags: fakeTags{}},
+			want: []string{`Found 1 video(s) tagged "comma":`, `"total": 1`, `"id": "v2"`},
+		},
+		{
+			name: "tagged rows",
+			opts: ListVideosOptions{Tag: "comma", Format: "tsv", Columns: "id,channel_id", Tags: fakeTags{}},
+			want: []string{"id\tchannel_id\nv2\tUC1\n"},
+		},
+		{
+			name: "with status",
+			opts: ListVideosOptions{ChannelID: "UC1", All: true, Format: "json", Status: fakeStatuses{}},
+			want: []string{"Found 2 video(s)", `"stage": "fetched"`},
+		},
+		{
+			name: "csv rows",
+			opts: ListVideosOptions{ChannelID: "UC1", All: true, Format: "csv", Columns: "id,title"},
+			want: []string{"id,title\nv1,First\nv2,\"Second, with comma\"\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, ListVideos(context.Background(), &out, newFakeVideos(), tt.opts))
+			for _, want := range tt.want {
+				assert.Contains(t, out.String(), want)
+			}
+		})
+	}
+}
+
+func TestListVideos_Empty(t *testing.T) {
+	// Messages go to Info, so an --output file is never written with them
+	var out, info bytes.Buffer
+	require.NoError(t, ListVideos(context.Background(), &out, &fakeVideos{}, ListVideosOptions{ChannelID: "UC9", Limit: 10, Format: "json", Info: &info}))
+	assert.Empty(t, out.String())
+	assert.Equal(t, "No videos found for channel ID: UC9\n", info.String())
+
+	info.Reset()
+	require.NoError(t, ListVideos(context.Background(), &out, &fakeVideos{}, ListVideosOptions{Tag: "paella", Format: "json", Tags: fakeTags{}, Info: &info}))
+	assert.Empty(t, out.String())
+	assert.Equal(t, "No videos tagged \"paella\"\n", info.String())
+}
@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/width"
+
+	studySvc "github.com/Taichi-iskw/yt-lang/internal/service/study"
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
+)
+
+// ViewOriginal selects the transcript itself as a column of View
+const ViewOriginal = "original"
+
+const (
+	DefaultViewWidth = 100 // Terminal columns when the width is unknown
+	minViewColumn    = 12  // Narrower text columns are unreadable
+	viewTimeWidth    = 8   // "00:00:00"
+	viewGap          = "  "
+)
+
+// SheetBuilder collects the transcript of a video with one translation
+type SheetBuilder interface {
+	BuildSheet(ctx context.Context, opts studySvc.SheetOptions) (*studySvc.Sheet, error)
+}
+
+// ViewOptions configures View
+type ViewOptions struct {
+	Language string        // Transcription language; may be empty when the video has one transcription
+	Langs    []string      // The two columns: ViewOriginal or a translation language each
+	From     time.Duration // Skip segments ending before this position
+	Width    int           // Terminal columns; defaults to DefaultViewWidth
+}
+
+// View prints the segments of a video's transcription in two aligned columns, each the transcript
+// or one of its translations, with the start time of each segment. Text is wrapped within its
+// column, counting East Asian wide characters as two columns. Untranslated segments show "-".
+func View(ctx context.Context, out io.Writer, builder SheetBuilder, videoID string, opts ViewOptions) error {
+	if len(opts.Langs) != 2 {
+		return fmt.Errorf("--langs needs two columns, e.g. original,ja (got %d)", len(opts.Langs))
+	}
+	if opts.Width <= 0 {
+		opts.Width = DefaultViewWidth
+	}
+	column := (opts.Width - viewTimeWidth - 2*len(viewGap)) / 2
+	if column < minViewColumn {
+		return fmt.Errorf("terminal width %d is too narrow for two columns", opts.Width)
+	}
+
+	// Each translation column needs its own sheet; the lines of every sheet are the same segments
+	var sheet *studySvc.Sheet
+	columns := make([][]string, 2)
+	for i, lang := range opts.Langs {
+		target := ""
+		if lang != ViewOriginal {
+			target = lang
+		}
+		built, err := builder.BuildSheet(ctx, studySvc.SheetOptions{VideoID: videoID, Language: opts.Language, TargetLanguage: target})
+		if err != nil {
+			return err
+		}
+		sheet = built
+
+		columns[i] = make([]string, len(built.Lines))
+		for n, line := range built.Lines {
+			columns[i][n] = line.Text
+			if target != "" {
+				columns[i][n] = line.Translation
+			}
+		}
+	}
+
+	headers := make([]string, 2)
+	for i, lang := range opts.Langs {
+		headers[i] = strings.ToUpper(lang)
+		if lang == ViewOriginal {
+			headers[i] = strings.ToUpper(sheet.Language)
+		}
+	}
+	fmt.Fprintf(out, "%s  %s\n\n", sheet.Video.Title, sheet.Video.ID)
+	writeViewRow(out, "TIME", headers, column)
+	writeViewRow(out, strings.Repeat("-", viewTimeWidth), []string{strings.Repeat("-", column), strings.Repeat("-", column)}, column)
+
+	shown := 0
+	for n, line := range sheet.Lines {
+		if opts.From > 0 {
+			end, err := timecode.ParseInterval(line.EndTime)
+			if err != nil {
+				return fmt.Errorf("segment %d: %w", n, err)
+			}
+			if end < opts.From {
+				continue
+			}
+		}
+		start, err := timecode.ParseInterval(line.StartTime)
+		if err != nil {
+			return fmt.Errorf("segment %d: %w", n, err)
+		}
+
+		texts := []string{columns[0][n], columns[1][n]}
+		for i := range texts {
+			if texts[i] == "" {
+				texts[i] = "-"
+			}
+		}
+		writeViewRow(out, formatViewTime(start), texts, column)
+		shown++
+	}
+
+	if shown == 0 && len(sheet.Lines) > 0 {
+		fmt.Fprintf(out, "No segments after %s\n", formatViewTime(opts.From))
+	}
+	return nil
+}
+
+// writeViewRow prints a time and two texts wrapped to column, continuation lines indented
+func writeViewRow(out io.Writer, label string, texts []string, column int) {
+	left, right := wrapColumn(texts[0], column), wrapColumn(texts[1], column)
+	for i := 0; i < max(len(left), len(right)); i++ {
+		if i > 0 {
+			label = ""
+		}
+		var l, r string
+		if i < len(left) {
+			l = left[i]
+		}
+		if i < len(right) {
+			r = right[i]
+		}
+		row := padColumn(label, viewTimeWidth) + viewGap + padColumn(l, column) + viewGap + r
+		fmt.Fprintln(out, strings.TrimRight(row, " "))
+	}
+}
+
+// formatViewTime formats a position as HH:MM:SS
+func formatViewTime(d time.Duration) string {
+	total := int(d / time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", total/3600, total/60%60, total%60)
+}
+
+// wrapColumn wraps text into lines of at most column display cells. Lines break at spaces when
+// there are any, otherwise between characters, as in Japanese and Chinese text.
+func wrapColumn(text string, column int) []string {
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return []string{""}
+	}
+
+	var lines []string
+	var line []rune
+	lineWidth, lastSpace := 0, -1
+	for _, r := range text {
+		if lineWidth == 0 && r == ' ' {
+			continue
+		}
+		w := runeWidth(r)
+		if lineWidth+w > column {
+			if r == ' ' {
+				lines = append(lines, string(line))
+				line, lineWidth, lastSpace = nil, 0, -1
+				continue
+			}
+			if lastSpace > 0 {
+				// Move the word being written to the next line
+				lines = append(lines, string(line[:lastSpace]))
+				line = append([]rune{}, line[lastSpace+1:]...)
+			} else {
+				lines = append(lines, string(line))
+				line = nil
+			}
+			lineWidth, lastSpace = stringWidth(string(line)), -1
+		}
+		if r == ' ' {
+			lastSpace = len(line)
+		}
+		line = append(line, r)
+		lineWidth += w
+	}
+	return append(lines, string(line))
+}
+
+// padColumn pads s with spaces to column display cells
+func padColumn(s string, column int) string {
+	if pad := column - stringWidth(s); pad > 0 {
+		return s + strings.Repeat(" ", pad)
+	}
+	return s
+}
+
+// stringWidth returns the display cells of s in a terminal
+func stringWidth(s string) int {
+	total := 0
+	for _, r := range s {
+		total += runeWidth(r)
+	}
+	return total
+}
+
+// runeWidth returns the display cells of r: two for East Asian wide and fullwidth characters,
+// none for combining marks
+func runeWidth(r rune) int {
+	if unicode.Is(unicode.Mn, r) {
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	studySvc "github.com/Taichi-iskw/yt-lang/internal/service/study"
+)
+
+// fakeSheetBuilder returns the lines of a fixed transcript with the translations of the requested language
+type fakeSheetBuilder struct {
+	translations map[string][]string
+}
+
+func (f *fakeSheetBuilder) BuildSheet(ctx context.Context, opts studySvc.SheetOptions) (*studySvc.Sheet, error) {
+	texts := []string{"Hola a todos.", "Hoy vamos a cocinar una paella valenciana con arroz bomba.", "Vamos."}
+	times := [][2]string{{"00:00:01.000", "00:00:03.000"}, {"00:00:03.000", "00:00:09.500"}, {"01:02:03.000", "01:02:04.000"}}
+	sheet := &studySvc.Sheet{Video: &model.Video{ID: "video1", Title: "Paella"}, Language: "es", TargetLanguage: opts.TargetLanguage}
+	for i, text := range texts {
+		line := studySvc.SheetLine{StartTime: times[i][0], EndTime: times[i][1], Text: text}
+		if translated := f.translations[opts.TargetLanguage]; i < len(translated) {
+			line.Translation = translated[i]
+		}
+		sheet.Lines = append(sheet.Lines, line)
+	}
+	return sheet, nil
+}
+
+func TestView(t *testing.T) {
+	builder := &fakeSheetBuilder{translations: map[string][]string{
+		"ja": {"皆さんこんにちは。", "今日はボンバ米でバレンシア風パエリアを作ります。"},
+	}}
+
+	var out bytes.Buffer
+	require.NoError(t, View(context.Background(), &out, builder, "video1", ViewOptions{Langs: []string{ViewOriginal, "ja"}, Width: 60}))
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+
+	assert.Equal(t, "Paella  video1", lines[0])
+	assert.Equal(t, "TIME      ES                        JA", lines[2])
+	assert.Equal(t, "00:00:01  Hola a todos.             皆さんこんにちは。", lines[4])
+	// Words wrap at spaces, Japanese between characters at 24 display cells
+	assert.Equal(t, "00:00:03  Hoy vamos a cocinar una   今日はボンバ米でバレンシ", lines[5])
+	assert.Equal(t, "          paella valenciana con     ア風パエリアを作ります。", lines[6])
+	assert.Equal(t, "          arroz bomba.", lines[7])
+	// Untranslated segments show a dash
+	assert.Equal(t, "01:02:03  Vamos.                    -", lines[8])
+
+	t.Run("from skips segments that ended", func(t *testing.T) {
+		out.Reset()
+		require.NoError(t, View(context.Background(), &out, builder, "video1", ViewOptions{Langs: []string{ViewOriginal, "ja"}, From: time.Hour}))
+		assert.NotContains(t, out.String(), "Hola")
+		assert.Contains(t, out.String(), "01:02:03  Vamos.")
+	})
+
+	t.Run("needs two columns", func(t *testing.T) {
+		err := View(context.Background(), &out, builder, "video1", ViewOptions{Langs: []string{"ja"}})
+		assert.Error(t, err)
+	})
+}
+
+func TestWrapColumn(t *testing.T) {
+	assert.Equal(t, []string{"one two", "three"}, wrapColumn("one  two three", 8))
+	assert.Equal(t, []string{"abcdefgh", "ij"}, wrapColumn("abcdefghij", 8))
+	assert.Equal(t, []string{"日本語の", "文"}, wrapColumn("日本語の文", 8))
+	assert.Equal(t, []string{""}, wrapColumn("  ", 8))
+	assert.Equal(t, 4, stringWidth("日本"))
+}
@@ -0,0 +1,288 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/schedule"
+	youtubeSvc "github.com/Taichi-iskw/yt-lang/internal/service/youtube"
+)
+
+// DefaultWatchPoll is how often the watch daemon checks for due channels
+const DefaultWatchPoll = time.Minute
+
+// ScheduleStore reads channel sync schedules and records their runs
+type ScheduleStore interface {
+	List(ctx context.Context) ([]*model.ChannelSchedule, error)
+	RecordRun(ctx context.Context, channelID string, at time.Time, errorMessage *string) error
+}
+
+// HeartbeatStore records the heartbeats of watch daemons and reads them back
+type HeartbeatStore interface {
+	Beat(ctx context.Context, heartbeat *model.WatchHeartbeat) error
+	List(ctx context.Context) ([]*model.WatchHeartbeat, error)
+}
+
+// ChannelSyncer saves the new videos of a channel
+type ChannelSyncer interface {
+	SaveChannelVideos(ctx context.Context, channelID string, opts youtubeSvc.FetchOptions) ([]*model.Video, error)
+}
+
+// ScheduledChannel is a channel schedule with its next run
+type ScheduledChannel struct {
+	*model.ChannelSchedule
+	NextRunAt *time.Time `json:"next_run_at"` // nil when the schedule never runs again
+	Due       bool       `json:"due"`
+	Invalid   string     `json:"invalid,omitempty"` // Why the spec could not be parsed
+}
+
+// ScheduledChannels computes the next run of every channel schedule at now
+func ScheduledChannels(ctx context.Context, store ScheduleStore, now time.Time) ([]*ScheduledChannel, error) {
+	schedules, err := store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list channel schedules: %w", err)
+	}
+
+	channels := make([]*ScheduledChannel, len(schedules))
+	for i, s := range schedules {
+		channels[i] = &ScheduledChannel{ChannelSchedule: s}
+		parsed, err := schedule.Parse(s.Spec)
+		if err != nil {
+			channels[i].Invalid = err.Error()
+			continue
+		}
+		if next := schedule.NextRun(parsed, s.LastRunAt, now); !next.IsZero() {
+			channels[i].NextRunAt = &next
+			channels[i].Due = !next.After(now)
+		}
+	}
+	return channels, nil
+}
+
+// WatchStatus prints every scheduled channel with its last and next run
+func WatchStatus(ctx context.Context, out io.Writer, store ScheduleStore, now time.Time, format string) error {
+	channels, err := ScheduledChannels(ctx, store, now)
+	if err != nil {
+		return err
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(channels, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format as JSON: %w", err)
+		}
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	}
+	if len(channels) == 0 {
+		fmt.Fprintln(out, "No channels scheduled; schedule one with channel schedule CHANNEL_ID --every 12h")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHANNEL\tSCHEDULE\tLAST RUN\tRESULT\tNEXT RUN\tNAME")
+	for _, c := range channels {
+		last, result := "never", "-"
+		if c.LastRunAt != nil {
+			last, result = c.LastRunAt.Local().Format("2006-01-02 15:04"), "ok"
+			if c.LastError != nil {
+				result = "failed: " + Truncate(*c.LastError, 40)
+			}
+		}
+
+		next := "never"
+		switch {
+		case c.Invalid != "":
+			next = "invalid schedule"
+		case c.Due:
+			next = "due"
+		case c.NextRunAt != nil:
+			next = c.NextRunAt.Local().Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", c.ChannelID, c.Spec, last, result, next, c.ChannelName)
+	}
+	return w.Flush()
+}
+
+// SyncDueChannels syncs the channels whose schedule is due at now, one at a time, and records
+// each run. A failing channel is reported and recorded; the others still sync.
+func SyncDueChannels(ctx context.Context, out io.Writer, store ScheduleStore, syncer ChannelSyncer, now time.Time) (int, error) {
+	synced, _, err := syncDueChannels(ctx, out, store, syncer, now)
+	return synced, err
+}
+
+// syncDueChannels implements SyncDueChannels, also returning how many channels were due
+func syncDueChannels(ctx context.Context, out io.Writer, store ScheduleStore, syncer ChannelSyncer, now time.Time) (synced, due int, err error) {
+	channels, err := ScheduledChannels(ctx, store, now)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, c := range channels {
+		if !c.Due {
+			continue
+		}
+		due++
+
+		started := time.Now()
+		opts := youtubeSvc.FetchOptions{OnChanged: func(changes []*model.VideoChange) {
+			PrintChangedVideos(out, changes)
+		}}
+		videos, err := syncer.SaveChannelVideos(ctx, c.ChannelID, opts)
+		var message *string
+		if err != nil {
+			text := err.Error()
+			message = &text
+			fmt.Fprintf(out, "%s  ❌ %s: %v\n", started.Local().Format("2006-01-02 15:04:05"), c.ChannelID, err)
+		} else {
+			synced++
+			fmt.Fprintf(out, "%s  ✅ %s: %d video(s) fetched\n", started.Local().Format("2006-01-02 15:04:05"), c.ChannelID, len(videos))
+		}
+		if err := store.RecordRun(ctx, c.ChannelID, started, message); err != nil {
+			return synced, due, fmt.Errorf("failed to record sync of channel %s: %w", c.ChannelID, err)
+		}
+	}
+	return synced, due, nil
+}
+
+// NewHeartbeat returns the heartbeat of a watch daemon of this process started at started
+func NewHeartbeat(started time.Time) *model.WatchHeartbeat {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return &model.WatchHeartbeat{Host: host, PID: os.Getpid(), StartedAt: started}
+}
+
+// WatchCheck syncs the channels due at now like SyncDueChannels, then records the daemon's
+// heartbeat. Failing to record the heartbeat only warns, so monitoring never stops syncs.
+func WatchCheck(ctx context.Context, out io.Writer, store ScheduleStore, syncer ChannelSyncer, heartbeats HeartbeatStore, heartbeat *model.WatchHeartbeat, now time.Time) (int, error) {
+	synced, due, err := syncDueChannels(ctx, out, store, syncer, now)
+	if err != nil {
+		return synced, err
+	}
+
+	heartbeat.LastLoopAt = now
+	heartbeat.QueueDepth = due
+	if err := heartbeats.Beat(ctx, heartbeat); err != nil && ctx.Err() == nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record watch heartbeat: %v\n", err)
+	}
+	return synced, nil
+}
+
+// Watch syncs due channels every poll interval until ctx is done, recording a heartbeat after
+// every check
+func Watch(ctx context.Context, out io.Writer, store ScheduleStore, syncer ChannelSyncer, heartbeats HeartbeatStore, poll time.Duration) error {
+	if poll <= 0 {
+		poll = DefaultWatchPoll
+	}
+
+	heartbeat := NewHeartbeat(time.Now())
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+	for {
+		if _, err := WatchCheck(ctx, out, store, syncer, heartbeats, heartbeat, time.Now()); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// WatchHealthReport is the health of the watch daemons of a workspace
+type WatchHealthReport struct {
+	LastLoopAt *time.Time              `json:"last_loop_at"` // Most recent heartbeat; nil when no daemon ever checked
+	Stale      bool                    `json:"stale"`        // The last loop is older than the allowed staleness
+	QueueDepth int                     `json:"queue_depth"`  // Channels due now, waiting for the next check
+	Daemons    []*model.WatchHeartbeat `json:"daemons"`
+	Channels   []*ScheduledChannel     `json:"channels"`
+}
+
+// WatchHealth prints when the watch daemons last checked for due channels, how many channels
+// are due now and the last success and error of every scheduled channel. With maxStaleness,
+// it fails when no daemon checked within maxStaleness of now, for monitoring from cron or
+// systemd.
+func WatchHealth(ctx context.Context, out io.Writer, heartbeats HeartbeatStore, store ScheduleStore, now time.Time, maxStaleness time.Duration, format string) error {
+	daemons, err := heartbeats.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list watch heartbeats: %w", err)
+	}
+	channels, err := ScheduledChannels(ctx, store, now)
+	if err != nil {
+		return err
+	}
+
+	report := &WatchHealthReport{Daemons: daemons, Channels: channels}
+	for _, d := range daemons {
+		if report.LastLoopAt == nil || d.LastLoopAt.After(*report.LastLoopAt) {
+			report.LastLoopAt = &d.LastLoopAt
+		}
+	}
+	for _, c := range channels {
+		if c.Due {
+			report.QueueDepth++
+		}
+	}
+	report.Stale = maxStaleness > 0 && (report.LastLoopAt == nil || now.Sub(*report.LastLoopAt) > maxStaleness)
+
+	if format == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format as JSON: %w", err)
+		}
+		if _, err := fmt.Fprintln(out, string(data)); err != nil {
+			return err
+		}
+	} else {
+		writeWatchHealth(out, report, now)
+	}
+
+	if report.Stale {
+		if report.LastLoopAt == nil {
+			return fmt.Errorf("no watch daemon has checked for due channels")
+		}
+		return fmt.Errorf("watch daemon is stale: last loop %s ago (max %s)", now.Sub(*report.LastLoopAt).Round(time.Second), maxStaleness)
+	}
+	return nil
+}
+
+// writeWatchHealth prints a health report as text
+func writeWatchHealth(out io.Writer, report *WatchHealthReport, now time.Time) {
+	if len(report.Daemons) == 0 {
+		fmt.Fprintln(out, "Last loop:  never (start the daemon with watch run)")
+	}
+	for _, d := range report.Daemons {
+		fmt.Fprintf(out, "Last loop:  %s (%s ago) on %s, pid %d, running since %s\n",
+			d.LastLoopAt.Local().Format("2006-01-02 15:04:05"), now.Sub(d.LastLoopAt).Round(time.Second),
+			d.Host, d.PID, d.StartedAt.Local().Format("2006-01-02 15:04"))
+	}
+	fmt.Fprintf(out, "Queue:      %d channel(s) due\n", report.QueueDepth)
+	if len(report.Channels) == 0 {
+		return
+	}
+
+	fmt.Fprintln(out)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHANNEL\tLAST SUCCESS\tLAST ERROR\tNAME")
+	for _, c := range report.Channels {
+		success, failure := "never", "-"
+		if c.LastSuccessAt != nil {
+			success = c.LastSuccessAt.Local().Format("2006-01-02 15:04")
+		}
+		if c.LastError != nil && c.LastRunAt != nil {
+			failure = c.LastRunAt.Local().Format("2006-01-02 15:04") + " " + Truncate(*c.LastError, 40)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.ChannelID, success, failure, c.ChannelName)
+	}
+	w.Flush()
+}
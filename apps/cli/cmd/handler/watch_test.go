@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	youtubeSvc "github.com/Taichi-iskw/yt-lang/internal/service/youtube"
+)
+
+// fakeScheduleStore implements ScheduleStore and remembers recorded runs
+type fakeScheduleStore struct {
+	schedules []*model.ChannelSchedule
+	recorded  map[string]*string
+}
+
+func (f *fakeScheduleStore) List(ctx context.Context) ([]*model.ChannelSchedule, error) {
+	return f.schedules, nil
+}
+
+func (f *fakeScheduleStore) RecordRun(ctx context.Context, channelID string, at time.Time, errorMessage *string) error {
+	if f.recorded == nil {
+		f.recorded = map[string]*string{}
+	}
+	f.recorded[channelID] = errorMessage
+	return nil
+}
+
+// fakeChannelSyncer fails for the channels in failing
+type fakeChannelSyncer struct {
+	failing map[string]bool
+	synced  []string
+}
+
+func (f *fakeChannelSyncer) SaveChannelVideos(ctx context.Context, channelID string, opts youtubeSvc.FetchOptions) ([]*model.Video, error) {
+	f.synced = append(f.synced, channelID)
+	if f.failing[channelID] {
+		return nil, errors.New("yt-dlp failed")
+	}
+	return []*model.Video{{ID: "video1"}}, nil
+}
+
+func newFakeScheduleStore(now time.Time) *fakeScheduleStore {
+	recent := now.Add(-time.Hour)
+	old := now.Add(-13 * time.Hour)
+	failure := "yt-dlp failed"
+	return &fakeScheduleStore{schedules: []*model.ChannelSchedule{
+		{ChannelID: "UCfresh", ChannelName: "Fresh", Spec: "@every 12h", LastRunAt: &recent},
+		{ChannelID: "UCnew", ChannelName: "New", Spec: "@every 12h"},
+		{ChannelID: "UCold", ChannelName: "Old", Spec: "@every 12h", LastRunAt: &old, LastError: &failure},
+		{ChannelID: "UCbroken", ChannelName: "Broken", Spec: "every day"},
+	}}
+}
+
+func TestWatchStatus(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	store := newFakeScheduleStore(now)
+
+	var out bytes.Buffer
+	require.NoError(t, WatchStatus(context.Background(), &out, store, now, "table"))
+	lastRun := now.Add(-time.Hour).Local().Format("2006-01-02 15:04")
+	nextRun := now.Add(11 * time.Hour).Local().Format("2006-01-02 15:04")
+	assert.Contains(t, out.String(), "UCfresh   @every 12h  "+lastRun)
+	assert.Contains(t, out.String(), "ok                     "+nextRun+"  Fresh")
+	assert.Contains(t, out.String(), "UCnew     @every 12h  never")
+	assert.Contains(t, out.String(), "failed: yt-dlp failed  due")
+	assert.Contains(t, out.String(), "invalid schedule")
+
+	out.Reset()
+	require.NoError(t, WatchStatus(context.Background(), &out, store, now, "json"))
+	assert.Contains(t, out.String(), `"channel_id": "UCold"`)
+	assert.Contains(t, out.String(), `"due": true`)
+
+	out.Reset()
+	require.NoError(t, WatchStatus(context.Background(), &out, &fakeScheduleStore{}, now, "table"))
+	assert.Contains(t, out.String(), "No channels scheduled")
+}
+
+func TestSyncDueChannels(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	store := newFakeScheduleStore(now)
+	syncer := &fakeChannelSyncer{failing: map[string]bool{"UCold": true}}
+
+	var out bytes.Buffer
+	synced, err := SyncDueChannels(context.Background(), &out, store, syncer, now)
+	require.NoError(t, err)
+
+	// Recently synced and invalid schedules are skipped; a failure does not stop the others
+	assert.Equal(t, []string{"UCnew", "UCold"}, syncer.synced)
+	assert.Equal(t, 1, synced)
+	require.Contains(t, store.recorded, "UCnew")
+	assert.Nil(t, store.recorded["UCnew"])
+	require.NotNil(t, store.recorded["UCold"])
+	assert.Equal(t, "yt-dlp failed", *store.recorded["UCold"])
+	assert.Contains(t, out.String(), "UCnew: 1 video(s) fetched")
+	assert.Contains(t, out.String(), "UCold: yt-dlp failed")
+}
+
+// fakeHeartbeatStore implements HeartbeatStore and remembers recorded heartbeats
+type fakeHeartbeatStore struct {
+	heartbeats []*model.WatchHeartbeat
+}
+
+func (f *fakeHeartbeatStore) Beat(ctx context.Context, heartbeat *model.WatchHeartbeat) error {
+	copied := *heartbeat
+	f.heartbeats = append(f.heartbeats, &copied)
+	return nil
+}
+
+func (f *fakeHeartbeatStore) List(ctx context.Context) ([]*model.WatchHeartbeat, error) {
+	return f.heartbeats, nil
+}
+
+func TestWatchCheck(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	store := newFakeScheduleStore(now)
+	heartbeats := &fakeHeartbeatStore{}
+	heartbeat := NewHeartbeat(now.Add(-time.Hour))
+
+	synced, err := WatchCheck(context.Background(), &bytes.Buffer{}, store, &fakeChannelSyncer{}, heartbeats, heartbeat, now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, synced)
+
+	// The heartbeat records the channels that were due when the check started
+	require.Len(t, heartbeats.heartbeats, 1)
+	assert.Equal(t, 2, heartbeats.heartbeats[0].QueueDepth)
+	assert.Equal(t, now.Add(-time.Hour), heartbeats.heartbeats[0].StartedAt)
+	assert.Equal(t, now, heartbeats.heartbeats[0].LastLoopAt)
+	assert.NotEmpty(t, heartbeats.heartbeats[0].Host)
+}
+
+func TestWatchHealth(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	store := newFakeScheduleStore(now)
+	success := now.Add(-25 * time.Hour)
+	store.schedules[2].LastSuccessAt = &success
+	heartbeats := &fakeHeartbeatStore{heartbeats: []*model.WatchHeartbeat{
+		{Host: "host1", PID: 1234, StartedAt: now.Add(-time.Hour), LastLoopAt: now.Add(-10 * time.Minute), QueueDepth: 1},
+	}}
+
+	var out bytes.Buffer
+	require.NoError(t, WatchHealth(context.Background(), &out, heartbeats, store, now, 0, "table"))
+	assert.Contains(t, out.String(), "(10m0s ago) on host1, pid 1234")
+	assert.Contains(t, out.String(), "Queue:      2 channel(s) due")
+	assert.Contains(t, out.String(), "UCold     "+success.Local().Format("2006-01-02 15:04"))
+	assert.Contains(t, out.String(), "yt-dlp failed  Old")
+	assert.Contains(t, out.String(), "UCnew     never")
+
+	t.Run("max staleness", func(t *testing.T) {
+		out.Reset()
+		assert.NoError(t, WatchHealth(context.Background(), &out, heartbeats, store, now, 15*time.Minute, "table"))
+
+		out.Reset()
+		err := WatchHealth(context.Background(), &out, heartbeats, store, now, 5*time.Minute, "json")
+		assert.ErrorContains(t, err, "last loop 10m0s ago (max 5m0s)")
+		assert.Contains(t, out.String(), `"stale": true`)
+		assert.Contains(t, out.String(), `"queue_depth": 2`)
+	})
+
+	t.Run("no daemon", func(t *testing.T) {
+		out.Reset()
+		require.NoError(t, WatchHealth(context.Background(), &out, &fakeHeartbeatStore{}, store, now, 0, "table"))
+		assert.Contains(t, out.String(), "Last loop:  never")
+
+		err := WatchHealth(context.Background(), &out, &fakeHeartbeatStore{}, store, now, time.Hour, "table")
+		assert.ErrorContains(t, err, "no watch daemon has checked")
+	})
+}
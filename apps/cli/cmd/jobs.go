@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/job"
+)
+
+// jobsCmd represents the jobs command
+var jobsCmd = &cobra.Command{
+	Use:   "jobs",
+	Short: "Manage the workspaces of interrupted jobs",
+	Long: `Long-running jobs such as transcription create keep their work in a workspace under
+~/.yt-lang/work: the files each step produces and a job.json manifest of the steps that
+completed. A job interrupted by a crash or Ctrl-C resumes after its last completed step when run
+again with the same arguments.
+Workspaces of finished jobs stay until jobs clean removes them.`,
+}
+
+// jobsListCmd lists the job workspaces
+var jobsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List job workspaces with the last step each completed",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workspaces, err := newJobWorkspaces()
+		if err != nil {
+			return err
+		}
+		return handler.ListJobs(cmd.OutOrStdout(), workspaces)
+	},
+}
+
+// jobsCleanCmd removes the workspaces of finished jobs
+var jobsCleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Remove the workspaces of finished jobs",
+	Long: `Remove the workspaces of finished jobs and of jobs that crashed before writing a manifest.
+With --all the workspaces of unfinished jobs are removed too; those jobs start over when run again.
+
+Examples:
+  yt-lang jobs clean
+  yt-lang jobs clean --all`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+
+		workspaces, err := newJobWorkspaces()
+		if err != nil {
+			return err
+		}
+		return handler.CleanJobs(cmd.OutOrStdout(), workspaces, all)
+	},
+}
+
+// newJobWorkspaces returns the job workspaces under ~/.yt-lang/work
+func newJobWorkspaces() (*job.Workspaces, error) {
+	workDir, err := config.GetWorkDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve work directory: %w", err)
+	}
+	return job.NewWorkspaces(workDir), nil
+}
+
+func init() {
+	jobsCleanCmd.Flags().Bool("all", false, "Also remove the workspaces of unfinished jobs")
+
+	jobsCmd.AddCommand(jobsListCmd)
+	jobsCmd.AddCommand(jobsCleanCmd)
+	rootCmd.AddCommand(jobsCmd)
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/i18n"
+)
+
+// langFlag selects the language of help text and messages, overriding config and YTLANG_LANG
+var langFlag string
+
+// setupLanguage selects the CLI language before cobra parses the command line, so help output
+// is localized too. Cobra parses --lang again later; this only peeks at it.
+func setupLanguage(args []string) error {
+	lang, err := config.ResolveCLILanguage(langFromArgs(args))
+	if err != nil || lang == "" {
+		return err
+	}
+	return i18n.SetLanguage(lang)
+}
+
+// langFromArgs returns the value of --lang in args, if any
+func langFromArgs(args []string) string {
+	for i, arg := range args {
+		switch {
+		case arg == "--":
+			return ""
+		case strings.HasPrefix(arg, "--lang="):
+			return strings.TrimPrefix(arg, "--lang=")
+		case arg == "--lang" && i+1 < len(args):
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// localizeCommands translates the short descriptions, global flags and usage template of the
+// command tree. Messages are keyed by command path, e.g. help.video.list.
+func localizeCommands(root *cobra.Command, args []string) {
+	if i18n.Language() == i18n.DefaultLanguage {
+		return
+	}
+
+	// Cobra adds these lazily when executing; add them now so they are translated too
+	root.InitDefaultHelpCmd()
+	root.InitDefaultCompletionCmd(args...)
+
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		c.Short = i18n.T(helpID(c), c.Short)
+		if c.Long != "" {
+			c.Long = i18n.T(helpID(c)+".long", c.Long)
+		}
+		c.InitDefaultHelpFlag()
+		if help := c.Flags().Lookup("help"); help != nil {
+			help.Usage = i18n.Tf("flag.help", "help for {{.Command}}", map[string]any{"Command": c.Name()})
+		}
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	walk(root)
+
+	root.PersistentFlags().VisitAll(func(f *pflag.Flag) {
+		f.Usage = i18n.T("flag."+f.Name, f.Usage)
+	})
+	root.SetUsageTemplate(localizeUsageTemplate(root.UsageTemplate()))
+}
+
+// helpID is the message ID of a command's short description
+func helpID(c *cobra.Command) string {
+	if !c.HasParent() {
+		return "help.root"
+	}
+	path := strings.TrimPrefix(c.CommandPath(), c.Root().Name()+" ")
+	return "help." + strings.ReplaceAll(path, " ", ".")
+}
+
+// localizeUsageTemplate translates the headings of cobra's usage template
+func localizeUsageTemplate(template string) string {
+	headings := []struct{ id, text string }{
+		{"usage.usage", "Usage:"},
+		{"usage.aliases", "Aliases:"},
+		{"usage.examples", "Examples:"},
+		{"usage.commands", "Available Commands:"},
+		{"usage.additional_commands", "Additional Commands:"},
+		{"usage.flags", "Flags:"},
+		{"usage.global_flags", "Global Flags:"},
+		{"usage.help_topics", "Additional help topics:"},
+	}
+	// Headings start a line; matching the newline keeps "Flags:" from matching "Global Flags:"
+	template = "\n" + template
+	for _, h := range headings {
+		template = strings.ReplaceAll(template, "\n"+h.text, "\n"+i18n.T(h.id, h.text))
+	}
+	template = strings.TrimPrefix(template, "\n")
+
+	// The placeholder is filled by cobra, not by the translation
+	more := `Use "{{.CommandPath}} [command] --help" for more information about a command.`
+	return strings.Replace(template, more,
+		i18n.Tf("usage.more", more, map[string]any{"CommandPath": "{{.CommandPath}}"}), 1)
+}
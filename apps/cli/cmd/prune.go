@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/janitor"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+	"github.com/Taichi-iskw/yt-lang/internal/service/prune"
+)
+
+// pruneCmd removes old untouched videos and their cached artifacts
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove old untouched videos and their cached artifacts by retention policy",
+	Long: `Remove videos uploaded longer ago than --older-than (90d, 6w, 18mo, 2y) that were never
+rated or annotated, together with their cached audio and, when transcribed videos are pruned,
+their transcriptions and raw whisper output. Videos of unknown upload date are kept.
+The defaults come from retention in config.yaml (older_than: 2y, keep_transcribed: true).
+Use --dry-run to see the videos and space that would be reclaimed.
+
+Examples:
+  yt-lang prune --dry-run
+  yt-lang prune --older-than 2y --keep-transcribed=false --force`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		force, _ := cmd.Flags().GetBool("force")
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		olderThan := cfg.Retention.OlderThan
+		if cmd.Flags().Changed("older-than") || olderThan == "" {
+			olderThan, _ = cmd.Flags().GetString("older-than")
+		}
+		keepTranscribed := cfg.Retention.KeepTranscribedVideos()
+		if cmd.Flags().Changed("keep-transcribed") {
+			keepTranscribed, _ = cmd.Flags().GetBool("keep-transcribed")
+		}
+
+		before, err := prune.Cutoff(olderThan, time.Now())
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		store, err := config.NewArtifactStore(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to open artifact store: %w", err)
+		}
+
+		service := prune.NewService(video.NewRepository(dbPool), transcription.NewRepository(dbPool), store)
+		opts := prune.Options{Before: before, KeepTranscribed: keepTranscribed, DryRun: true}
+
+		plan, err := service.Prune(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to find prunable videos: %w", err)
+		}
+		if len(plan.Videos) == 0 {
+			fmt.Printf("No untouched videos uploaded before %s\n", before.Format("2006-01-02"))
+			return nil
+		}
+
+		for _, candidate := range plan.Videos {
+			fmt.Printf("%s  %s  %s\n", candidate.Video.ID, candidate.Video.UploadDate.Format("2006-01-02"), candidate.Video.Title)
+		}
+		if dryRun {
+			printPruneSummary("Would remove", plan)
+			return nil
+		}
+
+		if !force {
+			fmt.Printf("Are you sure you want to remove these %d video(s)? [y/N]: ", len(plan.Videos))
+			var response string
+			fmt.Scanln(&response)
+			if response != "y" && response != "Y" {
+				fmt.Println("Prune cancelled.")
+				return nil
+			}
+		}
+
+		opts.DryRun = false
+		result, err := service.Prune(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to prune videos: %w", err)
+		}
+		printPruneSummary("Removed", result)
+		return nil
+	},
+}
+
+// printPruneSummary prints the rows and bytes reclaimed by a prune run
+func printPruneSummary(verb string, result *prune.Result) {
+	fmt.Printf("%s %d video(s) and %d transcription(s), %d artifact(s) freeing %s\n",
+		verb, len(result.Videos), result.Transcriptions, result.Artifacts, janitor.FormatSize(result.Bytes))
+}
+
+func init() {
+	pruneCmd.Flags().String("older-than", prune.DefaultOlderThan, "Remove videos uploaded longer ago than this (d, w, mo or y)")
+	pruneCmd.Flags().Bool("keep-transcribed", true, "Keep videos that have a transcription")
+	pruneCmd.Flags().Bool("dry-run", false, "List what would be removed without removing it")
+	pruneCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+	rootCmd.AddCommand(pruneCmd)
+}
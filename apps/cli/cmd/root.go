@@ -4,30 +4,112 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/offline"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/workspace"
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
+)
+
+// workspaceFlag selects the workspace for one command, overriding config and YTLANG_WORKSPACE
+var workspaceFlag string
+
+// networkFlags holds the global network flags, which override the config file's network section
+var networkFlags ytdlp.NetworkOptions
+
+// offlineFlag makes operations that need the network fail fast
+var offlineFlag bool
+
+// debugFlag and traceFileFlag enable the trace of external command invocations
+var (
+	debugFlag     bool
+	traceFileFlag string
 )
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "yt-lang",
-	Short: "A brief description of your application",
-	Long: `A longer description that spans multiple lines and likely contains
-examples and usage of using your application. For example:
+	Short: "Collect, transcribe and translate YouTube videos for language learning",
+	Long: `yt-lang saves YouTube channels and their videos, transcribes them with whisper and
+translates the transcriptions with PLaMo, so they can be studied side by side.
 
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
+Help text and messages are shown in English, Japanese or Spanish (--lang, YTLANG_LANG
+or lang in the config file).`,
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	// Run: func(cmd *cobra.Command, args []string) { },
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if workspaceFlag != "" {
+			if err := workspace.ValidateName(workspaceFlag); err != nil {
+				return err
+			}
+			config.SetWorkspaceOverride(workspaceFlag)
+		}
+
+		network, err := config.ResolveNetworkOptions(networkFlags)
+		if err != nil {
+			return err
+		}
+		ytdlp.SetNetworkOptions(network)
+
+		offlineMode, err := config.ResolveOffline(offlineFlag)
+		if err != nil {
+			return err
+		}
+		offline.Set(offlineMode)
+
+		toolPaths, err := config.ResolveToolPaths()
+		if err != nil {
+			return err
+		}
+		if err := common.SetToolPaths(toolPaths); err != nil {
+			return fmt.Errorf("invalid tools in config file: %w", err)
+		}
+
+		if err := setupTrace(); err != nil {
+			return err
+		}
+
+		startupCleanup(cmd)
+		return nil
+	},
+}
+
+// setupTrace traces every external command (yt-dlp, whisper, ffmpeg, PLaMo) as JSON lines:
+// to --trace-file when given, otherwise to stderr with --debug
+func setupTrace() error {
+	switch {
+	case traceFileFlag != "":
+		// Appended to so several runs can share a trace; closed when the process exits
+		file, err := os.OpenFile(traceFileFlag, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to open trace file: %w", err)
+		}
+		common.SetTraceWriter(file)
+	case debugFlag:
+		common.SetTraceWriter(os.Stderr)
+	}
+	return nil
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	err := rootCmd.Execute()
+	if err := setupLanguage(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	localizeCommands(rootCmd, os.Args[1:])
+
+	started := time.Now()
+	executed, err := rootCmd.ExecuteC()
+	recordUsage(executed, started, err)
 	if err != nil {
 		os.Exit(1)
 	}
@@ -39,6 +121,15 @@ func init() {
 	// will be global for your application.
 
 	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.yt-lang.yaml)")
+	rootCmd.PersistentFlags().StringVar(&workspaceFlag, "workspace", "", "Workspace to use for this command (overrides config and YTLANG_WORKSPACE)")
+	rootCmd.PersistentFlags().StringVar(&networkFlags.Proxy, "proxy", "", "Proxy URL for yt-dlp (http, https, socks4, socks5), e.g. socks5://127.0.0.1:1080")
+	rootCmd.PersistentFlags().BoolVar(&networkFlags.ForceIPv4, "force-ipv4", false, "Make yt-dlp connect over IPv4 only")
+	rootCmd.PersistentFlags().BoolVar(&networkFlags.ForceIPv6, "force-ipv6", false, "Make yt-dlp connect over IPv6 only")
+	rootCmd.PersistentFlags().StringVar(&networkFlags.UserAgent, "user-agent", "", "Custom user agent for yt-dlp requests")
+	rootCmd.PersistentFlags().BoolVar(&offlineFlag, "offline", false, "Fail operations that need the network (yt-dlp, APIs, remote storage) instead of trying; database and cached audio keep working")
+	rootCmd.PersistentFlags().BoolVar(&debugFlag, "debug", false, "Trace external commands (binary, args, duration, exit status, output size) to stderr as JSON lines")
+	rootCmd.PersistentFlags().StringVar(&langFlag, "lang", "", "Language of help text and messages: en, ja or es (overrides config and YTLANG_LANG)")
+	rootCmd.PersistentFlags().StringVar(&traceFileFlag, "trace-file", "", "Append the external command trace to this file instead of stderr")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/run"
+)
+
+// runsCmd represents the runs command
+var runsCmd = &cobra.Command{
+	Use:   "runs",
+	Short: "Inspect batch runs",
+	Long: `Every transcription create-batch invocation is recorded as a run: the videos it processes and
+the status, timing and transcription or error of each. Runs interrupted mid-video stay running.
+Resume a run's failed and unfinished videos with transcription create-batch --resume RUN_ID.`,
+}
+
+// runsListCmd lists the most recent batch runs
+var runsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recent batch runs",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limit, _ := cmd.Flags().GetInt("limit")
+		format, err := runsFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		repo, closePool, err := newRunRepository(ctx)
+		if err != nil {
+			return err
+		}
+		defer closePool()
+
+		return handler.ListRuns(ctx, cmd.OutOrStdout(), repo, limit, format)
+	},
+}
+
+// runsShowCmd shows a batch run with its items
+var runsShowCmd = &cobra.Command{
+	Use:   "show [RUN_ID]",
+	Short: "Show a batch run with the outcome of each item",
+	Long: `Show a batch run with the status, time taken and transcription or error of each video.
+--format json writes the full run manifest, e.g. to keep it with the results of a long batch.
+
+Examples:
+  yt-lang runs show 3f2b8c1e-5d4a-4c1b-9e7f-0a6d2c8b4e91
+  yt-lang runs show 3f2b8c1e-5d4a-4c1b-9e7f-0a6d2c8b4e91 --format json > run.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := runsFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		repo, closePool, err := newRunRepository(ctx)
+		if err != nil {
+			return err
+		}
+		defer closePool()
+
+		return handler.ShowRun(ctx, cmd.OutOrStdout(), repo, args[0], format)
+	},
+}
+
+// runsFormat returns the validated --format flag
+func runsFormat(cmd *cobra.Command) (string, error) {
+	format, _ := cmd.Flags().GetString("format")
+	if format != "table" && format != "json" {
+		return "", fmt.Errorf("unsupported format: %s (supported: table, json)", format)
+	}
+	return format, nil
+}
+
+// newRunRepository connects to the database and returns the run repository with a function
+// closing the connection
+func newRunRepository(ctx context.Context) (run.Repository, func(), error) {
+	cfg, err := config.NewConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	dbPool, err := config.NewDatabasePool(ctx, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return run.NewRepository(dbPool), dbPool.Close, nil
+}
+
+func init() {
+	runsListCmd.Flags().Int("limit", 20, "Number of runs to list")
+	runsListCmd.Flags().String("format", "table", "Output format: table, json")
+	runsShowCmd.Flags().String("format", "table", "Output format: table, json")
+
+	runsCmd.AddCommand(runsListCmd)
+	runsCmd.AddCommand(runsShowCmd)
+	rootCmd.AddCommand(runsCmd)
+}
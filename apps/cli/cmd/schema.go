@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/schema"
+)
+
+// schemaCmd represents the schema command
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Inspect the database data model",
+	Long:  `Describe the tables yt-lang keeps its data in, for external tools and scripts.`,
+}
+
+// schemaDumpCmd prints the tables, columns and relationships of the database
+var schemaDumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Print the tables, columns and relationships of the database",
+	Long: `Print the data model of the database as it is after the applied migrations: every table
+with its columns (type, nullability, default), primary key and foreign keys. It is read from the
+database catalog, so it always matches the database commands work on. JSON output is meant for
+tools generating views of the data; table output lists one column per row.
+
+Examples:
+  yt-lang schema dump
+  yt-lang schema dump --format table
+  yt-lang schema dump | jq '.tables[] | select(.name == "videos")'`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unsupported format: %s (supported: json, table)", format)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		return handler.DumpSchema(ctx, cmd.OutOrStdout(), schema.NewRepository(dbPool), format)
+	},
+}
+
+func init() {
+	schemaDumpCmd.Flags().String("format", "json", "Output format: json, table")
+
+	schemaCmd.AddCommand(schemaDumpCmd)
+	rootCmd.AddCommand(schemaCmd)
+}
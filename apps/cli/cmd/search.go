@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/embedding"
+	embeddingSvc "github.com/Taichi-iskw/yt-lang/internal/service/embedding"
+)
+
+// searchCmd represents the search command
+var searchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Search transcribed segments by meaning",
+	Long: `Find segments by what they mean rather than the words they contain, complementing the
+keyword search of transcription get --grep. Segments are compared through embeddings computed by
+the model configured under embeddings in the config file (any OpenAI-compatible embeddings API)
+and stored with pgvector, which the database must provide.
+Embeddings are optional: compute them with search index, e.g. from a transcription_completed hook.`,
+}
+
+// searchSemanticCmd finds the segments nearest in meaning to a query
+var searchSemanticCmd = &cobra.Command{
+	Use:   "semantic [QUERY]",
+	Short: "Find the segments closest in meaning to a query",
+	Long: `Compute the embedding of the query and list the segments whose embeddings are nearest to
+it, with a score from 1 (same meaning) down. Only segments embedded by the configured model are
+searched; run search index first.
+
+Examples:
+  yt-lang search semantic "how to conjugate past tense" --lang es
+  yt-lang search semantic "ordering food at a restaurant" --limit 20 --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var opts embeddingSvc.SearchOptions
+		opts.Language, _ = cmd.Flags().GetString("lang")
+		opts.Limit, _ = cmd.Flags().GetInt("limit")
+		format, _ := cmd.Flags().GetString("format")
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unsupported format: %s (supported: table, json)", format)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		service, closePool, err := newEmbeddingService(ctx)
+		if err != nil {
+			return err
+		}
+		defer closePool()
+
+		return handler.SearchSemantic(ctx, cmd.OutOrStdout(), service, args[0], opts, format)
+	},
+}
+
+// searchIndexCmd computes the missing segment embeddings
+var searchIndexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "Compute the segment embeddings semantic search needs",
+	Long: `Compute the embeddings of the segments that have none from the configured model, in
+batches of embeddings.batch_size segments. An interrupted index keeps the batches it stored and
+continues with the rest when run again; changing the model re-embeds every segment.
+
+Examples:
+  yt-lang search index
+  yt-lang search index --transcription 3f2b8c1e-5d4a-4c1b-9e7f-0a6d2c8b4e91`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		transcriptionID, _ := cmd.Flags().GetString("transcription")
+
+		// Indexing a whole library takes many requests
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Hour)
+		defer cancel()
+
+		service, closePool, err := newEmbeddingService(ctx)
+		if err != nil {
+			return err
+		}
+		defer closePool()
+
+		return handler.IndexEmbeddings(ctx, cmd.OutOrStdout(), service, transcriptionID)
+	},
+}
+
+// newEmbeddingService connects to the database and returns the embedding service of the model
+// configured under embeddings, with a function closing the connection
+func newEmbeddingService(ctx context.Context) (embeddingSvc.Service, func(), error) {
+	cfg, err := config.NewConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if !cfg.Embeddings.Enabled() {
+		return nil, nil, fmt.Errorf("semantic search requires embeddings.model in the config file")
+	}
+
+	provider, err := embeddingSvc.NewProvider(embeddingSvc.ProviderOptions{
+		Provider: cfg.Embeddings.Provider,
+		BaseURL:  cfg.Embeddings.BaseURL,
+		Model:    cfg.Embeddings.Model,
+		APIKey:   cfg.Embeddings.APIKey,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to configure embeddings: %w", err)
+	}
+
+	dbPool, err := config.NewDatabasePool(ctx, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	service := embeddingSvc.NewService(embedding.NewRepository(dbPool), provider, cfg.Embeddings.BatchSize)
+	return service, dbPool.Close, nil
+}
+
+func init() {
+	searchSemanticCmd.Flags().String("lang", "", "Only search transcriptions in this language (e.g. es)")
+	searchSemanticCmd.Flags().Int("limit", 10, "Number of segments to show")
+	searchSemanticCmd.Flags().String("format", "table", "Output format: table, json")
+	searchIndexCmd.Flags().String("transcription", "", "Only embed the segments of this transcription")
+
+	searchCmd.AddCommand(searchSemanticCmd)
+	searchCmd.AddCommand(searchIndexCmd)
+	rootCmd.AddCommand(searchCmd)
+}
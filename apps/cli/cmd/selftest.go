@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+	selftestSvc "github.com/Taichi-iskw/yt-lang/internal/service/selftest"
+	transcriptionSvc "github.com/Taichi-iskw/yt-lang/internal/service/transcription"
+	translationSvc "github.com/Taichi-iskw/yt-lang/internal/service/translation"
+)
+
+// selftestCmd runs the whole pipeline against a fixture to validate an installation
+var selftestCmd = &cobra.Command{
+	Use:   "selftest",
+	Short: "Run the full pipeline against a fixture video",
+	Long: `Run every pipeline stage against a short, long-lived fixture video and report which stages pass:
+yt-dlp, audio download, whisper transcription, database writes and PLaMo translation.
+Database writes happen inside a transaction that is rolled back, so nothing is kept.
+Use --audio to transcribe a local audio file instead of downloading the fixture,
+for machines without YouTube access.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		videoID, _ := cmd.Flags().GetString("video")
+		audioPath, _ := cmd.Flags().GetString("audio")
+		language, _ := cmd.Flags().GetString("lang")
+		targetLang, _ := cmd.Flags().GetString("target-lang")
+		model, _ := cmd.Flags().GetString("model")
+		skipTranslation, _ := cmd.Flags().GetBool("skip-translation")
+		format, _ := cmd.Flags().GetString("format")
+
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unsupported format: %s (supported: table, json)", format)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+		defer cancel()
+
+		// A missing database fails the database stage instead of the whole run
+		var store selftestSvc.Store
+		cfg, err := config.NewConfig()
+		if err != nil {
+			store = selftestSvc.NewFailedStore(err)
+		} else if dbPool, err := config.NewDatabasePool(ctx, cfg); err != nil {
+			store = selftestSvc.NewFailedStore(err)
+		} else {
+			defer dbPool.Close()
+			store = selftestSvc.NewRollbackStore(dbPool)
+		}
+
+		cmdRunner := common.NewCmdRunner()
+		runner := selftestSvc.NewRunner(
+			cmdRunner,
+			transcriptionSvc.NewAudioDownloadService(),
+			transcriptionSvc.NewWhisperServiceWithCmdRunner(cmdRunner, model),
+			store,
+			translationSvc.NewPlamoService(cmdRunner),
+		)
+
+		report := runner.Run(ctx, selftestSvc.Options{
+			VideoID:         videoID,
+			AudioPath:       audioPath,
+			Language:        language,
+			TargetLanguage:  targetLang,
+			SkipTranslation: skipTranslation,
+		})
+
+		if format == "json" {
+			data, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format JSON: %w", err)
+			}
+			fmt.Println(string(data))
+		} else {
+			printSelftestReport(report)
+		}
+
+		if !report.Passed() {
+			// The report already explains the failure; usage would only bury it
+			cmd.SilenceUsage = true
+			return fmt.Errorf("selftest failed")
+		}
+		return nil
+	},
+}
+
+// printSelftestReport prints one line per stage followed by an overall verdict
+func printSelftestReport(report *selftestSvc.Report) {
+	icons := map[string]string{
+		selftestSvc.StatusPass: "✅",
+		selftestSvc.StatusFail: "❌",
+		selftestSvc.StatusSkip: "⏭️ ",
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, step := range report.Steps {
+		duration := ""
+		if step.Status != selftestSvc.StatusSkip {
+			duration = step.Duration.Round(10 * time.Millisecond).String()
+		}
+		fmt.Fprintf(w, "%s %s\t%s\t%s\n", icons[step.Status], step.Name, duration, step.Detail)
+	}
+	w.Flush()
+
+	fmt.Println()
+	if report.Passed() {
+		fmt.Println("✅ Selftest passed")
+	} else {
+		fmt.Println("❌ Selftest failed")
+	}
+}
+
+func init() {
+	selftestCmd.Flags().String("video", "", "Video ID to run against (default: the bundled fixture video)")
+	selftestCmd.Flags().String("audio", "", "Local audio file to transcribe instead of downloading a video")
+	selftestCmd.Flags().StringP("lang", "l", "", "Spoken language passed to whisper (default: en for the fixture, auto otherwise)")
+	selftestCmd.Flags().String("target-lang", "ja", "Language the first segment is translated to")
+	selftestCmd.Flags().StringP("model", "m", "tiny", "Whisper model to use")
+	selftestCmd.Flags().Bool("skip-translation", false, "Skip the PLaMo translation stage")
+	selftestCmd.Flags().String("format", "table", "Output format: table, json")
+
+	rootCmd.AddCommand(selftestCmd)
+}
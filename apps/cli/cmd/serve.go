@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/cmd/translation"
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/channel"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
+	translationRepo "github.com/Taichi-iskw/yt-lang/internal/repository/translation"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+	"github.com/Taichi-iskw/yt-lang/internal/server"
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+)
+
+// serveTools are the external tools /readyz requires by default
+var serveTools = common.Tools
+
+// serveCmd runs yt-lang as a long-lived HTTP server
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run the HTTP server",
+	Long: `Serve the library of the active workspace over HTTP until interrupted.
+
+  GET /api/...  read-only JSON API: channels, their videos, transcriptions with their
+                segments, and translations aligned with the segments
+  GET /         with --ui, a viewer app for browsing the library and reading
+                transcriptions side by side with their translations
+  GET /healthz  the process is up (liveness)
+  GET /readyz   the database accepts connections and the required tools are installed
+                (readiness); 503 with the failing checks otherwise
+
+With tokens under server.tokens in config.yaml, /api requires "Authorization: Bearer <token>" and
+each token's scope limits what it may do: read tokens can only GET, write tokens can also change
+data and admin tokens can also delete. A read token can thus power a viewer without risking
+deletes. The health probes and the viewer app's files need no token; the app asks for one.
+
+On SIGINT or SIGTERM the server stops accepting connections and waits up to
+--shutdown-timeout for in-flight requests to finish.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, _ := cmd.Flags().GetString("addr")
+		shutdownTimeout, _ := cmd.Flags().GetDuration("shutdown-timeout")
+		tools, _ := cmd.Flags().GetStringSlice("require-tool")
+		ui, _ := cmd.Flags().GetBool("ui")
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection, kept for the lifetime of the server
+		connectCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		dbPool, err := config.NewDatabasePool(connectCtx, cfg)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		tokens, err := config.NewAPITokens(cfg)
+		if err != nil {
+			return err
+		}
+
+		checks := []server.Check{server.DatabaseCheck(dbPool)}
+		for _, tool := range tools {
+			checks = append(checks, server.ToolCheck(tool))
+		}
+		srv := server.New(server.Options{Addr: addr, ShutdownTimeout: shutdownTimeout, Checks: checks, Tokens: tokens})
+		srv.MountAPI(server.Library{
+			Channels:       channel.NewRepository(dbPool),
+			Videos:         video.NewRepository(dbPool),
+			Transcriptions: transcription.NewRepository(dbPool),
+			Segments:       transcription.NewSegmentRepository(dbPool),
+			Languages:      translationRepo.NewRepository(dbPool),
+			Translations:   translation.NewReadService(dbPool),
+		})
+		if ui {
+			srv.MountUI()
+		}
+
+		fmt.Printf("Serving on http://%s (workspace %s)\n", addr, cfg.ActiveWorkspace())
+		if len(tokens) == 0 && !isLoopbackAddr(addr) {
+			fmt.Fprintln(os.Stderr, "Warning: no API tokens configured (server.tokens); anyone who can reach the address can use the API")
+		}
+		if err := srv.Run(ctx); err != nil {
+			return err
+		}
+		fmt.Println("Server stopped")
+		return nil
+	},
+}
+
+// isLoopbackAddr reports whether a listen address only accepts local connections
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func init() {
+	serveCmd.Flags().String("addr", server.DefaultAddr, "Address to listen on")
+	serveCmd.Flags().Duration("shutdown-timeout", server.DefaultShutdownTimeout, "Time in-flight requests get to finish on shutdown")
+	serveCmd.Flags().Bool("ui", false, "Serve the viewer app at /")
+	serveCmd.Flags().StringSlice("require-tool", serveTools, "External tools /readyz requires (repeatable; empty to check the database only)")
+
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/janitor"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/stats"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/usage"
+)
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Library statistics",
+	Long:  `Summarize what is saved in the active workspace.`,
+}
+
+// statsOverviewCmd prints library-wide totals
+var statsOverviewCmd = &cobra.Command{
+	Use:   "overview",
+	Short: "Totals of channels, videos, transcriptions, translations and caches",
+	Long: `Show the number of channels and videos and their hours, how many hours are transcribed,
+how many transcriptions failed, the translated segments per language and the size of the local
+caches, as a dashboard or JSON. Transcribed hours count whole videos unless a transcription
+stores its own duration.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unsupported format: %s (supported: table, json)", format)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		overview, err := stats.NewRepository(dbPool).Overview(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to compute statistics: %w", err)
+		}
+		overview.Workspace = cfg.ActiveWorkspace()
+
+		if dirs, err := config.CacheDirs(cfg); err == nil {
+			overview.CacheBytes, err = janitor.DirSize(dirs)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to measure cache size: %v\n", err)
+			}
+		}
+
+		if format == "json" {
+			data, err := json.MarshalIndent(overview, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format result: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		printStatsDashboard(os.Stdout, overview)
+		return nil
+	},
+}
+
+// printStatsDashboard renders overview as a compact two-column dashboard
+func printStatsDashboard(out io.Writer, overview *model.LibraryStats) {
+	fmt.Fprintf(out, "Library overview (workspace %s)\n\n", overview.Workspace)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "  Channels\t%d\n", overview.Channels)
+	fmt.Fprintf(w, "  Videos\t%d (%d unavailable)\n", overview.Videos, overview.UnavailableVideos)
+	fmt.Fprintf(w, "  Video hours\t%.1f\n", overview.VideoHours)
+	fmt.Fprintf(w, "  Transcriptions\t%d completed, %d failed, %d pending\n",
+		overview.CompletedTranscriptions, overview.FailedTranscriptions, overview.PendingTranscriptions)
+	transcribed := fmt.Sprintf("%.1f", overview.TranscribedHours)
+	if overview.VideoHours > 0 {
+		transcribed += fmt.Sprintf(" (%.0f%% of video hours)", 100*overview.TranscribedHours/overview.VideoHours)
+	}
+	fmt.Fprintf(w, "  Transcribed hours\t%s\n", transcribed)
+	fmt.Fprintf(w, "  Cache size\t%s\n", janitor.FormatSize(overview.CacheBytes))
+	w.Flush()
+
+	fmt.Fprintln(out)
+	if len(overview.Translations) == 0 {
+		fmt.Fprintln(out, "No translated segments")
+		return
+	}
+	fmt.Fprintln(out, "Translated segments")
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  LANGUAGE\tSEGMENTS\tAPPROVED")
+	for _, language := range overview.Translations {
+		fmt.Fprintf(w, "  %s\t%d\t%d\n", language.Language, language.Segments, language.Approved)
+	}
+	w.Flush()
+}
+
+// statsUsageCmd summarizes the local usage stats
+var statsUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "How often and how long each command ran",
+	Long: `Show the runs, failures and total, median and longest duration of each command, the
+commands taking the most time first, to see where your workflow spends its time.
+
+Commands are only recorded once usage_stats.enabled is set in the config file. The stats stay
+in the workspace's database, never leave it and hold no arguments or error messages.
+--clear deletes them.
+
+Examples:
+  yt-lang stats usage
+  yt-lang stats usage --days 0 --format json
+  yt-lang stats usage --clear`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		days, _ := cmd.Flags().GetInt("days")
+		clearStats, _ := cmd.Flags().GetBool("clear")
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unsupported format: %s (supported: table, json)", format)
+		}
+		if days < 0 {
+			return fmt.Errorf("--days must not be negative")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		repo := usage.NewRepository(dbPool)
+		if clearStats {
+			deleted, err := repo.Clear(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to clear usage stats: %w", err)
+			}
+			fmt.Printf("Deleted %d recorded command runs\n", deleted)
+			return nil
+		}
+
+		var since time.Time
+		if days > 0 {
+			since = time.Now().AddDate(0, 0, -days)
+		}
+		return handler.ShowUsage(ctx, cmd.OutOrStdout(), repo, since, cfg.UsageStats.Enabled, format)
+	},
+}
+
+func init() {
+	statsOverviewCmd.Flags().String("format", "table", "Output format: table, json")
+	statsUsageCmd.Flags().String("format", "table", "Output format: table, json")
+	statsUsageCmd.Flags().Int("days", 30, "Only count runs of the last N days (0 for all)")
+	statsUsageCmd.Flags().Bool("clear", false, "Delete every recorded command run")
+
+	statsCmd.AddCommand(statsOverviewCmd)
+	statsCmd.AddCommand(statsUsageCmd)
+	rootCmd.AddCommand(statsCmd)
+}
@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/translation"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+	studySvc "github.com/Taichi-iskw/yt-lang/internal/service/study"
+	"github.com/Taichi-iskw/yt-lang/internal/tmpl"
+)
+
+// studyCmd represents the study command
+var studyCmd = &cobra.Command{
+	Use:   "study",
+	Short: "Generate study material from transcriptions",
+	Long:  `Generate language-learning exercises from stored transcriptions and translations.`,
+}
+
+// studyClozeCmd generates gap-fill exercises
+var studyClozeCmd = &cobra.Command{
+	Use:   "cloze [TRANSCRIPTION_ID]",
+	Short: "Generate cloze (gap-fill) exercises",
+	Long: `Select segments at the requested rate, blank out the most frequent non-stopword in each,
+and pair them with stored translations. Output as a text worksheet, an Anki cloze deck
+(tab-separated, import with the Cloze note type), JSON, or practice interactively.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		transcriptionID := args[0]
+
+		perMinute, _ := cmd.Flags().GetFloat64("per-minute")
+		targetLang, _ := cmd.Flags().GetString("target-lang")
+		format, _ := cmd.Flags().GetString("format")
+		outputPath, _ := cmd.Flags().GetString("output")
+		interactive, _ := cmd.Flags().GetBool("interactive")
+
+		// Create service with timeout context
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		studyService := studySvc.NewStudyService(
+			transcription.NewRepository(dbPool),
+			transcription.NewSegmentRepository(dbPool),
+			translation.NewRepository(dbPool),
+		)
+
+		cards, err := studyService.GenerateCloze(ctx, studySvc.ClozeOptions{
+			TranscriptionID: transcriptionID,
+			TargetLanguage:  targetLang,
+			PerMinute:       perMinute,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate cloze exercises: %w", err)
+		}
+
+		if len(cards) == 0 {
+			fmt.Printf("No suitable segments found for transcription: %s\n", transcriptionID)
+			return nil
+		}
+
+		if interactive {
+			return runClozePractice(cards, os.Stdin, os.Stdout)
+		}
+
+		var out io.Writer = os.Stdout
+		if outputPath != "" {
+			file, err := os.Create(outputPath)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer file.Close()
+			out = file
+		}
+
+		switch format {
+		case "text":
+			err = studySvc.WriteClozeText(out, cards)
+		case "anki":
+			err = studySvc.WriteAnkiCloze(out, cards, "yt-lang")
+		case "json":
+			var data []byte
+			data, err = json.MarshalIndent(cards, "", "  ")
+			if err == nil {
+				_, err = fmt.Fprintln(out, string(data))
+			}
+		default:
+			return fmt.Errorf("unsupported format: %s (supported: text, anki, json)", format)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write cloze exercises: %w", err)
+		}
+
+		if outputPath != "" {
+			fmt.Printf("Wrote %d card(s) to %s\n", len(cards), outputPath)
+		}
+		return nil
+	},
+}
+
+// studySheetCmd renders a study sheet of a video through a template
+var studySheetCmd = &cobra.Command{
+	Use:   "sheet [VIDEO_ID]",
+	Short: "Render a bilingual study sheet of a video",
+	Long: `Render the transcript of a video with its translation and vocabulary through a Go template.
+Without --template a built-in template produces a Markdown sheet: vocabulary table first, then
+the transcript with each line's translation. An output file ending in .pdf is converted from
+the Markdown with pandoc, which needs a PDF engine such as LaTeX.
+
+Templates get the sheet as . with these fields:
+  .Video (.ID .Title .URL ...), .Transcription, .Language, .TargetLanguage, .TotalWords,
+  .UniqueWords, .GeneratedAt, .Lines (.StartTime .EndTime .Text .Translation) and
+  .Vocabulary (.Word .Count .Example)
+
+Examples:
+  yt-lang study sheet dQw4w9WgXcQ --target-lang ja --output sheet.md
+  yt-lang study sheet dQw4w9WgXcQ --target-lang ja --template study.md.tmpl --output sheet.pdf`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := studySvc.SheetOptions{VideoID: args[0]}
+		opts.Language, _ = cmd.Flags().GetString("language")
+		opts.TargetLanguage, _ = cmd.Flags().GetString("target-lang")
+		opts.Words, _ = cmd.Flags().GetInt("words")
+		templatePath, _ := cmd.Flags().GetString("template")
+		outputPath, _ := cmd.Flags().GetString("output")
+
+		name, text := "default", studySvc.DefaultSheetTemplate
+		if templatePath != "" {
+			data, err := os.ReadFile(templatePath)
+			if err != nil {
+				return fmt.Errorf("failed to read template: %w", err)
+			}
+			name, text = filepath.Base(templatePath), string(data)
+		}
+		template, err := tmpl.ParseDocument(name, text)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		sheetService := studySvc.NewSheetService(
+			video.NewRepository(dbPool),
+			transcription.NewRepository(dbPool),
+			transcription.NewSegmentRepository(dbPool),
+			translation.NewRepository(dbPool),
+		)
+
+		sheet, err := sheetService.BuildSheet(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to build study sheet: %w", err)
+		}
+
+		var buf bytes.Buffer
+		if err := template.Execute(&buf, sheet); err != nil {
+			return err
+		}
+
+		switch {
+		case outputPath == "":
+			_, err = os.Stdout.Write(buf.Bytes())
+		case strings.EqualFold(filepath.Ext(outputPath), ".pdf"):
+			err = studySvc.WritePDF(ctx, common.NewCmdRunner(), buf.Bytes(), outputPath)
+		default:
+			err = os.WriteFile(outputPath, buf.Bytes(), 0644)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write study sheet: %w", err)
+		}
+
+		if outputPath != "" {
+			fmt.Printf("Wrote study sheet of %s (%d lines) to %s\n", sheet.Video.ID, len(sheet.Lines), outputPath)
+		}
+		return nil
+	},
+}
+
+// studyClipsCmd cuts the audio of transcript segments into shadowing clips
+var studyClipsCmd = &cobra.Command{
+	Use:   "clips [VIDEO_ID]",
+	Short: "Cut segment audio into clips for shadowing practice",
+	Long: `Cut the audio of the given transcript segments out of the video's cached audio with ffmpeg,
+one clip per segment, for shadowing and pronunciation practice. Clips are named after their
+segment index and text (0012-hola-a-todos.mp3), and a manifest.json in the output directory lists
+each clip with its times, text and, with --target-lang, its translation.
+
+The audio must be cached: enable storage.cache_audio before transcribing the video. Segment
+indexes are the segment_index values of transcription get --format json. Each clip keeps
+--padding of audio on both sides, since whisper's timestamps often cut into the first and last
+syllable.
+
+Examples:
+  yt-lang study clips dQw4w9WgXcQ --segments 12,13,14 --output clips
+  yt-lang study clips dQw4w9WgXcQ --segments 3,8 --target-lang ja --format m4a --output clips`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := studySvc.ClipOptions{VideoID: args[0]}
+		opts.Segments, _ = cmd.Flags().GetIntSlice("segments")
+		opts.OutputDir, _ = cmd.Flags().GetString("output")
+		opts.Language, _ = cmd.Flags().GetString("language")
+		opts.TargetLanguage, _ = cmd.Flags().GetString("target-lang")
+		opts.Format, _ = cmd.Flags().GetString("format")
+		opts.Padding, _ = cmd.Flags().GetDuration("padding")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		store, err := config.NewArtifactStore(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to open artifact store: %w", err)
+		}
+
+		clipService := studySvc.NewClipService(
+			transcription.NewRepository(dbPool),
+			transcription.NewSegmentRepository(dbPool),
+			translation.NewRepository(dbPool),
+			store,
+		)
+
+		manifest, err := clipService.ExtractClips(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to extract clips: %w", err)
+		}
+		for _, clip := range manifest.Clips {
+			fmt.Printf("  %s  %s\n", clip.File, clip.Text)
+		}
+		fmt.Printf("Wrote %d clip(s) of %s to %s\n", len(manifest.Clips), manifest.VideoID, filepath.Join(opts.OutputDir, studySvc.ClipManifestFile))
+		return nil
+	},
+}
+
+// runClozePractice quizzes the user card by card and reports the score
+func runClozePractice(cards []*studySvc.ClozeCard, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	correct := 0
+
+	for i, card := range cards {
+		fmt.Fprintf(out, "\n[%d/%d] %s\n", i+1, len(cards), card.Cloze)
+		if card.Translation != "" {
+			fmt.Fprintf(out, "      (%s)\n", card.Translation)
+		}
+		fmt.Fprint(out, "> ")
+
+		if !scanner.Scan() {
+			break
+		}
+		if strings.EqualFold(strings.TrimSpace(scanner.Text()), card.Answer) {
+			correct++
+			fmt.Fprintln(out, "✅ Correct")
+		} else {
+			fmt.Fprintf(out, "❌ Answer: %s\n", card.Answer)
+		}
+	}
+
+	fmt.Fprintf(out, "\nScore: %d/%d\n", correct, len(cards))
+	return scanner.Err()
+}
+
+func init() {
+	studyClozeCmd.Flags().Float64("per-minute", 2, "Number of cards per minute of audio")
+	studyClozeCmd.Flags().String("target-lang", "", "Pair cards with translations in this language")
+	studyClozeCmd.Flags().String("format", "text", "Output format: text, anki, json")
+	studyClozeCmd.Flags().StringP("output", "o", "", "Write to file instead of stdout")
+	studyClozeCmd.Flags().BoolP("interactive", "i", false, "Practice the cards interactively in the terminal")
+
+	studySheetCmd.Flags().StringP("language", "l", "", "Transcription language, when the video has several")
+	studySheetCmd.Flags().String("target-lang", "", "Show translations in this language next to each line")
+	studySheetCmd.Flags().Int("words", 20, "Number of vocabulary entries")
+	studySheetCmd.Flags().String("template", "", "Go template file to render instead of the built-in Markdown sheet")
+	studySheetCmd.Flags().StringP("output", "o", "", "Write to file instead of stdout (.pdf converts with pandoc)")
+
+	studyClipsCmd.Flags().IntSlice("segments", nil, "Indexes of the segments to cut (comma-separated)")
+	studyClipsCmd.Flags().StringP("output", "o", "", "Directory to write the clips and their manifest to")
+	studyClipsCmd.Flags().StringP("language", "l", "", "Transcription language, when the video has several")
+	studyClipsCmd.Flags().String("target-lang", "", "Record translations in this language in the manifest")
+	studyClipsCmd.Flags().String("format", studySvc.ClipFormats[0], "Clip format: "+strings.Join(studySvc.ClipFormats, ", "))
+	studyClipsCmd.Flags().Duration("padding", studySvc.DefaultClipPadding, "Audio kept before and after each segment")
+	studyClipsCmd.MarkFlagRequired("segments")
+	studyClipsCmd.MarkFlagRequired("output")
+
+	studyCmd.AddCommand(studyClozeCmd)
+	studyCmd.AddCommand(studySheetCmd)
+	studyCmd.AddCommand(studyClipsCmd)
+	rootCmd.AddCommand(studyCmd)
+}
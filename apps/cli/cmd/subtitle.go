@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/subtitle"
+)
+
+// subtitleCmd represents the subtitle command
+var subtitleCmd = &cobra.Command{
+	Use:   "subtitle",
+	Short: "Subtitle file operations",
+	Long:  `Operations on SRT and WebVTT subtitle files.`,
+}
+
+// subtitleLintCmd checks subtitle files for timing, readability and encoding problems
+var subtitleLintCmd = &cobra.Command{
+	Use:   "lint [FILE]...",
+	Short: "Check subtitle files for timing, readability and encoding problems",
+	Long: `Check SRT and WebVTT files and report, per cue, problems viewers or players would hit:
+cues out of order or overlapping the previous cue, cues without duration or longer than the
+maximum, reading speeds above --max-cps characters per second, and lines or cues longer than
+the limits of --style. Encoding problems are reported per line: invalid UTF-8 (e.g. files saved
+as Windows-1252 or Shift_JIS), UTF-16 files, replacement characters and mojibake.
+Exits with an error when any file has problems, so it can gate scripts and CI.
+
+Examples:
+  yt-lang subtitle lint video.srt
+  yt-lang subtitle lint exports/*.vtt --style netflix --format json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unsupported format: %s (supported: table, json)", format)
+		}
+		opts, err := lintOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		results := make([]handler.LintResult, 0, len(args))
+		for _, path := range args {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read subtitle file: %w", err)
+			}
+			results = append(results, handler.LintResult{Name: path, LintReport: subtitle.Lint(data, opts)})
+		}
+
+		passed, err := handler.WriteLintResults(cmd.OutOrStdout(), results, format)
+		if err != nil {
+			return err
+		}
+		if !passed {
+			// The report already explains the problems; usage would only bury it
+			cmd.SilenceUsage = true
+			return fmt.Errorf("subtitle lint found problems")
+		}
+		return nil
+	},
+}
+
+// lintOptionsFromFlags returns the lint limits of the --style, --max-cps and --max-duration flags
+func lintOptionsFromFlags(cmd *cobra.Command) (subtitle.LintOptions, error) {
+	style, _ := cmd.Flags().GetString("style")
+	rules, err := config.ResolveSubtitleRules(style)
+	if err != nil {
+		return subtitle.LintOptions{}, err
+	}
+
+	opts := subtitle.LintOptionsFor(rules)
+	opts.MaxCharsPerSecond, _ = cmd.Flags().GetFloat64("max-cps")
+	if cmd.Flags().Changed("max-duration") {
+		opts.MaxDuration, _ = cmd.Flags().GetDuration("max-duration")
+	}
+	return opts, nil
+}
+
+func init() {
+	subtitleLintCmd.Flags().String("style", "", "Subtitle style whose line limits and maximum duration apply (default, netflix, or a style from the config file)")
+	subtitleLintCmd.Flags().Float64("max-cps", subtitle.DefaultMaxCharsPerSecond, "Maximum reading speed in characters per second (0 disables)")
+	subtitleLintCmd.Flags().Duration("max-duration", 0, "Maximum cue duration (default: the style's, else 7s; 0 disables)")
+	subtitleLintCmd.Flags().String("format", "table", "Output format: table, json")
+
+	subtitleCmd.AddCommand(subtitleLintCmd)
+	rootCmd.AddCommand(subtitleCmd)
+}
@@ -0,0 +1,93 @@
+package transcription
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
+	transcriptionSvc "github.com/Taichi-iskw/yt-lang/internal/service/transcription"
+)
+
+func NewArtifactCmd() *cobra.Command {
+	artifactCmd := &cobra.Command{
+		Use:   "artifact [TRANSCRIPTION_ID]",
+		Short: "Retrieve the raw whisper output of a transcription",
+		Long: `Retrieve the raw whisper JSON output stored when the transcription was created.
+The artifact keeps word timings and per-segment confidence values that are not saved as segments.
+
+Examples:
+  yt-lang transcription artifact abc123 --output whisper.json
+  yt-lang transcription artifact abc123 | jq '.segments[0]'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			transcriptionID := args[0]
+			outputPath, _ := cmd.Flags().GetString("output")
+
+			// Create context
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			// Load database configuration
+			cfg, err := config.NewConfig()
+			if err != nil {
+				return err
+			}
+
+			// Create database connection
+			dbPool, err := config.NewDatabasePool(ctx, cfg)
+			if err != nil {
+				return err
+			}
+			defer dbPool.Close()
+
+			artifactStore, err := config.NewArtifactStore(cfg)
+			if err != nil {
+				return err
+			}
+
+			// Create repositories and service
+			transcriptionService := transcriptionSvc.NewTranscriptionServiceWithArtifactStore(
+				transcription.NewRepository(dbPool),
+				transcription.NewSegmentRepository(dbPool),
+				artifactStore,
+			)
+
+			reader, err := transcriptionService.OpenWhisperArtifact(ctx, transcriptionID)
+			if err != nil {
+				return err
+			}
+			defer reader.Close()
+
+			if outputPath == "" {
+				_, err = io.Copy(cmd.OutOrStdout(), reader)
+				return err
+			}
+
+			file, err := os.Create(outputPath)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			if _, err := io.Copy(file, reader); err != nil {
+				file.Close()
+				return fmt.Errorf("failed to write artifact: %w", err)
+			}
+			if err := file.Close(); err != nil {
+				return fmt.Errorf("failed to write artifact: %w", err)
+			}
+
+			fmt.Fprintf(os.Stderr, "✅ Whisper artifact written to %s\n", outputPath)
+			return nil
+		},
+	}
+
+	// Add flags
+	artifactCmd.Flags().StringP("output", "o", "", "Output file path (default: stdout)")
+
+	return artifactCmd
+}
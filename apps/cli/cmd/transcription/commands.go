@@ -1,18 +1,24 @@
 package transcription
 
 import (
+	"bufio"
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
 	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/pager"
 	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
 	transcriptionSvc "github.com/Taichi-iskw/yt-lang/internal/service/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/tmpl"
 )
 
+// porcelainFields are the transcription fields of --porcelain, in column order
+var porcelainFields = []string{"ID", "VideoID", "Language", "Status", "Source", "CreatedAt", "CompletedAt"}
+
 // NewTranscriptionCmd creates and returns the transcription command
 func NewTranscriptionCmd() *cobra.Command {
 	// transcriptionCmd represents the transcription command
@@ -24,9 +30,15 @@ func NewTranscriptionCmd() *cobra.Command {
 
 	// Add subcommands
 	transcriptionCmd.AddCommand(NewCreateCmd())
+	transcriptionCmd.AddCommand(NewCreateBatchCmd())
 	transcriptionCmd.AddCommand(NewGetCmd())
 	transcriptionCmd.AddCommand(NewListCmd())
 	transcriptionCmd.AddCommand(NewDeleteCmd())
+	transcriptionCmd.AddCommand(NewArtifactCmd())
+	transcriptionCmd.AddCommand(NewMergeCmd())
+	transcriptionCmd.AddCommand(NewImportCmd())
+	transcriptionCmd.AddCommand(NewLintCmd())
+	transcriptionCmd.AddCommand(NewHallucinationsCmd())
 
 	return transcriptionCmd
 }
@@ -35,13 +47,38 @@ func NewGetCmd() *cobra.Command {
 	getCmd := &cobra.Command{
 		Use:   "get [TRANSCRIPTION_ID]",
 		Short: "Get transcription by ID",
-		Long:  `Retrieve and display a transcription with its segments by ID.`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Retrieve and display a transcription with its segments by ID. Segments are streamed as they are read, so memory use stays constant for long transcriptions.
+
+When output is a terminal it is shown in a pager ($PAGER, or less when unset; PAGER=cat or --no-pager turns it off).
+--grep keeps only the segments whose text contains the pattern (case-insensitive), filtered in the database.
+--heatmap colors each segment by its whisper confidence (avg_logprob: green >= -0.4, yellow >= -0.8,
+red below) and ends with a histogram and the low-confidence regions to review before translating.
+Colors are left out when output is not a terminal or NO_COLOR is set.
+--template renders only the transcription's metadata with a Go template, for scripts
+(e.g. --template '{{.Status}}' or '{{.ID}} {{.DetectedLanguage}}'; {{json .}} shows every field).
+--porcelain prints the metadata as stable tab-separated fields with no header: id, video_id,
+language, status, source, created_at, completed_at (-z ends it with NUL instead of a newline).`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			transcriptionID := args[0]
 
 			// Get flags
 			format, _ := cmd.Flags().GetString("format")
+			style, _ := cmd.Flags().GetString("style")
+			grep, _ := cmd.Flags().GetString("grep")
+			noPager, _ := cmd.Flags().GetBool("no-pager")
+			heatmap, _ := cmd.Flags().GetBool("heatmap")
+
+			text, _ := cmd.Flags().GetString("template")
+			porcelain, _ := cmd.Flags().GetBool("porcelain")
+			nul, _ := cmd.Flags().GetBool("null")
+			rendered, err := tmpl.FromFlags(text, porcelain, nul, porcelainFields)
+			if err != nil {
+				return err
+			}
+			if rendered != nil && (format != "text" || grep != "" || heatmap) {
+				return fmt.Errorf("--template and --porcelain cannot be combined with --format, --grep or --heatmap")
+			}
 
 			// Create context
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -70,46 +107,65 @@ func NewGetCmd() *cobra.Command {
 				nil, // WhisperService not needed for retrieval
 			)
 
-			// Retrieve transcription
-			result, segments, err := transcriptionService.GetTranscription(ctx, transcriptionID)
+			// Prepare output writer before touching segments so invalid formats fail fast
+			out := bufio.NewWriter(cmd.OutOrStdout())
+			defer out.Flush()
+
+			rules, err := config.ResolveSubtitleRules(style)
 			if err != nil {
 				return err
 			}
 
-			// Display results based on format
-			switch format {
-			case "json":
-				output := map[string]interface{}{
-					"transcription": result,
-					"segments":      segments,
-				}
-				jsonBytes, err := json.MarshalIndent(output, "", "  ")
-				if err != nil {
-					return err
-				}
-				fmt.Println(string(jsonBytes))
-
-			case "srt":
-				srtContent := formatAsSRT(segments)
-				fmt.Print(srtContent)
-
-			default: // text
-				fmt.Printf("Transcription ID: %s\n", result.ID)
-				fmt.Printf("Video ID: %s\n", result.VideoID)
-				fmt.Printf("Language: %s\n", result.Language)
-				fmt.Printf("Status: %s\n", result.Status)
-				if result.DetectedLanguage != nil {
-					fmt.Printf("Detected Language: %s\n", *result.DetectedLanguage)
-				}
-				fmt.Printf("Created: %s\n", result.CreatedAt.Format(time.RFC3339))
-				if result.CompletedAt != nil {
-					fmt.Printf("Completed: %s\n", result.CompletedAt.Format(time.RFC3339))
+			writer, err := newSegmentStreamWriter(format, out, rules)
+			if err != nil {
+				return err
+			}
+			if heatmap {
+				if format != "text" {
+					return fmt.Errorf("--heatmap requires --format text")
 				}
-				fmt.Printf("\nSegments (%d):\n", len(segments))
+				writer = newHeatmapStreamWriter(out, pager.IsTerminal(cmd.OutOrStdout()))
+			}
 
-				for _, segment := range segments {
-					fmt.Printf("[%s - %s] %s\n", segment.StartTime, segment.EndTime, segment.Text)
-				}
+			// Retrieve transcription metadata
+			result, err := transcriptionService.GetTranscriptionInfo(ctx, transcriptionID)
+			if err != nil {
+				return err
+			}
+			if rendered != nil {
+				return rendered.Execute(out, result)
+			}
+
+			// Page terminal output once there is something to show
+			streamCtx := ctx
+			pagerOut, closePager := pager.Open(cmd.OutOrStdout(), !noPager)
+			defer func() {
+				// Flush buffered output before waiting for the user to quit the pager
+				out.Flush()
+				closePager()
+			}()
+			if pagerOut != cmd.OutOrStdout() {
+				out.Reset(pagerOut)
+				// Writes block while the user reads, so the stream must outlive the timeout
+				streamCtx = context.WithoutCancel(ctx)
+			}
+
+			if err := writer.WriteHeader(result); err != nil {
+				return err
+			}
+
+			// Stream segments to output as they are read to keep memory constant
+			if grep != "" {
+				err = transcriptionService.SearchSegments(streamCtx, transcriptionID, grep, writer.WriteSegment)
+			} else {
+				err = transcriptionService.StreamSegments(streamCtx, transcriptionID, writer.WriteSegment)
+			}
+			if err != nil {
+				return err
+			}
+
+			if err := writer.Close(); err != nil {
+				return err
 			}
 
 			return nil
@@ -117,7 +173,14 @@ func NewGetCmd() *cobra.Command {
 	}
 
 	// Add flags
-	getCmd.Flags().StringP("format", "f", "text", "Output format: text, json, srt")
+	getCmd.Flags().StringP("format", "f", "text", "Output format: text, json, srt, vtt")
+	getCmd.Flags().String("style", "", "Subtitle style for srt/vtt (default, netflix, or a style from the config file)")
+	getCmd.Flags().String("grep", "", "Show only segments whose text contains this pattern (case-insensitive)")
+	getCmd.Flags().Bool("no-pager", false, "Write output directly instead of through a pager")
+	getCmd.Flags().Bool("heatmap", false, "Color segments by confidence (green/yellow/red) and summarize low-confidence regions")
+	getCmd.Flags().String("template", "", "Render the transcription metadata with a Go template (e.g. '{{.ID}} {{.Status}}')")
+	getCmd.Flags().Bool("porcelain", false, "Print the metadata as stable tab-separated fields for scripts")
+	getCmd.Flags().BoolP("null", "z", false, "End the --porcelain record with NUL instead of a newline")
 
 	return getCmd
 }
@@ -126,10 +189,37 @@ func NewListCmd() *cobra.Command {
 	listCmd := &cobra.Command{
 		Use:   "list [VIDEO_ID]",
 		Short: "List transcriptions for a video",
-		Long:  `List all transcriptions for a specific video.`,
-		Args:  cobra.ExactArgs(1),
+		Long: `List all transcriptions for a specific video.
+--stale lists instead the transcriptions whose video changed on YouTube (title, duration or
+upload date, e.g. a re-upload with edits) after they were made, as detected by video fetch,
+channel sync and watch; VIDEO_ID is optional with --stale and limits the list to that video.
+--template renders each transcription with a Go template instead, one per line
+(e.g. --template '{{.ID}}\t{{.Language}}\t{{.Status}}').
+--porcelain prints id, video_id, language, status, source, created_at and completed_at
+tab-separated with no header, in a column order that never changes; -z ends each
+transcription with NUL instead of a newline.
+
+Examples:
+  yt-lang transcription list dQw4w9WgXcQ
+  yt-lang transcription list --stale`,
+		Args: cobra.RangeArgs(0, 1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			videoID := args[0]
+			stale, _ := cmd.Flags().GetBool("stale")
+			if len(args) == 0 && !stale {
+				return fmt.Errorf("VIDEO_ID is required unless --stale is given")
+			}
+			var videoID string
+			if len(args) == 1 {
+				videoID = args[0]
+			}
+
+			text, _ := cmd.Flags().GetString("template")
+			porcelain, _ := cmd.Flags().GetBool("porcelain")
+			nul, _ := cmd.Flags().GetBool("null")
+			rendered, err := tmpl.FromFlags(text, porcelain, nul, porcelainFields)
+			if err != nil {
+				return err
+			}
 
 			// Create context
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -158,37 +248,18 @@ func NewListCmd() *cobra.Command {
 				nil, // WhisperService not needed for listing
 			)
 
-			// List transcriptions
-			results, err := transcriptionService.ListTranscriptions(ctx, videoID)
-			if err != nil {
-				return err
-			}
-
-			// Display results
-			if len(results) == 0 {
-				fmt.Printf("No transcriptions found for video: %s\n", videoID)
-				return nil
+			if stale {
+				return handler.ListStaleTranscriptions(ctx, cmd.OutOrStdout(), transcriptionService, videoID, rendered)
 			}
-
-			fmt.Printf("Transcriptions for video %s (%d found):\n\n", videoID, len(results))
-			for _, t := range results {
-				fmt.Printf("ID: %s\n", t.ID)
-				fmt.Printf("Language: %s\n", t.Language)
-				fmt.Printf("Status: %s\n", t.Status)
-				if t.DetectedLanguage != nil {
-					fmt.Printf("Detected Language: %s\n", *t.DetectedLanguage)
-				}
-				fmt.Printf("Created: %s\n", t.CreatedAt.Format(time.RFC3339))
-				if t.CompletedAt != nil {
-					fmt.Printf("Completed: %s\n", t.CompletedAt.Format(time.RFC3339))
-				}
-				fmt.Println("---")
-			}
-
-			return nil
+			return handler.ListTranscriptions(ctx, cmd.OutOrStdout(), transcriptionService, videoID, rendered)
 		},
 	}
 
+	listCmd.Flags().Bool("stale", false, "List the transcriptions whose video changed on YouTube since they were made")
+	listCmd.Flags().String("template", "", "Render each transcription with a Go template (e.g. '{{.ID}} {{.Status}}')")
+	listCmd.Flags().Bool("porcelain", false, "Print stable tab-separated fields for scripts: id, video_id, language, status, source, created_at, completed_at")
+	listCmd.Flags().BoolP("null", "z", false, "End each --porcelain record with NUL instead of a newline")
+
 	return listCmd
 }
 
@@ -235,20 +306,18 @@ func NewDeleteCmd() *cobra.Command {
 			transcriptionRepo := transcription.NewRepository(dbPool)
 			segmentRepo := transcription.NewSegmentRepository(dbPool)
 
-			transcriptionService := transcriptionSvc.NewTranscriptionServiceWithDependencies(
-				transcriptionRepo,
-				segmentRepo,
-				nil, // WhisperService not needed for deletion
-			)
-
-			// Delete transcription
-			err = transcriptionService.DeleteTranscription(ctx, transcriptionID)
+			artifactStore, err := config.NewArtifactStore(cfg)
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("✅ Transcription '%s' deleted successfully.\n", transcriptionID)
-			return nil
+			transcriptionService := transcriptionSvc.NewTranscriptionServiceWithArtifactStore(
+				transcriptionRepo,
+				segmentRepo,
+				artifactStore,
+			)
+
+			return handler.DeleteTranscription(ctx, cmd.OutOrStdout(), transcriptionService, transcriptionID)
 		},
 	}
 
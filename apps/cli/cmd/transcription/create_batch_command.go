@@ -0,0 +1,303 @@
+package transcription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/diskguard"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/run"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+	transcriptionSvc "github.com/Taichi-iskw/yt-lang/internal/service/transcription"
+)
+
+func NewCreateBatchCmd() *cobra.Command {
+	createBatchCmd := &cobra.Command{
+		Use:   "create-batch [CHANNEL_ID]",
+		Short: "Create transcriptions for a channel's untranscribed videos",
+		Long: `Transcribe every available video of a channel that has no transcription in the requested language yet.
+Use --min-duration and --max-duration to leave out shorts and multi-hour streams before paying for
+whisper runs; when --max-duration is set, videos of unknown duration are left out too.
+Use --published-after to only transcribe videos uploaded on or after a date; videos of unknown upload
+date are left out too (run video save to record the dates of videos saved before they were tracked).
+Videos linked as re-uploads by video dedupe --link are skipped.
+--audio-format and --audio-quality download smaller audio (e.g. m4a at 48k) to keep the audio
+cache of large batches small, at the cost of a little accuracy.
+Without --language, a channel with one language inferred by channel languages uses that language.
+A failing video is reported and the batch continues with the next one.
+
+Before transcribing, the free disk space is checked against an estimate of the audio the batch
+keeps (in job workspaces and the audio cache, from the video durations and --audio-quality) and
+the whisper model if it is not downloaded yet. When it does not fit, the batch stops before
+starting; the check is repeated between videos and stops the batch, to be continued with
+--resume, when space runs out meanwhile. --ignore-disk-check skips the checks.
+
+--plan lists every saved video of the channel instead, with its duration, the estimated whisper
+time of the videos that would be transcribed (a rough CPU figure for --model) and the reason the
+others would be skipped. Nothing is transcribed.
+
+Every batch is recorded as a run: its videos and the status, timing and transcription or error
+of each (see runs list and runs show). --resume RUN_ID transcribes the videos of a run that failed
+or were interrupted again, with the run's language and model unless --language or --model is given.
+
+Examples:
+  yt-lang transcription create-batch UC123456789 --min-duration 2m --max-duration 30m
+  yt-lang transcription create-batch UC123456789 --max-duration 1h --limit 5 --dry-run
+  yt-lang transcription create-batch UC123456789 --max-duration 30m --plan
+  yt-lang transcription create-batch UC123456789 --published-after 2024-01-01
+  yt-lang transcription create-batch UC123456789 --audio-format m4a --audio-quality 48k
+  yt-lang transcription create-batch --resume 3f2b8c1e-5d4a-4c1b-9e7f-0a6d2c8b4e91`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resumeID, _ := cmd.Flags().GetString("resume")
+			switch {
+			case resumeID == "" && len(args) == 0:
+				return fmt.Errorf("a CHANNEL_ID (or --resume RUN_ID) is required")
+			case resumeID != "" && len(args) > 0:
+				return fmt.Errorf("--resume cannot be used with a CHANNEL_ID; the run's videos are transcribed")
+			}
+
+			// Get flags
+			language, _ := cmd.Flags().GetString("language")
+			whisperModel, _ := cmd.Flags().GetString("model")
+			minDuration, _ := cmd.Flags().GetDuration("min-duration")
+			maxDuration, _ := cmd.Flags().GetDuration("max-duration")
+			limit, _ := cmd.Flags().GetInt("limit")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			plan, _ := cmd.Flags().GetBool("plan")
+			noFallback, _ := cmd.Flags().GetBool("no-fallback")
+			ignoreDiskCheck, _ := cmd.Flags().GetBool("ignore-disk-check")
+
+			if minDuration < 0 || maxDuration < 0 {
+				return fmt.Errorf("durations must not be negative")
+			}
+			if maxDuration > 0 && minDuration > maxDuration {
+				return fmt.Errorf("--min-duration (%s) is longer than --max-duration (%s)", minDuration, maxDuration)
+			}
+			var publishedAfter time.Time
+			if value, _ := cmd.Flags().GetString("published-after"); value != "" {
+				date, err := time.Parse("2006-01-02", value)
+				if err != nil {
+					return fmt.Errorf("invalid --published-after %q (expected YYYY-MM-DD)", value)
+				}
+				publishedAfter = date
+			}
+
+			// Create context (12 hours per batch, as for a single long video)
+			ctx, cancel := context.WithTimeout(context.Background(), 12*time.Hour)
+			defer cancel()
+
+			// Load database configuration
+			cfg, err := config.NewConfig()
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			// Create database connection
+			dbPool, err := config.NewDatabasePool(ctx, cfg)
+			if err != nil {
+				return fmt.Errorf("failed to connect to database: %w", err)
+			}
+			defer dbPool.Close()
+
+			audio, err := audioFormatFlags(cmd, cfg.Transcription.Audio)
+			if err != nil {
+				return err
+			}
+
+			runRepo := run.NewRepository(dbPool)
+			if resumeID != "" {
+				return resumeBatch(ctx, cmd, cfg, dbPool, runRepo, resumeID, audio, !noFallback, ignoreDiskCheck)
+			}
+			channelID := args[0]
+
+			// Channels with one inferred language default to it
+			if !cmd.Flags().Changed("language") {
+				inferred, err := newProfileService(dbPool).ChannelLanguage(ctx, channelID)
+				language = channelLanguageOr(inferred, err, language)
+			}
+
+			filter := video.CandidateFilter{
+				ChannelID:      channelID,
+				Language:       language,
+				MinDuration:    minDuration,
+				MaxDuration:    maxDuration,
+				Limit:          limit,
+				PublishedAfter: publishedAfter,
+			}
+			// Config routing rules pick the model unless --model is given
+			route := cfg.Transcription.Routing.Enabled() && !cmd.Flags().Changed("model")
+
+			if plan {
+				return handler.PlanTranscriptionBatch(ctx, cmd.OutOrStdout(), video.NewRepository(dbPool), filter, handler.PlanOptions{Model: whisperModel, Routed: route})
+			}
+
+			videos, err := video.NewRepository(dbPool).ListTranscriptionCandidates(ctx, filter)
+			if err != nil {
+				return fmt.Errorf("failed to list videos: %w", err)
+			}
+
+			if len(videos) == 0 {
+				fmt.Printf("No videos to transcribe for channel: %s\n", channelID)
+				return nil
+			}
+
+			if dryRun {
+				fmt.Printf("DRY RUN: Would transcribe %d videos:\n", len(videos))
+				for _, v := range videos {
+					fmt.Printf("  %s  %8s  %s\n", v.ID, time.Duration(v.Duration*float64(time.Second)).Round(time.Second), v.Title)
+				}
+				return nil
+			}
+
+			durations := make([]time.Duration, len(videos))
+			for i, v := range videos {
+				durations[i] = time.Duration(v.Duration * float64(time.Second))
+			}
+			guard, err := newBatchDiskGuard(cfg, audio, whisperModel, durations, ignoreDiskCheck)
+			if err != nil {
+				return err
+			}
+
+			transcriptionService, err := newCreatingService(cfg, dbPool, whisperModel, audio, route, !noFallback)
+			if err != nil {
+				return err
+			}
+
+			// Record the batch so it can be inspected and resumed
+			batch := &model.Run{
+				Command: model.RunCommandCreateBatch,
+				Target:  channelID,
+				Options: map[string]string{"language": language, "model": whisperModel, "routed": strconv.FormatBool(route)},
+			}
+			items := make([]*model.RunItem, len(videos))
+			for i, v := range videos {
+				items[i] = &model.RunItem{ItemID: v.ID, Title: v.Title}
+			}
+			if err := runRepo.Create(ctx, batch, items); err != nil {
+				return fmt.Errorf("failed to record run: %w", err)
+			}
+
+			hallucinations, err := newHallucinationFilter(ctx, cfg.Transcription.Hallucinations, transcription.NewHallucinationRepository(dbPool))
+			if err != nil {
+				return err
+			}
+
+			return transcribeRun(ctx, transcriptionService, runRepo, batch, items, language, transcriptionSvc.CreateOptions{Hallucinations: hallucinations}, cfg.Transcription.LanguageConfidenceThreshold(), guard)
+		},
+	}
+
+	// Add flags
+	createBatchCmd.Flags().StringP("language", "l", "auto", "Language for transcription (e.g., 'en', 'ja', 'auto')")
+	createBatchCmd.Flags().StringP("model", "m", "base", "Whisper model to use (tiny, base, small, medium, large)")
+	createBatchCmd.Flags().Duration("min-duration", 0, "Skip videos shorter than this (e.g. 2m)")
+	createBatchCmd.Flags().Duration("max-duration", 0, "Skip videos longer than this (e.g. 30m, 1h30m)")
+	createBatchCmd.Flags().String("published-after", "", "Skip videos uploaded before this date (YYYY-MM-DD)")
+	createBatchCmd.Flags().Bool("plan", false, "List every video with its estimated whisper time or skip reason, without transcribing")
+	createBatchCmd.Flags().Bool("no-fallback", false, "Keep whisper's first output even when it looks pathological")
+	createBatchCmd.Flags().Int("limit", 0, "Maximum number of videos to transcribe (0 for all)")
+	createBatchCmd.Flags().BoolP("dry-run", "d", false, "List the videos that would be transcribed without transcribing them")
+	createBatchCmd.Flags().String("resume", "", "Transcribe the videos of a run that did not complete")
+	createBatchCmd.Flags().Bool("ignore-disk-check", false, "Start even when the disk seems too full for the batch")
+	addAudioFormatFlags(createBatchCmd)
+	createBatchCmd.MarkFlagsMutuallyExclusive("plan", "dry-run", "resume")
+
+	return createBatchCmd
+}
+
+// resumeBatch transcribes the videos of a create-batch run that failed, were interrupted or never
+// started, with the run's language and model unless --language or --model is given
+func resumeBatch(ctx context.Context, cmd *cobra.Command, cfg *config.Config, dbPool *config.DatabasePool, runRepo run.Repository, runID string, audio transcriptionSvc.AudioFormatOptions, fallback, ignoreDiskCheck bool) error {
+	batch, err := runRepo.GetByID(ctx, runID)
+	if err != nil {
+		return fmt.Errorf("failed to get run: %w", err)
+	}
+	if batch.Command != model.RunCommandCreateBatch {
+		return fmt.Errorf("run %s is a %s run, not a transcription create-batch run", runID, batch.Command)
+	}
+	items := batch.Unfinished()
+	if len(items) == 0 {
+		fmt.Printf("Run %s has no videos left to transcribe\n", runID)
+		return nil
+	}
+
+	language, whisperModel := batch.Options["language"], batch.Options["model"]
+	route := batch.Options["routed"] == "true" && cfg.Transcription.Routing.Enabled()
+	if cmd.Flags().Changed("language") {
+		language, _ = cmd.Flags().GetString("language")
+	}
+	if cmd.Flags().Changed("model") {
+		whisperModel, _ = cmd.Flags().GetString("model")
+		route = false
+	}
+
+	// Videos deleted since the run started count as unknown durations
+	durations := make([]time.Duration, len(items))
+	videoRepo := video.NewRepository(dbPool)
+	for i, item := range items {
+		if v, err := videoRepo.GetByID(ctx, item.ItemID); err == nil {
+			durations[i] = time.Duration(v.Duration * float64(time.Second))
+		}
+	}
+	guard, err := newBatchDiskGuard(cfg, audio, whisperModel, durations, ignoreDiskCheck)
+	if err != nil {
+		return err
+	}
+
+	transcriptionService, err := newCreatingService(cfg, dbPool, whisperModel, audio, route, fallback)
+	if err != nil {
+		return err
+	}
+	if err := runRepo.SetStatus(ctx, runID, model.RunStatusRunning); err != nil {
+		return fmt.Errorf("failed to resume run: %w", err)
+	}
+
+	hallucinations, err := newHallucinationFilter(ctx, cfg.Transcription.Hallucinations, transcription.NewHallucinationRepository(dbPool))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Resuming run %s: %d of %d videos left\n", runID, len(items), batch.Total)
+	return transcribeRun(ctx, transcriptionService, runRepo, batch, items, language, transcriptionSvc.CreateOptions{Hallucinations: hallucinations}, cfg.Transcription.LanguageConfidenceThreshold(), guard)
+}
+
+// transcribeRun transcribes the videos of a run's items in turn with opts, recording the outcome of
+// each. A failing video is reported and the batch continues; failing to record progress only warns.
+// When guard finds the disk running out of space, the batch stops before the next video.
+func transcribeRun(ctx context.Context, service transcriptionSvc.TranscriptionService, runRepo run.Repository, batch *model.Run, items []*model.RunItem, language string, opts transcriptionSvc.CreateOptions, confidenceThreshold float64, guard *diskguard.Guard) error {
+	return handler.ProcessRun(ctx, os.Stdout, runRepo, batch, items, handler.RunBatch{
+		Noun: "videos",
+		Verb: "transcribe",
+		Done: "Transcribed",
+		Check: func() error {
+			if err := guard.Check(); err != nil {
+				return fmt.Errorf("%w; free some space", err)
+			}
+			return nil
+		},
+		Process: func(ctx context.Context, item *model.RunItem) (string, error) {
+			result, err := service.CreateTranscription(ctx, item.ItemID, language, opts)
+			if err != nil {
+				return "", err
+			}
+			// An existing transcription is returned as is, including one that failed before
+			if result.Status != "completed" {
+				if result.ErrorMessage != nil && *result.ErrorMessage != "" {
+					return "", errors.New(*result.ErrorMessage)
+				}
+				return "", fmt.Errorf("transcription %s is %s", result.ID, result.Status)
+			}
+			warnLowLanguageConfidence(result, confidenceThreshold)
+			return result.ID, nil
+		},
+	})
+}
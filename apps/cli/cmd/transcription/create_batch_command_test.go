@@ -0,0 +1,81 @@
+package transcription
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/run"
+	transcriptionSvc "github.com/Taichi-iskw/yt-lang/internal/service/transcription"
+)
+
+// fakeCreatingService returns a fixed transcription or error per video
+type fakeCreatingService struct {
+	transcriptionSvc.TranscriptionService
+	results map[string]*model.Transcription
+	errs    map[string]error
+}
+
+func (s *fakeCreatingService) CreateTranscription(ctx context.Context, videoID string, language string, opts transcriptionSvc.CreateOptions) (*model.Transcription, error) {
+	return s.results[videoID], s.errs[videoID]
+}
+
+// finishedItem is an outcome recorded by fakeRunRepo
+type finishedItem struct {
+	status       string
+	resultID     *string
+	errorMessage *string
+}
+
+// fakeRunRepo records item outcomes and the run status
+type fakeRunRepo struct {
+	run.Repository
+	finished map[int]finishedItem
+	status   string
+}
+
+func (r *fakeRunRepo) StartItem(ctx context.Context, runID string, position int) error {
+	return nil
+}
+
+func (r *fakeRunRepo) FinishItem(ctx context.Context, runID string, position int, status string, resultID, errorMessage *string) error {
+	r.finished[position] = finishedItem{status: status, resultID: resultID, errorMessage: errorMessage}
+	return nil
+}
+
+func (r *fakeRunRepo) SetStatus(ctx context.Context, id string, status string) error {
+	r.status = status
+	return nil
+}
+
+func TestTranscribeRun(t *testing.T) {
+	message := "whisper crashed"
+	service := &fakeCreatingService{
+		results: map[string]*model.Transcription{
+			"v1": {ID: "t1", Status: "completed"},
+			"v2": {ID: "t2", Status: "failed", ErrorMessage: &message},
+		},
+		errs: map[string]error{"v3": errors.New("download failed")},
+	}
+	runRepo := &fakeRunRepo{finished: map[int]finishedItem{}}
+	items := []*model.RunItem{{Position: 0, ItemID: "v1"}, {Position: 1, ItemID: "v2"}, {Position: 2, ItemID: "v3"}}
+
+	err := transcribeRun(context.Background(), service, runRepo, &model.Run{ID: "run-1"}, items, "ja", transcriptionSvc.CreateOptions{}, 0, nil)
+	require.ErrorContains(t, err, "2 videos failed")
+
+	assert.Equal(t, model.RunStatusCompleted, runRepo.finished[0].status)
+	assert.Equal(t, "t1", *runRepo.finished[0].resultID)
+
+	// A failed transcription that already existed is not reported as done
+	assert.Equal(t, model.RunStatusFailed, runRepo.finished[1].status)
+	assert.Nil(t, runRepo.finished[1].resultID)
+	assert.Equal(t, "whisper crashed", *runRepo.finished[1].errorMessage)
+
+	assert.Equal(t, model.RunStatusFailed, runRepo.finished[2].status)
+	assert.Equal(t, "download failed", *runRepo.finished[2].errorMessage)
+	assert.Equal(t, model.RunStatusFailed, runRepo.status)
+}
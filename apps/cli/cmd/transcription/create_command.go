@@ -3,23 +3,64 @@ package transcription
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/i18n"
+	"github.com/Taichi-iskw/yt-lang/internal/job"
+	"github.com/Taichi-iskw/yt-lang/internal/lock"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
 	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
 	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
 	"github.com/Taichi-iskw/yt-lang/internal/service/common"
 	transcriptionSvc "github.com/Taichi-iskw/yt-lang/internal/service/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
 )
 
 func NewCreateCmd() *cobra.Command {
 	createCmd := &cobra.Command{
 		Use:   "create [VIDEO_ID]",
 		Short: "Create transcription for a video",
-		Long:  `Create a transcription for a video by downloading its audio using yt-dlp and processing with Whisper.`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Create a transcription for a video by downloading its audio using yt-dlp and processing with Whisper.
+
+Use --from and --to (HH:MM:SS, MM:SS or seconds) to transcribe only part of the video, e.g. to
+sample a long stream. The audio is clipped with ffmpeg before Whisper runs, and segment times
+still refer to the full video. The clipped transcription is the video's transcription in that
+language: delete it before transcribing the whole video.
+
+When transcription.routing models are configured and --model is not given, the model is picked
+by language. Without --language, the language is first detected by a cheap whisper pass over a
+short sample spread across the video. The decision is recorded on the transcription.
+
+When whisper's output looks pathological (a repetition loop, very low confidence), the audio is
+transcribed again by the transcription.fallback runs: by default beam search, then beam search
+with the next larger model. The run with the fewest problems is kept and every run is recorded
+on the transcription. --no-fallback keeps the first output.
+
+Segments consisting only of a phrase whisper emits on silence ("Thanks for watching!") are
+flagged or removed before they are saved; see transcription hallucinations.
+
+Each transcription works in a job workspace under ~/.yt-lang/work holding the downloaded audio
+and whisper's output. When a transcription is interrupted (a crash, Ctrl-C, a timeout), running
+the same command again resumes after the last completed step instead of downloading and
+transcribing again. Remove the workspaces of finished jobs with jobs clean.
+
+--audio-format and --audio-quality (or transcription.audio in the config) download audio smaller
+than YouTube's best, e.g. m4a at 48k: whisper loses little accuracy on speech and cached audio
+takes a fraction of the space. The format is recorded with the cached audio.
+
+Without --language, videos of a channel whose language was inferred by channel languages are
+transcribed in that language, unless the channel is multilingual.
+
+--events writes the pipeline steps as newline-delimited JSON for wrapper scripts and GUIs:
+started, audio_downloaded, whisper_progress (every few seconds while whisper runs),
+segments_saved, then completed or failed. Plain --events writes them to stdout instead of the
+summary; --events=PATH appends them to a file.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			videoID := args[0]
 
@@ -28,6 +69,26 @@ func NewCreateCmd() *cobra.Command {
 			model, _ := cmd.Flags().GetString("model")
 			dryRun, _ := cmd.Flags().GetBool("dry-run")
 			format, _ := cmd.Flags().GetString("format")
+			from, _ := cmd.Flags().GetString("from")
+			to, _ := cmd.Flags().GetString("to")
+			events, _ := cmd.Flags().GetString("events")
+			noFallback, _ := cmd.Flags().GetBool("no-fallback")
+
+			opts, err := parseCreateRange(from, to)
+			if err != nil {
+				return err
+			}
+			if events != "" {
+				if dryRun {
+					return fmt.Errorf("--events cannot be used with --dry-run")
+				}
+				writer, err := newEventWriter(events, cmd.OutOrStdout())
+				if err != nil {
+					return err
+				}
+				defer writer.Close()
+				opts.Events = writer.Write
+			}
 
 			// Create service with timeout context (12 hours for long videos)
 			ctx, cancel := context.WithTimeout(context.Background(), 12*time.Hour)
@@ -35,7 +96,11 @@ func NewCreateCmd() *cobra.Command {
 
 			if dryRun {
 				// Dry-run mode: test transcription without saving to database
-				return runDryRunMode(ctx, videoID, language, format, model)
+				audio, err := audioFormatFlags(cmd, config.AudioConfig{})
+				if err != nil {
+					return err
+				}
+				return runDryRunMode(ctx, videoID, language, format, model, audio, opts)
 			}
 
 			// Load database configuration
@@ -51,28 +116,41 @@ func NewCreateCmd() *cobra.Command {
 			}
 			defer dbPool.Close()
 
-			// Create repositories and services
-			transcriptionRepo := transcription.NewRepository(dbPool)
-			segmentRepo := transcription.NewSegmentRepository(dbPool)
-			videoRepo := video.NewRepository(dbPool)
-			whisperService := transcriptionSvc.NewWhisperServiceWithCmdRunner(common.NewCmdRunner(), model)
-			audioDownloadService := transcriptionSvc.NewAudioDownloadService()
+			// Channels with one inferred language default to it
+			if !cmd.Flags().Changed("language") {
+				inferred, err := newProfileService(dbPool).DefaultLanguage(ctx, videoID)
+				language = channelLanguageOr(inferred, err, language)
+			}
 
-			transcriptionService := transcriptionSvc.NewTranscriptionServiceWithAllDependencies(
-				transcriptionRepo,
-				segmentRepo,
-				whisperService,
-				audioDownloadService,
-				videoRepo,
-			)
+			opts.Hallucinations, err = newHallucinationFilter(ctx, cfg.Transcription.Hallucinations, transcription.NewHallucinationRepository(dbPool))
+			if err != nil {
+				return err
+			}
+
+			audio, err := audioFormatFlags(cmd, cfg.Transcription.Audio)
+			if err != nil {
+				return err
+			}
+
+			// Config routing rules pick the model unless --model is given
+			route := cfg.Transcription.Routing.Enabled() && !cmd.Flags().Changed("model")
+			transcriptionService, err := newCreatingService(cfg, dbPool, model, audio, route, !noFallback)
+			if err != nil {
+				return err
+			}
 
 			// Execute transcription
-			result, err := transcriptionService.CreateTranscription(ctx, videoID, language)
+			result, err := transcriptionService.CreateTranscription(ctx, videoID, language, opts)
 			if err != nil {
 				return fmt.Errorf("failed to create transcription: %w", err)
 			}
+			if events == "-" {
+				// The completed event replaces the summary on stdout
+				warnLowLanguageConfidence(result, cfg.Transcription.LanguageConfidenceThreshold())
+				return nil
+			}
 
-			fmt.Printf("✅ Transcription created successfully!\n")
+			fmt.Println(i18n.T("msg.transcription_created", "✅ Transcription created successfully!"))
 			fmt.Printf("ID: %s\n", result.ID)
 			fmt.Printf("Video ID: %s\n", result.VideoID)
 			fmt.Printf("Language: %s\n", result.Language)
@@ -80,7 +158,20 @@ func NewCreateCmd() *cobra.Command {
 			if result.DetectedLanguage != nil {
 				fmt.Printf("Detected Language: %s\n", *result.DetectedLanguage)
 			}
+			if len(result.LanguageCandidates) > 0 {
+				fmt.Printf("Language Candidates: %s\n", formatLanguageCandidates(result.LanguageCandidates))
+			}
+			if result.Routing != nil {
+				fmt.Printf("Model: %s (%s)\n", result.Routing.Model, result.Routing.Reason)
+			}
+			if len(result.WhisperAttempts) > 1 {
+				fmt.Printf("Whisper Attempts: %s\n", formatWhisperAttempts(result.WhisperAttempts))
+			}
+			if result.Hallucinations != nil {
+				fmt.Printf("Hallucinations: %s\n", formatHallucinations(result.Hallucinations))
+			}
 			fmt.Printf("Created: %s\n", result.CreatedAt.Format(time.RFC3339))
+			warnLowLanguageConfidence(result, cfg.Transcription.LanguageConfidenceThreshold())
 
 			return nil
 		},
@@ -91,6 +182,166 @@ func NewCreateCmd() *cobra.Command {
 	createCmd.Flags().StringP("model", "m", "base", "Whisper model to use (tiny, base, small, medium, large)")
 	createCmd.Flags().BoolP("dry-run", "d", false, "Dry run mode - test transcription without saving to database")
 	createCmd.Flags().StringP("format", "f", "text", "Output format (text, json, srt)")
+	createCmd.Flags().String("from", "", "Transcribe from this position in the video (e.g. 00:05:00)")
+	createCmd.Flags().String("to", "", "Transcribe up to this position in the video (e.g. 00:15:00)")
+	createCmd.Flags().String("events", "", "Write pipeline events as JSON lines to stdout, or to a file with --events=PATH")
+	createCmd.Flags().Lookup("events").NoOptDefVal = "-"
+	createCmd.Flags().Bool("no-fallback", false, "Keep whisper's first output even when it looks pathological")
+	addAudioFormatFlags(createCmd)
 
 	return createCmd
 }
+
+// parseCreateRange parses the --from and --to flags into the range to transcribe
+func parseCreateRange(from, to string) (transcriptionSvc.CreateOptions, error) {
+	var opts transcriptionSvc.CreateOptions
+	if from != "" {
+		offset, err := timecode.ParseOffset(from)
+		if err != nil {
+			return opts, fmt.Errorf("invalid --from: %w", err)
+		}
+		opts.From = offset
+	}
+	if to != "" {
+		offset, err := timecode.ParseOffset(to)
+		if err != nil {
+			return opts, fmt.Errorf("invalid --to: %w", err)
+		}
+		if offset <= opts.From {
+			return opts, fmt.Errorf("--to must be after --from")
+		}
+		opts.To = offset
+	}
+	return opts, nil
+}
+
+// addAudioFormatFlags adds the --audio-format and --audio-quality flags of the commands downloading audio
+func addAudioFormatFlags(cmd *cobra.Command) {
+	cmd.Flags().String("audio-format", transcriptionSvc.DefaultAudioFormat, "Audio format to download (best, aac, m4a, mp3, opus, vorbis, wav)")
+	cmd.Flags().String("audio-quality", transcriptionSvc.DefaultAudioQuality, "Audio quality to download: 0 (best) to 10, or a bitrate such as 48k")
+}
+
+// audioFormatFlags returns the audio format of the --audio-format and --audio-quality flags,
+// falling back to the config for flags not given
+func audioFormatFlags(cmd *cobra.Command, cfg config.AudioConfig) (transcriptionSvc.AudioFormatOptions, error) {
+	opts := transcriptionSvc.AudioFormatOptions{Format: cfg.Format, Quality: cfg.Quality}
+	if cmd.Flags().Changed("audio-format") {
+		opts.Format, _ = cmd.Flags().GetString("audio-format")
+	}
+	if cmd.Flags().Changed("audio-quality") {
+		opts.Quality, _ = cmd.Flags().GetString("audio-quality")
+	}
+	return opts, opts.Validate()
+}
+
+// newCreatingService builds the transcription service used to create transcriptions: whisper with
+// the given model (or the model picked by the config routing rules when route is set) and the
+// config decoding parameters and fallback ladder (unless fallback is false), audio downloaded at
+// the given format with optional caching, locking per video and language, and hooks
+func newCreatingService(cfg *config.Config, dbPool *config.DatabasePool, model string, audio transcriptionSvc.AudioFormatOptions, route, fallback bool) (transcriptionSvc.TranscriptionService, error) {
+	whisperOpts := whisperOptions(cfg.Transcription, fallback)
+	whisperService := transcriptionSvc.NewWhisperServiceWithOptions(common.NewCmdRunner(), model, whisperOpts)
+	audioDownloadService := transcriptionSvc.NewAudioDownloadServiceWithFormat(common.NewCmdRunner(), ytdlp.DefaultDetector(), audio)
+
+	artifactStore, err := config.NewArtifactStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Storage.CacheAudio {
+		audioDownloadService = transcriptionSvc.NewCachingAudioDownloadService(audioDownloadService, artifactStore)
+	}
+
+	hooks, err := config.NewHookDispatcher(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var router transcriptionSvc.ModelRouter
+	if route {
+		routing := cfg.Transcription.Routing
+		router = transcriptionSvc.NewModelRouter(transcriptionSvc.RoutingOptions{
+			DefaultModel:   model,
+			SampleModel:    routing.SampleModel,
+			SampleDuration: routing.SampleDuration,
+			Models:         routing.Models,
+			Whisper:        whisperOpts,
+		})
+	}
+
+	// Interrupted transcriptions resume from their job workspace
+	workDir, err := config.GetWorkDir()
+	if err != nil {
+		return nil, err
+	}
+
+	// Lock per video and language so overlapping runs don't transcribe twice
+	return transcriptionSvc.NewHookedService(
+		transcriptionSvc.NewLockingService(
+			transcriptionSvc.NewTranscriptionServiceWithWorkspaces(
+				transcription.NewRepository(dbPool),
+				transcription.NewSegmentRepository(dbPool),
+				whisperService,
+				audioDownloadService,
+				video.NewRepository(dbPool),
+				artifactStore,
+				router,
+				job.NewWorkspaces(workDir),
+				cfg.ActiveWorkspace(),
+			),
+			lock.NewPostgresLocker(dbPool.Pool),
+		),
+		hooks,
+	), nil
+}
+
+// whisperOptions maps the config decoding parameters and fallback ladder to whisper options.
+// Without configured runs the default ladder is used; fallback false disables it.
+func whisperOptions(cfg config.TranscriptionConfig, fallback bool) transcriptionSvc.WhisperOptions {
+	opts := transcriptionSvc.WhisperOptions{Decoding: decodingOptions(cfg.Decoding)}
+	switch {
+	case !fallback:
+	case cfg.Fallback == nil:
+		opts.Fallback = transcriptionSvc.DefaultFallback
+	default:
+		for _, step := range cfg.Fallback {
+			opts.Fallback = append(opts.Fallback, transcriptionSvc.FallbackStep{Model: step.Model, Decoding: decodingOptions(step.DecodingConfig)})
+		}
+	}
+	return opts
+}
+
+// decodingOptions maps config decoding parameters to whisper decoding options
+func decodingOptions(cfg config.DecodingConfig) transcriptionSvc.DecodingOptions {
+	return transcriptionSvc.DecodingOptions{
+		BeamSize:                  cfg.BeamSize,
+		BestOf:                    cfg.BestOf,
+		TemperatureIncrement:      cfg.TemperatureIncrement,
+		CompressionRatioThreshold: cfg.CompressionRatioThreshold,
+		LogprobThreshold:          cfg.LogprobThreshold,
+		NoSpeechThreshold:         cfg.NoSpeechThreshold,
+	}
+}
+
+// formatWhisperAttempts formats whisper runs as "base: 2 issue(s), base beam 5: ok (chosen)"
+func formatWhisperAttempts(attempts []model.WhisperAttempt) string {
+	parts := make([]string, len(attempts))
+	for i, a := range attempts {
+		part := a.Model
+		if a.BeamSize > 0 {
+			part += fmt.Sprintf(" beam %d", a.BeamSize)
+		}
+		switch {
+		case a.Error != "":
+			part += ": failed"
+		case len(a.Issues) > 0:
+			part += fmt.Sprintf(": %d issue(s)", len(a.Issues))
+		default:
+			part += ": ok"
+		}
+		if a.Chosen {
+			part += " (chosen)"
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, ", ")
+}
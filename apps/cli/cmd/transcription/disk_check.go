@@ -0,0 +1,79 @@
+package transcription
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/diskguard"
+	transcriptionSvc "github.com/Taichi-iskw/yt-lang/internal/service/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/whispermodel"
+)
+
+// diskCheckInterval is how often create-batch re-checks free disk space between videos
+const diskCheckInterval = time.Minute
+
+// batchDiskNeeds estimates the disk space transcribing audio of the given durations takes: the
+// audio kept in job workspaces (until jobs clean) and, with storage.cache_audio on the local
+// backend, in the audio cache, plus the whisper model when it is not downloaded yet
+func batchDiskNeeds(cfg *config.Config, audio transcriptionSvc.AudioFormatOptions, whisperModel string, durations []time.Duration) ([]diskguard.Need, error) {
+	var audioBytes int64
+	for _, duration := range durations {
+		audioBytes += audio.EstimateAudioSize(duration)
+	}
+
+	workDir, err := config.GetWorkDir()
+	if err != nil {
+		return nil, err
+	}
+	needs := []diskguard.Need{{Dir: workDir, Bytes: audioBytes, What: "audio in job workspaces"}}
+
+	if cfg.Storage.CacheAudio && (cfg.Storage.Backend == "" || cfg.Storage.Backend == "local") {
+		dir := cfg.Storage.Dir
+		if dir == "" {
+			if dir, err = config.GetArtifactDir(); err != nil {
+				return nil, err
+			}
+		}
+		needs = append(needs, diskguard.Need{Dir: dir, Bytes: audioBytes, What: "cached audio"})
+	}
+
+	// Unknown models are whisper's business; they only go without a check
+	if modelDir, err := whispermodel.DefaultDir(); err == nil {
+		if size, err := whispermodel.DownloadSize(modelDir, whisperModel); err == nil {
+			needs = append(needs, diskguard.Need{Dir: modelDir, Bytes: size, What: "whisper model " + whisperModel})
+		}
+	}
+	return needs, nil
+}
+
+// newBatchDiskGuard checks that the disk has room for the videos of a batch and returns a guard
+// re-checking, between videos, room for the longest of them. With ignore, nothing is checked and
+// the guard is nil.
+func newBatchDiskGuard(cfg *config.Config, audio transcriptionSvc.AudioFormatOptions, whisperModel string, durations []time.Duration, ignore bool) (*diskguard.Guard, error) {
+	if ignore {
+		return nil, nil
+	}
+	needs, err := batchDiskNeeds(cfg, audio, whisperModel, durations)
+	if err != nil {
+		return nil, err
+	}
+	if err := diskguard.Check(needs...); err != nil {
+		return nil, withDiskCheckHint(err)
+	}
+
+	var longest time.Duration
+	for _, duration := range durations {
+		longest = max(longest, duration)
+	}
+	needs, err = batchDiskNeeds(cfg, audio, whisperModel, []time.Duration{longest})
+	if err != nil {
+		return nil, err
+	}
+	return diskguard.NewGuard(diskCheckInterval, needs...), nil
+}
+
+// withDiskCheckHint adds how to get past the disk check to its error
+func withDiskCheckHint(err error) error {
+	return fmt.Errorf("%w; free some space (jobs clean, cache prune), download smaller audio with --audio-quality 48k, or skip the check with --ignore-disk-check", err)
+}
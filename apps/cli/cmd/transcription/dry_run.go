@@ -4,18 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 
+	"github.com/Taichi-iskw/yt-lang/internal/janitor"
 	"github.com/Taichi-iskw/yt-lang/internal/service/common"
 	transcriptionSvc "github.com/Taichi-iskw/yt-lang/internal/service/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
 )
 
 // runDryRunMode runs transcription in dry-run mode (no database save)
 // This function directly uses services without repository layer
-func runDryRunMode(ctx context.Context, videoID, language, format, model string) error {
+func runDryRunMode(ctx context.Context, videoID, language, format, model string, audio transcriptionSvc.AudioFormatOptions, opts transcriptionSvc.CreateOptions) error {
 	// Create services (no database needed)
 	whisperService := transcriptionSvc.NewWhisperServiceWithCmdRunner(common.NewCmdRunner(), model)
-	audioDownloadService := transcriptionSvc.NewAudioDownloadService()
+	audioDownloadService := transcriptionSvc.NewAudioDownloadServiceWithFormat(common.NewCmdRunner(), ytdlp.DefaultDetector(), audio)
 
 	fmt.Printf("🎵 Testing transcription for video %s (dry-run mode)...\n", videoID)
 	fmt.Printf("Language: %s\n", language)
@@ -23,11 +24,11 @@ func runDryRunMode(ctx context.Context, videoID, language, format, model string)
 	fmt.Printf("\n📥 Downloading audio...\n")
 
 	// Download audio to temporary directory
-	tmpDir, err := os.MkdirTemp("", "transcription-test-")
+	tmpDir, err := janitor.MkdirTemp("transcription-test-")
 	if err != nil {
 		return fmt.Errorf("failed to create temp directory: %w", err)
 	}
-	defer os.RemoveAll(tmpDir)
+	defer janitor.RemoveTemp(tmpDir)
 
 	videoURL := fmt.Sprintf("https://www.youtube.com/watch?v=%s", videoID)
 	audioPath, err := audioDownloadService.DownloadAudio(ctx, videoURL, tmpDir)
@@ -36,6 +37,14 @@ func runDryRunMode(ctx context.Context, videoID, language, format, model string)
 	}
 
 	fmt.Printf("✅ Audio downloaded: %s\n", audioPath)
+
+	if opts.From != 0 || opts.To != 0 {
+		audioPath, err = transcriptionSvc.NewAudioClipper().ClipAudio(ctx, audioPath, tmpDir, opts.From, opts.To)
+		if err != nil {
+			return formatTranscriptionError(err, videoID)
+		}
+		fmt.Printf("✂️  Audio clipped: %s\n", audioPath)
+	}
 	fmt.Printf("\n🎙️ Running transcription...\n")
 
 	// Run transcription
@@ -43,6 +52,7 @@ func runDryRunMode(ctx context.Context, videoID, language, format, model string)
 	if err != nil {
 		return formatTranscriptionError(err, videoID)
 	}
+	transcriptionSvc.OffsetWhisperResult(whisperResult, opts.From)
 
 	fmt.Printf("✅ Transcription completed!\n")
 	fmt.Printf("Detected Language: %s\n", whisperResult.Language)
@@ -0,0 +1,51 @@
+package transcription
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	transcriptionSvc "github.com/Taichi-iskw/yt-lang/internal/service/transcription"
+)
+
+// eventWriter writes pipeline events as newline-delimited JSON for --events
+type eventWriter struct {
+	mu      sync.Mutex // whisper_progress is sent from another goroutine
+	encoder *json.Encoder
+	file    *os.File // Set when writing to a file
+	err     error    // First write error; later events are dropped
+}
+
+// newEventWriter writes events to stdout for "-", or appends them to the file at dest
+func newEventWriter(dest string, stdout io.Writer) (*eventWriter, error) {
+	if dest == "-" {
+		return &eventWriter{encoder: json.NewEncoder(stdout)}, nil
+	}
+	file, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open events file: %w", err)
+	}
+	return &eventWriter{encoder: json.NewEncoder(file), file: file}, nil
+}
+
+// Write writes one event; it matches CreateOptions.Events
+func (w *eventWriter) Write(event transcriptionSvc.Event) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return
+	}
+	if w.err = w.encoder.Encode(event); w.err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write events: %v\n", w.err)
+	}
+}
+
+// Close closes the events file
+func (w *eventWriter) Close() error {
+	if w.file == nil {
+		return nil
+	}
+	return w.file.Close()
+}
@@ -2,6 +2,7 @@ package transcription
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/Taichi-iskw/yt-lang/internal/model"
@@ -12,17 +13,23 @@ func formatAsSRT(segments []*model.TranscriptionSegment) string {
 	var result strings.Builder
 
 	for i, segment := range segments {
-		// SRT format: sequence number, timestamp, text, blank line
-		result.WriteString(fmt.Sprintf("%d\n", i+1))
-		result.WriteString(fmt.Sprintf("%s --> %s\n",
-			formatTimeForSRT(segment.StartTime),
-			formatTimeForSRT(segment.EndTime)))
-		result.WriteString(fmt.Sprintf("%s\n\n", segment.Text))
+		writeSRTSegment(&result, i+1, segment)
 	}
 
 	return result.String()
 }
 
+// writeSRTSegment writes a single transcription segment as an SRT cue
+func writeSRTSegment(w io.Writer, sequence int, segment *model.TranscriptionSegment) error {
+	// SRT format: sequence number, timestamp, text, blank line
+	_, err := fmt.Fprintf(w, "%d\n%s --> %s\n%s\n\n",
+		sequence,
+		formatTimeForSRT(segment.StartTime),
+		formatTimeForSRT(segment.EndTime),
+		segment.Text)
+	return err
+}
+
 // formatTimeForSRT converts PostgreSQL interval format to SRT timestamp format
 func formatTimeForSRT(intervalTime string) string {
 	// Convert "HH:MM:SS.sss" to "HH:MM:SS,sss" (SRT uses comma for milliseconds)
@@ -0,0 +1,183 @@
+package transcription
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
+	transcriptionSvc "github.com/Taichi-iskw/yt-lang/internal/service/transcription"
+)
+
+func NewHallucinationsCmd() *cobra.Command {
+	hallucinationsCmd := &cobra.Command{
+		Use:   "hallucinations",
+		Short: "Manage the phrases whisper emits on silence",
+		Long: `Whisper often writes junk phrases such as "Thanks for watching!" over silence or music.
+When a transcription is saved, segments consisting only of a listed phrase (ignoring case and
+punctuation) are flagged, or removed when transcription.hallucinations.mode is remove. The
+counts are shown by transcription create and get, and the raw whisper output keeps every segment.
+
+The list is the built-in phrases (or transcription.hallucinations.phrases when configured) and
+the phrases added to the workspace with add.
+
+Examples:
+  yt-lang transcription hallucinations list
+  yt-lang transcription hallucinations add "Subtítulos realizados por la comunidad de Amara.org"
+  yt-lang transcription hallucinations remove "Subtítulos realizados por la comunidad de Amara.org"`,
+	}
+
+	hallucinationsCmd.AddCommand(newHallucinationsListCmd())
+	hallucinationsCmd.AddCommand(newHallucinationsAddCmd())
+	hallucinationsCmd.AddCommand(newHallucinationsRemoveCmd())
+
+	return hallucinationsCmd
+}
+
+func newHallucinationsListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the hallucination phrases and where they come from",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withHallucinationRepository(func(ctx context.Context, cfg *config.Config, repo transcription.HallucinationRepository) error {
+				stored, err := repo.List(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to list hallucination phrases: %w", err)
+				}
+
+				mode := cfg.Transcription.Hallucinations.Mode
+				if mode == "" {
+					mode = model.HallucinationFlag
+				}
+				fmt.Printf("Mode: %s\n\n", mode)
+
+				source := "default"
+				if cfg.Transcription.Hallucinations.Phrases != nil {
+					source = "config"
+				}
+				fmt.Printf("%-9s %s\n", "SOURCE", "PHRASE")
+				for _, phrase := range configuredHallucinations(cfg.Transcription.Hallucinations) {
+					fmt.Printf("%-9s %s\n", source, phrase)
+				}
+				for _, phrase := range stored {
+					fmt.Printf("%-9s %s\n", "database", phrase)
+				}
+				return nil
+			})
+		},
+	}
+}
+
+func newHallucinationsAddCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "add [PHRASE]",
+		Short: "Add a hallucination phrase to the workspace's list",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			phrase := strings.TrimSpace(args[0])
+			if phrase == "" {
+				return fmt.Errorf("phrase is required")
+			}
+			return withHallucinationRepository(func(ctx context.Context, cfg *config.Config, repo transcription.HallucinationRepository) error {
+				if err := repo.Add(ctx, phrase); err != nil {
+					return fmt.Errorf("failed to add hallucination phrase: %w", err)
+				}
+				fmt.Printf("Added hallucination phrase: %s\n", phrase)
+				return nil
+			})
+		},
+	}
+}
+
+func newHallucinationsRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove [PHRASE]",
+		Short: "Remove a hallucination phrase from the workspace's list",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withHallucinationRepository(func(ctx context.Context, cfg *config.Config, repo transcription.HallucinationRepository) error {
+				if err := repo.Remove(ctx, args[0]); err != nil {
+					return fmt.Errorf("failed to remove hallucination phrase: %w", err)
+				}
+				fmt.Printf("Removed hallucination phrase: %s\n", args[0])
+				return nil
+			})
+		},
+	}
+}
+
+// withHallucinationRepository runs fn with the configuration and the workspace's hallucination phrase repository
+func withHallucinationRepository(fn func(ctx context.Context, cfg *config.Config, repo transcription.HallucinationRepository) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Load database configuration
+	cfg, err := config.NewConfig()
+	if err != nil {
+		return err
+	}
+
+	// Create database connection
+	dbPool, err := config.NewDatabasePool(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer dbPool.Close()
+
+	return fn(ctx, cfg, transcription.NewHallucinationRepository(dbPool))
+}
+
+// configuredHallucinations returns the configured phrases, or the built-in ones when none are configured
+func configuredHallucinations(cfg config.HallucinationConfig) []string {
+	if cfg.Phrases == nil {
+		return transcriptionSvc.DefaultHallucinationPhrases
+	}
+	return cfg.Phrases
+}
+
+// newHallucinationFilter builds the filter applied to new transcriptions from the configured
+// phrases and those of the workspace's database list; nil when the mode is off
+func newHallucinationFilter(ctx context.Context, cfg config.HallucinationConfig, repo transcription.HallucinationRepository) (*transcriptionSvc.HallucinationFilter, error) {
+	if cfg.Mode == model.HallucinationOff {
+		return nil, nil
+	}
+
+	stored, err := repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list hallucination phrases: %w", err)
+	}
+	phrases := append(append([]string{}, configuredHallucinations(cfg)...), stored...)
+	return transcriptionSvc.NewHallucinationFilter(cfg.Mode, phrases)
+}
+
+// formatHallucinations formats a hallucination report as "3 flagged (Thanks for watching! ×2, ...)",
+// the most frequent phrase first
+func formatHallucinations(report *model.HallucinationReport) string {
+	phrases := make([]string, 0, len(report.Phrases))
+	for phrase := range report.Phrases {
+		phrases = append(phrases, phrase)
+	}
+	sort.Slice(phrases, func(i, j int) bool {
+		if report.Phrases[phrases[i]] != report.Phrases[phrases[j]] {
+			return report.Phrases[phrases[i]] > report.Phrases[phrases[j]]
+		}
+		return phrases[i] < phrases[j]
+	})
+
+	parts := make([]string, len(phrases))
+	for i, phrase := range phrases {
+		parts[i] = fmt.Sprintf("%s ×%d", phrase, report.Phrases[phrase])
+	}
+	verb := "flagged"
+	if report.Mode == model.HallucinationRemove {
+		verb = "removed"
+	}
+	return fmt.Sprintf("%d %s (%s)", report.Count(), verb, strings.Join(parts, ", "))
+}
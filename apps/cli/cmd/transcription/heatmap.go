@@ -0,0 +1,147 @@
+package transcription
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// Confidence bands of the heatmap, on whisper's avg_logprob (0 is certain, lower is worse)
+const (
+	heatmapHighConfidence = -0.4 // At or above: green
+	heatmapLowConfidence  = -0.8 // Below: red; in between: yellow
+)
+
+// ANSI colors of the confidence bands
+const (
+	ansiReset  = "\033[0m"
+	ansiGreen  = "\033[32m"
+	ansiYellow = "\033[33m"
+	ansiRed    = "\033[31m"
+	ansiGray   = "\033[90m"
+)
+
+const (
+	heatmapBarWidth   = 40 // Characters of the longest histogram bar
+	heatmapMaxRegions = 10 // Low-confidence regions listed in the summary
+)
+
+// confidenceBand is one row of the heatmap histogram
+type confidenceBand struct {
+	label string
+	color string
+	count int
+}
+
+// lowRegion is a run of consecutive low-confidence segments
+type lowRegion struct {
+	start, end string
+	segments   int
+}
+
+// heatmapStreamWriter writes text output with each segment colored by its confidence, followed
+// by a histogram of the bands and the low-confidence regions to review
+type heatmapStreamWriter struct {
+	textStreamWriter
+	color bool // ANSI colors; off when output is not a terminal or NO_COLOR is set
+
+	bands   [4]confidenceBand // high, medium, low, unknown
+	regions []lowRegion
+	inLow   bool // The previous segment was low-confidence
+}
+
+// newHeatmapStreamWriter returns a heatmap writer; color is used when the output is a terminal
+func newHeatmapStreamWriter(w io.Writer, terminal bool) *heatmapStreamWriter {
+	_, noColor := os.LookupEnv("NO_COLOR")
+	return &heatmapStreamWriter{
+		textStreamWriter: textStreamWriter{w: w},
+		color:            terminal && !noColor,
+		bands: [4]confidenceBand{
+			{label: fmt.Sprintf("high (>= %.1f)", heatmapHighConfidence), color: ansiGreen},
+			{label: fmt.Sprintf("medium (>= %.1f)", heatmapLowConfidence), color: ansiYellow},
+			{label: fmt.Sprintf("low (< %.1f)", heatmapLowConfidence), color: ansiRed},
+			{label: "unknown", color: ansiGray},
+		},
+	}
+}
+
+// band returns the index of the band of a confidence
+func band(confidence *float64) int {
+	switch {
+	case confidence == nil:
+		return 3
+	case *confidence >= heatmapHighConfidence:
+		return 0
+	case *confidence >= heatmapLowConfidence:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func (s *heatmapStreamWriter) WriteSegment(segment *model.TranscriptionSegment) error {
+	s.count++
+	b := band(segment.Confidence)
+	s.bands[b].count++
+
+	if b == 2 {
+		if s.inLow {
+			region := &s.regions[len(s.regions)-1]
+			region.end = segment.EndTime
+			region.segments++
+		} else {
+			s.regions = append(s.regions, lowRegion{start: segment.StartTime, end: segment.EndTime, segments: 1})
+		}
+	}
+	s.inLow = b == 2
+
+	confidence := "  n/a"
+	if segment.Confidence != nil {
+		confidence = fmt.Sprintf("%5.2f", *segment.Confidence)
+	}
+	line := fmt.Sprintf("[%s - %s] (%s) %s", segment.StartTime, segment.EndTime, confidence, segment.Text)
+	_, err := fmt.Fprintln(s.w, s.paint(s.bands[b].color, line))
+	return err
+}
+
+func (s *heatmapStreamWriter) Close() error {
+	fmt.Fprintf(s.w, "\nTotal segments: %d\n", s.count)
+	if s.count == 0 {
+		return nil
+	}
+
+	largest := 0
+	for _, b := range s.bands {
+		largest = max(largest, b.count)
+	}
+	fmt.Fprintf(s.w, "\nConfidence (avg_logprob):\n")
+	for _, b := range s.bands {
+		bar := strings.Repeat("█", b.count*heatmapBarWidth/largest)
+		fmt.Fprintf(s.w, "  %-16s %s %d (%.0f%%)\n", b.label, s.paint(b.color, bar), b.count, 100*float64(b.count)/float64(s.count))
+	}
+
+	if len(s.regions) == 0 {
+		_, err := fmt.Fprintf(s.w, "\nNo low-confidence regions\n")
+		return err
+	}
+	fmt.Fprintf(s.w, "\nLow-confidence regions to review (%d):\n", len(s.regions))
+	for i, region := range s.regions {
+		if i == heatmapMaxRegions {
+			fmt.Fprintf(s.w, "  ... and %d more\n", len(s.regions)-heatmapMaxRegions)
+			break
+		}
+		fmt.Fprintf(s.w, "  %s - %s (%d segment(s))\n", region.start, region.end, region.segments)
+	}
+	return nil
+}
+
+// paint wraps text in an ANSI color when colors are on
+func (s *heatmapStreamWriter) paint(color, text string) string {
+	if !s.color || text == "" {
+		return text
+	}
+	return color + text + ansiReset
+}
@@ -0,0 +1,126 @@
+package transcription
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/lock"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+	transcriptionSvc "github.com/Taichi-iskw/yt-lang/internal/service/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/subtitle"
+)
+
+func NewImportCmd() *cobra.Command {
+	importCmd := &cobra.Command{
+		Use:   "import [VIDEO_ID]",
+		Short: "Import an SRT or WebVTT file as a transcription",
+		Long: `Import existing subtitles of a video as a completed transcription, without running Whisper.
+Each cue becomes a segment with its timing; markup is stripped from the text. The format is
+detected from the file (WebVTT files start with WEBVTT, anything else is read as SRT).
+
+The transcription is recorded with source "import" and can be translated, studied and exported
+like a Whisper transcription. transcription_completed hooks run after the import.
+
+Examples:
+  yt-lang transcription import dQw4w9WgXcQ --file captions.srt --lang en
+  yt-lang transcription import dQw4w9WgXcQ --file captions.ja.vtt --lang ja`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			videoID := args[0]
+
+			// Get flags
+			path, _ := cmd.Flags().GetString("file")
+			language, _ := cmd.Flags().GetString("lang")
+
+			cues, err := parseSubtitleFile(path)
+			if err != nil {
+				return err
+			}
+
+			// Create context
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			defer cancel()
+
+			// Load database configuration
+			cfg, err := config.NewConfig()
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			// Create database connection
+			dbPool, err := config.NewDatabasePool(ctx, cfg)
+			if err != nil {
+				return fmt.Errorf("failed to connect to database: %w", err)
+			}
+			defer dbPool.Close()
+
+			hooks, err := config.NewHookDispatcher(cfg)
+			if err != nil {
+				return err
+			}
+
+			// Lock like transcription create so an import never races a whisper run
+			transcriptionService := transcriptionSvc.NewHookedService(
+				transcriptionSvc.NewLockingService(
+					transcriptionSvc.NewTranscriptionServiceWithAllDependencies(
+						transcription.NewRepository(dbPool),
+						transcription.NewSegmentRepository(dbPool),
+						nil, // WhisperService not needed for importing
+						nil, // AudioDownloadService not needed for importing
+						video.NewRepository(dbPool),
+						nil, // Imported transcriptions have no whisper artifact
+					),
+					lock.NewPostgresLocker(dbPool.Pool),
+				),
+				hooks,
+			)
+
+			result, err := transcriptionService.ImportTranscription(ctx, videoID, language, cues)
+			if err != nil {
+				return fmt.Errorf("failed to import transcription: %w", err)
+			}
+
+			fmt.Printf("✅ Imported %d segments from %s\n", len(cues), path)
+			fmt.Printf("ID: %s\n", result.ID)
+			fmt.Printf("Video ID: %s\n", result.VideoID)
+			fmt.Printf("Language: %s\n", result.Language)
+			if result.TotalDuration != nil {
+				fmt.Printf("Duration: %s\n", *result.TotalDuration)
+			}
+
+			return nil
+		},
+	}
+
+	// Add flags
+	importCmd.Flags().String("file", "", "SRT or WebVTT file to import (required)")
+	importCmd.Flags().String("lang", "", "Language of the subtitles (e.g., 'en', 'ja') (required)")
+	importCmd.MarkFlagRequired("file")
+	importCmd.MarkFlagRequired("lang")
+
+	return importCmd
+}
+
+// parseSubtitleFile reads the cues of an SRT or WebVTT file
+func parseSubtitleFile(path string) ([]subtitle.Cue, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open subtitle file: %w", err)
+	}
+	defer file.Close()
+
+	cues, err := subtitle.Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(cues) == 0 {
+		return nil, fmt.Errorf("no subtitle cues found in %s", path)
+	}
+	return cues, nil
+}
@@ -0,0 +1,53 @@
+package transcription
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/channel"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+	"github.com/Taichi-iskw/yt-lang/internal/service/langprofile"
+)
+
+// newProfileService builds the service reading the languages inferred by channel languages
+func newProfileService(dbPool *config.DatabasePool) langprofile.ProfileService {
+	return langprofile.NewProfileService(channel.NewRepository(dbPool), video.NewRepository(dbPool), transcription.NewRepository(dbPool))
+}
+
+// channelLanguageOr returns the channel language inferred by channel languages, reporting that
+// it is used, or language when the channel has none
+func channelLanguageOr(inferred string, err error, language string) string {
+	if err != nil {
+		// The default is a convenience; detection still works without it
+		fmt.Fprintf(os.Stderr, "Warning: failed to read the channel language: %v\n", err)
+		return language
+	}
+	if inferred == "" {
+		return language
+	}
+	fmt.Fprintf(os.Stderr, "Using the channel language %s (pass --language to override)\n", inferred)
+	return inferred
+}
+
+// formatLanguageCandidates formats detected languages as "ja 0.48, en 0.41"
+func formatLanguageCandidates(candidates []model.LanguageCandidate) string {
+	parts := make([]string, len(candidates))
+	for i, c := range candidates {
+		parts[i] = fmt.Sprintf("%s %.2f", c.Language, c.Probability)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// warnLowLanguageConfidence warns when the most likely detected language of t is below
+// threshold, which usually means a multilingual or music-heavy video
+func warnLowLanguageConfidence(t *model.Transcription, threshold float64) {
+	if len(t.LanguageCandidates) == 0 || t.LanguageCandidates[0].Probability >= threshold {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Warning: language detection confidence is low (%s); the video may be multilingual or music-heavy, consider --language\n",
+		formatLanguageCandidates(t.LanguageCandidates))
+}
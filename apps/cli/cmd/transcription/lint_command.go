@@ -0,0 +1,113 @@
+package transcription
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/subtitle"
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
+)
+
+func NewLintCmd() *cobra.Command {
+	lintCmd := &cobra.Command{
+		Use:   "lint [TRANSCRIPTION_ID]...",
+		Short: "Check transcriptions for timing and reading speed problems",
+		Long: `Check the segments of transcriptions, as subtitle cues, for segments out of order or
+overlapping the previous one, segments without duration or longer than --max-duration, and
+reading speeds above --max-cps characters per second. Line lengths are not checked: exports
+wrap and split segments by the subtitle style (see subtitle lint for exported files).
+Exits with an error when any transcription has problems, so it can gate scripts and CI.
+
+Examples:
+  yt-lang transcription lint abc123
+  yt-lang transcription lint abc123 def456 --max-cps 17 --format json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			if format != "table" && format != "json" {
+				return fmt.Errorf("unsupported format: %s (supported: table, json)", format)
+			}
+			opts := subtitle.LintOptions{}
+			opts.MaxCharsPerSecond, _ = cmd.Flags().GetFloat64("max-cps")
+			opts.MaxDuration, _ = cmd.Flags().GetDuration("max-duration")
+
+			// Create context
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			// Load database configuration
+			cfg, err := config.NewConfig()
+			if err != nil {
+				return err
+			}
+
+			// Create database connection
+			dbPool, err := config.NewDatabasePool(ctx, cfg)
+			if err != nil {
+				return err
+			}
+			defer dbPool.Close()
+
+			transcriptionRepo := transcription.NewRepository(dbPool)
+			segmentRepo := transcription.NewSegmentRepository(dbPool)
+
+			results := make([]handler.LintResult, 0, len(args))
+			for _, id := range args {
+				if _, err := transcriptionRepo.GetByID(ctx, id); err != nil {
+					return fmt.Errorf("failed to get transcription %s: %w", id, err)
+				}
+				segments, err := segmentRepo.GetByTranscriptionID(ctx, id)
+				if err != nil {
+					return fmt.Errorf("failed to get segments of transcription %s: %w", id, err)
+				}
+				cues, err := segmentLintCues(segments)
+				if err != nil {
+					return fmt.Errorf("transcription %s: %w", id, err)
+				}
+				results = append(results, handler.LintResult{Name: id, LintReport: subtitle.LintCues(cues, opts)})
+			}
+
+			passed, err := handler.WriteLintResults(cmd.OutOrStdout(), results, format)
+			if err != nil {
+				return err
+			}
+			if !passed {
+				// The report already explains the problems; usage would only bury it
+				cmd.SilenceUsage = true
+				return fmt.Errorf("transcription lint found problems")
+			}
+			return nil
+		},
+	}
+
+	// Add flags
+	lintCmd.Flags().Float64("max-cps", subtitle.DefaultMaxCharsPerSecond, "Maximum reading speed in characters per second (0 disables)")
+	lintCmd.Flags().Duration("max-duration", subtitle.DefaultMaxCueDuration, "Maximum segment duration (0 disables)")
+	lintCmd.Flags().String("format", "table", "Output format: table, json")
+
+	return lintCmd
+}
+
+// segmentLintCues returns the segments, in stored order, as single-line cues
+func segmentLintCues(segments []*model.TranscriptionSegment) ([]subtitle.Cue, error) {
+	cues := make([]subtitle.Cue, 0, len(segments))
+	for _, segment := range segments {
+		start, err := timecode.ParseInterval(segment.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("segment %d: %w", segment.SegmentIndex, err)
+		}
+		end, err := timecode.ParseInterval(segment.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("segment %d: %w", segment.SegmentIndex, err)
+		}
+		cues = append(cues, subtitle.Cue{Start: start, End: end, Lines: []string{segment.Text}})
+	}
+	return cues, nil
+}
@@ -0,0 +1,85 @@
+package transcription
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+	transcriptionSvc "github.com/Taichi-iskw/yt-lang/internal/service/transcription"
+)
+
+func NewMergeCmd() *cobra.Command {
+	mergeCmd := &cobra.Command{
+		Use:   "merge [TRANSCRIPTION_ID] [TRANSCRIPTION_ID]...",
+		Short: "Merge the transcriptions of a multi-part video",
+		Long: `Concatenate completed transcriptions, in the order given, into a new transcription.
+Each part's segments are shifted by the duration of the parts before it, so translation and
+export treat the whole talk as one unit. The parts are kept unchanged.
+
+The merged transcription belongs to a new synthetic video titled by --as, in the channel of
+the first part, unless --video attaches it to an existing video.
+
+Examples:
+  yt-lang transcription merge abc123 def456 --as "Full Talk"
+  yt-lang transcription merge abc123 def456 ghi789 --video dQw4w9WgXcQ`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			title, _ := cmd.Flags().GetString("as")
+			videoID, _ := cmd.Flags().GetString("video")
+
+			// Create context
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			defer cancel()
+
+			// Load database configuration
+			cfg, err := config.NewConfig()
+			if err != nil {
+				return err
+			}
+
+			// Create database connection
+			dbPool, err := config.NewDatabasePool(ctx, cfg)
+			if err != nil {
+				return err
+			}
+			defer dbPool.Close()
+
+			// Create repositories and service
+			transcriptionService := transcriptionSvc.NewTranscriptionServiceWithAllDependencies(
+				transcription.NewRepository(dbPool),
+				transcription.NewSegmentRepository(dbPool),
+				nil, // WhisperService not needed for merging
+				nil, // AudioDownloadService not needed for merging
+				video.NewRepository(dbPool),
+				nil, // Merged transcriptions have no whisper artifact
+			)
+
+			merged, err := transcriptionService.MergeTranscriptions(ctx, args, transcriptionSvc.MergeOptions{
+				Title:   title,
+				VideoID: videoID,
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("✅ Merged %d transcriptions into %s\n", len(args), merged.ID)
+			fmt.Printf("Video: %s\n", merged.VideoID)
+			if merged.TotalDuration != nil {
+				fmt.Printf("Duration: %s\n", *merged.TotalDuration)
+			}
+			return nil
+		},
+	}
+
+	// Add flags
+	mergeCmd.Flags().String("as", "", "Title of the synthetic video for the merged transcription (default: first part's title + \" (merged)\")")
+	mergeCmd.Flags().String("video", "", "Attach the merged transcription to this existing video instead of creating one")
+	mergeCmd.MarkFlagsMutuallyExclusive("as", "video")
+
+	return mergeCmd
+}
@@ -0,0 +1,212 @@
+package transcription
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/subtitle"
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
+)
+
+// segmentStreamWriter writes transcription output incrementally as segments are read
+type segmentStreamWriter interface {
+	// WriteHeader writes transcription metadata before any segment
+	WriteHeader(transcription *model.Transcription) error
+	// WriteSegment writes a single segment
+	WriteSegment(segment *model.TranscriptionSegment) error
+	// Close writes any trailing output
+	Close() error
+}
+
+// newSegmentStreamWriter returns a stream writer for the given output format.
+// Subtitle formats apply rules to the cues as they are written.
+func newSegmentStreamWriter(format string, w io.Writer, rules subtitle.Rules) (segmentStreamWriter, error) {
+	switch format {
+	case "json":
+		return &jsonStreamWriter{w: w}, nil
+	case "srt":
+		return &srtStreamWriter{w: w, processor: subtitle.NewProcessor(rules)}, nil
+	case "vtt":
+		return &vttStreamWriter{w: w, processor: subtitle.NewProcessor(rules)}, nil
+	case "text", "":
+		return &textStreamWriter{w: w}, nil
+	default:
+		return nil, fmt.Errorf("unsupported format: %s (supported: text, json, srt, vtt)", format)
+	}
+}
+
+// pushSegment feeds a segment's timing and text into a subtitle processor
+func pushSegment(processor *subtitle.Processor, segment *model.TranscriptionSegment) ([]subtitle.Cue, error) {
+	start, err := timecode.ParseInterval(segment.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("segment %d: %w", segment.SegmentIndex, err)
+	}
+	end, err := timecode.ParseInterval(segment.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("segment %d: %w", segment.SegmentIndex, err)
+	}
+	var speaker string
+	if segment.Speaker != nil {
+		speaker = *segment.Speaker
+	}
+	return processor.PushVoice(start, end, speaker, segment.Text), nil
+}
+
+// textStreamWriter writes human-readable output
+type textStreamWriter struct {
+	w       io.Writer
+	count   int
+	flagged map[int]bool // Indexes of the segments flagged as hallucinations
+}
+
+func (s *textStreamWriter) WriteHeader(t *model.Transcription) error {
+	fmt.Fprintf(s.w, "Transcription ID: %s\n", t.ID)
+	fmt.Fprintf(s.w, "Video ID: %s\n", t.VideoID)
+	fmt.Fprintf(s.w, "Language: %s\n", t.Language)
+	fmt.Fprintf(s.w, "Status: %s\n", t.Status)
+	if t.DetectedLanguage != nil {
+		fmt.Fprintf(s.w, "Detected Language: %s\n", *t.DetectedLanguage)
+	}
+	if len(t.LanguageCandidates) > 0 {
+		fmt.Fprintf(s.w, "Language Candidates: %s\n", formatLanguageCandidates(t.LanguageCandidates))
+	}
+	if len(t.WhisperAttempts) > 1 {
+		fmt.Fprintf(s.w, "Whisper Attempts: %s\n", formatWhisperAttempts(t.WhisperAttempts))
+	}
+	if t.Hallucinations != nil {
+		fmt.Fprintf(s.w, "Hallucinations: %s\n", formatHallucinations(t.Hallucinations))
+		s.flagged = make(map[int]bool, len(t.Hallucinations.Segments))
+		for _, index := range t.Hallucinations.Segments {
+			s.flagged[index] = true
+		}
+	}
+	fmt.Fprintf(s.w, "Created: %s\n", t.CreatedAt.Format(time.RFC3339))
+	if t.CompletedAt != nil {
+		fmt.Fprintf(s.w, "Completed: %s\n", t.CompletedAt.Format(time.RFC3339))
+	}
+	_, err := fmt.Fprintf(s.w, "\nSegments:\n")
+	return err
+}
+
+func (s *textStreamWriter) WriteSegment(segment *model.TranscriptionSegment) error {
+	s.count++
+	mark := ""
+	if s.flagged[segment.SegmentIndex] {
+		mark = " (hallucination?)"
+	}
+	_, err := fmt.Fprintf(s.w, "[%s - %s] %s%s\n", segment.StartTime, segment.EndTime, segment.Text, mark)
+	return err
+}
+
+func (s *textStreamWriter) Close() error {
+	_, err := fmt.Fprintf(s.w, "\nTotal segments: %d\n", s.count)
+	return err
+}
+
+// srtStreamWriter writes SRT cues as soon as the subtitle processor releases them
+type srtStreamWriter struct {
+	w         io.Writer
+	processor *subtitle.Processor
+	count     int
+}
+
+func (s *srtStreamWriter) WriteHeader(t *model.Transcription) error {
+	// SRT has no header
+	return nil
+}
+
+func (s *srtStreamWriter) WriteSegment(segment *model.TranscriptionSegment) error {
+	cues, err := pushSegment(s.processor, segment)
+	if err != nil {
+		return err
+	}
+	return s.writeCues(cues)
+}
+
+func (s *srtStreamWriter) Close() error {
+	return s.writeCues(s.processor.Flush())
+}
+
+func (s *srtStreamWriter) writeCues(cues []subtitle.Cue) error {
+	for _, cue := range cues {
+		s.count++
+		if err := subtitle.WriteSRTCue(s.w, s.count, cue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// vttStreamWriter writes WebVTT cues as soon as the subtitle processor releases them
+type vttStreamWriter struct {
+	w         io.Writer
+	processor *subtitle.Processor
+}
+
+func (s *vttStreamWriter) WriteHeader(t *model.Transcription) error {
+	return subtitle.WriteVTTHeader(s.w)
+}
+
+func (s *vttStreamWriter) WriteSegment(segment *model.TranscriptionSegment) error {
+	cues, err := pushSegment(s.processor, segment)
+	if err != nil {
+		return err
+	}
+	return s.writeCues(cues)
+}
+
+func (s *vttStreamWriter) Close() error {
+	return s.writeCues(s.processor.Flush())
+}
+
+func (s *vttStreamWriter) writeCues(cues []subtitle.Cue) error {
+	for _, cue := range cues {
+		if err := subtitle.WriteVTTCue(s.w, cue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonStreamWriter writes {"transcription": ..., "segments": [...]} without buffering the segment array
+type jsonStreamWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (s *jsonStreamWriter) WriteHeader(t *model.Transcription) error {
+	header, err := json.MarshalIndent(t, "  ", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(s.w, "{\n  \"transcription\": %s,\n  \"segments\": [", header)
+	return err
+}
+
+func (s *jsonStreamWriter) WriteSegment(segment *model.TranscriptionSegment) error {
+	data, err := json.MarshalIndent(segment, "    ", "  ")
+	if err != nil {
+		return err
+	}
+
+	separator := ","
+	if s.count == 0 {
+		separator = ""
+	}
+	s.count++
+
+	_, err = fmt.Fprintf(s.w, "%s\n    %s", separator, data)
+	return err
+}
+
+func (s *jsonStreamWriter) Close() error {
+	if s.count == 0 {
+		_, err := fmt.Fprint(s.w, "]\n}\n")
+		return err
+	}
+	_, err := fmt.Fprint(s.w, "\n  ]\n}\n")
+	return err
+}
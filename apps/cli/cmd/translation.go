@@ -26,6 +26,8 @@ func createTranslationCommand() *cobra.Command {
 			cmd.Println("  get [TRANSLATION_ID]       Get a translation")
 			cmd.Println("  list [TRANSCRIPTION_ID]    List translations for transcription")
 			cmd.Println("  delete [TRANSLATION_ID]    Delete a translation")
+			cmd.Println("  interactive [TRANSCRIPTION_ID]  Review translations segment by segment")
+			cmd.Println("  qa [TRANSCRIPTION_ID]      Check translated segments for alignment problems")
 			cmd.Println("")
 			cmd.Println("Example:")
 			cmd.Println("  ytlang translation create trans-123 --target-lang ja")
@@ -45,6 +47,8 @@ func createTranslationCommandWithRealServices(baseCmd *cobra.Command) *cobra.Com
 	baseCmd.AddCommand(translation.NewGetCommand(nil))
 	baseCmd.AddCommand(translation.NewListCommand(nil))
 	baseCmd.AddCommand(translation.NewDeleteCommand(nil))
+	baseCmd.AddCommand(translation.NewInteractiveCommand(nil))
+	baseCmd.AddCommand(translation.NewQACommand(nil))
 
 	return baseCmd
 }
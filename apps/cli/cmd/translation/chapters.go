@@ -0,0 +1,81 @@
+package translation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/run"
+	translationSvc "github.com/Taichi-iskw/yt-lang/internal/service/translation"
+)
+
+// newChapterRun prepares the run of a --per-chapter translation: one pending item per chapter,
+// identified by its time range
+func newChapterRun(transcriptionID, targetLang, style, strategy string, polish bool, chapters []*translationSvc.Chapter) (*model.Run, []*model.RunItem) {
+	chapterRun := &model.Run{
+		Command: model.RunCommandTranslateChapters,
+		Target:  transcriptionID,
+		Options: map[string]string{"target_lang": targetLang, "style": style, "strategy": strategy, "polish": strconv.FormatBool(polish)},
+	}
+	items := make([]*model.RunItem, len(chapters))
+	for i, chapter := range chapters {
+		items[i] = &model.RunItem{ItemID: chapter.Range(), Title: chapter.Title}
+	}
+	return chapterRun, items
+}
+
+// loadChapterRun retrieves a --per-chapter run to resume
+func loadChapterRun(ctx context.Context, runRepo run.Repository, runID string) (*model.Run, error) {
+	chapterRun, err := runRepo.GetByID(ctx, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run: %w", err)
+	}
+	if chapterRun.Command != model.RunCommandTranslateChapters {
+		return nil, fmt.Errorf("run %s is a %s run, not a translation create --per-chapter run", runID, chapterRun.Command)
+	}
+	return chapterRun, nil
+}
+
+// translateChapters translates the chapters of a run's items in turn, each saved as soon as it is
+// translated, recording the outcome of each with the ID of the chapter's first translation. A
+// failing chapter is reported and the next one translated; failing to record progress only warns.
+func translateChapters(ctx context.Context, out io.Writer, service translationSvc.TranslationService, runRepo run.Repository, chapterRun *model.Run, items []*model.RunItem, targetLang string) error {
+	return handler.ProcessRun(ctx, out, runRepo, chapterRun, items, handler.RunBatch{
+		Noun: "chapters",
+		Verb: "translate",
+		Done: "Translated",
+		Process: func(ctx context.Context, item *model.RunItem) (string, error) {
+			chapter, err := translationSvc.ParseChapterRange(item.ItemID)
+			if err != nil {
+				return "", err
+			}
+			result, err := service.CreateTranslation(translationSvc.WithChapters(ctx, []*translationSvc.Chapter{chapter}), chapterRun.Target, targetLang)
+			if err != nil {
+				return "", err
+			}
+			return strconv.Itoa(result.ID), nil
+		},
+	})
+}
+
+// openRunRepository connects to the database the runs of --per-chapter translations are
+// recorded in; the returned function closes the connection
+func openRunRepository() (run.Repository, func(), error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cfg, err := config.NewConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	dbPool, err := config.NewDatabasePool(ctx, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return run.NewRepository(dbPool), dbPool.Close, nil
+}
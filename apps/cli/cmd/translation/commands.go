@@ -13,10 +13,17 @@ func NewTranslationCommand(service translation.TranslationService) *cobra.Comman
 		Long:  `Create, get, list, and delete translations for transcriptions`,
 	}
 
+	cmd.PersistentFlags().Bool("debug-plamo", false, "Log PLaMo prompts and raw responses to ~/.yt-lang/logs/plamo")
+
 	// Add subcommands
 	cmd.AddCommand(NewCreateCommand(service))
 	cmd.AddCommand(NewGetCommand(service))
 	cmd.AddCommand(NewListCommand(service))
+	cmd.AddCommand(NewExportCommand(service))
+	cmd.AddCommand(NewCompareCommand(service))
+	cmd.AddCommand(NewInteractiveCommand(service))
+	cmd.AddCommand(NewQACommand(service))
+	cmd.AddCommand(NewProbeCommand())
 	cmd.AddCommand(NewDeleteCommand(service))
 
 	return cmd
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Taichi-iskw/yt-lang/internal/model"
 	"github.com/Taichi-iskw/yt-lang/internal/service/translation"
@@ -17,7 +19,13 @@ type mockTranslationService struct {
 	CreateTranslationFunc func(ctx context.Context, transcriptionID string, targetLang string) (*model.Translation, error)
 	GetTranslationFunc    func(ctx context.Context, id string) (*model.Translation, []*translation.TranslationSegment, error)
 	ListTranslationsFunc  func(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error)
+	CountTranslationsFunc func(ctx context.Context, transcriptionID string) (int, error)
 	DeleteTranslationFunc func(ctx context.Context, id string) error
+	GetAlignedTranslationFunc func(ctx context.Context, transcriptionID string, targetLang string) ([]*translation.TranslationSegment, error)
+	CompareTranslationsFunc   func(ctx context.Context, opts translation.CompareOptions) (*translation.TranslationComparison, error)
+	TranslateInteractivelyFunc func(ctx context.Context, opts translation.InteractiveOptions, reviewer translation.Reviewer) (*translation.InteractiveSummary, error)
+	CheckAlignmentFunc         func(ctx context.Context, opts translation.QAOptions) (*translation.QAReport, error)
+	PlanChaptersFunc           func(ctx context.Context, transcriptionID string, length time.Duration) ([]*translation.Chapter, error)
 }
 
 func (m *mockTranslationService) CreateTranslation(ctx context.Context, transcriptionID string, targetLang string) (*model.Translation, error) {
@@ -34,6 +42,41 @@ func (m *mockTranslationService) GetTranslation(ctx context.Context, id string)
 	return nil, nil, nil
 }
 
+func (m *mockTranslationService) GetAlignedTranslation(ctx context.Context, transcriptionID string, targetLang string) ([]*translation.TranslationSegment, error) {
+	if m.GetAlignedTranslationFunc != nil {
+		return m.GetAlignedTranslationFunc(ctx, transcriptionID, targetLang)
+	}
+	return nil, nil
+}
+
+func (m *mockTranslationService) CompareTranslations(ctx context.Context, opts translation.CompareOptions) (*translation.TranslationComparison, error) {
+	if m.CompareTranslationsFunc != nil {
+		return m.CompareTranslationsFunc(ctx, opts)
+	}
+	return nil, nil
+}
+
+func (m *mockTranslationService) TranslateInteractively(ctx context.Context, opts translation.InteractiveOptions, reviewer translation.Reviewer) (*translation.InteractiveSummary, error) {
+	if m.TranslateInteractivelyFunc != nil {
+		return m.TranslateInteractivelyFunc(ctx, opts, reviewer)
+	}
+	return &translation.InteractiveSummary{}, nil
+}
+
+func (m *mockTranslationService) CheckAlignment(ctx context.Context, opts translation.QAOptions) (*translation.QAReport, error) {
+	if m.CheckAlignmentFunc != nil {
+		return m.CheckAlignmentFunc(ctx, opts)
+	}
+	return nil, nil
+}
+
+func (m *mockTranslationService) PlanChapters(ctx context.Context, transcriptionID string, length time.Duration) ([]*translation.Chapter, error) {
+	if m.PlanChaptersFunc != nil {
+		return m.PlanChaptersFunc(ctx, transcriptionID, length)
+	}
+	return nil, nil
+}
+
 func (m *mockTranslationService) ListTranslations(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error) {
 	if m.ListTranslationsFunc != nil {
 		return m.ListTranslationsFunc(ctx, transcriptionID, limit, offset)
@@ -41,6 +84,13 @@ func (m *mockTranslationService) ListTranslations(ctx context.Context, transcrip
 	return []*model.Translation{}, nil
 }
 
+func (m *mockTranslationService) CountTranslations(ctx context.Context, transcriptionID string) (int, error) {
+	if m.CountTranslationsFunc != nil {
+		return m.CountTranslationsFunc(ctx, transcriptionID)
+	}
+	return 0, nil
+}
+
 func (m *mockTranslationService) DeleteTranslation(ctx context.Context, id string) error {
 	if m.DeleteTranslationFunc != nil {
 		return m.DeleteTranslationFunc(ctx, id)
@@ -100,6 +150,42 @@ func TestCreateCommand(t *testing.T) {
 			setupMock:  func(m *mockTranslationService) {},
 			wantErr:    true,
 		},
+		{
+			name:       "stitched chapters",
+			args:       []string{"trans-123", "--per-chapter", "--stitch"},
+			targetLang: "ja",
+			setupMock: func(m *mockTranslationService) {
+				m.PlanChaptersFunc = func(ctx context.Context, transcriptionID string, length time.Duration) ([]*translation.Chapter, error) {
+					assert.Equal(t, translation.DefaultChapterLength, length)
+					return []*translation.Chapter{{Start: 0, End: 10 * time.Minute}, {Start: 10 * time.Minute, End: 20 * time.Minute}}, nil
+				}
+				m.CreateTranslationFunc = func(ctx context.Context, transcriptionID string, targetLang string) (*model.Translation, error) {
+					return &model.Translation{TargetLanguage: "ja", Source: "plamo"}, nil
+				}
+			},
+			expectedOutput: "Translating 2 chapters, saved together once all are translated",
+		},
+		{
+			name:       "stitch without per-chapter",
+			args:       []string{"trans-123", "--stitch"},
+			targetLang: "ja",
+			setupMock:  func(m *mockTranslationService) {},
+			wantErr:    true,
+		},
+		{
+			name:       "unsupported strategy",
+			args:       []string{"trans-123", "--strategy", "random"},
+			targetLang: "ja",
+			setupMock:  func(m *mockTranslationService) {},
+			wantErr:    true,
+		},
+		{
+			name:       "resume with a transcription ID",
+			args:       []string{"trans-123", "--resume", "run-1"},
+			targetLang: "ja",
+			setupMock:  func(m *mockTranslationService) {},
+			wantErr:    true,
+		},
 		{
 			name:       "translation service error",
 			args:       []string{"trans-456"},
@@ -200,6 +286,39 @@ func TestGetCommand(t *testing.T) {
 			expectedOutput: `"target_language": "ja"`,
 			wantErr:        false,
 		},
+		{
+			name:   "get translation in srt format",
+			args:   []string{"1"},
+			format: "srt",
+			setupMock: func(m *mockTranslationService) {
+				m.GetTranslationFunc = func(ctx context.Context, id string) (*model.Translation, []*translation.TranslationSegment, error) {
+					return &model.Translation{ID: 1, TargetLanguage: "ja", TranslatedText: "こんにちは"}, []*translation.TranslationSegment{
+						{StartTime: "00:00:01", EndTime: "00:00:03", Text: "Hello", TranslatedText: "こんにちは"},
+					}, nil
+				}
+			},
+			expectedOutput: "00:00:01,000 --> 00:00:03,000\nこんにちは",
+			wantErr:        false,
+		},
+		{
+			name:   "get translation with a template",
+			args:   []string{"1", "--template", `{{.ID}}\t{{.TargetLanguage}}`},
+			format: "text",
+			setupMock: func(m *mockTranslationService) {
+				m.GetTranslationFunc = func(ctx context.Context, id string) (*model.Translation, []*translation.TranslationSegment, error) {
+					return &model.Translation{ID: 1, TargetLanguage: "ja", TranslatedText: "テスト"}, nil, nil
+				}
+			},
+			expectedOutput: "1\tja\n",
+			wantErr:        false,
+		},
+		{
+			name:      "template with another format",
+			args:      []string{"1", "--template", "{{.ID}}"},
+			format:    "json",
+			setupMock: func(m *mockTranslationService) {},
+			wantErr:   true,
+		},
 		{
 			name:      "missing translation ID",
 			args:      []string{},
@@ -246,3 +365,498 @@ func TestGetCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestExportCommand(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		format         string
+		setupMock      func(*mockTranslationService)
+		expectedOutput []string
+		wantErr        bool
+	}{
+		{
+			name:   "export srt with original timings",
+			args:   []string{"trans-123"},
+			format: "srt",
+			setupMock: func(m *mockTranslationService) {
+				m.GetAlignedTranslationFunc = func(ctx context.Context, transcriptionID string, targetLang string) ([]*translation.TranslationSegment, error) {
+					return []*translation.TranslationSegment{
+						{SegmentIndex: 0, StartTime: "00:00:01.5", EndTime: "00:00:04", TranslatedText: "こんにちは"},
+						{SegmentIndex: 1, StartTime: "00:01:04", EndTime: "00:01:07.25", TranslatedText: "世界"},
+					}, nil
+				}
+			},
+			expectedOutput: []string{
+				"1\n00:00:01,500 --> 00:00:04,000\nこんにちは\n",
+				"2\n00:01:04,000 --> 00:01:07,250\n世界\n",
+			},
+		},
+		{
+			name:   "export vtt",
+			args:   []string{"trans-123"},
+			format: "vtt",
+			setupMock: func(m *mockTranslationService) {
+				m.GetAlignedTranslationFunc = func(ctx context.Context, transcriptionID string, targetLang string) ([]*translation.TranslationSegment, error) {
+					return []*translation.TranslationSegment{
+						{SegmentIndex: 0, StartTime: "00:00:01.5", EndTime: "00:00:04", TranslatedText: "こんにちは"},
+					}, nil
+				}
+			},
+			expectedOutput: []string{
+				"WEBVTT\n\n00:00:01.500 --> 00:00:04.000\nこんにちは\n",
+			},
+		},
+		{
+			name:   "service error",
+			args:   []string{"trans-123"},
+			format: "srt",
+			setupMock: func(m *mockTranslationService) {
+				m.GetAlignedTranslationFunc = func(ctx context.Context, transcriptionID string, targetLang string) ([]*translation.TranslationSegment, error) {
+					return nil, errors.New("no ja translations found")
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name:      "unsupported format",
+			args:      []string{"trans-123"},
+			format:    "text",
+			setupMock: func(m *mockTranslationService) {},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mockTranslationService{}
+			tt.setupMock(mockService)
+
+			cmd := NewExportCommand(mockService)
+			cmd.Flags().Set("format", tt.format)
+
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for _, expected := range tt.expectedOutput {
+				assert.Contains(t, buf.String(), expected)
+			}
+		})
+	}
+}
+
+func TestCompareCommand(t *testing.T) {
+	comparison := &translation.TranslationComparison{
+		TranscriptionID: "trans-123",
+		TargetLanguage:  "ja",
+		Providers:       []string{"plamo", "deepl"},
+		Generated:       []string{"plamo"},
+		Segments: []*translation.ComparedSegment{
+			{SegmentIndex: 0, StartTime: "00:00:00", EndTime: "00:00:02", Text: "Hello",
+				Translations: map[string]string{"plamo": "こんにちは", "deepl": "こんにちは"}, Similarity: 1},
+			{SegmentIndex: 1, StartTime: "00:00:02", EndTime: "00:00:04", Text: "World",
+				Translations: map[string]string{"plamo": "世界", "deepl": "世の中"}, Similarity: 1.0 / 3.0},
+		},
+		Identical:      1,
+		MeanSimilarity: 2.0 / 3.0,
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		flags       map[string]string
+		providers   []string
+		expected    []string
+		notExpected []string
+		wantErr     bool
+	}{
+		{
+			name:     "default providers",
+			args:     []string{"trans-123"},
+			expected: []string{"Providers: plamo vs deepl"},
+		},
+		{
+			name:      "segment aligned diff",
+			args:      []string{"trans-123"},
+			flags:     map[string]string{"providers": "plamo,deepl"},
+			providers: []string{"plamo", "deepl"},
+			expected:  []string{"Providers: plamo vs deepl (translated now: plamo)", "Identical segments: 1/2", "[2] 00:00:02 - 00:00:04  ≠ 33%", "deepl  世の中"},
+		},
+		{
+			name:        "only differing segments side by side",
+			args:        []string{"trans-123"},
+			flags:       map[string]string{"providers": "plamo,deepl", "format": "side-by-side", "only-diff": "true"},
+			providers:   []string{"plamo", "deepl"},
+			expected:    []string{"PLAMO", "DEEPL", "世の中"},
+			notExpected: []string{"こんにちは"},
+		},
+		{
+			name:      "json",
+			args:      []string{"trans-123"},
+			flags:     map[string]string{"providers": "plamo,deepl", "format": "json"},
+			providers: []string{"plamo", "deepl"},
+			expected:  []string{`"mean_similarity"`, `"deepl": "世の中"`},
+		},
+		{
+			name:    "invalid format",
+			args:    []string{"trans-123"},
+			flags:   map[string]string{"format": "html"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mockTranslationService{
+				CompareTranslationsFunc: func(ctx context.Context, opts translation.CompareOptions) (*translation.TranslationComparison, error) {
+					// Without --providers the service picks the stored providers
+					assert.ElementsMatch(t, tt.providers, opts.Providers)
+					return comparison, nil
+				},
+			}
+
+			cmd := NewCompareCommand(mockService)
+			for name, value := range tt.flags {
+				require.NoError(t, cmd.Flags().Set(name, value))
+			}
+
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for _, expected := range tt.expected {
+				assert.Contains(t, buf.String(), expected)
+			}
+			for _, notExpected := range tt.notExpected {
+				assert.NotContains(t, buf.String(), notExpected)
+			}
+		})
+	}
+}
+
+func TestInteractiveCommand(t *testing.T) {
+	segments := []*model.TranscriptionSegment{
+		{ID: "seg-1", StartTime: "00:00:00", EndTime: "00:00:02", Text: "Hello"},
+		{ID: "seg-2", StartTime: "00:00:02", EndTime: "00:00:04", Text: "World"},
+		{ID: "seg-3", StartTime: "00:00:04", EndTime: "00:00:06", Text: "Bye"},
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		actions  []string
+		edited   string
+		expected []string
+	}{
+		{
+			name:     "accept, edit and skip",
+			input:    "\ne\n世の中\ns\n",
+			actions:  []string{translation.ReviewAccept, translation.ReviewEdit, translation.ReviewSkip},
+			edited:   "世の中",
+			expected: []string{"[1/3] 00:00:00 - 00:00:02", "proposal: mt: World", "Saved 2 segments (1 accepted, 1 edited), skipped 1"},
+		},
+		{
+			name:     "reprompts on unknown choice and empty edit",
+			input:    "x\ne\n\na\nq\n",
+			actions:  []string{translation.ReviewAccept, translation.ReviewQuit},
+			expected: []string{`Unknown choice "x"`, "Empty translation, choose again", "Run the command again"},
+		},
+		{
+			name:     "end of input quits",
+			input:    "a\n",
+			actions:  []string{translation.ReviewAccept, translation.ReviewQuit},
+			expected: []string{"Saved 1 segments"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mockTranslationService{
+				TranslateInteractivelyFunc: func(ctx context.Context, opts translation.InteractiveOptions, reviewer translation.Reviewer) (*translation.InteractiveSummary, error) {
+					assert.Equal(t, "trans-123", opts.TranscriptionID)
+					assert.Equal(t, "fr", opts.TargetLanguage)
+
+					summary := &translation.InteractiveSummary{Segments: len(segments)}
+					var actions []string
+					for i, segment := range segments {
+						decision, err := reviewer.Review(ctx, &translation.ReviewItem{
+							Position: i + 1, Total: len(segments), Segment: segment, Proposal: "mt: " + segment.Text,
+						})
+						require.NoError(t, err)
+						actions = append(actions, decision.Action)
+
+						switch decision.Action {
+						case translation.ReviewAccept:
+							summary.Accepted++
+						case translation.ReviewEdit:
+							summary.Edited++
+							assert.Equal(t, tt.edited, decision.Text)
+						case translation.ReviewSkip:
+							summary.Skipped++
+						}
+						if decision.Action == translation.ReviewQuit {
+							summary.Quit = true
+							break
+						}
+					}
+					assert.Equal(t, tt.actions, actions)
+					return summary, nil
+				},
+			}
+
+			cmd := NewInteractiveCommand(mockService)
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+			cmd.SetIn(strings.NewReader(tt.input))
+			cmd.SetArgs([]string{"trans-123", "--target", "fr"})
+
+			require.NoError(t, cmd.Execute())
+			for _, expected := range tt.expected {
+				assert.Contains(t, buf.String(), expected)
+			}
+		})
+	}
+}
+
+func TestQACommand(t *testing.T) {
+	report := &translation.QAReport{
+		TranscriptionID: "trans-123",
+		TargetLanguage:  "ja",
+		Segments:        10,
+		MedianRatio:     0.5,
+		Counts:          map[string]int{translation.WarningCopy: 1},
+		Warnings: []*translation.QAWarning{
+			{SegmentIndex: 2, StartTime: "00:00:04", EndTime: "00:00:06", Text: "Machine learning", TranslatedText: "machine learning",
+				Code: translation.WarningCopy, Message: "translation repeats the source text"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  string
+		expected []string
+	}{
+		{
+			name:     "prints counts and flagged segments",
+			args:     []string{"trans-123"},
+			expected: []string{"Segments: 10  Warnings: 1  Median length ratio: 0.50", "untranslated_copy  1", "[3] 00:00:04 - 00:00:06  untranslated_copy", "translation: machine learning"},
+		},
+		{
+			name:     "json output",
+			args:     []string{"trans-123", "--format", "json"},
+			expected: []string{`"code": "untranslated_copy"`, `"median_ratio": 0.5`},
+		},
+		{
+			name:    "fails on warnings",
+			args:    []string{"trans-123", "--fail-on-warnings"},
+			wantErr: "1 of 10 segments have warnings",
+		},
+		{
+			name:    "invalid format",
+			args:    []string{"trans-123", "--format", "xml"},
+			wantErr: "unsupported format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mockTranslationService{
+				CheckAlignmentFunc: func(ctx context.Context, opts translation.QAOptions) (*translation.QAReport, error) {
+					assert.Equal(t, "trans-123", opts.TranscriptionID)
+					assert.Equal(t, "ja", opts.TargetLanguage)
+					assert.Equal(t, 3.0, opts.RatioFactor)
+					return report, nil
+				},
+			}
+
+			cmd := NewQACommand(mockService)
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			for _, expected := range tt.expected {
+				assert.Contains(t, buf.String(), expected)
+			}
+		})
+	}
+}
+
+func TestListCommand(t *testing.T) {
+	mockService := &mockTranslationService{
+		ListTranslationsFunc: func(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error) {
+			assert.Equal(t, 10, limit)
+			assert.Equal(t, 10, offset)
+			translations := make([]*model.Translation, limit)
+			for i := range translations {
+				translations[i] = &model.Translation{ID: offset + i + 1, TargetLanguage: "ja", TranslatedText: "こんにちは"}
+			}
+			return translations, nil
+		},
+		CountTranslationsFunc: func(ctx context.Context, transcriptionID string) (int, error) {
+			assert.Equal(t, "trans-123", transcriptionID)
+			return 170, nil
+		},
+	}
+
+	cmd := NewListCommand(mockService)
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"trans-123", "--offset", "10"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, buf.String(), "ID: 11\n")
+	assert.Contains(t, buf.String(), "Showing 11-20 of 170 (page 2 of 17); next page: --offset 20")
+}
+
+func TestListCommand_Template(t *testing.T) {
+	mockService := &mockTranslationService{
+		ListTranslationsFunc: func(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error) {
+			return []*model.Translation{
+				{ID: 1, TargetLanguage: "ja", Source: "plamo"},
+				{ID: 2, TargetLanguage: "fr", Source: "manual"},
+			}, nil
+		},
+	}
+
+	cmd := NewListCommand(mockService)
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"trans-123", "--template", "{{.ID}} {{.TargetLanguage}} {{.Source}}"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "1 ja plamo\n2 fr manual\n", buf.String())
+}
+
+func TestListCommand_Porcelain(t *testing.T) {
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	mockService := &mockTranslationService{
+		ListTranslationsFunc: func(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error) {
+			return []*model.Translation{
+				{ID: 1, TranscriptionSegmentID: "seg-1", TargetLanguage: "ja", Source: "plamo", Approved: true, CreatedAt: created},
+				{ID: 2, TranscriptionSegmentID: "seg-2", TargetLanguage: "fr", Source: "manual", Style: "casual", CreatedAt: created},
+			}, nil
+		},
+	}
+
+	run := func(args ...string) (string, error) {
+		cmd := NewListCommand(mockService)
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs(append([]string{"trans-123"}, args...))
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	out, err := run("--porcelain")
+	require.NoError(t, err)
+	assert.Equal(t, "1\tseg-1\tja\tplamo\t\ttrue\t2026-03-01T09:00:00Z\n2\tseg-2\tfr\tmanual\tcasual\tfalse\t2026-03-01T09:00:00Z\n", out)
+
+	out, err = run("--porcelain", "-z")
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(out, "\x00"))
+	assert.NotContains(t, out, "\n")
+
+	_, err = run("--porcelain", "--template", "{{.ID}}")
+	assert.Error(t, err)
+}
+
+// fakeRunRepo records the progress of runs in memory
+type fakeRunRepo struct {
+	finished map[int]string // Status of each finished item by position
+	status   string
+}
+
+func (r *fakeRunRepo) Create(ctx context.Context, run *model.Run, items []*model.RunItem) error {
+	run.ID = "run-1"
+	for i, item := range items {
+		item.Position = i
+	}
+	return nil
+}
+
+func (r *fakeRunRepo) StartItem(ctx context.Context, runID string, position int) error {
+	return nil
+}
+
+func (r *fakeRunRepo) FinishItem(ctx context.Context, runID string, position int, status string, resultID, errorMessage *string) error {
+	r.finished[position] = status
+	return nil
+}
+
+func (r *fakeRunRepo) SetStatus(ctx context.Context, id string, status string) error {
+	r.status = status
+	return nil
+}
+
+func (r *fakeRunRepo) GetByID(ctx context.Context, id string) (*model.Run, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *fakeRunRepo) List(ctx context.Context, limit int) ([]*model.Run, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestTranslateChapters(t *testing.T) {
+	chapters := []*translation.Chapter{
+		{Title: "Intro", Start: 0, End: 10 * time.Minute},
+		{Start: 10 * time.Minute, End: 20 * time.Minute},
+	}
+	var translated []string
+	mockService := &mockTranslationService{
+		CreateTranslationFunc: func(ctx context.Context, transcriptionID string, targetLang string) (*model.Translation, error) {
+			assert.Equal(t, "trans-123", transcriptionID)
+			if len(translated) == 1 {
+				translated = append(translated, "failed")
+				return nil, errors.New("plamo crashed")
+			}
+			translated = append(translated, targetLang)
+			return &model.Translation{ID: 41, TargetLanguage: targetLang, Source: "plamo"}, nil
+		},
+	}
+
+	runRepo := &fakeRunRepo{finished: map[int]string{}}
+	chapterRun, items := newChapterRun("trans-123", "ja", "", translation.StrategyFastest, false, chapters)
+	require.NoError(t, runRepo.Create(context.Background(), chapterRun, items))
+	assert.Equal(t, model.RunCommandTranslateChapters, chapterRun.Command)
+	assert.Equal(t, translation.StrategyFastest, chapterRun.Options["strategy"])
+	assert.Equal(t, "00:00:00.000-00:10:00.000", items[0].ItemID)
+	assert.Equal(t, "Intro", items[0].Title)
+
+	var buf bytes.Buffer
+	err := translateChapters(context.Background(), &buf, mockService, runRepo, chapterRun, items, "ja")
+	assert.ErrorContains(t, err, "1 chapters failed to translate; retry them with --resume run-1")
+
+	assert.Equal(t, map[int]string{0: model.RunStatusCompleted, 1: model.RunStatusFailed}, runRepo.finished)
+	assert.Equal(t, model.RunStatusFailed, runRepo.status)
+	assert.Contains(t, buf.String(), "[1/2] 00:00:00.000-00:10:00.000 Intro\n  ✅ 41\n")
+	assert.Contains(t, buf.String(), "  ❌ plamo crashed\n")
+	assert.Contains(t, buf.String(), "Translated 1 of 2 chapters (run run-1)")
+}
@@ -0,0 +1,179 @@
+package translation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
+	"github.com/Taichi-iskw/yt-lang/internal/service/translation"
+	"github.com/spf13/cobra"
+)
+
+// NewCompareCommand creates the compare translations command
+func NewCompareCommand(service translation.TranslationService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compare [TRANSCRIPTION_ID]",
+		Short: "Compare translations from different providers",
+		Long: `Compare translations of the same transcription from several providers, aligned by segment.
+Stored translations are used when available; PLaMo translations are produced on the fly when
+missing (or always with --regenerate) and are not saved. Without --providers, PLaMo is compared
+with every provider that has stored translations in the language.
+
+Each segment shows the source text and every provider's translation with a character-level
+similarity score between the first two providers.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			transcriptionID := args[0]
+
+			// Get flags
+			langs, _ := cmd.Flags().GetStringSlice("langs")
+			providers, _ := cmd.Flags().GetStringSlice("providers")
+			format, _ := cmd.Flags().GetString("format")
+			regenerate, _ := cmd.Flags().GetBool("regenerate")
+			onlyDiff, _ := cmd.Flags().GetBool("only-diff")
+
+			switch format {
+			case "text", "side-by-side", "json":
+			default:
+				return fmt.Errorf("unsupported format: %s (supported: text, side-by-side, json)", format)
+			}
+
+			// Use provided service if available (for testing), otherwise create real service
+			var translationService translation.TranslationService
+			var cleanup func()
+
+			if service != nil {
+				translationService = service
+			} else {
+				// Create service using factory
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				defer cancel()
+
+				debugPlamo, _ := cmd.Flags().GetBool("debug-plamo")
+				workers, _ := cmd.Flags().GetInt("workers")
+				factory := NewServiceFactory().WithPlamoDebug(debugPlamo).WithWorkers(workers)
+				var err error
+				translationService, cleanup, err = factory.CreateService(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to create translation service: %w", err)
+				}
+				defer cleanup()
+				// Stop the PLaMo server if a provider had to be run
+				defer translationService.GetPlamoService().StopServer()
+			}
+
+			// Generating translations may take as long as translation create
+			ctx, cancel := context.WithTimeout(context.Background(), 12*time.Hour)
+			defer cancel()
+
+			var comparisons []*translation.TranslationComparison
+			for _, lang := range langs {
+				comparison, err := translationService.CompareTranslations(ctx, translation.CompareOptions{
+					TranscriptionID: transcriptionID,
+					TargetLanguage:  lang,
+					Providers:       providers,
+					Regenerate:      regenerate,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to compare %s translations: %w", lang, err)
+				}
+				comparisons = append(comparisons, comparison)
+			}
+
+			out := cmd.OutOrStdout()
+			switch format {
+			case "json":
+				data, err := json.MarshalIndent(comparisons, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to format as JSON: %w", err)
+				}
+				fmt.Fprintln(out, string(data))
+			case "side-by-side":
+				for _, comparison := range comparisons {
+					writeSideBySide(out, comparison, onlyDiff)
+				}
+			default:
+				for _, comparison := range comparisons {
+					writeSegmentDiff(out, comparison, onlyDiff)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	// Add flags
+	cmd.Flags().StringSlice("langs", []string{"ja"}, "Target languages to compare (comma-separated)")
+	cmd.Flags().StringSlice("providers", nil, "Providers to compare (comma-separated, at least two; default plamo and every stored provider)")
+	cmd.Flags().String("format", "text", "Output format (text, side-by-side, json)")
+	cmd.Flags().Bool("regenerate", false, "Translate again with runnable providers instead of using stored translations")
+	cmd.Flags().Bool("only-diff", false, "Only show segments where the translations differ")
+	cmd.Flags().Int("workers", 0, "Number of batches to translate concurrently (default from config, or 1)")
+
+	return cmd
+}
+
+// writeSegmentDiff prints each segment followed by one line per provider
+func writeSegmentDiff(w io.Writer, comparison *translation.TranslationComparison, onlyDiff bool) {
+	writeComparisonHeader(w, comparison)
+
+	width := 0
+	for _, provider := range comparison.Providers {
+		width = max(width, len(provider))
+	}
+
+	for _, seg := range comparison.Segments {
+		if onlyDiff && seg.Similarity == 1 {
+			continue
+		}
+
+		marker := "="
+		if seg.Similarity < 1 {
+			marker = "≠"
+		}
+		fmt.Fprintf(w, "[%d] %s - %s  %s %.0f%%\n", seg.SegmentIndex+1, seg.StartTime, seg.EndTime, marker, seg.Similarity*100)
+		fmt.Fprintf(w, "  %-*s  %s\n", width, "source", seg.Text)
+		for _, provider := range comparison.Providers {
+			fmt.Fprintf(w, "  %-*s  %s\n", width, provider, seg.Translations[provider])
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// writeSideBySide prints one table row per segment with a column per provider
+func writeSideBySide(w io.Writer, comparison *translation.TranslationComparison, onlyDiff bool) {
+	writeComparisonHeader(w, comparison)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "#\tSIM\t%s\n", strings.ToUpper(strings.Join(comparison.Providers, "\t")))
+	for _, seg := range comparison.Segments {
+		if onlyDiff && seg.Similarity == 1 {
+			continue
+		}
+
+		columns := make([]string, len(comparison.Providers))
+		for i, provider := range comparison.Providers {
+			columns[i] = handler.Truncate(seg.Translations[provider], 60)
+		}
+		fmt.Fprintf(tw, "%d\t%.0f%%\t%s\n", seg.SegmentIndex+1, seg.Similarity*100, strings.Join(columns, "\t"))
+	}
+	tw.Flush()
+	fmt.Fprintln(w)
+}
+
+// writeComparisonHeader prints the summary shared by the text formats
+func writeComparisonHeader(w io.Writer, comparison *translation.TranslationComparison) {
+	fmt.Fprintf(w, "Transcription: %s  Language: %s\n", comparison.TranscriptionID, comparison.TargetLanguage)
+	fmt.Fprintf(w, "Providers: %s", strings.Join(comparison.Providers, " vs "))
+	if len(comparison.Generated) > 0 {
+		fmt.Fprintf(w, " (translated now: %s)", strings.Join(comparison.Generated, ", "))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Identical segments: %d/%d  Mean similarity: %.1f%%\n\n",
+		comparison.Identical, len(comparison.Segments), comparison.MeanSimilarity*100)
+}
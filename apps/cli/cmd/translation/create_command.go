@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Taichi-iskw/yt-lang/internal/i18n"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/run"
 	translationSvc "github.com/Taichi-iskw/yt-lang/internal/service/translation"
 	"github.com/spf13/cobra"
 )
@@ -14,19 +17,115 @@ func NewCreateCommand(service translationSvc.TranslationService) *cobra.Command
 	cmd := &cobra.Command{
 		Use:   "create [TRANSCRIPTION_ID]",
 		Short: "Create a new translation",
-		Args:  cobra.ExactArgs(1),
+		Long: `Translate every segment of a transcription with PLaMo and, with --polish, post-edit the
+result with the configured LLM.
+--provider-opt passes settings through to the providers and is stored with the translation.
+Keys prefixed with openai. are sent as fields of the post-editing request (numbers and
+booleans as such); other keys are passed to plamo-translate as --key value.
+
+--style adapts the translation to a reader: simple (plain words and short sentences for
+learners), formal or casual. The style is applied by the --polish pass, since plamo-translate
+takes no instructions, and only the styled version is stored. Each style is kept as its own
+variant next to the plain translation.
+
+--per-chapter translates long transcriptions chapter by chapter, so PLaMo batches never span two
+chapters: the video's YouTube chapters when video add recorded them, otherwise windows of
+--chapter-length. Each chapter is saved as soon as it is translated, its translations marked
+with the chapter, and recorded as an item of a run with the ID of its first translation (see
+runs show); --resume RUN_ID translates the chapters that failed or were interrupted
+again, with the run's target language, style and strategy (give --provider-opt again). With
+--stitch the chapters are saved together once all are translated, as a single translation; a
+failure then saves nothing.
+
+--strategy schedules batches across the providers configured under translation.providers (PLaMo
+and OpenAI-compatible LLMs), sending each batch to a provider that translates its language pair
+and has daily token quota left: the cheapest (default), the fastest so far, or the best quality
+rank. A failing provider hands the batch to the next one. Each translation is recorded with the
+provider that made it as its source.
+
+Examples:
+  yt-lang translation create trans-123 --target-lang ja
+  yt-lang translation create trans-123 --polish --provider-opt openai.temperature=0.2
+  yt-lang translation create trans-123 --polish --style simple
+  yt-lang translation create trans-123 --per-chapter --chapter-length 15m
+  yt-lang translation create trans-123 --strategy quality
+  yt-lang translation create --resume 3f2b8c1e-5d4a-4c1b-9e7f-0a6d2c8b4e91`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			transcriptionID := args[0]
+			resumeID, _ := cmd.Flags().GetString("resume")
+			switch {
+			case resumeID == "" && len(args) == 0:
+				return fmt.Errorf("a TRANSCRIPTION_ID (or --resume RUN_ID) is required")
+			case resumeID != "" && len(args) > 0:
+				return fmt.Errorf("--resume cannot be used with a TRANSCRIPTION_ID; the run's chapters are translated")
+			}
+			var transcriptionID string
+			if len(args) > 0 {
+				transcriptionID = args[0]
+			}
 
 			// Get flags
 			targetLang, _ := cmd.Flags().GetString("target-lang")
 			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			optionPairs, _ := cmd.Flags().GetStringArray("provider-opt")
+			options, err := translationSvc.ParseProviderOptions(optionPairs)
+			if err != nil {
+				return err
+			}
+			style, _ := cmd.Flags().GetString("style")
+			if err := translationSvc.ValidateStyle(style); err != nil {
+				return err
+			}
+			strategy, _ := cmd.Flags().GetString("strategy")
+			if err := translationSvc.ValidateStrategy(strategy); err != nil {
+				return err
+			}
+			polish, _ := cmd.Flags().GetBool("polish")
+			if style != "" && !polish {
+				return fmt.Errorf("--style is applied when post-editing; add --polish")
+			}
+			perChapter, _ := cmd.Flags().GetBool("per-chapter")
+			stitch, _ := cmd.Flags().GetBool("stitch")
+			chapterLength, _ := cmd.Flags().GetDuration("chapter-length")
+			if (stitch || cmd.Flags().Changed("chapter-length")) && !perChapter {
+				return fmt.Errorf("--stitch and --chapter-length only apply to --per-chapter")
+			}
+			if chapterLength <= 0 {
+				return fmt.Errorf("--chapter-length must be positive")
+			}
 
 			if dryRun {
 				cmd.Println("DRY RUN: Would create translation for transcription", transcriptionID, "to", targetLang)
 				return nil
 			}
 
+			// Chapters saved one by one are recorded as a run, so failed ones can be resumed
+			var runRepo run.Repository
+			var chapterRun *model.Run
+			if resumeID != "" || (perChapter && !stitch) {
+				var closeRuns func()
+				if runRepo, closeRuns, err = openRunRepository(); err != nil {
+					return err
+				}
+				defer closeRuns()
+			}
+			if resumeID != "" {
+				if chapterRun, err = loadChapterRun(context.Background(), runRepo, resumeID); err != nil {
+					return err
+				}
+				transcriptionID = chapterRun.Target
+				if !cmd.Flags().Changed("target-lang") {
+					targetLang = chapterRun.Options["target_lang"]
+				}
+				if !cmd.Flags().Changed("style") {
+					style = chapterRun.Options["style"]
+				}
+				if !cmd.Flags().Changed("strategy") {
+					strategy = chapterRun.Options["strategy"]
+				}
+				polish = polish || chapterRun.Options["polish"] == "true"
+			}
+
 			// Use provided service if available (for testing), otherwise create real service
 			var translationService translationSvc.TranslationService
 			var cleanup func()
@@ -38,8 +137,12 @@ func NewCreateCommand(service translationSvc.TranslationService) *cobra.Command
 				ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
 				defer cancel()
 
-				factory := NewServiceFactory()
-				var err error
+				debugPlamo, _ := cmd.Flags().GetBool("debug-plamo")
+				workers, _ := cmd.Flags().GetInt("workers")
+				if !polish && len(options.For(translationSvc.ProviderOpenAI)) > 0 {
+					cmd.PrintErrln("Warning: openai provider options only apply with --polish")
+				}
+				factory := NewServiceFactory().WithPlamoDebug(debugPlamo).WithWorkers(workers).WithPolish(polish)
 
 				// Use the version that starts PLaMo server for better performance
 				cmd.Println("Starting PLaMo server...")
@@ -58,6 +161,37 @@ func NewCreateCommand(service translationSvc.TranslationService) *cobra.Command
 			// Create context with timeout for translation (12 hours for large texts)
 			ctx, cancel := context.WithTimeout(context.Background(), 12*time.Hour)
 			defer cancel()
+			ctx = translationSvc.WithProviderOptions(ctx, options)
+			ctx = translationSvc.WithStyle(ctx, style)
+			ctx = translationSvc.WithStrategy(ctx, strategy)
+
+			if chapterRun != nil {
+				items := chapterRun.Unfinished()
+				if len(items) == 0 {
+					cmd.Printf("Run %s has no chapters left to translate\n", chapterRun.ID)
+					return nil
+				}
+				if err := runRepo.SetStatus(ctx, chapterRun.ID, model.RunStatusRunning); err != nil {
+					return fmt.Errorf("failed to resume run: %w", err)
+				}
+				cmd.Printf("Resuming run %s: %d of %d chapters left\n", chapterRun.ID, len(items), chapterRun.Total)
+				return translateChapters(ctx, cmd.OutOrStdout(), translationService, runRepo, chapterRun, items, targetLang)
+			}
+			if perChapter {
+				chapters, err := translationService.PlanChapters(ctx, transcriptionID, chapterLength)
+				if err != nil {
+					return fmt.Errorf("failed to plan chapters: %w", err)
+				}
+				if !stitch {
+					chapterRun, items := newChapterRun(transcriptionID, targetLang, style, strategy, polish, chapters)
+					if err := runRepo.Create(ctx, chapterRun, items); err != nil {
+						return fmt.Errorf("failed to record run: %w", err)
+					}
+					return translateChapters(ctx, cmd.OutOrStdout(), translationService, runRepo, chapterRun, items, targetLang)
+				}
+				cmd.Printf("Translating %d chapters, saved together once all are translated\n", len(chapters))
+				ctx = translationSvc.WithChapters(ctx, chapters)
+			}
 
 			// Create translation
 			translationResult, err := translationService.CreateTranslation(ctx, transcriptionID, targetLang)
@@ -65,8 +199,8 @@ func NewCreateCommand(service translationSvc.TranslationService) *cobra.Command
 				return fmt.Errorf("failed to create translation: %w", err)
 			}
 
-			cmd.Printf("Translation created successfully (ID: %d, Language: %s)\n",
-				translationResult.ID, translationResult.TargetLanguage)
+			cmd.Println(i18n.Tf("msg.translation_created", "Translation created successfully (ID: {{.ID}}, Language: {{.Language}}, Source: {{.Source}})",
+				map[string]any{"ID": translationResult.ID, "Language": translationResult.TargetLanguage, "Source": translationResult.Source}))
 			return nil
 		},
 	}
@@ -74,6 +208,17 @@ func NewCreateCommand(service translationSvc.TranslationService) *cobra.Command
 	// Add flags
 	cmd.Flags().String("target-lang", "ja", "Target language for translation")
 	cmd.Flags().Bool("dry-run", false, "Perform a dry run without saving to database")
+	cmd.Flags().Int("workers", 0, "Number of batches to translate concurrently (default from config, or 1)")
+	cmd.Flags().Bool("polish", false, "Post-edit the PLaMo translation with the LLM configured under translation.polish, keeping both versions")
+	cmd.Flags().String("style", "", "Adapt the translation with --polish: simple, formal, casual")
+	cmd.Flags().String("strategy", "", "Schedule batches across translation.providers: cheapest, fastest, quality (default cheapest)")
+	cmd.Flags().Bool("per-chapter", false, "Translate chapter by chapter (YouTube chapters, or windows of --chapter-length), saving each as it completes")
+	cmd.Flags().Duration("chapter-length", translationSvc.DefaultChapterLength, "Length of the chapters of videos without YouTube chapters, with --per-chapter")
+	cmd.Flags().Bool("stitch", false, "With --per-chapter, save the chapters together as one translation once all are translated")
+	cmd.Flags().String("resume", "", "Translate the chapters of a --per-chapter run that did not complete")
+	cmd.MarkFlagsMutuallyExclusive("resume", "dry-run")
+	cmd.MarkFlagsMutuallyExclusive("resume", "per-chapter")
+	cmd.Flags().StringArray("provider-opt", nil, "Provider option as key=value, stored with the translation (repeatable); prefix the key with the provider, e.g. openai.temperature=0.2, plamo options need no prefix")
 
 	return cmd
 }
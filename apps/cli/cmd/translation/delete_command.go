@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
 	"github.com/Taichi-iskw/yt-lang/internal/service/translation"
 	"github.com/spf13/cobra"
 )
@@ -53,14 +54,7 @@ func NewDeleteCommand(service translation.TranslationService) *cobra.Command {
 				defer cleanup()
 			}
 
-			ctx := context.Background()
-			err := translationService.DeleteTranslation(ctx, translationID)
-			if err != nil {
-				return fmt.Errorf("failed to delete translation: %w", err)
-			}
-
-			cmd.Printf("Translation %s deleted successfully\n", translationID)
-			return nil
+			return handler.DeleteTranslation(context.Background(), cmd.OutOrStdout(), translationService, translationID)
 		},
 	}
 
@@ -37,8 +37,15 @@ func (s *DryRunService) SimulateTranslation(ctx context.Context, transcriptionID
 		return nil, fmt.Errorf("no segments found for transcription %s", transcriptionID)
 	}
 
+	// Batch for the language the segments are written in
+	transcription, err := s.transcriptionRepo.Get(ctx, transcriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transcription: %w", err)
+	}
+	sourceLanguage := translation.TranscriptionLanguage(transcription)
+
 	// Create batches
-	batches, err := s.batchProcessor.CreateBatches(segments, 7000)
+	batches, err := s.batchProcessor.CreateBatches(segments, sourceLanguage, 7000)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create batches: %w", err)
 	}
@@ -48,14 +55,18 @@ func (s *DryRunService) SimulateTranslation(ctx context.Context, transcriptionID
 	totalBatches := len(batches)
 	totalChars := 0
 	for _, seg := range segments {
-		totalChars += len(seg.Text)
+		totalChars += len([]rune(seg.Text))
 	}
 
-	// Estimate tokens
-	estimatedTokens := totalChars / 4 // Rough estimate for English
+	// Estimate tokens from the per-batch estimates
+	estimatedTokens := 0
+	for _, batch := range batches {
+		estimatedTokens += batch.EstimatedTokens
+	}
 
 	return &DryRunResult{
 		TranscriptionID:  transcriptionID,
+		SourceLanguage:   sourceLanguage,
 		TargetLanguage:   targetLang,
 		TotalSegments:    totalSegments,
 		TotalBatches:     totalBatches,
@@ -70,6 +81,7 @@ func (s *DryRunService) SimulateTranslation(ctx context.Context, transcriptionID
 // DryRunResult contains the dry-run analysis results
 type DryRunResult struct {
 	TranscriptionID  string
+	SourceLanguage   string
 	TargetLanguage   string
 	TotalSegments    int
 	TotalBatches     int
@@ -85,6 +97,7 @@ func FormatDryRunResult(result *DryRunResult) string {
 	output := fmt.Sprintf(`DRY RUN ANALYSIS
 ================
 Transcription ID: %s
+Source Language: %s
 Target Language: %s
 
 Statistics:
@@ -95,14 +108,14 @@ Statistics:
 - Estimated API Calls: %d
 
 Batch Details:
-`, result.TranscriptionID, result.TargetLanguage,
+`, result.TranscriptionID, result.SourceLanguage, result.TargetLanguage,
 		result.TotalSegments, result.TotalCharacters,
 		result.EstimatedTokens, result.TotalBatches,
 		result.EstimatedAPICall)
 
 	for i, batch := range result.Batches {
-		output += fmt.Sprintf("  Batch %d: %d segments, separator: %s\n",
-			i+1, len(batch.Segments), batch.Separator)
+		output += fmt.Sprintf("  Batch %d: %d segments, ~%d tokens, separator: %s\n",
+			i+1, len(batch.Segments), batch.EstimatedTokens, batch.Separator)
 	}
 
 	output += "\nThis is a dry run - no actual translation will be performed."
@@ -0,0 +1,107 @@
+package translation
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/service/translation"
+	"github.com/spf13/cobra"
+)
+
+// NewExportCommand creates the export translation command
+func NewExportCommand(service translation.TranslationService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [TRANSCRIPTION_ID]",
+		Short: "Export a translation as subtitles aligned to the original segments",
+		Long: `Export the stored translation of a transcription as subtitles. Each cue uses the start and
+end time of the original segment; long translations are split across consecutive cues
+(42 characters per line, 2 lines per cue by default). Use --style to select a cue
+constraint preset such as "netflix", or a custom style from the config file.
+The plain translation is exported unless --translation-style selects a variant made with
+translation create --style.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			transcriptionID := args[0]
+
+			// Get flags
+			targetLang, _ := cmd.Flags().GetString("target-lang")
+			format, _ := cmd.Flags().GetString("format")
+			outputPath, _ := cmd.Flags().GetString("output")
+			style, _ := cmd.Flags().GetString("style")
+			translationStyle, _ := cmd.Flags().GetString("translation-style")
+			if err := translation.ValidateStyle(translationStyle); err != nil {
+				return err
+			}
+
+			rules, err := config.ResolveSubtitleRules(style)
+			if err != nil {
+				return err
+			}
+
+			var formatter Formatter
+			switch strings.ToLower(format) {
+			case "srt":
+				formatter = &SRTFormatter{Rules: rules}
+			case "vtt":
+				formatter = &VTTFormatter{Rules: rules}
+			default:
+				return fmt.Errorf("unsupported format: %s (supported: srt, vtt)", format)
+			}
+
+			// Use provided service if available (for testing), otherwise create real service
+			var translationService translation.TranslationService
+			var cleanup func()
+
+			if service != nil {
+				translationService = service
+			} else {
+				// Create service using factory
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				defer cancel()
+
+				factory := NewServiceFactory()
+				translationService, cleanup, err = factory.CreateService(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to create translation service: %w", err)
+				}
+				defer cleanup()
+			}
+
+			ctx := translation.WithStyle(context.Background(), translationStyle)
+			segments, err := translationService.GetAlignedTranslation(ctx, transcriptionID, targetLang)
+			if err != nil {
+				return fmt.Errorf("failed to get translation: %w", err)
+			}
+
+			output, err := formatter.Format(&model.Translation{TargetLanguage: targetLang}, segments)
+			if err != nil {
+				return fmt.Errorf("failed to format translation: %w", err)
+			}
+
+			if outputPath == "" {
+				cmd.Print(output)
+				return nil
+			}
+
+			if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
+				return fmt.Errorf("failed to write output file: %w", err)
+			}
+			cmd.Printf("Wrote %d segment(s) to %s\n", len(segments), outputPath)
+			return nil
+		},
+	}
+
+	// Add flags
+	cmd.Flags().String("target-lang", "ja", "Target language of the translation")
+	cmd.Flags().String("format", "srt", "Output format (srt, vtt)")
+	cmd.Flags().String("style", "", "Subtitle style preset (default, netflix, or a style from the config file)")
+	cmd.Flags().String("translation-style", "", "Translation style variant to export (simple, formal, casual; default: the plain translation)")
+	cmd.Flags().StringP("output", "o", "", "Write to file instead of stdout")
+
+	return cmd
+}
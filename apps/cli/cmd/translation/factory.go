@@ -3,22 +3,48 @@ package translation
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/lock"
 	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
 	translationRepo "github.com/Taichi-iskw/yt-lang/internal/repository/translation"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
 	"github.com/Taichi-iskw/yt-lang/internal/service/common"
 	"github.com/Taichi-iskw/yt-lang/internal/service/translation"
 )
 
 // ServiceFactory creates translation service instances
-type ServiceFactory struct{}
+type ServiceFactory struct {
+	debugPlamo bool
+	workers    int
+	polish     bool
+}
 
 // NewServiceFactory creates a new service factory
 func NewServiceFactory() *ServiceFactory {
 	return &ServiceFactory{}
 }
 
+// WithPlamoDebug enables PLaMo transcript logging regardless of the config file setting
+func (f *ServiceFactory) WithPlamoDebug(enabled bool) *ServiceFactory {
+	f.debugPlamo = enabled
+	return f
+}
+
+// WithWorkers sets the number of concurrent batch translations, overriding the config file.
+// Values below 1 keep the configured setting.
+func (f *ServiceFactory) WithWorkers(workers int) *ServiceFactory {
+	f.workers = workers
+	return f
+}
+
+// WithPolish post-edits PLaMo translations with the LLM configured under translation.polish
+func (f *ServiceFactory) WithPolish(enabled bool) *ServiceFactory {
+	f.polish = enabled
+	return f
+}
+
 // CreateService creates a new translation service with all dependencies
 func (f *ServiceFactory) CreateService(ctx context.Context) (translation.TranslationService, func(), error) {
 	// Load database configuration
@@ -39,20 +65,60 @@ func (f *ServiceFactory) CreateService(ctx context.Context) (translation.Transla
 	translationRepository := translationRepo.NewRepository(dbPool)
 
 	// Create services
-	cmdRunner := common.NewCmdRunner()
-	plamoService := translation.NewPlamoServerService(cmdRunner)
-	batchProcessor := translation.NewBatchProcessor()
+	plamoService, err := f.newPlamoService(cfg)
+	if err != nil {
+		dbPool.Close()
+		return nil, nil, err
+	}
+	batchProcessor := translation.NewBatchProcessorWithEstimator(translation.NewTokenEstimator(cfg.Translation.TokenRatios))
+
+	workers := cfg.Translation.Workers
+	if f.workers > 0 {
+		workers = f.workers
+	}
+
+	var polisher translation.Polisher
+	if f.polish {
+		polisher, err = newPolisher(cfg.Translation.Polish)
+		if err != nil {
+			dbPool.Close()
+			return nil, nil, err
+		}
+	}
 
-	// Create translation service with real repositories
-	translationService := translation.NewTranslationService(
+	var scheduler *translation.Scheduler
+	if len(cfg.Translation.Providers) > 0 {
+		scheduler, err = newScheduler(cfg.Translation, plamoService, translationRepo.NewProviderUsageRepository(dbPool))
+		if err != nil {
+			dbPool.Close()
+			return nil, nil, err
+		}
+	}
+
+	// Create translation service with real repositories, locked per transcription and
+	// language so overlapping runs don't translate twice
+	translationService := translation.NewTranslationServiceWithScheduler(
 		&transcriptionRepoWrapper{
 			transcriptionRepo: transcriptionRepository,
 			segmentRepo:       segmentRepo,
+			videoRepo:         video.NewRepository(dbPool),
 		},
 		translationRepository,
+		translationRepo.NewWarningRepository(dbPool),
 		plamoService,
 		batchProcessor,
+		workers,
+		polisher,
+		scheduler,
 	)
+	translationService = translation.NewLockingService(translationService, lock.NewPostgresLocker(dbPool.Pool))
+
+	hooks, err := config.NewHookDispatcher(cfg)
+	if err != nil {
+		dbPool.Close()
+		return nil, nil, err
+	}
+	translationService = translation.NewHookedService(translationService, hooks)
 
 	// Cleanup function
 	cleanup := func() {
@@ -62,6 +128,47 @@ func (f *ServiceFactory) CreateService(ctx context.Context) (translation.Transla
 	return translationService, cleanup, nil
 }
 
+// CreatePlamoService creates the PLaMo service translations are made with, rate limited and
+// logged per the config file, without a database connection. The server is not started.
+func (f *ServiceFactory) CreatePlamoService() (translation.PlamoService, error) {
+	cfg, err := config.NewConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return f.newPlamoService(cfg)
+}
+
+// newPlamoService creates the PLaMo server service with the rate limit and debug logging of cfg
+func (f *ServiceFactory) newPlamoService(cfg *config.Config) (translation.PlamoService, error) {
+	plamoService := translation.NewPlamoServerService(common.NewCmdRunner())
+	if limit, ok := cfg.Translation.RateLimits[translation.ProviderPlamo]; ok {
+		plamoService = translation.NewRateLimitedPlamoService(plamoService, translation.RateLimit{
+			RequestsPerMinute: limit.RequestsPerMinute,
+			MaxParallel:       limit.MaxParallel,
+			MaxRetries:        limit.MaxRetries,
+		})
+	}
+	if f.debugPlamo || cfg.Translation.PlamoDebug.Enabled {
+		return newDebugPlamoService(plamoService, cfg.Translation.PlamoDebug)
+	}
+	return plamoService, nil
+}
+
+// NewReadService creates a translation service over an existing connection pool for reading
+// stored translations (e.g. GetAlignedTranslation); it has no PLaMo service and cannot translate
+func NewReadService(dbPool *config.DatabasePool) translation.TranslationService {
+	return translation.NewTranslationService(
+		&transcriptionRepoWrapper{
+			transcriptionRepo: transcription.NewRepository(dbPool),
+			segmentRepo:       transcription.NewSegmentRepository(dbPool),
+			videoRepo:         video.NewRepository(dbPool),
+		},
+		translationRepo.NewRepository(dbPool),
+		nil,
+		nil,
+	)
+}
+
 // CreateServiceWithPlamoServer creates a translation service and starts the PLaMo server
 func (f *ServiceFactory) CreateServiceWithPlamoServer(ctx context.Context) (translation.TranslationService, func(), error) {
 	service, dbCleanup, err := f.CreateService(ctx)
@@ -72,26 +179,108 @@ func (f *ServiceFactory) CreateServiceWithPlamoServer(ctx context.Context) (tran
 	// Get the PLaMo service through the interface
 	plamoService := service.GetPlamoService()
 
-	// Type assert to PlamoServerService if it supports server mode
-	if serverService, ok := plamoService.(*translation.PlamoServerService); ok {
-		// Start PLaMo server
-		if err := serverService.StartServer(ctx); err != nil {
-			dbCleanup()
-			return nil, nil, fmt.Errorf("failed to start PLaMo server: %w", err)
+	// Start PLaMo server (a no-op for simple mode; the debug wrapper forwards it)
+	if err := plamoService.StartServer(ctx); err != nil {
+		dbCleanup()
+		return nil, nil, fmt.Errorf("failed to start PLaMo server: %w", err)
+	}
+
+	// Combined cleanup function
+	cleanup := func() {
+		// Stop PLaMo server
+		if err := plamoService.StopServer(); err != nil {
+			// Log error but don't fail cleanup
+			fmt.Printf("Warning: failed to stop PLaMo server: %v\n", err)
 		}
+		dbCleanup()
+	}
+	return service, cleanup, nil
+}
+
+// newDebugPlamoService wraps a PLaMo service with transcript logging per the config
+func newDebugPlamoService(plamoService translation.PlamoService, cfg config.PlamoDebugConfig) (translation.PlamoService, error) {
+	dir := cfg.Dir
+	if dir == "" {
+		var err error
+		dir, err = config.GetPlamoLogDir()
+		if err != nil {
+			return nil, err
+		}
+	}
 
-		// Combined cleanup function
-		cleanup := func() {
-			// Stop PLaMo server
-			if err := serverService.StopServer(); err != nil {
-				// Log error but don't fail cleanup
-				fmt.Printf("Warning: failed to stop PLaMo server: %v\n", err)
+	debugService, err := translation.NewDebugPlamoService(plamoService, translation.PlamoDebugOptions{
+		Dir:            dir,
+		Redact:         cfg.Redact,
+		RedactPatterns: cfg.RedactPatterns,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable PLaMo debug logging: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "PLaMo debug logging enabled: %s\n", dir)
+	return debugService, nil
+}
+
+// newPolisher creates the post-editing client configured under translation.polish
+func newPolisher(cfg config.PolishConfig) (translation.Polisher, error) {
+	if !cfg.Enabled() {
+		return nil, fmt.Errorf("--polish requires translation.polish.model in the config file")
+	}
+
+	polisher, err := translation.NewOpenAIPolisher(translation.OpenAIPolisherOptions{
+		BaseURL: cfg.BaseURL,
+		Model:   cfg.Model,
+		APIKey:  cfg.APIKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure post-editing: %w", err)
+	}
+	return polisher, nil
+}
+
+// newScheduler creates the scheduler of the providers configured under translation.providers.
+// PLaMo providers share plamoService; the others are rate limited by their name.
+func newScheduler(cfg config.TranslationConfig, plamoService translation.PlamoService, usage translation.ProviderUsage) (*translation.Scheduler, error) {
+	var providers []*translation.Provider
+	for _, providerCfg := range cfg.Providers {
+		var service translation.PlamoService
+		switch providerCfg.Kind {
+		case config.ProviderKindPlamo:
+			service = plamoService
+		case config.ProviderKindOpenAI:
+			translator, err := translation.NewOpenAITranslator(translation.OpenAIPolisherOptions{
+				BaseURL: providerCfg.BaseURL,
+				Model:   providerCfg.Model,
+				APIKey:  providerCfg.APIKey,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to configure translation provider %s: %w", providerCfg.Name, err)
+			}
+			service = translator
+			if limit, ok := cfg.RateLimits[providerCfg.Name]; ok {
+				service = translation.NewRateLimitedPlamoService(service, translation.RateLimit{
+					RequestsPerMinute: limit.RequestsPerMinute,
+					MaxParallel:       limit.MaxParallel,
+					MaxRetries:        limit.MaxRetries,
+				})
 			}
-			dbCleanup()
+		default:
+			return nil, fmt.Errorf("unsupported kind %q of translation provider %s (supported: %s, %s)", providerCfg.Kind, providerCfg.Name, config.ProviderKindPlamo, config.ProviderKindOpenAI)
 		}
-		return service, cleanup, nil
+
+		providers = append(providers, &translation.Provider{
+			Name:        providerCfg.Name,
+			Service:     service,
+			Languages:   providerCfg.Languages,
+			DailyTokens: providerCfg.DailyTokens,
+			Cost:        providerCfg.Cost,
+			Quality:     providerCfg.Quality,
+		})
 	}
 
-	// If not a server service, just return with db cleanup
-	return service, dbCleanup, nil
+	scheduler, err := translation.NewScheduler(providers, usage)
+	if err != nil {
+		return nil, fmt.Errorf("invalid translation.providers: %w", err)
+	}
+	return scheduler, nil
 }
@@ -7,6 +7,9 @@ import (
 	"time"
 
 	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/service/translation"
+	"github.com/Taichi-iskw/yt-lang/internal/subtitle"
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
 )
 
 // Formatter defines interface for output formatting
@@ -14,11 +17,8 @@ type Formatter interface {
 	Format(translation *model.Translation, segments []*TranslationSegment) (string, error)
 }
 
-// TranslationSegment represents a translated segment (temporary definition)
-type TranslationSegment struct {
-	Text           string
-	TranslatedText string
-}
+// TranslationSegment represents a translated segment with its original timing
+type TranslationSegment = translation.TranslationSegment
 
 // TextFormatter formats output as plain text
 type TextFormatter struct{}
@@ -30,6 +30,9 @@ func (f *TextFormatter) Format(translation *model.Translation, segments []*Trans
 	output.WriteString(fmt.Sprintf("Translation ID: %d\n", translation.ID))
 	output.WriteString(fmt.Sprintf("Target Language: %s\n", translation.TargetLanguage))
 	output.WriteString(fmt.Sprintf("Source: %s\n", translation.Source))
+	if translation.Style != "" {
+		output.WriteString(fmt.Sprintf("Style: %s\n", translation.Style))
+	}
 	output.WriteString(fmt.Sprintf("Created At: %s\n", translation.CreatedAt.Format(time.RFC3339)))
 	output.WriteString("\n")
 
@@ -73,41 +76,73 @@ func (f *JSONFormatter) Format(translation *model.Translation, segments []*Trans
 }
 
 // SRTFormatter formats output as SRT subtitle format
-type SRTFormatter struct{}
+type SRTFormatter struct {
+	Rules subtitle.Rules
+}
 
-// Format formats translation as SRT
+// Format formats translation as SRT using the original segment timings, with cues
+// wrapped, split and merged according to the formatter's rules
 func (f *SRTFormatter) Format(translation *model.Translation, segments []*TranslationSegment) (string, error) {
-	if segments == nil || len(segments) == 0 {
-		return "", fmt.Errorf("SRT format requires segments with timing information")
+	cues, err := segmentCues(segments, f.Rules, "SRT")
+	if err != nil {
+		return "", err
 	}
 
 	var output strings.Builder
+	for i, cue := range cues {
+		subtitle.WriteSRTCue(&output, i+1, cue)
+	}
 
-	for i, seg := range segments {
-		// Subtitle number
-		output.WriteString(fmt.Sprintf("%d\n", i+1))
+	return output.String(), nil
+}
 
-		// Timing (placeholder - would need actual timing from TranscriptionSegment)
-		// Format: 00:00:00,000 --> 00:00:05,000
-		startTime := formatSRTTime(i * 5) // Placeholder: 5 seconds per segment
-		endTime := formatSRTTime((i + 1) * 5)
-		output.WriteString(fmt.Sprintf("%s --> %s\n", startTime, endTime))
+// VTTFormatter formats output as WebVTT subtitle format
+type VTTFormatter struct {
+	Rules subtitle.Rules
+}
+
+// Format formats translation as WebVTT using the original segment timings
+func (f *VTTFormatter) Format(translation *model.Translation, segments []*TranslationSegment) (string, error) {
+	cues, err := segmentCues(segments, f.Rules, "VTT")
+	if err != nil {
+		return "", err
+	}
 
-		// Translated text
-		output.WriteString(seg.TranslatedText)
-		output.WriteString("\n\n")
+	var output strings.Builder
+	subtitle.WriteVTTHeader(&output)
+	for _, cue := range cues {
+		subtitle.WriteVTTCue(&output, cue)
 	}
 
 	return output.String(), nil
 }
 
-// formatSRTTime formats seconds into SRT time format (00:00:00,000)
-func formatSRTTime(seconds int) string {
-	hours := seconds / 3600
-	minutes := (seconds % 3600) / 60
-	secs := seconds % 60
+// segmentCues converts timed translation segments into subtitle cues that satisfy rules
+func segmentCues(segments []*TranslationSegment, rules subtitle.Rules, format string) ([]subtitle.Cue, error) {
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("%s format requires segments with timing information", format)
+	}
+
+	processor := subtitle.NewProcessor(rules)
+	var cues []subtitle.Cue
+
+	for _, seg := range segments {
+		if seg.StartTime == "" || seg.EndTime == "" {
+			return nil, fmt.Errorf("%s format requires segments with timing information", format)
+		}
+		start, err := timecode.ParseInterval(seg.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("segment %d: %w", seg.SegmentIndex, err)
+		}
+		end, err := timecode.ParseInterval(seg.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("segment %d: %w", seg.SegmentIndex, err)
+		}
+
+		cues = append(cues, processor.Push(start, end, seg.TranslatedText)...)
+	}
 
-	return fmt.Sprintf("%02d:%02d:%02d,000", hours, minutes, secs)
+	return append(cues, processor.Flush()...), nil
 }
 
 // GetFormatter returns the appropriate formatter based on format string
@@ -118,7 +153,9 @@ func GetFormatter(format string) (Formatter, error) {
 	case "json":
 		return &JSONFormatter{}, nil
 	case "srt":
-		return &SRTFormatter{}, nil
+		return &SRTFormatter{Rules: subtitle.DefaultRules()}, nil
+	case "vtt":
+		return &VTTFormatter{Rules: subtitle.DefaultRules()}, nil
 	default:
 		return nil, fmt.Errorf("unsupported format: %s", format)
 	}
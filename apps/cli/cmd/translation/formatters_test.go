@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/subtitle"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -59,7 +60,7 @@ func TestJSONFormatter(t *testing.T) {
 }
 
 func TestSRTFormatter(t *testing.T) {
-	formatter := &SRTFormatter{}
+	formatter := &SRTFormatter{Rules: subtitle.DefaultRules()}
 
 	trans := &model.Translation{
 		ID:             1,
@@ -70,8 +71,8 @@ func TestSRTFormatter(t *testing.T) {
 
 	t.Run("with segments", func(t *testing.T) {
 		segments := []*TranslationSegment{
-			{Text: "Hello", TranslatedText: "こんにちは"},
-			{Text: "World", TranslatedText: "世界"},
+			{StartTime: "00:00:01.5", EndTime: "00:00:04", Text: "Hello", TranslatedText: "こんにちは"},
+			{StartTime: "00:00:04", EndTime: "00:00:06.2", Text: "World", TranslatedText: "世界"},
 		}
 
 		output, err := formatter.Format(trans, segments)
@@ -79,14 +80,49 @@ func TestSRTFormatter(t *testing.T) {
 
 		lines := strings.Split(output, "\n")
 		assert.Equal(t, "1", lines[0])
-		assert.Contains(t, lines[1], "-->")
+		assert.Equal(t, "00:00:01,500 --> 00:00:04,000", lines[1])
 		assert.Equal(t, "こんにちは", lines[2])
 
 		assert.Equal(t, "2", lines[4])
-		assert.Contains(t, lines[5], "-->")
+		assert.Equal(t, "00:00:04,000 --> 00:00:06,200", lines[5])
 		assert.Equal(t, "世界", lines[6])
 	})
 
+	t.Run("long translation splits into cues", func(t *testing.T) {
+		segments := []*TranslationSegment{
+			{
+				StartTime:      "00:00:10",
+				EndTime:        "00:00:20",
+				TranslatedText: strings.Repeat("subtitle text ", 12),
+			},
+		}
+
+		output, err := formatter.Format(trans, segments)
+		require.NoError(t, err)
+
+		cues := strings.Split(strings.TrimSpace(output), "\n\n")
+		require.Len(t, cues, 2)
+		assert.True(t, strings.HasPrefix(cues[0], "1\n00:00:10,000 --> "))
+		assert.Contains(t, cues[1], " --> 00:00:20,000\n")
+		for _, cue := range cues {
+			textLines := strings.Split(cue, "\n")[2:]
+			assert.LessOrEqual(t, len(textLines), 2)
+			for _, line := range textLines {
+				assert.LessOrEqual(t, len([]rune(line)), 42)
+			}
+		}
+	})
+
+	t.Run("segments without timing", func(t *testing.T) {
+		segments := []*TranslationSegment{
+			{Text: "Hello", TranslatedText: "こんにちは"},
+		}
+
+		_, err := formatter.Format(trans, segments)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "SRT format requires segments")
+	})
+
 	t.Run("without segments", func(t *testing.T) {
 		_, err := formatter.Format(trans, nil)
 		require.Error(t, err)
@@ -94,6 +130,23 @@ func TestSRTFormatter(t *testing.T) {
 	})
 }
 
+func TestVTTFormatter(t *testing.T) {
+	rules, err := subtitle.ResolveRules("netflix", nil)
+	require.NoError(t, err)
+	formatter := &VTTFormatter{Rules: rules}
+
+	segments := []*TranslationSegment{
+		{StartTime: "00:00:01", EndTime: "00:00:01.2", TranslatedText: "はい"},
+		{StartTime: "00:00:01.2", EndTime: "00:00:03", TranslatedText: "そうです"},
+	}
+
+	output, err := formatter.Format(&model.Translation{TargetLanguage: "ja"}, segments)
+	require.NoError(t, err)
+
+	// The short first cue is merged into the next under the netflix style
+	assert.Equal(t, "WEBVTT\n\n00:00:01.000 --> 00:00:03.000\nはい そうです\n\n", output)
+}
+
 func TestGetFormatter(t *testing.T) {
 	tests := []struct {
 		format       string
@@ -104,6 +157,7 @@ func TestGetFormatter(t *testing.T) {
 		{"txt", "*translation.TextFormatter", false},
 		{"json", "*translation.JSONFormatter", false},
 		{"srt", "*translation.SRTFormatter", false},
+		{"vtt", "*translation.VTTFormatter", false},
 		{"invalid", "", true},
 	}
 
@@ -4,10 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/Taichi-iskw/yt-lang/internal/service/translation"
+	"github.com/Taichi-iskw/yt-lang/internal/subtitle"
+	"github.com/Taichi-iskw/yt-lang/internal/tmpl"
 	"github.com/spf13/cobra"
 )
 
@@ -16,13 +17,29 @@ func NewGetCommand(service translation.TranslationService) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "get [TRANSLATION_ID]",
 		Short: "Get a translation",
-		Args:  cobra.ExactArgs(1),
+		Long: `Get a translation by ID.
+--template renders the translation with a Go template instead, for scripts
+(e.g. --template '{{.TargetLanguage}}\t{{.TranslatedText}}'; {{json .}} shows every field).
+--porcelain prints the fields of translation list --porcelain instead, tab-separated with no
+header (-z ends the record with NUL instead of a newline).`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			translationID := args[0]
 
 			// Get flags
 			format, _ := cmd.Flags().GetString("format")
 
+			text, _ := cmd.Flags().GetString("template")
+			porcelain, _ := cmd.Flags().GetBool("porcelain")
+			nul, _ := cmd.Flags().GetBool("null")
+			rendered, err := tmpl.FromFlags(text, porcelain, nul, porcelainFields)
+			if err != nil {
+				return err
+			}
+			if rendered != nil && format != "text" {
+				return fmt.Errorf("--template and --porcelain cannot be combined with --format %s", format)
+			}
+
 			// Use provided service if available (for testing), otherwise create real service
 			var translationService translation.TranslationService
 			var cleanup func()
@@ -49,6 +66,9 @@ func NewGetCommand(service translation.TranslationService) *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("failed to get translation: %w", err)
 			}
+			if rendered != nil {
+				return rendered.Execute(cmd.OutOrStdout(), translation)
+			}
 
 			// Format output
 			switch format {
@@ -59,37 +79,20 @@ func NewGetCommand(service translation.TranslationService) *cobra.Command {
 				}
 				cmd.Println(string(output))
 			case "srt":
-				// Format as SRT subtitle file
-				if segments != nil && len(segments) > 0 {
-					for i, seg := range segments {
-						// SRT format: sequence number, timing, content, blank line
-						cmd.Printf("%d\n", i+1)
-						// Since we don't have timing info from parsed segments,
-						// use estimated timing based on segment index
-						startTime := formatSRTTime(i * 3) // 3 seconds per segment estimate
-						endTime := formatSRTTime((i + 1) * 3)
-						cmd.Printf("%s --> %s\n", startTime, endTime)
-						cmd.Printf("%s\n\n", seg.TranslatedText)
-					}
-				} else {
-					// Fallback: split content into lines for SRT format
-					lines := strings.Split(translation.TranslatedText, "\n")
-					for i, line := range lines {
-						line = strings.TrimSpace(line)
-						if line == "" {
-							continue
-						}
-						cmd.Printf("%d\n", i+1)
-						startTime := formatSRTTime(i * 3)
-						endTime := formatSRTTime((i + 1) * 3)
-						cmd.Printf("%s --> %s\n", startTime, endTime)
-						cmd.Printf("%s\n\n", line)
-					}
+				// The segment the translation belongs to gives the cue timing; only rows whose segment is
+				// gone fall back to parsed segments without timing
+				output, err := (&SRTFormatter{Rules: subtitle.DefaultRules()}).Format(translation, segments)
+				if err != nil {
+					return fmt.Errorf("%w (use 'translation export TRANSCRIPTION_ID --target-lang %s --format srt' for subtitles aligned to the original segments)", err, translation.TargetLanguage)
 				}
+				cmd.Print(output)
 			default: // text
 				cmd.Printf("Translation ID: %d\n", translation.ID)
 				cmd.Printf("Target Language: %s\n", translation.TargetLanguage)
 				cmd.Printf("Source: %s\n", translation.Source)
+				if translation.Style != "" {
+					cmd.Printf("Style: %s\n", translation.Style)
+				}
 				cmd.Println("\nTranslatedText:")
 				if segments != nil && len(segments) > 0 {
 					for _, seg := range segments {
@@ -106,6 +109,9 @@ func NewGetCommand(service translation.TranslationService) *cobra.Command {
 
 	// Add flags
 	cmd.Flags().String("format", "text", "Output format (text, json, srt)")
+	cmd.Flags().String("template", "", "Render the translation with a Go template (e.g. '{{.ID}} {{.TargetLanguage}}')")
+	cmd.Flags().Bool("porcelain", false, "Print stable tab-separated fields for scripts (see translation list --porcelain)")
+	cmd.Flags().BoolP("null", "z", false, "End the --porcelain record with NUL instead of a newline")
 
 	return cmd
 }
@@ -0,0 +1,144 @@
+package translation
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	translationSvc "github.com/Taichi-iskw/yt-lang/internal/service/translation"
+	"github.com/spf13/cobra"
+)
+
+// NewInteractiveCommand creates the interactive translation command
+func NewInteractiveCommand(service translationSvc.TranslationService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "interactive [TRANSCRIPTION_ID]",
+		Short: "Review machine translations segment by segment",
+		Long: `Show each segment of a transcription with a proposed PLaMo translation and accept,
+edit or skip it. Every accepted or edited segment is saved immediately (edits are stored
+with source "manual"), so you can quit at any time and run the command again to continue
+with the segments that are still untranslated.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			transcriptionID := args[0]
+			targetLang, _ := cmd.Flags().GetString("target")
+
+			// Use provided service if available (for testing), otherwise create real service
+			var translationService translationSvc.TranslationService
+			var cleanup func()
+
+			if service != nil {
+				translationService = service
+			} else {
+				ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+				defer cancel()
+
+				debugPlamo, _ := cmd.Flags().GetBool("debug-plamo")
+				factory := NewServiceFactory().WithPlamoDebug(debugPlamo)
+				var err error
+
+				// Segments are translated one at a time, so keep the model loaded between them
+				cmd.Println("Starting PLaMo server...")
+				translationService, cleanup, err = factory.CreateServiceWithPlamoServer(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to create translation service: %w", err)
+				}
+				defer func() {
+					cmd.Println("Stopping PLaMo server...")
+					cleanup()
+				}()
+			}
+
+			// A review session lasts as long as the user keeps going
+			ctx := context.Background()
+
+			reviewer := newPromptReviewer(cmd.InOrStdin(), cmd.OutOrStdout())
+			summary, err := translationService.TranslateInteractively(ctx, translationSvc.InteractiveOptions{
+				TranscriptionID: transcriptionID,
+				TargetLanguage:  targetLang,
+			}, reviewer)
+			if summary != nil {
+				printInteractiveSummary(cmd.OutOrStdout(), summary)
+			}
+			if err != nil {
+				return fmt.Errorf("interactive translation stopped: %w", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("target", "ja", "Target language for translation")
+
+	return cmd
+}
+
+// promptReviewer asks the user about each proposed translation on the terminal
+type promptReviewer struct {
+	scanner *bufio.Scanner
+	out     io.Writer
+}
+
+func newPromptReviewer(in io.Reader, out io.Writer) *promptReviewer {
+	return &promptReviewer{scanner: bufio.NewScanner(in), out: out}
+}
+
+// Review shows the segment and its proposal and reads a decision. End of input quits.
+func (r *promptReviewer) Review(ctx context.Context, item *translationSvc.ReviewItem) (translationSvc.ReviewDecision, error) {
+	fmt.Fprintf(r.out, "\n[%d/%d] %s - %s\n", item.Position, item.Total, item.Segment.StartTime, item.Segment.EndTime)
+	fmt.Fprintf(r.out, "  source:   %s\n", strings.TrimSpace(item.Segment.Text))
+	fmt.Fprintf(r.out, "  proposal: %s\n", item.Proposal)
+
+	for {
+		fmt.Fprint(r.out, "[a]ccept, [e]dit, [s]kip, [q]uit (default: accept): ")
+		answer, ok := r.readLine()
+		if !ok {
+			return translationSvc.ReviewDecision{Action: translationSvc.ReviewQuit}, r.scanner.Err()
+		}
+
+		switch strings.ToLower(answer) {
+		case "", "a", "accept":
+			return translationSvc.ReviewDecision{Action: translationSvc.ReviewAccept}, nil
+		case "s", "skip":
+			return translationSvc.ReviewDecision{Action: translationSvc.ReviewSkip}, nil
+		case "q", "quit":
+			return translationSvc.ReviewDecision{Action: translationSvc.ReviewQuit}, nil
+		case "e", "edit":
+			fmt.Fprint(r.out, "translation> ")
+			text, ok := r.readLine()
+			if !ok {
+				return translationSvc.ReviewDecision{Action: translationSvc.ReviewQuit}, r.scanner.Err()
+			}
+			if text == "" {
+				fmt.Fprintln(r.out, "Empty translation, choose again")
+				continue
+			}
+			return translationSvc.ReviewDecision{Action: translationSvc.ReviewEdit, Text: text}, nil
+		default:
+			fmt.Fprintf(r.out, "Unknown choice %q\n", answer)
+		}
+	}
+}
+
+// readLine reads one trimmed line, reporting false at end of input
+func (r *promptReviewer) readLine() (string, bool) {
+	if !r.scanner.Scan() {
+		return "", false
+	}
+	return strings.TrimSpace(r.scanner.Text()), true
+}
+
+// printInteractiveSummary reports how many segments were saved in the session
+func printInteractiveSummary(out io.Writer, summary *translationSvc.InteractiveSummary) {
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "Saved %d segments (%d accepted, %d edited), skipped %d\n",
+		summary.Accepted+summary.Edited, summary.Accepted, summary.Edited, summary.Skipped)
+	if summary.AlreadyTranslated > 0 {
+		fmt.Fprintf(out, "%d of %d segments were already translated\n", summary.AlreadyTranslated, summary.Segments)
+	}
+	if summary.Quit {
+		fmt.Fprintln(out, "Run the command again to continue with the remaining segments")
+	}
+}
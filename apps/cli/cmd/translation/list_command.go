@@ -5,16 +5,27 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
 	"github.com/Taichi-iskw/yt-lang/internal/service/translation"
+	"github.com/Taichi-iskw/yt-lang/internal/tmpl"
 	"github.com/spf13/cobra"
 )
 
+// porcelainFields are the translation fields of --porcelain, in column order
+var porcelainFields = []string{"ID", "TranscriptionSegmentID", "TargetLanguage", "Source", "Style", "Approved", "CreatedAt"}
+
 // NewListCommand creates the list translations command
 func NewListCommand(service translation.TranslationService) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list [TRANSCRIPTION_ID]",
 		Short: "List all translations for a transcription",
-		Args:  cobra.ExactArgs(1),
+		Long: `List the translations of a transcription, one --limit/--offset page at a time.
+--template renders each translation with a Go template instead, one per line
+(e.g. --template '{{.ID}}\t{{.TargetLanguage}}\t{{.Source}}').
+--porcelain prints id, transcription_segment_id, target_language, source, style, approved and
+created_at tab-separated with no header, in a column order that never changes; -z ends each
+translation with NUL instead of a newline.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			transcriptionID := args[0]
 
@@ -22,6 +33,14 @@ func NewListCommand(service translation.TranslationService) *cobra.Command {
 			limit, _ := cmd.Flags().GetInt("limit")
 			offset, _ := cmd.Flags().GetInt("offset")
 
+			text, _ := cmd.Flags().GetString("template")
+			porcelain, _ := cmd.Flags().GetBool("porcelain")
+			nul, _ := cmd.Flags().GetBool("null")
+			rendered, err := tmpl.FromFlags(text, porcelain, nul, porcelainFields)
+			if err != nil {
+				return err
+			}
+
 			// Use provided service if available (for testing), otherwise create real service
 			var translationService translation.TranslationService
 			var cleanup func()
@@ -42,35 +61,20 @@ func NewListCommand(service translation.TranslationService) *cobra.Command {
 				defer cleanup()
 			}
 
-			ctx := context.Background()
-			translations, err := translationService.ListTranslations(ctx, transcriptionID, limit, offset)
-			if err != nil {
-				return fmt.Errorf("failed to list translations: %w", err)
-			}
-
-			if len(translations) == 0 {
-				cmd.Println("No translations found for transcription", transcriptionID)
-				return nil
-			}
-
-			// Display translations
-			cmd.Printf("Translations for transcription %s:\n\n", transcriptionID)
-			for _, translation := range translations {
-				cmd.Printf("ID: %d\n", translation.ID)
-				cmd.Printf("Target Language: %s\n", translation.TargetLanguage)
-				cmd.Printf("Source: %s\n", translation.Source)
-				cmd.Printf("Created: %s\n", translation.CreatedAt.Format("2006-01-02 15:04:05"))
-				cmd.Printf("Content Preview: %s\n", truncateString(translation.TranslatedText, 100))
-				cmd.Println("---")
-			}
-
-			return nil
+			return handler.ListTranslations(context.Background(), cmd.OutOrStdout(), translationService, transcriptionID, handler.ListTranslationsOptions{
+				Limit:    limit,
+				Offset:   offset,
+				Template: rendered,
+			})
 		},
 	}
 
 	// Add flags
 	cmd.Flags().Int("limit", 10, "Maximum number of translations to list")
 	cmd.Flags().Int("offset", 0, "Number of translations to skip")
+	cmd.Flags().String("template", "", "Render each translation with a Go template (e.g. '{{.ID}} {{.TargetLanguage}}')")
+	cmd.Flags().Bool("porcelain", false, "Print stable tab-separated fields for scripts: id, transcription_segment_id, target_language, source, style, approved, created_at")
+	cmd.Flags().BoolP("null", "z", false, "End each --porcelain record with NUL instead of a newline")
 
 	return cmd
 }
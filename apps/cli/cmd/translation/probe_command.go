@@ -0,0 +1,124 @@
+package translation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/service/translation"
+	"github.com/spf13/cobra"
+)
+
+// NewProbeCommand creates the translation provider probe command
+func NewProbeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "probe",
+		Short: "Check a translation provider with a small built-in benchmark",
+		Long: `Translate a small built-in set of everyday sentences with a provider and report how
+close each translation is to the built-in reference, the latency of each request and the
+throughput in source characters per second. With --round-trip every translation is translated
+back and compared with the source as well.
+
+Use it to check a local PLaMo install before a long job: a broken setup shows up as failed
+requests or similarities near 0. Good translations worded differently from the reference still
+score well below 1. Nothing is stored, and no database connection is needed.
+
+Benchmark languages: ` + strings.Join(translation.ProbeLanguages(), ", ") + `
+
+Examples:
+  yt-lang translation probe --provider plamo --pair en:ja
+  yt-lang translation probe --pair ja:es --round-trip --format json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			provider, _ := cmd.Flags().GetString("provider")
+			pair, _ := cmd.Flags().GetString("pair")
+			roundTrip, _ := cmd.Flags().GetBool("round-trip")
+			format, _ := cmd.Flags().GetString("format")
+
+			if provider != translation.ProviderPlamo {
+				return fmt.Errorf("unsupported provider: %s (supported: %s)", provider, translation.ProviderPlamo)
+			}
+			if format != "text" && format != "json" {
+				return fmt.Errorf("unsupported format: %s (supported: text, json)", format)
+			}
+			from, to, ok := strings.Cut(pair, ":")
+			if !ok || from == "" || to == "" {
+				return fmt.Errorf("invalid language pair %q (use source:target, e.g. en:ja)", pair)
+			}
+
+			// Loading the model dominates the first request
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+			defer cancel()
+
+			debugPlamo, _ := cmd.Flags().GetBool("debug-plamo")
+			plamoService, err := NewServiceFactory().WithPlamoDebug(debugPlamo).CreatePlamoService()
+			if err != nil {
+				return err
+			}
+			if err := plamoService.StartServer(ctx); err != nil {
+				return fmt.Errorf("failed to start PLaMo server: %w", err)
+			}
+			defer plamoService.StopServer()
+
+			result, err := translation.Probe(ctx, plamoService, translation.ProbeOptions{From: from, To: to, RoundTrip: roundTrip})
+			if err != nil {
+				return fmt.Errorf("failed to probe %s: %w", provider, err)
+			}
+
+			out := cmd.OutOrStdout()
+			if format == "json" {
+				data, err := json.MarshalIndent(result, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to format as JSON: %w", err)
+				}
+				fmt.Fprintln(out, string(data))
+			} else {
+				writeProbeReport(out, provider, result)
+			}
+
+			if result.Failed > 0 {
+				// The report already explains the problems; usage would only bury it
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d of %d requests failed", result.Failed, len(result.Items))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("provider", translation.ProviderPlamo, "Translation provider to probe (plamo)")
+	cmd.Flags().String("pair", "en:ja", "Language pair as source:target")
+	cmd.Flags().Bool("round-trip", false, "Translate each translation back and compare it with the source")
+	cmd.Flags().String("format", "text", "Output format (text, json)")
+
+	return cmd
+}
+
+// writeProbeReport prints each benchmark sentence with its translation followed by the summary
+func writeProbeReport(w io.Writer, provider string, result *translation.ProbeResult) {
+	fmt.Fprintf(w, "Provider: %s  Pair: %s:%s\n", provider, result.From, result.To)
+
+	for i, item := range result.Items {
+		fmt.Fprintf(w, "\n[%d] %s\n", i+1, item.Source)
+		if item.Translation != "" {
+			fmt.Fprintf(w, "  translation: %s  (similarity %.2f, %s)\n", item.Translation, item.Similarity, item.Latency.Round(time.Millisecond))
+			fmt.Fprintf(w, "  reference:   %s\n", item.Reference)
+		}
+		if item.BackTranslation != "" {
+			fmt.Fprintf(w, "  round trip:  %s  (similarity %.2f, %s)\n", item.BackTranslation, item.RoundTripSimilarity, item.BackLatency.Round(time.Millisecond))
+		}
+		if item.Error != "" {
+			fmt.Fprintf(w, "  error: %s\n", item.Error)
+		}
+	}
+
+	fmt.Fprintf(w, "\nSentences: %d  Failed: %d  Elapsed: %s\n", len(result.Items), result.Failed, result.Elapsed.Round(time.Millisecond))
+	fmt.Fprintf(w, "Mean similarity: %.2f", result.Similarity)
+	if result.RoundTrip {
+		fmt.Fprintf(w, "  Round trip: %.2f", result.RoundTripSimilarity)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Mean latency: %s  Throughput: %.1f chars/s\n", result.Latency.Round(time.Millisecond), result.CharsPerSecond)
+}
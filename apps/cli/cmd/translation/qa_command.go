@@ -0,0 +1,119 @@
+package translation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/service/translation"
+	"github.com/spf13/cobra"
+)
+
+// NewQACommand creates the translation alignment QA command
+func NewQACommand(service translation.TranslationService) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "qa [TRANSCRIPTION_ID]",
+		Short: "Check translated segments for alignment problems",
+		Long: `Check every segment of a translation and flag suspicious pairs:
+
+  empty_translation  the segment has no translation or it is blank
+  untranslated_copy  the translation repeats the source text
+  length_ratio       the target/source length ratio is far from the transcription's median
+
+The warnings are stored per segment, replacing those of the previous check.
+With --fail-on-warnings the command exits with an error when anything was flagged.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			transcriptionID := args[0]
+
+			// Get flags
+			targetLang, _ := cmd.Flags().GetString("target")
+			format, _ := cmd.Flags().GetString("format")
+			ratioFactor, _ := cmd.Flags().GetFloat64("ratio-factor")
+			failOnWarnings, _ := cmd.Flags().GetBool("fail-on-warnings")
+
+			if format != "text" && format != "json" {
+				return fmt.Errorf("unsupported format: %s (supported: text, json)", format)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			// Use provided service if available (for testing), otherwise create real service
+			var translationService translation.TranslationService
+			if service != nil {
+				translationService = service
+			} else {
+				factory := NewServiceFactory()
+				var cleanup func()
+				var err error
+				translationService, cleanup, err = factory.CreateService(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to create translation service: %w", err)
+				}
+				defer cleanup()
+			}
+
+			report, err := translationService.CheckAlignment(ctx, translation.QAOptions{
+				TranscriptionID: transcriptionID,
+				TargetLanguage:  targetLang,
+				RatioFactor:     ratioFactor,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to check translation: %w", err)
+			}
+
+			out := cmd.OutOrStdout()
+			if format == "json" {
+				data, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to format as JSON: %w", err)
+				}
+				fmt.Fprintln(out, string(data))
+			} else {
+				writeQAReport(out, report)
+			}
+
+			if failOnWarnings && len(report.Warnings) > 0 {
+				return fmt.Errorf("%d of %d segments have warnings", len(report.Warnings), report.Segments)
+			}
+			return nil
+		},
+	}
+
+	// Add flags
+	cmd.Flags().String("target", "ja", "Target language of the translation to check")
+	cmd.Flags().String("format", "text", "Output format (text, json)")
+	cmd.Flags().Float64("ratio-factor", 3, "Flag length ratios more than this factor above or below the median")
+	cmd.Flags().Bool("fail-on-warnings", false, "Exit with an error when any segment is flagged")
+
+	return cmd
+}
+
+// writeQAReport prints warning counts followed by each flagged segment
+func writeQAReport(w io.Writer, report *translation.QAReport) {
+	fmt.Fprintf(w, "Transcription: %s  Language: %s\n", report.TranscriptionID, report.TargetLanguage)
+	fmt.Fprintf(w, "Segments: %d  Warnings: %d", report.Segments, len(report.Warnings))
+	if report.MedianRatio > 0 {
+		fmt.Fprintf(w, "  Median length ratio: %.2f", report.MedianRatio)
+	}
+	fmt.Fprintln(w)
+
+	codes := make([]string, 0, len(report.Counts))
+	for code := range report.Counts {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		fmt.Fprintf(w, "  %-18s %d\n", code, report.Counts[code])
+	}
+
+	for _, warning := range report.Warnings {
+		fmt.Fprintf(w, "\n[%d] %s - %s  %s: %s\n", warning.SegmentIndex+1, warning.StartTime, warning.EndTime, warning.Code, warning.Message)
+		fmt.Fprintf(w, "  source:      %s\n", warning.Text)
+		fmt.Fprintf(w, "  translation: %s\n", warning.TranslatedText)
+	}
+}
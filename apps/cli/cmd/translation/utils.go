@@ -5,20 +5,14 @@ import (
 
 	"github.com/Taichi-iskw/yt-lang/internal/model"
 	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
 )
 
-// truncateString truncates a string to the specified length
-func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen] + "..."
-}
-
 // transcriptionRepoWrapper wraps transcription and segment repositories to implement TranscriptionRepository interface
 type transcriptionRepoWrapper struct {
 	transcriptionRepo transcription.Repository
 	segmentRepo       transcription.SegmentRepository
+	videoRepo         video.Repository
 }
 
 // GetSegments implements TranscriptionRepository interface
@@ -30,3 +24,8 @@ func (w *transcriptionRepoWrapper) GetSegments(ctx context.Context, transcriptio
 func (w *transcriptionRepoWrapper) Get(ctx context.Context, id string) (*model.Transcription, error) {
 	return w.transcriptionRepo.GetByID(ctx, id)
 }
+
+// GetVideoChapters implements TranscriptionRepository interface
+func (w *transcriptionRepoWrapper) GetVideoChapters(ctx context.Context, videoID string) ([]model.VideoChapter, error) {
+	return w.videoRepo.GetChapters(ctx, videoID)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
+	"github.com/Taichi-iskw/yt-lang/cmd/translation"
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	translationRepo "github.com/Taichi-iskw/yt-lang/internal/repository/translation"
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+	"github.com/Taichi-iskw/yt-lang/internal/service/tts"
+)
+
+// ttsCmd reads a translation aloud into an audio file
+var ttsCmd = &cobra.Command{
+	Use:   "tts [TRANSLATION_ID]",
+	Short: "Read a translation aloud into an audio file",
+	Long: `Read the translation a stored translation belongs to aloud with a text-to-speech engine, for
+listening practice. TRANSLATION_ID is the ID of any translated segment (translation list shows
+them); the whole translation of its transcription into its language is read.
+
+Each segment is read at its time in the video, so the audio follows the original; a segment that
+takes longer to read than the gap before the next one pushes the following segments back.
+--max-gap shortens long silences.
+
+The engine is tts.backend in the configuration: piper (the default, run locally) or openai (the
+OpenAI speech API or a compatible server at tts.base_url). --voice defaults to the translation's
+language; tts.voices maps voices to piper models or OpenAI voice names. Output other than .wav is
+encoded with ffmpeg.
+
+Examples:
+  yt-lang tts 42 --voice ja-JP --output out.mp3
+  yt-lang tts 42 --backend openai --voice alloy --output out.wav --max-gap 2s`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		voice, _ := cmd.Flags().GetString("voice")
+		output, _ := cmd.Flags().GetString("output")
+		backendName, _ := cmd.Flags().GetString("backend")
+		maxGap, _ := cmd.Flags().GetDuration("max-gap")
+
+		// Reading a long video aloud takes a while; Ctrl+C stops it
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+		if backendName == "" {
+			backendName = cfg.TTS.Backend
+		}
+		backend, err := tts.NewBackend(tts.BackendOptions{
+			Backend: backendName,
+			Binary:  cfg.TTS.Binary,
+			BaseURL: cfg.TTS.BaseURL,
+			Model:   cfg.TTS.Model,
+			APIKey:  cfg.TTS.APIKey,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Create database connection
+		dbCtx, dbCancel := context.WithTimeout(ctx, 30*time.Second)
+		defer dbCancel()
+		dbPool, err := config.NewDatabasePool(dbCtx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		cmdRunner := common.NewCmdRunner()
+		return handler.ReadAloud(ctx, cmd.OutOrStdout(), translationRepo.NewRepository(dbPool), translation.NewReadService(dbPool), backend, args[0], handler.ReadAloudOptions{
+			Voice:  voice,
+			Voices: cfg.TTS.Voices,
+			Output: output,
+			MaxGap: maxGap,
+			Encode: func(ctx context.Context, wavPath, outputPath string) error {
+				return tts.Encode(ctx, cmdRunner, wavPath, outputPath)
+			},
+		})
+	},
+}
+
+func init() {
+	ttsCmd.Flags().String("voice", "", "Voice to read with, or a name mapped by tts.voices (default: the translation's language)")
+	ttsCmd.Flags().StringP("output", "o", "", "Audio file to write (.wav, or any format ffmpeg encodes, such as .mp3)")
+	ttsCmd.Flags().String("backend", "", "Text-to-speech engine: piper or openai (default: tts.backend)")
+	ttsCmd.Flags().Duration("max-gap", 0, "Longest silence kept between segments (0 keeps the original timing)")
+	ttsCmd.MarkFlagRequired("output")
+	rootCmd.AddCommand(ttsCmd)
+}
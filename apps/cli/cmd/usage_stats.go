@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/usage"
+)
+
+// usageRecordTimeout bounds recording a command run, which happens after the command finished
+const usageRecordTimeout = 3 * time.Second
+
+// recordUsage records a run of cmd in the usage stats when usage_stats.enabled is set. It never
+// fails the command: without a config or database nothing is recorded, and failures are only
+// reported with --debug.
+func recordUsage(cmd *cobra.Command, started time.Time, runErr error) {
+	if cmd == nil || !cmd.Runnable() || cmd == rootCmd || cmd.Name() == "help" || strings.HasPrefix(cmd.Name(), "__") {
+		return
+	}
+	cfg, err := config.NewConfig()
+	if err != nil || !cfg.UsageStats.Enabled {
+		return
+	}
+
+	record := &model.CommandUsage{
+		Command:   usageCommand(cmd),
+		StartedAt: started.UTC(),
+		Duration:  time.Since(started),
+		Succeeded: runErr == nil,
+	}
+	var appErr *errors.AppError
+	if stderrors.As(runErr, &appErr) {
+		record.ErrorCode = &appErr.Code
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), usageRecordTimeout)
+	defer cancel()
+	dbPool, err := config.NewDatabasePool(ctx, cfg)
+	if err == nil {
+		defer dbPool.Close()
+		err = usage.NewRepository(dbPool).Record(ctx, record)
+	}
+	if err != nil && debugFlag {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record usage stats: %v\n", err)
+	}
+}
+
+// usageCommand returns the path of cmd without the program name, e.g. "transcription create"
+func usageCommand(cmd *cobra.Command) string {
+	return strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" ")
+}
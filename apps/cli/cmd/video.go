@@ -4,15 +4,31 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
 	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/i18n"
+	"github.com/Taichi-iskw/yt-lang/internal/lock"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
 	"github.com/Taichi-iskw/yt-lang/internal/repository/channel"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/translation"
 	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
 	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+	dedupeSvc "github.com/Taichi-iskw/yt-lang/internal/service/dedupe"
+	statusSvc "github.com/Taichi-iskw/yt-lang/internal/service/status"
+	taggingSvc "github.com/Taichi-iskw/yt-lang/internal/service/tagging"
 	youtubeSvc "github.com/Taichi-iskw/yt-lang/internal/service/youtube"
+	"github.com/Taichi-iskw/yt-lang/internal/tmpl"
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
 )
 
 // videoCmd represents the video command
@@ -26,13 +42,44 @@ var videoCmd = &cobra.Command{
 var videoSaveCmd = &cobra.Command{
 	Use:   "save [CHANNEL_ID]",
 	Short: "Save videos from a YouTube channel ID to database",
-	Long:  `Fetch videos from a YouTube channel ID and save them to the database. Channel ID must start with 'UC' (e.g., UC123456789abcdef).`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Fetch videos from a YouTube channel ID and save them to the database. Channel ID must start with 'UC' (e.g., UC123456789abcdef).
+Use --published-after to only save videos uploaded on or after a date, so periodic syncs only touch
+fresh uploads. Dates of channel listings are approximate, and videos without a known date are kept.
+
+Without --limit (or with --limit 0) the whole channel is fetched in pages of --page-size videos.
+Each page is saved before the next is fetched and progress is reported per page, so an
+interrupted save keeps the pages it got and running it again resumes cheaply.
+
+Examples:
+  yt-lang video save UC123456789abcdef --limit 50
+  yt-lang video save UC123456789abcdef --page-size 500`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		channelID := args[0]
 
-		// Create service with timeout context
-		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		var opts youtubeSvc.FetchOptions
+		opts.Limit, _ = cmd.Flags().GetInt("limit")
+		opts.PageSize, _ = cmd.Flags().GetInt("page-size")
+		if opts.Limit < 0 {
+			return fmt.Errorf("--limit must not be negative (0 fetches the whole channel)")
+		}
+		if opts.PageSize <= 0 {
+			return fmt.Errorf("--page-size must be positive")
+		}
+		if publishedAfter, _ := cmd.Flags().GetString("published-after"); publishedAfter != "" {
+			date, err := time.Parse("2006-01-02", publishedAfter)
+			if err != nil {
+				return fmt.Errorf("invalid --published-after %q (expected YYYY-MM-DD)", publishedAfter)
+			}
+			opts.PublishedAfter = date
+		}
+
+		// Create service with timeout context; whole channels are fetched page by page
+		timeout := 60 * time.Second
+		if opts.Limit == 0 {
+			timeout = 30 * time.Minute
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
 		defer cancel()
 
 		// Load configuration
@@ -52,24 +99,37 @@ var videoSaveCmd = &cobra.Command{
 		channelRepo := channel.NewRepository(dbPool)
 		videoRepo := video.NewRepository(dbPool)
 
-		// Create YouTube service with repositories
-		youtubeService := youtubeSvc.NewYouTubeServiceWithRepositories(
-			common.NewCmdRunner(),
-			channelRepo,
-			videoRepo,
+		hooks, err := config.NewHookDispatcher(cfg)
+		if err != nil {
+			return err
+		}
+
+		// Create YouTube service with repositories, locked per channel so overlapping
+		// syncs don't fetch and insert the same videos
+		youtubeService := youtubeSvc.NewHookedService(
+			youtubeSvc.NewLockingService(
+				youtubeSvc.NewYouTubeServiceWithDetector(
+					common.NewCmdRunner(),
+					channelRepo,
+					videoRepo,
+					ytdlp.DefaultDetector(),
+				),
+				lock.NewPostgresLocker(dbPool.Pool),
+			),
+			hooks,
 		)
 
 		// Get dry-run flag
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
 
-		// If dry-run, fetch videos without saving (limit = 0 means all videos)
+		// If dry-run, fetch videos without saving (no limit means all videos)
 		if dryRun {
-			videos, err := youtubeService.FetchChannelVideos(ctx, channelID, 0)
+			videos, err := youtubeService.FetchChannelVideos(ctx, channelID, opts)
 			if err != nil {
 				return fmt.Errorf("failed to fetch videos (dry-run): %w", err)
 			}
 
-			fmt.Printf("[DRY RUN] Would save %d video(s):\n", len(videos))
+			fmt.Println(i18n.Tf("msg.videos_dry_run", "[DRY RUN] Would save {{.Count}} video(s):", map[string]any{"Count": len(videos)}))
 			result, err := json.MarshalIndent(videos, "", "  ")
 			if err != nil {
 				return fmt.Errorf("failed to format result: %w", err)
@@ -78,8 +138,16 @@ var videoSaveCmd = &cobra.Command{
 			return nil
 		}
 
-		// Save videos (limit = 0 means all videos)
-		videos, err := youtubeService.SaveChannelVideos(ctx, channelID, 0)
+		// Save videos (no limit means all videos, page by page)
+		if opts.Limit == 0 {
+			opts.OnPage = func(page youtubeSvc.SavePage) {
+				fmt.Fprintf(os.Stderr, "Page %d: fetched %d video(s), %d saved so far\n", page.Number, page.Fetched, page.Saved)
+			}
+		}
+		opts.OnChanged = func(changes []*model.VideoChange) {
+			handler.PrintChangedVideos(os.Stderr, changes)
+		}
+		videos, err := youtubeService.SaveChannelVideos(ctx, channelID, opts)
 		if err != nil {
 			return fmt.Errorf("failed to save videos: %w", err)
 		}
@@ -90,19 +158,228 @@ var videoSaveCmd = &cobra.Command{
 			return fmt.Errorf("failed to format result: %w", err)
 		}
 
-		fmt.Printf("%d video(s) saved successfully:\n%s\n", len(videos), string(result))
+		fmt.Printf("%s\n%s\n", i18n.Tf("msg.videos_saved", "{{.Count}} video(s) saved successfully:", map[string]any{"Count": len(videos)}), string(result))
 		return nil
 	},
 }
 
+// videoAddCmd saves a single video from its URL
+var videoAddCmd = &cobra.Command{
+	Use:   "add [URL]",
+	Short: "Save a single video from its URL",
+	Long: `Save one video under the channel that uploaded it, creating the channel when it is not saved yet.
+Any form of video URL is accepted: watch pages with extra parameters (&t=, &list=, &si=),
+youtu.be short links, shorts, live and embed URLs, or a bare video ID. The URL is stored in its
+canonical https://www.youtube.com/watch?v=ID form, so adding a video again from another link
+doesn't create a second copy. The video's YouTube chapters are saved with it, for
+translation create --per-chapter.
+
+Examples:
+  yt-lang video add https://youtu.be/dQw4w9WgXcQ?si=abc
+  yt-lang video add https://www.youtube.com/shorts/dQw4w9WgXcQ`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		hooks, err := config.NewHookDispatcher(cfg)
+		if err != nil {
+			return err
+		}
+
+		youtubeService := youtubeSvc.NewHookedService(
+			youtubeSvc.NewYouTubeServiceWithDetector(
+				common.NewCmdRunner(),
+				channel.NewRepository(dbPool),
+				video.NewRepository(dbPool),
+				ytdlp.DefaultDetector(),
+			),
+			hooks,
+		)
+
+		added, err := youtubeService.AddVideo(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to add video: %w", err)
+		}
+
+		switch {
+		case added.AlreadySaved:
+			fmt.Printf("Video %s is already saved (%s)\n", added.Video.ID, added.Video.URL)
+		case added.CreatedChannel:
+			fmt.Printf("Saved video %s under new channel %s (%s)\n", added.Video.ID, added.Channel.ID, added.Channel.Name)
+		default:
+			fmt.Printf("Saved video %s under channel %s\n", added.Video.ID, added.Channel.ID)
+		}
+		fmt.Printf("%s\n%s\n", added.Video.Title, added.Video.URL)
+		return nil
+	},
+}
+
+// videoImportListCmd imports a personal playlist of the signed-in YouTube account
+var videoImportListCmd = &cobra.Command{
+	Use:   "import-list [WL|LL]",
+	Short: "Import your watch later or liked videos list",
+	Long: `Import the watch later (WL) or liked videos (LL) list of your YouTube account. yt-dlp reads
+the list with the cookies of a browser signed in to YouTube. Videos are saved under the channels
+that uploaded them, and channels that are not saved yet are created. Deleted and private entries
+are skipped.
+
+Examples:
+  yt-lang video import-list WL --cookies-from-browser firefox
+  yt-lang video import-list LL --cookies-from-browser "chrome:Profile 1" --limit 50`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var opts youtubeSvc.ImportListOptions
+		opts.CookiesFromBrowser, _ = cmd.Flags().GetString("cookies-from-browser")
+		opts.Limit, _ = cmd.Flags().GetInt("limit")
+
+		// Long lists take a while to page through
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		hooks, err := config.NewHookDispatcher(cfg)
+		if err != nil {
+			return err
+		}
+
+		// Locked per list so overlapping imports don't create the same channels and videos
+		youtubeService := youtubeSvc.NewHookedService(
+			youtubeSvc.NewLockingService(
+				youtubeSvc.NewYouTubeServiceWithDetector(
+					common.NewCmdRunner(),
+					channel.NewRepository(dbPool),
+					video.NewRepository(dbPool),
+					ytdlp.DefaultDetector(),
+				),
+				lock.NewPostgresLocker(dbPool.Pool),
+			),
+			hooks,
+		)
+
+		result, err := youtubeService.ImportList(ctx, args[0], opts)
+		if err != nil {
+			return fmt.Errorf("failed to import list: %w", err)
+		}
+
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format result: %w", err)
+		}
+		fmt.Println(i18n.Tf("msg.list_imported", "{{.Count}} video(s) imported from {{.List}} ({{.Channels}} new channel(s), {{.Skipped}} skipped):",
+			map[string]any{"Count": len(result.Videos), "List": result.List, "Channels": len(result.CreatedChannels), "Skipped": len(result.Skipped)}))
+		fmt.Println(string(data))
+		return nil
+	},
+}
+
+// videoPorcelainFields are the video fields of video list --porcelain, in column order
+var videoPorcelainFields = []string{"ID", "ChannelID", "Title", "Duration", "Status", "UploadDate", "URL"}
+
 // videoListCmd lists videos for a specific channel
 var videoListCmd = &cobra.Command{
 	Use:   "list [CHANNEL_ID]",
 	Short: "List videos for a specific channel",
-	Long:  `List videos for a specific channel saved in the database.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `List videos for a specific channel saved in the database.
+The channel is given as an argument or with --channel.
+--min-rating lists only videos annotated with at least that rating, best rated first; the
+channel is then optional, and without one rated videos of every channel are listed.
+--tag lists the videos of every channel tagged with a topic by video autotag, best match first.
+
+--format csv/tsv writes one row per video with the columns chosen by --columns
+(id, channel_id, title, url, duration, status, upload_date, rating, note), for spreadsheet analysis.
+An --output file ending in .csv or .tsv picks that format when --format is not given.
+--all lists every video of the channel instead of one --limit/--offset page; rows are
+streamed as they are loaded.
+--template renders each video with a Go template instead, one per line, for scripts that need
+a few fields (fields of the JSON output in Go form: .ID, .Title, .Duration, .UploadDate, ...).
+--porcelain prints id, channel_id, title, duration, status, upload_date and url
+tab-separated with no header, in a column order that never changes; -z ends each video with
+NUL instead of a newline, for titles read with xargs -0 or read -d ''.
+
+Examples:
+  yt-lang video list --channel UCxxx --format csv --columns id,title,duration,url --all
+  yt-lang video list UCxxx --format tsv --all --output videos.tsv
+  yt-lang video list --min-rating 4 --format csv --columns id,title,rating,note
+  yt-lang video list --tag paella --format csv --columns id,channel_id,title
+  yt-lang video list UCxxx --all --template '{{.ID}}\t{{.Title}}'
+  yt-lang video list UCxxx --all --porcelain -z | xargs -0 -n1 printf '%s\n'`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		channelID := args[0]
+		channelID, _ := cmd.Flags().GetString("channel")
+		if len(args) == 1 {
+			if channelID != "" && channelID != args[0] {
+				return fmt.Errorf("channel given both as argument (%s) and --channel (%s)", args[0], channelID)
+			}
+			channelID = args[0]
+		}
+		minRating, _ := cmd.Flags().GetInt("min-rating")
+		tag, _ := cmd.Flags().GetString("tag")
+		if tag != "" && (channelID != "" || minRating != 0) {
+			return fmt.Errorf("--tag cannot be combined with a channel or --min-rating")
+		}
+		if channelID == "" && minRating == 0 && tag == "" {
+			return fmt.Errorf("channel ID is required (argument or --channel)")
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		columns, _ := cmd.Flags().GetString("columns")
+		all, _ := cmd.Flags().GetBool("all")
+		output, _ := cmd.Flags().GetString("output")
+		withStatus, _ := cmd.Flags().GetBool("with-status")
+		switch output {
+		case "json", "csv", "tsv":
+			return fmt.Errorf("--output takes a file name; use --format %s to choose the format", output)
+		}
+		// The extension of the output file picks the format unless --format is given
+		if ext := strings.TrimPrefix(filepath.Ext(output), "."); !cmd.Flags().Changed("format") && (ext == "csv" || ext == "tsv") {
+			format = ext
+		}
+		switch format {
+		case "json":
+		case "csv", "tsv":
+			if withStatus {
+				return fmt.Errorf("--with-status is only supported with --format json")
+			}
+		default:
+			return fmt.Errorf("unsupported format: %s (supported: json, csv, tsv)", format)
+		}
+
+		text, _ := cmd.Flags().GetString("template")
+		porcelain, _ := cmd.Flags().GetBool("porcelain")
+		nul, _ := cmd.Flags().GetBool("null")
+		rendered, err := tmpl.FromFlags(text, porcelain, nul, videoPorcelainFields)
+		if err != nil {
+			return err
+		}
+		if rendered != nil && (format != "json" || withStatus) {
+			return fmt.Errorf("--template and --porcelain cannot be combined with --format or --with-status")
+		}
 
 		// Create service with timeout context
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -136,38 +413,634 @@ var videoListCmd = &cobra.Command{
 		limit, _ := cmd.Flags().GetInt("limit")
 		offset, _ := cmd.Flags().GetInt("offset")
 
-		// List videos
-		videos, err := youtubeService.ListVideos(ctx, channelID, limit, offset)
+		// Write to stdout unless an output file is given
+		var w io.Writer = os.Stdout
+		if output != "" {
+			file, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer file.Close()
+			w = file
+		}
+
+		opts := handler.ListVideosOptions{
+			ChannelID: channelID,
+			MinRating: minRating,
+			Tag:       tag,
+			Limit:     limit,
+			Offset:    offset,
+			All:       all,
+			Format:    format,
+			Columns:   columns,
+			Template:  rendered,
+			Info:      os.Stderr,
+		}
+
+		if tag != "" {
+			opts.Tags = taggingSvc.NewTaggingService(
+				videoRepo,
+				transcription.NewRepository(dbPool),
+				transcription.NewSegmentRepository(dbPool),
+				video.NewTagRepository(dbPool),
+			)
+		}
+
+		// Attach each video's workflow status when requested
+		if withStatus {
+			exportDir, _ := cmd.Flags().GetString("export-dir")
+			if opts.Status, err = newStatusService(cfg, dbPool, exportDir); err != nil {
+				return err
+			}
+		}
+
+		return handler.ListVideos(ctx, w, youtubeService, opts)
+	},
+}
+
+// videoAnnotateCmd rates a video and notes why, for curating study material
+var videoAnnotateCmd = &cobra.Command{
+	Use:   "annotate [VIDEO_ID]",
+	Short: "Rate a video and add a note",
+	Long: `Store a 1-5 rating and a free-form note on a saved video, e.g. to curate study material.
+Only the given fields change: --rating keeps the note and --note keeps the rating. An empty
+--note removes the note, and --clear removes both. Rated videos are listed with
+video list --min-rating.
+
+Examples:
+  yt-lang video annotate dQw4w9WgXcQ --rating 4 --note "great for B1 learners"
+  yt-lang video annotate dQw4w9WgXcQ --clear`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		videoID := args[0]
+
+		var opts youtubeSvc.AnnotateOptions
+		if cmd.Flags().Changed("rating") {
+			rating, _ := cmd.Flags().GetInt("rating")
+			opts.Rating = &rating
+		}
+		if cmd.Flags().Changed("note") {
+			note, _ := cmd.Flags().GetString("note")
+			opts.Note = &note
+		}
+		opts.Clear, _ = cmd.Flags().GetBool("clear")
+
+		// Create context
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		youtubeService := youtubeSvc.NewYouTubeServiceWithRepositories(
+			common.NewCmdRunner(),
+			channel.NewRepository(dbPool),
+			video.NewRepository(dbPool),
+		)
+
+		annotated, err := youtubeService.AnnotateVideo(ctx, videoID, opts)
+		if err != nil {
+			return fmt.Errorf("failed to annotate video: %w", err)
+		}
+
+		fmt.Printf("✅ Annotated %s (%s)\n", annotated.ID, annotated.Title)
+		rating, note := "-", "-"
+		if annotated.Rating != nil {
+			rating = fmt.Sprintf("%d/%d", *annotated.Rating, youtubeSvc.MaxRating)
+		}
+		if annotated.Note != nil {
+			note = *annotated.Note
+		}
+		fmt.Printf("Rating: %s\n", rating)
+		fmt.Printf("Note: %s\n", note)
+		return nil
+	},
+}
+
+// videoAutotagCmd tags videos with the topics of their transcripts
+var videoAutotagCmd = &cobra.Command{
+	Use:   "autotag [VIDEO_ID]",
+	Short: "Tag videos with topics found in their transcripts",
+	Long: `Propose topic tags for a transcribed video, or with --channel for every transcribed video of a
+channel, and store them. Tags are the keywords of a transcript weighed by TF-IDF: words a video
+uses often that the other videos of its channel rarely use, so a single video is always weighed
+against its channel. Stopwords, words shorter than --min-length and words said only once are
+ignored. Tagging a video again replaces its tags. Find the videos of a topic with video list --tag.
+
+Examples:
+  yt-lang video autotag --channel UCxxx
+  yt-lang video autotag dQw4w9WgXcQ --top 10 --dry-run
+  yt-lang video list --tag paella`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var opts taggingSvc.AutoTagOptions
+		opts.ChannelID, _ = cmd.Flags().GetString("channel")
+		if len(args) == 1 {
+			opts.VideoID = args[0]
+		}
+		if (opts.VideoID == "") == (opts.ChannelID == "") {
+			return fmt.Errorf("give either a video ID or --channel")
+		}
+		opts.Top, _ = cmd.Flags().GetInt("top")
+		opts.MinWordLength, _ = cmd.Flags().GetInt("min-length")
+		opts.DryRun, _ = cmd.Flags().GetBool("dry-run")
+		format, _ := cmd.Flags().GetString("format")
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unsupported format: %s (supported: table, json)", format)
+		}
+
+		// Every transcript of the channel is read to weigh the keywords
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		taggingService := taggingSvc.NewTaggingService(
+			video.NewRepository(dbPool),
+			transcription.NewRepository(dbPool),
+			transcription.NewSegmentRepository(dbPool),
+			video.NewTagRepository(dbPool),
+		)
+
+		result, err := taggingService.AutoTag(ctx, opts)
 		if err != nil {
-			return fmt.Errorf("failed to list videos: %w", err)
+			return fmt.Errorf("failed to tag videos: %w", err)
 		}
 
-		// Check if no videos found
-		if len(videos) == 0 {
-			fmt.Printf("No videos found for channel ID: %s\n", channelID)
+		if format == "json" {
+			data, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format result: %w", err)
+			}
+			fmt.Println(string(data))
 			return nil
 		}
 
-		// Display result as JSON
-		result, err := json.MarshalIndent(videos, "", "  ")
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VIDEO ID\tTAGS\tTITLE")
+		for _, tagged := range result.Videos {
+			tags := make([]string, len(tagged.Tags))
+			for i, tag := range tagged.Tags {
+				tags[i] = tag.Tag
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", tagged.Video.ID, strings.Join(tags, ", "), handler.Truncate(tagged.Video.Title, 50))
+		}
+		w.Flush()
+
+		verb := "Tagged"
+		if result.DryRun {
+			verb = "Would tag"
+		}
+		fmt.Printf("\n%s %d video(s), weighed against %d transcribed video(s) of channel %s\n", verb, len(result.Videos), result.Documents, result.ChannelID)
+		if result.Untranscribed > 0 {
+			fmt.Printf("Skipped %d video(s) without a completed transcription\n", result.Untranscribed)
+		}
+		return nil
+	},
+}
+
+// videoVerifyCmd checks that a channel's stored videos are still available on YouTube
+var videoVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check whether a channel's saved videos are still available",
+	Long: `Check the saved videos of a channel against a lightweight yt-dlp listing of the channel.
+Videos that were deleted or made private are marked unavailable and skipped by batch
+pipelines such as export and vocab; videos that reappear are marked available again.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		channelID, _ := cmd.Flags().GetString("channel")
+		format, _ := cmd.Flags().GetString("format")
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unsupported format: %s (supported: table, json)", format)
+		}
+
+		// Listing large channels can take a while
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
 		if err != nil {
-			return fmt.Errorf("failed to format result: %w", err)
+			return fmt.Errorf("failed to load configuration: %w", err)
 		}
 
-		fmt.Printf("Found %d video(s) for channel %s:\n%s\n", len(videos), channelID, string(result))
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		// Create YouTube service with repositories, locked per channel like video save
+		youtubeService := youtubeSvc.NewLockingService(
+			youtubeSvc.NewYouTubeServiceWithDetector(
+				common.NewCmdRunner(),
+				channel.NewRepository(dbPool),
+				video.NewRepository(dbPool),
+				ytdlp.DefaultDetector(),
+			),
+			lock.NewPostgresLocker(dbPool.Pool),
+		)
+
+		result, err := youtubeService.VerifyChannelVideos(ctx, channelID)
+		if err != nil {
+			return fmt.Errorf("failed to verify videos: %w", err)
+		}
+
+		if format == "json" {
+			data, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format result: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		all, _ := cmd.Flags().GetBool("all")
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VIDEO ID\tSTATUS\tCHANGE\tTITLE")
+		for _, v := range result.Videos {
+			if !all && !v.Changed && v.Status == model.VideoStatusAvailable {
+				continue
+			}
+			change := ""
+			if v.Changed {
+				change = v.Previous + " -> " + v.Status
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", v.ID, v.Status, change, v.Title)
+		}
+		w.Flush()
+
+		fmt.Printf("\nChecked %d video(s): %d available, %d unavailable, %d changed\n",
+			result.Checked, result.Available, result.Unavailable, result.Changed)
 		return nil
 	},
 }
 
+// videoDedupeCmd finds likely re-uploads and mirrors among the saved videos of all channels
+var videoDedupeCmd = &cobra.Command{
+	Use:   "dedupe",
+	Short: "Find re-uploaded videos across saved channels",
+	Long: `Find videos that are likely re-uploads or mirrors of each other across all saved channels.
+
+--by title-similarity (default) matches titles that are at least --threshold similar (0-1,
+ignoring case and punctuation) with durations within --tolerance when both are known.
+--by duration matches durations within --tolerance alone.
+
+In each group the earliest upload is the original. With --link the others are recorded as its
+duplicates, and transcription create-batch skips them.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		by, _ := cmd.Flags().GetString("by")
+		threshold, _ := cmd.Flags().GetFloat64("threshold")
+		tolerance, _ := cmd.Flags().GetDuration("tolerance")
+		link, _ := cmd.Flags().GetBool("link")
+		format, _ := cmd.Flags().GetString("format")
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unsupported format: %s (supported: table, json)", format)
+		}
+
+		// Create context
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		dedupeService := dedupeSvc.NewService(video.NewRepository(dbPool))
+		groups, err := dedupeService.Detect(ctx, dedupeSvc.Options{Mode: by, Threshold: threshold, Tolerance: tolerance})
+		if err != nil {
+			return fmt.Errorf("failed to detect duplicates: %w", err)
+		}
+
+		linked := 0
+		if link {
+			if linked, err = dedupeService.Link(ctx, groups); err != nil {
+				return fmt.Errorf("failed to link duplicates: %w", err)
+			}
+		}
+
+		if format == "json" {
+			data, err := json.MarshalIndent(groups, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format result: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		duplicates := printDuplicateGroups(cmd.OutOrStdout(), groups)
+		fmt.Printf("\nFound %d group(s) with %d likely duplicate(s)\n", len(groups), duplicates)
+		if link {
+			fmt.Printf("Linked %d duplicate(s) to their originals; batch transcription skips them\n", linked)
+		} else if duplicates > 0 {
+			fmt.Println("Run with --link to skip the duplicates in batch processing")
+		}
+		return nil
+	},
+}
+
+// printDuplicateGroups prints each group's original followed by its duplicates and returns the number of duplicates
+func printDuplicateGroups(out io.Writer, groups []*dedupeSvc.Group) int {
+	duplicates := 0
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "GROUP\tVIDEO ID\tCHANNEL\tDURATION\tMATCH\tTITLE")
+	for i, group := range groups {
+		v := group.Original
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", i+1, v.ID, v.ChannelID, handler.FormatDuration(v.Duration), "original", v.Title)
+		for _, match := range group.Duplicates {
+			v := match.Video
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%.2f\t%s\n", i+1, v.ID, v.ChannelID, handler.FormatDuration(v.Duration), match.Similarity, v.Title)
+			duplicates++
+		}
+	}
+	w.Flush()
+	return duplicates
+}
+
+// videoCaptionsCmd lists the caption tracks YouTube offers for a video
+var videoCaptionsCmd = &cobra.Command{
+	Use:   "captions [VIDEO_ID]",
+	Short: "List the caption tracks YouTube offers for a video",
+	Long: `List the caption tracks of a video with yt-dlp --list-subs: manual captions uploaded by the
+creator, and automatic captions generated by YouTube in the spoken language. Manual captions are
+usually accurate enough to import instead of running Whisper; automatic ones are rougher.
+YouTube machine-translates automatic captions into many languages: they are only counted
+unless --all is given.
+
+Examples:
+  yt-lang video captions dQw4w9WgXcQ
+  yt-lang video captions dQw4w9WgXcQ --all --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		videoID := args[0]
+		format, _ := cmd.Flags().GetString("format")
+		all, _ := cmd.Flags().GetBool("all")
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unsupported format: %s (supported: table, json)", format)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		// Listing captions needs no database
+		listing, err := youtubeSvc.NewYouTubeService().ListCaptions(ctx, videoID)
+		if err != nil {
+			return fmt.Errorf("failed to list captions: %w", err)
+		}
+
+		var shown []*youtubeSvc.CaptionTrack
+		translated := 0
+		for _, track := range listing.Tracks {
+			if all || track.Kind == youtubeSvc.CaptionManual || track.Original {
+				shown = append(shown, track)
+			} else {
+				translated++
+			}
+		}
+
+		if format == "json" {
+			data, err := json.MarshalIndent(&youtubeSvc.CaptionListing{VideoID: listing.VideoID, Tracks: shown}, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format captions: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return nil
+		}
+
+		out := cmd.OutOrStdout()
+		if len(listing.Tracks) == 0 {
+			fmt.Fprintf(out, "Video %s has no captions; transcribe it with: yt-lang transcription create %s\n", videoID, videoID)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "LANGUAGE\tKIND\tNAME\tFORMATS")
+		for _, track := range shown {
+			kind := track.Kind
+			if track.Original {
+				kind += " (original)"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", track.Language, kind, track.Name, strings.Join(track.Formats, ", "))
+		}
+		w.Flush()
+		if translated > 0 {
+			fmt.Fprintf(out, "\n%d machine-translated automatic track(s) hidden; use --all to list them\n", translated)
+		}
+
+		if manual := listing.Manual(); len(manual) > 0 {
+			fmt.Fprintf(out, "\nManual captions are available. Download and import them instead of running Whisper:\n")
+			fmt.Fprintf(out, "  yt-dlp --skip-download --write-subs --sub-langs %s --sub-format vtt -o %s https://www.youtube.com/watch?v=%s\n", manual[0].Language, videoID, videoID)
+			fmt.Fprintf(out, "  yt-lang transcription import %s --file %s.%s.vtt --lang %s\n", videoID, videoID, manual[0].Language, manual[0].Language)
+		} else {
+			fmt.Fprintf(out, "\nOnly automatic captions are available; Whisper usually transcribes more accurately:\n")
+			fmt.Fprintf(out, "  yt-lang transcription create %s\n", videoID)
+		}
+		return nil
+	},
+}
+
+// videoStatusCmd shows where a video is in the processing workflow
+var videoStatusCmd = &cobra.Command{
+	Use:   "status [VIDEO_ID]",
+	Short: "Show where a video is in the processing workflow",
+	Long: `Show which workflow stages a video has reached:
+fetched -> audio_cached -> transcribed (languages) -> translated (languages) -> exported.
+The status is derived from the database and the artifact storage configured in config.yaml.
+Exports are found through their manifests in the artifact storage (export transcripts --to-storage)
+and, with --export-dir, in a local export directory.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		videoID := args[0]
+		format, _ := cmd.Flags().GetString("format")
+		exportDir, _ := cmd.Flags().GetString("export-dir")
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unsupported format: %s (supported: table, json)", format)
+		}
+
+		// Create service with timeout context
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		statusService, err := newStatusService(cfg, dbPool, exportDir)
+		if err != nil {
+			return err
+		}
+
+		status, err := statusService.GetVideoStatus(ctx, videoID)
+		if err != nil {
+			return fmt.Errorf("failed to get video status: %w", err)
+		}
+
+		if format == "json" {
+			data, err := json.MarshalIndent(status, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format result: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		printVideoStatus(status)
+		return nil
+	},
+}
+
+// newStatusService creates a status service reading the database, the configured artifact storage and exportDir
+func newStatusService(cfg *config.Config, dbPool *config.DatabasePool, exportDir string) (statusSvc.StatusService, error) {
+	store, err := config.NewArtifactStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return statusSvc.NewStatusService(
+		video.NewRepository(dbPool),
+		transcription.NewRepository(dbPool),
+		translation.NewRepository(dbPool),
+		store,
+		exportDir,
+	), nil
+}
+
+// printVideoStatus prints one line per workflow stage with what was found for it
+func printVideoStatus(status *statusSvc.VideoStatus) {
+	fmt.Printf("%s  %s\n\n", status.Video.ID, status.Video.Title)
+
+	details := map[string]string{
+		statusSvc.StageTranscribed: strings.Join(status.Transcribed, ", "),
+		statusSvc.StageTranslated:  strings.Join(status.Translated, ", "),
+		statusSvc.StageExported:    strings.Join(status.Exported, ", "),
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, stage := range statusSvc.Stages {
+		icon := "⬜"
+		if status.Reached(stage) {
+			icon = "✅"
+		}
+		fmt.Fprintf(w, "%s %s\t%s\n", icon, stage, details[stage])
+	}
+	w.Flush()
+
+	fmt.Printf("\nStage: %s\n", status.Stage)
+	if !status.Video.IsAvailable() {
+		fmt.Println("⚠️  Video is unavailable on YouTube")
+	}
+}
+
 func init() {
 	// Add flags to save command
+	videoSaveCmd.Flags().Int("limit", 0, "Newest videos to save (0 saves the whole channel)")
+	videoSaveCmd.Flags().Int("page-size", youtubeSvc.DefaultSavePageSize, "Videos fetched and saved per page when saving the whole channel")
 	videoSaveCmd.Flags().Bool("dry-run", false, "Preview videos without saving to database")
+	videoSaveCmd.Flags().String("published-after", "", "Only save videos uploaded on or after this date (YYYY-MM-DD)")
+
+	// Add flags to import-list command
+	videoImportListCmd.Flags().String("cookies-from-browser", "", "Browser signed in to YouTube whose cookies yt-dlp uses, e.g. firefox or chrome:Profile 1 (required)")
+	videoImportListCmd.Flags().Int("limit", 0, "Import only the first N entries of the list (0 imports all)")
+	videoImportListCmd.MarkFlagRequired("cookies-from-browser")
 
 	// Add pagination flags to list command
 	videoListCmd.Flags().Int("limit", 10, "Maximum number of videos to retrieve")
 	videoListCmd.Flags().Int("offset", 0, "Number of videos to skip")
+	videoListCmd.Flags().Bool("all", false, "List every video of the channel, ignoring --limit and --offset")
+	videoListCmd.Flags().String("channel", "", "Channel ID whose videos are listed (instead of the argument)")
+	videoListCmd.Flags().String("format", "json", "Output format: json, csv, tsv")
+	videoListCmd.Flags().String("template", "", "Render each video with a Go template (e.g. '{{.ID}} {{.Title}}')")
+	videoListCmd.Flags().Bool("porcelain", false, "Print stable tab-separated fields for scripts: id, channel_id, title, duration, status, upload_date, url")
+	videoListCmd.Flags().BoolP("null", "z", false, "End each --porcelain record with NUL instead of a newline")
+	videoListCmd.Flags().String("columns", handler.DefaultVideoColumns, "Comma-separated columns for csv/tsv: id, channel_id, title, url, duration, status, upload_date, rating, note")
+	videoListCmd.Flags().Int("min-rating", 0, "List only videos rated at least this (1-5), across all channels unless one is given")
+	videoListCmd.Flags().String("tag", "", "List only videos tagged with this topic (video autotag), across all channels")
+	videoListCmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
+	videoListCmd.Flags().Bool("with-status", false, "Include each video's workflow status")
+	videoListCmd.Flags().String("export-dir", "", "Local export directory checked for exported files (with --with-status)")
+
+	// Add flags to status command
+	videoStatusCmd.Flags().String("format", "table", "Output format: table, json")
+	videoStatusCmd.Flags().String("export-dir", "", "Local export directory checked for exported files")
+
+	// Add flags to captions command
+	videoCaptionsCmd.Flags().String("format", "table", "Output format: table, json")
+	videoCaptionsCmd.Flags().Bool("all", false, "Also list automatic captions machine-translated by YouTube")
+
+	// Add flags to verify command
+	videoVerifyCmd.Flags().String("channel", "", "Channel ID whose saved videos are checked (required)")
+	videoVerifyCmd.Flags().Bool("all", false, "List every checked video, not only unavailable or changed ones")
+	videoVerifyCmd.Flags().String("format", "table", "Output format: table, json")
+
+	// Add flags to annotate command
+	videoAnnotateCmd.Flags().Int("rating", 0, "Rating from 1 to 5")
+	videoAnnotateCmd.Flags().String("note", "", "Free-form note (empty removes the note)")
+	videoAnnotateCmd.Flags().Bool("clear", false, "Remove the rating and note")
+
+	// Add flags to autotag command
+	videoAutotagCmd.Flags().String("channel", "", "Tag every transcribed video of this channel")
+	videoAutotagCmd.Flags().Int("top", taggingSvc.DefaultTop, "Tags per video")
+	videoAutotagCmd.Flags().Int("min-length", taggingSvc.DefaultMinWordLength, "Ignore words shorter than this many characters")
+	videoAutotagCmd.Flags().Bool("dry-run", false, "Show the proposed tags without storing them")
+	videoAutotagCmd.Flags().String("format", "table", "Output format: table, json")
+
+	// Add flags to dedupe command
+	videoDedupeCmd.Flags().String("by", dedupeSvc.ModeTitleSimilarity, "Detection mode: title-similarity, duration")
+	videoDedupeCmd.Flags().Float64("threshold", dedupeSvc.DefaultThreshold, "Minimum title similarity (0-1) for title-similarity")
+	videoDedupeCmd.Flags().Duration("tolerance", dedupeSvc.DefaultTolerance, "Maximum duration difference between duplicates")
+	videoDedupeCmd.Flags().Bool("link", false, "Record duplicates so batch transcription skips them")
+	videoDedupeCmd.Flags().String("format", "table", "Output format: table, json")
+	videoVerifyCmd.MarkFlagRequired("channel")
 
 	videoCmd.AddCommand(videoSaveCmd)
+	videoCmd.AddCommand(videoAddCmd)
+	videoCmd.AddCommand(videoImportListCmd)
 	videoCmd.AddCommand(videoListCmd)
+	videoCmd.AddCommand(videoVerifyCmd)
+	videoCmd.AddCommand(videoDedupeCmd)
+	videoCmd.AddCommand(videoAnnotateCmd)
+	videoCmd.AddCommand(videoAutotagCmd)
+	videoCmd.AddCommand(videoStatusCmd)
+	videoCmd.AddCommand(videoCaptionsCmd)
 	rootCmd.AddCommand(videoCmd)
 }
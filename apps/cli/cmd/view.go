@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/pager"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/translation"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+	studySvc "github.com/Taichi-iskw/yt-lang/internal/service/study"
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
+)
+
+// viewCmd shows a transcript next to its translation
+var viewCmd = &cobra.Command{
+	Use:   "view [VIDEO_ID]",
+	Short: "Show a transcript and its translation side by side",
+	Long: `Show the segments of a video's transcription in two aligned columns with their start times.
+Each column of --langs is the transcript itself (original) or a stored translation language, so
+original,ja reads the transcript against its Japanese translation and en,ja compares two
+translations. Untranslated segments show "-".
+
+Text wraps within its column, and wide characters such as Japanese count as two columns. The
+width is --width, or $COLUMNS, or 100. --from starts at a position in the video. When output is a
+terminal it is shown in a pager ($PAGER, or less when unset; PAGER=cat or --no-pager turns it off).
+
+Examples:
+  yt-lang view dQw4w9WgXcQ --langs original,ja
+  yt-lang view dQw4w9WgXcQ --langs original,en --from 12:30`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var opts handler.ViewOptions
+		opts.Langs, _ = cmd.Flags().GetStringSlice("langs")
+		opts.Language, _ = cmd.Flags().GetString("language")
+		opts.Width, _ = cmd.Flags().GetInt("width")
+		noPager, _ := cmd.Flags().GetBool("no-pager")
+		if from, _ := cmd.Flags().GetString("from"); from != "" {
+			offset, err := timecode.ParseOffset(from)
+			if err != nil {
+				return fmt.Errorf("invalid --from: %w", err)
+			}
+			opts.From = offset
+		}
+		if opts.Width == 0 {
+			opts.Width, _ = strconv.Atoi(os.Getenv("COLUMNS"))
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		sheetService := studySvc.NewSheetService(
+			video.NewRepository(dbPool),
+			transcription.NewRepository(dbPool),
+			transcription.NewSegmentRepository(dbPool),
+			translation.NewRepository(dbPool),
+		)
+
+		out, closePager := pager.Open(cmd.OutOrStdout(), !noPager)
+		defer closePager()
+		return handler.View(ctx, out, sheetService, args[0], opts)
+	},
+}
+
+func init() {
+	viewCmd.Flags().StringSlice("langs", []string{handler.ViewOriginal, "ja"}, "The two columns: original or a translation language each (comma-separated)")
+	viewCmd.Flags().StringP("language", "l", "", "Language of the transcription, when the video has several")
+	viewCmd.Flags().String("from", "", "Start at this position in the video (e.g. 00:12:30)")
+	viewCmd.Flags().Int("width", 0, "Width of the output in columns (default $COLUMNS, or 100)")
+	viewCmd.Flags().Bool("no-pager", false, "Write output directly instead of through a pager")
+	rootCmd.AddCommand(viewCmd)
+}
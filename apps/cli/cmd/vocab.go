@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+	vocabSvc "github.com/Taichi-iskw/yt-lang/internal/service/vocab"
+)
+
+// vocabCmd represents the vocab command
+var vocabCmd = &cobra.Command{
+	Use:   "vocab",
+	Short: "Vocabulary analysis of transcriptions",
+	Long:  `Analyze the vocabulary used across stored transcriptions for study prioritization.`,
+}
+
+// vocabStatsCmd computes word frequencies for a channel
+var vocabStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Word frequency statistics for a channel",
+	Long: `Tokenize all completed transcriptions of a channel in the given language and output
+the most frequent words (stopwords excluded) as a table, CSV or JSON.
+Per-transcription counts are cached under ~/.yt-lang/cache/vocab.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		channelID, _ := cmd.Flags().GetString("channel")
+		language, _ := cmd.Flags().GetString("lang")
+		top, _ := cmd.Flags().GetInt("top")
+		format, _ := cmd.Flags().GetString("format")
+		keepStopwords, _ := cmd.Flags().GetBool("keep-stopwords")
+		minLength, _ := cmd.Flags().GetInt("min-length")
+		noCache, _ := cmd.Flags().GetBool("no-cache")
+		includeUnavailable, _ := cmd.Flags().GetBool("include-unavailable")
+
+		// Create service with timeout context
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		cacheDir := ""
+		if !noCache {
+			baseDir, err := config.GetCacheDir()
+			if err != nil {
+				return err
+			}
+			cacheDir = filepath.Join(baseDir, "vocab")
+		}
+
+		vocabService := vocabSvc.NewVocabService(
+			video.NewRepository(dbPool),
+			transcription.NewRepository(dbPool),
+			transcription.NewSegmentRepository(dbPool),
+			cacheDir,
+		)
+
+		stats, err := vocabService.ChannelStats(ctx, vocabSvc.StatsOptions{
+			ChannelID:     channelID,
+			Language:      language,
+			Top:           top,
+			KeepStopwords: keepStopwords,
+			MinWordLength: minLength,
+
+			IncludeUnavailable: includeUnavailable,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to compute vocabulary stats: %w", err)
+		}
+
+		switch format {
+		case "csv":
+			w := csv.NewWriter(os.Stdout)
+			w.Write([]string{"rank", "word", "count", "per_mille", "documents"})
+			for _, word := range stats.Words {
+				w.Write([]string{
+					strconv.Itoa(word.Rank),
+					word.Word,
+					strconv.Itoa(word.Count),
+					strconv.FormatFloat(word.PerMille, 'f', 2, 64),
+					strconv.Itoa(word.Documents),
+				})
+			}
+			w.Flush()
+			return w.Error()
+
+		case "json":
+			result, err := json.MarshalIndent(stats, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format result: %w", err)
+			}
+			fmt.Println(string(result))
+
+		case "table":
+			fmt.Printf("Channel: %s  Language: %s\n", stats.ChannelID, stats.Language)
+			fmt.Printf("Transcriptions: %d  Tokens: %d  Unique words: %d\n\n", stats.Transcriptions, stats.TotalTokens, stats.UniqueWords)
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "RANK\tWORD\tCOUNT\t‰\tDOCS")
+			for _, word := range stats.Words {
+				fmt.Fprintf(w, "%d\t%s\t%d\t%.2f\t%d\n", word.Rank, word.Word, word.Count, word.PerMille, word.Documents)
+			}
+			w.Flush()
+
+		default:
+			return fmt.Errorf("unsupported format: %s (supported: table, csv, json)", format)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	vocabStatsCmd.Flags().String("channel", "", "Channel ID to analyze (required)")
+	vocabStatsCmd.Flags().String("lang", "", "Transcription language to analyze, e.g. es (required)")
+	vocabStatsCmd.Flags().Int("top", 100, "Number of most frequent words to show")
+	vocabStatsCmd.Flags().String("format", "table", "Output format: table, csv, json")
+	vocabStatsCmd.Flags().Bool("keep-stopwords", false, "Include stopwords in the frequency table")
+	vocabStatsCmd.Flags().Int("min-length", 1, "Ignore words shorter than this many characters")
+	vocabStatsCmd.Flags().Bool("no-cache", false, "Recompute counts instead of using the cache")
+	vocabStatsCmd.Flags().Bool("include-unavailable", false, "Also analyze videos marked unavailable by video verify")
+	vocabStatsCmd.MarkFlagRequired("channel")
+	vocabStatsCmd.MarkFlagRequired("lang")
+
+	vocabCmd.AddCommand(vocabStatsCmd)
+	rootCmd.AddCommand(vocabCmd)
+}
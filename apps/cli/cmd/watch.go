@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/cmd/handler"
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/lock"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/channel"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+	youtubeSvc "github.com/Taichi-iskw/yt-lang/internal/service/youtube"
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
+)
+
+// watchCmd represents the watch command
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Sync scheduled channels in the background",
+	Long: `Channels with a schedule (see channel schedule) are synced by watch run whenever their schedule
+is due, like channel sync. watch status shows when each channel last synced, whether that
+succeeded and when it syncs next; watch health shows whether the daemon is still checking.`,
+}
+
+// watchRunCmd syncs scheduled channels until interrupted
+var watchRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Sync scheduled channels as their schedules come due",
+	Long: `Check the channel schedules every --poll interval and sync the channels that are due, one at a
+time, until interrupted. Each sync is recorded with its outcome; a failed sync is retried at the
+next scheduled time. Syncs are locked per channel, so several watch run processes or a manual
+channel sync never save the same channel at once. --once syncs the due channels and exits, e.g.
+to run from cron or a systemd timer instead. Every check records a heartbeat for watch health.
+
+Examples:
+  yt-lang watch run
+  yt-lang watch run --once`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		poll, _ := cmd.Flags().GetDuration("poll")
+		once, _ := cmd.Flags().GetBool("once")
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection, kept for the lifetime of the daemon
+		connectCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		dbPool, err := config.NewDatabasePool(connectCtx, cfg)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		hooks, err := config.NewHookDispatcher(cfg)
+		if err != nil {
+			return err
+		}
+
+		// Locked per channel like channel sync
+		youtubeService := youtubeSvc.NewHookedService(
+			youtubeSvc.NewLockingService(
+				youtubeSvc.NewYouTubeServiceWithDetector(
+					common.NewCmdRunner(),
+					channel.NewRepository(dbPool),
+					video.NewRepository(dbPool),
+					ytdlp.DefaultDetector(),
+				),
+				lock.NewPostgresLocker(dbPool.Pool),
+			),
+			hooks,
+		)
+		scheduleRepo := channel.NewScheduleRepository(dbPool)
+		heartbeatRepo := channel.NewHeartbeatRepository(dbPool)
+
+		if once {
+			synced, err := handler.WatchCheck(ctx, cmd.OutOrStdout(), scheduleRepo, youtubeService, heartbeatRepo, handler.NewHeartbeat(time.Now()), time.Now())
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Synced %d channel(s)\n", synced)
+			return nil
+		}
+
+		fmt.Printf("Watching scheduled channels every %s (workspace %s)\n", poll, cfg.ActiveWorkspace())
+		if err := handler.Watch(ctx, cmd.OutOrStdout(), scheduleRepo, youtubeService, heartbeatRepo, poll); err != nil {
+			return err
+		}
+		fmt.Println("Watch stopped")
+		return nil
+	},
+}
+
+// watchStatusCmd shows the last and next run of every scheduled channel
+var watchStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the last and next sync of every scheduled channel",
+	Long: `List the scheduled channels with their schedule, the start and outcome of their last sync and
+when they sync next. Channels whose next sync has passed show "due" and are synced by the next
+check of watch run.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unsupported format: %s (supported: table, json)", format)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		return handler.WatchStatus(ctx, cmd.OutOrStdout(), channel.NewScheduleRepository(dbPool), time.Now(), format)
+	},
+}
+
+// watchHealthCmd reports whether the watch daemon is alive, for people and monitors
+var watchHealthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Show whether the watch daemon is running and keeping up",
+	Long: `Show when each watch run daemon (one per host) last checked for due channels, how many channels
+are due now and, per scheduled channel, its last successful sync and its last error. A daemon
+that is running checks every --poll interval, so an old last loop or a growing queue means it is
+wedged or stopped.
+
+With --max-staleness the command fails (exit status 1) when no daemon checked within that time,
+so cron, a systemd timer or another monitor can alert on a wedged daemon; allow at least the
+poll interval plus the longest channel sync.
+
+Examples:
+  yt-lang watch health
+  yt-lang watch health --max-staleness 15m || systemctl restart yt-lang-watch`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		maxStaleness, _ := cmd.Flags().GetDuration("max-staleness")
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unsupported format: %s (supported: table, json)", format)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		// Load configuration
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		// Create database connection
+		dbPool, err := config.NewDatabasePool(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer dbPool.Close()
+
+		return handler.WatchHealth(ctx, cmd.OutOrStdout(), channel.NewHeartbeatRepository(dbPool), channel.NewScheduleRepository(dbPool), time.Now(), maxStaleness, format)
+	},
+}
+
+func init() {
+	watchRunCmd.Flags().Duration("poll", handler.DefaultWatchPoll, "How often to check for due channels")
+	watchRunCmd.Flags().Bool("once", false, "Sync the due channels once and exit")
+	watchStatusCmd.Flags().String("format", "table", "Output format: table, json")
+	watchHealthCmd.Flags().String("format", "table", "Output format: table, json")
+	watchHealthCmd.Flags().Duration("max-staleness", 0, "Fail when no daemon checked for due channels within this time (e.g. 15m)")
+
+	watchCmd.AddCommand(watchRunCmd)
+	watchCmd.AddCommand(watchStatusCmd)
+	watchCmd.AddCommand(watchHealthCmd)
+	rootCmd.AddCommand(watchCmd)
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/internal/diskguard"
+	"github.com/Taichi-iskw/yt-lang/internal/janitor"
+	"github.com/Taichi-iskw/yt-lang/internal/whispermodel"
+)
+
+// whisperCmd represents the whisper command
+var whisperCmd = &cobra.Command{
+	Use:   "whisper",
+	Short: "Manage the local whisper installation",
+	Long:  `Manage what the whisper CLI keeps on local disk.`,
+}
+
+// whisperModelsCmd groups the model file commands
+var whisperModelsCmd = &cobra.Command{
+	Use:   "models",
+	Short: "List, download and remove whisper model files",
+	Long: `Manage the model files of the whisper CLI. Whisper downloads a model the first time it is
+used, which for large models means gigabytes in the middle of a transcription; download them
+ahead of time instead. Files live in whisper's model directory ($XDG_CACHE_HOME/whisper or
+~/.cache/whisper) unless --dir is given.`,
+}
+
+// whisperModelsListCmd lists known models and whether they are downloaded
+var whisperModelsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List whisper models with their size and path",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unsupported format: %s (supported: table, json)", format)
+		}
+
+		dir, err := whisperModelDir(cmd)
+		if err != nil {
+			return err
+		}
+		models, err := whispermodel.List(dir)
+		if err != nil {
+			return err
+		}
+
+		if format == "json" {
+			data, err := json.MarshalIndent(models, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format result: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "MODEL\tDOWNLOADED\tSIZE\tPATH")
+		for _, model := range models {
+			downloaded, size, path := "no", "~"+janitor.FormatSize(model.Size), "-"
+			if model.Downloaded {
+				downloaded, size, path = "yes", janitor.FormatSize(model.Size), model.Path
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", model.Name, downloaded, size, path)
+		}
+		return w.Flush()
+	},
+}
+
+// whisperModelsDownloadCmd downloads model files ahead of transcription
+var whisperModelsDownloadCmd = &cobra.Command{
+	Use:   "download [MODEL...]",
+	Short: "Download whisper models ahead of time",
+	Long: `Download whisper model files and verify their checksums. Models that are downloaded and
+intact already are skipped. Aliases such as large (large-v3) and turbo (large-v3-turbo)
+download the file whisper uses for them. Nothing is downloaded when the disk lacks the space
+for the models, unless --ignore-disk-check is given.
+
+Examples:
+  yt-lang whisper models download base
+  yt-lang whisper models download tiny large`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := whisperModelDir(cmd)
+		if err != nil {
+			return err
+		}
+		var needs []diskguard.Need
+		for _, name := range args {
+			size, err := whispermodel.DownloadSize(dir, name)
+			if err != nil {
+				return err
+			}
+			needs = append(needs, diskguard.Need{Dir: dir, Bytes: size, What: "whisper model " + name})
+		}
+		if ignore, _ := cmd.Flags().GetBool("ignore-disk-check"); !ignore {
+			if err := diskguard.Check(needs...); err != nil {
+				return fmt.Errorf("%w; free some space or skip the check with --ignore-disk-check", err)
+			}
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		downloader := whispermodel.NewDownloader()
+		for _, name := range args {
+			progress := newDownloadProgress(name)
+			model, err := downloader.Download(ctx, dir, name, progress.report)
+			progress.done()
+			if err != nil {
+				return fmt.Errorf("failed to download %s: %w", name, err)
+			}
+			fmt.Printf("✅ %s: %s (%s)\n", model.Name, model.Path, janitor.FormatSize(model.Size))
+		}
+		return nil
+	},
+}
+
+// whisperModelsRemoveCmd deletes downloaded model files
+var whisperModelsRemoveCmd = &cobra.Command{
+	Use:   "remove [MODEL...]",
+	Short: "Remove downloaded whisper models",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := whisperModelDir(cmd)
+		if err != nil {
+			return err
+		}
+
+		var freed int64
+		for _, name := range args {
+			model, err := whispermodel.Remove(dir, name)
+			if err != nil {
+				return fmt.Errorf("failed to remove %s: %w", name, err)
+			}
+			fmt.Printf("Removed %s\n", model.Path)
+			freed += model.Size
+		}
+		fmt.Printf("Freed %s\n", janitor.FormatSize(freed))
+		return nil
+	},
+}
+
+// whisperModelDir returns --dir, or whisper's default model directory
+func whisperModelDir(cmd *cobra.Command) (string, error) {
+	if dir, _ := cmd.Flags().GetString("dir"); dir != "" {
+		return dir, nil
+	}
+	return whispermodel.DefaultDir()
+}
+
+// downloadProgress prints the progress of one download to stderr at most once a second
+type downloadProgress struct {
+	name    string
+	printed time.Time
+	shown   bool
+}
+
+func newDownloadProgress(name string) *downloadProgress {
+	return &downloadProgress{name: name}
+}
+
+func (p *downloadProgress) report(written, total int64) {
+	if time.Since(p.printed) < time.Second && written != total {
+		return
+	}
+	p.printed = time.Now()
+	p.shown = true
+	if total > 0 {
+		fmt.Fprintf(os.Stderr, "\rDownloading %s: %s / %s (%d%%)", p.name, janitor.FormatSize(written), janitor.FormatSize(total), written*100/total)
+	} else {
+		fmt.Fprintf(os.Stderr, "\rDownloading %s: %s", p.name, janitor.FormatSize(written))
+	}
+}
+
+// done ends the progress line, if any was printed
+func (p *downloadProgress) done() {
+	if p.shown {
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+func init() {
+	whisperModelsCmd.PersistentFlags().String("dir", "", "Model directory (default: whisper's, $XDG_CACHE_HOME/whisper or ~/.cache/whisper)")
+	whisperModelsListCmd.Flags().String("format", "table", "Output format: table, json")
+	whisperModelsDownloadCmd.Flags().Bool("ignore-disk-check", false, "Download even when the disk seems too full for the models")
+
+	whisperModelsCmd.AddCommand(whisperModelsListCmd)
+	whisperModelsCmd.AddCommand(whisperModelsDownloadCmd)
+	whisperModelsCmd.AddCommand(whisperModelsRemoveCmd)
+	whisperCmd.AddCommand(whisperModelsCmd)
+	rootCmd.AddCommand(whisperCmd)
+}
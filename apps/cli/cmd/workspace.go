@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Taichi-iskw/yt-lang/internal/config"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/workspace"
+)
+
+// workspaceCmd represents the workspace command
+var workspaceCmd = &cobra.Command{
+	Use:   "workspace",
+	Short: "Manage workspaces",
+	Long: `Workspaces partition one database between users or projects: channels, videos,
+transcriptions and translations saved in one workspace are invisible in the others.
+The active workspace is the workspace key of config.yaml, overridden by YTLANG_WORKSPACE
+and the global --workspace flag.`,
+}
+
+// workspaceCreateCmd creates a workspace
+var workspaceCreateCmd = &cobra.Command{
+	Use:   "create [NAME]",
+	Short: "Create a new workspace",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		repo, _, closePool, err := newWorkspaceRepository(ctx)
+		if err != nil {
+			return err
+		}
+		defer closePool()
+
+		if err := repo.Create(ctx, name); err != nil {
+			return fmt.Errorf("failed to create workspace: %w", err)
+		}
+		fmt.Printf("Created workspace: %s\n", name)
+
+		if use, _ := cmd.Flags().GetBool("use"); use {
+			return useWorkspace(name)
+		}
+		return nil
+	},
+}
+
+// workspaceUseCmd makes a workspace the active one
+var workspaceUseCmd = &cobra.Command{
+	Use:   "use [NAME]",
+	Short: "Switch the active workspace",
+	Long:  `Switch the active workspace by setting the workspace key of config.yaml.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		repo, _, closePool, err := newWorkspaceRepository(ctx)
+		if err != nil {
+			return err
+		}
+		defer closePool()
+
+		exists, err := repo.Exists(ctx, name)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("workspace %s does not exist (create it with 'ytlang workspace create %s')", name, name)
+		}
+		return useWorkspace(name)
+	},
+}
+
+// workspaceListCmd lists workspaces
+var workspaceListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List workspaces",
+	Long:  `List all workspaces with the number of channels and videos they hold. The active workspace is marked with *.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		if format != "table" && format != "json" {
+			return fmt.Errorf("unsupported format: %s (supported: table, json)", format)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		repo, cfg, closePool, err := newWorkspaceRepository(ctx)
+		if err != nil {
+			return err
+		}
+		defer closePool()
+
+		workspaces, err := repo.List(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list workspaces: %w", err)
+		}
+
+		if format == "json" {
+			data, err := json.MarshalIndent(workspaces, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format result: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		active := cfg.ActiveWorkspace()
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "\tNAME\tCHANNELS\tVIDEOS\tCREATED")
+		for _, ws := range workspaces {
+			marker := ""
+			if ws.Name == active {
+				marker = "*"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\n", marker, ws.Name, ws.Channels, ws.Videos, ws.CreatedAt.Format("2006-01-02"))
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+// newWorkspaceRepository connects to the configured database and returns a workspace repository,
+// the loaded configuration and a function closing the connection
+func newWorkspaceRepository(ctx context.Context) (workspace.Repository, *config.Config, func(), error) {
+	cfg, err := config.NewConfig()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	dbPool, err := config.NewDatabasePool(ctx, cfg)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return workspace.NewRepository(dbPool), cfg, dbPool.Close, nil
+}
+
+// useWorkspace saves name as the active workspace and warns when an override hides it
+func useWorkspace(name string) error {
+	if err := config.SetActiveWorkspace(name); err != nil {
+		return err
+	}
+	fmt.Printf("Switched to workspace: %s\n", name)
+
+	if env := os.Getenv("YTLANG_WORKSPACE"); env != "" && env != name {
+		fmt.Printf("⚠️  YTLANG_WORKSPACE=%s overrides the configured workspace in this shell\n", env)
+	}
+	return nil
+}
+
+func init() {
+	workspaceCreateCmd.Flags().Bool("use", false, "Switch to the new workspace")
+	workspaceListCmd.Flags().String("format", "table", "Output format: table, json")
+
+	workspaceCmd.AddCommand(workspaceCreateCmd)
+	workspaceCmd.AddCommand(workspaceUseCmd)
+	workspaceCmd.AddCommand(workspaceListCmd)
+	rootCmd.AddCommand(workspaceCmd)
+}
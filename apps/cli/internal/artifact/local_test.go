@@ -0,0 +1,101 @@
+package artifact
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore_PutOpenDelete(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := NewLocalStore(dir)
+	key := WhisperKey("trans-1")
+	data := []byte(`{"text": "hello", "segments": [], "language": "en"}`)
+
+	require.NoError(t, PutGzip(ctx, store, key, data))
+
+	// Stored compressed
+	raw, err := os.ReadFile(filepath.Join(dir, "whisper", "trans-1.json.gz"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x1f, 0x8b}, raw[:2], "artifact should be gzip-compressed")
+
+	r, err := OpenGzip(ctx, store, key)
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, data, content)
+
+	// Overwrite replaces the artifact
+	require.NoError(t, PutGzip(ctx, store, key, []byte("{}")))
+	r, err = OpenGzip(ctx, store, key)
+	require.NoError(t, err)
+	content, _ = io.ReadAll(r)
+	r.Close()
+	assert.Equal(t, "{}", string(content))
+
+	exists, err := store.Exists(ctx, key)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	info, err := os.Stat(filepath.Join(dir, "whisper", "trans-1.json.gz"))
+	require.NoError(t, err)
+	size, err := store.Size(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, info.Size(), size)
+
+	require.NoError(t, store.Delete(ctx, key))
+	_, err = store.Open(ctx, key)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	exists, err = store.Exists(ctx, key)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	assert.NoError(t, store.Delete(ctx, key), "deleting a missing artifact is not an error")
+}
+
+func TestLocalStore_PutPlain(t *testing.T) {
+	dir := t.TempDir()
+	store := NewLocalStore(dir)
+
+	require.NoError(t, store.Put(context.Background(), "UC1/Talk [abc].ja.srt", strings.NewReader("1\n")))
+
+	info, err := os.Stat(filepath.Join(dir, "UC1", "Talk [abc].ja.srt"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+}
+
+func TestLocalStore_Touch(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := NewLocalStore(dir)
+	key := AudioKey("vid1", ".mp3")
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("audio")))
+
+	path := filepath.Join(dir, filepath.FromSlash(key))
+	old := time.Now().Add(-48 * time.Hour)
+	require.NoError(t, os.Chtimes(path, old, old))
+
+	require.NoError(t, store.Touch(ctx, key))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), info.ModTime(), time.Minute)
+
+	assert.Error(t, store.Touch(ctx, AudioKey("missing", ".mp3")))
+}
+
+func TestLocalStore_InvalidKeys(t *testing.T) {
+	store := NewLocalStore(t.TempDir())
+
+	for _, key := range []string{"", "../outside.gz", "/etc/passwd", "whisper/../../x", "..\\x"} {
+		assert.Error(t, store.Put(context.Background(), key, strings.NewReader("x")), key)
+	}
+}
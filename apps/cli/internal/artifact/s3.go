@@ -0,0 +1,219 @@
+package artifact
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/offline"
+)
+
+// S3Options configures an S3-compatible bucket
+type S3Options struct {
+	Endpoint        string // e.g. https://s3.ap-northeast-1.amazonaws.com or a MinIO/R2 URL
+	Region          string // signing region; defaults to us-east-1
+	Bucket          string
+	Prefix          string // optional key prefix inside the bucket
+	AccessKeyID     string
+	SecretAccessKey string
+	UsePathStyle    bool // address the bucket as <endpoint>/<bucket> instead of <bucket>.<host>
+}
+
+// S3Store keeps artifacts as objects in an S3-compatible bucket
+type S3Store struct {
+	opts     S3Options
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewS3Store creates a store backed by an S3-compatible bucket
+func NewS3Store(opts S3Options) (*S3Store, error) {
+	return NewS3StoreWithClient(opts, &http.Client{Timeout: 10 * time.Minute})
+}
+
+// NewS3StoreWithClient creates a store backed by an S3-compatible bucket using client (for testing)
+func NewS3StoreWithClient(opts S3Options, client *http.Client) (*S3Store, error) {
+	if opts.Bucket == "" {
+		return nil, fmt.Errorf("s3 bucket is required")
+	}
+	if opts.Endpoint == "" {
+		return nil, fmt.Errorf("s3 endpoint is required")
+	}
+	if opts.AccessKeyID == "" || opts.SecretAccessKey == "" {
+		return nil, fmt.Errorf("s3 access key ID and secret access key are required")
+	}
+	if opts.Region == "" {
+		opts.Region = "us-east-1"
+	}
+
+	endpoint, err := url.Parse(opts.Endpoint)
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint: %q", opts.Endpoint)
+	}
+
+	return &S3Store{opts: opts, endpoint: endpoint, client: client, now: time.Now}, nil
+}
+
+// Put uploads the content of r under key. The content is spooled to a temporary file
+// first because S3 needs the length and SHA-256 of the body before the upload starts.
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader) error {
+	tmp, err := os.CreateTemp("", "yt-lang-upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to buffer artifact: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), r)
+	if err != nil {
+		return fmt.Errorf("failed to buffer artifact: %w", err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to buffer artifact: %w", err)
+	}
+
+	req, err := s.newRequest(ctx, http.MethodPut, key, io.NopCloser(tmp), hex.EncodeToString(hash.Sum(nil)))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Open downloads the object stored under key
+func (s *S3Store) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Exists reports whether an object is stored under key
+func (s *S3Store) Exists(ctx context.Context, key string) (bool, error) {
+	req, err := s.newRequest(ctx, http.MethodHead, key, nil, emptyPayloadHash)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	resp.Body.Close()
+	return true, nil
+}
+
+// Size returns the Content-Length of the object stored under key
+func (s *S3Store) Size(ctx context.Context, key string) (int64, error) {
+	req, err := s.newRequest(ctx, http.MethodHead, key, nil, emptyPayloadHash)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.ContentLength, nil
+}
+
+// Delete removes the object stored under key; S3 reports success for missing objects
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil, emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// newRequest builds a signed request for the object stored under key
+func (s *S3Store) newRequest(ctx context.Context, method, key string, body io.ReadCloser, payloadHash string) (*http.Request, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	objectKey := strings.TrimPrefix(key, "/")
+	if prefix := strings.Trim(s.opts.Prefix, "/"); prefix != "" {
+		objectKey = prefix + "/" + objectKey
+	}
+
+	u := *s.endpoint
+	if s.opts.UsePathStyle {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.opts.Bucket + "/" + objectKey
+	} else {
+		u.Host = s.opts.Bucket + "." + u.Host
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + objectKey
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build s3 request: %w", err)
+	}
+	if body != nil {
+		req.Body = body
+	}
+
+	signRequest(req, payloadHash, s.opts.Region, s.opts.AccessKeyID, s.opts.SecretAccessKey, s.now())
+	return req, nil
+}
+
+// do sends req and converts non-2xx responses to errors
+func (s *S3Store) do(req *http.Request) (*http.Response, error) {
+	if err := offline.Check("s3 storage"); err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 %s %s failed: %w", req.Method, req.URL.Path, err)
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, req.URL.Path)
+	}
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return nil, fmt.Errorf("s3 %s %s failed: %s %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(detail)))
+}
+
+// isNotFound reports whether err means nothing is stored under the key
+func isNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
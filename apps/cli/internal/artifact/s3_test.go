@@ -0,0 +1,142 @@
+package artifact
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is an in-memory bucket that checks requests are signed
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	paths   []string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.paths = append(f.paths, r.URL.Path)
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=test-key/") ||
+		r.Header.Get("X-Amz-Content-Sha256") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		if r.ContentLength < 0 {
+			w.WriteHeader(http.StatusLengthRequired)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if sha256Hex(body) != r.Header.Get("X-Amz-Content-Sha256") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.objects[r.URL.Path] = body
+	case http.MethodGet, http.MethodHead:
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(body)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newTestS3Store(t *testing.T, prefix string) (*S3Store, *fakeS3) {
+	fake := &fakeS3{objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	store, err := NewS3StoreWithClient(S3Options{
+		Endpoint:        server.URL,
+		Bucket:          "artifacts",
+		Prefix:          prefix,
+		AccessKeyID:     "test-key",
+		SecretAccessKey: "test-secret",
+		UsePathStyle:    true,
+	}, server.Client())
+	require.NoError(t, err)
+	return store, fake
+}
+
+func TestS3Store_PutOpenDelete(t *testing.T) {
+	ctx := context.Background()
+	store, fake := newTestS3Store(t, "yt-lang/")
+	key := WhisperKey("trans-1")
+
+	require.NoError(t, PutGzip(ctx, store, key, []byte(`{"text": "hello"}`)))
+	assert.Contains(t, fake.objects, "/artifacts/yt-lang/whisper/trans-1.json.gz")
+
+	r, err := OpenGzip(ctx, store, key)
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, `{"text": "hello"}`, string(content))
+
+	exists, err := store.Exists(ctx, key)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	size, err := store.Size(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(fake.objects["/artifacts/yt-lang/whisper/trans-1.json.gz"])), size)
+
+	require.NoError(t, store.Delete(ctx, key))
+	exists, err = store.Exists(ctx, key)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = store.Size(ctx, key)
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	_, err = store.Open(ctx, key)
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestS3Store_Errors(t *testing.T) {
+	store, _ := newTestS3Store(t, "")
+	store.opts.AccessKeyID = "wrong-key"
+
+	err := store.Put(context.Background(), "exports/UC1/a.srt", strings.NewReader("x"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+	assert.False(t, errors.Is(err, ErrNotFound))
+
+	assert.Error(t, store.Put(context.Background(), "../escape", strings.NewReader("x")))
+}
+
+func TestNewS3Store_Validation(t *testing.T) {
+	valid := S3Options{Endpoint: "https://s3.example.com", Bucket: "b", AccessKeyID: "k", SecretAccessKey: "s"}
+
+	store, err := NewS3Store(valid)
+	require.NoError(t, err)
+	assert.Equal(t, "us-east-1", store.opts.Region)
+
+	for name, modify := range map[string]func(*S3Options){
+		"missing bucket":      func(o *S3Options) { o.Bucket = "" },
+		"missing endpoint":    func(o *S3Options) { o.Endpoint = "" },
+		"missing credentials": func(o *S3Options) { o.SecretAccessKey = "" },
+		"relative endpoint":   func(o *S3Options) { o.Endpoint = "s3.example.com" },
+	} {
+		opts := valid
+		modify(&opts)
+		_, err := NewS3Store(opts)
+		assert.Error(t, err, name)
+	}
+}
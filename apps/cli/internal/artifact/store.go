@@ -0,0 +1,117 @@
+package artifact
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// ErrNotFound is returned when nothing is stored under a key
+var ErrNotFound = errors.New("artifact not found")
+
+// Store is blob storage for large artifacts addressed by slash-separated keys
+type Store interface {
+	// Put stores the content of r under key, replacing any previous artifact
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Open returns the content stored under key; it fails with ErrNotFound if there is none
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Exists reports whether an artifact is stored under key
+	Exists(ctx context.Context, key string) (bool, error)
+	// Delete removes the artifact stored under key; missing artifacts are not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// Toucher is implemented by stores that track when artifacts were last used, so caches kept in
+// them can evict the least recently used ones
+type Toucher interface {
+	// Touch marks the artifact under key as used now
+	Touch(ctx context.Context, key string) error
+}
+
+// Sizer is implemented by stores that can report the size of an artifact without reading it
+type Sizer interface {
+	// Size returns the stored size in bytes of the artifact under key; it fails with ErrNotFound
+	// if there is none
+	Size(ctx context.Context, key string) (int64, error)
+}
+
+// WhisperKey returns the store key of a transcription's raw whisper JSON output
+func WhisperKey(transcriptionID string) string {
+	return "whisper/" + transcriptionID + ".json.gz"
+}
+
+// AudioKey returns the store key of a video's cached audio with the given file extension
+func AudioKey(videoID, ext string) string {
+	return "audio/" + videoID + ext
+}
+
+// AudioSourceKey returns the store key recording the original of a video's re-encoded cached audio
+func AudioSourceKey(videoID string) string {
+	return "audio/" + videoID + ".source.json"
+}
+
+// AudioFormatKey returns the store key recording the format a video's cached audio was downloaded at
+func AudioFormatKey(videoID string) string {
+	return "audio/" + videoID + ".format.json"
+}
+
+// ExportKey returns the store key of an exported file of a channel
+func ExportKey(channelID, name string) string {
+	return "exports/" + channelID + "/" + name
+}
+
+// PutGzip compresses data and stores it under key
+func PutGzip(ctx context.Context, s Store, key string, data []byte) error {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(data); err != nil {
+		return fmt.Errorf("failed to compress artifact: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to compress artifact: %w", err)
+	}
+	return s.Put(ctx, key, &buf)
+}
+
+// OpenGzip opens the artifact stored under key and returns its decompressed content
+func OpenGzip(ctx context.Context, s Store, key string) (io.ReadCloser, error) {
+	r, err := s.Open(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		r.Close()
+		return nil, fmt.Errorf("failed to read artifact: %w", err)
+	}
+	return &gzipReadCloser{Reader: gz, source: r}, nil
+}
+
+// validateKey rejects keys that are empty, absolute or escape the store root
+func validateKey(key string) error {
+	cleaned := path.Clean(strings.ReplaceAll(key, "\\", "/"))
+	if key == "" || path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return fmt.Errorf("invalid artifact key: %q", key)
+	}
+	return nil
+}
+
+// gzipReadCloser closes both the decompressor and the underlying reader
+type gzipReadCloser struct {
+	*gzip.Reader
+	source io.ReadCloser
+}
+
+func (r *gzipReadCloser) Close() error {
+	gzErr := r.Reader.Close()
+	if err := r.source.Close(); err != nil {
+		return err
+	}
+	return gzErr
+}
@@ -0,0 +1,20 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	clock := NewFake(start)
+	assert.Equal(t, start, clock.Now())
+
+	clock.Advance(90 * time.Minute)
+	assert.Equal(t, start.Add(90*time.Minute), clock.Now())
+
+	clock.Set(start)
+	assert.Equal(t, start, clock.Now())
+}
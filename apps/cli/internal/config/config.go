@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
@@ -9,11 +10,289 @@ import (
 	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/Taichi-iskw/yt-lang/internal/i18n"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/subtitle"
 )
 
 // Config holds all configuration for the application
 type Config struct {
-	DatabaseURL string `yaml:"database_url"`
+	DatabaseURL   string              `yaml:"database_url"`
+	Workspace     string              `yaml:"workspace"` // active workspace; defaults to "default"
+	Lang          string              `yaml:"lang"`      // CLI message language (en, ja, es); defaults to en
+	Subtitles     SubtitleConfig      `yaml:"subtitles"`
+	Transcription TranscriptionConfig `yaml:"transcription"`
+	Translation   TranslationConfig   `yaml:"translation"`
+	Storage       StorageConfig       `yaml:"storage"`
+	Hooks         HooksConfig         `yaml:"hooks"`
+	Network       NetworkConfig       `yaml:"network"`
+	Retention     RetentionConfig     `yaml:"retention"`
+	QueryLog      QueryLogConfig      `yaml:"query_log"`
+	Server        ServerConfig        `yaml:"server"`
+	Embeddings    EmbeddingsConfig    `yaml:"embeddings"`
+	UsageStats    UsageStatsConfig    `yaml:"usage_stats"`
+	TTS           TTSConfig           `yaml:"tts"`
+	Tools         map[string]string   `yaml:"tools"` // binary path by tool name, for tools not in PATH
+}
+
+// ServerConfig holds settings of serve mode
+type ServerConfig struct {
+	Tokens []APITokenConfig `yaml:"tokens"` // API tokens; without any the API needs no token
+}
+
+// APITokenConfig is an API token and the scope it grants: read (GET only), write (changes
+// but no deletes) or admin (everything)
+type APITokenConfig struct {
+	Name  string `yaml:"name"`
+	Token string `yaml:"token"`
+	Scope string `yaml:"scope"`
+}
+
+// EmbeddingsConfig selects the model computing segment embeddings for search semantic.
+// Embeddings are optional: without a model, search index and search semantic are unavailable.
+type EmbeddingsConfig struct {
+	Provider  string `yaml:"provider"` // openai (default): any OpenAI-compatible embeddings API
+	BaseURL   string `yaml:"base_url"` // defaults to https://api.openai.com/v1
+	Model     string `yaml:"model"`
+	APIKey    string `yaml:"api_key"`    // defaults to $OPENAI_API_KEY
+	BatchSize int    `yaml:"batch_size"` // segments per request; defaults to 100
+}
+
+// Enabled reports whether an embedding model is configured
+func (c EmbeddingsConfig) Enabled() bool {
+	return c.Model != ""
+}
+
+// TTSConfig selects the speech synthesizer reading translations aloud (tts)
+type TTSConfig struct {
+	Backend string            `yaml:"backend"`  // piper (default): a local piper binary; openai: any OpenAI-compatible speech API
+	Binary  string            `yaml:"binary"`   // piper only; defaults to piper in PATH
+	BaseURL string            `yaml:"base_url"` // openai only; defaults to https://api.openai.com/v1
+	Model   string            `yaml:"model"`    // openai only; defaults to tts-1
+	APIKey  string            `yaml:"api_key"`  // openai only; defaults to $OPENAI_API_KEY
+	Voices  map[string]string `yaml:"voices"`   // backend voice per --voice (piper model or openai voice name)
+}
+
+// UsageStatsConfig opts in to local usage stats: every command run is recorded in the database
+// (command, duration, success) for stats usage. Nothing is sent anywhere.
+type UsageStatsConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// QueryLogConfig enables logging of database queries, to diagnose slow listings and searches
+// on large libraries. Query arguments are logged redacted.
+type QueryLogConfig struct {
+	All           bool          `yaml:"all"`            // log every query to stderr as a JSON line
+	SlowThreshold time.Duration `yaml:"slow_threshold"` // warn about queries slower than this; 0 disables
+}
+
+// RetentionConfig holds the library retention rules applied by prune
+type RetentionConfig struct {
+	OlderThan       string `yaml:"older_than"`       // upload age of prunable videos, e.g. 2y; defaults to 2y
+	KeepTranscribed *bool  `yaml:"keep_transcribed"` // keep videos with a transcription; defaults to true
+}
+
+// KeepTranscribedVideos reports whether prune keeps videos that have a transcription
+func (c RetentionConfig) KeepTranscribedVideos() bool {
+	return c.KeepTranscribed == nil || *c.KeepTranscribed
+}
+
+// NetworkConfig holds network settings passed to yt-dlp, for users behind proxies or
+// on networks where one IP version is broken
+type NetworkConfig struct {
+	Proxy     string `yaml:"proxy"` // http(s)://, socks4(a):// or socks5(h):// URL
+	ForceIPv4 bool   `yaml:"force_ipv4"`
+	ForceIPv6 bool   `yaml:"force_ipv6"`
+	UserAgent string `yaml:"user_agent"`
+	Offline   bool   `yaml:"offline"` // fail operations that need the network (same as --offline)
+}
+
+// HooksConfig lists shell commands run at pipeline milestones. Each command gets the
+// event as JSON on stdin and its name in YTLANG_EVENT.
+type HooksConfig struct {
+	Timeout                time.Duration `yaml:"timeout"` // per command; defaults to 60s
+	VideoSaved             []string      `yaml:"video_saved"`
+	TranscriptionCompleted []string      `yaml:"transcription_completed"`
+	TranslationCompleted   []string      `yaml:"translation_completed"`
+}
+
+// StorageConfig selects where large artifacts (cached audio, whisper output, exports) are kept
+type StorageConfig struct {
+	Backend      string          `yaml:"backend"`        // local (default) or s3
+	Dir          string          `yaml:"dir"`            // local backend root; defaults to ~/.yt-lang/artifacts
+	CacheAudio   bool            `yaml:"cache_audio"`    // keep downloaded audio so re-transcription skips yt-dlp
+	MaxCacheSize string          `yaml:"max_cache_size"` // cap on local caches, e.g. 20GB; least recently used files are evicted beyond it
+	S3           S3StorageConfig `yaml:"s3"`
+}
+
+// S3StorageConfig holds settings of an S3-compatible bucket. The credentials fall back to
+// AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY when not set in the config file.
+type S3StorageConfig struct {
+	Endpoint        string `yaml:"endpoint"`
+	Region          string `yaml:"region"`
+	Bucket          string `yaml:"bucket"`
+	Prefix          string `yaml:"prefix"`
+	AccessKeyID     string `yaml:"access_key_id"`
+	SecretAccessKey string `yaml:"secret_access_key"`
+	UsePathStyle    bool   `yaml:"use_path_style"` // required by most MinIO deployments
+}
+
+// TranscriptionConfig holds transcription pipeline settings
+type TranscriptionConfig struct {
+	Routing RoutingConfig `yaml:"routing"`
+
+	// MinLanguageConfidence is the detection probability (0-1) below which transcription
+	// create warns that the language may be wrong; defaults to 0.5
+	MinLanguageConfidence float64 `yaml:"min_language_confidence"`
+
+	// Decoding sets whisper's decoding parameters for every run
+	Decoding DecodingConfig `yaml:"decoding"`
+
+	// Fallback lists the whisper runs tried in turn when the output looks pathological
+	// (repetition loops, low confidence). Unset uses the default ladder, beam search then beam
+	// search with the next larger model; an empty list disables fallback.
+	Fallback []FallbackConfig `yaml:"fallback"`
+
+	// Hallucinations flags or removes segments consisting only of a phrase whisper emits on silence
+	Hallucinations HallucinationConfig `yaml:"hallucinations"`
+
+	// Audio sets the format yt-dlp downloads audio at; --audio-format and --audio-quality override it
+	Audio AudioConfig `yaml:"audio"`
+}
+
+// AudioConfig holds the yt-dlp audio extraction settings of transcription create. A low quality
+// such as m4a at 48k costs little accuracy on speech and keeps audio caches of large batches small.
+type AudioConfig struct {
+	Format  string `yaml:"format"`  // yt-dlp --audio-format: best (default), aac, m4a, mp3, opus, vorbis or wav
+	Quality string `yaml:"quality"` // yt-dlp --audio-quality: 0 (best, default) to 10, or a bitrate such as 48k
+}
+
+// HallucinationConfig holds the hallucination phrase list applied when transcriptions are saved.
+// The phrases of the workspace's database list (transcription hallucinations add) are added to it.
+type HallucinationConfig struct {
+	Mode string `yaml:"mode"` // flag (default), remove or off
+
+	// Phrases replaces the built-in list of common whisper hallucinations; unset keeps it, an
+	// empty list leaves only the database phrases
+	Phrases []string `yaml:"phrases"`
+}
+
+// DecodingConfig holds whisper decoding parameters; unset values keep whisper's defaults
+type DecodingConfig struct {
+	BeamSize                  int     `yaml:"beam_size"`
+	BestOf                    int     `yaml:"best_of"`
+	TemperatureIncrement      float64 `yaml:"temperature_increment"` // temperature added on each of whisper's own retries
+	CompressionRatioThreshold float64 `yaml:"compression_ratio_threshold"`
+	LogprobThreshold          float64 `yaml:"logprob_threshold"`
+	NoSpeechThreshold         float64 `yaml:"no_speech_threshold"`
+}
+
+// FallbackConfig is one run of the whisper fallback ladder
+type FallbackConfig struct {
+	Model          string `yaml:"model"` // model to run; empty keeps the model, "next" is one size larger
+	DecodingConfig `yaml:",inline"`
+}
+
+// defaultMinLanguageConfidence is used when min_language_confidence is not configured
+const defaultMinLanguageConfidence = 0.5
+
+// LanguageConfidenceThreshold returns the configured detection probability warning threshold
+func (c TranscriptionConfig) LanguageConfidenceThreshold() float64 {
+	if c.MinLanguageConfidence <= 0 {
+		return defaultMinLanguageConfidence
+	}
+	return c.MinLanguageConfidence
+}
+
+// RoutingConfig selects the whisper model by language. When models are configured and
+// transcription create is run without --model, the language is detected on a short sample
+// of the audio (unless --language is given) and the model of its rule is used.
+type RoutingConfig struct {
+	Models         map[string]string `yaml:"models"`          // whisper model per language code; "*" matches the rest
+	SampleModel    string            `yaml:"sample_model"`    // model detecting the sample's language; defaults to tiny
+	SampleDuration time.Duration     `yaml:"sample_duration"` // audio sampled across the video; defaults to 60s
+}
+
+// Enabled reports whether any routing rule is configured
+func (c RoutingConfig) Enabled() bool {
+	return len(c.Models) > 0
+}
+
+// TranslationConfig holds translation pipeline settings
+type TranslationConfig struct {
+	// TokenRatios overrides the characters-per-token ratio used to size batches, keyed by
+	// source language code (e.g. ja: 1.2)
+	TokenRatios map[string]float64 `yaml:"token_ratios"`
+
+	// Workers is the number of batches sent to the PLaMo server concurrently (default 1)
+	Workers int `yaml:"workers"`
+
+	// PlamoDebug records PLaMo prompts and raw responses for debugging batch splitting
+	PlamoDebug PlamoDebugConfig `yaml:"plamo_debug"`
+
+	// RateLimits caps the requests sent to each translation provider, keyed by provider name (e.g. plamo)
+	RateLimits map[string]RateLimitConfig `yaml:"rate_limits"`
+
+	// Polish configures the LLM that post-edits PLaMo translations (translation create --polish)
+	Polish PolishConfig `yaml:"polish"`
+
+	// Providers are the translation providers batches are scheduled across (translation create
+	// --strategy); without any, every batch is translated by PLaMo
+	Providers []TranslationProviderConfig `yaml:"providers"`
+}
+
+// Translation provider kinds
+const (
+	ProviderKindPlamo  = "plamo"  // The PLaMo server translations are made with by default
+	ProviderKindOpenAI = "openai" // Any OpenAI-compatible chat completions API
+)
+
+// TranslationProviderConfig holds one provider batches can be scheduled to
+type TranslationProviderConfig struct {
+	Name        string   `yaml:"name"`         // recorded as the source of its translations
+	Kind        string   `yaml:"kind"`         // plamo or openai
+	BaseURL     string   `yaml:"base_url"`     // openai only; defaults to https://api.openai.com/v1
+	Model       string   `yaml:"model"`        // openai only
+	APIKey      string   `yaml:"api_key"`      // openai only; defaults to $OPENAI_API_KEY
+	Languages   []string `yaml:"languages"`    // pairs as SOURCE:TARGET ("*" matches any language); all when empty
+	DailyTokens int      `yaml:"daily_tokens"` // input tokens per UTC day; unlimited when 0
+	Cost        float64  `yaml:"cost"`         // price per 1000 input tokens, in a unit shared by all providers
+	Quality     int      `yaml:"quality"`      // rank for --strategy quality; higher is better
+}
+
+// PolishConfig holds the OpenAI-compatible chat completions API used to post-edit translations
+type PolishConfig struct {
+	BaseURL string `yaml:"base_url"` // defaults to https://api.openai.com/v1
+	Model   string `yaml:"model"`
+	APIKey  string `yaml:"api_key"` // defaults to $OPENAI_API_KEY
+}
+
+// Enabled reports whether a post-editing model is configured
+func (c PolishConfig) Enabled() bool {
+	return c.Model != ""
+}
+
+// RateLimitConfig holds the limits of one translation provider; zero values are unlimited
+type RateLimitConfig struct {
+	RequestsPerMinute int `yaml:"requests_per_minute"`
+	MaxParallel       int `yaml:"max_parallel"` // requests in flight at once, whatever the number of workers
+	MaxRetries        int `yaml:"max_retries"`  // retries of requests refused with a rate limit error (default 5)
+}
+
+// PlamoDebugConfig holds PLaMo transcript logging settings
+type PlamoDebugConfig struct {
+	Enabled        bool     `yaml:"enabled"`
+	Dir            string   `yaml:"dir"`             // defaults to ~/.yt-lang/logs/plamo
+	Redact         []string `yaml:"redact"`          // emails, urls, numbers, text
+	RedactPatterns []string `yaml:"redact_patterns"` // additional regular expressions
+}
+
+// SubtitleConfig holds subtitle formatting settings for SRT/VTT output
+type SubtitleConfig struct {
+	Style  string                    `yaml:"style"`  // style used when --style is not given
+	Styles map[string]subtitle.Rules `yaml:"styles"` // custom styles; override built-in presets of the same name
+	VTT    subtitle.VTTOptions       `yaml:"vtt"`    // cue placement and styling hints of WebVTT exports
 }
 
 // DatabaseConfig holds parsed database connection configuration
@@ -31,13 +310,13 @@ type DatabaseConfig struct {
 }
 
 // NewConfig loads configuration with the following priority:
-// Environment variables > Config file (required)
+// --workspace flag > Environment variables > Config file (required)
 func NewConfig() (*Config, error) {
 	// Load from config file (required)
 	config := &Config{}
 	if err := loadConfigFile(config); err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("configuration file not found. Please run 'ytlang config init' to create it")
+			return nil, errors.New(i18n.T("msg.config_not_found", "configuration file not found. Please run 'ytlang config init' to create it"))
 		}
 		return nil, fmt.Errorf("failed to load config file: %w", err)
 	}
@@ -46,10 +325,44 @@ func NewConfig() (*Config, error) {
 	if envURL := os.Getenv("DATABASE_URL"); envURL != "" {
 		config.DatabaseURL = envURL
 	}
+	if envWorkspace := os.Getenv("YTLANG_WORKSPACE"); envWorkspace != "" {
+		config.Workspace = envWorkspace
+	}
+	if workspaceOverride != "" {
+		config.Workspace = workspaceOverride
+	}
+	if config.Storage.S3.AccessKeyID == "" {
+		config.Storage.S3.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+	}
+	if config.Storage.S3.SecretAccessKey == "" {
+		config.Storage.S3.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	}
+	if config.Translation.Polish.APIKey == "" {
+		config.Translation.Polish.APIKey = os.Getenv("OPENAI_API_KEY")
+	}
+	for i := range config.Translation.Providers {
+		if provider := &config.Translation.Providers[i]; provider.Kind == ProviderKindOpenAI && provider.APIKey == "" {
+			provider.APIKey = os.Getenv("OPENAI_API_KEY")
+		}
+	}
+	if config.Embeddings.APIKey == "" {
+		config.Embeddings.APIKey = os.Getenv("OPENAI_API_KEY")
+	}
+	if config.TTS.APIKey == "" {
+		config.TTS.APIKey = os.Getenv("OPENAI_API_KEY")
+	}
 
 	return config, nil
 }
 
+// ActiveWorkspace returns the workspace commands read and write
+func (c *Config) ActiveWorkspace() string {
+	if c.Workspace == "" {
+		return model.DefaultWorkspace
+	}
+	return c.Workspace
+}
+
 // ParseDatabaseConfig parses the DATABASE_URL into DatabaseConfig
 func (c *Config) ParseDatabaseConfig() (*DatabaseConfig, error) {
 	if c.DatabaseURL == "" {
@@ -92,6 +405,183 @@ func InitConfig(databaseURL string) error {
 # postgres://[user[:password]@]host[:port]/dbname[?param1=value1&...]
 
 database_url: "%s"
+
+# Active workspace (switch with 'ytlang workspace use', override with --workspace
+# or YTLANG_WORKSPACE)
+# workspace: default
+
+# Language of help text and messages: en, ja or es (override with --lang or YTLANG_LANG)
+# lang: ja
+
+# Subtitle formatting for SRT/VTT output (select with --style)
+# subtitles:
+#   style: netflix
+#   styles:
+#     compact:
+#       max_line_length: 32
+#       max_lines: 2
+#       min_duration: 1s
+#       max_duration: 6s
+#       min_gap: 100ms
+#   # Placement and styling hints written into WebVTT exports
+#   vtt:
+#     settings: "line:85%% align:center"
+#     style: "::cue { color: yellow; background-color: rgba(0, 0, 0, 0.8); }"
+
+# Whisper model per language, used by transcription create without --model. Without
+# --language, the language is first detected on a 60s sample spread across the video.
+# transcription:
+#   routing:
+#     models:
+#       ja: medium
+#       en: base
+#       "*": small
+#     sample_model: tiny
+#     sample_duration: 60s
+#   # Warn when the detected language is less likely than this (multilingual or
+#   # music-heavy videos)
+#   min_language_confidence: 0.5
+#   # Whisper decoding parameters of every run
+#   decoding:
+#     no_speech_threshold: 0.6
+#   # Runs tried in turn when the output looks pathological (repetition loops,
+#   # low confidence); the run with the fewest problems is kept. "next" is the
+#   # next larger model; "fallback: []" disables retries.
+#   fallback:
+#     - beam_size: 5
+#       best_of: 5
+#     - model: next
+#       beam_size: 5
+#       best_of: 5
+#   # Segments consisting only of a phrase whisper emits on silence ("Thanks for
+#   # watching!") are flagged, or removed with mode: remove. Phrases replaces the
+#   # built-in list; add phrases per workspace with 'transcription hallucinations add'.
+#   hallucinations:
+#     mode: remove
+#     phrases: ["Thanks for watching!", "ご視聴ありがとうございました"]
+#   # Download audio smaller than YouTube's best, trading a little accuracy for
+#   # much smaller audio caches (see storage.cache_audio)
+#   audio:
+#     format: m4a
+#     quality: 48k
+
+# Characters per token by source language, used to keep translation batches
+# within the PLaMo input limit
+# translation:
+#   token_ratios:
+#     ja: 1.0
+#     en: 4.0
+#   workers: 1
+#   plamo_debug:
+#     enabled: false
+#     redact: [emails, urls]
+#   rate_limits:
+#     plamo:
+#       requests_per_minute: 60
+#       max_parallel: 2
+#   # LLM post-editing the PLaMo output (translation create --polish); any
+#   # OpenAI-compatible chat completions API
+#   polish:
+#     base_url: https://api.openai.com/v1
+#     model: gpt-4o-mini
+#   # Providers batches are scheduled across (translation create --strategy
+#   # cheapest|fastest|quality); rate_limits apply by provider name
+#   providers:
+#     - name: plamo
+#       kind: plamo
+#       languages: ["en:ja", "ja:en"]
+#       quality: 2
+#     - name: gpt-4o-mini
+#       kind: openai
+#       model: gpt-4o-mini
+#       daily_tokens: 2000000
+#       cost: 0.15
+#       quality: 3
+
+# Storage for large artifacts: cached audio, raw whisper output and exports
+# (export transcripts --to-storage). The default is a local directory.
+# storage:
+#   backend: s3
+#   cache_audio: true
+#   max_cache_size: 20GB  # evicts least recently used cached audio (local backend)
+#   s3:
+#     endpoint: https://s3.ap-northeast-1.amazonaws.com
+#     region: ap-northeast-1
+#     bucket: yt-lang-artifacts
+#     prefix: prod
+
+# Shell commands run at pipeline milestones with the event JSON on stdin
+# hooks:
+#   timeout: 60s
+#   transcription_completed:
+#     - jq -r .data.transcription.id >> ~/transcribed.txt
+#   translation_completed:
+#     - ./scripts/notify.sh
+
+# Network settings passed to yt-dlp (overridden by --proxy, --force-ipv4,
+# --force-ipv6 and --user-agent)
+# network:
+#   proxy: socks5://127.0.0.1:1080
+#   force_ipv4: true
+#   user_agent: "Mozilla/5.0"
+#   # Fail yt-dlp, API and remote storage operations right away; database and
+#   # cached audio keep working (same as --offline)
+#   offline: true
+
+# Library retention rules of prune (overridden by --older-than and
+# --keep-transcribed): unrated videos without a note uploaded longer ago
+# than older_than are removed with their cached artifacts
+# retention:
+#   older_than: 2y  # d, w, mo or y
+#   keep_transcribed: true
+
+# Database query logging to diagnose slow listings and searches; arguments
+# are redacted
+# query_log:
+#   all: true              # every query with its duration, as JSON lines on stderr
+#   slow_threshold: 500ms  # warn about slower queries
+
+# API tokens of serve (sent as "Authorization: Bearer <token>"); without tokens
+# the API is open to anyone who can reach the address. read tokens can only
+# browse, write tokens can change data and admin tokens can also delete
+# server:
+#   tokens:
+#     - name: viewer
+#       token: generate-with-openssl-rand-hex-32
+#       scope: read
+#     - name: admin
+#       token: another-long-random-secret
+#       scope: admin
+
+# Embeddings of segments for search semantic, computed by search index; any
+# OpenAI-compatible embeddings API (needs the pgvector extension in the database)
+# embeddings:
+#   base_url: https://api.openai.com/v1
+#   model: text-embedding-3-small
+#   batch_size: 100
+
+# Record every command run (command, duration, success; no arguments) in the
+# database, to see where your time goes with 'ytlang stats usage'. Off unless
+# enabled; the stats never leave the database
+# usage_stats:
+#   enabled: true
+
+# Speech synthesizer reading translations aloud ('ytlang tts'): a local piper
+# voice (pip install piper-tts) or any OpenAI-compatible speech API. voices maps
+# --voice (the translation's language by default) to a piper model or an API voice
+# tts:
+#   backend: piper
+#   voices:
+#     en: /opt/piper/voices/en_US-lessac-medium.onnx
+#     ja-JP: /opt/piper/voices/ja_JP-voice.onnx
+
+# Paths of external tools installed outside PATH (check them with 'ytlang doctor');
+# ~/ is expanded, and on Windows the .exe extension may be omitted
+# tools:
+#   yt-dlp: C:\Tools\yt-dlp
+#   whisper: ~/venvs/whisper/bin/whisper
+#   ffmpeg: /opt/homebrew/bin/ffmpeg
+#   plamo-translate: ~/.local/bin/plamo-translate
 `, databaseURL)
 
 	if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
@@ -101,11 +591,71 @@ database_url: "%s"
 	return nil
 }
 
+// ResolveSubtitleRules returns the subtitle rules for a style name, falling back to the
+// configured default style when style is empty. Styles from the config file are used when
+// it exists, but the file is not required.
+func ResolveSubtitleRules(style string) (subtitle.Rules, error) {
+	config := &Config{}
+	if err := loadConfigFile(config); err != nil && !os.IsNotExist(err) {
+		return subtitle.Rules{}, fmt.Errorf("failed to load config file: %w", err)
+	}
+
+	if style == "" {
+		style = config.Subtitles.Style
+	}
+	return subtitle.ResolveRules(style, config.Subtitles.Styles)
+}
+
 // GetConfigPath returns the path to the configuration file
 func GetConfigPath() (string, error) {
 	return getConfigFilePath()
 }
 
+// GetCacheDir returns the directory for cached computed data (~/.yt-lang/cache)
+func GetCacheDir() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "cache"), nil
+}
+
+// GetArtifactDir returns the directory for stored artifacts such as raw whisper output (~/.yt-lang/artifacts)
+func GetArtifactDir() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "artifacts"), nil
+}
+
+// GetStateDir returns the directory for local process state such as temp directory registrations (~/.yt-lang/state)
+func GetStateDir() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "state"), nil
+}
+
+// GetWorkDir returns the directory for the workspaces of resumable jobs (~/.yt-lang/work)
+func GetWorkDir() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "work"), nil
+}
+
+// GetPlamoLogDir returns the directory for PLaMo debug transcripts (~/.yt-lang/logs/plamo)
+func GetPlamoLogDir() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "logs", "plamo"), nil
+}
+
 // getConfigDir returns the configuration directory path (~/.yt-lang)
 func getConfigDir() (string, error) {
 	homeDir, err := os.UserHomeDir()
@@ -3,13 +3,64 @@ package config
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Taichi-iskw/yt-lang/internal/repository/common"
 )
 
+// DatabasePool is a PostgreSQL connection pool whose Exec, Query, QueryRow, CopyFrom and
+// Begin are logged per the query_log section of the config file. Other methods, e.g. the
+// Acquire of advisory locks, use the pool directly.
+type DatabasePool struct {
+	*pgxpool.Pool
+	queries common.Pool
+}
+
+// Exec runs a statement
+func (p *DatabasePool) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	return p.queries.Exec(ctx, sql, arguments...)
+}
+
+// Query runs a query
+func (p *DatabasePool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return p.queries.Query(ctx, sql, args...)
+}
+
+// QueryRow runs a query returning at most one row
+func (p *DatabasePool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return p.queries.QueryRow(ctx, sql, args...)
+}
+
+// CopyFrom bulk inserts rows
+func (p *DatabasePool) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return p.queries.CopyFrom(ctx, tableName, columnNames, rowSrc)
+}
+
+// Begin starts a transaction
+func (p *DatabasePool) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.queries.Begin(ctx)
+}
+
+// newQueryLog wraps pool with the query logging of cfg, if any is enabled
+func newQueryLog(pool *pgxpool.Pool, cfg QueryLogConfig) common.Pool {
+	if !cfg.All && cfg.SlowThreshold <= 0 {
+		return pool
+	}
+
+	opts := common.QueryLogOptions{Warnings: os.Stderr, SlowThreshold: cfg.SlowThreshold}
+	if cfg.All {
+		opts.Log = os.Stderr
+	}
+	return common.NewLoggingPool(pool, opts)
+}
+
 // NewDatabasePool creates a new PostgreSQL connection pool
-func NewDatabasePool(ctx context.Context, config *Config) (*pgxpool.Pool, error) {
+func NewDatabasePool(ctx context.Context, config *Config) (*DatabasePool, error) {
 	dbConfig, err := config.ParseDatabaseConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse database config: %w", err)
@@ -27,6 +78,13 @@ func NewDatabasePool(ctx context.Context, config *Config) (*pgxpool.Pool, error)
 	poolConfig.MaxConnLifetime = dbConfig.MaxConnLifetime
 	poolConfig.MaxConnIdleTime = dbConfig.MaxConnIdleTime
 
+	// Every connection reads and writes the active workspace (see current_workspace() in the migrations)
+	workspace := config.ActiveWorkspace()
+	poolConfig.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+		_, err := conn.Exec(ctx, "SELECT set_config('ytlang.workspace', $1, false)", workspace)
+		return err
+	}
+
 	// Create connection pool with timeout
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
@@ -42,11 +100,11 @@ func NewDatabasePool(ctx context.Context, config *Config) (*pgxpool.Pool, error)
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return pool, nil
+	return &DatabasePool{Pool: pool, queries: newQueryLog(pool, config.QueryLog)}, nil
 }
 
 // CloseDatabasePool gracefully closes the database connection pool
-func CloseDatabasePool(pool *pgxpool.Pool) {
+func CloseDatabasePool(pool *DatabasePool) {
 	if pool != nil {
 		pool.Close()
 	}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"github.com/Taichi-iskw/yt-lang/internal/hook"
+)
+
+// NewHookDispatcher creates a dispatcher running the hook commands of config
+func NewHookDispatcher(config *Config) (*hook.Dispatcher, error) {
+	dispatcher := hook.NewDispatcher()
+
+	commands := map[string][]string{
+		hook.EventVideoSaved:             config.Hooks.VideoSaved,
+		hook.EventTranscriptionCompleted: config.Hooks.TranscriptionCompleted,
+		hook.EventTranslationCompleted:   config.Hooks.TranslationCompleted,
+	}
+	for _, event := range hook.Events {
+		for _, command := range commands[event] {
+			if err := dispatcher.Register(event, hook.NewCommand(command, config.Hooks.Timeout)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return dispatcher, nil
+}
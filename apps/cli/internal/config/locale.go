@@ -0,0 +1,23 @@
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+// ResolveCLILanguage returns the language of CLI messages: the --lang flag, then YTLANG_LANG,
+// then lang from the config file (optional). Empty means the default, English.
+func ResolveCLILanguage(flag string) (string, error) {
+	if flag != "" {
+		return flag, nil
+	}
+	if env := os.Getenv("YTLANG_LANG"); env != "" {
+		return env, nil
+	}
+
+	config := &Config{}
+	if err := loadConfigFile(config); err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to load config file: %w", err)
+	}
+	return config.Lang, nil
+}
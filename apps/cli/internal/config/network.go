@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
+)
+
+// ResolveNetworkOptions merges the network section of the config file (optional) with
+// options given on the command line, which take precedence
+func ResolveNetworkOptions(flags ytdlp.NetworkOptions) (ytdlp.NetworkOptions, error) {
+	config := &Config{}
+	if err := loadConfigFile(config); err != nil && !os.IsNotExist(err) {
+		return ytdlp.NetworkOptions{}, fmt.Errorf("failed to load config file: %w", err)
+	}
+
+	opts := ytdlp.NetworkOptions{
+		Proxy:     config.Network.Proxy,
+		ForceIPv4: config.Network.ForceIPv4,
+		ForceIPv6: config.Network.ForceIPv6,
+		UserAgent: config.Network.UserAgent,
+	}
+	if flags.Proxy != "" {
+		opts.Proxy = flags.Proxy
+	}
+	// An IP version flag replaces the configured one instead of conflicting with it
+	if flags.ForceIPv4 || flags.ForceIPv6 {
+		opts.ForceIPv4, opts.ForceIPv6 = flags.ForceIPv4, flags.ForceIPv6
+	}
+	if flags.UserAgent != "" {
+		opts.UserAgent = flags.UserAgent
+	}
+
+	if err := opts.Validate(); err != nil {
+		return ytdlp.NetworkOptions{}, err
+	}
+	return opts, nil
+}
+
+// ResolveOffline reports whether offline mode is on: with --offline or network.offline in the
+// config file (optional)
+func ResolveOffline(flag bool) (bool, error) {
+	if flag {
+		return true, nil
+	}
+
+	config := &Config{}
+	if err := loadConfigFile(config); err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to load config file: %w", err)
+	}
+	return config.Network.Offline, nil
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/Taichi-iskw/yt-lang/internal/server"
+)
+
+// minTokenLength is the shortest accepted API token, so guessable tokens are rejected
+const minTokenLength = 16
+
+// NewAPITokens validates the API tokens of config and returns them for the server
+func NewAPITokens(config *Config) ([]server.Token, error) {
+	tokens := make([]server.Token, 0, len(config.Server.Tokens))
+	seen := map[string]string{}
+	for i, tc := range config.Server.Tokens {
+		name := tc.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+		if len(tc.Token) < minTokenLength {
+			return nil, fmt.Errorf("server token %s must be at least %d characters long", name, minTokenLength)
+		}
+		if other, ok := seen[tc.Token]; ok {
+			return nil, fmt.Errorf("server tokens %s and %s have the same token", other, name)
+		}
+		seen[tc.Token] = name
+
+		scope, err := server.ParseScope(tc.Scope)
+		if err != nil {
+			return nil, fmt.Errorf("server token %s: %w", name, err)
+		}
+		tokens = append(tokens, server.Token{Name: name, Secret: tc.Token, Scope: scope})
+	}
+	return tokens, nil
+}
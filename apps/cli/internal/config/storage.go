@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Taichi-iskw/yt-lang/internal/artifact"
+)
+
+// NewArtifactStore creates the artifact store selected by the storage section of config
+func NewArtifactStore(config *Config) (artifact.Store, error) {
+	storage := config.Storage
+
+	switch storage.Backend {
+	case "", "local":
+		dir := storage.Dir
+		if dir == "" {
+			var err error
+			if dir, err = GetArtifactDir(); err != nil {
+				return nil, err
+			}
+		}
+		return artifact.NewLocalStore(dir), nil
+	case "s3":
+		store, err := artifact.NewS3Store(artifact.S3Options{
+			Endpoint:        storage.S3.Endpoint,
+			Region:          storage.S3.Region,
+			Bucket:          storage.S3.Bucket,
+			Prefix:          storage.S3.Prefix,
+			AccessKeyID:     storage.S3.AccessKeyID,
+			SecretAccessKey: storage.S3.SecretAccessKey,
+			UsePathStyle:    storage.S3.UsePathStyle,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure s3 storage: %w", err)
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q (expected local or s3)", storage.Backend)
+	}
+}
+
+// CacheDirs returns the local directories holding evictable caches: ~/.yt-lang/cache and,
+// with the local storage backend, its cached audio
+func CacheDirs(config *Config) ([]string, error) {
+	cacheDir, err := GetCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	dirs := []string{cacheDir}
+
+	artifactDir, err := LocalArtifactDir(config)
+	if err != nil {
+		return nil, err
+	}
+	if artifactDir != "" {
+		dirs = append(dirs, filepath.Join(artifactDir, filepath.Dir(artifact.AudioKey("", ""))))
+	}
+	return dirs, nil
+}
+
+// LocalArtifactDir returns the directory of the local storage backend, or "" for other backends
+func LocalArtifactDir(config *Config) (string, error) {
+	if backend := config.Storage.Backend; backend != "" && backend != "local" {
+		return "", nil
+	}
+	if config.Storage.Dir != "" {
+		return config.Storage.Dir, nil
+	}
+	return GetArtifactDir()
+}
@@ -0,0 +1,15 @@
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+// ResolveToolPaths returns the binary paths of the tools section of the config file (optional)
+func ResolveToolPaths() (map[string]string, error) {
+	config := &Config{}
+	if err := loadConfigFile(config); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to load config file: %w", err)
+	}
+	return config.Tools, nil
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// workspaceOverride is the workspace selected with the global --workspace flag
+var workspaceOverride string
+
+// SetWorkspaceOverride makes NewConfig use workspace regardless of the config file and YTLANG_WORKSPACE
+func SetWorkspaceOverride(workspace string) {
+	workspaceOverride = workspace
+}
+
+// SetActiveWorkspace sets the workspace key of the config file, keeping its other settings and comments
+func SetActiveWorkspace(workspace string) error {
+	configPath, err := getConfigFilePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("configuration file not found. Please run 'ytlang config init' to create it")
+		}
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to parse config file: top level is not a mapping")
+	}
+
+	root := doc.Content[0]
+	value := &yaml.Node{Kind: yaml.ScalarNode, Value: workspace}
+	replaced := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "workspace" {
+			root.Content[i+1] = value
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "workspace"}, value)
+	}
+
+	// Keep the two-space indentation of the generated file
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to format config file: %w", err)
+	}
+	if err := os.WriteFile(configPath, out.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Package diskguard checks free disk space before operations that write a lot (batch
+// transcriptions, model downloads), so they fail before starting instead of running out of
+// space halfway and leaving truncated files in caches.
+package diskguard
+
+import (
+	stderrors "errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/clock"
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/janitor"
+)
+
+// Headroom is the free space left on a filesystem beyond what the operations need, for the
+// database, logs and everything else writing there meanwhile
+const Headroom = 256 << 20
+
+// errUnsupported is returned by usage on platforms where free space cannot be read
+var errUnsupported = stderrors.New("free disk space cannot be checked on this platform")
+
+// Need is the disk space an operation is going to take in a directory
+type Need struct {
+	Dir   string // Directory the files go to; need not exist yet
+	Bytes int64
+	What  string // What takes the space, e.g. "cached audio"
+}
+
+// Check fails with CodeDiskSpace when a filesystem has less free space than the needs on it
+// plus Headroom. Needs in directories on the same filesystem add up. Filesystems whose free
+// space cannot be read are not checked.
+func Check(needs ...Need) error {
+	type filesystem struct {
+		free  uint64
+		bytes int64
+		dirs  []string
+		what  []string
+	}
+	filesystems := map[uint64]*filesystem{}
+	var order []uint64
+
+	for _, need := range needs {
+		if need.Bytes <= 0 {
+			continue
+		}
+		free, device, err := usage(existingParent(need.Dir))
+		if err != nil {
+			continue
+		}
+		fs, ok := filesystems[device]
+		if !ok {
+			fs = &filesystem{free: free}
+			filesystems[device] = fs
+			order = append(order, device)
+		}
+		fs.bytes += need.Bytes
+		fs.dirs = append(fs.dirs, need.Dir)
+		fs.what = append(fs.what, fmt.Sprintf("%s %s", janitor.FormatSize(need.Bytes), need.What))
+	}
+
+	for _, device := range order {
+		fs := filesystems[device]
+		if uint64(fs.bytes)+Headroom <= fs.free {
+			continue
+		}
+		return errors.New(errors.CodeDiskSpace, fmt.Sprintf("not enough disk space in %s: about %s needed (%s), %s free",
+			strings.Join(fs.dirs, ", "), janitor.FormatSize(fs.bytes+Headroom), strings.Join(fs.what, ", "), janitor.FormatSize(int64(fs.free))))
+	}
+	return nil
+}
+
+// existingParent returns dir, or its nearest parent that exists
+func existingParent(dir string) string {
+	dir = filepath.Clean(dir)
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
+
+// Guard re-checks needs during a long operation, at most once per interval, so running out of
+// space because of other writers stops the operation between steps rather than in the middle
+// of one
+type Guard struct {
+	needs    []Need
+	interval time.Duration
+	clock    clock.Clock
+	checked  time.Time
+}
+
+// NewGuard creates a Guard checking needs at most once per interval
+func NewGuard(interval time.Duration, needs ...Need) *Guard {
+	return NewGuardWithClock(interval, clock.System, needs...)
+}
+
+// NewGuardWithClock creates a Guard with a custom clock (for testing)
+func NewGuardWithClock(interval time.Duration, clk clock.Clock, needs ...Need) *Guard {
+	return &Guard{needs: needs, interval: interval, clock: clk}
+}
+
+// Check checks the needs unless they were checked less than the interval ago. A nil Guard
+// never fails.
+func (g *Guard) Check() error {
+	if g == nil {
+		return nil
+	}
+	now := g.clock.Now()
+	if !g.checked.IsZero() && now.Sub(g.checked) < g.interval {
+		return nil
+	}
+	g.checked = now
+	return Check(g.needs...)
+}
@@ -0,0 +1,49 @@
+//go:build unix
+
+package diskguard
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/clock"
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+)
+
+// tooMuch is more space than any test machine has
+const tooMuch = int64(1) << 60
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.NoError(t, Check(), "no needs")
+	assert.NoError(t, Check(Need{Dir: dir, Bytes: 1024, What: "audio"}))
+	assert.NoError(t, Check(Need{Dir: filepath.Join(dir, "not", "created", "yet"), Bytes: 1024, What: "audio"}),
+		"missing directories are checked on their nearest existing parent")
+
+	err := Check(Need{Dir: dir, Bytes: 1024, What: "audio"}, Need{Dir: filepath.Join(dir, "cache"), Bytes: tooMuch, What: "cached audio"})
+	require.Error(t, err)
+	var appErr *errors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, errors.CodeDiskSpace, appErr.Code)
+	assert.Contains(t, err.Error(), "not enough disk space in "+dir)
+	assert.Contains(t, err.Error(), "cached audio")
+}
+
+func TestGuard_ChecksAtMostOncePerInterval(t *testing.T) {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	guard := NewGuardWithClock(time.Minute, clk, Need{Dir: t.TempDir(), Bytes: tooMuch, What: "audio"})
+
+	assert.Error(t, guard.Check())
+	clk.Advance(30 * time.Second)
+	assert.NoError(t, guard.Check(), "checked less than an interval ago")
+	clk.Advance(30 * time.Second)
+	assert.Error(t, guard.Check())
+
+	var none *Guard
+	assert.NoError(t, none.Check())
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package diskguard
+
+// usage cannot read free space on this platform; Check lets every operation through
+func usage(dir string) (free uint64, device uint64, err error) {
+	return 0, 0, errUnsupported
+}
@@ -0,0 +1,19 @@
+//go:build unix
+
+package diskguard
+
+import "syscall"
+
+// usage returns the space available to unprivileged users on the filesystem of dir, and an ID
+// of the filesystem
+func usage(dir string) (free uint64, device uint64, err error) {
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(dir, &fs); err != nil {
+		return 0, 0, err
+	}
+	var st syscall.Stat_t
+	if err := syscall.Stat(dir, &st); err != nil {
+		return 0, 0, err
+	}
+	return uint64(fs.Bavail) * uint64(fs.Bsize), uint64(st.Dev), nil
+}
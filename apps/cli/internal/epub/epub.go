@@ -0,0 +1,174 @@
+// Package epub writes minimal EPUB 3 e-books: XHTML chapters with a navigation document and
+// an NCX table of contents for older readers.
+package epub
+
+import (
+	"archive/zip"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+)
+
+// Book is an e-book to write
+type Book struct {
+	Identifier string    // Unique identifier, e.g. a URN
+	Title      string    // Book title
+	Creator    string    // Optional author
+	Language   string    // Main language of the text (BCP 47), e.g. en
+	Modified   time.Time // Last modification time; defaults to now
+	Stylesheet string    // Optional CSS shared by the chapters
+	Chapters   []Chapter
+}
+
+// Chapter is one XHTML document of a book
+type Chapter struct {
+	Title string // Shown in the table of contents and as the chapter heading
+	Body  string // XHTML body content after the heading; escape text with Escape
+}
+
+// Escape escapes text for use in chapter bodies and attributes
+func Escape(text string) string {
+	return html.EscapeString(text)
+}
+
+// Write writes the book to w as an EPUB (a ZIP archive whose first entry is the uncompressed
+// mimetype, as the format requires)
+func (b *Book) Write(w io.Writer) error {
+	if b.Identifier == "" || b.Title == "" || b.Language == "" {
+		return fmt.Errorf("epub: identifier, title and language are required")
+	}
+	if len(b.Chapters) == 0 {
+		return fmt.Errorf("epub: a book needs at least one chapter")
+	}
+
+	archive := zip.NewWriter(w)
+	mimetype, err := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(mimetype, "application/epub+zip"); err != nil {
+		return err
+	}
+
+	files := []struct{ name, content string }{
+		{"META-INF/container.xml", containerXML},
+		{"OEBPS/content.opf", b.packageDocument()},
+		{"OEBPS/nav.xhtml", b.navigationDocument()},
+		{"OEBPS/toc.ncx", b.ncx()},
+		{"OEBPS/style.css", b.Stylesheet},
+	}
+	for i, chapter := range b.Chapters {
+		files = append(files, struct{ name, content string }{"OEBPS/" + chapterFile(i), b.chapterDocument(chapter)})
+	}
+
+	for _, file := range files {
+		entry, err := archive.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(entry, file.content); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// chapterFile returns the file name of the i-th chapter
+func chapterFile(i int) string {
+	return fmt.Sprintf("chapter-%03d.xhtml", i+1)
+}
+
+const containerXML = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+// packageDocument returns the OPF listing the metadata, files and reading order of the book
+func (b *Book) packageDocument() string {
+	modified := b.Modified
+	if modified.IsZero() {
+		modified = time.Now()
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+`)
+	fmt.Fprintf(&sb, "    <dc:identifier id=\"book-id\">%s</dc:identifier>\n", Escape(b.Identifier))
+	fmt.Fprintf(&sb, "    <dc:title>%s</dc:title>\n", Escape(b.Title))
+	if b.Creator != "" {
+		fmt.Fprintf(&sb, "    <dc:creator>%s</dc:creator>\n", Escape(b.Creator))
+	}
+	fmt.Fprintf(&sb, "    <dc:language>%s</dc:language>\n", Escape(b.Language))
+	fmt.Fprintf(&sb, "    <meta property=\"dcterms:modified\">%s</meta>\n", modified.UTC().Format("2006-01-02T15:04:05Z"))
+	sb.WriteString(`  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>
+    <item id="css" href="style.css" media-type="text/css"/>
+`)
+	for i := range b.Chapters {
+		fmt.Fprintf(&sb, "    <item id=\"chapter-%d\" href=\"%s\" media-type=\"application/xhtml+xml\"/>\n", i+1, chapterFile(i))
+	}
+	sb.WriteString("  </manifest>\n  <spine toc=\"ncx\">\n")
+	for i := range b.Chapters {
+		fmt.Fprintf(&sb, "    <itemref idref=\"chapter-%d\"/>\n", i+1)
+	}
+	sb.WriteString("  </spine>\n</package>\n")
+	return sb.String()
+}
+
+// navigationDocument returns the EPUB 3 table of contents
+func (b *Book) navigationDocument() string {
+	var items strings.Builder
+	for i, chapter := range b.Chapters {
+		fmt.Fprintf(&items, "      <li><a href=\"%s\">%s</a></li>\n", chapterFile(i), Escape(chapter.Title))
+	}
+	body := fmt.Sprintf("  <nav epub:type=\"toc\" id=\"toc\">\n    <h1>%s</h1>\n    <ol>\n%s    </ol>\n  </nav>\n", Escape(b.Title), items.String())
+	return b.xhtml(b.Title, body)
+}
+
+// ncx returns the EPUB 2 table of contents, still used by some e-readers
+func (b *Book) ncx() string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1">
+  <head>
+`)
+	fmt.Fprintf(&sb, "    <meta name=\"dtb:uid\" content=\"%s\"/>\n", Escape(b.Identifier))
+	fmt.Fprintf(&sb, "  </head>\n  <docTitle><text>%s</text></docTitle>\n  <navMap>\n", Escape(b.Title))
+	for i, chapter := range b.Chapters {
+		fmt.Fprintf(&sb, "    <navPoint id=\"nav-%d\" playOrder=\"%d\">\n", i+1, i+1)
+		fmt.Fprintf(&sb, "      <navLabel><text>%s</text></navLabel>\n", Escape(chapter.Title))
+		fmt.Fprintf(&sb, "      <content src=\"%s\"/>\n    </navPoint>\n", chapterFile(i))
+	}
+	sb.WriteString("  </navMap>\n</ncx>\n")
+	return sb.String()
+}
+
+// chapterDocument returns the XHTML document of a chapter
+func (b *Book) chapterDocument(chapter Chapter) string {
+	return b.xhtml(chapter.Title, fmt.Sprintf("  <h1>%s</h1>\n%s\n", Escape(chapter.Title), chapter.Body))
+}
+
+// xhtml wraps body content in an XHTML document in the language of the book
+func (b *Book) xhtml(title, body string) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="%[1]s" lang="%[1]s">
+<head>
+  <meta charset="UTF-8"/>
+  <title>%[2]s</title>
+  <link rel="stylesheet" type="text/css" href="style.css"/>
+</head>
+<body>
+%[3]s</body>
+</html>
+`, Escape(b.Language), Escape(title), body)
+}
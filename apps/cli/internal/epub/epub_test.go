@@ -0,0 +1,72 @@
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBook_Write(t *testing.T) {
+	book := &Book{
+		Identifier: "urn:yt-lang:channel:UC123",
+		Title:      "Cocina & Co",
+		Language:   "es",
+		Modified:   time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		Stylesheet: "p { margin: 0; }",
+		Chapters: []Chapter{
+			{Title: "Tortilla <easy>", Body: "<p>" + Escape("Huevos & patatas") + "</p>"},
+			{Title: "Paella", Body: "<p>Arroz</p>"},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, book.Write(&buf))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.NotEmpty(t, archive.File)
+	assert.Equal(t, "mimetype", archive.File[0].Name)
+	assert.Equal(t, zip.Store, archive.File[0].Method)
+
+	files := map[string]string{}
+	for _, file := range archive.File {
+		r, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		files[file.Name] = string(content)
+	}
+	assert.Equal(t, "application/epub+zip", files["mimetype"])
+	assert.Contains(t, files["OEBPS/content.opf"], "<dc:title>Cocina &amp; Co</dc:title>")
+	assert.Contains(t, files["OEBPS/content.opf"], "2026-03-01T12:00:00Z")
+	assert.Contains(t, files["OEBPS/content.opf"], `<itemref idref="chapter-2"/>`)
+	assert.Contains(t, files["OEBPS/nav.xhtml"], `<a href="chapter-001.xhtml">Tortilla &lt;easy&gt;</a>`)
+	assert.Contains(t, files["OEBPS/chapter-001.xhtml"], "<p>Huevos &amp; patatas</p>")
+
+	// Every XML document is well-formed
+	for name, content := range files {
+		if name == "mimetype" || strings.HasSuffix(name, ".css") {
+			continue
+		}
+		decoder := xml.NewDecoder(strings.NewReader(content))
+		for {
+			_, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err, name)
+		}
+	}
+}
+
+func TestBook_Write_Invalid(t *testing.T) {
+	assert.Error(t, (&Book{Identifier: "id", Title: "Empty", Language: "en"}).Write(io.Discard))
+	assert.Error(t, (&Book{Title: "No ID", Language: "en", Chapters: []Chapter{{Title: "One"}}}).Write(io.Discard))
+}
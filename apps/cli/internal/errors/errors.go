@@ -45,4 +45,6 @@ const (
 	CodeExternal   = "EXTERNAL_ERROR"
 	CodeConflict   = "CONFLICT"         // Resource already exists (UNIQUE violation)
 	CodeDependency = "DEPENDENCY_ERROR" // Foreign key constraint violation
+	CodeOffline    = "OFFLINE"          // Operation needs the network but offline mode is on
+	CodeDiskSpace  = "DISK_SPACE"       // Not enough free disk space for the operation
 )
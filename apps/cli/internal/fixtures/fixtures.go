@@ -0,0 +1,119 @@
+// Package fixtures provides sanitized yt-dlp outputs, for parser tests that run against the
+// output of each yt-dlp schema variant instead of JSON written for one test.
+//
+// Fixtures live in testdata/ytdlp/KIND/NAME@VERSION.EXT, where VERSION is the yt-dlp version whose
+// output they hold. Recording with a newer yt-dlp adds files next to the older ones, so tests loop
+// over every schema variant yt-dlp has produced. Record them with go generate (needs yt-dlp and
+// network access); the recorder runs every source of Sources and sanitizes the output.
+//
+// The files checked in so far were written by hand in the shape of the output of those yt-dlp
+// versions, not recorded; running go generate replaces them with recorded output.
+package fixtures
+
+//go:generate go run ./record -dir testdata/ytdlp
+
+import (
+	"embed"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Fixture kinds, one per yt-dlp invocation the services parse
+const (
+	KindChannel      = "channel"       // --dump-json --playlist-items 1 of a channel URL
+	KindVideo        = "video"         // --dump-json --skip-download of a single video
+	KindFlatPlaylist = "flat_playlist" // --dump-json --flat-playlist of a channel: one JSON object per line
+	KindCaptions     = "captions"      // --list-subs tables of a video
+)
+
+// Source is a yt-dlp invocation recorded as a fixture
+type Source struct {
+	Kind string
+	Name string
+	Args []string // yt-dlp arguments, URL last
+}
+
+// Ext returns the file extension of the source's fixtures
+func (s Source) Ext() string {
+	switch s.Kind {
+	case KindFlatPlaylist:
+		return ".jsonl"
+	case KindCaptions:
+		return ".txt"
+	default:
+		return ".json"
+	}
+}
+
+// Sources are the invocations go generate records. They target long-lived public uploads (the
+// first YouTube video and its channel) so re-recording keeps producing comparable output.
+var Sources = []Source{
+	{KindChannel, "jawed", []string{"--dump-json", "--playlist-items", "1", "https://www.youtube.com/@jawed"}},
+	{KindVideo, "me-at-the-zoo", []string{"--dump-json", "--skip-download", "--no-playlist", "https://www.youtube.com/watch?v=jNQXAC9IVRw"}},
+	{KindFlatPlaylist, "jawed", []string{"--dump-json", "--flat-playlist", "--playlist-end", "3", "https://www.youtube.com/channel/UC4QobU6STFB0P71PMvOGN5A"}},
+	{KindCaptions, "me-at-the-zoo", []string{"--list-subs", "--skip-download", "https://www.youtube.com/watch?v=jNQXAC9IVRw"}},
+}
+
+//go:embed testdata/ytdlp
+var files embed.FS
+
+// Fixture is one recorded output
+type Fixture struct {
+	Kind    string
+	Name    string
+	Version string // yt-dlp version that produced it
+	Data    []byte
+}
+
+// ID returns NAME@VERSION, a subtest name telling the variants apart
+func (f *Fixture) ID() string {
+	return f.Name + "@" + f.Version
+}
+
+// Load returns the fixture of a kind, name and yt-dlp version, failing the test when it is missing
+func Load(t testing.TB, kind, name, version string) []byte {
+	t.Helper()
+	for _, f := range All(t, kind) {
+		if f.Name == name && f.Version == version {
+			return f.Data
+		}
+	}
+	t.Fatalf("no %s fixture %s@%s", kind, name, version)
+	return nil
+}
+
+// All returns every fixture of a kind, ordered by name and yt-dlp version
+func All(t testing.TB, kind string) []*Fixture {
+	t.Helper()
+	entries, err := fs.ReadDir(files, path.Join("testdata/ytdlp", kind))
+	if err != nil {
+		t.Fatalf("failed to list %s fixtures: %v", kind, err)
+	}
+
+	var fixtures []*Fixture
+	for _, entry := range entries {
+		base := strings.TrimSuffix(entry.Name(), path.Ext(entry.Name()))
+		name, version, ok := strings.Cut(base, "@")
+		if entry.IsDir() || !ok {
+			continue
+		}
+		data, err := files.ReadFile(path.Join("testdata/ytdlp", kind, entry.Name()))
+		if err != nil {
+			t.Fatalf("failed to read fixture %s: %v", entry.Name(), err)
+		}
+		fixtures = append(fixtures, &Fixture{Kind: kind, Name: name, Version: version, Data: data})
+	}
+	if len(fixtures) == 0 {
+		t.Fatalf("no %s fixtures recorded; run go generate ./internal/fixtures", kind)
+	}
+	sort.Slice(fixtures, func(i, j int) bool {
+		if fixtures[i].Name != fixtures[j].Name {
+			return fixtures[i].Name < fixtures[j].Name
+		}
+		return fixtures[i].Version < fixtures[j].Version
+	})
+	return fixtures
+}
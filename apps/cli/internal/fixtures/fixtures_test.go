@@ -0,0 +1,57 @@
+package fixtures
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSources_Recorded(t *testing.T) {
+	for _, source := range Sources {
+		var names []string
+		for _, f := range All(t, source.Kind) {
+			names = append(names, f.Name)
+		}
+		assert.Contains(t, names, source.Name, "%s %s has no fixture", source.Kind, source.Name)
+	}
+}
+
+func TestFixtures_Sanitized(t *testing.T) {
+	// Recorded fixtures are the sanitizer's output, so sanitizing them again changes nothing
+	for _, kind := range []string{KindChannel, KindVideo, KindFlatPlaylist, KindCaptions} {
+		for _, f := range All(t, kind) {
+			t.Run(kind+"/"+f.ID(), func(t *testing.T) {
+				sanitized, err := Sanitize(kind, f.Data)
+				require.NoError(t, err)
+				assert.Equal(t, string(f.Data), string(sanitized))
+			})
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	data := Load(t, KindVideo, "me-at-the-zoo", "2024.08.06")
+	assert.Contains(t, string(data), `"id": "jNQXAC9IVRw"`)
+}
+
+func TestSanitize(t *testing.T) {
+	output := `{"id": "abc", "view_count": 1234, "epoch": 1723456789, "http_headers": {"Cookie": "x"},` +
+		` "url": "https://rr1.googlevideo.com/videoplayback?expire=1&sig=secret", "webpage_url": "https://www.youtube.com/watch?v=abc&t=10",` +
+		` "formats": [1, 2, 3, 4, 5], "automatic_captions": {"en": [], "fr": [], "ja": []}, "title": "Cats & Dogs"}
+{"id": "def", "like_count": 5}
+`
+	sanitized, err := Sanitize(KindFlatPlaylist, []byte(output))
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(sanitized)), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, `{"automatic_captions":{"en":[],"ja":[]},"formats":[1,2,3],"id":"abc","title":"Cats & Dogs",`+
+		`"url":"https://rr1.googlevideo.com/videoplayback","view_count":0,"webpage_url":"https://www.youtube.com/watch?v=abc"}`, lines[0])
+	assert.Equal(t, `{"id":"def","like_count":0}`, lines[1])
+
+	captions, err := Sanitize(KindCaptions, []byte("[info] Available subtitles for abc:  \r\nen  English  vtt\n\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "[info] Available subtitles for abc:\nen  English  vtt\n", string(captions))
+}
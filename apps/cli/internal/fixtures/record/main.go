@@ -0,0 +1,68 @@
+// Command record runs the yt-dlp invocations of fixtures.Sources and writes their sanitized
+// output as fixtures of the installed yt-dlp version. Run it with go generate ./internal/fixtures.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/fixtures"
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
+)
+
+func main() {
+	dir := flag.String("dir", "testdata/ytdlp", "Directory the fixtures are written to")
+	kind := flag.String("kind", "", "Only record sources of this kind")
+	flag.Parse()
+
+	if err := record(*dir, *kind); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func record(dir, kind string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	runner := common.NewCmdRunner()
+
+	output, err := runner.Run(ctx, ytdlp.Binary, "--version")
+	if err != nil {
+		return fmt.Errorf("failed to run yt-dlp --version: %w", err)
+	}
+	version, err := ytdlp.ParseVersion(strings.TrimSpace(string(output)))
+	if err != nil {
+		return err
+	}
+
+	for _, source := range fixtures.Sources {
+		if kind != "" && source.Kind != kind {
+			continue
+		}
+
+		output, err := runner.Run(ctx, ytdlp.Binary, source.Args...)
+		if err != nil {
+			return fmt.Errorf("failed to record %s %s: %w", source.Kind, source.Name, err)
+		}
+		data, err := fixtures.Sanitize(source.Kind, output)
+		if err != nil {
+			return fmt.Errorf("failed to sanitize %s %s: %w", source.Kind, source.Name, err)
+		}
+
+		path := filepath.Join(dir, source.Kind, source.Name+"@"+version.String()+source.Ext())
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return err
+		}
+		fmt.Printf("Recorded %s\n", path)
+	}
+	return nil
+}
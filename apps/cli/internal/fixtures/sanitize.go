@@ -0,0 +1,122 @@
+package fixtures
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// maxArrayLen caps arrays such as formats and thumbnails, which are long and say nothing new
+// about the schema after the first entries
+const maxArrayLen = 3
+
+// captionLanguages are the caption languages kept in subtitles and automatic_captions maps
+var captionLanguages = map[string]bool{"en": true, "en-orig": true, "ja": true, "es": true}
+
+// keptQueryParams are the URL query parameters that identify content rather than a request
+var keptQueryParams = []string{"v", "list"}
+
+// Sanitize makes yt-dlp output deterministic and free of request-specific data: keys are
+// sorted, signed URLs lose their query strings (keeping v and list), counts that change with
+// every view (view_count, like_count and the like) become 0, the epoch of the run is dropped,
+// long arrays are cut to their first entries and caption maps keep only a few languages. JSON
+// Lines output (flat playlists) stays one object per line; the --list-subs text of captions
+// only has trailing whitespace trimmed.
+func Sanitize(kind string, output []byte) ([]byte, error) {
+	if kind == KindCaptions {
+		var lines []string
+		for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+			lines = append(lines, strings.TrimRight(line, " \t\r"))
+		}
+		return []byte(strings.Join(lines, "\n") + "\n"), nil
+	}
+
+	var out bytes.Buffer
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	for {
+		var value any
+		if err := decoder.Decode(&value); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse yt-dlp output: %w", err)
+		}
+
+		// Titles and descriptions stay readable: no \u0026 for &
+		encoder := json.NewEncoder(&out)
+		encoder.SetEscapeHTML(false)
+		if kind != KindFlatPlaylist {
+			encoder.SetIndent("", "  ")
+		}
+		if err := encoder.Encode(sanitizeValue("", value)); err != nil {
+			return nil, err
+		}
+	}
+	return out.Bytes(), nil
+}
+
+// sanitizeValue sanitizes a JSON value found under key
+func sanitizeValue(key string, value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, child := range v {
+			switch {
+			case k == "epoch" || k == "http_headers" || strings.Contains(k, "cookies"):
+				delete(v, k)
+			case (k == "subtitles" || k == "automatic_captions") && child != nil:
+				if languages, ok := child.(map[string]any); ok {
+					for lang := range languages {
+						if !captionLanguages[lang] {
+							delete(languages, lang)
+						}
+					}
+				}
+				v[k] = sanitizeValue(k, child)
+			default:
+				v[k] = sanitizeValue(k, child)
+			}
+		}
+		return v
+	case []any:
+		if len(v) > maxArrayLen {
+			v = v[:maxArrayLen]
+		}
+		for i, child := range v {
+			v[i] = sanitizeValue(key, child)
+		}
+		return v
+	case float64:
+		if strings.HasSuffix(key, "_count") && key != "playlist_count" {
+			return 0.0
+		}
+		return v
+	case string:
+		return sanitizeURL(v)
+	default:
+		return v
+	}
+}
+
+// sanitizeURL drops the query parameters of http(s) URLs that do not identify content, such as
+// the signatures and expiry times of media URLs
+func sanitizeURL(s string) string {
+	if !strings.HasPrefix(s, "http://") && !strings.HasPrefix(s, "https://") {
+		return s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.RawQuery == "" {
+		return s
+	}
+
+	query := u.Query()
+	kept := url.Values{}
+	for _, name := range keptQueryParams {
+		if value := query.Get(name); value != "" {
+			kept.Set(name, value)
+		}
+	}
+	u.RawQuery = kept.Encode()
+	return u.String()
+}
@@ -0,0 +1,64 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// DefaultTimeout bounds how long a hook command may run
+const DefaultTimeout = 60 * time.Second
+
+// Command is a Handler that runs a shell command with the event JSON on stdin.
+// The event name is also exported as YTLANG_EVENT.
+type Command struct {
+	command string
+	timeout time.Duration
+	output  io.Writer
+}
+
+// NewCommand creates a command hook; output of the command goes to stderr so it never
+// mixes with the CLI's own stdout
+func NewCommand(command string, timeout time.Duration) *Command {
+	return NewCommandWithOutput(command, timeout, os.Stderr)
+}
+
+// NewCommandWithOutput creates a command hook writing the command's output to output (for testing)
+func NewCommandWithOutput(command string, timeout time.Duration, output io.Writer) *Command {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Command{command: command, timeout: timeout, output: output}
+}
+
+// Handle runs the command and waits for it to finish
+func (c *Command) Handle(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s payload: %w", event.Name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", c.command)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = c.output
+	cmd.Stderr = c.output
+	cmd.Env = append(os.Environ(), "YTLANG_EVENT="+event.Name)
+	// Don't wait for background children of a killed shell that still hold its output open
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("%s hook %q timed out after %s", event.Name, c.command, c.timeout)
+		}
+		return fmt.Errorf("%s hook %q failed: %w", event.Name, c.command, err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package hook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Pipeline milestones hooks can subscribe to
+const (
+	EventVideoSaved             = "video_saved"
+	EventTranscriptionCompleted = "transcription_completed"
+	EventTranslationCompleted   = "translation_completed"
+)
+
+// Events lists every event a hook can subscribe to
+var Events = []string{EventVideoSaved, EventTranscriptionCompleted, EventTranslationCompleted}
+
+// Event is delivered to hooks; command hooks receive it as JSON on stdin
+type Event struct {
+	Name string    `json:"event"`
+	Time time.Time `json:"time"`
+	Data any       `json:"data"`
+}
+
+// Handler is the in-process extension point for reacting to pipeline events
+type Handler interface {
+	Handle(ctx context.Context, event Event) error
+}
+
+// HandlerFunc adapts a function to Handler
+type HandlerFunc func(ctx context.Context, event Event) error
+
+// Handle calls f
+func (f HandlerFunc) Handle(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// Dispatcher delivers events to the handlers registered for them
+type Dispatcher struct {
+	handlers map[string][]Handler
+	now      func() time.Time
+}
+
+// NewDispatcher creates a dispatcher without handlers
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{handlers: map[string][]Handler{}, now: time.Now}
+}
+
+// Register adds a handler for event; handlers run in registration order
+func (d *Dispatcher) Register(event string, handler Handler) error {
+	if !isEvent(event) {
+		return fmt.Errorf("unknown hook event %q (expected one of %v)", event, Events)
+	}
+	d.handlers[event] = append(d.handlers[event], handler)
+	return nil
+}
+
+// Dispatch delivers an event to every handler registered for it. A failing handler does
+// not stop the others; all failures are returned together. A nil dispatcher does nothing.
+func (d *Dispatcher) Dispatch(ctx context.Context, name string, data any) error {
+	if d == nil || len(d.handlers[name]) == 0 {
+		return nil
+	}
+
+	event := Event{Name: name, Time: d.now().UTC(), Data: data}
+	var errs []error
+	for _, handler := range d.handlers[name] {
+		if err := handler.Handle(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func isEvent(name string) bool {
+	for _, event := range Events {
+		if event == name {
+			return true
+		}
+	}
+	return false
+}
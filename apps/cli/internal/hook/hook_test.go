@@ -0,0 +1,99 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	t.Run("runs handlers of the event in order", func(t *testing.T) {
+		d := NewDispatcher()
+		var calls []string
+		require.NoError(t, d.Register(EventVideoSaved, HandlerFunc(func(ctx context.Context, e Event) error {
+			calls = append(calls, "first:"+e.Name)
+			return nil
+		})))
+		require.NoError(t, d.Register(EventVideoSaved, HandlerFunc(func(ctx context.Context, e Event) error {
+			calls = append(calls, "second:"+e.Data.(string))
+			return nil
+		})))
+		require.NoError(t, d.Register(EventTranslationCompleted, HandlerFunc(func(ctx context.Context, e Event) error {
+			calls = append(calls, "other")
+			return nil
+		})))
+
+		require.NoError(t, d.Dispatch(context.Background(), EventVideoSaved, "payload"))
+		assert.Equal(t, []string{"first:video_saved", "second:payload"}, calls)
+	})
+
+	t.Run("a failing handler does not stop the others", func(t *testing.T) {
+		d := NewDispatcher()
+		called := false
+		require.NoError(t, d.Register(EventTranscriptionCompleted, HandlerFunc(func(ctx context.Context, e Event) error {
+			return errors.New("webhook unreachable")
+		})))
+		require.NoError(t, d.Register(EventTranscriptionCompleted, HandlerFunc(func(ctx context.Context, e Event) error {
+			called = true
+			return nil
+		})))
+
+		err := d.Dispatch(context.Background(), EventTranscriptionCompleted, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "webhook unreachable")
+		assert.True(t, called)
+	})
+
+	t.Run("unknown events are rejected", func(t *testing.T) {
+		err := NewDispatcher().Register("video_deleted", HandlerFunc(func(ctx context.Context, e Event) error { return nil }))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown hook event")
+	})
+
+	t.Run("nil dispatcher is a no-op", func(t *testing.T) {
+		var d *Dispatcher
+		assert.NoError(t, d.Dispatch(context.Background(), EventVideoSaved, nil))
+	})
+}
+
+func TestCommand_Handle(t *testing.T) {
+	event := Event{Name: EventTranscriptionCompleted, Time: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), Data: map[string]string{"id": "trans-1"}}
+
+	t.Run("passes the event on stdin and in the environment", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "payload.json")
+		var output bytes.Buffer
+		hook := NewCommandWithOutput(`cat > "`+out+`"; echo "got $YTLANG_EVENT"`, time.Second*10, &output)
+
+		require.NoError(t, hook.Handle(context.Background(), event))
+		assert.Equal(t, "got transcription_completed\n", output.String())
+
+		data, err := os.ReadFile(out)
+		require.NoError(t, err)
+		var received Event
+		require.NoError(t, json.Unmarshal(data, &received))
+		assert.Equal(t, EventTranscriptionCompleted, received.Name)
+		assert.Equal(t, map[string]any{"id": "trans-1"}, received.Data)
+	})
+
+	t.Run("non-zero exit fails", func(t *testing.T) {
+		hook := NewCommandWithOutput("exit 3", time.Second*10, &bytes.Buffer{})
+		err := hook.Handle(context.Background(), event)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `transcription_completed hook "exit 3" failed`)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		hook := NewCommandWithOutput("sleep 5", 50*time.Millisecond, &bytes.Buffer{})
+		err := hook.Handle(context.Background(), event)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "timed out")
+	})
+}
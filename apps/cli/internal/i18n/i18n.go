@@ -0,0 +1,101 @@
+// Package i18n localizes CLI help text and user-facing messages. English is the source
+// language and lives in the code; locales/*.yaml translate it by message ID, and missing
+// translations fall back to the English text.
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+	"sync"
+
+	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultLanguage is the source language of all messages
+const DefaultLanguage = "en"
+
+// Languages are the supported CLI languages
+var Languages = []string{DefaultLanguage, "ja", "es"}
+
+//go:embed locales/*.yaml
+var locales embed.FS
+
+var (
+	bundle    = newBundle()
+	mu        sync.RWMutex
+	current   = DefaultLanguage
+	localizer = goi18n.NewLocalizer(bundle, DefaultLanguage)
+)
+
+func newBundle() *goi18n.Bundle {
+	b := goi18n.NewBundle(language.English)
+	b.RegisterUnmarshalFunc("yaml", yaml.Unmarshal)
+	for _, lang := range Languages[1:] {
+		// The catalogs are embedded, so a broken one is a build defect
+		if _, err := b.LoadMessageFileFS(locales, "locales/active."+lang+".yaml"); err != nil {
+			panic(err)
+		}
+	}
+	return b
+}
+
+// Normalize maps a language setting such as "ja", "ES" or "ja_JP.UTF-8" to a supported
+// language; ok is false when the language is not supported
+func Normalize(lang string) (string, bool) {
+	base := strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(base, "_-."); i >= 0 {
+		base = base[:i]
+	}
+	for _, supported := range Languages {
+		if base == supported {
+			return supported, true
+		}
+	}
+	return "", false
+}
+
+// SetLanguage selects the language of all following messages
+func SetLanguage(lang string) error {
+	normalized, ok := Normalize(lang)
+	if !ok {
+		return fmt.Errorf("unsupported language %q (supported: %s)", lang, strings.Join(Languages, ", "))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	current = normalized
+	localizer = goi18n.NewLocalizer(bundle, normalized)
+	return nil
+}
+
+// Language returns the selected language
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// T returns the message id in the selected language, or text (English) when it has no translation
+func T(id, text string) string {
+	return Tf(id, text, nil)
+}
+
+// Tf is T for messages with {{.Name}} placeholders filled from data
+func Tf(id, text string, data map[string]any) string {
+	mu.RLock()
+	l := localizer
+	mu.RUnlock()
+
+	msg, err := l.Localize(&goi18n.LocalizeConfig{
+		DefaultMessage: &goi18n.Message{ID: id, Other: text},
+		TemplateData:   data,
+	})
+	if err != nil {
+		// A broken translation must not hide the message
+		return text
+	}
+	return msg
+}
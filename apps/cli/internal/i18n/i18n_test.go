@@ -0,0 +1,70 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		lang   string
+		want   string
+		wantOK bool
+	}{
+		{lang: "ja", want: "ja", wantOK: true},
+		{lang: " ES ", want: "es", wantOK: true},
+		{lang: "ja_JP.UTF-8", want: "ja", wantOK: true},
+		{lang: "en-US", want: "en", wantOK: true},
+		{lang: "fr", wantOK: false},
+		{lang: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			got, ok := Normalize(tt.lang)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTf(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, SetLanguage(DefaultLanguage)) })
+
+	data := map[string]any{"Count": 3}
+	assert.Equal(t, "3 video(s) saved successfully:", Tf("msg.videos_saved", "{{.Count}} video(s) saved successfully:", data))
+
+	require.NoError(t, SetLanguage("ja_JP.UTF-8"))
+	assert.Equal(t, "ja", Language())
+	assert.Equal(t, "3 件の動画を保存しました:", Tf("msg.videos_saved", "{{.Count}} video(s) saved successfully:", data))
+
+	// Untranslated messages fall back to the English text
+	assert.Equal(t, "Not translated", T("msg.not_translated", "Not translated"))
+
+	assert.Error(t, SetLanguage("fr"))
+	assert.Equal(t, "ja", Language())
+}
+
+func TestCatalogsTranslateTheSameMessages(t *testing.T) {
+	ids := func(lang string) []string {
+		data, err := locales.ReadFile("locales/active." + lang + ".yaml")
+		require.NoError(t, err)
+		var messages map[string]string
+		require.NoError(t, yaml.Unmarshal(data, &messages))
+
+		var keys []string
+		for id := range messages {
+			keys = append(keys, id)
+		}
+		return keys
+	}
+
+	ja := ids("ja")
+	assert.NotEmpty(t, ja)
+	for _, lang := range Languages[2:] {
+		assert.ElementsMatch(t, ja, ids(lang), "active.%s.yaml", lang)
+	}
+}
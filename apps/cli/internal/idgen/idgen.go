@@ -0,0 +1,57 @@
+// Package idgen generates the UUIDs of records, so tests can predict the IDs services assign.
+package idgen
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+)
+
+// Generator generates record IDs
+type Generator interface {
+	NewID() string
+}
+
+// UUID generates random (version 4) UUIDs
+var UUID Generator = uuidGenerator{}
+
+type uuidGenerator struct{}
+
+func (uuidGenerator) NewID() string {
+	var b [16]byte
+	// crypto/rand.Read never returns an error
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return format(b)
+}
+
+// Sequence generates the UUIDs 00000000-0000-0000-0000-000000000001, ...02 and so on, in order
+// (for testing). It is safe for concurrent use.
+type Sequence struct {
+	mu   sync.Mutex
+	next uint64
+}
+
+// NewSequence creates a Sequence starting at ...01
+func NewSequence() *Sequence {
+	return &Sequence{next: 1}
+}
+
+// NewID returns the next UUID of the sequence
+func (s *Sequence) NewID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var b [16]byte
+	for i, n := 15, s.next; i >= 8 && n > 0; i, n = i-1, n>>8 {
+		b[i] = byte(n)
+	}
+	s.next++
+	return format(b)
+}
+
+// format writes b in the 8-4-4-4-12 form of UUIDs
+func format(b [16]byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,25 @@
+package idgen
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUUID(t *testing.T) {
+	v4 := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	first, second := UUID.NewID(), UUID.NewID()
+	assert.Regexp(t, v4, first)
+	assert.Regexp(t, v4, second)
+	assert.NotEqual(t, first, second)
+}
+
+func TestSequence(t *testing.T) {
+	ids := NewSequence()
+	assert.Equal(t, "00000000-0000-0000-0000-000000000001", ids.NewID())
+	assert.Equal(t, "00000000-0000-0000-0000-000000000002", ids.NewID())
+
+	ids.next = 0x1ff
+	assert.Equal(t, "00000000-0000-0000-0000-0000000001ff", ids.NewID())
+}
@@ -0,0 +1,121 @@
+package janitor
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cacheFile is one file considered for eviction
+type cacheFile struct {
+	path    string
+	size    int64
+	lastUse time.Time
+}
+
+// EnforceMaxSize deletes the least recently used files under dirs until their total size is
+// at most maxBytes. A file's last use is its modification time; caches refresh it on hits.
+func EnforceMaxSize(dirs []string, maxBytes int64) (*PruneResult, error) {
+	result := &PruneResult{}
+	if maxBytes <= 0 {
+		return result, nil
+	}
+
+	var files []cacheFile
+	var total int64
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			files = append(files, cacheFile{path: path, size: info.Size(), lastUse: info.ModTime()})
+			total += info.Size()
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan cache %s: %w", dir, err)
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].lastUse.Before(files[j].lastUse) })
+	for _, file := range files {
+		if total <= maxBytes {
+			break
+		}
+		if err := os.Remove(file.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return result, fmt.Errorf("failed to evict %s: %w", file.path, err)
+		}
+		total -= file.size
+		result.add(file.path, file.size)
+	}
+	return result, nil
+}
+
+// DirSize returns the total size of the files under dirs; missing directories are empty
+func DirSize(dirs []string) (int64, error) {
+	var total int64
+	for _, dir := range dirs {
+		size, err := diskUsage(dir)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return 0, fmt.Errorf("failed to measure %s: %w", dir, err)
+		}
+		total += size
+	}
+	return total, nil
+}
+
+// sizeUnits maps size suffixes to their multiplier
+var sizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"TB", 1 << 40}, {"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10},
+	{"T", 1 << 40}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10},
+	{"B", 1},
+}
+
+// ParseSize parses sizes such as "500MB", "10GB" or "1048576" (bytes). Units are binary
+// (1GB = 1024^3 bytes) and case-insensitive.
+func ParseSize(size string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(size))
+	multiplier := int64(1)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(s, unit.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix))
+			multiplier = unit.bytes
+			break
+		}
+	}
+
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid size %q (e.g. 500MB, 10GB)", size)
+	}
+	return int64(value * float64(multiplier)), nil
+}
+
+// FormatSize formats bytes with a binary unit, e.g. "1.5 GB"
+func FormatSize(bytes int64) string {
+	for _, unit := range sizeUnits[:4] {
+		if bytes >= unit.bytes {
+			return fmt.Sprintf("%.1f %s", float64(bytes)/float64(unit.bytes), unit.suffix)
+		}
+	}
+	return fmt.Sprintf("%d B", bytes)
+}
@@ -0,0 +1,89 @@
+package janitor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnforceMaxSize_EvictsLeastRecentlyUsed(t *testing.T) {
+	cacheDir := t.TempDir()
+	audioDir := t.TempDir()
+
+	now := time.Now()
+	files := []struct {
+		path string
+		used time.Time
+	}{
+		{filepath.Join(audioDir, "oldest.mp3"), now.Add(-3 * time.Hour)},
+		{filepath.Join(cacheDir, "older.bin"), now.Add(-2 * time.Hour)},
+		{filepath.Join(audioDir, "newest.mp3"), now.Add(-time.Hour)},
+	}
+	for _, file := range files {
+		require.NoError(t, os.WriteFile(file.path, make([]byte, 100), 0644))
+		require.NoError(t, os.Chtimes(file.path, file.used, file.used))
+	}
+
+	result, err := EnforceMaxSize([]string{cacheDir, audioDir, filepath.Join(cacheDir, "missing")}, 150)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{files[0].path, files[1].path}, result.Removed)
+	assert.Equal(t, int64(200), result.Bytes)
+	assert.FileExists(t, files[2].path)
+
+	// Within the limit nothing is evicted
+	result, err = EnforceMaxSize([]string{cacheDir, audioDir}, 150)
+	require.NoError(t, err)
+	assert.Empty(t, result.Removed)
+}
+
+func TestDirSize(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "vocab"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.bin"), make([]byte, 30), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "vocab", "b.json"), make([]byte, 12), 0644))
+
+	size, err := DirSize([]string{dir, filepath.Join(dir, "missing")})
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), size)
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+		wantErr  bool
+	}{
+		{"1048576", 1 << 20, false},
+		{"500MB", 500 << 20, false},
+		{"20GB", 20 << 30, false},
+		{"1.5g", 3 << 29, false},
+		{" 10 kb ", 10 << 10, false},
+		{"512B", 512, false},
+		{"", 0, true},
+		{"lots", 0, true},
+		{"-1GB", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSize(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	assert.Equal(t, "512 B", FormatSize(512))
+	assert.Equal(t, "1.5 KB", FormatSize(1536))
+	assert.Equal(t, "20.0 GB", FormatSize(20<<30))
+}
@@ -0,0 +1,203 @@
+package janitor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// AbandonAge is the age after which a temp directory or partial artifact is removed even if
+// the process that created it still seems to run (PIDs are reused). It is well above the
+// longest command timeout (12h transcriptions).
+const AbandonAge = 24 * time.Hour
+
+// partialArtifactPattern matches the temporary files artifact.LocalStore writes before renaming
+const partialArtifactPattern = ".artifact-*"
+
+var (
+	mu       sync.Mutex
+	stateDir string // Registrations are kept here; temp dirs are not tracked when empty
+)
+
+// SetStateDir makes MkdirTemp register temp directories in dir, one JSON file per directory,
+// so leftovers of crashed processes can be removed by PruneTemp
+func SetStateDir(dir string) {
+	mu.Lock()
+	defer mu.Unlock()
+	stateDir = dir
+}
+
+// registration records who created a temp directory
+type registration struct {
+	Path      string    `json:"path"`
+	PID       int       `json:"pid"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PruneResult lists what a prune removed
+type PruneResult struct {
+	Removed []string `json:"removed"`
+	Bytes   int64    `json:"bytes"` // Disk space freed
+}
+
+// add records a removed path
+func (r *PruneResult) add(path string, size int64) {
+	r.Removed = append(r.Removed, path)
+	r.Bytes += size
+}
+
+// MkdirTemp creates a directory like os.MkdirTemp("", pattern) and registers it. Remove it
+// with RemoveTemp. A failed registration only loses crash cleanup, so it is not an error.
+func MkdirTemp(pattern string) (string, error) {
+	dir, err := os.MkdirTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+
+	if regDir := currentStateDir(); regDir != "" {
+		reg := registration{Path: dir, PID: os.Getpid(), CreatedAt: time.Now()}
+		if err := writeRegistration(regDir, reg); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to register temp directory: %v\n", err)
+		}
+	}
+	return dir, nil
+}
+
+// RemoveTemp removes a directory created by MkdirTemp and its registration
+func RemoveTemp(dir string) error {
+	err := os.RemoveAll(dir)
+	if regDir := currentStateDir(); regDir != "" {
+		os.Remove(registrationPath(regDir, dir))
+	}
+	return err
+}
+
+// PruneTemp removes registered temp directories whose process is gone or that are older than
+// AbandonAge, together with their registrations
+func PruneTemp() (*PruneResult, error) {
+	result := &PruneResult{}
+	regDir := currentStateDir()
+	if regDir == "" {
+		return result, nil
+	}
+
+	entries, err := os.ReadDir(regDir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return result, nil
+		}
+		return nil, fmt.Errorf("failed to read temp registrations: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		regPath := filepath.Join(regDir, entry.Name())
+		data, err := os.ReadFile(regPath)
+		if err != nil {
+			continue
+		}
+
+		var reg registration
+		if err := json.Unmarshal(data, &reg); err != nil || reg.Path == "" {
+			// Unreadable registrations point at nothing we can clean up
+			os.Remove(regPath)
+			continue
+		}
+		if processAlive(reg.PID) && time.Since(reg.CreatedAt) < AbandonAge {
+			continue
+		}
+
+		// Directories removed by a finished process only leave their registration behind
+		if _, err := os.Stat(reg.Path); err == nil {
+			size, _ := diskUsage(reg.Path)
+			if err := os.RemoveAll(reg.Path); err != nil {
+				return result, fmt.Errorf("failed to remove %s: %w", reg.Path, err)
+			}
+			result.add(reg.Path, size)
+		}
+		os.Remove(regPath)
+	}
+	return result, nil
+}
+
+// PrunePartialArtifacts removes files left behind by artifact writes that never finished
+// (".artifact-*" older than AbandonAge) anywhere under dir
+func PrunePartialArtifacts(dir string) (*PruneResult, error) {
+	result := &PruneResult{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if matched, _ := filepath.Match(partialArtifactPattern, d.Name()); !matched {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || time.Since(info.ModTime()) < AbandonAge {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		result.add(path, info.Size())
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune partial artifacts: %w", err)
+	}
+	return result, nil
+}
+
+// currentStateDir returns the registration directory
+func currentStateDir() string {
+	mu.Lock()
+	defer mu.Unlock()
+	return stateDir
+}
+
+// registrationPath returns the registration file of a temp directory; MkdirTemp names are
+// unique within the temp root, so the base name identifies the directory
+func registrationPath(regDir, dir string) string {
+	return filepath.Join(regDir, filepath.Base(dir)+".json")
+}
+
+// writeRegistration stores reg in regDir
+func writeRegistration(regDir string, reg registration) error {
+	if err := os.MkdirAll(regDir, 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(reg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(registrationPath(regDir, reg.Path), data, 0644)
+}
+
+// diskUsage returns the total size of the files under path
+func diskUsage(path string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			if info, err := d.Info(); err == nil {
+				total += info.Size()
+			}
+		}
+		return nil
+	})
+	return total, err
+}
@@ -0,0 +1,111 @@
+package janitor
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useStateDir points the janitor at a fresh state directory for one test
+func useStateDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	SetStateDir(dir)
+	t.Cleanup(func() { SetStateDir("") })
+	return dir
+}
+
+func TestMkdirTemp_RegistersUntilRemoved(t *testing.T) {
+	stateDir := useStateDir(t)
+
+	dir, err := MkdirTemp("yt-lang-test-*")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	data, err := os.ReadFile(registrationPath(stateDir, dir))
+	require.NoError(t, err)
+	var reg registration
+	require.NoError(t, json.Unmarshal(data, &reg))
+	assert.Equal(t, dir, reg.Path)
+	assert.Equal(t, os.Getpid(), reg.PID)
+
+	require.NoError(t, RemoveTemp(dir))
+	assert.NoDirExists(t, dir)
+	assert.NoFileExists(t, registrationPath(stateDir, dir))
+}
+
+func TestPruneTemp(t *testing.T) {
+	stateDir := useStateDir(t)
+
+	// Owned by this process: kept
+	live, err := MkdirTemp("yt-lang-live-*")
+	require.NoError(t, err)
+	t.Cleanup(func() { RemoveTemp(live) })
+
+	// Owned by this process but older than AbandonAge: removed
+	abandoned, err := os.MkdirTemp("", "yt-lang-abandoned-*")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(abandoned, "audio.mp3"), make([]byte, 100), 0644))
+	require.NoError(t, writeRegistration(stateDir, registration{Path: abandoned, PID: os.Getpid(), CreatedAt: time.Now().Add(-2 * AbandonAge)}))
+
+	// Already removed by its process: only the registration goes
+	gone := filepath.Join(os.TempDir(), "yt-lang-gone-1")
+	require.NoError(t, writeRegistration(stateDir, registration{Path: gone, PID: os.Getpid(), CreatedAt: time.Now().Add(-2 * AbandonAge)}))
+
+	// Corrupt registration: dropped
+	require.NoError(t, os.WriteFile(filepath.Join(stateDir, "broken.json"), []byte("{"), 0644))
+
+	result, err := PruneTemp()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{abandoned}, result.Removed)
+	assert.Equal(t, int64(100), result.Bytes)
+	assert.NoDirExists(t, abandoned)
+	assert.DirExists(t, live)
+
+	entries, err := os.ReadDir(stateDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, filepath.Base(registrationPath(stateDir, live)), entries[0].Name())
+}
+
+func TestPruneTemp_WithoutStateDir(t *testing.T) {
+	SetStateDir("")
+
+	result, err := PruneTemp()
+	require.NoError(t, err)
+	assert.Empty(t, result.Removed)
+}
+
+func TestPrunePartialArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "audio"), 0755))
+
+	stale := filepath.Join(dir, "audio", ".artifact-123")
+	fresh := filepath.Join(dir, "audio", ".artifact-456")
+	complete := filepath.Join(dir, "audio", "vid.mp3")
+	for _, path := range []string{stale, fresh, complete} {
+		require.NoError(t, os.WriteFile(path, make([]byte, 10), 0644))
+	}
+	old := time.Now().Add(-2 * AbandonAge)
+	require.NoError(t, os.Chtimes(stale, old, old))
+	require.NoError(t, os.Chtimes(complete, old, old))
+
+	result, err := PrunePartialArtifacts(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{stale}, result.Removed)
+	assert.NoFileExists(t, stale)
+	assert.FileExists(t, fresh)
+	assert.FileExists(t, complete)
+
+	// A missing directory has nothing to prune
+	result, err = PrunePartialArtifacts(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, result.Removed)
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package janitor
+
+// processAlive cannot check processes on this platform; registrations are pruned by age only
+func processAlive(pid int) bool {
+	return pid > 0
+}
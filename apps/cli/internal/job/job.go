@@ -0,0 +1,257 @@
+// Package job keeps the work of long-running jobs in a workspace directory per job
+// (~/.yt-lang/work/JOB_ID): the files each step produces and a manifest marking the steps that
+// completed. A job interrupted by a crash or Ctrl-C finds its workspace again on restart and
+// resumes after its last completed step instead of starting over.
+package job
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/clock"
+	"github.com/Taichi-iskw/yt-lang/internal/janitor"
+)
+
+// manifestFile is the name of the manifest in each workspace
+const manifestFile = "job.json"
+
+// idPattern matches job IDs, which name directories
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Manifest records the progress of a job
+type Manifest struct {
+	ID         string     `json:"id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	Steps      []Step     `json:"steps,omitempty"` // Completed steps, in order
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// Step is a completed step of a job
+type Step struct {
+	Name        string    `json:"name"`
+	Value       string    `json:"value,omitempty"` // What the step recorded, e.g. the file it produced
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// LastStep returns the name of the last completed step; empty when none completed
+func (m *Manifest) LastStep() string {
+	if len(m.Steps) == 0 {
+		return ""
+	}
+	return m.Steps[len(m.Steps)-1].Name
+}
+
+// Workspaces manages the job workspaces under a root directory
+type Workspaces struct {
+	root  string
+	clock clock.Clock
+}
+
+// NewWorkspaces creates Workspaces keeping each job in a directory under root
+func NewWorkspaces(root string) *Workspaces {
+	return NewWorkspacesWithClock(root, clock.System)
+}
+
+// NewWorkspacesWithClock creates Workspaces with a custom clock (for testing)
+func NewWorkspacesWithClock(root string, clk clock.Clock) *Workspaces {
+	return &Workspaces{root: root, clock: clk}
+}
+
+// Workspace is the directory of one job
+type Workspace struct {
+	Manifest
+	Dir string
+
+	clock clock.Clock
+}
+
+// Open returns the workspace of a job, creating it on the first run. A workspace whose job
+// finished, or whose manifest cannot be read, is started afresh.
+func (w *Workspaces) Open(id string) (*Workspace, error) {
+	if !idPattern.MatchString(id) {
+		return nil, fmt.Errorf("invalid job ID %q", id)
+	}
+
+	ws := &Workspace{Dir: filepath.Join(w.root, id), clock: w.clock}
+	if err := ws.load(); err == nil && !ws.Finished() {
+		return ws, nil
+	}
+
+	if err := os.RemoveAll(ws.Dir); err != nil {
+		return nil, fmt.Errorf("failed to clear job workspace: %w", err)
+	}
+	if err := os.MkdirAll(ws.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create job workspace: %w", err)
+	}
+	now := w.clock.Now().UTC()
+	ws.Manifest = Manifest{ID: id, CreatedAt: now, UpdatedAt: now}
+	if err := ws.save(); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+// List returns the workspaces under the root, oldest first
+func (w *Workspaces) List() ([]*Workspace, error) {
+	entries, err := os.ReadDir(w.root)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list job workspaces: %w", err)
+	}
+
+	var workspaces []*Workspace
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		ws := &Workspace{Dir: filepath.Join(w.root, entry.Name()), clock: w.clock}
+		if err := ws.load(); err != nil {
+			// A directory without a readable manifest is a workspace that crashed while being
+			// created; it has nothing to resume
+			ws.Manifest = Manifest{ID: entry.Name()}
+		}
+		workspaces = append(workspaces, ws)
+	}
+	sort.Slice(workspaces, func(i, j int) bool {
+		return workspaces[i].CreatedAt.Before(workspaces[j].CreatedAt)
+	})
+	return workspaces, nil
+}
+
+// Clean removes the workspaces of finished jobs and those without a manifest; with all, the
+// workspaces of unfinished jobs too, which then start over when run again
+func (w *Workspaces) Clean(all bool) (*janitor.PruneResult, error) {
+	workspaces, err := w.List()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &janitor.PruneResult{}
+	for _, ws := range workspaces {
+		if !all && !ws.Finished() && !ws.CreatedAt.IsZero() {
+			continue
+		}
+		size, _ := ws.Size()
+		if err := os.RemoveAll(ws.Dir); err != nil {
+			return result, fmt.Errorf("failed to remove %s: %w", ws.Dir, err)
+		}
+		result.Removed = append(result.Removed, ws.Dir)
+		result.Bytes += size
+	}
+	return result, nil
+}
+
+// Path returns the path of a file in the workspace
+func (ws *Workspace) Path(name string) string {
+	return filepath.Join(ws.Dir, name)
+}
+
+// Step returns what a completed step recorded; ok is false when the step has not completed
+func (ws *Workspace) Step(name string) (value string, ok bool) {
+	for _, step := range ws.Steps {
+		if step.Name == name {
+			return step.Value, true
+		}
+	}
+	return "", false
+}
+
+// Complete marks a step completed, recording value (e.g. the file it produced, relative to the
+// workspace). Call it only once what the step produced is fully written.
+func (ws *Workspace) Complete(name, value string) error {
+	now := ws.clock.Now().UTC()
+	var steps []Step
+	for _, step := range ws.Steps {
+		if step.Name != name {
+			steps = append(steps, step)
+		}
+	}
+	ws.Steps = append(steps, Step{Name: name, Value: value, CompletedAt: now})
+	ws.UpdatedAt = now
+	return ws.save()
+}
+
+// Finish marks the job finished and removes the files its steps produced, keeping only the
+// manifest; jobs clean removes the rest and the next run of the job starts afresh
+func (ws *Workspace) Finish() error {
+	now := ws.clock.Now().UTC()
+	ws.UpdatedAt, ws.FinishedAt = now, &now
+	if err := ws.save(); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(ws.Dir)
+	if err != nil {
+		return fmt.Errorf("failed to list job workspace: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.Name() == manifestFile {
+			continue
+		}
+		if err := os.RemoveAll(ws.Path(entry.Name())); err != nil {
+			return fmt.Errorf("failed to remove job files: %w", err)
+		}
+	}
+	return nil
+}
+
+// Finished reports whether the job finished
+func (ws *Workspace) Finished() bool {
+	return ws.FinishedAt != nil
+}
+
+// Size returns the disk space the workspace takes
+func (ws *Workspace) Size() (int64, error) {
+	var total int64
+	err := filepath.WalkDir(ws.Dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			if info, err := d.Info(); err == nil {
+				total += info.Size()
+			}
+		}
+		return nil
+	})
+	return total, err
+}
+
+// load reads the manifest
+func (ws *Workspace) load() error {
+	data, err := os.ReadFile(ws.Path(manifestFile))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &ws.Manifest); err != nil {
+		return fmt.Errorf("failed to parse job manifest %s: %w", ws.Path(manifestFile), err)
+	}
+	return nil
+}
+
+// save writes the manifest to a temporary file and renames it, so a crash never leaves a
+// half-written manifest
+func (ws *Workspace) save() error {
+	data, err := json.MarshalIndent(ws.Manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode job manifest: %w", err)
+	}
+	tmp := ws.Path(manifestFile + ".tmp")
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write job manifest: %w", err)
+	}
+	if err := os.Rename(tmp, ws.Path(manifestFile)); err != nil {
+		return fmt.Errorf("failed to write job manifest: %w", err)
+	}
+	return nil
+}
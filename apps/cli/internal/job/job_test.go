@@ -0,0 +1,92 @@
+package job
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/clock"
+)
+
+func TestWorkspaces_ResumeAfterRestart(t *testing.T) {
+	root := t.TempDir()
+	started := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(started)
+
+	ws, err := NewWorkspacesWithClock(root, clk).Open("transcribe-abc123-en")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(ws.Path("audio.m4a"), make([]byte, 100), 0644))
+	clk.Advance(time.Minute)
+	require.NoError(t, ws.Complete("audio", "audio.m4a"))
+
+	// A new process finds the completed step and its file
+	resumed, err := NewWorkspaces(root).Open("transcribe-abc123-en")
+	require.NoError(t, err)
+	audio, ok := resumed.Step("audio")
+	assert.True(t, ok)
+	assert.Equal(t, "audio.m4a", audio)
+	assert.FileExists(t, resumed.Path(audio))
+	_, ok = resumed.Step("whisper")
+	assert.False(t, ok)
+	assert.Equal(t, started, resumed.CreatedAt)
+	assert.Equal(t, started.Add(time.Minute), resumed.UpdatedAt)
+	assert.Equal(t, "audio", resumed.LastStep())
+
+	// Finishing removes what the steps produced and keeps the manifest
+	require.NoError(t, resumed.Finish())
+	assert.NoFileExists(t, resumed.Path("audio.m4a"))
+	assert.FileExists(t, resumed.Path(manifestFile))
+	listed, err := NewWorkspaces(root).List()
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.True(t, listed[0].Finished())
+
+	// Once finished, the next run of the job starts afresh
+	fresh, err := NewWorkspaces(root).Open("transcribe-abc123-en")
+	require.NoError(t, err)
+	assert.Empty(t, fresh.Steps)
+	assert.Empty(t, fresh.LastStep())
+	assert.False(t, fresh.Finished())
+	assert.NoFileExists(t, fresh.Path("audio.m4a"))
+}
+
+func TestWorkspaces_Open_InvalidID(t *testing.T) {
+	for _, id := range []string{"", "../escape", "a/b", ".hidden"} {
+		_, err := NewWorkspaces(t.TempDir()).Open(id)
+		assert.Error(t, err, id)
+	}
+}
+
+func TestWorkspaces_Clean(t *testing.T) {
+	root := t.TempDir()
+	workspaces := NewWorkspaces(root)
+
+	finished, err := workspaces.Open("finished")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(finished.Path("audio.m4a"), make([]byte, 100), 0644))
+	require.NoError(t, finished.Finish())
+
+	unfinished, err := workspaces.Open("unfinished")
+	require.NoError(t, err)
+
+	// Crashed before writing its manifest: nothing to resume
+	require.NoError(t, os.Mkdir(root+"/broken", 0755))
+
+	listed, err := workspaces.List()
+	require.NoError(t, err)
+	assert.Len(t, listed, 3)
+
+	result, err := workspaces.Clean(false)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{finished.Dir, root + "/broken"}, result.Removed)
+	assert.Greater(t, result.Bytes, int64(100))
+	assert.DirExists(t, unfinished.Dir)
+
+	result, err = workspaces.Clean(true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{unfinished.Dir}, result.Removed)
+	assert.NoDirExists(t, unfinished.Dir)
+}
@@ -0,0 +1,170 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrLocked is returned when another process holds the lock for a resource
+var ErrLocked = errors.New("resource is locked by another process")
+
+const unlockTimeout = 5 * time.Second // Unlock runs on its own context so cancelled jobs still release
+
+// Locker acquires exclusive job locks keyed by resource
+type Locker interface {
+	// TryLock acquires the lock for key without waiting. It returns an error wrapping
+	// ErrLocked if another process already holds it.
+	TryLock(ctx context.Context, key string) (Lock, error)
+}
+
+// Lock is a held job lock
+type Lock interface {
+	Unlock() error
+}
+
+// Key builds a resource key such as "translation:<id>:ja" from its parts
+func Key(parts ...string) string {
+	return strings.Join(parts, ":")
+}
+
+// WithLock runs fn while holding the lock for key
+func WithLock(ctx context.Context, locker Locker, key string, fn func() error) error {
+	l, err := locker.TryLock(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer l.Unlock()
+
+	return fn()
+}
+
+// Conn is a dedicated database session; advisory locks belong to the session that took them
+type Conn interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Release()
+	Discard()
+}
+
+// postgresLocker implements Locker with PostgreSQL session advisory locks
+type postgresLocker struct {
+	acquire func(ctx context.Context) (Conn, error)
+}
+
+// NewPostgresLocker creates a Locker that takes pg_advisory_lock style locks on connections
+// from pool. Each held lock keeps one pool connection until it is unlocked.
+func NewPostgresLocker(pool *pgxpool.Pool) Locker {
+	return NewPostgresLockerWithAcquirer(func(ctx context.Context) (Conn, error) {
+		conn, err := pool.Acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &poolConn{conn}, nil
+	})
+}
+
+// NewPostgresLockerWithAcquirer creates a Locker using a custom session source (for testing)
+func NewPostgresLockerWithAcquirer(acquire func(ctx context.Context) (Conn, error)) Locker {
+	return &postgresLocker{acquire: acquire}
+}
+
+// TryLock takes pg_try_advisory_lock on a dedicated session
+func (l *postgresLocker) TryLock(ctx context.Context, key string) (Lock, error) {
+	conn, err := l.acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire database connection for lock %s: %w", key, err)
+	}
+
+	id := advisoryKey(key)
+	var acquired bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", id).Scan(&acquired); err != nil {
+		conn.Discard()
+		return nil, fmt.Errorf("failed to acquire lock %s: %w", key, err)
+	}
+	if !acquired {
+		conn.Release()
+		return nil, fmt.Errorf("%w: %s", ErrLocked, key)
+	}
+
+	return &postgresLock{conn: conn, key: key, id: id}, nil
+}
+
+// postgresLock is a held advisory lock and the session that owns it
+type postgresLock struct {
+	conn Conn
+	key  string
+	id   int64
+}
+
+// Unlock releases the advisory lock. If the unlock query fails the session is closed
+// instead of being returned to the pool, which releases the lock as well.
+func (l *postgresLock) Unlock() error {
+	if l.conn == nil {
+		return nil
+	}
+	conn := l.conn
+	l.conn = nil
+
+	ctx, cancel := context.WithTimeout(context.Background(), unlockTimeout)
+	defer cancel()
+
+	var released bool
+	if err := conn.QueryRow(ctx, "SELECT pg_advisory_unlock($1)", l.id).Scan(&released); err != nil {
+		conn.Discard()
+		return fmt.Errorf("failed to release lock %s: %w", l.key, err)
+	}
+	conn.Release()
+
+	if !released {
+		return fmt.Errorf("lock %s was not held", l.key)
+	}
+	return nil
+}
+
+// advisoryKey maps a resource key to the bigint key space of advisory locks
+func advisoryKey(key string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return int64(h.Sum64())
+}
+
+// poolConn adapts a pooled connection to Conn
+type poolConn struct {
+	*pgxpool.Conn
+}
+
+// Discard closes the underlying connection so the pool drops it, ending the session
+func (c *poolConn) Discard() {
+	ctx, cancel := context.WithTimeout(context.Background(), unlockTimeout)
+	defer cancel()
+
+	c.Conn.Conn().Close(ctx)
+	c.Conn.Release()
+}
+
+// noopLocker implements Locker without any locking
+type noopLocker struct{}
+
+// NewNoopLocker creates a Locker that always succeeds (single-process use and tests)
+func NewNoopLocker() Locker {
+	return noopLocker{}
+}
+
+// TryLock always succeeds
+func (noopLocker) TryLock(ctx context.Context, key string) (Lock, error) {
+	return noopLock{}, nil
+}
+
+// noopLock is the Lock returned by noopLocker
+type noopLock struct{}
+
+// Unlock does nothing
+func (noopLock) Unlock() error {
+	return nil
+}
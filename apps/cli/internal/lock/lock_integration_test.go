@@ -0,0 +1,40 @@
+//go:build integration
+
+package lock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Taichi-iskw/yt-lang/internal/repository/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPostgresLocker_Integration tests advisory locks across sessions with real PostgreSQL
+func TestPostgresLocker_Integration(t *testing.T) {
+	pool := common.SetupTestDB(t)
+	ctx := context.Background()
+
+	// Two lockers on the same pool behave like two processes: each lock owns a session
+	first := NewPostgresLocker(pool)
+	second := NewPostgresLocker(pool)
+
+	held, err := first.TryLock(ctx, "sync:UC123")
+	require.NoError(t, err)
+
+	_, err = second.TryLock(ctx, "sync:UC123")
+	assert.True(t, errors.Is(err, ErrLocked))
+
+	// Other resources are independent
+	other, err := second.TryLock(ctx, "sync:UC456")
+	require.NoError(t, err)
+	require.NoError(t, other.Unlock())
+
+	require.NoError(t, held.Unlock())
+
+	again, err := second.TryLock(ctx, "sync:UC123")
+	require.NoError(t, err)
+	require.NoError(t, again.Unlock())
+}
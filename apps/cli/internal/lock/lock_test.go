@@ -0,0 +1,128 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockConn adapts a pgxmock pool to Conn and records how the session ended
+type mockConn struct {
+	pgxmock.PgxPoolIface
+	released  bool
+	discarded bool
+}
+
+func (c *mockConn) Release() { c.released = true }
+func (c *mockConn) Discard() { c.discarded = true }
+
+func newMockLocker(t *testing.T) (Locker, pgxmock.PgxPoolIface, *mockConn) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	t.Cleanup(mock.Close)
+
+	conn := &mockConn{PgxPoolIface: mock}
+	locker := NewPostgresLockerWithAcquirer(func(ctx context.Context) (Conn, error) {
+		return conn, nil
+	})
+	return locker, mock, conn
+}
+
+func TestPostgresLocker_TryLock(t *testing.T) {
+	key := Key("translation", "trans-1", "ja")
+	id := advisoryKey(key)
+
+	t.Run("acquire and unlock", func(t *testing.T) {
+		locker, mock, conn := newMockLocker(t)
+		mock.ExpectQuery(`SELECT pg_try_advisory_lock\(\$1\)`).WithArgs(id).
+			WillReturnRows(pgxmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(true))
+		mock.ExpectQuery(`SELECT pg_advisory_unlock\(\$1\)`).WithArgs(id).
+			WillReturnRows(pgxmock.NewRows([]string{"pg_advisory_unlock"}).AddRow(true))
+
+		l, err := locker.TryLock(context.Background(), key)
+		require.NoError(t, err)
+		assert.False(t, conn.released, "session must be held while locked")
+
+		require.NoError(t, l.Unlock())
+		assert.True(t, conn.released)
+		assert.False(t, conn.discarded)
+		require.NoError(t, l.Unlock(), "second unlock is a no-op")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("held by another process", func(t *testing.T) {
+		locker, mock, conn := newMockLocker(t)
+		mock.ExpectQuery(`SELECT pg_try_advisory_lock\(\$1\)`).WithArgs(id).
+			WillReturnRows(pgxmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(false))
+
+		_, err := locker.TryLock(context.Background(), key)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrLocked))
+		assert.Contains(t, err.Error(), key)
+		assert.True(t, conn.released)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("failed unlock discards session", func(t *testing.T) {
+		locker, mock, conn := newMockLocker(t)
+		mock.ExpectQuery(`SELECT pg_try_advisory_lock\(\$1\)`).WithArgs(id).
+			WillReturnRows(pgxmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(true))
+		mock.ExpectQuery(`SELECT pg_advisory_unlock\(\$1\)`).WithArgs(id).
+			WillReturnError(errors.New("connection reset"))
+
+		l, err := locker.TryLock(context.Background(), key)
+		require.NoError(t, err)
+
+		err = l.Unlock()
+		require.Error(t, err)
+		assert.True(t, conn.discarded)
+		assert.False(t, conn.released)
+	})
+
+	t.Run("acquire connection fails", func(t *testing.T) {
+		locker := NewPostgresLockerWithAcquirer(func(ctx context.Context) (Conn, error) {
+			return nil, errors.New("pool closed")
+		})
+
+		_, err := locker.TryLock(context.Background(), key)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "pool closed")
+		assert.False(t, errors.Is(err, ErrLocked))
+	})
+}
+
+func TestWithLock(t *testing.T) {
+	t.Run("runs fn while locked", func(t *testing.T) {
+		called := false
+		err := WithLock(context.Background(), NewNoopLocker(), "sync:UC1", func() error {
+			called = true
+			return nil
+		})
+		require.NoError(t, err)
+		assert.True(t, called)
+	})
+
+	t.Run("skips fn when locked", func(t *testing.T) {
+		locker, mock, _ := newMockLocker(t)
+		mock.ExpectQuery(`SELECT pg_try_advisory_lock`).WithArgs(advisoryKey("sync:UC1")).
+			WillReturnRows(pgxmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(false))
+
+		called := false
+		err := WithLock(context.Background(), locker, "sync:UC1", func() error {
+			called = true
+			return nil
+		})
+		assert.True(t, errors.Is(err, ErrLocked))
+		assert.False(t, called)
+	})
+}
+
+func TestAdvisoryKey(t *testing.T) {
+	assert.Equal(t, advisoryKey("sync:UC1"), advisoryKey("sync:UC1"))
+	assert.NotEqual(t, advisoryKey("sync:UC1"), advisoryKey("sync:UC2"))
+	assert.Equal(t, "translation:trans-1:ja", Key("translation", "trans-1", "ja"))
+}
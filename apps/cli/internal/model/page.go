@@ -0,0 +1,50 @@
+package model
+
+import "fmt"
+
+// Page describes one page of a paginated listing
+type Page struct {
+	Total      int  `json:"total"`       // Rows across all pages
+	Limit      int  `json:"limit"`       // Page size
+	Offset     int  `json:"offset"`      // Rows skipped before this page
+	NextOffset *int `json:"next_offset"` // Offset of the next page; nil on the last page
+}
+
+// NewPage describes the page starting at offset of a listing with total rows
+func NewPage(total, limit, offset int) Page {
+	page := Page{Total: total, Limit: limit, Offset: offset}
+	if limit > 0 && offset+limit < total {
+		next := offset + limit
+		page.NextOffset = &next
+	}
+	return page
+}
+
+// Number returns the 1-based page number
+func (p Page) Number() int {
+	if p.Limit <= 0 {
+		return 1
+	}
+	return p.Offset/p.Limit + 1
+}
+
+// Pages returns the number of pages (at least 1)
+func (p Page) Pages() int {
+	if p.Limit <= 0 || p.Total == 0 {
+		return 1
+	}
+	return (p.Total + p.Limit - 1) / p.Limit
+}
+
+// Footer summarizes the page for table output, e.g.
+// "Showing 11-20 of 170 (page 2 of 17); next page: --offset 20"
+func (p Page) Footer(shown int) string {
+	if shown == 0 {
+		return fmt.Sprintf("Showing 0 of %d", p.Total)
+	}
+	footer := fmt.Sprintf("Showing %d-%d of %d (page %d of %d)", p.Offset+1, p.Offset+shown, p.Total, p.Number(), p.Pages())
+	if p.NextOffset != nil {
+		footer += fmt.Sprintf("; next page: --offset %d", *p.NextOffset)
+	}
+	return footer
+}
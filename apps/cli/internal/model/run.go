@@ -0,0 +1,67 @@
+package model
+
+import "time"
+
+// Run and run item statuses
+const (
+	RunStatusPending   = "pending" // Items only
+	RunStatusRunning   = "running"
+	RunStatusCompleted = "completed"
+	RunStatusFailed    = "failed"
+)
+
+// RunCommandCreateBatch is the command of transcription create-batch runs, which it can resume
+const RunCommandCreateBatch = "transcription create-batch"
+
+// RunCommandTranslateChapters is the command of translation create --per-chapter runs, one item
+// per chapter, which translation create --resume can resume
+const RunCommandTranslateChapters = "translation create --per-chapter"
+
+// Run is the manifest of a batch command invocation: its items and the outcome of each
+type Run struct {
+	ID         string            `json:"id" db:"id"`
+	Command    string            `json:"command" db:"command"` // e.g. "transcription create-batch"
+	Target     string            `json:"target" db:"target"`   // What the run processes, e.g. a channel ID
+	Options    map[string]string `json:"options,omitempty" db:"options"`
+	Status     string            `json:"status" db:"status"`
+	CreatedAt  time.Time         `json:"created_at" db:"created_at"`
+	FinishedAt *time.Time        `json:"finished_at,omitempty" db:"finished_at"`
+
+	// Item counts, filled when runs are listed or fetched
+	Total     int `json:"total" db:"-"`
+	Completed int `json:"completed" db:"-"`
+	Failed    int `json:"failed" db:"-"`
+
+	Items []*RunItem `json:"items,omitempty" db:"-"` // Filled when a single run is fetched
+}
+
+// Unfinished returns the items that did not complete: failed, interrupted and never started ones
+func (r *Run) Unfinished() []*RunItem {
+	var items []*RunItem
+	for _, item := range r.Items {
+		if item.Status != RunStatusCompleted {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// RunItem is one item of a run, e.g. a video of a batch transcription
+type RunItem struct {
+	Position     int        `json:"position" db:"position"`
+	ItemID       string     `json:"item_id" db:"item_id"`
+	Title        string     `json:"title,omitempty" db:"title"`
+	Status       string     `json:"status" db:"status"`
+	ResultID     *string    `json:"result_id,omitempty" db:"result_id"` // What the item produced, e.g. a transcription ID
+	ErrorMessage *string    `json:"error_message,omitempty" db:"error_message"`
+	StartedAt    *time.Time `json:"started_at,omitempty" db:"started_at"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty" db:"finished_at"`
+}
+
+// Duration returns how long the item took; 0 when it has not finished
+func (i *RunItem) Duration() time.Duration {
+	if i.StartedAt == nil || i.FinishedAt == nil {
+		return 0
+	}
+	return i.FinishedAt.Sub(*i.StartedAt)
+}
@@ -0,0 +1,41 @@
+package model
+
+// Schema describes the tables of the database, for tools generating views of the data
+type Schema struct {
+	Tables []*SchemaTable `json:"tables"`
+}
+
+// SchemaTable is a table with its columns and keys
+type SchemaTable struct {
+	Name        string              `json:"name"`
+	Columns     []*SchemaColumn     `json:"columns"`
+	PrimaryKey  []string            `json:"primary_key"`
+	ForeignKeys []*SchemaForeignKey `json:"foreign_keys"`
+}
+
+// SchemaColumn is a column of a table, in table order
+type SchemaColumn struct {
+	Name     string  `json:"name"`
+	Type     string  `json:"type"` // As PostgreSQL prints it, e.g. "character varying(255)"
+	Nullable bool    `json:"nullable"`
+	Default  *string `json:"default,omitempty"` // Default expression, e.g. "now()"
+}
+
+// SchemaForeignKey is a relationship from columns of a table to columns of another
+type SchemaForeignKey struct {
+	Name              string   `json:"name"`
+	Columns           []string `json:"columns"`
+	ReferencedTable   string   `json:"referenced_table"`
+	ReferencedColumns []string `json:"referenced_columns"`
+	OnDelete          string   `json:"on_delete"` // NO ACTION, RESTRICT, CASCADE, SET NULL or SET DEFAULT
+}
+
+// Table returns the table with the given name, or nil
+func (s *Schema) Table(name string) *SchemaTable {
+	for _, table := range s.Tables {
+		if table.Name == name {
+			return table
+		}
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package model
+
+// LibraryStats summarizes everything saved in the active workspace
+type LibraryStats struct {
+	Workspace string `json:"workspace"`
+
+	Channels          int     `json:"channels"`
+	Videos            int     `json:"videos"`
+	UnavailableVideos int     `json:"unavailable_videos"` // Marked unavailable by video verify
+	VideoHours        float64 `json:"video_hours"`        // Total duration of all videos
+
+	Transcriptions          int     `json:"transcriptions"` // Transcriptions in any status
+	CompletedTranscriptions int     `json:"completed_transcriptions"`
+	FailedTranscriptions    int     `json:"failed_transcriptions"`
+	PendingTranscriptions   int     `json:"pending_transcriptions"` // Pending or processing
+	TranscribedHours        float64 `json:"transcribed_hours"`      // Audio covered by completed transcriptions
+
+	Translations []*LanguageStats `json:"translations"` // Translated segments per target language, most first
+
+	CacheBytes int64 `json:"cache_bytes"` // Size of the local caches
+}
+
+// LanguageStats counts the translated segments of one target language
+type LanguageStats struct {
+	Language string `json:"language"`
+	Segments int    `json:"segments"`
+	Approved int    `json:"approved"` // Segments reviewed by a person
+}
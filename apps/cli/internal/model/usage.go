@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// CommandUsage is one recorded run of a command
+type CommandUsage struct {
+	Command   string        `json:"command"` // e.g. "transcription create"
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration"`
+	Succeeded bool          `json:"succeeded"`
+	ErrorCode *string       `json:"error_code,omitempty"` // Code of an application error; nil on success or for other errors
+}
+
+// CommandUsageStats sums up the recorded runs of one command
+type CommandUsageStats struct {
+	Command        string    `json:"command"`
+	Runs           int       `json:"runs"`
+	Failed         int       `json:"failed"`
+	TotalSeconds   float64   `json:"total_seconds"`
+	MedianSeconds  float64   `json:"median_seconds"`
+	LongestSeconds float64   `json:"longest_seconds"`
+	LastRunAt      time.Time `json:"last_run_at"`
+}
@@ -1,12 +1,27 @@
 package model
 
-import "time"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+)
 
 // WhisperResult represents the JSON output from Whisper CLI
 type WhisperResult struct {
 	Text     string           `json:"text"`
 	Segments []WhisperSegment `json:"segments"`
 	Language string           `json:"language"`
+
+	// Language detection scores, reported by whisper builds that include them: the probability
+	// of every language, or only that of the detected one
+	LanguageProbs       map[string]float64 `json:"language_probs,omitempty"`
+	LanguageProbability float64            `json:"language_probability,omitempty"`
+
+	Raw []byte `json:"-"` // Original whisper JSON output, kept as an artifact for reprocessing
+
+	Attempts []WhisperAttempt `json:"-"` // Runs of the fallback ladder; empty when fallback is disabled
 }
 
 // WhisperSegment represents individual segment from Whisper output
@@ -15,7 +30,31 @@ type WhisperSegment struct {
 	Start      float64 `json:"start"`
 	End        float64 `json:"end"`
 	Text       string  `json:"text"`
-	Confidence float64 `json:"avg_logprob"` // Whisper uses avg_logprob for confidence
+	Confidence float64 `json:"avg_logprob"`       // Whisper uses avg_logprob for confidence
+	Speaker    string  `json:"speaker,omitempty"` // Set by diarizing transcribers such as whisperX
+}
+
+// LanguageCandidates returns up to n detected languages, most probable first. It is empty
+// when whisper reported no detection scores.
+func (r *WhisperResult) LanguageCandidates(n int) []LanguageCandidate {
+	var candidates []LanguageCandidate
+	for language, probability := range r.LanguageProbs {
+		candidates = append(candidates, LanguageCandidate{Language: language, Probability: probability})
+	}
+	if len(candidates) == 0 && r.Language != "" && r.LanguageProbability > 0 {
+		candidates = append(candidates, LanguageCandidate{Language: r.Language, Probability: r.LanguageProbability})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Probability != candidates[j].Probability {
+			return candidates[i].Probability > candidates[j].Probability
+		}
+		return candidates[i].Language < candidates[j].Language
+	})
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+	return candidates
 }
 
 // Channel represents YouTube channel information
@@ -23,17 +62,104 @@ type Channel struct {
 	ID   string `json:"id" db:"id"`
 	Name string `json:"name" db:"name"`
 	URL  string `json:"url" db:"url"`
+
+	// Version is the row version (PostgreSQL xmin) the channel was read at. Update fails with a
+	// conflict when the row changed since; zero skips the check.
+	Version int64 `json:"-" db:"-"`
 }
 
+// ChannelSchedule is when the watch daemon syncs a channel
+type ChannelSchedule struct {
+	ChannelID   string     `json:"channel_id" db:"channel_id"`
+	ChannelName string     `json:"channel_name" db:"-"`
+	Spec        string     `json:"spec" db:"spec"` // "@every 12h" or a cron expression such as "0 6 * * *"
+	LastRunAt   *time.Time `json:"last_run_at,omitempty" db:"last_run_at"`
+	LastError   *string    `json:"last_error,omitempty" db:"last_error"` // Why the last sync failed; nil when it succeeded
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+
+	// LastSuccessAt is the start of the last successful sync, kept when later syncs fail; nil until
+	// a sync succeeds
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty" db:"last_success_at"`
+}
+
+// WatchHeartbeat is the last sign of life of a watch run daemon, recorded after every check for
+// due channels
+type WatchHeartbeat struct {
+	Host       string    `json:"host" db:"host"`
+	PID        int       `json:"pid" db:"pid"`
+	StartedAt  time.Time `json:"started_at" db:"started_at"`
+	LastLoopAt time.Time `json:"last_loop_at" db:"last_loop_at"` // When the last check started
+	QueueDepth int       `json:"queue_depth" db:"queue_depth"`   // Channels due at the start of the last check
+}
+
+// Video availability statuses
+const (
+	VideoStatusAvailable   = "available"
+	VideoStatusUnavailable = "unavailable" // Deleted or privated on YouTube
+)
+
 // Video represents YouTube video information
 type Video struct {
-	ID        string  `json:"id" db:"id"`
-	ChannelID string  `json:"channel_id" db:"channel_id"`
-	Title     string  `json:"title" db:"title"`
-	URL       string  `json:"url" db:"url"`
-	Duration  float64 `json:"duration" db:"duration"`
+	ID         string     `json:"id" db:"id"`
+	ChannelID  string     `json:"channel_id" db:"channel_id"`
+	Title      string     `json:"title" db:"title"`
+	URL        string     `json:"url" db:"url"`
+	Duration   float64    `json:"duration" db:"duration"`
+	Status     string     `json:"status,omitempty" db:"status"`
+	UploadDate *time.Time `json:"upload_date,omitempty" db:"upload_date"` // Publication date (UTC midnight); nil when unknown
+	Rating     *int       `json:"rating,omitempty" db:"rating"`           // 1-5 stars given with video annotate; nil when unrated
+	Note       *string    `json:"note,omitempty" db:"note"`               // Free-form note given with video annotate
+
+	// Version is the row version (PostgreSQL xmin) the video was read at. Update fails with a
+	// conflict when the row changed since; zero skips the check.
+	Version int64 `json:"-" db:"-"`
+}
+
+// IsAvailable reports whether the video was still available at its last check
+// (videos that were never checked count as available)
+func (v *Video) IsAvailable() bool {
+	return v.Status != VideoStatusUnavailable
+}
+
+// MetadataHash returns the hash of the video's title, duration (in whole seconds) and upload date,
+// which changes when the video is re-edited on YouTube
+func (v *Video) MetadataHash() string {
+	date := ""
+	if v.UploadDate != nil {
+		date = v.UploadDate.Format("2006-01-02")
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%.0f\x00%s", v.Title, v.Duration, date)))
+	return hex.EncodeToString(sum[:])
+}
+
+// VideoChange is a saved video whose metadata changed on YouTube, found by a channel sync
+type VideoChange struct {
+	VideoID string   `json:"video_id"`
+	Title   string   `json:"title"`  // Title as listed now
+	Fields  []string `json:"fields"` // Changed fields: title, duration and/or upload_date
 }
 
+// VideoChapter is a chapter of a video as YouTube lists it (yt-dlp's chapters), in seconds
+type VideoChapter struct {
+	Title     string  `json:"title"`
+	StartTime float64 `json:"start_time"`
+	EndTime   float64 `json:"end_time"`
+}
+
+// VideoTag is a topic tag of a video, proposed from its transcript by video autotag
+type VideoTag struct {
+	VideoID   string    `json:"video_id" db:"video_id"`
+	Tag       string    `json:"tag" db:"tag"`
+	Score     float64   `json:"score" db:"score"` // TF-IDF weight of the keyword in the transcript
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Transcription sources
+const (
+	TranscriptionSourceWhisper = "whisper" // Recognized from the video's audio
+	TranscriptionSourceImport  = "import"  // Read from an existing subtitle file
+)
+
 // Transcription represents video transcription metadata (Option B: Normalized)
 type Transcription struct {
 	ID               string     `json:"id" db:"id"`
@@ -45,6 +171,76 @@ type Transcription struct {
 	ErrorMessage     *string    `json:"error_message" db:"error_message"`
 	DetectedLanguage *string    `json:"detected_language" db:"detected_language"`
 	TotalDuration    *string    `json:"total_duration" db:"total_duration"` // INTERVAL as string
+	Source           string     `json:"source" db:"source"`                 // whisper (default) or import
+
+	// LanguageCandidates are the most likely spoken languages with whisper's probabilities; empty
+	// when the language was given or the whisper build does not report them
+	LanguageCandidates []LanguageCandidate `json:"language_candidates,omitempty" db:"language_candidates"`
+
+	// WhisperAttempts are the whisper runs of the fallback ladder, in order; empty when fallback
+	// was disabled
+	WhisperAttempts []WhisperAttempt `json:"whisper_attempts,omitempty" db:"whisper_attempts"`
+
+	// Hallucinations are the segments matching the hallucination phrase list when the transcription
+	// was saved; nil when none matched
+	Hallucinations *HallucinationReport `json:"hallucinations,omitempty" db:"hallucinations"`
+
+	// StaleAt is when a channel sync noticed the video changed on YouTube after the transcription
+	// was made, with the reason; nil when the transcription is current
+	StaleAt     *time.Time `json:"stale_at,omitempty" db:"stale_at"`
+	StaleReason *string    `json:"stale_reason,omitempty" db:"stale_reason"`
+
+	// Routing is the whisper model routing decision; set only on transcriptions just created with routing
+	Routing *TranscriptionRouting `json:"routing,omitempty" db:"-"`
+}
+
+// LanguageCandidate is a language whisper may have heard, with its detection probability (0-1)
+type LanguageCandidate struct {
+	Language    string  `json:"language"`
+	Probability float64 `json:"probability"`
+}
+
+// WhisperAttempt is one whisper run of a transcription. When the output of a run looks
+// pathological, the audio is transcribed again with a larger beam or model.
+type WhisperAttempt struct {
+	Model    string   `json:"model"`
+	BeamSize int      `json:"beam_size,omitempty"`
+	BestOf   int      `json:"best_of,omitempty"`
+	Issues   []string `json:"issues,omitempty"` // Quality problems found in the output
+	Error    string   `json:"error,omitempty"`  // Why the run failed
+	Chosen   bool     `json:"chosen,omitempty"` // The run whose output was kept
+}
+
+// Hallucination cleaning modes
+const (
+	HallucinationFlag   = "flag"   // Keep matching segments and record them
+	HallucinationRemove = "remove" // Drop matching segments before they are saved
+	HallucinationOff    = "off"    // Save segments as whisper wrote them
+)
+
+// HallucinationReport records the whisper segments that consisted only of a phrase whisper commonly
+// emits on silence ("Thanks for watching!")
+type HallucinationReport struct {
+	Mode     string         `json:"mode"`               // flag or remove
+	Phrases  map[string]int `json:"phrases"`            // Matching segments per phrase of the list
+	Segments []int          `json:"segments,omitempty"` // Indexes of the flagged segments; empty when they were removed
+}
+
+// Count returns the number of matching segments
+func (r *HallucinationReport) Count() int {
+	count := 0
+	for _, n := range r.Phrases {
+		count += n
+	}
+	return count
+}
+
+// TranscriptionRouting records how the whisper model and language of a transcription were chosen
+type TranscriptionRouting struct {
+	SampledLanguage string `json:"sampled_language,omitempty"` // Language detected on the audio sample; empty when the language was given
+	Language        string `json:"language"`                   // Language passed to whisper
+	Model           string `json:"model"`                      // Whisper model used
+	Reason          string `json:"reason"`                     // Human-readable summary of the decision
 }
 
 // TranscriptionSegment represents individual whisper segment
@@ -56,6 +252,16 @@ type TranscriptionSegment struct {
 	EndTime         string   `json:"end_time" db:"end_time"`     // INTERVAL as string
 	Text            string   `json:"text" db:"text"`
 	Confidence      *float64 `json:"confidence" db:"confidence"`
+	Speaker         *string  `json:"speaker,omitempty" db:"speaker"` // Diarized speaker; nil when unknown
+}
+
+// SegmentMatch is a segment found by search semantic, with the video it was said in
+type SegmentMatch struct {
+	Segment    TranscriptionSegment `json:"segment"`
+	VideoID    string               `json:"video_id"`
+	VideoTitle string               `json:"video_title"`
+	Language   string               `json:"language"` // Language of the transcription
+	Distance   float64              `json:"distance"` // Cosine distance to the query: 0 is the same meaning
 }
 
 // Translation represents translated transcription segment
@@ -65,5 +271,34 @@ type Translation struct {
 	TargetLanguage         string    `json:"target_language" db:"target_language"`
 	TranslatedText         string    `json:"translated_text" db:"translated_text"`
 	Source                 string    `json:"source" db:"source"`
+	Style                  string    `json:"style,omitempty" db:"style"`                   // simple, formal or casual (translation create --style); empty for plain translations
+	SplitStrategy          string    `json:"split_strategy,omitempty" db:"split_strategy"` // How the segment's batch translation was split back into segments; empty when not batch translated
+	Chapter                string    `json:"chapter,omitempty" db:"chapter"`               // Time range of the chapter translated with translation create --per-chapter; empty otherwise
+	Approved               bool      `json:"approved" db:"approved"`                       // Reviewed by a person
+	CreatedAt              time.Time `json:"created_at" db:"created_at"`
+
+	// ProviderOptions are the provider settings the translation was made with (translation create
+	// --provider-opt), e.g. {"openai.temperature": "0.2"}; nil when none were given
+	ProviderOptions map[string]string `json:"provider_options,omitempty" db:"provider_options"`
+}
+
+// TranslationWarning is an alignment QA finding for one translated segment
+type TranslationWarning struct {
+	ID                     int       `json:"id" db:"id"`
+	TranscriptionSegmentID string    `json:"transcription_segment_id" db:"transcription_segment_id"`
+	TargetLanguage         string    `json:"target_language" db:"target_language"`
+	Code                   string    `json:"code" db:"code"` // empty_translation, untranslated_copy, length_ratio
+	Message                string    `json:"message" db:"message"`
 	CreatedAt              time.Time `json:"created_at" db:"created_at"`
 }
+
+// DefaultWorkspace is the workspace used when none is selected
+const DefaultWorkspace = "default"
+
+// Workspace partitions channels, videos and their transcriptions within one database
+type Workspace struct {
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	Channels  int       `json:"channels"` // Number of channels saved in the workspace
+	Videos    int       `json:"videos"`   // Number of videos saved in the workspace
+}
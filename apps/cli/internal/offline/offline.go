@@ -0,0 +1,33 @@
+// Package offline implements offline mode: operations that need the network (yt-dlp, API
+// providers, remote storage) fail fast instead of hanging on timeouts, while everything served
+// from the database and local caches keeps working.
+package offline
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+)
+
+var enabled atomic.Bool
+
+// Set turns offline mode on or off for the process. The CLI calls it once at startup from
+// --offline and the config file.
+func Set(on bool) {
+	enabled.Store(on)
+}
+
+// Enabled reports whether offline mode is on
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// Check fails with CodeOffline when offline mode is on. operation describes what needs the
+// network, e.g. "fetching channel info with yt-dlp".
+func Check(operation string) error {
+	if !Enabled() {
+		return nil
+	}
+	return errors.New(errors.CodeOffline, fmt.Sprintf("%s needs the network, but offline mode is on (--offline or network.offline in the config file)", operation))
+}
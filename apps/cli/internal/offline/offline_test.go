@@ -0,0 +1,23 @@
+package offline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+)
+
+func TestCheck(t *testing.T) {
+	t.Cleanup(func() { Set(false) })
+
+	assert.NoError(t, Check("fetching channel info with yt-dlp"))
+
+	Set(true)
+	err := Check("fetching channel info with yt-dlp")
+	var appErr *errors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, errors.CodeOffline, appErr.Code)
+	assert.Contains(t, appErr.Message, "fetching channel info with yt-dlp needs the network")
+}
@@ -0,0 +1,116 @@
+package pager
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// DefaultCommand is the pager used when $PAGER is not set
+const DefaultCommand = "less"
+
+// defaultLess is the LESS value used when it is not set: quit when the output fits on one
+// screen (-F), keep ANSI colors (-R) and leave the output on screen after quitting (-X)
+const defaultLess = "FRX"
+
+// Command returns the pager command line from $PAGER, or DefaultCommand when PAGER is unset.
+// It returns "" when paging is turned off with PAGER="" or PAGER=cat.
+func Command() string {
+	command, ok := os.LookupEnv("PAGER")
+	if !ok {
+		return DefaultCommand
+	}
+	command = strings.TrimSpace(command)
+	if command == "cat" {
+		return ""
+	}
+	return command
+}
+
+// IsTerminal reports whether w is a terminal
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Pager pipes output through an external pager process
+type Pager struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	quit  bool // The pager exited before reading all output
+}
+
+// Start runs command through the shell with its output on out. Text written to the returned
+// Pager is shown in the pager; Close waits for the user to quit it.
+func Start(command string, out io.Writer) (*Pager, error) {
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stdout = out
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		cmd.Env = append(cmd.Env, "LESS="+defaultLess)
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &Pager{cmd: cmd, stdin: stdin}, nil
+}
+
+// Write sends b to the pager. Once the user has quit the pager, further output is discarded
+// so commands can finish normally.
+func (p *Pager) Write(b []byte) (int, error) {
+	if p.quit {
+		return len(b), nil
+	}
+
+	n, err := p.stdin.Write(b)
+	if err != nil && (errors.Is(err, syscall.EPIPE) || errors.Is(err, os.ErrClosed)) {
+		p.quit = true
+		return len(b), nil
+	}
+	return n, err
+}
+
+// Close ends the output and waits for the pager to exit
+func (p *Pager) Close() error {
+	if err := p.stdin.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+		return err
+	}
+	return p.cmd.Wait()
+}
+
+// Open returns a writer for command output: a pager when enabled, out is a terminal and a pager
+// command is available, otherwise out itself. The returned close function must be called once
+// the output is complete.
+func Open(out io.Writer, enabled bool) (io.Writer, func() error) {
+	noop := func() error { return nil }
+	if !enabled || !IsTerminal(out) {
+		return out, noop
+	}
+	command := Command()
+	if command == "" {
+		return out, noop
+	}
+
+	p, err := Start(command, out)
+	if err != nil {
+		// A missing pager is not worth failing the command for
+		return out, noop
+	}
+	return p, p.Close
+}
@@ -0,0 +1,67 @@
+package pager
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommand(t *testing.T) {
+	t.Run("uses PAGER", func(t *testing.T) {
+		t.Setenv("PAGER", "more")
+		assert.Equal(t, "more", Command())
+	})
+
+	t.Run("empty or cat disables paging", func(t *testing.T) {
+		t.Setenv("PAGER", "")
+		assert.Equal(t, "", Command())
+		t.Setenv("PAGER", "cat")
+		assert.Equal(t, "", Command())
+	})
+}
+
+func TestStart(t *testing.T) {
+	t.Run("pipes output through the pager", func(t *testing.T) {
+		var out bytes.Buffer
+		p, err := Start("tr a-z A-Z", &out)
+		require.NoError(t, err)
+
+		_, err = p.Write([]byte("hello\n"))
+		require.NoError(t, err)
+		require.NoError(t, p.Close())
+		assert.Equal(t, "HELLO\n", out.String())
+	})
+
+	t.Run("discards output after the pager quits", func(t *testing.T) {
+		var out bytes.Buffer
+		p, err := Start("head -n 1", &out)
+		require.NoError(t, err)
+
+		line := []byte(strings.Repeat("x", 1023) + "\n")
+		for i := 0; i < 1024; i++ {
+			_, err := p.Write(line)
+			require.NoError(t, err)
+		}
+		require.NoError(t, p.Close())
+		assert.Equal(t, string(line), out.String())
+	})
+}
+
+func TestOpen(t *testing.T) {
+	t.Run("writes directly when output is not a terminal", func(t *testing.T) {
+		var out bytes.Buffer
+		w, closeFn := Open(&out, true)
+		assert.Same(t, &out, w)
+		assert.NoError(t, closeFn())
+	})
+
+	t.Run("writes directly when disabled", func(t *testing.T) {
+		var out bytes.Buffer
+		w, closeFn := Open(&out, false)
+		assert.Same(t, &out, w)
+		assert.NoError(t, closeFn())
+	})
+}
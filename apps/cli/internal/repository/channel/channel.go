@@ -2,6 +2,7 @@ package channel
 
 import (
 	"context"
+	"time"
 
 	"github.com/Taichi-iskw/yt-lang/internal/model"
 )
@@ -17,12 +18,47 @@ type Repository interface {
 	// GetByURL retrieves a channel by its URL
 	GetByURL(ctx context.Context, url string) (*model.Channel, error)
 
-	// Update updates an existing channel record
+	// Update updates an existing channel record; fails with CodeConflict if it changed since it was read
 	Update(ctx context.Context, channel *model.Channel) error
 
 	// Delete deletes a channel by its ID
 	Delete(ctx context.Context, id string) error
 
+	// Merge moves the videos of channel fromID to channel intoID and deletes fromID in one
+	// transaction, returning the number of videos moved
+	Merge(ctx context.Context, fromID, intoID string) (int, error)
+
 	// List retrieves channels with pagination
 	List(ctx context.Context, limit, offset int) ([]*model.Channel, error)
+
+	// Count returns the number of channels, for pagination
+	Count(ctx context.Context) (int, error)
+
+	// Dominant spoken languages of the channel's videos, most common first
+	GetLanguages(ctx context.Context, id string) ([]string, error)
+	SetLanguages(ctx context.Context, id string, languages []string) error
+}
+
+// ScheduleRepository defines operations for channel sync schedule persistence
+type ScheduleRepository interface {
+	// Set sets the schedule of a channel, keeping its last run
+	Set(ctx context.Context, channelID, spec string) error
+
+	// Delete removes the schedule of a channel, failing with CodeNotFound when it has none
+	Delete(ctx context.Context, channelID string) error
+
+	// List retrieves the schedules of the workspace with their channel names, by channel ID
+	List(ctx context.Context) ([]*model.ChannelSchedule, error)
+
+	// RecordRun records the start time and outcome of a sync; errorMessage is nil when it succeeded
+	RecordRun(ctx context.Context, channelID string, at time.Time, errorMessage *string) error
+}
+
+// HeartbeatRepository defines operations for watch daemon heartbeat persistence
+type HeartbeatRepository interface {
+	// Beat inserts or replaces the heartbeat of the daemon's host
+	Beat(ctx context.Context, heartbeat *model.WatchHeartbeat) error
+
+	// List retrieves the heartbeats of the workspace, most recent first
+	List(ctx context.Context) ([]*model.WatchHeartbeat, error)
 }
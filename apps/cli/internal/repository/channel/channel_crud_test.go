@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
 	"github.com/Taichi-iskw/yt-lang/internal/model"
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
@@ -93,16 +94,17 @@ func TestChannelRepository_GetByID(t *testing.T) {
 			name: "channel found",
 			id:   "UC123456789",
 			setup: func(mock pgxmock.PgxPoolIface) {
-				rows := pgxmock.NewRows([]string{"id", "name", "url"}).
-					AddRow("UC123456789", "Test Channel", "https://www.youtube.com/@testchannel")
-				mock.ExpectQuery("SELECT id, name, url FROM channels WHERE id = \\$1").
+				rows := pgxmock.NewRows([]string{"id", "name", "url", "version"}).
+					AddRow("UC123456789", "Test Channel", "https://www.youtube.com/@testchannel", int64(741))
+				mock.ExpectQuery("SELECT id, name, url, xmin::text::bigint FROM channels WHERE id = \\$1").
 					WithArgs("UC123456789").
 					WillReturnRows(rows)
 			},
 			want: &model.Channel{
-				ID:   "UC123456789",
-				Name: "Test Channel",
-				URL:  "https://www.youtube.com/@testchannel",
+				ID:      "UC123456789",
+				Name:    "Test Channel",
+				URL:     "https://www.youtube.com/@testchannel",
+				Version: 741,
 			},
 			wantErr: false,
 		},
@@ -110,9 +112,9 @@ func TestChannelRepository_GetByID(t *testing.T) {
 			name: "channel not found",
 			id:   "UCnotfound",
 			setup: func(mock pgxmock.PgxPoolIface) {
-				mock.ExpectQuery("SELECT id, name, url FROM channels WHERE id = \\$1").
+				mock.ExpectQuery("SELECT id, name, url, xmin::text::bigint FROM channels WHERE id = \\$1").
 					WithArgs("UCnotfound").
-					WillReturnRows(pgxmock.NewRows([]string{"id", "name", "url"}))
+					WillReturnRows(pgxmock.NewRows([]string{"id", "name", "url", "version"}))
 			},
 			want:    nil,
 			wantErr: true,
@@ -121,7 +123,7 @@ func TestChannelRepository_GetByID(t *testing.T) {
 			name: "database error",
 			id:   "UC123456789",
 			setup: func(mock pgxmock.PgxPoolIface) {
-				mock.ExpectQuery("SELECT id, name, url FROM channels WHERE id = \\$1").
+				mock.ExpectQuery("SELECT id, name, url, xmin::text::bigint FROM channels WHERE id = \\$1").
 					WithArgs("UC123456789").
 					WillReturnError(assert.AnError)
 			},
@@ -177,16 +179,17 @@ func TestChannelRepository_GetByURL(t *testing.T) {
 			name: "channel found by URL",
 			url:  "https://www.youtube.com/@testchannel",
 			setup: func(mock pgxmock.PgxPoolIface) {
-				rows := pgxmock.NewRows([]string{"id", "name", "url"}).
-					AddRow("UC123456789", "Test Channel", "https://www.youtube.com/@testchannel")
-				mock.ExpectQuery("SELECT id, name, url FROM channels WHERE url = \\$1").
+				rows := pgxmock.NewRows([]string{"id", "name", "url", "version"}).
+					AddRow("UC123456789", "Test Channel", "https://www.youtube.com/@testchannel", int64(741))
+				mock.ExpectQuery("SELECT id, name, url, xmin::text::bigint FROM channels WHERE url = \\$1").
 					WithArgs("https://www.youtube.com/@testchannel").
 					WillReturnRows(rows)
 			},
 			want: &model.Channel{
-				ID:   "UC123456789",
-				Name: "Test Channel",
-				URL:  "https://www.youtube.com/@testchannel",
+				ID:      "UC123456789",
+				Name:    "Test Channel",
+				URL:     "https://www.youtube.com/@testchannel",
+				Version: 741,
 			},
 			wantErr: false,
 		},
@@ -228,25 +231,73 @@ func TestChannelRepository_GetByURL(t *testing.T) {
 }
 
 func TestChannelRepository_Update(t *testing.T) {
+	updateQuery := "UPDATE channels SET name = \\$2, url = \\$3 WHERE id = \\$1 AND workspace = current_workspace\\(\\)"
+	existsQuery := "SELECT EXISTS \\(SELECT 1 FROM channels WHERE id = \\$1"
+
 	tests := []struct {
-		name    string
-		channel *model.Channel
-		setup   func(mock pgxmock.PgxPoolIface)
-		wantErr bool
+		name        string
+		version     int64
+		setup       func(mock pgxmock.PgxPoolIface)
+		wantVersion int64
+		wantCode    string
 	}{
 		{
-			name: "successful update",
-			channel: &model.Channel{
-				ID:   "UC123456789",
-				Name: "Updated Channel",
-				URL:  "https://www.youtube.com/@updatedchannel",
+			name:    "successful update",
+			version: 0,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(updateQuery+" RETURNING").
+					WithArgs("UC123456789", "Updated Channel", "https://www.youtube.com/@updatedchannel").
+					WillReturnRows(pgxmock.NewRows([]string{"version"}).AddRow(int64(742)))
 			},
+			wantVersion: 742,
+		},
+		{
+			name:    "version unchanged",
+			version: 741,
 			setup: func(mock pgxmock.PgxPoolIface) {
-				mock.ExpectExec("UPDATE channels SET name = \\$2, url = \\$3 WHERE id = \\$1").
+				mock.ExpectQuery(updateQuery+" AND xmin::text::bigint = \\$4 RETURNING").
+					WithArgs("UC123456789", "Updated Channel", "https://www.youtube.com/@updatedchannel", int64(741)).
+					WillReturnRows(pgxmock.NewRows([]string{"version"}).AddRow(int64(742)))
+			},
+			wantVersion: 742,
+		},
+		{
+			name:    "modified concurrently",
+			version: 741,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(updateQuery+" AND xmin::text::bigint = \\$4 RETURNING").
+					WithArgs("UC123456789", "Updated Channel", "https://www.youtube.com/@updatedchannel", int64(741)).
+					WillReturnRows(pgxmock.NewRows([]string{"version"}))
+				mock.ExpectQuery(existsQuery).
+					WithArgs("UC123456789").
+					WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+			wantVersion: 741,
+			wantCode:    apperrors.CodeConflict,
+		},
+		{
+			name:    "deleted concurrently",
+			version: 741,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(updateQuery+" AND xmin::text::bigint = \\$4 RETURNING").
+					WithArgs("UC123456789", "Updated Channel", "https://www.youtube.com/@updatedchannel", int64(741)).
+					WillReturnRows(pgxmock.NewRows([]string{"version"}))
+				mock.ExpectQuery(existsQuery).
+					WithArgs("UC123456789").
+					WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+			wantVersion: 741,
+			wantCode:    apperrors.CodeNotFound,
+		},
+		{
+			name:    "channel not found",
+			version: 0,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(updateQuery+" RETURNING").
 					WithArgs("UC123456789", "Updated Channel", "https://www.youtube.com/@updatedchannel").
-					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+					WillReturnRows(pgxmock.NewRows([]string{"version"}))
 			},
-			wantErr: false,
+			wantCode: apperrors.CodeNotFound,
 		},
 	}
 
@@ -267,14 +318,23 @@ func TestChannelRepository_Update(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			defer cancel()
 
-			err = repo.Update(ctx, tt.channel)
+			channel := &model.Channel{
+				ID:      "UC123456789",
+				Name:    "Updated Channel",
+				URL:     "https://www.youtube.com/@updatedchannel",
+				Version: tt.version,
+			}
+			err = repo.Update(ctx, channel)
 
 			// Verify result
-			if tt.wantErr {
-				assert.Error(t, err)
+			if tt.wantCode != "" {
+				var appErr *apperrors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
 			} else {
 				assert.NoError(t, err)
 			}
+			assert.Equal(t, tt.wantVersion, channel.Version)
 
 			// Verify all expectations were met
 			err = mock.ExpectationsWereMet()
@@ -334,3 +394,64 @@ func TestChannelRepository_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestChannelRepository_Merge(t *testing.T) {
+	lockQuery := "SELECT COUNT\\(\\*\\) FROM \\(SELECT 1 FROM channels WHERE id IN \\(\\$1, \\$2\\)"
+	moveQuery := "UPDATE videos SET channel_id = \\$2 WHERE channel_id = \\$1"
+	deleteQuery := "DELETE FROM channels WHERE id = \\$1"
+
+	t.Run("moves videos and deletes the channel in a transaction", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs("UCold", "UCnew").
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectExec(moveQuery).WithArgs("UCold", "UCnew").
+			WillReturnResult(pgxmock.NewResult("UPDATE", 12))
+		mock.ExpectExec(deleteQuery).WithArgs("UCold").
+			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		mock.ExpectCommit()
+
+		moved, err := NewRepository(mock).Merge(context.Background(), "UCold", "UCnew")
+		require.NoError(t, err)
+		assert.Equal(t, 12, moved)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing channel rolls back", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs("UCold", "UCnew").
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectRollback()
+
+		_, err = NewRepository(mock).Merge(context.Background(), "UCold", "UCnew")
+		require.Error(t, err)
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.CodeNotFound, appErr.Code)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("move failure rolls back", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs("UCold", "UCnew").
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectExec(moveQuery).WithArgs("UCold", "UCnew").
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		_, err = NewRepository(mock).Merge(context.Background(), "UCold", "UCnew")
+		require.Error(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -45,11 +45,11 @@ func (r *channelRepository) Create(ctx context.Context, channel *model.Channel)
 
 // GetByID retrieves a channel by its ID
 func (r *channelRepository) GetByID(ctx context.Context, id string) (*model.Channel, error) {
-	sql := "SELECT id, name, url FROM channels WHERE id = $1"
+	sql := "SELECT id, name, url, " + common.VersionColumn + " FROM channels WHERE id = $1 AND workspace = current_workspace()"
 	row := r.pool.QueryRow(ctx, sql, id)
 
 	var channel model.Channel
-	err := row.Scan(&channel.ID, &channel.Name, &channel.URL)
+	err := row.Scan(&channel.ID, &channel.Name, &channel.URL, &channel.Version)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperrors.Wrap(err, apperrors.CodeNotFound, "channel not found")
@@ -62,11 +62,11 @@ func (r *channelRepository) GetByID(ctx context.Context, id string) (*model.Chan
 
 // GetByURL retrieves a channel by its URL
 func (r *channelRepository) GetByURL(ctx context.Context, url string) (*model.Channel, error) {
-	sql := "SELECT id, name, url FROM channels WHERE url = $1"
+	sql := "SELECT id, name, url, " + common.VersionColumn + " FROM channels WHERE url = $1 AND workspace = current_workspace()"
 	row := r.pool.QueryRow(ctx, sql, url)
 
 	var channel model.Channel
-	err := row.Scan(&channel.ID, &channel.Name, &channel.URL)
+	err := row.Scan(&channel.ID, &channel.Name, &channel.URL, &channel.Version)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperrors.Wrap(err, apperrors.CodeNotFound, "channel not found")
@@ -77,11 +77,22 @@ func (r *channelRepository) GetByURL(ctx context.Context, url string) (*model.Ch
 	return &channel, nil
 }
 
-// Update updates an existing channel record
+// Update updates an existing channel record. A channel read with a version is only updated if
+// the row has not changed since (conflict otherwise); channel.Version is advanced on success.
 func (r *channelRepository) Update(ctx context.Context, channel *model.Channel) error {
-	sql := "UPDATE channels SET name = $2, url = $3 WHERE id = $1"
-	_, err := r.pool.Exec(ctx, sql, channel.ID, channel.Name, channel.URL)
+	sql := "UPDATE channels SET name = $2, url = $3 WHERE id = $1 AND workspace = current_workspace()"
+	args := []any{channel.ID, channel.Name, channel.URL}
+	if channel.Version != 0 {
+		sql += " AND " + common.VersionColumn + " = $4"
+		args = append(args, channel.Version)
+	}
+	sql += " RETURNING " + common.VersionColumn
+
+	err := r.pool.QueryRow(ctx, sql, args...).Scan(&channel.Version)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return common.MissedUpdate(ctx, r.pool, "channels", "channel", channel.ID, channel.Version != 0)
+		}
 		return common.HandlePostgreSQLError(err, "failed to update channel")
 	}
 	return nil
@@ -89,7 +100,7 @@ func (r *channelRepository) Update(ctx context.Context, channel *model.Channel)
 
 // Delete deletes a channel by its ID
 func (r *channelRepository) Delete(ctx context.Context, id string) error {
-	sql := "DELETE FROM channels WHERE id = $1"
+	sql := "DELETE FROM channels WHERE id = $1 AND workspace = current_workspace()"
 	_, err := r.pool.Exec(ctx, sql, id)
 	if err != nil {
 		return common.HandlePostgreSQLError(err, "failed to delete channel")
@@ -97,9 +108,44 @@ func (r *channelRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// Merge re-points the videos of fromID to intoID and deletes fromID in one transaction
+func (r *channelRepository) Merge(ctx context.Context, fromID, intoID string) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, common.HandlePostgreSQLError(err, "failed to begin channel merge")
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock both rows so a concurrent save can't add videos to the channel being deleted
+	sql := "SELECT COUNT(*) FROM (SELECT 1 FROM channels WHERE id IN ($1, $2) AND workspace = current_workspace() FOR UPDATE) locked"
+	var found int
+	if err := tx.QueryRow(ctx, sql, fromID, intoID).Scan(&found); err != nil {
+		return 0, common.HandlePostgreSQLError(err, "failed to lock channels")
+	}
+	if found != 2 {
+		return 0, apperrors.New(apperrors.CodeNotFound, "channel not found")
+	}
+
+	sql = "UPDATE videos SET channel_id = $2 WHERE channel_id = $1 AND workspace = current_workspace()"
+	tag, err := tx.Exec(ctx, sql, fromID, intoID)
+	if err != nil {
+		return 0, common.HandlePostgreSQLError(err, "failed to move videos")
+	}
+
+	sql = "DELETE FROM channels WHERE id = $1 AND workspace = current_workspace()"
+	if _, err := tx.Exec(ctx, sql, fromID); err != nil {
+		return 0, common.HandlePostgreSQLError(err, "failed to delete merged channel")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, common.HandlePostgreSQLError(err, "failed to commit channel merge")
+	}
+	return int(tag.RowsAffected()), nil
+}
+
 // List retrieves channels with pagination
 func (r *channelRepository) List(ctx context.Context, limit, offset int) ([]*model.Channel, error) {
-	sql := "SELECT id, name, url FROM channels ORDER BY id LIMIT $1 OFFSET $2"
+	sql := "SELECT id, name, url FROM channels WHERE workspace = current_workspace() ORDER BY id LIMIT $1 OFFSET $2"
 	rows, err := r.pool.Query(ctx, sql, limit, offset)
 	if err != nil {
 		return nil, common.HandlePostgreSQLError(err, "failed to list channels")
@@ -122,3 +168,41 @@ func (r *channelRepository) List(ctx context.Context, limit, offset int) ([]*mod
 
 	return channels, nil
 }
+
+// Count returns the number of channels in the workspace
+func (r *channelRepository) Count(ctx context.Context) (int, error) {
+	sql := "SELECT COUNT(*) FROM channels WHERE workspace = current_workspace()"
+
+	var count int
+	if err := r.pool.QueryRow(ctx, sql).Scan(&count); err != nil {
+		return 0, common.HandlePostgreSQLError(err, "failed to count channels")
+	}
+	return count, nil
+}
+
+// GetLanguages returns the languages stored on the channel; none until they are inferred
+func (r *channelRepository) GetLanguages(ctx context.Context, id string) ([]string, error) {
+	sql := "SELECT COALESCE(languages, '{}') FROM channels WHERE id = $1 AND workspace = current_workspace()"
+
+	var languages []string
+	if err := r.pool.QueryRow(ctx, sql, id).Scan(&languages); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.Wrap(err, apperrors.CodeNotFound, "channel not found")
+		}
+		return nil, common.HandlePostgreSQLError(err, "failed to get channel languages")
+	}
+	return languages, nil
+}
+
+// SetLanguages stores the channel's languages, most common first
+func (r *channelRepository) SetLanguages(ctx context.Context, id string, languages []string) error {
+	sql := "UPDATE channels SET languages = $2 WHERE id = $1 AND workspace = current_workspace()"
+	tag, err := r.pool.Exec(ctx, sql, id, languages)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to set channel languages")
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.New(apperrors.CodeNotFound, "channel not found")
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
 	"github.com/Taichi-iskw/yt-lang/internal/model"
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
@@ -28,7 +29,7 @@ func TestChannelRepository_List(t *testing.T) {
 				rows := pgxmock.NewRows([]string{"id", "name", "url"}).
 					AddRow("UC123456789", "Test Channel 1", "https://www.youtube.com/@testchannel1").
 					AddRow("UC987654321", "Test Channel 2", "https://www.youtube.com/@testchannel2")
-				mock.ExpectQuery("SELECT id, name, url FROM channels ORDER BY id LIMIT \\$1 OFFSET \\$2").
+				mock.ExpectQuery("SELECT id, name, url FROM channels WHERE workspace = current_workspace\\(\\) ORDER BY id LIMIT \\$1 OFFSET \\$2").
 					WithArgs(2, 0).
 					WillReturnRows(rows)
 			},
@@ -82,3 +83,50 @@ func TestChannelRepository_List(t *testing.T) {
 		})
 	}
 }
+
+func TestChannelRepository_Count(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM channels WHERE workspace = current_workspace\\(\\)").
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(17))
+
+	count, err := NewRepository(mock).Count(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 17, count)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChannelRepository_Languages(t *testing.T) {
+	t.Run("get", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery("SELECT COALESCE\\(languages, '\\{\\}'\\) FROM channels WHERE id = \\$1").
+			WithArgs("UC1").
+			WillReturnRows(pgxmock.NewRows([]string{"languages"}).AddRow([]string{"ja", "en"}))
+
+		languages, err := NewRepository(mock).GetLanguages(context.Background(), "UC1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ja", "en"}, languages)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("set on a missing channel", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("UPDATE channels SET languages = \\$2").
+			WithArgs("UC-missing", []string{"ja"}).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		err = NewRepository(mock).SetLanguages(context.Background(), "UC-missing", []string{"ja"})
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.CodeNotFound, appErr.Code)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
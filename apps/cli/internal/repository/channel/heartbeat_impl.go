@@ -0,0 +1,59 @@
+package channel
+
+import (
+	"context"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/common"
+)
+
+// heartbeatRepository implements HeartbeatRepository using PostgreSQL
+type heartbeatRepository struct {
+	pool Pool
+}
+
+// NewHeartbeatRepository creates a new watch heartbeat repository
+func NewHeartbeatRepository(pool Pool) HeartbeatRepository {
+	return &heartbeatRepository{
+		pool: pool,
+	}
+}
+
+// Beat inserts or replaces the heartbeat of the daemon's host
+func (r *heartbeatRepository) Beat(ctx context.Context, heartbeat *model.WatchHeartbeat) error {
+	sql := `INSERT INTO watch_heartbeats (host, pid, started_at, last_loop_at, queue_depth)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (workspace, host) DO UPDATE SET pid = EXCLUDED.pid, started_at = EXCLUDED.started_at,
+			last_loop_at = EXCLUDED.last_loop_at, queue_depth = EXCLUDED.queue_depth`
+	_, err := r.pool.Exec(ctx, sql, heartbeat.Host, heartbeat.PID, heartbeat.StartedAt, heartbeat.LastLoopAt, heartbeat.QueueDepth)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to record watch heartbeat")
+	}
+	return nil
+}
+
+// List retrieves the heartbeats of the workspace, most recent first
+func (r *heartbeatRepository) List(ctx context.Context) ([]*model.WatchHeartbeat, error) {
+	sql := `SELECT host, pid, started_at, last_loop_at, queue_depth
+		FROM watch_heartbeats
+		WHERE workspace = current_workspace()
+		ORDER BY last_loop_at DESC, host`
+	rows, err := r.pool.Query(ctx, sql)
+	if err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to list watch heartbeats")
+	}
+	defer rows.Close()
+
+	heartbeats := []*model.WatchHeartbeat{}
+	for rows.Next() {
+		var h model.WatchHeartbeat
+		if err := rows.Scan(&h.Host, &h.PID, &h.StartedAt, &h.LastLoopAt, &h.QueueDepth); err != nil {
+			return nil, common.HandlePostgreSQLError(err, "failed to scan watch heartbeat")
+		}
+		heartbeats = append(heartbeats, &h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to list watch heartbeats")
+	}
+	return heartbeats, nil
+}
@@ -0,0 +1,50 @@
+package channel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+func TestHeartbeatRepository_Beat(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	started := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	beat := started.Add(time.Hour)
+	mock.ExpectExec("INSERT INTO watch_heartbeats \\(host, pid, started_at, last_loop_at, queue_depth\\)\\s+VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5\\)\\s+ON CONFLICT \\(workspace, host\\) DO UPDATE").
+		WithArgs("host1", 1234, started, beat, 2).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	repo := NewHeartbeatRepository(mock)
+	require.NoError(t, repo.Beat(context.Background(), &model.WatchHeartbeat{Host: "host1", PID: 1234, StartedAt: started, LastLoopAt: beat, QueueDepth: 2}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHeartbeatRepository_List(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	started := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	rows := pgxmock.NewRows([]string{"host", "pid", "started_at", "last_loop_at", "queue_depth"}).
+		AddRow("host1", 1234, started, started.Add(time.Hour), 0).
+		AddRow("host2", 99, started, started.Add(time.Minute), 3)
+	mock.ExpectQuery("SELECT host, pid, started_at, last_loop_at, queue_depth\\s+FROM watch_heartbeats\\s+WHERE workspace = current_workspace\\(\\)\\s+ORDER BY last_loop_at DESC").
+		WillReturnRows(rows)
+
+	repo := NewHeartbeatRepository(mock)
+	heartbeats, err := repo.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, heartbeats, 2)
+	assert.Equal(t, "host1", heartbeats[0].Host)
+	assert.Equal(t, 3, heartbeats[1].QueueDepth)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,88 @@
+package channel
+
+import (
+	"context"
+	"time"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/common"
+)
+
+// scheduleRepository implements ScheduleRepository using PostgreSQL
+type scheduleRepository struct {
+	pool Pool
+}
+
+// NewScheduleRepository creates a new channel schedule repository
+func NewScheduleRepository(pool Pool) ScheduleRepository {
+	return &scheduleRepository{
+		pool: pool,
+	}
+}
+
+// Set inserts or replaces the schedule of a channel
+func (r *scheduleRepository) Set(ctx context.Context, channelID, spec string) error {
+	sql := `INSERT INTO channel_schedules (channel_id, spec) VALUES ($1, $2)
+		ON CONFLICT (workspace, channel_id) DO UPDATE SET spec = EXCLUDED.spec`
+	if _, err := r.pool.Exec(ctx, sql, channelID, spec); err != nil {
+		return common.HandlePostgreSQLError(err, "failed to set channel schedule")
+	}
+	return nil
+}
+
+// Delete removes the schedule of a channel
+func (r *scheduleRepository) Delete(ctx context.Context, channelID string) error {
+	sql := "DELETE FROM channel_schedules WHERE workspace = current_workspace() AND channel_id = $1"
+	tag, err := r.pool.Exec(ctx, sql, channelID)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to delete channel schedule")
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.New(apperrors.CodeNotFound, "channel has no schedule")
+	}
+	return nil
+}
+
+// List retrieves the schedules of the workspace by channel ID
+func (r *scheduleRepository) List(ctx context.Context) ([]*model.ChannelSchedule, error) {
+	sql := `SELECT s.channel_id, c.name, s.spec, s.last_run_at, s.last_error, s.last_success_at, s.created_at
+		FROM channel_schedules s
+		JOIN channels c ON c.workspace = s.workspace AND c.id = s.channel_id
+		WHERE s.workspace = current_workspace()
+		ORDER BY s.channel_id`
+	rows, err := r.pool.Query(ctx, sql)
+	if err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to list channel schedules")
+	}
+	defer rows.Close()
+
+	schedules := []*model.ChannelSchedule{}
+	for rows.Next() {
+		var s model.ChannelSchedule
+		if err := rows.Scan(&s.ChannelID, &s.ChannelName, &s.Spec, &s.LastRunAt, &s.LastError, &s.LastSuccessAt, &s.CreatedAt); err != nil {
+			return nil, common.HandlePostgreSQLError(err, "failed to scan channel schedule")
+		}
+		schedules = append(schedules, &s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to list channel schedules")
+	}
+	return schedules, nil
+}
+
+// RecordRun records the start time and outcome of a channel's last sync, and its start as the
+// last success when it succeeded
+func (r *scheduleRepository) RecordRun(ctx context.Context, channelID string, at time.Time, errorMessage *string) error {
+	sql := `UPDATE channel_schedules SET last_run_at = $2, last_error = $3,
+			last_success_at = CASE WHEN $3::TEXT IS NULL THEN $2 ELSE last_success_at END
+		WHERE workspace = current_workspace() AND channel_id = $1`
+	tag, err := r.pool.Exec(ctx, sql, channelID, at, errorMessage)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to record channel sync")
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.New(apperrors.CodeNotFound, "channel has no schedule")
+	}
+	return nil
+}
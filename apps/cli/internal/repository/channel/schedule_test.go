@@ -0,0 +1,102 @@
+package channel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleRepository_Set(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec("INSERT INTO channel_schedules \\(channel_id, spec\\) VALUES \\(\\$1, \\$2\\)\\s+ON CONFLICT \\(workspace, channel_id\\) DO UPDATE SET spec = EXCLUDED.spec").
+		WithArgs("UC123", "@every 12h0m0s").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	repo := NewScheduleRepository(mock)
+	require.NoError(t, repo.Set(context.Background(), "UC123", "@every 12h0m0s"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestScheduleRepository_List(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	lastRun := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	failure := "yt-dlp failed"
+	lastSuccess := lastRun.Add(-12 * time.Hour)
+	rows := pgxmock.NewRows([]string{"channel_id", "name", "spec", "last_run_at", "last_error", "last_success_at", "created_at"}).
+		AddRow("UC123", "Channel 1", "@every 12h0m0s", &lastRun, &failure, &lastSuccess, lastRun).
+		AddRow("UC456", "Channel 2", "0 6 * * *", nil, nil, nil, lastRun)
+	mock.ExpectQuery("SELECT s.channel_id, c.name, s.spec, s.last_run_at, s.last_error, s.last_success_at, s.created_at\\s+FROM channel_schedules s").
+		WillReturnRows(rows)
+
+	repo := NewScheduleRepository(mock)
+	schedules, err := repo.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, schedules, 2)
+	assert.Equal(t, "Channel 1", schedules[0].ChannelName)
+	assert.Equal(t, lastRun, *schedules[0].LastRunAt)
+	assert.Equal(t, failure, *schedules[0].LastError)
+	assert.Equal(t, lastSuccess, *schedules[0].LastSuccessAt)
+	assert.Nil(t, schedules[1].LastRunAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestScheduleRepository_RecordRun(t *testing.T) {
+	at := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+
+	t.Run("records the run", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("UPDATE channel_schedules SET last_run_at = \\$2, last_error = \\$3,\\s+last_success_at = CASE WHEN \\$3::TEXT IS NULL THEN \\$2 ELSE last_success_at END").
+			WithArgs("UC123", at, (*string)(nil)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		repo := NewScheduleRepository(mock)
+		require.NoError(t, repo.RecordRun(context.Background(), "UC123", at, nil))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("channel without schedule", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("UPDATE channel_schedules").
+			WithArgs("UC123", at, (*string)(nil)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		repo := NewScheduleRepository(mock)
+		err = repo.RecordRun(context.Background(), "UC123", at, nil)
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.CodeNotFound, appErr.Code)
+	})
+}
+
+func TestScheduleRepository_Delete(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec("DELETE FROM channel_schedules WHERE workspace = current_workspace\\(\\) AND channel_id = \\$1").
+		WithArgs("UC123").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	repo := NewScheduleRepository(mock)
+	err = repo.Delete(context.Background(), "UC123")
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, apperrors.CodeNotFound, appErr.Code)
+}
@@ -66,6 +66,8 @@ func handleUniqueViolation(pgErr *pgconn.PgError, operation string) *apperrors.A
 			return apperrors.Wrap(pgErr, apperrors.CodeConflict, "channel with this ID already exists")
 		} else if strings.Contains(constraintName, "videos") {
 			return apperrors.Wrap(pgErr, apperrors.CodeConflict, "video with this ID already exists")
+		} else if strings.Contains(constraintName, "workspaces") {
+			return apperrors.Wrap(pgErr, apperrors.CodeConflict, "workspace already exists")
 		}
 		return apperrors.Wrap(pgErr, apperrors.CodeConflict, "resource with this ID already exists")
 
@@ -90,6 +92,9 @@ func handleForeignKeyViolation(pgErr *pgconn.PgError, operation string) *apperro
 
 	// Provide user-friendly messages based on foreign key constraint
 	switch {
+	case strings.Contains(constraintName, "workspace_fkey"):
+		return apperrors.Wrap(pgErr, apperrors.CodeDependency, "workspace does not exist (create it with 'ytlang workspace create')")
+
 	case strings.Contains(constraintName, "channel_id"):
 		return apperrors.Wrap(pgErr, apperrors.CodeDependency, "referenced channel does not exist")
 
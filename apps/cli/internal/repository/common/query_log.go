@@ -0,0 +1,280 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Pool is the connection pool interface of the repositories; the Pool interface of each
+// repository package is a subset of it
+type Pool interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Close()
+}
+
+// Query event kinds
+const (
+	QueryEventExec     = "exec"      // An Exec call finished
+	QueryEventQuery    = "query"     // The rows of a Query call were closed
+	QueryEventQueryRow = "query_row" // The row of a QueryRow call was scanned
+	QueryEventCopy     = "copy"      // A CopyFrom call finished
+)
+
+// QueryEvent is one logged database call, written as a JSON line
+type QueryEvent struct {
+	Time       time.Time `json:"time"`
+	Event      string    `json:"event"`
+	SQL        string    `json:"sql"` // Whitespace collapsed; COPY events name the table
+	Args       []string  `json:"args,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+	Rows       int64     `json:"rows,omitempty"` // Rows affected, returned or copied
+	Slow       bool      `json:"slow,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// QueryLogOptions configures NewLoggingPool
+type QueryLogOptions struct {
+	Log           io.Writer     // Every call as a JSON line; nil logs none
+	Warnings      io.Writer     // Warnings about slow calls; nil warns about none
+	SlowThreshold time.Duration // Calls taking longer are slow; 0 marks none
+}
+
+// loggingPool logs the calls made through the wrapped pool
+type loggingPool struct {
+	Pool
+	logger *queryLogger
+}
+
+// NewLoggingPool wraps a pool so that every call, including those of its transactions, is
+// timed and logged with its SQL and redacted arguments, and calls over the slow threshold
+// are warned about. A query is timed until its rows are closed, since pgx reads them lazily.
+// Logging failures never fail a call.
+func NewLoggingPool(pool Pool, opts QueryLogOptions) Pool {
+	return &loggingPool{Pool: pool, logger: &queryLogger{opts: opts}}
+}
+
+// Exec runs the statement and logs it
+func (p *loggingPool) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	return p.logger.exec(ctx, p.Pool, sql, arguments)
+}
+
+// Query runs the query and logs it when its rows are closed
+func (p *loggingPool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return p.logger.query(ctx, p.Pool, sql, args)
+}
+
+// QueryRow runs the query and logs it when its row is scanned
+func (p *loggingPool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return p.logger.queryRow(ctx, p.Pool, sql, args)
+}
+
+// CopyFrom copies the rows and logs the copy
+func (p *loggingPool) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return p.logger.copyFrom(ctx, p.Pool, tableName, columnNames, rowSrc)
+}
+
+// Begin starts a transaction whose calls are logged as well
+func (p *loggingPool) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx, err := p.Pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &loggingTx{Tx: tx, logger: p.logger}, nil
+}
+
+// loggingTx logs the calls made in a transaction of a loggingPool
+type loggingTx struct {
+	pgx.Tx
+	logger *queryLogger
+}
+
+// Exec runs the statement and logs it
+func (t *loggingTx) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	return t.logger.exec(ctx, t.Tx, sql, arguments)
+}
+
+// Query runs the query and logs it when its rows are closed
+func (t *loggingTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return t.logger.query(ctx, t.Tx, sql, args)
+}
+
+// QueryRow runs the query and logs it when its row is scanned
+func (t *loggingTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return t.logger.queryRow(ctx, t.Tx, sql, args)
+}
+
+// CopyFrom copies the rows and logs the copy
+func (t *loggingTx) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return t.logger.copyFrom(ctx, t.Tx, tableName, columnNames, rowSrc)
+}
+
+// querier is what pools and transactions have in common
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+// queryLogger times the calls of a querier and writes their events
+type queryLogger struct {
+	opts QueryLogOptions
+	mu   sync.Mutex // Serializes events of concurrent calls, which share the writers
+}
+
+func (l *queryLogger) exec(ctx context.Context, q querier, sql string, args []any) (pgconn.CommandTag, error) {
+	started := time.Now()
+	tag, err := q.Exec(ctx, sql, args...)
+	l.write(QueryEventExec, started, sql, args, tag.RowsAffected(), err)
+	return tag, err
+}
+
+func (l *queryLogger) query(ctx context.Context, q querier, sql string, args []any) (pgx.Rows, error) {
+	started := time.Now()
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		l.write(QueryEventQuery, started, sql, args, 0, err)
+		return nil, err
+	}
+	return &loggingRows{Rows: rows, logger: l, started: started, sql: sql, args: args}, nil
+}
+
+func (l *queryLogger) queryRow(ctx context.Context, q querier, sql string, args []any) pgx.Row {
+	started := time.Now()
+	return &loggingRow{row: q.QueryRow(ctx, sql, args...), logger: l, started: started, sql: sql, args: args}
+}
+
+func (l *queryLogger) copyFrom(ctx context.Context, q querier, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	started := time.Now()
+	copied, err := q.CopyFrom(ctx, tableName, columnNames, rowSrc)
+	sql := fmt.Sprintf("COPY %s (%s)", tableName.Sanitize(), strings.Join(columnNames, ", "))
+	l.write(QueryEventCopy, started, sql, nil, copied, err)
+	return copied, err
+}
+
+// write logs a finished call and warns when it was slow
+func (l *queryLogger) write(kind string, started time.Time, sql string, args []any, rows int64, err error) {
+	duration := time.Since(started)
+	event := QueryEvent{
+		Time:       started,
+		Event:      kind,
+		SQL:        strings.Join(strings.Fields(sql), " "),
+		Args:       redactQueryArgs(args),
+		DurationMS: duration.Milliseconds(),
+		Rows:       rows,
+		Slow:       l.opts.SlowThreshold > 0 && duration > l.opts.SlowThreshold,
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.opts.Log != nil {
+		if data, err := json.Marshal(event); err == nil {
+			l.opts.Log.Write(append(data, '\n'))
+		}
+	}
+	if event.Slow && l.opts.Warnings != nil {
+		fmt.Fprintf(l.opts.Warnings, "Warning: slow query took %s (threshold %s): %s\n", duration.Round(time.Millisecond), l.opts.SlowThreshold, event.SQL)
+		if len(event.Args) > 0 {
+			fmt.Fprintf(l.opts.Warnings, "  args: %s\n", strings.Join(event.Args, ", "))
+		}
+	}
+}
+
+// loggingRows logs its query when closed
+type loggingRows struct {
+	pgx.Rows
+	logger  *queryLogger
+	started time.Time
+	sql     string
+	args    []any
+	count   int64
+	closed  bool
+}
+
+// Next advances to the next row, counting the rows read
+func (r *loggingRows) Next() bool {
+	if r.Rows.Next() {
+		r.count++
+		return true
+	}
+	r.Close() // pgx closes exhausted rows itself
+	return false
+}
+
+// Close closes the rows and logs the query once
+func (r *loggingRows) Close() {
+	r.Rows.Close()
+	if r.closed {
+		return
+	}
+	r.closed = true
+	r.logger.write(QueryEventQuery, r.started, r.sql, r.args, r.count, r.Rows.Err())
+}
+
+// loggingRow logs its query when scanned
+type loggingRow struct {
+	row     pgx.Row
+	logger  *queryLogger
+	started time.Time
+	sql     string
+	args    []any
+}
+
+// Scan reads the row and logs the query
+func (r *loggingRow) Scan(dest ...any) error {
+	err := r.row.Scan(dest...)
+	rows, logged := int64(1), err
+	if err != nil {
+		rows = 0
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Not finding a row is an answer, not a failure of the query
+		logged = nil
+	}
+	r.logger.write(QueryEventQueryRow, r.started, r.sql, r.args, rows, logged)
+	return err
+}
+
+// redactQueryArgs describes query arguments without exposing text: numbers, booleans, times
+// and NULLs are shown, anything else (titles, transcripts, URLs) only by type and length
+func redactQueryArgs(args []any) []string {
+	if len(args) == 0 {
+		return nil
+	}
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case nil:
+			redacted[i] = "NULL"
+		case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			redacted[i] = fmt.Sprint(v)
+		case time.Time:
+			redacted[i] = v.Format(time.RFC3339)
+		case time.Duration:
+			redacted[i] = v.String()
+		case string:
+			redacted[i] = fmt.Sprintf("<string %d chars>", len([]rune(v)))
+		case []byte:
+			redacted[i] = fmt.Sprintf("<%d bytes>", len(v))
+		default:
+			redacted[i] = fmt.Sprintf("<%T>", v)
+		}
+	}
+	return redacted
+}
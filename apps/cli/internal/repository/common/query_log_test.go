@@ -0,0 +1,110 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeQueryEvents parses the JSON lines written by a logging pool
+func decodeQueryEvents(t *testing.T, log *bytes.Buffer) []QueryEvent {
+	var events []QueryEvent
+	for _, line := range strings.Split(strings.TrimSpace(log.String()), "\n") {
+		var event QueryEvent
+		require.NoError(t, json.Unmarshal([]byte(line), &event))
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestLoggingPool(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT id FROM videos").
+		WithArgs("UC123", 20).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow("video1").AddRow("video2"))
+	mock.ExpectQuery("SELECT title FROM videos").
+		WithArgs("missing").
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM videos").
+		WithArgs(nil).
+		WillReturnResult(pgxmock.NewResult("DELETE", 3))
+	mock.ExpectCommit()
+
+	var log bytes.Buffer
+	pool := NewLoggingPool(mock, QueryLogOptions{Log: &log})
+	ctx := context.Background()
+
+	rows, err := pool.Query(ctx, "SELECT id FROM videos\n\t\tWHERE channel_id = $1 LIMIT $2", "UC123", 20)
+	require.NoError(t, err)
+	for rows.Next() {
+	}
+	rows.Close()
+
+	var title string
+	assert.ErrorIs(t, pool.QueryRow(ctx, "SELECT title FROM videos WHERE id = $1", "missing").Scan(&title), pgx.ErrNoRows)
+
+	tx, err := pool.Begin(ctx)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "DELETE FROM videos WHERE note = $1", nil)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit(ctx))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	events := decodeQueryEvents(t, &log)
+	require.Len(t, events, 3)
+	assert.Equal(t, QueryEventQuery, events[0].Event)
+	assert.Equal(t, "SELECT id FROM videos WHERE channel_id = $1 LIMIT $2", events[0].SQL)
+	assert.Equal(t, []string{"<string 5 chars>", "20"}, events[0].Args)
+	assert.Equal(t, int64(2), events[0].Rows)
+	assert.Equal(t, QueryEventQueryRow, events[1].Event)
+	assert.Empty(t, events[1].Error)
+	assert.Equal(t, QueryEventExec, events[2].Event)
+	assert.Equal(t, []string{"NULL"}, events[2].Args)
+	assert.Equal(t, int64(3), events[2].Rows)
+}
+
+func TestLoggingPool_SlowQuery(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec("UPDATE videos").
+		WithArgs("secret title").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1)).
+		WillDelayFor(20 * time.Millisecond)
+	mock.ExpectExec("UPDATE channels").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectQuery("SELECT title FROM videos").
+		WillReturnRows(pgxmock.NewRows([]string{"title"}).AddRow("title")).
+		WillDelayFor(20 * time.Millisecond)
+
+	var warnings bytes.Buffer
+	pool := NewLoggingPool(mock, QueryLogOptions{Warnings: &warnings, SlowThreshold: 10 * time.Millisecond})
+
+	_, err = pool.Exec(context.Background(), "UPDATE videos SET title = $1", "secret title")
+	require.NoError(t, err)
+	_, err = pool.Exec(context.Background(), "UPDATE channels SET name = name")
+	require.NoError(t, err)
+	// QueryRow runs the query when called, so the call is timed from before it
+	var title string
+	require.NoError(t, pool.QueryRow(context.Background(), "SELECT title FROM videos LIMIT 1").Scan(&title))
+
+	assert.Contains(t, warnings.String(), "Warning: slow query took")
+	assert.Contains(t, warnings.String(), "UPDATE videos SET title = $1")
+	assert.Contains(t, warnings.String(), "args: <string 12 chars>")
+	assert.NotContains(t, warnings.String(), "secret")
+	assert.NotContains(t, warnings.String(), "UPDATE channels")
+	assert.Contains(t, warnings.String(), "SELECT title FROM videos LIMIT 1")
+}
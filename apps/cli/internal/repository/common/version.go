@@ -0,0 +1,36 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/jackc/pgx/v5"
+)
+
+// VersionColumn reads the row version of a table row. xmin changes with every update of the row,
+// so it serves as an optimistic lock without a dedicated column.
+const VersionColumn = "xmin::text::bigint"
+
+// RowQuerier is the subset of a pool or transaction MissedUpdate needs
+type RowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// MissedUpdate explains an UPDATE of table that matched no row: the row is gone, or, when the
+// update was guarded by a version, someone else changed it since it was read
+func MissedUpdate(ctx context.Context, q RowQuerier, table, entity, id string, versioned bool) *apperrors.AppError {
+	if !versioned {
+		return apperrors.New(apperrors.CodeNotFound, entity+" not found")
+	}
+
+	sql := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE id = $1 AND workspace = current_workspace())", table)
+	var exists bool
+	if err := q.QueryRow(ctx, sql, id).Scan(&exists); err != nil {
+		return HandlePostgreSQLError(err, "failed to check "+entity)
+	}
+	if !exists {
+		return apperrors.New(apperrors.CodeNotFound, entity+" not found")
+	}
+	return apperrors.New(apperrors.CodeConflict, fmt.Sprintf("%s %s was modified concurrently; reload it and retry", entity, id))
+}
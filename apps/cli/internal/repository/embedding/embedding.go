@@ -0,0 +1,20 @@
+package embedding
+
+import (
+	"context"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// Repository defines operations on segment embeddings (pgvector) in the active workspace.
+// Embeddings of different models are never compared: each is stored with the model that computed it.
+type Repository interface {
+	// Pending returns up to limit segments without an embedding of embeddingModel, in
+	// transcription and segment order; only those of transcriptionID when it is set
+	Pending(ctx context.Context, embeddingModel, transcriptionID string, limit int) ([]*model.TranscriptionSegment, error)
+	// Store saves the embeddings computed by embeddingModel of segments, vectors[i] of segmentIDs[i]
+	Store(ctx context.Context, embeddingModel string, segmentIDs []string, vectors [][]float32) error
+	// Nearest returns the limit segments whose embedding of embeddingModel is closest to vector
+	// by cosine distance, closest first; only those of transcriptions in language when it is set
+	Nearest(ctx context.Context, embeddingModel string, vector []float32, language string, limit int) ([]*model.SegmentMatch, error)
+}
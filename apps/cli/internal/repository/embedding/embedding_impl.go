@@ -0,0 +1,181 @@
+package embedding
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/common"
+)
+
+// Pool interface for abstracting pgx connection pool
+type Pool interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+// embeddingRepository implements Repository using PostgreSQL with pgvector
+type embeddingRepository struct {
+	pool Pool
+}
+
+// NewRepository creates a new instance of Repository
+func NewRepository(pool Pool) Repository {
+	return &embeddingRepository{
+		pool: pool,
+	}
+}
+
+// Pending returns segments without an embedding of embeddingModel. Segments embedded by another
+// model are pending too, so switching models re-embeds everything; empty segments never are.
+func (r *embeddingRepository) Pending(ctx context.Context, embeddingModel, transcriptionID string, limit int) ([]*model.TranscriptionSegment, error) {
+	sql := `SELECT s.id, s.transcription_id, s.segment_index,
+		s.start_time::text, s.end_time::text, s.text, s.confidence
+		FROM transcription_segments s
+		JOIN transcriptions t ON t.id = s.transcription_id
+		WHERE t.workspace = current_workspace()
+			AND (s.embedding IS NULL OR s.embedding_model IS DISTINCT FROM $1)
+			AND s.text <> ''
+			AND ($2 = '' OR s.transcription_id::text = $2)
+		ORDER BY s.transcription_id, s.segment_index
+		LIMIT $3`
+
+	rows, err := r.pool.Query(ctx, sql, embeddingModel, transcriptionID, limit)
+	if err != nil {
+		return nil, handleError(err, "failed to list segments without embeddings")
+	}
+	defer rows.Close()
+
+	var segments []*model.TranscriptionSegment
+	for rows.Next() {
+		var segment model.TranscriptionSegment
+		err := rows.Scan(
+			&segment.ID,
+			&segment.TranscriptionID,
+			&segment.SegmentIndex,
+			&segment.StartTime,
+			&segment.EndTime,
+			&segment.Text,
+			&segment.Confidence,
+		)
+		if err != nil {
+			return nil, common.HandlePostgreSQLError(err, "failed to scan transcription segment")
+		}
+		segments = append(segments, &segment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, handleError(err, "failed to list segments without embeddings")
+	}
+	return segments, nil
+}
+
+// Store saves the embeddings of a batch of segments in one statement
+func (r *embeddingRepository) Store(ctx context.Context, embeddingModel string, segmentIDs []string, vectors [][]float32) error {
+	if len(segmentIDs) != len(vectors) {
+		return apperrors.New(apperrors.CodeInvalidArg, "number of embeddings does not match number of segments")
+	}
+	if len(segmentIDs) == 0 {
+		return nil
+	}
+
+	literals := make([]string, len(vectors))
+	for i, vector := range vectors {
+		literals[i] = formatVector(vector)
+	}
+
+	sql := `UPDATE transcription_segments AS s
+		SET embedding = v.embedding::vector, embedding_model = $1
+		FROM unnest($2::uuid[], $3::text[]) AS v(id, embedding)
+		WHERE s.id = v.id`
+
+	if _, err := r.pool.Exec(ctx, sql, embeddingModel, segmentIDs, literals); err != nil {
+		return handleError(err, "failed to store segment embeddings")
+	}
+	return nil
+}
+
+// Nearest orders the segments by cosine distance (pgvector's <=>) to vector. Embeddings are cast
+// to the dimension of vector, the expression the HNSW index of that dimension is built on
+// (migration 036); the dimension is a literal so the planner can match the partial index.
+func (r *embeddingRepository) Nearest(ctx context.Context, embeddingModel string, vector []float32, language string, limit int) ([]*model.SegmentMatch, error) {
+	if len(vector) == 0 {
+		return nil, apperrors.New(apperrors.CodeInvalidArg, "empty query embedding")
+	}
+
+	dims := strconv.Itoa(len(vector))
+	sql := `SELECT s.id, s.transcription_id, s.segment_index,
+		s.start_time::text, s.end_time::text, s.text, s.confidence,
+		t.video_id, COALESCE(v.title, ''), t.language,
+		s.embedding::vector(` + dims + `) <=> $1::vector(` + dims + `) AS distance
+		FROM transcription_segments s
+		JOIN transcriptions t ON t.id = s.transcription_id
+		LEFT JOIN videos v ON v.workspace = t.workspace AND v.id = t.video_id
+		WHERE t.workspace = current_workspace()
+			AND vector_dims(s.embedding) = ` + dims + `
+			AND s.embedding_model = $2
+			AND ($3 = '' OR t.language = $3)
+		ORDER BY distance
+		LIMIT $4`
+
+	rows, err := r.pool.Query(ctx, sql, formatVector(vector), embeddingModel, language, limit)
+	if err != nil {
+		return nil, handleError(err, "failed to search segment embeddings")
+	}
+	defer rows.Close()
+
+	var matches []*model.SegmentMatch
+	for rows.Next() {
+		var match model.SegmentMatch
+		err := rows.Scan(
+			&match.Segment.ID,
+			&match.Segment.TranscriptionID,
+			&match.Segment.SegmentIndex,
+			&match.Segment.StartTime,
+			&match.Segment.EndTime,
+			&match.Segment.Text,
+			&match.Segment.Confidence,
+			&match.VideoID,
+			&match.VideoTitle,
+			&match.Language,
+			&match.Distance,
+		)
+		if err != nil {
+			return nil, common.HandlePostgreSQLError(err, "failed to scan segment match")
+		}
+		matches = append(matches, &match)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, handleError(err, "failed to search segment embeddings")
+	}
+	return matches, nil
+}
+
+// formatVector writes a vector in pgvector's text format, e.g. [0.1,-0.2,0.3]
+func formatVector(vector []float32) string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, v := range vector {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(v), 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// handleError converts PostgreSQL errors like common.HandlePostgreSQLError, explaining the
+// missing embedding columns or vector type of databases without pgvector
+func handleError(err error, operation string) *apperrors.AppError {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && (pgErr.Code == "42703" || pgErr.Code == "42704") {
+		return apperrors.Wrap(err, apperrors.CodeInternal, "segment embeddings need the pgvector extension; install it and run migration 026")
+	}
+	return common.HandlePostgreSQLError(err, operation)
+}
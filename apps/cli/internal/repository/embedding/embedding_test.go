@@ -0,0 +1,116 @@
+package embedding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// segmentColumns are the segment columns the queries select
+var segmentColumns = []string{"id", "transcription_id", "segment_index", "start_time", "end_time", "text", "confidence"}
+
+func TestEmbeddingRepository_Pending(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("FROM transcription_segments s.*WHERE t.workspace = current_workspace\\(\\).*s.embedding_model IS DISTINCT FROM \\$1.*LIMIT \\$3").
+		WithArgs("text-embedding-3-small", "trans-123", 2).
+		WillReturnRows(pgxmock.NewRows(segmentColumns).
+			AddRow("seg-1", "trans-123", 0, "00:00:00", "00:00:02.5", "Hola", (*float64)(nil)).
+			AddRow("seg-2", "trans-123", 1, "00:00:02.5", "00:00:04", "¿Qué tal?", (*float64)(nil)))
+
+	segments, err := NewRepository(mock).Pending(context.Background(), "text-embedding-3-small", "trans-123", 2)
+	require.NoError(t, err)
+	require.Len(t, segments, 2)
+	assert.Equal(t, "seg-1", segments[0].ID)
+	assert.Equal(t, "¿Qué tal?", segments[1].Text)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEmbeddingRepository_Store(t *testing.T) {
+	t.Run("updates the batch in one statement", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("UPDATE transcription_segments AS s.*unnest\\(\\$2::uuid\\[\\], \\$3::text\\[\\]\\)").
+			WithArgs("text-embedding-3-small", []string{"seg-1", "seg-2"}, []string{"[0.5,-0.25]", "[1,0]"}).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+
+		err = NewRepository(mock).Store(context.Background(), "text-embedding-3-small",
+			[]string{"seg-1", "seg-2"}, [][]float32{{0.5, -0.25}, {1, 0}})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rejects mismatched vectors without querying", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		err = NewRepository(mock).Store(context.Background(), "m", []string{"seg-1"}, nil)
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.CodeInvalidArg, appErr.Code)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("explains a database without pgvector", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("UPDATE transcription_segments").
+			WithArgs("m", []string{"seg-1"}, []string{"[1]"}).
+			WillReturnError(&pgconn.PgError{Code: "42703", Message: `column "embedding" does not exist`})
+
+		err = NewRepository(mock).Store(context.Background(), "m", []string{"seg-1"}, [][]float32{{1}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "pgvector")
+	})
+}
+
+func TestEmbeddingRepository_Nearest(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	columns := append(segmentColumns, "video_id", "title", "language", "distance")
+	mock.ExpectQuery("s.embedding::vector\\(2\\) <=> \\$1::vector\\(2\\) AS distance.*vector_dims\\(s.embedding\\) = 2.*s.embedding_model = \\$2.*t.language = \\$3.*ORDER BY distance.*LIMIT \\$4").
+		WithArgs("[0.1,0.2]", "text-embedding-3-small", "es", 5).
+		WillReturnRows(pgxmock.NewRows(columns).
+			AddRow("seg-9", "trans-1", 12, "00:01:02", "00:01:05", "Ayer comí paella", (*float64)(nil), "vid-1", "Pretérito indefinido", "es", 0.18))
+
+	matches, err := NewRepository(mock).Nearest(context.Background(), "text-embedding-3-small", []float32{0.1, 0.2}, "es", 5)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.SegmentMatch{{
+		Segment: model.TranscriptionSegment{
+			ID: "seg-9", TranscriptionID: "trans-1", SegmentIndex: 12,
+			StartTime: "00:01:02", EndTime: "00:01:05", Text: "Ayer comí paella",
+		},
+		VideoID:    "vid-1",
+		VideoTitle: "Pretérito indefinido",
+		Language:   "es",
+		Distance:   0.18,
+	}}, matches)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEmbeddingRepository_Nearest_EmptyVector(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	_, err = NewRepository(mock).Nearest(context.Background(), "text-embedding-3-small", nil, "", 5)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, apperrors.CodeInvalidArg, appErr.Code)
+}
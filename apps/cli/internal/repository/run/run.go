@@ -0,0 +1,30 @@
+package run
+
+import (
+	"context"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// Repository defines operations for batch run manifest persistence
+type Repository interface {
+	// Create records a new running run with its items, all pending, and sets the run's ID,
+	// creation time and items
+	Create(ctx context.Context, run *model.Run, items []*model.RunItem) error
+
+	// StartItem marks an item of a run running
+	StartItem(ctx context.Context, runID string, position int) error
+
+	// FinishItem records the outcome of an item: completed with the ID of what it produced, or
+	// failed with an error message
+	FinishItem(ctx context.Context, runID string, position int, status string, resultID, errorMessage *string) error
+
+	// SetStatus sets the status of a run; finished statuses also set its finish time
+	SetStatus(ctx context.Context, id string, status string) error
+
+	// GetByID retrieves a run with its item counts and items
+	GetByID(ctx context.Context, id string) (*model.Run, error)
+
+	// List retrieves the most recent runs with their item counts, newest first
+	List(ctx context.Context, limit int) ([]*model.Run, error)
+}
@@ -0,0 +1,202 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/common"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Pool interface for abstracting pgx connection pool
+type Pool interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// runRepository implements Repository using PostgreSQL
+type runRepository struct {
+	pool Pool
+}
+
+// NewRepository creates a new instance of Repository
+func NewRepository(pool Pool) Repository {
+	return &runRepository{
+		pool: pool,
+	}
+}
+
+// workspaceRun restricts run_items statements to the runs of the active workspace
+const workspaceRun = "run_id IN (SELECT id FROM runs WHERE workspace = current_workspace())"
+
+// Create inserts the run and copies its items in one transaction
+func (r *runRepository) Create(ctx context.Context, run *model.Run, items []*model.RunItem) error {
+	options, err := json.Marshal(run.Options)
+	if err != nil {
+		return apperrors.Wrap(err, apperrors.CodeInternal, "failed to encode run options")
+	}
+	if run.Options == nil {
+		options = []byte("{}")
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	sql := `INSERT INTO runs (command, target, options, status) VALUES ($1, $2, $3, $4) RETURNING id, created_at`
+	run.Status = model.RunStatusRunning
+	if err := tx.QueryRow(ctx, sql, run.Command, run.Target, options, run.Status).Scan(&run.ID, &run.CreatedAt); err != nil {
+		return common.HandlePostgreSQLError(err, "failed to create run")
+	}
+
+	if len(items) > 0 {
+		rows := make([][]interface{}, len(items))
+		for i, item := range items {
+			item.Position = i
+			item.Status = model.RunStatusPending
+			rows[i] = []interface{}{run.ID, item.Position, item.ItemID, item.Title}
+		}
+
+		columns := []string{"run_id", "position", "item_id", "title"}
+		if _, err := tx.CopyFrom(ctx, pgx.Identifier{"run_items"}, columns, pgx.CopyFromRows(rows)); err != nil {
+			return common.HandlePostgreSQLError(err, "failed to insert run items")
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return common.HandlePostgreSQLError(err, "failed to commit run")
+	}
+	run.Items = items
+	run.Total = len(items)
+	return nil
+}
+
+// StartItem marks an item running, clearing the outcome of an earlier attempt
+func (r *runRepository) StartItem(ctx context.Context, runID string, position int) error {
+	sql := `UPDATE run_items SET status = 'running', started_at = NOW(), finished_at = NULL, result_id = NULL, error_message = NULL
+		WHERE run_id = $1 AND position = $2 AND ` + workspaceRun
+	return r.updateItem(ctx, sql, runID, position)
+}
+
+// FinishItem records the outcome of an item
+func (r *runRepository) FinishItem(ctx context.Context, runID string, position int, status string, resultID, errorMessage *string) error {
+	sql := `UPDATE run_items SET status = $3, result_id = $4, error_message = $5, finished_at = NOW()
+		WHERE run_id = $1 AND position = $2 AND ` + workspaceRun
+	return r.updateItem(ctx, sql, runID, position, status, resultID, errorMessage)
+}
+
+// updateItem runs an UPDATE of one run item
+func (r *runRepository) updateItem(ctx context.Context, sql string, args ...any) error {
+	tag, err := r.pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to update run item")
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.New(apperrors.CodeNotFound, "run item not found")
+	}
+	return nil
+}
+
+// SetStatus sets the status of a run; a running run has no finish time
+func (r *runRepository) SetStatus(ctx context.Context, id string, status string) error {
+	sql := `UPDATE runs SET status = $2, finished_at = CASE WHEN $2 = 'running' THEN NULL ELSE NOW() END
+		WHERE id = $1 AND workspace = current_workspace()`
+	tag, err := r.pool.Exec(ctx, sql, id, status)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to set run status")
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.New(apperrors.CodeNotFound, "run not found")
+	}
+	return nil
+}
+
+// runColumns selects a run with its item counts
+const runColumns = `SELECT r.id, r.command, r.target, r.options, r.status, r.created_at, r.finished_at,
+		(SELECT COUNT(*) FROM run_items i WHERE i.run_id = r.id),
+		(SELECT COUNT(*) FROM run_items i WHERE i.run_id = r.id AND i.status = 'completed'),
+		(SELECT COUNT(*) FROM run_items i WHERE i.run_id = r.id AND i.status = 'failed')
+	FROM runs r`
+
+// GetByID retrieves a run with its item counts and items
+func (r *runRepository) GetByID(ctx context.Context, id string) (*model.Run, error) {
+	sql := runColumns + ` WHERE r.id = $1 AND r.workspace = current_workspace()`
+	run, err := scanRun(r.pool.QueryRow(ctx, sql, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.Wrap(err, apperrors.CodeNotFound, "run not found")
+		}
+		return nil, common.HandlePostgreSQLError(err, "failed to get run")
+	}
+
+	sql = `SELECT position, item_id, title, status, result_id, error_message, started_at, finished_at
+		FROM run_items WHERE run_id = $1 ORDER BY position`
+	rows, err := r.pool.Query(ctx, sql, id)
+	if err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to get run items")
+	}
+	defer rows.Close()
+
+	run.Items = []*model.RunItem{}
+	for rows.Next() {
+		var item model.RunItem
+		err := rows.Scan(&item.Position, &item.ItemID, &item.Title, &item.Status, &item.ResultID, &item.ErrorMessage, &item.StartedAt, &item.FinishedAt)
+		if err != nil {
+			return nil, common.HandlePostgreSQLError(err, "failed to scan run item row")
+		}
+		run.Items = append(run.Items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to iterate run item rows")
+	}
+
+	return run, nil
+}
+
+// List retrieves the most recent runs, newest first
+func (r *runRepository) List(ctx context.Context, limit int) ([]*model.Run, error) {
+	sql := runColumns + ` WHERE r.workspace = current_workspace() ORDER BY r.created_at DESC LIMIT $1`
+	rows, err := r.pool.Query(ctx, sql, limit)
+	if err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to list runs")
+	}
+	defer rows.Close()
+
+	runs := []*model.Run{}
+	for rows.Next() {
+		run, err := scanRun(rows)
+		if err != nil {
+			return nil, common.HandlePostgreSQLError(err, "failed to scan run row")
+		}
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to iterate run rows")
+	}
+
+	return runs, nil
+}
+
+// scanRun scans a row of runColumns
+func scanRun(row pgx.Row) (*model.Run, error) {
+	var run model.Run
+	var options []byte
+	err := row.Scan(&run.ID, &run.Command, &run.Target, &options, &run.Status, &run.CreatedAt, &run.FinishedAt, &run.Total, &run.Completed, &run.Failed)
+	if err != nil {
+		return nil, err
+	}
+	if len(options) > 0 {
+		if err := json.Unmarshal(options, &run.Options); err != nil {
+			return nil, apperrors.Wrap(err, apperrors.CodeInternal, "failed to decode run options")
+		}
+	}
+	return &run, nil
+}
@@ -0,0 +1,147 @@
+package run
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var runRowColumns = []string{"id", "command", "target", "options", "status", "created_at", "finished_at", "total", "completed", "failed"}
+
+func TestRunRepository_Create(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO runs \\(command, target, options, status\\)").
+		WithArgs("transcription create-batch", "UC123", []byte(`{"language":"ja"}`), "running").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow("run-1", now))
+	mock.ExpectCopyFrom(pgx.Identifier{"run_items"}, []string{"run_id", "position", "item_id", "title"}).
+		WillReturnResult(2)
+	mock.ExpectCommit()
+
+	run := &model.Run{Command: "transcription create-batch", Target: "UC123", Options: map[string]string{"language": "ja"}}
+	items := []*model.RunItem{{ItemID: "video1", Title: "First"}, {ItemID: "video2", Title: "Second"}}
+	require.NoError(t, NewRepository(mock).Create(context.Background(), run, items))
+
+	assert.Equal(t, "run-1", run.ID)
+	assert.Equal(t, model.RunStatusRunning, run.Status)
+	assert.Equal(t, 2, run.Total)
+	assert.Equal(t, 1, run.Items[1].Position)
+	assert.Equal(t, model.RunStatusPending, run.Items[1].Status)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunRepository_Items(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	transcriptionID := "trans-1"
+	mock.ExpectExec("UPDATE run_items SET status = 'running'").
+		WithArgs("run-1", 0).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("UPDATE run_items SET status = \\$3").
+		WithArgs("run-1", 0, "completed", &transcriptionID, (*string)(nil)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("UPDATE run_items SET status = 'running'").
+		WithArgs("run-1", 9).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	repo := NewRepository(mock)
+	require.NoError(t, repo.StartItem(context.Background(), "run-1", 0))
+	require.NoError(t, repo.FinishItem(context.Background(), "run-1", 0, model.RunStatusCompleted, &transcriptionID, nil))
+
+	err = repo.StartItem(context.Background(), "run-1", 9)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, apperrors.CodeNotFound, appErr.Code)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunRepository_SetStatus(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec("UPDATE runs SET status = \\$2").
+		WithArgs("run-1", "failed").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	require.NoError(t, NewRepository(mock).SetStatus(context.Background(), "run-1", model.RunStatusFailed))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunRepository_GetByID(t *testing.T) {
+	t.Run("run with items", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		now := time.Now()
+		failure := "whisper transcription failed"
+		mock.ExpectQuery("SELECT (.+) FROM runs r WHERE r.id = \\$1").
+			WithArgs("run-1").
+			WillReturnRows(pgxmock.NewRows(runRowColumns).
+				AddRow("run-1", "transcription create-batch", "UC123", []byte(`{"language":"ja","model":"base"}`), "failed", now, &now, 2, 1, 1))
+		mock.ExpectQuery("SELECT (.+) FROM run_items WHERE run_id = \\$1 ORDER BY position").
+			WithArgs("run-1").
+			WillReturnRows(pgxmock.NewRows([]string{"position", "item_id", "title", "status", "result_id", "error_message", "started_at", "finished_at"}).
+				AddRow(0, "video1", "First", "completed", nil, nil, &now, &now).
+				AddRow(1, "video2", "Second", "failed", nil, &failure, &now, &now))
+
+		run, err := NewRepository(mock).GetByID(context.Background(), "run-1")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"language": "ja", "model": "base"}, run.Options)
+		assert.Equal(t, 1, run.Failed)
+		require.Len(t, run.Items, 2)
+		require.Len(t, run.Unfinished(), 1)
+		assert.Equal(t, "video2", run.Unfinished()[0].ItemID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing run", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery("SELECT (.+) FROM runs r WHERE r.id = \\$1").
+			WithArgs("run-missing").
+			WillReturnError(pgx.ErrNoRows)
+
+		_, err = NewRepository(mock).GetByID(context.Background(), "run-missing")
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.CodeNotFound, appErr.Code)
+	})
+}
+
+func TestRunRepository_List(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT (.+) FROM runs r WHERE r.workspace = current_workspace\\(\\) ORDER BY r.created_at DESC LIMIT \\$1").
+		WithArgs(20).
+		WillReturnRows(pgxmock.NewRows(runRowColumns).
+			AddRow("run-2", "transcription create-batch", "UC123", []byte(`{}`), "running", now, nil, 5, 2, 0).
+			AddRow("run-1", "transcription create-batch", "UC456", []byte(`{}`), "completed", now, &now, 3, 3, 0))
+
+	runs, err := NewRepository(mock).List(context.Background(), 20)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, "run-2", runs[0].ID)
+	assert.Equal(t, 5, runs[0].Total)
+	assert.Nil(t, runs[0].FinishedAt)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
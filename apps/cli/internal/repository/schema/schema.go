@@ -0,0 +1,14 @@
+package schema
+
+import (
+	"context"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// Repository reads the data model from the database catalog
+type Repository interface {
+	// Dump returns the tables of the current schema with their columns, primary keys and
+	// foreign keys, ordered by name. The migration bookkeeping table is left out.
+	Dump(ctx context.Context) (*model.Schema, error)
+}
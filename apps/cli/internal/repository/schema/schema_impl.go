@@ -0,0 +1,138 @@
+package schema
+
+import (
+	"context"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/common"
+	"github.com/jackc/pgx/v5"
+)
+
+// Pool interface for abstracting pgx connection pool
+type Pool interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// schemaRepository implements Repository using the PostgreSQL catalog
+type schemaRepository struct {
+	pool Pool
+}
+
+// NewRepository creates a new instance of Repository
+func NewRepository(pool Pool) Repository {
+	return &schemaRepository{
+		pool: pool,
+	}
+}
+
+// migrationsTable is where golang-migrate keeps the applied migration version
+const migrationsTable = "schema_migrations"
+
+// onDeleteActions maps pg_constraint.confdeltype onto the SQL of the action
+var onDeleteActions = map[string]string{
+	"a": "NO ACTION",
+	"r": "RESTRICT",
+	"c": "CASCADE",
+	"n": "SET NULL",
+	"d": "SET DEFAULT",
+}
+
+// Dump returns the tables of the current schema with their columns and keys
+func (r *schemaRepository) Dump(ctx context.Context) (*model.Schema, error) {
+	schema := &model.Schema{Tables: []*model.SchemaTable{}}
+	if err := r.columns(ctx, schema); err != nil {
+		return nil, err
+	}
+	if err := r.constraints(ctx, schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// columns adds the tables of the current schema and their columns, in table order
+func (r *schemaRepository) columns(ctx context.Context, schema *model.Schema) error {
+	sql := `SELECT c.relname, a.attname, format_type(a.atttypid, a.atttypmod), NOT a.attnotnull,
+			pg_get_expr(d.adbin, d.adrelid)
+		FROM pg_attribute a
+		JOIN pg_class c ON c.oid = a.attrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE n.nspname = current_schema() AND c.relkind IN ('r', 'p') AND c.relname <> $1
+			AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY c.relname, a.attnum`
+
+	rows, err := r.pool.Query(ctx, sql, migrationsTable)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to read table columns")
+	}
+	defer rows.Close()
+
+	var table *model.SchemaTable
+	for rows.Next() {
+		var tableName string
+		column := &model.SchemaColumn{}
+		if err := rows.Scan(&tableName, &column.Name, &column.Type, &column.Nullable, &column.Default); err != nil {
+			return common.HandlePostgreSQLError(err, "failed to scan table column")
+		}
+		if table == nil || table.Name != tableName {
+			table = &model.SchemaTable{Name: tableName, PrimaryKey: []string{}, ForeignKeys: []*model.SchemaForeignKey{}}
+			schema.Tables = append(schema.Tables, table)
+		}
+		table.Columns = append(table.Columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return common.HandlePostgreSQLError(err, "failed to iterate table columns")
+	}
+	return nil
+}
+
+// constraints adds the primary and foreign keys of the tables, with their columns in key order
+func (r *schemaRepository) constraints(ctx context.Context, schema *model.Schema) error {
+	// contype is a "char", which doesn't scan into a string in the binary format
+	sql := `SELECT con.conname, con.contype::text, c.relname,
+			ARRAY(SELECT a.attname::text FROM unnest(con.conkey) WITH ORDINALITY k(attnum, ord)
+				JOIN pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum ORDER BY k.ord),
+			COALESCE(rc.relname, ''),
+			ARRAY(SELECT a.attname::text FROM unnest(con.confkey) WITH ORDINALITY k(attnum, ord)
+				JOIN pg_attribute a ON a.attrelid = con.confrelid AND a.attnum = k.attnum ORDER BY k.ord),
+			con.confdeltype::text
+		FROM pg_constraint con
+		JOIN pg_class c ON c.oid = con.conrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_class rc ON rc.oid = con.confrelid
+		WHERE n.nspname = current_schema() AND con.contype IN ('p', 'f')
+		ORDER BY c.relname, con.conname`
+
+	rows, err := r.pool.Query(ctx, sql)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to read table keys")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, kind, tableName, referencedTable, onDelete string
+		var columns, referencedColumns []string
+		if err := rows.Scan(&name, &kind, &tableName, &columns, &referencedTable, &referencedColumns, &onDelete); err != nil {
+			return common.HandlePostgreSQLError(err, "failed to scan table key")
+		}
+		table := schema.Table(tableName)
+		if table == nil {
+			continue
+		}
+		if kind == "p" {
+			table.PrimaryKey = columns
+			continue
+		}
+		table.ForeignKeys = append(table.ForeignKeys, &model.SchemaForeignKey{
+			Name:              name,
+			Columns:           columns,
+			ReferencedTable:   referencedTable,
+			ReferencedColumns: referencedColumns,
+			OnDelete:          onDeleteActions[onDelete],
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return common.HandlePostgreSQLError(err, "failed to iterate table keys")
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+//go:build integration
+
+package schema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Taichi-iskw/yt-lang/internal/repository/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSchemaRepository_Integration dumps the migrated schema from real PostgreSQL, scanning the
+// catalog types in the binary format
+func TestSchemaRepository_Integration(t *testing.T) {
+	pool := common.SetupTestDB(t)
+
+	schema, err := NewRepository(pool).Dump(context.Background())
+	require.NoError(t, err)
+
+	videos := schema.Table("videos")
+	require.NotNil(t, videos)
+	assert.Equal(t, []string{"workspace", "id"}, videos.PrimaryKey)
+
+	var referenced []string
+	for _, key := range videos.ForeignKeys {
+		referenced = append(referenced, key.ReferencedTable)
+		assert.NotEmpty(t, key.OnDelete)
+	}
+	assert.Contains(t, referenced, "channels")
+}
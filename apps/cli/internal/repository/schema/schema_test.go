@@ -0,0 +1,87 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+var (
+	columnColumns     = []string{"relname", "attname", "type", "nullable", "default"}
+	constraintColumns = []string{"conname", "contype", "relname", "columns", "referenced_table", "referenced_columns", "confdeltype"}
+)
+
+func TestSchemaRepository_Dump(t *testing.T) {
+	t.Run("reads tables, columns and keys", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		workspaceDefault := "current_workspace()"
+		mock.ExpectQuery("SELECT c.relname, a.attname, format_type.*FROM pg_attribute a.*WHERE n.nspname = current_schema\\(\\)").
+			WithArgs("schema_migrations").
+			WillReturnRows(pgxmock.NewRows(columnColumns).
+				AddRow("channels", "workspace", "character varying(100)", false, &workspaceDefault).
+				AddRow("channels", "id", "character varying(255)", false, (*string)(nil)).
+				AddRow("videos", "workspace", "character varying(100)", false, &workspaceDefault).
+				AddRow("videos", "id", "character varying(255)", false, (*string)(nil)).
+				AddRow("videos", "channel_id", "character varying(255)", true, (*string)(nil)))
+		mock.ExpectQuery("SELECT con.conname, con.contype::text.*FROM pg_constraint con.*con.contype IN \\('p', 'f'\\)").
+			WillReturnRows(pgxmock.NewRows(constraintColumns).
+				AddRow("channels_pkey", "p", "channels", []string{"workspace", "id"}, "", []string{}, " ").
+				AddRow("fk_videos_channel_id", "f", "videos", []string{"workspace", "channel_id"}, "channels", []string{"workspace", "id"}, "c").
+				AddRow("videos_pkey", "p", "videos", []string{"workspace", "id"}, "", []string{}, " "))
+
+		schema, err := NewRepository(mock).Dump(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, &model.Schema{Tables: []*model.SchemaTable{
+			{
+				Name: "channels",
+				Columns: []*model.SchemaColumn{
+					{Name: "workspace", Type: "character varying(100)", Default: &workspaceDefault},
+					{Name: "id", Type: "character varying(255)"},
+				},
+				PrimaryKey:  []string{"workspace", "id"},
+				ForeignKeys: []*model.SchemaForeignKey{},
+			},
+			{
+				Name: "videos",
+				Columns: []*model.SchemaColumn{
+					{Name: "workspace", Type: "character varying(100)", Default: &workspaceDefault},
+					{Name: "id", Type: "character varying(255)"},
+					{Name: "channel_id", Type: "character varying(255)", Nullable: true},
+				},
+				PrimaryKey: []string{"workspace", "id"},
+				ForeignKeys: []*model.SchemaForeignKey{{
+					Name:              "fk_videos_channel_id",
+					Columns:           []string{"workspace", "channel_id"},
+					ReferencedTable:   "channels",
+					ReferencedColumns: []string{"workspace", "id"},
+					OnDelete:          "CASCADE",
+				}},
+			},
+		}}, schema)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("catalog error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery("FROM pg_attribute").WithArgs("schema_migrations").WillReturnError(errors.New("permission denied"))
+
+		_, err = NewRepository(mock).Dump(context.Background())
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.CodeInternal, appErr.Code)
+	})
+}
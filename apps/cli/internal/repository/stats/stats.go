@@ -0,0 +1,14 @@
+package stats
+
+import (
+	"context"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// Repository defines aggregate queries over the active workspace
+type Repository interface {
+	// Overview counts channels, videos, transcriptions and translated segments.
+	// CacheBytes is left zero: caches live on local disk, not in the database.
+	Overview(ctx context.Context) (*model.LibraryStats, error)
+}
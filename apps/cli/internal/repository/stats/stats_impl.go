@@ -0,0 +1,96 @@
+package stats
+
+import (
+	"context"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/common"
+	"github.com/jackc/pgx/v5"
+)
+
+// Pool interface for abstracting pgx connection pool
+type Pool interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// statsRepository implements Repository using PostgreSQL
+type statsRepository struct {
+	pool Pool
+}
+
+// NewRepository creates a new instance of Repository
+func NewRepository(pool Pool) Repository {
+	return &statsRepository{
+		pool: pool,
+	}
+}
+
+// Overview counts channels, videos, transcriptions and translated segments
+func (r *statsRepository) Overview(ctx context.Context) (*model.LibraryStats, error) {
+	// Transcriptions without a stored duration count the whole video
+	sql := `SELECT
+			(SELECT COUNT(*) FROM channels WHERE workspace = current_workspace()),
+			v.videos, v.unavailable, v.seconds,
+			t.transcriptions, t.completed, t.failed, t.pending, t.seconds
+		FROM (
+			SELECT COUNT(*) AS videos,
+				COUNT(*) FILTER (WHERE status = 'unavailable') AS unavailable,
+				COALESCE(SUM(duration), 0)::float8 AS seconds
+			FROM videos WHERE workspace = current_workspace()
+		) v, (
+			SELECT COUNT(*) AS transcriptions,
+				COUNT(*) FILTER (WHERE t.status = 'completed') AS completed,
+				COUNT(*) FILTER (WHERE t.status = 'failed') AS failed,
+				COUNT(*) FILTER (WHERE t.status IN ('pending', 'processing')) AS pending,
+				COALESCE(SUM(COALESCE(EXTRACT(EPOCH FROM t.total_duration), vd.duration)) FILTER (WHERE t.status = 'completed'), 0)::float8 AS seconds
+			FROM transcriptions t
+			LEFT JOIN videos vd ON vd.workspace = t.workspace AND vd.id = t.video_id
+			WHERE t.workspace = current_workspace()
+		) t`
+
+	stats := &model.LibraryStats{Translations: []*model.LanguageStats{}}
+	var videoSeconds, transcribedSeconds float64
+	err := r.pool.QueryRow(ctx, sql).Scan(
+		&stats.Channels,
+		&stats.Videos,
+		&stats.UnavailableVideos,
+		&videoSeconds,
+		&stats.Transcriptions,
+		&stats.CompletedTranscriptions,
+		&stats.FailedTranscriptions,
+		&stats.PendingTranscriptions,
+		&transcribedSeconds,
+	)
+	if err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to count library totals")
+	}
+	stats.VideoHours = videoSeconds / 3600
+	stats.TranscribedHours = transcribedSeconds / 3600
+
+	// A segment translated in several styles or by several providers counts once
+	sql = `SELECT tr.target_language, COUNT(DISTINCT ts.id), COUNT(DISTINCT ts.id) FILTER (WHERE tr.approved)
+		FROM translations tr
+		JOIN transcription_segments ts ON ts.id = tr.transcription_segment_id
+		JOIN transcriptions t ON t.id = ts.transcription_id
+		WHERE t.workspace = current_workspace()
+		GROUP BY tr.target_language
+		ORDER BY COUNT(DISTINCT ts.id) DESC, tr.target_language`
+	rows, err := r.pool.Query(ctx, sql)
+	if err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to count translations by language")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		language := &model.LanguageStats{}
+		if err := rows.Scan(&language.Language, &language.Segments, &language.Approved); err != nil {
+			return nil, common.HandlePostgreSQLError(err, "failed to scan translation counts")
+		}
+		stats.Translations = append(stats.Translations, language)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to iterate translation counts")
+	}
+	return stats, nil
+}
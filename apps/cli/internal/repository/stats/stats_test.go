@@ -0,0 +1,81 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// totalColumns are the columns of the totals query
+var totalColumns = []string{"channels", "videos", "unavailable", "video_seconds", "transcriptions", "completed", "failed", "pending", "transcribed_seconds"}
+
+func TestStatsRepository_Overview(t *testing.T) {
+	t.Run("aggregates the workspace", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery("SELECT .*FROM channels WHERE workspace = current_workspace\\(\\).*FROM videos WHERE workspace = current_workspace\\(\\).*FROM transcriptions t").
+			WillReturnRows(pgxmock.NewRows(totalColumns).AddRow(2, 40, 3, 36000.0, 30, 25, 4, 1, 27000.0))
+		mock.ExpectQuery("SELECT tr.target_language, COUNT\\(DISTINCT ts.id\\).*WHERE t.workspace = current_workspace\\(\\).*GROUP BY tr.target_language").
+			WillReturnRows(pgxmock.NewRows([]string{"target_language", "segments", "approved"}).
+				AddRow("en", 1200, 300).
+				AddRow("ja", 80, 0))
+
+		stats, err := NewRepository(mock).Overview(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, &model.LibraryStats{
+			Channels:                2,
+			Videos:                  40,
+			UnavailableVideos:       3,
+			VideoHours:              10,
+			Transcriptions:          30,
+			CompletedTranscriptions: 25,
+			FailedTranscriptions:    4,
+			PendingTranscriptions:   1,
+			TranscribedHours:        7.5,
+			Translations: []*model.LanguageStats{
+				{Language: "en", Segments: 1200, Approved: 300},
+				{Language: "ja", Segments: 80, Approved: 0},
+			},
+		}, stats)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("empty workspace has no translations", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery("FROM channels").
+			WillReturnRows(pgxmock.NewRows(totalColumns).AddRow(0, 0, 0, 0.0, 0, 0, 0, 0, 0.0))
+		mock.ExpectQuery("FROM translations tr").
+			WillReturnRows(pgxmock.NewRows([]string{"target_language", "segments", "approved"}))
+
+		stats, err := NewRepository(mock).Overview(context.Background())
+		require.NoError(t, err)
+		assert.NotNil(t, stats.Translations)
+		assert.Empty(t, stats.Translations)
+	})
+
+	t.Run("query failure is an internal error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery("FROM channels").WillReturnError(errors.New("connection reset"))
+
+		_, err = NewRepository(mock).Overview(context.Background())
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.CodeInternal, appErr.Code)
+	})
+}
@@ -0,0 +1,65 @@
+package transcription
+
+import (
+	"context"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/common"
+)
+
+// hallucinationRepository implements HallucinationRepository using PostgreSQL
+type hallucinationRepository struct {
+	pool Pool
+}
+
+// NewHallucinationRepository creates a new hallucination phrase repository
+func NewHallucinationRepository(pool Pool) HallucinationRepository {
+	return &hallucinationRepository{
+		pool: pool,
+	}
+}
+
+// List retrieves the hallucination phrases of the workspace, sorted
+func (r *hallucinationRepository) List(ctx context.Context) ([]string, error) {
+	sql := "SELECT phrase FROM hallucination_phrases WHERE workspace = current_workspace() ORDER BY phrase"
+	rows, err := r.pool.Query(ctx, sql)
+	if err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to list hallucination phrases")
+	}
+	defer rows.Close()
+
+	phrases := []string{}
+	for rows.Next() {
+		var phrase string
+		if err := rows.Scan(&phrase); err != nil {
+			return nil, common.HandlePostgreSQLError(err, "failed to scan hallucination phrase")
+		}
+		phrases = append(phrases, phrase)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to list hallucination phrases")
+	}
+	return phrases, nil
+}
+
+// Add adds a hallucination phrase to the workspace's list
+func (r *hallucinationRepository) Add(ctx context.Context, phrase string) error {
+	sql := "INSERT INTO hallucination_phrases (phrase) VALUES ($1) ON CONFLICT DO NOTHING"
+	if _, err := r.pool.Exec(ctx, sql, phrase); err != nil {
+		return common.HandlePostgreSQLError(err, "failed to add hallucination phrase")
+	}
+	return nil
+}
+
+// Remove removes a hallucination phrase from the workspace's list
+func (r *hallucinationRepository) Remove(ctx context.Context, phrase string) error {
+	sql := "DELETE FROM hallucination_phrases WHERE workspace = current_workspace() AND phrase = $1"
+	tag, err := r.pool.Exec(ctx, sql, phrase)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to remove hallucination phrase")
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.New(apperrors.CodeNotFound, "hallucination phrase not found")
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package transcription
+
+import (
+	"context"
+	"testing"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHallucinationRepository(t *testing.T) {
+	t.Run("lists phrases of the workspace", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery("SELECT phrase FROM hallucination_phrases WHERE workspace = current_workspace\\(\\) ORDER BY phrase").
+			WillReturnRows(pgxmock.NewRows([]string{"phrase"}).AddRow("Amara.org").AddRow("Suscríbete"))
+
+		phrases, err := NewHallucinationRepository(mock).List(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Amara.org", "Suscríbete"}, phrases)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("adds phrases once", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("INSERT INTO hallucination_phrases \\(phrase\\) VALUES \\(\\$1\\) ON CONFLICT DO NOTHING").
+			WithArgs("Suscríbete").
+			WillReturnResult(pgxmock.NewResult("INSERT", 0))
+
+		assert.NoError(t, NewHallucinationRepository(mock).Add(context.Background(), "Suscríbete"))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("removing an unlisted phrase is not found", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("DELETE FROM hallucination_phrases WHERE workspace = current_workspace\\(\\) AND phrase = \\$1").
+			WithArgs("Suscríbete").
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+		err = NewHallucinationRepository(mock).Remove(context.Background(), "Suscríbete")
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.CodeNotFound, appErr.Code)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -42,7 +42,7 @@ func TestSegmentRepository_CreateBatch(t *testing.T) {
 			},
 			setup: func(mock pgxmock.PgxPoolIface) {
 				mock.ExpectCopyFrom(pgx.Identifier{"transcription_segments"},
-					[]string{"transcription_id", "segment_index", "start_time", "end_time", "text", "confidence"}).
+					[]string{"transcription_id", "segment_index", "start_time", "end_time", "text", "confidence", "speaker"}).
 					WillReturnResult(2)
 			},
 			wantErr: false,
@@ -70,7 +70,7 @@ func TestSegmentRepository_CreateBatch(t *testing.T) {
 			},
 			setup: func(mock pgxmock.PgxPoolIface) {
 				mock.ExpectCopyFrom(pgx.Identifier{"transcription_segments"},
-					[]string{"transcription_id", "segment_index", "start_time", "end_time", "text", "confidence"}).
+					[]string{"transcription_id", "segment_index", "start_time", "end_time", "text", "confidence", "speaker"}).
 					WillReturnError(assert.AnError)
 			},
 			wantErr: true,
@@ -99,6 +99,54 @@ func TestSegmentRepository_CreateBatch(t *testing.T) {
 	}
 }
 
+func TestSegmentRepository_CreateBatchWithProgress(t *testing.T) {
+	columns := []string{"transcription_id", "segment_index", "start_time", "end_time", "text", "confidence", "speaker"}
+	segments := make([]*model.TranscriptionSegment, 2*segmentCopyChunkSize+1)
+	for i := range segments {
+		segments[i] = &model.TranscriptionSegment{TranscriptionID: "trans-123", SegmentIndex: i, StartTime: "00:00:00", EndTime: "00:00:01", Text: "x"}
+	}
+
+	t.Run("copies chunks in one transaction", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectCopyFrom(pgx.Identifier{"transcription_segments"}, columns).WillReturnResult(segmentCopyChunkSize)
+		mock.ExpectCopyFrom(pgx.Identifier{"transcription_segments"}, columns).WillReturnResult(segmentCopyChunkSize)
+		mock.ExpectCopyFrom(pgx.Identifier{"transcription_segments"}, columns).WillReturnResult(1)
+		mock.ExpectCommit()
+
+		var reported []int
+		repo := NewSegmentRepository(mock)
+		err = repo.CreateBatchWithProgress(context.Background(), segments, func(saved, total int) {
+			assert.Equal(t, len(segments), total)
+			reported = append(reported, saved)
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{segmentCopyChunkSize, 2 * segmentCopyChunkSize, 2*segmentCopyChunkSize + 1}, reported)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rolls back when a chunk fails", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectCopyFrom(pgx.Identifier{"transcription_segments"}, columns).WillReturnResult(segmentCopyChunkSize)
+		mock.ExpectCopyFrom(pgx.Identifier{"transcription_segments"}, columns).WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		repo := NewSegmentRepository(mock)
+		err = repo.CreateBatchWithProgress(context.Background(), segments, nil)
+
+		assert.Error(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestSegmentRepository_GetByTranscriptionID(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -114,10 +162,10 @@ func TestSegmentRepository_GetByTranscriptionID(t *testing.T) {
 				conf1 := 0.95
 				conf2 := 0.92
 				rows := pgxmock.NewRows([]string{
-					"id", "transcription_id", "segment_index", "start_time", "end_time", "text", "confidence",
+					"id", "transcription_id", "segment_index", "start_time", "end_time", "text", "confidence", "speaker",
 				}).
-					AddRow("seg-1", "trans-123", 0, "00:00:00", "00:00:02.5", "Hello, this is a test.", &conf1).
-					AddRow("seg-2", "trans-123", 1, "00:00:02.5", "00:00:06", "We're learning Go.", &conf2)
+					AddRow("seg-1", "trans-123", 0, "00:00:00", "00:00:02.5", "Hello, this is a test.", &conf1, (*string)(nil)).
+					AddRow("seg-2", "trans-123", 1, "00:00:02.5", "00:00:06", "We're learning Go.", &conf2, (*string)(nil))
 
 				mock.ExpectQuery("SELECT (.+) FROM transcription_segments WHERE transcription_id").
 					WithArgs("trans-123").
@@ -131,7 +179,7 @@ func TestSegmentRepository_GetByTranscriptionID(t *testing.T) {
 			transcriptionID: "trans-456",
 			setup: func(mock pgxmock.PgxPoolIface) {
 				rows := pgxmock.NewRows([]string{
-					"id", "transcription_id", "segment_index", "start_time", "end_time", "text", "confidence",
+					"id", "transcription_id", "segment_index", "start_time", "end_time", "text", "confidence", "speaker",
 				})
 
 				mock.ExpectQuery("SELECT (.+) FROM transcription_segments WHERE transcription_id").
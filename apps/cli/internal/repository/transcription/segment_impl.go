@@ -2,12 +2,21 @@ package transcription
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/Taichi-iskw/yt-lang/internal/model"
 	"github.com/Taichi-iskw/yt-lang/internal/repository/common"
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
 	"github.com/jackc/pgx/v5"
 )
 
+// defaultSegmentBatchSize is the number of segments fetched per query when iterating
+const defaultSegmentBatchSize = 500
+
+// segmentCopyChunkSize is the number of segments inserted per COPY FROM
+const segmentCopyChunkSize = 5000
+
 // segmentRepository implements SegmentRepository using PostgreSQL
 type segmentRepository struct {
 	pool Pool
@@ -22,10 +31,50 @@ func NewSegmentRepository(pool Pool) SegmentRepository {
 
 // CreateBatch creates multiple transcription segments using COPY FROM for performance
 func (r *segmentRepository) CreateBatch(ctx context.Context, segments []*model.TranscriptionSegment) error {
+	return r.CreateBatchWithProgress(ctx, segments, nil)
+}
+
+// CreateBatchWithProgress copies segments in chunks of segmentCopyChunkSize rows within one
+// transaction, so whisper output of long videos doesn't become one huge COPY
+func (r *segmentRepository) CreateBatchWithProgress(ctx context.Context, segments []*model.TranscriptionSegment, progress func(saved, total int)) error {
 	if len(segments) == 0 {
 		return nil // Nothing to insert
 	}
 
+	// A single chunk needs no transaction of its own
+	if len(segments) <= segmentCopyChunkSize {
+		return copySegments(ctx, r.pool, segments)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to begin segment copy")
+	}
+	defer tx.Rollback(ctx)
+
+	for start := 0; start < len(segments); start += segmentCopyChunkSize {
+		end := min(start+segmentCopyChunkSize, len(segments))
+		if err := copySegments(ctx, tx, segments[start:end]); err != nil {
+			return err
+		}
+		if progress != nil {
+			progress(end, len(segments))
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return common.HandlePostgreSQLError(err, "failed to commit transcription segments")
+	}
+	return nil
+}
+
+// copier is the pool or transaction segments are copied with
+type copier interface {
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+// copySegments inserts segments with one COPY FROM
+func copySegments(ctx context.Context, db copier, segments []*model.TranscriptionSegment) error {
 	// Prepare data for COPY FROM
 	rows := make([][]interface{}, len(segments))
 	for i, segment := range segments {
@@ -36,14 +85,15 @@ func (r *segmentRepository) CreateBatch(ctx context.Context, segments []*model.T
 			segment.EndTime,
 			segment.Text,
 			segment.Confidence,
+			segment.Speaker,
 		}
 	}
 
 	// Use COPY FROM for efficient bulk insert
-	_, err := r.pool.CopyFrom(
+	_, err := db.CopyFrom(
 		ctx,
 		pgx.Identifier{"transcription_segments"},
-		[]string{"transcription_id", "segment_index", "start_time", "end_time", "text", "confidence"},
+		[]string{"transcription_id", "segment_index", "start_time", "end_time", "text", "confidence", "speaker"},
 		pgx.CopyFromRows(rows),
 	)
 	if err != nil {
@@ -56,7 +106,7 @@ func (r *segmentRepository) CreateBatch(ctx context.Context, segments []*model.T
 // GetByTranscriptionID retrieves all segments for a transcription, ordered by segment_index
 func (r *segmentRepository) GetByTranscriptionID(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error) {
 	sql := `SELECT id, transcription_id, segment_index, 
-		start_time::text, end_time::text, text, confidence 
+		start_time::text, end_time::text, text, confidence, speaker 
 		FROM transcription_segments 
 		WHERE transcription_id = $1 
 		ORDER BY segment_index`
@@ -78,6 +128,7 @@ func (r *segmentRepository) GetByTranscriptionID(ctx context.Context, transcript
 			&segment.EndTime,
 			&segment.Text,
 			&segment.Confidence,
+			&segment.Speaker,
 		)
 		if err != nil {
 			return nil, common.HandlePostgreSQLError(err, "failed to scan transcription segment")
@@ -88,17 +139,118 @@ func (r *segmentRepository) GetByTranscriptionID(ctx context.Context, transcript
 	return segments, nil
 }
 
-// GetByTimeRange retrieves segments within a time range
-func (r *segmentRepository) GetByTimeRange(ctx context.Context, transcriptionID string, startTime, endTime string) ([]*model.TranscriptionSegment, error) {
+// IterateByTranscriptionID streams segments for a transcription page by page, ordered by segment_index
+func (r *segmentRepository) IterateByTranscriptionID(ctx context.Context, transcriptionID string, batchSize int, fn func(segment *model.TranscriptionSegment) error) error {
+	if batchSize <= 0 {
+		batchSize = defaultSegmentBatchSize
+	}
+
+	sql := `SELECT id, transcription_id, segment_index, 
+		start_time::text, end_time::text, text, confidence, speaker 
+		FROM transcription_segments 
+		WHERE transcription_id = $1 AND segment_index > $2 
+		ORDER BY segment_index 
+		LIMIT $3`
+
+	// Cursor is the last segment_index seen; segment indexes start from 0
+	cursor := -1
+	for {
+		count, last, err := r.iteratePage(ctx, sql, transcriptionID, cursor, batchSize, fn)
+		if err != nil {
+			return err
+		}
+		if count < batchSize {
+			return nil
+		}
+		cursor = last
+	}
+}
+
+// SearchByTranscriptionID streams the segments whose text contains pattern (case-insensitive),
+// page by page in segment_index order
+func (r *segmentRepository) SearchByTranscriptionID(ctx context.Context, transcriptionID string, pattern string, batchSize int, fn func(segment *model.TranscriptionSegment) error) error {
+	if batchSize <= 0 {
+		batchSize = defaultSegmentBatchSize
+	}
+
+	sql := `SELECT id, transcription_id, segment_index, 
+		start_time::text, end_time::text, text, confidence, speaker 
+		FROM transcription_segments 
+		WHERE transcription_id = $1 AND segment_index > $2 AND text ILIKE $4 
+		ORDER BY segment_index 
+		LIMIT $3`
+
+	like := "%" + escapeLike(pattern) + "%"
+	cursor := -1
+	for {
+		count, last, err := r.iteratePage(ctx, sql, transcriptionID, cursor, batchSize, fn, like)
+		if err != nil {
+			return err
+		}
+		if count < batchSize {
+			return nil
+		}
+		cursor = last
+	}
+}
+
+// escapeLike escapes LIKE wildcards so pattern matches literally
+func escapeLike(pattern string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(pattern)
+}
+
+// iteratePage reads a single page of segments after cursor and returns the row count and last segment_index
+func (r *segmentRepository) iteratePage(ctx context.Context, sql string, transcriptionID string, cursor, batchSize int, fn func(segment *model.TranscriptionSegment) error, extraArgs ...any) (int, int, error) {
+	args := append([]any{transcriptionID, cursor, batchSize}, extraArgs...)
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return 0, cursor, common.HandlePostgreSQLError(err, "failed to iterate transcription segments")
+	}
+	defer rows.Close()
+
+	count := 0
+	last := cursor
+	for rows.Next() {
+		var segment model.TranscriptionSegment
+		err := rows.Scan(
+			&segment.ID,
+			&segment.TranscriptionID,
+			&segment.SegmentIndex,
+			&segment.StartTime,
+			&segment.EndTime,
+			&segment.Text,
+			&segment.Confidence,
+			&segment.Speaker,
+		)
+		if err != nil {
+			return count, last, common.HandlePostgreSQLError(err, "failed to scan transcription segment")
+		}
+		if err := fn(&segment); err != nil {
+			return count, last, err
+		}
+		count++
+		last = segment.SegmentIndex
+	}
+
+	if err := rows.Err(); err != nil {
+		return count, last, common.HandlePostgreSQLError(err, "failed to iterate transcription segment rows")
+	}
+
+	return count, last, nil
+}
+
+// GetByTimeRange retrieves segments that lie entirely within [startTime, endTime]. The bounds are
+// passed as HH:MM:SS.mmm so PostgreSQL can't read a bare "1:30" as hours and minutes.
+func (r *segmentRepository) GetByTimeRange(ctx context.Context, transcriptionID string, startTime, endTime time.Duration) ([]*model.TranscriptionSegment, error) {
 	sql := `SELECT id, transcription_id, segment_index, 
-		start_time::text, end_time::text, text, confidence 
+		start_time::text, end_time::text, text, confidence, speaker 
 		FROM transcription_segments 
 		WHERE transcription_id = $1 
 		AND start_time >= $2::interval 
 		AND end_time <= $3::interval
 		ORDER BY segment_index`
 
-	rows, err := r.pool.Query(ctx, sql, transcriptionID, startTime, endTime)
+	rows, err := r.pool.Query(ctx, sql, transcriptionID, timecode.FormatInterval(startTime), timecode.FormatInterval(endTime))
 	if err != nil {
 		return nil, common.HandlePostgreSQLError(err, "failed to get transcription segments by time range")
 	}
@@ -115,6 +267,7 @@ func (r *segmentRepository) GetByTimeRange(ctx context.Context, transcriptionID
 			&segment.EndTime,
 			&segment.Text,
 			&segment.Confidence,
+			&segment.Speaker,
 		)
 		if err != nil {
 			return nil, common.HandlePostgreSQLError(err, "failed to scan transcription segment")
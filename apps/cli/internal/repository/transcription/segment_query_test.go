@@ -0,0 +1,166 @@
+package transcription
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSegmentRepository_IterateByTranscriptionID(t *testing.T) {
+	columns := []string{
+		"id", "transcription_id", "segment_index", "start_time", "end_time", "text", "confidence", "speaker",
+	}
+
+	tests := []struct {
+		name            string
+		transcriptionID string
+		batchSize       int
+		setup           func(mock pgxmock.PgxPoolIface)
+		wantIndexes     []int
+		wantErr         bool
+	}{
+		{
+			name:            "single partial page",
+			transcriptionID: "trans-123",
+			batchSize:       10,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows(columns).
+					AddRow("seg-1", "trans-123", 0, "00:00:00", "00:00:02.5", "Hello", floatPtr(0.95), (*string)(nil)).
+					AddRow("seg-2", "trans-123", 1, "00:00:02.5", "00:00:06", "World", floatPtr(0.92), (*string)(nil))
+
+				mock.ExpectQuery("SELECT (.+) FROM transcription_segments WHERE transcription_id = (.+) AND segment_index >").
+					WithArgs("trans-123", -1, 10).
+					WillReturnRows(rows)
+			},
+			wantIndexes: []int{0, 1},
+			wantErr:     false,
+		},
+		{
+			name:            "multiple pages advance the cursor",
+			transcriptionID: "trans-123",
+			batchSize:       2,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("SELECT (.+) FROM transcription_segments WHERE transcription_id = (.+) AND segment_index >").
+					WithArgs("trans-123", -1, 2).
+					WillReturnRows(pgxmock.NewRows(columns).
+						AddRow("seg-1", "trans-123", 0, "00:00:00", "00:00:01", "One", nil, (*string)(nil)).
+						AddRow("seg-2", "trans-123", 1, "00:00:01", "00:00:02", "Two", nil, (*string)(nil)))
+
+				mock.ExpectQuery("SELECT (.+) FROM transcription_segments WHERE transcription_id = (.+) AND segment_index >").
+					WithArgs("trans-123", 1, 2).
+					WillReturnRows(pgxmock.NewRows(columns).
+						AddRow("seg-3", "trans-123", 2, "00:00:02", "00:00:03", "Three", nil, (*string)(nil)))
+			},
+			wantIndexes: []int{0, 1, 2},
+			wantErr:     false,
+		},
+		{
+			name:            "database error",
+			transcriptionID: "trans-789",
+			batchSize:       10,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("SELECT (.+) FROM transcription_segments WHERE transcription_id = (.+) AND segment_index >").
+					WithArgs("trans-789", -1, 10).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			tt.setup(mock)
+
+			repo := NewSegmentRepository(mock)
+
+			var indexes []int
+			err = repo.IterateByTranscriptionID(context.Background(), tt.transcriptionID, tt.batchSize, func(segment *model.TranscriptionSegment) error {
+				indexes = append(indexes, segment.SegmentIndex)
+				return nil
+			})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantIndexes, indexes)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSegmentRepository_SearchByTranscriptionID(t *testing.T) {
+	columns := []string{
+		"id", "transcription_id", "segment_index", "start_time", "end_time", "text", "confidence", "speaker",
+	}
+
+	t.Run("matches text case-insensitively with literal wildcards", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery("SELECT (.+) FROM transcription_segments WHERE transcription_id = (.+) AND segment_index > (.+) AND text ILIKE").
+			WithArgs("trans-123", -1, 2, `%100\% off\_sale%`).
+			WillReturnRows(pgxmock.NewRows(columns).
+				AddRow("seg-4", "trans-123", 4, "00:00:08", "00:00:10", "100% OFF_SALE today", nil, (*string)(nil)))
+
+		repo := NewSegmentRepository(mock)
+
+		var indexes []int
+		err = repo.SearchByTranscriptionID(context.Background(), "trans-123", "100% off_sale", 2, func(segment *model.TranscriptionSegment) error {
+			indexes = append(indexes, segment.SegmentIndex)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int{4}, indexes)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery("SELECT (.+) FROM transcription_segments WHERE (.+) AND text ILIKE").
+			WithArgs("trans-123", -1, defaultSegmentBatchSize, "%hello%").
+			WillReturnError(assert.AnError)
+
+		repo := NewSegmentRepository(mock)
+
+		err = repo.SearchByTranscriptionID(context.Background(), "trans-123", "hello", 0, func(*model.TranscriptionSegment) error { return nil })
+		assert.Error(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSegmentRepository_GetByTimeRange(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	// 90 seconds must reach PostgreSQL as 00:01:30, not as "1:30" (an hour and a half)
+	mock.ExpectQuery("SELECT (.+) FROM transcription_segments WHERE transcription_id = \\$1 AND start_time >= \\$2::interval").
+		WithArgs("trans-123", "00:01:30.000", "00:02:00.500").
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "transcription_id", "segment_index", "start_time", "end_time", "text", "confidence", "speaker",
+		}).AddRow("seg-1", "trans-123", 12, "00:01:31", "00:01:35", "Hello", nil, (*string)(nil)))
+
+	repo := NewSegmentRepository(mock)
+	segments, err := repo.GetByTimeRange(context.Background(), "trans-123", 90*time.Second, 120500*time.Millisecond)
+
+	require.NoError(t, err)
+	require.Len(t, segments, 1)
+	assert.Equal(t, 12, segments[0].SegmentIndex)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
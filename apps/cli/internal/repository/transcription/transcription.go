@@ -2,6 +2,7 @@ package transcription
 
 import (
 	"context"
+	"time"
 
 	"github.com/Taichi-iskw/yt-lang/internal/model"
 )
@@ -12,16 +13,63 @@ type Repository interface {
 	Create(ctx context.Context, transcription *model.Transcription) error
 	GetByID(ctx context.Context, id string) (*model.Transcription, error)
 	GetByVideoID(ctx context.Context, videoID string) ([]*model.Transcription, error)
+	CountByVideoID(ctx context.Context, videoID string) (int, error)
 	GetByVideoIDAndLanguage(ctx context.Context, videoID, language string) (*model.Transcription, error)
 	UpdateStatus(ctx context.Context, id string, status string, errorMessage *string) error
 	Delete(ctx context.Context, id string) error
+
+	// Spoken languages of a channel's completed transcriptions: the number of videos per language,
+	// with auto-detected transcriptions counted under their detected language, and the number of
+	// videos with any of them
+	CountSpokenLanguages(ctx context.Context, channelID string) (map[string]int, int, error)
+
+	// Transcriptions of videos that changed on YouTube since they were transcribed; only those of
+	// videoID when it is set
+	ListStale(ctx context.Context, videoID string) ([]*model.Transcription, error)
+
+	// Raw whisper output artifact reference (artifact store key)
+	SetWhisperArtifact(ctx context.Context, id string, key string) error
+	GetWhisperArtifact(ctx context.Context, id string) (string, error)
+
+	// Whisper model routing decision
+	SetRouting(ctx context.Context, id string, routing *model.TranscriptionRouting) error
+
+	// Languages whisper detected, most probable first
+	SetLanguageCandidates(ctx context.Context, id string, candidates []model.LanguageCandidate) error
+
+	// Whisper runs of the fallback ladder, the chosen one marked
+	SetWhisperAttempts(ctx context.Context, id string, attempts []model.WhisperAttempt) error
+
+	// Segments matching the hallucination phrase list
+	SetHallucinations(ctx context.Context, id string, report *model.HallucinationReport) error
 }
 
 // SegmentRepository defines operations for TranscriptionSegment persistence
 type SegmentRepository interface {
 	// Segment operations
 	CreateBatch(ctx context.Context, segments []*model.TranscriptionSegment) error
+	// CreateBatchWithProgress is CreateBatch for large inputs: segments are copied in chunks within
+	// one transaction and progress, if set, is called after each chunk when there is more than one
+	CreateBatchWithProgress(ctx context.Context, segments []*model.TranscriptionSegment, progress func(saved, total int)) error
 	GetByTranscriptionID(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error)
-	GetByTimeRange(ctx context.Context, transcriptionID string, startTime, endTime string) ([]*model.TranscriptionSegment, error)
+	// IterateByTranscriptionID streams segments in segment_index order using keyset pagination,
+	// fetching batchSize rows per query and calling fn for each segment as it is read
+	IterateByTranscriptionID(ctx context.Context, transcriptionID string, batchSize int, fn func(segment *model.TranscriptionSegment) error) error
+	// SearchByTranscriptionID streams like IterateByTranscriptionID, limited to segments whose text
+	// contains pattern (case-insensitive, wildcards matched literally)
+	SearchByTranscriptionID(ctx context.Context, transcriptionID string, pattern string, batchSize int, fn func(segment *model.TranscriptionSegment) error) error
+	// GetByTimeRange returns the segments lying entirely within [startTime, endTime], served by the
+	// (transcription_id, start_time, end_time) index
+	GetByTimeRange(ctx context.Context, transcriptionID string, startTime, endTime time.Duration) ([]*model.TranscriptionSegment, error)
 	Delete(ctx context.Context, transcriptionID string) error
 }
+
+// HallucinationRepository defines operations for the workspace's hallucination phrase list
+type HallucinationRepository interface {
+	// List retrieves the phrases, sorted
+	List(ctx context.Context) ([]string, error)
+	// Add adds a phrase; adding a listed phrase again is not an error
+	Add(ctx context.Context, phrase string) error
+	// Remove removes a phrase, failing with CodeNotFound when it is not listed
+	Remove(ctx context.Context, phrase string) error
+}
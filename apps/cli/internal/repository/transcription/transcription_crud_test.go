@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
 	"github.com/Taichi-iskw/yt-lang/internal/model"
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
@@ -33,8 +34,8 @@ func TestTranscriptionRepository_Create(t *testing.T) {
 			},
 			setup: func(mock pgxmock.PgxPoolIface) {
 				mock.ExpectQuery("INSERT INTO transcriptions").
-					WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
-					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow("generated-uuid"))
+					WithArgs("trans-123", pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow("trans-123"))
 			},
 			wantErr: false,
 		},
@@ -49,7 +50,7 @@ func TestTranscriptionRepository_Create(t *testing.T) {
 			},
 			setup: func(mock pgxmock.PgxPoolIface) {
 				mock.ExpectQuery("INSERT INTO transcriptions").
-					WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+					WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
 					WillReturnError(assert.AnError)
 			},
 			wantErr: true,
@@ -97,10 +98,15 @@ func TestTranscriptionRepository_GetByID(t *testing.T) {
 				duration := "00:10:30"
 				rows := pgxmock.NewRows([]string{
 					"id", "video_id", "language", "status", "created_at",
-					"completed_at", "error_message", "detected_language", "total_duration",
+					"completed_at", "error_message", "detected_language", "total_duration", "source",
+					"language_candidates", "whisper_attempts", "hallucinations", "stale_at", "stale_reason",
 				}).AddRow(
 					"trans-123", "video-456", "auto", "completed", now,
-					&now, nil, &detectedLang, &duration,
+					&now, nil, &detectedLang, &duration, "whisper",
+					[]byte(`[{"language":"en","probability":0.91},{"language":"de","probability":0.05}]`),
+					[]byte(`[{"model":"base","issues":["repetition loop"]},{"model":"base","beam_size":5,"best_of":5,"chosen":true}]`),
+					[]byte(`{"mode":"flag","phrases":{"Thanks for watching!":2},"segments":[41,87]}`),
+					nil, nil,
 				)
 				mock.ExpectQuery("SELECT (.+) FROM transcriptions WHERE id").
 					WithArgs("trans-123").
@@ -111,6 +117,20 @@ func TestTranscriptionRepository_GetByID(t *testing.T) {
 				VideoID:  "video-456",
 				Language: "auto",
 				Status:   "completed",
+				Source:   "whisper",
+				LanguageCandidates: []model.LanguageCandidate{
+					{Language: "en", Probability: 0.91},
+					{Language: "de", Probability: 0.05},
+				},
+				WhisperAttempts: []model.WhisperAttempt{
+					{Model: "base", Issues: []string{"repetition loop"}},
+					{Model: "base", BeamSize: 5, BestOf: 5, Chosen: true},
+				},
+				Hallucinations: &model.HallucinationReport{
+					Mode:     "flag",
+					Phrases:  map[string]int{"Thanks for watching!": 2},
+					Segments: []int{41, 87},
+				},
 			},
 			wantErr: false,
 		},
@@ -120,7 +140,7 @@ func TestTranscriptionRepository_GetByID(t *testing.T) {
 			setup: func(mock pgxmock.PgxPoolIface) {
 				mock.ExpectQuery("SELECT (.+) FROM transcriptions WHERE id").
 					WithArgs("trans-nonexistent").
-					WillReturnRows(pgxmock.NewRows([]string{"id", "video_id", "language", "status", "created_at", "completed_at", "error_message", "detected_language", "total_duration"}))
+					WillReturnRows(pgxmock.NewRows([]string{"id", "video_id", "language", "status", "created_at", "completed_at", "error_message", "detected_language", "total_duration", "source", "language_candidates", "whisper_attempts", "hallucinations", "stale_at", "stale_reason"}))
 			},
 			want:    nil,
 			wantErr: true,
@@ -148,9 +168,243 @@ func TestTranscriptionRepository_GetByID(t *testing.T) {
 				assert.Equal(t, tt.want.VideoID, result.VideoID)
 				assert.Equal(t, tt.want.Language, result.Language)
 				assert.Equal(t, tt.want.Status, result.Status)
+				assert.Equal(t, tt.want.Source, result.Source)
+				assert.Equal(t, tt.want.LanguageCandidates, result.LanguageCandidates)
 			}
 
 			require.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
+
+func TestTranscriptionRepository_ListStale(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	now := time.Now()
+	reason := "title, duration changed on YouTube"
+	rows := pgxmock.NewRows([]string{
+		"id", "video_id", "language", "status", "created_at",
+		"completed_at", "error_message", "detected_language", "total_duration", "source",
+		"language_candidates", "whisper_attempts", "hallucinations", "stale_at", "stale_reason",
+	}).AddRow(
+		"trans-123", "video-456", "es", "completed", now,
+		&now, nil, nil, nil, "whisper",
+		nil, nil, nil, &now, &reason,
+	)
+	mock.ExpectQuery("SELECT (.+) FROM transcriptions WHERE stale_at IS NOT NULL AND workspace = current_workspace\\(\\) AND \\(\\$1 = '' OR video_id = \\$1\\) ORDER BY stale_at DESC").
+		WithArgs("video-456").
+		WillReturnRows(rows)
+
+	repo := NewRepository(mock)
+	result, err := repo.ListStale(context.Background(), "video-456")
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "video-456", result[0].VideoID)
+	assert.Equal(t, &reason, result[0].StaleReason)
+	require.NotNil(t, result[0].StaleAt)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTranscriptionRepository_WhisperArtifact(t *testing.T) {
+	t.Run("set artifact key", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("UPDATE transcriptions SET whisper_artifact").
+			WithArgs("trans-123", "whisper/trans-123.json.gz").
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		repo := NewRepository(mock)
+		err = repo.SetWhisperArtifact(context.Background(), "trans-123", "whisper/trans-123.json.gz")
+		assert.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("set on missing transcription", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("UPDATE transcriptions SET whisper_artifact").
+			WithArgs("trans-missing", "whisper/trans-missing.json.gz").
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		repo := NewRepository(mock)
+		err = repo.SetWhisperArtifact(context.Background(), "trans-missing", "whisper/trans-missing.json.gz")
+		assert.Error(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("get artifact key", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		key := "whisper/trans-123.json.gz"
+		mock.ExpectQuery("SELECT whisper_artifact FROM transcriptions WHERE id").
+			WithArgs("trans-123").
+			WillReturnRows(pgxmock.NewRows([]string{"whisper_artifact"}).AddRow(&key))
+
+		repo := NewRepository(mock)
+		result, err := repo.GetWhisperArtifact(context.Background(), "trans-123")
+		assert.NoError(t, err)
+		assert.Equal(t, key, result)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no artifact stored", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery("SELECT whisper_artifact FROM transcriptions WHERE id").
+			WithArgs("trans-456").
+			WillReturnRows(pgxmock.NewRows([]string{"whisper_artifact"}).AddRow(nil))
+
+		repo := NewRepository(mock)
+		result, err := repo.GetWhisperArtifact(context.Background(), "trans-456")
+		assert.Error(t, err)
+		assert.Empty(t, result)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTranscriptionRepository_SetRouting(t *testing.T) {
+	routing := &model.TranscriptionRouting{
+		SampledLanguage: "ja",
+		Language:        "ja",
+		Model:           "medium",
+		Reason:          "sample detected ja; rule ja -> medium",
+	}
+
+	t.Run("records the decision", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("UPDATE transcriptions SET routed_language = \\$2, routed_model = \\$3, routing_reason = \\$4").
+			WithArgs("trans-123", "ja", "medium", "sample detected ja; rule ja -> medium").
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		repo := NewRepository(mock)
+		assert.NoError(t, repo.SetRouting(context.Background(), "trans-123", routing))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing transcription", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("UPDATE transcriptions SET routed_language").
+			WithArgs("trans-missing", "ja", "medium", "sample detected ja; rule ja -> medium").
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		repo := NewRepository(mock)
+		assert.Error(t, repo.SetRouting(context.Background(), "trans-missing", routing))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTranscriptionRepository_SetLanguageCandidates(t *testing.T) {
+	candidates := []model.LanguageCandidate{{Language: "ja", Probability: 0.62}, {Language: "en", Probability: 0.3}}
+	encoded := []byte(`[{"language":"ja","probability":0.62},{"language":"en","probability":0.3}]`)
+
+	t.Run("records the candidates", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("UPDATE transcriptions SET language_candidates = \\$2").
+			WithArgs("trans-123", encoded).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		repo := NewRepository(mock)
+		assert.NoError(t, repo.SetLanguageCandidates(context.Background(), "trans-123", candidates))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing transcription", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("UPDATE transcriptions SET language_candidates").
+			WithArgs("trans-missing", encoded).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		repo := NewRepository(mock)
+		err = repo.SetLanguageCandidates(context.Background(), "trans-missing", candidates)
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.CodeNotFound, appErr.Code)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTranscriptionRepository_SetWhisperAttempts(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	attempts := []model.WhisperAttempt{{Model: "small", Error: "exit status 1"}, {Model: "medium", Chosen: true}}
+	mock.ExpectExec("UPDATE transcriptions SET whisper_attempts = \\$2").
+		WithArgs("trans-123", []byte(`[{"model":"small","error":"exit status 1"},{"model":"medium","chosen":true}]`)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	repo := NewRepository(mock)
+	assert.NoError(t, repo.SetWhisperAttempts(context.Background(), "trans-123", attempts))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTranscriptionRepository_SetHallucinations(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	report := &model.HallucinationReport{Mode: "remove", Phrases: map[string]int{"Thanks for watching!": 3}}
+	mock.ExpectExec("UPDATE transcriptions SET hallucinations = \\$2").
+		WithArgs("trans-123", []byte(`{"mode":"remove","phrases":{"Thanks for watching!":3}}`)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	repo := NewRepository(mock)
+	assert.NoError(t, repo.SetHallucinations(context.Background(), "trans-123", report))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTranscriptionRepository_CountSpokenLanguages(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	ja, en := "ja", "en"
+	mock.ExpectQuery("SELECT s.language, COUNT\\(DISTINCT s.video_id\\) FROM .* GROUP BY ROLLUP \\(s.language\\)").
+		WithArgs("UC1").
+		WillReturnRows(pgxmock.NewRows([]string{"language", "count"}).
+			AddRow(&en, 1).
+			AddRow(&ja, 4).
+			AddRow(nil, 4))
+
+	counts, total, err := NewRepository(mock).CountSpokenLanguages(context.Background(), "UC1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"ja": 4, "en": 1}, counts)
+	assert.Equal(t, 4, total)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTranscriptionRepository_CountByVideoID(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM transcriptions WHERE video_id = \\$1").
+		WithArgs("video-1").
+		WillReturnError(assert.AnError)
+
+	_, err = NewRepository(mock).CountByVideoID(context.Background(), "video-1")
+	assert.Error(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,6 +2,7 @@ package transcription
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 
 	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
@@ -35,12 +36,18 @@ func NewRepository(pool Pool) Repository {
 
 // Create creates a new transcription record
 func (r *transcriptionRepository) Create(ctx context.Context, transcription *model.Transcription) error {
+	if transcription.Source == "" {
+		transcription.Source = model.TranscriptionSourceWhisper
+	}
+
+	// The database generates the ID unless the caller assigned one
 	sql := `INSERT INTO transcriptions 
-		(video_id, language, status, created_at, completed_at, error_message, detected_language, total_duration) 
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		(id, video_id, language, status, created_at, completed_at, error_message, detected_language, total_duration, source) 
+		VALUES (COALESCE(NULLIF($1, '')::uuid, gen_random_uuid()), $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id`
 
 	err := r.pool.QueryRow(ctx, sql,
+		transcription.ID,
 		transcription.VideoID,
 		transcription.Language,
 		transcription.Status,
@@ -49,6 +56,7 @@ func (r *transcriptionRepository) Create(ctx context.Context, transcription *mod
 		transcription.ErrorMessage,
 		transcription.DetectedLanguage,
 		transcription.TotalDuration,
+		transcription.Source,
 	).Scan(&transcription.ID)
 	if err != nil {
 		return common.HandlePostgreSQLError(err, "failed to create transcription")
@@ -58,11 +66,12 @@ func (r *transcriptionRepository) Create(ctx context.Context, transcription *mod
 
 // GetByID retrieves a transcription by its ID
 func (r *transcriptionRepository) GetByID(ctx context.Context, id string) (*model.Transcription, error) {
-	sql := `SELECT id, video_id, language, status, created_at, completed_at, error_message, detected_language, total_duration 
-		FROM transcriptions WHERE id = $1`
+	sql := `SELECT id, video_id, language, status, created_at, completed_at, error_message, detected_language, total_duration, source, language_candidates, whisper_attempts, hallucinations, stale_at, stale_reason
+		FROM transcriptions WHERE id = $1 AND workspace = current_workspace()`
 	row := r.pool.QueryRow(ctx, sql, id)
 
 	var transcription model.Transcription
+	var candidates, attempts, hallucinations []byte
 	err := row.Scan(
 		&transcription.ID,
 		&transcription.VideoID,
@@ -73,6 +82,12 @@ func (r *transcriptionRepository) GetByID(ctx context.Context, id string) (*mode
 		&transcription.ErrorMessage,
 		&transcription.DetectedLanguage,
 		&transcription.TotalDuration,
+		&transcription.Source,
+		&candidates,
+		&attempts,
+		&hallucinations,
+		&transcription.StaleAt,
+		&transcription.StaleReason,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -80,22 +95,97 @@ func (r *transcriptionRepository) GetByID(ctx context.Context, id string) (*mode
 		}
 		return nil, common.HandlePostgreSQLError(err, "failed to get transcription")
 	}
+	if transcription.LanguageCandidates, err = decodeLanguageCandidates(candidates); err != nil {
+		return nil, err
+	}
+	if transcription.WhisperAttempts, err = decodeWhisperAttempts(attempts); err != nil {
+		return nil, err
+	}
+	if transcription.Hallucinations, err = decodeHallucinations(hallucinations); err != nil {
+		return nil, err
+	}
 	return &transcription, nil
 }
 
+// CountByVideoID returns the number of transcriptions of a video
+func (r *transcriptionRepository) CountByVideoID(ctx context.Context, videoID string) (int, error) {
+	sql := "SELECT COUNT(*) FROM transcriptions WHERE video_id = $1 AND workspace = current_workspace()"
+
+	var count int
+	if err := r.pool.QueryRow(ctx, sql, videoID).Scan(&count); err != nil {
+		return 0, common.HandlePostgreSQLError(err, "failed to count transcriptions by video ID")
+	}
+	return count, nil
+}
+
+// CountSpokenLanguages counts the videos of a channel per spoken language in one grouped query.
+// The ROLLUP row, whose language is NULL, counts the videos with any spoken language.
+func (r *transcriptionRepository) CountSpokenLanguages(ctx context.Context, channelID string) (map[string]int, int, error) {
+	sql := `SELECT s.language, COUNT(DISTINCT s.video_id) FROM (
+			SELECT t.video_id, COALESCE(NULLIF(t.detected_language, ''), t.language) AS language
+			FROM transcriptions t JOIN videos v ON v.workspace = t.workspace AND v.id = t.video_id
+			WHERE v.channel_id = $1 AND t.workspace = current_workspace() AND t.status = 'completed'
+		) s
+		WHERE s.language NOT IN ('', 'auto')
+		GROUP BY ROLLUP (s.language)`
+
+	rows, err := r.pool.Query(ctx, sql, channelID)
+	if err != nil {
+		return nil, 0, common.HandlePostgreSQLError(err, "failed to count spoken languages")
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	total := 0
+	for rows.Next() {
+		var language *string
+		var count int
+		if err := rows.Scan(&language, &count); err != nil {
+			return nil, 0, common.HandlePostgreSQLError(err, "failed to scan spoken language row")
+		}
+		if language == nil {
+			total = count
+			continue
+		}
+		counts[*language] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, common.HandlePostgreSQLError(err, "failed to iterate spoken language rows")
+	}
+	return counts, total, nil
+}
+
 // GetByVideoID retrieves all transcriptions for a video
 func (r *transcriptionRepository) GetByVideoID(ctx context.Context, videoID string) ([]*model.Transcription, error) {
-	sql := `SELECT id, video_id, language, status, created_at, completed_at, error_message, detected_language, total_duration 
-		FROM transcriptions WHERE video_id = $1 ORDER BY created_at`
+	sql := `SELECT id, video_id, language, status, created_at, completed_at, error_message, detected_language, total_duration, source, language_candidates, whisper_attempts, hallucinations, stale_at, stale_reason
+		FROM transcriptions WHERE video_id = $1 AND workspace = current_workspace() ORDER BY created_at`
 	rows, err := r.pool.Query(ctx, sql, videoID)
 	if err != nil {
 		return nil, common.HandlePostgreSQLError(err, "failed to get transcriptions by video ID")
 	}
 	defer rows.Close()
+	return scanTranscriptions(rows)
+}
+
+// ListStale retrieves the transcriptions marked stale because their video changed on YouTube,
+// most recently marked first; only those of videoID when it is set
+func (r *transcriptionRepository) ListStale(ctx context.Context, videoID string) ([]*model.Transcription, error) {
+	sql := `SELECT id, video_id, language, status, created_at, completed_at, error_message, detected_language, total_duration, source, language_candidates, whisper_attempts, hallucinations, stale_at, stale_reason
+		FROM transcriptions WHERE stale_at IS NOT NULL AND workspace = current_workspace() AND ($1 = '' OR video_id = $1) ORDER BY stale_at DESC, video_id, language`
+	rows, err := r.pool.Query(ctx, sql, videoID)
+	if err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to list stale transcriptions")
+	}
+	defer rows.Close()
+	return scanTranscriptions(rows)
+}
 
+// scanTranscriptions reads the rows of a query selecting the columns of GetByVideoID
+func scanTranscriptions(rows pgx.Rows) ([]*model.Transcription, error) {
 	var transcriptions []*model.Transcription
 	for rows.Next() {
 		var transcription model.Transcription
+		var candidates, attempts, hallucinations []byte
 		err := rows.Scan(
 			&transcription.ID,
 			&transcription.VideoID,
@@ -106,10 +196,25 @@ func (r *transcriptionRepository) GetByVideoID(ctx context.Context, videoID stri
 			&transcription.ErrorMessage,
 			&transcription.DetectedLanguage,
 			&transcription.TotalDuration,
+			&transcription.Source,
+			&candidates,
+			&attempts,
+			&hallucinations,
+			&transcription.StaleAt,
+			&transcription.StaleReason,
 		)
 		if err != nil {
 			return nil, common.HandlePostgreSQLError(err, "failed to scan transcription")
 		}
+		if transcription.LanguageCandidates, err = decodeLanguageCandidates(candidates); err != nil {
+			return nil, err
+		}
+		if transcription.WhisperAttempts, err = decodeWhisperAttempts(attempts); err != nil {
+			return nil, err
+		}
+		if transcription.Hallucinations, err = decodeHallucinations(hallucinations); err != nil {
+			return nil, err
+		}
 		transcriptions = append(transcriptions, &transcription)
 	}
 
@@ -118,11 +223,12 @@ func (r *transcriptionRepository) GetByVideoID(ctx context.Context, videoID stri
 
 // GetByVideoIDAndLanguage retrieves a transcription for a video in specific language
 func (r *transcriptionRepository) GetByVideoIDAndLanguage(ctx context.Context, videoID, language string) (*model.Transcription, error) {
-	sql := `SELECT id, video_id, language, status, created_at, completed_at, error_message, detected_language, total_duration 
-		FROM transcriptions WHERE video_id = $1 AND language = $2`
+	sql := `SELECT id, video_id, language, status, created_at, completed_at, error_message, detected_language, total_duration, source, language_candidates, whisper_attempts, hallucinations, stale_at, stale_reason
+		FROM transcriptions WHERE video_id = $1 AND language = $2 AND workspace = current_workspace()`
 	row := r.pool.QueryRow(ctx, sql, videoID, language)
 
 	var transcription model.Transcription
+	var candidates, attempts, hallucinations []byte
 	err := row.Scan(
 		&transcription.ID,
 		&transcription.VideoID,
@@ -133,6 +239,12 @@ func (r *transcriptionRepository) GetByVideoIDAndLanguage(ctx context.Context, v
 		&transcription.ErrorMessage,
 		&transcription.DetectedLanguage,
 		&transcription.TotalDuration,
+		&transcription.Source,
+		&candidates,
+		&attempts,
+		&hallucinations,
+		&transcription.StaleAt,
+		&transcription.StaleReason,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -140,6 +252,15 @@ func (r *transcriptionRepository) GetByVideoIDAndLanguage(ctx context.Context, v
 		}
 		return nil, common.HandlePostgreSQLError(err, "failed to get transcription")
 	}
+	if transcription.LanguageCandidates, err = decodeLanguageCandidates(candidates); err != nil {
+		return nil, err
+	}
+	if transcription.WhisperAttempts, err = decodeWhisperAttempts(attempts); err != nil {
+		return nil, err
+	}
+	if transcription.Hallucinations, err = decodeHallucinations(hallucinations); err != nil {
+		return nil, err
+	}
 	return &transcription, nil
 }
 
@@ -153,6 +274,140 @@ func (r *transcriptionRepository) UpdateStatus(ctx context.Context, id string, s
 	return nil
 }
 
+// SetWhisperArtifact records the artifact store key of a transcription's raw whisper output
+func (r *transcriptionRepository) SetWhisperArtifact(ctx context.Context, id string, key string) error {
+	sql := `UPDATE transcriptions SET whisper_artifact = $2 WHERE id = $1`
+	tag, err := r.pool.Exec(ctx, sql, id, key)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to set whisper artifact")
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.New(apperrors.CodeNotFound, "transcription not found")
+	}
+	return nil
+}
+
+// GetWhisperArtifact returns the artifact store key of a transcription's raw whisper output
+func (r *transcriptionRepository) GetWhisperArtifact(ctx context.Context, id string) (string, error) {
+	sql := `SELECT whisper_artifact FROM transcriptions WHERE id = $1 AND workspace = current_workspace()`
+
+	var key *string
+	if err := r.pool.QueryRow(ctx, sql, id).Scan(&key); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", apperrors.Wrap(err, apperrors.CodeNotFound, "transcription not found")
+		}
+		return "", common.HandlePostgreSQLError(err, "failed to get whisper artifact")
+	}
+	if key == nil || *key == "" {
+		return "", apperrors.New(apperrors.CodeNotFound, "no whisper artifact stored for transcription")
+	}
+	return *key, nil
+}
+
+// SetRouting records the whisper model routing decision of a transcription
+func (r *transcriptionRepository) SetRouting(ctx context.Context, id string, routing *model.TranscriptionRouting) error {
+	sql := `UPDATE transcriptions SET routed_language = $2, routed_model = $3, routing_reason = $4
+		WHERE id = $1 AND workspace = current_workspace()`
+	tag, err := r.pool.Exec(ctx, sql, id, routing.Language, routing.Model, routing.Reason)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to set transcription routing")
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.New(apperrors.CodeNotFound, "transcription not found")
+	}
+	return nil
+}
+
+// SetLanguageCandidates records the languages whisper detected for a transcription
+func (r *transcriptionRepository) SetLanguageCandidates(ctx context.Context, id string, candidates []model.LanguageCandidate) error {
+	data, err := json.Marshal(candidates)
+	if err != nil {
+		return apperrors.Wrap(err, apperrors.CodeInternal, "failed to encode language candidates")
+	}
+
+	sql := `UPDATE transcriptions SET language_candidates = $2 WHERE id = $1 AND workspace = current_workspace()`
+	tag, err := r.pool.Exec(ctx, sql, id, data)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to set language candidates")
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.New(apperrors.CodeNotFound, "transcription not found")
+	}
+	return nil
+}
+
+// decodeLanguageCandidates decodes the language_candidates column; NULL decodes to none
+func decodeLanguageCandidates(data []byte) ([]model.LanguageCandidate, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var candidates []model.LanguageCandidate
+	if err := json.Unmarshal(data, &candidates); err != nil {
+		return nil, apperrors.Wrap(err, apperrors.CodeInternal, "failed to decode language candidates")
+	}
+	return candidates, nil
+}
+
+// SetWhisperAttempts records the whisper runs of a transcription's fallback ladder
+func (r *transcriptionRepository) SetWhisperAttempts(ctx context.Context, id string, attempts []model.WhisperAttempt) error {
+	data, err := json.Marshal(attempts)
+	if err != nil {
+		return apperrors.Wrap(err, apperrors.CodeInternal, "failed to encode whisper attempts")
+	}
+
+	sql := `UPDATE transcriptions SET whisper_attempts = $2 WHERE id = $1 AND workspace = current_workspace()`
+	tag, err := r.pool.Exec(ctx, sql, id, data)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to set whisper attempts")
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.New(apperrors.CodeNotFound, "transcription not found")
+	}
+	return nil
+}
+
+// decodeWhisperAttempts decodes the whisper_attempts column; NULL decodes to none
+func decodeWhisperAttempts(data []byte) ([]model.WhisperAttempt, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var attempts []model.WhisperAttempt
+	if err := json.Unmarshal(data, &attempts); err != nil {
+		return nil, apperrors.Wrap(err, apperrors.CodeInternal, "failed to decode whisper attempts")
+	}
+	return attempts, nil
+}
+
+// SetHallucinations records the segments of a transcription matching the hallucination phrase list
+func (r *transcriptionRepository) SetHallucinations(ctx context.Context, id string, report *model.HallucinationReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return apperrors.Wrap(err, apperrors.CodeInternal, "failed to encode hallucination report")
+	}
+
+	sql := `UPDATE transcriptions SET hallucinations = $2 WHERE id = $1 AND workspace = current_workspace()`
+	tag, err := r.pool.Exec(ctx, sql, id, data)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to set hallucination report")
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.New(apperrors.CodeNotFound, "transcription not found")
+	}
+	return nil
+}
+
+// decodeHallucinations decodes the hallucinations column; NULL decodes to nil
+func decodeHallucinations(data []byte) (*model.HallucinationReport, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var report model.HallucinationReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, apperrors.Wrap(err, apperrors.CodeInternal, "failed to decode hallucination report")
+	}
+	return &report, nil
+}
+
 // Delete deletes a transcription by ID
 func (r *transcriptionRepository) Delete(ctx context.Context, id string) error {
 	sql := "DELETE FROM transcriptions WHERE id = $1"
@@ -0,0 +1,55 @@
+package translation
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// compressThreshold is the length in bytes from which translated texts are stored gzip-compressed
+// in translated_text_gz. Postgres only compresses (TOASTs) values of rows over about 2 kB, which
+// single segments rarely reach, and gzip doesn't pay off for short texts either.
+const compressThreshold = 256
+
+// encodeText returns the translated_text and translated_text_gz values of a translated text:
+// long texts are compressed when that makes them smaller, leaving translated_text empty
+func encodeText(text string) (string, []byte, error) {
+	if len(text) < compressThreshold {
+		return text, nil, nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(text)); err != nil {
+		return "", nil, fmt.Errorf("failed to compress translated text: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return "", nil, fmt.Errorf("failed to compress translated text: %w", err)
+	}
+
+	if buf.Len() >= len(text) {
+		return text, nil, nil
+	}
+	return "", buf.Bytes(), nil
+}
+
+// decodeText returns the translated text of a row: the decompressed translated_text_gz when set,
+// translated_text otherwise, so rows saved before compression read unchanged
+func decodeText(text string, compressed []byte) (string, error) {
+	if len(compressed) == 0 {
+		return text, nil
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress translated text: %w", err)
+	}
+	defer zr.Close()
+
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress translated text: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,73 @@
+package translation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeText(t *testing.T) {
+	long := strings.Repeat("これは長い翻訳のテストです。", 40)
+
+	tests := []struct {
+		name           string
+		text           string
+		wantCompressed bool
+	}{
+		{name: "short text is stored as is", text: "こんにちは"},
+		{name: "long text is compressed", text: long, wantCompressed: true},
+		{name: "incompressible text is stored as is", text: "abcdefghijklmnopqrstuvwxyz0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ!@#$%^&*()_+-=[]{};:,.<>?/|~`" +
+			"zyxwvutsrqponmlkjihgfedcba9876543210ZYXWVUTSRQPONMLKJIHGFEDCBA)(*&^%$#@!+_=-][}{:;.,?></~|`" +
+			"qazwsxedcrfvtgbyhnujmikolp1234567890QAZWSXEDCRFVTGBYHNUJMIKOLP"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, compressed, err := encodeText(tt.text)
+			require.NoError(t, err)
+			if tt.wantCompressed {
+				assert.Empty(t, text)
+				assert.Less(t, len(compressed), len(tt.text))
+			} else {
+				assert.Equal(t, tt.text, text)
+				assert.Nil(t, compressed)
+			}
+
+			decoded, err := decodeText(text, compressed)
+			require.NoError(t, err)
+			assert.Equal(t, tt.text, decoded)
+		})
+	}
+
+	_, err := decodeText("", []byte("not gzip"))
+	assert.Error(t, err)
+}
+
+// benchmarkText is a long translated segment, e.g. a merged paragraph of a lecture
+var benchmarkText = strings.Repeat("今日は日本語の文法について、特に助詞の使い方を詳しく説明します。", 20)
+
+func BenchmarkEncodeText(b *testing.B) {
+	var stored int
+	for i := 0; i < b.N; i++ {
+		_, compressed, err := encodeText(benchmarkText)
+		if err != nil {
+			b.Fatal(err)
+		}
+		stored = len(compressed)
+	}
+	b.ReportMetric(float64(stored)/float64(len(benchmarkText)), "ratio")
+}
+
+func BenchmarkDecodeText(b *testing.B) {
+	_, compressed, err := encodeText(benchmarkText)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := decodeText("", compressed); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package translation
+
+import (
+	"context"
+	"time"
+)
+
+// providerUsageRepository implements ProviderUsageRepository
+type providerUsageRepository struct {
+	pool Pool
+}
+
+// NewProviderUsageRepository creates a new translation provider usage repository
+func NewProviderUsageRepository(pool Pool) ProviderUsageRepository {
+	return &providerUsageRepository{
+		pool: pool,
+	}
+}
+
+// TokensUsed sums up the usage of each provider on day
+func (r *providerUsageRepository) TokensUsed(ctx context.Context, day time.Time) (map[string]int, error) {
+	query := `
+		SELECT provider, tokens
+		FROM translation_provider_usage
+		WHERE day = $1`
+
+	rows, err := r.pool.Query(ctx, query, usageDay(day))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	used := make(map[string]int)
+	for rows.Next() {
+		var provider string
+		var tokens int64
+		if err := rows.Scan(&provider, &tokens); err != nil {
+			return nil, err
+		}
+		used[provider] = int(tokens)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return used, nil
+}
+
+// AddTokens adds to the provider's row of the day, creating it on first use
+func (r *providerUsageRepository) AddTokens(ctx context.Context, provider string, day time.Time, tokens int) error {
+	query := `
+		INSERT INTO translation_provider_usage (provider, day, tokens)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (provider, day) DO UPDATE SET tokens = translation_provider_usage.tokens + EXCLUDED.tokens`
+
+	_, err := r.pool.Exec(ctx, query, provider, usageDay(day), int64(tokens))
+	return err
+}
+
+// usageDay truncates t to its UTC day, the key of the usage rows
+func usageDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,61 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderUsageRepository_TokensUsed(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("sums up the day's usage per provider", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		// Any time of the day reads that day's rows
+		mock.ExpectQuery("SELECT provider, tokens FROM translation_provider_usage WHERE day = \\$1").
+			WithArgs(day).
+			WillReturnRows(pgxmock.NewRows([]string{"provider", "tokens"}).
+				AddRow("plamo", int64(12000)).
+				AddRow("gpt-4o-mini", int64(3500)))
+
+		used, err := NewProviderUsageRepository(mock).TokensUsed(context.Background(), day.Add(15*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"plamo": 12000, "gpt-4o-mini": 3500}, used)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery("SELECT provider, tokens").WithArgs(day).WillReturnError(errors.New("connection refused"))
+
+		_, err = NewProviderUsageRepository(mock).TokensUsed(context.Background(), day)
+		assert.Error(t, err)
+	})
+}
+
+func TestProviderUsageRepository_AddTokens(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	// The day is the UTC day, whatever the time zone of the time given
+	tokyo := time.FixedZone("JST", 9*60*60)
+	mock.ExpectExec("INSERT INTO translation_provider_usage \\(provider, day, tokens\\).*ON CONFLICT \\(provider, day\\) DO UPDATE").
+		WithArgs("plamo", time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC), int64(800)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err = NewProviderUsageRepository(mock).AddTokens(context.Background(), "plamo", time.Date(2024, 5, 1, 8, 0, 0, 0, tokyo), 800)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
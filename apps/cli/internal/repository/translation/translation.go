@@ -2,6 +2,7 @@ package translation
 
 import (
 	"context"
+	"time"
 
 	"github.com/Taichi-iskw/yt-lang/internal/model"
 )
@@ -11,6 +12,9 @@ type TranslationRepository interface {
 	// Get retrieves a translation by ID
 	Get(ctx context.Context, id int) (*model.Translation, error)
 
+	// GetTranscriptionID returns the ID of the transcription a translation's segment belongs to
+	GetTranscriptionID(ctx context.Context, id int) (string, error)
+
 	// Create creates a new translation for a transcription segment
 	Create(ctx context.Context, translation *model.Translation) error
 
@@ -20,16 +24,24 @@ type TranslationRepository interface {
 	// GetByTranscriptionID retrieves all translations for a transcription segment
 	GetByTranscriptionID(ctx context.Context, transcriptionID string) ([]*model.Translation, error)
 
-	// ListByTranscriptionID retrieves translations for a transcription segment with pagination
+	// ListByTranscriptionID retrieves translations for a transcription segment with pagination, in
+	// every language and style; readers pick the rows of the style they want
 	ListByTranscriptionID(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error)
 
-	// GetByTranscriptionIDAndLanguage retrieves translation for specific target language
-	GetByTranscriptionIDAndLanguage(ctx context.Context, transcriptionID string, targetLanguage string) (*model.Translation, error)
+	// CountByTranscriptionID returns the number of translations of a transcription, for pagination
+	CountByTranscriptionID(ctx context.Context, transcriptionID string) (int, error)
+
+	// GetByTranscriptionIDAndLanguage retrieves translation for specific target language and style
+	// (empty for the plain translation)
+	GetByTranscriptionIDAndLanguage(ctx context.Context, transcriptionID string, targetLanguage, style string) (*model.Translation, error)
 
 	// GetByVideoIDAndLanguage retrieves all translations for a video in specific target language
 	// This method joins with transcriptions table to get all translations for a video
 	GetByVideoIDAndLanguage(ctx context.Context, videoID, targetLanguage string) ([]*model.Translation, error)
 
+	// ListLanguagesByTranscriptionID returns the distinct target languages a transcription has translations in
+	ListLanguagesByTranscriptionID(ctx context.Context, transcriptionID string) ([]string, error)
+
 	// Update updates an existing translation
 	Update(ctx context.Context, translation *model.Translation) error
 
@@ -42,3 +54,19 @@ type TranslationRepository interface {
 	// DeleteByVideoID deletes all translations for a video (via transcription segments)
 	DeleteByVideoID(ctx context.Context, videoID string) error
 }
+
+// WarningRepository defines operations for translation QA warning persistence
+type WarningRepository interface {
+	// ReplaceByTranscriptionID replaces the warnings of a transcription in a target language
+	ReplaceByTranscriptionID(ctx context.Context, transcriptionID, targetLanguage string, warnings []*model.TranslationWarning) error
+}
+
+// ProviderUsageRepository defines operations on the tokens sent to each translation provider per
+// day, shared by all workspaces
+type ProviderUsageRepository interface {
+	// TokensUsed returns the tokens sent to each provider on day (UTC); providers without usage are absent
+	TokensUsed(ctx context.Context, day time.Time) (map[string]int, error)
+
+	// AddTokens adds tokens to the usage of provider on day (UTC)
+	AddTokens(ctx context.Context, provider string, day time.Time, tokens int) error
+}
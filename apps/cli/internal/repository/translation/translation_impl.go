@@ -2,6 +2,9 @@ package translation
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/Taichi-iskw/yt-lang/internal/model"
 	"github.com/jackc/pgx/v5"
@@ -38,15 +41,33 @@ func NewRepository(pool Pool) TranslationRepository {
 // Create creates a new translation record
 func (r *translationRepository) Create(ctx context.Context, translation *model.Translation) error {
 	query := `
-		INSERT INTO translations (transcription_segment_id, target_language, translated_text, source)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO translations (transcription_segment_id, target_language, translated_text, source, approved, provider_options, translated_text_gz, style, split_strategy, chapter, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id, created_at`
 
-	err := r.pool.QueryRow(ctx, query,
+	stampCreatedAt(translation)
+
+	options, err := encodeProviderOptions(translation.ProviderOptions)
+	if err != nil {
+		return err
+	}
+	text, compressed, err := encodeText(translation.TranslatedText)
+	if err != nil {
+		return err
+	}
+
+	err = r.pool.QueryRow(ctx, query,
 		translation.TranscriptionSegmentID,
 		translation.TargetLanguage,
-		translation.TranslatedText,
-		translation.Source).Scan(&translation.ID, &translation.CreatedAt)
+		text,
+		translation.Source,
+		translation.Approved,
+		options,
+		compressed,
+		translation.Style,
+		translation.SplitStrategy,
+		translation.Chapter,
+		translation.CreatedAt).Scan(&translation.ID, &translation.CreatedAt)
 
 	if err != nil {
 		return err
@@ -55,75 +76,103 @@ func (r *translationRepository) Create(ctx context.Context, translation *model.T
 	return nil
 }
 
-// Get retrieves a translation by ID
+// Get retrieves a translation of the active workspace by ID. Translations are partitioned
+// through their transcription.
 func (r *translationRepository) Get(ctx context.Context, id int) (*model.Translation, error) {
 	query := `
-		SELECT id, transcription_segment_id, target_language, translated_text, source, created_at
-		FROM translations
-		WHERE id = $1`
+		SELECT t.id, t.transcription_segment_id, t.target_language, t.translated_text, t.translated_text_gz, t.source, t.style, t.split_strategy, t.chapter, t.approved, t.provider_options, t.created_at
+		FROM translations t
+		JOIN transcription_segments ts ON t.transcription_segment_id = ts.id
+		JOIN transcriptions tr ON ts.transcription_id = tr.id
+		WHERE t.id = $1 AND tr.workspace = current_workspace()`
 
-	var translation model.Translation
-	err := r.pool.QueryRow(ctx, query, id).
-		Scan(&translation.ID, &translation.TranscriptionSegmentID, &translation.TargetLanguage,
-			&translation.TranslatedText, &translation.Source, &translation.CreatedAt)
+	return scanTranslation(r.pool.QueryRow(ctx, query, id))
+}
 
-	if err != nil {
-		return nil, err
-	}
+// GetTranscriptionID looks up the transcription of a translation through its segment
+func (r *translationRepository) GetTranscriptionID(ctx context.Context, id int) (string, error) {
+	query := `
+		SELECT ts.transcription_id
+		FROM translations t
+		JOIN transcription_segments ts ON t.transcription_segment_id = ts.id
+		JOIN transcriptions tr ON ts.transcription_id = tr.id
+		WHERE t.id = $1 AND tr.workspace = current_workspace()`
 
-	return &translation, nil
+	var transcriptionID string
+	if err := r.pool.QueryRow(ctx, query, id).Scan(&transcriptionID); err != nil {
+		return "", err
+	}
+	return transcriptionID, nil
 }
 
-// GetByTranscriptionIDAndLanguage retrieves translation by transcription ID and target language
-func (r *translationRepository) GetByTranscriptionIDAndLanguage(ctx context.Context, transcriptionID string, targetLanguage string) (*model.Translation, error) {
+// GetByTranscriptionIDAndLanguage retrieves translation by transcription ID, target language and style
+func (r *translationRepository) GetByTranscriptionIDAndLanguage(ctx context.Context, transcriptionID string, targetLanguage, style string) (*model.Translation, error) {
 	// Join with transcription_segments to find translations for a transcription
 	query := `
-		SELECT t.id, t.transcription_segment_id, t.target_language, t.translated_text, t.source, t.created_at
+		SELECT t.id, t.transcription_segment_id, t.target_language, t.translated_text, t.translated_text_gz, t.source, t.style, t.split_strategy, t.chapter, t.approved, t.provider_options, t.created_at
 		FROM translations t
 		JOIN transcription_segments ts ON t.transcription_segment_id = ts.id
-		WHERE ts.transcription_id = $1 AND t.target_language = $2
+		WHERE ts.transcription_id = $1 AND t.target_language = $2 AND t.style = $3
 		ORDER BY ts.segment_index ASC
 		LIMIT 1`
 
-	var translation model.Translation
-	err := r.pool.QueryRow(ctx, query, transcriptionID, targetLanguage).
-		Scan(&translation.ID, &translation.TranscriptionSegmentID, &translation.TargetLanguage,
-			&translation.TranslatedText, &translation.Source, &translation.CreatedAt)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return &translation, nil
+	return scanTranslation(r.pool.QueryRow(ctx, query, transcriptionID, targetLanguage, style))
 }
 
-// Delete removes a translation record
+// Delete removes a translation record of the active workspace
 func (r *translationRepository) Delete(ctx context.Context, id int) error {
-	query := `DELETE FROM translations WHERE id = $1`
+	query := `
+		DELETE FROM translations t
+		USING transcription_segments ts, transcriptions tr
+		WHERE t.id = $1 AND t.transcription_segment_id = ts.id AND ts.transcription_id = tr.id
+			AND tr.workspace = current_workspace()`
 
 	_, err := r.pool.Exec(ctx, query, id)
 	return err
 }
 
-// CreateBatch creates multiple translations (placeholder implementation)
+// CreateBatch creates multiple translations, setting their IDs. COPY FROM returns no rows, so
+// the IDs are taken from the id sequence first and copied with the rows.
 func (r *translationRepository) CreateBatch(ctx context.Context, translations []*model.Translation) error {
 	if len(translations) == 0 {
 		return nil
 	}
 
+	ids, err := r.nextIDs(ctx, len(translations))
+	if err != nil {
+		return err
+	}
+
 	// Prepare data for COPY FROM
 	rows := make([][]interface{}, len(translations))
 	for i, t := range translations {
+		stampCreatedAt(t)
+		options, err := encodeProviderOptions(t.ProviderOptions)
+		if err != nil {
+			return err
+		}
+		text, compressed, err := encodeText(t.TranslatedText)
+		if err != nil {
+			return err
+		}
 		rows[i] = []interface{}{
+			ids[i],
 			t.TranscriptionSegmentID,
 			t.TargetLanguage,
-			t.TranslatedText,
+			text,
 			t.Source,
+			t.Approved,
+			options,
+			compressed,
+			t.Style,
+			t.SplitStrategy,
+			t.Chapter,
+			t.CreatedAt,
 		}
 	}
 
 	// Use CopyFrom for efficient bulk insert
-	columns := []string{"transcription_segment_id", "target_language", "translated_text", "source"}
+	columns := []string{"id", "transcription_segment_id", "target_language", "translated_text", "source", "approved", "provider_options", "translated_text_gz", "style", "split_strategy", "chapter", "created_at"}
 	count, err := r.pool.CopyFrom(
 		ctx,
 		pgx.Identifier{"translations"},
@@ -138,20 +187,110 @@ func (r *translationRepository) CreateBatch(ctx context.Context, translations []
 	// Optionally, you can log the number of rows inserted
 	_ = count
 
+	for i, t := range translations {
+		t.ID = ids[i]
+	}
 	return nil
 }
 
+// nextIDs takes n values from the sequence of translation IDs
+func (r *translationRepository) nextIDs(ctx context.Context, n int) ([]int, error) {
+	query := `SELECT nextval(pg_get_serial_sequence('translations', 'id')) FROM generate_series(1, $1)`
+
+	rows, err := r.pool.Query(ctx, query, n)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) != n {
+		return nil, fmt.Errorf("expected %d translation IDs, got %d", n, len(ids))
+	}
+	return ids, nil
+}
+
+// stampCreatedAt sets the creation time of a translation made without one. Services stamp
+// translations with their clock; Create and CreateBatch always write created_at, so a zero time
+// would be stored as 0001-01-01 instead of falling back to the column default.
+func stampCreatedAt(translation *model.Translation) {
+	if translation.CreatedAt.IsZero() {
+		translation.CreatedAt = time.Now()
+	}
+}
+
+// scanTranslation scans a translation row selected with its translated_text_gz, style,
+// split_strategy and chapter columns, decompressing the text and decoding the provider options
+func scanTranslation(row pgx.Row) (*model.Translation, error) {
+	var translation model.Translation
+	var text string
+	var compressed, options []byte
+	err := row.Scan(&translation.ID, &translation.TranscriptionSegmentID, &translation.TargetLanguage,
+		&text, &compressed, &translation.Source, &translation.Style, &translation.SplitStrategy, &translation.Chapter, &translation.Approved, &options, &translation.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if translation.TranslatedText, err = decodeText(text, compressed); err != nil {
+		return nil, err
+	}
+	if translation.ProviderOptions, err = decodeProviderOptions(options); err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
+
+// encodeProviderOptions encodes provider options as JSON; empty options are stored as NULL
+func encodeProviderOptions(options map[string]string) ([]byte, error) {
+	if len(options) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode provider options: %w", err)
+	}
+	return data, nil
+}
+
+// decodeProviderOptions decodes the provider_options column; NULL decodes to nil
+func decodeProviderOptions(data []byte) (map[string]string, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var options map[string]string
+	if err := json.Unmarshal(data, &options); err != nil {
+		return nil, fmt.Errorf("failed to decode provider options: %w", err)
+	}
+	return options, nil
+}
+
 // GetByTranscriptionID retrieves all translations for a transcription (placeholder implementation)
 func (r *translationRepository) GetByTranscriptionID(ctx context.Context, transcriptionID string) ([]*model.Translation, error) {
 	// TODO: implement
 	return []*model.Translation{}, nil
 }
 
-// ListByTranscriptionID retrieves translations for a transcription with pagination
+// CountByTranscriptionID returns the number of translations of a transcription, in all languages
+func (r *translationRepository) CountByTranscriptionID(ctx context.Context, transcriptionID string) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM translations t
+		JOIN transcription_segments ts ON t.transcription_segment_id = ts.id
+		WHERE ts.transcription_id = $1`
+
+	var count int
+	if err := r.pool.QueryRow(ctx, query, transcriptionID).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListByTranscriptionID retrieves translations for a transcription with pagination, in every style
 func (r *translationRepository) ListByTranscriptionID(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error) {
 	// Join with transcription_segments to get translations for a transcription
 	query := `
-		SELECT t.id, t.transcription_segment_id, t.target_language, t.translated_text, t.source, t.created_at
+		SELECT t.id, t.transcription_segment_id, t.target_language, t.translated_text, t.translated_text_gz, t.source, t.style, t.split_strategy, t.chapter, t.approved, t.provider_options, t.created_at
 		FROM translations t
 		JOIN transcription_segments ts ON t.transcription_segment_id = ts.id
 		WHERE ts.transcription_id = $1
@@ -166,13 +305,11 @@ func (r *translationRepository) ListByTranscriptionID(ctx context.Context, trans
 
 	var translations []*model.Translation
 	for rows.Next() {
-		var translation model.Translation
-		err := rows.Scan(&translation.ID, &translation.TranscriptionSegmentID, &translation.TargetLanguage,
-			&translation.TranslatedText, &translation.Source, &translation.CreatedAt)
+		translation, err := scanTranslation(rows)
 		if err != nil {
 			return nil, err
 		}
-		translations = append(translations, &translation)
+		translations = append(translations, translation)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -182,6 +319,37 @@ func (r *translationRepository) ListByTranscriptionID(ctx context.Context, trans
 	return translations, nil
 }
 
+// ListLanguagesByTranscriptionID returns the distinct target languages of a transcription's translations, sorted
+func (r *translationRepository) ListLanguagesByTranscriptionID(ctx context.Context, transcriptionID string) ([]string, error) {
+	query := `
+		SELECT DISTINCT t.target_language
+		FROM translations t
+		JOIN transcription_segments ts ON t.transcription_segment_id = ts.id
+		WHERE ts.transcription_id = $1
+		ORDER BY t.target_language`
+
+	rows, err := r.pool.Query(ctx, query, transcriptionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	languages := []string{}
+	for rows.Next() {
+		var language string
+		if err := rows.Scan(&language); err != nil {
+			return nil, err
+		}
+		languages = append(languages, language)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return languages, nil
+}
+
 // GetByVideoIDAndLanguage retrieves translations by video ID and language (placeholder implementation)
 func (r *translationRepository) GetByVideoIDAndLanguage(ctx context.Context, videoID, targetLanguage string) ([]*model.Translation, error) {
 	// TODO: implement
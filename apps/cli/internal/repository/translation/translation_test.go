@@ -7,12 +7,14 @@ import (
 	"time"
 
 	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/jackc/pgx/v5"
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestTranslationRepository_Create(t *testing.T) {
+	createdAt := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
 	tests := []struct {
 		name        string
 		translation *model.Translation
@@ -25,6 +27,7 @@ func TestTranslationRepository_Create(t *testing.T) {
 				TargetLanguage:         "ja",
 				TranslatedText:         "こんにちは",
 				Source:                 "plamo",
+				CreatedAt:              createdAt,
 			},
 			wantErr: false,
 		},
@@ -35,6 +38,7 @@ func TestTranslationRepository_Create(t *testing.T) {
 				TargetLanguage:         "ja",
 				TranslatedText:         "こんにちは",
 				Source:                 "plamo",
+				CreatedAt:              createdAt,
 			},
 			wantErr: true,
 		},
@@ -52,15 +56,15 @@ func TestTranslationRepository_Create(t *testing.T) {
 				// Expect constraint violation error
 				mock.ExpectQuery("INSERT INTO translations").
 					WithArgs(tt.translation.TranscriptionSegmentID, tt.translation.TargetLanguage,
-						tt.translation.TranslatedText, tt.translation.Source).
+						tt.translation.TranslatedText, tt.translation.Source, tt.translation.Approved, []byte(nil), []byte(nil), "", "", "", createdAt).
 					WillReturnError(errors.New("constraint violation"))
 			} else {
 				// Expect successful insert with returning ID and created_at
 				rows := mock.NewRows([]string{"id", "created_at"}).
-					AddRow(1, time.Now())
+					AddRow(1, createdAt)
 				mock.ExpectQuery("INSERT INTO translations").
 					WithArgs(tt.translation.TranscriptionSegmentID, tt.translation.TargetLanguage,
-						tt.translation.TranslatedText, tt.translation.Source).
+						tt.translation.TranslatedText, tt.translation.Source, tt.translation.Approved, []byte(nil), []byte(nil), "", "", "", createdAt).
 					WillReturnRows(rows)
 			}
 
@@ -72,7 +76,7 @@ func TestTranslationRepository_Create(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 				assert.NotZero(t, tt.translation.ID)
-				assert.NotZero(t, tt.translation.CreatedAt)
+				assert.Equal(t, createdAt, tt.translation.CreatedAt)
 			}
 
 			require.NoError(t, mock.ExpectationsWereMet())
@@ -80,6 +84,27 @@ func TestTranslationRepository_Create(t *testing.T) {
 	}
 }
 
+func TestTranslationRepository_CreateBatch(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	translations := []*model.Translation{
+		{TranscriptionSegmentID: "seg-1", TargetLanguage: "ja", TranslatedText: "こんにちは", Source: "plamo", Chapter: "00:00:00.000-00:10:00.000"},
+		{TranscriptionSegmentID: "seg-2", TargetLanguage: "ja", TranslatedText: "世界", Source: "plamo", Chapter: "00:00:00.000-00:10:00.000"},
+	}
+	mock.ExpectQuery("SELECT nextval\\(pg_get_serial_sequence\\('translations', 'id'\\)\\) FROM generate_series\\(1, \\$1\\)").
+		WithArgs(2).
+		WillReturnRows(mock.NewRows([]string{"nextval"}).AddRow(41).AddRow(42))
+	mock.ExpectCopyFrom(pgx.Identifier{"translations"}, []string{"id", "transcription_segment_id", "target_language", "translated_text", "source", "approved", "provider_options", "translated_text_gz", "style", "split_strategy", "chapter", "created_at"}).
+		WillReturnResult(2)
+
+	require.NoError(t, NewTranslationRepository(mock).CreateBatch(context.Background(), translations))
+	assert.Equal(t, 41, translations[0].ID)
+	assert.Equal(t, 42, translations[1].ID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestTranslationRepository_Get(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -93,9 +118,9 @@ func TestTranslationRepository_Get(t *testing.T) {
 			name: "successful get",
 			id:   1,
 			setupMock: func(mock pgxmock.PgxPoolIface) {
-				rows := mock.NewRows([]string{"id", "transcription_segment_id", "target_language", "translated_text", "source", "created_at"}).
-					AddRow(1, "123", "ja", "こんにちは世界", "plamo", time.Now())
-				mock.ExpectQuery("SELECT (.+) FROM translations WHERE id = \\$1").
+				rows := mock.NewRows([]string{"id", "transcription_segment_id", "target_language", "translated_text", "translated_text_gz", "source", "style", "split_strategy", "chapter", "approved", "provider_options", "created_at"}).
+					AddRow(1, "123", "ja", "こんにちは世界", nil, "plamo", "", "", "", false, nil, time.Now())
+				mock.ExpectQuery("SELECT (.+) FROM translations t JOIN transcription_segments ts ON t.transcription_segment_id = ts.id JOIN transcriptions tr ON ts.transcription_id = tr.id WHERE t.id = \\$1 AND tr.workspace = current_workspace\\(\\)").
 					WithArgs(1).
 					WillReturnRows(rows)
 			},
@@ -112,7 +137,7 @@ func TestTranslationRepository_Get(t *testing.T) {
 			name: "translation not found",
 			id:   999,
 			setupMock: func(mock pgxmock.PgxPoolIface) {
-				mock.ExpectQuery("SELECT (.+) FROM translations WHERE id = \\$1").
+				mock.ExpectQuery("SELECT (.+) FROM translations t JOIN transcription_segments ts ON t.transcription_segment_id = ts.id JOIN transcriptions tr ON ts.transcription_id = tr.id WHERE t.id = \\$1 AND tr.workspace = current_workspace\\(\\)").
 					WithArgs(999).
 					WillReturnError(errors.New("no rows in result set"))
 			},
@@ -153,6 +178,50 @@ func TestTranslationRepository_Get(t *testing.T) {
 	}
 }
 
+func TestTranslationRepository_GetTranscriptionID(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewTranslationRepository(mock)
+	mock.ExpectQuery("SELECT ts.transcription_id FROM translations t JOIN transcription_segments ts ON t.transcription_segment_id = ts.id JOIN transcriptions tr ON ts.transcription_id = tr.id WHERE t.id = \\$1 AND tr.workspace = current_workspace\\(\\)").
+		WithArgs(7).
+		WillReturnRows(mock.NewRows([]string{"transcription_id"}).AddRow("trans-1"))
+
+	transcriptionID, err := repo.GetTranscriptionID(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, "trans-1", transcriptionID)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTranslationRepository_ProviderOptions(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewTranslationRepository(mock)
+	options := map[string]string{"openai.temperature": "0.2"}
+	data := []byte(`{"openai.temperature":"0.2"}`)
+
+	mock.ExpectQuery("INSERT INTO translations").
+		WithArgs("1", "ja", "こんにちは", "plamo-polished", false, data, []byte(nil), "", "", "", pgxmock.AnyArg()).
+		WillReturnRows(mock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+	require.NoError(t, repo.Create(context.Background(), &model.Translation{
+		TranscriptionSegmentID: "1", TargetLanguage: "ja", TranslatedText: "こんにちは", Source: "plamo-polished", ProviderOptions: options,
+	}))
+
+	mock.ExpectQuery("SELECT (.+) FROM translations t JOIN transcription_segments ts ON t.transcription_segment_id = ts.id JOIN transcriptions tr ON ts.transcription_id = tr.id WHERE t.id = \\$1 AND tr.workspace = current_workspace\\(\\)").
+		WithArgs(1).
+		WillReturnRows(mock.NewRows([]string{"id", "transcription_segment_id", "target_language", "translated_text", "translated_text_gz", "source", "style", "split_strategy", "chapter", "approved", "provider_options", "created_at"}).
+			AddRow(1, "1", "ja", "こんにちは", nil, "plamo-polished", "", "", "", false, data, time.Now()))
+	got, err := repo.Get(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, options, got.ProviderOptions)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestTranslationRepository_GetByTranscriptionSegmentIDAndLanguage(t *testing.T) {
 	mock, err := pgxmock.NewPool()
 	require.NoError(t, err)
@@ -164,19 +233,21 @@ func TestTranslationRepository_GetByTranscriptionSegmentIDAndLanguage(t *testing
 	targetLanguage := "ja"
 
 	// Setup mock expectation
-	rows := mock.NewRows([]string{"id", "transcription_segment_id", "target_language", "translated_text", "source", "created_at"}).
-		AddRow(1, transcriptionID, targetLanguage, "こんにちは", "plamo", time.Now())
-	mock.ExpectQuery("SELECT (.+) FROM translations t JOIN transcription_segments ts ON t.transcription_segment_id = ts.id WHERE ts.transcription_id = \\$1 AND t.target_language = \\$2").
-		WithArgs(transcriptionID, targetLanguage).
+	rows := mock.NewRows([]string{"id", "transcription_segment_id", "target_language", "translated_text", "translated_text_gz", "source", "style", "split_strategy", "chapter", "approved", "provider_options", "created_at"}).
+		AddRow(1, transcriptionID, targetLanguage, "こんにちは", nil, "plamo", "", "sentences", "00:00:00.000-00:10:00.000", false, nil, time.Now())
+	mock.ExpectQuery("SELECT (.+) FROM translations t JOIN transcription_segments ts ON t.transcription_segment_id = ts.id WHERE ts.transcription_id = \\$1 AND t.target_language = \\$2 AND t.style = \\$3").
+		WithArgs(transcriptionID, targetLanguage, "").
 		WillReturnRows(rows)
 
 	ctx := context.Background()
-	translation, err := repo.GetByTranscriptionIDAndLanguage(ctx, transcriptionID, targetLanguage)
+	translation, err := repo.GetByTranscriptionIDAndLanguage(ctx, transcriptionID, targetLanguage, "")
 
 	require.NoError(t, err)
 	require.NotNil(t, translation)
 	assert.Equal(t, transcriptionID, translation.TranscriptionSegmentID)
 	assert.Equal(t, targetLanguage, translation.TargetLanguage)
+	assert.Equal(t, "sentences", translation.SplitStrategy)
+	assert.Equal(t, "00:00:00.000-00:10:00.000", translation.Chapter)
 
 	require.NoError(t, mock.ExpectationsWereMet())
 }
@@ -198,9 +269,9 @@ func TestTranslationRepository_ListByTranscriptionSegmentID(t *testing.T) {
 			limit:           10,
 			offset:          0,
 			setupMock: func(mock pgxmock.PgxPoolIface) {
-				rows := mock.NewRows([]string{"id", "transcription_segment_id", "target_language", "translated_text", "source", "created_at"}).
-					AddRow(1, "123", "ja", "こんにちは", "plamo", time.Now()).
-					AddRow(2, "123", "en", "hello", "plamo", time.Now())
+				rows := mock.NewRows([]string{"id", "transcription_segment_id", "target_language", "translated_text", "translated_text_gz", "source", "style", "split_strategy", "chapter", "approved", "provider_options", "created_at"}).
+					AddRow(1, "123", "ja", "こんにちは", nil, "plamo", "", "", "", false, nil, time.Now()).
+					AddRow(2, "123", "en", "hello", nil, "plamo", "", "", "", false, nil, time.Now())
 				mock.ExpectQuery("SELECT (.+) FROM translations t JOIN transcription_segments ts ON t.transcription_segment_id = ts.id WHERE ts.transcription_id = \\$1 ORDER BY ts.segment_index ASC, t.created_at DESC LIMIT \\$2 OFFSET \\$3").
 					WithArgs("123", 10, 0).
 					WillReturnRows(rows)
@@ -214,7 +285,7 @@ func TestTranslationRepository_ListByTranscriptionSegmentID(t *testing.T) {
 			limit:           10,
 			offset:          0,
 			setupMock: func(mock pgxmock.PgxPoolIface) {
-				rows := mock.NewRows([]string{"id", "transcription_id", "target_language", "content", "source", "created_at"})
+				rows := mock.NewRows([]string{"id", "transcription_id", "target_language", "content", "translated_text_gz", "source", "style", "split_strategy", "chapter", "approved", "provider_options", "created_at"})
 				mock.ExpectQuery("SELECT (.+) FROM translations t JOIN transcription_segments ts ON t.transcription_segment_id = ts.id WHERE ts.transcription_id = \\$1 ORDER BY ts.segment_index ASC, t.created_at DESC LIMIT \\$2 OFFSET \\$3").
 					WithArgs("999", 10, 0).
 					WillReturnRows(rows)
@@ -268,6 +339,40 @@ func TestTranslationRepository_ListByTranscriptionSegmentID(t *testing.T) {
 	}
 }
 
+func TestTranslationRepository_ListLanguagesByTranscriptionID(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewTranslationRepository(mock)
+	mock.ExpectQuery("SELECT DISTINCT t.target_language FROM translations t JOIN transcription_segments ts ON t.transcription_segment_id = ts.id WHERE ts.transcription_id = \\$1 ORDER BY t.target_language").
+		WithArgs("123").
+		WillReturnRows(mock.NewRows([]string{"target_language"}).AddRow("en").AddRow("ja"))
+
+	languages, err := repo.ListLanguagesByTranscriptionID(context.Background(), "123")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"en", "ja"}, languages)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTranslationRepository_CountByTranscriptionID(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewTranslationRepository(mock)
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM translations t JOIN transcription_segments ts ON t.transcription_segment_id = ts.id WHERE ts.transcription_id = \\$1").
+		WithArgs("123").
+		WillReturnRows(mock.NewRows([]string{"count"}).AddRow(42))
+
+	count, err := repo.CountByTranscriptionID(context.Background(), "123")
+	require.NoError(t, err)
+	assert.Equal(t, 42, count)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestTranslationRepository_Delete(t *testing.T) {
 	mock, err := pgxmock.NewPool()
 	require.NoError(t, err)
@@ -278,7 +383,7 @@ func TestTranslationRepository_Delete(t *testing.T) {
 	translationID := 1
 
 	// Setup mock expectation for delete
-	mock.ExpectExec("DELETE FROM translations WHERE id = \\$1").
+	mock.ExpectExec("DELETE FROM translations t USING transcription_segments ts, transcriptions tr WHERE t.id = \\$1 .+ AND tr.workspace = current_workspace\\(\\)").
 		WithArgs(translationID).
 		WillReturnResult(pgxmock.NewResult("DELETE", 1))
 
@@ -0,0 +1,57 @@
+package translation
+
+import (
+	"context"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/jackc/pgx/v5"
+)
+
+// warningRepository implements WarningRepository
+type warningRepository struct {
+	pool Pool
+}
+
+// NewWarningRepository creates a new translation warning repository
+func NewWarningRepository(pool Pool) WarningRepository {
+	return &warningRepository{
+		pool: pool,
+	}
+}
+
+// ReplaceByTranscriptionID deletes the previous warnings and inserts the new ones in one transaction
+func (r *warningRepository) ReplaceByTranscriptionID(ctx context.Context, transcriptionID, targetLanguage string, warnings []*model.TranslationWarning) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		DELETE FROM translation_warnings w
+		USING transcription_segments ts
+		WHERE w.transcription_segment_id = ts.id AND ts.transcription_id = $1 AND w.target_language = $2`
+
+	if _, err := tx.Exec(ctx, query, transcriptionID, targetLanguage); err != nil {
+		return err
+	}
+
+	if len(warnings) > 0 {
+		rows := make([][]interface{}, len(warnings))
+		for i, w := range warnings {
+			rows[i] = []interface{}{
+				w.TranscriptionSegmentID,
+				w.TargetLanguage,
+				w.Code,
+				w.Message,
+			}
+		}
+
+		columns := []string{"transcription_segment_id", "target_language", "code", "message"}
+		if _, err := tx.CopyFrom(ctx, pgx.Identifier{"translation_warnings"}, columns, pgx.CopyFromRows(rows)); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,75 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deleteWarningsQuery = "DELETE FROM translation_warnings w USING transcription_segments ts WHERE w.transcription_segment_id = ts.id AND ts.transcription_id = \\$1 AND w.target_language = \\$2"
+
+func TestWarningRepository_ReplaceByTranscriptionID(t *testing.T) {
+	warnings := []*model.TranslationWarning{
+		{TranscriptionSegmentID: "seg-1", TargetLanguage: "ja", Code: "empty_translation", Message: "translation is empty"},
+		{TranscriptionSegmentID: "seg-2", TargetLanguage: "ja", Code: "untranslated_copy", Message: "translation repeats the source"},
+	}
+
+	t.Run("replaces warnings in a transaction", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteWarningsQuery).
+			WithArgs("trans-1", "ja").
+			WillReturnResult(pgxmock.NewResult("DELETE", 3))
+		mock.ExpectCopyFrom(pgx.Identifier{"translation_warnings"}, []string{"transcription_segment_id", "target_language", "code", "message"}).
+			WillReturnResult(2)
+		mock.ExpectCommit()
+
+		err = NewWarningRepository(mock).ReplaceByTranscriptionID(context.Background(), "trans-1", "ja", warnings)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no warnings only clears previous ones", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteWarningsQuery).
+			WithArgs("trans-1", "ja").
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+		mock.ExpectCommit()
+
+		err = NewWarningRepository(mock).ReplaceByTranscriptionID(context.Background(), "trans-1", "ja", nil)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("insert failure rolls back", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteWarningsQuery).
+			WithArgs("trans-1", "ja").
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+		mock.ExpectCopyFrom(pgx.Identifier{"translation_warnings"}, []string{"transcription_segment_id", "target_language", "code", "message"}).
+			WillReturnError(errors.New("copy failed"))
+		mock.ExpectRollback()
+
+		err = NewWarningRepository(mock).ReplaceByTranscriptionID(context.Background(), "trans-1", "ja", warnings)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "copy failed")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
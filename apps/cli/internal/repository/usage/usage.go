@@ -0,0 +1,21 @@
+package usage
+
+import (
+	"context"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// Repository defines operations on the local usage stats of the active workspace
+type Repository interface {
+	// Record stores one run of a command
+	Record(ctx context.Context, usage *model.CommandUsage) error
+
+	// Summary sums up the runs of each command started at or after since (all runs when zero),
+	// the commands taking the most time in total first
+	Summary(ctx context.Context, since time.Time) ([]*model.CommandUsageStats, error)
+
+	// Clear deletes every recorded run and returns how many there were
+	Clear(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,79 @@
+package usage
+
+import (
+	"context"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/common"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Pool interface for abstracting pgx connection pool
+type Pool interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// usageRepository implements Repository using PostgreSQL
+type usageRepository struct {
+	pool Pool
+}
+
+// NewRepository creates a new instance of Repository
+func NewRepository(pool Pool) Repository {
+	return &usageRepository{
+		pool: pool,
+	}
+}
+
+// Record stores one run of a command
+func (r *usageRepository) Record(ctx context.Context, usage *model.CommandUsage) error {
+	sql := `INSERT INTO command_usage (command, started_at, duration_ms, succeeded, error_code) VALUES ($1, $2, $3, $4, $5)`
+	if _, err := r.pool.Exec(ctx, sql, usage.Command, usage.StartedAt, usage.Duration.Milliseconds(), usage.Succeeded, usage.ErrorCode); err != nil {
+		return common.HandlePostgreSQLError(err, "failed to record command usage")
+	}
+	return nil
+}
+
+// Summary sums up the runs of each command started at or after since
+func (r *usageRepository) Summary(ctx context.Context, since time.Time) ([]*model.CommandUsageStats, error) {
+	sql := `SELECT command, COUNT(*), COUNT(*) FILTER (WHERE NOT succeeded),
+			SUM(duration_ms)::float8 / 1000,
+			(percentile_cont(0.5) WITHIN GROUP (ORDER BY duration_ms))::float8 / 1000,
+			MAX(duration_ms)::float8 / 1000,
+			MAX(started_at)
+		FROM command_usage
+		WHERE workspace = current_workspace() AND started_at >= $1
+		GROUP BY command
+		ORDER BY SUM(duration_ms) DESC, command`
+
+	rows, err := r.pool.Query(ctx, sql, since)
+	if err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to summarize command usage")
+	}
+	defer rows.Close()
+
+	stats := []*model.CommandUsageStats{}
+	for rows.Next() {
+		s := &model.CommandUsageStats{}
+		if err := rows.Scan(&s.Command, &s.Runs, &s.Failed, &s.TotalSeconds, &s.MedianSeconds, &s.LongestSeconds, &s.LastRunAt); err != nil {
+			return nil, common.HandlePostgreSQLError(err, "failed to scan command usage")
+		}
+		stats = append(stats, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to iterate command usage")
+	}
+	return stats, nil
+}
+
+// Clear deletes every recorded run of the workspace
+func (r *usageRepository) Clear(ctx context.Context) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM command_usage WHERE workspace = current_workspace()`)
+	if err != nil {
+		return 0, common.HandlePostgreSQLError(err, "failed to clear command usage")
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,89 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+func TestUsageRepository_Record(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	started := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	code := apperrors.CodeExternal
+	mock.ExpectExec("INSERT INTO command_usage \\(command, started_at, duration_ms, succeeded, error_code\\)").
+		WithArgs("transcription create", started, int64(95500), false, &code).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err = NewRepository(mock).Record(context.Background(), &model.CommandUsage{
+		Command:   "transcription create",
+		StartedAt: started,
+		Duration:  95500 * time.Millisecond,
+		ErrorCode: &code,
+	})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUsageRepository_Summary(t *testing.T) {
+	since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	columns := []string{"command", "runs", "failed", "total", "median", "longest", "last_run_at"}
+
+	t.Run("sums up each command of the workspace", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		last := time.Date(2024, 5, 3, 12, 0, 0, 0, time.UTC)
+		mock.ExpectQuery("SELECT command, COUNT\\(\\*\\).*FROM command_usage.*WHERE workspace = current_workspace\\(\\) AND started_at >= \\$1.*GROUP BY command").
+			WithArgs(since).
+			WillReturnRows(pgxmock.NewRows(columns).
+				AddRow("transcription create", 4, 1, 1800.0, 420.0, 700.0, last).
+				AddRow("video list", 10, 0, 5.0, 0.4, 1.2, last))
+
+		stats, err := NewRepository(mock).Summary(context.Background(), since)
+		require.NoError(t, err)
+		assert.Equal(t, []*model.CommandUsageStats{
+			{Command: "transcription create", Runs: 4, Failed: 1, TotalSeconds: 1800, MedianSeconds: 420, LongestSeconds: 700, LastRunAt: last},
+			{Command: "video list", Runs: 10, TotalSeconds: 5, MedianSeconds: 0.4, LongestSeconds: 1.2, LastRunAt: last},
+		}, stats)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery("SELECT command").WithArgs(since).WillReturnError(errors.New("connection refused"))
+
+		_, err = NewRepository(mock).Summary(context.Background(), since)
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.CodeInternal, appErr.Code)
+	})
+}
+
+func TestUsageRepository_Clear(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec("DELETE FROM command_usage WHERE workspace = current_workspace\\(\\)").
+		WillReturnResult(pgxmock.NewResult("DELETE", 12))
+
+	deleted, err := NewRepository(mock).Clear(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), deleted)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,82 @@
+package video
+
+import (
+	"context"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/common"
+	"github.com/jackc/pgx/v5"
+)
+
+// tagRepository implements TagRepository using PostgreSQL
+type tagRepository struct {
+	pool Pool
+}
+
+// NewTagRepository creates a new video tag repository
+func NewTagRepository(pool Pool) TagRepository {
+	return &tagRepository{
+		pool: pool,
+	}
+}
+
+// ReplaceByVideoID deletes the previous tags of a video and inserts the new ones in one transaction
+func (r *tagRepository) ReplaceByVideoID(ctx context.Context, videoID string, tags []*model.VideoTag) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	sql := "DELETE FROM video_tags WHERE workspace = current_workspace() AND video_id = $1"
+	if _, err := tx.Exec(ctx, sql, videoID); err != nil {
+		return common.HandlePostgreSQLError(err, "failed to delete video tags")
+	}
+
+	if len(tags) > 0 {
+		rows := make([][]interface{}, len(tags))
+		for i, tag := range tags {
+			rows[i] = []interface{}{videoID, tag.Tag, tag.Score}
+		}
+
+		columns := []string{"video_id", "tag", "score"}
+		if _, err := tx.CopyFrom(ctx, pgx.Identifier{"video_tags"}, columns, pgx.CopyFromRows(rows)); err != nil {
+			return common.HandlePostgreSQLError(err, "failed to insert video tags")
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return common.HandlePostgreSQLError(err, "failed to commit video tags")
+	}
+	return nil
+}
+
+// ListVideosByTag retrieves the videos tagged with tag, highest scoring first
+func (r *tagRepository) ListVideosByTag(ctx context.Context, tag string) ([]*model.Video, error) {
+	sql := `SELECT v.id, v.channel_id, v.title, v.url, v.duration, v.status, v.upload_date, v.rating, v.note FROM videos v
+		JOIN video_tags t ON t.workspace = v.workspace AND t.video_id = v.id
+		WHERE v.workspace = current_workspace() AND t.tag = $1
+		ORDER BY t.score DESC, v.id`
+
+	rows, err := r.pool.Query(ctx, sql, tag)
+	if err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to list tagged videos")
+	}
+	defer rows.Close()
+
+	videos := []*model.Video{}
+	for rows.Next() {
+		var video model.Video
+		err := rows.Scan(&video.ID, &video.ChannelID, &video.Title, &video.URL, &video.Duration, &video.Status, &video.UploadDate, &video.Rating, &video.Note)
+		if err != nil {
+			return nil, common.HandlePostgreSQLError(err, "failed to scan video row")
+		}
+		videos = append(videos, &video)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to iterate video rows")
+	}
+
+	return videos, nil
+}
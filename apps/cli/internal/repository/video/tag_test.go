@@ -0,0 +1,99 @@
+package video
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deleteTagsQuery = "DELETE FROM video_tags WHERE workspace = current_workspace\\(\\) AND video_id = \\$1"
+
+func TestTagRepository_ReplaceByVideoID(t *testing.T) {
+	tags := []*model.VideoTag{
+		{Tag: "paella", Score: 0.42},
+		{Tag: "arroz", Score: 0.31},
+	}
+
+	t.Run("replaces tags in a transaction", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteTagsQuery).
+			WithArgs("video1").
+			WillReturnResult(pgxmock.NewResult("DELETE", 4))
+		mock.ExpectCopyFrom(pgx.Identifier{"video_tags"}, []string{"video_id", "tag", "score"}).
+			WillReturnResult(2)
+		mock.ExpectCommit()
+
+		err = NewTagRepository(mock).ReplaceByVideoID(context.Background(), "video1", tags)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no tags only clears previous ones", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteTagsQuery).
+			WithArgs("video1").
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+		mock.ExpectCommit()
+
+		err = NewTagRepository(mock).ReplaceByVideoID(context.Background(), "video1", nil)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("insert failure rolls back", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(deleteTagsQuery).
+			WithArgs("video1").
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+		mock.ExpectCopyFrom(pgx.Identifier{"video_tags"}, []string{"video_id", "tag", "score"}).
+			WillReturnError(errors.New("copy failed"))
+		mock.ExpectRollback()
+
+		err = NewTagRepository(mock).ReplaceByVideoID(context.Background(), "video1", tags)
+		require.Error(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTagRepository_ListVideosByTag(t *testing.T) {
+	query := `SELECT v.id, v.channel_id, v.title, v.url, v.duration, v.status, v.upload_date, v.rating, v.note FROM videos v
+		JOIN video_tags t ON t.workspace = v.workspace AND t.video_id = v.id
+		WHERE v.workspace = current_workspace\(\) AND t.tag = \$1
+		ORDER BY t.score DESC, v.id`
+	columns := []string{"id", "channel_id", "title", "url", "duration", "status", "upload_date", "rating", "note"}
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(query).
+		WithArgs("paella").
+		WillReturnRows(pgxmock.NewRows(columns).
+			AddRow("video1", "UCcook", "Paella valenciana", "https://www.youtube.com/watch?v=video1", 600.0, "available", nil, nil, nil).
+			AddRow("video2", "UCcook", "Arroz negro", "https://www.youtube.com/watch?v=video2", 480.0, "available", nil, nil, nil))
+
+	got, err := NewTagRepository(mock).ListVideosByTag(context.Background(), "paella")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "video1", got[0].ID)
+	assert.Equal(t, "Arroz negro", got[1].Title)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,6 +2,7 @@ package video
 
 import (
 	"context"
+	"time"
 
 	"github.com/Taichi-iskw/yt-lang/internal/model"
 )
@@ -17,18 +18,110 @@ type Repository interface {
 	// UpsertBatch creates or ignores multiple video records, filtering duplicates by channel
 	UpsertBatch(ctx context.Context, videos []*model.Video) error
 
+	// DetectChanges compares listed videos with the saved ones by metadata hash. Saved videos whose
+	// title, duration or upload date changed take the listed metadata, their completed
+	// transcriptions not stale yet are marked stale, and they are returned. Fields the listing
+	// leaves out (empty title, zero duration, no upload date) count as unchanged; videos not saved
+	// yet are ignored.
+	DetectChanges(ctx context.Context, videos []*model.Video) ([]*model.VideoChange, error)
+
 	// GetByID retrieves a video by its ID
 	GetByID(ctx context.Context, id string) (*model.Video, error)
 
 	// GetByChannelID retrieves videos by channel ID with pagination
 	GetByChannelID(ctx context.Context, channelID string, limit, offset int) ([]*model.Video, error)
 
-	// Update updates an existing video record
+	// CountByChannelID returns the number of videos of a channel, for pagination
+	CountByChannelID(ctx context.Context, channelID string) (int, error)
+
+	// CountByChannelIDAndStatus returns the number of videos of a channel with an availability status
+	CountByChannelIDAndStatus(ctx context.Context, channelID string, status string) (int, error)
+
+	// Update updates an existing video record; fails with CodeConflict if it changed since it was read
 	Update(ctx context.Context, video *model.Video) error
 
+	// UpdateStatus sets the availability status of a video (available, unavailable)
+	UpdateStatus(ctx context.Context, id string, status string) error
+
+	// SetDuplicateOf links a video to the original it re-uploads; an empty originalID removes the link.
+	// Batch transcription skips linked duplicates.
+	SetDuplicateOf(ctx context.Context, id string, originalID string) error
+
+	// UpdateAnnotation changes the rating and note of a video in one statement, so concurrent
+	// annotations don't overwrite each other. Nil keeps the current value, an empty note removes
+	// it, and clear removes both before the others are applied.
+	UpdateAnnotation(ctx context.Context, id string, rating *int, note *string, clear bool) error
+
+	// SetChapters replaces the YouTube chapters recorded for a video
+	SetChapters(ctx context.Context, id string, chapters []model.VideoChapter) error
+
+	// GetChapters retrieves the YouTube chapters recorded for a video; none when unknown
+	GetChapters(ctx context.Context, id string) ([]model.VideoChapter, error)
+
 	// Delete deletes a video by its ID
 	Delete(ctx context.Context, id string) error
 
 	// List retrieves videos with pagination
 	List(ctx context.Context, limit, offset int) ([]*model.Video, error)
+
+	// ListTranscriptionCandidates retrieves available videos of a channel that have no
+	// transcription in the filter's language, within its duration and upload date bounds
+	ListTranscriptionCandidates(ctx context.Context, filter CandidateFilter) ([]*model.Video, error)
+
+	// PlanTranscriptionCandidates retrieves every video of the filter's channel with the reason
+	// ListTranscriptionCandidates would leave it out, if any
+	PlanTranscriptionCandidates(ctx context.Context, filter CandidateFilter) ([]*PlannedVideo, error)
+
+	// ListByMinRating retrieves the videos rated at least minRating, best rated first, of a
+	// channel or of every channel when channelID is empty
+	ListByMinRating(ctx context.Context, channelID string, minRating int) ([]*model.Video, error)
+
+	// ListPruneCandidates retrieves the untouched videos uploaded before the filter's date:
+	// unrated, without a note and, with KeepTranscribed, without any transcription
+	ListPruneCandidates(ctx context.Context, filter PruneFilter) ([]*model.Video, error)
+}
+
+// PruneFilter selects videos for library pruning. Videos of unknown upload date are never selected.
+type PruneFilter struct {
+	UploadedBefore  time.Time
+	KeepTranscribed bool // Videos with a transcription are excluded
+}
+
+// CandidateFilter selects videos for batch transcription. Zero durations, date and limit don't filter.
+// Videos linked as duplicates of another video are always excluded.
+type CandidateFilter struct {
+	ChannelID   string
+	Language    string        // Videos with a transcription in this language are excluded
+	MinDuration time.Duration // Shorter videos are excluded
+	MaxDuration time.Duration // Longer videos, and videos of unknown duration, are excluded
+	Limit       int
+
+	// PublishedAfter excludes videos uploaded before this date, and videos of unknown upload date
+	PublishedAfter time.Time
+}
+
+// Reasons batch transcription leaves a video out, in the order they are checked
+const (
+	SkipUnavailable     = "unavailable"      // The video was unavailable at its last check
+	SkipDuplicate       = "duplicate"        // The video is linked as a re-upload of another video
+	SkipTranscribed     = "transcribed"      // The video has a transcription in the language
+	SkipTooShort        = "too_short"        // The video is shorter than MinDuration
+	SkipTooLong         = "too_long"         // The video is longer than MaxDuration, or of unknown duration
+	SkipPublishedBefore = "published_before" // The video was uploaded before PublishedAfter, or on an unknown date
+	SkipLimit           = "limit"            // The video is past Limit
+)
+
+// PlannedVideo is a video of a batch transcription plan
+type PlannedVideo struct {
+	Video *model.Video `json:"video"`
+	Skip  string       `json:"skip,omitempty"` // Why the video is left out; empty when it is transcribed
+}
+
+// TagRepository defines operations for video topic tag persistence
+type TagRepository interface {
+	// ReplaceByVideoID replaces the tags of a video
+	ReplaceByVideoID(ctx context.Context, videoID string, tags []*model.VideoTag) error
+
+	// ListVideosByTag retrieves the videos tagged with tag, those it scores highest in first
+	ListVideosByTag(ctx context.Context, tag string) ([]*model.Video, error)
 }
@@ -41,7 +41,7 @@ func TestVideoRepository_CreateBatch(t *testing.T) {
 				// Expect CopyFrom call for bulk insert
 				mock.ExpectCopyFrom(
 					[]string{"videos"}, // table identifier
-					[]string{"id", "channel_id", "title", "url", "duration"}, // columns
+					[]string{"id", "channel_id", "title", "url", "duration", "upload_date"}, // columns
 				).WillReturnResult(2) // 2 rows inserted
 			},
 			wantErr: false,
@@ -69,7 +69,7 @@ func TestVideoRepository_CreateBatch(t *testing.T) {
 				// Expect CopyFrom call that fails
 				mock.ExpectCopyFrom(
 					[]string{"videos"}, // table identifier
-					[]string{"id", "channel_id", "title", "url", "duration"}, // columns
+					[]string{"id", "channel_id", "title", "url", "duration", "upload_date"}, // columns
 				).WillReturnError(assert.AnError)
 			},
 			wantErr: true,
@@ -110,6 +110,8 @@ func TestVideoRepository_CreateBatch(t *testing.T) {
 }
 
 func TestVideoRepository_UpsertBatch(t *testing.T) {
+	uploadDate := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
 	tests := []struct {
 		name    string
 		videos  []*model.Video
@@ -137,11 +139,11 @@ func TestVideoRepository_UpsertBatch(t *testing.T) {
 			setup: func(mock pgxmock.PgxPoolIface) {
 				// First query: get existing video IDs for the channel
 				mock.ExpectQuery("SELECT id FROM videos WHERE channel_id = \\$1").
-					WithArgs("UC123456789").
+					WithArgs("UC123456789", []string{"video1", "video2"}).
 					WillReturnRows(pgxmock.NewRows([]string{"id"})) // No existing videos
 
 				// Second: COPY FROM for all videos (none filtered out)
-				mock.ExpectCopyFrom(pgx.Identifier{"videos"}, []string{"id", "channel_id", "title", "url", "duration"}).
+				mock.ExpectCopyFrom(pgx.Identifier{"videos"}, []string{"id", "channel_id", "title", "url", "duration", "upload_date"}).
 					WillReturnResult(2)
 			},
 			wantErr: false,
@@ -167,12 +169,12 @@ func TestVideoRepository_UpsertBatch(t *testing.T) {
 			setup: func(mock pgxmock.PgxPoolIface) {
 				// First query: get existing video IDs
 				mock.ExpectQuery("SELECT id FROM videos WHERE channel_id = \\$1").
-					WithArgs("UC123456789").
+					WithArgs("UC123456789", []string{"video1", "video3"}).
 					WillReturnRows(pgxmock.NewRows([]string{"id"}).
 						AddRow("video1")) // video1 already exists
 
 				// Second: COPY FROM only video3 (video1 filtered out)
-				mock.ExpectCopyFrom(pgx.Identifier{"videos"}, []string{"id", "channel_id", "title", "url", "duration"}).
+				mock.ExpectCopyFrom(pgx.Identifier{"videos"}, []string{"id", "channel_id", "title", "url", "duration", "upload_date"}).
 					WillReturnResult(1)
 			},
 			wantErr: false,
@@ -191,13 +193,36 @@ func TestVideoRepository_UpsertBatch(t *testing.T) {
 			setup: func(mock pgxmock.PgxPoolIface) {
 				// First query: all videos exist
 				mock.ExpectQuery("SELECT id FROM videos WHERE channel_id = \\$1").
-					WithArgs("UC123456789").
+					WithArgs("UC123456789", []string{"video1"}).
 					WillReturnRows(pgxmock.NewRows([]string{"id"}).
 						AddRow("video1"))
 				// No COPY FROM expected since all videos filtered out
 			},
 			wantErr: false,
 		},
+		{
+			name: "backfills upload dates of existing videos",
+			videos: []*model.Video{
+				{
+					ID:         "video1",
+					ChannelID:  "UC123456789",
+					Title:      "Video 1",
+					URL:        "https://www.youtube.com/watch?v=video1",
+					Duration:   300,
+					UploadDate: &uploadDate,
+				},
+			},
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("SELECT id FROM videos WHERE channel_id = \\$1").
+					WithArgs("UC123456789", []string{"video1"}).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).
+						AddRow("video1"))
+				mock.ExpectExec("UPDATE videos v SET upload_date = u.upload_date").
+					WithArgs([]string{"video1"}, []time.Time{uploadDate}).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			wantErr: false,
+		},
 		{
 			name:   "empty videos list",
 			videos: []*model.Video{},
@@ -240,3 +265,42 @@ func TestVideoRepository_UpsertBatch(t *testing.T) {
 		})
 	}
 }
+
+func TestVideoRepository_DetectChanges(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	uploadDate := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	saved := &model.Video{ID: "video1", Title: "Lección 1", Duration: 300, UploadDate: &uploadDate}
+	hash := saved.MetadataHash()
+
+	// video1 was re-uploaded with a new title and cut; video2 was saved without a duration
+	// or hash, so filling it in is not a change; video3 is not saved yet
+	mock.ExpectQuery("SELECT id, title, duration, upload_date, metadata_hash FROM videos").
+		WithArgs([]string{"video1", "video2", "video3"}).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "title", "duration", "upload_date", "metadata_hash"}).
+			AddRow("video1", "Lección 1", 300.0, &uploadDate, &hash).
+			AddRow("video2", "Lección 2", 0.0, nil, nil))
+	mock.ExpectExec("UPDATE videos v SET metadata_hash = u.hash").
+		WithArgs([]string{"video2"}, pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec("UPDATE transcriptions t SET stale_at = NOW\\(\\), stale_reason = u.reason .+ AND t.status = 'completed' AND t.stale_at IS NULL").
+		WithArgs([]string{"video1"}, []string{"title, duration changed on YouTube"}).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+	mock.ExpectExec("UPDATE videos v SET title = u.title").
+		WithArgs([]string{"video1"}, []string{"Lección 1 (corregida)"}, []float64{280}, []*time.Time{&uploadDate}, pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	repo := NewRepository(mock)
+	changes, err := repo.DetectChanges(context.Background(), []*model.Video{
+		{ID: "video1", Title: "Lección 1 (corregida)", Duration: 280},
+		{ID: "video2", Title: "Lección 2", Duration: 200},
+		{ID: "video3", Title: "Lección 3", Duration: 100},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*model.VideoChange{
+		{VideoID: "video1", Title: "Lección 1 (corregida)", Fields: []string{"title", "duration"}},
+	}, changes)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
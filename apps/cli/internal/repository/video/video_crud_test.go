@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
 	"github.com/Taichi-iskw/yt-lang/internal/model"
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
@@ -29,7 +30,7 @@ func TestVideoRepository_Create(t *testing.T) {
 			},
 			setup: func(mock pgxmock.PgxPoolIface) {
 				mock.ExpectExec("INSERT INTO videos").
-					WithArgs("dQw4w9WgXcQ", "UC123456789", "Never Gonna Give You Up", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", 212.0).
+					WithArgs("dQw4w9WgXcQ", "UC123456789", "Never Gonna Give You Up", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", 212.0, (*time.Time)(nil)).
 					WillReturnResult(pgxmock.NewResult("INSERT", 1))
 			},
 			wantErr: false,
@@ -45,7 +46,7 @@ func TestVideoRepository_Create(t *testing.T) {
 			},
 			setup: func(mock pgxmock.PgxPoolIface) {
 				mock.ExpectExec("INSERT INTO videos").
-					WithArgs("dQw4w9WgXcQ", "UC123456789", "Never Gonna Give You Up", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", 212.0).
+					WithArgs("dQw4w9WgXcQ", "UC123456789", "Never Gonna Give You Up", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", 212.0, (*time.Time)(nil)).
 					WillReturnError(assert.AnError)
 			},
 			wantErr: true,
@@ -97,9 +98,9 @@ func TestVideoRepository_GetByID(t *testing.T) {
 			name: "video found",
 			id:   "dQw4w9WgXcQ",
 			setup: func(mock pgxmock.PgxPoolIface) {
-				rows := pgxmock.NewRows([]string{"id", "channel_id", "title", "url", "duration"}).
-					AddRow("dQw4w9WgXcQ", "UC123456789", "Never Gonna Give You Up", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", 212)
-				mock.ExpectQuery("SELECT id, channel_id, title, url, duration FROM videos WHERE id = \\$1").
+				rows := pgxmock.NewRows([]string{"id", "channel_id", "title", "url", "duration", "status", "upload_date", "rating", "note", "version"}).
+					AddRow("dQw4w9WgXcQ", "UC123456789", "Never Gonna Give You Up", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", 212, "available", nil, nil, nil, int64(901))
+				mock.ExpectQuery("SELECT id, channel_id, title, url, duration, status, upload_date, rating, note, xmin::text::bigint FROM videos WHERE id = \\$1").
 					WithArgs("dQw4w9WgXcQ").
 					WillReturnRows(rows)
 			},
@@ -109,6 +110,8 @@ func TestVideoRepository_GetByID(t *testing.T) {
 				Title:     "Never Gonna Give You Up",
 				URL:       "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
 				Duration:  212.0,
+				Status:    "available",
+				Version:   901,
 			},
 			wantErr: false,
 		},
@@ -116,9 +119,9 @@ func TestVideoRepository_GetByID(t *testing.T) {
 			name: "video not found",
 			id:   "notfound",
 			setup: func(mock pgxmock.PgxPoolIface) {
-				mock.ExpectQuery("SELECT id, channel_id, title, url, duration FROM videos WHERE id = \\$1").
+				mock.ExpectQuery("SELECT id, channel_id, title, url, duration, status, upload_date, rating, note, xmin::text::bigint FROM videos WHERE id = \\$1").
 					WithArgs("notfound").
-					WillReturnRows(pgxmock.NewRows([]string{"id", "channel_id", "title", "url", "duration"}))
+					WillReturnRows(pgxmock.NewRows([]string{"id", "channel_id", "title", "url", "duration", "status", "upload_date", "rating", "note", "version"}))
 			},
 			want:    nil,
 			wantErr: true,
@@ -161,27 +164,59 @@ func TestVideoRepository_GetByID(t *testing.T) {
 }
 
 func TestVideoRepository_Update(t *testing.T) {
+	updateQuery := "UPDATE videos SET channel_id = \\$2, title = \\$3, url = \\$4, duration = \\$5, upload_date = \\$6 WHERE id = \\$1 AND workspace = current_workspace\\(\\)"
+	args := []any{"dQw4w9WgXcQ", "UC123456789", "Updated Title", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", 220.0, (*time.Time)(nil)}
+
 	tests := []struct {
-		name    string
-		video   *model.Video
-		setup   func(mock pgxmock.PgxPoolIface)
-		wantErr bool
+		name        string
+		version     int64
+		setup       func(mock pgxmock.PgxPoolIface)
+		wantVersion int64
+		wantCode    string
 	}{
 		{
-			name: "successful update",
-			video: &model.Video{
-				ID:        "dQw4w9WgXcQ",
-				ChannelID: "UC123456789",
-				Title:     "Updated Title",
-				URL:       "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
-				Duration:  220.0,
+			name:    "successful update",
+			version: 0,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(updateQuery + " RETURNING").
+					WithArgs(args...).
+					WillReturnRows(pgxmock.NewRows([]string{"version"}).AddRow(int64(902)))
 			},
+			wantVersion: 902,
+		},
+		{
+			name:    "version unchanged",
+			version: 901,
 			setup: func(mock pgxmock.PgxPoolIface) {
-				mock.ExpectExec("UPDATE videos SET channel_id = \\$2, title = \\$3, url = \\$4, duration = \\$5 WHERE id = \\$1").
-					WithArgs("dQw4w9WgXcQ", "UC123456789", "Updated Title", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", 220.0).
-					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				mock.ExpectQuery(updateQuery + " AND xmin::text::bigint = \\$7 RETURNING").
+					WithArgs(append(args, int64(901))...).
+					WillReturnRows(pgxmock.NewRows([]string{"version"}).AddRow(int64(902)))
 			},
-			wantErr: false,
+			wantVersion: 902,
+		},
+		{
+			name:    "modified concurrently",
+			version: 901,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(updateQuery + " AND xmin::text::bigint = \\$7 RETURNING").
+					WithArgs(append(args, int64(901))...).
+					WillReturnRows(pgxmock.NewRows([]string{"version"}))
+				mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM videos WHERE id = \\$1").
+					WithArgs("dQw4w9WgXcQ").
+					WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+			wantVersion: 901,
+			wantCode:    apperrors.CodeConflict,
+		},
+		{
+			name:    "video not found",
+			version: 0,
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(updateQuery + " RETURNING").
+					WithArgs(args...).
+					WillReturnRows(pgxmock.NewRows([]string{"version"}))
+			},
+			wantCode: apperrors.CodeNotFound,
 		},
 	}
 
@@ -202,14 +237,25 @@ func TestVideoRepository_Update(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			defer cancel()
 
-			err = repo.Update(ctx, tt.video)
+			video := &model.Video{
+				ID:        "dQw4w9WgXcQ",
+				ChannelID: "UC123456789",
+				Title:     "Updated Title",
+				URL:       "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+				Duration:  220.0,
+				Version:   tt.version,
+			}
+			err = repo.Update(ctx, video)
 
 			// Verify result
-			if tt.wantErr {
-				assert.Error(t, err)
+			if tt.wantCode != "" {
+				var appErr *apperrors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
 			} else {
 				assert.NoError(t, err)
 			}
+			assert.Equal(t, tt.wantVersion, video.Version)
 
 			// Verify all expectations were met
 			err = mock.ExpectationsWereMet()
@@ -269,3 +315,150 @@ func TestVideoRepository_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestVideoRepository_SetDuplicateOf(t *testing.T) {
+	original := "dQw4w9WgXcQ"
+
+	tests := []struct {
+		name       string
+		originalID string
+		arg        any
+		affected   int64
+		wantCode   string
+	}{
+		{name: "links the original", originalID: original, arg: &original, affected: 1},
+		{name: "empty original clears the link", originalID: "", arg: (*string)(nil), affected: 1},
+		{name: "video not found", originalID: original, arg: &original, affected: 0, wantCode: apperrors.CodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			mock.ExpectExec("UPDATE videos SET duplicate_of = \\$2 WHERE id = \\$1").
+				WithArgs("reupload123", tt.arg).
+				WillReturnResult(pgxmock.NewResult("UPDATE", tt.affected))
+
+			repo := NewRepository(mock)
+			err = repo.SetDuplicateOf(context.Background(), "reupload123", tt.originalID)
+			if tt.wantCode != "" {
+				var appErr *apperrors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestVideoRepository_UpdateAnnotation(t *testing.T) {
+	rating, note := 4, "great for B1 learners"
+
+	tests := []struct {
+		name     string
+		rating   *int
+		note     *string
+		clear    bool
+		affected int64
+		wantCode string
+	}{
+		{name: "sets rating and note", rating: &rating, note: &note, affected: 1},
+		{name: "clears", clear: true, affected: 1},
+		{name: "video not found", rating: &rating, affected: 0, wantCode: apperrors.CodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			// Unset fields keep the stored value within the statement, not from an earlier read
+			mock.ExpectExec("UPDATE videos SET\\s+rating = COALESCE\\(\\$2::int, CASE WHEN \\$4 THEN NULL ELSE rating END\\)").
+				WithArgs("dQw4w9WgXcQ", tt.rating, tt.note, tt.clear).
+				WillReturnResult(pgxmock.NewResult("UPDATE", tt.affected))
+
+			repo := NewRepository(mock)
+			err = repo.UpdateAnnotation(context.Background(), "dQw4w9WgXcQ", tt.rating, tt.note, tt.clear)
+			if tt.wantCode != "" {
+				var appErr *apperrors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestVideoRepository_Chapters(t *testing.T) {
+	chapters := []model.VideoChapter{
+		{Title: "Intro", StartTime: 0, EndTime: 95.5},
+		{Title: "Ordering food", StartTime: 95.5, EndTime: 600},
+	}
+	data := []byte(`[{"title":"Intro","start_time":0,"end_time":95.5},{"title":"Ordering food","start_time":95.5,"end_time":600}]`)
+
+	t.Run("set", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("UPDATE videos SET chapters = \\$2 WHERE id = \\$1").
+			WithArgs("dQw4w9WgXcQ", data).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		require.NoError(t, NewRepository(mock).SetChapters(context.Background(), "dQw4w9WgXcQ", chapters))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("set on a missing video", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("UPDATE videos SET chapters").
+			WithArgs("missing", data).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		err = NewRepository(mock).SetChapters(context.Background(), "missing", chapters)
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.CodeNotFound, appErr.Code)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery("SELECT chapters FROM videos WHERE id = \\$1").
+			WithArgs("dQw4w9WgXcQ").
+			WillReturnRows(pgxmock.NewRows([]string{"chapters"}).AddRow(data))
+
+		got, err := NewRepository(mock).GetChapters(context.Background(), "dQw4w9WgXcQ")
+		require.NoError(t, err)
+		assert.Equal(t, chapters, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("get unknown chapters", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery("SELECT chapters FROM videos").
+			WithArgs("dQw4w9WgXcQ").
+			WillReturnRows(pgxmock.NewRows([]string{"chapters"}).AddRow([]byte(nil)))
+
+		got, err := NewRepository(mock).GetChapters(context.Background(), "dQw4w9WgXcQ")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
@@ -2,7 +2,11 @@ package video
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
 	"github.com/Taichi-iskw/yt-lang/internal/model"
@@ -35,8 +39,8 @@ func NewRepository(pool Pool) Repository {
 
 // Create creates a new video record
 func (r *videoRepository) Create(ctx context.Context, video *model.Video) error {
-	sql := "INSERT INTO videos (id, channel_id, title, url, duration) VALUES ($1, $2, $3, $4, $5)"
-	_, err := r.pool.Exec(ctx, sql, video.ID, video.ChannelID, video.Title, video.URL, video.Duration)
+	sql := "INSERT INTO videos (id, channel_id, title, url, duration, upload_date) VALUES ($1, $2, $3, $4, $5, $6)"
+	_, err := r.pool.Exec(ctx, sql, video.ID, video.ChannelID, video.Title, video.URL, video.Duration, video.UploadDate)
 	if err != nil {
 		return common.HandlePostgreSQLError(err, "failed to create video")
 	}
@@ -52,12 +56,12 @@ func (r *videoRepository) CreateBatch(ctx context.Context, videos []*model.Video
 	// Prepare data for COPY FROM
 	rows := make([][]any, len(videos))
 	for i, video := range videos {
-		rows[i] = []any{video.ID, video.ChannelID, video.Title, video.URL, video.Duration}
+		rows[i] = []any{video.ID, video.ChannelID, video.Title, video.URL, video.Duration, video.UploadDate}
 	}
 
 	// Use COPY FROM for optimal bulk insert performance
 	tableName := pgx.Identifier{"videos"}
-	columnNames := []string{"id", "channel_id", "title", "url", "duration"}
+	columnNames := []string{"id", "channel_id", "title", "url", "duration", "upload_date"}
 	copyFromSource := pgx.CopyFromRows(rows)
 
 	_, err := r.pool.CopyFrom(ctx, tableName, columnNames, copyFromSource)
@@ -70,11 +74,11 @@ func (r *videoRepository) CreateBatch(ctx context.Context, videos []*model.Video
 
 // GetByID retrieves a video by its ID
 func (r *videoRepository) GetByID(ctx context.Context, id string) (*model.Video, error) {
-	sql := "SELECT id, channel_id, title, url, duration FROM videos WHERE id = $1"
+	sql := "SELECT id, channel_id, title, url, duration, status, upload_date, rating, note, " + common.VersionColumn + " FROM videos WHERE id = $1 AND workspace = current_workspace()"
 	row := r.pool.QueryRow(ctx, sql, id)
 
 	var video model.Video
-	err := row.Scan(&video.ID, &video.ChannelID, &video.Title, &video.URL, &video.Duration)
+	err := row.Scan(&video.ID, &video.ChannelID, &video.Title, &video.URL, &video.Duration, &video.Status, &video.UploadDate, &video.Rating, &video.Note, &video.Version)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperrors.Wrap(err, apperrors.CodeNotFound, "video not found")
@@ -85,9 +89,31 @@ func (r *videoRepository) GetByID(ctx context.Context, id string) (*model.Video,
 	return &video, nil
 }
 
+// CountByChannelID returns the number of stored videos of a channel
+func (r *videoRepository) CountByChannelID(ctx context.Context, channelID string) (int, error) {
+	sql := "SELECT COUNT(*) FROM videos WHERE channel_id = $1 AND workspace = current_workspace()"
+
+	var count int
+	if err := r.pool.QueryRow(ctx, sql, channelID).Scan(&count); err != nil {
+		return 0, common.HandlePostgreSQLError(err, "failed to count videos by channel ID")
+	}
+	return count, nil
+}
+
+// CountByChannelIDAndStatus returns the number of stored videos of a channel with an availability status
+func (r *videoRepository) CountByChannelIDAndStatus(ctx context.Context, channelID string, status string) (int, error) {
+	sql := "SELECT COUNT(*) FROM videos WHERE channel_id = $1 AND status = $2 AND workspace = current_workspace()"
+
+	var count int
+	if err := r.pool.QueryRow(ctx, sql, channelID, status).Scan(&count); err != nil {
+		return 0, common.HandlePostgreSQLError(err, "failed to count videos by channel ID and status")
+	}
+	return count, nil
+}
+
 // GetByChannelID retrieves videos by channel ID with pagination
 func (r *videoRepository) GetByChannelID(ctx context.Context, channelID string, limit, offset int) ([]*model.Video, error) {
-	sql := "SELECT id, channel_id, title, url, duration FROM videos WHERE channel_id = $1 ORDER BY id LIMIT $2 OFFSET $3"
+	sql := "SELECT id, channel_id, title, url, duration, status, upload_date, rating, note FROM videos WHERE channel_id = $1 AND workspace = current_workspace() ORDER BY id LIMIT $2 OFFSET $3"
 	rows, err := r.pool.Query(ctx, sql, channelID, limit, offset)
 	if err != nil {
 		return nil, common.HandlePostgreSQLError(err, "failed to get videos by channel ID")
@@ -97,7 +123,7 @@ func (r *videoRepository) GetByChannelID(ctx context.Context, channelID string,
 	videos := []*model.Video{}
 	for rows.Next() {
 		var video model.Video
-		err := rows.Scan(&video.ID, &video.ChannelID, &video.Title, &video.URL, &video.Duration)
+		err := rows.Scan(&video.ID, &video.ChannelID, &video.Title, &video.URL, &video.Duration, &video.Status, &video.UploadDate, &video.Rating, &video.Note)
 		if err != nil {
 			return nil, common.HandlePostgreSQLError(err, "failed to scan video row")
 		}
@@ -111,19 +137,117 @@ func (r *videoRepository) GetByChannelID(ctx context.Context, channelID string,
 	return videos, nil
 }
 
-// Update updates an existing video record
+// Update updates an existing video record. A video read with a version is only updated if the
+// row has not changed since (conflict otherwise); video.Version is advanced on success.
 func (r *videoRepository) Update(ctx context.Context, video *model.Video) error {
-	sql := "UPDATE videos SET channel_id = $2, title = $3, url = $4, duration = $5 WHERE id = $1"
-	_, err := r.pool.Exec(ctx, sql, video.ID, video.ChannelID, video.Title, video.URL, video.Duration)
+	sql := "UPDATE videos SET channel_id = $2, title = $3, url = $4, duration = $5, upload_date = $6 WHERE id = $1 AND workspace = current_workspace()"
+	args := []any{video.ID, video.ChannelID, video.Title, video.URL, video.Duration, video.UploadDate}
+	if video.Version != 0 {
+		sql += " AND " + common.VersionColumn + " = $7"
+		args = append(args, video.Version)
+	}
+	sql += " RETURNING " + common.VersionColumn
+
+	err := r.pool.QueryRow(ctx, sql, args...).Scan(&video.Version)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return common.MissedUpdate(ctx, r.pool, "videos", "video", video.ID, video.Version != 0)
+		}
 		return common.HandlePostgreSQLError(err, "failed to update video")
 	}
 	return nil
 }
 
+// UpdateStatus sets the availability status of a video and records the check time
+func (r *videoRepository) UpdateStatus(ctx context.Context, id string, status string) error {
+	sql := "UPDATE videos SET status = $2, checked_at = NOW() WHERE id = $1 AND workspace = current_workspace()"
+	tag, err := r.pool.Exec(ctx, sql, id, status)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to update video status")
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.New(apperrors.CodeNotFound, "video not found")
+	}
+	return nil
+}
+
+// SetDuplicateOf records (or, with an empty originalID, clears) the original of a re-uploaded video
+func (r *videoRepository) SetDuplicateOf(ctx context.Context, id string, originalID string) error {
+	var original *string
+	if originalID != "" {
+		original = &originalID
+	}
+
+	sql := "UPDATE videos SET duplicate_of = $2 WHERE id = $1 AND workspace = current_workspace()"
+	tag, err := r.pool.Exec(ctx, sql, id, original)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to link duplicate video")
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.New(apperrors.CodeNotFound, "video not found")
+	}
+	return nil
+}
+
+// UpdateAnnotation changes the rating and note of a video in one statement
+func (r *videoRepository) UpdateAnnotation(ctx context.Context, id string, rating *int, note *string, clear bool) error {
+	sql := `UPDATE videos SET
+		rating = COALESCE($2::int, CASE WHEN $4 THEN NULL ELSE rating END),
+		note = CASE WHEN $3::text IS NULL THEN CASE WHEN $4 THEN NULL ELSE note END ELSE NULLIF($3, '') END
+		WHERE id = $1 AND workspace = current_workspace()`
+	tag, err := r.pool.Exec(ctx, sql, id, rating, note, clear)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to annotate video")
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.New(apperrors.CodeNotFound, "video not found")
+	}
+	return nil
+}
+
+// SetChapters replaces the YouTube chapters recorded for a video
+func (r *videoRepository) SetChapters(ctx context.Context, id string, chapters []model.VideoChapter) error {
+	data, err := json.Marshal(chapters)
+	if err != nil {
+		return apperrors.Wrap(err, apperrors.CodeInternal, "failed to encode video chapters")
+	}
+
+	sql := "UPDATE videos SET chapters = $2 WHERE id = $1 AND workspace = current_workspace()"
+	tag, err := r.pool.Exec(ctx, sql, id, data)
+	if err != nil {
+		return common.HandlePostgreSQLError(err, "failed to set video chapters")
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.New(apperrors.CodeNotFound, "video not found")
+	}
+	return nil
+}
+
+// GetChapters retrieves the YouTube chapters recorded for a video; NULL decodes to none
+func (r *videoRepository) GetChapters(ctx context.Context, id string) ([]model.VideoChapter, error) {
+	sql := "SELECT chapters FROM videos WHERE id = $1 AND workspace = current_workspace()"
+
+	var data []byte
+	if err := r.pool.QueryRow(ctx, sql, id).Scan(&data); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.Wrap(err, apperrors.CodeNotFound, "video not found")
+		}
+		return nil, common.HandlePostgreSQLError(err, "failed to get video chapters")
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var chapters []model.VideoChapter
+	if err := json.Unmarshal(data, &chapters); err != nil {
+		return nil, apperrors.Wrap(err, apperrors.CodeInternal, "failed to decode video chapters")
+	}
+	return chapters, nil
+}
+
 // Delete deletes a video by its ID
 func (r *videoRepository) Delete(ctx context.Context, id string) error {
-	sql := "DELETE FROM videos WHERE id = $1"
+	sql := "DELETE FROM videos WHERE id = $1 AND workspace = current_workspace()"
 	_, err := r.pool.Exec(ctx, sql, id)
 	if err != nil {
 		return common.HandlePostgreSQLError(err, "failed to delete video")
@@ -133,7 +257,7 @@ func (r *videoRepository) Delete(ctx context.Context, id string) error {
 
 // List retrieves videos with pagination
 func (r *videoRepository) List(ctx context.Context, limit, offset int) ([]*model.Video, error) {
-	sql := "SELECT id, channel_id, title, url, duration FROM videos ORDER BY id LIMIT $1 OFFSET $2"
+	sql := "SELECT id, channel_id, title, url, duration, status, upload_date, rating, note FROM videos WHERE workspace = current_workspace() ORDER BY id LIMIT $1 OFFSET $2"
 	rows, err := r.pool.Query(ctx, sql, limit, offset)
 	if err != nil {
 		return nil, common.HandlePostgreSQLError(err, "failed to list videos")
@@ -143,7 +267,205 @@ func (r *videoRepository) List(ctx context.Context, limit, offset int) ([]*model
 	videos := []*model.Video{}
 	for rows.Next() {
 		var video model.Video
-		err := rows.Scan(&video.ID, &video.ChannelID, &video.Title, &video.URL, &video.Duration)
+		err := rows.Scan(&video.ID, &video.ChannelID, &video.Title, &video.URL, &video.Duration, &video.Status, &video.UploadDate, &video.Rating, &video.Note)
+		if err != nil {
+			return nil, common.HandlePostgreSQLError(err, "failed to scan video row")
+		}
+		videos = append(videos, &video)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to iterate video rows")
+	}
+
+	return videos, nil
+}
+
+// ListTranscriptionCandidates retrieves videos to transcribe, filtering by duration and upload date in the query.
+// Unset bounds are passed as NULL so one statement serves every combination of filters.
+func (r *videoRepository) ListTranscriptionCandidates(ctx context.Context, filter CandidateFilter) ([]*model.Video, error) {
+	sql := `SELECT v.id, v.channel_id, v.title, v.url, v.duration, v.status, v.upload_date, v.rating, v.note FROM videos v
+		WHERE v.channel_id = $1
+			AND v.workspace = current_workspace()
+			AND v.status = 'available'
+			AND ($2::real IS NULL OR v.duration >= $2)
+			AND ($3::real IS NULL OR (v.duration > 0 AND v.duration <= $3))
+			AND NOT ` + alreadyTranscribed + `
+			AND ($6::date IS NULL OR v.upload_date >= $6)
+			AND v.duplicate_of IS NULL
+		ORDER BY v.id
+		LIMIT $5`
+
+	var minDuration, maxDuration *float64
+	if filter.MinDuration > 0 {
+		seconds := filter.MinDuration.Seconds()
+		minDuration = &seconds
+	}
+	if filter.MaxDuration > 0 {
+		seconds := filter.MaxDuration.Seconds()
+		maxDuration = &seconds
+	}
+	var limit *int
+	if filter.Limit > 0 {
+		limit = &filter.Limit
+	}
+	var publishedAfter *time.Time
+	if !filter.PublishedAfter.IsZero() {
+		publishedAfter = &filter.PublishedAfter
+	}
+
+	rows, err := r.pool.Query(ctx, sql, filter.ChannelID, minDuration, maxDuration, filter.Language, limit, publishedAfter)
+	if err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to list transcription candidates")
+	}
+	defer rows.Close()
+
+	videos := []*model.Video{}
+	for rows.Next() {
+		var video model.Video
+		err := rows.Scan(&video.ID, &video.ChannelID, &video.Title, &video.URL, &video.Duration, &video.Status, &video.UploadDate, &video.Rating, &video.Note)
+		if err != nil {
+			return nil, common.HandlePostgreSQLError(err, "failed to scan video row")
+		}
+		videos = append(videos, &video)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to iterate video rows")
+	}
+
+	return videos, nil
+}
+
+// alreadyTranscribed matches videos already transcribed in the language $4. Auto-detected
+// transcriptions match too: their language is known once detected, and a video whose detection
+// is still pending would most likely be transcribed in the same language again.
+const alreadyTranscribed = `EXISTS (SELECT 1 FROM transcriptions t WHERE t.workspace = v.workspace AND t.video_id = v.id
+				AND (t.language = $4 OR t.detected_language = $4 OR t.language = 'auto'))`
+
+// PlanTranscriptionCandidates retrieves the videos of a channel with the first reason
+// ListTranscriptionCandidates would leave each out. Both queries must apply the same filters.
+func (r *videoRepository) PlanTranscriptionCandidates(ctx context.Context, filter CandidateFilter) ([]*PlannedVideo, error) {
+	sql := `SELECT v.id, v.channel_id, v.title, v.url, v.duration, v.status, v.upload_date, v.rating, v.note,
+			CASE
+				WHEN v.status <> 'available' THEN '` + SkipUnavailable + `'
+				WHEN v.duplicate_of IS NOT NULL THEN '` + SkipDuplicate + `'
+				WHEN ` + alreadyTranscribed + ` THEN '` + SkipTranscribed + `'
+				WHEN $2::real IS NOT NULL AND NOT v.duration >= $2 THEN '` + SkipTooShort + `'
+				WHEN $3::real IS NOT NULL AND NOT (v.duration > 0 AND v.duration <= $3) THEN '` + SkipTooLong + `'
+				WHEN $5::date IS NOT NULL AND (v.upload_date IS NULL OR v.upload_date < $5) THEN '` + SkipPublishedBefore + `'
+				ELSE ''
+			END AS skip
+		FROM videos v
+		WHERE v.channel_id = $1
+			AND v.workspace = current_workspace()
+		ORDER BY v.id`
+
+	var minDuration, maxDuration *float64
+	if filter.MinDuration > 0 {
+		seconds := filter.MinDuration.Seconds()
+		minDuration = &seconds
+	}
+	if filter.MaxDuration > 0 {
+		seconds := filter.MaxDuration.Seconds()
+		maxDuration = &seconds
+	}
+	var publishedAfter *time.Time
+	if !filter.PublishedAfter.IsZero() {
+		publishedAfter = &filter.PublishedAfter
+	}
+
+	rows, err := r.pool.Query(ctx, sql, filter.ChannelID, minDuration, maxDuration, filter.Language, publishedAfter)
+	if err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to plan transcription candidates")
+	}
+	defer rows.Close()
+
+	planned := []*PlannedVideo{}
+	selected := 0
+	for rows.Next() {
+		var video model.Video
+		var skip string
+		err := rows.Scan(&video.ID, &video.ChannelID, &video.Title, &video.URL, &video.Duration, &video.Status, &video.UploadDate, &video.Rating, &video.Note, &skip)
+		if err != nil {
+			return nil, common.HandlePostgreSQLError(err, "failed to scan video row")
+		}
+		if skip == "" {
+			// The limit applies in ID order, as in ListTranscriptionCandidates
+			if filter.Limit > 0 && selected >= filter.Limit {
+				skip = SkipLimit
+			} else {
+				selected++
+			}
+		}
+		planned = append(planned, &PlannedVideo{Video: &video, Skip: skip})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to iterate video rows")
+	}
+
+	return planned, nil
+}
+
+// ListByMinRating retrieves rated videos, best rated first. An empty channelID is passed as NULL
+// to list rated videos of every channel.
+func (r *videoRepository) ListByMinRating(ctx context.Context, channelID string, minRating int) ([]*model.Video, error) {
+	sql := `SELECT id, channel_id, title, url, duration, status, upload_date, rating, note FROM videos
+		WHERE workspace = current_workspace()
+			AND ($1::varchar IS NULL OR channel_id = $1)
+			AND rating >= $2
+		ORDER BY rating DESC, id`
+
+	var channel *string
+	if channelID != "" {
+		channel = &channelID
+	}
+
+	rows, err := r.pool.Query(ctx, sql, channel, minRating)
+	if err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to list rated videos")
+	}
+	defer rows.Close()
+
+	videos := []*model.Video{}
+	for rows.Next() {
+		var video model.Video
+		err := rows.Scan(&video.ID, &video.ChannelID, &video.Title, &video.URL, &video.Duration, &video.Status, &video.UploadDate, &video.Rating, &video.Note)
+		if err != nil {
+			return nil, common.HandlePostgreSQLError(err, "failed to scan video row")
+		}
+		videos = append(videos, &video)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to iterate video rows")
+	}
+
+	return videos, nil
+}
+
+// ListPruneCandidates retrieves old untouched videos, oldest first
+func (r *videoRepository) ListPruneCandidates(ctx context.Context, filter PruneFilter) ([]*model.Video, error) {
+	sql := `SELECT id, channel_id, title, url, duration, status, upload_date, rating, note FROM videos v
+		WHERE workspace = current_workspace()
+			AND upload_date < $1
+			AND rating IS NULL
+			AND COALESCE(note, '') = ''
+			AND (NOT $2 OR NOT EXISTS (
+				SELECT 1 FROM transcriptions t WHERE t.workspace = v.workspace AND t.video_id = v.id))
+		ORDER BY upload_date, id`
+
+	rows, err := r.pool.Query(ctx, sql, filter.UploadedBefore, filter.KeepTranscribed)
+	if err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to list prune candidates")
+	}
+	defer rows.Close()
+
+	videos := []*model.Video{}
+	for rows.Next() {
+		var video model.Video
+		err := rows.Scan(&video.ID, &video.ChannelID, &video.Title, &video.URL, &video.Duration, &video.Status, &video.UploadDate, &video.Rating, &video.Note)
 		if err != nil {
 			return nil, common.HandlePostgreSQLError(err, "failed to scan video row")
 		}
@@ -165,10 +487,15 @@ func (r *videoRepository) UpsertBatch(ctx context.Context, videos []*model.Video
 
 	// Get the channel ID from first video (assume all videos belong to same channel)
 	channelID := videos[0].ChannelID
+	ids := make([]string, len(videos))
+	for i, video := range videos {
+		ids[i] = video.ID
+	}
 
-	// Step 1: Get existing video IDs for the channel
-	sql := "SELECT id FROM videos WHERE channel_id = $1"
-	rows, err := r.pool.Query(ctx, sql, channelID)
+	// Step 1: Get which of the videos already exist, so saving a page of a large channel
+	// doesn't load every saved video ID of the channel
+	sql := "SELECT id FROM videos WHERE channel_id = $1 AND id = ANY($2) AND workspace = current_workspace()"
+	rows, err := r.pool.Query(ctx, sql, channelID, ids)
 	if err != nil {
 		return common.HandlePostgreSQLError(err, "failed to get existing video IDs")
 	}
@@ -188,11 +515,26 @@ func (r *videoRepository) UpsertBatch(ctx context.Context, videos []*model.Video
 		return common.HandlePostgreSQLError(err, "failed to iterate existing video IDs")
 	}
 
-	// Step 2: Filter out existing videos
+	// Step 2: Filter out existing videos, remembering upload dates they may not have yet
 	newVideos := make([]*model.Video, 0, len(videos))
+	var datedIDs []string
+	var uploadDates []time.Time
 	for _, video := range videos {
 		if !existingIDs[video.ID] {
 			newVideos = append(newVideos, video)
+		} else if video.UploadDate != nil {
+			datedIDs = append(datedIDs, video.ID)
+			uploadDates = append(uploadDates, *video.UploadDate)
+		}
+	}
+
+	// Backfill upload dates of videos saved before dates were recorded
+	if len(datedIDs) > 0 {
+		sql := `UPDATE videos v SET upload_date = u.upload_date
+			FROM unnest($1::varchar[], $2::date[]) AS u(id, upload_date)
+			WHERE v.workspace = current_workspace() AND v.id = u.id AND v.upload_date IS NULL`
+		if _, err := r.pool.Exec(ctx, sql, datedIDs, uploadDates); err != nil {
+			return common.HandlePostgreSQLError(err, "failed to backfill video upload dates")
 		}
 	}
 
@@ -204,3 +546,133 @@ func (r *videoRepository) UpsertBatch(ctx context.Context, videos []*model.Video
 	// Step 4: Use existing COPY FROM for new videos only
 	return r.CreateBatch(ctx, newVideos)
 }
+
+// DetectChanges compares listed videos with the saved ones by metadata hash, updating the
+// changed ones and marking their transcriptions stale
+func (r *videoRepository) DetectChanges(ctx context.Context, videos []*model.Video) ([]*model.VideoChange, error) {
+	if len(videos) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, len(videos))
+	for i, video := range videos {
+		ids[i] = video.ID
+	}
+
+	sql := `SELECT id, title, duration, upload_date, metadata_hash FROM videos
+		WHERE workspace = current_workspace() AND id = ANY($1)`
+	rows, err := r.pool.Query(ctx, sql, ids)
+	if err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to get saved video metadata")
+	}
+	defer rows.Close()
+
+	saved := make(map[string]*model.Video)
+	hashes := make(map[string]*string)
+	for rows.Next() {
+		var video model.Video
+		var hash *string
+		if err := rows.Scan(&video.ID, &video.Title, &video.Duration, &video.UploadDate, &hash); err != nil {
+			return nil, common.HandlePostgreSQLError(err, "failed to scan video metadata")
+		}
+		saved[video.ID] = &video
+		hashes[video.ID] = hash
+	}
+	if err := rows.Err(); err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to iterate video metadata")
+	}
+
+	var changes []*model.VideoChange
+	var hashedIDs, newHashes []string
+	var changedIDs, titles, changedHashes, reasons []string
+	var durations []float64
+	var uploadDates []*time.Time
+	for _, video := range videos {
+		old, ok := saved[video.ID]
+		if !ok {
+			continue
+		}
+		listed := &model.Video{Title: video.Title, Duration: video.Duration, UploadDate: video.UploadDate}
+		if listed.Title == "" {
+			listed.Title = old.Title
+		}
+		if listed.Duration == 0 {
+			listed.Duration = old.Duration
+		}
+		if listed.UploadDate == nil {
+			listed.UploadDate = old.UploadDate
+		}
+
+		// Videos saved before hashes were recorded are compared with their saved metadata
+		oldHash := old.MetadataHash()
+		if hashes[video.ID] != nil {
+			oldHash = *hashes[video.ID]
+		}
+		hash := listed.MetadataHash()
+		if hash == oldHash && hashes[video.ID] != nil {
+			continue
+		}
+		// A field saved empty and listed now is filled in rather than changed
+		fields := changedFields(old, listed)
+		if len(fields) == 0 {
+			hashedIDs = append(hashedIDs, video.ID)
+			newHashes = append(newHashes, hash)
+			continue
+		}
+
+		change := &model.VideoChange{VideoID: video.ID, Title: listed.Title, Fields: fields}
+		changes = append(changes, change)
+		changedIDs = append(changedIDs, video.ID)
+		titles = append(titles, listed.Title)
+		durations = append(durations, listed.Duration)
+		uploadDates = append(uploadDates, listed.UploadDate)
+		changedHashes = append(changedHashes, hash)
+		reasons = append(reasons, strings.Join(change.Fields, ", ")+" changed on YouTube")
+	}
+
+	if len(hashedIDs) > 0 {
+		sql := `UPDATE videos v SET metadata_hash = u.hash
+			FROM unnest($1::varchar[], $2::varchar[]) AS u(id, hash)
+			WHERE v.workspace = current_workspace() AND v.id = u.id`
+		if _, err := r.pool.Exec(ctx, sql, hashedIDs, newHashes); err != nil {
+			return nil, common.HandlePostgreSQLError(err, "failed to record video metadata hashes")
+		}
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	// Transcriptions are marked before the videos take the new hash, so a failure in between
+	// is detected again by the next sync. Only completed transcriptions go stale, and one already
+	// stale keeps the time and reason of the first change.
+	sql = `UPDATE transcriptions t SET stale_at = NOW(), stale_reason = u.reason
+		FROM unnest($1::varchar[], $2::text[]) AS u(video_id, reason)
+		WHERE t.workspace = current_workspace() AND t.video_id = u.video_id
+			AND t.status = 'completed' AND t.stale_at IS NULL`
+	if _, err := r.pool.Exec(ctx, sql, changedIDs, reasons); err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to mark transcriptions stale")
+	}
+	sql = `UPDATE videos v SET title = u.title, duration = u.duration, upload_date = u.upload_date, metadata_hash = u.hash
+		FROM unnest($1::varchar[], $2::varchar[], $3::real[], $4::date[], $5::varchar[]) AS u(id, title, duration, upload_date, hash)
+		WHERE v.workspace = current_workspace() AND v.id = u.id`
+	if _, err := r.pool.Exec(ctx, sql, changedIDs, titles, durations, uploadDates, changedHashes); err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to update changed videos")
+	}
+	return changes, nil
+}
+
+// changedFields lists the metadata fields, as compared by Video.MetadataHash, that are set in
+// both versions of a video and differ
+func changedFields(old, listed *model.Video) []string {
+	var fields []string
+	if old.Title != "" && old.Title != listed.Title {
+		fields = append(fields, "title")
+	}
+	if old.Duration != 0 && fmt.Sprintf("%.0f", old.Duration) != fmt.Sprintf("%.0f", listed.Duration) {
+		fields = append(fields, "duration")
+	}
+	if old.UploadDate != nil && listed.UploadDate != nil &&
+		old.UploadDate.Format("2006-01-02") != listed.UploadDate.Format("2006-01-02") {
+		fields = append(fields, "upload_date")
+	}
+	return fields
+}
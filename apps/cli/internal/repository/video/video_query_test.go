@@ -27,10 +27,10 @@ func TestVideoRepository_GetByChannelID(t *testing.T) {
 			limit:     2,
 			offset:    0,
 			setup: func(mock pgxmock.PgxPoolIface) {
-				rows := pgxmock.NewRows([]string{"id", "channel_id", "title", "url", "duration"}).
-					AddRow("dQw4w9WgXcQ", "UC123456789", "Never Gonna Give You Up", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", 212).
-					AddRow("oHg5SJYRHA0", "UC123456789", "Never Gonna Let You Down", "https://www.youtube.com/watch?v=oHg5SJYRHA0", 233)
-				mock.ExpectQuery("SELECT id, channel_id, title, url, duration FROM videos WHERE channel_id = \\$1 ORDER BY id LIMIT \\$2 OFFSET \\$3").
+				rows := pgxmock.NewRows([]string{"id", "channel_id", "title", "url", "duration", "status", "upload_date", "rating", "note"}).
+					AddRow("dQw4w9WgXcQ", "UC123456789", "Never Gonna Give You Up", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", 212, "available", nil, nil, nil).
+					AddRow("oHg5SJYRHA0", "UC123456789", "Never Gonna Let You Down", "https://www.youtube.com/watch?v=oHg5SJYRHA0", 233, "available", nil, nil, nil)
+				mock.ExpectQuery("SELECT id, channel_id, title, url, duration, status, upload_date, rating, note FROM videos WHERE channel_id = \\$1 AND workspace = current_workspace\\(\\) ORDER BY id LIMIT \\$2 OFFSET \\$3").
 					WithArgs("UC123456789", 2, 0).
 					WillReturnRows(rows)
 			},
@@ -41,6 +41,7 @@ func TestVideoRepository_GetByChannelID(t *testing.T) {
 					Title:     "Never Gonna Give You Up",
 					URL:       "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
 					Duration:  212,
+					Status:    "available",
 				},
 				{
 					ID:        "oHg5SJYRHA0",
@@ -48,6 +49,7 @@ func TestVideoRepository_GetByChannelID(t *testing.T) {
 					Title:     "Never Gonna Let You Down",
 					URL:       "https://www.youtube.com/watch?v=oHg5SJYRHA0",
 					Duration:  233,
+					Status:    "available",
 				},
 			},
 			wantErr: false,
@@ -58,9 +60,9 @@ func TestVideoRepository_GetByChannelID(t *testing.T) {
 			limit:     10,
 			offset:    0,
 			setup: func(mock pgxmock.PgxPoolIface) {
-				mock.ExpectQuery("SELECT id, channel_id, title, url, duration FROM videos WHERE channel_id = \\$1 ORDER BY id LIMIT \\$2 OFFSET \\$3").
+				mock.ExpectQuery("SELECT id, channel_id, title, url, duration, status, upload_date, rating, note FROM videos WHERE channel_id = \\$1 AND workspace = current_workspace\\(\\) ORDER BY id LIMIT \\$2 OFFSET \\$3").
 					WithArgs("UCnotfound", 10, 0).
-					WillReturnRows(pgxmock.NewRows([]string{"id", "channel_id", "title", "url", "duration"}))
+					WillReturnRows(pgxmock.NewRows([]string{"id", "channel_id", "title", "url", "duration", "status", "upload_date", "rating", "note"}))
 			},
 			want:    []*model.Video{},
 			wantErr: false,
@@ -116,10 +118,10 @@ func TestVideoRepository_List(t *testing.T) {
 			limit:  2,
 			offset: 0,
 			setup: func(mock pgxmock.PgxPoolIface) {
-				rows := pgxmock.NewRows([]string{"id", "channel_id", "title", "url", "duration"}).
-					AddRow("dQw4w9WgXcQ", "UC123456789", "Never Gonna Give You Up", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", 212).
-					AddRow("oHg5SJYRHA0", "UC123456789", "Never Gonna Let You Down", "https://www.youtube.com/watch?v=oHg5SJYRHA0", 233)
-				mock.ExpectQuery("SELECT id, channel_id, title, url, duration FROM videos ORDER BY id LIMIT \\$1 OFFSET \\$2").
+				rows := pgxmock.NewRows([]string{"id", "channel_id", "title", "url", "duration", "status", "upload_date", "rating", "note"}).
+					AddRow("dQw4w9WgXcQ", "UC123456789", "Never Gonna Give You Up", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", 212, "available", nil, nil, nil).
+					AddRow("oHg5SJYRHA0", "UC123456789", "Never Gonna Let You Down", "https://www.youtube.com/watch?v=oHg5SJYRHA0", 233, "available", nil, nil, nil)
+				mock.ExpectQuery("SELECT id, channel_id, title, url, duration, status, upload_date, rating, note FROM videos WHERE workspace = current_workspace\\(\\) ORDER BY id LIMIT \\$1 OFFSET \\$2").
 					WithArgs(2, 0).
 					WillReturnRows(rows)
 			},
@@ -130,6 +132,7 @@ func TestVideoRepository_List(t *testing.T) {
 					Title:     "Never Gonna Give You Up",
 					URL:       "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
 					Duration:  212,
+					Status:    "available",
 				},
 				{
 					ID:        "oHg5SJYRHA0",
@@ -137,6 +140,7 @@ func TestVideoRepository_List(t *testing.T) {
 					Title:     "Never Gonna Let You Down",
 					URL:       "https://www.youtube.com/watch?v=oHg5SJYRHA0",
 					Duration:  233,
+					Status:    "available",
 				},
 			},
 			wantErr: false,
@@ -177,3 +181,200 @@ func TestVideoRepository_List(t *testing.T) {
 		})
 	}
 }
+
+func TestVideoRepository_ListTranscriptionCandidates(t *testing.T) {
+	query := `SELECT v.id, v.channel_id, v.title, v.url, v.duration, v.status, v.upload_date, v.rating, v.note FROM videos v
+		WHERE v.channel_id = \$1
+			AND v.workspace = current_workspace\(\)
+			AND v.status = 'available'
+			AND \(\$2::real IS NULL OR v.duration >= \$2\)
+			AND \(\$3::real IS NULL OR \(v.duration > 0 AND v.duration <= \$3\)\)
+			AND NOT EXISTS \(SELECT 1 FROM transcriptions t WHERE t.workspace = v.workspace AND t.video_id = v.id
+				AND \(t.language = \$4 OR t.detected_language = \$4 OR t.language = 'auto'\)\)
+			AND \(\$6::date IS NULL OR v.upload_date >= \$6\)
+			AND v.duplicate_of IS NULL
+		ORDER BY v.id
+		LIMIT \$5`
+	columns := []string{"id", "channel_id", "title", "url", "duration", "status", "upload_date", "rating", "note"}
+
+	minDuration, maxDuration, limit := 120.0, 1800.0, 10
+	publishedAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter CandidateFilter
+		args   []any
+	}{
+		{
+			name:   "duration bounds and limit",
+			filter: CandidateFilter{ChannelID: "UC123456789", Language: "auto", MinDuration: 2 * time.Minute, MaxDuration: 30 * time.Minute, Limit: 10},
+			args:   []any{"UC123456789", &minDuration, &maxDuration, "auto", &limit, (*time.Time)(nil)},
+		},
+		{
+			name:   "published after",
+			filter: CandidateFilter{ChannelID: "UC123456789", Language: "auto", PublishedAfter: publishedAfter},
+			args:   []any{"UC123456789", (*float64)(nil), (*float64)(nil), "auto", (*int)(nil), &publishedAfter},
+		},
+		{
+			name:   "unset bounds are passed as NULL",
+			filter: CandidateFilter{ChannelID: "UC123456789", Language: "en"},
+			args:   []any{"UC123456789", (*float64)(nil), (*float64)(nil), "en", (*int)(nil), (*time.Time)(nil)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			mock.ExpectQuery(query).
+				WithArgs(tt.args...).
+				WillReturnRows(pgxmock.NewRows(columns).
+					AddRow("dQw4w9WgXcQ", "UC123456789", "Never Gonna Give You Up", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", 212.0, "available", &publishedAfter, nil, nil))
+
+			repo := NewRepository(mock)
+			got, err := repo.ListTranscriptionCandidates(context.Background(), tt.filter)
+			require.NoError(t, err)
+			require.Len(t, got, 1)
+			assert.Equal(t, "dQw4w9WgXcQ", got[0].ID)
+			assert.Equal(t, 212.0, got[0].Duration)
+			require.NotNil(t, got[0].UploadDate)
+			assert.True(t, publishedAfter.Equal(*got[0].UploadDate))
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestVideoRepository_PlanTranscriptionCandidates(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	maxDuration := 1800.0
+	columns := []string{"id", "channel_id", "title", "url", "duration", "status", "upload_date", "rating", "note", "skip"}
+	mock.ExpectQuery("SELECT (.+) CASE (.+) END AS skip\\s+FROM videos v\\s+WHERE v.channel_id = \\$1").
+		WithArgs("UC123456789", (*float64)(nil), &maxDuration, "auto", (*time.Time)(nil)).
+		WillReturnRows(pgxmock.NewRows(columns).
+			AddRow("video1", "UC123456789", "First", "https://www.youtube.com/watch?v=video1", 600.0, "available", nil, nil, nil, "").
+			AddRow("video2", "UC123456789", "Gone", "https://www.youtube.com/watch?v=video2", 300.0, "unavailable", nil, nil, nil, SkipUnavailable).
+			AddRow("video3", "UC123456789", "Second", "https://www.youtube.com/watch?v=video3", 900.0, "available", nil, nil, nil, "").
+			AddRow("video4", "UC123456789", "Stream", "https://www.youtube.com/watch?v=video4", 7200.0, "available", nil, nil, nil, SkipTooLong))
+
+	planned, err := NewRepository(mock).PlanTranscriptionCandidates(context.Background(), CandidateFilter{ChannelID: "UC123456789", Language: "auto", MaxDuration: 30 * time.Minute, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, planned, 4)
+	assert.Equal(t, "", planned[0].Skip)
+	assert.Equal(t, SkipUnavailable, planned[1].Skip)
+	assert.Equal(t, SkipLimit, planned[2].Skip, "selected videos past the limit are left out")
+	assert.Equal(t, SkipTooLong, planned[3].Skip)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestVideoRepository_CountByChannelID(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM videos WHERE channel_id = \\$1").
+		WithArgs("UC123").
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(170))
+
+	count, err := NewRepository(mock).CountByChannelID(context.Background(), "UC123")
+	require.NoError(t, err)
+	assert.Equal(t, 170, count)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestVideoRepository_CountByChannelIDAndStatus(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM videos WHERE channel_id = \\$1 AND status = \\$2").
+		WithArgs("UC123", model.VideoStatusAvailable).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(164))
+
+	count, err := NewRepository(mock).CountByChannelIDAndStatus(context.Background(), "UC123", model.VideoStatusAvailable)
+	require.NoError(t, err)
+	assert.Equal(t, 164, count)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestVideoRepository_ListByMinRating(t *testing.T) {
+	query := `SELECT id, channel_id, title, url, duration, status, upload_date, rating, note FROM videos
+		WHERE workspace = current_workspace\(\)
+			AND \(\$1::varchar IS NULL OR channel_id = \$1\)
+			AND rating >= \$2
+		ORDER BY rating DESC, id`
+	columns := []string{"id", "channel_id", "title", "url", "duration", "status", "upload_date", "rating", "note"}
+	channelID := "UC123456789"
+	rating, note := 5, "great for B1 learners"
+
+	tests := []struct {
+		name      string
+		channelID string
+		arg       any
+	}{
+		{name: "one channel", channelID: channelID, arg: &channelID},
+		{name: "every channel", channelID: "", arg: (*string)(nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			mock.ExpectQuery(query).
+				WithArgs(tt.arg, 4).
+				WillReturnRows(pgxmock.NewRows(columns).
+					AddRow("dQw4w9WgXcQ", channelID, "Never Gonna Give You Up", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", 212.0, "available", nil, &rating, &note))
+
+			repo := NewRepository(mock)
+			got, err := repo.ListByMinRating(context.Background(), tt.channelID, 4)
+			require.NoError(t, err)
+			require.Len(t, got, 1)
+			require.NotNil(t, got[0].Rating)
+			assert.Equal(t, 5, *got[0].Rating)
+			require.NotNil(t, got[0].Note)
+			assert.Equal(t, note, *got[0].Note)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestVideoRepository_ListPruneCandidates(t *testing.T) {
+	query := `SELECT id, channel_id, title, url, duration, status, upload_date, rating, note FROM videos v
+		WHERE workspace = current_workspace\(\)
+			AND upload_date < \$1
+			AND rating IS NULL
+			AND COALESCE\(note, ''\) = ''
+			AND \(NOT \$2 OR NOT EXISTS \(
+				SELECT 1 FROM transcriptions t WHERE t.workspace = v.workspace AND t.video_id = v.id\)\)
+		ORDER BY upload_date, id`
+	columns := []string{"id", "channel_id", "title", "url", "duration", "status", "upload_date", "rating", "note"}
+	cutoff := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	uploaded := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery(query).
+		WithArgs(cutoff, true).
+		WillReturnRows(pgxmock.NewRows(columns).
+			AddRow("dQw4w9WgXcQ", "UC123456789", "Never Gonna Give You Up", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", 212.0, "available", &uploaded, nil, nil))
+
+	repo := NewRepository(mock)
+	got, err := repo.ListPruneCandidates(context.Background(), PruneFilter{UploadedBefore: cutoff, KeepTranscribed: true})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "dQw4w9WgXcQ", got[0].ID)
+	require.NotNil(t, got[0].UploadDate)
+	assert.Equal(t, uploaded, *got[0].UploadDate)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
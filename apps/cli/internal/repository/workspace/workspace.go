@@ -0,0 +1,19 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// Repository defines operations for Workspace persistence
+type Repository interface {
+	// Create creates a new, empty workspace
+	Create(ctx context.Context, name string) error
+
+	// Exists reports whether a workspace exists
+	Exists(ctx context.Context, name string) (bool, error)
+
+	// List retrieves all workspaces with their channel and video counts, sorted by name
+	List(ctx context.Context) ([]*model.Workspace, error)
+}
@@ -0,0 +1,95 @@
+package workspace
+
+import (
+	"context"
+	"regexp"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/common"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// validName restricts workspace names to lowercase identifiers usable in config files and flags
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,99}$`)
+
+// Pool interface for abstracting pgx connection pool
+type Pool interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// workspaceRepository implements Repository using PostgreSQL
+type workspaceRepository struct {
+	pool Pool
+}
+
+// NewRepository creates a new instance of Repository
+func NewRepository(pool Pool) Repository {
+	return &workspaceRepository{
+		pool: pool,
+	}
+}
+
+// ValidateName checks that name can be used as a workspace name
+func ValidateName(name string) error {
+	if !validName.MatchString(name) {
+		return apperrors.New(apperrors.CodeInvalidArg, "invalid workspace name (use lowercase letters, digits, '-' and '_', up to 100 characters)")
+	}
+	return nil
+}
+
+// Create creates a new, empty workspace
+func (r *workspaceRepository) Create(ctx context.Context, name string) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+
+	sql := "INSERT INTO workspaces (name) VALUES ($1)"
+	if _, err := r.pool.Exec(ctx, sql, name); err != nil {
+		return common.HandlePostgreSQLError(err, "failed to create workspace")
+	}
+	return nil
+}
+
+// Exists reports whether a workspace exists
+func (r *workspaceRepository) Exists(ctx context.Context, name string) (bool, error) {
+	sql := "SELECT EXISTS (SELECT 1 FROM workspaces WHERE name = $1)"
+
+	var exists bool
+	if err := r.pool.QueryRow(ctx, sql, name).Scan(&exists); err != nil {
+		return false, common.HandlePostgreSQLError(err, "failed to check workspace")
+	}
+	return exists, nil
+}
+
+// List retrieves all workspaces with their channel and video counts, sorted by name
+func (r *workspaceRepository) List(ctx context.Context) ([]*model.Workspace, error) {
+	sql := `SELECT w.name, w.created_at,
+			(SELECT COUNT(*) FROM channels c WHERE c.workspace = w.name),
+			(SELECT COUNT(*) FROM videos v WHERE v.workspace = w.name)
+		FROM workspaces w
+		ORDER BY w.name`
+	rows, err := r.pool.Query(ctx, sql)
+	if err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to list workspaces")
+	}
+	defer rows.Close()
+
+	workspaces := []*model.Workspace{}
+	for rows.Next() {
+		var workspace model.Workspace
+		if err := rows.Scan(&workspace.Name, &workspace.CreatedAt, &workspace.Channels, &workspace.Videos); err != nil {
+			return nil, common.HandlePostgreSQLError(err, "failed to scan workspace row")
+		}
+		workspaces = append(workspaces, &workspace)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, common.HandlePostgreSQLError(err, "failed to iterate workspace rows")
+	}
+
+	return workspaces, nil
+}
@@ -0,0 +1,95 @@
+package workspace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+)
+
+func TestWorkspaceRepository_Create(t *testing.T) {
+	t.Run("creates a workspace", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("INSERT INTO workspaces \\(name\\) VALUES \\(\\$1\\)").
+			WithArgs("research").
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		require.NoError(t, NewRepository(mock).Create(context.Background(), "research"))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("existing workspace is a conflict", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("INSERT INTO workspaces").
+			WithArgs("research").
+			WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "workspaces_pkey"})
+
+		err = NewRepository(mock).Create(context.Background(), "research")
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.CodeConflict, appErr.Code)
+		assert.Contains(t, err.Error(), "workspace already exists")
+	})
+
+	t.Run("rejects invalid names without querying", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		for _, name := range []string{"", "Research", "my workspace", "-team"} {
+			err := NewRepository(mock).Create(context.Background(), name)
+			var appErr *apperrors.AppError
+			require.ErrorAs(t, err, &appErr, name)
+			assert.Equal(t, apperrors.CodeInvalidArg, appErr.Code)
+		}
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestWorkspaceRepository_Exists(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM workspaces WHERE name = \\$1\\)").
+		WithArgs("research").
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+
+	exists, err := NewRepository(mock).Exists(context.Background(), "research")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWorkspaceRepository_List(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	created := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT w.name, w.created_at,").
+		WillReturnRows(pgxmock.NewRows([]string{"name", "created_at", "channels", "videos"}).
+			AddRow("default", created, 2, 40).
+			AddRow("research", created, 0, 0))
+
+	workspaces, err := NewRepository(mock).List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, workspaces, 2)
+	assert.Equal(t, "default", workspaces[0].Name)
+	assert.Equal(t, 2, workspaces[0].Channels)
+	assert.Equal(t, 40, workspaces[0].Videos)
+	assert.Equal(t, "research", workspaces[1].Name)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,189 @@
+// Package schedule parses sync schedules and computes when they run next. A schedule is either
+// an interval ("@every 12h") or a five-field cron expression ("0 6 * * *": minute, hour, day of
+// month, month, day of week), with the @hourly, @daily and @weekly shorthands.
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MinInterval is the shortest interval between runs; shorter intervals would only hammer YouTube
+const MinInterval = 5 * time.Minute
+
+// shorthands are the cron expressions of the @ shorthands
+var shorthands = map[string]string{
+	"@hourly": "0 * * * *",
+	"@daily":  "0 0 * * *",
+	"@weekly": "0 0 * * 0",
+}
+
+// Schedule computes run times
+type Schedule interface {
+	// Next returns the first run time after t
+	Next(t time.Time) time.Time
+}
+
+// Every returns the spec of a schedule running every interval
+func Every(interval time.Duration) string {
+	return "@every " + interval.String()
+}
+
+// Parse parses a schedule spec
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if rest, ok := strings.CutPrefix(spec, "@every "); ok {
+		interval, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("invalid interval %q: %w", rest, err)
+		}
+		if interval < MinInterval {
+			return nil, fmt.Errorf("interval %s is shorter than the minimum of %s", interval, MinInterval)
+		}
+		return fixedInterval(interval), nil
+	}
+	if expr, ok := shorthands[spec]; ok {
+		spec = expr
+	}
+	return parseCron(spec)
+}
+
+// NextRun returns when a schedule runs next: right away when it never ran, otherwise its first
+// run time after the last run
+func NextRun(s Schedule, lastRun *time.Time, now time.Time) time.Time {
+	if lastRun == nil {
+		return now
+	}
+	return s.Next(*lastRun)
+}
+
+// fixedInterval runs at a fixed interval after the last run
+type fixedInterval time.Duration
+
+func (i fixedInterval) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(i))
+}
+
+// cron runs at the minutes matching all of its fields
+type cron struct {
+	minute, hour, dom, month, dow uint64 // Bit n is set when value n matches
+	domAny, dowAny                bool   // The day field was "*"
+}
+
+// cronFields are the name and value range of the cron fields, in order
+var cronFields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7}, // 0 and 7 are Sunday
+}
+
+// parseCron parses a five-field cron expression
+func parseCron(spec string) (*cron, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("invalid schedule %q (use \"@every 12h\" or a cron expression such as \"0 6 * * *\")", spec)
+	}
+
+	bits := make([]uint64, len(fields))
+	for i, field := range fields {
+		set, err := parseCronField(field, cronFields[i].min, cronFields[i].max)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s field %q: %w", cronFields[i].name, field, err)
+		}
+		bits[i] = set
+	}
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+	return &cron{
+		minute: bits[0], hour: bits[1], dom: bits[2], month: bits[3], dow: bits[4],
+		domAny: fields[2] == "*", dowAny: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField parses a comma-separated list of *, values and ranges, each with an optional /step
+func parseCronField(field string, min, max int) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+			step = n
+		}
+
+		low, high := min, max
+		if rangePart != "*" {
+			from, to, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = strconv.Atoi(from); err != nil {
+				return 0, fmt.Errorf("invalid value %q", from)
+			}
+			high = low
+			if isRange {
+				if high, err = strconv.Atoi(to); err != nil {
+					return 0, fmt.Errorf("invalid value %q", to)
+				}
+			} else if hasStep {
+				high = max
+			}
+		}
+		if low < min || high > max || low > high {
+			return 0, fmt.Errorf("values must be between %d and %d", min, max)
+		}
+
+		for v := low; v <= high; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// Next returns the first matching minute after t, in t's location. Expressions matching no
+// date (such as February 30th) never run; Next then returns the zero time.
+func (c *cron) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	// Every combination of month, day and time recurs within a few years
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches applies cron's day rule: when both day fields are restricted, either may match
+func (c *cron) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dow
+	case c.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
@@ -0,0 +1,69 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func at(value string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParse_Next(t *testing.T) {
+	tests := []struct {
+		spec string
+		from string
+		want string
+	}{
+		{"@every 12h", "2026-10-15 09:30", "2026-10-15 21:30"},
+		{Every(90 * time.Minute), "2026-10-15 23:00", "2026-10-16 00:30"},
+		{"0 6 * * *", "2026-10-15 05:59", "2026-10-15 06:00"},
+		{"0 6 * * *", "2026-10-15 06:00", "2026-10-16 06:00"},
+		{"*/15 * * * *", "2026-10-15 10:07", "2026-10-15 10:15"},
+		{"30 8-10 * * *", "2026-10-15 10:31", "2026-10-16 08:30"},
+		{"0 9 * * 1-5", "2026-10-16 09:00", "2026-10-19 09:00"}, // Friday to Monday
+		{"0 0 * * 7", "2026-10-15 12:00", "2026-10-18 00:00"},   // 7 is Sunday
+		{"0 0 1 */3 *", "2026-10-15 00:00", "2027-01-01 00:00"},
+		{"0 12 13 * 5", "2026-10-15 00:00", "2026-10-16 12:00"}, // Either day field matches
+		{"@daily", "2026-12-31 23:59", "2027-01-01 00:00"},
+		{"@weekly", "2026-10-15 00:00", "2026-10-18 00:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec+" from "+tt.from, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, at(tt.want), s.Next(at(tt.from)))
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, spec := range []string{"", "@every", "@every 1m", "@every soon", "0 6 * *", "60 * * * *", "* 24 * * *", "0 0 0 * *", "5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+		_, err := Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestNextRun(t *testing.T) {
+	s, err := Parse("@every 6h")
+	require.NoError(t, err)
+	now := at("2026-10-15 12:00")
+
+	// Schedules that never ran are due right away
+	assert.Equal(t, now, NextRun(s, nil, now))
+
+	last := at("2026-10-15 09:00")
+	assert.Equal(t, at("2026-10-15 15:00"), NextRun(s, &last, now))
+
+	// Cron expressions matching no date never run
+	never, err := Parse("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, NextRun(never, &last, now).IsZero())
+}
@@ -0,0 +1,255 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/channel"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+	"github.com/Taichi-iskw/yt-lang/internal/service/translation"
+)
+
+// Page sizes of list endpoints
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// Aligner pairs the stored translations of a transcription with its segments
+type Aligner interface {
+	GetAlignedTranslation(ctx context.Context, transcriptionID string, targetLang string) ([]*translation.TranslationSegment, error)
+}
+
+// TranslationLanguages lists the languages a transcription is translated into
+type TranslationLanguages interface {
+	ListLanguagesByTranscriptionID(ctx context.Context, transcriptionID string) ([]string, error)
+}
+
+// Library is the stored data the REST API reads
+type Library struct {
+	Channels       channel.Repository
+	Videos         video.Repository
+	Transcriptions transcription.Repository
+	Segments       transcription.SegmentRepository
+	Languages      TranslationLanguages
+	Translations   Aligner
+}
+
+// alignedSegment is a transcription segment with its translation, for side-by-side reading
+type alignedSegment struct {
+	SegmentIndex   int    `json:"segment_index"`
+	StartTime      string `json:"start_time"`
+	EndTime        string `json:"end_time"`
+	Text           string `json:"text"`
+	TranslatedText string `json:"translated_text"`
+}
+
+// MountAPI mounts the read-only JSON API under /api
+func (s *Server) MountAPI(lib Library) {
+	api := &api{lib: lib}
+	s.mux.HandleFunc("GET /api/channels", api.listChannels)
+	s.mux.HandleFunc("GET /api/channels/{id}", api.getChannel)
+	s.mux.HandleFunc("GET /api/channels/{id}/videos", api.listVideos)
+	s.mux.HandleFunc("GET /api/videos/{id}", api.getVideo)
+	s.mux.HandleFunc("GET /api/videos/{id}/transcriptions", api.listTranscriptions)
+	s.mux.HandleFunc("GET /api/transcriptions/{id}", api.getTranscription)
+	s.mux.HandleFunc("GET /api/transcriptions/{id}/translations/{lang}", api.getTranslation)
+}
+
+type api struct {
+	lib Library
+}
+
+func (a *api) listChannels(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := pagination(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	channels, err := a.lib.Channels.List(r.Context(), limit, offset)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	total, err := a.lib.Channels.Count(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"channels": nonNil(channels), "total": total})
+}
+
+func (a *api) getChannel(w http.ResponseWriter, r *http.Request) {
+	ch, err := a.lib.Channels.GetByID(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ch)
+}
+
+func (a *api) listVideos(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := pagination(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	channelID := r.PathValue("id")
+	videos, err := a.lib.Videos.GetByChannelID(r.Context(), channelID, limit, offset)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	total, err := a.lib.Videos.CountByChannelID(r.Context(), channelID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"videos": nonNil(videos), "total": total})
+}
+
+func (a *api) getVideo(w http.ResponseWriter, r *http.Request) {
+	v, err := a.lib.Videos.GetByID(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, v)
+}
+
+func (a *api) listTranscriptions(w http.ResponseWriter, r *http.Request) {
+	transcriptions, err := a.lib.Transcriptions.GetByVideoID(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"transcriptions": nonNil(transcriptions)})
+}
+
+// getTranscription returns a transcription with its segments and translation languages
+func (a *api) getTranscription(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	t, err := a.lib.Transcriptions.GetByID(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	segments, err := a.lib.Segments.GetByTranscriptionID(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	languages, err := a.lib.Languages.ListLanguagesByTranscriptionID(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"transcription": t,
+		"segments":      nonNil(segments),
+		"languages":     nonNil(languages),
+	})
+}
+
+// getTranslation returns the translated segments of a transcription in one language
+func (a *api) getTranslation(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, err := a.lib.Transcriptions.GetByID(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+	lang := r.PathValue("lang")
+	languages, err := a.lib.Languages.ListLanguagesByTranscriptionID(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !slices.Contains(languages, lang) {
+		writeError(w, errors.New(errors.CodeNotFound, fmt.Sprintf("transcription %s has no %s translation", id, lang)))
+		return
+	}
+	aligned, err := a.lib.Translations.GetAlignedTranslation(r.Context(), id, lang)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	segments := make([]alignedSegment, 0, len(aligned))
+	for _, seg := range aligned {
+		segments = append(segments, alignedSegment{
+			SegmentIndex:   seg.SegmentIndex,
+			StartTime:      seg.StartTime,
+			EndTime:        seg.EndTime,
+			Text:           seg.Text,
+			TranslatedText: seg.TranslatedText,
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"target_language": lang, "segments": segments})
+}
+
+// pagination reads the limit and offset query parameters
+func pagination(r *http.Request) (int, int, error) {
+	limit, offset := defaultPageSize, 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxPageSize {
+			return 0, 0, errors.New(errors.CodeInvalidArg, fmt.Sprintf("limit must be between 1 and %d", maxPageSize))
+		}
+		limit = n
+	}
+	if value := r.URL.Query().Get("offset"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return 0, 0, errors.New(errors.CodeInvalidArg, "offset must be a non-negative number")
+		}
+		offset = n
+	}
+	return limit, offset, nil
+}
+
+// nonNil makes empty lists encode as [] instead of null
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+// errorStatus maps application error codes to HTTP statuses
+var errorStatus = map[string]int{
+	errors.CodeNotFound:   http.StatusNotFound,
+	errors.CodeInvalidArg: http.StatusBadRequest,
+	errors.CodeConflict:   http.StatusConflict,
+	errors.CodeExternal:   http.StatusBadGateway,
+}
+
+// writeError writes err as {"error": {"code": ..., "message": ...}}. Only the message of
+// application errors is shown; causes such as database errors stay in the server log.
+func writeError(w http.ResponseWriter, err error) {
+	code, status, message := errors.CodeInternal, http.StatusInternalServerError, "internal error"
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) {
+		code, message = appErr.Code, appErr.Message
+		if mapped, ok := errorStatus[code]; ok {
+			status = mapped
+		}
+	}
+	if status == http.StatusInternalServerError {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+	writeJSON(w, status, map[string]any{"error": map[string]string{"code": code, "message": message}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
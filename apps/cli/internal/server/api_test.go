@@ -0,0 +1,160 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/channel"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+	"github.com/Taichi-iskw/yt-lang/internal/service/translation"
+)
+
+// The fakes embed the repository interfaces and implement only what the API reads
+
+type fakeChannels struct {
+	channel.Repository
+	channels []*model.Channel
+}
+
+func (f *fakeChannels) List(ctx context.Context, limit, offset int) ([]*model.Channel, error) {
+	return f.channels[min(offset, len(f.channels)):min(offset+limit, len(f.channels))], nil
+}
+
+func (f *fakeChannels) Count(ctx context.Context) (int, error) { return len(f.channels), nil }
+
+type fakeVideos struct {
+	video.Repository
+}
+
+func (f *fakeVideos) GetByID(ctx context.Context, id string) (*model.Video, error) {
+	if id != "video1" {
+		return nil, apperrors.New(apperrors.CodeNotFound, "video not found")
+	}
+	return &model.Video{ID: id, ChannelID: "UC1", Title: "First"}, nil
+}
+
+// fakeTranscriptions also stands in for the translation lookups
+type fakeTranscriptions struct {
+	transcription.Repository
+}
+
+func (f *fakeTranscriptions) GetByID(ctx context.Context, id string) (*model.Transcription, error) {
+	return &model.Transcription{ID: id, VideoID: "video1", Language: "en", Status: "completed"}, nil
+}
+
+func (f *fakeTranscriptions) ListLanguagesByTranscriptionID(ctx context.Context, transcriptionID string) ([]string, error) {
+	return []string{"ja"}, nil
+}
+
+func (f *fakeTranscriptions) GetAlignedTranslation(ctx context.Context, transcriptionID string, targetLang string) ([]*translation.TranslationSegment, error) {
+	return []*translation.TranslationSegment{{SegmentIndex: 0, StartTime: "00:00:00", EndTime: "00:00:02", Text: "Hello", TranslatedText: "こんにちは"}}, nil
+}
+
+type fakeSegments struct {
+	transcription.SegmentRepository
+}
+
+func (f *fakeSegments) GetByTranscriptionID(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error) {
+	return []*model.TranscriptionSegment{{ID: "seg1", SegmentIndex: 0, StartTime: "00:00:00", EndTime: "00:00:02", Text: "Hello"}}, nil
+}
+
+func newTestAPI() http.Handler {
+	transcriptions := &fakeTranscriptions{}
+	srv := New(Options{})
+	srv.MountAPI(Library{
+		Channels:       &fakeChannels{channels: []*model.Channel{{ID: "UC1", Name: "One"}, {ID: "UC2", Name: "Two"}}},
+		Videos:         &fakeVideos{},
+		Transcriptions: transcriptions,
+		Segments:       &fakeSegments{},
+		Languages:      transcriptions,
+		Translations:   transcriptions,
+	})
+	srv.MountUI()
+	return srv.Handler()
+}
+
+func TestAPI(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "channels page",
+			path:       "/api/channels?limit=1&offset=1",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"channels": [{"id": "UC2", "name": "Two", "url": ""}], "total": 2}`,
+		},
+		{
+			name:       "invalid limit",
+			path:       "/api/channels?limit=0",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error": {"code": "INVALID_ARGUMENT", "message": "limit must be between 1 and 500"}}`,
+		},
+		{
+			name:       "video not found",
+			path:       "/api/videos/missing",
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"error": {"code": "NOT_FOUND", "message": "video not found"}}`,
+		},
+		{
+			name:       "translation",
+			path:       "/api/transcriptions/trans1/translations/ja",
+			wantStatus: http.StatusOK,
+			wantBody: `{"target_language": "ja", "segments": [
+				{"segment_index": 0, "start_time": "00:00:00", "end_time": "00:00:02", "text": "Hello", "translated_text": "こんにちは"}]}`,
+		},
+		{
+			name:       "language not translated",
+			path:       "/api/transcriptions/trans1/translations/fr",
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"error": {"code": "NOT_FOUND", "message": "transcription trans1 has no fr translation"}}`,
+		},
+	}
+
+	handler := newTestAPI()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestAPI_Transcription(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestAPI().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/transcriptions/trans1", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"languages":["ja"]`)
+	assert.Contains(t, rec.Body.String(), `"text":"Hello"`)
+}
+
+func TestUI(t *testing.T) {
+	handler := newTestAPI()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `<script src="/app.js">`)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/app.js", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "javascript")
+
+	// The probes keep their own handlers
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.JSONEq(t, `{"status": "ok"}`, rec.Body.String())
+}
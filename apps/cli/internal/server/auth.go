@@ -0,0 +1,107 @@
+package server
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Scope is the access level of an API token; each scope includes the lower ones
+type Scope int
+
+// Scopes, from least to most privileged
+const (
+	ScopeRead  Scope = iota + 1 // GET requests: browsing the library
+	ScopeWrite                  // Requests changing data: POST, PUT and PATCH
+	ScopeAdmin                  // Destructive requests: DELETE
+)
+
+// scopeNames are the names of the scopes in config.yaml
+var scopeNames = map[Scope]string{
+	ScopeRead:  "read",
+	ScopeWrite: "write",
+	ScopeAdmin: "admin",
+}
+
+func (s Scope) String() string {
+	if name, ok := scopeNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Scope(%d)", int(s))
+}
+
+// ParseScope parses a scope name (read, write or admin)
+func ParseScope(name string) (Scope, error) {
+	for scope, scopeName := range scopeNames {
+		if scopeName == name {
+			return scope, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid scope %q (supported: read, write, admin)", name)
+}
+
+// Token is an API token accepted as "Authorization: Bearer <secret>"
+type Token struct {
+	Name   string // Shown in logs instead of the secret
+	Secret string
+	Scope  Scope
+}
+
+// requiredScope returns the scope a request needs, from its method
+func requiredScope(r *http.Request) Scope {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return ScopeRead
+	case http.MethodDelete:
+		return ScopeAdmin
+	default:
+		return ScopeWrite
+	}
+}
+
+// authorize requires a token with the scope of the request for /api endpoints. The health
+// probes and the viewer app's static files stay public; the app sends its token to the API.
+// Without tokens every request is allowed, as before tokens were configured.
+func (s *Server) authorize(next http.Handler) http.Handler {
+	if len(s.opts.Tokens) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api" && !strings.HasPrefix(r.URL.Path, "/api/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token := s.lookupToken(r)
+		if token == nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="yt-lang"`)
+			writeJSON(w, http.StatusUnauthorized, map[string]any{"error": map[string]string{
+				"code": "UNAUTHORIZED", "message": "a valid API token is required (Authorization: Bearer <token>)",
+			}})
+			return
+		}
+		if required := requiredScope(r); token.Scope < required {
+			writeJSON(w, http.StatusForbidden, map[string]any{"error": map[string]string{
+				"code": "FORBIDDEN", "message": fmt.Sprintf("token %s has %s scope; %s %s needs %s", token.Name, token.Scope, r.Method, r.URL.Path, required),
+			}})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// lookupToken returns the configured token of the request's bearer credentials, or nil
+func (s *Server) lookupToken(r *http.Request) *Token {
+	scheme, secret, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || secret == "" {
+		return nil
+	}
+	for i := range s.opts.Tokens {
+		// Compare every token in constant time so timing does not reveal prefixes
+		if subtle.ConstantTimeCompare([]byte(s.opts.Tokens[i].Secret), []byte(secret)) == 1 {
+			return &s.opts.Tokens[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_Authorize(t *testing.T) {
+	srv := New(Options{Tokens: []Token{
+		{Name: "viewer", Secret: "read-secret-0123456789", Scope: ScopeRead},
+		{Name: "editor", Secret: "write-secret-0123456789", Scope: ScopeWrite},
+		{Name: "admin", Secret: "admin-secret-0123456789", Scope: ScopeAdmin},
+	}})
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	srv.Handle("GET /api/videos/{id}", ok)
+	srv.Handle("PATCH /api/videos/{id}", ok)
+	srv.Handle("DELETE /api/videos/{id}", ok)
+	srv.Handle("GET /", ok)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		token      string
+		wantStatus int
+	}{
+		{"health probes are public", http.MethodGet, "/healthz", "", http.StatusOK},
+		{"viewer app files are public", http.MethodGet, "/app.js", "", http.StatusNoContent},
+		{"api needs a token", http.MethodGet, "/api/videos/v1", "", http.StatusUnauthorized},
+		{"unknown token", http.MethodGet, "/api/videos/v1", "Bearer wrong-secret-0123456789", http.StatusUnauthorized},
+		{"other scheme", http.MethodGet, "/api/videos/v1", "Basic read-secret-0123456789", http.StatusUnauthorized},
+		{"read token reads", http.MethodGet, "/api/videos/v1", "Bearer read-secret-0123456789", http.StatusNoContent},
+		{"read token cannot write", http.MethodPatch, "/api/videos/v1", "Bearer read-secret-0123456789", http.StatusForbidden},
+		{"write token writes", http.MethodPatch, "/api/videos/v1", "Bearer write-secret-0123456789", http.StatusNoContent},
+		{"write token cannot delete", http.MethodDelete, "/api/videos/v1", "Bearer write-secret-0123456789", http.StatusForbidden},
+		{"admin token deletes", http.MethodDelete, "/api/videos/v1", "bearer admin-secret-0123456789", http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", tt.token)
+			}
+			rec := httptest.NewRecorder()
+			srv.Handler().ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.Equal(t, `Bearer realm="yt-lang"`, rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestServer_AuthorizeWithoutTokens(t *testing.T) {
+	srv := New(Options{})
+	srv.Handle("DELETE /api/videos/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }))
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/api/videos/v1", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestParseScope(t *testing.T) {
+	scope, err := ParseScope("write")
+	require.NoError(t, err)
+	assert.Equal(t, ScopeWrite, scope)
+	assert.Equal(t, "write", scope.String())
+
+	_, err = ParseScope("readonly")
+	assert.Error(t, err)
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+)
+
+// checkTimeout bounds each readiness check so a hung dependency fails the probe instead of
+// outliving the orchestrator's probe timeout
+const checkTimeout = 5 * time.Second
+
+// Check is one dependency validated by /readyz
+type Check struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// Pinger is satisfied by *pgxpool.Pool
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// DatabaseCheck validates that the database accepts connections
+func DatabaseCheck(db Pinger) Check {
+	return Check{Name: "database", Check: db.Ping}
+}
+
+// ToolCheck validates that an external tool is installed at its configured path or in PATH
+func ToolCheck(binary string) Check {
+	return Check{Name: binary, Check: func(ctx context.Context) error {
+		_, err := common.LookTool(binary)
+		return err
+	}}
+}
+
+// healthResponse is the JSON body of /healthz and /readyz
+type healthResponse struct {
+	Status string            `json:"status"`           // ok, ready or not_ready
+	Checks map[string]string `json:"checks,omitempty"` // Check name -> ok or the failure
+}
+
+// handleHealthz reports that the process is up and serving, without touching dependencies
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, http.StatusOK, healthResponse{Status: "ok"})
+}
+
+// handleReadyz runs every check concurrently and reports 503 unless all of them pass
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+	defer cancel()
+
+	results := make([]error, len(s.opts.Checks))
+	var wg sync.WaitGroup
+	for i, check := range s.opts.Checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = check.Check(ctx)
+		}()
+	}
+	wg.Wait()
+
+	response := healthResponse{Status: "ready", Checks: make(map[string]string, len(results))}
+	status := http.StatusOK
+	for i, err := range results {
+		response.Checks[s.opts.Checks[i].Name] = "ok"
+		if err != nil {
+			response.Checks[s.opts.Checks[i].Name] = err.Error()
+			response.Status = "not_ready"
+			status = http.StatusServiceUnavailable
+		}
+	}
+	writeHealth(w, status, response)
+}
+
+func writeHealth(w http.ResponseWriter, status int, response healthResponse) {
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, status, response)
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Defaults for Options
+const (
+	DefaultAddr            = "127.0.0.1:8080"
+	DefaultShutdownTimeout = 30 * time.Second
+)
+
+// Options configures the HTTP server of serve mode
+type Options struct {
+	Addr            string        // Listen address; defaults to DefaultAddr
+	ShutdownTimeout time.Duration // Time in-flight requests get to finish on shutdown; defaults to DefaultShutdownTimeout
+	Checks          []Check       // Dependencies /readyz validates
+	Tokens          []Token       // API tokens required by /api; the API is open when empty
+}
+
+// Server serves the yt-lang HTTP endpoints with /healthz and /readyz probes for orchestrators
+type Server struct {
+	opts Options
+	mux  *http.ServeMux
+}
+
+// New creates a server with the health endpoints mounted
+func New(opts Options) *Server {
+	if opts.Addr == "" {
+		opts.Addr = DefaultAddr
+	}
+	if opts.ShutdownTimeout <= 0 {
+		opts.ShutdownTimeout = DefaultShutdownTimeout
+	}
+
+	s := &Server{opts: opts, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /healthz", s.handleHealthz)
+	s.mux.HandleFunc("GET /readyz", s.handleReadyz)
+	return s
+}
+
+// Handle mounts handler under pattern (http.ServeMux syntax, e.g. "GET /api/channels")
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// Handler returns the handler serving every mounted endpoint, behind token authorization
+func (s *Server) Handler() http.Handler {
+	return s.authorize(s.mux)
+}
+
+// Run serves until ctx is cancelled, then stops accepting connections and waits up to the
+// shutdown timeout for in-flight requests to finish
+func (s *Server) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.opts.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.opts.Addr, err)
+	}
+	return s.Serve(ctx, listener)
+}
+
+// Serve is Run on an existing listener
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	httpServer := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return context.WithoutCancel(ctx) },
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		served <- httpServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-served:
+		return fmt.Errorf("server stopped: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.opts.ShutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		httpServer.Close()
+		return fmt.Errorf("in-flight requests did not finish within %s: %w", s.opts.ShutdownTimeout, err)
+	}
+	if err := <-served; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("server stopped: %w", err)
+	}
+	return nil
+}
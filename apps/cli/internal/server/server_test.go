@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePinger fails Ping with err
+type fakePinger struct{ err error }
+
+func (p fakePinger) Ping(ctx context.Context) error { return p.err }
+
+func TestServer_Healthz(t *testing.T) {
+	// Liveness does not depend on the checks
+	srv := New(Options{Checks: []Check{DatabaseCheck(fakePinger{err: errors.New("connection refused")})}})
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status": "ok"}`, rec.Body.String())
+}
+
+func TestServer_Readyz(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     []Check
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "all checks pass",
+			checks:     []Check{DatabaseCheck(fakePinger{}), ToolCheck("sh")},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"status": "ready", "checks": {"database": "ok", "sh": "ok"}}`,
+		},
+		{
+			name:       "database down and tool missing",
+			checks:     []Check{DatabaseCheck(fakePinger{err: errors.New("connection refused")}), ToolCheck("yt-lang-missing-binary")},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody: `{"status": "not_ready", "checks": {
+				"database": "connection refused",
+				"yt-lang-missing-binary": "yt-lang-missing-binary is not installed or not found in PATH"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := New(Options{Checks: tt.checks})
+
+			rec := httptest.NewRecorder()
+			srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestServer_Readyz_CheckTimeout(t *testing.T) {
+	hung := Check{Name: "hung", Check: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+	srv := New(Options{Checks: []Check{hung}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil).WithContext(ctx))
+
+	var body healthResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, context.DeadlineExceeded.Error(), body.Checks["hung"])
+}
+
+func TestServer_Serve_DrainsInFlightRequests(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	srv := New(Options{})
+	srv.Handle("GET /slow", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		io.WriteString(w, "done")
+	}))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(ctx, listener) }()
+
+	response := make(chan string, 1)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String() + "/slow")
+		if err != nil {
+			response <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		response <- string(body)
+	}()
+
+	// Shutdown starts while the request is in flight and waits for it
+	<-started
+	cancel()
+	select {
+	case err := <-served:
+		t.Fatalf("server stopped before the in-flight request finished: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	assert.Equal(t, "done", <-response)
+	assert.NoError(t, <-served)
+}
+
+func TestServer_Serve_ShutdownTimeout(t *testing.T) {
+	srv := New(Options{ShutdownTimeout: 10 * time.Millisecond})
+	started := make(chan struct{})
+	srv.Handle("GET /stuck", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+	}))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(ctx, listener) }()
+	go http.Get("http://" + listener.Addr().String() + "/stuck")
+
+	<-started
+	cancel()
+	assert.ErrorContains(t, <-served, "did not finish within 10ms")
+}
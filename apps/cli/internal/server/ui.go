@@ -0,0 +1,21 @@
+package server
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// uiFiles is the single-page viewer app, built without a bundler so it can be embedded as is
+//
+//go:embed ui
+var uiFiles embed.FS
+
+// MountUI serves the embedded viewer app at /; it reads the data through the API mounted with MountAPI
+func (s *Server) MountUI() {
+	files, err := fs.Sub(uiFiles, "ui")
+	if err != nil {
+		panic(err) // The embedded directory always exists
+	}
+	s.mux.Handle("GET /", http.FileServerFS(files))
+}
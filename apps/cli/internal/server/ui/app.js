@@ -0,0 +1,189 @@
+// yt-lang viewer: a hash-routed single-page app over the read-only /api endpoints. When the
+// server requires API tokens, the token entered on the sign-in page is kept in localStorage.
+//
+//   #/                              channels
+//   #/channels/{id}?offset=N        videos of a channel
+//   #/videos/{id}                   transcriptions of a video
+//   #/transcriptions/{id}?lang=ja   segments, side by side with a translation
+"use strict";
+
+const PAGE_SIZE = 50;
+const TOKEN_KEY = "yt-lang-token";
+const app = document.getElementById("app");
+const breadcrumbs = document.getElementById("breadcrumbs");
+
+// h creates an element with attributes and children (strings become text nodes)
+function h(tag, attrs, ...children) {
+  const el = document.createElement(tag);
+  for (const [key, value] of Object.entries(attrs || {})) {
+    if (key.startsWith("on")) {
+      el.addEventListener(key.slice(2), value);
+    } else if (value !== undefined && value !== null) {
+      el.setAttribute(key, value);
+    }
+  }
+  for (const child of children.flat()) {
+    if (child !== undefined && child !== null) {
+      el.append(child instanceof Node ? child : String(child));
+    }
+  }
+  return el;
+}
+
+// AuthError is thrown when the server requires an API token the app does not have
+class AuthError extends Error {}
+
+async function api(path) {
+  const headers = { Accept: "application/json" };
+  const token = localStorage.getItem(TOKEN_KEY);
+  if (token) headers.Authorization = "Bearer " + token;
+  const resp = await fetch("/api" + path, { headers });
+  const body = await resp.json().catch(() => ({}));
+  if (!resp.ok) {
+    const message = (body.error && body.error.message) || resp.statusText;
+    throw resp.status === 401 ? new AuthError(message) : new Error(message);
+  }
+  return body;
+}
+
+// tokenPage asks for an API token (a read token is enough) and keeps it in this browser
+function tokenPage(message) {
+  const input = h("input", { type: "password", autocomplete: "off", placeholder: "API token" });
+  const form = h("form", { onsubmit: (e) => {
+    e.preventDefault();
+    localStorage.setItem(TOKEN_KEY, input.value.trim());
+    route();
+  } }, input, " ", h("button", { type: "submit" }, "Sign in"));
+  render("Sign in", [], h("p", { class: "muted" }, message), form);
+}
+
+function render(title, crumbs, ...content) {
+  document.title = title ? title + " · yt-lang" : "yt-lang";
+  breadcrumbs.replaceChildren(...crumbs.map(([label, href]) => (href ? h("a", { href }, label) : h("span", {}, label))));
+  app.replaceChildren(h("h1", {}, title || "Channels"), ...content);
+}
+
+// time formats a PostgreSQL INTERVAL such as "00:01:02.5" as 1:02
+function time(interval) {
+  const match = /^(\d+):(\d+):(\d+)/.exec(interval || "");
+  if (!match) return interval || "";
+  const [, hours, minutes, seconds] = match.map(Number);
+  return (hours ? hours + ":" + String(minutes).padStart(2, "0") : String(minutes)) + ":" + String(seconds).padStart(2, "0");
+}
+
+function duration(seconds) {
+  return seconds ? time(new Date(seconds * 1000).toISOString().slice(11, 19)) : "";
+}
+
+function pager(total, offset, hrefFor) {
+  if (total <= PAGE_SIZE) return null;
+  return h("div", { class: "pager" },
+    offset > 0 ? h("a", { href: hrefFor(Math.max(offset - PAGE_SIZE, 0)) }, "← Previous") : null,
+    h("span", { class: "muted" }, `${offset + 1}–${Math.min(offset + PAGE_SIZE, total)} of ${total}`),
+    offset + PAGE_SIZE < total ? h("a", { href: hrefFor(offset + PAGE_SIZE) }, "Next →") : null);
+}
+
+async function channelsPage() {
+  const { channels } = await api("/channels?limit=500");
+  render("", [],
+    channels.length === 0
+      ? h("p", { class: "muted" }, "No channels yet. Add one with `ytlang channel save`.")
+      : h("table", {},
+          h("thead", {}, h("tr", {}, h("th", {}, "Channel"), h("th", {}, "ID"))),
+          h("tbody", {}, channels.map((c) =>
+            h("tr", {}, h("td", {}, h("a", { href: `#/channels/${encodeURIComponent(c.id)}` }, c.name)), h("td", { class: "muted" }, c.id))))));
+}
+
+async function channelPage(id, params) {
+  const offset = Number(params.get("offset")) || 0;
+  const [channel, { videos, total }] = await Promise.all([
+    api(`/channels/${encodeURIComponent(id)}`),
+    api(`/channels/${encodeURIComponent(id)}/videos?limit=${PAGE_SIZE}&offset=${offset}`),
+  ]);
+  render(channel.name, [["Channels", "#/"], [channel.name]],
+    h("table", {},
+      h("thead", {}, h("tr", {}, h("th", {}, "Video"), h("th", {}, "Uploaded"), h("th", {}, "Duration"), h("th", {}, "Rating"))),
+      h("tbody", {}, videos.map((v) =>
+        h("tr", {},
+          h("td", {}, h("a", { href: `#/videos/${encodeURIComponent(v.id)}` }, v.title), v.status === "unavailable" ? h("span", { class: "muted" }, " (unavailable)") : null),
+          h("td", { class: "time" }, v.upload_date ? v.upload_date.slice(0, 10) : ""),
+          h("td", { class: "time" }, duration(v.duration)),
+          h("td", {}, v.rating ? "★".repeat(v.rating) : ""))))),
+    pager(total, offset, (o) => `#/channels/${encodeURIComponent(id)}?offset=${o}`));
+}
+
+async function videoPage(id) {
+  const [video, { transcriptions }] = await Promise.all([
+    api(`/videos/${encodeURIComponent(id)}`),
+    api(`/videos/${encodeURIComponent(id)}/transcriptions`),
+  ]);
+  render(video.title, [["Channels", "#/"], ["Channel", `#/channels/${encodeURIComponent(video.channel_id)}`], [video.title]],
+    h("p", {}, h("a", { href: video.url, target: "_blank", rel: "noopener" }, "Watch on YouTube"), video.note ? h("span", { class: "muted" }, " · " + video.note) : null),
+    transcriptions.length === 0
+      ? h("p", { class: "muted" }, "Not transcribed yet.")
+      : h("table", {},
+          h("thead", {}, h("tr", {}, h("th", {}, "Transcription"), h("th", {}, "Language"), h("th", {}, "Status"), h("th", {}, "Created"))),
+          h("tbody", {}, transcriptions.map((t) =>
+            h("tr", {},
+              h("td", {}, t.status === "completed" ? h("a", { href: `#/transcriptions/${encodeURIComponent(t.id)}` }, t.id) : t.id),
+              h("td", {}, t.detected_language || t.language),
+              h("td", {}, t.status),
+              h("td", { class: "time" }, t.created_at.slice(0, 10)))))));
+}
+
+async function transcriptionPage(id, params) {
+  const { transcription, segments, languages } = await api(`/transcriptions/${encodeURIComponent(id)}`);
+  const lang = params.get("lang") || languages[0] || "";
+  const translated = new Map();
+  if (lang) {
+    const translation = await api(`/transcriptions/${encodeURIComponent(id)}/translations/${encodeURIComponent(lang)}`);
+    for (const seg of translation.segments) translated.set(seg.segment_index, seg.translated_text);
+  }
+
+  const picker = h("select", { onchange: (e) => { location.hash = `#/transcriptions/${encodeURIComponent(id)}?lang=${e.target.value}`; } },
+    languages.map((l) => h("option", l === lang ? { value: l, selected: "" } : { value: l }, l)));
+  const source = transcription.detected_language || transcription.language;
+
+  render(`Transcription ${transcription.id}`,
+    [["Channels", "#/"], ["Video", `#/videos/${encodeURIComponent(transcription.video_id)}`], ["Transcription"]],
+    h("div", { class: "toolbar" },
+      languages.length > 0 ? h("label", {}, "Translation ", picker) : h("span", { class: "muted" }, "Not translated yet."),
+      h("span", { class: "muted" }, `${segments.length} segments`)),
+    h("table", {},
+      h("thead", {}, h("tr", {}, h("th", {}, "Time"), h("th", {}, source), lang ? h("th", {}, lang) : null)),
+      h("tbody", {}, segments.map((s) =>
+        h("tr", {},
+          h("td", { class: "time" }, time(s.start_time)),
+          h("td", { class: "side" }, s.text),
+          lang ? h("td", { class: "side" }, translated.get(s.segment_index) ?? h("span", { class: "muted" }, "—")) : null)))));
+}
+
+const routes = [
+  [/^\/?$/, channelsPage],
+  [/^\/channels\/([^/]+)$/, channelPage],
+  [/^\/videos\/([^/]+)$/, videoPage],
+  [/^\/transcriptions\/([^/]+)$/, transcriptionPage],
+];
+
+async function route() {
+  const [path, query] = location.hash.replace(/^#/, "").split("?");
+  const params = new URLSearchParams(query || "");
+  for (const [pattern, page] of routes) {
+    const match = pattern.exec(path);
+    if (!match) continue;
+    try {
+      await page(match[1] && decodeURIComponent(match[1]), params);
+    } catch (err) {
+      if (err instanceof AuthError) {
+        tokenPage(localStorage.getItem(TOKEN_KEY) ? "The saved API token was rejected; enter another." : "This server requires an API token.");
+      } else {
+        render("Error", [["Channels", "#/"]], h("p", { class: "error" }, err.message));
+      }
+    }
+    return;
+  }
+  render("Not found", [["Channels", "#/"]], h("p", { class: "muted" }, "No such page."));
+}
+
+window.addEventListener("hashchange", route);
+route();
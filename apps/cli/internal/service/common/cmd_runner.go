@@ -22,8 +22,11 @@ type CmdRunner interface {
 // realCmdRunner implements CmdRunner using os/exec
 type realCmdRunner struct{}
 
-// NewCmdRunner creates a new CmdRunner
+// NewCmdRunner creates a new CmdRunner, tracing invocations when SetTraceWriter enabled it
 func NewCmdRunner() CmdRunner {
+	if w := currentTraceWriter(); w != nil {
+		return NewTracingCmdRunner(&realCmdRunner{}, w)
+	}
 	return &realCmdRunner{}
 }
 
@@ -50,15 +53,15 @@ func (p *processWrapper) Signal(sig os.Signal) error {
 	return p.cmd.Process.Signal(sig)
 }
 
-// Run executes external command with given arguments
+// Run executes external command with given arguments, running the configured binary of a tool
 func (r *realCmdRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
-	cmd := exec.CommandContext(ctx, name, args...)
+	cmd := exec.CommandContext(ctx, ToolPath(name), args...)
 	return cmd.Output()
 }
 
 // Start starts external command and returns Process for management
 func (r *realCmdRunner) Start(ctx context.Context, name string, args ...string) (Process, error) {
-	cmd := exec.CommandContext(ctx, name, args...)
+	cmd := exec.CommandContext(ctx, ToolPath(name), args...)
 	if err := cmd.Start(); err != nil {
 		return nil, err
 	}
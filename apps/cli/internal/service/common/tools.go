@@ -0,0 +1,116 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// External tools run by the pipeline, by the name they are looked up in PATH with
+const (
+	ToolYtDlp   = "yt-dlp"
+	ToolWhisper = "whisper"
+	ToolFFmpeg  = "ffmpeg"
+	ToolPlamo   = "plamo-translate"
+)
+
+// Tools lists the external tools whose binary path can be configured
+var Tools = []string{ToolYtDlp, ToolWhisper, ToolFFmpeg, ToolPlamo}
+
+var (
+	toolMu    sync.Mutex
+	toolPaths map[string]string // Configured binary paths by tool name
+)
+
+// SetToolPaths makes runners created by NewCmdRunner run the given binaries instead of looking
+// the tools up in PATH. It is set once at startup from the tools section of the config file.
+// Paths may start with ~/; on Windows the .exe extension may be omitted.
+func SetToolPaths(paths map[string]string) error {
+	resolved := make(map[string]string, len(paths))
+	for tool, path := range paths {
+		if !IsTool(tool) {
+			return fmt.Errorf("unknown tool %q (supported: %s)", tool, strings.Join(Tools, ", "))
+		}
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		expanded, err := expandHome(path)
+		if err != nil {
+			return err
+		}
+		resolved[tool] = withExecutableSuffix(expanded, runtime.GOOS, fileExists)
+	}
+
+	toolMu.Lock()
+	defer toolMu.Unlock()
+	toolPaths = resolved
+	return nil
+}
+
+// IsTool reports whether name is one of Tools
+func IsTool(name string) bool {
+	for _, tool := range Tools {
+		if tool == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ToolPath returns the binary run for a tool: its configured path, or the name itself to be
+// looked up in PATH
+func ToolPath(name string) string {
+	toolMu.Lock()
+	defer toolMu.Unlock()
+	if path, ok := toolPaths[name]; ok {
+		return path
+	}
+	return name
+}
+
+// LookTool returns the absolute path of the binary run for a tool, failing when it is not an
+// executable file (configured path) or not found in PATH
+func LookTool(name string) (string, error) {
+	path := ToolPath(name)
+	resolved, err := exec.LookPath(path)
+	if err != nil {
+		if path != name {
+			return "", fmt.Errorf("%s is not an executable at the configured path %s", name, path)
+		}
+		return "", fmt.Errorf("%s is not installed or not found in PATH", name)
+	}
+	if abs, err := filepath.Abs(resolved); err == nil {
+		resolved = abs
+	}
+	return resolved, nil
+}
+
+// expandHome replaces a leading ~ with the home directory
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, `~\`) {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, path[1:]), nil
+}
+
+// withExecutableSuffix adds .exe to a Windows path without an extension when only the .exe exists
+func withExecutableSuffix(path, goos string, exists func(string) bool) string {
+	if goos != "windows" || filepath.Ext(path) != "" || exists(path) || !exists(path+".exe") {
+		return path
+	}
+	return path + ".exe"
+}
+
+// fileExists reports whether a regular file exists at path
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,53 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetToolPaths(t *testing.T) {
+	defer SetToolPaths(nil)
+
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "yt-dlp-nightly")
+	require.NoError(t, os.WriteFile(binary, []byte("#!/bin/sh\necho 2025.01.01\n"), 0755))
+
+	require.NoError(t, SetToolPaths(map[string]string{ToolYtDlp: binary, ToolFFmpeg: filepath.Join(dir, "missing"), ToolWhisper: " "}))
+	assert.Equal(t, binary, ToolPath(ToolYtDlp))
+	assert.Equal(t, ToolWhisper, ToolPath(ToolWhisper), "blank paths keep the PATH lookup")
+
+	path, err := LookTool(ToolYtDlp)
+	require.NoError(t, err)
+	assert.Equal(t, binary, path)
+	_, err = LookTool(ToolFFmpeg)
+	assert.ErrorContains(t, err, "configured path")
+
+	// Configured binaries are run in place of the tool name
+	output, err := NewCmdRunner().Run(t.Context(), ToolYtDlp)
+	require.NoError(t, err)
+	assert.Equal(t, "2025.01.01\n", string(output))
+
+	assert.ErrorContains(t, SetToolPaths(map[string]string{"youtube-dl": binary}), `unknown tool "youtube-dl"`)
+}
+
+func TestWithExecutableSuffix(t *testing.T) {
+	exists := func(files ...string) func(string) bool {
+		return func(path string) bool {
+			for _, file := range files {
+				if file == path {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	assert.Equal(t, `C:\Tools\yt-dlp.exe`, withExecutableSuffix(`C:\Tools\yt-dlp`, "windows", exists(`C:\Tools\yt-dlp.exe`)))
+	assert.Equal(t, `C:\Tools\yt-dlp`, withExecutableSuffix(`C:\Tools\yt-dlp`, "windows", exists(`C:\Tools\yt-dlp`, `C:\Tools\yt-dlp.exe`)))
+	assert.Equal(t, `C:\Tools\whisper.cmd`, withExecutableSuffix(`C:\Tools\whisper.cmd`, "windows", exists()))
+	assert.Equal(t, "/usr/local/bin/yt-dlp", withExecutableSuffix("/usr/local/bin/yt-dlp", "darwin", exists("/usr/local/bin/yt-dlp.exe")))
+}
@@ -0,0 +1,159 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Trace event kinds
+const (
+	TraceEventRun   = "run"   // A Run call finished
+	TraceEventStart = "start" // A Start call launched a process
+	TraceEventExit  = "exit"  // A process launched by Start was waited for
+)
+
+// CommandEvent is one traced external command invocation, written as a JSON line
+type CommandEvent struct {
+	Time        time.Time `json:"time"`
+	Event       string    `json:"event"`
+	Binary      string    `json:"binary"`
+	Args        []string  `json:"args"`
+	DurationMS  int64     `json:"duration_ms"`
+	ExitCode    int       `json:"exit_code"`              // -1 when the command could not be run or was killed
+	StdoutBytes int       `json:"stdout_bytes,omitempty"` // Captured output (Run only)
+	StderrBytes int       `json:"stderr_bytes,omitempty"` // Captured error output (failed Run only)
+	Error       string    `json:"error,omitempty"`
+}
+
+var (
+	traceMu     sync.Mutex
+	traceWriter io.Writer // Runners created by NewCmdRunner trace to it when set
+
+	// eventMu serializes events of concurrent commands, which may share one writer
+	eventMu sync.Mutex
+)
+
+// SetTraceWriter makes NewCmdRunner return runners that trace every invocation to w (nil
+// turns tracing off). It is set once at startup by the global --debug and --trace-file flags.
+func SetTraceWriter(w io.Writer) {
+	traceMu.Lock()
+	defer traceMu.Unlock()
+	traceWriter = w
+}
+
+// currentTraceWriter returns the writer set by SetTraceWriter
+func currentTraceWriter() io.Writer {
+	traceMu.Lock()
+	defer traceMu.Unlock()
+	return traceWriter
+}
+
+// tracingCmdRunner writes a CommandEvent for every command run through the wrapped runner
+type tracingCmdRunner struct {
+	inner CmdRunner
+	w     io.Writer
+}
+
+// NewTracingCmdRunner wraps a CmdRunner so that every invocation is written to w as a JSON
+// line. Tracing failures never fail a command.
+func NewTracingCmdRunner(inner CmdRunner, w io.Writer) CmdRunner {
+	return &tracingCmdRunner{inner: inner, w: w}
+}
+
+// Run runs the command and traces its duration, exit status and output sizes
+func (r *tracingCmdRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	started := time.Now()
+	output, err := r.inner.Run(ctx, name, args...)
+
+	event := r.event(TraceEventRun, started, name, args, err)
+	event.StdoutBytes = len(output)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		event.StderrBytes = len(exitErr.Stderr)
+	}
+	r.write(event)
+	return output, err
+}
+
+// Start launches the command, tracing the launch now and its exit when the process is waited for
+func (r *tracingCmdRunner) Start(ctx context.Context, name string, args ...string) (Process, error) {
+	started := time.Now()
+	process, err := r.inner.Start(ctx, name, args...)
+	r.write(r.event(TraceEventStart, started, name, args, err))
+	if err != nil {
+		return nil, err
+	}
+	return &tracingProcess{Process: process, runner: r, started: started, name: name, args: args}, nil
+}
+
+// event builds the event of a finished call
+func (r *tracingCmdRunner) event(kind string, started time.Time, name string, args []string, err error) CommandEvent {
+	event := CommandEvent{
+		Time:       started,
+		Event:      kind,
+		Binary:     name,
+		Args:       redactArgs(args),
+		DurationMS: time.Since(started).Milliseconds(),
+	}
+	if err != nil {
+		event.ExitCode = -1
+		event.Error = err.Error()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			event.ExitCode = exitErr.ExitCode()
+		}
+	}
+	return event
+}
+
+// write appends event as one JSON line
+func (r *tracingCmdRunner) write(event CommandEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	eventMu.Lock()
+	defer eventMu.Unlock()
+	r.w.Write(append(data, '\n'))
+}
+
+// tracingProcess traces the exit of a process launched by tracingCmdRunner.Start
+type tracingProcess struct {
+	Process
+	runner  *tracingCmdRunner
+	started time.Time
+	name    string
+	args    []string
+}
+
+// Wait waits for the process and traces its exit status and total run time
+func (p *tracingProcess) Wait() error {
+	err := p.Process.Wait()
+	p.runner.write(p.runner.event(TraceEventExit, p.started, p.name, p.args, err))
+	return err
+}
+
+// redactArgs hides passwords of URLs (e.g. authenticated proxies) in traced arguments
+func redactArgs(args []string) []string {
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		redacted[i] = arg
+		if !strings.Contains(arg, "://") || !strings.Contains(arg, "@") {
+			continue
+		}
+		if u, err := url.Parse(arg); err == nil && u.User != nil {
+			if _, hasPassword := u.User.Password(); hasPassword {
+				u.User = url.UserPassword(u.User.Username(), "xxxxx")
+				redacted[i] = u.String()
+			}
+		}
+	}
+	return redacted
+}
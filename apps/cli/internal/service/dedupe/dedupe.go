@@ -0,0 +1,267 @@
+package dedupe
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// Detection modes
+const (
+	ModeTitleSimilarity = "title-similarity" // Similar titles, with durations within the tolerance when both are known
+	ModeDuration        = "duration"         // Durations within the tolerance, whatever the titles
+)
+
+// Detection defaults
+const (
+	DefaultThreshold = 0.8
+	DefaultTolerance = 3 * time.Second
+)
+
+// listPageSize is the number of videos loaded per query while scanning the library
+const listPageSize = 500
+
+// VideoRepository interface for scanning videos and recording duplicates
+type VideoRepository interface {
+	List(ctx context.Context, limit, offset int) ([]*model.Video, error)
+	SetDuplicateOf(ctx context.Context, id string, originalID string) error
+}
+
+// Options configures duplicate detection
+type Options struct {
+	Mode      string        // ModeTitleSimilarity (default) or ModeDuration
+	Threshold float64       // Minimum title similarity, 0-1; defaults to DefaultThreshold
+	Tolerance time.Duration // Maximum duration difference; defaults to DefaultTolerance
+}
+
+// Group is a video and the videos that likely re-upload it
+type Group struct {
+	Original   *model.Video `json:"original"` // Earliest upload in the group
+	Duplicates []*Match     `json:"duplicates"`
+}
+
+// Match is a likely duplicate of a group's original
+type Match struct {
+	Video      *model.Video `json:"video"`
+	Similarity float64      `json:"similarity"`        // Title similarity to the original, 0-1
+	Difference float64      `json:"duration_diff_sec"` // Duration difference to the original in seconds; 0 when unknown
+}
+
+// Service finds re-uploaded videos across saved channels
+type Service interface {
+	// Detect scans every saved video of the workspace for likely duplicates
+	Detect(ctx context.Context, opts Options) ([]*Group, error)
+
+	// Link records each group's duplicates as duplicates of its original, so batch
+	// transcription skips them. It returns the number of videos linked.
+	Link(ctx context.Context, groups []*Group) (int, error)
+}
+
+// service implements Service
+type service struct {
+	videoRepo VideoRepository
+}
+
+// NewService creates a new duplicate detection service
+func NewService(videoRepo VideoRepository) Service {
+	return &service{videoRepo: videoRepo}
+}
+
+// Detect loads every video and groups likely duplicates
+func (s *service) Detect(ctx context.Context, opts Options) ([]*Group, error) {
+	var videos []*model.Video
+	for offset := 0; ; offset += listPageSize {
+		page, err := s.videoRepo.List(ctx, listPageSize, offset)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, "failed to list videos")
+		}
+		videos = append(videos, page...)
+		if len(page) < listPageSize {
+			break
+		}
+	}
+	return Find(videos, opts)
+}
+
+// Link marks the duplicates of every group
+func (s *service) Link(ctx context.Context, groups []*Group) (int, error) {
+	linked := 0
+	for _, group := range groups {
+		for _, match := range group.Duplicates {
+			if err := s.videoRepo.SetDuplicateOf(ctx, match.Video.ID, group.Original.ID); err != nil {
+				return linked, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to link video %s", match.Video.ID))
+			}
+			linked++
+		}
+	}
+	return linked, nil
+}
+
+// Find groups likely duplicates among videos. Groups are ordered by their original's ID.
+func Find(videos []*model.Video, opts Options) ([]*Group, error) {
+	if opts.Mode == "" {
+		opts.Mode = ModeTitleSimilarity
+	}
+	if opts.Threshold == 0 {
+		opts.Threshold = DefaultThreshold
+	}
+	if opts.Tolerance == 0 {
+		opts.Tolerance = DefaultTolerance
+	}
+	if opts.Threshold < 0 || opts.Threshold > 1 {
+		return nil, errors.New(errors.CodeInvalidArg, "similarity threshold must be between 0 and 1")
+	}
+	if opts.Tolerance < 0 {
+		return nil, errors.New(errors.CodeInvalidArg, "duration tolerance must not be negative")
+	}
+
+	var clusters [][]*model.Video
+	switch opts.Mode {
+	case ModeTitleSimilarity:
+		clusters = clusterByTitle(videos, opts.Threshold, opts.Tolerance.Seconds())
+	case ModeDuration:
+		clusters = clusterByDuration(videos, opts.Tolerance.Seconds())
+	default:
+		return nil, errors.New(errors.CodeInvalidArg, fmt.Sprintf("unsupported mode: %s (supported: %s, %s)", opts.Mode, ModeTitleSimilarity, ModeDuration))
+	}
+
+	groups := make([]*Group, 0, len(clusters))
+	for _, cluster := range clusters {
+		groups = append(groups, newGroup(cluster))
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Original.ID < groups[j].Original.ID })
+	return groups, nil
+}
+
+// clusterByTitle links every pair of videos with similar titles whose durations are within
+// tolerance (or unknown) and returns the connected groups of more than one video
+func clusterByTitle(videos []*model.Video, threshold, tolerance float64) [][]*model.Video {
+	grams := make([]map[string]int, len(videos))
+	for i, v := range videos {
+		grams[i] = bigrams(normalizeTitle(v.Title))
+	}
+
+	parent := make([]int, len(videos))
+	for i := range parent {
+		parent[i] = i
+	}
+	var root func(i int) int
+	root = func(i int) int {
+		if parent[i] != i {
+			parent[i] = root(parent[i])
+		}
+		return parent[i]
+	}
+	compare := func(i, j int) {
+		if titleSimilarity(grams[i], grams[j]) >= threshold {
+			parent[root(i)] = root(j)
+		}
+	}
+
+	// Videos of known duration only need comparing with those close in duration
+	var known, unknown []int
+	for i, v := range videos {
+		if v.Duration > 0 {
+			known = append(known, i)
+		} else {
+			unknown = append(unknown, i)
+		}
+	}
+	sort.Slice(known, func(a, b int) bool { return videos[known[a]].Duration < videos[known[b]].Duration })
+	for a, i := range known {
+		for _, j := range known[a+1:] {
+			if videos[j].Duration-videos[i].Duration > tolerance {
+				break
+			}
+			compare(i, j)
+		}
+	}
+	for a, i := range unknown {
+		for _, j := range known {
+			compare(i, j)
+		}
+		for _, j := range unknown[a+1:] {
+			compare(i, j)
+		}
+	}
+
+	members := make(map[int][]*model.Video)
+	var roots []int
+	for i, v := range videos {
+		r := root(i)
+		if _, ok := members[r]; !ok {
+			roots = append(roots, r)
+		}
+		members[r] = append(members[r], v)
+	}
+
+	var clusters [][]*model.Video
+	for _, r := range roots {
+		if len(members[r]) > 1 {
+			clusters = append(clusters, members[r])
+		}
+	}
+	return clusters
+}
+
+// clusterByDuration groups videos of known duration that are all within tolerance of the
+// shortest in their group. Anchoring on the shortest keeps a run of slightly longer videos
+// from chaining into one large group.
+func clusterByDuration(videos []*model.Video, tolerance float64) [][]*model.Video {
+	var known []*model.Video
+	for _, v := range videos {
+		if v.Duration > 0 {
+			known = append(known, v)
+		}
+	}
+	sort.SliceStable(known, func(i, j int) bool { return known[i].Duration < known[j].Duration })
+
+	var clusters [][]*model.Video
+	for start := 0; start < len(known); {
+		end := start + 1
+		for end < len(known) && known[end].Duration-known[start].Duration <= tolerance {
+			end++
+		}
+		if end-start > 1 {
+			clusters = append(clusters, known[start:end])
+		}
+		start = end
+	}
+	return clusters
+}
+
+// newGroup picks the earliest upload of a cluster as its original (videos of unknown upload
+// date count as later, ties go to the lowest ID) and matches the others against it
+func newGroup(cluster []*model.Video) *Group {
+	videos := append([]*model.Video(nil), cluster...)
+	sort.Slice(videos, func(i, j int) bool {
+		a, b := videos[i].UploadDate, videos[j].UploadDate
+		switch {
+		case a != nil && b != nil && !a.Equal(*b):
+			return a.Before(*b)
+		case (a == nil) != (b == nil):
+			return a != nil
+		}
+		return videos[i].ID < videos[j].ID
+	})
+
+	original := videos[0]
+	originalGrams := bigrams(normalizeTitle(original.Title))
+	group := &Group{Original: original}
+	for _, v := range videos[1:] {
+		match := &Match{
+			Video:      v,
+			Similarity: math.Round(titleSimilarity(originalGrams, bigrams(normalizeTitle(v.Title)))*100) / 100,
+		}
+		if v.Duration > 0 && original.Duration > 0 {
+			match.Difference = math.Abs(v.Duration - original.Duration)
+		}
+		group.Duplicates = append(group.Duplicates, match)
+	}
+	return group
+}
@@ -0,0 +1,165 @@
+package dedupe
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockVideoRepository is a mock implementation of VideoRepository
+type mockVideoRepository struct {
+	mock.Mock
+}
+
+func (m *mockVideoRepository) List(ctx context.Context, limit, offset int) ([]*model.Video, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Video), args.Error(1)
+}
+
+func (m *mockVideoRepository) SetDuplicateOf(ctx context.Context, id string, originalID string) error {
+	args := m.Called(ctx, id, originalID)
+	return args.Error(0)
+}
+
+func date(year int, month time.Month, day int) *time.Time {
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &d
+}
+
+func TestTitleSimilarity(t *testing.T) {
+	similarity := func(a, b string) float64 {
+		return titleSimilarity(bigrams(normalizeTitle(a)), bigrams(normalizeTitle(b)))
+	}
+
+	assert.Equal(t, 1.0, similarity("How Bees Find Flowers", "how bees find flowers!!"))
+	assert.Greater(t, similarity("How Bees Find Flowers", "【HD】How Bees Find Flowers (re-upload)"), 0.7)
+	assert.Greater(t, similarity("はじめての銭湯", "【再アップ】はじめての銭湯"), 0.7)
+	assert.Less(t, similarity("How Bees Find Flowers", "Why the Sky Is Blue"), 0.3)
+	assert.Equal(t, 0.0, similarity("", "anything"))
+}
+
+func TestFind(t *testing.T) {
+	original := &model.Video{ID: "orig", ChannelID: "UCa", Title: "Why the Sky Is Blue", Duration: 300, UploadDate: date(2024, 1, 10)}
+	mirror := &model.Video{ID: "mirror", ChannelID: "UCb", Title: "Why the sky is blue [HD]", Duration: 301.5, UploadDate: date(2024, 3, 2)}
+	undated := &model.Video{ID: "aaa", ChannelID: "UCc", Title: "WHY THE SKY IS BLUE", Duration: 299}
+	longer := &model.Video{ID: "longer", ChannelID: "UCb", Title: "Why the Sky Is Blue", Duration: 900, UploadDate: date(2024, 5, 1)}
+	other := &model.Video{ID: "other", ChannelID: "UCa", Title: "The Water Cycle", Duration: 300.5, UploadDate: date(2024, 2, 1)}
+	videos := []*model.Video{mirror, other, longer, undated, original}
+
+	t.Run("title similarity within the duration tolerance", func(t *testing.T) {
+		groups, err := Find(videos, Options{})
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+
+		// The earliest upload is the original; undated videos count as later
+		assert.Equal(t, "orig", groups[0].Original.ID)
+		require.Len(t, groups[0].Duplicates, 2)
+		assert.Equal(t, "mirror", groups[0].Duplicates[0].Video.ID)
+		assert.Equal(t, 1.5, groups[0].Duplicates[0].Difference)
+		assert.Equal(t, "aaa", groups[0].Duplicates[1].Video.ID)
+		assert.Equal(t, 1.0, groups[0].Duplicates[1].Similarity)
+	})
+
+	t.Run("wider tolerance", func(t *testing.T) {
+		groups, err := Find(videos, Options{Tolerance: 10 * time.Minute})
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Len(t, groups[0].Duplicates, 3)
+	})
+
+	t.Run("unknown durations compare by title only", func(t *testing.T) {
+		unknown := &model.Video{ID: "unknown", ChannelID: "UCd", Title: "Why the Sky is Blue?"}
+		groups, err := Find([]*model.Video{original, unknown}, Options{})
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Equal(t, 0.0, groups[0].Duplicates[0].Difference)
+	})
+
+	t.Run("duration", func(t *testing.T) {
+		groups, err := Find(videos, Options{Mode: ModeDuration})
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+
+		ids := []string{groups[0].Original.ID}
+		for _, match := range groups[0].Duplicates {
+			ids = append(ids, match.Video.ID)
+		}
+		assert.ElementsMatch(t, []string{"orig", "mirror", "aaa", "other"}, ids)
+	})
+
+	t.Run("duration groups anchor on the shortest video", func(t *testing.T) {
+		var chain []*model.Video
+		for i := 0; i < 5; i++ {
+			chain = append(chain, &model.Video{ID: fmt.Sprintf("v%d", i), Duration: 100 + 2*float64(i)})
+		}
+		groups, err := Find(chain, Options{Mode: ModeDuration})
+		require.NoError(t, err)
+		require.Len(t, groups, 2)
+		assert.Len(t, groups[0].Duplicates, 1) // v0, v1 (v2 is 4s longer than v0)
+		assert.Len(t, groups[1].Duplicates, 1) // v2, v3
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		for _, opts := range []Options{{Mode: "thumbnail"}, {Threshold: 1.5}, {Tolerance: -time.Second}} {
+			_, err := Find(videos, opts)
+			var appErr *apperrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, apperrors.CodeInvalidArg, appErr.Code)
+		}
+	})
+}
+
+func TestService_Detect(t *testing.T) {
+	page := make([]*model.Video, listPageSize)
+	for i := range page {
+		page[i] = &model.Video{ID: fmt.Sprintf("v%04d", i), Title: fmt.Sprintf("Episode %d", i), Duration: float64(60 * (i + 1))}
+	}
+	reupload := &model.Video{ID: "w-reupload", Title: "Episode 0", Duration: 60}
+
+	repo := &mockVideoRepository{}
+	repo.On("List", mock.Anything, listPageSize, 0).Return(page, nil)
+	repo.On("List", mock.Anything, listPageSize, listPageSize).Return([]*model.Video{reupload}, nil)
+
+	groups, err := NewService(repo).Detect(context.Background(), Options{})
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "w-reupload", groups[0].Duplicates[0].Video.ID)
+	repo.AssertExpectations(t)
+}
+
+func TestService_Link(t *testing.T) {
+	groups := []*Group{{
+		Original:   &model.Video{ID: "orig"},
+		Duplicates: []*Match{{Video: &model.Video{ID: "dup1"}}, {Video: &model.Video{ID: "dup2"}}},
+	}}
+
+	t.Run("links every duplicate to its original", func(t *testing.T) {
+		repo := &mockVideoRepository{}
+		repo.On("SetDuplicateOf", mock.Anything, "dup1", "orig").Return(nil)
+		repo.On("SetDuplicateOf", mock.Anything, "dup2", "orig").Return(nil)
+
+		linked, err := NewService(repo).Link(context.Background(), groups)
+		require.NoError(t, err)
+		assert.Equal(t, 2, linked)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("stops at the first failure", func(t *testing.T) {
+		repo := &mockVideoRepository{}
+		repo.On("SetDuplicateOf", mock.Anything, "dup1", "orig").Return(assert.AnError)
+
+		linked, err := NewService(repo).Link(context.Background(), groups)
+		assert.Error(t, err)
+		assert.Equal(t, 0, linked)
+	})
+}
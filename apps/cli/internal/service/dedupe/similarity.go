@@ -0,0 +1,60 @@
+package dedupe
+
+import (
+	"strings"
+	"unicode"
+)
+
+// normalizeTitle lowercases a title and reduces it to letters and digits separated by single
+// spaces, so punctuation and decoration added by mirrors ("【HD】", " - re-upload!") weigh less
+func normalizeTitle(title string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteRune(r)
+			space = false
+			continue
+		}
+		space = true
+	}
+	return b.String()
+}
+
+// bigrams counts the character pairs of a normalized title. Characters rather than words are
+// paired so titles without spaces (e.g. Japanese) compare as well as English ones.
+func bigrams(title string) map[string]int {
+	runes := []rune(title)
+	counts := make(map[string]int, len(runes))
+	if len(runes) == 1 {
+		counts[title]++
+	}
+	for i := 0; i+1 < len(runes); i++ {
+		counts[string(runes[i:i+2])]++
+	}
+	return counts
+}
+
+// titleSimilarity returns the Dice coefficient of the bigrams of two titles, from 0 (nothing in
+// common) to 1 (the same after normalization)
+func titleSimilarity(a, b map[string]int) float64 {
+	total := 0
+	for _, n := range a {
+		total += n
+	}
+	for _, n := range b {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+
+	shared := 0
+	for gram, n := range a {
+		shared += min(n, b[gram])
+	}
+	return 2 * float64(shared) / float64(total)
+}
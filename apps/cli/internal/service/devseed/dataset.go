@@ -0,0 +1,245 @@
+package devseed
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// ChannelIDPrefix starts the ID of every seeded channel, so seeded rows can be told apart from
+// synced ones and replaced on the next run
+const ChannelIDPrefix = "UCdevseed"
+
+// Seed defaults
+const (
+	DefaultChannels         = 3
+	DefaultVideosPerChannel = 8
+	DefaultSegmentsPerVideo = 40
+)
+
+// firstUpload is the upload date of every channel's first video; later ones follow weekly.
+// It is fixed so that a seed always generates the same library.
+var firstUpload = time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)
+
+// Options sizes the sample library
+type Options struct {
+	Channels         int   // Number of channels; defaults to DefaultChannels
+	VideosPerChannel int   // Videos per channel; defaults to DefaultVideosPerChannel
+	SegmentsPerVideo int   // Segments per completed transcription; defaults to DefaultSegmentsPerVideo
+	Seed             int64 // Random seed; the same seed generates the same library
+}
+
+// withDefaults fills unset options
+func (o Options) withDefaults() Options {
+	if o.Channels <= 0 {
+		o.Channels = DefaultChannels
+	}
+	if o.VideosPerChannel <= 0 {
+		o.VideosPerChannel = DefaultVideosPerChannel
+	}
+	if o.SegmentsPerVideo <= 0 {
+		o.SegmentsPerVideo = DefaultSegmentsPerVideo
+	}
+	return o
+}
+
+// Dataset is a generated sample library, ready to be written
+type Dataset struct {
+	Channels []*SeedChannel
+}
+
+// SeedChannel is a channel with its videos
+type SeedChannel struct {
+	Channel *model.Channel
+	Videos  []*SeedVideo
+}
+
+// SeedVideo is a video with its transcription, if any
+type SeedVideo struct {
+	Video         *model.Video
+	Transcription *SeedTranscription // nil for videos not transcribed yet
+}
+
+// SeedTranscription is a transcription with its segments and their translations. Translations
+// are indexed like Segments: Translations[lang][i] translates Segments[i].
+type SeedTranscription struct {
+	Transcription *model.Transcription
+	Segments      []*model.TranscriptionSegment
+	Translations  map[string][]*model.Translation
+}
+
+// channelTopics are the sample channels, cycled when more are requested; each speaks one
+// language and is translated into the other
+var channelTopics = []struct {
+	name     string
+	language string
+	titles   []string
+}{
+	{"Tokyo Street Talk", "ja", []string{"渋谷で朝ごはん", "下町の商店街を歩く", "はじめての銭湯", "駅前のラーメン屋", "雨の日の浅草"}},
+	{"Everyday English Cafe", "en", []string{"Ordering Coffee Like a Local", "Small Talk at Work", "Weekend Plans", "At the Train Station", "Cooking Dinner Together"}},
+	{"ゆっくり日本語ニュース", "ja", []string{"今週の天気まとめ", "新しい図書館がオープン", "桜の開花予想", "地元の祭りの準備", "電車の新しい路線"}},
+	{"Science in Five Minutes", "en", []string{"Why the Sky Is Blue", "How Bees Find Flowers", "The Water Cycle", "What Is a Black Hole", "Tiny Machines in Your Cells"}},
+}
+
+// phrases are the sample sentences with their translations
+var phrases = []struct{ en, ja string }{
+	{"Good morning, everyone.", "皆さん、おはようございます。"},
+	{"Today we are going somewhere special.", "今日は特別な場所に行きます。"},
+	{"It is a little cold, but the sky is clear.", "少し寒いですが、空は晴れています。"},
+	{"Let's take a look inside.", "中を見てみましょう。"},
+	{"This shop has been open for fifty years.", "このお店は五十年前から営業しています。"},
+	{"The owner recommended this dish.", "店主がこの料理をおすすめしてくれました。"},
+	{"It smells really good.", "とてもいい匂いがします。"},
+	{"How much is this one?", "これはいくらですか。"},
+	{"I didn't expect it to be so crowded.", "こんなに混んでいるとは思いませんでした。"},
+	{"Many people come here on weekends.", "週末には多くの人がここに来ます。"},
+	{"Please try it if you have the chance.", "機会があればぜひ試してみてください。"},
+	{"Next, we will take the train.", "次は電車に乗ります。"},
+	{"The station is just around the corner.", "駅はすぐそこの角にあります。"},
+	{"I learned something new today.", "今日は新しいことを学びました。"},
+	{"Thank you for watching until the end.", "最後まで見てくれてありがとうございます。"},
+	{"See you in the next video.", "また次の動画で会いましょう。"},
+}
+
+// Generate builds a deterministic sample library: most videos have a completed transcription
+// with translations (some of them approved), a few failed or were never transcribed, and some
+// videos are marked unavailable
+func Generate(opts Options) *Dataset {
+	opts = opts.withDefaults()
+	rng := rand.New(rand.NewSource(opts.Seed))
+
+	dataset := &Dataset{}
+	for c := 0; c < opts.Channels; c++ {
+		topic := channelTopics[c%len(channelTopics)]
+		channelID := fmt.Sprintf("%s%015d", ChannelIDPrefix, c+1)
+		name := topic.name
+		if c >= len(channelTopics) {
+			name = fmt.Sprintf("%s %d", topic.name, c/len(channelTopics)+1)
+		}
+
+		seedChannel := &SeedChannel{Channel: &model.Channel{
+			ID:   channelID,
+			Name: name,
+			URL:  "https://www.youtube.com/channel/" + channelID,
+		}}
+
+		for v := 0; v < opts.VideosPerChannel; v++ {
+			videoID := fmt.Sprintf("dev%02d%06d", c+1, v+1)
+			uploaded := firstUpload.AddDate(0, 0, 7*v)
+			title := topic.titles[v%len(topic.titles)]
+			if v >= len(topic.titles) {
+				title = fmt.Sprintf("%s (part %d)", title, v/len(topic.titles)+1)
+			}
+
+			video := &model.Video{
+				ID:         videoID,
+				ChannelID:  channelID,
+				Title:      title,
+				URL:        "https://www.youtube.com/watch?v=" + videoID,
+				Duration:   float64(180 + rng.Intn(1500)),
+				Status:     model.VideoStatusAvailable,
+				UploadDate: &uploaded,
+			}
+			if rng.Intn(10) == 0 {
+				video.Status = model.VideoStatusUnavailable
+			}
+
+			seedVideo := &SeedVideo{Video: video}
+			switch roll := rng.Intn(10); {
+			case roll < 7:
+				seedVideo.Transcription = completedTranscription(rng, video, topic.language, opts.SegmentsPerVideo)
+			case roll < 8:
+				seedVideo.Transcription = failedTranscription(video, topic.language)
+			}
+			seedChannel.Videos = append(seedChannel.Videos, seedVideo)
+		}
+		dataset.Channels = append(dataset.Channels, seedChannel)
+	}
+	return dataset
+}
+
+// completedTranscription transcribes video in language and translates it into the other language
+func completedTranscription(rng *rand.Rand, video *model.Video, language string, segments int) *SeedTranscription {
+	target := "en"
+	if language == "en" {
+		target = "ja"
+	}
+
+	completed := video.UploadDate.Add(36 * time.Hour)
+	detected := language
+	transcription := &SeedTranscription{
+		Transcription: &model.Transcription{
+			VideoID:          video.ID,
+			Language:         language,
+			Status:           "completed",
+			CreatedAt:        completed.Add(-10 * time.Minute),
+			CompletedAt:      &completed,
+			DetectedLanguage: &detected,
+		},
+		Translations: map[string][]*model.Translation{},
+	}
+
+	// Each segment starts on its share of the video and covers most of it, leaving pauses between
+	step := video.Duration / float64(segments)
+	approvedUpTo := rng.Intn(segments + 1) // A reviewer worked through the first segments
+	for i := 0; i < segments; i++ {
+		phrase := phrases[rng.Intn(len(phrases))]
+		text, translated := phrase.ja, phrase.en
+		if language == "en" {
+			text, translated = phrase.en, phrase.ja
+		}
+
+		start := float64(i) * step
+		end := start + step*(0.6+0.3*rng.Float64())
+		confidence := 0.75 + 0.25*rng.Float64()
+		transcription.Segments = append(transcription.Segments, &model.TranscriptionSegment{
+			SegmentIndex: i,
+			StartTime:    fmt.Sprintf("%.3f", start),
+			EndTime:      fmt.Sprintf("%.3f", end),
+			Text:         text,
+			Confidence:   &confidence,
+		})
+		transcription.Translations[target] = append(transcription.Translations[target], &model.Translation{
+			TargetLanguage: target,
+			TranslatedText: translated,
+			Source:         "plamo",
+			Approved:       i < approvedUpTo,
+		})
+	}
+	return transcription
+}
+
+// failedTranscription is a transcription whose download failed
+func failedTranscription(video *model.Video, language string) *SeedTranscription {
+	message := "failed to download audio: yt-dlp: HTTP Error 403: Forbidden"
+	return &SeedTranscription{
+		Transcription: &model.Transcription{
+			VideoID:      video.ID,
+			Language:     language,
+			Status:       "failed",
+			CreatedAt:    video.UploadDate.Add(36 * time.Hour),
+			ErrorMessage: &message,
+		},
+	}
+}
+
+// Counts returns the number of rows the dataset writes
+func (d *Dataset) Counts() *Result {
+	result := &Result{Channels: len(d.Channels)}
+	for _, channel := range d.Channels {
+		result.Videos += len(channel.Videos)
+		for _, video := range channel.Videos {
+			if video.Transcription == nil {
+				continue
+			}
+			result.Transcriptions++
+			result.Segments += len(video.Transcription.Segments)
+			for _, translations := range video.Transcription.Translations {
+				result.Translations += len(translations)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,71 @@
+package devseed
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+func TestGenerate(t *testing.T) {
+	dataset := Generate(Options{Channels: 5, VideosPerChannel: 12, SegmentsPerVideo: 10, Seed: 7})
+
+	require.Len(t, dataset.Channels, 5)
+	videoIDs := map[string]bool{}
+	var transcribed, failed, unavailable int
+	for _, channel := range dataset.Channels {
+		assert.True(t, strings.HasPrefix(channel.Channel.ID, ChannelIDPrefix))
+		assert.Len(t, channel.Channel.ID, 24, "seeded channel IDs look like YouTube's")
+		require.Len(t, channel.Videos, 12)
+
+		for _, video := range channel.Videos {
+			assert.Len(t, video.Video.ID, 11, "seeded video IDs look like YouTube's")
+			assert.False(t, videoIDs[video.Video.ID], "duplicate video ID %s", video.Video.ID)
+			videoIDs[video.Video.ID] = true
+			assert.Equal(t, channel.Channel.ID, video.Video.ChannelID)
+			if video.Video.Status == model.VideoStatusUnavailable {
+				unavailable++
+			}
+
+			if video.Transcription == nil {
+				continue
+			}
+			switch video.Transcription.Transcription.Status {
+			case "failed":
+				failed++
+				assert.NotNil(t, video.Transcription.Transcription.ErrorMessage)
+				assert.Empty(t, video.Transcription.Segments)
+			case "completed":
+				transcribed++
+				require.Len(t, video.Transcription.Segments, 10)
+				require.Len(t, video.Transcription.Translations, 1)
+				for language, translations := range video.Transcription.Translations {
+					assert.NotEqual(t, video.Transcription.Transcription.Language, language, "translated into the other language")
+					assert.Len(t, translations, 10, "one translation per segment")
+				}
+			}
+		}
+	}
+	assert.Positive(t, transcribed)
+	assert.Positive(t, failed)
+	assert.Positive(t, unavailable)
+
+	counts := dataset.Counts()
+	assert.Equal(t, 5, counts.Channels)
+	assert.Equal(t, 60, counts.Videos)
+	assert.Equal(t, transcribed+failed, counts.Transcriptions)
+	assert.Equal(t, 10*transcribed, counts.Segments)
+	assert.Equal(t, 10*transcribed, counts.Translations)
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	assert.Equal(t, Generate(Options{Seed: 3}), Generate(Options{Seed: 3}))
+	assert.NotEqual(t, Generate(Options{Seed: 3}), Generate(Options{Seed: 4}))
+
+	defaults := Generate(Options{}).Counts()
+	assert.Equal(t, DefaultChannels, defaults.Channels)
+	assert.Equal(t, DefaultChannels*DefaultVideosPerChannel, defaults.Videos)
+}
@@ -0,0 +1,183 @@
+package devseed
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/Taichi-iskw/yt-lang/internal/repository/channel"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/translation"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+)
+
+// channelPageSize is the number of channels read per page while looking for seeded ones
+const channelPageSize = 100
+
+// Beginner starts database transactions
+type Beginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// Result counts the rows written by Seed or removed by Clear
+type Result struct {
+	Channels       int `json:"channels"`
+	Videos         int `json:"videos"`
+	Transcriptions int `json:"transcriptions"`
+	Segments       int `json:"segments"`
+	Translations   int `json:"translations"`
+}
+
+// Seeder writes sample data into the active workspace
+type Seeder interface {
+	// Seed replaces earlier seeded channels with a library generated from opts
+	Seed(ctx context.Context, opts Options) (*Result, error)
+
+	// Clear removes seeded channels with everything saved under them, returning how many
+	Clear(ctx context.Context) (int, error)
+}
+
+// dbSeeder writes through the real repositories in one transaction, so a failed seed leaves
+// the database as it was
+type dbSeeder struct {
+	db Beginner
+}
+
+// NewSeeder creates a Seeder writing to db
+func NewSeeder(db Beginner) Seeder {
+	return &dbSeeder{db: db}
+}
+
+// Seed replaces earlier seeded channels with a library generated from opts
+func (s *dbSeeder) Seed(ctx context.Context, opts Options) (*Result, error) {
+	dataset := Generate(opts)
+
+	err := s.inTx(ctx, func(pool txPool) error {
+		if _, err := clearSeeded(ctx, pool); err != nil {
+			return err
+		}
+
+		channelRepo := channel.NewRepository(pool)
+		videoRepo := video.NewRepository(pool)
+		transcriptionRepo := transcription.NewRepository(pool)
+		segmentRepo := transcription.NewSegmentRepository(pool)
+		translationRepo := translation.NewRepository(pool)
+
+		for _, seedChannel := range dataset.Channels {
+			if err := channelRepo.Create(ctx, seedChannel.Channel); err != nil {
+				return fmt.Errorf("failed to create channel %s: %w", seedChannel.Channel.ID, err)
+			}
+
+			for _, seedVideo := range seedChannel.Videos {
+				if err := videoRepo.Create(ctx, seedVideo.Video); err != nil {
+					return fmt.Errorf("failed to create video %s: %w", seedVideo.Video.ID, err)
+				}
+
+				seedTranscription := seedVideo.Transcription
+				if seedTranscription == nil {
+					continue
+				}
+				if err := transcriptionRepo.Create(ctx, seedTranscription.Transcription); err != nil {
+					return fmt.Errorf("failed to create transcription of %s: %w", seedVideo.Video.ID, err)
+				}
+				if len(seedTranscription.Segments) == 0 {
+					continue
+				}
+
+				for _, segment := range seedTranscription.Segments {
+					segment.TranscriptionID = seedTranscription.Transcription.ID
+				}
+				if err := segmentRepo.CreateBatch(ctx, seedTranscription.Segments); err != nil {
+					return fmt.Errorf("failed to create segments of %s: %w", seedVideo.Video.ID, err)
+				}
+
+				// COPY does not return IDs; read the segments back to point translations at them
+				stored, err := segmentRepo.GetByTranscriptionID(ctx, seedTranscription.Transcription.ID)
+				if err != nil {
+					return err
+				}
+				for _, translations := range seedTranscription.Translations {
+					for i, t := range translations {
+						t.TranscriptionSegmentID = stored[i].ID
+					}
+					if err := translationRepo.CreateBatch(ctx, translations); err != nil {
+						return fmt.Errorf("failed to create translations of %s: %w", seedVideo.Video.ID, err)
+					}
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dataset.Counts(), nil
+}
+
+// Clear removes seeded channels with everything saved under them
+func (s *dbSeeder) Clear(ctx context.Context) (int, error) {
+	var removed int
+	err := s.inTx(ctx, func(pool txPool) error {
+		var err error
+		removed, err = clearSeeded(ctx, pool)
+		return err
+	})
+	return removed, err
+}
+
+// inTx runs fn in a transaction committed when fn succeeds
+func (s *dbSeeder) inTx(ctx context.Context, fn func(pool txPool) error) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(txPool{tx}); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit seed data: %w", err)
+	}
+	return nil
+}
+
+// clearSeeded deletes channels with ChannelIDPrefix; their videos, transcriptions, segments and
+// translations go with them
+func clearSeeded(ctx context.Context, pool txPool) (int, error) {
+	channelRepo := channel.NewRepository(pool)
+
+	var seeded []string
+	for offset := 0; ; offset += channelPageSize {
+		channels, err := channelRepo.List(ctx, channelPageSize, offset)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list channels: %w", err)
+		}
+		for _, c := range channels {
+			if strings.HasPrefix(c.ID, ChannelIDPrefix) {
+				seeded = append(seeded, c.ID)
+			}
+		}
+		if len(channels) < channelPageSize {
+			break
+		}
+	}
+
+	// Deleted after listing so the pages don't shift underneath
+	for _, id := range seeded {
+		if err := channelRepo.Delete(ctx, id); err != nil {
+			return 0, fmt.Errorf("failed to delete seeded channel %s: %w", id, err)
+		}
+	}
+	return len(seeded), nil
+}
+
+// txPool adapts a transaction to the repositories' Pool interface
+type txPool struct {
+	pgx.Tx
+}
+
+// Close is a no-op; the transaction is ended by inTx
+func (txPool) Close() {}
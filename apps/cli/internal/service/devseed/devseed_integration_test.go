@@ -0,0 +1,42 @@
+//go:build integration
+
+package devseed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Taichi-iskw/yt-lang/internal/repository/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSeeder_Integration checks seeding twice replaces the first run and clear removes everything
+func TestSeeder_Integration(t *testing.T) {
+	pool := common.SetupTestDB(t)
+	ctx := context.Background()
+	seeder := NewSeeder(pool)
+
+	opts := Options{Channels: 2, VideosPerChannel: 4, SegmentsPerVideo: 5, Seed: 1}
+	_, err := seeder.Seed(ctx, opts)
+	require.NoError(t, err)
+	result, err := seeder.Seed(ctx, opts)
+	require.NoError(t, err)
+
+	count := func(sql string) int {
+		var n int
+		require.NoError(t, pool.QueryRow(ctx, sql).Scan(&n))
+		return n
+	}
+	assert.Equal(t, result.Channels, count("SELECT COUNT(*) FROM channels"))
+	assert.Equal(t, result.Videos, count("SELECT COUNT(*) FROM videos"))
+	assert.Equal(t, result.Transcriptions, count("SELECT COUNT(*) FROM transcriptions"))
+	assert.Equal(t, result.Segments, count("SELECT COUNT(*) FROM transcription_segments"))
+	assert.Equal(t, result.Translations, count("SELECT COUNT(*) FROM translations"))
+
+	removed, err := seeder.Clear(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	assert.Zero(t, count("SELECT COUNT(*) FROM videos"))
+	assert.Zero(t, count("SELECT COUNT(*) FROM translations"))
+}
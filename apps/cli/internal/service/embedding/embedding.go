@@ -0,0 +1,114 @@
+// Package embedding computes embeddings of transcription segments and finds segments by meaning:
+// search semantic returns the segments whose embeddings are nearest to the query's, complementing
+// the keyword search of transcription get --grep.
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/embedding"
+)
+
+const (
+	defaultBatchSize   = 100
+	defaultSearchLimit = 10
+)
+
+// Provider computes embedding vectors of texts
+type Provider interface {
+	// Model names the embedding model; vectors of different models are not comparable
+	Model() string
+	// Embed returns the vector of each text, in order
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// Service indexes and searches segment embeddings
+type Service interface {
+	// Index computes the embeddings of segments that have none of the provider's model yet, of one
+	// transcription when transcriptionID is set, calling progress with the running total after
+	// each batch. It returns the number of segments embedded.
+	Index(ctx context.Context, transcriptionID string, progress func(indexed int)) (int, error)
+	// Search returns the segments closest in meaning to query, closest first
+	Search(ctx context.Context, query string, opts SearchOptions) ([]*model.SegmentMatch, error)
+}
+
+// SearchOptions narrows a semantic search
+type SearchOptions struct {
+	Language string // Only segments of transcriptions in this language; empty for any
+	Limit    int    // Number of segments returned; defaults to 10
+}
+
+// embeddingService implements Service
+type embeddingService struct {
+	repo      embedding.Repository
+	provider  Provider
+	batchSize int
+}
+
+// NewService creates a Service embedding batchSize segments per provider request (100 when 0)
+func NewService(repo embedding.Repository, provider Provider, batchSize int) Service {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return &embeddingService{repo: repo, provider: provider, batchSize: batchSize}
+}
+
+// Index embeds pending segments batch by batch until none are left. Stored batches stay stored
+// when a later one fails, so an interrupted index continues where it stopped.
+func (s *embeddingService) Index(ctx context.Context, transcriptionID string, progress func(indexed int)) (int, error) {
+	indexed := 0
+	for {
+		segments, err := s.repo.Pending(ctx, s.provider.Model(), transcriptionID, s.batchSize)
+		if err != nil {
+			return indexed, err
+		}
+		if len(segments) == 0 {
+			return indexed, nil
+		}
+
+		ids := make([]string, len(segments))
+		texts := make([]string, len(segments))
+		for i, segment := range segments {
+			ids[i], texts[i] = segment.ID, segment.Text
+		}
+		vectors, err := s.provider.Embed(ctx, texts)
+		if err != nil {
+			return indexed, errors.Wrap(err, errors.CodeExternal, "failed to compute embeddings")
+		}
+		if len(vectors) != len(texts) {
+			return indexed, errors.New(errors.CodeExternal, fmt.Sprintf("embedding provider returned %d vectors for %d segments", len(vectors), len(texts)))
+		}
+		if err := s.repo.Store(ctx, s.provider.Model(), ids, vectors); err != nil {
+			return indexed, err
+		}
+
+		indexed += len(segments)
+		if progress != nil {
+			progress(indexed)
+		}
+	}
+}
+
+// Search embeds the query with the provider's model and looks up its nearest segments
+func (s *embeddingService) Search(ctx context.Context, query string, opts SearchOptions) ([]*model.SegmentMatch, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New(errors.CodeInvalidArg, "search query is empty")
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = defaultSearchLimit
+	}
+
+	vectors, err := s.provider.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeExternal, "failed to compute query embedding")
+	}
+	if len(vectors) != 1 {
+		return nil, errors.New(errors.CodeExternal, fmt.Sprintf("embedding provider returned %d vectors for the query", len(vectors)))
+	}
+	return s.repo.Nearest(ctx, s.provider.Model(), vectors[0], opts.Language, opts.Limit)
+}
@@ -0,0 +1,195 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// fakeRepository keeps embeddings in memory
+type fakeRepository struct {
+	segments []*model.TranscriptionSegment
+	stored   map[string][]float32
+	models   map[string]string
+
+	nearestArgs []any
+}
+
+func newFakeRepository(texts ...string) *fakeRepository {
+	repo := &fakeRepository{stored: map[string][]float32{}, models: map[string]string{}}
+	for i, text := range texts {
+		repo.segments = append(repo.segments, &model.TranscriptionSegment{ID: fmt.Sprintf("seg-%d", i), TranscriptionID: "trans-1", SegmentIndex: i, Text: text})
+	}
+	return repo
+}
+
+func (r *fakeRepository) Pending(ctx context.Context, embeddingModel, transcriptionID string, limit int) ([]*model.TranscriptionSegment, error) {
+	var pending []*model.TranscriptionSegment
+	for _, segment := range r.segments {
+		if r.models[segment.ID] != embeddingModel && len(pending) < limit {
+			pending = append(pending, segment)
+		}
+	}
+	return pending, nil
+}
+
+func (r *fakeRepository) Store(ctx context.Context, embeddingModel string, segmentIDs []string, vectors [][]float32) error {
+	for i, id := range segmentIDs {
+		r.stored[id], r.models[id] = vectors[i], embeddingModel
+	}
+	return nil
+}
+
+func (r *fakeRepository) Nearest(ctx context.Context, embeddingModel string, vector []float32, language string, limit int) ([]*model.SegmentMatch, error) {
+	r.nearestArgs = []any{embeddingModel, vector, language, limit}
+	return []*model.SegmentMatch{{Segment: *r.segments[0], Distance: 0.1}}, nil
+}
+
+// fakeProvider embeds each text as its length
+type fakeProvider struct {
+	requests [][]string
+	err      error
+}
+
+func (p *fakeProvider) Model() string { return "fake-model" }
+
+func (p *fakeProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	p.requests = append(p.requests, texts)
+	if p.err != nil {
+		return nil, p.err
+	}
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = []float32{float32(len(text))}
+	}
+	return vectors, nil
+}
+
+func TestEmbeddingService_Index(t *testing.T) {
+	repo := newFakeRepository("uno", "dos", "tres", "cuatro", "cinco")
+	provider := &fakeProvider{}
+	service := NewService(repo, provider, 2)
+
+	var progress []int
+	indexed, err := service.Index(context.Background(), "", func(n int) { progress = append(progress, n) })
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, indexed)
+	assert.Equal(t, []int{2, 4, 5}, progress)
+	assert.Equal(t, [][]string{{"uno", "dos"}, {"tres", "cuatro"}, {"cinco"}}, provider.requests)
+	assert.Equal(t, []float32{6}, repo.stored["seg-3"])
+	assert.Equal(t, "fake-model", repo.models["seg-3"])
+
+	// Everything is embedded; indexing again embeds nothing
+	indexed, err = service.Index(context.Background(), "", nil)
+	require.NoError(t, err)
+	assert.Zero(t, indexed)
+	assert.Len(t, provider.requests, 3)
+}
+
+func TestEmbeddingService_Index_ProviderError(t *testing.T) {
+	repo := newFakeRepository("uno")
+	service := NewService(repo, &fakeProvider{err: assert.AnError}, 0)
+
+	_, err := service.Index(context.Background(), "", nil)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, apperrors.CodeExternal, appErr.Code)
+	assert.Empty(t, repo.stored)
+}
+
+func TestEmbeddingService_Search(t *testing.T) {
+	repo := newFakeRepository("Ayer comí paella")
+	provider := &fakeProvider{}
+	service := NewService(repo, provider, 0)
+
+	matches, err := service.Search(context.Background(), "  how to conjugate past tense ", SearchOptions{Language: "es"})
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, [][]string{{"how to conjugate past tense"}}, provider.requests)
+	assert.Equal(t, []any{"fake-model", []float32{27}, "es", defaultSearchLimit}, repo.nearestArgs)
+
+	_, err = service.Search(context.Background(), " ", SearchOptions{})
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, apperrors.CodeInvalidArg, appErr.Code)
+}
+
+func TestOpenAIProvider_Embed(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/embeddings", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		// Out of order on purpose
+		fmt.Fprint(w, `{"data": [{"index": 1, "embedding": [0.3, 0.4]}, {"index": 0, "embedding": [0.1, 0.2]}]}`)
+	}))
+	defer server.Close()
+
+	provider, err := NewProvider(ProviderOptions{BaseURL: server.URL + "/v1/", Model: "text-embedding-3-small", APIKey: "secret"})
+	require.NoError(t, err)
+	provider.(*openAIProvider).client = server.Client()
+
+	vectors, err := provider.Embed(context.Background(), []string{"hola", "adiós"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{0.1, 0.2}, {0.3, 0.4}}, vectors)
+	assert.JSONEq(t, `{"model": "text-embedding-3-small", "input": ["hola", "adiós"]}`, body)
+	assert.Equal(t, "text-embedding-3-small", provider.Model())
+}
+
+func TestOpenAIProvider_Errors(t *testing.T) {
+	_, err := NewProvider(ProviderOptions{})
+	assert.ErrorContains(t, err, "model is required")
+	_, err = NewProvider(ProviderOptions{Provider: "cohere", Model: "m"})
+	assert.ErrorContains(t, err, "unsupported embedding provider: cohere")
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		wantErr string
+	}{
+		{
+			name: "server error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "model not found", http.StatusNotFound)
+			},
+			wantErr: "model not found",
+		},
+		{
+			name: "missing vector",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"data": [{"index": 0, "embedding": [0.1]}]}`)
+			},
+			wantErr: "no vector for input 1",
+		},
+		{
+			name: "index out of range",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"data": [{"index": 5, "embedding": [0.1]}]}`)
+			},
+			wantErr: "entry for input 5 of 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			provider, err := NewOpenAIProviderWithClient(ProviderOptions{BaseURL: server.URL, Model: "m"}, server.Client())
+			require.NoError(t, err)
+			_, err = provider.Embed(context.Background(), []string{"a", "b"})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
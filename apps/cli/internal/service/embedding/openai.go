@@ -0,0 +1,131 @@
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/offline"
+)
+
+// Provider names accepted by NewProvider
+const (
+	ProviderOpenAI = "openai"
+)
+
+const defaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// ProviderOptions configures an embedding provider
+type ProviderOptions struct {
+	Provider string // openai (default): an OpenAI-compatible embeddings API
+	BaseURL  string // e.g. https://api.openai.com/v1 or a local server; defaults to the OpenAI API
+	Model    string
+	APIKey   string // optional for local servers
+}
+
+// NewProvider creates the provider named by opts.Provider
+func NewProvider(opts ProviderOptions) (Provider, error) {
+	switch opts.Provider {
+	case "", ProviderOpenAI:
+		return NewOpenAIProvider(opts)
+	default:
+		return nil, fmt.Errorf("unsupported embedding provider: %s (supported: %s)", opts.Provider, ProviderOpenAI)
+	}
+}
+
+// openAIProvider computes embeddings with an OpenAI-compatible embeddings API
+type openAIProvider struct {
+	opts   ProviderOptions
+	client *http.Client
+}
+
+// NewOpenAIProvider creates a provider backed by an OpenAI-compatible embeddings API
+func NewOpenAIProvider(opts ProviderOptions) (Provider, error) {
+	return NewOpenAIProviderWithClient(opts, &http.Client{Timeout: 2 * time.Minute})
+}
+
+// NewOpenAIProviderWithClient creates a provider using client (for testing)
+func NewOpenAIProviderWithClient(opts ProviderOptions, client *http.Client) (Provider, error) {
+	if opts.Model == "" {
+		return nil, errors.New("embedding model is required")
+	}
+	if opts.BaseURL == "" {
+		opts.BaseURL = defaultOpenAIBaseURL
+	}
+	opts.BaseURL = strings.TrimRight(opts.BaseURL, "/")
+	return &openAIProvider{opts: opts, client: client}, nil
+}
+
+type embeddingsRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type embeddingsResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Model returns the configured model
+func (p *openAIProvider) Model() string {
+	return p.opts.Model
+}
+
+// Embed sends the texts in one request and returns the vectors in input order
+func (p *openAIProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if err := offline.Check("computing embeddings with " + p.opts.Model); err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(embeddingsRequest{Model: p.opts.Model, Input: texts})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.opts.BaseURL+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build embeddings request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.opts.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.opts.APIKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embeddings request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("embeddings request failed: %s %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+
+	var result embeddingsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse embeddings response: %w", err)
+	}
+
+	// Entries carry the index of their input; servers need not return them in order
+	vectors := make([][]float32, len(texts))
+	for _, entry := range result.Data {
+		if entry.Index < 0 || entry.Index >= len(texts) {
+			return nil, fmt.Errorf("embeddings response has an entry for input %d of %d", entry.Index, len(texts))
+		}
+		vectors[entry.Index] = entry.Embedding
+	}
+	for i, vector := range vectors {
+		if len(vector) == 0 {
+			return nil, fmt.Errorf("embeddings response has no vector for input %d", i)
+		}
+	}
+	return vectors, nil
+}
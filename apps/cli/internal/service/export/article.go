@@ -0,0 +1,196 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
+)
+
+// FormatArticle renders a transcription as readable prose: segments are re-chunked into
+// sentences, and sentences into paragraphs
+const FormatArticle = "article"
+
+// Inline timestamps of article exports (export transcripts --timestamps)
+const (
+	TimestampsNone      = "none"
+	TimestampsParagraph = "paragraph" // [12:34] at the start of each paragraph
+	TimestampsSentence  = "sentence"  // [12:34] before each sentence
+)
+
+const (
+	paragraphGap          = 2 * time.Second // A pause this long between segments starts a new paragraph
+	maxParagraphSentences = 5               // Longer paragraphs are split to stay readable
+)
+
+// articleSentence is a sentence re-chunked from segments, with the start of the segment it begins in
+type articleSentence struct {
+	start time.Duration
+	text  string
+}
+
+// articleFormatter renders transcripts as paragraphs of sentences with optional timestamps
+type articleFormatter struct {
+	timestamps string
+}
+
+func (f articleFormatter) format(_ *model.Transcription, segments []*model.TranscriptionSegment) ([]byte, error) {
+	paragraphs, err := articleParagraphs(segments)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	for i, paragraph := range paragraphs {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		var line string
+		for j, sentence := range paragraph {
+			text := sentence.text
+			if f.timestamps == TimestampsSentence || (f.timestamps == TimestampsParagraph && j == 0) {
+				text = articleTimestamp(sentence.start) + " " + text
+			}
+			line = joinText(line, text)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return []byte(b.String()), nil
+}
+
+func (articleFormatter) extension() string { return "md" }
+
+// articleParagraphs re-chunks segments into sentences, which Whisper segments often split or
+// run together, and groups the sentences into paragraphs at pauses in speech
+func articleParagraphs(segments []*model.TranscriptionSegment) ([][]articleSentence, error) {
+	var paragraphs [][]articleSentence
+	var paragraph []articleSentence
+	var open *articleSentence // Sentence continued by the next segment
+	var previousEnd time.Duration
+
+	closeParagraph := func() {
+		if len(paragraph) > 0 {
+			paragraphs = append(paragraphs, paragraph)
+			paragraph = nil
+		}
+	}
+
+	for i, segment := range segments {
+		start, err := timecode.ParseInterval(segment.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("segment %d: %w", segment.SegmentIndex, err)
+		}
+		end, err := timecode.ParseInterval(segment.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("segment %d: %w", segment.SegmentIndex, err)
+		}
+		if i > 0 && open == nil && start-previousEnd >= paragraphGap {
+			closeParagraph()
+		}
+		previousEnd = end
+
+		for _, piece := range sentencePieces(segment.Text) {
+			if open == nil {
+				open = &articleSentence{start: start}
+			}
+			open.text = joinText(open.text, piece)
+			if !endsSentence(piece) {
+				continue
+			}
+			paragraph = append(paragraph, *open)
+			open = nil
+			if len(paragraph) >= maxParagraphSentences {
+				closeParagraph()
+			}
+		}
+	}
+
+	if open != nil {
+		paragraph = append(paragraph, *open)
+	}
+	closeParagraph()
+	return paragraphs, nil
+}
+
+// sentencePieces splits text after each sentence end, so only the last piece may continue
+// into the next segment
+func sentencePieces(text string) []string {
+	runes := []rune(strings.TrimSpace(text))
+	var pieces []string
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		if !isSentenceEnd(runes[i]) {
+			continue
+		}
+		end := i + 1
+		for end < len(runes) && (isSentenceEnd(runes[end]) || isClosingQuote(runes[end])) {
+			end++
+		}
+		// Decimal points and abbreviations like "e.g." are followed by no space
+		if end < len(runes) && !unicode.IsSpace(runes[end]) && !isFullWidthSentenceEnd(runes[i]) {
+			continue
+		}
+		if piece := strings.TrimSpace(string(runes[start:end])); piece != "" {
+			pieces = append(pieces, piece)
+		}
+		start = end
+		i = end - 1
+	}
+	if piece := strings.TrimSpace(string(runes[start:])); piece != "" {
+		pieces = append(pieces, piece)
+	}
+	return pieces
+}
+
+// endsSentence reports whether a piece ends with sentence punctuation, possibly in quotes
+func endsSentence(piece string) bool {
+	piece = strings.TrimRightFunc(piece, isClosingQuote)
+	r := []rune(piece)
+	return len(r) > 0 && isSentenceEnd(r[len(r)-1])
+}
+
+// joinText joins two pieces of text with a space, except between CJK characters, which are
+// written without spaces
+func joinText(a, b string) string {
+	if a == "" || b == "" {
+		return b
+	}
+	last := []rune(a)[len([]rune(a))-1]
+	first := []rune(b)[0]
+	if isCJK(last) || isCJK(first) {
+		return a + b
+	}
+	return a + " " + b
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) || isFullWidthSentenceEnd(r) || strings.ContainsRune("、「」『』", r)
+}
+
+func isSentenceEnd(r rune) bool {
+	return strings.ContainsRune(".!?…", r) || isFullWidthSentenceEnd(r)
+}
+
+func isFullWidthSentenceEnd(r rune) bool {
+	return strings.ContainsRune("。！？", r)
+}
+
+func isClosingQuote(r rune) bool {
+	return strings.ContainsRune(`"'”’」』)）]`, r)
+}
+
+// articleTimestamp formats a time as [MM:SS], or [H:MM:SS] from the first hour on
+func articleTimestamp(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	hours := d / time.Hour
+	minutes := (d % time.Hour) / time.Minute
+	seconds := (d % time.Minute) / time.Second
+	if hours > 0 {
+		return fmt.Sprintf("[%d:%02d:%02d]", hours, minutes, seconds)
+	}
+	return fmt.Sprintf("[%02d:%02d]", minutes, seconds)
+}
@@ -0,0 +1,61 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/subtitle"
+)
+
+func TestArticleFormatter(t *testing.T) {
+	segments := []*model.TranscriptionSegment{
+		{SegmentIndex: 0, StartTime: "00:00:01", EndTime: "00:00:04", Text: "Welcome back. Today we"},
+		{SegmentIndex: 1, StartTime: "00:00:04", EndTime: "00:00:07", Text: "cook paella, which costs 3.50 euros."},
+		// Long pauses start new paragraphs
+		{SegmentIndex: 2, StartTime: "00:12:30", EndTime: "00:12:33", Text: "First, the rice!"},
+		{SegmentIndex: 3, StartTime: "01:02:03", EndTime: "01:02:05", Text: "Done"},
+	}
+
+	tests := []struct {
+		timestamps string
+		want       string
+	}{
+		{TimestampsNone, "Welcome back. Today we cook paella, which costs 3.50 euros.\n\nFirst, the rice!\n\nDone\n"},
+		{TimestampsParagraph, "[00:01] Welcome back. Today we cook paella, which costs 3.50 euros.\n\n[12:30] First, the rice!\n\n[1:02:03] Done\n"},
+		{TimestampsSentence, "[00:01] Welcome back. [00:01] Today we cook paella, which costs 3.50 euros.\n\n[12:30] First, the rice!\n\n[1:02:03] Done\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.timestamps, func(t *testing.T) {
+			formatter, err := getTranscriptFormatter(FormatArticle, subtitle.Rules{}, subtitle.VTTOptions{}, tt.timestamps)
+			require.NoError(t, err)
+			assert.Equal(t, "md", formatter.extension())
+
+			content, err := formatter.format(nil, segments)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(content))
+		})
+	}
+
+	_, err := getTranscriptFormatter(FormatArticle, subtitle.Rules{}, subtitle.VTTOptions{}, "word")
+	assert.ErrorContains(t, err, "unsupported timestamps")
+}
+
+func TestArticleParagraphs(t *testing.T) {
+	// Japanese sentences are joined without spaces, and long paragraphs are split
+	var segments []*model.TranscriptionSegment
+	for i := 0; i < maxParagraphSentences+2; i++ {
+		segments = append(segments, &model.TranscriptionSegment{SegmentIndex: i, StartTime: "00:00:01", EndTime: "00:00:02", Text: "今日は。"})
+	}
+	segments[0].Text = "今日は"
+	segments = append([]*model.TranscriptionSegment{{StartTime: "00:00:00", EndTime: "00:00:01", Text: "ええと、"}}, segments...)
+
+	paragraphs, err := articleParagraphs(segments)
+	require.NoError(t, err)
+	require.Len(t, paragraphs, 2)
+	assert.Equal(t, "ええと、今日は今日は。", paragraphs[0][0].text)
+	assert.Len(t, paragraphs[0], maxParagraphSentences)
+	assert.Len(t, paragraphs[1], 1)
+}
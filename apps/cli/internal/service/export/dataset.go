@@ -0,0 +1,244 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+const (
+	translationPageSize = 500 // Translations fetched per repository call while building pairs
+
+	DatasetFormatJSONL  = "jsonl"  // One {source, target, ...} object per line
+	DatasetFormatOpenAI = "openai" // One chat fine-tuning example ({messages: [...]}) per line
+)
+
+// TranslationRepository interface for accessing segment translations
+type TranslationRepository interface {
+	ListByTranscriptionID(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error)
+}
+
+// DatasetExportOptions configures a parallel-text dataset export
+type DatasetExportOptions struct {
+	ChannelID      string // Channel whose videos are exported
+	Format         string // Output format: jsonl, openai
+	SourceLanguage string // Spoken language of the transcriptions used as source side
+	TargetLanguage string // Translation language used as target side
+
+	MinConfidence  *float64 // Optional minimum segment confidence (whisper avg_logprob); segments without one are dropped
+	MinLengthRatio float64  // Optional minimum target/source character ratio (0 disables)
+	MaxLengthRatio float64  // Optional maximum target/source character ratio (0 disables)
+	ApprovedOnly   bool     // Only use translations approved in translation interactive
+	Style          string   // Translation style variant (translation create --style); empty for plain translations
+
+	IncludeUnavailable bool // Also export videos marked unavailable by video verify
+}
+
+// DatasetResult summarizes a dataset export
+type DatasetResult struct {
+	Pairs           int `json:"pairs"`              // Pairs written
+	Untranslated    int `json:"untranslated"`       // Segments without a usable translation
+	LowConfidence   int `json:"low_confidence"`     // Pairs dropped by MinConfidence
+	RatioOutOfRange int `json:"ratio_out_of_range"` // Pairs dropped by the length ratio bounds
+}
+
+// DatasetPair is one aligned source/target sentence pair in jsonl output
+type DatasetPair struct {
+	Source         string `json:"source"`
+	Target         string `json:"target"`
+	SourceLanguage string `json:"source_lang"`
+	TargetLanguage string `json:"target_lang"`
+	VideoID        string `json:"video_id"`
+	SegmentIndex   int    `json:"segment_index"`
+}
+
+// chatExample is one OpenAI chat fine-tuning example
+type chatExample struct {
+	Messages []chatMessage `json:"messages"`
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// NewExportServiceWithTranslations creates an export service that can also export translation datasets
+func NewExportServiceWithTranslations(videoRepo VideoRepository, transcriptionRepo TranscriptionRepository, segmentRepo SegmentRepository, translationRepo TranslationRepository) ExportService {
+	return &exportService{
+		videoRepo:         videoRepo,
+		transcriptionRepo: transcriptionRepo,
+		segmentRepo:       segmentRepo,
+		translationRepo:   translationRepo,
+	}
+}
+
+// ExportDataset writes the aligned transcription/translation pairs of a channel's videos to w
+func (s *exportService) ExportDataset(ctx context.Context, opts DatasetExportOptions, w io.Writer) (*DatasetResult, error) {
+	if opts.ChannelID == "" {
+		return nil, errors.New(errors.CodeInvalidArg, "channel ID is required")
+	}
+	if opts.SourceLanguage == "" || opts.TargetLanguage == "" {
+		return nil, errors.New(errors.CodeInvalidArg, "source and target languages are required")
+	}
+	if opts.Format != DatasetFormatJSONL && opts.Format != DatasetFormatOpenAI {
+		return nil, errors.New(errors.CodeInvalidArg, fmt.Sprintf("unsupported dataset format: %s (use jsonl or openai)", opts.Format))
+	}
+	if opts.MinLengthRatio < 0 || opts.MaxLengthRatio < 0 || (opts.MaxLengthRatio > 0 && opts.MinLengthRatio > opts.MaxLengthRatio) {
+		return nil, errors.New(errors.CodeInvalidArg, "invalid length ratio bounds")
+	}
+	if s.translationRepo == nil {
+		return nil, errors.New(errors.CodeInternal, "translation repository is not configured")
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	result := &DatasetResult{}
+
+	for offset := 0; ; offset += videoPageSize {
+		videos, err := s.videoRepo.GetByChannelID(ctx, opts.ChannelID, videoPageSize, offset)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, "failed to list channel videos")
+		}
+
+		for _, video := range videos {
+			if !video.IsAvailable() && !opts.IncludeUnavailable {
+				continue
+			}
+			if err := s.exportVideoPairs(ctx, video, opts, encoder, result); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(videos) < videoPageSize {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// exportVideoPairs writes the pairs of every completed transcription of a video in the source language
+func (s *exportService) exportVideoPairs(ctx context.Context, video *model.Video, opts DatasetExportOptions, encoder *json.Encoder, result *DatasetResult) error {
+	transcriptions, err := s.transcriptionRepo.GetByVideoID(ctx, video.ID)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to list transcriptions for video %s", video.ID))
+	}
+
+	for _, t := range transcriptions {
+		if t.Status != "completed" || spokenLanguage(t) != opts.SourceLanguage {
+			continue
+		}
+
+		segments, err := s.segmentRepo.GetByTranscriptionID(ctx, t.ID)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to get segments for transcription %s", t.ID))
+		}
+		translations, err := s.segmentTranslations(ctx, t.ID, opts.TargetLanguage, opts.Style, opts.ApprovedOnly)
+		if err != nil {
+			return err
+		}
+
+		for _, segment := range segments {
+			source := strings.TrimSpace(segment.Text)
+			target := strings.TrimSpace(translations[segment.ID])
+			if source == "" || target == "" {
+				result.Untranslated++
+				continue
+			}
+			if opts.MinConfidence != nil && (segment.Confidence == nil || *segment.Confidence < *opts.MinConfidence) {
+				result.LowConfidence++
+				continue
+			}
+			if !lengthRatioInRange(source, target, opts) {
+				result.RatioOutOfRange++
+				continue
+			}
+
+			if err := encoder.Encode(datasetRecord(opts, &DatasetPair{
+				Source:         source,
+				Target:         target,
+				SourceLanguage: opts.SourceLanguage,
+				TargetLanguage: opts.TargetLanguage,
+				VideoID:        video.ID,
+				SegmentIndex:   segment.SegmentIndex,
+			})); err != nil {
+				return errors.Wrap(err, errors.CodeInternal, "failed to write dataset")
+			}
+			result.Pairs++
+		}
+	}
+
+	return nil
+}
+
+// segmentTranslations maps segment IDs to their translation in targetLanguage and style (empty
+// for plain translations). An approved translation wins over newer unreviewed ones; otherwise the
+// newest is used.
+func (s *exportService) segmentTranslations(ctx context.Context, transcriptionID, targetLanguage, style string, approvedOnly bool) (map[string]string, error) {
+	chosen := make(map[string]*model.Translation)
+
+	for offset := 0; ; offset += translationPageSize {
+		translations, err := s.translationRepo.ListByTranscriptionID(ctx, transcriptionID, translationPageSize, offset)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, "failed to list translations")
+		}
+
+		for _, t := range translations {
+			if t.TargetLanguage != targetLanguage || t.Style != style || (approvedOnly && !t.Approved) {
+				continue
+			}
+			// Rows are ordered newest first per segment
+			if current, exists := chosen[t.TranscriptionSegmentID]; !exists || (t.Approved && !current.Approved) {
+				chosen[t.TranscriptionSegmentID] = t
+			}
+		}
+
+		if len(translations) < translationPageSize {
+			break
+		}
+	}
+
+	texts := make(map[string]string, len(chosen))
+	for segmentID, t := range chosen {
+		texts[segmentID] = t.TranslatedText
+	}
+	return texts, nil
+}
+
+// lengthRatioInRange reports whether the target/source character ratio is within the configured bounds
+func lengthRatioInRange(source, target string, opts DatasetExportOptions) bool {
+	ratio := float64(utf8.RuneCountInString(target)) / float64(utf8.RuneCountInString(source))
+	if opts.MinLengthRatio > 0 && ratio < opts.MinLengthRatio {
+		return false
+	}
+	if opts.MaxLengthRatio > 0 && ratio > opts.MaxLengthRatio {
+		return false
+	}
+	return true
+}
+
+// datasetRecord shapes a pair for the output format
+func datasetRecord(opts DatasetExportOptions, pair *DatasetPair) any {
+	if opts.Format == DatasetFormatOpenAI {
+		return &chatExample{Messages: []chatMessage{
+			{Role: "system", Content: fmt.Sprintf("Translate from %s to %s.", pair.SourceLanguage, pair.TargetLanguage)},
+			{Role: "user", Content: pair.Source},
+			{Role: "assistant", Content: pair.Target},
+		}}
+	}
+	return pair
+}
+
+// spokenLanguage returns the detected language of a transcription, falling back to the requested one
+func spokenLanguage(t *model.Transcription) string {
+	if t.DetectedLanguage != nil && *t.DetectedLanguage != "" {
+		return *t.DetectedLanguage
+	}
+	return t.Language
+}
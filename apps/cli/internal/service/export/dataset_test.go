@@ -0,0 +1,154 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTranslationRepo mocks TranslationRepository
+type mockTranslationRepo struct {
+	byTranscription map[string][]*model.Translation
+}
+
+func (m *mockTranslationRepo) ListByTranscriptionID(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error) {
+	all := m.byTranscription[transcriptionID]
+	if offset >= len(all) {
+		return []*model.Translation{}, nil
+	}
+	return all[offset:min(offset+limit, len(all))], nil
+}
+
+func newTestDatasetService() ExportService {
+	high, low := -0.2, -1.5
+	english := "en"
+	videoRepo := &mockVideoRepo{videos: []*model.Video{
+		{ID: "vid1", ChannelID: "UC123", Title: "Lesson"},
+		{ID: "vid2", ChannelID: "UC123", Title: "Japanese talk"},
+	}}
+	transcriptionRepo := &mockTranscriptionRepo{byVideo: map[string][]*model.Transcription{
+		"vid1": {{ID: "t1", VideoID: "vid1", Language: "auto", Status: "completed", DetectedLanguage: &english}},
+		"vid2": {{ID: "t2", VideoID: "vid2", Language: "ja", Status: "completed"}},
+	}}
+	segmentRepo := &mockSegmentRepo{byTranscription: map[string][]*model.TranscriptionSegment{
+		"t1": {
+			{ID: "s0", SegmentIndex: 0, Text: " Hello", Confidence: &high},
+			{ID: "s1", SegmentIndex: 1, Text: " Good morning", Confidence: &low},
+			{ID: "s2", SegmentIndex: 2, Text: " Untranslated"},
+			{ID: "s3", SegmentIndex: 3, Text: " Yes", Confidence: &high},
+		},
+		"t2": {{ID: "s9", SegmentIndex: 0, Text: "こんにちは"}},
+	}}
+	translationRepo := &mockTranslationRepo{byTranscription: map[string][]*model.Translation{
+		"t1": {
+			// Newest first per segment, as the repository returns them
+			{TranscriptionSegmentID: "s0", TargetLanguage: "ja", TranslatedText: "こんにちは！", Source: "plamo-polished", Style: "simple"},
+			{TranscriptionSegmentID: "s0", TargetLanguage: "ja", TranslatedText: "やあ", Source: "plamo"},
+			{TranscriptionSegmentID: "s0", TargetLanguage: "ja", TranslatedText: "こんにちは", Source: "manual", Approved: true},
+			{TranscriptionSegmentID: "s0", TargetLanguage: "fr", TranslatedText: "Bonjour", Source: "plamo"},
+			{TranscriptionSegmentID: "s1", TargetLanguage: "ja", TranslatedText: "おはようございます", Source: "plamo"},
+			{TranscriptionSegmentID: "s3", TargetLanguage: "ja", TranslatedText: "はい、その通りです", Source: "plamo"},
+		},
+	}}
+	return NewExportServiceWithTranslations(videoRepo, transcriptionRepo, segmentRepo, translationRepo)
+}
+
+func decodePairs(t *testing.T, out string) []DatasetPair {
+	var pairs []DatasetPair
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if line == "" {
+			continue
+		}
+		var pair DatasetPair
+		require.NoError(t, json.Unmarshal([]byte(line), &pair))
+		pairs = append(pairs, pair)
+	}
+	return pairs
+}
+
+func TestExportService_ExportDataset(t *testing.T) {
+	ctx := context.Background()
+	service := newTestDatasetService()
+	base := DatasetExportOptions{ChannelID: "UC123", Format: DatasetFormatJSONL, SourceLanguage: "en", TargetLanguage: "ja"}
+
+	t.Run("pairs every translated segment, preferring approved translations", func(t *testing.T) {
+		var out bytes.Buffer
+		result, err := service.ExportDataset(ctx, base, &out)
+		require.NoError(t, err)
+		assert.Equal(t, &DatasetResult{Pairs: 3, Untranslated: 1}, result)
+
+		pairs := decodePairs(t, out.String())
+		require.Len(t, pairs, 3)
+		assert.Equal(t, DatasetPair{Source: "Hello", Target: "こんにちは", SourceLanguage: "en", TargetLanguage: "ja", VideoID: "vid1", SegmentIndex: 0}, pairs[0])
+		assert.Equal(t, 3, pairs[2].SegmentIndex)
+	})
+
+	t.Run("filters by confidence, length ratio and approval", func(t *testing.T) {
+		minConfidence := -1.0
+		opts := base
+		opts.MinConfidence = &minConfidence
+		opts.MaxLengthRatio = 2
+
+		var out bytes.Buffer
+		result, err := service.ExportDataset(ctx, opts, &out)
+		require.NoError(t, err)
+		assert.Equal(t, &DatasetResult{Pairs: 1, Untranslated: 1, LowConfidence: 1, RatioOutOfRange: 1}, result)
+
+		opts = base
+		opts.ApprovedOnly = true
+		out.Reset()
+		result, err = service.ExportDataset(ctx, opts, &out)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Pairs)
+		assert.Equal(t, "こんにちは", decodePairs(t, out.String())[0].Target)
+	})
+
+	t.Run("pairs a style variant only when selected", func(t *testing.T) {
+		opts := base
+		opts.Style = "simple"
+
+		var out bytes.Buffer
+		result, err := service.ExportDataset(ctx, opts, &out)
+		require.NoError(t, err)
+		assert.Equal(t, &DatasetResult{Pairs: 1, Untranslated: 3}, result)
+		assert.Equal(t, "こんにちは！", decodePairs(t, out.String())[0].Target)
+	})
+
+	t.Run("writes openai chat examples", func(t *testing.T) {
+		opts := base
+		opts.Format = DatasetFormatOpenAI
+
+		var out bytes.Buffer
+		_, err := service.ExportDataset(ctx, opts, &out)
+		require.NoError(t, err)
+
+		var example chatExample
+		require.NoError(t, json.Unmarshal([]byte(strings.Split(out.String(), "\n")[0]), &example))
+		require.Len(t, example.Messages, 3)
+		assert.Equal(t, "Translate from en to ja.", example.Messages[0].Content)
+		assert.Equal(t, chatMessage{Role: "user", Content: "Hello"}, example.Messages[1])
+		assert.Equal(t, chatMessage{Role: "assistant", Content: "こんにちは"}, example.Messages[2])
+	})
+
+	t.Run("rejects invalid options", func(t *testing.T) {
+		invalid := []DatasetExportOptions{
+			{Format: DatasetFormatJSONL, SourceLanguage: "en", TargetLanguage: "ja"},
+			{ChannelID: "UC123", Format: "csv", SourceLanguage: "en", TargetLanguage: "ja"},
+			{ChannelID: "UC123", Format: DatasetFormatJSONL, SourceLanguage: "en"},
+			{ChannelID: "UC123", Format: DatasetFormatJSONL, SourceLanguage: "en", TargetLanguage: "ja", MinLengthRatio: 2, MaxLengthRatio: 1},
+		}
+		for _, opts := range invalid {
+			_, err := service.ExportDataset(ctx, opts, &bytes.Buffer{})
+			var appErr *apperrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, apperrors.CodeInvalidArg, appErr.Code)
+		}
+	})
+}
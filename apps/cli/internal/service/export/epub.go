@@ -0,0 +1,202 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/epub"
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// epubStylesheet sets the translations of a bilingual pair apart from the source text
+const epubStylesheet = `h1 { font-size: 1.4em; }
+p.meta { font-size: 0.8em; color: #666; }
+div.pair { margin: 0 0 1em 0; }
+div.pair p { margin: 0; }
+p.translation { color: #444; font-style: italic; }
+`
+
+// EPUBExportOptions configures a bilingual e-book export
+type EPUBExportOptions struct {
+	ChannelID string    // Channel whose videos become chapters
+	Title     string    // Book title; defaults to the channel ID
+	Languages []string  // Spoken language of the transcriptions first, then the translation languages
+	Modified  time.Time // Modification time stored in the book; defaults to now
+
+	ApprovedOnly       bool // Only use translations approved in translation interactive
+	IncludeUnavailable bool // Also export videos marked unavailable by video verify
+}
+
+// EPUBResult summarizes an e-book export
+type EPUBResult struct {
+	Chapters     int `json:"chapters"`     // Videos written as chapters
+	Segments     int `json:"segments"`     // Source paragraphs written
+	Untranslated int `json:"untranslated"` // Missing translations of those paragraphs, over all languages
+	Skipped      int `json:"skipped"`      // Videos without a completed transcription in the source language
+}
+
+// epubChapter is a chapter with the upload date it is ordered by
+type epubChapter struct {
+	video   *model.Video
+	chapter epub.Chapter
+}
+
+// ExportEPUB writes an EPUB e-book with one chapter per transcribed video of a channel, in
+// upload order. Each segment becomes a paragraph in the source language followed by its
+// translations, so the text can be studied offline on an e-reader.
+func (s *exportService) ExportEPUB(ctx context.Context, opts EPUBExportOptions, w io.Writer) (*EPUBResult, error) {
+	if opts.ChannelID == "" {
+		return nil, errors.New(errors.CodeInvalidArg, "channel ID is required")
+	}
+	if len(opts.Languages) < 2 {
+		return nil, errors.New(errors.CodeInvalidArg, "a source language and at least one translation language are required")
+	}
+	if s.translationRepo == nil {
+		return nil, errors.New(errors.CodeInternal, "translation repository is not configured")
+	}
+
+	result := &EPUBResult{}
+	var chapters []*epubChapter
+	for offset := 0; ; offset += videoPageSize {
+		videos, err := s.videoRepo.GetByChannelID(ctx, opts.ChannelID, videoPageSize, offset)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, "failed to list channel videos")
+		}
+
+		for _, video := range videos {
+			if !video.IsAvailable() && !opts.IncludeUnavailable {
+				continue
+			}
+			chapter, err := s.epubChapter(ctx, video, opts, result)
+			if err != nil {
+				return nil, err
+			}
+			if chapter == nil {
+				result.Skipped++
+				continue
+			}
+			chapters = append(chapters, chapter)
+		}
+
+		if len(videos) < videoPageSize {
+			break
+		}
+	}
+	if len(chapters) == 0 {
+		return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("channel %s has no completed %s transcription to export", opts.ChannelID, opts.Languages[0]))
+	}
+
+	sortChaptersByUpload(chapters)
+	title := opts.Title
+	if title == "" {
+		title = opts.ChannelID
+	}
+	book := &epub.Book{
+		Identifier: "urn:yt-lang:channel:" + opts.ChannelID,
+		Title:      title,
+		Language:   opts.Languages[0],
+		Modified:   opts.Modified,
+		Stylesheet: epubStylesheet,
+	}
+	for _, c := range chapters {
+		book.Chapters = append(book.Chapters, c.chapter)
+	}
+	if err := book.Write(w); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to write e-book")
+	}
+
+	result.Chapters = len(chapters)
+	return result, nil
+}
+
+// epubChapter renders the newest completed source-language transcription of a video with its
+// translations; nil when the video has none
+func (s *exportService) epubChapter(ctx context.Context, video *model.Video, opts EPUBExportOptions, result *EPUBResult) (*epubChapter, error) {
+	transcriptions, err := s.transcriptionRepo.GetByVideoID(ctx, video.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to list transcriptions for video %s", video.ID))
+	}
+
+	// Transcriptions are ordered oldest first
+	var source *model.Transcription
+	for _, t := range transcriptions {
+		if t.Status == "completed" && spokenLanguage(t) == opts.Languages[0] {
+			source = t
+		}
+	}
+	if source == nil {
+		return nil, nil
+	}
+
+	segments, err := s.segmentRepo.GetByTranscriptionID(ctx, source.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to get segments for transcription %s", source.ID))
+	}
+	translations := make([]map[string]string, len(opts.Languages)-1)
+	for i, language := range opts.Languages[1:] {
+		if translations[i], err = s.segmentTranslations(ctx, source.ID, language, "", opts.ApprovedOnly); err != nil {
+			return nil, err
+		}
+	}
+
+	var body strings.Builder
+	if meta := videoMeta(video); meta != "" {
+		fmt.Fprintf(&body, "  <p class=\"meta\">%s</p>\n", epub.Escape(meta))
+	}
+	for _, segment := range segments {
+		text := strings.TrimSpace(segment.Text)
+		if text == "" {
+			continue
+		}
+		result.Segments++
+
+		body.WriteString("  <div class=\"pair\">\n")
+		fmt.Fprintf(&body, "    <p class=\"source\" lang=\"%s\">%s</p>\n", epub.Escape(opts.Languages[0]), epub.Escape(text))
+		for i, language := range opts.Languages[1:] {
+			translated := strings.TrimSpace(translations[i][segment.ID])
+			if translated == "" {
+				result.Untranslated++
+				continue
+			}
+			fmt.Fprintf(&body, "    <p class=\"translation\" lang=\"%s\">%s</p>\n", epub.Escape(language), epub.Escape(translated))
+		}
+		body.WriteString("  </div>\n")
+	}
+
+	title := video.Title
+	if title == "" {
+		title = video.ID
+	}
+	return &epubChapter{video: video, chapter: epub.Chapter{Title: title, Body: strings.TrimSuffix(body.String(), "\n")}}, nil
+}
+
+// videoMeta returns the upload date and URL of a video for a chapter's byline
+func videoMeta(video *model.Video) string {
+	var parts []string
+	if video.UploadDate != nil {
+		parts = append(parts, video.UploadDate.Format("2006-01-02"))
+	}
+	if video.URL != "" {
+		parts = append(parts, video.URL)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// sortChaptersByUpload orders chapters by upload date, videos without one last, ties by title
+func sortChaptersByUpload(chapters []*epubChapter) {
+	sort.SliceStable(chapters, func(i, j int) bool {
+		a, b := chapters[i].video.UploadDate, chapters[j].video.UploadDate
+		switch {
+		case a != nil && b != nil && !a.Equal(*b):
+			return a.Before(*b)
+		case (a == nil) != (b == nil):
+			return a != nil
+		}
+		return chapters[i].chapter.Title < chapters[j].chapter.Title
+	})
+}
@@ -0,0 +1,60 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readEPUBFile returns the content of a file of an EPUB archive
+func readEPUBFile(t *testing.T, data []byte, name string) string {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	for _, file := range archive.File {
+		if file.Name != name {
+			continue
+		}
+		r, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return string(content)
+	}
+	t.Fatalf("%s not found in the e-book", name)
+	return ""
+}
+
+func TestExportService_ExportEPUB(t *testing.T) {
+	ctx := context.Background()
+	service := newTestDatasetService()
+
+	var buf bytes.Buffer
+	result, err := service.ExportEPUB(ctx, EPUBExportOptions{ChannelID: "UC123", Title: "Lessons", Languages: []string{"en", "ja", "fr"}}, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, &EPUBResult{Chapters: 1, Segments: 4, Untranslated: 4, Skipped: 1}, result)
+
+	assert.Contains(t, readEPUBFile(t, buf.Bytes(), "OEBPS/content.opf"), "<dc:title>Lessons</dc:title>")
+	chapter := readEPUBFile(t, buf.Bytes(), "OEBPS/chapter-001.xhtml")
+	assert.Contains(t, chapter, "<h1>Lesson</h1>")
+	// The approved translation wins, and every translation language follows the source
+	assert.Contains(t, chapter, `<p class="source" lang="en">Hello</p>
+    <p class="translation" lang="ja">こんにちは</p>
+    <p class="translation" lang="fr">Bonjour</p>`)
+	assert.Contains(t, chapter, `<p class="source" lang="en">Untranslated</p>
+  </div>`)
+
+	var appErr *apperrors.AppError
+	_, err = service.ExportEPUB(ctx, EPUBExportOptions{ChannelID: "UC123", Languages: []string{"en"}}, io.Discard)
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, apperrors.CodeInvalidArg, appErr.Code)
+
+	_, err = service.ExportEPUB(ctx, EPUBExportOptions{ChannelID: "UC123", Languages: []string{"de", "ja"}}, io.Discard)
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, apperrors.CodeNotFound, appErr.Code)
+}
@@ -0,0 +1,229 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/artifact"
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/subtitle"
+)
+
+const (
+	videoPageSize = 100 // Videos fetched per repository call while walking a channel
+)
+
+// VideoRepository interface for accessing channel videos
+type VideoRepository interface {
+	GetByChannelID(ctx context.Context, channelID string, limit, offset int) ([]*model.Video, error)
+}
+
+// TranscriptionRepository interface for accessing transcription metadata
+type TranscriptionRepository interface {
+	GetByVideoID(ctx context.Context, videoID string) ([]*model.Transcription, error)
+}
+
+// SegmentRepository interface for accessing transcription segments
+type SegmentRepository interface {
+	GetByTranscriptionID(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error)
+}
+
+// ExportService defines operations for exporting stored data to files
+type ExportService interface {
+	// ExportChannelTranscripts writes one file per completed transcription of a channel's videos
+	ExportChannelTranscripts(ctx context.Context, opts TranscriptExportOptions) (*ExportResult, error)
+
+	// ExportDataset writes aligned source/target segment pairs of a channel's videos as JSON lines
+	ExportDataset(ctx context.Context, opts DatasetExportOptions, w io.Writer) (*DatasetResult, error)
+
+	// ExportVideoSubtitles writes one subtitle file per language of a video: its transcription and translations
+	ExportVideoSubtitles(ctx context.Context, opts SubtitleExportOptions) (*ExportResult, error)
+
+	// ExportEPUB writes an e-book with one chapter of bilingual paragraphs per transcribed video of a channel
+	ExportEPUB(ctx context.Context, opts EPUBExportOptions, w io.Writer) (*EPUBResult, error)
+}
+
+// TranscriptExportOptions configures a channel transcript export
+type TranscriptExportOptions struct {
+	ChannelID string              // Channel whose videos are exported
+	Format    string              // Output format: srt, vtt, text, json, article
+	Dir       string              // Root output directory, used when Store is nil
+	Store     artifact.Store      // Optional artifact store; files are written under exports/<channelID>/
+	Language  string              // Optional transcription language filter (empty means all)
+	Subtitles subtitle.Rules      // Cue constraints applied to srt/vtt output
+	VTT       subtitle.VTTOptions // Cue settings and style of vtt output
+
+	// Timestamps places inline timestamps in article output: none (default), paragraph, sentence
+	Timestamps string
+
+	IncludeUnavailable bool // Also export videos marked unavailable by video verify
+}
+
+// ExportResult summarizes an export run
+type ExportResult struct {
+	Dir     string   `json:"dir"` // Local directory or store key prefix the files were written to
+	Written []string `json:"written"`
+	Skipped []string `json:"skipped"`
+}
+
+// exportService implements ExportService
+type exportService struct {
+	videoRepo         VideoRepository
+	transcriptionRepo TranscriptionRepository
+	segmentRepo       SegmentRepository
+	translationRepo   TranslationRepository // Optional; required by ExportDataset and ExportEPUB
+}
+
+// NewExportService creates a new export service
+func NewExportService(videoRepo VideoRepository, transcriptionRepo TranscriptionRepository, segmentRepo SegmentRepository) ExportService {
+	return &exportService{
+		videoRepo:         videoRepo,
+		transcriptionRepo: transcriptionRepo,
+		segmentRepo:       segmentRepo,
+	}
+}
+
+// ExportChannelTranscripts exports all completed transcriptions of a channel into Dir/<channelID>/
+func (s *exportService) ExportChannelTranscripts(ctx context.Context, opts TranscriptExportOptions) (*ExportResult, error) {
+	if opts.ChannelID == "" {
+		return nil, errors.New(errors.CodeInvalidArg, "channel ID is required")
+	}
+	formatter, err := getTranscriptFormatter(opts.Format, opts.Subtitles, opts.VTT, opts.Timestamps)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidArg, "invalid export format")
+	}
+	dest := newDestination(opts)
+
+	manifest, err := loadManifest(ctx, dest)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to load export manifest")
+	}
+
+	result := &ExportResult{Dir: dest.location, Written: []string{}, Skipped: []string{}}
+
+	for offset := 0; ; offset += videoPageSize {
+		videos, err := s.videoRepo.GetByChannelID(ctx, opts.ChannelID, videoPageSize, offset)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, "failed to list channel videos")
+		}
+
+		for _, video := range videos {
+			if !video.IsAvailable() && !opts.IncludeUnavailable {
+				continue
+			}
+			if err := s.exportVideo(ctx, video, opts, formatter, dest, manifest, result); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(videos) < videoPageSize {
+			break
+		}
+	}
+
+	if err := manifest.save(ctx, dest); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to save export manifest")
+	}
+
+	return result, nil
+}
+
+// exportVideo writes every matching transcription of a single video
+func (s *exportService) exportVideo(ctx context.Context, video *model.Video, opts TranscriptExportOptions, formatter transcriptFormatter, dest destination, m *manifest, result *ExportResult) error {
+	transcriptions, err := s.transcriptionRepo.GetByVideoID(ctx, video.ID)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to list transcriptions for video %s", video.ID))
+	}
+
+	for _, t := range transcriptions {
+		if t.Status != "completed" {
+			continue
+		}
+		if opts.Language != "" && t.Language != opts.Language {
+			continue
+		}
+
+		segments, err := s.segmentRepo.GetByTranscriptionID(ctx, t.ID)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to get segments for transcription %s", t.ID))
+		}
+
+		content, err := formatter.format(t, segments)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeInternal, "failed to format transcription")
+		}
+
+		name := exportFileName(video, t.Language, formatter.extension())
+		hash := contentHash(content)
+
+		// Skip files whose content is unchanged since the last export
+		unchanged, err := m.unchanged(ctx, dest, name, hash)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to check %s", name))
+		}
+		if unchanged {
+			result.Skipped = append(result.Skipped, name)
+			continue
+		}
+
+		if err := dest.store.Put(ctx, dest.key(name), bytes.NewReader(content)); err != nil {
+			return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to write %s", name))
+		}
+		m.Files[name] = hash
+		result.Written = append(result.Written, name)
+	}
+
+	return nil
+}
+
+// destination is where the files of one channel export are written
+type destination struct {
+	store    artifact.Store
+	prefix   string // key prefix of the channel's files
+	location string // reported in ExportResult.Dir
+}
+
+// newDestination resolves the store and key prefix of an export
+func newDestination(opts TranscriptExportOptions) destination {
+	if opts.Store != nil {
+		prefix := artifact.ExportKey(opts.ChannelID, "")
+		return destination{store: opts.Store, prefix: prefix, location: strings.TrimSuffix(prefix, "/")}
+	}
+
+	dir := opts.Dir
+	if dir == "" {
+		dir = "."
+	}
+	return destination{
+		store:    artifact.NewLocalStore(dir),
+		prefix:   opts.ChannelID + "/",
+		location: filepath.Join(dir, opts.ChannelID),
+	}
+}
+
+// key returns the store key of an exported file
+func (d destination) key(name string) string {
+	return d.prefix + name
+}
+
+// unsafeFileChars matches characters that are not portable in file names
+var unsafeFileChars = regexp.MustCompile(`[<>:"/\\|?*\x00-\x1f]+`)
+
+// exportFileName builds "<title> [<videoID>].<lang>.<ext>", the naming convention used by yt-dlp
+func exportFileName(video *model.Video, language, ext string) string {
+	title := strings.TrimSpace(unsafeFileChars.ReplaceAllString(video.Title, "_"))
+	title = strings.Trim(title, ". ")
+	if runes := []rune(title); len(runes) > 100 {
+		title = strings.TrimSpace(string(runes[:100]))
+	}
+	if title == "" {
+		return fmt.Sprintf("[%s].%s.%s", video.ID, language, ext)
+	}
+	return fmt.Sprintf("%s [%s].%s.%s", title, video.ID, language, ext)
+}
@@ -0,0 +1,210 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Taichi-iskw/yt-lang/internal/artifact"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/subtitle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockVideoRepo mocks VideoRepository
+type mockVideoRepo struct {
+	videos []*model.Video
+}
+
+func (m *mockVideoRepo) GetByChannelID(ctx context.Context, channelID string, limit, offset int) ([]*model.Video, error) {
+	if offset >= len(m.videos) {
+		return []*model.Video{}, nil
+	}
+	end := offset + limit
+	if end > len(m.videos) {
+		end = len(m.videos)
+	}
+	return m.videos[offset:end], nil
+}
+
+// mockTranscriptionRepo mocks TranscriptionRepository
+type mockTranscriptionRepo struct {
+	byVideo map[string][]*model.Transcription
+}
+
+func (m *mockTranscriptionRepo) GetByVideoID(ctx context.Context, videoID string) ([]*model.Transcription, error) {
+	return m.byVideo[videoID], nil
+}
+
+// mockSegmentRepo mocks SegmentRepository
+type mockSegmentRepo struct {
+	byTranscription map[string][]*model.TranscriptionSegment
+}
+
+func (m *mockSegmentRepo) GetByTranscriptionID(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error) {
+	return m.byTranscription[transcriptionID], nil
+}
+
+func newTestExportService() (ExportService, *mockSegmentRepo) {
+	videoRepo := &mockVideoRepo{videos: []*model.Video{
+		{ID: "vid1", ChannelID: "UC123", Title: "Intro: Go/Rust?"},
+		{ID: "vid2", ChannelID: "UC123", Title: "Pending video"},
+	}}
+	transcriptionRepo := &mockTranscriptionRepo{byVideo: map[string][]*model.Transcription{
+		"vid1": {{ID: "t1", VideoID: "vid1", Language: "en", Status: "completed"}},
+		"vid2": {{ID: "t2", VideoID: "vid2", Language: "en", Status: "processing"}},
+	}}
+	segmentRepo := &mockSegmentRepo{byTranscription: map[string][]*model.TranscriptionSegment{
+		"t1": {
+			{SegmentIndex: 0, StartTime: "00:00:00", EndTime: "00:00:02.5", Text: " Hello"},
+			{SegmentIndex: 1, StartTime: "00:00:02.5", EndTime: "00:00:06", Text: " World"},
+		},
+	}}
+	return NewExportService(videoRepo, transcriptionRepo, segmentRepo), segmentRepo
+}
+
+func TestExportService_ExportChannelTranscripts(t *testing.T) {
+	service, segmentRepo := newTestExportService()
+	dir := t.TempDir()
+	opts := TranscriptExportOptions{ChannelID: "UC123", Format: "srt", Dir: dir}
+
+	// First export writes only the completed transcription
+	result, err := service.ExportChannelTranscripts(context.Background(), opts)
+	require.NoError(t, err)
+	require.Len(t, result.Written, 1)
+	assert.Empty(t, result.Skipped)
+	assert.Equal(t, "Intro_ Go_Rust_ [vid1].en.srt", result.Written[0])
+
+	content, err := os.ReadFile(filepath.Join(dir, "UC123", result.Written[0]))
+	require.NoError(t, err)
+	assert.Equal(t, "1\n00:00:00,000 --> 00:00:02,500\nHello\n\n2\n00:00:02,500 --> 00:00:06,000\nWorld\n\n", string(content))
+	assert.FileExists(t, filepath.Join(dir, "UC123", manifestFileName))
+
+	// Second export skips unchanged files
+	result, err = service.ExportChannelTranscripts(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Empty(t, result.Written)
+	assert.Equal(t, []string{"Intro_ Go_Rust_ [vid1].en.srt"}, result.Skipped)
+
+	// Changed content is rewritten
+	segmentRepo.byTranscription["t1"][1].Text = " Gophers"
+	result, err = service.ExportChannelTranscripts(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Len(t, result.Written, 1)
+}
+
+func TestExportService_ExportChannelTranscripts_ToStore(t *testing.T) {
+	service, _ := newTestExportService()
+	root := t.TempDir()
+	opts := TranscriptExportOptions{ChannelID: "UC123", Format: "text", Store: artifact.NewLocalStore(root)}
+
+	result, err := service.ExportChannelTranscripts(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, "exports/UC123", result.Dir)
+	require.Len(t, result.Written, 1)
+	assert.FileExists(t, filepath.Join(root, "exports", "UC123", result.Written[0]))
+	assert.FileExists(t, filepath.Join(root, "exports", "UC123", manifestFileName))
+
+	result, err = service.ExportChannelTranscripts(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Empty(t, result.Written)
+	assert.Len(t, result.Skipped, 1)
+}
+
+func TestExportService_ExportChannelTranscripts_SkipsUnavailable(t *testing.T) {
+	videoRepo := &mockVideoRepo{videos: []*model.Video{
+		{ID: "vid1", ChannelID: "UC123", Title: "Gone", Status: model.VideoStatusUnavailable},
+	}}
+	transcriptionRepo := &mockTranscriptionRepo{byVideo: map[string][]*model.Transcription{
+		"vid1": {{ID: "t1", VideoID: "vid1", Language: "en", Status: "completed"}},
+	}}
+	segmentRepo := &mockSegmentRepo{byTranscription: map[string][]*model.TranscriptionSegment{
+		"t1": {{SegmentIndex: 0, StartTime: "00:00:00", EndTime: "00:00:02", Text: "Hello"}},
+	}}
+	service := NewExportService(videoRepo, transcriptionRepo, segmentRepo)
+
+	result, err := service.ExportChannelTranscripts(context.Background(), TranscriptExportOptions{ChannelID: "UC123", Format: "text", Dir: t.TempDir()})
+	require.NoError(t, err)
+	assert.Empty(t, result.Written)
+
+	result, err = service.ExportChannelTranscripts(context.Background(), TranscriptExportOptions{ChannelID: "UC123", Format: "text", Dir: t.TempDir(), IncludeUnavailable: true})
+	require.NoError(t, err)
+	assert.Len(t, result.Written, 1)
+}
+
+func TestExportService_ExportChannelTranscripts_Validation(t *testing.T) {
+	service, _ := newTestExportService()
+
+	_, err := service.ExportChannelTranscripts(context.Background(), TranscriptExportOptions{Format: "srt", Dir: t.TempDir()})
+	assert.Error(t, err)
+
+	_, err = service.ExportChannelTranscripts(context.Background(), TranscriptExportOptions{ChannelID: "UC123", Format: "docx", Dir: t.TempDir()})
+	assert.Error(t, err)
+}
+
+func TestVTTFormatter_AppliesRules(t *testing.T) {
+	rules, err := subtitle.ResolveRules("netflix", nil)
+	require.NoError(t, err)
+
+	formatter, err := getTranscriptFormatter("vtt", rules, subtitle.VTTOptions{}, TimestampsNone)
+	require.NoError(t, err)
+	assert.Equal(t, "vtt", formatter.extension())
+
+	content, err := formatter.format(nil, []*model.TranscriptionSegment{
+		{SegmentIndex: 0, StartTime: "00:00:01", EndTime: "00:00:01.2", Text: "Hi"},
+		{SegmentIndex: 1, StartTime: "00:00:01.2", EndTime: "00:00:03.234567", Text: "there"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "WEBVTT\n\n00:00:01.000 --> 00:00:03.235\nHi there\n\n", string(content))
+}
+
+func TestVTTFormatter_SpeakersStyleAndNote(t *testing.T) {
+	host, guest := "SPEAKER_00", "SPEAKER_01"
+	vtt := subtitle.VTTOptions{Settings: "line:85% align:center", Style: "::cue { color: yellow; }"}
+	formatter, err := getTranscriptFormatter("vtt", subtitle.DefaultRules(), vtt, TimestampsNone)
+	require.NoError(t, err)
+
+	content, err := formatter.format(&model.Transcription{ID: "trans-1", VideoID: "vid1"}, []*model.TranscriptionSegment{
+		{SegmentIndex: 0, StartTime: "00:00:01", EndTime: "00:00:02", Text: "How are you?", Speaker: &host},
+		{SegmentIndex: 1, StartTime: "00:00:02", EndTime: "00:00:03", Text: "Fine, thanks.", Speaker: &guest},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `WEBVTT
+
+NOTE
+video: vid1
+transcription: trans-1
+
+STYLE
+::cue { color: yellow; }
+
+00:00:01.000 --> 00:00:02.000 line:85% align:center
+<v SPEAKER_00>How are you?
+
+00:00:02.000 --> 00:00:03.000 line:85% align:center
+<v SPEAKER_01>Fine, thanks.
+
+`, string(content))
+
+	_, err = getTranscriptFormatter("vtt", subtitle.DefaultRules(), subtitle.VTTOptions{Settings: "color:red"}, TimestampsNone)
+	assert.ErrorContains(t, err, "invalid WebVTT cue setting")
+}
+
+func TestExportedFiles(t *testing.T) {
+	service, _ := newTestExportService()
+	dir := t.TempDir()
+	opts := TranscriptExportOptions{ChannelID: "UC123", Format: "vtt", Dir: dir}
+
+	files, err := ExportedFiles(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	_, err = service.ExportChannelTranscripts(context.Background(), opts)
+	require.NoError(t, err)
+
+	files, err = ExportedFiles(context.Background(), TranscriptExportOptions{ChannelID: "UC123", Dir: dir})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Intro_ Go_Rust_ [vid1].en.vtt"}, files)
+}
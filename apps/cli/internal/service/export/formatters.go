@@ -0,0 +1,147 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/subtitle"
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
+)
+
+// transcriptFormatter renders a transcription to file content
+type transcriptFormatter interface {
+	format(transcription *model.Transcription, segments []*model.TranscriptionSegment) ([]byte, error)
+	extension() string
+}
+
+// getTranscriptFormatter returns the formatter for the given format name; subtitle formats
+// apply rules to their cues, WebVTT adds the vtt presentation hints, and articles place
+// timestamps as given
+func getTranscriptFormatter(format string, rules subtitle.Rules, vtt subtitle.VTTOptions, timestamps string) (transcriptFormatter, error) {
+	switch strings.ToLower(format) {
+	case "srt", "":
+		return srtFormatter{rules: rules}, nil
+	case "vtt":
+		if err := vtt.Validate(); err != nil {
+			return nil, err
+		}
+		return vttFormatter{rules: rules, vtt: vtt}, nil
+	case "text", "txt":
+		return textFormatter{}, nil
+	case "json":
+		return jsonFormatter{}, nil
+	case FormatArticle:
+		switch timestamps {
+		case "", TimestampsNone, TimestampsParagraph, TimestampsSentence:
+			return articleFormatter{timestamps: timestamps}, nil
+		default:
+			return nil, fmt.Errorf("unsupported timestamps: %s (supported: none, paragraph, sentence)", timestamps)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format: %s (supported: srt, vtt, text, json, article)", format)
+	}
+}
+
+// srtFormatter renders SRT subtitles
+type srtFormatter struct {
+	rules subtitle.Rules
+}
+
+func (f srtFormatter) format(_ *model.Transcription, segments []*model.TranscriptionSegment) ([]byte, error) {
+	cues, err := segmentCues(segments, f.rules)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	for i, cue := range cues {
+		subtitle.WriteSRTCue(&b, i+1, cue)
+	}
+	return []byte(b.String()), nil
+}
+
+func (srtFormatter) extension() string { return "srt" }
+
+// vttFormatter renders WebVTT subtitles. A NOTE block records the video and transcription the
+// file was made from, so a subtitle file found on disk can be traced back to its source.
+type vttFormatter struct {
+	rules subtitle.Rules
+	vtt   subtitle.VTTOptions
+}
+
+func (f vttFormatter) format(transcription *model.Transcription, segments []*model.TranscriptionSegment) ([]byte, error) {
+	cues, err := segmentCues(segments, f.rules)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	subtitle.WriteVTTHeader(&b)
+	if transcription != nil {
+		subtitle.WriteVTTNote(&b, "video: "+transcription.VideoID, "transcription: "+transcription.ID)
+	}
+	subtitle.WriteVTTStyle(&b, f.vtt.Style)
+	for _, cue := range cues {
+		subtitle.WriteVTTCueWithSettings(&b, cue, f.vtt.Settings)
+	}
+	return []byte(b.String()), nil
+}
+
+func (vttFormatter) extension() string { return "vtt" }
+
+// textFormatter renders plain transcript text, one segment per line
+type textFormatter struct{}
+
+func (textFormatter) format(_ *model.Transcription, segments []*model.TranscriptionSegment) ([]byte, error) {
+	var b strings.Builder
+	for _, segment := range segments {
+		b.WriteString(strings.TrimSpace(segment.Text))
+		b.WriteString("\n")
+	}
+	return []byte(b.String()), nil
+}
+
+func (textFormatter) extension() string { return "txt" }
+
+// jsonFormatter renders transcription metadata and segments as JSON
+type jsonFormatter struct{}
+
+func (jsonFormatter) format(transcription *model.Transcription, segments []*model.TranscriptionSegment) ([]byte, error) {
+	output := struct {
+		Transcription *model.Transcription          `json:"transcription"`
+		Segments      []*model.TranscriptionSegment `json:"segments"`
+	}{
+		Transcription: transcription,
+		Segments:      segments,
+	}
+	return json.MarshalIndent(output, "", "  ")
+}
+
+func (jsonFormatter) extension() string { return "json" }
+
+// segmentCues converts transcription segments into subtitle cues that satisfy rules, keeping
+// the speakers of diarized segments apart
+func segmentCues(segments []*model.TranscriptionSegment, rules subtitle.Rules) ([]subtitle.Cue, error) {
+	processor := subtitle.NewProcessor(rules)
+	var cues []subtitle.Cue
+
+	for _, segment := range segments {
+		start, err := timecode.ParseInterval(segment.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("segment %d: %w", segment.SegmentIndex, err)
+		}
+		end, err := timecode.ParseInterval(segment.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("segment %d: %w", segment.SegmentIndex, err)
+		}
+		var speaker string
+		if segment.Speaker != nil {
+			speaker = *segment.Speaker
+		}
+		cues = append(cues, processor.PushVoice(start, end, speaker, segment.Text)...)
+	}
+
+	return append(cues, processor.Flush()...), nil
+}
@@ -0,0 +1,88 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+
+	"github.com/Taichi-iskw/yt-lang/internal/artifact"
+)
+
+// manifestFileName is the file recording content hashes of exported files
+const manifestFileName = ".ytlang-manifest.json"
+
+// manifest maps exported file names to the SHA-256 of their content
+type manifest struct {
+	Files map[string]string `json:"files"`
+}
+
+// loadManifest reads the manifest of dest, returning an empty manifest if none exists
+func loadManifest(ctx context.Context, dest destination) (*manifest, error) {
+	m := &manifest{Files: map[string]string{}}
+
+	r, err := dest.store.Open(ctx, dest.key(manifestFileName))
+	if err != nil {
+		if errors.Is(err, artifact.ErrNotFound) {
+			return m, nil
+		}
+		return nil, err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	if m.Files == nil {
+		m.Files = map[string]string{}
+	}
+	return m, nil
+}
+
+// save writes the manifest to dest
+func (m *manifest) save(ctx context.Context, dest destination) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return dest.store.Put(ctx, dest.key(manifestFileName), bytes.NewReader(data))
+}
+
+// unchanged reports whether name was exported with the same hash and still exists in dest
+func (m *manifest) unchanged(ctx context.Context, dest destination, name, hash string) (bool, error) {
+	if m.Files[name] != hash {
+		return false, nil
+	}
+	return dest.store.Exists(ctx, dest.key(name))
+}
+
+// contentHash returns the hex-encoded SHA-256 of content
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// ExportedFiles lists the files recorded in the manifest of a channel export. Only ChannelID,
+// Dir and Store of opts are used, as for ExportChannelTranscripts.
+func ExportedFiles(ctx context.Context, opts TranscriptExportOptions) ([]string, error) {
+	m, err := loadManifest(ctx, newDestination(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(m.Files))
+	for name := range m.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
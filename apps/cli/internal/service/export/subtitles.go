@@ -0,0 +1,189 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/artifact"
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/subtitle"
+)
+
+// OriginalLanguage selects the transcription itself in SubtitleExportOptions.Languages
+const OriginalLanguage = "original"
+
+// SubtitleExportOptions configures a per-video subtitle package export
+type SubtitleExportOptions struct {
+	VideoID        string              // Video whose subtitles are exported
+	Languages      []string            // OriginalLanguage for the transcription; any other entry is a translation language
+	SourceLanguage string              // Optional; selects the transcription when the video has several
+	Format         string              // Output format: srt, vtt, json-timed
+	Dir            string              // Output directory
+	Subtitles      subtitle.Rules      // Cue constraints
+	VTT            subtitle.VTTOptions // Cue settings and style of vtt output
+	ApprovedOnly   bool                // Only use translations approved in translation interactive
+	Words          bool                // json-timed only: add estimated per-word timing to each cue
+}
+
+// subtitleFile is one rendered file of a subtitle package
+type subtitleFile struct {
+	name    string
+	content []byte
+}
+
+// ExportVideoSubtitles writes "<videoID>.<lang>.<ext>" for the transcription and each requested
+// translation, the naming media players use to pick up side-car subtitles. Translated cues keep
+// the timing of the original segments. Nothing is written unless every language can be exported.
+func (s *exportService) ExportVideoSubtitles(ctx context.Context, opts SubtitleExportOptions) (*ExportResult, error) {
+	if opts.VideoID == "" {
+		return nil, errors.New(errors.CodeInvalidArg, "video ID is required")
+	}
+	if len(opts.Languages) == 0 {
+		return nil, errors.New(errors.CodeInvalidArg, "at least one language is required")
+	}
+	format := strings.ToLower(opts.Format)
+	if format != "srt" && format != "vtt" && format != FormatJSONTimed {
+		return nil, errors.New(errors.CodeInvalidArg, fmt.Sprintf("unsupported subtitle format: %s (supported: srt, vtt, %s)", opts.Format, FormatJSONTimed))
+	}
+
+	transcription, err := s.sourceTranscription(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	segments, err := s.segmentRepo.GetByTranscriptionID(ctx, transcription.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to get segments for transcription %s", transcription.ID))
+	}
+
+	if format == FormatJSONTimed {
+		file, err := s.timedFile(ctx, opts, transcription, segments)
+		if err != nil {
+			return nil, err
+		}
+		return writeSubtitleFiles(ctx, opts.Dir, []subtitleFile{file})
+	}
+
+	formatter, err := getTranscriptFormatter(format, opts.Subtitles, opts.VTT, TimestampsNone)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidArg, "invalid export format")
+	}
+
+	// Render every file first so a missing translation doesn't leave a partial package behind
+	var files []subtitleFile
+	seen := make(map[string]bool)
+	for _, language := range opts.Languages {
+		fileLanguage, fileSegments := language, segments
+		if language == OriginalLanguage {
+			fileLanguage = originalFileLanguage(transcription)
+		} else {
+			if fileSegments, err = s.translatedSegments(ctx, transcription, segments, language, opts.ApprovedOnly); err != nil {
+				return nil, err
+			}
+		}
+		if seen[fileLanguage] {
+			return nil, errors.New(errors.CodeInvalidArg, fmt.Sprintf("language %s is requested more than once", fileLanguage))
+		}
+		seen[fileLanguage] = true
+
+		content, err := formatter.format(transcription, fileSegments)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, "failed to format subtitles")
+		}
+		files = append(files, subtitleFile{
+			name:    fmt.Sprintf("%s.%s.%s", opts.VideoID, fileLanguage, formatter.extension()),
+			content: content,
+		})
+	}
+
+	return writeSubtitleFiles(ctx, opts.Dir, files)
+}
+
+// writeSubtitleFiles writes the rendered files of a subtitle package into dir
+func writeSubtitleFiles(ctx context.Context, dir string, files []subtitleFile) (*ExportResult, error) {
+	if dir == "" {
+		dir = "."
+	}
+	store := artifact.NewLocalStore(dir)
+	result := &ExportResult{Dir: dir, Written: []string{}, Skipped: []string{}}
+	for _, file := range files {
+		if err := store.Put(ctx, file.name, bytes.NewReader(file.content)); err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to write %s", file.name))
+		}
+		result.Written = append(result.Written, file.name)
+	}
+
+	return result, nil
+}
+
+// sourceTranscription picks the completed transcription of the video the package is built from
+func (s *exportService) sourceTranscription(ctx context.Context, opts SubtitleExportOptions) (*model.Transcription, error) {
+	transcriptions, err := s.transcriptionRepo.GetByVideoID(ctx, opts.VideoID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to list transcriptions for video %s", opts.VideoID))
+	}
+
+	var candidates []*model.Transcription
+	var languages []string
+	for _, t := range transcriptions {
+		if t.Status != "completed" {
+			continue
+		}
+		if opts.SourceLanguage != "" && t.Language != opts.SourceLanguage && spokenLanguage(t) != opts.SourceLanguage {
+			continue
+		}
+		candidates = append(candidates, t)
+		languages = append(languages, t.Language)
+	}
+
+	switch len(candidates) {
+	case 0:
+		if opts.SourceLanguage != "" {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("video %s has no completed %s transcription", opts.VideoID, opts.SourceLanguage))
+		}
+		return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("video %s has no completed transcription", opts.VideoID))
+	case 1:
+		return candidates[0], nil
+	default:
+		return nil, errors.New(errors.CodeInvalidArg, fmt.Sprintf("video %s has transcriptions in %s; select one with the source language", opts.VideoID, strings.Join(languages, ", ")))
+	}
+}
+
+// translatedSegments returns the segments of transcription carrying their translation in language.
+// Segments without one are left out.
+func (s *exportService) translatedSegments(ctx context.Context, transcription *model.Transcription, segments []*model.TranscriptionSegment, language string, approvedOnly bool) ([]*model.TranscriptionSegment, error) {
+	if s.translationRepo == nil {
+		return nil, errors.New(errors.CodeInternal, "translation repository is not configured")
+	}
+
+	translations, err := s.segmentTranslations(ctx, transcription.ID, language, "", approvedOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	var translated []*model.TranscriptionSegment
+	for _, segment := range segments {
+		text := strings.TrimSpace(translations[segment.ID])
+		if text == "" {
+			continue
+		}
+		copied := *segment
+		copied.Text = text
+		translated = append(translated, &copied)
+	}
+	if len(translated) == 0 {
+		return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("no %s translations found for transcription %s", language, transcription.ID))
+	}
+	return translated, nil
+}
+
+// originalFileLanguage is the language code in the transcription's file name: the spoken
+// language, or "original" when it is unknown
+func originalFileLanguage(t *model.Transcription) string {
+	if language := spokenLanguage(t); language != "" && language != "auto" {
+		return language
+	}
+	return OriginalLanguage
+}
@@ -0,0 +1,155 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSubtitleService() ExportService {
+	english := "en"
+	transcriptionRepo := &mockTranscriptionRepo{byVideo: map[string][]*model.Transcription{
+		"vid1": {{ID: "t1", VideoID: "vid1", Language: "auto", DetectedLanguage: &english, Status: "completed"}},
+		"vid2": {
+			{ID: "t2", VideoID: "vid2", Language: "en", Status: "completed"},
+			{ID: "t3", VideoID: "vid2", Language: "ja", Status: "completed"},
+		},
+	}}
+	segmentRepo := &mockSegmentRepo{byTranscription: map[string][]*model.TranscriptionSegment{
+		"t1": {
+			{ID: "s0", SegmentIndex: 0, StartTime: "00:00:00", EndTime: "00:00:02.5", Text: " Hello"},
+			{ID: "s1", SegmentIndex: 1, StartTime: "00:00:02.5", EndTime: "00:00:06", Text: " World"},
+		},
+	}}
+	translationRepo := &mockTranslationRepo{byTranscription: map[string][]*model.Translation{
+		"t1": {
+			{TranscriptionSegmentID: "s0", TargetLanguage: "ja", TranslatedText: "こんにちは"},
+			{TranscriptionSegmentID: "s1", TargetLanguage: "ja", TranslatedText: "世界"},
+			{TranscriptionSegmentID: "s1", TargetLanguage: "ko", TranslatedText: "세계"},
+		},
+	}}
+	return NewExportServiceWithTranslations(&mockVideoRepo{}, transcriptionRepo, segmentRepo, translationRepo)
+}
+
+func TestExportService_ExportVideoSubtitles(t *testing.T) {
+	service := newTestSubtitleService()
+	dir := t.TempDir()
+
+	result, err := service.ExportVideoSubtitles(context.Background(), SubtitleExportOptions{
+		VideoID:   "vid1",
+		Languages: []string{OriginalLanguage, "ja", "ko"},
+		Format:    "srt",
+		Dir:       dir,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vid1.en.srt", "vid1.ja.srt", "vid1.ko.srt"}, result.Written)
+
+	original, err := os.ReadFile(filepath.Join(dir, "vid1.en.srt"))
+	require.NoError(t, err)
+	assert.Equal(t, "1\n00:00:00,000 --> 00:00:02,500\nHello\n\n2\n00:00:02,500 --> 00:00:06,000\nWorld\n\n", string(original))
+
+	// Translations keep the original timing; untranslated segments are left out
+	korean, err := os.ReadFile(filepath.Join(dir, "vid1.ko.srt"))
+	require.NoError(t, err)
+	assert.Equal(t, "1\n00:00:02,500 --> 00:00:06,000\n세계\n\n", string(korean))
+}
+
+func TestExportService_ExportVideoSubtitles_Errors(t *testing.T) {
+	service := newTestSubtitleService()
+
+	tests := []struct {
+		name     string
+		opts     SubtitleExportOptions
+		wantCode string
+	}{
+		{name: "missing translation", opts: SubtitleExportOptions{VideoID: "vid1", Languages: []string{"original", "fr"}, Format: "srt"}, wantCode: apperrors.CodeNotFound},
+		{name: "no transcription", opts: SubtitleExportOptions{VideoID: "vid9", Languages: []string{"original"}, Format: "srt"}, wantCode: apperrors.CodeNotFound},
+		{name: "several transcriptions", opts: SubtitleExportOptions{VideoID: "vid2", Languages: []string{"original"}, Format: "srt"}, wantCode: apperrors.CodeInvalidArg},
+		{name: "repeated translation", opts: SubtitleExportOptions{VideoID: "vid1", Languages: []string{"ja", "ja"}, Format: "srt"}, wantCode: apperrors.CodeInvalidArg},
+		{name: "unsupported format", opts: SubtitleExportOptions{VideoID: "vid1", Languages: []string{"original"}, Format: "text"}, wantCode: apperrors.CodeInvalidArg},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			tt.opts.Dir = dir
+
+			_, err := service.ExportVideoSubtitles(context.Background(), tt.opts)
+			var appErr *apperrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, tt.wantCode, appErr.Code)
+
+			// Nothing is written when any language fails
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+		})
+	}
+
+	t.Run("source language selects the transcription", func(t *testing.T) {
+		result, err := service.ExportVideoSubtitles(context.Background(), SubtitleExportOptions{
+			VideoID: "vid2", Languages: []string{OriginalLanguage}, SourceLanguage: "ja", Format: "vtt", Dir: t.TempDir(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"vid2.ja.vtt"}, result.Written)
+	})
+}
+
+func TestExportService_ExportVideoSubtitles_JSONTimed(t *testing.T) {
+	service := newTestSubtitleService()
+	dir := t.TempDir()
+
+	result, err := service.ExportVideoSubtitles(context.Background(), SubtitleExportOptions{
+		VideoID:   "vid1",
+		Languages: []string{OriginalLanguage, "ko"},
+		Format:    FormatJSONTimed,
+		Dir:       dir,
+		Words:     true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vid1.timed.json"}, result.Written)
+
+	data, err := os.ReadFile(filepath.Join(dir, "vid1.timed.json"))
+	require.NoError(t, err)
+	var doc TimedDocument
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	assert.Equal(t, TimedDocument{
+		Version:             1,
+		VideoID:             "vid1",
+		TranscriptionID:     "t1",
+		Language:            "en",
+		TranslationLanguage: "ko",
+		Duration:            6,
+		Cues: []TimedCue{
+			// Untranslated segments keep their cue
+			{Start: 0, End: 2.5, Text: "Hello", Words: []TimedWord{{Start: 0, End: 2.5, Text: "Hello"}}},
+			{Start: 2.5, End: 6, Text: "World", Translation: "세계", Words: []TimedWord{{Start: 2.5, End: 6, Text: "World"}}},
+		},
+	}, doc)
+
+	_, err = service.ExportVideoSubtitles(context.Background(), SubtitleExportOptions{
+		VideoID: "vid1", Languages: []string{"ja", "ko"}, Format: FormatJSONTimed, Dir: t.TempDir(),
+	})
+	assert.ErrorContains(t, err, "one translation language")
+}
+
+func TestEstimateWords(t *testing.T) {
+	words := estimateWords("a bbb", 0, 4*time.Second)
+	assert.Equal(t, []TimedWord{{Start: 0, End: 1, Text: "a"}, {Start: 1, End: 4, Text: "bbb"}}, words)
+}
+
+func TestEstimateWords_CJK(t *testing.T) {
+	words := estimateWords("日本 word", 0, 6*time.Second)
+	assert.Equal(t, []TimedWord{{Start: 0, End: 1, Text: "日"}, {Start: 1, End: 2, Text: "本"}, {Start: 2, End: 6, Text: "word"}}, words)
+
+	assert.Equal(t, []string{"で", "す。", "OK"}, splitWords("です。OK"))
+}
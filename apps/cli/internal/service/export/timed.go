@@ -0,0 +1,175 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
+)
+
+// FormatJSONTimed is the time-synced JSON format of ExportVideoSubtitles, built for custom web
+// players: one file per video whose cues carry the original text and its translation
+const FormatJSONTimed = "json-timed"
+
+// timedVersion is the schema version of json-timed files, bumped on incompatible changes
+const timedVersion = 1
+
+// TimedDocument is a json-timed file. Times are seconds from the start of the video.
+type TimedDocument struct {
+	Version             int        `json:"version"`
+	VideoID             string     `json:"video_id"`
+	TranscriptionID     string     `json:"transcription_id"`
+	Language            string     `json:"language"`                       // Spoken language of text ("original" when unknown)
+	TranslationLanguage string     `json:"translation_language,omitempty"` // Language of translation, if requested
+	Duration            float64    `json:"duration"`                       // End of the last cue
+	Cues                []TimedCue `json:"cues"`
+}
+
+// TimedCue is one transcription segment
+type TimedCue struct {
+	Start       float64     `json:"start"`
+	End         float64     `json:"end"`
+	Text        string      `json:"text"`
+	Translation string      `json:"translation,omitempty"` // Empty when the segment is not translated
+	Words       []TimedWord `json:"words,omitempty"`
+}
+
+// TimedWord is a word of a cue. Whisper segments carry no word timing, so the cue's time is
+// split between its words by their length.
+type TimedWord struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Text  string  `json:"text"`
+}
+
+// timedFile renders the json-timed file of a video: every segment of the transcription, with the
+// translation of the one translation language in opts.Languages (original is always included)
+func (s *exportService) timedFile(ctx context.Context, opts SubtitleExportOptions, transcription *model.Transcription, segments []*model.TranscriptionSegment) (subtitleFile, error) {
+	var translationLanguage string
+	for _, language := range opts.Languages {
+		if language == OriginalLanguage || language == translationLanguage {
+			continue
+		}
+		if translationLanguage != "" {
+			return subtitleFile{}, errors.New(errors.CodeInvalidArg, fmt.Sprintf("%s takes one translation language, got %s and %s", FormatJSONTimed, translationLanguage, language))
+		}
+		translationLanguage = language
+	}
+
+	translations := map[string]string{}
+	if translationLanguage != "" {
+		if s.translationRepo == nil {
+			return subtitleFile{}, errors.New(errors.CodeInternal, "translation repository is not configured")
+		}
+		var err error
+		if translations, err = s.segmentTranslations(ctx, transcription.ID, translationLanguage, "", opts.ApprovedOnly); err != nil {
+			return subtitleFile{}, err
+		}
+		if len(translations) == 0 {
+			return subtitleFile{}, errors.New(errors.CodeNotFound, fmt.Sprintf("no %s translations found for transcription %s", translationLanguage, transcription.ID))
+		}
+	}
+
+	doc := TimedDocument{
+		Version:             timedVersion,
+		VideoID:             opts.VideoID,
+		TranscriptionID:     transcription.ID,
+		Language:            originalFileLanguage(transcription),
+		TranslationLanguage: translationLanguage,
+		Cues:                make([]TimedCue, 0, len(segments)),
+	}
+	for _, segment := range segments {
+		start, err := timecode.ParseInterval(segment.StartTime)
+		if err != nil {
+			return subtitleFile{}, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("segment %d", segment.SegmentIndex))
+		}
+		end, err := timecode.ParseInterval(segment.EndTime)
+		if err != nil {
+			return subtitleFile{}, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("segment %d", segment.SegmentIndex))
+		}
+
+		text := strings.TrimSpace(segment.Text)
+		cue := TimedCue{
+			Start:       seconds(start),
+			End:         seconds(end),
+			Text:        text,
+			Translation: strings.TrimSpace(translations[segment.ID]),
+		}
+		if opts.Words {
+			cue.Words = estimateWords(text, start, end)
+		}
+		doc.Cues = append(doc.Cues, cue)
+		doc.Duration = math.Max(doc.Duration, cue.End)
+	}
+
+	content, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return subtitleFile{}, errors.Wrap(err, errors.CodeInternal, "failed to encode json-timed export")
+	}
+	return subtitleFile{name: opts.VideoID + ".timed.json", content: append(content, '\n')}, nil
+}
+
+// estimateWords splits [start, end] between the words of text in proportion to their rune count
+func estimateWords(text string, start, end time.Duration) []TimedWord {
+	words := splitWords(text)
+	total := 0
+	for _, word := range words {
+		total += utf8.RuneCountInString(word)
+	}
+	if total == 0 {
+		return nil
+	}
+
+	timed := make([]TimedWord, 0, len(words))
+	span := end - start
+	done := 0
+	for _, word := range words {
+		wordStart := start + span*time.Duration(done)/time.Duration(total)
+		done += utf8.RuneCountInString(word)
+		wordEnd := start + span*time.Duration(done)/time.Duration(total)
+		timed = append(timed, TimedWord{Start: seconds(wordStart), End: seconds(wordEnd), Text: word})
+	}
+	return timed
+}
+
+// splitWords splits text at spaces and, since Chinese and Japanese are written without them,
+// between ideographs and kana. Punctuation stays attached to the word before it.
+func splitWords(text string) []string {
+	var words []string
+	for _, field := range strings.Fields(text) {
+		current, closed := "", false // closed: current is a single ideograph or kana
+		for _, r := range field {
+			switch {
+			case unspaced(r), closed && !unicode.IsPunct(r):
+				if current != "" {
+					words = append(words, current)
+				}
+				current, closed = string(r), unspaced(r)
+			default:
+				current += string(r)
+			}
+		}
+		if current != "" {
+			words = append(words, current)
+		}
+	}
+	return words
+}
+
+// unspaced reports whether r belongs to a script written without spaces between words
+func unspaced(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
+
+// seconds converts d to seconds, rounded to milliseconds
+func seconds(d time.Duration) float64 {
+	return math.Round(d.Seconds()*1000) / 1000
+}
@@ -0,0 +1,169 @@
+package langprofile
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sort"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// DefaultMinShare is the share of a channel's transcribed videos a language needs to count as
+// one of its languages
+const DefaultMinShare = 0.2
+
+// ChannelRepository interface for reading and storing channel languages
+type ChannelRepository interface {
+	GetByID(ctx context.Context, id string) (*model.Channel, error)
+	GetLanguages(ctx context.Context, id string) ([]string, error)
+	SetLanguages(ctx context.Context, id string, languages []string) error
+}
+
+// VideoRepository interface for looking up the channel of a video
+type VideoRepository interface {
+	GetByID(ctx context.Context, id string) (*model.Video, error)
+}
+
+// TranscriptionRepository interface for counting the spoken languages of a channel
+type TranscriptionRepository interface {
+	CountSpokenLanguages(ctx context.Context, channelID string) (map[string]int, int, error)
+}
+
+// ProfileService infers the spoken languages of channels from their transcriptions
+type ProfileService interface {
+	// InferChannel counts the spoken languages of the channel's completed transcriptions and,
+	// unless opts.DryRun is set, stores the dominant ones on the channel
+	InferChannel(ctx context.Context, channelID string, opts InferOptions) (*Profile, error)
+
+	// DefaultLanguage returns the language to transcribe a video in when none is given: the
+	// language of its channel if the channel has exactly one, empty otherwise
+	DefaultLanguage(ctx context.Context, videoID string) (string, error)
+
+	// ChannelLanguage is DefaultLanguage for every video of a channel
+	ChannelLanguage(ctx context.Context, channelID string) (string, error)
+}
+
+// InferOptions configures InferChannel
+type InferOptions struct {
+	MinShare float64 // Share of transcribed videos a language needs (default DefaultMinShare)
+	DryRun   bool    // Compute the profile without storing it
+}
+
+// Profile is the language profile of a channel
+type Profile struct {
+	ChannelID string          `json:"channel_id"`
+	Videos    int             `json:"videos"` // Videos with at least one completed transcription
+	Counts    []LanguageCount `json:"counts"` // Every spoken language, most common first
+	Languages []string        `json:"languages"`
+	Stored    bool            `json:"stored"`
+}
+
+// LanguageCount is the number of transcribed videos spoken in a language
+type LanguageCount struct {
+	Language string  `json:"language"`
+	Videos   int     `json:"videos"`
+	Share    float64 `json:"share"`
+}
+
+// profileService implements ProfileService
+type profileService struct {
+	channelRepo       ChannelRepository
+	videoRepo         VideoRepository
+	transcriptionRepo TranscriptionRepository
+}
+
+// NewProfileService creates a new language profile service
+func NewProfileService(channelRepo ChannelRepository, videoRepo VideoRepository, transcriptionRepo TranscriptionRepository) ProfileService {
+	return &profileService{
+		channelRepo:       channelRepo,
+		videoRepo:         videoRepo,
+		transcriptionRepo: transcriptionRepo,
+	}
+}
+
+// InferChannel builds the language profile of a channel
+func (s *profileService) InferChannel(ctx context.Context, channelID string, opts InferOptions) (*Profile, error) {
+	if opts.MinShare <= 0 {
+		opts.MinShare = DefaultMinShare
+	}
+	if opts.MinShare > 1 {
+		return nil, errors.New(errors.CodeInvalidArg, fmt.Sprintf("minimum share must be between 0 and 1, got %g", opts.MinShare))
+	}
+
+	if _, err := s.channelRepo.GetByID(ctx, channelID); err != nil {
+		return nil, err
+	}
+
+	counts, videos, err := s.transcriptionRepo.CountSpokenLanguages(ctx, channelID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to count the spoken languages of channel %s", channelID))
+	}
+	profile := &Profile{ChannelID: channelID, Videos: videos, Counts: []LanguageCount{}, Languages: []string{}}
+	if profile.Videos == 0 {
+		return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("channel %s has no completed transcriptions to infer its languages from", channelID))
+	}
+
+	for language, count := range counts {
+		profile.Counts = append(profile.Counts, LanguageCount{
+			Language: language,
+			Videos:   count,
+			Share:    float64(count) / float64(profile.Videos),
+		})
+	}
+	sort.Slice(profile.Counts, func(i, j int) bool {
+		if profile.Counts[i].Videos != profile.Counts[j].Videos {
+			return profile.Counts[i].Videos > profile.Counts[j].Videos
+		}
+		return profile.Counts[i].Language < profile.Counts[j].Language
+	})
+	for _, count := range profile.Counts {
+		if count.Share >= opts.MinShare {
+			profile.Languages = append(profile.Languages, count.Language)
+		}
+	}
+
+	if opts.DryRun {
+		return profile, nil
+	}
+	if err := s.channelRepo.SetLanguages(ctx, channelID, profile.Languages); err != nil {
+		return nil, err
+	}
+	profile.Stored = true
+	return profile, nil
+}
+
+// DefaultLanguage returns the single stored language of the video's channel. Multilingual
+// channels return none, so each video is detected on its own.
+func (s *profileService) DefaultLanguage(ctx context.Context, videoID string) (string, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		if isNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return s.ChannelLanguage(ctx, video.ChannelID)
+}
+
+// ChannelLanguage returns the single stored language of a channel
+func (s *profileService) ChannelLanguage(ctx context.Context, channelID string) (string, error) {
+	languages, err := s.channelRepo.GetLanguages(ctx, channelID)
+	if err != nil {
+		if isNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	if len(languages) != 1 {
+		return "", nil
+	}
+	return languages[0], nil
+}
+
+// isNotFound reports whether err is a CodeNotFound application error
+func isNotFound(err error) bool {
+	var appErr *errors.AppError
+	return stderrors.As(err, &appErr) && appErr.Code == errors.CodeNotFound
+}
@@ -0,0 +1,169 @@
+package langprofile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// mockChannelRepo mocks ChannelRepository
+type mockChannelRepo struct {
+	languages map[string][]string
+}
+
+func (m *mockChannelRepo) GetByID(ctx context.Context, id string) (*model.Channel, error) {
+	if _, ok := m.languages[id]; !ok {
+		return nil, errors.New(errors.CodeNotFound, "channel not found")
+	}
+	return &model.Channel{ID: id}, nil
+}
+
+func (m *mockChannelRepo) GetLanguages(ctx context.Context, id string) ([]string, error) {
+	languages, ok := m.languages[id]
+	if !ok {
+		return nil, errors.New(errors.CodeNotFound, "channel not found")
+	}
+	return languages, nil
+}
+
+func (m *mockChannelRepo) SetLanguages(ctx context.Context, id string, languages []string) error {
+	m.languages[id] = languages
+	return nil
+}
+
+// mockVideoRepo mocks VideoRepository
+type mockVideoRepo struct {
+	videos []*model.Video
+}
+
+func (m *mockVideoRepo) GetByID(ctx context.Context, id string) (*model.Video, error) {
+	for _, v := range m.videos {
+		if v.ID == id {
+			return v, nil
+		}
+	}
+	return nil, errors.New(errors.CodeNotFound, "video not found")
+}
+
+// mockTranscriptionRepo mocks TranscriptionRepository, counting languages from transcriptions
+// by video like the grouped query does
+type mockTranscriptionRepo struct {
+	videos  *mockVideoRepo
+	byVideo map[string][]*model.Transcription
+}
+
+func (m *mockTranscriptionRepo) CountSpokenLanguages(ctx context.Context, channelID string) (map[string]int, int, error) {
+	counts := map[string]int{}
+	total := 0
+	for _, video := range m.videos.videos {
+		if video.ChannelID != channelID {
+			continue
+		}
+		seen := map[string]bool{}
+		for _, t := range m.byVideo[video.ID] {
+			language := t.Language
+			if t.DetectedLanguage != nil && *t.DetectedLanguage != "" {
+				language = *t.DetectedLanguage
+			}
+			if t.Status != "completed" || language == "" || language == "auto" || seen[language] {
+				continue
+			}
+			seen[language] = true
+			counts[language]++
+		}
+		if len(seen) > 0 {
+			total++
+		}
+	}
+	return counts, total, nil
+}
+
+func newTestProfileService() (ProfileService, *mockChannelRepo) {
+	japanese := "ja"
+	channels := &mockChannelRepo{languages: map[string][]string{"UC1": nil, "UC2": {"ja", "en"}, "UC3": nil}}
+	videos := &mockVideoRepo{videos: []*model.Video{
+		{ID: "v1", ChannelID: "UC1"}, {ID: "v2", ChannelID: "UC1"}, {ID: "v3", ChannelID: "UC1"},
+		{ID: "v4", ChannelID: "UC1"}, {ID: "v5", ChannelID: "UC1"}, {ID: "v6", ChannelID: "UC1"},
+		{ID: "v7", ChannelID: "UC2"}, {ID: "v8", ChannelID: "UC3"},
+	}}
+	transcriptions := &mockTranscriptionRepo{videos: videos, byVideo: map[string][]*model.Transcription{
+		"v1": {{Language: "auto", DetectedLanguage: &japanese, Status: "completed"}},
+		"v2": {{Language: "ja", Status: "completed"}, {Language: "en", Status: "completed"}},
+		"v3": {{Language: "ja", Status: "completed"}},
+		"v4": {{Language: "ja", Status: "completed"}},
+		"v5": {{Language: "ko", Status: "failed"}},
+		"v8": {{Language: "auto", Status: "failed"}},
+	}}
+	return NewProfileService(channels, videos, transcriptions), channels
+}
+
+func TestProfileService_InferChannel(t *testing.T) {
+	service, channels := newTestProfileService()
+
+	profile, err := service.InferChannel(context.Background(), "UC1", InferOptions{MinShare: 0.3})
+	require.NoError(t, err)
+	assert.Equal(t, 4, profile.Videos)
+	assert.Equal(t, []LanguageCount{
+		{Language: "ja", Videos: 4, Share: 1},
+		{Language: "en", Videos: 1, Share: 0.25},
+	}, profile.Counts)
+	assert.Equal(t, []string{"ja"}, profile.Languages)
+	assert.True(t, profile.Stored)
+	assert.Equal(t, []string{"ja"}, channels.languages["UC1"])
+
+	// A dry run leaves the stored languages alone
+	profile, err = service.InferChannel(context.Background(), "UC1", InferOptions{MinShare: 0.25, DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ja", "en"}, profile.Languages)
+	assert.False(t, profile.Stored)
+	assert.Equal(t, []string{"ja"}, channels.languages["UC1"])
+}
+
+func TestProfileService_InferChannel_Errors(t *testing.T) {
+	service, _ := newTestProfileService()
+
+	tests := []struct {
+		name      string
+		channelID string
+		opts      InferOptions
+		wantCode  string
+	}{
+		{name: "unknown channel", channelID: "UC9", wantCode: errors.CodeNotFound},
+		{name: "nothing transcribed", channelID: "UC3", wantCode: errors.CodeNotFound},
+		{name: "invalid share", channelID: "UC1", opts: InferOptions{MinShare: 1.5}, wantCode: errors.CodeInvalidArg},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.InferChannel(context.Background(), tt.channelID, tt.opts)
+			var appErr *errors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, tt.wantCode, appErr.Code)
+		})
+	}
+}
+
+func TestProfileService_DefaultLanguage(t *testing.T) {
+	service, _ := newTestProfileService()
+	_, err := service.InferChannel(context.Background(), "UC1", InferOptions{MinShare: 0.3})
+	require.NoError(t, err)
+
+	tests := []struct {
+		videoID string
+		want    string
+	}{
+		{videoID: "v6", want: "ja"},    // Single-language channel
+		{videoID: "v7", want: ""},      // Multilingual channel
+		{videoID: "v8", want: ""},      // Not inferred yet
+		{videoID: "missing", want: ""}, // Unknown video
+	}
+	for _, tt := range tests {
+		language, err := service.DefaultLanguage(context.Background(), tt.videoID)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, language, tt.videoID)
+	}
+}
@@ -0,0 +1,183 @@
+package prune
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/artifact"
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+	"github.com/Taichi-iskw/yt-lang/internal/service/transcription"
+)
+
+// DefaultOlderThan is the retention age used when neither a flag nor the config sets one
+const DefaultOlderThan = "2y"
+
+// VideoRepository interface for finding and deleting prunable videos
+type VideoRepository interface {
+	ListPruneCandidates(ctx context.Context, filter video.PruneFilter) ([]*model.Video, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// TranscriptionRepository interface for finding the transcriptions removed with a video
+type TranscriptionRepository interface {
+	GetByVideoID(ctx context.Context, videoID string) ([]*model.Transcription, error)
+}
+
+// Options configures a prune run
+type Options struct {
+	Before          time.Time // Videos uploaded before this date are pruned
+	KeepTranscribed bool      // Keep videos that have a transcription
+	DryRun          bool      // Report what would be removed without removing it
+}
+
+// Candidate is a pruned video with what is removed along with it
+type Candidate struct {
+	Video          *model.Video `json:"video"`
+	Transcriptions int          `json:"transcriptions"`
+	Artifacts      []string     `json:"artifacts"` // Store keys of cached audio and whisper output
+	Bytes          int64        `json:"bytes"`     // Size of the artifacts; 0 when the store cannot report sizes
+}
+
+// Result reports a prune run
+type Result struct {
+	Videos         []*Candidate `json:"videos"`
+	Transcriptions int          `json:"transcriptions"` // Transcription rows removed with the videos (segments and translations cascade)
+	Artifacts      int          `json:"artifacts"`
+	Bytes          int64        `json:"bytes"`
+	DryRun         bool         `json:"dry_run"`
+}
+
+// Service removes old untouched videos from the library
+type Service interface {
+	// Prune removes the videos uploaded before opts.Before that were never rated, annotated or,
+	// with KeepTranscribed, transcribed, together with their cached artifacts
+	Prune(ctx context.Context, opts Options) (*Result, error)
+}
+
+// service implements Service
+type service struct {
+	videoRepo         VideoRepository
+	transcriptionRepo TranscriptionRepository
+	store             artifact.Store
+}
+
+// NewService creates a new prune service. store may be nil, in which case no artifacts are removed.
+func NewService(videoRepo VideoRepository, transcriptionRepo TranscriptionRepository, store artifact.Store) Service {
+	return &service{videoRepo: videoRepo, transcriptionRepo: transcriptionRepo, store: store}
+}
+
+// Prune lists the candidates and, unless dry-running, deletes their artifacts and then the videos,
+// so an interrupted run leaves nothing orphaned
+func (s *service) Prune(ctx context.Context, opts Options) (*Result, error) {
+	if opts.Before.IsZero() {
+		return nil, errors.New(errors.CodeInvalidArg, "retention cutoff date is required")
+	}
+
+	videos, err := s.videoRepo.ListPruneCandidates(ctx, video.PruneFilter{UploadedBefore: opts.Before, KeepTranscribed: opts.KeepTranscribed})
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to list prune candidates")
+	}
+
+	result := &Result{Videos: make([]*Candidate, 0, len(videos)), DryRun: opts.DryRun}
+	for _, v := range videos {
+		candidate, err := s.candidate(ctx, v, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if !opts.DryRun {
+			for _, key := range candidate.Artifacts {
+				if err := s.store.Delete(ctx, key); err != nil {
+					return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to delete artifact %s", key))
+				}
+			}
+			if err := s.videoRepo.Delete(ctx, v.ID); err != nil {
+				return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to delete video %s", v.ID))
+			}
+		}
+
+		result.Videos = append(result.Videos, candidate)
+		result.Transcriptions += candidate.Transcriptions
+		result.Artifacts += len(candidate.Artifacts)
+		result.Bytes += candidate.Bytes
+	}
+	return result, nil
+}
+
+// candidate collects the transcriptions and stored artifacts of a video
+func (s *service) candidate(ctx context.Context, v *model.Video, opts Options) (*Candidate, error) {
+	candidate := &Candidate{Video: v, Artifacts: []string{}}
+	keys := transcription.CachedAudioKeys(v.ID)
+
+	if !opts.KeepTranscribed {
+		transcriptions, err := s.transcriptionRepo.GetByVideoID(ctx, v.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to list transcriptions for video %s", v.ID))
+		}
+		candidate.Transcriptions = len(transcriptions)
+		for _, t := range transcriptions {
+			keys = append(keys, artifact.WhisperKey(t.ID))
+		}
+	}
+
+	if s.store == nil {
+		return candidate, nil
+	}
+	for _, key := range keys {
+		size, found, err := s.size(ctx, key)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to check artifact %s", key))
+		}
+		if found {
+			candidate.Artifacts = append(candidate.Artifacts, key)
+			candidate.Bytes += size
+		}
+	}
+	return candidate, nil
+}
+
+// size reports whether an artifact is stored under key and its size, when the store can tell
+func (s *service) size(ctx context.Context, key string) (int64, bool, error) {
+	if sizer, ok := s.store.(artifact.Sizer); ok {
+		size, err := sizer.Size(ctx, key)
+		if stderrors.Is(err, artifact.ErrNotFound) {
+			return 0, false, nil
+		}
+		return size, err == nil, err
+	}
+	exists, err := s.store.Exists(ctx, key)
+	return 0, exists, err
+}
+
+// Cutoff returns the date an age such as 90d, 6w, 18mo or 2y before now
+func Cutoff(age string, now time.Time) (time.Time, error) {
+	units := []struct {
+		suffix              string
+		years, months, days int
+	}{
+		{"mo", 0, 1, 0},
+		{"y", 1, 0, 0},
+		{"w", 0, 0, 7},
+		{"d", 0, 0, 1},
+	}
+
+	age = strings.ToLower(strings.TrimSpace(age))
+	for _, unit := range units {
+		number, ok := strings.CutSuffix(age, unit.suffix)
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil || n <= 0 {
+			break
+		}
+		return now.AddDate(-n*unit.years, -n*unit.months, -n*unit.days), nil
+	}
+	return time.Time{}, errors.New(errors.CodeInvalidArg, fmt.Sprintf("invalid age %q (use a positive number of d, w, mo or y, e.g. 2y)", age))
+}
@@ -0,0 +1,124 @@
+package prune
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/artifact"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+)
+
+// mockVideoRepo mocks VideoRepository
+type mockVideoRepo struct {
+	candidates []*model.Video
+	filter     video.PruneFilter
+	deleted    []string
+}
+
+func (m *mockVideoRepo) ListPruneCandidates(ctx context.Context, filter video.PruneFilter) ([]*model.Video, error) {
+	m.filter = filter
+	return m.candidates, nil
+}
+
+func (m *mockVideoRepo) Delete(ctx context.Context, id string) error {
+	m.deleted = append(m.deleted, id)
+	return nil
+}
+
+// mockTranscriptionRepo mocks TranscriptionRepository
+type mockTranscriptionRepo struct {
+	byVideo map[string][]*model.Transcription
+}
+
+func (m *mockTranscriptionRepo) GetByVideoID(ctx context.Context, videoID string) ([]*model.Transcription, error) {
+	return m.byVideo[videoID], nil
+}
+
+func newTestStore(t *testing.T) *artifact.LocalStore {
+	store := artifact.NewLocalStore(t.TempDir())
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, artifact.AudioKey("v1", ".m4a"), strings.NewReader("audio")))
+	require.NoError(t, store.Put(ctx, artifact.WhisperKey("t2"), strings.NewReader("{}")))
+	return store
+}
+
+func TestService_Prune(t *testing.T) {
+	ctx := context.Background()
+	before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	transcriptionRepo := &mockTranscriptionRepo{byVideo: map[string][]*model.Transcription{"v2": {{ID: "t2", VideoID: "v2"}}}}
+
+	t.Run("dry run keeps everything", func(t *testing.T) {
+		videoRepo := &mockVideoRepo{candidates: []*model.Video{{ID: "v1"}, {ID: "v2"}}}
+		store := newTestStore(t)
+
+		result, err := NewService(videoRepo, transcriptionRepo, store).Prune(ctx, Options{Before: before, DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, video.PruneFilter{UploadedBefore: before}, videoRepo.filter)
+		require.Len(t, result.Videos, 2)
+		assert.Equal(t, []string{"audio/v1.m4a"}, result.Videos[0].Artifacts)
+		assert.Equal(t, []string{"whisper/t2.json.gz"}, result.Videos[1].Artifacts)
+		assert.Equal(t, 1, result.Transcriptions)
+		assert.Equal(t, 2, result.Artifacts)
+		assert.Equal(t, int64(len("audio")+len("{}")), result.Bytes)
+		assert.True(t, result.DryRun)
+
+		assert.Empty(t, videoRepo.deleted)
+		exists, err := store.Exists(ctx, artifact.AudioKey("v1", ".m4a"))
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("keep transcribed leaves transcriptions alone", func(t *testing.T) {
+		videoRepo := &mockVideoRepo{candidates: []*model.Video{{ID: "v1"}}}
+		store := newTestStore(t)
+
+		result, err := NewService(videoRepo, transcriptionRepo, store).Prune(ctx, Options{Before: before, KeepTranscribed: true})
+		require.NoError(t, err)
+		assert.True(t, videoRepo.filter.KeepTranscribed)
+		assert.Equal(t, []string{"v1"}, videoRepo.deleted)
+		assert.Equal(t, 0, result.Transcriptions)
+		assert.Equal(t, int64(len("audio")), result.Bytes)
+
+		exists, err := store.Exists(ctx, artifact.AudioKey("v1", ".m4a"))
+		require.NoError(t, err)
+		assert.False(t, exists)
+		exists, err = store.Exists(ctx, artifact.WhisperKey("t2"))
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("missing cutoff", func(t *testing.T) {
+		_, err := NewService(&mockVideoRepo{}, transcriptionRepo, nil).Prune(ctx, Options{})
+		assert.ErrorContains(t, err, "cutoff")
+	})
+}
+
+func TestCutoff(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		age  string
+		want time.Time
+	}{
+		{age: "2y", want: time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)},
+		{age: "18mo", want: time.Date(2024, 9, 15, 12, 0, 0, 0, time.UTC)},
+		{age: "6w", want: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)},
+		{age: "90D", want: time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := Cutoff(tt.age, now)
+		require.NoError(t, err, tt.age)
+		assert.Equal(t, tt.want, got, tt.age)
+	}
+
+	for _, age := range []string{"", "2", "0y", "-1y", "2h", "y"} {
+		_, err := Cutoff(age, now)
+		assert.Error(t, err, age)
+	}
+}
@@ -0,0 +1,240 @@
+package selftest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/janitor"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+const (
+	// FixtureVideoID is the default video the pipeline runs against: "Me at the zoo" (19s),
+	// the first video uploaded to YouTube and one of the least likely to disappear
+	FixtureVideoID = "jNQXAC9IVRw"
+
+	// FixtureLanguage is the spoken language of the fixture video
+	FixtureLanguage = "en"
+)
+
+// Step statuses
+const (
+	StatusPass = "pass"
+	StatusFail = "fail"
+	StatusSkip = "skip"
+)
+
+// CmdRunner runs external commands
+type CmdRunner interface {
+	Run(ctx context.Context, name string, args ...string) ([]byte, error)
+}
+
+// Downloader downloads the audio of a video
+type Downloader interface {
+	DownloadAudio(ctx context.Context, videoURL string, outputDir string) (string, error)
+}
+
+// Transcriber transcribes an audio file with whisper
+type Transcriber interface {
+	TranscribeAudio(ctx context.Context, audioPath string, language string) (*model.WhisperResult, error)
+}
+
+// Translator translates a single text
+type Translator interface {
+	Translate(ctx context.Context, text string, fromLang, toLang string) (string, error)
+}
+
+// Store writes a transcription to the database and reads it back without keeping it
+type Store interface {
+	WriteTranscription(ctx context.Context, videoID string, result *model.WhisperResult) (int, error)
+}
+
+// Options configures a self-test run
+type Options struct {
+	VideoID         string // Fixture video; defaults to FixtureVideoID
+	AudioPath       string // Local audio fixture; skips yt-dlp when set
+	Language        string // Spoken language passed to whisper; defaults to FixtureLanguage for the fixture video, auto otherwise
+	TargetLanguage  string // Language the first segment is translated to
+	SkipTranslation bool
+}
+
+// StepResult is the outcome of one pipeline stage
+type StepResult struct {
+	Name     string        `json:"name"`
+	Status   string        `json:"status"`
+	Detail   string        `json:"detail"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Report collects the outcome of every stage of a self-test run
+type Report struct {
+	Steps []StepResult `json:"steps"`
+}
+
+// Passed reports whether no stage failed
+func (r *Report) Passed() bool {
+	for _, step := range r.Steps {
+		if step.Status == StatusFail {
+			return false
+		}
+	}
+	return true
+}
+
+// Runner runs the pipeline stages in order against a fixture
+type Runner struct {
+	cmdRunner   CmdRunner
+	downloader  Downloader
+	transcriber Transcriber
+	store       Store
+	translator  Translator
+}
+
+// NewRunner creates a self-test runner. store and translator may be nil, in which case
+// their stages fail with a configuration error.
+func NewRunner(cmdRunner CmdRunner, downloader Downloader, transcriber Transcriber, store Store, translator Translator) *Runner {
+	return &Runner{
+		cmdRunner:   cmdRunner,
+		downloader:  downloader,
+		transcriber: transcriber,
+		store:       store,
+		translator:  translator,
+	}
+}
+
+// Run executes every stage and reports each outcome. A failed stage skips the stages
+// that depend on its output instead of aborting the run.
+func (r *Runner) Run(ctx context.Context, opts Options) *Report {
+	if opts.Language == "" {
+		opts.Language = "auto"
+		if opts.VideoID == "" && opts.AudioPath == "" {
+			opts.Language = FixtureLanguage
+		}
+	}
+	if opts.VideoID == "" {
+		opts.VideoID = FixtureVideoID
+	}
+	if opts.TargetLanguage == "" {
+		opts.TargetLanguage = "ja"
+	}
+
+	report := &Report{}
+	tempDir, err := janitor.MkdirTemp("yt-lang-selftest-*")
+	if err != nil {
+		report.add("setup", 0, "", fmt.Errorf("failed to create temp directory: %w", err))
+		return report
+	}
+	defer janitor.RemoveTemp(tempDir)
+
+	// Audio: a local fixture, or yt-dlp against the fixture video
+	audioPath := opts.AudioPath
+	if audioPath != "" {
+		report.skip("yt-dlp", "using local audio fixture")
+		report.skip("download", "using local audio fixture "+audioPath)
+	} else {
+		ok := report.run("yt-dlp", func() (string, error) {
+			output, err := r.cmdRunner.Run(ctx, "yt-dlp", "--version")
+			if err != nil {
+				return "", fmt.Errorf("yt-dlp is not installed or not found in PATH: %w", err)
+			}
+			return "version " + strings.TrimSpace(string(output)), nil
+		})
+		if ok {
+			report.run("download", func() (string, error) {
+				videoURL := "https://www.youtube.com/watch?v=" + opts.VideoID
+				path, err := r.downloader.DownloadAudio(ctx, videoURL, tempDir)
+				if err != nil {
+					return "", err
+				}
+				audioPath = path
+				return fmt.Sprintf("downloaded %s", opts.VideoID), nil
+			})
+		} else {
+			report.skip("download", "yt-dlp is unavailable")
+		}
+	}
+
+	// Transcription
+	var result *model.WhisperResult
+	if audioPath == "" {
+		report.skip("whisper", "no audio to transcribe")
+	} else {
+		report.run("whisper", func() (string, error) {
+			res, err := r.transcriber.TranscribeAudio(ctx, audioPath, opts.Language)
+			if err != nil {
+				return "", err
+			}
+			if len(res.Segments) == 0 {
+				return "", fmt.Errorf("whisper returned no segments")
+			}
+			result = res
+			return fmt.Sprintf("%d segments, language %s", len(res.Segments), res.Language), nil
+		})
+	}
+
+	// Database writes
+	if result == nil {
+		report.skip("database", "no transcription to store")
+	} else {
+		report.run("database", func() (string, error) {
+			if r.store == nil {
+				return "", fmt.Errorf("database is not configured")
+			}
+			count, err := r.store.WriteTranscription(ctx, opts.VideoID, result)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("wrote and read back %d segments (rolled back)", count), nil
+		})
+	}
+
+	// Translation wiring
+	switch {
+	case opts.SkipTranslation:
+		report.skip("translation", "disabled by --skip-translation")
+	case result == nil:
+		report.skip("translation", "no transcription to translate")
+	default:
+		report.run("translation", func() (string, error) {
+			if r.translator == nil {
+				return "", fmt.Errorf("translator is not configured")
+			}
+			text := strings.TrimSpace(result.Segments[0].Text)
+			translated, err := r.translator.Translate(ctx, text, result.Language, opts.TargetLanguage)
+			if err != nil {
+				return "", err
+			}
+			if strings.TrimSpace(translated) == "" {
+				return "", fmt.Errorf("translation of %q is empty", text)
+			}
+			return fmt.Sprintf("%q -> %q", text, strings.TrimSpace(translated)), nil
+		})
+	}
+
+	return report
+}
+
+// run times fn and records its outcome, reporting whether it passed
+func (r *Report) run(name string, fn func() (string, error)) bool {
+	start := time.Now()
+	detail, err := fn()
+	r.add(name, time.Since(start), detail, err)
+	return err == nil
+}
+
+// add records a finished stage
+func (r *Report) add(name string, duration time.Duration, detail string, err error) {
+	step := StepResult{Name: name, Status: StatusPass, Detail: detail, Duration: duration}
+	if err != nil {
+		step.Status = StatusFail
+		step.Detail = err.Error()
+	}
+	r.Steps = append(r.Steps, step)
+}
+
+// skip records a stage that did not run
+func (r *Report) skip(name, reason string) {
+	r.Steps = append(r.Steps, StepResult{Name: name, Status: StatusSkip, Detail: reason})
+}
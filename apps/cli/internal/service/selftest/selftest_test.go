@@ -0,0 +1,129 @@
+package selftest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCmdRunner returns a fixed result for every command
+type fakeCmdRunner struct {
+	output []byte
+	err    error
+}
+
+func (r *fakeCmdRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return r.output, r.err
+}
+
+type fakeDownloader struct {
+	urls []string
+	err  error
+}
+
+func (d *fakeDownloader) DownloadAudio(ctx context.Context, videoURL string, outputDir string) (string, error) {
+	d.urls = append(d.urls, videoURL)
+	return outputDir + "/audio.m4a", d.err
+}
+
+type fakeTranscriber struct {
+	paths  []string
+	result *model.WhisperResult
+}
+
+func (t *fakeTranscriber) TranscribeAudio(ctx context.Context, audioPath string, language string) (*model.WhisperResult, error) {
+	t.paths = append(t.paths, audioPath)
+	return t.result, nil
+}
+
+type fakeStore struct{ err error }
+
+func (s *fakeStore) WriteTranscription(ctx context.Context, videoID string, result *model.WhisperResult) (int, error) {
+	return len(result.Segments), s.err
+}
+
+type fakeTranslator struct{}
+
+func (fakeTranslator) Translate(ctx context.Context, text string, fromLang, toLang string) (string, error) {
+	return "ぼくはゾウの前にいます", nil
+}
+
+func fixtureResult() *model.WhisperResult {
+	return &model.WhisperResult{
+		Language: "en",
+		Segments: []model.WhisperSegment{{Start: 0, End: 4, Text: " All right, so here we are in front of the elephants."}},
+	}
+}
+
+func statuses(report *Report) map[string]string {
+	result := map[string]string{}
+	for _, step := range report.Steps {
+		result[step.Name] = step.Status
+	}
+	return result
+}
+
+func TestRunner_Run(t *testing.T) {
+	t.Run("full pipeline passes", func(t *testing.T) {
+		downloader := &fakeDownloader{}
+		runner := NewRunner(&fakeCmdRunner{output: []byte("2025.06.30\n")}, downloader,
+			&fakeTranscriber{result: fixtureResult()}, &fakeStore{}, fakeTranslator{})
+
+		report := runner.Run(context.Background(), Options{})
+		assert.True(t, report.Passed())
+		assert.Equal(t, map[string]string{
+			"yt-dlp": StatusPass, "download": StatusPass, "whisper": StatusPass,
+			"database": StatusPass, "translation": StatusPass,
+		}, statuses(report))
+		assert.Equal(t, []string{"https://www.youtube.com/watch?v=" + FixtureVideoID}, downloader.urls)
+		assert.Equal(t, "version 2025.06.30", report.Steps[0].Detail)
+	})
+
+	t.Run("local audio fixture skips yt-dlp", func(t *testing.T) {
+		transcriber := &fakeTranscriber{result: fixtureResult()}
+		runner := NewRunner(&fakeCmdRunner{err: errors.New("not found")}, &fakeDownloader{},
+			transcriber, &fakeStore{}, fakeTranslator{})
+
+		report := runner.Run(context.Background(), Options{AudioPath: "fixture.wav", SkipTranslation: true})
+		assert.True(t, report.Passed())
+		assert.Equal(t, StatusSkip, statuses(report)["yt-dlp"])
+		assert.Equal(t, StatusSkip, statuses(report)["translation"])
+		assert.Equal(t, []string{"fixture.wav"}, transcriber.paths)
+	})
+
+	t.Run("missing yt-dlp skips dependent stages", func(t *testing.T) {
+		runner := NewRunner(&fakeCmdRunner{err: errors.New("executable file not found")}, &fakeDownloader{},
+			&fakeTranscriber{result: fixtureResult()}, &fakeStore{}, fakeTranslator{})
+
+		report := runner.Run(context.Background(), Options{})
+		require.False(t, report.Passed())
+		assert.Equal(t, map[string]string{
+			"yt-dlp": StatusFail, "download": StatusSkip, "whisper": StatusSkip,
+			"database": StatusSkip, "translation": StatusSkip,
+		}, statuses(report))
+		assert.Contains(t, report.Steps[0].Detail, "not found in PATH")
+	})
+
+	t.Run("database failure does not block translation", func(t *testing.T) {
+		runner := NewRunner(&fakeCmdRunner{}, &fakeDownloader{},
+			&fakeTranscriber{result: fixtureResult()}, &fakeStore{err: errors.New("relation \"videos\" does not exist")}, fakeTranslator{})
+
+		report := runner.Run(context.Background(), Options{})
+		assert.False(t, report.Passed())
+		assert.Equal(t, StatusFail, statuses(report)["database"])
+		assert.Equal(t, StatusPass, statuses(report)["translation"])
+	})
+
+	t.Run("no segments fails whisper", func(t *testing.T) {
+		runner := NewRunner(&fakeCmdRunner{}, &fakeDownloader{},
+			&fakeTranscriber{result: &model.WhisperResult{Language: "en"}}, nil, nil)
+
+		report := runner.Run(context.Background(), Options{})
+		assert.Equal(t, StatusFail, statuses(report)["whisper"])
+		assert.Equal(t, StatusSkip, statuses(report)["database"])
+	})
+}
@@ -0,0 +1,124 @@
+package selftest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/channel"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+)
+
+// selftestChannelID is the placeholder channel the fixture video is stored under
+const selftestChannelID = "UCselftest0000000000000"
+
+// Beginner starts database transactions
+type Beginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// rollbackStore writes through the real repositories inside a transaction that is always
+// rolled back, so the self-test never leaves rows behind
+type rollbackStore struct {
+	db Beginner
+}
+
+// NewRollbackStore creates a Store that exercises the repositories on db without committing
+func NewRollbackStore(db Beginner) Store {
+	return &rollbackStore{db: db}
+}
+
+// WriteTranscription stores the fixture channel, video, transcription and segments, then reads the segments back
+func (s *rollbackStore) WriteTranscription(ctx context.Context, videoID string, result *model.WhisperResult) (int, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	pool := txPool{tx}
+
+	// Reuse rows that already exist so the fixture can be a video the user has synced
+	if _, err := video.NewRepository(pool).GetByID(ctx, videoID); err != nil {
+		if err := channel.NewRepository(pool).Create(ctx, &model.Channel{
+			ID:   selftestChannelID,
+			Name: "yt-lang selftest",
+			URL:  "https://www.youtube.com/channel/" + selftestChannelID,
+		}); err != nil {
+			return 0, err
+		}
+		if err := video.NewRepository(pool).Create(ctx, &model.Video{
+			ID:        videoID,
+			ChannelID: selftestChannelID,
+			Title:     "yt-lang selftest fixture",
+			URL:       "https://www.youtube.com/watch?v=" + videoID,
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	transcriptionRepo := transcription.NewRepository(pool)
+	t := &model.Transcription{
+		VideoID:   videoID,
+		Language:  "selftest",
+		Status:    "completed",
+		CreatedAt: time.Now(),
+	}
+	if err := transcriptionRepo.Create(ctx, t); err != nil {
+		return 0, err
+	}
+
+	segments := make([]*model.TranscriptionSegment, len(result.Segments))
+	for i, seg := range result.Segments {
+		confidence := seg.Confidence
+		segments[i] = &model.TranscriptionSegment{
+			TranscriptionID: t.ID,
+			SegmentIndex:    i,
+			StartTime:       fmt.Sprintf("%.3f", seg.Start),
+			EndTime:         fmt.Sprintf("%.3f", seg.End),
+			Text:            seg.Text,
+			Confidence:      &confidence,
+		}
+	}
+
+	segmentRepo := transcription.NewSegmentRepository(pool)
+	if err := segmentRepo.CreateBatch(ctx, segments); err != nil {
+		return 0, err
+	}
+
+	stored, err := segmentRepo.GetByTranscriptionID(ctx, t.ID)
+	if err != nil {
+		return 0, err
+	}
+	if len(stored) != len(segments) {
+		return 0, fmt.Errorf("wrote %d segments but read back %d", len(segments), len(stored))
+	}
+	return len(stored), nil
+}
+
+// failedStore reports why the database could not be reached
+type failedStore struct {
+	err error
+}
+
+// NewFailedStore creates a Store whose writes fail with err, for when the database is unreachable
+func NewFailedStore(err error) Store {
+	return failedStore{err: err}
+}
+
+// WriteTranscription returns the connection error
+func (s failedStore) WriteTranscription(ctx context.Context, videoID string, result *model.WhisperResult) (int, error) {
+	return 0, s.err
+}
+
+// txPool adapts a transaction to the repositories' Pool interface
+type txPool struct {
+	pgx.Tx
+}
+
+// Close is a no-op; the transaction is ended by WriteTranscription
+func (txPool) Close() {}
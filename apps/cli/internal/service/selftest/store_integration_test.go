@@ -0,0 +1,34 @@
+//go:build integration
+
+package selftest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRollbackStore_Integration checks the self-test writes leave no rows behind
+func TestRollbackStore_Integration(t *testing.T) {
+	pool := common.SetupTestDB(t)
+	ctx := context.Background()
+
+	store := NewRollbackStore(pool)
+	count, err := store.WriteTranscription(ctx, FixtureVideoID, &model.WhisperResult{
+		Language: "en",
+		Segments: []model.WhisperSegment{
+			{Start: 0, End: 1.5, Text: "Alright"},
+			{Start: 1.5, End: 4, Text: "so here we are"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	var videos int
+	require.NoError(t, pool.QueryRow(ctx, "SELECT COUNT(*) FROM videos WHERE id = $1", FixtureVideoID).Scan(&videos))
+	assert.Zero(t, videos, "self-test rows must be rolled back")
+}
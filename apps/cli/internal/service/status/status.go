@@ -0,0 +1,209 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/artifact"
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/service/export"
+	"github.com/Taichi-iskw/yt-lang/internal/service/transcription"
+)
+
+// Workflow stages a video moves through, in order
+const (
+	StageFetched     = "fetched"      // Saved from the channel
+	StageAudioCached = "audio_cached" // Audio kept in the artifact store
+	StageTranscribed = "transcribed"  // At least one completed transcription
+	StageTranslated  = "translated"   // At least one translated language
+	StageExported    = "exported"     // Transcripts written by export transcripts
+)
+
+// Stages lists every stage in workflow order
+var Stages = []string{StageFetched, StageAudioCached, StageTranscribed, StageTranslated, StageExported}
+
+// VideoRepository interface for accessing videos
+type VideoRepository interface {
+	GetByID(ctx context.Context, id string) (*model.Video, error)
+}
+
+// TranscriptionRepository interface for accessing transcription metadata
+type TranscriptionRepository interface {
+	GetByVideoID(ctx context.Context, videoID string) ([]*model.Transcription, error)
+}
+
+// TranslationRepository interface for accessing translation languages
+type TranslationRepository interface {
+	ListLanguagesByTranscriptionID(ctx context.Context, transcriptionID string) ([]string, error)
+}
+
+// VideoStatus is where a video is in the workflow. Every field is derived from stored data.
+type VideoStatus struct {
+	Video       *model.Video `json:"video"`
+	Stage       string       `json:"stage"` // Furthest stage reached
+	AudioCached bool         `json:"audio_cached"`
+	Transcribed []string     `json:"transcribed"` // Languages of completed transcriptions
+	Translated  []string     `json:"translated"`  // Target languages with translations
+	Exported    []string     `json:"exported"`    // Exported files of the video
+}
+
+// Reached reports whether the video has completed stage
+func (s *VideoStatus) Reached(stage string) bool {
+	switch stage {
+	case StageFetched:
+		return true
+	case StageAudioCached:
+		return s.AudioCached
+	case StageTranscribed:
+		return len(s.Transcribed) > 0
+	case StageTranslated:
+		return len(s.Translated) > 0
+	case StageExported:
+		return len(s.Exported) > 0
+	}
+	return false
+}
+
+// StatusService derives the workflow state of videos
+type StatusService interface {
+	// GetVideoStatus computes the status of a single video
+	GetVideoStatus(ctx context.Context, videoID string) (*VideoStatus, error)
+
+	// GetVideoStatuses computes the status of already loaded videos
+	GetVideoStatuses(ctx context.Context, videos []*model.Video) ([]*VideoStatus, error)
+}
+
+// statusService implements StatusService
+type statusService struct {
+	videoRepo         VideoRepository
+	transcriptionRepo TranscriptionRepository
+	translationRepo   TranslationRepository
+	store             artifact.Store // Optional; audio cache and storage exports are not checked when nil
+	exportDir         string         // Optional local export directory checked for exported files
+}
+
+// NewStatusService creates a new status service
+func NewStatusService(videoRepo VideoRepository, transcriptionRepo TranscriptionRepository, translationRepo TranslationRepository, store artifact.Store, exportDir string) StatusService {
+	return &statusService{
+		videoRepo:         videoRepo,
+		transcriptionRepo: transcriptionRepo,
+		translationRepo:   translationRepo,
+		store:             store,
+		exportDir:         exportDir,
+	}
+}
+
+// GetVideoStatus computes the status of a single video
+func (s *statusService) GetVideoStatus(ctx context.Context, videoID string) (*VideoStatus, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeNotFound, "video not found")
+	}
+
+	statuses, err := s.GetVideoStatuses(ctx, []*model.Video{video})
+	if err != nil {
+		return nil, err
+	}
+	return statuses[0], nil
+}
+
+// GetVideoStatuses computes the status of videos, reading each channel's export manifests once
+func (s *statusService) GetVideoStatuses(ctx context.Context, videos []*model.Video) ([]*VideoStatus, error) {
+	exported := make(map[string][]string) // channel ID -> exported file names
+
+	statuses := make([]*VideoStatus, len(videos))
+	for i, video := range videos {
+		status := &VideoStatus{Video: video, Transcribed: []string{}, Translated: []string{}, Exported: []string{}}
+
+		if s.store != nil {
+			cached, err := transcription.AudioCached(ctx, s.store, video.ID)
+			if err != nil {
+				return nil, errors.Wrap(err, errors.CodeInternal, "failed to check audio cache")
+			}
+			status.AudioCached = cached
+		}
+
+		if err := s.addTranscriptions(ctx, status); err != nil {
+			return nil, err
+		}
+
+		files, ok := exported[video.ChannelID]
+		if !ok {
+			var err error
+			if files, err = s.exportedFiles(ctx, video.ChannelID); err != nil {
+				return nil, errors.Wrap(err, errors.CodeInternal, "failed to read export manifest")
+			}
+			exported[video.ChannelID] = files
+		}
+		marker := "[" + video.ID + "]."
+		for _, name := range files {
+			if strings.Contains(name, marker) {
+				status.Exported = append(status.Exported, name)
+			}
+		}
+
+		status.Stage = StageFetched
+		for _, stage := range Stages {
+			if status.Reached(stage) {
+				status.Stage = stage
+			}
+		}
+		statuses[i] = status
+	}
+
+	return statuses, nil
+}
+
+// addTranscriptions records the languages of completed transcriptions and their translations
+func (s *statusService) addTranscriptions(ctx context.Context, status *VideoStatus) error {
+	transcriptions, err := s.transcriptionRepo.GetByVideoID(ctx, status.Video.ID)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to list transcriptions for video %s", status.Video.ID))
+	}
+
+	translated := make(map[string]bool)
+	for _, t := range transcriptions {
+		if t.Status != "completed" {
+			continue
+		}
+		status.Transcribed = append(status.Transcribed, t.Language)
+
+		languages, err := s.translationRepo.ListLanguagesByTranscriptionID(ctx, t.ID)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to list translations for transcription %s", t.ID))
+		}
+		for _, language := range languages {
+			translated[language] = true
+		}
+	}
+
+	for language := range translated {
+		status.Translated = append(status.Translated, language)
+	}
+	sort.Strings(status.Transcribed)
+	sort.Strings(status.Translated)
+	return nil
+}
+
+// exportedFiles lists the files exported for a channel to the artifact store and the local export directory
+func (s *statusService) exportedFiles(ctx context.Context, channelID string) ([]string, error) {
+	var files []string
+	if s.store != nil {
+		names, err := export.ExportedFiles(ctx, export.TranscriptExportOptions{ChannelID: channelID, Store: s.store})
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, names...)
+	}
+	if s.exportDir != "" {
+		names, err := export.ExportedFiles(ctx, export.TranscriptExportOptions{ChannelID: channelID, Dir: s.exportDir})
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, names...)
+	}
+	return files, nil
+}
@@ -0,0 +1,134 @@
+package status
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/Taichi-iskw/yt-lang/internal/artifact"
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/service/export"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockVideoRepo mocks VideoRepository
+type mockVideoRepo struct {
+	videos map[string]*model.Video
+}
+
+func (m *mockVideoRepo) GetByID(ctx context.Context, id string) (*model.Video, error) {
+	if video, ok := m.videos[id]; ok {
+		return video, nil
+	}
+	return nil, apperrors.New(apperrors.CodeNotFound, "video not found")
+}
+
+// mockTranscriptionRepo mocks TranscriptionRepository
+type mockTranscriptionRepo struct {
+	byVideo map[string][]*model.Transcription
+}
+
+func (m *mockTranscriptionRepo) GetByVideoID(ctx context.Context, videoID string) ([]*model.Transcription, error) {
+	return m.byVideo[videoID], nil
+}
+
+// mockTranslationRepo mocks TranslationRepository
+type mockTranslationRepo struct {
+	languages map[string][]string
+}
+
+func (m *mockTranslationRepo) ListLanguagesByTranscriptionID(ctx context.Context, transcriptionID string) ([]string, error) {
+	return m.languages[transcriptionID], nil
+}
+
+func TestStatusService_GetVideoStatuses(t *testing.T) {
+	ctx := context.Background()
+	videos := map[string]*model.Video{
+		"vid1": {ID: "vid1", ChannelID: "UC123", Title: "Fetched only"},
+		"vid2": {ID: "vid2", ChannelID: "UC123", Title: "Cached"},
+		"vid3": {ID: "vid3", ChannelID: "UC123", Title: "Translated"},
+		"vid4": {ID: "vid4", ChannelID: "UC123", Title: "Exported"},
+	}
+	transcriptionRepo := &mockTranscriptionRepo{byVideo: map[string][]*model.Transcription{
+		"vid1": {{ID: "t1", Language: "en", Status: "failed"}},
+		"vid3": {{ID: "t3a", Language: "ja", Status: "completed"}, {ID: "t3b", Language: "en", Status: "completed"}},
+		"vid4": {{ID: "t4", Language: "en", Status: "completed"}},
+	}}
+	translationRepo := &mockTranslationRepo{languages: map[string][]string{
+		"t3a": {"fr"},
+		"t3b": {"fr", "ja"},
+	}}
+
+	store := artifact.NewLocalStore(t.TempDir())
+	require.NoError(t, store.Put(ctx, artifact.AudioKey("vid2", ".opus"), bytes.NewReader([]byte("audio"))))
+
+	// vid4 was exported to a local directory
+	exportDir := t.TempDir()
+	_, err := export.NewExportService(
+		&channelVideos{videos: []*model.Video{videos["vid4"]}},
+		transcriptionRepo,
+		&oneSegment{},
+	).ExportChannelTranscripts(ctx, export.TranscriptExportOptions{ChannelID: "UC123", Format: "text", Dir: exportDir})
+	require.NoError(t, err)
+
+	service := NewStatusService(&mockVideoRepo{videos: videos}, transcriptionRepo, translationRepo, store, exportDir)
+
+	statuses, err := service.GetVideoStatuses(ctx, []*model.Video{videos["vid1"], videos["vid2"], videos["vid3"], videos["vid4"]})
+	require.NoError(t, err)
+	require.Len(t, statuses, 4)
+
+	assert.Equal(t, StageFetched, statuses[0].Stage)
+	assert.Empty(t, statuses[0].Transcribed)
+
+	assert.Equal(t, StageAudioCached, statuses[1].Stage)
+	assert.True(t, statuses[1].AudioCached)
+
+	assert.Equal(t, StageTranslated, statuses[2].Stage)
+	assert.Equal(t, []string{"en", "ja"}, statuses[2].Transcribed)
+	assert.Equal(t, []string{"fr", "ja"}, statuses[2].Translated)
+
+	assert.Equal(t, StageExported, statuses[3].Stage)
+	assert.Equal(t, []string{"Exported [vid4].en.txt"}, statuses[3].Exported)
+	assert.False(t, statuses[3].Reached(StageTranslated))
+}
+
+func TestStatusService_GetVideoStatus(t *testing.T) {
+	service := NewStatusService(
+		&mockVideoRepo{videos: map[string]*model.Video{"vid1": {ID: "vid1", ChannelID: "UC123"}}},
+		&mockTranscriptionRepo{},
+		&mockTranslationRepo{},
+		nil,
+		"",
+	)
+
+	status, err := service.GetVideoStatus(context.Background(), "vid1")
+	require.NoError(t, err)
+	assert.Equal(t, StageFetched, status.Stage)
+	assert.False(t, status.AudioCached)
+
+	_, err = service.GetVideoStatus(context.Background(), "missing")
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, apperrors.CodeNotFound, appErr.Code)
+}
+
+// channelVideos serves a fixed video list to the export service
+type channelVideos struct {
+	videos []*model.Video
+}
+
+func (c *channelVideos) GetByChannelID(ctx context.Context, channelID string, limit, offset int) ([]*model.Video, error) {
+	if offset > 0 {
+		return nil, nil
+	}
+	return c.videos, nil
+}
+
+// oneSegment returns one segment for every transcription
+type oneSegment struct{}
+
+func (oneSegment) GetByTranscriptionID(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error) {
+	return []*model.TranscriptionSegment{{StartTime: "00:00:00", EndTime: "00:00:01", Text: "Hi"}}, nil
+}
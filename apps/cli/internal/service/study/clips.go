@@ -0,0 +1,274 @@
+package study
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Taichi-iskw/yt-lang/internal/artifact"
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/janitor"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+	transcriptionSvc "github.com/Taichi-iskw/yt-lang/internal/service/transcription"
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
+)
+
+// ClipFormats are the audio formats shadowing clips can be cut to; ffmpeg picks the encoder
+// from the extension
+var ClipFormats = []string{"mp3", "m4a", "opus", "wav"}
+
+// DefaultClipPadding is kept before and after each segment, since whisper's timestamps often cut
+// into the first and last syllable
+const DefaultClipPadding = 250 * time.Millisecond
+
+// ClipManifestFile is the name of the manifest written next to the clips
+const ClipManifestFile = "manifest.json"
+
+// maxClipNameRunes caps the part of a clip's file name taken from its text
+const maxClipNameRunes = 40
+
+// ClipService cuts the audio of transcript segments into clips for shadowing practice
+type ClipService interface {
+	// ExtractClips cuts the requested segments out of the video's cached audio into
+	// opts.OutputDir and writes a manifest of the clips there
+	ExtractClips(ctx context.Context, opts ClipOptions) (*ClipManifest, error)
+}
+
+// ClipOptions configures clip extraction
+type ClipOptions struct {
+	VideoID        string
+	Language       string        // Transcription language; may be empty when the video has one transcription
+	TargetLanguage string        // Translation recorded with each clip (empty means none)
+	Segments       []int         // Indexes of the segments to cut, in the order listed
+	OutputDir      string        // Created when missing
+	Format         string        // One of ClipFormats; defaults to mp3
+	Padding        time.Duration // Audio kept before and after each segment
+}
+
+// ClipManifest lists the clips cut from a video, for importing them into a flashcard or
+// shadowing app
+type ClipManifest struct {
+	VideoID         string `json:"video_id"`
+	TranscriptionID string `json:"transcription_id"`
+	Language        string `json:"language"`
+	TargetLanguage  string `json:"target_language,omitempty"`
+	Clips           []Clip `json:"clips"`
+}
+
+// Clip is the audio of one segment
+type Clip struct {
+	SegmentIndex int    `json:"segment_index"`
+	File         string `json:"file"`       // Relative to the output directory
+	StartTime    string `json:"start_time"` // Of the segment, without padding
+	EndTime      string `json:"end_time"`
+	Text         string `json:"text"`
+	Translation  string `json:"translation,omitempty"` // Empty when the segment is not translated
+}
+
+// clipService implements ClipService with ffmpeg
+type clipService struct {
+	transcriptionRepo VideoTranscriptionRepository
+	segmentRepo       SegmentRepository
+	translationRepo   TranslationRepository
+	store             artifact.Store
+	cmdRunner         common.CmdRunner
+}
+
+// NewClipService creates a new clip service cutting the audio cached in store
+func NewClipService(transcriptionRepo VideoTranscriptionRepository, segmentRepo SegmentRepository, translationRepo TranslationRepository, store artifact.Store) ClipService {
+	return NewClipServiceWithCmdRunner(transcriptionRepo, segmentRepo, translationRepo, store, common.NewCmdRunner())
+}
+
+// NewClipServiceWithCmdRunner creates a new clip service with custom CmdRunner (for testing)
+func NewClipServiceWithCmdRunner(transcriptionRepo VideoTranscriptionRepository, segmentRepo SegmentRepository, translationRepo TranslationRepository, store artifact.Store, cmdRunner common.CmdRunner) ClipService {
+	return &clipService{
+		transcriptionRepo: transcriptionRepo,
+		segmentRepo:       segmentRepo,
+		translationRepo:   translationRepo,
+		store:             store,
+		cmdRunner:         cmdRunner,
+	}
+}
+
+func (s *clipService) ExtractClips(ctx context.Context, opts ClipOptions) (*ClipManifest, error) {
+	if opts.VideoID == "" {
+		return nil, errors.New(errors.CodeInvalidArg, "video ID is required")
+	}
+	if len(opts.Segments) == 0 {
+		return nil, errors.New(errors.CodeInvalidArg, "at least one segment is required")
+	}
+	if opts.OutputDir == "" {
+		return nil, errors.New(errors.CodeInvalidArg, "output directory is required")
+	}
+	if opts.Format == "" {
+		opts.Format = ClipFormats[0]
+	}
+	if !slices.Contains(ClipFormats, opts.Format) {
+		return nil, errors.New(errors.CodeInvalidArg, fmt.Sprintf("unsupported clip format %q (supported: %s)", opts.Format, strings.Join(ClipFormats, ", ")))
+	}
+
+	transcription, err := videoTranscription(ctx, s.transcriptionRepo, opts.VideoID, opts.Language)
+	if err != nil {
+		return nil, err
+	}
+	segments, err := s.segmentRepo.GetByTranscriptionID(ctx, transcription.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to get transcription segments")
+	}
+	byIndex := make(map[int]*model.TranscriptionSegment, len(segments))
+	for _, segment := range segments {
+		byIndex[segment.SegmentIndex] = segment
+	}
+	for _, index := range opts.Segments {
+		if byIndex[index] == nil {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("transcription %s has no segment %d (it has %d segments)", transcription.ID, index, len(segments)))
+		}
+	}
+	translations, err := segmentTranslations(ctx, s.translationRepo, transcription.ID, opts.TargetLanguage)
+	if err != nil {
+		return nil, err
+	}
+
+	tempDir, err := janitor.MkdirTemp("yt-lang-clips-*")
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to create temp directory")
+	}
+	defer janitor.RemoveTemp(tempDir)
+
+	audioPath, err := s.restoreAudio(ctx, opts.VideoID, tempDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to create output directory")
+	}
+
+	manifest := &ClipManifest{
+		VideoID:         opts.VideoID,
+		TranscriptionID: transcription.ID,
+		Language:        transcription.Language,
+		TargetLanguage:  opts.TargetLanguage,
+		Clips:           make([]Clip, 0, len(opts.Segments)),
+	}
+	for _, index := range opts.Segments {
+		segment := byIndex[index]
+		start, err := timecode.ParseInterval(segment.StartTime)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("invalid start of segment %d", index))
+		}
+		end, err := timecode.ParseInterval(segment.EndTime)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("invalid end of segment %d", index))
+		}
+
+		text := strings.TrimSpace(segment.Text)
+		clip := Clip{
+			SegmentIndex: index,
+			File:         fmt.Sprintf("%04d-%s.%s", index, clipName(text), opts.Format),
+			StartTime:    segment.StartTime,
+			EndTime:      segment.EndTime,
+			Text:         text,
+			Translation:  strings.TrimSpace(translations[segment.ID]),
+		}
+		if err := s.cut(ctx, audioPath, filepath.Join(opts.OutputDir, clip.File), max(start-opts.Padding, 0), end+opts.Padding); err != nil {
+			return nil, errors.Wrap(err, errors.CodeExternal, fmt.Sprintf("failed to cut segment %d with ffmpeg", index))
+		}
+		manifest.Clips = append(manifest.Clips, clip)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to encode clip manifest")
+	}
+	if err := os.WriteFile(filepath.Join(opts.OutputDir, ClipManifestFile), append(data, '\n'), 0644); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to write clip manifest")
+	}
+	return manifest, nil
+}
+
+// restoreAudio copies the cached audio of a video into dir
+func (s *clipService) restoreAudio(ctx context.Context, videoID, dir string) (string, error) {
+	for _, key := range transcriptionSvc.CachedAudioKeys(videoID) {
+		exists, err := s.store.Exists(ctx, key)
+		if err != nil {
+			return "", errors.Wrap(err, errors.CodeExternal, "failed to look up cached audio")
+		}
+		if !exists {
+			continue
+		}
+
+		reader, err := s.store.Open(ctx, key)
+		if err != nil {
+			return "", errors.Wrap(err, errors.CodeExternal, "failed to open cached audio")
+		}
+		defer reader.Close()
+
+		path := filepath.Join(dir, "audio"+filepath.Ext(key))
+		file, err := os.Create(path)
+		if err != nil {
+			return "", errors.Wrap(err, errors.CodeInternal, "failed to create temp file")
+		}
+		_, err = io.Copy(file, reader)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return "", errors.Wrap(err, errors.CodeExternal, "failed to read cached audio")
+		}
+
+		// Keep audio used for practice when the cache size limit evicts
+		if toucher, ok := s.store.(artifact.Toucher); ok {
+			toucher.Touch(ctx, key)
+		}
+		return path, nil
+	}
+	return "", errors.New(errors.CodeNotFound, fmt.Sprintf("no cached audio of video %s; enable storage.cache_audio and transcribe it again", videoID))
+}
+
+// cut writes the audio between start and end to outputPath. Seeking after the input is slower
+// but exact, which matters for clips a few seconds long.
+func (s *clipService) cut(ctx context.Context, audioPath, outputPath string, start, end time.Duration) error {
+	args := []string{
+		"-nostdin", "-hide_banner", "-loglevel", "error", "-y",
+		"-i", audioPath,
+		"-ss", fmt.Sprintf("%.3f", start.Seconds()), "-to", fmt.Sprintf("%.3f", end.Seconds()),
+		"-vn", outputPath,
+	}
+	_, err := s.cmdRunner.Run(ctx, common.ToolFFmpeg, args...)
+	return err
+}
+
+// clipName turns segment text into a file name part: lowercase letters and digits of any
+// script joined by hyphens, at most maxClipNameRunes long
+func clipName(text string) string {
+	var b strings.Builder
+	runes, hyphen := 0, false
+	for _, r := range strings.ToLower(text) {
+		if runes >= maxClipNameRunes {
+			break
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			hyphen = b.Len() > 0
+			continue
+		}
+		if hyphen {
+			b.WriteRune('-')
+			runes++
+			hyphen = false
+		}
+		b.WriteRune(r)
+		runes++
+	}
+	if b.Len() == 0 {
+		return "segment"
+	}
+	return b.String()
+}
@@ -0,0 +1,107 @@
+package study
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/artifact"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+)
+
+// fakeFFmpeg records ffmpeg invocations and writes the name of each clip into it
+type fakeFFmpeg struct {
+	calls [][]string
+}
+
+func (f *fakeFFmpeg) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, args)
+	output := args[len(args)-1]
+	return nil, os.WriteFile(output, []byte(filepath.Base(output)), 0644)
+}
+
+func (f *fakeFFmpeg) Start(ctx context.Context, name string, args ...string) (common.Process, error) {
+	panic("not used")
+}
+
+func TestClipService_ExtractClips(t *testing.T) {
+	ctx := context.Background()
+	transcriptionRepo := &mockVideoTranscriptionRepo{transcriptions: []*model.Transcription{
+		{ID: "t1", Language: "es", Status: "completed"},
+	}}
+	segmentRepo := &mockSegmentRepo{segments: []*model.TranscriptionSegment{
+		{ID: "s12", SegmentIndex: 12, StartTime: "00:01:00.100", EndTime: "00:01:03.500", Text: " ¡Hola a todos!"},
+		{ID: "s13", SegmentIndex: 13, StartTime: "00:01:03.500", EndTime: "00:01:06", Text: " ¿Qué tal?"},
+	}}
+	translationRepo := &mockTranslationRepo{translations: []*model.Translation{
+		{TranscriptionSegmentID: "s13", TargetLanguage: "ja", TranslatedText: "元気？"},
+	}}
+
+	store := artifact.NewLocalStore(t.TempDir())
+	require.NoError(t, store.Put(ctx, artifact.AudioKey("v1", ".opus"), strings.NewReader("audio")))
+
+	t.Run("cuts each segment and writes a manifest", func(t *testing.T) {
+		ffmpeg := &fakeFFmpeg{}
+		service := NewClipServiceWithCmdRunner(transcriptionRepo, segmentRepo, translationRepo, store, ffmpeg)
+		outputDir := filepath.Join(t.TempDir(), "clips")
+
+		manifest, err := service.ExtractClips(ctx, ClipOptions{
+			VideoID:        "v1",
+			TargetLanguage: "ja",
+			Segments:       []int{13, 12},
+			OutputDir:      outputDir,
+			Padding:        200 * time.Millisecond,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []Clip{
+			{SegmentIndex: 13, File: "0013-qué-tal.mp3", StartTime: "00:01:03.500", EndTime: "00:01:06", Text: "¿Qué tal?", Translation: "元気？"},
+			{SegmentIndex: 12, File: "0012-hola-a-todos.mp3", StartTime: "00:01:00.100", EndTime: "00:01:03.500", Text: "¡Hola a todos!"},
+		}, manifest.Clips)
+
+		// Segments are padded on both sides
+		require.Len(t, ffmpeg.calls, 2)
+		assert.Contains(t, strings.Join(ffmpeg.calls[0], " "), "-ss 63.300 -to 66.200")
+		assert.Equal(t, ".opus", filepath.Ext(ffmpeg.calls[0][6]))
+
+		data, err := os.ReadFile(filepath.Join(outputDir, ClipManifestFile))
+		require.NoError(t, err)
+		var written ClipManifest
+		require.NoError(t, json.Unmarshal(data, &written))
+		assert.Equal(t, manifest, &written)
+		assert.FileExists(t, filepath.Join(outputDir, "0012-hola-a-todos.mp3"))
+	})
+
+	t.Run("unknown segment", func(t *testing.T) {
+		service := NewClipServiceWithCmdRunner(transcriptionRepo, segmentRepo, translationRepo, store, &fakeFFmpeg{})
+		_, err := service.ExtractClips(ctx, ClipOptions{VideoID: "v1", Segments: []int{14}, OutputDir: t.TempDir()})
+		assert.ErrorContains(t, err, "has no segment 14")
+	})
+
+	t.Run("no cached audio", func(t *testing.T) {
+		service := NewClipServiceWithCmdRunner(transcriptionRepo, segmentRepo, translationRepo, artifact.NewLocalStore(t.TempDir()), &fakeFFmpeg{})
+		_, err := service.ExtractClips(ctx, ClipOptions{VideoID: "v1", Segments: []int{12}, OutputDir: t.TempDir()})
+		assert.ErrorContains(t, err, "no cached audio of video v1")
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		service := NewClipServiceWithCmdRunner(transcriptionRepo, segmentRepo, translationRepo, store, &fakeFFmpeg{})
+		_, err := service.ExtractClips(ctx, ClipOptions{VideoID: "v1", Segments: []int{12}, OutputDir: t.TempDir(), Format: "flac"})
+		assert.ErrorContains(t, err, "unsupported clip format")
+	})
+}
+
+func TestClipName(t *testing.T) {
+	assert.Equal(t, "hola-a-todos", clipName("¡Hola a todos!"))
+	assert.Equal(t, "今日は-いい天気", clipName("今日は、いい天気。"))
+	assert.Equal(t, "segment", clipName(" ... "))
+	assert.Equal(t, strings.Repeat("a", 40), clipName(strings.Repeat("a", 50)))
+}
@@ -0,0 +1,249 @@
+package study
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/service/vocab"
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
+)
+
+const (
+	translationPageSize = 1000 // Translations fetched per repository call
+	defaultPerMinute    = 2.0  // Default number of cloze cards per minute of audio
+	defaultMinWordLen   = 3    // Shorter words are rarely worth blanking
+	clozeBlank          = "_____"
+)
+
+// TranscriptionRepository interface for accessing transcription metadata
+type TranscriptionRepository interface {
+	GetByID(ctx context.Context, id string) (*model.Transcription, error)
+}
+
+// SegmentRepository interface for accessing transcription segments
+type SegmentRepository interface {
+	GetByTranscriptionID(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error)
+}
+
+// TranslationRepository interface for accessing segment translations
+type TranslationRepository interface {
+	ListByTranscriptionID(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error)
+}
+
+// StudyService defines operations for generating study material from transcriptions
+type StudyService interface {
+	// GenerateCloze selects segments and blanks out one word in each
+	GenerateCloze(ctx context.Context, opts ClozeOptions) ([]*ClozeCard, error)
+}
+
+// ClozeOptions configures cloze generation
+type ClozeOptions struct {
+	TranscriptionID string  // Transcription to build cards from
+	TargetLanguage  string  // Translation language paired with each card (empty means none)
+	PerMinute       float64 // Cards per minute of audio
+	MinWordLength   int     // Minimum length (in runes) of a blanked word
+}
+
+// ClozeCard is a single gap-fill exercise
+type ClozeCard struct {
+	SegmentIndex int    `json:"segment_index"`
+	StartTime    string `json:"start_time"`
+	EndTime      string `json:"end_time"`
+	Text         string `json:"text"`        // Original segment text
+	Cloze        string `json:"cloze"`       // Segment text with the answer blanked out
+	Answer       string `json:"answer"`      // Blanked word as written in the segment
+	Translation  string `json:"translation"` // Translation of the segment, if available
+}
+
+// studyService implements StudyService
+type studyService struct {
+	transcriptionRepo TranscriptionRepository
+	segmentRepo       SegmentRepository
+	translationRepo   TranslationRepository
+}
+
+// NewStudyService creates a new study service
+func NewStudyService(transcriptionRepo TranscriptionRepository, segmentRepo SegmentRepository, translationRepo TranslationRepository) StudyService {
+	return &studyService{
+		transcriptionRepo: transcriptionRepo,
+		segmentRepo:       segmentRepo,
+		translationRepo:   translationRepo,
+	}
+}
+
+// GenerateCloze picks one segment per time window (60/PerMinute seconds) and blanks the word
+// that is most frequent across the whole transcription, so cards drill recurring vocabulary
+func (s *studyService) GenerateCloze(ctx context.Context, opts ClozeOptions) ([]*ClozeCard, error) {
+	if opts.TranscriptionID == "" {
+		return nil, errors.New(errors.CodeInvalidArg, "transcription ID is required")
+	}
+	if opts.PerMinute <= 0 {
+		opts.PerMinute = defaultPerMinute
+	}
+	if opts.MinWordLength <= 0 {
+		opts.MinWordLength = defaultMinWordLen
+	}
+
+	transcription, err := s.transcriptionRepo.GetByID(ctx, opts.TranscriptionID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeNotFound, "transcription not found")
+	}
+
+	segments, err := s.segmentRepo.GetByTranscriptionID(ctx, opts.TranscriptionID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to get transcription segments")
+	}
+	if len(segments) == 0 {
+		return []*ClozeCard{}, nil
+	}
+
+	translations, err := segmentTranslations(ctx, s.translationRepo, opts.TranscriptionID, opts.TargetLanguage)
+	if err != nil {
+		return nil, err
+	}
+
+	language := transcription.Language
+	if transcription.DetectedLanguage != nil && *transcription.DetectedLanguage != "" {
+		language = *transcription.DetectedLanguage
+	}
+	stopwords := vocab.Stopwords(language)
+
+	// Word frequencies across the transcription drive which word gets blanked
+	frequencies := map[string]int{}
+	for _, segment := range segments {
+		for _, word := range vocab.Tokenize(segment.Text) {
+			frequencies[word]++
+		}
+	}
+
+	window := time.Duration(float64(time.Minute) / opts.PerMinute)
+	best := map[int64]*ClozeCard{}
+	bestScore := map[int64]int{}
+	var order []int64
+
+	for _, segment := range segments {
+		start, err := timecode.ParseInterval(segment.StartTime)
+		if err != nil {
+			continue
+		}
+
+		word, score := pickClozeWord(segment.Text, frequencies, stopwords, opts.MinWordLength)
+		if word == "" {
+			continue
+		}
+
+		bucket := int64(start / window)
+		if _, seen := best[bucket]; !seen {
+			order = append(order, bucket)
+		} else if score <= bestScore[bucket] {
+			continue
+		}
+
+		cloze, answer := blankWord(segment.Text, word)
+		best[bucket] = &ClozeCard{
+			SegmentIndex: segment.SegmentIndex,
+			StartTime:    segment.StartTime,
+			EndTime:      segment.EndTime,
+			Text:         strings.TrimSpace(segment.Text),
+			Cloze:        strings.TrimSpace(cloze),
+			Answer:       answer,
+			Translation:  translations[segment.ID],
+		}
+		bestScore[bucket] = score
+	}
+
+	cards := make([]*ClozeCard, 0, len(order))
+	for _, bucket := range order {
+		cards = append(cards, best[bucket])
+	}
+	return cards, nil
+}
+
+// segmentTranslations maps segment IDs to their plain translated text in the target language;
+// style variants (translation create --style) are left out
+func segmentTranslations(ctx context.Context, translationRepo TranslationRepository, transcriptionID, targetLanguage string) (map[string]string, error) {
+	result := map[string]string{}
+	if targetLanguage == "" || translationRepo == nil {
+		return result, nil
+	}
+
+	for offset := 0; ; offset += translationPageSize {
+		translations, err := translationRepo.ListByTranscriptionID(ctx, transcriptionID, translationPageSize, offset)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, "failed to list translations")
+		}
+
+		for _, t := range translations {
+			if t.TargetLanguage != targetLanguage || t.Style != "" {
+				continue
+			}
+			// Rows are ordered newest first per segment; keep the first seen
+			if _, exists := result[t.TranscriptionSegmentID]; !exists {
+				result[t.TranscriptionSegmentID] = t.TranslatedText
+			}
+		}
+
+		if len(translations) < translationPageSize {
+			return result, nil
+		}
+	}
+}
+
+// pickClozeWord returns the segment word with the highest transcription-wide frequency,
+// preferring longer words on ties, along with a score for comparing segments
+func pickClozeWord(text string, frequencies map[string]int, stopwords map[string]bool, minLength int) (string, int) {
+	bestWord := ""
+	bestScore := math.MinInt
+	for _, word := range vocab.Tokenize(text) {
+		length := len([]rune(word))
+		if stopwords[word] || length < minLength {
+			continue
+		}
+		score := frequencies[word]*100 + length
+		if score > bestScore {
+			bestWord, bestScore = word, score
+		}
+	}
+	return bestWord, bestScore
+}
+
+// blankWord replaces the first whole-word, case-insensitive occurrence of word in text
+// and returns the cloze text together with the answer in its original casing
+func blankWord(text, word string) (string, string) {
+	runes := []rune(text)
+	target := []rune(word)
+
+	for i := 0; i+len(target) <= len(runes); i++ {
+		if i > 0 && isLetterOrDigit(runes[i-1]) {
+			continue
+		}
+		end := i + len(target)
+		if end < len(runes) && isLetterOrDigit(runes[end]) {
+			continue
+		}
+
+		match := true
+		for j, r := range target {
+			if unicode.ToLower(runes[i+j]) != r {
+				match = false
+				break
+			}
+		}
+		if match {
+			answer := string(runes[i:end])
+			return string(runes[:i]) + clozeBlank + string(runes[end:]), answer
+		}
+	}
+
+	return text, word
+}
+
+// isLetterOrDigit reports whether r continues a word
+func isLetterOrDigit(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
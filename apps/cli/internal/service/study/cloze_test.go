@@ -0,0 +1,104 @@
+package study
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTranscriptionRepo mocks TranscriptionRepository
+type mockTranscriptionRepo struct {
+	transcription *model.Transcription
+	err           error
+}
+
+func (m *mockTranscriptionRepo) GetByID(ctx context.Context, id string) (*model.Transcription, error) {
+	return m.transcription, m.err
+}
+
+// mockSegmentRepo mocks SegmentRepository
+type mockSegmentRepo struct {
+	segments []*model.TranscriptionSegment
+}
+
+func (m *mockSegmentRepo) GetByTranscriptionID(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error) {
+	return m.segments, nil
+}
+
+// mockTranslationRepo mocks TranslationRepository
+type mockTranslationRepo struct {
+	translations []*model.Translation
+}
+
+func (m *mockTranslationRepo) ListByTranscriptionID(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error) {
+	if offset > 0 {
+		return []*model.Translation{}, nil
+	}
+	return m.translations, nil
+}
+
+func TestStudyService_GenerateCloze(t *testing.T) {
+	transcriptionRepo := &mockTranscriptionRepo{transcription: &model.Transcription{ID: "t1", Language: "en"}}
+	segmentRepo := &mockSegmentRepo{segments: []*model.TranscriptionSegment{
+		{ID: "s1", SegmentIndex: 0, StartTime: "00:00:00", EndTime: "00:00:05", Text: " The Gopher likes code."},
+		{ID: "s2", SegmentIndex: 1, StartTime: "00:00:10", EndTime: "00:00:15", Text: " A gopher writes code."},
+		{ID: "s3", SegmentIndex: 2, StartTime: "00:00:40", EndTime: "00:00:45", Text: " Gophers everywhere."},
+		{ID: "s4", SegmentIndex: 3, StartTime: "00:00:50", EndTime: "00:00:55", Text: " It is ok."},
+	}}
+	translationRepo := &mockTranslationRepo{translations: []*model.Translation{
+		{TranscriptionSegmentID: "s1", TargetLanguage: "ja", TranslatedText: "ゴーファーはコードが大好き！", Style: "casual"}, // Style variants are left out
+		{TranscriptionSegmentID: "s1", TargetLanguage: "ja", TranslatedText: "ゴーファーはコードが好き。"},
+		{TranscriptionSegmentID: "s1", TargetLanguage: "es", TranslatedText: "Al gopher le gusta el código."},
+	}}
+
+	service := NewStudyService(transcriptionRepo, segmentRepo, translationRepo)
+
+	cards, err := service.GenerateCloze(context.Background(), ClozeOptions{
+		TranscriptionID: "t1",
+		TargetLanguage:  "ja",
+		PerMinute:       2,
+	})
+	require.NoError(t, err)
+
+	// One card per 30 second window; s4 has no eligible word
+	require.Len(t, cards, 2)
+	assert.Equal(t, 0, cards[0].SegmentIndex)
+	assert.Equal(t, "The _____ likes code.", cards[0].Cloze)
+	assert.Equal(t, "Gopher", cards[0].Answer)
+	assert.Equal(t, "ゴーファーはコードが好き。", cards[0].Translation)
+	assert.Equal(t, 2, cards[1].SegmentIndex)
+	assert.Equal(t, "Gophers _____.", cards[1].Cloze) // equal frequency, longer word wins
+	assert.Empty(t, cards[1].Translation)
+}
+
+func TestStudyService_GenerateCloze_NotFound(t *testing.T) {
+	service := NewStudyService(&mockTranscriptionRepo{err: assert.AnError}, &mockSegmentRepo{}, nil)
+
+	_, err := service.GenerateCloze(context.Background(), ClozeOptions{TranscriptionID: "missing"})
+	assert.Error(t, err)
+}
+
+func TestBlankWord(t *testing.T) {
+	cloze, answer := blankWord("Go and gophers go far", "go")
+	assert.Equal(t, "_____ and gophers go far", cloze)
+	assert.Equal(t, "Go", answer)
+
+	cloze, answer = blankWord("nothing here", "absent")
+	assert.Equal(t, "nothing here", cloze)
+	assert.Equal(t, "absent", answer)
+}
+
+func TestWriteAnkiCloze(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteAnkiCloze(&buf, []*ClozeCard{
+		{Cloze: "The _____ likes code.", Answer: "Gopher", Translation: "ゴーファー\tはコード"},
+	}, "yt-lang")
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "#notetype:Cloze\n")
+	assert.Contains(t, buf.String(), "The {{c1::Gopher}} likes code.\tゴーファー はコード\tyt-lang\n")
+}
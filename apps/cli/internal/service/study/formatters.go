@@ -0,0 +1,46 @@
+package study
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteAnkiCloze writes cards as a tab-separated file importable into Anki using the Cloze note type.
+// The first field holds the sentence with {{c1::answer}} markup and the second the translation.
+func WriteAnkiCloze(w io.Writer, cards []*ClozeCard, tag string) error {
+	if _, err := fmt.Fprint(w, "#separator:tab\n#html:false\n#notetype:Cloze\n#tags column:3\n"); err != nil {
+		return err
+	}
+
+	for _, card := range cards {
+		front := strings.Replace(card.Cloze, clozeBlank, "{{c1::"+card.Answer+"}}", 1)
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", ankiField(front), ankiField(card.Translation), tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteClozeText writes cards as a plain-text worksheet with an answer key at the end
+func WriteClozeText(w io.Writer, cards []*ClozeCard) error {
+	for i, card := range cards {
+		fmt.Fprintf(w, "%d. [%s] %s\n", i+1, card.StartTime, card.Cloze)
+		if card.Translation != "" {
+			fmt.Fprintf(w, "   (%s)\n", card.Translation)
+		}
+	}
+
+	fmt.Fprint(w, "\nAnswers:\n")
+	for i, card := range cards {
+		if _, err := fmt.Fprintf(w, "%d. %s\n", i+1, card.Answer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ankiField removes characters that would break the tab-separated layout
+func ankiField(s string) string {
+	return strings.NewReplacer("\t", " ", "\n", " ", "\r", " ").Replace(s)
+}
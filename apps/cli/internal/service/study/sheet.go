@@ -0,0 +1,243 @@
+package study
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/janitor"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+	"github.com/Taichi-iskw/yt-lang/internal/service/vocab"
+)
+
+// defaultSheetWords is the default number of vocabulary entries on a sheet
+const defaultSheetWords = 20
+
+// DefaultSheetTemplate renders a bilingual Markdown study sheet
+//
+//go:embed sheet.md.tmpl
+var DefaultSheetTemplate string
+
+// VideoRepository interface for accessing video metadata
+type VideoRepository interface {
+	GetByID(ctx context.Context, id string) (*model.Video, error)
+}
+
+// VideoTranscriptionRepository interface for finding the transcriptions of a video
+type VideoTranscriptionRepository interface {
+	GetByVideoID(ctx context.Context, videoID string) ([]*model.Transcription, error)
+}
+
+// SheetService builds study sheets: a video's transcript with its translation and vocabulary
+type SheetService interface {
+	BuildSheet(ctx context.Context, opts SheetOptions) (*Sheet, error)
+}
+
+// SheetOptions configures a study sheet
+type SheetOptions struct {
+	VideoID        string
+	Language       string // Transcription language; may be empty when the video has one transcription
+	TargetLanguage string // Translation shown next to each line (empty means none)
+	Words          int    // Vocabulary entries to list
+	MinWordLength  int    // Shorter words are left out of the vocabulary
+}
+
+// Sheet is the data a study sheet template renders
+type Sheet struct {
+	Video          *model.Video
+	Transcription  *model.Transcription
+	Language       string // Spoken language of the transcription
+	TargetLanguage string
+	Lines          []SheetLine
+	Vocabulary     []SheetWord // Most frequent non-stopwords, most frequent first
+	TotalWords     int
+	UniqueWords    int
+	GeneratedAt    time.Time
+}
+
+// SheetLine is one transcript segment with its translation
+type SheetLine struct {
+	StartTime   string
+	EndTime     string
+	Text        string
+	Translation string // Empty when the segment is not translated
+}
+
+// SheetWord is a vocabulary entry of a sheet
+type SheetWord struct {
+	Word    string
+	Count   int
+	Example string // First line using the word
+}
+
+// sheetService implements SheetService
+type sheetService struct {
+	videoRepo         VideoRepository
+	transcriptionRepo VideoTranscriptionRepository
+	segmentRepo       SegmentRepository
+	translationRepo   TranslationRepository
+}
+
+// NewSheetService creates a new study sheet service
+func NewSheetService(videoRepo VideoRepository, transcriptionRepo VideoTranscriptionRepository, segmentRepo SegmentRepository, translationRepo TranslationRepository) SheetService {
+	return &sheetService{
+		videoRepo:         videoRepo,
+		transcriptionRepo: transcriptionRepo,
+		segmentRepo:       segmentRepo,
+		translationRepo:   translationRepo,
+	}
+}
+
+// BuildSheet collects the transcript, translations and vocabulary of a video
+func (s *sheetService) BuildSheet(ctx context.Context, opts SheetOptions) (*Sheet, error) {
+	if opts.VideoID == "" {
+		return nil, errors.New(errors.CodeInvalidArg, "video ID is required")
+	}
+	if opts.Words <= 0 {
+		opts.Words = defaultSheetWords
+	}
+	if opts.MinWordLength <= 0 {
+		opts.MinWordLength = defaultMinWordLen
+	}
+
+	video, err := s.videoRepo.GetByID(ctx, opts.VideoID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeNotFound, "video not found")
+	}
+
+	transcription, err := videoTranscription(ctx, s.transcriptionRepo, opts.VideoID, opts.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	segments, err := s.segmentRepo.GetByTranscriptionID(ctx, transcription.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to get transcription segments")
+	}
+
+	translations, err := segmentTranslations(ctx, s.translationRepo, transcription.ID, opts.TargetLanguage)
+	if err != nil {
+		return nil, err
+	}
+
+	language := transcription.Language
+	if transcription.DetectedLanguage != nil && *transcription.DetectedLanguage != "" {
+		language = *transcription.DetectedLanguage
+	}
+
+	sheet := &Sheet{
+		Video:          video,
+		Transcription:  transcription,
+		Language:       language,
+		TargetLanguage: opts.TargetLanguage,
+		Lines:          make([]SheetLine, 0, len(segments)),
+		GeneratedAt:    time.Now(),
+	}
+
+	stopwords := vocab.Stopwords(language)
+	counts := map[string]int{}
+	examples := map[string]string{}
+	for _, segment := range segments {
+		text := strings.TrimSpace(segment.Text)
+		sheet.Lines = append(sheet.Lines, SheetLine{
+			StartTime:   segment.StartTime,
+			EndTime:     segment.EndTime,
+			Text:        text,
+			Translation: strings.TrimSpace(translations[segment.ID]),
+		})
+
+		for _, word := range vocab.Tokenize(text) {
+			sheet.TotalWords++
+			if stopwords[word] || len([]rune(word)) < opts.MinWordLength {
+				continue
+			}
+			if counts[word] == 0 {
+				examples[word] = text
+			}
+			counts[word]++
+		}
+	}
+	sheet.UniqueWords = len(counts)
+	sheet.Vocabulary = topWords(counts, examples, opts.Words)
+
+	return sheet, nil
+}
+
+// videoTranscription picks the completed transcription of a video in language; language may be
+// empty when the video has one completed transcription
+func videoTranscription(ctx context.Context, transcriptionRepo VideoTranscriptionRepository, videoID, language string) (*model.Transcription, error) {
+	transcriptions, err := transcriptionRepo.GetByVideoID(ctx, videoID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to list transcriptions for video %s", videoID))
+	}
+
+	var candidates []*model.Transcription
+	var languages []string
+	for _, t := range transcriptions {
+		if t.Status != "completed" {
+			continue
+		}
+		detected := t.DetectedLanguage != nil && *t.DetectedLanguage == language
+		if language != "" && t.Language != language && !detected {
+			continue
+		}
+		candidates = append(candidates, t)
+		languages = append(languages, t.Language)
+	}
+
+	switch len(candidates) {
+	case 0:
+		if language != "" {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("video %s has no completed %s transcription", videoID, language))
+		}
+		return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("video %s has no completed transcription", videoID))
+	case 1:
+		return candidates[0], nil
+	default:
+		return nil, errors.New(errors.CodeInvalidArg, fmt.Sprintf("video %s has transcriptions in %s; select one with the language", videoID, strings.Join(languages, ", ")))
+	}
+}
+
+// topWords returns the n most frequent words, ties broken alphabetically
+func topWords(counts map[string]int, examples map[string]string, n int) []SheetWord {
+	words := make([]SheetWord, 0, len(counts))
+	for word, count := range counts {
+		words = append(words, SheetWord{Word: word, Count: count, Example: examples[word]})
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if words[i].Count != words[j].Count {
+			return words[i].Count > words[j].Count
+		}
+		return words[i].Word < words[j].Word
+	})
+	if len(words) > n {
+		words = words[:n]
+	}
+	return words
+}
+
+// WritePDF converts a Markdown study sheet to PDF with pandoc, which needs a PDF engine such
+// as a LaTeX installation
+func WritePDF(ctx context.Context, cmdRunner common.CmdRunner, markdown []byte, outputPath string) error {
+	dir, err := janitor.MkdirTemp("yt-lang-sheet-*")
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to create temp directory")
+	}
+	defer janitor.RemoveTemp(dir)
+
+	input := filepath.Join(dir, "sheet.md")
+	if err := os.WriteFile(input, markdown, 0644); err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to write study sheet")
+	}
+	if _, err := cmdRunner.Run(ctx, "pandoc", input, "--from", "markdown", "--output", outputPath); err != nil {
+		return errors.Wrap(err, errors.CodeExternal, "failed to convert the study sheet to PDF with pandoc (is pandoc installed with a PDF engine?)")
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package study
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/tmpl"
+)
+
+// mockVideoRepo mocks VideoRepository
+type mockVideoRepo struct {
+	video *model.Video
+}
+
+func (m *mockVideoRepo) GetByID(ctx context.Context, id string) (*model.Video, error) {
+	return m.video, nil
+}
+
+// mockVideoTranscriptionRepo mocks VideoTranscriptionRepository
+type mockVideoTranscriptionRepo struct {
+	transcriptions []*model.Transcription
+}
+
+func (m *mockVideoTranscriptionRepo) GetByVideoID(ctx context.Context, videoID string) ([]*model.Transcription, error) {
+	return m.transcriptions, nil
+}
+
+func TestSheetService_BuildSheet(t *testing.T) {
+	videoRepo := &mockVideoRepo{video: &model.Video{ID: "v1", Title: "Gophers", URL: "https://www.youtube.com/watch?v=v1"}}
+	transcriptionRepo := &mockVideoTranscriptionRepo{transcriptions: []*model.Transcription{
+		{ID: "t0", Language: "en", Status: "failed"},
+		{ID: "t1", Language: "en", Status: "completed"},
+	}}
+	segmentRepo := &mockSegmentRepo{segments: []*model.TranscriptionSegment{
+		{ID: "s1", StartTime: "00:00:00", EndTime: "00:00:05", Text: " The gopher likes code."},
+		{ID: "s2", StartTime: "00:00:05", EndTime: "00:00:09", Text: " A gopher writes code too."},
+	}}
+	translationRepo := &mockTranslationRepo{translations: []*model.Translation{
+		{TranscriptionSegmentID: "s1", TargetLanguage: "ja", TranslatedText: "ゴーファーはコードが好き。"},
+	}}
+
+	service := NewSheetService(videoRepo, transcriptionRepo, segmentRepo, translationRepo)
+	sheet, err := service.BuildSheet(context.Background(), SheetOptions{VideoID: "v1", TargetLanguage: "ja", Words: 2})
+	require.NoError(t, err)
+
+	assert.Equal(t, "t1", sheet.Transcription.ID)
+	require.Len(t, sheet.Lines, 2)
+	assert.Equal(t, "ゴーファーはコードが好き。", sheet.Lines[0].Translation)
+	assert.Empty(t, sheet.Lines[1].Translation)
+	assert.Equal(t, 9, sheet.TotalWords)
+	assert.Equal(t, []SheetWord{
+		{Word: "code", Count: 2, Example: "The gopher likes code."},
+		{Word: "gopher", Count: 2, Example: "The gopher likes code."},
+	}, sheet.Vocabulary)
+
+	template, err := tmpl.ParseDocument("default", DefaultSheetTemplate)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, template.Execute(&buf, sheet))
+	assert.Contains(t, buf.String(), "# Gophers")
+	assert.Contains(t, buf.String(), "| **code** | 2 | The gopher likes code. |")
+	assert.Contains(t, buf.String(), "**[00:00:00]** The gopher likes code.\\\n*ゴーファーはコードが好き。*")
+	assert.Contains(t, buf.String(), "**[00:00:05]** A gopher writes code too.\n")
+}
+
+func TestSheetService_BuildSheet_AmbiguousTranscription(t *testing.T) {
+	transcriptionRepo := &mockVideoTranscriptionRepo{transcriptions: []*model.Transcription{
+		{ID: "t1", Language: "en", Status: "completed"},
+		{ID: "t2", Language: "ja", Status: "completed"},
+	}}
+	service := NewSheetService(&mockVideoRepo{video: &model.Video{ID: "v1"}}, transcriptionRepo, &mockSegmentRepo{}, nil)
+
+	_, err := service.BuildSheet(context.Background(), SheetOptions{VideoID: "v1"})
+	assert.ErrorContains(t, err, "transcriptions in en, ja")
+
+	sheet, err := service.BuildSheet(context.Background(), SheetOptions{VideoID: "v1", Language: "ja"})
+	require.NoError(t, err)
+	assert.Equal(t, "t2", sheet.Transcription.ID)
+}
@@ -0,0 +1,283 @@
+package tagging
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/service/vocab"
+)
+
+const (
+	DefaultTop           = 5 // Tags proposed per video
+	DefaultMinWordLength = 3 // Shorter words are rarely topics
+
+	videoPageSize  = 100 // Videos fetched per repository call while walking a channel
+	minOccurrences = 2   // Words mentioned once in a transcript are not its topic
+)
+
+// VideoRepository interface for accessing videos
+type VideoRepository interface {
+	GetByID(ctx context.Context, id string) (*model.Video, error)
+	GetByChannelID(ctx context.Context, channelID string, limit, offset int) ([]*model.Video, error)
+}
+
+// TranscriptionRepository interface for accessing transcription metadata
+type TranscriptionRepository interface {
+	GetByVideoID(ctx context.Context, videoID string) ([]*model.Transcription, error)
+}
+
+// SegmentRepository interface for accessing transcription segments
+type SegmentRepository interface {
+	GetByTranscriptionID(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error)
+}
+
+// TagRepository interface for storing and searching video tags
+type TagRepository interface {
+	ReplaceByVideoID(ctx context.Context, videoID string, tags []*model.VideoTag) error
+	ListVideosByTag(ctx context.Context, tag string) ([]*model.Video, error)
+}
+
+// TaggingService defines operations for topic tags
+type TaggingService interface {
+	// AutoTag proposes topic tags for a video, or the transcribed videos of a channel, from the
+	// keywords of their transcripts and stores them
+	AutoTag(ctx context.Context, opts AutoTagOptions) (*AutoTagResult, error)
+
+	// VideosByTag retrieves the videos tagged with a topic, those it describes best first
+	VideosByTag(ctx context.Context, tag string) ([]*model.Video, error)
+}
+
+// AutoTagOptions configures AutoTag. Exactly one of VideoID and ChannelID is required.
+type AutoTagOptions struct {
+	VideoID       string // Video to tag; the transcripts of its channel weigh the keywords
+	ChannelID     string // Channel whose transcribed videos are tagged
+	Top           int    // Tags per video; defaults to DefaultTop
+	MinWordLength int    // Ignore words shorter than this (in runes); defaults to DefaultMinWordLength
+	DryRun        bool   // Propose tags without storing them
+}
+
+// VideoTags are the tags proposed for a video, best first
+type VideoTags struct {
+	Video *model.Video      `json:"video"`
+	Tags  []*model.VideoTag `json:"tags"`
+}
+
+// AutoTagResult is the outcome of an AutoTag run
+type AutoTagResult struct {
+	ChannelID     string       `json:"channel_id"`
+	Documents     int          `json:"documents"`     // Transcribed videos of the channel the keywords were weighed against
+	Untranscribed int          `json:"untranscribed"` // Videos skipped for lack of a completed transcription
+	Videos        []*VideoTags `json:"videos"`
+	DryRun        bool         `json:"dry_run"`
+}
+
+// taggingService implements TaggingService
+type taggingService struct {
+	videoRepo         VideoRepository
+	transcriptionRepo TranscriptionRepository
+	segmentRepo       SegmentRepository
+	tagRepo           TagRepository
+}
+
+// NewTaggingService creates a new tagging service
+func NewTaggingService(videoRepo VideoRepository, transcriptionRepo TranscriptionRepository, segmentRepo SegmentRepository, tagRepo TagRepository) TaggingService {
+	return &taggingService{
+		videoRepo:         videoRepo,
+		transcriptionRepo: transcriptionRepo,
+		segmentRepo:       segmentRepo,
+		tagRepo:           tagRepo,
+	}
+}
+
+// document is the word counts of the transcripts of one video
+type document struct {
+	video  *model.Video
+	counts map[string]int
+	total  int
+}
+
+// AutoTag weighs the words of each transcribed video of the channel by TF-IDF (frequent in the
+// video, rare in the rest of the channel) and tags videos with their highest weighted words
+func (s *taggingService) AutoTag(ctx context.Context, opts AutoTagOptions) (*AutoTagResult, error) {
+	if (opts.VideoID == "") == (opts.ChannelID == "") {
+		return nil, errors.New(errors.CodeInvalidArg, "either a video ID or a channel ID is required")
+	}
+	if opts.Top <= 0 {
+		opts.Top = DefaultTop
+	}
+	if opts.MinWordLength <= 0 {
+		opts.MinWordLength = DefaultMinWordLength
+	}
+
+	channelID := opts.ChannelID
+	if opts.VideoID != "" {
+		video, err := s.videoRepo.GetByID(ctx, opts.VideoID)
+		if err != nil {
+			return nil, err
+		}
+		channelID = video.ChannelID
+	}
+
+	documents, untranscribed, err := s.channelDocuments(ctx, channelID, opts.MinWordLength)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AutoTagResult{ChannelID: channelID, Documents: len(documents), Untranscribed: untranscribed, Videos: []*VideoTags{}, DryRun: opts.DryRun}
+	frequency := documentFrequency(documents)
+	for _, doc := range documents {
+		if opts.VideoID != "" && doc.video.ID != opts.VideoID {
+			continue
+		}
+		result.Videos = append(result.Videos, &VideoTags{
+			Video: doc.video,
+			Tags:  topTags(doc, frequency, len(documents), opts.Top),
+		})
+	}
+	if opts.VideoID != "" {
+		result.Untranscribed = 0
+		if len(result.Videos) == 0 {
+			return nil, errors.New(errors.CodeInvalidArg, fmt.Sprintf("video %s has no completed transcription to tag", opts.VideoID))
+		}
+	}
+
+	if opts.DryRun {
+		return result, nil
+	}
+	for _, tagged := range result.Videos {
+		if err := s.tagRepo.ReplaceByVideoID(ctx, tagged.Video.ID, tagged.Tags); err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to store tags for video %s", tagged.Video.ID))
+		}
+	}
+	return result, nil
+}
+
+// VideosByTag retrieves the videos tagged with a topic. Tags are stored lower-cased.
+func (s *taggingService) VideosByTag(ctx context.Context, tag string) ([]*model.Video, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return nil, errors.New(errors.CodeInvalidArg, "tag is required")
+	}
+
+	videos, err := s.tagRepo.ListVideosByTag(ctx, tag)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to list tagged videos")
+	}
+	return videos, nil
+}
+
+// channelDocuments counts the transcript words of every available video of a channel, without
+// stopwords and short words. Videos without a completed transcription are counted, not returned.
+func (s *taggingService) channelDocuments(ctx context.Context, channelID string, minWordLength int) ([]*document, int, error) {
+	var documents []*document
+	untranscribed := 0
+
+	for offset := 0; ; offset += videoPageSize {
+		videos, err := s.videoRepo.GetByChannelID(ctx, channelID, videoPageSize, offset)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, errors.CodeInternal, "failed to list channel videos")
+		}
+
+		for _, video := range videos {
+			if !video.IsAvailable() {
+				continue
+			}
+			doc, err := s.videoDocument(ctx, video, minWordLength)
+			if err != nil {
+				return nil, 0, err
+			}
+			if doc == nil {
+				untranscribed++
+				continue
+			}
+			documents = append(documents, doc)
+		}
+
+		if len(videos) < videoPageSize {
+			break
+		}
+	}
+	return documents, untranscribed, nil
+}
+
+// videoDocument counts the words of the completed transcriptions of a video; nil when it has none
+func (s *taggingService) videoDocument(ctx context.Context, video *model.Video, minWordLength int) (*document, error) {
+	transcriptions, err := s.transcriptionRepo.GetByVideoID(ctx, video.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to list transcriptions for video %s", video.ID))
+	}
+
+	var doc *document
+	for _, t := range transcriptions {
+		if t.Status != "completed" {
+			continue
+		}
+		segments, err := s.segmentRepo.GetByTranscriptionID(ctx, t.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to get segments for transcription %s", t.ID))
+		}
+
+		stopwords := vocab.Stopwords(t.Language)
+		if t.DetectedLanguage != nil {
+			for word := range vocab.Stopwords(*t.DetectedLanguage) {
+				stopwords[word] = true
+			}
+		}
+
+		if doc == nil {
+			doc = &document{video: video, counts: map[string]int{}}
+		}
+		for _, segment := range segments {
+			for _, word := range vocab.Tokenize(segment.Text) {
+				if stopwords[word] || len([]rune(word)) < minWordLength {
+					continue
+				}
+				doc.counts[word]++
+				doc.total++
+			}
+		}
+	}
+	return doc, nil
+}
+
+// documentFrequency returns the number of documents each word occurs in
+func documentFrequency(documents []*document) map[string]int {
+	frequency := map[string]int{}
+	for _, doc := range documents {
+		for word := range doc.counts {
+			frequency[word]++
+		}
+	}
+	return frequency
+}
+
+// topTags returns the n words of doc with the highest TF-IDF weight, ties broken alphabetically.
+// The inverse document frequency is smoothed so that a channel with a single transcribed video
+// still gets tags from its term frequencies.
+func topTags(doc *document, frequency map[string]int, documents, n int) []*model.VideoTag {
+	tags := []*model.VideoTag{}
+	for word, count := range doc.counts {
+		if count < minOccurrences {
+			continue
+		}
+		tf := float64(count) / float64(doc.total)
+		idf := math.Log(float64(1+documents)/float64(1+frequency[word])) + 1
+		tags = append(tags, &model.VideoTag{VideoID: doc.video.ID, Tag: word, Score: tf * idf})
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Score != tags[j].Score {
+			return tags[i].Score > tags[j].Score
+		}
+		return tags[i].Tag < tags[j].Tag
+	})
+	if len(tags) > n {
+		tags = tags[:n]
+	}
+	return tags
+}
@@ -0,0 +1,162 @@
+package tagging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// fakeVideoRepo serves the videos of one channel
+type fakeVideoRepo struct {
+	videos []*model.Video
+}
+
+func (f *fakeVideoRepo) GetByID(ctx context.Context, id string) (*model.Video, error) {
+	for _, v := range f.videos {
+		if v.ID == id {
+			return v, nil
+		}
+	}
+	return nil, errors.New(errors.CodeNotFound, "video not found")
+}
+
+func (f *fakeVideoRepo) GetByChannelID(ctx context.Context, channelID string, limit, offset int) ([]*model.Video, error) {
+	if offset >= len(f.videos) {
+		return nil, nil
+	}
+	return f.videos[offset:], nil
+}
+
+// fakeTranscriptionRepo serves one transcription per video, keyed by video ID
+type fakeTranscriptionRepo struct {
+	byVideo map[string][]*model.Transcription
+}
+
+func (f *fakeTranscriptionRepo) GetByVideoID(ctx context.Context, videoID string) ([]*model.Transcription, error) {
+	return f.byVideo[videoID], nil
+}
+
+// fakeSegmentRepo serves segments keyed by transcription ID
+type fakeSegmentRepo struct {
+	byTranscription map[string][]*model.TranscriptionSegment
+}
+
+func (f *fakeSegmentRepo) GetByTranscriptionID(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error) {
+	return f.byTranscription[transcriptionID], nil
+}
+
+// fakeTagRepo records stored tags
+type fakeTagRepo struct {
+	stored map[string][]*model.VideoTag
+}
+
+func (f *fakeTagRepo) ReplaceByVideoID(ctx context.Context, videoID string, tags []*model.VideoTag) error {
+	f.stored[videoID] = tags
+	return nil
+}
+
+func (f *fakeTagRepo) ListVideosByTag(ctx context.Context, tag string) ([]*model.Video, error) {
+	return []*model.Video{{ID: "tagged-" + tag}}, nil
+}
+
+func newTestService() (TaggingService, *fakeTagRepo) {
+	videos := &fakeVideoRepo{videos: []*model.Video{
+		{ID: "paella", ChannelID: "UCcook"},
+		{ID: "gazpacho", ChannelID: "UCcook"},
+		{ID: "pending", ChannelID: "UCcook"},
+		{ID: "gone", ChannelID: "UCcook", Status: model.VideoStatusUnavailable},
+	}}
+	transcriptions := &fakeTranscriptionRepo{byVideo: map[string][]*model.Transcription{
+		"paella":   {{ID: "t1", Language: "es", Status: "completed"}},
+		"gazpacho": {{ID: "t2", Language: "es", Status: "completed"}},
+		"pending":  {{ID: "t3", Language: "es", Status: "failed"}},
+	}}
+	segments := &fakeSegmentRepo{byTranscription: map[string][]*model.TranscriptionSegment{
+		"t1": {
+			{Text: "Hoy cocinamos una paella con arroz y azafrán."},
+			{Text: "La paella lleva arroz, pollo y receta de mi abuela."},
+			{Text: "Buena receta, buena paella."},
+		},
+		"t2": {
+			{Text: "El gazpacho es una receta fría con tomate."},
+			{Text: "Tomate, pepino y más tomate para el gazpacho."},
+			{Text: "Una receta fácil."},
+		},
+	}}
+	tags := &fakeTagRepo{stored: map[string][]*model.VideoTag{}}
+	return NewTaggingService(videos, transcriptions, segments, tags), tags
+}
+
+func tagNames(tags []*model.VideoTag) []string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Tag
+	}
+	return names
+}
+
+func TestTaggingService_AutoTag(t *testing.T) {
+	t.Run("channel", func(t *testing.T) {
+		service, repo := newTestService()
+
+		result, err := service.AutoTag(context.Background(), AutoTagOptions{ChannelID: "UCcook", Top: 3})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Documents)
+		assert.Equal(t, 1, result.Untranscribed)
+		require.Len(t, result.Videos, 2)
+
+		// Words shared by every transcript weigh less than the words of one video
+		assert.Equal(t, []string{"paella", "arroz", "buena"}, tagNames(result.Videos[0].Tags))
+		assert.Equal(t, []string{"tomate", "gazpacho", "receta"}, tagNames(result.Videos[1].Tags))
+		assert.Equal(t, "paella", result.Videos[0].Tags[0].VideoID)
+
+		assert.Len(t, repo.stored, 2)
+		assert.Equal(t, result.Videos[1].Tags, repo.stored["gazpacho"])
+	})
+
+	t.Run("one video weighed against its channel", func(t *testing.T) {
+		service, repo := newTestService()
+
+		result, err := service.AutoTag(context.Background(), AutoTagOptions{VideoID: "gazpacho", Top: 1, DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, "UCcook", result.ChannelID)
+		assert.Equal(t, 2, result.Documents)
+		require.Len(t, result.Videos, 1)
+		assert.Equal(t, []string{"tomate"}, tagNames(result.Videos[0].Tags))
+		assert.Empty(t, repo.stored)
+	})
+
+	t.Run("video without transcription", func(t *testing.T) {
+		service, _ := newTestService()
+
+		_, err := service.AutoTag(context.Background(), AutoTagOptions{VideoID: "pending"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no completed transcription")
+	})
+
+	t.Run("video or channel required", func(t *testing.T) {
+		service, _ := newTestService()
+
+		_, err := service.AutoTag(context.Background(), AutoTagOptions{})
+		assert.Error(t, err)
+		_, err = service.AutoTag(context.Background(), AutoTagOptions{VideoID: "paella", ChannelID: "UCcook"})
+		assert.Error(t, err)
+	})
+}
+
+func TestTaggingService_VideosByTag(t *testing.T) {
+	service, _ := newTestService()
+
+	videos, err := service.VideosByTag(context.Background(), " Paella ")
+	require.NoError(t, err)
+	require.Len(t, videos, 1)
+	assert.Equal(t, "tagged-paella", videos[0].ID)
+
+	_, err = service.VideosByTag(context.Background(), " ")
+	assert.Error(t, err)
+}
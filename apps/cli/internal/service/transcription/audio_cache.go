@@ -0,0 +1,194 @@
+package transcription
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/artifact"
+	"github.com/Taichi-iskw/yt-lang/internal/clock"
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+)
+
+// cachedAudioExtensions lists the audio formats looked up in the cache, in yt-dlp preference order
+var cachedAudioExtensions = []string{".m4a", ".opus", ".webm", ".mp3", ".aac", ".ogg", ".wav"}
+
+// AudioFormatRecord records the format cached audio was downloaded at, so a cache filled at a
+// low quality can be told apart from one holding the audio YouTube served. It is kept next to
+// the audio under artifact.AudioFormatKey.
+type AudioFormatRecord struct {
+	VideoID      string    `json:"video_id"`
+	Key          string    `json:"key"`
+	Format       string    `json:"format"`
+	Quality      string    `json:"quality"`
+	Size         int64     `json:"size"`
+	DownloadedAt time.Time `json:"downloaded_at"`
+}
+
+// cachingAudioDownloadService keeps downloaded audio in an artifact store and
+// restores it instead of downloading again
+type cachingAudioDownloadService struct {
+	AudioDownloadService
+	store artifact.Store
+	clock clock.Clock
+}
+
+// NewCachingAudioDownloadService wraps inner so audio is cached per video in store
+func NewCachingAudioDownloadService(inner AudioDownloadService, store artifact.Store) AudioDownloadService {
+	return &cachingAudioDownloadService{AudioDownloadService: inner, store: store, clock: clock.System}
+}
+
+// DownloadAudio restores cached audio of the video into outputDir, downloading and caching it on a
+// miss. Cached audio is restored whatever format it was downloaded at.
+func (s *cachingAudioDownloadService) DownloadAudio(ctx context.Context, videoURL string, outputDir string) (string, error) {
+	videoID := extractVideoIDFromURL(videoURL)
+	if videoID == "" {
+		return s.AudioDownloadService.DownloadAudio(ctx, videoURL, outputDir)
+	}
+
+	for _, ext := range cachedAudioExtensions {
+		key := artifact.AudioKey(videoID, ext)
+		exists, err := s.store.Exists(ctx, key)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: audio cache lookup failed: %v\n", err)
+			break
+		}
+		if !exists {
+			continue
+		}
+
+		audioPath, err := s.restore(ctx, key, filepath.Join(outputDir, videoID+ext))
+		if err == nil {
+			// Keep recently used audio when the cache size limit evicts
+			if toucher, ok := s.store.(artifact.Toucher); ok {
+				toucher.Touch(ctx, key)
+			}
+			return audioPath, nil
+		}
+		fmt.Fprintf(os.Stderr, "Warning: failed to restore cached audio: %v\n", err)
+		break
+	}
+
+	audioPath, err := s.AudioDownloadService.DownloadAudio(ctx, videoURL, outputDir)
+	if err != nil {
+		return "", err
+	}
+
+	// A failed upload only costs a download next time
+	key := artifact.AudioKey(videoID, filepath.Ext(audioPath))
+	if err := s.save(ctx, key, audioPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to cache audio: %v\n", err)
+	} else {
+		// Freshly downloaded audio is an original; drop the record of any earlier re-encoding
+		s.store.Delete(ctx, artifact.AudioSourceKey(videoID))
+		if err := s.recordFormat(ctx, videoID, key, audioPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to record cached audio format: %v\n", err)
+		}
+	}
+	return audioPath, nil
+}
+
+// recordFormat stores the format the cached audio under key was downloaded at. Without a
+// downloader reporting its format, a stale record of an earlier download is dropped.
+func (s *cachingAudioDownloadService) recordFormat(ctx context.Context, videoID, key, audioPath string) error {
+	formatter, ok := s.AudioDownloadService.(AudioFormatter)
+	if !ok {
+		return s.store.Delete(ctx, artifact.AudioFormatKey(videoID))
+	}
+	format := formatter.AudioFormat()
+
+	record := &AudioFormatRecord{
+		VideoID:      videoID,
+		Key:          key,
+		Format:       format.Format,
+		Quality:      format.Quality,
+		DownloadedAt: s.clock.Now().UTC(),
+	}
+	if info, err := os.Stat(audioPath); err == nil {
+		record.Size = info.Size()
+	}
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	return s.store.Put(ctx, artifact.AudioFormatKey(videoID), bytes.NewReader(data))
+}
+
+// ReadAudioFormat returns the format the cached audio of a video was downloaded at; it fails with
+// artifact.ErrNotFound when no format was recorded
+func ReadAudioFormat(ctx context.Context, store artifact.Store, videoID string) (*AudioFormatRecord, error) {
+	reader, err := store.Open(ctx, artifact.AudioFormatKey(videoID))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var record AudioFormatRecord
+	if err := json.NewDecoder(reader).Decode(&record); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to decode audio format")
+	}
+	return &record, nil
+}
+
+// AudioCached reports whether audio of the video is cached in store
+func AudioCached(ctx context.Context, store artifact.Store, videoID string) (bool, error) {
+	for _, key := range CachedAudioKeys(videoID) {
+		exists, err := store.Exists(ctx, key)
+		if err != nil || exists {
+			return exists, err
+		}
+	}
+	return false, nil
+}
+
+// CachedAudioKeys returns every store key the audio of the video may be cached under
+func CachedAudioKeys(videoID string) []string {
+	keys := make([]string, 0, len(cachedAudioExtensions))
+	for _, ext := range cachedAudioExtensions {
+		keys = append(keys, artifact.AudioKey(videoID, ext))
+	}
+	return keys
+}
+
+// restore copies the cached audio stored under key to path
+func (s *cachingAudioDownloadService) restore(ctx context.Context, key, path string) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", errors.Wrap(err, errors.CodeInternal, "failed to create output directory")
+	}
+
+	reader, err := s.store.Open(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(file, reader); err != nil {
+		file.Close()
+		os.Remove(path)
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+// save uploads the downloaded audio file under key
+func (s *cachingAudioDownloadService) save(ctx context.Context, key, audioPath string) error {
+	file, err := os.Open(audioPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return s.store.Put(ctx, key, file)
+}
@@ -0,0 +1,130 @@
+package transcription
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Taichi-iskw/yt-lang/internal/artifact"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachingAudioDownloadService_DownloadAudio(t *testing.T) {
+	ctx := context.Background()
+	videoURL := "https://www.youtube.com/watch?v=abc123"
+	store := artifact.NewLocalStore(t.TempDir())
+
+	// First download goes to yt-dlp and is cached
+	firstDir := t.TempDir()
+	downloaded := filepath.Join(firstDir, "Some Title.opus")
+	inner := new(mockAudioDownloadService)
+	inner.On("DownloadAudio", mock.Anything, videoURL, firstDir).
+		Run(func(args mock.Arguments) {
+			require.NoError(t, os.WriteFile(downloaded, []byte("audio-bytes"), 0644))
+		}).
+		Return(downloaded, nil).Once()
+
+	service := NewCachingAudioDownloadService(inner, store)
+	path, err := service.DownloadAudio(ctx, videoURL, firstDir)
+	require.NoError(t, err)
+	assert.Equal(t, downloaded, path)
+
+	exists, err := store.Exists(ctx, artifact.AudioKey("abc123", ".opus"))
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	cached, err := AudioCached(ctx, store, "abc123")
+	require.NoError(t, err)
+	assert.True(t, cached)
+	cached, err = AudioCached(ctx, store, "other")
+	require.NoError(t, err)
+	assert.False(t, cached)
+
+	// Second download is restored from the cache without calling yt-dlp
+	secondDir := t.TempDir()
+	path, err = service.DownloadAudio(ctx, videoURL, secondDir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(secondDir, "abc123.opus"), path)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "audio-bytes", string(content))
+
+	inner.AssertExpectations(t)
+}
+
+func TestCachingAudioDownloadService_AAC(t *testing.T) {
+	ctx := context.Background()
+	videoURL := "https://www.youtube.com/watch?v=abc123"
+	store := artifact.NewLocalStore(t.TempDir())
+
+	firstDir := t.TempDir()
+	downloaded := filepath.Join(firstDir, "Some Title.aac")
+	inner := new(mockAudioDownloadService)
+	inner.On("DownloadAudio", mock.Anything, videoURL, firstDir).
+		Run(func(args mock.Arguments) {
+			require.NoError(t, os.WriteFile(downloaded, []byte("aac-bytes"), 0644))
+		}).
+		Return(downloaded, nil).Once()
+
+	service := NewCachingAudioDownloadService(inner, store)
+	_, err := service.DownloadAudio(ctx, videoURL, firstDir)
+	require.NoError(t, err)
+
+	// The cached aac audio is found again instead of downloading it twice
+	secondDir := t.TempDir()
+	path, err := service.DownloadAudio(ctx, videoURL, secondDir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(secondDir, "abc123.aac"), path)
+	inner.AssertExpectations(t)
+}
+
+func TestCachingAudioDownloadService_UnknownURL(t *testing.T) {
+	inner := new(mockAudioDownloadService)
+	inner.On("DownloadAudio", mock.Anything, "https://youtu.be/abc123", "/tmp/out").
+		Return("/tmp/out/a.m4a", nil)
+
+	service := NewCachingAudioDownloadService(inner, artifact.NewLocalStore(t.TempDir()))
+	path, err := service.DownloadAudio(context.Background(), "https://youtu.be/abc123", "/tmp/out")
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/out/a.m4a", path)
+	inner.AssertExpectations(t)
+}
+
+func TestCachingAudioDownloadService_RecordsFormat(t *testing.T) {
+	ctx := context.Background()
+	videoURL := "https://www.youtube.com/watch?v=abc123"
+	store := artifact.NewLocalStore(t.TempDir())
+	outputDir := t.TempDir()
+
+	runner := new(mockWhisperCmdRunner)
+	runner.On("Run", mock.Anything, "yt-dlp", mock.Anything).
+		Run(func(args mock.Arguments) {
+			require.NoError(t, os.WriteFile(filepath.Join(outputDir, "Title.m4a"), []byte("small-audio"), 0644))
+		}).
+		Return([]byte(""), nil)
+	inner := NewAudioDownloadServiceWithFormat(runner, nil, AudioFormatOptions{Format: "m4a", Quality: "48k"})
+
+	_, err := NewCachingAudioDownloadService(inner, store).DownloadAudio(ctx, videoURL, outputDir)
+	require.NoError(t, err)
+
+	record, err := ReadAudioFormat(ctx, store, "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", record.VideoID)
+	assert.Equal(t, artifact.AudioKey("abc123", ".m4a"), record.Key)
+	assert.Equal(t, "m4a", record.Format)
+	assert.Equal(t, "48k", record.Quality)
+	assert.Equal(t, int64(len("small-audio")), record.Size)
+	assert.False(t, record.DownloadedAt.IsZero())
+
+	// A download by a service that cannot report its format drops the stale record
+	other := new(mockAudioDownloadService)
+	other.On("DownloadAudio", mock.Anything, videoURL, outputDir).Return(filepath.Join(outputDir, "Title.m4a"), nil)
+	require.NoError(t, store.Delete(ctx, artifact.AudioKey("abc123", ".m4a")))
+	_, err = NewCachingAudioDownloadService(other, store).DownloadAudio(ctx, videoURL, outputDir)
+	require.NoError(t, err)
+	_, err = ReadAudioFormat(ctx, store, "abc123")
+	assert.ErrorIs(t, err, artifact.ErrNotFound)
+}
@@ -0,0 +1,133 @@
+package transcription
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+)
+
+// AudioClipper defines operations for cutting a time range out of downloaded audio
+type AudioClipper interface {
+	// ClipAudio writes the audio between from and to (to the end when to is 0) into outputDir
+	ClipAudio(ctx context.Context, audioPath string, outputDir string, from, to time.Duration) (string, error)
+
+	// SampleAudio writes total worth of audio taken from windows spread across audio of the
+	// given duration (from the start when the duration is unknown) into outputDir
+	SampleAudio(ctx context.Context, audioPath string, outputDir string, duration, total time.Duration) (string, error)
+}
+
+// sampleWindows is the number of windows SampleAudio takes across the audio
+const sampleWindows = 3
+
+// ffmpegAudioClipper implements AudioClipper using ffmpeg
+type ffmpegAudioClipper struct {
+	cmdRunner common.CmdRunner
+}
+
+// NewAudioClipper creates a new AudioClipper with default CmdRunner
+func NewAudioClipper() AudioClipper {
+	return &ffmpegAudioClipper{
+		cmdRunner: common.NewCmdRunner(),
+	}
+}
+
+// NewAudioClipperWithCmdRunner creates a new AudioClipper with custom CmdRunner (for testing)
+func NewAudioClipperWithCmdRunner(cmdRunner common.CmdRunner) AudioClipper {
+	return &ffmpegAudioClipper{
+		cmdRunner: cmdRunner,
+	}
+}
+
+// ClipAudio cuts the range out with ffmpeg, re-encoded as 16 kHz mono WAV (what whisper resamples
+// to anyway) so the cut is exact rather than snapped to the source's packet boundaries
+func (c *ffmpegAudioClipper) ClipAudio(ctx context.Context, audioPath string, outputDir string, from, to time.Duration) (string, error) {
+	if audioPath == "" {
+		return "", errors.New(errors.CodeInvalidArg, "audio path is required")
+	}
+	if to != 0 && to <= from {
+		return "", errors.New(errors.CodeInvalidArg, "end of the range must be after its start")
+	}
+
+	clipPath := filepath.Join(outputDir, "clip.wav")
+	args := []string{
+		"-nostdin", "-hide_banner", "-loglevel", "error", "-y",
+		"-ss", ffmpegSeconds(from), // Seek on the input so only the range is decoded
+		"-i", audioPath,
+	}
+	if to != 0 {
+		args = append(args, "-t", ffmpegSeconds(to-from))
+	}
+	args = append(args, "-vn", "-ac", "1", "-ar", "16000", clipPath)
+
+	if err := c.run(ctx, args); err != nil {
+		return "", err
+	}
+	return clipPath, nil
+}
+
+// SampleAudio concatenates sampleWindows evenly spaced windows into one 16 kHz mono WAV file
+func (c *ffmpegAudioClipper) SampleAudio(ctx context.Context, audioPath string, outputDir string, duration, total time.Duration) (string, error) {
+	if total <= 0 {
+		return "", errors.New(errors.CodeInvalidArg, "sample duration must be positive")
+	}
+	if duration <= total {
+		// Short or unknown length: the first total of the audio is the sample
+		return c.ClipAudio(ctx, audioPath, outputDir, 0, total)
+	}
+
+	samplePath := filepath.Join(outputDir, "sample.wav")
+	window := total / sampleWindows
+	args := []string{"-nostdin", "-hide_banner", "-loglevel", "error", "-y"}
+	var inputs strings.Builder
+	for i := 0; i < sampleWindows; i++ {
+		// Center window i in the i-th of sampleWindows equal parts of the audio
+		start := duration*time.Duration(2*i+1)/(2*sampleWindows) - window/2
+		args = append(args, "-ss", ffmpegSeconds(start), "-t", ffmpegSeconds(window), "-i", audioPath)
+		fmt.Fprintf(&inputs, "[%d:a]", i)
+	}
+	args = append(args,
+		"-filter_complex", fmt.Sprintf("%sconcat=n=%d:v=0:a=1", inputs.String(), sampleWindows),
+		"-ac", "1", "-ar", "16000", samplePath,
+	)
+
+	if err := c.run(ctx, args); err != nil {
+		return "", err
+	}
+	return samplePath, nil
+}
+
+// run executes ffmpeg with args
+func (c *ffmpegAudioClipper) run(ctx context.Context, args []string) error {
+	if _, err := c.cmdRunner.Run(ctx, common.ToolFFmpeg, args...); err != nil {
+		if strings.Contains(err.Error(), "executable file not found") {
+			return errors.Wrap(err, errors.CodeExternal, "ffmpeg is not installed or not found in PATH. Please install ffmpeg")
+		}
+		return errors.Wrap(err, errors.CodeExternal, "failed to clip audio with ffmpeg")
+	}
+	return nil
+}
+
+// ffmpegSeconds formats d as seconds for ffmpeg time options
+func ffmpegSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
+
+// OffsetWhisperResult shifts segment times by offset, so a transcription of a clip carries the
+// times of the full video
+func OffsetWhisperResult(result *model.WhisperResult, offset time.Duration) {
+	if offset == 0 {
+		return
+	}
+	seconds := offset.Seconds()
+	for i := range result.Segments {
+		result.Segments[i].Start += seconds
+		result.Segments[i].End += seconds
+	}
+}
@@ -0,0 +1,104 @@
+package transcription
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudioClipper_ClipAudio(t *testing.T) {
+	outputDir := t.TempDir()
+	clipPath := filepath.Join(outputDir, "clip.wav")
+
+	t.Run("cuts the range with ffmpeg", func(t *testing.T) {
+		runner := new(mockWhisperCmdRunner)
+		runner.On("Run", mock.Anything, "ffmpeg", []string{
+			"-nostdin", "-hide_banner", "-loglevel", "error", "-y",
+			"-ss", "300.000", "-i", "/tmp/audio.m4a", "-t", "600.000",
+			"-vn", "-ac", "1", "-ar", "16000", clipPath,
+		}).Return([]byte(""), nil)
+
+		path, err := NewAudioClipperWithCmdRunner(runner).ClipAudio(context.Background(), "/tmp/audio.m4a", outputDir, 5*time.Minute, 15*time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, clipPath, path)
+		runner.AssertExpectations(t)
+	})
+
+	t.Run("runs to the end without an end offset", func(t *testing.T) {
+		runner := new(mockWhisperCmdRunner)
+		runner.On("Run", mock.Anything, "ffmpeg", mock.MatchedBy(func(args []string) bool {
+			for _, arg := range args {
+				if arg == "-t" {
+					return false
+				}
+			}
+			return true
+		})).Return([]byte(""), nil)
+
+		_, err := NewAudioClipperWithCmdRunner(runner).ClipAudio(context.Background(), "/tmp/audio.m4a", outputDir, time.Minute, 0)
+		require.NoError(t, err)
+		runner.AssertExpectations(t)
+	})
+
+	t.Run("reports a missing ffmpeg", func(t *testing.T) {
+		runner := new(mockWhisperCmdRunner)
+		runner.On("Run", mock.Anything, "ffmpeg", mock.Anything).
+			Return(nil, errors.New(`exec: "ffmpeg": executable file not found in $PATH`))
+
+		_, err := NewAudioClipperWithCmdRunner(runner).ClipAudio(context.Background(), "/tmp/audio.m4a", outputDir, time.Minute, 0)
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.CodeExternal, appErr.Code)
+		assert.Contains(t, appErr.Message, "ffmpeg is not installed")
+	})
+}
+
+func TestAudioClipper_SampleAudio(t *testing.T) {
+	outputDir := t.TempDir()
+
+	t.Run("takes windows spread across the audio", func(t *testing.T) {
+		runner := new(mockWhisperCmdRunner)
+		runner.On("Run", mock.Anything, "ffmpeg", []string{
+			"-nostdin", "-hide_banner", "-loglevel", "error", "-y",
+			"-ss", "590.000", "-t", "20.000", "-i", "/tmp/audio.m4a",
+			"-ss", "1790.000", "-t", "20.000", "-i", "/tmp/audio.m4a",
+			"-ss", "2990.000", "-t", "20.000", "-i", "/tmp/audio.m4a",
+			"-filter_complex", "[0:a][1:a][2:a]concat=n=3:v=0:a=1",
+			"-ac", "1", "-ar", "16000", filepath.Join(outputDir, "sample.wav"),
+		}).Return([]byte(""), nil)
+
+		path, err := NewAudioClipperWithCmdRunner(runner).SampleAudio(context.Background(), "/tmp/audio.m4a", outputDir, time.Hour, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(outputDir, "sample.wav"), path)
+		runner.AssertExpectations(t)
+	})
+
+	t.Run("short audio is sampled from the start", func(t *testing.T) {
+		runner := new(mockWhisperCmdRunner)
+		runner.On("Run", mock.Anything, "ffmpeg", mock.MatchedBy(func(args []string) bool {
+			return args[6] == "0.000" && args[10] == "60.000"
+		})).Return([]byte(""), nil)
+
+		_, err := NewAudioClipperWithCmdRunner(runner).SampleAudio(context.Background(), "/tmp/audio.m4a", outputDir, 0, time.Minute)
+		require.NoError(t, err)
+		runner.AssertExpectations(t)
+	})
+}
+
+func TestOffsetWhisperResult(t *testing.T) {
+	result := &model.WhisperResult{Segments: []model.WhisperSegment{{Start: 0, End: 1.5}, {Start: 1.5, End: 3}}}
+
+	OffsetWhisperResult(result, 90*time.Second)
+
+	assert.Equal(t, 90.0, result.Segments[0].Start)
+	assert.Equal(t, 91.5, result.Segments[0].End)
+	assert.Equal(t, 93.0, result.Segments[1].End)
+}
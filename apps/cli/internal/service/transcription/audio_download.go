@@ -5,12 +5,91 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/offline"
 	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
 )
 
+// DefaultAudioFormat and DefaultAudioQuality keep the audio YouTube serves at its best quality
+const (
+	DefaultAudioFormat  = "best"
+	DefaultAudioQuality = "0"
+)
+
+// audioFormats are the yt-dlp --audio-format values whose output the audio cache looks up
+var audioFormats = []string{"best", "aac", "m4a", "mp3", "opus", "vorbis", "wav"}
+
+// audioQualityPattern matches yt-dlp --audio-quality values: a VBR level from 0 (best) to 10
+// (worst), or a bitrate such as 48k
+var audioQualityPattern = regexp.MustCompile(`^(10|[0-9]|[1-9][0-9]*[kK])$`)
+
+// AudioFormatOptions holds the format and quality yt-dlp extracts audio at. A lower quality
+// (opus or m4a at 48k) costs whisper little accuracy on speech and makes audio caches of large
+// batches much smaller.
+type AudioFormatOptions struct {
+	Format  string // yt-dlp --audio-format; defaults to best
+	Quality string // yt-dlp --audio-quality: 0 (best) to 10, or a bitrate such as 48k; defaults to 0
+}
+
+// withDefaults returns the options with unset fields set to their defaults
+func (o AudioFormatOptions) withDefaults() AudioFormatOptions {
+	if o.Format == "" {
+		o.Format = DefaultAudioFormat
+	}
+	if o.Quality == "" {
+		o.Quality = DefaultAudioQuality
+	}
+	return o
+}
+
+// Validate checks the format and quality
+func (o AudioFormatOptions) Validate() error {
+	o = o.withDefaults()
+	supported := false
+	for _, format := range audioFormats {
+		supported = supported || o.Format == format
+	}
+	if !supported {
+		return errors.New(errors.CodeInvalidArg, fmt.Sprintf("unsupported audio format %q (supported: %s)", o.Format, strings.Join(audioFormats, ", ")))
+	}
+	if !audioQualityPattern.MatchString(o.Quality) {
+		return errors.New(errors.CodeInvalidArg, fmt.Sprintf("invalid audio quality %q (expected 0-10 or a bitrate such as 48k)", o.Quality))
+	}
+	return nil
+}
+
+// bestAudioBitrate is roughly the bitrate of the best audio YouTube serves (opus at ~160 kbps)
+const bestAudioBitrate = 160
+
+// EstimateAudioSize roughly estimates the size of audio of the given duration downloaded at
+// the format; VBR levels are estimated at the bitrate of the best audio
+func (o AudioFormatOptions) EstimateAudioSize(audio time.Duration) int64 {
+	o = o.withDefaults()
+	kbps := bestAudioBitrate
+	switch {
+	case o.Format == "wav":
+		kbps = 1411 // Uncompressed, whatever the quality
+	case strings.HasSuffix(strings.ToLower(o.Quality), "k"):
+		if n, err := strconv.Atoi(o.Quality[:len(o.Quality)-1]); err == nil {
+			kbps = n
+		}
+	}
+	return int64(audio.Seconds() * float64(kbps) * 1000 / 8)
+}
+
+// AudioFormatter is implemented by AudioDownloadServices that report the format they download
+// audio at, which the audio cache records with the cached file
+type AudioFormatter interface {
+	// AudioFormat returns the format and quality audio is downloaded at
+	AudioFormat() AudioFormatOptions
+}
+
 // AudioDownloadService defines operations for downloading audio from videos
 type AudioDownloadService interface {
 	// DownloadAudio downloads audio from a video URL using yt-dlp
@@ -20,12 +99,15 @@ type AudioDownloadService interface {
 // audioDownloadService implements AudioDownloadService using yt-dlp
 type audioDownloadService struct {
 	cmdRunner common.CmdRunner
+	detector  *ytdlp.Detector // Optional; without it only baseline yt-dlp arguments are used
+	format    AudioFormatOptions
 }
 
 // NewAudioDownloadService creates a new AudioDownloadService with default CmdRunner
 func NewAudioDownloadService() AudioDownloadService {
 	return &audioDownloadService{
 		cmdRunner: common.NewCmdRunner(),
+		detector:  ytdlp.DefaultDetector(),
 	}
 }
 
@@ -36,6 +118,30 @@ func NewAudioDownloadServiceWithCmdRunner(cmdRunner common.CmdRunner) AudioDownl
 	}
 }
 
+// NewAudioDownloadServiceWithDetector creates a new AudioDownloadService that adapts yt-dlp
+// arguments to the capabilities reported by detector
+func NewAudioDownloadServiceWithDetector(cmdRunner common.CmdRunner, detector *ytdlp.Detector) AudioDownloadService {
+	return &audioDownloadService{
+		cmdRunner: cmdRunner,
+		detector:  detector,
+	}
+}
+
+// NewAudioDownloadServiceWithFormat creates a new AudioDownloadService extracting audio at the
+// given format and quality; call opts.Validate first
+func NewAudioDownloadServiceWithFormat(cmdRunner common.CmdRunner, detector *ytdlp.Detector, opts AudioFormatOptions) AudioDownloadService {
+	return &audioDownloadService{
+		cmdRunner: cmdRunner,
+		detector:  detector,
+		format:    opts,
+	}
+}
+
+// AudioFormat returns the format and quality audio is downloaded at
+func (s *audioDownloadService) AudioFormat() AudioFormatOptions {
+	return s.format.withDefaults()
+}
+
 // DownloadAudio downloads audio from a video URL using yt-dlp
 func (s *audioDownloadService) DownloadAudio(ctx context.Context, videoURL string, outputDir string) (string, error) {
 	// Validate input
@@ -52,22 +158,40 @@ func (s *audioDownloadService) DownloadAudio(ctx context.Context, videoURL strin
 	}
 
 	// Prepare yt-dlp command arguments for audio-only download
+	format := s.AudioFormat()
 	args := []string{
-		"-x",                     // Extract audio only
-		"--audio-format", "best", // Use best available audio format
-		"--audio-quality", "0", // Best quality
+		"-x", // Extract audio only
+		"--audio-format", format.Format,
+		"--audio-quality", format.Quality,
 		"--output", filepath.Join(outputDir, "%(title)s.%(ext)s"), // Output template
-		videoURL,
 	}
 
+	// Ask yt-dlp for the final file path when it can report it (--print implies --simulate)
+	printPath := s.detector.Supports(ctx, ytdlp.CapPrintFilepath)
+	if printPath {
+		args = append(args, "--no-simulate", "--print", "after_move:filepath")
+	}
+	args = append(args, videoURL)
+
 	// Execute yt-dlp command
-	_, err := s.cmdRunner.Run(ctx, "yt-dlp", args...)
+	if err := offline.Check("downloading audio with yt-dlp"); err != nil {
+		return "", err
+	}
+	output, err := s.cmdRunner.Run(ctx, ytdlp.Binary, ytdlp.WithNetworkArgs(args...)...)
 	if err != nil {
 		return "", errors.Wrap(err, errors.CodeExternal, s.formatYtDlpError(err, videoURL))
 	}
 
+	if printPath {
+		if path := printedPath(output); path != "" {
+			if _, err := os.Stat(path); err == nil {
+				return path, nil
+			}
+		}
+	}
+
 	// Find the downloaded audio file
-	// Since we can't predict the exact filename, we need to scan the output directory
+	// Older yt-dlp can't report the filename, so we need to scan the output directory
 	audioPath, err := s.findDownloadedAudio(outputDir)
 	if err != nil {
 		return "", errors.Wrap(err, errors.CodeInternal, "failed to find downloaded audio file")
@@ -76,6 +200,12 @@ func (s *audioDownloadService) DownloadAudio(ctx context.Context, videoURL strin
 	return audioPath, nil
 }
 
+// printedPath returns the last line printed by --print after_move:filepath
+func printedPath(output []byte) string {
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
 // findDownloadedAudio finds the most recently downloaded audio file in the output directory
 func (s *audioDownloadService) findDownloadedAudio(outputDir string) (string, error) {
 	entries, err := os.ReadDir(outputDir)
@@ -84,7 +214,7 @@ func (s *audioDownloadService) findDownloadedAudio(outputDir string) (string, er
 	}
 
 	// Look for audio files (common extensions from yt-dlp)
-	audioExtensions := []string{".m4a", ".mp3", ".webm", ".ogg", ".wav", ".opus"}
+	audioExtensions := []string{".m4a", ".mp3", ".webm", ".ogg", ".wav", ".opus", ".aac"}
 	var audioFiles []string
 	var allFiles []string
 
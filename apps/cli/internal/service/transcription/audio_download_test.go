@@ -0,0 +1,125 @@
+package transcription
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudioDownloadService_DownloadAudio_AdaptsToYtDlpVersion(t *testing.T) {
+	videoURL := "https://www.youtube.com/watch?v=abc123"
+
+	t.Run("uses the path printed by current yt-dlp", func(t *testing.T) {
+		outputDir := t.TempDir()
+		// Another audio file in the directory must not be picked up by a scan
+		require.NoError(t, os.WriteFile(filepath.Join(outputDir, "Another.m4a"), []byte("old"), 0644))
+		downloaded := filepath.Join(outputDir, "Title.opus")
+
+		runner := new(mockWhisperCmdRunner)
+		runner.On("Run", mock.Anything, "yt-dlp", []string{"--version"}).Return([]byte("2024.08.06\n"), nil).Once()
+		runner.On("Run", mock.Anything, "yt-dlp", mock.MatchedBy(func(args []string) bool {
+			return slices.Contains(args, "--print") && slices.Contains(args, "after_move:filepath") && args[len(args)-1] == videoURL
+		})).
+			Run(func(args mock.Arguments) {
+				require.NoError(t, os.WriteFile(downloaded, []byte("audio"), 0644))
+			}).
+			Return([]byte(downloaded+"\n"), nil)
+
+		service := NewAudioDownloadServiceWithDetector(runner, ytdlp.NewDetectorWithWarnings(runner, io.Discard))
+		path, err := service.DownloadAudio(context.Background(), videoURL, outputDir)
+		require.NoError(t, err)
+		assert.Equal(t, downloaded, path)
+		runner.AssertExpectations(t)
+	})
+
+	t.Run("scans the output directory on old yt-dlp", func(t *testing.T) {
+		outputDir := t.TempDir()
+		downloaded := filepath.Join(outputDir, "Title.m4a")
+
+		runner := new(mockWhisperCmdRunner)
+		runner.On("Run", mock.Anything, "yt-dlp", []string{"--version"}).Return([]byte("2021.12.01\n"), nil).Once()
+		runner.On("Run", mock.Anything, "yt-dlp", mock.MatchedBy(func(args []string) bool {
+			return !slices.Contains(args, "--print") && args[len(args)-1] == videoURL
+		})).
+			Run(func(args mock.Arguments) {
+				require.NoError(t, os.WriteFile(downloaded, []byte("audio"), 0644))
+			}).
+			Return([]byte(""), nil)
+
+		service := NewAudioDownloadServiceWithDetector(runner, ytdlp.NewDetectorWithWarnings(runner, io.Discard))
+		path, err := service.DownloadAudio(context.Background(), videoURL, outputDir)
+		require.NoError(t, err)
+		assert.Equal(t, downloaded, path)
+		runner.AssertExpectations(t)
+	})
+}
+
+func TestAudioDownloadService_DownloadAudio_Format(t *testing.T) {
+	videoURL := "https://www.youtube.com/watch?v=abc123"
+	hasArg := func(args []string, flag, value string) bool {
+		i := slices.Index(args, flag)
+		return i >= 0 && i+1 < len(args) && args[i+1] == value
+	}
+
+	tests := []struct {
+		name    string
+		opts    AudioFormatOptions
+		format  string
+		quality string
+		file    string // What yt-dlp writes
+	}{
+		{"defaults to the best audio", AudioFormatOptions{}, "best", "0", "Title.m4a"},
+		{"uses the chosen format and quality", AudioFormatOptions{Format: "m4a", Quality: "48k"}, "m4a", "48k", "Title.m4a"},
+		{"finds aac audio", AudioFormatOptions{Format: "aac"}, "aac", "0", "Title.aac"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputDir := t.TempDir()
+			runner := new(mockWhisperCmdRunner)
+			runner.On("Run", mock.Anything, "yt-dlp", mock.MatchedBy(func(args []string) bool {
+				return hasArg(args, "--audio-format", tt.format) && hasArg(args, "--audio-quality", tt.quality)
+			})).
+				Run(func(args mock.Arguments) {
+					require.NoError(t, os.WriteFile(filepath.Join(outputDir, tt.file), []byte("audio"), 0644))
+				}).
+				Return([]byte(""), nil)
+
+			service := NewAudioDownloadServiceWithFormat(runner, nil, tt.opts)
+			path, err := service.DownloadAudio(context.Background(), videoURL, outputDir)
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(outputDir, tt.file), path)
+			assert.Equal(t, AudioFormatOptions{Format: tt.format, Quality: tt.quality}, service.(AudioFormatter).AudioFormat())
+			runner.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAudioFormatOptions_Validate(t *testing.T) {
+	valid := []AudioFormatOptions{{}, {Format: "m4a", Quality: "48k"}, {Format: "opus", Quality: "10"}, {Format: "mp3", Quality: "128K"}}
+	for _, opts := range valid {
+		assert.NoError(t, opts.Validate(), "%+v", opts)
+	}
+
+	invalid := []AudioFormatOptions{{Format: "flac"}, {Quality: "11"}, {Quality: "48"}, {Quality: "0k"}, {Quality: "high"}}
+	for _, opts := range invalid {
+		assert.Error(t, opts.Validate(), "%+v", opts)
+	}
+}
+
+func TestAudioFormatOptions_EstimateAudioSize(t *testing.T) {
+	hour := time.Hour
+	assert.Equal(t, int64(72_000_000), AudioFormatOptions{}.EstimateAudioSize(hour), "best audio at ~160 kbps")
+	assert.Equal(t, int64(21_600_000), AudioFormatOptions{Format: "m4a", Quality: "48k"}.EstimateAudioSize(hour))
+	assert.Equal(t, int64(57_600_000), AudioFormatOptions{Format: "mp3", Quality: "128K"}.EstimateAudioSize(hour))
+	assert.Equal(t, int64(634_950_000), AudioFormatOptions{Format: "wav"}.EstimateAudioSize(hour))
+	assert.Zero(t, AudioFormatOptions{}.EstimateAudioSize(0))
+}
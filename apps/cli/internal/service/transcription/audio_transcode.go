@@ -0,0 +1,267 @@
+package transcription
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/artifact"
+	"github.com/Taichi-iskw/yt-lang/internal/clock"
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/janitor"
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+)
+
+// DefaultTranscodeBitrate is a bitrate at which Opus speech stays transcribable at a fraction
+// of the size of YouTube's audio
+const DefaultTranscodeBitrate = "32k"
+
+// transcodeFormats maps the formats cached audio can be re-encoded to onto their file extension
+// and ffmpeg encoder; the extensions must be ones the audio cache looks up
+var transcodeFormats = map[string]struct{ ext, codec string }{
+	"opus": {".opus", "libopus"},
+	"mp3":  {".mp3", "libmp3lame"},
+}
+
+// bitratePattern matches ffmpeg bitrates such as 32k
+var bitratePattern = regexp.MustCompile(`^[1-9][0-9]*k$`)
+
+// TranscodeOptions holds the target encoding of AudioTranscoder
+type TranscodeOptions struct {
+	Format  string // opus or mp3
+	Bitrate string // ffmpeg bitrate, e.g. 32k
+	DryRun  bool   // Only report the cached audio that would be re-encoded
+}
+
+// Validate checks the format and bitrate
+func (o TranscodeOptions) Validate() error {
+	if _, ok := transcodeFormats[o.Format]; !ok {
+		return errors.New(errors.CodeInvalidArg, fmt.Sprintf("unsupported format %q (supported: opus, mp3)", o.Format))
+	}
+	if !bitratePattern.MatchString(o.Bitrate) {
+		return errors.New(errors.CodeInvalidArg, fmt.Sprintf("invalid bitrate %q (expected e.g. 32k)", o.Bitrate))
+	}
+	return nil
+}
+
+// AudioSource records the audio a cached file was re-encoded from, so transcriptions of the
+// re-encoded audio can still be traced to the audio YouTube served. It is kept next to the audio
+// under artifact.AudioSourceKey.
+type AudioSource struct {
+	VideoID        string    `json:"video_id"`
+	OriginalKey    string    `json:"original_key"`
+	OriginalSHA256 string    `json:"original_sha256"`
+	OriginalSize   int64     `json:"original_size"`
+	Key            string    `json:"key"`
+	SHA256         string    `json:"sha256"`
+	Size           int64     `json:"size"`
+	Format         string    `json:"format"`
+	Bitrate        string    `json:"bitrate"`
+	TranscodedAt   time.Time `json:"transcoded_at"`
+}
+
+// TranscodeResult is the outcome of re-encoding the cached audio of one video
+type TranscodeResult struct {
+	VideoID string
+	FromKey string // Empty when no audio is cached
+	ToKey   string
+	Before  int64  // Size of the cached audio; unknown (0) in dry runs on stores without sizes
+	After   int64  // Size of the re-encoded audio; 0 when skipped
+	Skipped string // Why the audio was left as is
+}
+
+// AudioTranscoder re-encodes cached audio to a smaller format
+type AudioTranscoder interface {
+	// Transcode re-encodes the cached audio of a video. Audio already in the target format,
+	// and audio the encoding would not make smaller, is left as is and reported as skipped.
+	Transcode(ctx context.Context, videoID string, opts TranscodeOptions) (*TranscodeResult, error)
+}
+
+// ffmpegAudioTranscoder implements AudioTranscoder with ffmpeg
+type ffmpegAudioTranscoder struct {
+	store     artifact.Store
+	cmdRunner common.CmdRunner
+	clock     clock.Clock
+}
+
+// NewAudioTranscoder creates a new AudioTranscoder for the audio cached in store
+func NewAudioTranscoder(store artifact.Store) AudioTranscoder {
+	return NewAudioTranscoderWithCmdRunner(store, common.NewCmdRunner())
+}
+
+// NewAudioTranscoderWithCmdRunner creates a new AudioTranscoder with custom CmdRunner (for testing)
+func NewAudioTranscoderWithCmdRunner(store artifact.Store, cmdRunner common.CmdRunner) AudioTranscoder {
+	return &ffmpegAudioTranscoder{store: store, cmdRunner: cmdRunner, clock: clock.System}
+}
+
+func (t *ffmpegAudioTranscoder) Transcode(ctx context.Context, videoID string, opts TranscodeOptions) (*TranscodeResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	format := transcodeFormats[opts.Format]
+	result := &TranscodeResult{VideoID: videoID, ToKey: artifact.AudioKey(videoID, format.ext)}
+
+	for _, key := range CachedAudioKeys(videoID) {
+		exists, err := t.store.Exists(ctx, key)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeExternal, "failed to look up cached audio")
+		}
+		if exists {
+			result.FromKey = key
+			break
+		}
+	}
+	switch {
+	case result.FromKey == "":
+		result.Skipped = "no cached audio"
+		return result, nil
+	case result.FromKey == result.ToKey:
+		result.Skipped = "already " + opts.Format
+		return result, nil
+	}
+	if sizer, ok := t.store.(artifact.Sizer); ok {
+		if size, err := sizer.Size(ctx, result.FromKey); err == nil {
+			result.Before = size
+		}
+	}
+	if opts.DryRun {
+		return result, nil
+	}
+
+	tempDir, err := janitor.MkdirTemp("yt-lang-transcode-*")
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to create temp directory")
+	}
+	defer janitor.RemoveTemp(tempDir)
+
+	inputPath := filepath.Join(tempDir, "input"+filepath.Ext(result.FromKey))
+	originalSHA, originalSize, err := t.download(ctx, result.FromKey, inputPath)
+	if err != nil {
+		return nil, err
+	}
+	result.Before = originalSize
+
+	// Whisper mixes down to mono anyway, so the second channel would only take space
+	outputPath := filepath.Join(tempDir, "output"+format.ext)
+	args := []string{
+		"-nostdin", "-hide_banner", "-loglevel", "error", "-y",
+		"-i", inputPath, "-vn", "-ac", "1", "-c:a", format.codec, "-b:a", opts.Bitrate,
+		outputPath,
+	}
+	if _, err := t.cmdRunner.Run(ctx, common.ToolFFmpeg, args...); err != nil {
+		if strings.Contains(err.Error(), "executable file not found") {
+			return nil, errors.Wrap(err, errors.CodeExternal, "ffmpeg is not installed or not found in PATH. Please install ffmpeg")
+		}
+		return nil, errors.Wrap(err, errors.CodeExternal, "failed to re-encode audio with ffmpeg")
+	}
+
+	sha, size, err := fileDigest(outputPath)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to read re-encoded audio")
+	}
+	if size >= originalSize {
+		result.Skipped = fmt.Sprintf("%s at %s would not be smaller", opts.Format, opts.Bitrate)
+		return result, nil
+	}
+	result.After = size
+
+	// Keep the source of audio re-encoded before, which is what YouTube served
+	source := &AudioSource{OriginalKey: result.FromKey, OriginalSHA256: originalSHA, OriginalSize: originalSize}
+	if previous, err := ReadAudioSource(ctx, t.store, videoID); err == nil {
+		source.OriginalKey, source.OriginalSHA256, source.OriginalSize = previous.OriginalKey, previous.OriginalSHA256, previous.OriginalSize
+	}
+	source.VideoID, source.Key, source.SHA256, source.Size = videoID, result.ToKey, sha, size
+	source.Format, source.Bitrate, source.TranscodedAt = opts.Format, opts.Bitrate, t.clock.Now().UTC()
+
+	// Store the new audio before deleting the old, so the video always has cached audio
+	if err := t.upload(ctx, result.ToKey, outputPath); err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(source, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to encode audio source")
+	}
+	if err := t.store.Put(ctx, artifact.AudioSourceKey(videoID), bytes.NewReader(data)); err != nil {
+		return nil, errors.Wrap(err, errors.CodeExternal, "failed to store audio source")
+	}
+	if err := t.store.Delete(ctx, result.FromKey); err != nil {
+		return nil, errors.Wrap(err, errors.CodeExternal, "failed to delete the original cached audio")
+	}
+	return result, nil
+}
+
+// ReadAudioSource returns the source of re-encoded cached audio; it fails with
+// artifact.ErrNotFound when the cached audio of the video was never re-encoded
+func ReadAudioSource(ctx context.Context, store artifact.Store, videoID string) (*AudioSource, error) {
+	reader, err := store.Open(ctx, artifact.AudioSourceKey(videoID))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var source AudioSource
+	if err := json.NewDecoder(reader).Decode(&source); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to decode audio source")
+	}
+	return &source, nil
+}
+
+// download copies the artifact under key to path and returns its SHA-256 and size
+func (t *ffmpegAudioTranscoder) download(ctx context.Context, key, path string) (string, int64, error) {
+	reader, err := t.store.Open(ctx, key)
+	if err != nil {
+		return "", 0, errors.Wrap(err, errors.CodeExternal, "failed to open cached audio")
+	}
+	defer reader.Close()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", 0, errors.Wrap(err, errors.CodeInternal, "failed to create temp file")
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(file, hash), reader)
+	if err != nil {
+		return "", 0, errors.Wrap(err, errors.CodeExternal, "failed to read cached audio")
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+// upload stores the file at path under key
+func (t *ffmpegAudioTranscoder) upload(ctx context.Context, key, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to open re-encoded audio")
+	}
+	defer file.Close()
+	if err := t.store.Put(ctx, key, file); err != nil {
+		return errors.Wrap(err, errors.CodeExternal, "failed to store re-encoded audio")
+	}
+	return nil
+}
+
+// fileDigest returns the SHA-256 and size of a file
+func fileDigest(path string) (string, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
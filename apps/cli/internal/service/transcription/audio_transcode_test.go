@@ -0,0 +1,137 @@
+package transcription
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Taichi-iskw/yt-lang/internal/artifact"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFFmpeg makes the mock runner write output bytes to the output path, the last ffmpeg argument
+func fakeFFmpeg(runner *mockWhisperCmdRunner, output string) {
+	runner.On("Run", mock.Anything, "ffmpeg", mock.Anything).
+		Run(func(args mock.Arguments) {
+			ffmpegArgs := args.Get(2).([]string)
+			os.WriteFile(ffmpegArgs[len(ffmpegArgs)-1], []byte(output), 0644)
+		}).
+		Return([]byte(""), nil)
+}
+
+func TestAudioTranscoder_Transcode(t *testing.T) {
+	ctx := context.Background()
+	original := strings.Repeat("m4a-audio", 100)
+	originalSum := sha256.Sum256([]byte(original))
+
+	t.Run("replaces the cached audio and records its source", func(t *testing.T) {
+		store := artifact.NewLocalStore(t.TempDir())
+		require.NoError(t, store.Put(ctx, artifact.AudioKey("abc123", ".m4a"), strings.NewReader(original)))
+
+		runner := new(mockWhisperCmdRunner)
+		runner.On("Run", mock.Anything, "ffmpeg", mock.MatchedBy(func(args []string) bool {
+			joined := strings.Join(args, " ")
+			return strings.Contains(joined, "-vn -ac 1 -c:a libopus -b:a 32k") && strings.HasSuffix(joined, "output.opus")
+		})).
+			Run(func(args mock.Arguments) {
+				ffmpegArgs := args.Get(2).([]string)
+				require.NoError(t, os.WriteFile(ffmpegArgs[len(ffmpegArgs)-1], []byte("opus"), 0644))
+			}).
+			Return([]byte(""), nil)
+
+		transcoder := NewAudioTranscoderWithCmdRunner(store, runner)
+		result, err := transcoder.Transcode(ctx, "abc123", TranscodeOptions{Format: "opus", Bitrate: "32k"})
+		require.NoError(t, err)
+		assert.Equal(t, &TranscodeResult{VideoID: "abc123", FromKey: "audio/abc123.m4a", ToKey: "audio/abc123.opus", Before: int64(len(original)), After: 4}, result)
+		runner.AssertExpectations(t)
+
+		exists, err := store.Exists(ctx, artifact.AudioKey("abc123", ".m4a"))
+		require.NoError(t, err)
+		assert.False(t, exists)
+		cached, err := AudioCached(ctx, store, "abc123")
+		require.NoError(t, err)
+		assert.True(t, cached)
+
+		source, err := ReadAudioSource(ctx, store, "abc123")
+		require.NoError(t, err)
+		assert.Equal(t, "audio/abc123.m4a", source.OriginalKey)
+		assert.Equal(t, hex.EncodeToString(originalSum[:]), source.OriginalSHA256)
+		assert.Equal(t, "audio/abc123.opus", source.Key)
+
+		// Re-encoding again keeps the original, not the intermediate file
+		runner = new(mockWhisperCmdRunner)
+		fakeFFmpeg(runner, "mp")
+		_, err = NewAudioTranscoderWithCmdRunner(store, runner).Transcode(ctx, "abc123", TranscodeOptions{Format: "mp3", Bitrate: "24k"})
+		require.NoError(t, err)
+		source, err = ReadAudioSource(ctx, store, "abc123")
+		require.NoError(t, err)
+		assert.Equal(t, "audio/abc123.m4a", source.OriginalKey)
+		assert.Equal(t, "audio/abc123.mp3", source.Key)
+		assert.Equal(t, "mp3", source.Format)
+	})
+
+	t.Run("keeps audio that would not get smaller", func(t *testing.T) {
+		store := artifact.NewLocalStore(t.TempDir())
+		require.NoError(t, store.Put(ctx, artifact.AudioKey("abc123", ".m4a"), strings.NewReader("tiny")))
+		runner := new(mockWhisperCmdRunner)
+		fakeFFmpeg(runner, "larger output")
+
+		result, err := NewAudioTranscoderWithCmdRunner(store, runner).Transcode(ctx, "abc123", TranscodeOptions{Format: "opus", Bitrate: "32k"})
+		require.NoError(t, err)
+		assert.Equal(t, "opus at 32k would not be smaller", result.Skipped)
+		exists, err := store.Exists(ctx, artifact.AudioKey("abc123", ".m4a"))
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("skips audio in the target format, uncached audio and dry runs", func(t *testing.T) {
+		store := artifact.NewLocalStore(t.TempDir())
+		require.NoError(t, store.Put(ctx, artifact.AudioKey("opus1", ".opus"), bytes.NewReader([]byte("opus"))))
+		require.NoError(t, store.Put(ctx, artifact.AudioKey("m4a1", ".m4a"), strings.NewReader(original)))
+		transcoder := NewAudioTranscoderWithCmdRunner(store, new(mockWhisperCmdRunner))
+		opts := TranscodeOptions{Format: "opus", Bitrate: "32k", DryRun: true}
+
+		result, err := transcoder.Transcode(ctx, "opus1", opts)
+		require.NoError(t, err)
+		assert.Equal(t, "already opus", result.Skipped)
+
+		result, err = transcoder.Transcode(ctx, "none", opts)
+		require.NoError(t, err)
+		assert.Empty(t, result.FromKey)
+
+		result, err = transcoder.Transcode(ctx, "m4a1", opts)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(original)), result.Before)
+		assert.Empty(t, result.Skipped)
+		exists, err := store.Exists(ctx, artifact.AudioKey("m4a1", ".m4a"))
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("ffmpeg failure keeps the cached audio", func(t *testing.T) {
+		store := artifact.NewLocalStore(t.TempDir())
+		require.NoError(t, store.Put(ctx, artifact.AudioKey("abc123", ".m4a"), strings.NewReader(original)))
+		runner := new(mockWhisperCmdRunner)
+		runner.On("Run", mock.Anything, "ffmpeg", mock.Anything).Return(nil, errors.New("exit status 1"))
+
+		_, err := NewAudioTranscoderWithCmdRunner(store, runner).Transcode(ctx, "abc123", TranscodeOptions{Format: "opus", Bitrate: "32k"})
+		assert.Error(t, err)
+		exists, err := store.Exists(ctx, artifact.AudioKey("abc123", ".m4a"))
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
+
+func TestTranscodeOptions_Validate(t *testing.T) {
+	assert.NoError(t, TranscodeOptions{Format: "opus", Bitrate: "32k"}.Validate())
+	assert.Error(t, TranscodeOptions{Format: "flac", Bitrate: "32k"}.Validate())
+	assert.Error(t, TranscodeOptions{Format: "opus", Bitrate: "32"}.Validate())
+	assert.Error(t, TranscodeOptions{Format: "opus", Bitrate: "0k"}.Validate())
+}
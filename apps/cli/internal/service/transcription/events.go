@@ -0,0 +1,79 @@
+package transcription
+
+import (
+	"context"
+	"time"
+)
+
+// Pipeline events reported to CreateOptions.Events while a transcription is created
+const (
+	EventStarted         = "started"          // The video was found and its audio is being downloaded
+	EventAudioDownloaded = "audio_downloaded" // Audio is ready (and clipped to the range)
+	EventWhisperProgress = "whisper_progress" // Whisper is still running; sent periodically
+	EventSegmentsSaved   = "segments_saved"   // Segments are stored
+	EventCompleted       = "completed"        // The transcription is completed (or already was)
+	EventFailed          = "failed"           // Creating the transcription failed
+)
+
+// whisperProgressInterval is how often whisper_progress is sent while whisper runs. The whisper
+// CLI reports no progress of its own, so the events only carry the elapsed time.
+var whisperProgressInterval = 10 * time.Second
+
+// Event is a step of the transcription pipeline, written as one JSON line by transcription
+// create --events. Fields that don't apply to an event are left out.
+type Event struct {
+	Time            time.Time `json:"time"`
+	Event           string    `json:"event"`
+	VideoID         string    `json:"video_id"`
+	TranscriptionID string    `json:"transcription_id,omitempty"`
+	Language        string    `json:"language,omitempty"`        // Requested language (started), spoken language (completed)
+	AudioSeconds    float64   `json:"audio_seconds,omitempty"`   // Length of the audio given to whisper
+	ElapsedSeconds  float64   `json:"elapsed_seconds,omitempty"` // Time whisper has been running
+	Segments        int       `json:"segments,omitempty"`        // Segments saved
+	Error           string    `json:"error,omitempty"`
+}
+
+// emit sends event to opts.Events, if set
+func (o CreateOptions) emit(event Event) {
+	if o.Events == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	o.Events(event)
+}
+
+// reportWhisperProgress sends whisper_progress every whisperProgressInterval until the returned
+// stop function is called
+func (o CreateOptions) reportWhisperProgress(ctx context.Context, base Event) (stop func()) {
+	if o.Events == nil {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	started := time.Now()
+	go func() {
+		defer close(finished)
+		ticker := time.NewTicker(whisperProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				event := base
+				event.Event = EventWhisperProgress
+				event.ElapsedSeconds = time.Since(started).Round(time.Second).Seconds()
+				o.emit(event)
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-finished
+	}
+}
@@ -0,0 +1,103 @@
+package transcription
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// eventRecorder collects events, which whisper_progress sends from another goroutine
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *eventRecorder) record(event Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+// types returns the event types in order, with repeated whisper_progress events collapsed
+func (r *eventRecorder) types() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var types []string
+	for _, event := range r.events {
+		if len(types) > 0 && types[len(types)-1] == event.Event {
+			continue
+		}
+		types = append(types, event.Event)
+	}
+	return types
+}
+
+func TestTranscriptionService_CreateTranscription_Events(t *testing.T) {
+	interval := whisperProgressInterval
+	whisperProgressInterval = 5 * time.Millisecond
+	defer func() { whisperProgressInterval = interval }()
+
+	transcRepo := new(mockTranscriptionRepository)
+	segRepo := new(mockSegmentRepository)
+	whisperSvc := new(mockWhisperService)
+	audioSvc := new(mockAudioDownloadService)
+	videoRepo := new(mockVideoRepository)
+
+	videoRepo.On("GetByID", mock.Anything, "video-123").
+		Return(&model.Video{ID: "video-123", URL: "https://youtube.com/watch?v=test", Duration: 90}, nil)
+	audioSvc.On("DownloadAudio", mock.Anything, "https://youtube.com/watch?v=test", mock.AnythingOfType("string")).
+		Return("/tmp/audio.m4a", nil)
+	transcRepo.On("GetByVideoIDAndLanguage", mock.Anything, "video-123", "auto").
+		Return(nil, assert.AnError)
+	transcRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Transcription")).
+		Run(func(args mock.Arguments) { args.Get(1).(*model.Transcription).ID = "transcription-123" }).
+		Return(nil)
+	whisperSvc.On("TranscribeAudio", mock.Anything, "/tmp/audio.m4a", "auto").
+		After(30*time.Millisecond).
+		Return(&model.WhisperResult{
+			Language: "ja",
+			Segments: []model.WhisperSegment{{Start: 0, End: 2, Text: "こんにちは"}, {Start: 2, End: 4, Text: "世界"}},
+		}, nil)
+	segRepo.On("CreateBatch", mock.Anything, mock.AnythingOfType("[]*model.TranscriptionSegment")).
+		Return(nil)
+	transcRepo.On("UpdateStatus", mock.Anything, "transcription-123", "completed", (*string)(nil)).
+		Return(nil)
+
+	recorder := &eventRecorder{}
+	service := NewTranscriptionServiceWithAllDependencies(transcRepo, segRepo, whisperSvc, audioSvc, videoRepo, nil)
+	_, err := service.CreateTranscription(context.Background(), "video-123", "auto", CreateOptions{Events: recorder.record})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{EventStarted, EventAudioDownloaded, EventWhisperProgress, EventSegmentsSaved, EventCompleted}, recorder.types())
+	events := recorder.events
+	assert.Equal(t, float64(90), events[1].AudioSeconds)
+	assert.Equal(t, "transcription-123", events[2].TranscriptionID)
+	assert.Equal(t, 2, events[len(events)-2].Segments)
+
+	// Completed reports the detected language
+	completed := events[len(events)-1]
+	assert.False(t, completed.Time.IsZero())
+	completed.Time = time.Time{}
+	assert.Equal(t, Event{Event: EventCompleted, VideoID: "video-123", TranscriptionID: "transcription-123", Language: "ja"}, completed)
+}
+
+func TestTranscriptionService_CreateTranscription_FailedEvent(t *testing.T) {
+	videoRepo := new(mockVideoRepository)
+	videoRepo.On("GetByID", mock.Anything, "missing").Return(nil, assert.AnError)
+
+	recorder := &eventRecorder{}
+	service := NewTranscriptionServiceWithAllDependencies(new(mockTranscriptionRepository), new(mockSegmentRepository), new(mockWhisperService), new(mockAudioDownloadService), videoRepo, nil)
+	_, err := service.CreateTranscription(context.Background(), "missing", "en", CreateOptions{Events: recorder.record})
+	require.Error(t, err)
+
+	assert.Equal(t, []string{EventStarted, EventFailed}, recorder.types())
+	assert.Equal(t, "en", recorder.events[0].Language)
+	assert.Contains(t, recorder.events[1].Error, "video not found")
+}
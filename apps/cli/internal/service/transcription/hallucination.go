@@ -0,0 +1,95 @@
+package transcription
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// DefaultHallucinationPhrases are phrases whisper commonly emits on silence or music, learned from
+// the end screens of its training videos. They are used unless transcription.hallucinations.phrases
+// is configured.
+var DefaultHallucinationPhrases = []string{
+	"Thanks for watching!",
+	"Thank you for watching!",
+	"Thank you so much for watching!",
+	"Please subscribe to my channel.",
+	"Don't forget to like and subscribe!",
+	"Subtitles by the Amara.org community",
+	"ご視聴ありがとうございました",
+	"チャンネル登録よろしくお願いします",
+	"Gracias por ver el video.",
+	"¡Suscríbete!",
+	"Sous-titres réalisés par la communauté d'Amara.org",
+	"Untertitel im Auftrag des ZDF, 2017",
+}
+
+// HallucinationFilter finds whisper segments consisting only of a phrase of its list. Phrases
+// match regardless of case, punctuation and spacing; a segment saying more than the phrase is
+// kept as it is, since the phrase may then really have been said.
+type HallucinationFilter struct {
+	mode    string
+	phrases map[string]string // Normalized phrase => phrase as listed
+}
+
+// NewHallucinationFilter creates a filter flagging or removing segments matching phrases. Mode
+// defaults to model.HallucinationFlag; with model.HallucinationOff it returns nil, which keeps
+// every segment.
+func NewHallucinationFilter(mode string, phrases []string) (*HallucinationFilter, error) {
+	switch mode {
+	case "":
+		mode = model.HallucinationFlag
+	case model.HallucinationFlag, model.HallucinationRemove:
+	case model.HallucinationOff:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported hallucination mode: %s (supported: flag, remove, off)", mode)
+	}
+
+	filter := &HallucinationFilter{mode: mode, phrases: make(map[string]string, len(phrases))}
+	for _, phrase := range phrases {
+		if key := normalizeHallucination(phrase); key != "" {
+			filter.phrases[key] = phrase
+		}
+	}
+	return filter, nil
+}
+
+// Clean returns the segments to save and the report of those matching a phrase; the report is nil
+// when none matched. Flagged segments are kept at their index; removed ones are left out.
+func (f *HallucinationFilter) Clean(segments []model.WhisperSegment) ([]model.WhisperSegment, *model.HallucinationReport) {
+	if f == nil || len(f.phrases) == 0 {
+		return segments, nil
+	}
+
+	report := &model.HallucinationReport{Mode: f.mode, Phrases: map[string]int{}}
+	kept := make([]model.WhisperSegment, 0, len(segments))
+	for i, segment := range segments {
+		phrase, ok := f.phrases[normalizeHallucination(segment.Text)]
+		if !ok {
+			kept = append(kept, segment)
+			continue
+		}
+		report.Phrases[phrase]++
+		if f.mode == model.HallucinationFlag {
+			report.Segments = append(report.Segments, i)
+			kept = append(kept, segment)
+		}
+	}
+
+	if len(report.Phrases) == 0 {
+		return segments, nil
+	}
+	return kept, report
+}
+
+// normalizeHallucination lower-cases text and keeps only its letters and digits, separated by
+// single spaces, so "Thanks for watching!" and " thanks for watching " match
+func normalizeHallucination(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,122 @@
+package transcription
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+func hallucinationSegments() []model.WhisperSegment {
+	return []model.WhisperSegment{
+		{Start: 0, End: 3, Text: " Today we cook paella."},
+		{Start: 3, End: 30, Text: " Thanks for watching!"},
+		{Start: 30, End: 33, Text: "Thanks for watching the whole series, see you next week."},
+		{Start: 33, End: 60, Text: "  thanks FOR watching  "},
+		{Start: 60, End: 62, Text: "ご視聴ありがとうございました。"},
+	}
+}
+
+func TestHallucinationFilter_Clean(t *testing.T) {
+	t.Run("flag keeps segments and records their indexes", func(t *testing.T) {
+		filter, err := NewHallucinationFilter("", DefaultHallucinationPhrases)
+		require.NoError(t, err)
+
+		kept, report := filter.Clean(hallucinationSegments())
+		assert.Len(t, kept, 5)
+		require.NotNil(t, report)
+		assert.Equal(t, model.HallucinationFlag, report.Mode)
+		assert.Equal(t, map[string]int{"Thanks for watching!": 2, "ご視聴ありがとうございました": 1}, report.Phrases)
+		assert.Equal(t, []int{1, 3, 4}, report.Segments)
+		assert.Equal(t, 3, report.Count())
+	})
+
+	t.Run("remove drops matching segments only", func(t *testing.T) {
+		filter, err := NewHallucinationFilter(model.HallucinationRemove, []string{"thanks for watching"})
+		require.NoError(t, err)
+
+		kept, report := filter.Clean(hallucinationSegments())
+		require.Len(t, kept, 3)
+		assert.Equal(t, " Today we cook paella.", kept[0].Text)
+		assert.Equal(t, "Thanks for watching the whole series, see you next week.", kept[1].Text)
+		require.NotNil(t, report)
+		assert.Equal(t, map[string]int{"thanks for watching": 2}, report.Phrases)
+		assert.Empty(t, report.Segments)
+	})
+
+	t.Run("no match returns no report", func(t *testing.T) {
+		filter, err := NewHallucinationFilter(model.HallucinationRemove, []string{"Please subscribe"})
+		require.NoError(t, err)
+
+		segments := hallucinationSegments()
+		kept, report := filter.Clean(segments)
+		assert.Equal(t, segments, kept)
+		assert.Nil(t, report)
+	})
+
+	t.Run("off and nil filters keep everything", func(t *testing.T) {
+		filter, err := NewHallucinationFilter(model.HallucinationOff, DefaultHallucinationPhrases)
+		require.NoError(t, err)
+		assert.Nil(t, filter)
+
+		kept, report := filter.Clean(hallucinationSegments())
+		assert.Len(t, kept, 5)
+		assert.Nil(t, report)
+	})
+
+	t.Run("rejects unknown modes", func(t *testing.T) {
+		_, err := NewHallucinationFilter("drop", nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestTranscriptionService_CreateTranscription_Hallucinations(t *testing.T) {
+	transcRepo := new(mockTranscriptionRepository)
+	segRepo := new(mockSegmentRepository)
+	whisperSvc := new(mockWhisperService)
+	audioSvc := new(mockAudioDownloadService)
+	videoRepo := new(mockVideoRepository)
+
+	videoRepo.On("GetByID", mock.Anything, "video-123").
+		Return(&model.Video{ID: "video-123", URL: "https://youtube.com/watch?v=test"}, nil)
+	audioSvc.On("DownloadAudio", mock.Anything, "https://youtube.com/watch?v=test", mock.AnythingOfType("string")).
+		Return("/tmp/audio.m4a", nil)
+	transcRepo.On("GetByVideoIDAndLanguage", mock.Anything, "video-123", "en").
+		Return(nil, assert.AnError)
+	transcRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Transcription")).
+		Run(func(args mock.Arguments) { args.Get(1).(*model.Transcription).ID = "transcription-123" }).
+		Return(nil)
+	whisperSvc.On("TranscribeAudio", mock.Anything, "/tmp/audio.m4a", "en").
+		Return(&model.WhisperResult{Language: "en", Segments: hallucinationSegments()}, nil)
+
+	report := &model.HallucinationReport{
+		Mode:    model.HallucinationRemove,
+		Phrases: map[string]int{"Thanks for watching!": 2, "ご視聴ありがとうございました": 1},
+	}
+	transcRepo.On("SetHallucinations", mock.Anything, "transcription-123", report).Return(nil)
+	var saved []*model.TranscriptionSegment
+	segRepo.On("CreateBatch", mock.Anything, mock.AnythingOfType("[]*model.TranscriptionSegment")).
+		Run(func(args mock.Arguments) { saved = args.Get(1).([]*model.TranscriptionSegment) }).
+		Return(nil)
+	transcRepo.On("UpdateStatus", mock.Anything, "transcription-123", "completed", (*string)(nil)).
+		Return(nil)
+
+	filter, err := NewHallucinationFilter(model.HallucinationRemove, DefaultHallucinationPhrases)
+	require.NoError(t, err)
+
+	service := NewTranscriptionServiceWithAllDependencies(transcRepo, segRepo, whisperSvc, audioSvc, videoRepo, nil)
+	result, err := service.CreateTranscription(context.Background(), "video-123", "en", CreateOptions{Hallucinations: filter})
+	require.NoError(t, err)
+
+	// Removed segments leave no gap in the segment indexes
+	require.Len(t, saved, 2)
+	assert.Equal(t, 0, saved[0].SegmentIndex)
+	assert.Equal(t, 1, saved[1].SegmentIndex)
+	assert.Equal(t, "00:00:30.000", saved[1].StartTime)
+	assert.Equal(t, report, result.Hallucinations)
+	transcRepo.AssertExpectations(t)
+}
@@ -0,0 +1,64 @@
+package transcription
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Taichi-iskw/yt-lang/internal/hook"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/subtitle"
+)
+
+// TranscriptionCompletedPayload is the data of a transcription_completed event
+type TranscriptionCompletedPayload struct {
+	Transcription *model.Transcription `json:"transcription"`
+}
+
+// hookedService runs hooks after transcriptions complete
+type hookedService struct {
+	TranscriptionService
+	dispatcher *hook.Dispatcher
+}
+
+// NewHookedService wraps a transcription service so that transcription_completed hooks
+// run after each completed transcription
+func NewHookedService(inner TranscriptionService, dispatcher *hook.Dispatcher) TranscriptionService {
+	return &hookedService{TranscriptionService: inner, dispatcher: dispatcher}
+}
+
+// CreateTranscription transcribes and then dispatches transcription_completed.
+// Hook failures are reported as warnings; the transcription stays saved.
+func (s *hookedService) CreateTranscription(ctx context.Context, videoID string, language string, opts CreateOptions) (*model.Transcription, error) {
+	transcription, err := s.TranscriptionService.CreateTranscription(ctx, videoID, language, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	s.completed(ctx, transcription)
+	return transcription, nil
+}
+
+// ImportTranscription imports subtitles and then dispatches transcription_completed, so imported
+// transcriptions enter the same pipeline as whisper ones
+func (s *hookedService) ImportTranscription(ctx context.Context, videoID string, language string, cues []subtitle.Cue) (*model.Transcription, error) {
+	transcription, err := s.TranscriptionService.ImportTranscription(ctx, videoID, language, cues)
+	if err != nil {
+		return nil, err
+	}
+
+	s.completed(ctx, transcription)
+	return transcription, nil
+}
+
+// completed dispatches transcription_completed for a completed transcription, reporting hook
+// failures as warnings
+func (s *hookedService) completed(ctx context.Context, transcription *model.Transcription) {
+	if transcription.Status != "completed" {
+		return
+	}
+	payload := TranscriptionCompletedPayload{Transcription: transcription}
+	if err := s.dispatcher.Dispatch(ctx, hook.EventTranscriptionCompleted, payload); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
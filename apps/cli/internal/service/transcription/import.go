@@ -0,0 +1,73 @@
+package transcription
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/subtitle"
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
+)
+
+// ImportTranscription saves subtitle cues (e.g. parsed from an SRT or WebVTT file) as a completed
+// transcription of a video in language, one segment per cue, without running whisper
+func (s *transcriptionService) ImportTranscription(ctx context.Context, videoID string, language string, cues []subtitle.Cue) (*model.Transcription, error) {
+	if language == "" || language == "auto" {
+		return nil, errors.New(errors.CodeInvalidArg, "the language of imported subtitles must be given")
+	}
+	if len(cues) == 0 {
+		return nil, errors.New(errors.CodeInvalidArg, "no subtitle cues to import")
+	}
+
+	if _, err := s.videoRepo.GetByID(ctx, videoID); err != nil {
+		return nil, errors.Wrap(err, errors.CodeNotFound, "video not found")
+	}
+	if existing, err := s.transcriptionRepo.GetByVideoIDAndLanguage(ctx, videoID, language); err == nil {
+		return nil, errors.New(errors.CodeConflict, fmt.Sprintf("video %s already has a transcription in %s (%s); delete it before importing", videoID, language, existing.ID))
+	}
+
+	// The transcription lasts until the latest cue ends; cues may overlap
+	var total time.Duration
+	for _, cue := range cues {
+		total = max(total, cue.End)
+	}
+
+	now := s.clock.Now()
+	totalDuration := timecode.FormatInterval(total)
+	detected := language
+	imported := &model.Transcription{
+		ID:               s.ids.NewID(),
+		VideoID:          videoID,
+		Language:         language,
+		Status:           "completed",
+		Source:           model.TranscriptionSourceImport,
+		CreatedAt:        now,
+		CompletedAt:      &now,
+		DetectedLanguage: &detected,
+		TotalDuration:    &totalDuration,
+	}
+	if err := s.transcriptionRepo.Create(ctx, imported); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to create transcription record")
+	}
+
+	segments := make([]*model.TranscriptionSegment, len(cues))
+	for i, cue := range cues {
+		segments[i] = &model.TranscriptionSegment{
+			TranscriptionID: imported.ID,
+			SegmentIndex:    i,
+			StartTime:       timecode.FormatInterval(cue.Start),
+			EndTime:         timecode.FormatInterval(cue.End),
+			Text:            strings.Join(cue.Lines, " "), // Segments are single lines
+		}
+	}
+	if err := s.segmentRepo.CreateBatch(ctx, segments); err != nil {
+		// Segments cascade with the transcription
+		s.transcriptionRepo.Delete(ctx, imported.ID)
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to save imported segments")
+	}
+
+	return imported, nil
+}
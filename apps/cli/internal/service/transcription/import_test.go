@@ -0,0 +1,113 @@
+package transcription
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/clock"
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/idgen"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/subtitle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscriptionService_ImportTranscription(t *testing.T) {
+	cues := []subtitle.Cue{
+		{Start: time.Second, End: 2500 * time.Millisecond, Lines: []string{"Hello"}},
+		{Start: 3 * time.Second, End: 65 * time.Second, Lines: []string{"Two", "lines"}},
+	}
+	importedAt := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	setup := func() (*mockTranscriptionRepository, *mockSegmentRepository, *mockVideoRepository, TranscriptionService) {
+		transcriptionRepo := &mockTranscriptionRepository{}
+		segmentRepo := &mockSegmentRepository{}
+		videoRepo := &mockVideoRepository{}
+		videoRepo.On("GetByID", mock.Anything, "v1").Return(&model.Video{ID: "v1"}, nil)
+
+		service := NewTranscriptionServiceWithAllDependencies(transcriptionRepo, segmentRepo, nil, nil, videoRepo, nil)
+		service.(*transcriptionService).clock = clock.NewFake(importedAt)
+		service.(*transcriptionService).ids = idgen.NewSequence()
+		return transcriptionRepo, segmentRepo, videoRepo, service
+	}
+
+	t.Run("saves cues as a completed imported transcription", func(t *testing.T) {
+		transcriptionRepo, segmentRepo, _, service := setup()
+		transcriptionRepo.On("GetByVideoIDAndLanguage", mock.Anything, "v1", "en").
+			Return(nil, apperrors.New(apperrors.CodeNotFound, "transcription not found"))
+		transcriptionRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Transcription")).Return(nil)
+
+		var saved []*model.TranscriptionSegment
+		segmentRepo.On("CreateBatch", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { saved = args.Get(1).([]*model.TranscriptionSegment) }).
+			Return(nil)
+
+		imported, err := service.ImportTranscription(context.Background(), "v1", "en", cues)
+		require.NoError(t, err)
+
+		assert.Equal(t, "00000000-0000-0000-0000-000000000001", imported.ID)
+		assert.Equal(t, "completed", imported.Status)
+		assert.Equal(t, importedAt, imported.CreatedAt)
+		assert.Equal(t, importedAt, *imported.CompletedAt)
+		assert.Equal(t, model.TranscriptionSourceImport, imported.Source)
+		assert.Equal(t, "en", *imported.DetectedLanguage)
+		assert.Equal(t, "00:01:05.000", *imported.TotalDuration)
+
+		require.Len(t, saved, 2)
+		assert.Equal(t, "00000000-0000-0000-0000-000000000001", saved[1].TranscriptionID)
+		assert.Equal(t, 1, saved[1].SegmentIndex)
+		assert.Equal(t, "00:00:01.000", saved[0].StartTime)
+		assert.Equal(t, "00:00:02.500", saved[0].EndTime)
+		assert.Equal(t, "Two lines", saved[1].Text)
+	})
+
+	t.Run("rejects a language that already has a transcription", func(t *testing.T) {
+		transcriptionRepo, _, _, service := setup()
+		transcriptionRepo.On("GetByVideoIDAndLanguage", mock.Anything, "v1", "en").
+			Return(&model.Transcription{ID: "t-existing"}, nil)
+
+		_, err := service.ImportTranscription(context.Background(), "v1", "en", cues)
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.CodeConflict, appErr.Code)
+		transcriptionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("requires a language", func(t *testing.T) {
+		_, _, _, service := setup()
+
+		_, err := service.ImportTranscription(context.Background(), "v1", "auto", cues)
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.CodeInvalidArg, appErr.Code)
+	})
+
+	t.Run("video not found", func(t *testing.T) {
+		_, _, videoRepo, service := setup()
+		videoRepo.On("GetByID", mock.Anything, "missing").
+			Return(nil, apperrors.New(apperrors.CodeNotFound, "video not found"))
+
+		_, err := service.ImportTranscription(context.Background(), "missing", "en", cues)
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.CodeNotFound, appErr.Code)
+	})
+
+	t.Run("removes the transcription when segments fail to save", func(t *testing.T) {
+		transcriptionRepo, segmentRepo, _, service := setup()
+		transcriptionRepo.On("GetByVideoIDAndLanguage", mock.Anything, "v1", "en").
+			Return(nil, apperrors.New(apperrors.CodeNotFound, "transcription not found"))
+		transcriptionRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Transcription")).
+			Run(func(args mock.Arguments) { args.Get(1).(*model.Transcription).ID = "t-imported" }).
+			Return(nil)
+		transcriptionRepo.On("Delete", mock.Anything, "t-imported").Return(nil)
+		segmentRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(assert.AnError)
+
+		_, err := service.ImportTranscription(context.Background(), "v1", "en", cues)
+		require.Error(t, err)
+		transcriptionRepo.AssertCalled(t, "Delete", mock.Anything, "t-imported")
+	})
+}
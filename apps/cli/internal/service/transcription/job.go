@@ -0,0 +1,146 @@
+package transcription
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/job"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// Steps of a transcription job, in order
+const (
+	stepAudio    = "audio"    // Audio downloaded and cut; records the file
+	stepRecord   = "record"   // Transcription record created; records its ID
+	stepWhisper  = "whisper"  // Whisper ran; records the file of its JSON output
+	stepSegments = "segments" // Segments saved; records the ID of the record they were saved for
+)
+
+// whisperOutputFile is the file the whisper output of a job is kept in
+const whisperOutputFile = "whisper.json"
+
+// transcriptionJobID returns the ID of the job transcribing a video in language with a whisper
+// model in a database workspace, the same on every run so an interrupted transcription finds its
+// job workspace again. A run with another model or in another database workspace gets a job of
+// its own instead of resuming from the output of the first.
+func transcriptionJobID(workspace, videoID, language, whisperModel string, opts CreateOptions) string {
+	id := fmt.Sprintf("transcribe-%s-%s-%s", jobIDPart(workspace), videoID, language)
+	if whisperModel != "" {
+		id += "-" + whisperModel
+	}
+	if opts.clipped() {
+		id += fmt.Sprintf("-%d-%d", opts.From.Milliseconds(), opts.To.Milliseconds())
+	}
+	return id
+}
+
+// jobIDPart replaces the characters job IDs do not allow in name
+func jobIDPart(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '_', r == '-':
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// jobModel returns the whisper model named in job IDs: "routed" when the router picks the model
+// of each video, otherwise the model of the whisper service, empty when it is unknown
+func (s *transcriptionService) jobModel() string {
+	if s.router != nil {
+		return "routed"
+	}
+	if named, ok := s.whisperService.(interface{ Model() string }); ok {
+		return named.Model()
+	}
+	return ""
+}
+
+// jobWorkspace is the workspace of a transcription job. Its methods do nothing on nil, the
+// workspace of services without job workspaces, whose steps never completed before.
+type jobWorkspace struct {
+	*job.Workspace
+}
+
+// step returns what a completed step recorded
+func (ws *jobWorkspace) step(name string) (string, bool) {
+	if ws == nil {
+		return "", false
+	}
+	return ws.Step(name)
+}
+
+// complete marks a step completed
+func (ws *jobWorkspace) complete(step, value string) error {
+	if ws == nil {
+		return nil
+	}
+	if err := ws.Complete(step, value); err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to record job progress")
+	}
+	return nil
+}
+
+// finish marks the job finished. Its work is done either way, so a failure only leaves the
+// workspace to jobs clean --all.
+func (ws *jobWorkspace) finish() {
+	if ws == nil {
+		return
+	}
+	if err := ws.Finish(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to mark job %s finished: %v\n", ws.ID, err)
+	}
+}
+
+// transcribe runs whisperService on the audio and keeps its output in the job workspace, or
+// reads the output an interrupted run of the job kept
+func (s *transcriptionService) transcribe(ctx context.Context, transcription *model.Transcription, ws *jobWorkspace, whisperService WhisperService, audioPath, language string, opts CreateOptions) (*model.WhisperResult, error) {
+	if name, ok := ws.step(stepWhisper); ok {
+		if result, err := readWhisperOutput(ws.Path(name)); err == nil {
+			return result, nil
+		}
+	}
+
+	stopProgress := opts.reportWhisperProgress(ctx, Event{VideoID: transcription.VideoID, TranscriptionID: transcription.ID})
+	result, err := whisperService.TranscribeAudio(ctx, audioPath, language)
+	stopProgress()
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeExternal, "whisper transcription failed")
+	}
+
+	if ws != nil {
+		// The raw output is what whisper wrote; results without it are stored in the same format
+		raw := result.Raw
+		if len(raw) == 0 {
+			if raw, err = json.Marshal(result); err != nil {
+				return nil, errors.Wrap(err, errors.CodeInternal, "failed to encode whisper output")
+			}
+		}
+		if err := os.WriteFile(ws.Path(whisperOutputFile), raw, 0644); err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, "failed to keep whisper output")
+		}
+		if err := ws.complete(stepWhisper, whisperOutputFile); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// readWhisperOutput reads whisper JSON output kept by transcribe
+func readWhisperOutput(path string) (*model.WhisperResult, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var result model.WhisperResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, err
+	}
+	result.Raw = raw
+	return &result, nil
+}
@@ -0,0 +1,50 @@
+package transcription
+
+import (
+	"context"
+
+	"github.com/Taichi-iskw/yt-lang/internal/lock"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/subtitle"
+)
+
+// lockingService serializes transcription jobs across processes
+type lockingService struct {
+	TranscriptionService
+	locker lock.Locker
+}
+
+// NewLockingService wraps a transcription service so that only one process at a time
+// transcribes a video in a given language
+func NewLockingService(inner TranscriptionService, locker lock.Locker) TranscriptionService {
+	return &lockingService{TranscriptionService: inner, locker: locker}
+}
+
+// CreateTranscription holds the transcription:<video>:<language> lock while transcribing
+func (s *lockingService) CreateTranscription(ctx context.Context, videoID string, language string, opts CreateOptions) (*model.Transcription, error) {
+	var transcription *model.Transcription
+	err := lock.WithLock(ctx, s.locker, lock.Key("transcription", videoID, language), func() error {
+		var err error
+		transcription, err = s.TranscriptionService.CreateTranscription(ctx, videoID, language, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transcription, nil
+}
+
+// ImportTranscription holds the same lock as CreateTranscription, so an import never races a
+// whisper run for the same video and language
+func (s *lockingService) ImportTranscription(ctx context.Context, videoID string, language string, cues []subtitle.Cue) (*model.Transcription, error) {
+	var transcription *model.Transcription
+	err := lock.WithLock(ctx, s.locker, lock.Key("transcription", videoID, language), func() error {
+		var err error
+		transcription, err = s.TranscriptionService.ImportTranscription(ctx, videoID, language, cues)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transcription, nil
+}
@@ -0,0 +1,219 @@
+package transcription
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
+)
+
+// mergedVideoPrefix marks the IDs of synthetic videos created for merged transcriptions
+const mergedVideoPrefix = "merged-"
+
+// MergeOptions configures how the transcriptions of a multi-part video are merged
+type MergeOptions struct {
+	Title   string // Title of the synthetic video holding the merged transcription (default: first part's title + " (merged)")
+	VideoID string // Attach the merged transcription to this existing video instead of creating a synthetic one
+}
+
+// mergePart is one transcription being merged, with its segments and time offset
+type mergePart struct {
+	transcription *model.Transcription
+	video         *model.Video
+	segments      []*model.TranscriptionSegment
+	offset        time.Duration
+}
+
+// MergeTranscriptions concatenates the segments of completed transcriptions, in the given
+// order, into a new transcription. Each part is shifted by the total duration of the parts
+// before it, so translation and export see one continuous timeline.
+func (s *transcriptionService) MergeTranscriptions(ctx context.Context, ids []string, opts MergeOptions) (*model.Transcription, error) {
+	if len(ids) < 2 {
+		return nil, errors.New(errors.CodeInvalidArg, "at least two transcriptions are required to merge")
+	}
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return nil, errors.New(errors.CodeInvalidArg, fmt.Sprintf("transcription %s is listed more than once", id))
+		}
+		seen[id] = true
+	}
+
+	parts, total, err := s.loadMergeParts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	language := spokenLanguage(parts[0].transcription)
+	for _, part := range parts[1:] {
+		if spokenLanguage(part.transcription) != language {
+			return nil, errors.New(errors.CodeInvalidArg, fmt.Sprintf("transcription %s is in %s but %s is in %s",
+				part.transcription.ID, spokenLanguage(part.transcription), parts[0].transcription.ID, language))
+		}
+	}
+
+	// Resolve the video the merged transcription belongs to
+	var createdVideo *model.Video
+	videoID := opts.VideoID
+	if videoID != "" {
+		if _, err := s.videoRepo.GetByID(ctx, videoID); err != nil {
+			return nil, errors.Wrap(err, errors.CodeNotFound, "video not found")
+		}
+	} else {
+		createdVideo = mergedVideo(parts, opts.Title, total)
+		if existing, err := s.videoRepo.GetByID(ctx, createdVideo.ID); err == nil {
+			return nil, errors.New(errors.CodeConflict, fmt.Sprintf("these transcriptions were already merged into video %s", existing.ID))
+		}
+		if err := s.videoRepo.Create(ctx, createdVideo); err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, "failed to create merged video")
+		}
+		videoID = createdVideo.ID
+	}
+
+	now := s.clock.Now()
+	totalDuration := timecode.FormatInterval(total)
+	detected := language
+	merged := &model.Transcription{
+		ID:               s.ids.NewID(),
+		VideoID:          videoID,
+		Language:         parts[0].transcription.Language,
+		Status:           "completed",
+		CreatedAt:        now,
+		CompletedAt:      &now,
+		DetectedLanguage: &detected,
+		TotalDuration:    &totalDuration,
+	}
+
+	// Remove what was created if a later step fails (segments cascade with the transcription)
+	cleanup := func() {
+		if merged.ID != "" {
+			s.transcriptionRepo.Delete(ctx, merged.ID)
+		}
+		if createdVideo != nil {
+			s.videoRepo.Delete(ctx, createdVideo.ID)
+		}
+	}
+
+	if err := s.transcriptionRepo.Create(ctx, merged); err != nil {
+		cleanup()
+		return nil, err
+	}
+
+	segments, err := offsetSegments(merged.ID, parts)
+	if err != nil {
+		cleanup()
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to shift segment times")
+	}
+	if err := s.segmentRepo.CreateBatch(ctx, segments); err != nil {
+		cleanup()
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to save merged segments")
+	}
+
+	return merged, nil
+}
+
+// loadMergeParts loads every part with its segments and computes their offsets and total duration
+func (s *transcriptionService) loadMergeParts(ctx context.Context, ids []string) ([]*mergePart, time.Duration, error) {
+	parts := make([]*mergePart, len(ids))
+	var offset time.Duration
+
+	for i, id := range ids {
+		t, err := s.transcriptionRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, errors.CodeNotFound, fmt.Sprintf("transcription %s not found", id))
+		}
+		if t.Status != "completed" {
+			return nil, 0, errors.New(errors.CodeInvalidArg, fmt.Sprintf("transcription %s is %s, only completed transcriptions can be merged", id, t.Status))
+		}
+
+		v, err := s.videoRepo.GetByID(ctx, t.VideoID)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, errors.CodeNotFound, fmt.Sprintf("video %s not found", t.VideoID))
+		}
+
+		segments, err := s.segmentRepo.GetByTranscriptionID(ctx, id)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, errors.CodeInternal, "failed to get transcription segments")
+		}
+		if len(segments) == 0 {
+			return nil, 0, errors.New(errors.CodeInvalidArg, fmt.Sprintf("transcription %s has no segments", id))
+		}
+
+		// A part lasts as long as its video, or until its last segment if that runs longer
+		// (or the duration is unknown), so consecutive parts never overlap
+		last, err := timecode.ParseInterval(segments[len(segments)-1].EndTime)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, errors.CodeInternal, "invalid segment end time")
+		}
+		duration := max(timecode.FromSeconds(v.Duration), last)
+
+		parts[i] = &mergePart{transcription: t, video: v, segments: segments, offset: offset}
+		offset += duration
+	}
+
+	return parts, offset, nil
+}
+
+// offsetSegments renumbers the segments of all parts and shifts them by their part's offset
+func offsetSegments(transcriptionID string, parts []*mergePart) ([]*model.TranscriptionSegment, error) {
+	var segments []*model.TranscriptionSegment
+	for _, part := range parts {
+		for _, seg := range part.segments {
+			start, err := timecode.ParseInterval(seg.StartTime)
+			if err != nil {
+				return nil, err
+			}
+			end, err := timecode.ParseInterval(seg.EndTime)
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, &model.TranscriptionSegment{
+				TranscriptionID: transcriptionID,
+				SegmentIndex:    len(segments),
+				StartTime:       timecode.FormatInterval(start + part.offset),
+				EndTime:         timecode.FormatInterval(end + part.offset),
+				Text:            seg.Text,
+				Confidence:      seg.Confidence,
+				Speaker:         seg.Speaker,
+			})
+		}
+	}
+	return segments, nil
+}
+
+// mergedVideo builds the synthetic video record for a merge. Its ID is derived from the
+// merged transcription IDs, so repeating a merge is detected instead of duplicated.
+func mergedVideo(parts []*mergePart, title string, duration time.Duration) *model.Video {
+	ids := make([]string, len(parts))
+	for i, part := range parts {
+		ids[i] = part.transcription.ID
+	}
+	sum := sha1.Sum([]byte(strings.Join(ids, ",")))
+	id := mergedVideoPrefix + hex.EncodeToString(sum[:])[:12]
+
+	if title == "" {
+		title = parts[0].video.Title + " (merged)"
+	}
+
+	return &model.Video{
+		ID:        id,
+		ChannelID: parts[0].video.ChannelID,
+		Title:     title,
+		URL:       "ytlang://merged/" + id,
+		Duration:  duration.Seconds(),
+	}
+}
+
+// spokenLanguage returns the detected language of a transcription, falling back to the requested one
+func spokenLanguage(t *model.Transcription) string {
+	if t.DetectedLanguage != nil && *t.DetectedLanguage != "" {
+		return *t.DetectedLanguage
+	}
+	return t.Language
+}
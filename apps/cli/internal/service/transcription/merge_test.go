@@ -0,0 +1,157 @@
+package transcription
+
+import (
+	"context"
+	"testing"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscriptionService_MergeTranscriptions(t *testing.T) {
+	english := "en"
+	part1 := &model.Transcription{ID: "t1", VideoID: "v1", Language: "auto", Status: "completed", DetectedLanguage: &english}
+	part2 := &model.Transcription{ID: "t2", VideoID: "v2", Language: "en", Status: "completed"}
+	video1 := &model.Video{ID: "v1", ChannelID: "ch1", Title: "Talk (1/2)", Duration: 60}
+	video2 := &model.Video{ID: "v2", ChannelID: "ch1", Title: "Talk (2/2)"}
+	segments1 := []*model.TranscriptionSegment{
+		{SegmentIndex: 0, StartTime: "00:00:00", EndTime: "00:00:05.5", Text: "Hello"},
+		{SegmentIndex: 1, StartTime: "00:00:50", EndTime: "00:00:58", Text: "Part one ends"},
+	}
+	segments2 := []*model.TranscriptionSegment{
+		{SegmentIndex: 0, StartTime: "00:00:01", EndTime: "00:00:03", Text: "Part two"},
+		{SegmentIndex: 1, StartTime: "00:00:03", EndTime: "00:00:10", Text: "Bye"},
+	}
+
+	setup := func() (*mockTranscriptionRepository, *mockSegmentRepository, *mockVideoRepository, TranscriptionService) {
+		transcriptionRepo := &mockTranscriptionRepository{}
+		segmentRepo := &mockSegmentRepository{}
+		videoRepo := &mockVideoRepository{}
+		transcriptionRepo.On("GetByID", mock.Anything, "t1").Return(part1, nil)
+		transcriptionRepo.On("GetByID", mock.Anything, "t2").Return(part2, nil)
+		videoRepo.On("GetByID", mock.Anything, "v1").Return(video1, nil)
+		videoRepo.On("GetByID", mock.Anything, "v2").Return(video2, nil)
+		segmentRepo.On("GetByTranscriptionID", mock.Anything, "t1").Return(segments1, nil)
+		segmentRepo.On("GetByTranscriptionID", mock.Anything, "t2").Return(segments2, nil)
+
+		service := NewTranscriptionServiceWithAllDependencies(transcriptionRepo, segmentRepo, nil, nil, videoRepo, nil)
+		return transcriptionRepo, segmentRepo, videoRepo, service
+	}
+
+	t.Run("merges parts into a synthetic video with cumulative offsets", func(t *testing.T) {
+		transcriptionRepo, segmentRepo, videoRepo, service := setup()
+
+		var createdVideo *model.Video
+		videoRepo.On("GetByID", mock.Anything, mock.MatchedBy(func(id string) bool { return id != "v1" && id != "v2" })).
+			Return(nil, apperrors.New(apperrors.CodeNotFound, "video not found"))
+		videoRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Video")).
+			Run(func(args mock.Arguments) { createdVideo = args.Get(1).(*model.Video) }).
+			Return(nil)
+		transcriptionRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Transcription")).
+			Run(func(args mock.Arguments) { args.Get(1).(*model.Transcription).ID = "merged-t" }).
+			Return(nil)
+
+		var saved []*model.TranscriptionSegment
+		segmentRepo.On("CreateBatch", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { saved = args.Get(1).([]*model.TranscriptionSegment) }).
+			Return(nil)
+
+		merged, err := service.MergeTranscriptions(context.Background(), []string{"t1", "t2"}, MergeOptions{Title: "Full Talk"})
+		require.NoError(t, err)
+
+		require.NotNil(t, createdVideo)
+		assert.Contains(t, createdVideo.ID, mergedVideoPrefix)
+		assert.Equal(t, "Full Talk", createdVideo.Title)
+		assert.Equal(t, "ch1", createdVideo.ChannelID)
+		assert.Equal(t, 70.0, createdVideo.Duration)
+
+		assert.Equal(t, "merged-t", merged.ID)
+		assert.Equal(t, createdVideo.ID, merged.VideoID)
+		assert.Equal(t, "completed", merged.Status)
+		assert.Equal(t, "en", *merged.DetectedLanguage)
+		assert.Equal(t, "00:01:10.000", *merged.TotalDuration)
+
+		// Part two starts after the 60s of part one's video, not at its last segment
+		require.Len(t, saved, 4)
+		assert.Equal(t, "00:00:05.500", saved[0].EndTime)
+		assert.Equal(t, 2, saved[2].SegmentIndex)
+		assert.Equal(t, "00:01:01.000", saved[2].StartTime)
+		assert.Equal(t, "00:01:10.000", saved[3].EndTime)
+		assert.Equal(t, "merged-t", saved[3].TranscriptionID)
+	})
+
+	t.Run("attaches to an existing video", func(t *testing.T) {
+		transcriptionRepo, segmentRepo, videoRepo, service := setup()
+		videoRepo.On("GetByID", mock.Anything, "full").Return(&model.Video{ID: "full"}, nil)
+		transcriptionRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Transcription")).Return(nil)
+		segmentRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil)
+
+		merged, err := service.MergeTranscriptions(context.Background(), []string{"t1", "t2"}, MergeOptions{VideoID: "full"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "full", merged.VideoID)
+		videoRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a merge that already exists", func(t *testing.T) {
+		_, _, videoRepo, service := setup()
+		videoRepo.On("GetByID", mock.Anything, mock.MatchedBy(func(id string) bool { return id != "v1" && id != "v2" })).
+			Return(&model.Video{ID: "merged-existing"}, nil)
+
+		_, err := service.MergeTranscriptions(context.Background(), []string{"t1", "t2"}, MergeOptions{})
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.CodeConflict, appErr.Code)
+	})
+
+	t.Run("removes created records when segments fail to save", func(t *testing.T) {
+		transcriptionRepo, segmentRepo, videoRepo, service := setup()
+		videoRepo.On("GetByID", mock.Anything, mock.MatchedBy(func(id string) bool { return id != "v1" && id != "v2" })).
+			Return(nil, apperrors.New(apperrors.CodeNotFound, "video not found"))
+		videoRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		videoRepo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+		transcriptionRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Transcription")).
+			Run(func(args mock.Arguments) { args.Get(1).(*model.Transcription).ID = "merged-t" }).
+			Return(nil)
+		transcriptionRepo.On("Delete", mock.Anything, "merged-t").Return(nil)
+		segmentRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(assert.AnError)
+
+		_, err := service.MergeTranscriptions(context.Background(), []string{"t1", "t2"}, MergeOptions{})
+		require.Error(t, err)
+
+		transcriptionRepo.AssertCalled(t, "Delete", mock.Anything, "merged-t")
+		videoRepo.AssertCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		french := "fr"
+		_, _, _, service := setup()
+
+		tests := []struct {
+			name string
+			ids  []string
+		}{
+			{name: "single transcription", ids: []string{"t1"}},
+			{name: "duplicate transcription", ids: []string{"t1", "t1"}},
+		}
+		for _, tt := range tests {
+			_, err := service.MergeTranscriptions(context.Background(), tt.ids, MergeOptions{})
+			var appErr *apperrors.AppError
+			require.ErrorAs(t, err, &appErr, tt.name)
+			assert.Equal(t, apperrors.CodeInvalidArg, appErr.Code, tt.name)
+		}
+
+		transcriptionRepo, _, _, service := setup()
+		transcriptionRepo.ExpectedCalls = nil
+		transcriptionRepo.On("GetByID", mock.Anything, "t1").Return(part1, nil)
+		transcriptionRepo.On("GetByID", mock.Anything, "t2").
+			Return(&model.Transcription{ID: "t2", VideoID: "v2", Language: "fr", Status: "completed", DetectedLanguage: &french}, nil)
+
+		_, err := service.MergeTranscriptions(context.Background(), []string{"t1", "t2"}, MergeOptions{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is in fr")
+	})
+}
@@ -0,0 +1,90 @@
+package transcription
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// Quality heuristics for whisper output. They flag the failure modes a retry with a larger beam
+// or model usually fixes: decoding stuck in a loop and low-confidence hallucination.
+const (
+	maxRepeatedSegments     = 3    // More identical consecutive segments is a repetition loop
+	maxCompressionRatio     = 2.4  // Text compressing better than this repeats itself (whisper's own threshold)
+	compressionPassageBytes = 200  // Segments are judged in passages of about this size; shorter text compresses too poorly
+	minAverageLogprob       = -1.0 // Lower mean segment log probability means whisper was guessing
+)
+
+// QualityIssues describes the problems of whisper output that suggest transcribing it again;
+// empty when the output looks fine
+func QualityIssues(result *model.WhisperResult) []string {
+	var issues []string
+	if run, text := longestRepeat(result.Segments); run > maxRepeatedSegments {
+		issues = append(issues, fmt.Sprintf("repetition loop: %q repeated in %d consecutive segments", text, run))
+	}
+	if passages := repetitivePassages(result.Segments); passages > 0 {
+		issues = append(issues, fmt.Sprintf("repetitive text: %d passage(s) with a compression ratio above %.1f", passages, maxCompressionRatio))
+	}
+	if len(result.Segments) > 0 {
+		var total float64
+		for _, segment := range result.Segments {
+			total += segment.Confidence
+		}
+		if mean := total / float64(len(result.Segments)); mean < minAverageLogprob {
+			issues = append(issues, fmt.Sprintf("low confidence: mean log probability %.2f (minimum %.1f)", mean, minAverageLogprob))
+		}
+	}
+	return issues
+}
+
+// longestRepeat returns the longest run of consecutive segments with the same non-empty text
+func longestRepeat(segments []model.WhisperSegment) (int, string) {
+	longest, text, run := 0, "", 0
+	for i, segment := range segments {
+		current := strings.TrimSpace(segment.Text)
+		if current == "" {
+			run = 0
+			continue
+		}
+		if i > 0 && current == strings.TrimSpace(segments[i-1].Text) {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest, text = run, current
+		}
+	}
+	return longest, text
+}
+
+// repetitivePassages splits the segments into passages of about compressionPassageBytes and
+// counts those compressing better than maxCompressionRatio. Passages are judged on their own, as
+// whisper judges its 30 second windows, since a whole transcript compresses better the longer it is.
+func repetitivePassages(segments []model.WhisperSegment) int {
+	count := 0
+	var passage strings.Builder
+	for _, segment := range segments {
+		passage.WriteString(segment.Text)
+		if passage.Len() < compressionPassageBytes {
+			continue
+		}
+		if compressionRatio(passage.String()) > maxCompressionRatio {
+			count++
+		}
+		passage.Reset()
+	}
+	return count
+}
+
+// compressionRatio returns how many times smaller text gets with zlib
+func compressionRatio(text string) float64 {
+	var compressed bytes.Buffer
+	w := zlib.NewWriter(&compressed)
+	w.Write([]byte(text))
+	w.Close()
+	return float64(len(text)) / float64(compressed.Len())
+}
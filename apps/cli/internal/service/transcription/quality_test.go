@@ -0,0 +1,51 @@
+package transcription
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+func TestQualityIssues(t *testing.T) {
+	t.Run("natural speech", func(t *testing.T) {
+		result := &model.WhisperResult{Segments: []model.WhisperSegment{
+			{Text: " Today we are going to cook a Spanish omelette with potatoes and onions.", Confidence: -0.3},
+			{Text: " First, peel the potatoes and slice them thinly so they cook evenly.", Confidence: -0.4},
+			{Text: " Then fry them slowly in plenty of olive oil until they are soft.", Confidence: -0.2},
+			{Text: " Meanwhile, beat six eggs in a large bowl with a pinch of salt.", Confidence: -0.5},
+		}}
+		assert.Empty(t, QualityIssues(result))
+	})
+
+	t.Run("repetition loop", func(t *testing.T) {
+		var segments []model.WhisperSegment
+		for i := 0; i < 10; i++ {
+			segments = append(segments, model.WhisperSegment{Text: " Thank you for watching.", Confidence: -0.2})
+		}
+		issues := QualityIssues(&model.WhisperResult{Segments: segments})
+		require.Len(t, issues, 2)
+		assert.Contains(t, issues[0], `"Thank you for watching." repeated in 10 consecutive segments`)
+		assert.Contains(t, issues[1], "repetitive text: 1 passage(s)")
+	})
+
+	t.Run("repetition within a segment", func(t *testing.T) {
+		result := &model.WhisperResult{Segments: []model.WhisperSegment{
+			{Text: strings.Repeat(" la la la", 40), Confidence: -0.2},
+		}}
+		issues := QualityIssues(result)
+		require.Len(t, issues, 1)
+		assert.Contains(t, issues[0], "repetitive text")
+	})
+
+	t.Run("low confidence", func(t *testing.T) {
+		result := &model.WhisperResult{Segments: []model.WhisperSegment{
+			{Text: " Something.", Confidence: -1.4},
+			{Text: " Something else.", Confidence: -0.9},
+		}}
+		assert.Equal(t, []string{"low confidence: mean log probability -1.15 (minimum -1.0)"}, QualityIssues(result))
+	})
+}
@@ -0,0 +1,109 @@
+package transcription
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+)
+
+// Routing defaults
+const (
+	DefaultSampleModel    = "tiny"
+	DefaultSampleDuration = 60 * time.Second
+
+	// anyLanguage is the Models key matching languages without a rule of their own
+	anyLanguage = "*"
+)
+
+// RoutingOptions configures whisper model routing
+type RoutingOptions struct {
+	DefaultModel   string            // Model used when no rule matches
+	SampleModel    string            // Model that detects the language of the sample; defaults to DefaultSampleModel
+	SampleDuration time.Duration     // Audio sampled across the video; defaults to DefaultSampleDuration
+	Models         map[string]string // Whisper model per language code; "*" matches languages without a rule
+	Whisper        WhisperOptions    // Decoding and fallback of the routed model; the sample pass runs plain
+}
+
+// ModelRouter picks the whisper model and language of a transcription
+type ModelRouter interface {
+	// Route decides the model for audio of the given duration. With language "auto" it first
+	// detects the language on a short sample of the audio written to workDir.
+	Route(ctx context.Context, audioPath, workDir, language string, duration time.Duration) (*model.TranscriptionRouting, error)
+
+	// WhisperService returns the whisper service running the given model
+	WhisperService(model string) WhisperService
+}
+
+// modelRouter implements ModelRouter with a whisper pre-pass on an ffmpeg sample
+type modelRouter struct {
+	cmdRunner common.CmdRunner
+	clipper   AudioClipper
+	opts      RoutingOptions
+}
+
+// NewModelRouter creates a new ModelRouter with default CmdRunner
+func NewModelRouter(opts RoutingOptions) ModelRouter {
+	return NewModelRouterWithCmdRunner(common.NewCmdRunner(), opts)
+}
+
+// NewModelRouterWithCmdRunner creates a new ModelRouter with custom CmdRunner (for testing)
+func NewModelRouterWithCmdRunner(cmdRunner common.CmdRunner, opts RoutingOptions) ModelRouter {
+	if opts.SampleModel == "" {
+		opts.SampleModel = DefaultSampleModel
+	}
+	if opts.SampleDuration <= 0 {
+		opts.SampleDuration = DefaultSampleDuration
+	}
+	return &modelRouter{
+		cmdRunner: cmdRunner,
+		clipper:   NewAudioClipperWithCmdRunner(cmdRunner),
+		opts:      opts,
+	}
+}
+
+// Route samples the audio when the language is not given, then applies the model rules
+func (r *modelRouter) Route(ctx context.Context, audioPath, workDir, language string, duration time.Duration) (*model.TranscriptionRouting, error) {
+	routing := &model.TranscriptionRouting{Language: language}
+	source := fmt.Sprintf("language %s given", language)
+
+	if language == "" || language == "auto" {
+		samplePath, err := r.clipper.SampleAudio(ctx, audioPath, workDir, duration, r.opts.SampleDuration)
+		if err != nil {
+			return nil, err
+		}
+		result, err := NewWhisperServiceWithCmdRunner(r.cmdRunner, r.opts.SampleModel).TranscribeAudio(ctx, samplePath, "auto")
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeExternal, "language detection on the audio sample failed")
+		}
+		if result.Language == "" {
+			return nil, errors.New(errors.CodeExternal, "whisper did not report the language of the audio sample")
+		}
+
+		routing.SampledLanguage = result.Language
+		routing.Language = result.Language
+		source = fmt.Sprintf("sample detected %s", result.Language)
+	}
+
+	switch {
+	case r.opts.Models[routing.Language] != "":
+		routing.Model = r.opts.Models[routing.Language]
+		routing.Reason = fmt.Sprintf("%s; rule %s -> %s", source, routing.Language, routing.Model)
+	case r.opts.Models[anyLanguage] != "":
+		routing.Model = r.opts.Models[anyLanguage]
+		routing.Reason = fmt.Sprintf("%s; rule %s -> %s", source, anyLanguage, routing.Model)
+	default:
+		routing.Model = r.opts.DefaultModel
+		routing.Reason = fmt.Sprintf("%s; no rule, default model %s", source, routing.Model)
+	}
+
+	return routing, nil
+}
+
+// WhisperService returns a whisper service running model through the router's CmdRunner
+func (r *modelRouter) WhisperService(model string) WhisperService {
+	return NewWhisperServiceWithOptions(r.cmdRunner, model, r.opts.Whisper)
+}
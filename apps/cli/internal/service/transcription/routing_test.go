@@ -0,0 +1,81 @@
+package transcription
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// expectSampleDetection mocks the ffmpeg sample and a whisper run on it reporting language
+func expectSampleDetection(t *testing.T, runner *mockWhisperCmdRunner, language string) {
+	runner.On("Run", mock.Anything, "ffmpeg", mock.MatchedBy(func(args []string) bool {
+		return slices.Contains(args, "-filter_complex")
+	})).Return([]byte(""), nil).Once()
+	runner.On("Run", mock.Anything, "whisper", mock.MatchedBy(func(args []string) bool {
+		return filepath.Base(args[0]) == "sample.wav" && args[2] == DefaultSampleModel && !slices.Contains(args, "--language")
+	})).
+		Run(func(args mock.Arguments) {
+			whisperArgs := args.Get(2).([]string)
+			outputDir := whisperArgs[slices.Index(whisperArgs, "--output_dir")+1]
+			output := `{"text": "sample", "segments": [], "language": "` + language + `"}`
+			require.NoError(t, os.WriteFile(filepath.Join(outputDir, "sample.json"), []byte(output), 0644))
+		}).
+		Return([]byte(""), nil).Once()
+}
+
+func TestModelRouter_Route(t *testing.T) {
+	opts := RoutingOptions{
+		DefaultModel: "base",
+		Models:       map[string]string{"ja": "medium", "en": "base"},
+	}
+
+	t.Run("detects the language on a sample and applies its rule", func(t *testing.T) {
+		runner := new(mockWhisperCmdRunner)
+		expectSampleDetection(t, runner, "ja")
+
+		routing, err := NewModelRouterWithCmdRunner(runner, opts).Route(context.Background(), "/tmp/audio.m4a", t.TempDir(), "auto", time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, "ja", routing.SampledLanguage)
+		assert.Equal(t, "ja", routing.Language)
+		assert.Equal(t, "medium", routing.Model)
+		assert.Equal(t, "sample detected ja; rule ja -> medium", routing.Reason)
+		runner.AssertExpectations(t)
+	})
+
+	t.Run("given language skips the sample", func(t *testing.T) {
+		runner := new(mockWhisperCmdRunner)
+
+		routing, err := NewModelRouterWithCmdRunner(runner, opts).Route(context.Background(), "/tmp/audio.m4a", t.TempDir(), "ja", time.Hour)
+		require.NoError(t, err)
+		assert.Empty(t, routing.SampledLanguage)
+		assert.Equal(t, "medium", routing.Model)
+		assert.Equal(t, "language ja given; rule ja -> medium", routing.Reason)
+		runner.AssertNotCalled(t, "Run", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("wildcard rule matches other languages", func(t *testing.T) {
+		runner := new(mockWhisperCmdRunner)
+		withWildcard := RoutingOptions{DefaultModel: "base", Models: map[string]string{"ja": "medium", "*": "small"}}
+
+		routing, err := NewModelRouterWithCmdRunner(runner, withWildcard).Route(context.Background(), "/tmp/audio.m4a", t.TempDir(), "fr", time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, "small", routing.Model)
+		assert.Equal(t, "language fr given; rule * -> small", routing.Reason)
+	})
+
+	t.Run("falls back to the default model", func(t *testing.T) {
+		runner := new(mockWhisperCmdRunner)
+
+		routing, err := NewModelRouterWithCmdRunner(runner, opts).Route(context.Background(), "/tmp/audio.m4a", t.TempDir(), "fr", time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, "base", routing.Model)
+		assert.Equal(t, "language fr given; no rule, default model base", routing.Reason)
+	})
+}
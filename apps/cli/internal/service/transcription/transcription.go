@@ -3,30 +3,83 @@ package transcription
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/Taichi-iskw/yt-lang/internal/artifact"
+	"github.com/Taichi-iskw/yt-lang/internal/clock"
 	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/idgen"
+	"github.com/Taichi-iskw/yt-lang/internal/janitor"
+	"github.com/Taichi-iskw/yt-lang/internal/job"
 	"github.com/Taichi-iskw/yt-lang/internal/model"
 	"github.com/Taichi-iskw/yt-lang/internal/repository/transcription"
 	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
+	"github.com/Taichi-iskw/yt-lang/internal/subtitle"
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
 )
 
 // TranscriptionService defines operations for transcription management
 type TranscriptionService interface {
 	// CreateTranscription creates a new transcription for a video by downloading its audio
-	CreateTranscription(ctx context.Context, videoID string, language string) (*model.Transcription, error)
+	CreateTranscription(ctx context.Context, videoID string, language string, opts CreateOptions) (*model.Transcription, error)
 
 	// GetTranscription retrieves transcription and its segments by ID
 	GetTranscription(ctx context.Context, id string) (*model.Transcription, []*model.TranscriptionSegment, error)
 
+	// GetTranscriptionInfo retrieves transcription metadata by ID without loading segments
+	GetTranscriptionInfo(ctx context.Context, id string) (*model.Transcription, error)
+
+	// StreamSegments streams transcription segments to fn in order without holding them all in memory
+	StreamSegments(ctx context.Context, id string, fn func(segment *model.TranscriptionSegment) error) error
+	// SearchSegments streams only the segments whose text contains pattern (case-insensitive)
+	SearchSegments(ctx context.Context, id string, pattern string, fn func(segment *model.TranscriptionSegment) error) error
+
 	// ListTranscriptions lists transcriptions for a video
 	ListTranscriptions(ctx context.Context, videoID string) ([]*model.Transcription, error)
 
+	// CountTranscriptions returns the number of transcriptions of a video
+	CountTranscriptions(ctx context.Context, videoID string) (int, error)
+
+	// ListStaleTranscriptions lists the transcriptions whose video changed on YouTube after they
+	// were made, of one video or, when videoID is empty, of all videos
+	ListStaleTranscriptions(ctx context.Context, videoID string) ([]*model.Transcription, error)
+
 	// DeleteTranscription deletes transcription and its segments
 	DeleteTranscription(ctx context.Context, id string) error
+
+	// OpenWhisperArtifact opens the raw whisper JSON output kept for a transcription
+	OpenWhisperArtifact(ctx context.Context, id string) (io.ReadCloser, error)
+
+	// MergeTranscriptions concatenates the transcriptions of a multi-part video into a new transcription
+	MergeTranscriptions(ctx context.Context, ids []string, opts MergeOptions) (*model.Transcription, error)
+
+	// ImportTranscription saves existing subtitles of a video as a completed transcription
+	ImportTranscription(ctx context.Context, videoID string, language string, cues []subtitle.Cue) (*model.Transcription, error)
 }
 
+// CreateOptions controls which part of a video CreateTranscription transcribes
+type CreateOptions struct {
+	From time.Duration // Start of the range to transcribe; 0 for the beginning
+	To   time.Duration // End of the range to transcribe; 0 for the end of the video
+
+	// Events, if set, receives each step of the pipeline, from started to completed or failed
+	Events func(event Event)
+
+	// Hallucinations, if set, flags or removes the whisper segments matching its phrase list
+	Hallucinations *HallucinationFilter
+}
+
+// clipped reports whether only part of the audio is transcribed
+func (o CreateOptions) clipped() bool {
+	return o.From != 0 || o.To != 0
+}
+
+// languageCandidateCount is the number of detected languages kept on a transcription
+const languageCandidateCount = 3
+
 // transcriptionService implements TranscriptionService
 type transcriptionService struct {
 	transcriptionRepo transcription.Repository
@@ -34,6 +87,13 @@ type transcriptionService struct {
 	whisperService    WhisperService
 	audioDownloadSvc  AudioDownloadService
 	videoRepo         video.Repository
+	artifactStore     artifact.Store  // Optional; raw whisper output is not kept when nil
+	audioClipper      AudioClipper    // Cuts the audio for CreateOptions ranges
+	router            ModelRouter     // Optional; whisperService transcribes every video when nil
+	clock             clock.Clock     // Stamps created and completed times
+	ids               idgen.Generator // Assigns transcription IDs
+	workspaces        *job.Workspaces // Optional; work happens in temp directories when nil
+	workspace         string          // Database workspace transcriptions are created in; part of job IDs
 }
 
 // NewTranscriptionService creates a new TranscriptionService with default dependencies
@@ -41,6 +101,9 @@ func NewTranscriptionService() TranscriptionService {
 	return &transcriptionService{
 		whisperService:   NewWhisperService(),
 		audioDownloadSvc: NewAudioDownloadService(),
+		audioClipper:     NewAudioClipper(),
+		clock:            clock.System,
+		ids:              idgen.UUID,
 	}
 }
 
@@ -50,61 +113,184 @@ func NewTranscriptionServiceWithDependencies(transcriptionRepo transcription.Rep
 		transcriptionRepo: transcriptionRepo,
 		segmentRepo:       segmentRepo,
 		whisperService:    whisperService,
+		clock:             clock.System,
+		ids:               idgen.UUID,
+	}
+}
+
+// NewTranscriptionServiceWithArtifactStore creates a new TranscriptionService for reading and deleting stored transcriptions and their artifacts
+func NewTranscriptionServiceWithArtifactStore(transcriptionRepo transcription.Repository, segmentRepo transcription.SegmentRepository, artifactStore artifact.Store) TranscriptionService {
+	return &transcriptionService{
+		transcriptionRepo: transcriptionRepo,
+		segmentRepo:       segmentRepo,
+		artifactStore:     artifactStore,
+		clock:             clock.System,
+		ids:               idgen.UUID,
 	}
 }
 
 // NewTranscriptionServiceWithAllDependencies creates a new TranscriptionService with all dependencies (for CLI)
-func NewTranscriptionServiceWithAllDependencies(transcriptionRepo transcription.Repository, segmentRepo transcription.SegmentRepository, whisperService WhisperService, audioDownloadSvc AudioDownloadService, videoRepo video.Repository) TranscriptionService {
+func NewTranscriptionServiceWithAllDependencies(transcriptionRepo transcription.Repository, segmentRepo transcription.SegmentRepository, whisperService WhisperService, audioDownloadSvc AudioDownloadService, videoRepo video.Repository, artifactStore artifact.Store) TranscriptionService {
+	return &transcriptionService{
+		transcriptionRepo: transcriptionRepo,
+		segmentRepo:       segmentRepo,
+		whisperService:    whisperService,
+		audioDownloadSvc:  audioDownloadSvc,
+		videoRepo:         videoRepo,
+		artifactStore:     artifactStore,
+		audioClipper:      NewAudioClipper(),
+		clock:             clock.System,
+		ids:               idgen.UUID,
+	}
+}
+
+// NewTranscriptionServiceWithRouter creates a new TranscriptionService (for CLI) that lets router
+// pick the whisper model and language of each transcription instead of using whisperService
+func NewTranscriptionServiceWithRouter(transcriptionRepo transcription.Repository, segmentRepo transcription.SegmentRepository, whisperService WhisperService, audioDownloadSvc AudioDownloadService, videoRepo video.Repository, artifactStore artifact.Store, router ModelRouter) TranscriptionService {
+	return &transcriptionService{
+		transcriptionRepo: transcriptionRepo,
+		segmentRepo:       segmentRepo,
+		whisperService:    whisperService,
+		audioDownloadSvc:  audioDownloadSvc,
+		videoRepo:         videoRepo,
+		artifactStore:     artifactStore,
+		audioClipper:      NewAudioClipper(),
+		router:            router,
+		clock:             clock.System,
+		ids:               idgen.UUID,
+	}
+}
+
+// NewTranscriptionServiceWithWorkspaces creates a new TranscriptionService (for CLI) like
+// NewTranscriptionServiceWithRouter that works in a job workspace per transcription. A
+// transcription interrupted by a crash or Ctrl-C resumes after its last completed step (audio
+// download, whisper, saving segments) when it is created again in the same database workspace.
+func NewTranscriptionServiceWithWorkspaces(transcriptionRepo transcription.Repository, segmentRepo transcription.SegmentRepository, whisperService WhisperService, audioDownloadSvc AudioDownloadService, videoRepo video.Repository, artifactStore artifact.Store, router ModelRouter, workspaces *job.Workspaces, workspace string) TranscriptionService {
 	return &transcriptionService{
 		transcriptionRepo: transcriptionRepo,
 		segmentRepo:       segmentRepo,
 		whisperService:    whisperService,
 		audioDownloadSvc:  audioDownloadSvc,
 		videoRepo:         videoRepo,
+		artifactStore:     artifactStore,
+		audioClipper:      NewAudioClipper(),
+		router:            router,
+		clock:             clock.System,
+		ids:               idgen.UUID,
+		workspaces:        workspaces,
+		workspace:         workspace,
+	}
+}
+
+// CreateTranscription creates a new transcription for a video by downloading its audio.
+// With a range in opts only that part of the audio is transcribed; segment times still
+// refer to the full video.
+func (s *transcriptionService) CreateTranscription(ctx context.Context, videoID string, language string, opts CreateOptions) (*model.Transcription, error) {
+	opts.emit(Event{Event: EventStarted, VideoID: videoID, Language: language})
+
+	transcription, err := s.createTranscription(ctx, videoID, language, opts)
+	if err != nil {
+		opts.emit(Event{Event: EventFailed, VideoID: videoID, Error: err.Error()})
+		return nil, err
 	}
+
+	completed := Event{Event: EventCompleted, VideoID: videoID, TranscriptionID: transcription.ID, Language: transcription.Language}
+	if transcription.DetectedLanguage != nil && *transcription.DetectedLanguage != "" {
+		completed.Language = *transcription.DetectedLanguage
+	}
+	opts.emit(completed)
+	return transcription, nil
 }
 
-// CreateTranscription creates a new transcription for a video by downloading its audio
-func (s *transcriptionService) CreateTranscription(ctx context.Context, videoID string, language string) (*model.Transcription, error) {
+// createTranscription is CreateTranscription without the started, completed and failed events
+func (s *transcriptionService) createTranscription(ctx context.Context, videoID string, language string, opts CreateOptions) (*model.Transcription, error) {
+	if opts.From < 0 || opts.To < 0 {
+		return nil, errors.New(errors.CodeInvalidArg, "range offsets must not be negative")
+	}
+	if opts.To != 0 && opts.To <= opts.From {
+		return nil, errors.New(errors.CodeInvalidArg, "end of the range must be after its start")
+	}
+
 	// Get video information from database
 	video, err := s.videoRepo.GetByID(ctx, videoID)
 	if err != nil {
 		return nil, errors.Wrap(err, errors.CodeNotFound, "video not found")
 	}
-
-	// Create temporary directory for audio download
-	tempDir, err := os.MkdirTemp("", "yt-lang-audio-*")
-	if err != nil {
-		return nil, errors.Wrap(err, errors.CodeInternal, "failed to create temp directory")
+	if video.Duration > 0 && opts.From.Seconds() >= video.Duration {
+		return nil, errors.New(errors.CodeInvalidArg, fmt.Sprintf("range starts after the end of the video (%s)", timecode.FormatInterval(timecode.FromSeconds(video.Duration))))
 	}
-	defer os.RemoveAll(tempDir)
 
-	// Download audio from video URL
-	audioPath, err := s.audioDownloadSvc.DownloadAudio(ctx, video.URL, tempDir)
-	if err != nil {
-		return nil, errors.Wrap(err, errors.CodeExternal, "failed to download audio")
+	// Work in the job's workspace when there is one, so an interrupted transcription resumes
+	// from its last completed step; otherwise in a temp directory removed when done
+	var ws *jobWorkspace
+	var workDir string
+	if s.workspaces != nil {
+		opened, err := s.workspaces.Open(transcriptionJobID(s.workspace, videoID, language, s.jobModel(), opts))
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, "failed to open job workspace")
+		}
+		ws, workDir = &jobWorkspace{opened}, opened.Dir
+	} else {
+		tempDir, err := janitor.MkdirTemp("yt-lang-audio-*")
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, "failed to create temp directory")
+		}
+		defer janitor.RemoveTemp(tempDir)
+		workDir = tempDir
 	}
 
-	// Check if transcription already exists
+	// Check if transcription already exists before downloading and cutting audio for it. The
+	// record an interrupted run of this job created is resumed unless it completed.
+	var transcription *model.Transcription
 	existing, err := s.transcriptionRepo.GetByVideoIDAndLanguage(ctx, videoID, language)
 	if err == nil {
-		return existing, nil
+		recordID, _ := ws.step(stepRecord)
+		if existing.Status == "completed" || existing.ID != recordID {
+			ws.finish()
+			return existing, nil
+		}
+		transcription = existing
 	}
 
-	// Create new transcription record
-	transcription := &model.Transcription{
-		VideoID:   videoID,
-		Language:  language,
-		Status:    "pending",
-		CreatedAt: time.Now(),
+	audioPath, err := s.prepareAudio(ctx, ws, workDir, video.URL, opts)
+	if err != nil {
+		return nil, err
+	}
+	audioDuration := time.Duration(video.Duration*float64(time.Second)) - opts.From
+	if opts.To != 0 && opts.To-opts.From < audioDuration {
+		audioDuration = opts.To - opts.From
+	}
+	opts.emit(Event{Event: EventAudioDownloaded, VideoID: videoID, AudioSeconds: max(audioDuration, 0).Seconds()})
+
+	if transcription == nil {
+		// Create new transcription record
+		transcription = &model.Transcription{
+			ID:        s.ids.NewID(),
+			VideoID:   videoID,
+			Language:  language,
+			Status:    "pending",
+			CreatedAt: s.clock.Now(),
+		}
+
+		if err := s.transcriptionRepo.Create(ctx, transcription); err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, "failed to create transcription record")
+		}
+		if err := ws.complete(stepRecord, transcription.ID); err != nil {
+			return nil, err
+		}
 	}
 
-	if err := s.transcriptionRepo.Create(ctx, transcription); err != nil {
-		return nil, errors.Wrap(err, errors.CodeInternal, "failed to create transcription record")
+	// Pick the whisper model and language from a sample of the audio when routing is configured;
+	// a resumed job that already ran whisper needs neither
+	whisperService, whisperLanguage := s.whisperService, language
+	if _, transcribed := ws.step(stepWhisper); s.router != nil && !transcribed {
+		if routing := s.route(ctx, transcription, audioPath, workDir, audioDuration); routing != nil {
+			whisperService, whisperLanguage = s.router.WhisperService(routing.Model), routing.Language
+		}
 	}
 
 	// Perform transcription in background (for now, synchronously)
-	err = s.processTranscription(ctx, transcription, audioPath)
+	err = s.processTranscription(ctx, transcription, ws, whisperService, audioPath, whisperLanguage, opts)
 	if err != nil {
 		// Update status to failed
 		errorMsg := "whisper transcription failed"
@@ -112,15 +298,98 @@ func (s *transcriptionService) CreateTranscription(ctx context.Context, videoID
 		return nil, err
 	}
 
+	ws.finish()
 	return transcription, nil
 }
 
-// processTranscription handles the actual transcription process
-func (s *transcriptionService) processTranscription(ctx context.Context, transcription *model.Transcription, audioPath string) error {
-	// Execute Whisper transcription
-	result, err := s.whisperService.TranscribeAudio(ctx, audioPath, transcription.Language)
+// prepareAudio downloads the audio of the video to workDir and cuts the requested range, or
+// returns the audio an interrupted run of the job already prepared
+func (s *transcriptionService) prepareAudio(ctx context.Context, ws *jobWorkspace, workDir, videoURL string, opts CreateOptions) (string, error) {
+	if name, ok := ws.step(stepAudio); ok {
+		if _, err := os.Stat(ws.Path(name)); err == nil {
+			return ws.Path(name), nil
+		}
+	}
+
+	// Download audio from video URL
+	audioPath, err := s.audioDownloadSvc.DownloadAudio(ctx, videoURL, workDir)
+	if err != nil {
+		return "", errors.Wrap(err, errors.CodeExternal, "failed to download audio")
+	}
+
+	// Cut the requested range so whisper only processes that part
+	if opts.clipped() {
+		audioPath, err = s.audioClipper.ClipAudio(ctx, audioPath, workDir, opts.From, opts.To)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if err := ws.complete(stepAudio, filepath.Base(audioPath)); err != nil {
+		return "", err
+	}
+	return audioPath, nil
+}
+
+// route asks the router for the whisper model and language and records the decision on the
+// transcription. It returns nil when routing fails; the default model is used then.
+func (s *transcriptionService) route(ctx context.Context, transcription *model.Transcription, audioPath, workDir string, duration time.Duration) *model.TranscriptionRouting {
+	routing, err := s.router.Route(ctx, audioPath, workDir, transcription.Language, duration)
 	if err != nil {
-		return errors.Wrap(err, errors.CodeExternal, "whisper transcription failed")
+		fmt.Fprintf(os.Stderr, "Warning: model routing failed, using the default model: %v\n", err)
+		return nil
+	}
+
+	// The decision only explains the transcription; losing it doesn't affect the result
+	if err := s.transcriptionRepo.SetRouting(ctx, transcription.ID, routing); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record model routing: %v\n", err)
+	}
+	transcription.Routing = routing
+	return routing
+}
+
+// processTranscription handles the actual transcription process, running whisperService on
+// audioPath in language. opts.From is the position of audioPath in the video and is added to the
+// segment times.
+func (s *transcriptionService) processTranscription(ctx context.Context, transcription *model.Transcription, ws *jobWorkspace, whisperService WhisperService, audioPath, language string, opts CreateOptions) error {
+	// Execute Whisper transcription, unless an interrupted run of the job already did
+	result, err := s.transcribe(ctx, transcription, ws, whisperService, audioPath, language, opts)
+	if err != nil {
+		return err
+	}
+
+	// Keep the raw whisper output; losing it only prevents later reprocessing
+	if err := s.saveWhisperArtifact(ctx, transcription.ID, result.Raw); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to keep whisper output: %v\n", err)
+	}
+
+	// Losing the candidates only hides how confident language detection was
+	if candidates := result.LanguageCandidates(languageCandidateCount); len(candidates) > 0 {
+		if err := s.transcriptionRepo.SetLanguageCandidates(ctx, transcription.ID, candidates); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save language candidates: %v\n", err)
+		}
+		transcription.LanguageCandidates = candidates
+	}
+
+	// Losing the attempts only hides which run of the fallback ladder was kept
+	if len(result.Attempts) > 0 {
+		if err := s.transcriptionRepo.SetWhisperAttempts(ctx, transcription.ID, result.Attempts); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save whisper attempts: %v\n", err)
+		}
+		transcription.WhisperAttempts = result.Attempts
+	}
+
+	// The kept output is relative to the clip; stored segments are relative to the video
+	OffsetWhisperResult(result, opts.From)
+
+	// Losing the report only hides which segments were flagged or how many were removed
+	var report *model.HallucinationReport
+	result.Segments, report = opts.Hallucinations.Clean(result.Segments)
+	if report != nil {
+		if err := s.transcriptionRepo.SetHallucinations(ctx, transcription.ID, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save hallucination report: %v\n", err)
+		}
+		transcription.Hallucinations = report
 	}
 
 	// Convert Whisper segments to TranscriptionSegments
@@ -129,22 +398,35 @@ func (s *transcriptionService) processTranscription(ctx context.Context, transcr
 		segments[i] = &model.TranscriptionSegment{
 			TranscriptionID: transcription.ID,
 			SegmentIndex:    i,
-			StartTime:       formatDuration(seg.Start),
-			EndTime:         formatDuration(seg.End),
+			StartTime:       timecode.FormatInterval(timecode.FromSeconds(seg.Start)),
+			EndTime:         timecode.FormatInterval(timecode.FromSeconds(seg.End)),
 			Text:            seg.Text,
 			Confidence:      &seg.Confidence,
 		}
+		if seg.Speaker != "" {
+			segments[i].Speaker = &seg.Speaker
+		}
 	}
 
-	// Save segments to database
-	if err := s.segmentRepo.CreateBatch(ctx, segments); err != nil {
-		return errors.Wrap(err, errors.CodeInternal, "failed to save transcription segments")
+	// Save segments to database; long videos report progress per chunk
+	progress := func(saved, total int) {
+		fmt.Fprintf(os.Stderr, "Saved %d/%d segments\n", saved, total)
 	}
+	// Segments saved for another record, one deleted since the interrupted run, are saved again
+	if recordID, saved := ws.step(stepSegments); !saved || recordID != transcription.ID {
+		if err := s.segmentRepo.CreateBatchWithProgress(ctx, segments, progress); err != nil {
+			return errors.Wrap(err, errors.CodeInternal, "failed to save transcription segments")
+		}
+		if err := ws.complete(stepSegments, transcription.ID); err != nil {
+			return err
+		}
+	}
+	opts.emit(Event{Event: EventSegmentsSaved, VideoID: transcription.VideoID, TranscriptionID: transcription.ID, Segments: len(segments)})
 
 	// Update transcription status and metadata
 	transcription.Status = "completed"
 	transcription.DetectedLanguage = &result.Language
-	now := time.Now()
+	now := s.clock.Now()
 	transcription.CompletedAt = &now
 
 	if err := s.transcriptionRepo.UpdateStatus(ctx, transcription.ID, "completed", nil); err != nil {
@@ -154,6 +436,37 @@ func (s *transcriptionService) processTranscription(ctx context.Context, transcr
 	return nil
 }
 
+// saveWhisperArtifact stores raw whisper output and references it from the transcription
+func (s *transcriptionService) saveWhisperArtifact(ctx context.Context, transcriptionID string, raw []byte) error {
+	if s.artifactStore == nil || len(raw) == 0 {
+		return nil
+	}
+
+	key := artifact.WhisperKey(transcriptionID)
+	if err := artifact.PutGzip(ctx, s.artifactStore, key, raw); err != nil {
+		return err
+	}
+	return s.transcriptionRepo.SetWhisperArtifact(ctx, transcriptionID, key)
+}
+
+// OpenWhisperArtifact opens the raw whisper JSON output kept for a transcription
+func (s *transcriptionService) OpenWhisperArtifact(ctx context.Context, id string) (io.ReadCloser, error) {
+	if s.artifactStore == nil {
+		return nil, errors.New(errors.CodeInternal, "artifact store is not configured")
+	}
+
+	key, err := s.transcriptionRepo.GetWhisperArtifact(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeNotFound, "whisper artifact not found")
+	}
+
+	reader, err := artifact.OpenGzip(ctx, s.artifactStore, key)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to open whisper artifact")
+	}
+	return reader, nil
+}
+
 // GetTranscription retrieves transcription and its segments by ID
 func (s *transcriptionService) GetTranscription(ctx context.Context, id string) (*model.Transcription, []*model.TranscriptionSegment, error) {
 	// Get transcription
@@ -171,6 +484,34 @@ func (s *transcriptionService) GetTranscription(ctx context.Context, id string)
 	return transcription, segments, nil
 }
 
+// GetTranscriptionInfo retrieves transcription metadata by ID without loading segments
+func (s *transcriptionService) GetTranscriptionInfo(ctx context.Context, id string) (*model.Transcription, error) {
+	transcription, err := s.transcriptionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeNotFound, "transcription not found")
+	}
+
+	return transcription, nil
+}
+
+// StreamSegments streams transcription segments to fn in segment order
+func (s *transcriptionService) StreamSegments(ctx context.Context, id string, fn func(segment *model.TranscriptionSegment) error) error {
+	if err := s.segmentRepo.IterateByTranscriptionID(ctx, id, 0, fn); err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to stream transcription segments")
+	}
+
+	return nil
+}
+
+// SearchSegments streams the segments matching pattern to fn in segment order
+func (s *transcriptionService) SearchSegments(ctx context.Context, id string, pattern string, fn func(segment *model.TranscriptionSegment) error) error {
+	if err := s.segmentRepo.SearchByTranscriptionID(ctx, id, pattern, 0, fn); err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to search transcription segments")
+	}
+
+	return nil
+}
+
 // ListTranscriptions lists transcriptions for a video
 func (s *transcriptionService) ListTranscriptions(ctx context.Context, videoID string) ([]*model.Transcription, error) {
 	transcriptions, err := s.transcriptionRepo.GetByVideoID(ctx, videoID)
@@ -181,6 +522,26 @@ func (s *transcriptionService) ListTranscriptions(ctx context.Context, videoID s
 	return transcriptions, nil
 }
 
+// CountTranscriptions returns the number of transcriptions of a video
+func (s *transcriptionService) CountTranscriptions(ctx context.Context, videoID string) (int, error) {
+	count, err := s.transcriptionRepo.CountByVideoID(ctx, videoID)
+	if err != nil {
+		return 0, errors.Wrap(err, errors.CodeInternal, "failed to count transcriptions")
+	}
+
+	return count, nil
+}
+
+// ListStaleTranscriptions lists the transcriptions whose video changed on YouTube
+func (s *transcriptionService) ListStaleTranscriptions(ctx context.Context, videoID string) ([]*model.Transcription, error) {
+	transcriptions, err := s.transcriptionRepo.ListStale(ctx, videoID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to list stale transcriptions")
+	}
+
+	return transcriptions, nil
+}
+
 // DeleteTranscription deletes transcription and its segments
 func (s *transcriptionService) DeleteTranscription(ctx context.Context, id string) error {
 	// Delete segments first (foreign key constraint)
@@ -193,14 +554,12 @@ func (s *transcriptionService) DeleteTranscription(ctx context.Context, id strin
 		return errors.Wrap(err, errors.CodeInternal, "failed to delete transcription")
 	}
 
-	return nil
-}
+	// Remove the raw whisper output, if any was kept
+	if s.artifactStore != nil {
+		if err := s.artifactStore.Delete(ctx, artifact.WhisperKey(id)); err != nil {
+			return errors.Wrap(err, errors.CodeInternal, "failed to delete whisper artifact")
+		}
+	}
 
-// formatDuration converts seconds to PostgreSQL INTERVAL format
-func formatDuration(seconds float64) string {
-	duration := time.Duration(seconds * float64(time.Second))
-	return fmt.Sprintf("%02d:%02d:%06.3f",
-		int(duration.Hours()),
-		int(duration.Minutes())%60,
-		float64(duration.Nanoseconds()%int64(time.Minute))/float64(time.Second))
+	return nil
 }
@@ -144,11 +144,12 @@ func TestTranscriptionService_Integration(t *testing.T) {
 		mockWhisperSvc,
 		mockAudioSvc,
 		videoRepo,
+		nil,
 	)
 
 	t.Run("CreateTranscription_Success", func(t *testing.T) {
 		// Test transcription creation
-		result, err := transcriptionService.CreateTranscription(ctx, "test-video-123", "auto")
+		result, err := transcriptionService.CreateTranscription(ctx, "test-video-123", "auto", CreateOptions{})
 		require.NoError(t, err)
 		assert.NotNil(t, result)
 
@@ -181,11 +182,11 @@ func TestTranscriptionService_Integration(t *testing.T) {
 		require.NoError(t, err)
 
 		// Create first transcription
-		result1, err := transcriptionService.CreateTranscription(ctx, "test-video-456", "ja")
+		result1, err := transcriptionService.CreateTranscription(ctx, "test-video-456", "ja", CreateOptions{})
 		require.NoError(t, err)
 
 		// Try to create another transcription for same video/language
-		result2, err := transcriptionService.CreateTranscription(ctx, "test-video-456", "ja")
+		result2, err := transcriptionService.CreateTranscription(ctx, "test-video-456", "ja", CreateOptions{})
 		require.NoError(t, err)
 
 		// Should return the existing transcription
@@ -194,7 +195,7 @@ func TestTranscriptionService_Integration(t *testing.T) {
 
 	t.Run("GetTranscription_Success", func(t *testing.T) {
 		// Create transcription first
-		created, err := transcriptionService.CreateTranscription(ctx, "test-video-123", "en")
+		created, err := transcriptionService.CreateTranscription(ctx, "test-video-123", "en", CreateOptions{})
 		require.NoError(t, err)
 
 		// Get transcription
@@ -234,7 +235,7 @@ func TestTranscriptionService_Integration(t *testing.T) {
 		require.NoError(t, err)
 
 		// Create transcription
-		created, err := transcriptionService.CreateTranscription(ctx, "test-video-789", "fr")
+		created, err := transcriptionService.CreateTranscription(ctx, "test-video-789", "fr", CreateOptions{})
 		require.NoError(t, err)
 
 		// Delete transcription
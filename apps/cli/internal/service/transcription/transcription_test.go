@@ -2,12 +2,19 @@ package transcription
 
 import (
 	"context"
+	"io"
+	"os"
 	"testing"
 	"time"
 
+	"github.com/Taichi-iskw/yt-lang/internal/artifact"
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/job"
 	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // mockTranscriptionRepository for testing
@@ -28,6 +35,11 @@ func (m *mockTranscriptionRepository) GetByID(ctx context.Context, id string) (*
 	return args.Get(0).(*model.Transcription), args.Error(1)
 }
 
+func (m *mockTranscriptionRepository) CountByVideoID(ctx context.Context, videoID string) (int, error) {
+	args := m.Called(ctx, videoID)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *mockTranscriptionRepository) GetByVideoID(ctx context.Context, videoID string) ([]*model.Transcription, error) {
 	args := m.Called(ctx, videoID)
 	if args.Get(0) == nil {
@@ -54,6 +66,52 @@ func (m *mockTranscriptionRepository) Delete(ctx context.Context, id string) err
 	return args.Error(0)
 }
 
+func (m *mockTranscriptionRepository) CountSpokenLanguages(ctx context.Context, channelID string) (map[string]int, int, error) {
+	args := m.Called(ctx, channelID)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).(map[string]int), args.Int(1), args.Error(2)
+}
+
+func (m *mockTranscriptionRepository) ListStale(ctx context.Context, videoID string) ([]*model.Transcription, error) {
+	args := m.Called(ctx, videoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Transcription), args.Error(1)
+}
+
+func (m *mockTranscriptionRepository) SetWhisperArtifact(ctx context.Context, id string, key string) error {
+	args := m.Called(ctx, id, key)
+	return args.Error(0)
+}
+
+func (m *mockTranscriptionRepository) SetLanguageCandidates(ctx context.Context, id string, candidates []model.LanguageCandidate) error {
+	args := m.Called(ctx, id, candidates)
+	return args.Error(0)
+}
+
+func (m *mockTranscriptionRepository) SetWhisperAttempts(ctx context.Context, id string, attempts []model.WhisperAttempt) error {
+	args := m.Called(ctx, id, attempts)
+	return args.Error(0)
+}
+
+func (m *mockTranscriptionRepository) SetHallucinations(ctx context.Context, id string, report *model.HallucinationReport) error {
+	args := m.Called(ctx, id, report)
+	return args.Error(0)
+}
+
+func (m *mockTranscriptionRepository) SetRouting(ctx context.Context, id string, routing *model.TranscriptionRouting) error {
+	args := m.Called(ctx, id, routing)
+	return args.Error(0)
+}
+
+func (m *mockTranscriptionRepository) GetWhisperArtifact(ctx context.Context, id string) (string, error) {
+	args := m.Called(ctx, id)
+	return args.String(0), args.Error(1)
+}
+
 // mockSegmentRepository for testing
 type mockSegmentRepository struct {
 	mock.Mock
@@ -64,6 +122,10 @@ func (m *mockSegmentRepository) CreateBatch(ctx context.Context, segments []*mod
 	return args.Error(0)
 }
 
+func (m *mockSegmentRepository) CreateBatchWithProgress(ctx context.Context, segments []*model.TranscriptionSegment, progress func(saved, total int)) error {
+	return m.CreateBatch(ctx, segments)
+}
+
 func (m *mockSegmentRepository) GetByTranscriptionID(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error) {
 	args := m.Called(ctx, transcriptionID)
 	if args.Get(0) == nil {
@@ -72,7 +134,31 @@ func (m *mockSegmentRepository) GetByTranscriptionID(ctx context.Context, transc
 	return args.Get(0).([]*model.TranscriptionSegment), args.Error(1)
 }
 
-func (m *mockSegmentRepository) GetByTimeRange(ctx context.Context, transcriptionID string, startTime, endTime string) ([]*model.TranscriptionSegment, error) {
+func (m *mockSegmentRepository) SearchByTranscriptionID(ctx context.Context, transcriptionID string, pattern string, batchSize int, fn func(segment *model.TranscriptionSegment) error) error {
+	args := m.Called(ctx, transcriptionID, pattern, batchSize, fn)
+	if segments, ok := args.Get(0).([]*model.TranscriptionSegment); ok {
+		for _, segment := range segments {
+			if err := fn(segment); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *mockSegmentRepository) IterateByTranscriptionID(ctx context.Context, transcriptionID string, batchSize int, fn func(segment *model.TranscriptionSegment) error) error {
+	args := m.Called(ctx, transcriptionID, batchSize, fn)
+	if segments, ok := args.Get(0).([]*model.TranscriptionSegment); ok {
+		for _, segment := range segments {
+			if err := fn(segment); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *mockSegmentRepository) GetByTimeRange(ctx context.Context, transcriptionID string, startTime, endTime time.Duration) ([]*model.TranscriptionSegment, error) {
 	args := m.Called(ctx, transcriptionID, startTime, endTime)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -98,6 +184,25 @@ func (m *mockWhisperService) TranscribeAudio(ctx context.Context, audioPath stri
 	return args.Get(0).(*model.WhisperResult), args.Error(1)
 }
 
+// mockModelRouter for testing
+type mockModelRouter struct {
+	mock.Mock
+	whisper WhisperService
+}
+
+func (m *mockModelRouter) Route(ctx context.Context, audioPath, workDir, language string, duration time.Duration) (*model.TranscriptionRouting, error) {
+	args := m.Called(ctx, audioPath, workDir, language, duration)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TranscriptionRouting), args.Error(1)
+}
+
+func (m *mockModelRouter) WhisperService(model string) WhisperService {
+	m.Called(model)
+	return m.whisper
+}
+
 // mockAudioDownloadService for testing
 type mockAudioDownloadService struct {
 	mock.Mock
@@ -123,11 +228,35 @@ func (m *mockVideoRepository) CreateBatch(ctx context.Context, videos []*model.V
 	return args.Error(0)
 }
 
+func (m *mockVideoRepository) ListTranscriptionCandidates(ctx context.Context, filter video.CandidateFilter) ([]*model.Video, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Video), args.Error(1)
+}
+
+func (m *mockVideoRepository) PlanTranscriptionCandidates(ctx context.Context, filter video.CandidateFilter) ([]*video.PlannedVideo, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*video.PlannedVideo), args.Error(1)
+}
+
 func (m *mockVideoRepository) UpsertBatch(ctx context.Context, videos []*model.Video) error {
 	args := m.Called(ctx, videos)
 	return args.Error(0)
 }
 
+func (m *mockVideoRepository) DetectChanges(ctx context.Context, videos []*model.Video) ([]*model.VideoChange, error) {
+	args := m.Called(ctx, videos)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.VideoChange), args.Error(1)
+}
+
 func (m *mockVideoRepository) GetByID(ctx context.Context, id string) (*model.Video, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -136,6 +265,16 @@ func (m *mockVideoRepository) GetByID(ctx context.Context, id string) (*model.Vi
 	return args.Get(0).(*model.Video), args.Error(1)
 }
 
+func (m *mockVideoRepository) CountByChannelID(ctx context.Context, channelID string) (int, error) {
+	args := m.Called(ctx, channelID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockVideoRepository) CountByChannelIDAndStatus(ctx context.Context, channelID string, status string) (int, error) {
+	args := m.Called(ctx, channelID, status)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *mockVideoRepository) GetByChannelID(ctx context.Context, channelID string, limit, offset int) ([]*model.Video, error) {
 	args := m.Called(ctx, channelID, limit, offset)
 	if args.Get(0) == nil {
@@ -149,6 +288,50 @@ func (m *mockVideoRepository) Update(ctx context.Context, video *model.Video) er
 	return args.Error(0)
 }
 
+func (m *mockVideoRepository) UpdateStatus(ctx context.Context, id string, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *mockVideoRepository) SetDuplicateOf(ctx context.Context, id string, originalID string) error {
+	args := m.Called(ctx, id, originalID)
+	return args.Error(0)
+}
+
+func (m *mockVideoRepository) UpdateAnnotation(ctx context.Context, id string, rating *int, note *string, clear bool) error {
+	args := m.Called(ctx, id, rating, note, clear)
+	return args.Error(0)
+}
+
+func (m *mockVideoRepository) SetChapters(ctx context.Context, id string, chapters []model.VideoChapter) error {
+	args := m.Called(ctx, id, chapters)
+	return args.Error(0)
+}
+
+func (m *mockVideoRepository) GetChapters(ctx context.Context, id string) ([]model.VideoChapter, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.VideoChapter), args.Error(1)
+}
+
+func (m *mockVideoRepository) ListByMinRating(ctx context.Context, channelID string, minRating int) ([]*model.Video, error) {
+	args := m.Called(ctx, channelID, minRating)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Video), args.Error(1)
+}
+
+func (m *mockVideoRepository) ListPruneCandidates(ctx context.Context, filter video.PruneFilter) ([]*model.Video, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Video), args.Error(1)
+}
+
 func (m *mockVideoRepository) Delete(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -241,12 +424,12 @@ func TestTranscriptionService_CreateTranscription(t *testing.T) {
 
 			tt.setupMocks(transcRepo, segRepo, whisperSvc, audioSvc, videoRepo)
 
-			service := NewTranscriptionServiceWithAllDependencies(transcRepo, segRepo, whisperSvc, audioSvc, videoRepo)
+			service := NewTranscriptionServiceWithAllDependencies(transcRepo, segRepo, whisperSvc, audioSvc, videoRepo, nil)
 
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 			defer cancel()
 
-			result, err := service.CreateTranscription(ctx, tt.videoID, tt.language)
+			result, err := service.CreateTranscription(ctx, tt.videoID, tt.language, CreateOptions{})
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -265,6 +448,25 @@ func TestTranscriptionService_CreateTranscription(t *testing.T) {
 	}
 }
 
+func TestTranscriptionService_CreateTranscription_Existing(t *testing.T) {
+	// An existing transcription is returned before any audio is downloaded or cut
+	transcRepo := new(mockTranscriptionRepository)
+	audioSvc := new(mockAudioDownloadService)
+	videoRepo := new(mockVideoRepository)
+	videoRepo.On("GetByID", mock.Anything, "test-video-123").
+		Return(&model.Video{ID: "test-video-123", URL: "https://youtube.com/watch?v=test", Duration: 600}, nil)
+	existing := &model.Transcription{ID: "t1", VideoID: "test-video-123", Language: "es", Status: "completed"}
+	transcRepo.On("GetByVideoIDAndLanguage", mock.Anything, "test-video-123", "es").Return(existing, nil)
+
+	service := NewTranscriptionServiceWithAllDependencies(transcRepo, new(mockSegmentRepository), new(mockWhisperService), audioSvc, videoRepo, nil)
+	result, err := service.CreateTranscription(context.Background(), "test-video-123", "es", CreateOptions{From: time.Minute, To: 2 * time.Minute})
+	require.NoError(t, err)
+	assert.Equal(t, existing, result)
+
+	audioSvc.AssertNotCalled(t, "DownloadAudio", mock.Anything, mock.Anything, mock.Anything)
+	transcRepo.AssertExpectations(t)
+}
+
 func TestTranscriptionService_GetTranscription(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -351,7 +553,465 @@ func TestTranscriptionService_GetTranscription(t *testing.T) {
 	}
 }
 
+func TestTranscriptionService_StreamSegments(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		setupMocks func(*mockSegmentRepository)
+		fnErr      error
+		wantErr    bool
+		wantTexts  []string
+	}{
+		{
+			name: "streams segments in order",
+			id:   "transcription-123",
+			setupMocks: func(segRepo *mockSegmentRepository) {
+				segments := []*model.TranscriptionSegment{
+					{SegmentIndex: 0, Text: "First"},
+					{SegmentIndex: 1, Text: "Second"},
+				}
+				segRepo.On("IterateByTranscriptionID", mock.Anything, "transcription-123", 0, mock.Anything).
+					Return(segments, nil)
+			},
+			wantErr:   false,
+			wantTexts: []string{"First", "Second"},
+		},
+		{
+			name: "callback error stops streaming",
+			id:   "transcription-123",
+			setupMocks: func(segRepo *mockSegmentRepository) {
+				segments := []*model.TranscriptionSegment{
+					{SegmentIndex: 0, Text: "First"},
+					{SegmentIndex: 1, Text: "Second"},
+				}
+				segRepo.On("IterateByTranscriptionID", mock.Anything, "transcription-123", 0, mock.Anything).
+					Return(segments, nil)
+			},
+			fnErr:     assert.AnError,
+			wantErr:   true,
+			wantTexts: []string{"First"},
+		},
+		{
+			name: "repository error",
+			id:   "transcription-456",
+			setupMocks: func(segRepo *mockSegmentRepository) {
+				segRepo.On("IterateByTranscriptionID", mock.Anything, "transcription-456", 0, mock.Anything).
+					Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transcRepo := new(mockTranscriptionRepository)
+			segRepo := new(mockSegmentRepository)
+
+			tt.setupMocks(segRepo)
+
+			service := NewTranscriptionServiceWithDependencies(transcRepo, segRepo, nil)
+
+			var texts []string
+			err := service.StreamSegments(context.Background(), tt.id, func(segment *model.TranscriptionSegment) error {
+				texts = append(texts, segment.Text)
+				return tt.fnErr
+			})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantTexts, texts)
+
+			segRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTranscriptionService_CountTranscriptions(t *testing.T) {
+	transcRepo := new(mockTranscriptionRepository)
+	transcRepo.On("CountByVideoID", mock.Anything, "video-123").Return(0, assert.AnError)
+
+	service := NewTranscriptionServiceWithDependencies(transcRepo, new(mockSegmentRepository), nil)
+
+	_, err := service.CountTranscriptions(context.Background(), "video-123")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to count transcriptions")
+	transcRepo.AssertExpectations(t)
+}
+
+func TestTranscriptionService_SearchSegments(t *testing.T) {
+	t.Run("streams matching segments", func(t *testing.T) {
+		segRepo := new(mockSegmentRepository)
+		segRepo.On("SearchByTranscriptionID", mock.Anything, "transcription-123", "hello", 0, mock.Anything).
+			Return([]*model.TranscriptionSegment{{SegmentIndex: 3, Text: "Hello there"}}, nil)
+
+		service := NewTranscriptionServiceWithDependencies(new(mockTranscriptionRepository), segRepo, nil)
+
+		var texts []string
+		err := service.SearchSegments(context.Background(), "transcription-123", "hello", func(segment *model.TranscriptionSegment) error {
+			texts = append(texts, segment.Text)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Hello there"}, texts)
+		segRepo.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		segRepo := new(mockSegmentRepository)
+		segRepo.On("SearchByTranscriptionID", mock.Anything, "transcription-123", "hello", 0, mock.Anything).
+			Return(nil, assert.AnError)
+
+		service := NewTranscriptionServiceWithDependencies(new(mockTranscriptionRepository), segRepo, nil)
+
+		err := service.SearchSegments(context.Background(), "transcription-123", "hello", func(*model.TranscriptionSegment) error { return nil })
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to search transcription segments")
+	})
+}
+
+func TestTranscriptionService_WhisperArtifact(t *testing.T) {
+	raw := []byte(`{"text": "Hello", "segments": [{"id": 0, "words": [{"word": "Hello"}]}], "language": "en"}`)
+
+	transcRepo := new(mockTranscriptionRepository)
+	segRepo := new(mockSegmentRepository)
+	whisperSvc := new(mockWhisperService)
+	audioSvc := new(mockAudioDownloadService)
+	videoRepo := new(mockVideoRepository)
+	store := artifact.NewLocalStore(t.TempDir())
+
+	videoRepo.On("GetByID", mock.Anything, "video-123").
+		Return(&model.Video{ID: "video-123", URL: "https://youtube.com/watch?v=test"}, nil)
+	audioSvc.On("DownloadAudio", mock.Anything, "https://youtube.com/watch?v=test", mock.AnythingOfType("string")).
+		Return("/tmp/audio.m4a", nil)
+	transcRepo.On("GetByVideoIDAndLanguage", mock.Anything, "video-123", "en").
+		Return(nil, assert.AnError)
+	transcRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Transcription")).
+		Run(func(args mock.Arguments) { args.Get(1).(*model.Transcription).ID = "transcription-123" }).
+		Return(nil)
+	whisperSvc.On("TranscribeAudio", mock.Anything, "/tmp/audio.m4a", "en").
+		Return(&model.WhisperResult{Text: "Hello", Language: "en", Raw: raw}, nil)
+	transcRepo.On("SetWhisperArtifact", mock.Anything, "transcription-123", "whisper/transcription-123.json.gz").
+		Return(nil)
+	segRepo.On("CreateBatch", mock.Anything, mock.AnythingOfType("[]*model.TranscriptionSegment")).
+		Return(nil)
+	transcRepo.On("UpdateStatus", mock.Anything, "transcription-123", "completed", (*string)(nil)).
+		Return(nil)
+
+	service := NewTranscriptionServiceWithAllDependencies(transcRepo, segRepo, whisperSvc, audioSvc, videoRepo, store)
+	_, err := service.CreateTranscription(context.Background(), "video-123", "en", CreateOptions{})
+	require.NoError(t, err)
+	transcRepo.AssertExpectations(t)
+
+	// The stored artifact decompresses back to the exact whisper output
+	transcRepo.On("GetWhisperArtifact", mock.Anything, "transcription-123").
+		Return("whisper/transcription-123.json.gz", nil)
+	reader, err := service.OpenWhisperArtifact(context.Background(), "transcription-123")
+	require.NoError(t, err)
+	got, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	assert.Equal(t, raw, got)
+
+	// Transcriptions created without an artifact report not found
+	transcRepo.On("GetWhisperArtifact", mock.Anything, "transcription-456").
+		Return("", apperrors.New(apperrors.CodeNotFound, "whisper artifact not found"))
+	_, err = service.OpenWhisperArtifact(context.Background(), "transcription-456")
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, apperrors.CodeNotFound, appErr.Code)
+}
+
+func TestTranscriptionService_CreateTranscription_LanguageCandidates(t *testing.T) {
+	transcRepo := new(mockTranscriptionRepository)
+	segRepo := new(mockSegmentRepository)
+	whisperSvc := new(mockWhisperService)
+	audioSvc := new(mockAudioDownloadService)
+	videoRepo := new(mockVideoRepository)
+
+	videoRepo.On("GetByID", mock.Anything, "video-123").
+		Return(&model.Video{ID: "video-123", URL: "https://youtube.com/watch?v=test"}, nil)
+	audioSvc.On("DownloadAudio", mock.Anything, "https://youtube.com/watch?v=test", mock.AnythingOfType("string")).
+		Return("/tmp/audio.m4a", nil)
+	transcRepo.On("GetByVideoIDAndLanguage", mock.Anything, "video-123", "auto").
+		Return(nil, assert.AnError)
+	transcRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Transcription")).
+		Run(func(args mock.Arguments) { args.Get(1).(*model.Transcription).ID = "transcription-123" }).
+		Return(nil)
+	whisperSvc.On("TranscribeAudio", mock.Anything, "/tmp/audio.m4a", "auto").
+		Return(&model.WhisperResult{
+			Language:      "ja",
+			LanguageProbs: map[string]float64{"ja": 0.48, "en": 0.41, "ko": 0.06, "zh": 0.05},
+		}, nil)
+
+	// Only the three most probable languages are kept
+	candidates := []model.LanguageCandidate{
+		{Language: "ja", Probability: 0.48},
+		{Language: "en", Probability: 0.41},
+		{Language: "ko", Probability: 0.06},
+	}
+	transcRepo.On("SetLanguageCandidates", mock.Anything, "transcription-123", candidates).Return(nil)
+	segRepo.On("CreateBatch", mock.Anything, mock.AnythingOfType("[]*model.TranscriptionSegment")).
+		Return(nil)
+	transcRepo.On("UpdateStatus", mock.Anything, "transcription-123", "completed", (*string)(nil)).
+		Return(nil)
+
+	service := NewTranscriptionServiceWithAllDependencies(transcRepo, segRepo, whisperSvc, audioSvc, videoRepo, nil)
+	result, err := service.CreateTranscription(context.Background(), "video-123", "auto", CreateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, candidates, result.LanguageCandidates)
+	transcRepo.AssertExpectations(t)
+}
+
+func TestTranscriptionService_CreateTranscription_Range(t *testing.T) {
+	transcRepo := new(mockTranscriptionRepository)
+	segRepo := new(mockSegmentRepository)
+	whisperSvc := new(mockWhisperService)
+	audioSvc := new(mockAudioDownloadService)
+	videoRepo := new(mockVideoRepository)
+	runner := new(mockWhisperCmdRunner)
+
+	videoRepo.On("GetByID", mock.Anything, "video-123").
+		Return(&model.Video{ID: "video-123", URL: "https://youtube.com/watch?v=test", Duration: 3600}, nil)
+	audioSvc.On("DownloadAudio", mock.Anything, "https://youtube.com/watch?v=test", mock.AnythingOfType("string")).
+		Return("/tmp/audio.m4a", nil)
+	var clipPath string
+	runner.On("Run", mock.Anything, "ffmpeg", mock.AnythingOfType("[]string")).
+		Run(func(args mock.Arguments) {
+			ffmpegArgs := args.Get(2).([]string)
+			clipPath = ffmpegArgs[len(ffmpegArgs)-1]
+		}).
+		Return([]byte(""), nil)
+	transcRepo.On("GetByVideoIDAndLanguage", mock.Anything, "video-123", "en").
+		Return(nil, assert.AnError)
+	transcRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Transcription")).
+		Return(nil)
+	whisperSvc.On("TranscribeAudio", mock.Anything, mock.MatchedBy(func(path string) bool { return path == clipPath }), "en").
+		Return(&model.WhisperResult{
+			Language: "en",
+			Segments: []model.WhisperSegment{
+				{Start: 0, End: 2.5, Text: "Hello"},
+				{Start: 2.5, End: 4, Text: "again"},
+			},
+		}, nil)
+	var saved []*model.TranscriptionSegment
+	segRepo.On("CreateBatch", mock.Anything, mock.AnythingOfType("[]*model.TranscriptionSegment")).
+		Run(func(args mock.Arguments) { saved = args.Get(1).([]*model.TranscriptionSegment) }).
+		Return(nil)
+	transcRepo.On("UpdateStatus", mock.Anything, mock.Anything, "completed", (*string)(nil)).
+		Return(nil)
+
+	service := NewTranscriptionServiceWithAllDependencies(transcRepo, segRepo, whisperSvc, audioSvc, videoRepo, nil)
+	service.(*transcriptionService).audioClipper = NewAudioClipperWithCmdRunner(runner)
+
+	_, err := service.CreateTranscription(context.Background(), "video-123", "en", CreateOptions{From: 5 * time.Minute, To: 15 * time.Minute})
+	require.NoError(t, err)
+
+	// Whisper ran on the clip; stored times are offset by the start of the range
+	require.Len(t, saved, 2)
+	assert.Equal(t, "00:05:00.000", saved[0].StartTime)
+	assert.Equal(t, "00:05:02.500", saved[0].EndTime)
+	assert.Equal(t, "00:05:04.000", saved[1].EndTime)
+	runner.AssertExpectations(t)
+	whisperSvc.AssertExpectations(t)
+
+	t.Run("rejects a range past the end of the video", func(t *testing.T) {
+		_, err := service.CreateTranscription(context.Background(), "video-123", "en", CreateOptions{From: 2 * time.Hour})
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.CodeInvalidArg, appErr.Code)
+	})
+
+	t.Run("rejects an end before the start", func(t *testing.T) {
+		_, err := service.CreateTranscription(context.Background(), "video-123", "en", CreateOptions{From: 10 * time.Minute, To: 5 * time.Minute})
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, apperrors.CodeInvalidArg, appErr.Code)
+	})
+}
+
+func TestTranscriptionService_CreateTranscription_Routing(t *testing.T) {
+	setup := func() (*mockTranscriptionRepository, *mockSegmentRepository, *mockWhisperService, *mockAudioDownloadService, *mockVideoRepository) {
+		transcRepo := new(mockTranscriptionRepository)
+		segRepo := new(mockSegmentRepository)
+		defaultWhisper := new(mockWhisperService)
+		audioSvc := new(mockAudioDownloadService)
+		videoRepo := new(mockVideoRepository)
+
+		videoRepo.On("GetByID", mock.Anything, "video-123").
+			Return(&model.Video{ID: "video-123", URL: "https://youtube.com/watch?v=test", Duration: 1800}, nil)
+		audioSvc.On("DownloadAudio", mock.Anything, "https://youtube.com/watch?v=test", mock.AnythingOfType("string")).
+			Return("/tmp/audio.m4a", nil)
+		transcRepo.On("GetByVideoIDAndLanguage", mock.Anything, "video-123", "auto").
+			Return(nil, assert.AnError)
+		transcRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Transcription")).
+			Run(func(args mock.Arguments) { args.Get(1).(*model.Transcription).ID = "transcription-123" }).
+			Return(nil)
+		segRepo.On("CreateBatch", mock.Anything, mock.AnythingOfType("[]*model.TranscriptionSegment")).
+			Return(nil)
+		transcRepo.On("UpdateStatus", mock.Anything, "transcription-123", "completed", (*string)(nil)).
+			Return(nil)
+		return transcRepo, segRepo, defaultWhisper, audioSvc, videoRepo
+	}
+
+	t.Run("transcribes with the routed model and language", func(t *testing.T) {
+		transcRepo, segRepo, defaultWhisper, audioSvc, videoRepo := setup()
+		routedWhisper := new(mockWhisperService)
+		router := &mockModelRouter{whisper: routedWhisper}
+
+		routing := &model.TranscriptionRouting{SampledLanguage: "ja", Language: "ja", Model: "medium", Reason: "sample detected ja; rule ja -> medium"}
+		router.On("Route", mock.Anything, "/tmp/audio.m4a", mock.AnythingOfType("string"), "auto", 30*time.Minute).
+			Return(routing, nil)
+		router.On("WhisperService", "medium").Return()
+		transcRepo.On("SetRouting", mock.Anything, "transcription-123", routing).Return(nil)
+		routedWhisper.On("TranscribeAudio", mock.Anything, "/tmp/audio.m4a", "ja").
+			Return(&model.WhisperResult{Language: "ja"}, nil)
+
+		service := NewTranscriptionServiceWithRouter(transcRepo, segRepo, defaultWhisper, audioSvc, videoRepo, nil, router)
+		result, err := service.CreateTranscription(context.Background(), "video-123", "auto", CreateOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, routing, result.Routing)
+
+		router.AssertExpectations(t)
+		routedWhisper.AssertExpectations(t)
+		transcRepo.AssertExpectations(t)
+		defaultWhisper.AssertNotCalled(t, "TranscribeAudio", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("falls back to the default model when routing fails", func(t *testing.T) {
+		transcRepo, segRepo, defaultWhisper, audioSvc, videoRepo := setup()
+		router := &mockModelRouter{}
+
+		router.On("Route", mock.Anything, "/tmp/audio.m4a", mock.AnythingOfType("string"), "auto", 30*time.Minute).
+			Return(nil, assert.AnError)
+		defaultWhisper.On("TranscribeAudio", mock.Anything, "/tmp/audio.m4a", "auto").
+			Return(&model.WhisperResult{Language: "en"}, nil)
+
+		service := NewTranscriptionServiceWithRouter(transcRepo, segRepo, defaultWhisper, audioSvc, videoRepo, nil, router)
+		result, err := service.CreateTranscription(context.Background(), "video-123", "auto", CreateOptions{})
+		require.NoError(t, err)
+		assert.Nil(t, result.Routing)
+
+		defaultWhisper.AssertExpectations(t)
+		transcRepo.AssertNotCalled(t, "SetRouting", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
 // Helper function to create string pointer
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestTranscriptionService_CreateTranscription_ResumesJob(t *testing.T) {
+	transcRepo := new(mockTranscriptionRepository)
+	segRepo := new(mockSegmentRepository)
+	whisperSvc := new(mockWhisperService)
+	audioSvc := new(mockAudioDownloadService)
+	videoRepo := new(mockVideoRepository)
+
+	// An earlier run downloaded the audio, created the record and ran whisper before it was
+	// interrupted
+	workspaces := job.NewWorkspaces(t.TempDir())
+	ws, err := workspaces.Open(transcriptionJobID("default", "video-123", "en", "", CreateOptions{}))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(ws.Path("audio.m4a"), []byte("audio"), 0644))
+	require.NoError(t, ws.Complete(stepAudio, "audio.m4a"))
+	require.NoError(t, ws.Complete(stepRecord, "transcription-1"))
+	require.NoError(t, os.WriteFile(ws.Path(whisperOutputFile), []byte(`{"language":"en","segments":[{"start":0,"end":2.5,"text":"Hello","speaker":"SPEAKER_00"}]}`), 0644))
+	require.NoError(t, ws.Complete(stepWhisper, whisperOutputFile))
+
+	videoRepo.On("GetByID", mock.Anything, "video-123").
+		Return(&model.Video{ID: "video-123", URL: "https://youtube.com/watch?v=test", Duration: 60}, nil)
+	transcRepo.On("GetByVideoIDAndLanguage", mock.Anything, "video-123", "en").
+		Return(&model.Transcription{ID: "transcription-1", VideoID: "video-123", Language: "en", Status: "pending"}, nil)
+	var saved []*model.TranscriptionSegment
+	segRepo.On("CreateBatch", mock.Anything, mock.AnythingOfType("[]*model.TranscriptionSegment")).
+		Run(func(args mock.Arguments) { saved = args.Get(1).([]*model.TranscriptionSegment) }).
+		Return(nil)
+	transcRepo.On("UpdateStatus", mock.Anything, "transcription-1", "completed", (*string)(nil)).
+		Return(nil)
+
+	service := NewTranscriptionServiceWithWorkspaces(transcRepo, segRepo, whisperSvc, audioSvc, videoRepo, nil, nil, workspaces, "default")
+
+	result, err := service.CreateTranscription(context.Background(), "video-123", "en", CreateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "transcription-1", result.ID)
+
+	// Neither the download nor whisper ran again; the kept whisper output was saved
+	audioSvc.AssertNotCalled(t, "DownloadAudio", mock.Anything, mock.Anything, mock.Anything)
+	whisperSvc.AssertNotCalled(t, "TranscribeAudio", mock.Anything, mock.Anything, mock.Anything)
+	require.Len(t, saved, 1)
+	assert.Equal(t, "Hello", saved[0].Text)
+	require.NotNil(t, saved[0].Speaker)
+	assert.Equal(t, "SPEAKER_00", *saved[0].Speaker)
+
+	// The job finished, so the next run starts afresh
+	list, err := workspaces.List()
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.True(t, list[0].Finished())
+	assert.Equal(t, stepSegments, list[0].LastStep())
+}
+
+func TestTranscriptionService_CreateTranscription_ResumesJobOfDeletedRecord(t *testing.T) {
+	transcRepo := new(mockTranscriptionRepository)
+	segRepo := new(mockSegmentRepository)
+	whisperSvc := new(mockWhisperService)
+	audioSvc := new(mockAudioDownloadService)
+	videoRepo := new(mockVideoRepository)
+
+	// An earlier run saved the segments of transcription-1 but failed before completing it, and
+	// the record was deleted since
+	workspaces := job.NewWorkspaces(t.TempDir())
+	ws, err := workspaces.Open(transcriptionJobID("default", "video-123", "en", "", CreateOptions{}))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(ws.Path("audio.m4a"), []byte("audio"), 0644))
+	require.NoError(t, ws.Complete(stepAudio, "audio.m4a"))
+	require.NoError(t, ws.Complete(stepRecord, "transcription-1"))
+	require.NoError(t, os.WriteFile(ws.Path(whisperOutputFile), []byte(`{"language":"en","segments":[{"start":0,"end":2.5,"text":"Hello"}]}`), 0644))
+	require.NoError(t, ws.Complete(stepWhisper, whisperOutputFile))
+	require.NoError(t, ws.Complete(stepSegments, "transcription-1"))
+
+	videoRepo.On("GetByID", mock.Anything, "video-123").
+		Return(&model.Video{ID: "video-123", URL: "https://youtube.com/watch?v=test", Duration: 60}, nil)
+	transcRepo.On("GetByVideoIDAndLanguage", mock.Anything, "video-123", "en").
+		Return(nil, assert.AnError)
+	transcRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Transcription")).Return(nil)
+	var saved []*model.TranscriptionSegment
+	segRepo.On("CreateBatch", mock.Anything, mock.AnythingOfType("[]*model.TranscriptionSegment")).
+		Run(func(args mock.Arguments) { saved = args.Get(1).([]*model.TranscriptionSegment) }).
+		Return(nil)
+	transcRepo.On("UpdateStatus", mock.Anything, mock.Anything, "completed", (*string)(nil)).
+		Return(nil)
+
+	service := NewTranscriptionServiceWithWorkspaces(transcRepo, segRepo, whisperSvc, audioSvc, videoRepo, nil, nil, workspaces, "default")
+
+	result, err := service.CreateTranscription(context.Background(), "video-123", "en", CreateOptions{})
+	require.NoError(t, err)
+	assert.NotEqual(t, "transcription-1", result.ID)
+
+	// The kept whisper output was saved again for the new record
+	whisperSvc.AssertNotCalled(t, "TranscribeAudio", mock.Anything, mock.Anything, mock.Anything)
+	require.Len(t, saved, 1)
+	assert.Equal(t, result.ID, saved[0].TranscriptionID)
+}
+
+func TestTranscriptionJobID(t *testing.T) {
+	clip := CreateOptions{From: time.Minute, To: 2 * time.Minute}
+
+	assert.Equal(t, "transcribe-default-video-123-en", transcriptionJobID("default", "video-123", "en", "", CreateOptions{}))
+	assert.Equal(t, "transcribe-default-video-123-en-large", transcriptionJobID("default", "video-123", "en", "large", CreateOptions{}))
+	assert.Equal(t, "transcribe-default-video-123-en-small-60000-120000", transcriptionJobID("default", "video-123", "en", "small", clip))
+
+	// Each database workspace has jobs of its own, named after it even when its name has characters
+	// job IDs do not allow
+	assert.Equal(t, "transcribe-client_a-video-123-en", transcriptionJobID("client a", "video-123", "en", "", CreateOptions{}))
+
+	// The job of a whisper service is named after its model; routed services pick one per video
+	service := &transcriptionService{whisperService: NewWhisperServiceWithCmdRunner(nil, "medium")}
+	assert.Equal(t, "medium", service.jobModel())
+	service.router = new(mockModelRouter)
+	assert.Equal(t, "routed", service.jobModel())
+}
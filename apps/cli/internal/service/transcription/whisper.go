@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/janitor"
 	"github.com/Taichi-iskw/yt-lang/internal/model"
 	"github.com/Taichi-iskw/yt-lang/internal/service/common"
 )
@@ -19,18 +22,49 @@ type WhisperService interface {
 	TranscribeAudio(ctx context.Context, audioPath string, language string) (*model.WhisperResult, error)
 }
 
-// WhisperOptions represents configuration for Whisper transcription
+// NextModel in a fallback step stands for the model one size larger than the configured one
+const NextModel = "next"
+
+// whisperModels are the whisper model sizes, smallest first
+var whisperModels = []string{"tiny", "base", "small", "medium", "large"}
+
+// whisperSpeeds are rough seconds whisper takes per second of audio on a CPU, by model size
+var whisperSpeeds = map[string]float64{"tiny": 0.1, "base": 0.2, "small": 0.5, "medium": 1.2, "large": 2.5, "turbo": 0.8}
+
+// DefaultFallback is the fallback ladder used when none is configured: the same model with beam
+// search, then the next larger model with beam search
+var DefaultFallback = []FallbackStep{
+	{Decoding: DecodingOptions{BeamSize: 5, BestOf: 5}},
+	{Model: NextModel, Decoding: DecodingOptions{BeamSize: 5, BestOf: 5}},
+}
+
+// WhisperOptions configures whisper decoding and the fallback ladder
 type WhisperOptions struct {
-	Model       string  // Model size: tiny, base, small, medium, large
-	Language    string  // Language code: auto, en, ja, etc.
-	OutputDir   string  // Directory for output files
-	Temperature float64 // Temperature for sampling
+	Decoding DecodingOptions // Decoding of every run; fallback steps override what they set
+	Fallback []FallbackStep  // Runs tried in order while the output looks pathological; none disables fallback
+}
+
+// DecodingOptions are whisper's decoding parameters. Zero values keep whisper's defaults.
+type DecodingOptions struct {
+	BeamSize                  int     // --beam_size: beams of beam search when sampling at temperature 0
+	BestOf                    int     // --best_of: candidates when sampling at non-zero temperature
+	TemperatureIncrement      float64 // --temperature_increment_on_fallback: temperature added on each of whisper's own retries
+	CompressionRatioThreshold float64 // --compression_ratio_threshold: gzip ratio above which whisper retries a segment
+	LogprobThreshold          float64 // --logprob_threshold: average log probability below which whisper retries a segment
+	NoSpeechThreshold         float64 // --no_speech_threshold: no-speech probability above which a segment is silent
+}
+
+// FallbackStep is one run of the fallback ladder
+type FallbackStep struct {
+	Model    string          // Model to run; empty keeps the configured model, NextModel picks the next larger one
+	Decoding DecodingOptions // Decoding parameters overriding the configured ones
 }
 
 // whisperService implements WhisperService using Whisper CLI
 type whisperService struct {
 	cmdRunner common.CmdRunner
 	model     string // default model to use
+	opts      WhisperOptions
 }
 
 // NewWhisperService creates a new WhisperService with default CmdRunner
@@ -49,6 +83,22 @@ func NewWhisperServiceWithCmdRunner(cmdRunner common.CmdRunner, model string) Wh
 	}
 }
 
+// NewWhisperServiceWithOptions creates a new WhisperService with decoding parameters and a
+// fallback ladder: when the output looks pathological (see QualityIssues), the audio is
+// transcribed again by each step in turn and the output with the fewest issues is kept
+func NewWhisperServiceWithOptions(cmdRunner common.CmdRunner, model string, opts WhisperOptions) WhisperService {
+	return &whisperService{
+		cmdRunner: cmdRunner,
+		model:     model,
+		opts:      opts,
+	}
+}
+
+// Model returns the model the service runs
+func (s *whisperService) Model() string {
+	return s.model
+}
+
 // TranscribeAudio transcribes audio file using Whisper CLI
 func (s *whisperService) TranscribeAudio(ctx context.Context, audioPath string, language string) (*model.WhisperResult, error) {
 	// Validate input
@@ -56,6 +106,53 @@ func (s *whisperService) TranscribeAudio(ctx context.Context, audioPath string,
 		return nil, errors.New(errors.CodeInvalidArg, "audio path is required")
 	}
 
+	result, err := s.run(ctx, audioPath, language, s.model, s.opts.Decoding)
+	if err != nil || len(s.opts.Fallback) == 0 {
+		return result, err
+	}
+	return s.fallback(ctx, audioPath, language, result), nil
+}
+
+// fallback runs the steps of the fallback ladder while no output so far is free of quality
+// issues, and returns the output with the fewest issues (the earliest on ties) with every
+// attempt recorded. Steps repeating an earlier run are skipped; failing steps are recorded.
+func (s *whisperService) fallback(ctx context.Context, audioPath, language string, first *model.WhisperResult) *model.WhisperResult {
+	tried := []FallbackStep{{Model: s.model, Decoding: s.opts.Decoding}}
+	attempts := []model.WhisperAttempt{newWhisperAttempt(tried[0])}
+	attempts[0].Issues = QualityIssues(first)
+	best, chosen := first, 0
+
+	for _, step := range s.opts.Fallback {
+		if len(attempts[chosen].Issues) == 0 {
+			break
+		}
+		run := FallbackStep{Model: resolveModel(step.Model, s.model), Decoding: s.opts.Decoding.override(step.Decoding)}
+		if containsStep(tried, run) {
+			continue
+		}
+		tried = append(tried, run)
+
+		attempt := newWhisperAttempt(run)
+		result, err := s.run(ctx, audioPath, language, run.Model, run.Decoding)
+		if err != nil {
+			attempt.Error = err.Error()
+			attempts = append(attempts, attempt)
+			continue
+		}
+		attempt.Issues = QualityIssues(result)
+		attempts = append(attempts, attempt)
+		if len(attempt.Issues) < len(attempts[chosen].Issues) {
+			best, chosen = result, len(attempts)-1
+		}
+	}
+
+	attempts[chosen].Chosen = true
+	best.Attempts = attempts
+	return best
+}
+
+// run transcribes the audio once with the given model and decoding parameters
+func (s *whisperService) run(ctx context.Context, audioPath, language, whisperModel string, decoding DecodingOptions) (*model.WhisperResult, error) {
 	// Create temp directory for output
 	var tempDir string
 	if ctxTempDir := ctx.Value("tempDir"); ctxTempDir != nil {
@@ -65,14 +162,14 @@ func (s *whisperService) TranscribeAudio(ctx context.Context, audioPath string,
 	shouldCleanup := false
 	if tempDir == "" {
 		var err error
-		tempDir, err = os.MkdirTemp("", "yt-lang-whisper-*")
+		tempDir, err = janitor.MkdirTemp("yt-lang-whisper-*")
 		if err != nil {
 			return nil, errors.Wrap(err, errors.CodeInternal, "failed to create temp directory")
 		}
 		shouldCleanup = true
 		defer func() {
 			if shouldCleanup {
-				os.RemoveAll(tempDir)
+				janitor.RemoveTemp(tempDir)
 			}
 		}()
 	}
@@ -80,11 +177,12 @@ func (s *whisperService) TranscribeAudio(ctx context.Context, audioPath string,
 	// Prepare whisper command arguments
 	args := []string{
 		audioPath,
-		"--model", s.model,
+		"--model", whisperModel,
 		"--output_format", "json",
 		"--output_dir", tempDir,
 		"--temperature", "0",
 	}
+	args = append(args, decoding.args()...)
 
 	// Add language parameter only if not auto-detection
 	if language != "" && language != "auto" {
@@ -92,9 +190,9 @@ func (s *whisperService) TranscribeAudio(ctx context.Context, audioPath string,
 	}
 
 	// Execute whisper command
-	_, err := s.cmdRunner.Run(ctx, "whisper", args...)
+	_, err := s.cmdRunner.Run(ctx, common.ToolWhisper, args...)
 	if err != nil {
-		return nil, errors.Wrap(err, errors.CodeExternal, s.formatWhisperError(err, audioPath, language))
+		return nil, errors.Wrap(err, errors.CodeExternal, formatWhisperError(err, audioPath, language, whisperModel))
 	}
 
 	// Read the output JSON file
@@ -112,12 +210,115 @@ func (s *whisperService) TranscribeAudio(ctx context.Context, audioPath string,
 	if err := json.Unmarshal(jsonData, &result); err != nil {
 		return nil, errors.Wrap(err, errors.CodeInternal, "failed to parse whisper output")
 	}
+	result.Raw = jsonData
 
 	return &result, nil
 }
 
+// args returns the whisper flags of the parameters that are set
+func (d DecodingOptions) args() []string {
+	var args []string
+	if d.BeamSize > 0 {
+		args = append(args, "--beam_size", strconv.Itoa(d.BeamSize))
+	}
+	if d.BestOf > 0 {
+		args = append(args, "--best_of", strconv.Itoa(d.BestOf))
+	}
+	for _, flag := range []struct {
+		name  string
+		value float64
+	}{
+		{"--temperature_increment_on_fallback", d.TemperatureIncrement},
+		{"--compression_ratio_threshold", d.CompressionRatioThreshold},
+		{"--logprob_threshold", d.LogprobThreshold},
+		{"--no_speech_threshold", d.NoSpeechThreshold},
+	} {
+		if flag.value != 0 {
+			args = append(args, flag.name, strconv.FormatFloat(flag.value, 'g', -1, 64))
+		}
+	}
+	return args
+}
+
+// override returns d with the parameters set in step replacing its own
+func (d DecodingOptions) override(step DecodingOptions) DecodingOptions {
+	if step.BeamSize != 0 {
+		d.BeamSize = step.BeamSize
+	}
+	if step.BestOf != 0 {
+		d.BestOf = step.BestOf
+	}
+	if step.TemperatureIncrement != 0 {
+		d.TemperatureIncrement = step.TemperatureIncrement
+	}
+	if step.CompressionRatioThreshold != 0 {
+		d.CompressionRatioThreshold = step.CompressionRatioThreshold
+	}
+	if step.LogprobThreshold != 0 {
+		d.LogprobThreshold = step.LogprobThreshold
+	}
+	if step.NoSpeechThreshold != 0 {
+		d.NoSpeechThreshold = step.NoSpeechThreshold
+	}
+	return d
+}
+
+// resolveModel returns the model a fallback step runs
+func resolveModel(step, configured string) string {
+	switch step {
+	case "":
+		return configured
+	case NextModel:
+		return NextWhisperModel(configured)
+	default:
+		return step
+	}
+}
+
+// NextWhisperModel returns the model one size larger than whisperModel, keeping the English-only
+// ".en" variants (there is no large.en). Models of unknown size, and large, are returned as is.
+func NextWhisperModel(whisperModel string) string {
+	size, english := strings.CutSuffix(whisperModel, ".en")
+	for i, m := range whisperModels[:len(whisperModels)-1] {
+		if m != size {
+			continue
+		}
+		next := whisperModels[i+1]
+		if english && next != "large" {
+			next += ".en"
+		}
+		return next
+	}
+	return whisperModel
+}
+
+// EstimateWhisperTime roughly estimates how long whisperModel takes to transcribe audio on a
+// CPU, without fallback runs; 0 when the model size or the duration is unknown
+func EstimateWhisperTime(whisperModel string, audio time.Duration) time.Duration {
+	size, _ := strings.CutSuffix(whisperModel, ".en")
+	if strings.HasPrefix(size, "large-") {
+		size = "large" // large-v2, large-v3
+	}
+	return time.Duration(float64(audio) * whisperSpeeds[size]).Round(time.Second)
+}
+
+// containsStep reports whether steps holds step
+func containsStep(steps []FallbackStep, step FallbackStep) bool {
+	for _, s := range steps {
+		if s == step {
+			return true
+		}
+	}
+	return false
+}
+
+// newWhisperAttempt returns the record of a run of step
+func newWhisperAttempt(step FallbackStep) model.WhisperAttempt {
+	return model.WhisperAttempt{Model: step.Model, BeamSize: step.Decoding.BeamSize, BestOf: step.Decoding.BestOf}
+}
+
 // formatWhisperError provides user-friendly error messages for Whisper failures
-func (s *whisperService) formatWhisperError(err error, audioPath, language string) string {
+func formatWhisperError(err error, audioPath, language, whisperModel string) string {
 	errMsg := err.Error()
 
 	// Check for common Whisper error patterns
@@ -129,13 +330,13 @@ func (s *whisperService) formatWhisperError(err error, audioPath, language strin
 	case strings.Contains(errMsg, "CUDA"):
 		return "GPU/CUDA error detected. Whisper will fallback to CPU processing (this may be slower)"
 	case strings.Contains(errMsg, "not enough memory") || strings.Contains(errMsg, "OutOfMemoryError"):
-		return fmt.Sprintf("insufficient memory for model '%s'. Try using a smaller model (tiny, base, small)", s.model)
+		return fmt.Sprintf("insufficient memory for model '%s'. Try using a smaller model (tiny, base, small)", whisperModel)
 	case strings.Contains(errMsg, "Invalid language"):
 		return fmt.Sprintf("unsupported language '%s'. Use language codes like 'en', 'ja', 'es' or 'auto'", language)
 	case strings.Contains(errMsg, "Invalid model"):
-		return fmt.Sprintf("unsupported model '%s'. Available models: tiny, base, small, medium, large", s.model)
+		return fmt.Sprintf("unsupported model '%s'. Available models: tiny, base, small, medium, large", whisperModel)
 	case strings.Contains(errMsg, "Could not load model"):
-		return fmt.Sprintf("failed to load Whisper model '%s'. The model may need to be downloaded on first use", s.model)
+		return fmt.Sprintf("failed to load Whisper model '%s'. The model may need to be downloaded on first use", whisperModel)
 	case strings.Contains(errMsg, "File not found") || strings.Contains(errMsg, "No such file"):
 		return fmt.Sprintf("audio file not found: %s", filepath.Base(audioPath))
 	case strings.Contains(errMsg, "Unsupported format") || strings.Contains(errMsg, "format not supported"):
@@ -143,6 +344,6 @@ func (s *whisperService) formatWhisperError(err error, audioPath, language strin
 	case strings.Contains(errMsg, "exit status 2"):
 		return fmt.Sprintf("Whisper processing failed. This may be due to corrupted audio or unsupported format (%s)", filepath.Ext(audioPath))
 	default:
-		return fmt.Sprintf("transcription failed with model '%s' - %s", s.model, errMsg)
+		return fmt.Sprintf("transcription failed with model '%s' - %s", whisperModel, errMsg)
 	}
 }
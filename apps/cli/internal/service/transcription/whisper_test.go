@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/Taichi-iskw/yt-lang/internal/model"
 	"github.com/Taichi-iskw/yt-lang/internal/service/common"
@@ -169,3 +170,101 @@ func TestWhisperService_TranscribeAudio(t *testing.T) {
 		})
 	}
 }
+
+func TestWhisperService_FallbackLadder(t *testing.T) {
+	looping := model.WhisperResult{Language: "en"}
+	for i := 0; i < 5; i++ {
+		looping.Segments = append(looping.Segments, model.WhisperSegment{Text: " Thank you.", Confidence: -0.3})
+	}
+	clean := model.WhisperResult{Language: "en", Segments: []model.WhisperSegment{
+		{Text: " Welcome back to the channel.", Confidence: -0.3},
+		{Text: " Thank you.", Confidence: -0.2},
+	}}
+
+	baseArgs := func(tempDir, whisperModel string, extra ...string) []string {
+		args := []string{"/tmp/test-audio.wav", "--model", whisperModel, "--output_format", "json", "--output_dir", tempDir, "--temperature", "0"}
+		return append(args, extra...)
+	}
+	writes := func(tempDir string, result model.WhisperResult) func(mock.Arguments) {
+		return func(mock.Arguments) {
+			data, _ := json.Marshal(result)
+			os.WriteFile(filepath.Join(tempDir, "test-audio.json"), data, 0644)
+		}
+	}
+
+	t.Run("larger beam fixes the loop", func(t *testing.T) {
+		tempDir := t.TempDir()
+		runner := new(mockWhisperCmdRunner)
+		runner.On("Run", mock.Anything, "whisper", baseArgs(tempDir, "base", "--no_speech_threshold", "0.5", "--language", "en")).
+			Run(writes(tempDir, looping)).Return([]byte{}, nil).Once()
+		runner.On("Run", mock.Anything, "whisper", baseArgs(tempDir, "base", "--beam_size", "5", "--best_of", "5", "--no_speech_threshold", "0.5", "--language", "en")).
+			Run(writes(tempDir, clean)).Return([]byte{}, nil).Once()
+
+		service := NewWhisperServiceWithOptions(runner, "base", WhisperOptions{
+			Decoding: DecodingOptions{NoSpeechThreshold: 0.5},
+			Fallback: DefaultFallback,
+		})
+		ctx := context.WithValue(context.Background(), "tempDir", tempDir)
+		result, err := service.TranscribeAudio(ctx, "/tmp/test-audio.wav", "en")
+		require.NoError(t, err)
+
+		assert.Len(t, result.Segments, 2)
+		require.Len(t, result.Attempts, 2)
+		assert.Equal(t, "base", result.Attempts[0].Model)
+		assert.NotEmpty(t, result.Attempts[0].Issues)
+		assert.False(t, result.Attempts[0].Chosen)
+		assert.Equal(t, model.WhisperAttempt{Model: "base", BeamSize: 5, BestOf: 5, Chosen: true}, result.Attempts[1])
+		runner.AssertExpectations(t)
+	})
+
+	t.Run("keeps the best run when every run has issues", func(t *testing.T) {
+		tempDir := t.TempDir()
+		runner := new(mockWhisperCmdRunner)
+		runner.On("Run", mock.Anything, "whisper", baseArgs(tempDir, "small")).
+			Run(writes(tempDir, looping)).Return([]byte{}, nil).Once()
+		runner.On("Run", mock.Anything, "whisper", baseArgs(tempDir, "small", "--beam_size", "5", "--best_of", "5")).
+			Return(nil, assert.AnError).Once()
+		runner.On("Run", mock.Anything, "whisper", baseArgs(tempDir, "medium", "--beam_size", "5", "--best_of", "5")).
+			Run(writes(tempDir, looping)).Return([]byte{}, nil).Once()
+
+		service := NewWhisperServiceWithOptions(runner, "small", WhisperOptions{Fallback: DefaultFallback})
+		ctx := context.WithValue(context.Background(), "tempDir", tempDir)
+		result, err := service.TranscribeAudio(ctx, "/tmp/test-audio.wav", "auto")
+		require.NoError(t, err)
+
+		require.Len(t, result.Attempts, 3)
+		assert.True(t, result.Attempts[0].Chosen, "ties keep the earliest run")
+		assert.NotEmpty(t, result.Attempts[1].Error)
+		assert.Equal(t, "medium", result.Attempts[2].Model)
+		runner.AssertExpectations(t)
+	})
+
+	t.Run("clean output runs once", func(t *testing.T) {
+		tempDir := t.TempDir()
+		runner := new(mockWhisperCmdRunner)
+		runner.On("Run", mock.Anything, "whisper", baseArgs(tempDir, "large")).
+			Run(writes(tempDir, clean)).Return([]byte{}, nil).Once()
+
+		service := NewWhisperServiceWithOptions(runner, "large", WhisperOptions{Fallback: DefaultFallback})
+		ctx := context.WithValue(context.Background(), "tempDir", tempDir)
+		result, err := service.TranscribeAudio(ctx, "/tmp/test-audio.wav", "auto")
+		require.NoError(t, err)
+		assert.Equal(t, []model.WhisperAttempt{{Model: "large", Chosen: true}}, result.Attempts)
+		runner.AssertExpectations(t)
+	})
+}
+
+func TestNextWhisperModel(t *testing.T) {
+	assert.Equal(t, "base", NextWhisperModel("tiny"))
+	assert.Equal(t, "medium.en", NextWhisperModel("small.en"))
+	assert.Equal(t, "large", NextWhisperModel("medium.en"))
+	assert.Equal(t, "large", NextWhisperModel("large"))
+	assert.Equal(t, "turbo", NextWhisperModel("turbo"))
+}
+
+func TestEstimateWhisperTime(t *testing.T) {
+	assert.Equal(t, 2*time.Minute, EstimateWhisperTime("base", 10*time.Minute))
+	assert.Equal(t, 25*time.Minute, EstimateWhisperTime("large-v3", 10*time.Minute))
+	assert.Equal(t, time.Minute, EstimateWhisperTime("tiny.en", 10*time.Minute))
+	assert.Zero(t, EstimateWhisperTime("custom", 10*time.Minute))
+}
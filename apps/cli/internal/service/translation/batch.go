@@ -12,51 +12,63 @@ import (
 
 // SegmentBatch represents a batch of segments to be translated together
 type SegmentBatch struct {
-	Segments     []*model.TranscriptionSegment
-	CombinedText string
-	Separator    string
+	Segments        []*model.TranscriptionSegment
+	CombinedText    string
+	Separator       string
+	EstimatedTokens int // Estimated input tokens including separators
 }
 
 // TranslationSegment represents a translated segment
 type TranslationSegment struct {
 	TranscriptionSegmentID string
 	SegmentIndex           int
+	StartTime              string // INTERVAL text of the original segment
+	EndTime                string // INTERVAL text of the original segment
 	Text                   string
 	TranslatedText         string
+	SplitStrategy          string // How the translation of the segment's batch was split (Split* constants)
+	Provider               string // Provider the scheduler sent the segment's batch to; empty without one
+	Chapter                string // Time range of the chapter the segment was translated in; empty unless translated per chapter
 }
 
+// Strategies splitting a batch translation back into segments, in the order they are tried
+const (
+	SplitSeparator  = "separator"            // The separator came back unchanged
+	SplitNormalized = "normalized_separator" // The separator came back full-width or spaced
+	SplitSentences  = "sentences"            // Separators were lost; sentences were counted instead
+	SplitIndividual = "individual"           // Each segment was translated on its own
+)
+
 // BatchProcessor handles batching and splitting of translation segments
 type BatchProcessor interface {
-	CreateBatches(segments []*model.TranscriptionSegment, maxTokens int) ([]SegmentBatch, error)
+	CreateBatches(segments []*model.TranscriptionSegment, sourceLang string, maxTokens int) ([]SegmentBatch, error)
 	TranslateBatchWithFallback(batch SegmentBatch, plamoService PlamoService, ctx context.Context, sourceLang, targetLang string) ([]*TranslationSegment, error)
 }
 
 // batchProcessor implements BatchProcessor
 type batchProcessor struct {
 	separators []string
+	estimator  *TokenEstimator
 }
 
-// NewBatchProcessor creates a new batch processor
+// NewBatchProcessor creates a new batch processor with the default token ratios
 func NewBatchProcessor() BatchProcessor {
-	return &batchProcessor{
-		separators: []string{"__", "<<<SEP>>>"},
-	}
+	return NewBatchProcessorWithEstimator(NewTokenEstimator(nil))
 }
 
-// estimateTokenCount estimates token count for text based on language
-func estimateTokenCount(text string, language string) int {
-	switch language {
-	case "en":
-		return len(text) / 4 // English: ~4 chars ≈ 1 token
-	case "ja":
-		return len([]rune(text)) / 2 // Japanese: ~2 chars ≈ 1 token
-	default:
-		return len([]rune(text)) / 2 // Conservative estimate
+// NewBatchProcessorWithEstimator creates a new batch processor using the given token estimator
+func NewBatchProcessorWithEstimator(estimator *TokenEstimator) BatchProcessor {
+	return &batchProcessor{
+		separators: []string{"__", "<<<SEP>>>"},
+		estimator:  estimator,
 	}
 }
 
-// CreateBatches creates batches of segments that fit within token limits
-func (bp *batchProcessor) CreateBatches(segments []*model.TranscriptionSegment, maxTokens int) ([]SegmentBatch, error) {
+// CreateBatches creates batches of segments that fit within token limits, estimating tokens
+// for the source language. Separator tokens are counted with the longest fallback separator
+// so a batch stays within the limit whichever separator is used. A single segment larger
+// than maxTokens still gets a batch of its own.
+func (bp *batchProcessor) CreateBatches(segments []*model.TranscriptionSegment, sourceLang string, maxTokens int) ([]SegmentBatch, error) {
 	if maxTokens <= 0 {
 		return nil, errors.New("maxTokens must be positive")
 	}
@@ -74,13 +86,15 @@ func (bp *batchProcessor) CreateBatches(segments []*model.TranscriptionSegment,
 		Separator: separator,
 	}
 	currentTokens := 0
+	separatorTokens := bp.separatorTokens(sourceLang)
 
 	for _, segment := range segments {
-		segmentTokens := estimateTokenCount(segment.Text, "en") // Default to English
+		segmentTokens := bp.estimator.Estimate(segment.Text, sourceLang)
 
 		// If adding this segment would exceed limit, start new batch
-		if currentTokens+segmentTokens > maxTokens && len(currentBatch.Segments) > 0 {
+		if len(currentBatch.Segments) > 0 && currentTokens+separatorTokens+segmentTokens > maxTokens {
 			// Finalize current batch
+			currentBatch.EstimatedTokens = currentTokens
 			bp.finalizeBatch(&currentBatch)
 			batches = append(batches, currentBatch)
 
@@ -91,6 +105,9 @@ func (bp *batchProcessor) CreateBatches(segments []*model.TranscriptionSegment,
 			}
 			currentTokens = segmentTokens
 		} else {
+			if len(currentBatch.Segments) > 0 {
+				currentTokens += separatorTokens
+			}
 			currentBatch.Segments = append(currentBatch.Segments, segment)
 			currentTokens += segmentTokens
 		}
@@ -98,6 +115,7 @@ func (bp *batchProcessor) CreateBatches(segments []*model.TranscriptionSegment,
 
 	// Add final batch if it has segments
 	if len(currentBatch.Segments) > 0 {
+		currentBatch.EstimatedTokens = currentTokens
 		bp.finalizeBatch(&currentBatch)
 		batches = append(batches, currentBatch)
 	}
@@ -105,6 +123,15 @@ func (bp *batchProcessor) CreateBatches(segments []*model.TranscriptionSegment,
 	return batches, nil
 }
 
+// separatorTokens returns the token cost of the longest separator between two segments
+func (bp *batchProcessor) separatorTokens(sourceLang string) int {
+	tokens := 0
+	for _, separator := range bp.separators {
+		tokens = max(tokens, bp.estimator.Estimate(separator, sourceLang))
+	}
+	return tokens
+}
+
 // finalizeBatch sets the CombinedText for a batch
 func (bp *batchProcessor) finalizeBatch(batch *SegmentBatch) {
 	var texts []string
@@ -114,29 +141,45 @@ func (bp *batchProcessor) finalizeBatch(batch *SegmentBatch) {
 	batch.CombinedText = strings.Join(texts, batch.Separator)
 }
 
-// TranslateBatchWithFallback implements the three-stage fallback strategy
+// TranslateBatchWithFallback implements the three-stage fallback strategy. Before falling back
+// to individual translation, the responses of the separator stages are aligned by sentence
+// count, which saves one request per segment when PLaMo only dropped the separators.
 func (bp *batchProcessor) TranslateBatchWithFallback(batch SegmentBatch, plamoService PlamoService, ctx context.Context, sourceLang, targetLang string) ([]*TranslationSegment, error) {
+	var responses []string
+
 	// Stage 1: Try with "__" separator
 	fmt.Println("First Try: Translate with __ separator")
-	result, err := bp.tryTranslateWithSeparator(batch.Segments, "__", plamoService, ctx, sourceLang, targetLang)
+	result, response, err := bp.tryTranslateWithSeparator(batch.Segments, "__", plamoService, ctx, sourceLang, targetLang)
 	if err == nil {
 		return result, nil
 	}
+	responses = append(responses, response)
 
 	// Stage 2: Try with "<<<SEP>>>" separator
 	fmt.Println("Second Try: Translate with <<<SEP>>> separator")
-	result, err = bp.tryTranslateWithSeparator(batch.Segments, "<<<SEP>>>", plamoService, ctx, sourceLang, targetLang)
+	result, response, err = bp.tryTranslateWithSeparator(batch.Segments, "<<<SEP>>>", plamoService, ctx, sourceLang, targetLang)
 	if err == nil {
 		return result, nil
 	}
+	responses = append(responses, response)
+
+	// Sentence alignment of the responses already received, latest first
+	for i := len(responses) - 1; i >= 0; i-- {
+		if result, err := bp.alignBySentences(batch.Segments, responses[i]); err == nil {
+			fmt.Println("Aligned the translation by sentence count")
+			return result, nil
+		}
+	}
 
 	// Stage 3: Individual translation fallback
 	fmt.Println("Third Try: Translate with individual translation fallback")
 	return bp.translateIndividually(batch.Segments, plamoService, ctx, sourceLang, targetLang)
 }
 
-// tryTranslateWithSeparator tries to translate segments using a specific separator
-func (bp *batchProcessor) tryTranslateWithSeparator(segments []*model.TranscriptionSegment, separator string, plamoService PlamoService, ctx context.Context, sourceLang, targetLang string) ([]*TranslationSegment, error) {
+// tryTranslateWithSeparator tries to translate segments using a specific separator. The PLaMo
+// response is returned with split errors so other strategies can use it; it is empty when the
+// request itself failed.
+func (bp *batchProcessor) tryTranslateWithSeparator(segments []*model.TranscriptionSegment, separator string, plamoService PlamoService, ctx context.Context, sourceLang, targetLang string) ([]*TranslationSegment, string, error) {
 	// Create batch with specified separator
 	batch := SegmentBatch{
 		Segments:  segments,
@@ -147,36 +190,49 @@ func (bp *batchProcessor) tryTranslateWithSeparator(segments []*model.Transcript
 	// Translate the combined text
 	translatedText, err := plamoService.Translate(ctx, batch.CombinedText, sourceLang, targetLang)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	// Split and validate
-	return bp.splitAndValidateTranslation(batch, translatedText)
+	result, err := bp.splitAndValidateTranslation(batch, translatedText)
+	return result, translatedText, err
 }
 
-// splitAndValidateTranslation splits translated text and validates segment count
+// splitAndValidateTranslation splits translated text and validates segment count. When the
+// separator did not come back unchanged, its full-width and spaced variants are tried.
 func (bp *batchProcessor) splitAndValidateTranslation(batch SegmentBatch, translation string) ([]*TranslationSegment, error) {
 	// Split translation by separator
 	translatedTexts := strings.Split(translation, batch.Separator)
+	strategy := SplitSeparator
 
 	// Check if the number of segments matches
 	if len(translatedTexts) != len(batch.Segments) {
-		return nil, errors.New("segment count mismatch: expected " + strconv.Itoa(len(batch.Segments)) + " but got " + strconv.Itoa(len(translatedTexts)))
+		normalized := splitNormalized(translation, batch.Separator)
+		if len(normalized) != len(batch.Segments) {
+			return nil, errors.New("segment count mismatch: expected " + strconv.Itoa(len(batch.Segments)) + " but got " + strconv.Itoa(len(translatedTexts)))
+		}
+		translatedTexts = normalized
+		strategy = SplitNormalized
 	}
 
-	// Create translation segments
+	return newTranslationSegments(batch.Segments, translatedTexts, strategy), nil
+}
+
+// newTranslationSegments pairs segments with their translated texts
+func newTranslationSegments(segments []*model.TranscriptionSegment, translatedTexts []string, strategy string) []*TranslationSegment {
 	var results []*TranslationSegment
-	for i, segment := range batch.Segments {
-		result := &TranslationSegment{
+	for i, segment := range segments {
+		results = append(results, &TranslationSegment{
 			TranscriptionSegmentID: segment.ID,
 			SegmentIndex:           segment.SegmentIndex,
+			StartTime:              segment.StartTime,
+			EndTime:                segment.EndTime,
 			Text:                   segment.Text,
 			TranslatedText:         strings.TrimSpace(translatedTexts[i]),
-		}
-		results = append(results, result)
+			SplitStrategy:          strategy,
+		})
 	}
-
-	return results, nil
+	return results
 }
 
 // translateIndividually translates each segment individually as final fallback
@@ -194,8 +250,11 @@ func (bp *batchProcessor) translateIndividually(segments []*model.TranscriptionS
 		result := &TranslationSegment{
 			TranscriptionSegmentID: segment.ID,
 			SegmentIndex:           segment.SegmentIndex,
+			StartTime:              segment.StartTime,
+			EndTime:                segment.EndTime,
 			Text:                   segment.Text,
 			TranslatedText:         strings.TrimSpace(translatedText),
+			SplitStrategy:          SplitIndividual,
 		}
 		results = append(results, result)
 	}
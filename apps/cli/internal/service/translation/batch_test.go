@@ -1,6 +1,7 @@
 package translation
 
 import (
+	"context"
 	"testing"
 
 	"github.com/Taichi-iskw/yt-lang/internal/model"
@@ -58,7 +59,7 @@ func TestBatchProcessor_CreateBatches(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			processor := NewBatchProcessor()
 
-			batches, err := processor.CreateBatches(tt.segments, tt.maxTokens)
+			batches, err := processor.CreateBatches(tt.segments, "en", tt.maxTokens)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -80,10 +81,12 @@ func TestBatchProcessor_CreateBatches(t *testing.T) {
 
 func TestBatchProcessor_SplitTranslation(t *testing.T) {
 	tests := []struct {
-		name        string
-		batch       SegmentBatch
-		translation string
-		wantErr     bool
+		name         string
+		batch        SegmentBatch
+		translation  string
+		wantErr      bool
+		wantStrategy string
+		wantTexts    []string
 	}{
 		{
 			name: "successful split with underscore separator",
@@ -95,8 +98,38 @@ func TestBatchProcessor_SplitTranslation(t *testing.T) {
 				Separator:    "__",
 				CombinedText: "Hello__World",
 			},
-			translation: "こんにちは__世界",
-			wantErr:     false,
+			translation:  "こんにちは__世界",
+			wantErr:      false,
+			wantStrategy: SplitSeparator,
+		},
+		{
+			name: "full-width and spaced separators",
+			batch: SegmentBatch{
+				Segments: []*model.TranscriptionSegment{
+					{ID: "1", Text: "Hello"},
+					{ID: "2", Text: "World"},
+					{ID: "3", Text: "Again"},
+				},
+				Separator:    "__",
+				CombinedText: "Hello__World__Again",
+			},
+			translation:  "こんにちは＿＿世界 _ _ もう一度",
+			wantStrategy: SplitNormalized,
+			wantTexts:    []string{"こんにちは", "世界", "もう一度"},
+		},
+		{
+			name: "mutated long separator moved to the end",
+			batch: SegmentBatch{
+				Segments: []*model.TranscriptionSegment{
+					{ID: "1", Text: "Hello"},
+					{ID: "2", Text: "World"},
+				},
+				Separator:    "<<<SEP>>>",
+				CombinedText: "Hello<<<SEP>>>World",
+			},
+			translation:  "こんにちは ＜＜＜sep＞＞＞ 世界<<< SEP >>>",
+			wantStrategy: SplitNormalized,
+			wantTexts:    []string{"こんにちは", "世界"},
 		},
 		{
 			name: "separator count mismatch",
@@ -128,6 +161,135 @@ func TestBatchProcessor_SplitTranslation(t *testing.T) {
 
 			require.NoError(t, err)
 			assert.Len(t, segments, len(tt.batch.Segments))
+			for i, segment := range segments {
+				assert.Equal(t, tt.wantStrategy, segment.SplitStrategy)
+				if tt.wantTexts != nil {
+					assert.Equal(t, tt.wantTexts[i], segment.TranslatedText)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitSentences(t *testing.T) {
+	assert.Equal(t, []string{"It costs 3.5 dollars. ", "Really?! ", "\"Yes.\" ", "OK"}, splitSentences(`It costs 3.5 dollars. Really?! "Yes." OK`))
+	assert.Equal(t, []string{"今日は晴れ。", "「本当？」", "はい"}, splitSentences("今日は晴れ。「本当？」はい"))
+	assert.Empty(t, splitSentences("  "))
+}
+
+// responsePlamoService answers Translate calls with the scripted responses in order
+type responsePlamoService struct {
+	PlamoService
+	responses []string
+	calls     int
+}
+
+func (s *responsePlamoService) Translate(ctx context.Context, text string, fromLang, toLang string) (string, error) {
+	s.calls++
+	if len(s.responses) == 0 {
+		return "individual " + text, nil
+	}
+	response := s.responses[0]
+	s.responses = s.responses[1:]
+	return response, nil
+}
+
+func TestBatchProcessor_TranslateBatchWithFallback_Strategies(t *testing.T) {
+	segments := []*model.TranscriptionSegment{
+		{ID: "1", Text: "Hello there. How are you?"},
+		{ID: "2", Text: "Fine"},
+	}
+
+	tests := []struct {
+		name         string
+		responses    []string
+		wantStrategy string
+		wantCalls    int
+		wantTexts    []string
+	}{
+		{
+			name:         "separators dropped in both attempts",
+			responses:    []string{"やあ。元気？大丈夫", "やあ。調子は？ 大丈夫"},
+			wantStrategy: SplitSentences,
+			wantCalls:    2,
+			wantTexts:    []string{"やあ。調子は？", "大丈夫"}, // From the latest response
+		},
+		{
+			name:         "sentence counts differ",
+			responses:    []string{"やあ元気？大丈夫", "やあ元気？大丈夫"},
+			wantStrategy: SplitIndividual,
+			wantCalls:    4,
+			wantTexts:    []string{"individual Hello there. How are you?", "individual Fine"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plamo := &responsePlamoService{responses: tt.responses}
+			result, err := NewBatchProcessor().TranslateBatchWithFallback(SegmentBatch{Segments: segments}, plamo, context.Background(), "en", "ja")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantCalls, plamo.calls)
+			require.Len(t, result, len(segments))
+			for i, segment := range result {
+				assert.Equal(t, tt.wantStrategy, segment.SplitStrategy)
+				assert.Equal(t, tt.wantTexts[i], segment.TranslatedText)
+			}
+		})
+	}
+}
+
+func TestBatchProcessor_CreateBatches_CJKWithinLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		text     string
+	}{
+		{name: "japanese", language: "ja", text: "今日はとても良い天気ですね。散歩に行きましょう。"},
+		{name: "korean", language: "ko", text: "오늘은 날씨가 정말 좋네요. 산책하러 갑시다."},
+		{name: "japanese labelled english", language: "en", text: "今日はとても良い天気ですね。散歩に行きましょう。"},
+	}
+
+	const maxTokens = 200
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var segments []*model.TranscriptionSegment
+			for i := 0; i < 100; i++ {
+				segments = append(segments, &model.TranscriptionSegment{SegmentIndex: i, Text: tt.text})
+			}
+
+			processor := NewBatchProcessor()
+			batches, err := processor.CreateBatches(segments, tt.language, maxTokens)
+			require.NoError(t, err)
+			require.Greater(t, len(batches), 1)
+
+			estimator := NewTokenEstimator(nil)
+			total := 0
+			for _, batch := range batches {
+				total += len(batch.Segments)
+				assert.LessOrEqual(t, batch.EstimatedTokens, maxTokens)
+
+				var combined string
+				for i, seg := range batch.Segments {
+					if i > 0 {
+						combined += "<<<SEP>>>"
+					}
+					combined += seg.Text
+				}
+				// Re-estimate with the longest fallback separator
+				assert.LessOrEqual(t, estimator.Estimate(combined, tt.language), maxTokens)
+
+				// Worst case of one token per CJK character, whatever the language label
+				cjk := 0
+				for _, r := range combined {
+					if isCJK(r) {
+						cjk++
+					}
+				}
+				assert.LessOrEqual(t, cjk, maxTokens)
+			}
+			assert.Equal(t, len(segments), total)
 		})
 	}
 }
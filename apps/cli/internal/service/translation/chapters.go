@@ -0,0 +1,168 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
+)
+
+// DefaultChapterLength is the length of the chapters of transcriptions whose video has no
+// YouTube chapters (translation create --chapter-length)
+const DefaultChapterLength = 10 * time.Minute
+
+// Chapter is a part of a transcription translated on its own by translation create
+// --per-chapter, so PLaMo batches never mix two chapters and a failed chapter can be retried alone
+type Chapter struct {
+	Title    string        `json:"title,omitempty"` // Empty for time-based chapters
+	Start    time.Duration `json:"start"`
+	End      time.Duration `json:"end"`
+	Segments int           `json:"segments"` // Segments starting in the chapter
+}
+
+// Range returns the chapter's time range as START-END interval text, e.g. 00:10:00.000-00:20:00.000
+func (c *Chapter) Range() string {
+	return timecode.FormatInterval(c.Start) + "-" + timecode.FormatInterval(c.End)
+}
+
+// ParseChapterRange parses the time range of a chapter written by Range
+func ParseChapterRange(text string) (*Chapter, error) {
+	startText, endText, ok := strings.Cut(text, "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid chapter range %q", text)
+	}
+	start, err := timecode.ParseInterval(startText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid chapter range %q: %w", text, err)
+	}
+	end, err := timecode.ParseInterval(endText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid chapter range %q: %w", text, err)
+	}
+	return &Chapter{Start: start, End: end}, nil
+}
+
+// contains reports whether a segment starting at start belongs to the chapter
+func (c *Chapter) contains(start time.Duration) bool {
+	return start >= c.Start && start < c.End
+}
+
+// PlanChapters splits a transcription into the chapters translation create --per-chapter
+// translates: the YouTube chapters of its video when video add recorded them, otherwise
+// windows of length. Chapters without segments are left out.
+func (s *translationService) PlanChapters(ctx context.Context, transcriptionID string, length time.Duration) ([]*Chapter, error) {
+	transcription, err := s.transcriptionRepo.Get(ctx, transcriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transcription: %w", err)
+	}
+	segments, err := s.transcriptionRepo.GetSegments(ctx, transcriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) == 0 {
+		return nil, errors.New("no segments found")
+	}
+	videoChapters, err := s.transcriptionRepo.GetVideoChapters(ctx, transcription.VideoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get video chapters: %w", err)
+	}
+	return splitChapters(segments, videoChapters, length)
+}
+
+// splitChapters splits segments into the video's chapters or, without any, into windows of
+// length. The chapters cover the whole transcription without gaps: the first starts at 0 and
+// each ends where the next starts, so every segment falls in exactly one.
+func splitChapters(segments []*model.TranscriptionSegment, videoChapters []model.VideoChapter, length time.Duration) ([]*Chapter, error) {
+	starts := make([]time.Duration, len(segments))
+	var end time.Duration
+	for i, segment := range segments {
+		start, err := timecode.ParseInterval(segment.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start of segment %d: %w", segment.SegmentIndex, err)
+		}
+		starts[i] = start
+		if segmentEnd, err := timecode.ParseInterval(segment.EndTime); err == nil {
+			end = max(end, segmentEnd)
+		}
+		end = max(end, start+time.Millisecond)
+	}
+
+	var chapters []*Chapter
+	if len(videoChapters) > 0 {
+		sorted := append([]model.VideoChapter(nil), videoChapters...)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].StartTime < sorted[j].StartTime })
+		for i, vc := range sorted {
+			chapter := &Chapter{Title: vc.Title, Start: timecode.FromSeconds(vc.StartTime)}
+			if i == 0 {
+				chapter.Start = 0
+			}
+			if i+1 < len(sorted) {
+				chapter.End = timecode.FromSeconds(sorted[i+1].StartTime)
+			} else {
+				chapter.End = max(timecode.FromSeconds(vc.EndTime), end)
+			}
+			chapters = append(chapters, chapter)
+		}
+	} else {
+		if length <= 0 {
+			return nil, fmt.Errorf("chapter length must be positive, got %s", length)
+		}
+		for start := time.Duration(0); start < end; start += length {
+			chapters = append(chapters, &Chapter{Start: start, End: start + length})
+		}
+	}
+
+	var planned []*Chapter
+	for _, chapter := range chapters {
+		for _, start := range starts {
+			if chapter.contains(start) {
+				chapter.Segments++
+			}
+		}
+		if chapter.Segments > 0 {
+			planned = append(planned, chapter)
+		}
+	}
+	return planned, nil
+}
+
+// chaptersKey is the context key of the chapters a translation is restricted to
+type chaptersKey struct{}
+
+// WithChapters returns a context whose translations cover only the segments of chapters, each
+// chapter translated in batches of its own; the translated chapters are saved together
+func WithChapters(ctx context.Context, chapters []*Chapter) context.Context {
+	if len(chapters) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, chaptersKey{}, chapters)
+}
+
+// chaptersFrom returns the chapters of ctx, none when the whole transcription is translated
+func chaptersFrom(ctx context.Context) []*Chapter {
+	chapters, _ := ctx.Value(chaptersKey{}).([]*Chapter)
+	return chapters
+}
+
+// chapterSegments returns the segments of each chapter, in chapter order
+func chapterSegments(segments []*model.TranscriptionSegment, chapters []*Chapter) ([][]*model.TranscriptionSegment, error) {
+	parts := make([][]*model.TranscriptionSegment, len(chapters))
+	for _, segment := range segments {
+		start, err := timecode.ParseInterval(segment.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start of segment %d: %w", segment.SegmentIndex, err)
+		}
+		for i, chapter := range chapters {
+			if chapter.contains(start) {
+				parts[i] = append(parts[i], segment)
+				break
+			}
+		}
+	}
+	return parts, nil
+}
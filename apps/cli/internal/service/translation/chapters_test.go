@@ -0,0 +1,171 @@
+package translation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// timedSegments returns segments starting at the given seconds, each lasting until the next
+func timedSegments(starts ...int) []*model.TranscriptionSegment {
+	segments := make([]*model.TranscriptionSegment, len(starts))
+	for i, start := range starts {
+		end := start + 5
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		segments[i] = &model.TranscriptionSegment{
+			ID:           fmt.Sprintf("seg-%d", i),
+			SegmentIndex: i,
+			StartTime:    fmt.Sprintf("00:%02d:%02d", start/60, start%60),
+			EndTime:      fmt.Sprintf("00:%02d:%02d", end/60, end%60),
+			Text:         fmt.Sprintf("text %d", i),
+		}
+	}
+	return segments
+}
+
+func TestSplitChapters(t *testing.T) {
+	segments := timedSegments(0, 100, 250, 700, 1300)
+
+	t.Run("time-based windows", func(t *testing.T) {
+		chapters, err := splitChapters(segments, nil, 5*time.Minute)
+		require.NoError(t, err)
+
+		// The 600-900s window has no segment and is left out
+		assert.Equal(t, []*Chapter{
+			{Start: 0, End: 5 * time.Minute, Segments: 3},
+			{Start: 10 * time.Minute, End: 15 * time.Minute, Segments: 1},
+			{Start: 20 * time.Minute, End: 25 * time.Minute, Segments: 1},
+		}, chapters)
+	})
+
+	t.Run("video chapters", func(t *testing.T) {
+		// Chapters start at the first one's start and end where the next begins; the last one
+		// covers the end of the transcription even if YouTube ends it earlier
+		chapters, err := splitChapters(segments, []model.VideoChapter{
+			{Title: "Main part", StartTime: 240, EndTime: 1200},
+			{Title: "Intro", StartTime: 3, EndTime: 240},
+		}, DefaultChapterLength)
+		require.NoError(t, err)
+
+		assert.Equal(t, []*Chapter{
+			{Title: "Intro", Start: 0, End: 4 * time.Minute, Segments: 2},
+			{Title: "Main part", Start: 4 * time.Minute, End: 1305 * time.Second, Segments: 3},
+		}, chapters)
+	})
+
+	t.Run("invalid length", func(t *testing.T) {
+		_, err := splitChapters(segments, nil, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestChapter_Range(t *testing.T) {
+	chapter := &Chapter{Start: 10 * time.Minute, End: 20*time.Minute + 1500*time.Millisecond}
+	assert.Equal(t, "00:10:00.000-00:20:01.500", chapter.Range())
+
+	parsed, err := ParseChapterRange(chapter.Range())
+	require.NoError(t, err)
+	assert.Equal(t, chapter.Start, parsed.Start)
+	assert.Equal(t, chapter.End, parsed.End)
+
+	_, err = ParseChapterRange("chapter 1")
+	assert.Error(t, err)
+}
+
+func TestTranslationService_PlanChapters(t *testing.T) {
+	transcriptionRepo := &mockTranscriptionRepo{
+		GetFunc: func(ctx context.Context, id string) (*model.Transcription, error) {
+			return &model.Transcription{ID: id, VideoID: "video-1"}, nil
+		},
+		GetSegmentsFunc: func(ctx context.Context, id string) ([]*model.TranscriptionSegment, error) {
+			return timedSegments(0, 100, 700), nil
+		},
+		GetVideoChaptersFunc: func(ctx context.Context, videoID string) ([]model.VideoChapter, error) {
+			assert.Equal(t, "video-1", videoID)
+			return nil, nil
+		},
+	}
+	service := NewTranslationService(transcriptionRepo, &mockTranslationRepo{}, NewPlamoService(&MockCmdRunner{}), &mockBatchProcessor{})
+
+	chapters, err := service.PlanChapters(context.Background(), "trans-1", 10*time.Minute)
+	require.NoError(t, err)
+	require.Len(t, chapters, 2)
+	assert.Equal(t, 2, chapters[0].Segments)
+	assert.Equal(t, 1, chapters[1].Segments)
+}
+
+func TestTranslationService_CreateTranslation_Chapters(t *testing.T) {
+	segments := timedSegments(0, 100, 700, 1300)
+	transcriptionRepo := &mockTranscriptionRepo{
+		GetSegmentsFunc: func(ctx context.Context, id string) ([]*model.TranscriptionSegment, error) {
+			return segments, nil
+		},
+		GetFunc: func(ctx context.Context, id string) (*model.Transcription, error) {
+			return &model.Transcription{ID: id, Language: "en"}, nil
+		},
+	}
+	var saved []*model.Translation
+	translationRepo := &mockTranslationRepo{
+		CreateBatchFunc: func(ctx context.Context, translations []*model.Translation) error {
+			saved = append(saved, translations...)
+			return nil
+		},
+	}
+	// Every segment given to CreateBatches goes into one batch, so the batches show how the
+	// segments were grouped
+	var batches [][]string
+	batchProcessor := &mockBatchProcessor{
+		CreateBatchesFunc: func(segments []*model.TranscriptionSegment, sourceLang string, maxTokens int) ([]SegmentBatch, error) {
+			var ids []string
+			for _, seg := range segments {
+				ids = append(ids, seg.ID)
+			}
+			batches = append(batches, ids)
+			return []SegmentBatch{{Segments: segments}}, nil
+		},
+		TranslateBatchWithFallbackFunc: func(batch SegmentBatch, plamoService PlamoService, ctx context.Context, sourceLang, targetLang string) ([]*TranslationSegment, error) {
+			return echoBatch(batch), nil
+		},
+	}
+	service := NewTranslationService(transcriptionRepo, translationRepo, NewPlamoService(&MockCmdRunner{}), batchProcessor)
+
+	t.Run("stitched chapters", func(t *testing.T) {
+		batches, saved = nil, nil
+		ctx := WithChapters(context.Background(), []*Chapter{
+			{Start: 0, End: 10 * time.Minute},
+			{Start: 10 * time.Minute, End: 30 * time.Minute},
+		})
+		_, err := service.CreateTranslation(ctx, "trans-1", "ja")
+		require.NoError(t, err)
+
+		assert.Equal(t, [][]string{{"seg-0", "seg-1"}, {"seg-2", "seg-3"}}, batches)
+		require.Len(t, saved, 4)
+		// Each translation is linked to the chapter it was translated in
+		assert.Equal(t, "00:00:00.000-00:10:00.000", saved[1].Chapter)
+		assert.Equal(t, "00:10:00.000-00:30:00.000", saved[2].Chapter)
+	})
+
+	t.Run("one chapter", func(t *testing.T) {
+		batches, saved = nil, nil
+		ctx := WithChapters(context.Background(), []*Chapter{{Start: 10 * time.Minute, End: 20 * time.Minute}})
+		_, err := service.CreateTranslation(ctx, "trans-1", "ja")
+		require.NoError(t, err)
+
+		assert.Equal(t, [][]string{{"seg-2"}}, batches)
+		require.Len(t, saved, 1)
+		assert.Equal(t, "seg-2", saved[0].TranscriptionSegmentID)
+	})
+
+	t.Run("chapter without segments", func(t *testing.T) {
+		ctx := WithChapters(context.Background(), []*Chapter{{Start: time.Hour, End: 2 * time.Hour}})
+		_, err := service.CreateTranslation(ctx, "trans-1", "ja")
+		assert.ErrorContains(t, err, "no segments found")
+	})
+}
@@ -0,0 +1,203 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ProviderPlamo is the translation source recorded for PLaMo translations
+const ProviderPlamo = "plamo"
+
+// CompareOptions configures a provider comparison for one target language
+type CompareOptions struct {
+	TranscriptionID string
+	TargetLanguage  string
+	Providers       []string // Providers to compare, in display order; empty uses every stored provider and PLaMo
+	Regenerate      bool     // Translate again even if stored translations exist
+}
+
+// ComparedSegment holds every provider's translation of one source segment
+type ComparedSegment struct {
+	SegmentIndex int               `json:"segment_index"`
+	StartTime    string            `json:"start_time"`
+	EndTime      string            `json:"end_time"`
+	Text         string            `json:"text"`
+	Translations map[string]string `json:"translations"` // provider -> translated text
+	Similarity   float64           `json:"similarity"`   // 0..1 between the first two providers
+}
+
+// TranslationComparison is a segment-aligned comparison of provider translations
+type TranslationComparison struct {
+	TranscriptionID string             `json:"transcription_id"`
+	TargetLanguage  string             `json:"target_language"`
+	Providers       []string           `json:"providers"`
+	Generated       []string           `json:"generated"` // Providers translated for this comparison rather than loaded
+	Segments        []*ComparedSegment `json:"segments"`
+	Identical       int                `json:"identical"`
+	MeanSimilarity  float64            `json:"mean_similarity"`
+}
+
+// CompareTranslations aligns translations of the same transcription from several providers.
+// Stored translations are used where available; providers the service can run (PLaMo) are
+// translated on the fly otherwise. Generated translations are not saved.
+func (s *translationService) CompareTranslations(ctx context.Context, opts CompareOptions) (*TranslationComparison, error) {
+	if len(opts.Providers) == 1 {
+		return nil, errors.New("at least two providers are required for comparison")
+	}
+
+	segments, err := s.transcriptionRepo.GetSegments(ctx, opts.TranscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get segments: %w", err)
+	}
+	if len(segments) == 0 {
+		return nil, errors.New("no segments found")
+	}
+
+	sourceLanguage, err := s.sourceLanguage(ctx, opts.TranscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.loadTranslations(ctx, opts.TranscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// provider -> segment ID -> text, keeping the newest translation per segment
+	byProvider := make(map[string]map[string]string)
+	for _, t := range stored {
+		if t.TargetLanguage != opts.TargetLanguage {
+			continue
+		}
+		texts, ok := byProvider[t.Source]
+		if !ok {
+			texts = make(map[string]string)
+			byProvider[t.Source] = texts
+		}
+		if _, ok := texts[t.TranscriptionSegmentID]; !ok {
+			texts[t.TranscriptionSegmentID] = t.TranslatedText
+		}
+	}
+
+	if len(opts.Providers) == 0 {
+		opts.Providers = defaultProviders(byProvider)
+		if len(opts.Providers) < 2 {
+			return nil, fmt.Errorf("at least two providers are required for comparison (stored %s translations: %s)",
+				opts.TargetLanguage, strings.Join(storedProviders(byProvider), ", "))
+		}
+	}
+
+	comparison := &TranslationComparison{
+		TranscriptionID: opts.TranscriptionID,
+		TargetLanguage:  opts.TargetLanguage,
+		Providers:       opts.Providers,
+		Generated:       []string{},
+	}
+
+	for _, provider := range opts.Providers {
+		if len(byProvider[provider]) > 0 && !opts.Regenerate {
+			continue
+		}
+		if provider != ProviderPlamo {
+			return nil, fmt.Errorf("no stored %s translations from %s and the provider cannot be run (available: %s)",
+				opts.TargetLanguage, provider, strings.Join(storedProviders(byProvider), ", "))
+		}
+
+		translated, err := s.translateSegments(ctx, segments, sourceLanguage, opts.TargetLanguage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate with %s: %w", provider, err)
+		}
+		texts := make(map[string]string, len(translated))
+		for _, seg := range translated {
+			texts[seg.TranscriptionSegmentID] = seg.TranslatedText
+		}
+		byProvider[provider] = texts
+		comparison.Generated = append(comparison.Generated, provider)
+	}
+
+	first, second := opts.Providers[0], opts.Providers[1]
+	var totalSimilarity float64
+
+	for _, segment := range segments {
+		compared := &ComparedSegment{
+			SegmentIndex: segment.SegmentIndex,
+			StartTime:    segment.StartTime,
+			EndTime:      segment.EndTime,
+			Text:         segment.Text,
+			Translations: make(map[string]string, len(opts.Providers)),
+		}
+		for _, provider := range opts.Providers {
+			compared.Translations[provider] = byProvider[provider][segment.ID]
+		}
+
+		compared.Similarity = Similarity(compared.Translations[first], compared.Translations[second])
+		if compared.Similarity == 1 {
+			comparison.Identical++
+		}
+		totalSimilarity += compared.Similarity
+		comparison.Segments = append(comparison.Segments, compared)
+	}
+
+	comparison.MeanSimilarity = totalSimilarity / float64(len(comparison.Segments))
+	return comparison, nil
+}
+
+// defaultProviders compares PLaMo, which can always be translated on the fly, with every
+// provider that has stored translations
+func defaultProviders(byProvider map[string]map[string]string) []string {
+	providers := []string{ProviderPlamo}
+	for name, texts := range byProvider {
+		if len(texts) > 0 && name != ProviderPlamo {
+			providers = append(providers, name)
+		}
+	}
+	sort.Strings(providers[1:])
+	return providers
+}
+
+// storedProviders lists providers with stored translations in sorted order
+func storedProviders(byProvider map[string]map[string]string) []string {
+	var names []string
+	for name, texts := range byProvider {
+		if len(texts) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return []string{"none"}
+	}
+	return names
+}
+
+// Similarity returns 1 - normalized Levenshtein distance between a and b, computed on
+// characters so it works for languages written without spaces
+func Similarity(a, b string) float64 {
+	ra, rb := []rune(strings.TrimSpace(a)), []rune(strings.TrimSpace(b))
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	longest := max(len(ra), len(rb))
+	return 1 - float64(prev[len(rb)])/float64(longest)
+}
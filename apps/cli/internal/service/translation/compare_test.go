@@ -0,0 +1,152 @@
+package translation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslationService_CompareTranslations(t *testing.T) {
+	segments := []*model.TranscriptionSegment{
+		{ID: "seg-1", SegmentIndex: 0, StartTime: "00:00:00", EndTime: "00:00:02", Text: "Hello"},
+		{ID: "seg-2", SegmentIndex: 1, StartTime: "00:00:02", EndTime: "00:00:04", Text: "World"},
+	}
+	stored := []*model.Translation{
+		{TranscriptionSegmentID: "seg-1", TargetLanguage: "ja", TranslatedText: "こんにちは", Source: "plamo"},
+		{TranscriptionSegmentID: "seg-1", TargetLanguage: "ja", TranslatedText: "こんにちは", Source: "deepl"},
+		{TranscriptionSegmentID: "seg-2", TargetLanguage: "ja", TranslatedText: "世界", Source: "plamo"},
+		{TranscriptionSegmentID: "seg-2", TargetLanguage: "ja", TranslatedText: "世の中", Source: "deepl"},
+		{TranscriptionSegmentID: "seg-2", TargetLanguage: "fr", TranslatedText: "Monde", Source: "deepl"},
+	}
+
+	newService := func(translations []*model.Translation, batch *mockBatchProcessor) TranslationService {
+		transcriptionRepo := &mockTranscriptionRepo{
+			GetSegmentsFunc: func(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error) {
+				return segments, nil
+			},
+		}
+		translationRepo := &mockTranslationRepo{
+			ListByTranscriptionIDFunc: func(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error) {
+				if offset > 0 {
+					return nil, nil
+				}
+				return translations, nil
+			},
+		}
+		return NewTranslationService(transcriptionRepo, translationRepo, NewPlamoService(&MockCmdRunner{}), batch)
+	}
+
+	t.Run("compares stored translations", func(t *testing.T) {
+		service := newService(stored, &mockBatchProcessor{})
+
+		comparison, err := service.CompareTranslations(context.Background(), CompareOptions{
+			TranscriptionID: "trans-1",
+			TargetLanguage:  "ja",
+			Providers:       []string{"plamo", "deepl"},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, comparison.Segments, 2)
+		assert.Empty(t, comparison.Generated)
+		assert.Equal(t, 1, comparison.Identical)
+		assert.Equal(t, "世界", comparison.Segments[1].Translations["plamo"])
+		assert.Equal(t, "世の中", comparison.Segments[1].Translations["deepl"])
+		assert.Equal(t, 1.0, comparison.Segments[0].Similarity)
+		assert.InDelta(t, 1.0/3.0, comparison.Segments[1].Similarity, 0.001)
+		assert.InDelta(t, 2.0/3.0, comparison.MeanSimilarity, 0.001)
+	})
+
+	t.Run("generates missing plamo translations", func(t *testing.T) {
+		batch := &mockBatchProcessor{
+			CreateBatchesFunc: func(segs []*model.TranscriptionSegment, sourceLang string, maxTokens int) ([]SegmentBatch, error) {
+				return []SegmentBatch{{Segments: segs}}, nil
+			},
+			TranslateBatchWithFallbackFunc: func(b SegmentBatch, p PlamoService, ctx context.Context, sourceLang, targetLang string) ([]*TranslationSegment, error) {
+				var out []*TranslationSegment
+				for _, seg := range b.Segments {
+					out = append(out, &TranslationSegment{TranscriptionSegmentID: seg.ID, TranslatedText: "訳: " + seg.Text})
+				}
+				return out, nil
+			},
+		}
+		service := newService(stored[1:2], batch)
+
+		comparison, err := service.CompareTranslations(context.Background(), CompareOptions{
+			TranscriptionID: "trans-1",
+			TargetLanguage:  "ja",
+			Providers:       []string{"plamo", "deepl"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"plamo"}, comparison.Generated)
+		assert.Equal(t, "訳: World", comparison.Segments[1].Translations["plamo"])
+		assert.Equal(t, "", comparison.Segments[1].Translations["deepl"])
+	})
+
+	t.Run("unknown provider without stored translations", func(t *testing.T) {
+		service := newService(stored, &mockBatchProcessor{})
+
+		_, err := service.CompareTranslations(context.Background(), CompareOptions{
+			TranscriptionID: "trans-1",
+			TargetLanguage:  "ja",
+			Providers:       []string{"plamo", "openai"},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "available: deepl, plamo")
+	})
+
+	t.Run("defaults to plamo and stored providers", func(t *testing.T) {
+		service := newService(stored, &mockBatchProcessor{})
+
+		comparison, err := service.CompareTranslations(context.Background(), CompareOptions{
+			TranscriptionID: "trans-1",
+			TargetLanguage:  "ja",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"plamo", "deepl"}, comparison.Providers)
+		assert.Empty(t, comparison.Generated)
+	})
+
+	t.Run("default without other stored providers", func(t *testing.T) {
+		service := newService(stored[:1], &mockBatchProcessor{})
+
+		_, err := service.CompareTranslations(context.Background(), CompareOptions{
+			TranscriptionID: "trans-1",
+			TargetLanguage:  "ja",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "stored ja translations: plamo")
+	})
+
+	t.Run("requires two providers", func(t *testing.T) {
+		service := newService(stored, &mockBatchProcessor{})
+
+		_, err := service.CompareTranslations(context.Background(), CompareOptions{
+			TranscriptionID: "trans-1",
+			TargetLanguage:  "ja",
+			Providers:       []string{"plamo"},
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"", "", 1},
+		{"abc", "abc", 1},
+		{"abc", "", 0},
+		{"kitten", "sitting", 1 - 3.0/7.0},
+		{"世界", "世の中", 1.0 / 3.0},
+	}
+
+	for _, tt := range tests {
+		assert.InDelta(t, tt.expected, Similarity(tt.a, tt.b), 0.001, "%q vs %q", tt.a, tt.b)
+	}
+}
@@ -0,0 +1,48 @@
+package translation
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Taichi-iskw/yt-lang/internal/hook"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// TranslationCompletedPayload is the data of a translation_completed event
+type TranslationCompletedPayload struct {
+	TranscriptionID string             `json:"transcription_id"`
+	TargetLanguage  string             `json:"target_language"`
+	Translation     *model.Translation `json:"translation"`
+}
+
+// hookedService runs hooks after translations complete
+type hookedService struct {
+	TranslationService
+	dispatcher *hook.Dispatcher
+}
+
+// NewHookedService wraps a translation service so that translation_completed hooks
+// run after each translation
+func NewHookedService(inner TranslationService, dispatcher *hook.Dispatcher) TranslationService {
+	return &hookedService{TranslationService: inner, dispatcher: dispatcher}
+}
+
+// CreateTranslation translates and then dispatches translation_completed.
+// Hook failures are reported as warnings; the translation stays saved.
+func (s *hookedService) CreateTranslation(ctx context.Context, transcriptionID string, targetLang string) (*model.Translation, error) {
+	translation, err := s.TranslationService.CreateTranslation(ctx, transcriptionID, targetLang)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := TranslationCompletedPayload{
+		TranscriptionID: transcriptionID,
+		TargetLanguage:  targetLang,
+		Translation:     translation,
+	}
+	if err := s.dispatcher.Dispatch(ctx, hook.EventTranslationCompleted, payload); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	return translation, nil
+}
@@ -0,0 +1,69 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Taichi-iskw/yt-lang/internal/hook"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHookedService_CreateTranslation(t *testing.T) {
+	newInner := func(translateErr error) TranslationService {
+		return NewTranslationService(
+			&mockTranscriptionRepo{
+				GetSegmentsFunc: func(ctx context.Context, id string) ([]*model.TranscriptionSegment, error) {
+					return []*model.TranscriptionSegment{{ID: "seg-1", Text: "Hello"}}, nil
+				},
+			},
+			&mockTranslationRepo{},
+			NewPlamoService(&MockCmdRunner{}),
+			&mockBatchProcessor{
+				CreateBatchesFunc: func(segments []*model.TranscriptionSegment, sourceLang string, maxTokens int) ([]SegmentBatch, error) {
+					return []SegmentBatch{{Segments: segments}}, nil
+				},
+				TranslateBatchWithFallbackFunc: func(batch SegmentBatch, plamoService PlamoService, ctx context.Context, sourceLang, targetLang string) ([]*TranslationSegment, error) {
+					if translateErr != nil {
+						return nil, translateErr
+					}
+					return []*TranslationSegment{{TranscriptionSegmentID: "seg-1", TranslatedText: "こんにちは"}}, nil
+				},
+			},
+		)
+	}
+
+	t.Run("dispatches translation_completed", func(t *testing.T) {
+		var events []hook.Event
+		dispatcher := hook.NewDispatcher()
+		require.NoError(t, dispatcher.Register(hook.EventTranslationCompleted, hook.HandlerFunc(func(ctx context.Context, e hook.Event) error {
+			events = append(events, e)
+			return errors.New("hook failures are only warnings")
+		})))
+
+		result, err := NewHookedService(newInner(nil), dispatcher).CreateTranslation(context.Background(), "trans-1", "ja")
+		require.NoError(t, err)
+		assert.Equal(t, "こんにちは", result.TranslatedText)
+
+		require.Len(t, events, 1)
+		payload := events[0].Data.(TranslationCompletedPayload)
+		assert.Equal(t, "trans-1", payload.TranscriptionID)
+		assert.Equal(t, "ja", payload.TargetLanguage)
+		assert.Same(t, result, payload.Translation)
+	})
+
+	t.Run("failed translations dispatch nothing", func(t *testing.T) {
+		called := false
+		dispatcher := hook.NewDispatcher()
+		require.NoError(t, dispatcher.Register(hook.EventTranslationCompleted, hook.HandlerFunc(func(ctx context.Context, e hook.Event) error {
+			called = true
+			return nil
+		})))
+
+		_, err := NewHookedService(newInner(errors.New("plamo crashed")), dispatcher).CreateTranslation(context.Background(), "trans-1", "ja")
+		require.Error(t, err)
+		assert.False(t, called)
+	})
+}
@@ -0,0 +1,153 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// ProviderManual is the translation source recorded for translations corrected by hand
+const ProviderManual = "manual"
+
+// Review actions a reviewer can take on a proposed translation
+const (
+	ReviewAccept = "accept" // Save the machine translation as is
+	ReviewEdit   = "edit"   // Save the reviewer's text instead
+	ReviewSkip   = "skip"   // Leave the segment untranslated for now
+	ReviewQuit   = "quit"   // Stop reviewing; saved segments are kept
+)
+
+// ReviewItem is one segment presented to the reviewer
+type ReviewItem struct {
+	Position int // 1-based position among the segments still to review
+	Total    int // Number of segments still to review
+	Segment  *model.TranscriptionSegment
+	Proposal string // Machine translation of the segment
+}
+
+// ReviewDecision is the reviewer's verdict on a ReviewItem
+type ReviewDecision struct {
+	Action string // One of the Review* actions
+	Text   string // Corrected translation for ReviewEdit
+}
+
+// Reviewer decides what happens to each proposed translation
+type Reviewer interface {
+	Review(ctx context.Context, item *ReviewItem) (ReviewDecision, error)
+}
+
+// InteractiveOptions configures an interactive translation session
+type InteractiveOptions struct {
+	TranscriptionID string
+	TargetLanguage  string
+}
+
+// InteractiveSummary reports what happened during an interactive session
+type InteractiveSummary struct {
+	Segments          int  // Segments in the transcription
+	AlreadyTranslated int  // Segments skipped because a translation was already stored
+	Accepted          int  // Machine translations saved unchanged
+	Edited            int  // Corrected translations saved
+	Skipped           int  // Segments the reviewer skipped
+	Quit              bool // The reviewer stopped before the last segment
+}
+
+// TranslateInteractively proposes a PLaMo translation for every segment without a stored
+// translation in the target language and lets reviewer accept, edit or skip it. Each
+// decision is saved as soon as it is made, so quitting (or a failure) keeps earlier work
+// and a later session resumes where this one stopped.
+func (s *translationService) TranslateInteractively(ctx context.Context, opts InteractiveOptions, reviewer Reviewer) (*InteractiveSummary, error) {
+	segments, err := s.transcriptionRepo.GetSegments(ctx, opts.TranscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get segments: %w", err)
+	}
+	if len(segments) == 0 {
+		return nil, errors.New("no segments found")
+	}
+
+	sourceLanguage, err := s.sourceLanguage(ctx, opts.TranscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.loadTranslations(ctx, opts.TranscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	// Styled translations don't count; interactive translations are plain like GetAlignedTranslation reads
+	translated := make(map[string]bool)
+	for _, t := range stored {
+		if t.TargetLanguage == opts.TargetLanguage && t.Style == "" {
+			translated[t.TranscriptionSegmentID] = true
+		}
+	}
+
+	summary := &InteractiveSummary{Segments: len(segments)}
+	var pending []*model.TranscriptionSegment
+	for _, segment := range segments {
+		if translated[segment.ID] {
+			summary.AlreadyTranslated++
+			continue
+		}
+		pending = append(pending, segment)
+	}
+
+	for i, segment := range pending {
+		proposal, err := s.plamoService.Translate(ctx, segment.Text, sourceLanguage, opts.TargetLanguage)
+		if err != nil {
+			return summary, fmt.Errorf("failed to translate segment %d: %w", segment.SegmentIndex, err)
+		}
+
+		decision, err := reviewer.Review(ctx, &ReviewItem{
+			Position: i + 1,
+			Total:    len(pending),
+			Segment:  segment,
+			Proposal: proposal,
+		})
+		if err != nil {
+			return summary, err
+		}
+
+		// Every saved segment was looked at by the user
+		translation := &model.Translation{
+			TranscriptionSegmentID: segment.ID,
+			TargetLanguage:         opts.TargetLanguage,
+			Approved:               true,
+			CreatedAt:              s.clock.Now(),
+		}
+		switch decision.Action {
+		case ReviewAccept:
+			translation.TranslatedText = proposal
+			translation.Source = ProviderPlamo
+		case ReviewEdit:
+			text := strings.TrimSpace(decision.Text)
+			if text == "" {
+				return summary, fmt.Errorf("edited translation of segment %d is empty", segment.SegmentIndex)
+			}
+			translation.TranslatedText = text
+			translation.Source = ProviderManual
+		case ReviewSkip:
+			summary.Skipped++
+			continue
+		case ReviewQuit:
+			summary.Quit = true
+			return summary, nil
+		default:
+			return summary, fmt.Errorf("unknown review action %q", decision.Action)
+		}
+
+		if err := s.translationRepo.Create(ctx, translation); err != nil {
+			return summary, fmt.Errorf("failed to save translation of segment %d: %w", segment.SegmentIndex, err)
+		}
+		if translation.Source == ProviderManual {
+			summary.Edited++
+		} else {
+			summary.Accepted++
+		}
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,155 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedReviewer answers reviews with a fixed list of decisions
+type scriptedReviewer struct {
+	decisions []ReviewDecision
+	items     []*ReviewItem
+}
+
+func (r *scriptedReviewer) Review(ctx context.Context, item *ReviewItem) (ReviewDecision, error) {
+	r.items = append(r.items, item)
+	if len(r.decisions) == 0 {
+		return ReviewDecision{}, errors.New("no more decisions")
+	}
+	decision := r.decisions[0]
+	r.decisions = r.decisions[1:]
+	return decision, nil
+}
+
+func TestTranslationService_TranslateInteractively(t *testing.T) {
+	segments := []*model.TranscriptionSegment{
+		{ID: "seg-1", SegmentIndex: 0, Text: "Hello"},
+		{ID: "seg-2", SegmentIndex: 1, Text: "World"},
+		{ID: "seg-3", SegmentIndex: 2, Text: "Again"},
+		{ID: "seg-4", SegmentIndex: 3, Text: "Bye"},
+	}
+
+	newService := func(stored []*model.Translation, saved *[]*model.Translation) TranslationService {
+		transcriptionRepo := &mockTranscriptionRepo{
+			GetSegmentsFunc: func(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error) {
+				return segments, nil
+			},
+		}
+		translationRepo := &mockTranslationRepo{
+			ListByTranscriptionIDFunc: func(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error) {
+				if offset > 0 {
+					return nil, nil
+				}
+				return stored, nil
+			},
+			CreateFunc: func(ctx context.Context, translation *model.Translation) error {
+				*saved = append(*saved, translation)
+				return nil
+			},
+		}
+		plamo := NewPlamoService(&MockCmdRunner{
+			RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+				return []byte("mt: " + args[len(args)-1]), nil
+			},
+		})
+		return NewTranslationService(transcriptionRepo, translationRepo, plamo, &mockBatchProcessor{})
+	}
+
+	t.Run("saves accepted and edited segments", func(t *testing.T) {
+		var saved []*model.Translation
+		service := newService(nil, &saved)
+		reviewer := &scriptedReviewer{decisions: []ReviewDecision{
+			{Action: ReviewAccept},
+			{Action: ReviewEdit, Text: " 世界 "},
+			{Action: ReviewSkip},
+			{Action: ReviewAccept},
+		}}
+
+		summary, err := service.TranslateInteractively(context.Background(), InteractiveOptions{
+			TranscriptionID: "trans-1",
+			TargetLanguage:  "ja",
+		}, reviewer)
+		require.NoError(t, err)
+
+		assert.Equal(t, &InteractiveSummary{Segments: 4, Accepted: 2, Edited: 1, Skipped: 1}, summary)
+		require.Len(t, saved, 3)
+		assert.Equal(t, "mt: Hello", saved[0].TranslatedText)
+		assert.Equal(t, ProviderPlamo, saved[0].Source)
+		assert.Equal(t, "世界", saved[1].TranslatedText)
+		assert.Equal(t, ProviderManual, saved[1].Source)
+		assert.True(t, saved[0].Approved)
+		assert.True(t, saved[1].Approved)
+		assert.Equal(t, "seg-4", saved[2].TranscriptionSegmentID)
+		assert.Equal(t, "ja", saved[2].TargetLanguage)
+
+		require.Len(t, reviewer.items, 4)
+		assert.Equal(t, 2, reviewer.items[1].Position)
+		assert.Equal(t, 4, reviewer.items[1].Total)
+		assert.Equal(t, "mt: World", reviewer.items[1].Proposal)
+	})
+
+	t.Run("resumes after segments translated earlier", func(t *testing.T) {
+		var saved []*model.Translation
+		service := newService([]*model.Translation{
+			{TranscriptionSegmentID: "seg-1", TargetLanguage: "ja", TranslatedText: "こんにちは"},
+			{TranscriptionSegmentID: "seg-2", TargetLanguage: "fr", TranslatedText: "Monde"},
+			{TranscriptionSegmentID: "seg-3", TargetLanguage: "ja", TranslatedText: "また"},
+			{TranscriptionSegmentID: "seg-4", TargetLanguage: "ja", TranslatedText: "じゃあね", Style: StyleCasual},
+		}, &saved)
+		reviewer := &scriptedReviewer{decisions: []ReviewDecision{
+			{Action: ReviewAccept},
+			{Action: ReviewAccept},
+		}}
+
+		summary, err := service.TranslateInteractively(context.Background(), InteractiveOptions{
+			TranscriptionID: "trans-1",
+			TargetLanguage:  "ja",
+		}, reviewer)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, summary.AlreadyTranslated)
+		assert.Equal(t, 2, summary.Accepted)
+		require.Len(t, reviewer.items, 2)
+		assert.Equal(t, "seg-2", reviewer.items[0].Segment.ID)
+		assert.Equal(t, "seg-4", reviewer.items[1].Segment.ID)
+	})
+
+	t.Run("quit keeps earlier decisions", func(t *testing.T) {
+		var saved []*model.Translation
+		service := newService(nil, &saved)
+		reviewer := &scriptedReviewer{decisions: []ReviewDecision{
+			{Action: ReviewAccept},
+			{Action: ReviewQuit},
+		}}
+
+		summary, err := service.TranslateInteractively(context.Background(), InteractiveOptions{
+			TranscriptionID: "trans-1",
+			TargetLanguage:  "ja",
+		}, reviewer)
+		require.NoError(t, err)
+
+		assert.True(t, summary.Quit)
+		assert.Equal(t, 1, summary.Accepted)
+		assert.Len(t, saved, 1)
+		assert.Len(t, reviewer.items, 2)
+	})
+
+	t.Run("rejects empty edits", func(t *testing.T) {
+		var saved []*model.Translation
+		service := newService(nil, &saved)
+		reviewer := &scriptedReviewer{decisions: []ReviewDecision{{Action: ReviewEdit, Text: "  "}}}
+
+		_, err := service.TranslateInteractively(context.Background(), InteractiveOptions{
+			TranscriptionID: "trans-1",
+			TargetLanguage:  "ja",
+		}, reviewer)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "empty")
+		assert.Empty(t, saved)
+	})
+}
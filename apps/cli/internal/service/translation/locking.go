@@ -0,0 +1,45 @@
+package translation
+
+import (
+	"context"
+
+	"github.com/Taichi-iskw/yt-lang/internal/lock"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// lockingService serializes translation jobs across processes
+type lockingService struct {
+	TranslationService
+	locker lock.Locker
+}
+
+// NewLockingService wraps a translation service so that only one process at a time
+// translates a transcription into a given language
+func NewLockingService(inner TranslationService, locker lock.Locker) TranslationService {
+	return &lockingService{TranslationService: inner, locker: locker}
+}
+
+// CreateTranslation holds the translation:<transcription>:<language> lock while translating
+func (s *lockingService) CreateTranslation(ctx context.Context, transcriptionID string, targetLang string) (*model.Translation, error) {
+	var translation *model.Translation
+	err := lock.WithLock(ctx, s.locker, lock.Key("translation", transcriptionID, targetLang), func() error {
+		var err error
+		translation, err = s.TranslationService.CreateTranslation(ctx, transcriptionID, targetLang)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return translation, nil
+}
+
+// TranslateInteractively holds the same lock as CreateTranslation for the whole session
+func (s *lockingService) TranslateInteractively(ctx context.Context, opts InteractiveOptions, reviewer Reviewer) (*InteractiveSummary, error) {
+	var summary *InteractiveSummary
+	err := lock.WithLock(ctx, s.locker, lock.Key("translation", opts.TranscriptionID, opts.TargetLanguage), func() error {
+		var err error
+		summary, err = s.TranslationService.TranslateInteractively(ctx, opts, reviewer)
+		return err
+	})
+	return summary, err
+}
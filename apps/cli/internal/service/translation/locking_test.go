@@ -0,0 +1,83 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Taichi-iskw/yt-lang/internal/lock"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLocker records requested keys and refuses the ones marked as held
+type fakeLocker struct {
+	held     map[string]bool
+	keys     []string
+	unlocked int
+}
+
+func (l *fakeLocker) TryLock(ctx context.Context, key string) (lock.Lock, error) {
+	l.keys = append(l.keys, key)
+	if l.held[key] {
+		return nil, fmt.Errorf("%w: %s", lock.ErrLocked, key)
+	}
+	return fakeLock{l}, nil
+}
+
+type fakeLock struct{ locker *fakeLocker }
+
+func (l fakeLock) Unlock() error {
+	l.locker.unlocked++
+	return nil
+}
+
+func TestLockingService_CreateTranslation(t *testing.T) {
+	newInner := func(called *bool) TranslationService {
+		return NewTranslationService(
+			&mockTranscriptionRepo{
+				GetSegmentsFunc: func(ctx context.Context, id string) ([]*model.TranscriptionSegment, error) {
+					*called = true
+					return []*model.TranscriptionSegment{{ID: "seg-1", Text: "Hello"}}, nil
+				},
+			},
+			&mockTranslationRepo{},
+			NewPlamoService(&MockCmdRunner{}),
+			&mockBatchProcessor{
+				CreateBatchesFunc: func(segments []*model.TranscriptionSegment, sourceLang string, maxTokens int) ([]SegmentBatch, error) {
+					return []SegmentBatch{{Segments: segments}}, nil
+				},
+				TranslateBatchWithFallbackFunc: func(batch SegmentBatch, plamoService PlamoService, ctx context.Context, sourceLang, targetLang string) ([]*TranslationSegment, error) {
+					return []*TranslationSegment{{TranscriptionSegmentID: "seg-1", TranslatedText: "こんにちは"}}, nil
+				},
+			},
+		)
+	}
+
+	t.Run("translates while holding the lock", func(t *testing.T) {
+		called := false
+		locker := &fakeLocker{}
+		service := NewLockingService(newInner(&called), locker)
+
+		result, err := service.CreateTranslation(context.Background(), "trans-1", "ja")
+		require.NoError(t, err)
+		assert.Equal(t, "こんにちは", result.TranslatedText)
+		assert.True(t, called)
+		assert.Equal(t, []string{"translation:trans-1:ja"}, locker.keys)
+		assert.Equal(t, 1, locker.unlocked)
+	})
+
+	t.Run("skips when another process holds the lock", func(t *testing.T) {
+		called := false
+		locker := &fakeLocker{held: map[string]bool{"translation:trans-1:ja": true}}
+		service := NewLockingService(newInner(&called), locker)
+
+		result, err := service.CreateTranslation(context.Background(), "trans-1", "ja")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, lock.ErrLocked))
+		assert.Nil(t, result)
+		assert.False(t, called)
+	})
+}
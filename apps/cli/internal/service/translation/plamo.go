@@ -49,8 +49,9 @@ func (s *plamoService) Translate(ctx context.Context, text string, fromLang, toL
 		"--to", toLangPLaMo,
 		"--input", text,
 	}
+	args = append(args, plamoOptionArgs(ctx)...)
 
-	output, err := s.cmdRunner.Run(ctx, "plamo-translate", args...)
+	output, err := s.cmdRunner.Run(ctx, common.ToolPlamo, args...)
 	if err != nil {
 		return "", errors.New("PLaMo CLI execution failed: " + err.Error())
 	}
@@ -0,0 +1,203 @@
+package translation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Built-in redaction rule names accepted in PlamoDebugOptions.Redact
+const (
+	RedactEmails  = "emails"
+	RedactURLs    = "urls"
+	RedactNumbers = "numbers"
+	RedactText    = "text" // replaces all text between separators with its length
+)
+
+const redactedPlaceholder = "[REDACTED]"
+
+var builtinRedactions = map[string]*regexp.Regexp{
+	RedactEmails:  regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`),
+	RedactURLs:    regexp.MustCompile(`https?://[^\s]+`),
+	RedactNumbers: regexp.MustCompile(`\d[\d\-\s]{2,}\d`),
+}
+
+// PlamoDebugOptions configures PLaMo transcript logging
+type PlamoDebugOptions struct {
+	Dir            string   // Directory for transcript files
+	Redact         []string // Built-in redaction rules: emails, urls, numbers, text
+	RedactPatterns []string // Additional regular expressions to redact
+}
+
+// PlamoExchange is one logged PLaMo request and response
+type PlamoExchange struct {
+	Timestamp  time.Time                 `json:"timestamp"`
+	Sequence   int                       `json:"sequence"`
+	FromLang   string                    `json:"from_lang"`
+	ToLang     string                    `json:"to_lang"`
+	DurationMS int64                     `json:"duration_ms"`
+	Prompt     string                    `json:"prompt"`
+	Response   string                    `json:"response"`
+	Error      string                    `json:"error,omitempty"`
+	Separators map[string]SeparatorCount `json:"separators"`
+	Redacted   []string                  `json:"redacted,omitempty"`
+}
+
+// SeparatorCount compares how often a batch separator occurs in prompt and response;
+// a mismatch means the model dropped or invented a segment boundary
+type SeparatorCount struct {
+	Prompt   int  `json:"prompt"`
+	Response int  `json:"response"`
+	Match    bool `json:"match"`
+}
+
+// debugPlamoService records every Translate call of the wrapped service
+type debugPlamoService struct {
+	inner    PlamoService
+	dir      string
+	redact   []string
+	patterns []*regexp.Regexp
+	mu       sync.Mutex
+	sequence int
+}
+
+// NewDebugPlamoService wraps a PLaMo service so that every prompt and raw response is written
+// to a timestamped JSON file under opts.Dir. Logging failures never fail a translation.
+func NewDebugPlamoService(inner PlamoService, opts PlamoDebugOptions) (PlamoService, error) {
+	if opts.Dir == "" {
+		return nil, fmt.Errorf("debug log directory is required")
+	}
+
+	s := &debugPlamoService{inner: inner, dir: opts.Dir}
+	for _, name := range opts.Redact {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == RedactText {
+			s.redact = append(s.redact, name)
+			continue
+		}
+		pattern, ok := builtinRedactions[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown redaction rule: %s (supported: emails, urls, numbers, text)", name)
+		}
+		s.redact = append(s.redact, name)
+		s.patterns = append(s.patterns, pattern)
+	}
+	for _, expr := range opts.RedactPatterns {
+		pattern, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", expr, err)
+		}
+		s.redact = append(s.redact, "pattern")
+		s.patterns = append(s.patterns, pattern)
+	}
+
+	if err := os.MkdirAll(opts.Dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create debug log directory: %w", err)
+	}
+	return s, nil
+}
+
+// Translate forwards to the wrapped service and records the exchange
+func (s *debugPlamoService) Translate(ctx context.Context, text string, fromLang, toLang string) (string, error) {
+	started := time.Now()
+	response, err := s.inner.Translate(ctx, text, fromLang, toLang)
+
+	exchange := &PlamoExchange{
+		Timestamp:  started,
+		FromLang:   fromLang,
+		ToLang:     toLang,
+		DurationMS: time.Since(started).Milliseconds(),
+		Prompt:     s.redactText(text),
+		Response:   s.redactText(response),
+		Separators: countSeparators(text, response),
+		Redacted:   s.redact,
+	}
+	if err != nil {
+		exchange.Error = err.Error()
+	}
+
+	if logErr := s.write(exchange); logErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write PLaMo debug log: %v\n", logErr)
+	}
+
+	return response, err
+}
+
+// StartServer forwards to the wrapped service
+func (s *debugPlamoService) StartServer(ctx context.Context) error {
+	return s.inner.StartServer(ctx)
+}
+
+// StopServer forwards to the wrapped service
+func (s *debugPlamoService) StopServer() error {
+	return s.inner.StopServer()
+}
+
+// write stores an exchange as <timestamp>-<sequence>.json
+func (s *debugPlamoService) write(exchange *PlamoExchange) error {
+	s.mu.Lock()
+	s.sequence++
+	exchange.Sequence = s.sequence
+	s.mu.Unlock()
+
+	data, err := json.MarshalIndent(exchange, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%04d.json", exchange.Timestamp.Format("20060102T150405.000"), exchange.Sequence)
+	return os.WriteFile(filepath.Join(s.dir, name), data, 0600)
+}
+
+// redactText applies the configured redaction rules, keeping separators intact
+func (s *debugPlamoService) redactText(text string) string {
+	for _, pattern := range s.patterns {
+		text = pattern.ReplaceAllString(text, redactedPlaceholder)
+	}
+	for _, rule := range s.redact {
+		if rule == RedactText {
+			return redactAllText(text)
+		}
+	}
+	return text
+}
+
+// redactAllText replaces each piece of text between separators with its character count
+func redactAllText(text string) string {
+	separator := ""
+	for _, candidate := range []string{"<<<SEP>>>", "__"} {
+		if strings.Contains(text, candidate) {
+			separator = candidate
+			break
+		}
+	}
+
+	parts := []string{text}
+	if separator != "" {
+		parts = strings.Split(text, separator)
+	}
+	for i, part := range parts {
+		parts[i] = fmt.Sprintf("[%d chars]", utf8.RuneCountInString(part))
+	}
+	return strings.Join(parts, separator)
+}
+
+// countSeparators counts each batch separator in the prompt and the response
+func countSeparators(prompt, response string) map[string]SeparatorCount {
+	counts := make(map[string]SeparatorCount)
+	for _, separator := range []string{"__", "<<<SEP>>>"} {
+		p, r := strings.Count(prompt, separator), strings.Count(response, separator)
+		if p == 0 && r == 0 {
+			continue
+		}
+		counts[separator] = SeparatorCount{Prompt: p, Response: r, Match: p == r}
+	}
+	return counts
+}
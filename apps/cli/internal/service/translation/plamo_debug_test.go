@@ -0,0 +1,188 @@
+package translation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readExchanges loads every logged exchange in dir in file name order
+func readExchanges(t *testing.T, dir string) []PlamoExchange {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+
+	var exchanges []PlamoExchange
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+
+		var exchange PlamoExchange
+		require.NoError(t, json.Unmarshal(data, &exchange))
+		exchanges = append(exchanges, exchange)
+	}
+	return exchanges
+}
+
+func TestDebugPlamoService_Translate(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           PlamoDebugOptions
+		prompt         string
+		output         string
+		runErr         error
+		wantPrompt     string
+		wantResponse   string
+		wantSeparators map[string]SeparatorCount
+		wantError      bool
+	}{
+		{
+			name:         "records prompt and response",
+			prompt:       "Hello world__Good morning",
+			output:       "こんにちは世界__おはようございます",
+			wantPrompt:   "Hello world__Good morning",
+			wantResponse: "こんにちは世界__おはようございます",
+			wantSeparators: map[string]SeparatorCount{
+				"__": {Prompt: 1, Response: 1, Match: true},
+			},
+		},
+		{
+			name:         "flags separator mismatch",
+			prompt:       "One<<<SEP>>>Two<<<SEP>>>Three",
+			output:       "いち<<<SEP>>>にさん",
+			wantPrompt:   "One<<<SEP>>>Two<<<SEP>>>Three",
+			wantResponse: "いち<<<SEP>>>にさん",
+			wantSeparators: map[string]SeparatorCount{
+				"<<<SEP>>>": {Prompt: 2, Response: 1, Match: false},
+			},
+		},
+		{
+			name:           "redacts emails and urls",
+			opts:           PlamoDebugOptions{Redact: []string{"emails", "urls"}},
+			prompt:         "Mail me at a.b@example.com or visit https://example.com/x",
+			output:         "翻訳",
+			wantPrompt:     "Mail me at [REDACTED] or visit [REDACTED]",
+			wantResponse:   "翻訳",
+			wantSeparators: map[string]SeparatorCount{},
+		},
+		{
+			name:         "redacts all text but keeps separators",
+			opts:         PlamoDebugOptions{Redact: []string{"text"}},
+			prompt:       "Hello__World",
+			output:       "こんにちは__世界",
+			wantPrompt:   "[5 chars]__[5 chars]",
+			wantResponse: "[5 chars]__[2 chars]",
+			wantSeparators: map[string]SeparatorCount{
+				"__": {Prompt: 1, Response: 1, Match: true},
+			},
+		},
+		{
+			name:           "redacts custom patterns",
+			opts:           PlamoDebugOptions{RedactPatterns: []string{`ACME-\d+`}},
+			prompt:         "Order ACME-1234 shipped",
+			output:         "注文済み",
+			wantPrompt:     "Order [REDACTED] shipped",
+			wantResponse:   "注文済み",
+			wantSeparators: map[string]SeparatorCount{},
+		},
+		{
+			name:           "records translation errors",
+			prompt:         "Hello",
+			runErr:         errors.New("plamo crashed"),
+			wantPrompt:     "Hello",
+			wantSeparators: map[string]SeparatorCount{},
+			wantError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "plamo")
+			tt.opts.Dir = dir
+
+			inner := NewPlamoService(&MockCmdRunner{
+				RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+					return []byte(tt.output), tt.runErr
+				},
+			})
+			service, err := NewDebugPlamoService(inner, tt.opts)
+			require.NoError(t, err)
+
+			result, err := service.Translate(context.Background(), tt.prompt, "en", "ja")
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.output, result, "translation must not be redacted")
+			}
+
+			exchanges := readExchanges(t, dir)
+			require.Len(t, exchanges, 1)
+			exchange := exchanges[0]
+
+			assert.Equal(t, 1, exchange.Sequence)
+			assert.Equal(t, "en", exchange.FromLang)
+			assert.Equal(t, "ja", exchange.ToLang)
+			assert.Equal(t, tt.wantPrompt, exchange.Prompt)
+			assert.Equal(t, tt.wantResponse, exchange.Response)
+			assert.Equal(t, tt.wantSeparators, exchange.Separators)
+			if tt.wantError {
+				assert.Contains(t, exchange.Error, "plamo crashed")
+			} else {
+				assert.Empty(t, exchange.Error)
+			}
+		})
+	}
+}
+
+func TestDebugPlamoService_SequentialFiles(t *testing.T) {
+	dir := t.TempDir()
+	service, err := NewDebugPlamoService(NewPlamoService(&MockCmdRunner{}), PlamoDebugOptions{Dir: dir})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err := service.Translate(context.Background(), "Hello", "en", "ja")
+		require.NoError(t, err)
+	}
+
+	exchanges := readExchanges(t, dir)
+	require.Len(t, exchanges, 3)
+	for i, exchange := range exchanges {
+		assert.Equal(t, i+1, exchange.Sequence)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	info, err := os.Stat(files[0])
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "transcripts may contain private text")
+}
+
+func TestNewDebugPlamoService_InvalidOptions(t *testing.T) {
+	inner := NewPlamoService(&MockCmdRunner{})
+
+	tests := []struct {
+		name       string
+		opts       PlamoDebugOptions
+		errMessage string
+	}{
+		{name: "missing directory", opts: PlamoDebugOptions{}, errMessage: "directory is required"},
+		{name: "unknown rule", opts: PlamoDebugOptions{Dir: t.TempDir(), Redact: []string{"phones"}}, errMessage: "unknown redaction rule"},
+		{name: "invalid pattern", opts: PlamoDebugOptions{Dir: t.TempDir(), RedactPatterns: []string{"("}}, errMessage: "invalid redaction pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDebugPlamoService(inner, tt.opts)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMessage)
+		})
+	}
+}
@@ -50,7 +50,7 @@ func (s *PlamoServerService) StartServer(ctx context.Context) error {
 	}
 
 	// Use CmdRunner to start server process
-	process, err := s.cmdRunner.Start(serverCtx, "plamo-translate", args...)
+	process, err := s.cmdRunner.Start(serverCtx, common.ToolPlamo, args...)
 	if err != nil {
 		cancel()
 		return fmt.Errorf("failed to start PLaMo server: %w", err)
@@ -123,11 +123,10 @@ func (s *PlamoServerService) Translate(ctx context.Context, text string, fromLan
 		return "", errors.New("unsupported language")
 	}
 
-	// Start server if not running
-	if !s.serverStarted {
-		if err := s.StartServer(ctx); err != nil {
-			return "", fmt.Errorf("failed to start PLaMo server: %w", err)
-		}
+	// Start server if not running (StartServer checks under the lock, so concurrent
+	// batch workers start it only once)
+	if err := s.StartServer(ctx); err != nil {
+		return "", fmt.Errorf("failed to start PLaMo server: %w", err)
 	}
 
 	// In server mode, we would send commands to the running server
@@ -137,8 +136,9 @@ func (s *PlamoServerService) Translate(ctx context.Context, text string, fromLan
 		"--to", toLangPLaMo,
 		"--input", text,
 	}
+	args = append(args, plamoOptionArgs(ctx)...)
 
-	output, err := s.cmdRunner.Run(ctx, "plamo-translate", args...)
+	output, err := s.cmdRunner.Run(ctx, common.ToolPlamo, args...)
 	if err != nil {
 		return "", fmt.Errorf("PLaMo server translation failed: %w", err)
 	}
@@ -0,0 +1,100 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// SourcePolished is the translation source recorded for PLaMo translations post-edited by a
+// Polisher. The raw PLaMo translations are kept alongside under ProviderPlamo.
+const SourcePolished = ProviderPlamo + "-polished"
+
+// PolishSegment is one machine-translated segment to post-edit
+type PolishSegment struct {
+	Source      string `json:"source"`      // Original transcription text
+	Translation string `json:"translation"` // Machine translation to improve
+}
+
+// Polisher post-edits machine translations, fixing fluency while preserving their meaning
+type Polisher interface {
+	// Polish returns one post-edited text per segment, in segment order
+	Polish(ctx context.Context, segments []PolishSegment, sourceLang, targetLang string) ([]string, error)
+}
+
+// polishTranslations post-edits translated segments batch by batch. Batches are rebuilt the way
+// PLaMo's were, so each request carries the context the segments were translated in. Segments
+// the polisher returns empty keep their PLaMo translation.
+func (s *translationService) polishTranslations(ctx context.Context, segments []*model.TranscriptionSegment, translated []*TranslationSegment, sourceLang, targetLang string) ([]*TranslationSegment, error) {
+	batches, err := s.batchProcessor.CreateBatches(segments, sourceLang, defaultMaxTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make(map[string]*TranslationSegment, len(translated))
+	for _, seg := range translated {
+		raw[seg.TranscriptionSegmentID] = seg
+	}
+
+	var polished []*TranslationSegment
+	for i, batch := range batches {
+		var batchSegments []*TranslationSegment
+		var input []PolishSegment
+		for _, segment := range batch.Segments {
+			seg, ok := raw[segment.ID]
+			if !ok {
+				continue
+			}
+			batchSegments = append(batchSegments, seg)
+			input = append(input, PolishSegment{Source: segment.Text, Translation: seg.TranslatedText})
+		}
+		if len(input) == 0 {
+			continue
+		}
+
+		texts, err := s.polishBatch(ctx, input, sourceLang, targetLang)
+		if err != nil {
+			return nil, fmt.Errorf("polishing failed (batch %d of %d): %w", i+1, len(batches), err)
+		}
+
+		for j, seg := range batchSegments {
+			edited := *seg
+			if texts[j] != "" {
+				edited.TranslatedText = texts[j]
+			}
+			polished = append(polished, &edited)
+		}
+	}
+	return polished, nil
+}
+
+// polishBatch post-edits one batch, retrying it like PLaMo batches
+func (s *translationService) polishBatch(ctx context.Context, input []PolishSegment, sourceLang, targetLang string) ([]string, error) {
+	var err error
+	for attempt := 1; attempt <= batchAttempts; attempt++ {
+		var texts []string
+		texts, err = s.polisher.Polish(ctx, input, sourceLang, targetLang)
+		if err == nil && len(texts) != len(input) {
+			err = fmt.Errorf("expected %d post-edited segments, got %d", len(input), len(texts))
+		}
+		if err == nil {
+			return texts, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		// Honor the wait a rate-limited API asks for before the next attempt
+		var limited *RateLimitedError
+		if errors.As(err, &limited) && limited.RetryAfter > 0 && attempt < batchAttempts {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(limited.RetryAfter):
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w (after %d attempts)", err, batchAttempts)
+}
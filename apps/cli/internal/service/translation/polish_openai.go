@@ -0,0 +1,182 @@
+package translation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/offline"
+)
+
+// ProviderOpenAI names the OpenAI-compatible post-editing API in rate limit errors
+const ProviderOpenAI = "openai"
+
+const defaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// polishInstructions is the system prompt of a post-editing request; it is completed with the
+// source and target language names
+const polishInstructions = `You are a professional %[2]s editor post-editing a machine translation of %[1]s video subtitles.
+The user sends a JSON array of segments, each with the original "source" text and its machine "translation".
+Rewrite each translation so it reads fluently and naturally in %[2]s while keeping exactly the meaning of its source. Do not add, drop or merge information, and keep each segment on its own.
+Reply with only a JSON array of strings: the post-edited translations, one per segment, in the same order.`
+
+// OpenAIPolisherOptions configures an OpenAI-compatible chat completions API
+type OpenAIPolisherOptions struct {
+	BaseURL string // e.g. https://api.openai.com/v1 or a local server; defaults to the OpenAI API
+	Model   string
+	APIKey  string // optional for local servers
+}
+
+// openAIPolisher post-edits translations with an OpenAI-compatible chat completions API
+type openAIPolisher struct {
+	opts   OpenAIPolisherOptions
+	client *http.Client
+}
+
+// NewOpenAIPolisher creates a polisher backed by an OpenAI-compatible chat completions API
+func NewOpenAIPolisher(opts OpenAIPolisherOptions) (Polisher, error) {
+	return NewOpenAIPolisherWithClient(opts, &http.Client{Timeout: 5 * time.Minute})
+}
+
+// NewOpenAIPolisherWithClient creates a polisher using client (for testing)
+func NewOpenAIPolisherWithClient(opts OpenAIPolisherOptions, client *http.Client) (Polisher, error) {
+	if opts.Model == "" {
+		return nil, errors.New("polish model is required")
+	}
+	if opts.BaseURL == "" {
+		opts.BaseURL = defaultOpenAIBaseURL
+	}
+	opts.BaseURL = strings.TrimRight(opts.BaseURL, "/")
+	return &openAIPolisher{opts: opts, client: client}, nil
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature float64       `json:"temperature"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// Polish sends the segments in one chat completion and parses the post-edited texts from the reply
+func (p *openAIPolisher) Polish(ctx context.Context, segments []PolishSegment, sourceLang, targetLang string) ([]string, error) {
+	if err := offline.Check("polishing with " + p.opts.Model); err != nil {
+		return nil, err
+	}
+	input, err := json.Marshal(segments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode segments: %w", err)
+	}
+	instructions := fmt.Sprintf(polishInstructions, languageName(sourceLang), languageName(targetLang))
+	if style := styleFrom(ctx); style != "" {
+		instructions += "\n" + styleInstructions[style]
+	}
+	content, err := chatCompletion(ctx, p.client, p.opts, "polish", []chatMessage{
+		{Role: "system", Content: instructions},
+		{Role: "user", Content: string(input)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parsePolished(content)
+}
+
+// chatCompletion sends messages to the chat completions API of opts, with the OpenAI provider
+// options of ctx as extra request fields, and returns the reply. purpose names the request in
+// errors (e.g. polish).
+func chatCompletion(ctx context.Context, client *http.Client, opts OpenAIPolisherOptions, purpose string, messages []chatMessage) (string, error) {
+	body, err := json.Marshal(chatRequest{
+		Model:    opts.Model,
+		Messages: messages,
+	})
+	if err == nil {
+		body, err = withRequestOptions(ctx, body)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, opts.BaseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to build %s request: %w", purpose, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if opts.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+opts.APIKey)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%s request failed: %w", purpose, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return "", &RateLimitedError{Provider: ProviderOpenAI, RetryAfter: retryAfter(resp.Header.Get("Retry-After"))}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("%s request failed: %s %s", purpose, resp.Status, strings.TrimSpace(string(detail)))
+	}
+
+	var completion chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		return "", fmt.Errorf("failed to parse %s response: %w", purpose, err)
+	}
+	if len(completion.Choices) == 0 {
+		return "", fmt.Errorf("%s response has no choices", purpose)
+	}
+	return completion.Choices[0].Message.Content, nil
+}
+
+// parsePolished reads the JSON array of strings in a reply, ignoring a surrounding code fence
+func parsePolished(content string) ([]string, error) {
+	content = strings.TrimSpace(content)
+	if strings.HasPrefix(content, "```") {
+		content = strings.TrimPrefix(content, "```json")
+		content = strings.TrimPrefix(content, "```")
+		content = strings.TrimSuffix(strings.TrimSpace(content), "```")
+	}
+
+	var texts []string
+	if err := json.Unmarshal([]byte(strings.TrimSpace(content)), &texts); err != nil {
+		return nil, fmt.Errorf("polish reply is not a JSON array of strings: %w", err)
+	}
+	for i := range texts {
+		texts[i] = strings.TrimSpace(texts[i])
+	}
+	return texts, nil
+}
+
+// languageName returns the English name of a language code for prompts, or the code itself
+func languageName(lang string) string {
+	if name := mapLanguageToPLaMo(lang); name != "" {
+		return name
+	}
+	return lang
+}
+
+// retryAfter parses a Retry-After header given in seconds; 0 when absent or a date
+func retryAfter(header string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(header))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,317 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/clock"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockPolisher mocks Polisher
+type mockPolisher struct {
+	PolishFunc func(ctx context.Context, segments []PolishSegment, sourceLang, targetLang string) ([]string, error)
+	calls      int
+}
+
+func (m *mockPolisher) Polish(ctx context.Context, segments []PolishSegment, sourceLang, targetLang string) ([]string, error) {
+	m.calls++
+	return m.PolishFunc(ctx, segments, sourceLang, targetLang)
+}
+
+// newPolishTestService creates a service translating three segments in two batches, recording
+// every saved batch of translations
+func newPolishTestService(polisher Polisher, saved *[][]*model.Translation) TranslationService {
+	batches := numberedBatches(3)
+	batches = []SegmentBatch{
+		{Segments: append(batches[0].Segments, batches[1].Segments...)},
+		batches[2],
+	}
+
+	transcriptionRepo := &mockTranscriptionRepo{
+		GetSegmentsFunc: func(ctx context.Context, id string) ([]*model.TranscriptionSegment, error) {
+			var segments []*model.TranscriptionSegment
+			for _, batch := range batches {
+				segments = append(segments, batch.Segments...)
+			}
+			return segments, nil
+		},
+		GetFunc: func(ctx context.Context, id string) (*model.Transcription, error) {
+			return &model.Transcription{ID: id, Language: "en"}, nil
+		},
+	}
+	translationRepo := &mockTranslationRepo{
+		CreateBatchFunc: func(ctx context.Context, translations []*model.Translation) error {
+			*saved = append(*saved, translations)
+			return nil
+		},
+	}
+	batchProcessor := &mockBatchProcessor{
+		CreateBatchesFunc: func(segments []*model.TranscriptionSegment, sourceLang string, maxTokens int) ([]SegmentBatch, error) {
+			return batches, nil
+		},
+		TranslateBatchWithFallbackFunc: func(batch SegmentBatch, plamoService PlamoService, ctx context.Context, sourceLang, targetLang string) ([]*TranslationSegment, error) {
+			return echoBatch(batch), nil
+		},
+	}
+
+	return NewTranslationServiceWithPolisher(transcriptionRepo, translationRepo, nil, NewPlamoService(&MockCmdRunner{}), batchProcessor, 1, polisher)
+}
+
+func TestTranslationService_CreateTranslation_Polish(t *testing.T) {
+	started := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(started)
+	var batchSizes []int
+	polisher := &mockPolisher{PolishFunc: func(ctx context.Context, segments []PolishSegment, sourceLang, targetLang string) ([]string, error) {
+		clk.Advance(time.Minute)
+		assert.Equal(t, "en", sourceLang)
+		assert.Equal(t, "ja", targetLang)
+		batchSizes = append(batchSizes, len(segments))
+		var texts []string
+		for _, seg := range segments {
+			texts = append(texts, "polished "+seg.Source)
+		}
+		// An empty post-edit keeps the PLaMo translation
+		if len(segments) == 1 {
+			texts[0] = ""
+		}
+		return texts, nil
+	}}
+
+	var saved [][]*model.Translation
+	service := newPolishTestService(polisher, &saved)
+	service.(*translationService).clock = clk
+	result, err := service.CreateTranslation(context.Background(), "trans-1", "ja")
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{2, 1}, batchSizes)
+	require.Len(t, saved, 2)
+	for i, translation := range saved[0] {
+		assert.Equal(t, ProviderPlamo, translation.Source)
+		assert.Equal(t, fmt.Sprintf("translated text %d", i), translation.TranslatedText)
+		assert.Equal(t, started, translation.CreatedAt)
+	}
+	// The polished version is newer than the raw one it post-edits
+	var polished []string
+	for _, translation := range saved[1] {
+		assert.Equal(t, SourcePolished, translation.Source)
+		assert.Equal(t, started.Add(2*time.Minute), translation.CreatedAt)
+		polished = append(polished, translation.TranslatedText)
+	}
+	assert.Equal(t, []string{"polished text 0", "polished text 1", "translated text 2"}, polished)
+	for _, translation := range saved[0] {
+		assert.Equal(t, SplitSeparator, translation.SplitStrategy)
+	}
+	assert.Equal(t, SourcePolished, result.Source)
+}
+
+func TestTranslationService_CreateTranslation_PolishFailureKeepsRaw(t *testing.T) {
+	tests := []struct {
+		name   string
+		polish func(segments []PolishSegment) ([]string, error)
+	}{
+		{
+			name: "polisher error",
+			polish: func(segments []PolishSegment) ([]string, error) {
+				return nil, errors.New("connection refused")
+			},
+		},
+		{
+			name: "segment count mismatch",
+			polish: func(segments []PolishSegment) ([]string, error) {
+				return []string{"merged"}, nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polisher := &mockPolisher{PolishFunc: func(ctx context.Context, segments []PolishSegment, sourceLang, targetLang string) ([]string, error) {
+				return tt.polish(segments)
+			}}
+
+			var saved [][]*model.Translation
+			service := newPolishTestService(polisher, &saved)
+			result, err := service.CreateTranslation(context.Background(), "trans-1", "ja")
+			require.NoError(t, err)
+
+			// The first batch is retried, then the raw translations are all that is saved
+			assert.Equal(t, batchAttempts, polisher.calls)
+			require.Len(t, saved, 1)
+			assert.Equal(t, ProviderPlamo, result.Source)
+		})
+	}
+}
+
+func TestTranslationService_CreateTranslation_NothingPolished(t *testing.T) {
+	// The post-edit pass batches no segment the PLaMo pass translated
+	polisher := &mockPolisher{PolishFunc: func(ctx context.Context, segments []PolishSegment, sourceLang, targetLang string) ([]string, error) {
+		t.Fatal("no segment should be post-edited")
+		return nil, nil
+	}}
+	batches := numberedBatches(2)
+	created := 0
+	transcriptionRepo := &mockTranscriptionRepo{
+		GetSegmentsFunc: func(ctx context.Context, id string) ([]*model.TranscriptionSegment, error) {
+			return batches[0].Segments, nil
+		},
+		GetFunc: func(ctx context.Context, id string) (*model.Transcription, error) {
+			return &model.Transcription{ID: id, Language: "en"}, nil
+		},
+	}
+	var saved [][]*model.Translation
+	translationRepo := &mockTranslationRepo{
+		CreateBatchFunc: func(ctx context.Context, translations []*model.Translation) error {
+			saved = append(saved, translations)
+			return nil
+		},
+	}
+	batchProcessor := &mockBatchProcessor{
+		CreateBatchesFunc: func(segments []*model.TranscriptionSegment, sourceLang string, maxTokens int) ([]SegmentBatch, error) {
+			created++
+			if created > 1 {
+				return []SegmentBatch{batches[1]}, nil
+			}
+			return batches[:1], nil
+		},
+		TranslateBatchWithFallbackFunc: func(batch SegmentBatch, plamoService PlamoService, ctx context.Context, sourceLang, targetLang string) ([]*TranslationSegment, error) {
+			return echoBatch(batch), nil
+		},
+	}
+
+	service := NewTranslationServiceWithPolisher(transcriptionRepo, translationRepo, nil, NewPlamoService(&MockCmdRunner{}), batchProcessor, 1, polisher)
+	result, err := service.CreateTranslation(context.Background(), "trans-1", "ja")
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, ProviderPlamo, result.Source)
+}
+
+func TestTranslationService_CreateTranslation_Style(t *testing.T) {
+	polisher := &mockPolisher{PolishFunc: func(ctx context.Context, segments []PolishSegment, sourceLang, targetLang string) ([]string, error) {
+		var texts []string
+		for _, seg := range segments {
+			texts = append(texts, "simple "+seg.Source)
+		}
+		return texts, nil
+	}}
+
+	// Only the styled variant is saved
+	var saved [][]*model.Translation
+	service := newPolishTestService(polisher, &saved)
+	result, err := service.CreateTranslation(WithStyle(context.Background(), StyleSimple), "trans-1", "ja")
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	for _, translation := range saved[0] {
+		assert.Equal(t, SourcePolished, translation.Source)
+		assert.Equal(t, StyleSimple, translation.Style)
+	}
+	assert.Equal(t, StyleSimple, result.Style)
+
+	// A failed post-edit saves nothing
+	polisher.PolishFunc = func(ctx context.Context, segments []PolishSegment, sourceLang, targetLang string) ([]string, error) {
+		return nil, errors.New("connection refused")
+	}
+	saved = nil
+	_, err = service.CreateTranslation(WithStyle(context.Background(), StyleSimple), "trans-1", "ja")
+	assert.ErrorContains(t, err, "failed to apply the simple style")
+	assert.Empty(t, saved)
+
+	// Styles need a polisher, and must be known
+	_, err = newPolishTestService(nil, &saved).CreateTranslation(WithStyle(context.Background(), StyleFormal), "trans-1", "ja")
+	assert.ErrorContains(t, err, "--polish")
+	_, err = service.CreateTranslation(WithStyle(context.Background(), "poetic"), "trans-1", "ja")
+	assert.ErrorContains(t, err, "unsupported style: poetic")
+}
+
+func TestOpenAIPolisher_Polish(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		fmt.Fprint(w, `{"choices": [{"message": {"role": "assistant", "content": "`+"```json\\n[\\\"こんにちは。\\\", \\\" 世界 \\\"]\\n```"+`"}}]}`)
+	}))
+	defer server.Close()
+
+	polisher, err := NewOpenAIPolisherWithClient(OpenAIPolisherOptions{BaseURL: server.URL + "/v1/", Model: "gpt-4o-mini", APIKey: "secret"}, server.Client())
+	require.NoError(t, err)
+
+	texts, err := polisher.Polish(context.Background(), []PolishSegment{
+		{Source: "Hello.", Translation: "こんにちわ"},
+		{Source: "World", Translation: "世界"},
+	}, "en", "ja")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"こんにちは。", "世界"}, texts)
+	assert.Contains(t, body, `"model":"gpt-4o-mini"`)
+	assert.Contains(t, body, "English video subtitles")
+	assert.Contains(t, body, `\"translation\":\"こんにちわ\"`)
+	assert.NotContains(t, body, "language learners")
+
+	_, err = polisher.Polish(WithStyle(context.Background(), StyleSimple), []PolishSegment{{Source: "Hello.", Translation: "こんにちわ"}}, "en", "ja")
+	require.NoError(t, err)
+	assert.Contains(t, body, "language learners")
+}
+
+func TestOpenAIPolisher_Errors(t *testing.T) {
+	_, err := NewOpenAIPolisher(OpenAIPolisherOptions{})
+	assert.ErrorContains(t, err, "model is required")
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		check   func(t *testing.T, err error)
+	}{
+		{
+			name: "rate limited",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", "7")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			check: func(t *testing.T, err error) {
+				var limited *RateLimitedError
+				require.ErrorAs(t, err, &limited)
+				assert.Equal(t, ProviderOpenAI, limited.Provider)
+				assert.Equal(t, 7*time.Second, limited.RetryAfter)
+			},
+		},
+		{
+			name: "server error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "model overloaded", http.StatusInternalServerError)
+			},
+			check: func(t *testing.T, err error) {
+				assert.ErrorContains(t, err, "model overloaded")
+			},
+		},
+		{
+			name: "reply is not a JSON array",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"choices": [{"message": {"content": "Sure! Here you go."}}]}`)
+			},
+			check: func(t *testing.T, err error) {
+				assert.ErrorContains(t, err, "not a JSON array")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			polisher, err := NewOpenAIPolisherWithClient(OpenAIPolisherOptions{BaseURL: server.URL, Model: "m"}, server.Client())
+			require.NoError(t, err)
+			_, err = polisher.Polish(context.Background(), []PolishSegment{{Source: "a", Translation: "b"}}, "en", "ja")
+			tt.check(t, err)
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package translation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+)
+
+// probeSentences is the built-in benchmark of translation probe: short everyday sentences of
+// the kind spoken in videos, in every language they are available in
+var probeSentences = []map[string]string{
+	{
+		"en": "Thank you for watching this video.",
+		"ja": "この動画を見てくれてありがとうございます。",
+		"es": "Gracias por ver este vídeo.",
+		"fr": "Merci d'avoir regardé cette vidéo.",
+		"de": "Danke, dass du dieses Video angesehen hast.",
+	},
+	{
+		"en": "Today we are going to cook a simple dinner.",
+		"ja": "今日は簡単な夕食を作ります。",
+		"es": "Hoy vamos a cocinar una cena sencilla.",
+		"fr": "Aujourd'hui, nous allons préparer un dîner simple.",
+		"de": "Heute kochen wir ein einfaches Abendessen.",
+	},
+	{
+		"en": "The train was late because of the heavy snow.",
+		"ja": "大雪のせいで電車が遅れました。",
+		"es": "El tren llegó tarde por la fuerte nevada.",
+		"fr": "Le train était en retard à cause de la neige abondante.",
+		"de": "Der Zug hatte wegen des starken Schnees Verspätung.",
+	},
+	{
+		"en": "Could you tell me where the nearest station is?",
+		"ja": "一番近い駅はどこか教えていただけますか？",
+		"es": "¿Podría decirme dónde está la estación más cercana?",
+		"fr": "Pourriez-vous me dire où se trouve la gare la plus proche ?",
+		"de": "Könnten Sie mir sagen, wo der nächste Bahnhof ist?",
+	},
+	{
+		"en": "I started learning the guitar last year.",
+		"ja": "去年ギターを習い始めました。",
+		"es": "Empecé a aprender a tocar la guitarra el año pasado.",
+		"fr": "J'ai commencé à apprendre la guitare l'année dernière.",
+		"de": "Ich habe letztes Jahr angefangen, Gitarre zu lernen.",
+	},
+	{
+		"en": "If you have any questions, please leave a comment below.",
+		"ja": "質問があれば、下にコメントを残してください。",
+		"es": "Si tienes alguna pregunta, deja un comentario abajo.",
+		"fr": "Si vous avez des questions, laissez un commentaire ci-dessous.",
+		"de": "Wenn ihr Fragen habt, hinterlasst unten einen Kommentar.",
+	},
+}
+
+// ProbeLanguages returns the languages of the built-in benchmark, sorted
+func ProbeLanguages() []string {
+	var languages []string
+	for language := range probeSentences[0] {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// ProbeOptions configures Probe
+type ProbeOptions struct {
+	From, To  string // Language pair, e.g. en and ja
+	RoundTrip bool   // Translate each translation back and compare it with the source
+}
+
+// ProbeItem is the outcome of one benchmark sentence
+type ProbeItem struct {
+	Source              string        `json:"source"`
+	Reference           string        `json:"reference"` // Built-in translation of the source
+	Translation         string        `json:"translation"`
+	Similarity          float64       `json:"similarity"` // Of the translation to the reference (0-1)
+	Latency             time.Duration `json:"latency_ns"`
+	BackTranslation     string        `json:"back_translation,omitempty"`
+	RoundTripSimilarity float64       `json:"round_trip_similarity,omitempty"` // Of the back translation to the source (0-1)
+	BackLatency         time.Duration `json:"back_latency_ns,omitempty"`
+	Error               string        `json:"error,omitempty"`
+}
+
+// ProbeResult is the outcome of a translation probe
+type ProbeResult struct {
+	From      string       `json:"from"`
+	To        string       `json:"to"`
+	RoundTrip bool         `json:"round_trip"`
+	Items     []*ProbeItem `json:"items"`
+	Failed    int          `json:"failed"`
+
+	// Means over the sentences that translated
+	Similarity          float64       `json:"similarity"`
+	RoundTripSimilarity float64       `json:"round_trip_similarity,omitempty"`
+	Latency             time.Duration `json:"latency_ns"` // Mean latency of one request
+
+	Elapsed        time.Duration `json:"elapsed_ns"`
+	CharsPerSecond float64       `json:"chars_per_second"` // Source characters translated per second of translation time
+}
+
+// Probe translates the built-in benchmark sentences of a language pair one request at a time,
+// scores each translation against the built-in reference with Similarity, and measures latency
+// and throughput. A sentence failing to translate is recorded and the probe
+// continues. Similarity only flags broken setups (wrong language, truncation, garbage); good
+// translations worded differently from the reference score well below 1.
+func Probe(ctx context.Context, plamo PlamoService, opts ProbeOptions) (*ProbeResult, error) {
+	if opts.From == opts.To {
+		return nil, errors.New(errors.CodeInvalidArg, "source and target languages must differ")
+	}
+	for _, language := range []string{opts.From, opts.To} {
+		if _, ok := probeSentences[0][language]; !ok {
+			return nil, errors.New(errors.CodeInvalidArg, fmt.Sprintf("no built-in benchmark for language %q (available: %s)", language, strings.Join(ProbeLanguages(), ", ")))
+		}
+	}
+
+	result := &ProbeResult{From: opts.From, To: opts.To, RoundTrip: opts.RoundTrip, Items: []*ProbeItem{}}
+	var similarity, roundTrip float64
+	var busy time.Duration
+	requests, chars := 0, 0
+	started := time.Now()
+
+	for _, sentences := range probeSentences {
+		item := &ProbeItem{Source: sentences[opts.From], Reference: sentences[opts.To]}
+		result.Items = append(result.Items, item)
+
+		translation, latency, err := timedTranslate(ctx, plamo, item.Source, opts.From, opts.To)
+		if err != nil {
+			item.Error = err.Error()
+			result.Failed++
+			continue
+		}
+		item.Translation, item.Latency = translation, latency
+		item.Similarity = Similarity(item.Translation, item.Reference)
+		similarity += item.Similarity
+		busy += latency
+		requests++
+		chars += utf8.RuneCountInString(item.Source)
+
+		if !opts.RoundTrip {
+			continue
+		}
+		back, latency, err := timedTranslate(ctx, plamo, item.Translation, opts.To, opts.From)
+		if err != nil {
+			item.Error = "round trip: " + err.Error()
+			result.Failed++
+			continue
+		}
+		item.BackTranslation, item.BackLatency = back, latency
+		item.RoundTripSimilarity = Similarity(item.BackTranslation, item.Source)
+		roundTrip += item.RoundTripSimilarity
+		busy += latency
+		requests++
+		chars += utf8.RuneCountInString(item.Translation)
+	}
+
+	result.Elapsed = time.Since(started)
+	if translated := len(result.Items) - countUntranslated(result.Items); translated > 0 {
+		result.Similarity = similarity / float64(translated)
+	}
+	if opts.RoundTrip {
+		if roundTrips := countRoundTrips(result.Items); roundTrips > 0 {
+			result.RoundTripSimilarity = roundTrip / float64(roundTrips)
+		}
+	}
+	if requests > 0 {
+		result.Latency = busy / time.Duration(requests)
+	}
+	if busy > 0 {
+		result.CharsPerSecond = float64(chars) / busy.Seconds()
+	}
+	return result, nil
+}
+
+// timedTranslate translates text and measures how long the request took
+func timedTranslate(ctx context.Context, plamo PlamoService, text, from, to string) (string, time.Duration, error) {
+	started := time.Now()
+	translation, err := plamo.Translate(ctx, text, from, to)
+	if err != nil {
+		return "", 0, err
+	}
+	if strings.TrimSpace(translation) == "" {
+		return "", 0, fmt.Errorf("empty translation")
+	}
+	return translation, time.Since(started), nil
+}
+
+// countUntranslated counts the items without a translation
+func countUntranslated(items []*ProbeItem) int {
+	count := 0
+	for _, item := range items {
+		if item.Translation == "" {
+			count++
+		}
+	}
+	return count
+}
+
+// countRoundTrips counts the items translated back
+func countRoundTrips(items []*ProbeItem) int {
+	count := 0
+	for _, item := range items {
+		if item.BackTranslation != "" {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,64 @@
+package translation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// referencePlamoService translates benchmark sentences to their built-in reference and fails on
+// the sentences listed in fail
+type referencePlamoService struct {
+	PlamoService
+	fail map[string]bool
+}
+
+func (s *referencePlamoService) Translate(ctx context.Context, text string, fromLang, toLang string) (string, error) {
+	if s.fail[text] {
+		return "", fmt.Errorf("server not responding")
+	}
+	for _, sentences := range probeSentences {
+		if sentences[fromLang] == text {
+			return sentences[toLang], nil
+		}
+	}
+	return "", nil
+}
+
+func TestProbe(t *testing.T) {
+	plamo := &referencePlamoService{fail: map[string]bool{probeSentences[2]["en"]: true}}
+
+	result, err := Probe(context.Background(), plamo, ProbeOptions{From: "en", To: "ja", RoundTrip: true})
+	require.NoError(t, err)
+	require.Len(t, result.Items, len(probeSentences))
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, "server not responding", result.Items[2].Error)
+	assert.Equal(t, probeSentences[0]["ja"], result.Items[0].Translation)
+	assert.Equal(t, probeSentences[0]["en"], result.Items[0].BackTranslation)
+	assert.Equal(t, 1.0, result.Similarity)
+	assert.Equal(t, 1.0, result.RoundTripSimilarity)
+	assert.Positive(t, result.CharsPerSecond)
+
+	// Without a round trip nothing is translated back
+	result, err = Probe(context.Background(), &referencePlamoService{}, ProbeOptions{From: "ja", To: "de"})
+	require.NoError(t, err)
+	assert.Zero(t, result.Failed)
+	assert.Empty(t, result.Items[0].BackTranslation)
+	assert.Zero(t, result.RoundTripSimilarity)
+
+	// A blank translation is a failure
+	result, err = Probe(context.Background(), &responsePlamoService{responses: []string{" "}}, ProbeOptions{From: "en", To: "es"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, "empty translation", result.Items[0].Error)
+}
+
+func TestProbe_InvalidPair(t *testing.T) {
+	for _, opts := range []ProbeOptions{{From: "en", To: "en"}, {From: "en", To: "xx"}} {
+		_, err := Probe(context.Background(), &referencePlamoService{}, opts)
+		assert.Error(t, err, opts)
+	}
+}
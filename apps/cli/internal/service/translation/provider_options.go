@@ -0,0 +1,123 @@
+package translation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ProviderOptions are provider settings passed through to the translation providers without the
+// pipeline knowing them (translation create --provider-opt key=value), so new provider features
+// need no code changes. Keys prefixed with a provider name (openai.temperature) go to that
+// provider; unprefixed keys go to PLaMo, which makes the translation.
+type ProviderOptions map[string]string
+
+// optionProviders lists the providers options can be addressed to
+var optionProviders = []string{ProviderPlamo, ProviderOpenAI}
+
+// ParseProviderOptions parses key=value pairs. Later pairs replace earlier ones with the same key.
+func ParseProviderOptions(pairs []string) (ProviderOptions, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	options := make(ProviderOptions, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid provider option %q (use key=value, e.g. openai.temperature=0.2)", pair)
+		}
+		if provider, _, prefixed := strings.Cut(key, "."); prefixed && !isOptionProvider(provider) {
+			return nil, fmt.Errorf("unknown provider %q in option %q (supported: %s)", provider, key, strings.Join(optionProviders, ", "))
+		}
+		options[key] = value
+	}
+	return options, nil
+}
+
+// For returns the options addressed to provider, without their provider prefix
+func (o ProviderOptions) For(provider string) map[string]string {
+	options := make(map[string]string)
+	for key, value := range o {
+		name, option, prefixed := strings.Cut(key, ".")
+		switch {
+		case prefixed && name == provider:
+			options[option] = value
+		case !prefixed && provider == ProviderPlamo:
+			options[key] = value
+		}
+	}
+	return options
+}
+
+// isOptionProvider reports whether options can be addressed to provider
+func isOptionProvider(provider string) bool {
+	for _, p := range optionProviders {
+		if p == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// providerOptionsKey is the context key of the provider options of a translation
+type providerOptionsKey struct{}
+
+// WithProviderOptions returns a context whose translations are made and stored with options
+func WithProviderOptions(ctx context.Context, options ProviderOptions) context.Context {
+	if len(options) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, providerOptionsKey{}, options)
+}
+
+// providerOptionsFrom returns the provider options of ctx, nil when there are none
+func providerOptionsFrom(ctx context.Context) ProviderOptions {
+	options, _ := ctx.Value(providerOptionsKey{}).(ProviderOptions)
+	return options
+}
+
+// plamoOptionArgs returns the PLaMo options of ctx as plamo-translate flags, sorted by name
+func plamoOptionArgs(ctx context.Context) []string {
+	options := providerOptionsFrom(ctx).For(ProviderPlamo)
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var args []string
+	for _, name := range names {
+		args = append(args, "--"+name, options[name])
+	}
+	return args
+}
+
+// withRequestOptions sets the OpenAI options of ctx as fields of a JSON request body. Values that
+// are valid JSON (numbers, booleans, objects) are sent as such, anything else as a string.
+func withRequestOptions(ctx context.Context, body []byte) ([]byte, error) {
+	options := providerOptionsFrom(ctx).For(ProviderOpenAI)
+	if len(options) == 0 {
+		return body, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range options {
+		if json.Valid([]byte(value)) {
+			fields[name] = json.RawMessage(value)
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		fields[name] = encoded
+	}
+	return json.Marshal(fields)
+}
@@ -0,0 +1,79 @@
+package translation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+func TestParseProviderOptions(t *testing.T) {
+	options, err := ParseProviderOptions([]string{"openai.temperature=0.2", "max-new-tokens=512", "openai.temperature=0.4", "openai.stop="})
+	require.NoError(t, err)
+	assert.Equal(t, ProviderOptions{"openai.temperature": "0.4", "max-new-tokens": "512", "openai.stop": ""}, options)
+	assert.Equal(t, map[string]string{"max-new-tokens": "512"}, options.For(ProviderPlamo))
+	assert.Equal(t, map[string]string{"temperature": "0.4", "stop": ""}, options.For(ProviderOpenAI))
+
+	options, err = ParseProviderOptions(nil)
+	require.NoError(t, err)
+	assert.Nil(t, options)
+
+	_, err = ParseProviderOptions([]string{"temperature"})
+	assert.ErrorContains(t, err, "key=value")
+	_, err = ParseProviderOptions([]string{"=1"})
+	assert.ErrorContains(t, err, "key=value")
+	_, err = ParseProviderOptions([]string{"deepl.formality=more"})
+	assert.ErrorContains(t, err, `unknown provider "deepl"`)
+}
+
+func TestProviderOptions_PassedToProviders(t *testing.T) {
+	ctx := WithProviderOptions(context.Background(), ProviderOptions{"max-new-tokens": "512", "openai.temperature": "0.2", "openai.user": "ytlang"})
+
+	t.Run("plamo flags", func(t *testing.T) {
+		var got []string
+		service := NewPlamoService(&MockCmdRunner{RunFunc: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			got = args
+			return []byte("こんにちは"), nil
+		}})
+		_, err := service.Translate(ctx, "Hello", "en", "ja")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"--max-new-tokens", "512"}, got[len(got)-2:])
+	})
+
+	t.Run("openai request fields", func(t *testing.T) {
+		var body string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			body = string(data)
+			fmt.Fprint(w, `{"choices": [{"message": {"role": "assistant", "content": "[\"こんにちは\"]"}}]}`)
+		}))
+		defer server.Close()
+
+		polisher, err := NewOpenAIPolisherWithClient(OpenAIPolisherOptions{BaseURL: server.URL, Model: "gpt-4o-mini"}, server.Client())
+		require.NoError(t, err)
+		_, err = polisher.Polish(ctx, []PolishSegment{{Source: "Hello", Translation: "こんちには"}}, "en", "ja")
+		require.NoError(t, err)
+		assert.Contains(t, body, `"temperature":0.2`)
+		assert.Contains(t, body, `"user":"ytlang"`)
+	})
+
+	t.Run("stored with the translations", func(t *testing.T) {
+		var saved [][]*model.Translation
+		service := newPolishTestService(&mockPolisher{PolishFunc: func(ctx context.Context, segments []PolishSegment, sourceLang, targetLang string) ([]string, error) {
+			return make([]string, len(segments)), nil
+		}}, &saved)
+		_, err := service.CreateTranslation(ctx, "trans-1", "ja")
+		require.NoError(t, err)
+		require.NotEmpty(t, saved)
+		for _, translation := range saved[0] {
+			assert.Equal(t, "0.2", translation.ProviderOptions["openai.temperature"])
+		}
+	})
+}
@@ -0,0 +1,206 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// Alignment QA warning codes
+const (
+	WarningEmpty       = "empty_translation" // Missing or blank translation
+	WarningCopy        = "untranslated_copy" // Translation repeats the source text
+	WarningLengthRatio = "length_ratio"      // Length ratio far from the transcription's median
+)
+
+const (
+	defaultRatioFactor  = 3.0 // Allowed deviation from the median ratio, in either direction
+	copySimilarity      = 0.9 // Similarity from which a translation counts as a copy
+	minRatioSourceChars = 10  // Shorter sources give too noisy ratios to check
+	minRatioSamples     = 5   // Segments needed before a median ratio is meaningful
+)
+
+// WarningRepository interface for storing alignment QA warnings
+type WarningRepository interface {
+	ReplaceByTranscriptionID(ctx context.Context, transcriptionID, targetLanguage string, warnings []*model.TranslationWarning) error
+}
+
+// QAOptions configures an alignment check of one translation
+type QAOptions struct {
+	TranscriptionID string
+	TargetLanguage  string
+	RatioFactor     float64 // Flag ratios above median*factor or below median/factor (default 3)
+}
+
+// QAWarning is a suspicious segment pair found by CheckAlignment
+type QAWarning struct {
+	SegmentIndex   int    `json:"segment_index"`
+	StartTime      string `json:"start_time"`
+	EndTime        string `json:"end_time"`
+	Text           string `json:"text"`
+	TranslatedText string `json:"translated_text"`
+	Code           string `json:"code"`
+	Message        string `json:"message"`
+}
+
+// QAReport summarizes an alignment check
+type QAReport struct {
+	TranscriptionID string         `json:"transcription_id"`
+	TargetLanguage  string         `json:"target_language"`
+	Segments        int            `json:"segments"`
+	MedianRatio     float64        `json:"median_ratio"` // Median target/source character ratio (0 when too few samples)
+	Counts          map[string]int `json:"counts"`       // Warning code -> number of segments
+	Warnings        []*QAWarning   `json:"warnings"`
+}
+
+// CheckAlignment validates every segment pair of a translation and flags empty translations,
+// untranslated copies and length-ratio outliers. Length ratios are compared with the
+// transcription's own median so the check works for any language pair. The warnings
+// replace the ones stored by the previous check.
+func (s *translationService) CheckAlignment(ctx context.Context, opts QAOptions) (*QAReport, error) {
+	factor := opts.RatioFactor
+	if factor <= 1 {
+		factor = defaultRatioFactor
+	}
+
+	segments, err := s.transcriptionRepo.GetSegments(ctx, opts.TranscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get segments: %w", err)
+	}
+	if len(segments) == 0 {
+		return nil, errors.New("no segments found")
+	}
+
+	stored, err := s.loadTranslations(ctx, opts.TranscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the translation readers see: approved first, otherwise the newest
+	chosen := make(map[string]*model.Translation)
+	for _, t := range stored {
+		if t.TargetLanguage != opts.TargetLanguage {
+			continue
+		}
+		if current, ok := chosen[t.TranscriptionSegmentID]; !ok || (t.Approved && !current.Approved) {
+			chosen[t.TranscriptionSegmentID] = t
+		}
+	}
+	if len(chosen) == 0 {
+		return nil, fmt.Errorf("no %s translations found for transcription %s", opts.TargetLanguage, opts.TranscriptionID)
+	}
+
+	report := &QAReport{
+		TranscriptionID: opts.TranscriptionID,
+		TargetLanguage:  opts.TargetLanguage,
+		Segments:        len(segments),
+		Counts:          map[string]int{},
+		Warnings:        []*QAWarning{},
+	}
+
+	flag := func(segment *model.TranscriptionSegment, translated, code, message string) {
+		report.Counts[code]++
+		report.Warnings = append(report.Warnings, &QAWarning{
+			SegmentIndex:   segment.SegmentIndex,
+			StartTime:      segment.StartTime,
+			EndTime:        segment.EndTime,
+			Text:           segment.Text,
+			TranslatedText: translated,
+			Code:           code,
+			Message:        message,
+		})
+	}
+
+	ratios := make(map[int]float64) // segment position -> target/source character ratio
+	for i, segment := range segments {
+		source := strings.TrimSpace(segment.Text)
+		t, ok := chosen[segment.ID]
+		if !ok {
+			flag(segment, "", WarningEmpty, "segment has no translation")
+			continue
+		}
+		target := strings.TrimSpace(t.TranslatedText)
+
+		switch {
+		case target == "" && source != "":
+			flag(segment, t.TranslatedText, WarningEmpty, "translation is empty")
+		case hasLetters(source) && Similarity(strings.ToLower(source), strings.ToLower(target)) >= copySimilarity:
+			flag(segment, t.TranslatedText, WarningCopy, "translation repeats the source text")
+		case utf8.RuneCountInString(source) >= minRatioSourceChars:
+			ratios[i] = float64(utf8.RuneCountInString(target)) / float64(utf8.RuneCountInString(source))
+		}
+	}
+
+	if len(ratios) >= minRatioSamples {
+		report.MedianRatio = median(ratios)
+		for i, ratio := range ratios {
+			if ratio > report.MedianRatio*factor || ratio < report.MedianRatio/factor {
+				flag(segments[i], chosen[segments[i].ID].TranslatedText, WarningLengthRatio,
+					fmt.Sprintf("length ratio %.2f is far from the median %.2f", ratio, report.MedianRatio))
+			}
+		}
+	}
+
+	sort.SliceStable(report.Warnings, func(i, j int) bool {
+		return report.Warnings[i].SegmentIndex < report.Warnings[j].SegmentIndex
+	})
+
+	if s.warningRepo != nil {
+		if err := s.warningRepo.ReplaceByTranscriptionID(ctx, opts.TranscriptionID, opts.TargetLanguage,
+			storedWarnings(segments, report.Warnings, opts.TargetLanguage)); err != nil {
+			return nil, fmt.Errorf("failed to save warnings: %w", err)
+		}
+	}
+
+	return report, nil
+}
+
+// storedWarnings converts report warnings to records keyed by segment ID
+func storedWarnings(segments []*model.TranscriptionSegment, warnings []*QAWarning, targetLanguage string) []*model.TranslationWarning {
+	segmentIDs := make(map[int]string, len(segments))
+	for _, segment := range segments {
+		segmentIDs[segment.SegmentIndex] = segment.ID
+	}
+
+	records := make([]*model.TranslationWarning, len(warnings))
+	for i, w := range warnings {
+		records[i] = &model.TranslationWarning{
+			TranscriptionSegmentID: segmentIDs[w.SegmentIndex],
+			TargetLanguage:         targetLanguage,
+			Code:                   w.Code,
+			Message:                w.Message,
+		}
+	}
+	return records
+}
+
+// hasLetters reports whether text contains a letter, so numbers and symbols are not flagged as copies
+func hasLetters(text string) bool {
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// median returns the median of the map's values
+func median(values map[int]float64) float64 {
+	sorted := make([]float64, 0, len(values))
+	for _, v := range values {
+		sorted = append(sorted, v)
+	}
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
@@ -0,0 +1,103 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingWarningRepo keeps the last replaced warnings
+type recordingWarningRepo struct {
+	transcriptionID string
+	targetLanguage  string
+	warnings        []*model.TranslationWarning
+	err             error
+}
+
+func (r *recordingWarningRepo) ReplaceByTranscriptionID(ctx context.Context, transcriptionID, targetLanguage string, warnings []*model.TranslationWarning) error {
+	r.transcriptionID, r.targetLanguage, r.warnings = transcriptionID, targetLanguage, warnings
+	return r.err
+}
+
+func TestTranslationService_CheckAlignment(t *testing.T) {
+	// Six ordinary pairs settle the median ratio, then one pair per problem
+	var segments []*model.TranscriptionSegment
+	var stored []*model.Translation
+	for i := 0; i < 6; i++ {
+		id := fmt.Sprintf("seg-%d", i)
+		segments = append(segments, &model.TranscriptionSegment{ID: id, SegmentIndex: i, Text: "Ordinary sentence."})
+		stored = append(stored, &model.Translation{TranscriptionSegmentID: id, TargetLanguage: "ja", TranslatedText: "普通の文です。ですです"})
+	}
+	segments = append(segments,
+		&model.TranscriptionSegment{ID: "seg-missing", SegmentIndex: 6, Text: "Nobody translated me"},
+		&model.TranscriptionSegment{ID: "seg-blank", SegmentIndex: 7, Text: "Blank translation"},
+		&model.TranscriptionSegment{ID: "seg-copy", SegmentIndex: 8, Text: "Machine learning"},
+		&model.TranscriptionSegment{ID: "seg-long", SegmentIndex: 9, Text: "Short source text"},
+		&model.TranscriptionSegment{ID: "seg-number", SegmentIndex: 10, Text: "2024"},
+	)
+	stored = append(stored,
+		&model.Translation{TranscriptionSegmentID: "seg-blank", TargetLanguage: "ja", TranslatedText: "  "},
+		&model.Translation{TranscriptionSegmentID: "seg-copy", TargetLanguage: "ja", TranslatedText: "machine learning"},
+		&model.Translation{TranscriptionSegmentID: "seg-long", TargetLanguage: "ja", TranslatedText: "とても長い翻訳がここに続きます。とても長い翻訳がここに続きます。とても長い翻訳がここに続きます。"},
+		&model.Translation{TranscriptionSegmentID: "seg-number", TargetLanguage: "ja", TranslatedText: "2024"},
+		// Translations into other languages are not checked
+		&model.Translation{TranscriptionSegmentID: "seg-0", TargetLanguage: "fr", TranslatedText: "Phrase ordinaire."},
+	)
+
+	newService := func(warningRepo WarningRepository) TranslationService {
+		transcriptionRepo := &mockTranscriptionRepo{
+			GetSegmentsFunc: func(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error) {
+				return segments, nil
+			},
+		}
+		translationRepo := &mockTranslationRepo{
+			ListByTranscriptionIDFunc: func(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error) {
+				if offset > 0 {
+					return nil, nil
+				}
+				return stored, nil
+			},
+		}
+		return NewTranslationServiceWithQA(transcriptionRepo, translationRepo, warningRepo, nil, &mockBatchProcessor{}, 1)
+	}
+
+	t.Run("flags suspicious pairs and stores warnings", func(t *testing.T) {
+		warningRepo := &recordingWarningRepo{}
+		report, err := newService(warningRepo).CheckAlignment(context.Background(), QAOptions{TranscriptionID: "trans-1", TargetLanguage: "ja"})
+		require.NoError(t, err)
+
+		assert.Equal(t, 11, report.Segments)
+		assert.Equal(t, map[string]int{WarningEmpty: 2, WarningCopy: 1, WarningLengthRatio: 1}, report.Counts)
+
+		require.Len(t, report.Warnings, 4)
+		assert.Equal(t, 6, report.Warnings[0].SegmentIndex)
+		assert.Equal(t, "segment has no translation", report.Warnings[0].Message)
+		assert.Equal(t, WarningEmpty, report.Warnings[1].Code)
+		assert.Equal(t, WarningCopy, report.Warnings[2].Code)
+		assert.Equal(t, WarningLengthRatio, report.Warnings[3].Code)
+		assert.Equal(t, 9, report.Warnings[3].SegmentIndex)
+
+		assert.Equal(t, "trans-1", warningRepo.transcriptionID)
+		assert.Equal(t, "ja", warningRepo.targetLanguage)
+		require.Len(t, warningRepo.warnings, 4)
+		assert.Equal(t, "seg-missing", warningRepo.warnings[0].TranscriptionSegmentID)
+		assert.Equal(t, "seg-long", warningRepo.warnings[3].TranscriptionSegmentID)
+	})
+
+	t.Run("fails without translations in the target language", func(t *testing.T) {
+		_, err := newService(nil).CheckAlignment(context.Background(), QAOptions{TranscriptionID: "trans-1", TargetLanguage: "de"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no de translations")
+	})
+
+	t.Run("returns storage errors", func(t *testing.T) {
+		_, err := newService(&recordingWarningRepo{err: errors.New("db down")}).CheckAlignment(context.Background(), QAOptions{TranscriptionID: "trans-1", TargetLanguage: "ja"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to save warnings")
+	})
+}
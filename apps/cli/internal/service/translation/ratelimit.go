@@ -0,0 +1,149 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Backoff after a provider reports a rate limit without saying how long to wait
+const (
+	DefaultRateLimitRetries = 5
+	initialBackoff          = time.Second
+	maxBackoff              = time.Minute
+)
+
+// RateLimit caps how hard the translation pipeline drives one provider. Zero fields are unlimited.
+type RateLimit struct {
+	RequestsPerMinute int // Requests started per minute, spaced evenly
+	MaxParallel       int // Requests in flight at once, across all workers
+	MaxRetries        int // Retries of a rate-limited request; defaults to DefaultRateLimitRetries
+}
+
+// RateLimitedError is returned by providers whose API refused a request for exceeding its quota
+// (HTTP 429). The rate limiter retries these after RetryAfter, or with exponential backoff when
+// the API did not say.
+type RateLimitedError struct {
+	Provider   string
+	RetryAfter time.Duration // Wait requested by the API (Retry-After); 0 when not given
+}
+
+// Error describes the refusal
+func (e *RateLimitedError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%s rate limit exceeded (retry after %s)", e.Provider, e.RetryAfter)
+	}
+	return fmt.Sprintf("%s rate limit exceeded", e.Provider)
+}
+
+// rateLimitedPlamoService spaces and bounds the requests of the wrapped service and retries
+// requests refused for exceeding the provider's quota
+type rateLimitedPlamoService struct {
+	PlamoService
+	limit    RateLimit
+	interval time.Duration // Minimum time between request starts; 0 when unlimited
+	slots    chan struct{} // Semaphore of MaxParallel; nil when unlimited
+
+	mu   sync.Mutex
+	next time.Time // Earliest start of the next request
+
+	// Replaced in tests
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewRateLimitedPlamoService wraps a translation service so that it stays within limit. The
+// limit holds across all batch workers sharing the returned service.
+func NewRateLimitedPlamoService(inner PlamoService, limit RateLimit) PlamoService {
+	if limit.MaxRetries <= 0 {
+		limit.MaxRetries = DefaultRateLimitRetries
+	}
+
+	s := &rateLimitedPlamoService{
+		PlamoService: inner,
+		limit:        limit,
+		now:          time.Now,
+		sleep:        sleepContext,
+	}
+	if limit.RequestsPerMinute > 0 {
+		s.interval = time.Minute / time.Duration(limit.RequestsPerMinute)
+	}
+	if limit.MaxParallel > 0 {
+		s.slots = make(chan struct{}, limit.MaxParallel)
+	}
+	return s
+}
+
+// Translate waits for a free slot and the next request start, then forwards the request,
+// backing off while the provider reports its rate limit
+func (s *rateLimitedPlamoService) Translate(ctx context.Context, text string, fromLang, toLang string) (string, error) {
+	if s.slots != nil {
+		select {
+		case s.slots <- struct{}{}:
+			defer func() { <-s.slots }()
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	backoff := initialBackoff
+	for retry := 0; ; retry++ {
+		if err := s.wait(ctx); err != nil {
+			return "", err
+		}
+
+		translated, err := s.PlamoService.Translate(ctx, text, fromLang, toLang)
+		var limited *RateLimitedError
+		if !errors.As(err, &limited) || retry >= s.limit.MaxRetries {
+			return translated, err
+		}
+
+		delay := limited.RetryAfter
+		if delay <= 0 {
+			delay = backoff
+			backoff = min(2*backoff, maxBackoff)
+		}
+		// Hold back every worker, not just this one: the quota is shared
+		s.delayAll(delay)
+	}
+}
+
+// wait blocks until this request may start and reserves the start after it
+func (s *rateLimitedPlamoService) wait(ctx context.Context) error {
+	s.mu.Lock()
+	now := s.now()
+	start := now
+	if s.next.After(now) {
+		start = s.next
+	}
+	s.next = start.Add(s.interval)
+	s.mu.Unlock()
+
+	if delay := start.Sub(now); delay > 0 {
+		return s.sleep(ctx, delay)
+	}
+	return ctx.Err()
+}
+
+// delayAll postpones the next request start by at least delay from now
+func (s *rateLimitedPlamoService) delayAll(delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if resume := s.now().Add(delay); resume.After(s.next) {
+		s.next = resume
+	}
+}
+
+// sleepContext sleeps for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
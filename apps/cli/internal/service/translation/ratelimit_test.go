@@ -0,0 +1,167 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedPlamoService answers Translate calls with the next scripted error (nil translates)
+type scriptedPlamoService struct {
+	PlamoService
+	mu       sync.Mutex
+	errs     []error
+	calls    int
+	inFlight atomic.Int32
+	peak     atomic.Int32
+	hold     time.Duration // Time each call stays in flight
+}
+
+func (s *scriptedPlamoService) Translate(ctx context.Context, text string, fromLang, toLang string) (string, error) {
+	n := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		peak := s.peak.Load()
+		if n <= peak || s.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(s.hold)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		if err != nil {
+			return "", err
+		}
+	}
+	return "translated " + text, nil
+}
+
+// fakeClock records the sleeps of a rate-limited service and advances time by them
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+// install makes s use the fake clock
+func (c *fakeClock) install(s PlamoService) {
+	limited := s.(*rateLimitedPlamoService)
+	limited.now = func() time.Time {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.now
+	}
+	limited.sleep = func(ctx context.Context, d time.Duration) error {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.sleeps = append(c.sleeps, d)
+		c.now = c.now.Add(d)
+		return ctx.Err()
+	}
+}
+
+func TestRateLimitedPlamoService_SpacesRequests(t *testing.T) {
+	inner := &scriptedPlamoService{}
+	service := NewRateLimitedPlamoService(inner, RateLimit{RequestsPerMinute: 30})
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	clock.install(service)
+
+	for i := 0; i < 3; i++ {
+		translated, err := service.Translate(context.Background(), "hello", "en", "ja")
+		require.NoError(t, err)
+		assert.Equal(t, "translated hello", translated)
+	}
+
+	// 30 requests per minute start two seconds apart; the first starts at once
+	assert.Equal(t, []time.Duration{2 * time.Second, 2 * time.Second}, clock.sleeps)
+}
+
+func TestRateLimitedPlamoService_RetriesRateLimitedRequests(t *testing.T) {
+	t.Run("honors Retry-After, then backs off exponentially", func(t *testing.T) {
+		inner := &scriptedPlamoService{errs: []error{
+			&RateLimitedError{Provider: "deepl", RetryAfter: 10 * time.Second},
+			&RateLimitedError{Provider: "deepl"},
+			&RateLimitedError{Provider: "deepl"},
+		}}
+		service := NewRateLimitedPlamoService(inner, RateLimit{})
+		clock := &fakeClock{now: time.Unix(0, 0)}
+		clock.install(service)
+
+		translated, err := service.Translate(context.Background(), "hello", "en", "ja")
+		require.NoError(t, err)
+		assert.Equal(t, "translated hello", translated)
+		assert.Equal(t, 4, inner.calls)
+		assert.Equal(t, []time.Duration{10 * time.Second, time.Second, 2 * time.Second}, clock.sleeps)
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		limited := &RateLimitedError{Provider: "openai"}
+		inner := &scriptedPlamoService{errs: []error{limited, limited, limited}}
+		service := NewRateLimitedPlamoService(inner, RateLimit{MaxRetries: 2})
+		(&fakeClock{now: time.Unix(0, 0)}).install(service)
+
+		_, err := service.Translate(context.Background(), "hello", "en", "ja")
+		var rateErr *RateLimitedError
+		require.ErrorAs(t, err, &rateErr)
+		assert.Equal(t, 3, inner.calls, "one request and two retries")
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		inner := &scriptedPlamoService{errs: []error{errors.New("PLaMo CLI execution failed")}}
+		service := NewRateLimitedPlamoService(inner, RateLimit{})
+
+		_, err := service.Translate(context.Background(), "hello", "en", "ja")
+		require.Error(t, err)
+		assert.Equal(t, 1, inner.calls)
+	})
+}
+
+func TestRateLimitedPlamoService_MaxParallel(t *testing.T) {
+	inner := &scriptedPlamoService{hold: 20 * time.Millisecond}
+	service := NewRateLimitedPlamoService(inner, RateLimit{MaxParallel: 2})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.Translate(context.Background(), "hello", "en", "ja")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 6, inner.calls)
+	assert.Equal(t, int32(2), inner.peak.Load())
+}
+
+func TestRateLimitedPlamoService_Cancelled(t *testing.T) {
+	inner := &scriptedPlamoService{}
+	service := NewRateLimitedPlamoService(inner, RateLimit{RequestsPerMinute: 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := service.Translate(ctx, "first", "en", "ja")
+	require.NoError(t, err)
+
+	// The second request would wait a minute
+	cancel()
+	_, err = service.Translate(ctx, "second", "en", "ja")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, inner.calls)
+}
+
+func TestRateLimitedError_Error(t *testing.T) {
+	assert.Equal(t, "deepl rate limit exceeded", (&RateLimitedError{Provider: "deepl"}).Error())
+	assert.Equal(t, "deepl rate limit exceeded (retry after 30s)", (&RateLimitedError{Provider: "deepl", RetryAfter: 30 * time.Second}).Error())
+}
@@ -0,0 +1,362 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/clock"
+)
+
+// Scheduling strategies (translation create --strategy), ranking the providers a batch can go to
+const (
+	StrategyCheapest = "cheapest" // Lowest cost per token, then best quality (the default)
+	StrategyFastest  = "fastest"  // Lowest latency per token seen so far; providers not yet measured first
+	StrategyQuality  = "quality"  // Best quality rank, then lowest cost
+)
+
+// Strategies lists the supported scheduling strategies
+var Strategies = []string{StrategyCheapest, StrategyFastest, StrategyQuality}
+
+// ValidateStrategy checks that strategy is empty or a supported strategy
+func ValidateStrategy(strategy string) error {
+	if strategy == "" {
+		return nil
+	}
+	for _, supported := range Strategies {
+		if strategy == supported {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported strategy: %s (supported: %s)", strategy, strings.Join(Strategies, ", "))
+}
+
+// strategyKey is the context key of the scheduling strategy of a translation
+type strategyKey struct{}
+
+// WithStrategy returns a context whose batches are scheduled across providers with strategy
+func WithStrategy(ctx context.Context, strategy string) context.Context {
+	if strategy == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, strategyKey{}, strategy)
+}
+
+// strategyFrom returns the scheduling strategy of ctx, empty when there is none
+func strategyFrom(ctx context.Context) string {
+	strategy, _ := ctx.Value(strategyKey{}).(string)
+	return strategy
+}
+
+// Provider is a translation provider batches can be scheduled to (translation.providers)
+type Provider struct {
+	Name        string // Recorded as the source of the translations it makes
+	Service     PlamoService
+	Languages   []string // Supported pairs as SOURCE:TARGET, "*" matching any language; all pairs when empty
+	DailyTokens int      // Input tokens it may be sent per UTC day; unlimited when 0
+	Cost        float64  // Price per 1000 input tokens, in any unit shared by all providers
+	Quality     int      // Rank for the quality strategy; higher is better
+}
+
+// Supports reports whether the provider translates sourceLang to targetLang
+func (p *Provider) Supports(sourceLang, targetLang string) bool {
+	if len(p.Languages) == 0 {
+		return true
+	}
+	for _, pair := range p.Languages {
+		from, to, _ := strings.Cut(pair, ":")
+		if (from == "*" || from == sourceLang) && (to == "*" || to == targetLang) {
+			return true
+		}
+	}
+	return false
+}
+
+// ProviderUsage stores the tokens sent to each provider per UTC day, so daily quotas hold across runs
+type ProviderUsage interface {
+	TokensUsed(ctx context.Context, day time.Time) (map[string]int, error)
+	AddTokens(ctx context.Context, provider string, day time.Time, tokens int) error
+}
+
+// Scheduler routes each batch to a provider that supports its language pair and has quota left
+// for it, ranked by the strategy of the translation. It is safe for concurrent use by the batch
+// workers: the estimated tokens of a batch are reserved on its provider until the batch is done,
+// so concurrent batches can't overrun a quota together.
+type Scheduler struct {
+	providers []*Provider
+	usage     ProviderUsage // Optional; quotas only count the batches of this process when nil
+	clock     clock.Clock
+	estimator *TokenEstimator // Counts the tokens actually sent to a provider
+
+	mu       sync.Mutex
+	day      time.Time                // UTC day used was loaded for
+	used     map[string]int           // Tokens sent to each provider on day
+	reserved map[string]int           // Estimated tokens of the batches in flight on each provider
+	latency  map[string]time.Duration // Average time of each provider per 1000 tokens
+}
+
+// reservedProviderNames are translation sources that aren't providers, which a provider named
+// alike would be mistaken for
+var reservedProviderNames = []string{ProviderManual, SourcePolished}
+
+// NewScheduler creates a scheduler over providers, keeping their daily usage in usage (optional)
+func NewScheduler(providers []*Provider, usage ProviderUsage) (*Scheduler, error) {
+	if len(providers) == 0 {
+		return nil, errors.New("no translation providers configured")
+	}
+	names := make(map[string]bool, len(providers))
+	for _, p := range providers {
+		switch {
+		case p.Name == "":
+			return nil, errors.New("translation provider name is required")
+		case names[p.Name]:
+			return nil, fmt.Errorf("duplicate translation provider: %s", p.Name)
+		case slices.Contains(reservedProviderNames, p.Name):
+			return nil, fmt.Errorf("translation provider name %s is reserved (reserved: %s)", p.Name, strings.Join(reservedProviderNames, ", "))
+		case p.Service == nil:
+			return nil, fmt.Errorf("translation provider %s has no service", p.Name)
+		}
+		names[p.Name] = true
+		for _, pair := range p.Languages {
+			if from, to, ok := strings.Cut(pair, ":"); !ok || from == "" || to == "" {
+				return nil, fmt.Errorf("invalid language pair %q of translation provider %s (expected SOURCE:TARGET, e.g. en:ja)", pair, p.Name)
+			}
+		}
+	}
+	return &Scheduler{
+		providers: providers,
+		usage:     usage,
+		clock:     clock.System,
+		estimator: NewTokenEstimator(nil),
+		reserved:  make(map[string]int),
+		latency:   make(map[string]time.Duration),
+	}, nil
+}
+
+// schedule returns the providers a batch may be sent to, the preferred one first, and reserves
+// the estimated tokens of the batch on the preferred one. The reservation is given back by
+// settle, release or a move to another provider.
+func (s *Scheduler) schedule(ctx context.Context, batch SegmentBatch, sourceLang, targetLang string) ([]*Provider, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	var candidates []*Provider
+	var exhausted []string
+	for _, p := range s.providers {
+		if !p.Supports(sourceLang, targetLang) {
+			continue
+		}
+		if !s.fits(p, batch.EstimatedTokens) {
+			exhausted = append(exhausted, p.Name)
+			continue
+		}
+		candidates = append(candidates, p)
+	}
+	if len(candidates) == 0 {
+		if len(exhausted) > 0 {
+			return nil, fmt.Errorf("daily token quota of %s used up and no other provider translates %s to %s", strings.Join(exhausted, ", "), sourceLang, targetLang)
+		}
+		return nil, fmt.Errorf("no translation provider translates %s to %s", sourceLang, targetLang)
+	}
+
+	strategy := strategyFrom(ctx)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return s.prefers(strategy, candidates[i], candidates[j])
+	})
+	s.reserved[candidates[0].Name] += batch.EstimatedTokens
+	return candidates, nil
+}
+
+// move gives back the tokens reserved on from, if any, and reserves them on to, reporting false
+// (keeping nothing reserved) when they no longer fit the quota of to
+func (s *Scheduler) move(from, to *Provider, tokens int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if from != nil {
+		s.reserved[from.Name] -= tokens
+	}
+	if !s.fits(to, tokens) {
+		return false
+	}
+	s.reserved[to.Name] += tokens
+	return true
+}
+
+// release gives back the tokens reserved on a provider for a batch that was not translated
+func (s *Scheduler) release(provider *Provider, tokens int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reserved[provider.Name] -= tokens
+}
+
+// fits reports whether tokens more fit the quota of a provider next to its usage and the
+// reservations of batches in flight. Called with s.mu held.
+func (s *Scheduler) fits(p *Provider, tokens int) bool {
+	return p.DailyTokens == 0 || s.used[p.Name]+s.reserved[p.Name]+tokens <= p.DailyTokens
+}
+
+// prefers reports whether strategy ranks a before b. Ties go to the provider with the most quota
+// left, then to the one configured first. Called with s.mu held.
+func (s *Scheduler) prefers(strategy string, a, b *Provider) bool {
+	switch strategy {
+	case StrategyFastest:
+		latencyA, measuredA := s.latency[a.Name]
+		latencyB, measuredB := s.latency[b.Name]
+		if measuredA != measuredB {
+			return !measuredA
+		}
+		if latencyA != latencyB {
+			return latencyA < latencyB
+		}
+	case StrategyQuality:
+		if a.Quality != b.Quality {
+			return a.Quality > b.Quality
+		}
+		if a.Cost != b.Cost {
+			return a.Cost < b.Cost
+		}
+	default:
+		if a.Cost != b.Cost {
+			return a.Cost < b.Cost
+		}
+		if a.Quality != b.Quality {
+			return a.Quality > b.Quality
+		}
+	}
+	return s.remaining(a) > s.remaining(b)
+}
+
+// remaining returns the tokens a provider may still be sent today, math.MaxInt when it is
+// unlimited. Called with s.mu held.
+func (s *Scheduler) remaining(p *Provider) int {
+	if p.DailyTokens == 0 {
+		return math.MaxInt
+	}
+	return p.DailyTokens - s.used[p.Name] - s.reserved[p.Name]
+}
+
+// refresh loads the usage of the current UTC day when the day changed since it was last loaded.
+// Called with s.mu held.
+func (s *Scheduler) refresh(ctx context.Context) error {
+	day := s.clock.Now().UTC().Truncate(24 * time.Hour)
+	if s.used != nil && day.Equal(s.day) {
+		return nil
+	}
+
+	used := make(map[string]int)
+	if s.usage != nil {
+		loaded, err := s.usage.TokensUsed(ctx, day)
+		if err != nil {
+			return fmt.Errorf("failed to load translation provider usage: %w", err)
+		}
+		for name, tokens := range loaded {
+			used[name] = tokens
+		}
+	}
+	s.day, s.used = day, used
+	return nil
+}
+
+// settle gives back the tokens reserved for a batch translated by provider in elapsed, and adds
+// the tokens it was actually sent to its usage and average latency
+func (s *Scheduler) settle(ctx context.Context, provider *Provider, reserved, tokens int, elapsed time.Duration) error {
+	s.mu.Lock()
+	s.reserved[provider.Name] -= reserved
+	if err := s.refresh(ctx); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	s.used[provider.Name] += tokens
+	perThousand := elapsed
+	if tokens > 0 {
+		perThousand = elapsed * 1000 / time.Duration(tokens)
+	}
+	if average, ok := s.latency[provider.Name]; ok {
+		perThousand = (average + perThousand) / 2
+	}
+	s.latency[provider.Name] = perThousand
+	day := s.day
+	s.mu.Unlock()
+
+	if s.usage == nil || tokens == 0 {
+		return nil
+	}
+	return s.usage.AddTokens(ctx, provider.Name, day, tokens)
+}
+
+// countingService counts the tokens of the text sent to a provider, including the retries of
+// the batch processor's fallbacks
+type countingService struct {
+	PlamoService
+	estimator *TokenEstimator
+	tokens    atomic.Int64
+}
+
+// Translate counts text and translates it
+func (c *countingService) Translate(ctx context.Context, text string, fromLang, toLang string) (string, error) {
+	c.tokens.Add(int64(c.estimator.Estimate(text, fromLang)))
+	return c.PlamoService.Translate(ctx, text, fromLang, toLang)
+}
+
+// translateScheduledBatch translates a batch with the provider the scheduler prefers, moving on
+// to the next one when it fails. A batch only one provider can take is retried like an
+// unscheduled batch. The provider is recorded on the translated segments.
+func (s *translationService) translateScheduledBatch(ctx context.Context, batch SegmentBatch, sourceLanguage, targetLang string) ([]*TranslationSegment, error) {
+	candidates, err := s.scheduler.schedule(ctx, batch, sourceLanguage, targetLang)
+	if err != nil {
+		return nil, err
+	}
+	reserved := candidates[0] // Holds the batch's tokens until it is settled or released
+	defer func() {
+		if reserved != nil {
+			s.scheduler.release(reserved, batch.EstimatedTokens)
+		}
+	}()
+
+	attempts := max(batchAttempts, len(candidates))
+	for attempt := 0; attempt < attempts; attempt++ {
+		provider := candidates[attempt%len(candidates)]
+		if provider != reserved {
+			from := reserved
+			reserved = nil
+			if !s.scheduler.move(from, provider, batch.EstimatedTokens) {
+				err = fmt.Errorf("%s: daily token quota used up", provider.Name)
+				continue
+			}
+			reserved = provider
+		}
+
+		started := time.Now()
+		counted := &countingService{PlamoService: provider.Service, estimator: s.scheduler.estimator}
+		var translated []*TranslationSegment
+		translated, err = s.batchProcessor.TranslateBatchWithFallback(batch, counted, ctx, sourceLanguage, targetLang)
+		if err == nil {
+			reserved = nil
+			if err := s.scheduler.settle(ctx, provider, batch.EstimatedTokens, int(counted.tokens.Load()), time.Since(started)); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to record %s usage: %v\n", provider.Name, err)
+			}
+			for _, seg := range translated {
+				seg.Provider = provider.Name
+			}
+			return translated, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		err = fmt.Errorf("%s: %w", provider.Name, err)
+	}
+	return nil, fmt.Errorf("%w (after %d attempts)", err, attempts)
+}
@@ -0,0 +1,276 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/clock"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// namedPlamoService stands for a provider in scheduled batch translations
+type namedPlamoService struct {
+	PlamoService
+	name string
+}
+
+func (n *namedPlamoService) Translate(ctx context.Context, text string, fromLang, toLang string) (string, error) {
+	return "translated " + text, nil
+}
+
+// sentName returns the provider name of the service a scheduled batch was sent to
+func sentName(service PlamoService) string {
+	return service.(*countingService).PlamoService.(*namedPlamoService).name
+}
+
+// fakeProviderUsage keeps provider usage in memory
+type fakeProviderUsage struct {
+	used  map[string]int
+	loads int
+}
+
+func (f *fakeProviderUsage) TokensUsed(ctx context.Context, day time.Time) (map[string]int, error) {
+	f.loads++
+	return f.used, nil
+}
+
+func (f *fakeProviderUsage) AddTokens(ctx context.Context, provider string, day time.Time, tokens int) error {
+	f.used[provider] += tokens
+	return nil
+}
+
+// testProviders returns a free PLaMo limited to en/ja, a cheap LLM and an expensive LLM with a
+// daily quota
+func testProviders() []*Provider {
+	return []*Provider{
+		{Name: "plamo", Service: &namedPlamoService{name: "plamo"}, Languages: []string{"en:ja", "ja:en"}, Quality: 2},
+		{Name: "mini", Service: &namedPlamoService{name: "mini"}, Cost: 0.15, Quality: 3},
+		{Name: "large", Service: &namedPlamoService{name: "large"}, Cost: 2.5, Quality: 5, DailyTokens: 1000},
+	}
+}
+
+// providerNames returns the names of providers, in order
+func providerNames(providers []*Provider) []string {
+	var names []string
+	for _, p := range providers {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func TestValidateStrategy(t *testing.T) {
+	assert.NoError(t, ValidateStrategy(""))
+	assert.NoError(t, ValidateStrategy(StrategyFastest))
+	assert.ErrorContains(t, ValidateStrategy("random"), "unsupported strategy: random")
+}
+
+func TestProvider_Supports(t *testing.T) {
+	provider := &Provider{Languages: []string{"en:ja", "*:en"}}
+	assert.True(t, provider.Supports("en", "ja"))
+	assert.True(t, provider.Supports("fr", "en"))
+	assert.False(t, provider.Supports("ja", "fr"))
+	assert.True(t, (&Provider{}).Supports("ja", "fr"))
+}
+
+func TestNewScheduler_Invalid(t *testing.T) {
+	service := &namedPlamoService{}
+	tests := []struct {
+		name      string
+		providers []*Provider
+		want      string
+	}{
+		{"no providers", nil, "no translation providers"},
+		{"missing name", []*Provider{{Service: service}}, "name is required"},
+		{"duplicate name", []*Provider{{Name: "plamo", Service: service}, {Name: "plamo", Service: service}}, "duplicate translation provider: plamo"},
+		{"manual name", []*Provider{{Name: ProviderManual, Service: service}}, "translation provider name manual is reserved"},
+		{"polished name", []*Provider{{Name: SourcePolished, Service: service}}, "translation provider name plamo-polished is reserved"},
+		{"invalid pair", []*Provider{{Name: "plamo", Service: service, Languages: []string{"en-ja"}}}, `invalid language pair "en-ja"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewScheduler(tt.providers, nil)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestScheduler_Schedule(t *testing.T) {
+	newScheduler := func(t *testing.T) (*Scheduler, *fakeProviderUsage) {
+		usage := &fakeProviderUsage{used: map[string]int{"large": 900}}
+		scheduler, err := NewScheduler(testProviders(), usage)
+		require.NoError(t, err)
+		return scheduler, usage
+	}
+	small := SegmentBatch{EstimatedTokens: 50}
+
+	tests := []struct {
+		name       string
+		strategy   string
+		batch      SegmentBatch
+		sourceLang string
+		want       []string
+	}{
+		{"cheapest by default", "", small, "en", []string{"plamo", "mini", "large"}},
+		{"cheapest", StrategyCheapest, small, "en", []string{"plamo", "mini", "large"}},
+		{"quality", StrategyQuality, small, "en", []string{"large", "mini", "plamo"}},
+		{"unsupported pair skipped", StrategyCheapest, small, "fr", []string{"mini", "large"}},
+		{"batch over the remaining quota", StrategyQuality, SegmentBatch{EstimatedTokens: 200}, "en", []string{"mini", "plamo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduler, _ := newScheduler(t)
+			candidates, err := scheduler.schedule(WithStrategy(context.Background(), tt.strategy), tt.batch, tt.sourceLang, "ja")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, providerNames(candidates))
+		})
+	}
+
+	t.Run("fastest tries unmeasured providers first", func(t *testing.T) {
+		scheduler, _ := newScheduler(t)
+		ctx := WithStrategy(context.Background(), StrategyFastest)
+		providers := scheduler.providers
+		require.NoError(t, scheduler.settle(ctx, providers[0], 0, 1000, 3*time.Second))
+		require.NoError(t, scheduler.settle(ctx, providers[1], 0, 500, time.Second))
+
+		candidates, err := scheduler.schedule(ctx, small, "en", "ja")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"large", "mini", "plamo"}, providerNames(candidates))
+	})
+
+	t.Run("usage is recorded and counts against the quota", func(t *testing.T) {
+		scheduler, usage := newScheduler(t)
+		ctx := context.Background()
+		_, err := scheduler.schedule(ctx, small, "fr", "ja")
+		require.NoError(t, err)
+		require.NoError(t, scheduler.settle(ctx, scheduler.providers[1], small.EstimatedTokens, 300, time.Second))
+		require.NoError(t, scheduler.settle(ctx, scheduler.providers[2], 0, 100, time.Second))
+		assert.Equal(t, map[string]int{"mini": 300, "large": 1000}, usage.used)
+		assert.Equal(t, map[string]int{"mini": 0, "large": 0}, scheduler.reserved)
+
+		candidates, err := scheduler.schedule(ctx, small, "fr", "ja")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"mini"}, providerNames(candidates))
+		assert.Equal(t, 1, usage.loads, "usage is loaded once a day")
+	})
+
+	t.Run("batches in flight reserve their tokens", func(t *testing.T) {
+		scheduler, _ := newScheduler(t)
+		ctx := WithStrategy(context.Background(), StrategyQuality)
+		candidates, err := scheduler.schedule(ctx, small, "fr", "ja")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"large", "mini"}, providerNames(candidates))
+		candidates, err = scheduler.schedule(ctx, small, "fr", "ja")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"large", "mini"}, providerNames(candidates))
+
+		candidates, err = scheduler.schedule(ctx, small, "fr", "ja")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"mini"}, providerNames(candidates), "large is reserved up to its quota")
+
+		scheduler.release(scheduler.providers[2], small.EstimatedTokens)
+		assert.True(t, scheduler.move(scheduler.providers[1], scheduler.providers[2], small.EstimatedTokens))
+		assert.False(t, scheduler.move(nil, scheduler.providers[2], small.EstimatedTokens))
+	})
+
+	t.Run("usage is reloaded on a new day", func(t *testing.T) {
+		scheduler, usage := newScheduler(t)
+		fake := clock.NewFake(time.Date(2024, 5, 1, 23, 0, 0, 0, time.UTC))
+		scheduler.clock = fake
+		_, err := scheduler.schedule(context.Background(), small, "en", "ja")
+		require.NoError(t, err)
+
+		fake.Advance(2 * time.Hour)
+		usage.used = map[string]int{}
+		candidates, err := scheduler.schedule(context.Background(), SegmentBatch{EstimatedTokens: 1000}, "en", "ja")
+		require.NoError(t, err)
+		assert.Equal(t, 2, usage.loads)
+		assert.Contains(t, providerNames(candidates), "large")
+	})
+
+	t.Run("no provider left", func(t *testing.T) {
+		scheduler, err := NewScheduler(testProviders()[:1], nil)
+		require.NoError(t, err)
+		_, err = scheduler.schedule(context.Background(), small, "fr", "ja")
+		assert.ErrorContains(t, err, "no translation provider translates fr to ja")
+
+		scheduler, _ = newScheduler(t)
+		_, err = scheduler.schedule(context.Background(), SegmentBatch{EstimatedTokens: 200}, "fr", "de")
+		require.NoError(t, err)
+		scheduler.providers = scheduler.providers[2:]
+		_, err = scheduler.schedule(context.Background(), SegmentBatch{EstimatedTokens: 200}, "fr", "de")
+		assert.ErrorContains(t, err, "daily token quota of large used up")
+	})
+}
+
+func TestTranslationService_CreateTranslation_Scheduled(t *testing.T) {
+	transcriptionRepo := &mockTranscriptionRepo{
+		GetSegmentsFunc: func(ctx context.Context, id string) ([]*model.TranscriptionSegment, error) {
+			return timedSegments(0, 100, 200), nil
+		},
+		GetFunc: func(ctx context.Context, id string) (*model.Transcription, error) {
+			return &model.Transcription{ID: id, Language: "en"}, nil
+		},
+	}
+	var saved []*model.Translation
+	translationRepo := &mockTranslationRepo{
+		CreateBatchFunc: func(ctx context.Context, translations []*model.Translation) error {
+			saved = append(saved, translations...)
+			return nil
+		},
+	}
+	// Each segment is a batch; the quality strategy prefers large, which is down
+	var sentTo []string
+	batchProcessor := &mockBatchProcessor{
+		CreateBatchesFunc: func(segments []*model.TranscriptionSegment, sourceLang string, maxTokens int) ([]SegmentBatch, error) {
+			var batches []SegmentBatch
+			for _, seg := range segments {
+				batches = append(batches, SegmentBatch{Segments: []*model.TranscriptionSegment{seg}, EstimatedTokens: 100})
+			}
+			return batches, nil
+		},
+		TranslateBatchWithFallbackFunc: func(batch SegmentBatch, plamoService PlamoService, ctx context.Context, sourceLang, targetLang string) ([]*TranslationSegment, error) {
+			name := sentName(plamoService)
+			sentTo = append(sentTo, name)
+			if name == "large" {
+				return nil, errors.New("model overloaded")
+			}
+			for _, seg := range batch.Segments {
+				if _, err := plamoService.Translate(ctx, seg.Text, sourceLang, targetLang); err != nil {
+					return nil, err
+				}
+			}
+			return echoBatch(batch), nil
+		},
+	}
+	usage := &fakeProviderUsage{used: map[string]int{}}
+	scheduler, err := NewScheduler(testProviders(), usage)
+	require.NoError(t, err)
+	service := NewTranslationServiceWithScheduler(transcriptionRepo, translationRepo, nil, NewPlamoService(&MockCmdRunner{}), batchProcessor, 1, nil, scheduler)
+
+	result, err := service.CreateTranslation(WithStrategy(context.Background(), StrategyQuality), "trans-1", "ja")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"large", "mini", "large", "mini", "large", "mini"}, sentTo)
+	require.Len(t, saved, 3)
+	for _, translation := range saved {
+		assert.Equal(t, "mini", translation.Source)
+	}
+	assert.Equal(t, "mini", result.Source)
+	// Usage counts the text sent, not the estimates the batches were scheduled with
+	sent := 0
+	for _, seg := range timedSegments(0, 100, 200) {
+		sent += NewTokenEstimator(nil).Estimate(seg.Text, "en")
+	}
+	assert.Equal(t, map[string]int{"mini": sent}, usage.used)
+	assert.Equal(t, map[string]int{"large": 0, "mini": 0}, scheduler.reserved)
+
+	t.Run("strategy without providers", func(t *testing.T) {
+		service := NewTranslationService(transcriptionRepo, translationRepo, NewPlamoService(&MockCmdRunner{}), batchProcessor)
+		_, err := service.CreateTranslation(WithStrategy(context.Background(), StrategyFastest), "trans-1", "ja")
+		assert.ErrorContains(t, err, "translation.providers")
+	})
+}
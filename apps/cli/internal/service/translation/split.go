@@ -0,0 +1,139 @@
+package translation
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// separatorPattern matches a batch separator as PLaMo tends to mutate it: characters turned
+// full-width (＿＿), spaces inserted (_ _, <<< SEP >>>), letters in another case, and
+// whitespace around it
+func separatorPattern(separator string) *regexp.Regexp {
+	var chars []string
+	for _, r := range separator {
+		variants := string(r)
+		if r > ' ' && r <= '~' {
+			variants += string(r + 0xFEE0) // Full-width form of printable ASCII
+		}
+		chars = append(chars, "["+regexp.QuoteMeta(variants)+"]")
+	}
+	return regexp.MustCompile(`(?i)\s*` + strings.Join(chars, `\s*`) + `\s*`)
+}
+
+// splitNormalized splits a translation on any variant of separator. Empty pieces at either
+// end, left by a separator PLaMo moved to the start or end of its output, are dropped.
+func splitNormalized(translation, separator string) []string {
+	pieces := separatorPattern(separator).Split(strings.TrimSpace(translation), -1)
+	for len(pieces) > 0 && strings.TrimSpace(pieces[0]) == "" {
+		pieces = pieces[1:]
+	}
+	for len(pieces) > 0 && strings.TrimSpace(pieces[len(pieces)-1]) == "" {
+		pieces = pieces[:len(pieces)-1]
+	}
+	return pieces
+}
+
+// alignBySentences splits a translation whose separators were lost by giving each segment as
+// many translated sentences as its source text has. It fails unless the sentence counts of
+// source and translation match.
+func (bp *batchProcessor) alignBySentences(segments []*model.TranscriptionSegment, translation string) ([]*TranslationSegment, error) {
+	if strings.TrimSpace(translation) == "" {
+		return nil, errors.New("empty translation")
+	}
+
+	// Whatever is left of the separators only gets in the way of sentence boundaries
+	for _, separator := range bp.separators {
+		translation = separatorPattern(separator).ReplaceAllString(translation, " ")
+	}
+	sentences := splitSentences(translation)
+
+	counts := make([]int, len(segments))
+	total := 0
+	for i, segment := range segments {
+		counts[i] = len(splitSentences(segment.Text))
+		total += counts[i]
+	}
+	if total != len(sentences) {
+		return nil, fmt.Errorf("sentence count mismatch: expected %d but got %d", total, len(sentences))
+	}
+
+	texts := make([]string, len(segments))
+	next := 0
+	for i, count := range counts {
+		texts[i] = strings.Join(sentences[next:next+count], "")
+		next += count
+	}
+	return newTranslationSegments(segments, texts, SplitSentences), nil
+}
+
+// splitSentences splits text after sentence-ending punctuation, keeping the punctuation,
+// closing quotes and following whitespace with the sentence. Western punctuation only ends a
+// sentence before whitespace, so numbers like "3.5" stay whole.
+func splitSentences(text string) []string {
+	runes := []rune(text)
+	var sentences []string
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		if !isSentenceEnd(runes[i]) {
+			continue
+		}
+		end := i + 1
+		for end < len(runes) && (isSentenceEnd(runes[end]) || isClosingQuote(runes[end])) {
+			end++
+		}
+		if end < len(runes) && !unicode.IsSpace(runes[end]) && !isFullWidthSentenceEnd(runes[i]) {
+			continue
+		}
+		for end < len(runes) && unicode.IsSpace(runes[end]) {
+			end++
+		}
+		if sentence := string(runes[start:end]); strings.TrimSpace(sentence) != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = end
+		i = end - 1
+	}
+	if sentence := string(runes[start:]); strings.TrimSpace(sentence) != "" {
+		sentences = append(sentences, sentence)
+	}
+	return sentences
+}
+
+func isSentenceEnd(r rune) bool {
+	return strings.ContainsRune(".!?…", r) || isFullWidthSentenceEnd(r)
+}
+
+func isFullWidthSentenceEnd(r rune) bool {
+	return strings.ContainsRune("。！？", r)
+}
+
+func isClosingQuote(r rune) bool {
+	return strings.ContainsRune(`"'”’」』)）]`, r)
+}
+
+// reportSplitStrategies prints how many batches each split strategy handled when any batch
+// needed more than the plain separator
+func reportSplitStrategies(results [][]*TranslationSegment) {
+	counts := make(map[string]int)
+	for _, batch := range results {
+		if len(batch) > 0 {
+			counts[batch[0].SplitStrategy]++
+		}
+	}
+	if counts[SplitNormalized]+counts[SplitSentences]+counts[SplitIndividual] == 0 {
+		return
+	}
+
+	var parts []string
+	for _, strategy := range []string{SplitSeparator, SplitNormalized, SplitSentences, SplitIndividual} {
+		if counts[strategy] > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", strategy, counts[strategy]))
+		}
+	}
+	fmt.Printf("Batches split by strategy: %s\n", strings.Join(parts, ", "))
+}
@@ -0,0 +1,52 @@
+package translation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Translation styles (translation create --style). The empty style is the plain translation.
+const (
+	StyleSimple = "simple" // Plain words and short sentences, for learners with a lower reading level
+	StyleFormal = "formal" // Polite, written register
+	StyleCasual = "casual" // Relaxed, spoken register
+)
+
+// Styles lists the supported translation styles
+var Styles = []string{StyleSimple, StyleFormal, StyleCasual}
+
+// styleInstructions are added to the post-editing prompt for each style
+var styleInstructions = map[string]string{
+	StyleSimple: "Adapt each translation for language learners: use common, everyday words and short, simple sentences, and avoid idioms and rare kanji or vocabulary, while keeping the meaning.",
+	StyleFormal: "Use a formal, polite register, as in a written report or a news broadcast.",
+	StyleCasual: "Use a casual, friendly spoken register, as between friends.",
+}
+
+// ValidateStyle checks that style is empty or a supported style
+func ValidateStyle(style string) error {
+	if style == "" {
+		return nil
+	}
+	if _, ok := styleInstructions[style]; !ok {
+		return fmt.Errorf("unsupported style: %s (supported: %s)", style, strings.Join(Styles, ", "))
+	}
+	return nil
+}
+
+// styleKey is the context key of the style of a translation
+type styleKey struct{}
+
+// WithStyle returns a context whose translations are made and stored in style
+func WithStyle(ctx context.Context, style string) context.Context {
+	if style == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, styleKey{}, style)
+}
+
+// styleFrom returns the style of ctx, empty when there is none
+func styleFrom(ctx context.Context) string {
+	style, _ := ctx.Value(styleKey{}).(string)
+	return style
+}
@@ -0,0 +1,82 @@
+package translation
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+const (
+	fallbackCharsPerToken = 2.0 // Conservative ratio for languages without a configured ratio
+	cjkCharsPerToken      = 1.0 // Han, kana and hangul are never counted lighter than this
+)
+
+// defaultCharsPerToken holds approximate characters per token by source language
+var defaultCharsPerToken = map[string]float64{
+	"en": 4.0,
+	"es": 3.5,
+	"fr": 3.5,
+	"de": 3.5,
+	"it": 3.5,
+	"pt": 3.5,
+	"ja": 1.0,
+	"zh": 1.0,
+	"ko": 1.0,
+}
+
+// TokenEstimator estimates token counts from character counts using per-language ratios
+type TokenEstimator struct {
+	charsPerToken map[string]float64
+}
+
+// NewTokenEstimator creates an estimator from the default ratios, replaced by any
+// positive overrides (language code -> characters per token)
+func NewTokenEstimator(overrides map[string]float64) *TokenEstimator {
+	ratios := make(map[string]float64, len(defaultCharsPerToken)+len(overrides))
+	for lang, ratio := range defaultCharsPerToken {
+		ratios[lang] = ratio
+	}
+	for lang, ratio := range overrides {
+		if ratio > 0 {
+			ratios[normalizeLanguage(lang)] = ratio
+		}
+	}
+	return &TokenEstimator{charsPerToken: ratios}
+}
+
+// Estimate returns the estimated token count of text written in language. CJK characters
+// are weighted by the CJK ratio even when the language says otherwise, so mislabelled or
+// mixed-script text cannot be underestimated.
+func (e *TokenEstimator) Estimate(text string, language string) int {
+	ratio, ok := e.charsPerToken[normalizeLanguage(language)]
+	if !ok {
+		ratio = fallbackCharsPerToken
+	}
+	cjkRatio := math.Min(ratio, cjkCharsPerToken)
+
+	var tokens float64
+	for _, r := range text {
+		if isCJK(r) {
+			tokens += 1 / cjkRatio
+		} else {
+			tokens += 1 / ratio
+		}
+	}
+	return int(math.Ceil(tokens))
+}
+
+// normalizeLanguage reduces a language tag such as "en-US" to its lowercase base code
+func normalizeLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if i := strings.IndexAny(language, "-_"); i > 0 {
+		language = language[:i]
+	}
+	return language
+}
+
+// isCJK reports whether r belongs to a script that is tokenized roughly per character,
+// including CJK punctuation and fullwidth forms
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+		(r >= 0x3000 && r <= 0x303F) || (r >= 0xFF00 && r <= 0xFFEF)
+}
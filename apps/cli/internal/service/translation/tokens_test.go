@@ -0,0 +1,44 @@
+package translation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenEstimator_Estimate(t *testing.T) {
+	estimator := NewTokenEstimator(nil)
+
+	tests := []struct {
+		name     string
+		text     string
+		language string
+		expected int
+	}{
+		{name: "english", text: "Hello world!", language: "en", expected: 3},
+		{name: "english rounds up", text: "Hi", language: "en", expected: 1},
+		{name: "region tag", text: "Hello world!", language: "en-US", expected: 3},
+		{name: "japanese", text: "こんにちは世界", language: "ja", expected: 7},
+		{name: "korean", text: "안녕하세요", language: "ko", expected: 5},
+		{name: "chinese", text: "你好世界", language: "zh", expected: 4},
+		{name: "japanese mislabelled as english", text: "こんにちは世界", language: "en", expected: 7},
+		{name: "mixed script", text: "Go言語", language: "en", expected: 3},
+		{name: "unknown language uses fallback", text: "abcdef", language: "xx", expected: 3},
+		{name: "empty", text: "", language: "en", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, estimator.Estimate(tt.text, tt.language))
+		})
+	}
+}
+
+func TestNewTokenEstimator_Overrides(t *testing.T) {
+	estimator := NewTokenEstimator(map[string]float64{"EN": 2, "xx": 5, "ja": 0})
+
+	assert.Equal(t, 6, estimator.Estimate("Hello world!", "en"))
+	assert.Equal(t, 2, estimator.Estimate("abcdefghij", "xx"))
+	// Non-positive ratios are ignored
+	assert.Equal(t, 7, estimator.Estimate("こんにちは世界", "ja"))
+}
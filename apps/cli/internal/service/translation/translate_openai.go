@@ -0,0 +1,72 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/offline"
+)
+
+// translateInstructions is the system prompt of a translation request; it is completed with the
+// source and target language names. Batches join segments with a separator that has to come back
+// unchanged for the translation to be split again, as with PLaMo.
+const translateInstructions = `You are a professional translator of %[1]s video subtitles into %[2]s.
+Translate the text the user sends from %[1]s to %[2]s. The text may consist of several subtitle segments joined by a separator such as "__" or "<<<SEP>>>": keep every separator exactly as written, in the same places, and translate each segment on its own without merging or dropping any.
+Reply with only the translation.`
+
+// openAITranslator translates with an OpenAI-compatible chat completions API, so batches can be
+// scheduled to an LLM next to PLaMo (translation.providers)
+type openAITranslator struct {
+	opts   OpenAIPolisherOptions
+	client *http.Client
+}
+
+// NewOpenAITranslator creates a translation service backed by an OpenAI-compatible chat
+// completions API
+func NewOpenAITranslator(opts OpenAIPolisherOptions) (PlamoService, error) {
+	return NewOpenAITranslatorWithClient(opts, &http.Client{Timeout: 5 * time.Minute})
+}
+
+// NewOpenAITranslatorWithClient creates a translation service using client (for testing)
+func NewOpenAITranslatorWithClient(opts OpenAIPolisherOptions, client *http.Client) (PlamoService, error) {
+	if opts.Model == "" {
+		return nil, errors.New("translation model is required")
+	}
+	if opts.BaseURL == "" {
+		opts.BaseURL = defaultOpenAIBaseURL
+	}
+	opts.BaseURL = strings.TrimRight(opts.BaseURL, "/")
+	return &openAITranslator{opts: opts, client: client}, nil
+}
+
+// Translate sends text in one chat completion and returns the reply
+func (t *openAITranslator) Translate(ctx context.Context, text string, fromLang, toLang string) (string, error) {
+	if strings.TrimSpace(text) == "" {
+		return "", errors.New("text cannot be empty")
+	}
+	if err := offline.Check("translating with " + t.opts.Model); err != nil {
+		return "", err
+	}
+	content, err := chatCompletion(ctx, t.client, t.opts, "translation", []chatMessage{
+		{Role: "system", Content: fmt.Sprintf(translateInstructions, languageName(fromLang), languageName(toLang))},
+		{Role: "user", Content: text},
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(content), nil
+}
+
+// StartServer does nothing: the API is always up
+func (t *openAITranslator) StartServer(ctx context.Context) error {
+	return nil
+}
+
+// StopServer does nothing: the API is always up
+func (t *openAITranslator) StopServer() error {
+	return nil
+}
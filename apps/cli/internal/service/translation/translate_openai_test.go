@@ -0,0 +1,39 @@
+package translation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAITranslator_Translate(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		fmt.Fprint(w, `{"choices": [{"message": {"role": "assistant", "content": " こんにちは__世界\n"}}]}`)
+	}))
+	defer server.Close()
+
+	translator, err := NewOpenAITranslatorWithClient(OpenAIPolisherOptions{BaseURL: server.URL + "/v1", Model: "gpt-4o-mini"}, server.Client())
+	require.NoError(t, err)
+
+	translated, err := translator.Translate(context.Background(), "Hello__World", "en", "ja")
+	require.NoError(t, err)
+	assert.Equal(t, "こんにちは__世界", translated)
+	assert.Contains(t, body, "from English to Japanese")
+	assert.Contains(t, body, `"content":"Hello__World"`)
+
+	_, err = translator.Translate(context.Background(), " ", "en", "ja")
+	assert.ErrorContains(t, err, "text cannot be empty")
+
+	_, err = NewOpenAITranslator(OpenAIPolisherOptions{})
+	assert.ErrorContains(t, err, "model is required")
+}
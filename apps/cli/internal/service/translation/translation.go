@@ -4,28 +4,37 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/Taichi-iskw/yt-lang/internal/clock"
 	"github.com/Taichi-iskw/yt-lang/internal/model"
 )
 
 const (
 	defaultMaxTokens = 7000 // PLaMo input limit
+	alignedPageSize  = 1000 // Page size when loading all translations of a transcription
+
+	defaultSourceLanguage = "en" // Used when a transcription has no usable language
 )
 
 // TranscriptionRepository interface for accessing transcription data
 type TranscriptionRepository interface {
 	GetSegments(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error)
 	Get(ctx context.Context, id string) (*model.Transcription, error)
+	GetVideoChapters(ctx context.Context, videoID string) ([]model.VideoChapter, error)
 }
 
 // TranslationRepository interface for accessing translation data
 type TranslationRepository interface {
 	Get(ctx context.Context, id int) (*model.Translation, error)
+	GetTranscriptionID(ctx context.Context, id int) (string, error)
 	Create(ctx context.Context, translation *model.Translation) error
 	CreateBatch(ctx context.Context, translations []*model.Translation) error
 	ListByTranscriptionID(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error)
+	CountByTranscriptionID(ctx context.Context, transcriptionID string) (int, error)
 	Delete(ctx context.Context, id int) error
 }
 
@@ -33,8 +42,14 @@ type TranslationRepository interface {
 type TranslationService interface {
 	CreateTranslation(ctx context.Context, transcriptionID string, targetLang string) (*model.Translation, error)
 	GetTranslation(ctx context.Context, id string) (*model.Translation, []*TranslationSegment, error)
+	GetAlignedTranslation(ctx context.Context, transcriptionID string, targetLang string) ([]*TranslationSegment, error)
+	CompareTranslations(ctx context.Context, opts CompareOptions) (*TranslationComparison, error)
+	TranslateInteractively(ctx context.Context, opts InteractiveOptions, reviewer Reviewer) (*InteractiveSummary, error)
+	CheckAlignment(ctx context.Context, opts QAOptions) (*QAReport, error)
 	ListTranslations(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error)
+	CountTranslations(ctx context.Context, transcriptionID string) (int, error)
 	DeleteTranslation(ctx context.Context, id string) error
+	PlanChapters(ctx context.Context, transcriptionID string, length time.Duration) ([]*Chapter, error)
 	GetPlamoService() PlamoService
 }
 
@@ -44,6 +59,11 @@ type translationService struct {
 	translationRepo   TranslationRepository
 	plamoService      PlamoService
 	batchProcessor    BatchProcessor
+	warningRepo       WarningRepository // Optional; alignment warnings are not stored when nil
+	workers           int               // Maximum concurrent batch translations
+	polisher          Polisher          // Optional; PLaMo translations are not post-edited when nil
+	scheduler         *Scheduler        // Optional; every batch is sent to plamoService when nil
+	clock             clock.Clock       // Stamps created times
 }
 
 // NewTranslationService creates a new translation service
@@ -52,12 +72,98 @@ func NewTranslationService(
 	translationRepo TranslationRepository,
 	plamoService PlamoService,
 	batchProcessor BatchProcessor,
+) TranslationService {
+	return NewTranslationServiceWithWorkers(transcriptionRepo, translationRepo, plamoService, batchProcessor, DefaultWorkers)
+}
+
+// NewTranslationServiceWithWorkers creates a new translation service that translates up to
+// workers batches concurrently
+func NewTranslationServiceWithWorkers(
+	transcriptionRepo TranscriptionRepository,
+	translationRepo TranslationRepository,
+	plamoService PlamoService,
+	batchProcessor BatchProcessor,
+	workers int,
+) TranslationService {
+	return &translationService{
+		transcriptionRepo: transcriptionRepo,
+		translationRepo:   translationRepo,
+		plamoService:      plamoService,
+		batchProcessor:    batchProcessor,
+		workers:           max(workers, 1),
+		clock:             clock.System,
+	}
+}
+
+// NewTranslationServiceWithQA creates a new translation service that also stores the
+// warnings of alignment checks
+func NewTranslationServiceWithQA(
+	transcriptionRepo TranscriptionRepository,
+	translationRepo TranslationRepository,
+	warningRepo WarningRepository,
+	plamoService PlamoService,
+	batchProcessor BatchProcessor,
+	workers int,
+) TranslationService {
+	return &translationService{
+		transcriptionRepo: transcriptionRepo,
+		translationRepo:   translationRepo,
+		plamoService:      plamoService,
+		batchProcessor:    batchProcessor,
+		warningRepo:       warningRepo,
+		workers:           max(workers, 1),
+		clock:             clock.System,
+	}
+}
+
+// NewTranslationServiceWithPolisher creates a new translation service like NewTranslationServiceWithQA
+// that also post-edits PLaMo translations with polisher, storing both versions. A nil polisher
+// turns post-editing off.
+func NewTranslationServiceWithPolisher(
+	transcriptionRepo TranscriptionRepository,
+	translationRepo TranslationRepository,
+	warningRepo WarningRepository,
+	plamoService PlamoService,
+	batchProcessor BatchProcessor,
+	workers int,
+	polisher Polisher,
+) TranslationService {
+	return &translationService{
+		transcriptionRepo: transcriptionRepo,
+		translationRepo:   translationRepo,
+		plamoService:      plamoService,
+		batchProcessor:    batchProcessor,
+		warningRepo:       warningRepo,
+		workers:           max(workers, 1),
+		polisher:          polisher,
+		clock:             clock.System,
+	}
+}
+
+// NewTranslationServiceWithScheduler creates a new translation service like
+// NewTranslationServiceWithPolisher that sends each batch to the provider scheduler picks,
+// recording it as the source of the batch's translations. A nil scheduler sends every batch to
+// plamoService.
+func NewTranslationServiceWithScheduler(
+	transcriptionRepo TranscriptionRepository,
+	translationRepo TranslationRepository,
+	warningRepo WarningRepository,
+	plamoService PlamoService,
+	batchProcessor BatchProcessor,
+	workers int,
+	polisher Polisher,
+	scheduler *Scheduler,
 ) TranslationService {
 	return &translationService{
 		transcriptionRepo: transcriptionRepo,
 		translationRepo:   translationRepo,
 		plamoService:      plamoService,
 		batchProcessor:    batchProcessor,
+		warningRepo:       warningRepo,
+		workers:           max(workers, 1),
+		polisher:          polisher,
+		scheduler:         scheduler,
+		clock:             clock.System,
 	}
 }
 
@@ -74,77 +180,216 @@ func NewTranslationServiceWithFallback(
 		translationRepo:   translationRepo,
 		plamoService:      plamoService,
 		batchProcessor:    batchProcessor,
+		workers:           DefaultWorkers,
+		clock:             clock.System,
 	}
 }
 
 // CreateTranslation creates translations for all segments in a transcription
 func (s *translationService) CreateTranslation(ctx context.Context, transcriptionID string, targetLang string) (*model.Translation, error) {
+	// plamo-translate takes no instructions, so only the post-editing pass can apply a style
+	style := styleFrom(ctx)
+	if err := ValidateStyle(style); err != nil {
+		return nil, err
+	}
+	if style != "" && s.polisher == nil {
+		return nil, fmt.Errorf("the %s style is applied when post-editing; use --polish with translation.polish configured", style)
+	}
+	if strategy := strategyFrom(ctx); strategy != "" {
+		if err := ValidateStrategy(strategy); err != nil {
+			return nil, err
+		}
+		if s.scheduler == nil {
+			return nil, errors.New("the scheduling strategy chooses among translation.providers; none are configured")
+		}
+	}
+
 	// Step 1: Get transcription segments
 	segments, err := s.transcriptionRepo.GetSegments(ctx, transcriptionID)
 	if err != nil {
 		return nil, err
 	}
 
+	// Restricted to chapters, only their segments are translated
+	chapters := chaptersFrom(ctx)
+	var parts [][]*model.TranscriptionSegment
+	if len(chapters) > 0 {
+		if parts, err = chapterSegments(segments, chapters); err != nil {
+			return nil, err
+		}
+		segments = nil
+		for _, part := range parts {
+			segments = append(segments, part...)
+		}
+	}
+
 	if len(segments) == 0 {
 		return nil, errors.New("no segments found")
 	}
 
-	// Step 2: Create batches for efficient translation
-	batches, err := s.batchProcessor.CreateBatches(segments, defaultMaxTokens)
+	// Step 2: Translate segments in batches, sized for the transcription's language
+	sourceLanguage, err := s.sourceLanguage(ctx, transcriptionID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Step 3: Optimize for batch translation - start server once for multiple batches
-	sourceLanguage := "en" // Default source language - should be detected from transcription
+	var allTranslatedSegments []*TranslationSegment
+	if len(chapters) > 0 {
+		allTranslatedSegments, err = s.translateChapters(ctx, chapters, parts, sourceLanguage, targetLang)
+	} else {
+		allTranslatedSegments, err = s.translateSegments(ctx, segments, sourceLanguage, targetLang)
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	// If we have multiple batches, start the server once for better performance
-	if len(batches) > 1 {
-		if err := s.plamoService.StartServer(ctx); err != nil {
-			// If server startup fails, continue with simple mode
-			// Server implementation will handle this gracefully
-		}
-		// Note: We don't defer StopServer here as it's managed at CLI level
+	// Step 3: Prepare translations for batch save (one per segment)
+	options := providerOptionsFrom(ctx)
+	if style != "" {
+		return s.createStyledTranslations(ctx, segments, allTranslatedSegments, sourceLanguage, targetLang, style, options)
 	}
+	translations := s.newTranslations(allTranslatedSegments, targetLang, ProviderPlamo, "", options)
 
-	// Step 4: Translate each batch with fallback strategy
-	var allTranslatedSegments []*TranslationSegment
+	// Step 4: Save all translations using batch insert
+	err = s.translationRepo.CreateBatch(ctx, translations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save translations: %w", err)
+	}
 
-	for _, batch := range batches {
-		translatedSegments, err := s.batchProcessor.TranslateBatchWithFallback(
-			batch, s.plamoService, ctx, sourceLanguage, targetLang,
-		)
+	// Step 5: Post-edit the PLaMo output when a polisher is configured. The raw translations
+	// are already saved, so a failed pass only loses the polished version.
+	if s.polisher != nil && len(translations) > 0 {
+		// Nothing to save when no segment came back polished; the raw translation stands
+		polished, err := s.polishTranslations(ctx, segments, allTranslatedSegments, sourceLanguage, targetLang)
+		if err == nil && len(polished) > 0 {
+			polishedTranslations := s.newTranslations(polished, targetLang, SourcePolished, "", options)
+			if err = s.translationRepo.CreateBatch(ctx, polishedTranslations); err == nil {
+				return polishedTranslations[0], nil
+			}
+		}
 		if err != nil {
-			return nil, fmt.Errorf("batch translation failed: %w", err)
+			fmt.Fprintf(os.Stderr, "Warning: %v; keeping the raw PLaMo translation\n", err)
 		}
+	}
 
-		allTranslatedSegments = append(allTranslatedSegments, translatedSegments...)
+	// Return the first translation as representative (for CLI display purposes)
+	if len(translations) > 0 {
+		return translations[0], nil
 	}
 
-	// Step 5: Prepare translations for batch save (one per segment)
+	return nil, errors.New("no translations created")
+}
+
+// createStyledTranslations post-edits the PLaMo translations in style and saves only the styled
+// version, so each style is a variant of its own next to the plain translation. Unlike plain
+// translations, a failed post-editing pass fails the translation: the raw PLaMo output is not
+// in the requested style.
+func (s *translationService) createStyledTranslations(ctx context.Context, segments []*model.TranscriptionSegment, translated []*TranslationSegment, sourceLang, targetLang, style string, options ProviderOptions) (*model.Translation, error) {
+	polished, err := s.polishTranslations(ctx, segments, translated, sourceLang, targetLang)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply the %s style: %w", style, err)
+	}
+
+	translations := s.newTranslations(polished, targetLang, SourcePolished, style, options)
+	if len(translations) == 0 {
+		return nil, errors.New("no translations created")
+	}
+	if err := s.translationRepo.CreateBatch(ctx, translations); err != nil {
+		return nil, fmt.Errorf("failed to save translations: %w", err)
+	}
+	return translations[0], nil
+}
+
+// newTranslations prepares one translation per translated segment, recorded under source with the
+// style and provider options it was made with. Raw translations of scheduled batches are recorded
+// under the provider that made them instead of PLaMo.
+func (s *translationService) newTranslations(segments []*TranslationSegment, targetLang, source, style string, options ProviderOptions) []*model.Translation {
+	now := s.clock.Now()
 	var translations []*model.Translation
-	for _, seg := range allTranslatedSegments {
-		translation := &model.Translation{
+	for _, seg := range segments {
+		recorded := source
+		if source == ProviderPlamo && seg.Provider != "" {
+			recorded = seg.Provider
+		}
+		translations = append(translations, &model.Translation{
 			TranscriptionSegmentID: seg.TranscriptionSegmentID,
 			TargetLanguage:         targetLang,
 			TranslatedText:         seg.TranslatedText,
-			Source:                 "plamo",
-		}
-		translations = append(translations, translation)
+			Source:                 recorded,
+			Style:                  style,
+			SplitStrategy:          seg.SplitStrategy,
+			Chapter:                seg.Chapter,
+			ProviderOptions:        options,
+			CreatedAt:              now,
+		})
 	}
+	return translations
+}
 
-	// Step 6: Save all translations using batch insert
-	err = s.translationRepo.CreateBatch(ctx, translations)
+// translateSegments translates segments with PLaMo in token-limited batches
+func (s *translationService) translateSegments(ctx context.Context, segments []*model.TranscriptionSegment, sourceLanguage, targetLang string) ([]*TranslationSegment, error) {
+	// Create batches for efficient translation
+	batches, err := s.batchProcessor.CreateBatches(segments, sourceLanguage, defaultMaxTokens)
 	if err != nil {
-		return nil, fmt.Errorf("failed to save translations: %w", err)
+		return nil, err
 	}
 
-	// Return the first translation as representative (for CLI display purposes)
-	if len(translations) > 0 {
-		return translations[0], nil
+	// Optimize for batch translation - start server once for multiple batches
+	// If we have multiple batches, start the server once for better performance
+	if len(batches) > 1 {
+		if err := s.plamoService.StartServer(ctx); err != nil {
+			// If server startup fails, continue with simple mode
+			// Server implementation will handle this gracefully
+		}
+		// Note: We don't defer StopServer here as it's managed at CLI level
 	}
 
-	return nil, errors.New("no translations created")
+	// Translate batches with fallback strategy, concurrently when workers allow
+	return s.translateBatches(ctx, batches, sourceLanguage, targetLang)
+}
+
+// translateChapters translates the segments of each chapter in batches of their own, so no
+// PLaMo batch spans two chapters
+func (s *translationService) translateChapters(ctx context.Context, chapters []*Chapter, parts [][]*model.TranscriptionSegment, sourceLanguage, targetLang string) ([]*TranslationSegment, error) {
+	var translated []*TranslationSegment
+	for i, chapter := range chapters {
+		if len(parts[i]) == 0 {
+			continue
+		}
+		segments, err := s.translateSegments(ctx, parts[i], sourceLanguage, targetLang)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate chapter %s: %w", chapter.Range(), err)
+		}
+		for _, segment := range segments {
+			segment.Chapter = chapter.Range()
+		}
+		translated = append(translated, segments...)
+	}
+	return translated, nil
+}
+
+// sourceLanguage returns the language segments of a transcription are written in
+func (s *translationService) sourceLanguage(ctx context.Context, transcriptionID string) (string, error) {
+	transcription, err := s.transcriptionRepo.Get(ctx, transcriptionID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get transcription: %w", err)
+	}
+	return TranscriptionLanguage(transcription), nil
+}
+
+// TranscriptionLanguage returns the detected language of a transcription, then its requested
+// language unless that was "auto", then the default source language
+func TranscriptionLanguage(transcription *model.Transcription) string {
+	if transcription == nil {
+		return defaultSourceLanguage
+	}
+	if transcription.DetectedLanguage != nil && *transcription.DetectedLanguage != "" {
+		return *transcription.DetectedLanguage
+	}
+	if transcription.Language != "" && transcription.Language != "auto" {
+		return transcription.Language
+	}
+	return defaultSourceLanguage
 }
 
 // GetTranslation retrieves a translation
@@ -161,9 +406,33 @@ func (s *translationService) GetTranslation(ctx context.Context, id string) (*mo
 		return nil, nil, fmt.Errorf("failed to get translation: %w", err)
 	}
 
-	// Get segments to reconstruct translation segments
-	// First, we need to reverse-engineer the transcription ID from the hashed value
-	// For now, we'll return the translation segments by parsing the translated text
+	// Rows translate one original segment, so take the timing of that segment
+	if translation.TranscriptionSegmentID != "" {
+		transcriptionID, err := s.translationRepo.GetTranscriptionID(ctx, translationID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get transcription of translation: %w", err)
+		}
+		segments, err := s.transcriptionRepo.GetSegments(ctx, transcriptionID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get segments: %w", err)
+		}
+		for _, segment := range segments {
+			if segment.ID == translation.TranscriptionSegmentID {
+				return translation, []*TranslationSegment{{
+					TranscriptionSegmentID: segment.ID,
+					SegmentIndex:           segment.SegmentIndex,
+					StartTime:              segment.StartTime,
+					EndTime:                segment.EndTime,
+					Text:                   segment.Text,
+					TranslatedText:         translation.TranslatedText,
+					SplitStrategy:          translation.SplitStrategy,
+					Chapter:                translation.Chapter,
+				}}, nil
+			}
+		}
+	}
+
+	// Without its segment, return the translation segments by parsing the translated text
 	segments, err := s.parseTranslationSegments(translation.TranslatedText)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to parse translation segments: %w", err)
@@ -172,6 +441,96 @@ func (s *translationService) GetTranslation(ctx context.Context, id string) (*mo
 	return translation, segments, nil
 }
 
+// sourceKey is the context key of the source translations are read from
+type sourceKey struct{}
+
+// WithSource returns a context whose translations are only read from source (e.g. plamo-polished)
+func WithSource(ctx context.Context, source string) context.Context {
+	if source == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, sourceKey{}, source)
+}
+
+// sourceFrom returns the source of ctx, empty when translations of any source are read
+func sourceFrom(ctx context.Context) string {
+	source, _ := ctx.Value(sourceKey{}).(string)
+	return source
+}
+
+// GetAlignedTranslation pairs each transcription segment with its stored translation in targetLang,
+// keeping the original segment timings. Segments without a translation are omitted. The style of
+// ctx (WithStyle) selects a variant; the plain translation is used by default. The source of ctx
+// (WithSource) restricts it to the translations of one source; the newest of any source is used
+// by default.
+func (s *translationService) GetAlignedTranslation(ctx context.Context, transcriptionID string, targetLang string) ([]*TranslationSegment, error) {
+	segments, err := s.transcriptionRepo.GetSegments(ctx, transcriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get segments: %w", err)
+	}
+
+	all, err := s.loadTranslations(ctx, transcriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Translations are ordered by segment index, newest first, so the first hit per segment wins
+	style, source := styleFrom(ctx), sourceFrom(ctx)
+	translated := make(map[string]string)
+	for _, t := range all {
+		if t.TargetLanguage != targetLang || t.Style != style || (source != "" && t.Source != source) {
+			continue
+		}
+		if _, ok := translated[t.TranscriptionSegmentID]; !ok {
+			translated[t.TranscriptionSegmentID] = t.TranslatedText
+		}
+	}
+
+	if len(translated) == 0 {
+		variant := targetLang + " translations"
+		if source != "" {
+			variant += " from " + source
+		}
+		if style != "" {
+			variant += " in the " + style + " style"
+		}
+		return nil, fmt.Errorf("no %s found for transcription %s", variant, transcriptionID)
+	}
+
+	var aligned []*TranslationSegment
+	for _, segment := range segments {
+		text, ok := translated[segment.ID]
+		if !ok {
+			continue
+		}
+		aligned = append(aligned, &TranslationSegment{
+			TranscriptionSegmentID: segment.ID,
+			SegmentIndex:           segment.SegmentIndex,
+			StartTime:              segment.StartTime,
+			EndTime:                segment.EndTime,
+			Text:                   segment.Text,
+			TranslatedText:         text,
+		})
+	}
+
+	return aligned, nil
+}
+
+// loadTranslations loads every stored translation of a transcription, page by page
+func (s *translationService) loadTranslations(ctx context.Context, transcriptionID string) ([]*model.Translation, error) {
+	var all []*model.Translation
+	for offset := 0; ; offset += alignedPageSize {
+		page, err := s.translationRepo.ListByTranscriptionID(ctx, transcriptionID, alignedPageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list translations: %w", err)
+		}
+		all = append(all, page...)
+		if len(page) < alignedPageSize {
+			return all, nil
+		}
+	}
+}
+
 // ListTranslations retrieves translations for a transcription with pagination
 func (s *translationService) ListTranslations(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error) {
 	// Get translations from repository with pagination (transcriptionID is UUID string)
@@ -183,6 +542,16 @@ func (s *translationService) ListTranslations(ctx context.Context, transcription
 	return translations, nil
 }
 
+// CountTranslations returns the number of translations of a transcription
+func (s *translationService) CountTranslations(ctx context.Context, transcriptionID string) (int, error) {
+	count, err := s.translationRepo.CountByTranscriptionID(ctx, transcriptionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count translations: %w", err)
+	}
+
+	return count, nil
+}
+
 // DeleteTranslation deletes a translation by ID
 func (s *translationService) DeleteTranslation(ctx context.Context, id string) error {
 	// Convert string ID to int
@@ -28,7 +28,7 @@ func TestTranslationService_FallbackStrategy(t *testing.T) {
 				}
 
 				// Setup batches
-				bp.CreateBatchesFunc = func(segments []*model.TranscriptionSegment, maxTokens int) ([]SegmentBatch, error) {
+				bp.CreateBatchesFunc = func(segments []*model.TranscriptionSegment, sourceLang string, maxTokens int) ([]SegmentBatch, error) {
 					return []SegmentBatch{
 						{
 							Segments:     segments,
@@ -62,7 +62,7 @@ func TestTranslationService_FallbackStrategy(t *testing.T) {
 					}, nil
 				}
 
-				bp.CreateBatchesFunc = func(segments []*model.TranscriptionSegment, maxTokens int) ([]SegmentBatch, error) {
+				bp.CreateBatchesFunc = func(segments []*model.TranscriptionSegment, sourceLang string, maxTokens int) ([]SegmentBatch, error) {
 					return []SegmentBatch{
 						{
 							Segments:     segments,
@@ -93,7 +93,7 @@ func TestTranslationService_FallbackStrategy(t *testing.T) {
 					}, nil
 				}
 
-				bp.CreateBatchesFunc = func(segments []*model.TranscriptionSegment, maxTokens int) ([]SegmentBatch, error) {
+				bp.CreateBatchesFunc = func(segments []*model.TranscriptionSegment, sourceLang string, maxTokens int) ([]SegmentBatch, error) {
 					return []SegmentBatch{
 						{
 							Segments:     segments,
@@ -163,13 +163,13 @@ func TestTranslationService_FallbackStrategy(t *testing.T) {
 
 // Mock batch processor with fallback support
 type mockBatchProcessorWithFallback struct {
-	CreateBatchesFunc              func(segments []*model.TranscriptionSegment, maxTokens int) ([]SegmentBatch, error)
+	CreateBatchesFunc              func(segments []*model.TranscriptionSegment, sourceLang string, maxTokens int) ([]SegmentBatch, error)
 	TranslateBatchWithFallbackFunc func(batch SegmentBatch, plamoService PlamoService, ctx context.Context, sourceLang, targetLang string) ([]*TranslationSegment, error)
 }
 
-func (m *mockBatchProcessorWithFallback) CreateBatches(segments []*model.TranscriptionSegment, maxTokens int) ([]SegmentBatch, error) {
+func (m *mockBatchProcessorWithFallback) CreateBatches(segments []*model.TranscriptionSegment, sourceLang string, maxTokens int) ([]SegmentBatch, error) {
 	if m.CreateBatchesFunc != nil {
-		return m.CreateBatchesFunc(segments, maxTokens)
+		return m.CreateBatchesFunc(segments, sourceLang, maxTokens)
 	}
 	return nil, nil
 }
@@ -10,8 +10,9 @@ import (
 
 // mockTranscriptionRepo mocks TranscriptionRepository
 type mockTranscriptionRepo struct {
-	GetSegmentsFunc func(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error)
-	GetFunc         func(ctx context.Context, id string) (*model.Transcription, error)
+	GetSegmentsFunc      func(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error)
+	GetFunc              func(ctx context.Context, id string) (*model.Transcription, error)
+	GetVideoChaptersFunc func(ctx context.Context, videoID string) ([]model.VideoChapter, error)
 }
 
 func (m *mockTranscriptionRepo) GetSegments(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error) {
@@ -28,13 +29,22 @@ func (m *mockTranscriptionRepo) Get(ctx context.Context, id string) (*model.Tran
 	return nil, nil
 }
 
+func (m *mockTranscriptionRepo) GetVideoChapters(ctx context.Context, videoID string) ([]model.VideoChapter, error) {
+	if m.GetVideoChaptersFunc != nil {
+		return m.GetVideoChaptersFunc(ctx, videoID)
+	}
+	return nil, nil
+}
+
 // mockTranslationRepo mocks TranslationRepository
 type mockTranslationRepo struct {
-	CreateFunc                func(ctx context.Context, translation *model.Translation) error
-	CreateBatchFunc           func(ctx context.Context, translations []*model.Translation) error
-	GetFunc                   func(ctx context.Context, id int) (*model.Translation, error)
-	ListByTranscriptionIDFunc func(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error)
-	DeleteFunc                func(ctx context.Context, id int) error
+	CreateFunc                 func(ctx context.Context, translation *model.Translation) error
+	CreateBatchFunc            func(ctx context.Context, translations []*model.Translation) error
+	GetFunc                    func(ctx context.Context, id int) (*model.Translation, error)
+	GetTranscriptionIDFunc     func(ctx context.Context, id int) (string, error)
+	ListByTranscriptionIDFunc  func(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error)
+	CountByTranscriptionIDFunc func(ctx context.Context, transcriptionID string) (int, error)
+	DeleteFunc                 func(ctx context.Context, id int) error
 }
 
 func (m *mockTranslationRepo) Create(ctx context.Context, translation *model.Translation) error {
@@ -58,6 +68,13 @@ func (m *mockTranslationRepo) Get(ctx context.Context, id int) (*model.Translati
 	return nil, nil
 }
 
+func (m *mockTranslationRepo) GetTranscriptionID(ctx context.Context, id int) (string, error) {
+	if m.GetTranscriptionIDFunc != nil {
+		return m.GetTranscriptionIDFunc(ctx, id)
+	}
+	return "", nil
+}
+
 func (m *mockTranslationRepo) ListByTranscriptionID(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error) {
 	if m.ListByTranscriptionIDFunc != nil {
 		return m.ListByTranscriptionIDFunc(ctx, transcriptionID, limit, offset)
@@ -65,6 +82,13 @@ func (m *mockTranslationRepo) ListByTranscriptionID(ctx context.Context, transcr
 	return []*model.Translation{}, nil
 }
 
+func (m *mockTranslationRepo) CountByTranscriptionID(ctx context.Context, transcriptionID string) (int, error) {
+	if m.CountByTranscriptionIDFunc != nil {
+		return m.CountByTranscriptionIDFunc(ctx, transcriptionID)
+	}
+	return 0, nil
+}
+
 func (m *mockTranslationRepo) Delete(ctx context.Context, id int) error {
 	if m.DeleteFunc != nil {
 		return m.DeleteFunc(ctx, id)
@@ -77,13 +101,13 @@ type Batch = SegmentBatch
 
 // mockBatchProcessor mocks BatchProcessor interface
 type mockBatchProcessor struct {
-	CreateBatchesFunc              func(segments []*model.TranscriptionSegment, maxTokens int) ([]SegmentBatch, error)
+	CreateBatchesFunc              func(segments []*model.TranscriptionSegment, sourceLang string, maxTokens int) ([]SegmentBatch, error)
 	TranslateBatchWithFallbackFunc func(batch SegmentBatch, plamoService PlamoService, ctx context.Context, sourceLang, targetLang string) ([]*TranslationSegment, error)
 }
 
-func (m *mockBatchProcessor) CreateBatches(segments []*model.TranscriptionSegment, maxTokens int) ([]SegmentBatch, error) {
+func (m *mockBatchProcessor) CreateBatches(segments []*model.TranscriptionSegment, sourceLang string, maxTokens int) ([]SegmentBatch, error) {
 	if m.CreateBatchesFunc != nil {
-		return m.CreateBatchesFunc(segments, maxTokens)
+		return m.CreateBatchesFunc(segments, sourceLang, maxTokens)
 	}
 	return []SegmentBatch{}, nil
 }
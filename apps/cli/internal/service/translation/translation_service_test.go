@@ -18,6 +18,8 @@ func TestTranslationService_GetTranslation(t *testing.T) {
 		wantErr    bool
 		expectNil  bool
 		expectedID int
+
+		expectedSegment *TranslationSegment
 	}{
 		{
 			name: "successful get translation",
@@ -37,6 +39,22 @@ func TestTranslationService_GetTranslation(t *testing.T) {
 			expectNil:  false,
 			expectedID: 123,
 		},
+		{
+			name: "takes the timing of the translated segment",
+			id:   "124",
+			setupMocks: func(tr *mockTranslationRepo) {
+				tr.GetFunc = func(ctx context.Context, id int) (*model.Translation, error) {
+					return &model.Translation{ID: 124, TranscriptionSegmentID: "seg-2", TargetLanguage: "ja", TranslatedText: "世界"}, nil
+				}
+				tr.GetTranscriptionIDFunc = func(ctx context.Context, id int) (string, error) {
+					return "trans-1", nil
+				}
+			},
+			expectedID: 124,
+			expectedSegment: &TranslationSegment{
+				TranscriptionSegmentID: "seg-2", SegmentIndex: 1, StartTime: "00:00:02", EndTime: "00:00:04", Text: "World", TranslatedText: "世界",
+			},
+		},
 		{
 			name: "translation not found",
 			id:   "999",
@@ -62,7 +80,14 @@ func TestTranslationService_GetTranslation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Setup mocks
-			mockTranscriptionRepo := &mockTranscriptionRepo{}
+			mockTranscriptionRepo := &mockTranscriptionRepo{
+				GetSegmentsFunc: func(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error) {
+					return []*model.TranscriptionSegment{
+						{ID: "seg-1", SegmentIndex: 0, StartTime: "00:00:00", EndTime: "00:00:02", Text: "Hello"},
+						{ID: "seg-2", SegmentIndex: 1, StartTime: "00:00:02", EndTime: "00:00:04", Text: "World"},
+					}, nil
+				},
+			}
 			mockTranslationRepo := &mockTranslationRepo{}
 			mockCmdRunner := &MockCmdRunner{}
 			mockBatchProcessor := &mockBatchProcessor{}
@@ -97,6 +122,9 @@ func TestTranslationService_GetTranslation(t *testing.T) {
 				assert.Equal(t, tt.expectedID, translation.ID)
 				// Now we implement segment retrieval, so segments should be available
 				assert.NotNil(t, segments)
+				if tt.expectedSegment != nil {
+					assert.Equal(t, []*TranslationSegment{tt.expectedSegment}, segments)
+				}
 			}
 		})
 	}
@@ -266,3 +294,109 @@ func TestTranslationService_DeleteTranslation(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslationService_GetAlignedTranslation(t *testing.T) {
+	segments := []*model.TranscriptionSegment{
+		{ID: "seg-1", SegmentIndex: 0, StartTime: "00:00:01.5", EndTime: "00:00:04", Text: "Hello"},
+		{ID: "seg-2", SegmentIndex: 1, StartTime: "00:00:04", EndTime: "00:00:07.25", Text: "World"},
+		{ID: "seg-3", SegmentIndex: 2, StartTime: "00:00:08", EndTime: "00:00:09", Text: "Untranslated"},
+	}
+
+	tests := []struct {
+		name         string
+		style        string
+		source       string
+		translations []*model.Translation
+		wantErr      bool
+		expected     []*TranslationSegment
+	}{
+		{
+			name: "aligns translations to segment timings",
+			translations: []*model.Translation{
+				{TranscriptionSegmentID: "seg-1", TargetLanguage: "ja", TranslatedText: "こんにちは"},
+				{TranscriptionSegmentID: "seg-1", TargetLanguage: "ja", TranslatedText: "古い訳"},
+				{TranscriptionSegmentID: "seg-2", TargetLanguage: "fr", TranslatedText: "Monde"},
+				{TranscriptionSegmentID: "seg-2", TargetLanguage: "ja", TranslatedText: "世界"},
+			},
+			expected: []*TranslationSegment{
+				{TranscriptionSegmentID: "seg-1", SegmentIndex: 0, StartTime: "00:00:01.5", EndTime: "00:00:04", Text: "Hello", TranslatedText: "こんにちは"},
+				{TranscriptionSegmentID: "seg-2", SegmentIndex: 1, StartTime: "00:00:04", EndTime: "00:00:07.25", Text: "World", TranslatedText: "世界"},
+			},
+		},
+		{
+			name: "plain translations by default",
+			translations: []*model.Translation{
+				{TranscriptionSegmentID: "seg-1", TargetLanguage: "ja", TranslatedText: "やあ", Style: StyleCasual},
+				{TranscriptionSegmentID: "seg-1", TargetLanguage: "ja", TranslatedText: "こんにちは"},
+			},
+			expected: []*TranslationSegment{
+				{TranscriptionSegmentID: "seg-1", SegmentIndex: 0, StartTime: "00:00:01.5", EndTime: "00:00:04", Text: "Hello", TranslatedText: "こんにちは"},
+			},
+		},
+		{
+			name:  "style variant selected by the context",
+			style: StyleCasual,
+			translations: []*model.Translation{
+				{TranscriptionSegmentID: "seg-1", TargetLanguage: "ja", TranslatedText: "やあ", Style: StyleCasual},
+				{TranscriptionSegmentID: "seg-1", TargetLanguage: "ja", TranslatedText: "こんにちは"},
+			},
+			expected: []*TranslationSegment{
+				{TranscriptionSegmentID: "seg-1", SegmentIndex: 0, StartTime: "00:00:01.5", EndTime: "00:00:04", Text: "Hello", TranslatedText: "やあ"},
+			},
+		},
+		{
+			name:   "source selected by the context",
+			source: SourcePolished,
+			translations: []*model.Translation{
+				{TranscriptionSegmentID: "seg-1", TargetLanguage: "ja", TranslatedText: "こんにちは", Source: ProviderPlamo},
+				{TranscriptionSegmentID: "seg-1", TargetLanguage: "ja", TranslatedText: "こんにちは！", Source: SourcePolished},
+			},
+			expected: []*TranslationSegment{
+				{TranscriptionSegmentID: "seg-1", SegmentIndex: 0, StartTime: "00:00:01.5", EndTime: "00:00:04", Text: "Hello", TranslatedText: "こんにちは！"},
+			},
+		},
+		{
+			name:   "no translations from the source",
+			source: ProviderManual,
+			translations: []*model.Translation{
+				{TranscriptionSegmentID: "seg-1", TargetLanguage: "ja", TranslatedText: "こんにちは", Source: ProviderPlamo},
+			},
+			wantErr: true,
+		},
+		{
+			name: "no translations in target language",
+			translations: []*model.Translation{
+				{TranscriptionSegmentID: "seg-1", TargetLanguage: "fr", TranslatedText: "Bonjour"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transcriptionRepo := &mockTranscriptionRepo{
+				GetSegmentsFunc: func(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error) {
+					return segments, nil
+				},
+			}
+			translationRepo := &mockTranslationRepo{
+				ListByTranscriptionIDFunc: func(ctx context.Context, transcriptionID string, limit, offset int) ([]*model.Translation, error) {
+					if offset > 0 {
+						return nil, nil
+					}
+					return tt.translations, nil
+				},
+			}
+
+			service := NewTranslationService(transcriptionRepo, translationRepo, NewPlamoService(&MockCmdRunner{}), &mockBatchProcessor{})
+
+			aligned, err := service.GetAlignedTranslation(WithSource(WithStyle(context.Background(), tt.style), tt.source), "trans-1", "ja")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, aligned)
+		})
+	}
+}
@@ -33,7 +33,7 @@ func TestTranslationService_CreateTranslation(t *testing.T) {
 				}
 
 				// Setup batch processor
-				bp.CreateBatchesFunc = func(segments []*model.TranscriptionSegment, maxTokens int) ([]SegmentBatch, error) {
+				bp.CreateBatchesFunc = func(segments []*model.TranscriptionSegment, sourceLang string, maxTokens int) ([]SegmentBatch, error) {
 					return []SegmentBatch{
 						{
 							Segments:     segments,
@@ -86,7 +86,7 @@ func TestTranslationService_CreateTranslation(t *testing.T) {
 					}, nil
 				}
 
-				bp.CreateBatchesFunc = func(segments []*model.TranscriptionSegment, maxTokens int) ([]SegmentBatch, error) {
+				bp.CreateBatchesFunc = func(segments []*model.TranscriptionSegment, sourceLang string, maxTokens int) ([]SegmentBatch, error) {
 					return []SegmentBatch{
 						{Segments: segments, CombinedText: "Hello", Separator: "__"},
 					}, nil
@@ -144,3 +144,49 @@ func TestTranslationService_CreateTranslation(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslationService_CreateTranslation_UsesDetectedLanguage(t *testing.T) {
+	detected := "ko"
+
+	tests := []struct {
+		name          string
+		transcription *model.Transcription
+		expectedLang  string
+	}{
+		{name: "detected language", transcription: &model.Transcription{Language: "auto", DetectedLanguage: &detected}, expectedLang: "ko"},
+		{name: "requested language", transcription: &model.Transcription{Language: "ja"}, expectedLang: "ja"},
+		{name: "auto without detection", transcription: &model.Transcription{Language: "auto"}, expectedLang: "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var batchLang, translateLang string
+
+			transcriptionRepo := &mockTranscriptionRepo{
+				GetFunc: func(ctx context.Context, id string) (*model.Transcription, error) {
+					return tt.transcription, nil
+				},
+				GetSegmentsFunc: func(ctx context.Context, id string) ([]*model.TranscriptionSegment, error) {
+					return []*model.TranscriptionSegment{{ID: "seg-1", Text: "안녕하세요"}}, nil
+				},
+			}
+			batchProcessor := &mockBatchProcessor{
+				CreateBatchesFunc: func(segments []*model.TranscriptionSegment, sourceLang string, maxTokens int) ([]SegmentBatch, error) {
+					batchLang = sourceLang
+					return []SegmentBatch{{Segments: segments}}, nil
+				},
+				TranslateBatchWithFallbackFunc: func(batch SegmentBatch, plamoService PlamoService, ctx context.Context, sourceLang, targetLang string) ([]*TranslationSegment, error) {
+					translateLang = sourceLang
+					return []*TranslationSegment{{TranscriptionSegmentID: "seg-1", TranslatedText: "こんにちは"}}, nil
+				},
+			}
+
+			service := NewTranslationService(transcriptionRepo, &mockTranslationRepo{}, NewPlamoService(&MockCmdRunner{}), batchProcessor)
+
+			_, err := service.CreateTranslation(context.Background(), "trans-1", "ja")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedLang, batchLang)
+			assert.Equal(t, tt.expectedLang, translateLang)
+		})
+	}
+}
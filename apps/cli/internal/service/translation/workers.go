@@ -0,0 +1,97 @@
+package translation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const (
+	DefaultWorkers = 1 // Batches are translated one at a time unless configured otherwise
+	batchAttempts  = 2 // Attempts per batch before the translation fails
+)
+
+// translateBatches translates batches with up to s.workers concurrent PLaMo requests and
+// reassembles the results in batch order. Each batch is retried on its own; once a batch
+// has exhausted its attempts the remaining batches are cancelled.
+func (s *translationService) translateBatches(ctx context.Context, batches []SegmentBatch, sourceLanguage, targetLang string) ([]*TranslationSegment, error) {
+	workers := min(max(s.workers, 1), len(batches))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([][]*TranslationSegment, len(batches))
+	jobs := make(chan int)
+
+	var (
+		wg       sync.WaitGroup
+		failOnce sync.Once
+		failErr  error
+	)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				translated, err := s.translateBatch(ctx, batches[i], sourceLanguage, targetLang)
+				if err != nil {
+					// Keep the first failure; later ones are usually caused by the cancellation
+					failOnce.Do(func() {
+						failErr = fmt.Errorf("batch translation failed (batch %d of %d): %w", i+1, len(batches), err)
+						cancel()
+					})
+					continue
+				}
+				results[i] = translated
+			}
+		}()
+	}
+
+feed:
+	for i := range batches {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if failErr != nil {
+		return nil, failErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("batch translation cancelled: %w", err)
+	}
+
+	reportSplitStrategies(results)
+
+	var allTranslatedSegments []*TranslationSegment
+	for _, translated := range results {
+		allTranslatedSegments = append(allTranslatedSegments, translated...)
+	}
+	return allTranslatedSegments, nil
+}
+
+// translateBatch translates one batch with the fallback strategy, retrying it independently
+// of the other batches
+func (s *translationService) translateBatch(ctx context.Context, batch SegmentBatch, sourceLanguage, targetLang string) ([]*TranslationSegment, error) {
+	if s.scheduler != nil {
+		return s.translateScheduledBatch(ctx, batch, sourceLanguage, targetLang)
+	}
+
+	var err error
+	for attempt := 1; attempt <= batchAttempts; attempt++ {
+		var translated []*TranslationSegment
+		translated, err = s.batchProcessor.TranslateBatchWithFallback(batch, s.plamoService, ctx, sourceLanguage, targetLang)
+		if err == nil {
+			return translated, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("%w (after %d attempts)", err, batchAttempts)
+}
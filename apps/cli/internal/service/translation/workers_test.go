@@ -0,0 +1,128 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// numberedBatches creates n single-segment batches with IDs seg-0 .. seg-(n-1)
+func numberedBatches(n int) []SegmentBatch {
+	batches := make([]SegmentBatch, n)
+	for i := range batches {
+		batches[i] = SegmentBatch{Segments: []*model.TranscriptionSegment{
+			{ID: fmt.Sprintf("seg-%d", i), SegmentIndex: i, Text: fmt.Sprintf("text %d", i)},
+		}}
+	}
+	return batches
+}
+
+// echoBatch translates a batch by prefixing each segment's text
+func echoBatch(batch SegmentBatch) []*TranslationSegment {
+	var result []*TranslationSegment
+	for _, seg := range batch.Segments {
+		result = append(result, &TranslationSegment{
+			TranscriptionSegmentID: seg.ID,
+			SegmentIndex:           seg.SegmentIndex,
+			TranslatedText:         "translated " + seg.Text,
+			SplitStrategy:          SplitSeparator,
+		})
+	}
+	return result
+}
+
+func TestTranslationService_TranslateBatches_OrderedConcurrent(t *testing.T) {
+	const batchCount = 8
+
+	var inFlight, peak int32
+	batchProcessor := &mockBatchProcessor{
+		TranslateBatchWithFallbackFunc: func(batch SegmentBatch, plamoService PlamoService, ctx context.Context, sourceLang, targetLang string) ([]*TranslationSegment, error) {
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				old := atomic.LoadInt32(&peak)
+				if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+					break
+				}
+			}
+
+			// Earlier batches finish later so completion order differs from batch order
+			time.Sleep(time.Duration(batchCount-batch.Segments[0].SegmentIndex) * 5 * time.Millisecond)
+			return echoBatch(batch), nil
+		},
+	}
+
+	service := NewTranslationServiceWithWorkers(&mockTranscriptionRepo{}, &mockTranslationRepo{}, NewPlamoService(&MockCmdRunner{}), batchProcessor, 4).(*translationService)
+
+	result, err := service.translateBatches(context.Background(), numberedBatches(batchCount), "en", "ja")
+	require.NoError(t, err)
+	require.Len(t, result, batchCount)
+	for i, seg := range result {
+		assert.Equal(t, fmt.Sprintf("seg-%d", i), seg.TranscriptionSegmentID)
+		assert.Equal(t, fmt.Sprintf("translated text %d", i), seg.TranslatedText)
+	}
+	assert.LessOrEqual(t, peak, int32(4))
+	assert.Greater(t, peak, int32(1), "batches should be translated concurrently")
+}
+
+func TestTranslationService_TranslateBatches_RetriesFailedBatchOnly(t *testing.T) {
+	var mu sync.Mutex
+	attempts := make(map[string]int)
+
+	batchProcessor := &mockBatchProcessor{
+		TranslateBatchWithFallbackFunc: func(batch SegmentBatch, plamoService PlamoService, ctx context.Context, sourceLang, targetLang string) ([]*TranslationSegment, error) {
+			id := batch.Segments[0].ID
+			mu.Lock()
+			attempts[id]++
+			attempt := attempts[id]
+			mu.Unlock()
+
+			if id == "seg-2" && attempt == 1 {
+				return nil, errors.New("server busy")
+			}
+			return echoBatch(batch), nil
+		},
+	}
+
+	service := NewTranslationServiceWithWorkers(&mockTranscriptionRepo{}, &mockTranslationRepo{}, NewPlamoService(&MockCmdRunner{}), batchProcessor, 3).(*translationService)
+
+	result, err := service.translateBatches(context.Background(), numberedBatches(5), "en", "ja")
+	require.NoError(t, err)
+	require.Len(t, result, 5)
+	assert.Equal(t, "seg-2", result[2].TranscriptionSegmentID)
+
+	assert.Equal(t, 2, attempts["seg-2"])
+	for _, id := range []string{"seg-0", "seg-1", "seg-3", "seg-4"} {
+		assert.Equal(t, 1, attempts[id], "batch %s should not be retried", id)
+	}
+}
+
+func TestTranslationService_TranslateBatches_FailsAfterAttempts(t *testing.T) {
+	var attempts int32
+	batchProcessor := &mockBatchProcessor{
+		TranslateBatchWithFallbackFunc: func(batch SegmentBatch, plamoService PlamoService, ctx context.Context, sourceLang, targetLang string) ([]*TranslationSegment, error) {
+			if batch.Segments[0].ID == "seg-1" {
+				atomic.AddInt32(&attempts, 1)
+				return nil, errors.New("server busy")
+			}
+			return echoBatch(batch), nil
+		},
+	}
+
+	service := NewTranslationServiceWithWorkers(&mockTranscriptionRepo{}, &mockTranslationRepo{}, NewPlamoService(&MockCmdRunner{}), batchProcessor, 2).(*translationService)
+
+	result, err := service.translateBatches(context.Background(), numberedBatches(4), "en", "ja")
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "batch translation failed (batch 2 of 4)")
+	assert.Contains(t, err.Error(), "server busy")
+	assert.Equal(t, int32(batchAttempts), attempts)
+}
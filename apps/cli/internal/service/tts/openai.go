@@ -0,0 +1,86 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/offline"
+)
+
+const (
+	defaultOpenAIBaseURL = "https://api.openai.com/v1"
+	defaultOpenAIModel   = "tts-1"
+)
+
+// openAIBackend synthesizes speech with an OpenAI-compatible speech API
+type openAIBackend struct {
+	opts   BackendOptions
+	client *http.Client
+}
+
+// NewOpenAIBackend creates a backend calling an OpenAI-compatible speech API
+func NewOpenAIBackend(opts BackendOptions) Backend {
+	return NewOpenAIBackendWithClient(opts, &http.Client{Timeout: 2 * time.Minute})
+}
+
+// NewOpenAIBackendWithClient creates a backend using client (for testing)
+func NewOpenAIBackendWithClient(opts BackendOptions, client *http.Client) Backend {
+	if opts.BaseURL == "" {
+		opts.BaseURL = defaultOpenAIBaseURL
+	}
+	if opts.Model == "" {
+		opts.Model = defaultOpenAIModel
+	}
+	opts.BaseURL = strings.TrimRight(opts.BaseURL, "/")
+	return &openAIBackend{opts: opts, client: client}
+}
+
+type speechRequest struct {
+	Model          string `json:"model"`
+	Input          string `json:"input"`
+	Voice          string `json:"voice"`
+	ResponseFormat string `json:"response_format"`
+}
+
+// Synthesize requests the speech of text as WAV
+func (b *openAIBackend) Synthesize(ctx context.Context, text, voice string) ([]byte, error) {
+	if err := offline.Check("synthesizing speech with " + b.opts.Model); err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(speechRequest{Model: b.opts.Model, Input: text, Voice: voice, ResponseFormat: "wav"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.opts.BaseURL+"/audio/speech", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build speech request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if b.opts.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+b.opts.APIKey)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("speech request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("speech request failed: %s %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+
+	audio, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read speech response: %w", err)
+	}
+	return audio, nil
+}
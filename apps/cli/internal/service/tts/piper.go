@@ -0,0 +1,58 @@
+package tts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/janitor"
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+)
+
+// defaultPiperBinary is the piper command looked up in PATH when none is configured
+const defaultPiperBinary = "piper"
+
+// piperBackend synthesizes speech with a local piper voice (pip install piper-tts)
+type piperBackend struct {
+	cmdRunner common.CmdRunner
+	binary    string
+}
+
+// NewPiperBackend creates a backend running the piper binary (piper in PATH when empty)
+func NewPiperBackend(cmdRunner common.CmdRunner, binary string) Backend {
+	if binary == "" {
+		binary = defaultPiperBinary
+	}
+	return &piperBackend{cmdRunner: cmdRunner, binary: binary}
+}
+
+// Synthesize runs piper with voice as its model (a voice name or the path of its .onnx file),
+// writing the speech to a temporary file
+func (b *piperBackend) Synthesize(ctx context.Context, text, voice string) ([]byte, error) {
+	if voice == "" {
+		return nil, errors.New(errors.CodeInvalidArg, "piper needs a voice model")
+	}
+
+	dir, err := janitor.MkdirTemp("yt-lang-tts-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer janitor.RemoveTemp(dir)
+
+	outputPath := filepath.Join(dir, "speech.wav")
+	if _, err := b.cmdRunner.Run(ctx, b.binary, "--model", voice, "--output-file", outputPath, "--", text); err != nil {
+		if strings.Contains(err.Error(), "executable file not found") {
+			return nil, errors.Wrap(err, errors.CodeExternal, "piper is not installed or not found in PATH. Install it with pip install piper-tts")
+		}
+		return nil, errors.Wrap(err, errors.CodeExternal, "piper failed")
+	}
+
+	audio, err := os.ReadFile(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read piper output: %w", err)
+	}
+	return audio, nil
+}
@@ -0,0 +1,144 @@
+// Package tts reads translations aloud: each segment is synthesized by a speech backend (a local
+// piper voice or an OpenAI-compatible speech API) and the clips are laid out on the timeline of
+// the original video, so the audio can be listened to on its own, e.g. while commuting.
+package tts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+)
+
+// Backend names accepted by NewBackend
+const (
+	BackendPiper  = "piper"
+	BackendOpenAI = "openai"
+)
+
+// Backend synthesizes speech
+type Backend interface {
+	// Synthesize returns text spoken by voice as PCM WAV audio
+	Synthesize(ctx context.Context, text, voice string) ([]byte, error)
+}
+
+// BackendOptions configures a speech backend
+type BackendOptions struct {
+	Backend string // piper (default) or openai
+	Binary  string // piper only; defaults to piper in PATH
+	BaseURL string // openai only; defaults to the OpenAI API
+	Model   string // openai only; defaults to tts-1
+	APIKey  string // openai only; optional for local servers
+}
+
+// NewBackend creates the backend named by opts.Backend
+func NewBackend(opts BackendOptions) (Backend, error) {
+	switch opts.Backend {
+	case "", BackendPiper:
+		return NewPiperBackend(common.NewCmdRunner(), opts.Binary), nil
+	case BackendOpenAI:
+		return NewOpenAIBackend(opts), nil
+	default:
+		return nil, fmt.Errorf("unsupported tts backend: %s (supported: %s, %s)", opts.Backend, BackendPiper, BackendOpenAI)
+	}
+}
+
+// Segment is a translated segment to read aloud
+type Segment struct {
+	Start time.Duration // Start of the original segment in the video
+	Text  string
+}
+
+// Options controls how the segments are laid out
+type Options struct {
+	Voice  string        // Voice passed to the backend
+	MaxGap time.Duration // Longest silence kept between segments; 0 keeps the original gaps
+}
+
+// Report summarizes a rendered reading
+type Report struct {
+	Segments int           // Segments read
+	Skipped  int           // Segments without text
+	Overruns int           // Segments started late because the previous one was still being read
+	Duration time.Duration // Length of the audio
+}
+
+// Render reads segments aloud in order into a WAV file written to w. Each segment starts at its
+// start in the video, less the silence cut by opts.MaxGap, or right after the previous one when
+// that is still being read. The backend has to return the same format for every segment.
+func Render(ctx context.Context, backend Backend, segments []Segment, opts Options, w io.WriteSeeker) (*Report, error) {
+	report := &Report{}
+	var writer *wavWriter
+	var format wavFormat
+	var cut time.Duration // Silence left out so far by MaxGap
+
+	for i, segment := range segments {
+		text := strings.TrimSpace(segment.Text)
+		if text == "" {
+			report.Skipped++
+			continue
+		}
+
+		audio, err := backend.Synthesize(ctx, text, opts.Voice)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeExternal, fmt.Sprintf("failed to synthesize segment %d", i+1))
+		}
+		clipFormat, samples, err := readWAV(audio)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeExternal, fmt.Sprintf("failed to read the audio of segment %d", i+1))
+		}
+		if writer == nil {
+			format = clipFormat
+			if writer, err = newWAVWriter(w, format); err != nil {
+				return nil, fmt.Errorf("failed to write audio: %w", err)
+			}
+		} else if clipFormat != format {
+			return nil, fmt.Errorf("segment %d was synthesized as %+v, unlike the previous segments (%+v)", i+1, clipFormat, format)
+		}
+
+		gap := segment.Start - cut - writer.Duration()
+		if gap < 0 {
+			report.Overruns++
+		}
+		if opts.MaxGap > 0 && gap > opts.MaxGap {
+			cut += gap - opts.MaxGap
+			gap = opts.MaxGap
+		}
+		if err := writer.WriteSilence(gap); err != nil {
+			return nil, fmt.Errorf("failed to write audio: %w", err)
+		}
+		if err := writer.Write(samples); err != nil {
+			return nil, fmt.Errorf("failed to write audio: %w", err)
+		}
+		report.Segments++
+	}
+
+	if writer == nil {
+		return nil, errors.New(errors.CodeInvalidArg, "no segments with text to read")
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write audio: %w", err)
+	}
+	report.Duration = writer.Duration()
+	return report, nil
+}
+
+// Encode converts a WAV file to the format of outputPath's extension (e.g. .mp3, .m4a, .ogg) with ffmpeg
+func Encode(ctx context.Context, cmdRunner common.CmdRunner, wavPath, outputPath string) error {
+	if strings.EqualFold(filepath.Ext(outputPath), ".wav") {
+		return errors.New(errors.CodeInvalidArg, "WAV output needs no encoding")
+	}
+	args := []string{"-nostdin", "-hide_banner", "-loglevel", "error", "-y", "-i", wavPath, outputPath}
+	if _, err := cmdRunner.Run(ctx, common.ToolFFmpeg, args...); err != nil {
+		if strings.Contains(err.Error(), "executable file not found") {
+			return errors.Wrap(err, errors.CodeExternal, "ffmpeg is not installed or not found in PATH. Please install ffmpeg")
+		}
+		return errors.Wrap(err, errors.CodeExternal, "failed to encode audio with ffmpeg")
+	}
+	return nil
+}
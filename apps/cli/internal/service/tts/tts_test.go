@@ -0,0 +1,259 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testFormat plays 1000 bytes per second, so byte counts read as milliseconds
+var testFormat = wavFormat{Channels: 1, SampleRate: 500, BitsPerSample: 16}
+
+// testWAV returns a WAV clip of d filled with 0x11 samples
+func testWAV(t *testing.T, format wavFormat, d time.Duration) []byte {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "clip.wav")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	writer, err := newWAVWriter(file, format)
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(bytes.Repeat([]byte{0x11}, format.bytesFor(d))))
+	require.NoError(t, writer.Close())
+	require.NoError(t, file.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return data
+}
+
+// fakeBackend speaks each text for the duration given for it
+type fakeBackend struct {
+	t         *testing.T
+	durations map[string]time.Duration
+	formats   map[string]wavFormat // Format of a text's clip; testFormat otherwise
+	voices    []string
+}
+
+func (b *fakeBackend) Synthesize(ctx context.Context, text, voice string) ([]byte, error) {
+	b.voices = append(b.voices, voice)
+	d, ok := b.durations[text]
+	if !ok {
+		return nil, fmt.Errorf("unexpected text %q", text)
+	}
+	format, ok := b.formats[text]
+	if !ok {
+		format = testFormat
+	}
+	return testWAV(b.t, format, d), nil
+}
+
+// render renders segments into a temporary file and returns the report and the samples
+func render(t *testing.T, backend Backend, segments []Segment, opts Options) (*Report, []byte, error) {
+	t.Helper()
+	file, err := os.Create(filepath.Join(t.TempDir(), "out.wav"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	report, err := Render(context.Background(), backend, segments, opts, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := os.ReadFile(file.Name())
+	require.NoError(t, err)
+	format, samples, err := readWAV(data)
+	require.NoError(t, err)
+	assert.Equal(t, testFormat, format)
+	return report, samples, nil
+}
+
+// layout describes samples as runs, e.g. "500 silence, 1000 speech" in milliseconds
+func layout(samples []byte) []string {
+	var runs []string
+	for start := 0; start < len(samples); {
+		end := start
+		for end < len(samples) && samples[end] == samples[start] {
+			end++
+		}
+		kind := "speech"
+		if samples[start] == 0 {
+			kind = "silence"
+		}
+		runs = append(runs, fmt.Sprintf("%d %s", end-start, kind))
+		start = end
+	}
+	return runs
+}
+
+func TestRender(t *testing.T) {
+	backend := &fakeBackend{t: t, durations: map[string]time.Duration{
+		"おはよう":   time.Second,
+		"今日は晴れ":  3 * time.Second,
+		"さようなら":  time.Second,
+		"また明日ね。": 500 * time.Millisecond,
+	}}
+	segments := []Segment{
+		{Start: 500 * time.Millisecond, Text: "おはよう"},
+		{Start: 2 * time.Second, Text: " 今日は晴れ "},
+		{Start: 4 * time.Second, Text: "さようなら"}, // Still reading the previous segment
+		{Start: 5 * time.Second, Text: ""},
+		{Start: 20 * time.Second, Text: "また明日ね。"},
+	}
+
+	t.Run("keeps the original timing", func(t *testing.T) {
+		backend.voices = nil
+		report, samples, err := render(t, backend, segments, Options{Voice: "ja_JP-test"})
+		require.NoError(t, err)
+
+		// The speech runs of adjacent segments merge: 3000 + 1000
+		assert.Equal(t, []string{"500 silence", "1000 speech", "500 silence", "4000 speech", "14000 silence", "500 speech"}, layout(samples))
+		assert.Equal(t, &Report{Segments: 4, Skipped: 1, Overruns: 1, Duration: 20500 * time.Millisecond}, report)
+		assert.Equal(t, []string{"ja_JP-test", "ja_JP-test", "ja_JP-test", "ja_JP-test"}, backend.voices)
+	})
+
+	t.Run("caps long gaps", func(t *testing.T) {
+		report, samples, err := render(t, backend, segments, Options{MaxGap: 2 * time.Second})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"500 silence", "1000 speech", "500 silence", "4000 speech", "2000 silence", "500 speech"}, layout(samples))
+		assert.Equal(t, 8500*time.Millisecond, report.Duration)
+	})
+
+	t.Run("format changes between segments", func(t *testing.T) {
+		backend := &fakeBackend{t: t, durations: map[string]time.Duration{"a": time.Second, "b": time.Second}, formats: map[string]wavFormat{
+			"b": {Channels: 1, SampleRate: 22050, BitsPerSample: 16},
+		}}
+		_, _, err := render(t, backend, []Segment{{Text: "a"}, {Start: time.Second, Text: "b"}}, Options{})
+		assert.ErrorContains(t, err, "unlike the previous segments")
+	})
+
+	t.Run("nothing to read", func(t *testing.T) {
+		_, _, err := render(t, backend, []Segment{{Text: " "}}, Options{})
+		assert.ErrorContains(t, err, "no segments with text")
+	})
+}
+
+func TestReadWAV(t *testing.T) {
+	data := testWAV(t, testFormat, time.Second)
+
+	t.Run("streamed output with an unknown data size", func(t *testing.T) {
+		streamed := append([]byte(nil), data...)
+		binary.LittleEndian.PutUint32(streamed[40:], 0xFFFFFFFF)
+		format, samples, err := readWAV(streamed)
+		require.NoError(t, err)
+		assert.Equal(t, testFormat, format)
+		assert.Len(t, samples, 1000)
+	})
+
+	t.Run("not a WAV file", func(t *testing.T) {
+		_, _, err := readWAV([]byte("ID3 mp3 data"))
+		assert.ErrorContains(t, err, "not a WAV file")
+	})
+
+	t.Run("compressed audio", func(t *testing.T) {
+		compressed := append([]byte(nil), data...)
+		binary.LittleEndian.PutUint16(compressed[20:], 3)
+		_, _, err := readWAV(compressed)
+		assert.ErrorContains(t, err, "only PCM")
+	})
+}
+
+// fakeCmdRunner records commands, writing a WAV clip to the file after --output-file
+type fakeCmdRunner struct {
+	t    *testing.T
+	name string
+	args []string
+	err  error
+}
+
+func (r *fakeCmdRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	r.name, r.args = name, args
+	if r.err != nil {
+		return nil, r.err
+	}
+	for i, arg := range args {
+		if arg == "--output-file" {
+			require.NoError(r.t, os.WriteFile(args[i+1], testWAV(r.t, testFormat, time.Second), 0o644))
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeCmdRunner) Start(ctx context.Context, name string, args ...string) (common.Process, error) {
+	return nil, errors.New("not supported")
+}
+
+func TestPiperBackend(t *testing.T) {
+	runner := &fakeCmdRunner{t: t}
+	audio, err := NewPiperBackend(runner, "").Synthesize(context.Background(), "--こんにちは", "ja_JP-test")
+	require.NoError(t, err)
+
+	_, samples, err := readWAV(audio)
+	require.NoError(t, err)
+	assert.Len(t, samples, 1000)
+	assert.Equal(t, "piper", runner.name)
+	assert.Equal(t, []string{"--model", "ja_JP-test", "--output-file", runner.args[3], "--", "--こんにちは"}, runner.args)
+
+	_, err = NewPiperBackend(runner, "").Synthesize(context.Background(), "text", "")
+	assert.ErrorContains(t, err, "needs a voice model")
+
+	runner.err = errors.New(`exec: "piper": executable file not found in $PATH`)
+	_, err = NewPiperBackend(runner, "").Synthesize(context.Background(), "text", "voice")
+	assert.ErrorContains(t, err, "pip install piper-tts")
+}
+
+func TestOpenAIBackend(t *testing.T) {
+	clip := testWAV(t, testFormat, time.Second)
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/audio/speech", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.Write(clip)
+	}))
+	defer server.Close()
+
+	backend := NewOpenAIBackendWithClient(BackendOptions{BaseURL: server.URL + "/v1/", APIKey: "secret"}, server.Client())
+	audio, err := backend.Synthesize(context.Background(), "こんにちは", "nova")
+	require.NoError(t, err)
+	assert.Equal(t, clip, audio)
+	assert.JSONEq(t, `{"model": "tts-1", "input": "こんにちは", "voice": "nova", "response_format": "wav"}`, body)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid voice", http.StatusBadRequest)
+	}))
+	defer failing.Close()
+	_, err = NewOpenAIBackendWithClient(BackendOptions{BaseURL: failing.URL}, failing.Client()).Synthesize(context.Background(), "a", "ja-JP")
+	assert.ErrorContains(t, err, "invalid voice")
+}
+
+func TestEncode(t *testing.T) {
+	runner := &fakeCmdRunner{t: t}
+	require.NoError(t, Encode(context.Background(), runner, "/tmp/speech.wav", "out.mp3"))
+	assert.Equal(t, "ffmpeg", runner.name)
+	assert.Equal(t, []string{"-nostdin", "-hide_banner", "-loglevel", "error", "-y", "-i", "/tmp/speech.wav", "out.mp3"}, runner.args)
+
+	runner.err = errors.New("exit status 1")
+	assert.ErrorContains(t, Encode(context.Background(), runner, "/tmp/speech.wav", "out.mp3"), "failed to encode audio")
+}
+
+func TestNewBackend(t *testing.T) {
+	_, err := NewBackend(BackendOptions{Backend: "espeak"})
+	assert.ErrorContains(t, err, "unsupported tts backend: espeak")
+
+	backend, err := NewBackend(BackendOptions{})
+	require.NoError(t, err)
+	assert.IsType(t, &piperBackend{}, backend)
+}
@@ -0,0 +1,169 @@
+package tts
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// wavHeaderSize is the size of the header written by wavWriter: RIFF, fmt and data chunk headers
+const wavHeaderSize = 44
+
+// wavFormat is the sample format of PCM WAV audio
+type wavFormat struct {
+	Channels      uint16
+	SampleRate    uint32
+	BitsPerSample uint16
+}
+
+// bytesPerSecond returns the data rate of the format
+func (f wavFormat) bytesPerSecond() int {
+	return int(f.SampleRate) * f.frameSize()
+}
+
+// frameSize returns the size of one sample of every channel
+func (f wavFormat) frameSize() int {
+	return int(f.Channels) * int(f.BitsPerSample) / 8
+}
+
+// duration returns how long size bytes of audio play
+func (f wavFormat) duration(size int) time.Duration {
+	return time.Duration(size) * time.Second / time.Duration(f.bytesPerSecond())
+}
+
+// bytesFor returns the size of d worth of audio, whole frames only
+func (f wavFormat) bytesFor(d time.Duration) int {
+	frames := int(d * time.Duration(f.SampleRate) / time.Second)
+	return frames * f.frameSize()
+}
+
+// readWAV returns the format and samples of PCM WAV data. Streamed WAV output may declare an
+// unknown (oversized) data chunk; the samples then run to the end of the data.
+func readWAV(data []byte) (wavFormat, []byte, error) {
+	var format wavFormat
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return format, nil, errors.New("audio is not a WAV file")
+	}
+
+	haveFormat := false
+	for pos := 12; pos+8 <= len(data); {
+		id := string(data[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(data[pos+4 : pos+8]))
+		body := pos + 8
+		end := body + size
+		if end > len(data) {
+			end = len(data)
+		}
+
+		switch id {
+		case "fmt ":
+			if end-body < 16 {
+				return format, nil, errors.New("WAV format chunk is truncated")
+			}
+			if audioFormat := binary.LittleEndian.Uint16(data[body:]); audioFormat != 1 {
+				return format, nil, fmt.Errorf("unsupported WAV encoding %d (only PCM is supported)", audioFormat)
+			}
+			format.Channels = binary.LittleEndian.Uint16(data[body+2:])
+			format.SampleRate = binary.LittleEndian.Uint32(data[body+4:])
+			format.BitsPerSample = binary.LittleEndian.Uint16(data[body+14:])
+			if format.frameSize() == 0 || format.SampleRate == 0 {
+				return format, nil, errors.New("WAV format chunk is invalid")
+			}
+			haveFormat = true
+		case "data":
+			if !haveFormat {
+				return format, nil, errors.New("WAV data comes before its format")
+			}
+			samples := data[body:end]
+			return format, samples[:len(samples)-len(samples)%format.frameSize()], nil
+		}
+
+		// Chunks are padded to an even size
+		pos = end + size%2
+	}
+	return format, nil, errors.New("WAV file has no data")
+}
+
+// wavWriter streams PCM samples into a WAV file, filling in the sizes of the header on Close
+type wavWriter struct {
+	w      io.WriteSeeker
+	format wavFormat
+	size   int // Bytes of samples written
+}
+
+// newWAVWriter writes the header of a WAV file in format to w
+func newWAVWriter(w io.WriteSeeker, format wavFormat) (*wavWriter, error) {
+	writer := &wavWriter{w: w, format: format}
+	if err := writer.writeHeader(); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+// Write appends samples
+func (w *wavWriter) Write(samples []byte) error {
+	n, err := w.w.Write(samples)
+	w.size += n
+	return err
+}
+
+// WriteSilence appends d worth of silence
+func (w *wavWriter) WriteSilence(d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	// 8-bit PCM is unsigned: silence is the middle value
+	silence := byte(0)
+	if w.format.BitsPerSample == 8 {
+		silence = 0x80
+	}
+	chunk := make([]byte, min(w.format.bytesFor(d), w.format.bytesPerSecond()))
+	for i := range chunk {
+		chunk[i] = silence
+	}
+	for remaining := w.format.bytesFor(d); remaining > 0; remaining -= len(chunk) {
+		if err := w.Write(chunk[:min(len(chunk), remaining)]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Duration returns how long the audio written so far plays
+func (w *wavWriter) Duration() time.Duration {
+	return w.format.duration(w.size)
+}
+
+// Close rewrites the header with the final sizes
+func (w *wavWriter) Close() error {
+	if _, err := w.w.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	_, err := w.w.Seek(0, io.SeekEnd)
+	return err
+}
+
+// writeHeader writes the RIFF header for the samples written so far
+func (w *wavWriter) writeHeader() error {
+	header := make([]byte, wavHeaderSize)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(wavHeaderSize-8+w.size))
+	copy(header[8:], "WAVE")
+	copy(header[12:], "fmt ")
+	binary.LittleEndian.PutUint32(header[16:], 16)
+	binary.LittleEndian.PutUint16(header[20:], 1) // PCM
+	binary.LittleEndian.PutUint16(header[22:], w.format.Channels)
+	binary.LittleEndian.PutUint32(header[24:], w.format.SampleRate)
+	binary.LittleEndian.PutUint32(header[28:], uint32(w.format.bytesPerSecond()))
+	binary.LittleEndian.PutUint16(header[32:], uint16(w.format.frameSize()))
+	binary.LittleEndian.PutUint16(header[34:], w.format.BitsPerSample)
+	copy(header[36:], "data")
+	binary.LittleEndian.PutUint32(header[40:], uint32(w.size))
+	_, err := w.w.Write(header)
+	return err
+}
@@ -0,0 +1,81 @@
+package vocab
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// countCache stores per-transcription word counts on disk
+type countCache struct {
+	dir string
+}
+
+// cachedCounts is the on-disk cache entry for a transcription
+type cachedCounts struct {
+	TranscriptionID string         `json:"transcription_id"`
+	CompletedAt     *time.Time     `json:"completed_at"`
+	Counts          map[string]int `json:"counts"`
+}
+
+// newCountCache creates a cache rooted at dir; an empty dir disables caching
+func newCountCache(dir string) *countCache {
+	return &countCache{dir: dir}
+}
+
+// get returns cached counts if they were computed for the same completion of the transcription
+func (c *countCache) get(t *model.Transcription) (map[string]int, bool) {
+	if c.dir == "" {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(c.path(t.ID))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cachedCounts
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	if !sameTime(entry.CompletedAt, t.CompletedAt) {
+		return nil, false
+	}
+	return entry.Counts, true
+}
+
+// put stores counts for a transcription
+func (c *countCache) put(t *model.Transcription, counts map[string]int) error {
+	if c.dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(cachedCounts{
+		TranscriptionID: t.ID,
+		CompletedAt:     t.CompletedAt,
+		Counts:          counts,
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path(t.ID), data, 0644)
+}
+
+// path returns the cache file path for a transcription
+func (c *countCache) path(transcriptionID string) string {
+	return filepath.Join(c.dir, filepath.Base(transcriptionID)+".json")
+}
+
+// sameTime compares optional timestamps
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
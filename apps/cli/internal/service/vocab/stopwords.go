@@ -0,0 +1,42 @@
+package vocab
+
+import "strings"
+
+// stopwordLists holds common function words per language code
+var stopwordLists = map[string]string{
+	"en": `a about above after again against all am an and any are as at be because been before being
+		below between both but by can could did do does doing don't down during each few for from further
+		had has have having he her here hers herself him himself his how i i'm if in into is it it's its
+		itself just let's me more most my myself no nor not now of off on once only or other our ours
+		ourselves out over own really same she so some such than that that's the their theirs them
+		themselves then there there's these they they're this those through to too under until up very
+		was we we're were what when where which while who whom why will with would you you're your yours
+		yourself yourselves yeah oh okay um uh gonna like`,
+	"es": `a al algo algunos ante antes como con contra cual cuando de del desde donde durante e el ella
+		ellas ellos en entre era es esa esas ese eso esos esta estaba estado estamos estar este esto estos
+		estoy fue ha hay la las le les lo los me mi mis mucho muy más ni no nos nosotros o os otra otro
+		para pero poco por porque que quien qué se sea ser si sin sobre son su sus también te tiene todo
+		tu tus tú un una uno unos usted y ya yo él`,
+	"fr": `a au aux avec ce ces cela cette dans de des du elle elles en est et eu il ils je la le les leur
+		lui ma mais me même mes moi mon ne nos notre nous on ou où par pas pour qu que qui sa se ses son
+		sur ta te tes toi ton tu un une vos votre vous y c'est j'ai été être avoir fait`,
+	"de": `aber als am an auch auf aus bei bin bis bist da dann das dass dein dem den der des die dir du
+		durch ein eine einem einen einer es für hab habe haben hat ich ihr im in ist ja kann mein mich mir
+		mit nach nicht noch nur oder schon sein sich sie sind so um und uns vom von vor war was wenn wer
+		wie wir wird zu zum zur über`,
+	"it": `a ad al alla alle anche che chi ci come con da dal dei del della delle di e ed era gli ha hai
+		ho i il in io la le lei li lo loro lui ma mi mia mio ne nei nel nella noi non o per perché più
+		quella quello questa questo se si sono su sua suo ti tu un una uno voi è`,
+	"pt": `a ao aos as com como da das de do dos e ela elas ele eles em entre era essa esse esta este eu
+		foi há isso isto já lhe mais mas me meu minha muito na nas não no nos nós o os ou para pela pelo
+		por que se sem seu sua são também te tem tu um uma você é`,
+}
+
+// Stopwords returns the stopword set for a language (empty for unknown languages)
+func Stopwords(language string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range strings.Fields(stopwordLists[strings.ToLower(language)]) {
+		set[word] = true
+	}
+	return set
+}
@@ -0,0 +1,62 @@
+package vocab
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tokenize splits text into lower-cased words. A word is a run of letters, marks and digits;
+// apostrophes and hyphens are kept only between letters (e.g. "don't", "well-known").
+// Scripts written without spaces (Japanese, Chinese, Thai) come out as whole runs, which is
+// still useful for counting repeated phrases but is not a true word segmentation.
+func Tokenize(text string) []string {
+	var tokens []string
+	runes := []rune(strings.ToLower(text))
+	start := -1
+
+	flush := func(end int) {
+		if start >= 0 {
+			word := string(runes[start:end])
+			if hasLetter(word) {
+				tokens = append(tokens, word)
+			}
+			start = -1
+		}
+	}
+
+	for i, r := range runes {
+		switch {
+		case isWordRune(r):
+			if start < 0 {
+				start = i
+			}
+		case isJoiner(r) && start >= 0 && i+1 < len(runes) && unicode.IsLetter(runes[i+1]) && unicode.IsLetter(runes[i-1]):
+			// Keep inner apostrophes and hyphens as part of the word
+		default:
+			flush(i)
+		}
+	}
+	flush(len(runes))
+
+	return tokens
+}
+
+// isWordRune reports whether r can be part of a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r)
+}
+
+// isJoiner reports whether r joins two word parts
+func isJoiner(r rune) bool {
+	return r == '\'' || r == '’' || r == '-'
+}
+
+// hasLetter reports whether s contains at least one letter (drops pure numbers)
+func hasLetter(s string) bool {
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+	return false
+}
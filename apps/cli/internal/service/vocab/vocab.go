@@ -0,0 +1,226 @@
+package vocab
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+const (
+	videoPageSize = 100 // Videos fetched per repository call while walking a channel
+	defaultTop    = 100 // Default number of words returned
+)
+
+// VideoRepository interface for accessing channel videos
+type VideoRepository interface {
+	GetByChannelID(ctx context.Context, channelID string, limit, offset int) ([]*model.Video, error)
+}
+
+// TranscriptionRepository interface for accessing transcription metadata
+type TranscriptionRepository interface {
+	GetByVideoID(ctx context.Context, videoID string) ([]*model.Transcription, error)
+}
+
+// SegmentRepository interface for accessing transcription segments
+type SegmentRepository interface {
+	GetByTranscriptionID(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error)
+}
+
+// VocabService defines operations for vocabulary statistics
+type VocabService interface {
+	// ChannelStats computes word frequencies across all completed transcriptions of a channel
+	ChannelStats(ctx context.Context, opts StatsOptions) (*Stats, error)
+}
+
+// StatsOptions configures a vocabulary statistics run
+type StatsOptions struct {
+	ChannelID     string // Channel whose transcriptions are analyzed
+	Language      string // Transcription language to analyze (matches language or detected language)
+	Top           int    // Number of most frequent words to return
+	KeepStopwords bool   // Include stopwords in the frequency table
+	MinWordLength int    // Ignore words shorter than this (in runes)
+
+	IncludeUnavailable bool // Also analyze videos marked unavailable by video verify
+}
+
+// WordFrequency is a single row of the frequency table
+type WordFrequency struct {
+	Rank      int     `json:"rank"`
+	Word      string  `json:"word"`
+	Count     int     `json:"count"`
+	PerMille  float64 `json:"per_mille"` // Occurrences per 1000 counted tokens
+	Documents int     `json:"documents"` // Number of transcriptions containing the word
+}
+
+// Stats is the result of a vocabulary statistics run
+type Stats struct {
+	ChannelID      string          `json:"channel_id"`
+	Language       string          `json:"language"`
+	Transcriptions int             `json:"transcriptions"`
+	TotalTokens    int             `json:"total_tokens"`
+	UniqueWords    int             `json:"unique_words"`
+	Words          []WordFrequency `json:"words"`
+}
+
+// vocabService implements VocabService
+type vocabService struct {
+	videoRepo         VideoRepository
+	transcriptionRepo TranscriptionRepository
+	segmentRepo       SegmentRepository
+	cache             *countCache
+}
+
+// NewVocabService creates a new vocabulary service. cacheDir stores per-transcription
+// word counts so repeated runs skip re-tokenizing; an empty cacheDir disables caching.
+func NewVocabService(videoRepo VideoRepository, transcriptionRepo TranscriptionRepository, segmentRepo SegmentRepository, cacheDir string) VocabService {
+	return &vocabService{
+		videoRepo:         videoRepo,
+		transcriptionRepo: transcriptionRepo,
+		segmentRepo:       segmentRepo,
+		cache:             newCountCache(cacheDir),
+	}
+}
+
+// ChannelStats computes word frequencies across all completed transcriptions of a channel
+func (s *vocabService) ChannelStats(ctx context.Context, opts StatsOptions) (*Stats, error) {
+	if opts.ChannelID == "" {
+		return nil, errors.New(errors.CodeInvalidArg, "channel ID is required")
+	}
+	if opts.Language == "" {
+		return nil, errors.New(errors.CodeInvalidArg, "language is required")
+	}
+	if opts.Top <= 0 {
+		opts.Top = defaultTop
+	}
+
+	totals := map[string]int{}
+	documents := map[string]int{}
+	stats := &Stats{ChannelID: opts.ChannelID, Language: opts.Language}
+
+	for offset := 0; ; offset += videoPageSize {
+		videos, err := s.videoRepo.GetByChannelID(ctx, opts.ChannelID, videoPageSize, offset)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, "failed to list channel videos")
+		}
+
+		for _, video := range videos {
+			if !video.IsAvailable() && !opts.IncludeUnavailable {
+				continue
+			}
+			transcriptions, err := s.transcriptionRepo.GetByVideoID(ctx, video.ID)
+			if err != nil {
+				return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to list transcriptions for video %s", video.ID))
+			}
+
+			for _, t := range transcriptions {
+				if !matchesLanguage(t, opts.Language) {
+					continue
+				}
+
+				counts, err := s.transcriptionCounts(ctx, t)
+				if err != nil {
+					return nil, err
+				}
+
+				stats.Transcriptions++
+				for word, n := range counts {
+					totals[word] += n
+					documents[word]++
+				}
+			}
+		}
+
+		if len(videos) < videoPageSize {
+			break
+		}
+	}
+
+	stopwords := Stopwords(opts.Language)
+	for word, n := range totals {
+		if !opts.KeepStopwords && stopwords[word] {
+			delete(totals, word)
+			continue
+		}
+		if len([]rune(word)) < opts.MinWordLength {
+			delete(totals, word)
+			continue
+		}
+		stats.TotalTokens += n
+	}
+	stats.UniqueWords = len(totals)
+	stats.Words = rankWords(totals, documents, stats.TotalTokens, opts.Top)
+
+	return stats, nil
+}
+
+// transcriptionCounts returns raw word counts for a transcription, using the cache when possible
+func (s *vocabService) transcriptionCounts(ctx context.Context, t *model.Transcription) (map[string]int, error) {
+	if counts, ok := s.cache.get(t); ok {
+		return counts, nil
+	}
+
+	segments, err := s.segmentRepo.GetByTranscriptionID(ctx, t.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to get segments for transcription %s", t.ID))
+	}
+
+	counts := map[string]int{}
+	for _, segment := range segments {
+		for _, word := range Tokenize(segment.Text) {
+			counts[word]++
+		}
+	}
+
+	// Caching is best effort; a failed write only costs a recount next time
+	_ = s.cache.put(t, counts)
+
+	return counts, nil
+}
+
+// matchesLanguage reports whether a completed transcription is in the requested language
+func matchesLanguage(t *model.Transcription, language string) bool {
+	if t.Status != "completed" {
+		return false
+	}
+	if t.Language == language {
+		return true
+	}
+	return t.DetectedLanguage != nil && *t.DetectedLanguage == language
+}
+
+// rankWords sorts words by count (ties broken alphabetically) and returns the top n
+func rankWords(totals, documents map[string]int, totalTokens, n int) []WordFrequency {
+	words := make([]string, 0, len(totals))
+	for word := range totals {
+		words = append(words, word)
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if totals[words[i]] != totals[words[j]] {
+			return totals[words[i]] > totals[words[j]]
+		}
+		return words[i] < words[j]
+	})
+
+	if len(words) > n {
+		words = words[:n]
+	}
+
+	result := make([]WordFrequency, len(words))
+	for i, word := range words {
+		perMille := 0.0
+		if totalTokens > 0 {
+			perMille = float64(totals[word]) * 1000 / float64(totalTokens)
+		}
+		result[i] = WordFrequency{
+			Rank:      i + 1,
+			Word:      word,
+			Count:     totals[word],
+			PerMille:  perMille,
+			Documents: documents[word],
+		}
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+package vocab
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockVideoRepo mocks VideoRepository
+type mockVideoRepo struct {
+	videos []*model.Video
+}
+
+func (m *mockVideoRepo) GetByChannelID(ctx context.Context, channelID string, limit, offset int) ([]*model.Video, error) {
+	if offset >= len(m.videos) {
+		return []*model.Video{}, nil
+	}
+	return m.videos[offset:], nil
+}
+
+// mockTranscriptionRepo mocks TranscriptionRepository
+type mockTranscriptionRepo struct {
+	byVideo map[string][]*model.Transcription
+}
+
+func (m *mockTranscriptionRepo) GetByVideoID(ctx context.Context, videoID string) ([]*model.Transcription, error) {
+	return m.byVideo[videoID], nil
+}
+
+// mockSegmentRepo mocks SegmentRepository and counts calls
+type mockSegmentRepo struct {
+	byTranscription map[string][]*model.TranscriptionSegment
+	calls           int
+}
+
+func (m *mockSegmentRepo) GetByTranscriptionID(ctx context.Context, transcriptionID string) ([]*model.TranscriptionSegment, error) {
+	m.calls++
+	return m.byTranscription[transcriptionID], nil
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "punctuation and case", text: "Hola, ¿Qué tal? HOLA!", want: []string{"hola", "qué", "tal", "hola"}},
+		{name: "inner apostrophe and hyphen", text: "don't stop well-known 'quoted'", want: []string{"don't", "stop", "well-known", "quoted"}},
+		{name: "numbers dropped", text: "in 2024 we had 3x growth", want: []string{"in", "we", "had", "3x", "growth"}},
+		{name: "empty", text: "  ...  ", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Tokenize(tt.text))
+		})
+	}
+}
+
+func TestVocabService_ChannelStats(t *testing.T) {
+	completed := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	es := "es"
+	videoRepo := &mockVideoRepo{videos: []*model.Video{{ID: "v1"}, {ID: "v2"}}}
+	transcriptionRepo := &mockTranscriptionRepo{byVideo: map[string][]*model.Transcription{
+		"v1": {{ID: "t1", Language: "es", Status: "completed", CompletedAt: &completed}},
+		"v2": {
+			{ID: "t2", Language: "auto", DetectedLanguage: &es, Status: "completed", CompletedAt: &completed},
+			{ID: "t3", Language: "en", Status: "completed", CompletedAt: &completed},
+		},
+	}}
+	segmentRepo := &mockSegmentRepo{byTranscription: map[string][]*model.TranscriptionSegment{
+		"t1": {{Text: "El gato come pescado."}, {Text: "El gato duerme."}},
+		"t2": {{Text: "Un perro y un gato."}},
+		"t3": {{Text: "The cat sleeps."}},
+	}}
+
+	service := NewVocabService(videoRepo, transcriptionRepo, segmentRepo, t.TempDir())
+
+	stats, err := service.ChannelStats(context.Background(), StatsOptions{ChannelID: "UC1", Language: "es", Top: 2})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, stats.Transcriptions)
+	assert.Equal(t, 7, stats.TotalTokens) // gato x3, come, pescado, duerme, perro; stopwords el/un/y excluded
+	assert.Equal(t, 5, stats.UniqueWords)
+	require.Len(t, stats.Words, 2)
+	assert.Equal(t, 1, stats.Words[0].Rank)
+	assert.Equal(t, "gato", stats.Words[0].Word)
+	assert.Equal(t, 3, stats.Words[0].Count)
+	assert.Equal(t, 2, stats.Words[0].Documents)
+	assert.InDelta(t, 428.57, stats.Words[0].PerMille, 0.01)
+	assert.Equal(t, "come", stats.Words[1].Word)
+	assert.Equal(t, 2, segmentRepo.calls)
+
+	// Second run is served from cache
+	_, err = service.ChannelStats(context.Background(), StatsOptions{ChannelID: "UC1", Language: "es", KeepStopwords: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, segmentRepo.calls)
+}
+
+func TestVocabService_ChannelStats_Validation(t *testing.T) {
+	service := NewVocabService(&mockVideoRepo{}, &mockTranscriptionRepo{}, &mockSegmentRepo{}, "")
+
+	_, err := service.ChannelStats(context.Background(), StatsOptions{Language: "es"})
+	assert.Error(t, err)
+
+	_, err = service.ChannelStats(context.Background(), StatsOptions{ChannelID: "UC1"})
+	assert.Error(t, err)
+}
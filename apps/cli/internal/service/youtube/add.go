@@ -0,0 +1,90 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/offline"
+	"github.com/Taichi-iskw/yt-lang/internal/videourl"
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
+)
+
+// AddedVideo is the outcome of AddVideo
+type AddedVideo struct {
+	Video          *model.Video   `json:"video"`
+	Channel        *model.Channel `json:"channel"`
+	CreatedChannel bool           `json:"created_channel"` // The channel was not stored yet
+	AlreadySaved   bool           `json:"already_saved"`   // The video was stored already, possibly from another URL form
+}
+
+// AddVideo saves a single video from any form of its URL (watch page with extra parameters,
+// youtu.be short link, shorts or live URL) under its channel, creating the channel when it is
+// not stored yet. The URL is stored in its canonical watch?v= form, and the video's chapters
+// with it when YouTube lists any.
+func (s *youTubeService) AddVideo(ctx context.Context, rawURL string) (*AddedVideo, error) {
+	videoID, err := videourl.VideoID(rawURL)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidArg, "invalid video URL")
+	}
+	canonical := videourl.Canonical(videoID)
+
+	args := []string{"--dump-json", "--skip-download", "--no-playlist", canonical}
+	if err := offline.Check("fetching video metadata with yt-dlp"); err != nil {
+		return nil, err
+	}
+	output, err := s.cmdRunner.Run(ctx, ytdlp.Binary, ytdlp.WithNetworkArgs(args...)...)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeExternal, "failed to fetch video metadata with yt-dlp")
+	}
+
+	var entry ytDlpListEntry
+	if err := json.Unmarshal([]byte(strings.TrimSpace(string(output))), &entry); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to parse yt-dlp output")
+	}
+	if entry.ChannelID == "" {
+		return nil, errors.New(errors.CodeExternal, "yt-dlp reported no channel for video "+videoID)
+	}
+
+	video := entry.video(entry.ChannelID)
+	video.ID = videoID
+	video.URL = canonical
+
+	saved, err := s.videoSaved(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	channel := entry.channel()
+	result := &ListImport{}
+	if err := s.saveListed(ctx, []*model.Video{video}, map[string]*model.Channel{channel.ID: channel}, result); err != nil {
+		return nil, err
+	}
+	if len(entry.Chapters) > 0 {
+		if err := s.videoRepo.SetChapters(ctx, videoID, entry.Chapters); err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, "failed to save video chapters")
+		}
+	}
+	return &AddedVideo{
+		Video:          video,
+		Channel:        channel,
+		CreatedChannel: len(result.CreatedChannels) > 0,
+		AlreadySaved:   saved,
+	}, nil
+}
+
+// videoSaved reports whether a video with the ID is stored
+func (s *youTubeService) videoSaved(ctx context.Context, videoID string) (bool, error) {
+	_, err := s.videoRepo.GetByID(ctx, videoID)
+	if err == nil {
+		return true, nil
+	}
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) && appErr.Code == errors.CodeNotFound {
+		return false, nil
+	}
+	return false, errors.Wrap(err, errors.CodeInternal, "failed to look up video")
+}
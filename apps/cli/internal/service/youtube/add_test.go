@@ -0,0 +1,81 @@
+package youtube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+func TestYouTubeService_AddVideo(t *testing.T) {
+	canonical := "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+	metadata := `{"id": "dQw4w9WgXcQ", "title": "A Short", "webpage_url": "https://www.youtube.com/shorts/dQw4w9WgXcQ", "duration": 42, "upload_date": "20240315", "channel_id": "UCnew", "channel": "Newcomer"}`
+
+	mockRunner := new(mockCmdRunner)
+	mockChannelRepo := new(mockChannelRepository)
+	mockVideoRepo := new(mockVideoRepository)
+	mockRunner.On("Run", mock.Anything, "yt-dlp", []string{"--dump-json", "--skip-download", "--no-playlist", canonical}).Return([]byte(metadata), nil)
+	mockVideoRepo.On("GetByID", mock.Anything, "dQw4w9WgXcQ").Return((*model.Video)(nil), errors.New(errors.CodeNotFound, "video not found"))
+	mockChannelRepo.On("GetByID", mock.Anything, "UCnew").Return((*model.Channel)(nil), errors.New(errors.CodeNotFound, "channel not found"))
+	mockChannelRepo.On("Create", mock.Anything, &model.Channel{ID: "UCnew", Name: "Newcomer", URL: "https://www.youtube.com/channel/UCnew"}).Return(nil)
+	mockVideoRepo.On("UpsertBatch", mock.Anything, mock.MatchedBy(func(videos []*model.Video) bool {
+		return len(videos) == 1 && videos[0].URL == canonical && videos[0].ChannelID == "UCnew"
+	})).Return(nil)
+
+	// Short links with tracking parameters resolve to the canonical watch URL
+	service := NewYouTubeServiceWithRepositories(mockRunner, mockChannelRepo, mockVideoRepo)
+	added, err := service.AddVideo(context.Background(), "https://youtu.be/dQw4w9WgXcQ?si=tracking")
+	require.NoError(t, err)
+
+	assert.Equal(t, canonical, added.Video.URL)
+	assert.Equal(t, "A Short", added.Video.Title)
+	assert.True(t, added.CreatedChannel)
+	assert.False(t, added.AlreadySaved)
+	mockChannelRepo.AssertExpectations(t)
+	mockVideoRepo.AssertExpectations(t)
+
+	_, err = service.AddVideo(context.Background(), "https://www.youtube.com/channel/UCnew")
+	var appErr *errors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, errors.CodeInvalidArg, appErr.Code)
+}
+
+func TestYouTubeService_AddVideo_Chapters(t *testing.T) {
+	metadata := `{"id": "dQw4w9WgXcQ", "title": "Long Talk", "webpage_url": "https://www.youtube.com/watch?v=dQw4w9WgXcQ", "duration": 3600, "channel_id": "UCknown", "channel": "Known",
+		"chapters": [{"start_time": 0.0, "end_time": 95.5, "title": "Intro"}, {"start_time": 95.5, "end_time": 3600.0, "title": "Main part"}]}`
+
+	mockRunner := new(mockCmdRunner)
+	mockChannelRepo := new(mockChannelRepository)
+	mockVideoRepo := new(mockVideoRepository)
+	mockRunner.On("Run", mock.Anything, "yt-dlp", mock.Anything).Return([]byte(metadata), nil)
+	mockVideoRepo.On("GetByID", mock.Anything, "dQw4w9WgXcQ").Return((*model.Video)(nil), errors.New(errors.CodeNotFound, "video not found"))
+	mockChannelRepo.On("GetByID", mock.Anything, "UCknown").Return(&model.Channel{ID: "UCknown", Name: "Known"}, nil)
+	mockVideoRepo.On("UpsertBatch", mock.Anything, mock.Anything).Return(nil)
+	mockVideoRepo.On("SetChapters", mock.Anything, "dQw4w9WgXcQ", []model.VideoChapter{
+		{Title: "Intro", StartTime: 0, EndTime: 95.5},
+		{Title: "Main part", StartTime: 95.5, EndTime: 3600},
+	}).Return(nil)
+
+	service := NewYouTubeServiceWithRepositories(mockRunner, mockChannelRepo, mockVideoRepo)
+	_, err := service.AddVideo(context.Background(), "https://www.youtube.com/watch?v=dQw4w9WgXcQ")
+	require.NoError(t, err)
+	mockVideoRepo.AssertExpectations(t)
+}
+
+func TestYtDlpVideoInfo_PageURL(t *testing.T) {
+	// Flat channel listings link shorts by their shorts URL
+	info := ytDlpVideoInfo{FlatURL: "https://www.youtube.com/shorts/dQw4w9WgXcQ"}
+	assert.Equal(t, "https://www.youtube.com/watch?v=dQw4w9WgXcQ", info.pageURL())
+
+	info = ytDlpVideoInfo{URL: "https://example.com/video1"}
+	assert.Equal(t, "https://example.com/video1", info.pageURL())
+
+	// Flat entries carry the channel page as webpage_url; the video is in url
+	info = ytDlpVideoInfo{URL: "https://www.youtube.com/@jawed/videos", FlatURL: "https://www.youtube.com/watch?v=jNQXAC9IVRw"}
+	assert.Equal(t, "https://www.youtube.com/watch?v=jNQXAC9IVRw", info.pageURL())
+}
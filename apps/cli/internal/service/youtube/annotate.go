@@ -0,0 +1,59 @@
+package youtube
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// Rating bounds of video annotations
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// AnnotateOptions changes the annotation of a video. Nil fields keep their current value.
+type AnnotateOptions struct {
+	Rating *int    // New rating, MinRating-MaxRating
+	Note   *string // New note; an empty note removes it
+	Clear  bool    // Remove rating and note before applying the other fields
+}
+
+// AnnotateVideo updates the rating and note of a stored video and returns the annotated video
+func (s *youTubeService) AnnotateVideo(ctx context.Context, videoID string, opts AnnotateOptions) (*model.Video, error) {
+	if videoID == "" {
+		return nil, errors.New(errors.CodeInvalidArg, "video ID is required")
+	}
+	if opts.Rating != nil && (*opts.Rating < MinRating || *opts.Rating > MaxRating) {
+		return nil, errors.New(errors.CodeInvalidArg, "rating must be between 1 and 5")
+	}
+	if opts.Rating == nil && opts.Note == nil && !opts.Clear {
+		return nil, errors.New(errors.CodeInvalidArg, "nothing to annotate: give a rating, a note or clear")
+	}
+
+	note := opts.Note
+	if note != nil {
+		trimmed := strings.TrimSpace(*note)
+		note = &trimmed
+	}
+	if err := s.videoRepo.UpdateAnnotation(ctx, videoID, opts.Rating, note, opts.Clear); err != nil {
+		return nil, err
+	}
+	return s.videoRepo.GetByID(ctx, videoID)
+}
+
+// ListRatedVideos retrieves the videos rated at least minRating, of a channel or of every
+// channel when channelID is empty
+func (s *youTubeService) ListRatedVideos(ctx context.Context, channelID string, minRating int) ([]*model.Video, error) {
+	if minRating < MinRating || minRating > MaxRating {
+		return nil, errors.New(errors.CodeInvalidArg, "minimum rating must be between 1 and 5")
+	}
+
+	videos, err := s.videoRepo.ListByMinRating(ctx, channelID, minRating)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to list rated videos")
+	}
+	return videos, nil
+}
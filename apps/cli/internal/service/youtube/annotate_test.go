@@ -0,0 +1,71 @@
+package youtube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+func TestYouTubeService_AnnotateVideo(t *testing.T) {
+	intPtr := func(n int) *int { return &n }
+	strPtr := func(s string) *string { return &s }
+
+	// The repository merges the changes into the stored annotation; the service only trims the
+	// note and returns the video as stored afterwards
+	tests := []struct {
+		name       string
+		opts       AnnotateOptions
+		wantRating *int
+		wantNote   *string
+	}{
+		{name: "rating", opts: AnnotateOptions{Rating: intPtr(4)}, wantRating: intPtr(4)},
+		{name: "note is trimmed", opts: AnnotateOptions{Note: strPtr(" great for B1 learners ")}, wantNote: strPtr("great for B1 learners")},
+		{name: "empty note", opts: AnnotateOptions{Note: strPtr("")}, wantNote: strPtr("")},
+		{name: "clear then rate", opts: AnnotateOptions{Clear: true, Rating: intPtr(5)}, wantRating: intPtr(5)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotated := &model.Video{ID: "video1", Rating: intPtr(3), Note: strPtr("slow speech")}
+			mockVideoRepo := new(mockVideoRepository)
+			mockVideoRepo.On("UpdateAnnotation", mock.Anything, "video1", tt.wantRating, tt.wantNote, tt.opts.Clear).Return(nil)
+			mockVideoRepo.On("GetByID", mock.Anything, "video1").Return(annotated, nil)
+
+			service := NewYouTubeServiceWithRepositories(nil, nil, mockVideoRepo)
+			video, err := service.AnnotateVideo(context.Background(), "video1", tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, annotated, video)
+			mockVideoRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("invalid options", func(t *testing.T) {
+		for _, opts := range []AnnotateOptions{{}, {Rating: intPtr(0)}, {Rating: intPtr(6)}} {
+			service := NewYouTubeServiceWithRepositories(nil, nil, new(mockVideoRepository))
+			_, err := service.AnnotateVideo(context.Background(), "video1", opts)
+			var appErr *apperrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, apperrors.CodeInvalidArg, appErr.Code)
+		}
+	})
+}
+
+func TestYouTubeService_ListRatedVideos(t *testing.T) {
+	mockVideoRepo := new(mockVideoRepository)
+	rated := []*model.Video{{ID: "video1"}}
+	mockVideoRepo.On("ListByMinRating", mock.Anything, "", 4).Return(rated, nil)
+
+	service := NewYouTubeServiceWithRepositories(nil, nil, mockVideoRepo)
+	videos, err := service.ListRatedVideos(context.Background(), "", 4)
+	require.NoError(t, err)
+	assert.Equal(t, rated, videos)
+
+	_, err = service.ListRatedVideos(context.Background(), "", 9)
+	assert.Error(t, err)
+}
@@ -0,0 +1,134 @@
+package youtube
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/offline"
+	"github.com/Taichi-iskw/yt-lang/internal/videourl"
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
+)
+
+// Caption track kinds
+const (
+	CaptionManual = "manual" // Uploaded by the creator (yt-dlp "subtitles")
+	CaptionAuto   = "auto"   // Generated by YouTube's speech recognition, or machine translated from it
+)
+
+// CaptionTrack is a caption track YouTube offers for a video
+type CaptionTrack struct {
+	Language string   `json:"language"` // Language code as yt-dlp --sub-langs takes it, e.g. en, pt-BR, en-orig
+	Name     string   `json:"name,omitempty"`
+	Kind     string   `json:"kind"`
+	Formats  []string `json:"formats"`
+	Original bool     `json:"original,omitempty"` // Automatic captions in the spoken language rather than translated
+}
+
+// CaptionListing lists the caption tracks of a video
+type CaptionListing struct {
+	VideoID string          `json:"video_id"`
+	Tracks  []*CaptionTrack `json:"tracks"` // Manual tracks first, then automatic ones, in yt-dlp order
+}
+
+// Manual returns the tracks uploaded by the creator
+func (l *CaptionListing) Manual() []*CaptionTrack {
+	var tracks []*CaptionTrack
+	for _, track := range l.Tracks {
+		if track.Kind == CaptionManual {
+			tracks = append(tracks, track)
+		}
+	}
+	return tracks
+}
+
+// ListCaptions lists the caption tracks YouTube offers for a video with yt-dlp --list-subs,
+// without downloading anything, to decide between importing captions and running whisper
+func (s *youTubeService) ListCaptions(ctx context.Context, videoID string) (*CaptionListing, error) {
+	if !videourl.ValidID(videoID) {
+		return nil, errors.New(errors.CodeInvalidArg, "invalid video ID format (expected 11 characters)")
+	}
+
+	args := []string{"--list-subs", "--skip-download", videourl.Canonical(videoID)}
+	if err := offline.Check("listing captions with yt-dlp"); err != nil {
+		return nil, err
+	}
+	output, err := s.cmdRunner.Run(ctx, ytdlp.Binary, ytdlp.WithNetworkArgs(args...)...)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeExternal, "failed to list captions with yt-dlp")
+	}
+
+	return &CaptionListing{VideoID: videoID, Tracks: parseCaptionList(string(output))}, nil
+}
+
+// parseCaptionList parses the tables printed by yt-dlp --list-subs:
+//
+//	[info] Available automatic captions for VIDEO_ID:
+//	Language Name                     Formats
+//	en-orig  English (Original)       vtt, ttml, srv3, srv2, srv1, json3
+//	[info] Available subtitles for VIDEO_ID:
+//	Language Name    Formats
+//	en       English vtt, ttml, srv3, srv2, srv1, json3
+//
+// Versions before 2021.10 print no Name column. Manual tracks are returned first.
+func parseCaptionList(output string) []*CaptionTrack {
+	var manual, auto []*CaptionTrack
+	var section *[]*CaptionTrack
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.Contains(line, "Available automatic captions for"):
+			section = &auto
+			continue
+		case strings.Contains(line, "Available subtitles for"):
+			section = &manual
+			continue
+		case strings.HasPrefix(line, "[") || strings.Contains(line, " has no "):
+			// A new log line, or "VIDEO has no subtitles", ends the section
+			section = nil
+			continue
+		case line == "" || strings.HasPrefix(line, "Language "):
+			continue
+		case section == nil:
+			continue
+		}
+
+		if track := parseCaptionRow(line); track != nil {
+			*section = append(*section, track)
+		}
+	}
+
+	for _, track := range manual {
+		track.Kind = CaptionManual
+	}
+	for _, track := range auto {
+		track.Kind = CaptionAuto
+		track.Original = strings.HasSuffix(track.Language, "-orig") || strings.Contains(track.Name, "(Original)")
+	}
+	return append(manual, auto...)
+}
+
+// parseCaptionRow parses "LANG [NAME] FORMAT, FORMAT, ..."; nil for lines that are not rows
+func parseCaptionRow(line string) *CaptionTrack {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return nil
+	}
+
+	// The formats are the trailing comma-separated fields
+	first := len(fields) - 1
+	for first > 1 && strings.HasSuffix(fields[first-1], ",") {
+		first--
+	}
+	var formats []string
+	for _, field := range fields[first:] {
+		formats = append(formats, strings.TrimSuffix(field, ","))
+	}
+
+	return &CaptionTrack{
+		Language: fields[0],
+		Name:     strings.Join(fields[1:first], " "),
+		Formats:  formats,
+	}
+}
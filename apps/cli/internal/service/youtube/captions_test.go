@@ -0,0 +1,57 @@
+package youtube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const listSubsOutput = `[youtube] Extracting URL: https://www.youtube.com/watch?v=dQw4w9WgXcQ
+[youtube] dQw4w9WgXcQ: Downloading webpage
+[info] Available automatic captions for dQw4w9WgXcQ:
+Language Name                     Formats
+en-orig  English (Original)       vtt, ttml, srv3, srv2, srv1, json3
+ja       Japanese                 vtt, ttml, srv3, srv2, srv1, json3
+[info] Available subtitles for dQw4w9WgXcQ:
+Language Name                     Formats
+en       English                  vtt, ttml, srv3, srv2, srv1, json3
+pt-BR    Portuguese (Brazil)      vtt, ttml
+`
+
+func TestYouTubeService_ListCaptions(t *testing.T) {
+	mockRunner := new(mockCmdRunner)
+	mockRunner.On("Run", mock.Anything, "yt-dlp", []string{"--list-subs", "--skip-download", "https://www.youtube.com/watch?v=dQw4w9WgXcQ"}).
+		Return([]byte(listSubsOutput), nil)
+
+	service := NewYouTubeServiceWithRepositories(mockRunner, nil, nil)
+	listing, err := service.ListCaptions(context.Background(), "dQw4w9WgXcQ")
+	require.NoError(t, err)
+
+	require.Len(t, listing.Tracks, 4)
+	assert.Equal(t, &CaptionTrack{Language: "en", Name: "English", Kind: CaptionManual, Formats: []string{"vtt", "ttml", "srv3", "srv2", "srv1", "json3"}}, listing.Tracks[0])
+	assert.Equal(t, "Portuguese (Brazil)", listing.Tracks[1].Name)
+	assert.Equal(t, []string{"vtt", "ttml"}, listing.Tracks[1].Formats)
+	assert.Equal(t, CaptionAuto, listing.Tracks[2].Kind)
+	assert.True(t, listing.Tracks[2].Original)
+	assert.False(t, listing.Tracks[3].Original)
+	assert.Len(t, listing.Manual(), 2)
+
+	_, err = service.ListCaptions(context.Background(), "not a video")
+	assert.Error(t, err)
+}
+
+func TestParseCaptionList(t *testing.T) {
+	// Old yt-dlp versions print no name column, and videos may have no captions at all
+	tracks := parseCaptionList(`[info] Available subtitles for abc:
+Language formats
+en       vtt, ttml, srv3
+abc has no automatic captions
+`)
+	require.Len(t, tracks, 1)
+	assert.Equal(t, &CaptionTrack{Language: "en", Kind: CaptionManual, Formats: []string{"vtt", "ttml", "srv3"}}, tracks[0])
+
+	assert.Empty(t, parseCaptionList("[info] abc has no automatic captions\n[info] abc has no subtitles\n"))
+}
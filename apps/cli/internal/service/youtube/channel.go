@@ -3,9 +3,12 @@ package youtube
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/Taichi-iskw/yt-lang/internal/errors"
 	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/offline"
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
 )
 
 // FetchChannelInfo fetches channel information from YouTube URL using yt-dlp
@@ -22,7 +25,10 @@ func (s *youTubeService) FetchChannelInfo(ctx context.Context, channelURL string
 		channelURL,
 	}
 
-	output, err := s.cmdRunner.Run(ctx, "yt-dlp", args...)
+	if err := offline.Check("fetching channel info with yt-dlp"); err != nil {
+		return nil, err
+	}
+	output, err := s.cmdRunner.Run(ctx, ytdlp.Binary, ytdlp.WithNetworkArgs(args...)...)
 	if err != nil {
 		return nil, errors.Wrap(err, errors.CodeExternal, "failed to fetch channel info with yt-dlp")
 	}
@@ -85,3 +91,91 @@ func (s *youTubeService) ListChannels(ctx context.Context, limit, offset int) ([
 
 	return channels, nil
 }
+
+// CountChannels returns the number of saved channels
+func (s *youTubeService) CountChannels(ctx context.Context) (int, error) {
+	count, err := s.channelRepo.Count(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, errors.CodeInternal, "failed to count channels")
+	}
+
+	return count, nil
+}
+
+// ChannelRefresh is the outcome of RefreshChannel
+type ChannelRefresh struct {
+	Previous *model.Channel `json:"previous"`
+	Current  *model.Channel `json:"current"`
+	Changed  bool           `json:"changed"`
+}
+
+// RefreshChannel re-reads a saved channel's name and URL from YouTube, so renamed channels and
+// changed handles don't leave stale rows
+func (s *youTubeService) RefreshChannel(ctx context.Context, channelID string) (*ChannelRefresh, error) {
+	if channelID == "" {
+		return nil, errors.New(errors.CodeInvalidArg, "channel ID is required")
+	}
+
+	previous, err := s.channelRepo.GetByID(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.FetchChannelInfo(ctx, "https://www.youtube.com/channel/"+channelID)
+	if err != nil {
+		return nil, err
+	}
+	if current.ID != channelID {
+		return nil, errors.New(errors.CodeExternal, fmt.Sprintf("yt-dlp returned channel %s for %s", current.ID, channelID))
+	}
+
+	result := &ChannelRefresh{
+		Previous: previous,
+		Current:  current,
+		Changed:  current.Name != previous.Name || current.URL != previous.URL,
+	}
+	if !result.Changed {
+		return result, nil
+	}
+
+	// A stale duplicate saved under the new URL would violate the unique URL
+	if current.URL != previous.URL {
+		if other, err := s.channelRepo.GetByURL(ctx, current.URL); err == nil && other.ID != channelID {
+			return nil, errors.New(errors.CodeConflict, fmt.Sprintf("channel %s is already saved with URL %s; combine them with `channel merge %s %s`",
+				other.ID, current.URL, other.ID, channelID))
+		}
+	}
+
+	// Guard against a concurrent refresh or merge between the read above and this write
+	current.Version = previous.Version
+	if err := s.channelRepo.Update(ctx, current); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ChannelMerge is the outcome of MergeChannels
+type ChannelMerge struct {
+	From        string `json:"from"`
+	Into        string `json:"into"`
+	MovedVideos int    `json:"moved_videos"`
+}
+
+// MergeChannels moves every video of channel fromID to channel intoID and deletes fromID.
+// Transcriptions and translations follow their videos.
+func (s *youTubeService) MergeChannels(ctx context.Context, fromID, intoID string) (*ChannelMerge, error) {
+	if fromID == "" || intoID == "" {
+		return nil, errors.New(errors.CodeInvalidArg, "both channel IDs are required")
+	}
+	if fromID == intoID {
+		return nil, errors.New(errors.CodeInvalidArg, "cannot merge a channel into itself")
+	}
+
+	moved, err := s.channelRepo.Merge(ctx, fromID, intoID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to merge channels")
+	}
+
+	return &ChannelMerge{From: fromID, Into: intoID, MovedVideos: moved}, nil
+}
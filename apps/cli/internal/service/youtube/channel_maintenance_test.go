@@ -0,0 +1,114 @@
+package youtube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+func TestYouTubeService_RefreshChannel(t *testing.T) {
+	channelID := "UC123456789"
+	stored := &model.Channel{ID: channelID, Name: "Old Name", URL: "https://www.youtube.com/@OldHandle"}
+	listing := `{"channel": "New Name", "channel_id": "UC123456789", "channel_url": "https://www.youtube.com/@NewHandle"}`
+
+	tests := []struct {
+		name             string
+		listing          string
+		channelRepoSetup func(*mockChannelRepository)
+		wantChanged      bool
+		errorContains    string
+	}{
+		{
+			name:    "updates a renamed channel",
+			listing: listing,
+			channelRepoSetup: func(m *mockChannelRepository) {
+				m.On("GetByURL", mock.Anything, "https://www.youtube.com/@NewHandle").
+					Return((*model.Channel)(nil), apperrors.New(apperrors.CodeNotFound, "channel not found"))
+				m.On("Update", mock.Anything, &model.Channel{ID: channelID, Name: "New Name", URL: "https://www.youtube.com/@NewHandle"}).Return(nil)
+			},
+			wantChanged: true,
+		},
+		{
+			name:             "leaves an unchanged channel alone",
+			listing:          `{"channel": "Old Name", "channel_id": "UC123456789", "channel_url": "https://www.youtube.com/@OldHandle"}`,
+			channelRepoSetup: func(m *mockChannelRepository) {},
+			wantChanged:      false,
+		},
+		{
+			name:    "refuses a URL saved for another channel",
+			listing: listing,
+			channelRepoSetup: func(m *mockChannelRepository) {
+				m.On("GetByURL", mock.Anything, "https://www.youtube.com/@NewHandle").
+					Return(&model.Channel{ID: "UCstale", URL: "https://www.youtube.com/@NewHandle"}, nil)
+			},
+			errorContains: "channel merge UCstale UC123456789",
+		},
+		{
+			name:             "rejects a different channel from yt-dlp",
+			listing:          `{"channel": "Other", "channel_id": "UCother", "channel_url": "https://www.youtube.com/@Other"}`,
+			channelRepoSetup: func(m *mockChannelRepository) {},
+			errorContains:    "yt-dlp returned channel UCother",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRunner := new(mockCmdRunner)
+			mockChannelRepo := new(mockChannelRepository)
+
+			mockRunner.On("Run", mock.Anything, "yt-dlp", []string{"--dump-json", "--playlist-items", "1", "https://www.youtube.com/channel/" + channelID}).
+				Return([]byte(tt.listing), nil)
+			mockChannelRepo.On("GetByID", mock.Anything, channelID).Return(stored, nil)
+			tt.channelRepoSetup(mockChannelRepo)
+
+			service := NewYouTubeServiceWithRepositories(mockRunner, mockChannelRepo, nil)
+			result, err := service.RefreshChannel(context.Background(), channelID)
+
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantChanged, result.Changed)
+			assert.Equal(t, stored, result.Previous)
+			mockChannelRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestYouTubeService_MergeChannels(t *testing.T) {
+	t.Run("moves videos and reports the count", func(t *testing.T) {
+		mockChannelRepo := new(mockChannelRepository)
+		mockChannelRepo.On("Merge", mock.Anything, "UCold", "UCnew").Return(12, nil)
+
+		service := NewYouTubeServiceWithRepositories(new(mockCmdRunner), mockChannelRepo, nil)
+		result, err := service.MergeChannels(context.Background(), "UCold", "UCnew")
+		require.NoError(t, err)
+		assert.Equal(t, &ChannelMerge{From: "UCold", Into: "UCnew", MovedVideos: 12}, result)
+		mockChannelRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects merging a channel into itself", func(t *testing.T) {
+		service := NewYouTubeServiceWithRepositories(new(mockCmdRunner), new(mockChannelRepository), nil)
+		_, err := service.MergeChannels(context.Background(), "UCold", "UCold")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot merge a channel into itself")
+	})
+
+	t.Run("returns repository errors", func(t *testing.T) {
+		mockChannelRepo := new(mockChannelRepository)
+		mockChannelRepo.On("Merge", mock.Anything, "UCold", "UCnew").Return(0, apperrors.New(apperrors.CodeNotFound, "channel not found"))
+
+		service := NewYouTubeServiceWithRepositories(new(mockCmdRunner), mockChannelRepo, nil)
+		_, err := service.MergeChannels(context.Background(), "UCold", "UCnew")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "channel not found")
+	})
+}
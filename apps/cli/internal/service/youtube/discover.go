@@ -0,0 +1,152 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/offline"
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
+)
+
+// Search limits. Searches are paged by YouTube, so large limits mean many requests.
+const (
+	DefaultDiscoverLimit = 20
+	MaxDiscoverLimit     = 100
+
+	// DefaultDiscoverSleep is the pause yt-dlp takes between the requests of a search
+	DefaultDiscoverSleep = time.Second
+)
+
+// DiscoverOptions configures Discover
+type DiscoverOptions struct {
+	Limit int           // Search results to return; defaults to DefaultDiscoverLimit, at most MaxDiscoverLimit
+	Sleep time.Duration // Pause between yt-dlp requests (--sleep-requests), keeping searches polite
+}
+
+// Discovery is the outcome of a search: candidate videos and the channels that uploaded them
+type Discovery struct {
+	Query    string               `json:"query"`
+	Videos   []*DiscoveredVideo   `json:"videos"`
+	Channels []*DiscoveredChannel `json:"channels"` // In order of their first result
+}
+
+// DiscoveredVideo is a search result
+type DiscoveredVideo struct {
+	Video     *model.Video   `json:"video"`
+	Channel   *model.Channel `json:"channel"`
+	ViewCount int64          `json:"view_count"` // 0 when the listing does not report it
+}
+
+// DiscoveredChannel is a channel with results in a search
+type DiscoveredChannel struct {
+	Channel *model.Channel `json:"channel"`
+	Results int            `json:"results"` // Search results uploaded by the channel
+	Saved   bool           `json:"saved"`   // The channel is stored in the workspace already
+}
+
+// ytDlpSearchEntry is a flat entry of a ytsearch listing
+type ytDlpSearchEntry struct {
+	ytDlpListEntry
+	ViewCount int64 `json:"view_count"`
+}
+
+// Discover searches YouTube for videos matching query with yt-dlp's ytsearch, without saving
+// anything. Pass the chosen results to SaveDiscovered.
+func (s *youTubeService) Discover(ctx context.Context, query string, opts DiscoverOptions) (*Discovery, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New(errors.CodeInvalidArg, "search query is required")
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultDiscoverLimit
+	}
+	if opts.Limit > MaxDiscoverLimit {
+		return nil, errors.New(errors.CodeInvalidArg, fmt.Sprintf("limit %d exceeds the maximum of %d search results", opts.Limit, MaxDiscoverLimit))
+	}
+
+	entries, err := s.search(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	discovery := &Discovery{Query: query, Videos: []*DiscoveredVideo{}, Channels: []*DiscoveredChannel{}}
+	channels := make(map[string]*DiscoveredChannel)
+	for _, entry := range entries {
+		// Results without an uploader cannot be saved under a channel
+		if entry.ChannelID == "" || !listedAvailable(entry.ytDlpVideoInfo) {
+			continue
+		}
+
+		found, ok := channels[entry.ChannelID]
+		if !ok {
+			saved, err := s.channelSaved(ctx, entry.ChannelID)
+			if err != nil {
+				return nil, err
+			}
+			found = &DiscoveredChannel{Channel: entry.channel(), Saved: saved}
+			channels[entry.ChannelID] = found
+			discovery.Channels = append(discovery.Channels, found)
+		}
+		found.Results++
+
+		discovery.Videos = append(discovery.Videos, &DiscoveredVideo{
+			Video:     entry.video(entry.ChannelID),
+			Channel:   found.Channel,
+			ViewCount: entry.ViewCount,
+		})
+	}
+	return discovery, nil
+}
+
+// SaveDiscovered saves search results under their own channels, creating the channels that are
+// not stored yet
+func (s *youTubeService) SaveDiscovered(ctx context.Context, videos []*DiscoveredVideo) (*ListImport, error) {
+	result := &ListImport{List: "search", Videos: []*model.Video{}, CreatedChannels: []*model.Channel{}, Skipped: []string{}}
+	list := make([]*model.Video, 0, len(videos))
+	channels := make(map[string]*model.Channel)
+	for _, discovered := range videos {
+		list = append(list, discovered.Video)
+		channels[discovered.Video.ChannelID] = discovered.Channel
+	}
+
+	if err := s.saveListed(ctx, list, channels, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// search lists the results of a ytsearch query with yt-dlp flat extraction
+func (s *youTubeService) search(ctx context.Context, query string, opts DiscoverOptions) ([]ytDlpSearchEntry, error) {
+	args := []string{"--dump-json", "--flat-playlist"}
+	if opts.Sleep > 0 {
+		args = append(args, "--sleep-requests", strconv.FormatFloat(opts.Sleep.Seconds(), 'f', -1, 64))
+	}
+	args = append(args, fmt.Sprintf("ytsearch%d:%s", opts.Limit, query))
+
+	if err := offline.Check("searching YouTube with yt-dlp"); err != nil {
+		return nil, err
+	}
+	output, err := s.cmdRunner.Run(ctx, ytdlp.Binary, ytdlp.WithNetworkArgs(args...)...)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeExternal, fmt.Sprintf("failed to search YouTube for %q with yt-dlp", query))
+	}
+
+	var entries []ytDlpSearchEntry
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry ytDlpSearchEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, "failed to parse yt-dlp output")
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
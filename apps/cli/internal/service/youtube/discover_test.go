@@ -0,0 +1,72 @@
+package youtube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+func TestYouTubeService_Discover(t *testing.T) {
+	searchArgs := []string{"--dump-json", "--flat-playlist", "--sleep-requests", "1.5", "ytsearch3:spanish cooking"}
+	listing := `{"id": "video1", "title": "Tortilla", "url": "https://www.youtube.com/watch?v=video1", "duration": 600, "view_count": 12000, "channel_id": "UCcook", "channel": "Cocina"}
+{"id": "video2", "title": "[Private video]", "channel_id": "UCcook"}
+{"id": "video3", "title": "Paella", "url": "https://www.youtube.com/watch?v=video3", "channel_id": "UCsaved", "uploader": "Saved Chef"}
+{"id": "video4", "title": "Gazpacho", "url": "https://www.youtube.com/watch?v=video4", "channel_id": "UCcook", "channel": "Cocina"}`
+
+	mockRunner := new(mockCmdRunner)
+	mockChannelRepo := new(mockChannelRepository)
+	mockRunner.On("Run", mock.Anything, "yt-dlp", searchArgs).Return([]byte(listing), nil)
+	mockChannelRepo.On("GetByID", mock.Anything, "UCcook").Return((*model.Channel)(nil), errors.New(errors.CodeNotFound, "channel not found"))
+	mockChannelRepo.On("GetByID", mock.Anything, "UCsaved").Return(&model.Channel{ID: "UCsaved"}, nil)
+
+	service := NewYouTubeServiceWithRepositories(mockRunner, mockChannelRepo, nil)
+	discovery, err := service.Discover(context.Background(), " spanish cooking ", DiscoverOptions{Limit: 3, Sleep: 1500 * time.Millisecond})
+	require.NoError(t, err)
+
+	assert.Equal(t, "spanish cooking", discovery.Query)
+	require.Len(t, discovery.Videos, 3)
+	assert.Equal(t, "video1", discovery.Videos[0].Video.ID)
+	assert.Equal(t, int64(12000), discovery.Videos[0].ViewCount)
+	assert.Equal(t, "Cocina", discovery.Videos[0].Channel.Name)
+	require.Len(t, discovery.Channels, 2)
+	assert.Equal(t, &DiscoveredChannel{Channel: &model.Channel{ID: "UCcook", Name: "Cocina", URL: "https://www.youtube.com/channel/UCcook"}, Results: 2}, discovery.Channels[0])
+	assert.True(t, discovery.Channels[1].Saved)
+	mockChannelRepo.AssertNumberOfCalls(t, "GetByID", 2)
+}
+
+func TestYouTubeService_Discover_InvalidInput(t *testing.T) {
+	service := NewYouTubeServiceWithRepositories(new(mockCmdRunner), nil, nil)
+
+	_, err := service.Discover(context.Background(), "  ", DiscoverOptions{})
+	assert.ErrorContains(t, err, "query is required")
+
+	_, err = service.Discover(context.Background(), "cooking", DiscoverOptions{Limit: MaxDiscoverLimit + 1})
+	assert.ErrorContains(t, err, "exceeds the maximum")
+}
+
+func TestYouTubeService_SaveDiscovered(t *testing.T) {
+	cook := &model.Channel{ID: "UCcook", Name: "Cocina", URL: "https://www.youtube.com/channel/UCcook"}
+	video := &model.Video{ID: "video1", ChannelID: "UCcook", Title: "Tortilla"}
+
+	mockChannelRepo := new(mockChannelRepository)
+	mockVideoRepo := new(mockVideoRepository)
+	mockChannelRepo.On("GetByID", mock.Anything, "UCcook").Return((*model.Channel)(nil), errors.New(errors.CodeNotFound, "channel not found"))
+	mockChannelRepo.On("Create", mock.Anything, cook).Return(nil)
+	mockVideoRepo.On("UpsertBatch", mock.Anything, []*model.Video{video}).Return(nil)
+
+	service := NewYouTubeServiceWithRepositories(new(mockCmdRunner), mockChannelRepo, mockVideoRepo)
+	result, err := service.SaveDiscovered(context.Background(), []*DiscoveredVideo{{Video: video, Channel: cook}})
+	require.NoError(t, err)
+
+	assert.Equal(t, []*model.Video{video}, result.Videos)
+	assert.Equal(t, []*model.Channel{cook}, result.CreatedChannels)
+	mockChannelRepo.AssertExpectations(t)
+	mockVideoRepo.AssertExpectations(t)
+}
@@ -0,0 +1,125 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/offline"
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
+)
+
+// A video count difference is flagged as drift when it exceeds both bounds
+const (
+	driftMinVideos = 5    // Absolute difference tolerated on any channel
+	driftRatio     = 0.05 // Share of the reported uploads tolerated on large channels
+)
+
+// VideoCountDrift compares the uploads YouTube reports for a channel with its stored videos
+type VideoCountDrift struct {
+	ChannelID  string `json:"channel_id"`
+	Reported   int    `json:"reported"`   // Uploads reported by YouTube
+	Stored     int    `json:"stored"`     // Stored videos marked available
+	Difference int    `json:"difference"` // Stored minus reported
+	Drifted    bool   `json:"drifted"`    // The difference is large enough to look into
+}
+
+// Reconciliation is the outcome of reconciling a channel's stored videos with its full listing
+type Reconciliation struct {
+	Added  []*model.Video `json:"added"`  // Listed videos that were not stored yet
+	Verify *VerifyResult  `json:"verify"` // Availability of the videos stored before
+}
+
+// ytDlpPlaylistInfo represents the yt-dlp JSON output fields of a playlist used here
+type ytDlpPlaylistInfo struct {
+	PlaylistCount int `json:"playlist_count"`
+}
+
+// CheckVideoCount compares the number of uploads YouTube reports for a channel, read from its
+// uploads playlist without listing it, with the number of available stored videos. More stored
+// videos than reported suggests deletions; fewer suggests gaps in earlier fetches.
+func (s *youTubeService) CheckVideoCount(ctx context.Context, channelID string) (*VideoCountDrift, error) {
+	if !strings.HasPrefix(channelID, "UC") {
+		return nil, errors.New(errors.CodeInvalidArg, "invalid channel ID format (must start with UC)")
+	}
+
+	// Every channel UC... has an uploads playlist UU... whose header carries the upload count
+	args := []string{
+		"--dump-single-json",
+		"--flat-playlist",
+		"--playlist-end", "1",
+		"https://www.youtube.com/playlist?list=UU" + strings.TrimPrefix(channelID, "UC"),
+	}
+	if err := offline.Check("fetching the channel upload count with yt-dlp"); err != nil {
+		return nil, err
+	}
+	output, err := s.cmdRunner.Run(ctx, ytdlp.Binary, ytdlp.WithNetworkArgs(args...)...)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeExternal, "failed to fetch channel upload count with yt-dlp")
+	}
+
+	var info ytDlpPlaylistInfo
+	if err := json.Unmarshal(output, &info); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to parse yt-dlp output")
+	}
+	if info.PlaylistCount <= 0 {
+		return nil, errors.New(errors.CodeExternal, fmt.Sprintf("yt-dlp reported no upload count for channel %s", channelID))
+	}
+
+	stored, err := s.videoRepo.CountByChannelIDAndStatus(ctx, channelID, model.VideoStatusAvailable)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to count videos")
+	}
+
+	difference := stored - info.PlaylistCount
+	tolerated := math.Max(driftMinVideos, driftRatio*float64(info.PlaylistCount))
+	return &VideoCountDrift{
+		ChannelID:  channelID,
+		Reported:   info.PlaylistCount,
+		Stored:     stored,
+		Difference: difference,
+		Drifted:    math.Abs(float64(difference)) > tolerated,
+	}, nil
+}
+
+// ReconcileChannelVideos lists every video of a channel, saves the listed videos that are not
+// stored yet and records the availability of the stored ones like VerifyChannelVideos
+func (s *youTubeService) ReconcileChannelVideos(ctx context.Context, channelID string) (*Reconciliation, error) {
+	entries, err := s.fetchFlatPlaylist(ctx, channelID, FetchOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.storedChannelVideos(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	verify, err := s.verifyAgainstListing(ctx, channelID, entries, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(stored))
+	for _, video := range stored {
+		known[video.ID] = true
+	}
+	added := []*model.Video{}
+	for _, entry := range entries {
+		if !known[entry.ID] && listedAvailable(entry) {
+			known[entry.ID] = true
+			added = append(added, entry.video(channelID))
+		}
+	}
+	if len(added) > 0 {
+		if err := s.videoRepo.UpsertBatch(ctx, added); err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, "failed to save videos to database")
+		}
+	}
+
+	return &Reconciliation{Added: added, Verify: verify}, nil
+}
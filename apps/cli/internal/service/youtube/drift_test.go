@@ -0,0 +1,84 @@
+package youtube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+func TestYouTubeService_CheckVideoCount(t *testing.T) {
+	channelID := "UC123456789abcdef"
+	countArgs := []string{"--dump-single-json", "--flat-playlist", "--playlist-end", "1", "https://www.youtube.com/playlist?list=UU123456789abcdef"}
+
+	tests := []struct {
+		name        string
+		output      string
+		stored      int
+		wantDrifted bool
+		wantError   bool
+	}{
+		{name: "within tolerance", output: `{"id": "UU123456789abcdef", "playlist_count": 200}`, stored: 192},
+		{name: "fetch gap", output: `{"playlist_count": 200}`, stored: 150, wantDrifted: true},
+		{name: "possible deletions", output: `{"playlist_count": 40}`, stored: 46, wantDrifted: true},
+		{name: "no count reported", output: `{"id": "UU123456789abcdef"}`, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRunner := new(mockCmdRunner)
+			mockVideoRepo := new(mockVideoRepository)
+			mockRunner.On("Run", mock.Anything, "yt-dlp", countArgs).Return([]byte(tt.output), nil)
+			mockVideoRepo.On("CountByChannelIDAndStatus", mock.Anything, channelID, model.VideoStatusAvailable).Return(tt.stored, nil)
+
+			service := NewYouTubeServiceWithRepositories(mockRunner, nil, mockVideoRepo)
+			drift, err := service.CheckVideoCount(context.Background(), channelID)
+
+			if tt.wantError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.stored, drift.Stored)
+			assert.Equal(t, drift.Stored-drift.Reported, drift.Difference)
+			assert.Equal(t, tt.wantDrifted, drift.Drifted)
+		})
+	}
+}
+
+func TestYouTubeService_ReconcileChannelVideos(t *testing.T) {
+	channelID := "UC123456789abcdef"
+	listArgs := []string{"--dump-json", "--flat-playlist", "https://www.youtube.com/channel/" + channelID}
+	listing := `{"id": "video1", "title": "Still Here"}
+{"id": "video3", "title": "Missed Earlier", "url": "https://www.youtube.com/watch?v=video3"}
+{"id": "video4", "title": "[Deleted video]"}`
+
+	mockRunner := new(mockCmdRunner)
+	mockVideoRepo := new(mockVideoRepository)
+	mockRunner.On("Run", mock.Anything, "yt-dlp", listArgs).Return([]byte(listing), nil)
+	mockVideoRepo.On("GetByChannelID", mock.Anything, channelID, verifyPageSize, 0).Return([]*model.Video{
+		{ID: "video1", Title: "Still Here", Status: model.VideoStatusAvailable},
+		{ID: "video2", Title: "Removed", Status: model.VideoStatusAvailable},
+	}, nil)
+	mockVideoRepo.On("UpdateStatus", mock.Anything, "video1", model.VideoStatusAvailable).Return(nil)
+	mockVideoRepo.On("UpdateStatus", mock.Anything, "video2", model.VideoStatusUnavailable).Return(nil)
+	// Only the missed video is saved; placeholders of removed videos are not
+	mockVideoRepo.On("UpsertBatch", mock.Anything, []*model.Video{
+		{ID: "video3", ChannelID: channelID, Title: "Missed Earlier", URL: "https://www.youtube.com/watch?v=video3"},
+	}).Return(nil)
+
+	service := NewYouTubeServiceWithRepositories(mockRunner, nil, mockVideoRepo)
+	result, err := service.ReconcileChannelVideos(context.Background(), channelID)
+	require.NoError(t, err)
+	require.Len(t, result.Added, 1)
+	assert.Equal(t, "video3", result.Added[0].ID)
+	assert.Equal(t, 2, result.Verify.Checked)
+	assert.Equal(t, 1, result.Verify.Changed)
+
+	mockRunner.AssertExpectations(t)
+	mockVideoRepo.AssertExpectations(t)
+}
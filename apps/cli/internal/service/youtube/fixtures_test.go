@@ -0,0 +1,83 @@
+package youtube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/fixtures"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// The tests below parse recorded yt-dlp output, one subtest per yt-dlp version that produced it
+
+func fixtureRunner(data []byte) *mockCmdRunner {
+	runner := new(mockCmdRunner)
+	runner.On("Run", mock.Anything, "yt-dlp", mock.AnythingOfType("[]string")).Return(data, nil)
+	return runner
+}
+
+func TestFixtures_FetchChannelInfo(t *testing.T) {
+	for _, f := range fixtures.All(t, fixtures.KindChannel) {
+		t.Run(f.ID(), func(t *testing.T) {
+			service := NewYouTubeServiceWithCmdRunner(fixtureRunner(f.Data))
+
+			channel, err := service.FetchChannelInfo(context.Background(), "https://www.youtube.com/@jawed")
+			require.NoError(t, err)
+			assert.Equal(t, &model.Channel{
+				ID:   "UC4QobU6STFB0P71PMvOGN5A",
+				Name: "jawed",
+				URL:  "https://www.youtube.com/channel/UC4QobU6STFB0P71PMvOGN5A",
+			}, channel)
+		})
+	}
+}
+
+func TestFixtures_FetchChannelVideos(t *testing.T) {
+	for _, f := range fixtures.All(t, fixtures.KindFlatPlaylist) {
+		t.Run(f.ID(), func(t *testing.T) {
+			service := NewYouTubeServiceWithCmdRunner(fixtureRunner(f.Data))
+
+			videos, err := service.FetchChannelVideos(context.Background(), "UC4QobU6STFB0P71PMvOGN5A", FetchOptions{})
+			require.NoError(t, err)
+			require.Len(t, videos, 1)
+			assert.Equal(t, "jNQXAC9IVRw", videos[0].ID)
+			assert.Equal(t, "UC4QobU6STFB0P71PMvOGN5A", videos[0].ChannelID)
+			assert.Equal(t, "Me at the zoo", videos[0].Title)
+			assert.Equal(t, "https://www.youtube.com/watch?v=jNQXAC9IVRw", videos[0].URL)
+			assert.Equal(t, 19.0, videos[0].Duration)
+		})
+	}
+}
+
+func TestFixtures_ListCaptions(t *testing.T) {
+	for _, f := range fixtures.All(t, fixtures.KindCaptions) {
+		t.Run(f.ID(), func(t *testing.T) {
+			service := NewYouTubeServiceWithCmdRunner(fixtureRunner(f.Data))
+
+			listing, err := service.ListCaptions(context.Background(), "jNQXAC9IVRw")
+			require.NoError(t, err)
+
+			var manual []string
+			for _, track := range listing.Manual() {
+				manual = append(manual, track.Language)
+				assert.Contains(t, track.Formats, "vtt")
+			}
+			assert.Subset(t, manual, []string{"en", "de-DE"})
+
+			auto := map[string]*CaptionTrack{}
+			for _, track := range listing.Tracks {
+				if track.Kind == CaptionAuto {
+					auto[track.Language] = track
+				}
+			}
+			require.Contains(t, auto, "en")
+			if original, ok := auto["en-orig"]; ok {
+				assert.True(t, original.Original)
+			}
+		})
+	}
+}
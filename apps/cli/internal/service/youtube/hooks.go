@@ -0,0 +1,110 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Taichi-iskw/yt-lang/internal/hook"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// VideoSavedPayload is the data of a video_saved event
+type VideoSavedPayload struct {
+	ChannelID string         `json:"channel_id"`
+	Videos    []*model.Video `json:"videos"`
+}
+
+// hookedService runs hooks after channel videos are saved
+type hookedService struct {
+	YouTubeService
+	dispatcher *hook.Dispatcher
+}
+
+// NewHookedService wraps a YouTube service so that video_saved hooks run after each save
+func NewHookedService(inner YouTubeService, dispatcher *hook.Dispatcher) YouTubeService {
+	return &hookedService{YouTubeService: inner, dispatcher: dispatcher}
+}
+
+// SaveChannelVideos saves the videos and then dispatches one video_saved event for the batch.
+// Hook failures are reported as warnings; the videos stay saved.
+func (s *hookedService) SaveChannelVideos(ctx context.Context, channelID string, opts FetchOptions) ([]*model.Video, error) {
+	videos, err := s.YouTubeService.SaveChannelVideos(ctx, channelID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := VideoSavedPayload{ChannelID: channelID, Videos: videos}
+	if err := s.dispatcher.Dispatch(ctx, hook.EventVideoSaved, payload); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	return videos, nil
+}
+
+// ReconcileChannelVideos reconciles the channel and then dispatches a video_saved event for the
+// videos it added, if any
+func (s *hookedService) ReconcileChannelVideos(ctx context.Context, channelID string) (*Reconciliation, error) {
+	result, err := s.YouTubeService.ReconcileChannelVideos(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Added) == 0 {
+		return result, nil
+	}
+
+	payload := VideoSavedPayload{ChannelID: channelID, Videos: result.Added}
+	if err := s.dispatcher.Dispatch(ctx, hook.EventVideoSaved, payload); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	return result, nil
+}
+
+// ImportList imports the list and then dispatches one video_saved event per channel of the
+// imported videos
+func (s *hookedService) ImportList(ctx context.Context, list string, opts ImportListOptions) (*ListImport, error) {
+	result, err := s.YouTubeService.ImportList(ctx, list, opts)
+	if err != nil {
+		return nil, err
+	}
+	s.dispatchByChannel(ctx, result.Videos)
+	return result, nil
+}
+
+// SaveDiscovered saves the search results and then dispatches one video_saved event per channel
+// of the saved videos
+func (s *hookedService) SaveDiscovered(ctx context.Context, videos []*DiscoveredVideo) (*ListImport, error) {
+	result, err := s.YouTubeService.SaveDiscovered(ctx, videos)
+	if err != nil {
+		return nil, err
+	}
+	s.dispatchByChannel(ctx, result.Videos)
+	return result, nil
+}
+
+// AddVideo saves the video and then dispatches a video_saved event for it, unless it was saved already
+func (s *hookedService) AddVideo(ctx context.Context, rawURL string) (*AddedVideo, error) {
+	added, err := s.YouTubeService.AddVideo(ctx, rawURL)
+	if err != nil || added.AlreadySaved {
+		return added, err
+	}
+	s.dispatchByChannel(ctx, []*model.Video{added.Video})
+	return added, nil
+}
+
+// dispatchByChannel dispatches one video_saved event per channel of videos, in order of first appearance
+func (s *hookedService) dispatchByChannel(ctx context.Context, videos []*model.Video) {
+	var channelIDs []string
+	byChannel := make(map[string][]*model.Video)
+	for _, video := range videos {
+		if _, ok := byChannel[video.ChannelID]; !ok {
+			channelIDs = append(channelIDs, video.ChannelID)
+		}
+		byChannel[video.ChannelID] = append(byChannel[video.ChannelID], video)
+	}
+	for _, channelID := range channelIDs {
+		payload := VideoSavedPayload{ChannelID: channelID, Videos: byChannel[channelID]}
+		if err := s.dispatcher.Dispatch(ctx, hook.EventVideoSaved, payload); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/offline"
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
+)
+
+// Personal playlists of the signed-in account
+const (
+	ListWatchLater = "WL" // Watch later
+	ListLiked      = "LL" // Liked videos
+)
+
+// ImportListOptions configures ImportList
+type ImportListOptions struct {
+	// CookiesFromBrowser names the browser whose YouTube session authenticates yt-dlp, optionally
+	// with a profile (yt-dlp --cookies-from-browser syntax, e.g. firefox or chrome:Profile 1)
+	CookiesFromBrowser string
+
+	Limit int // Newest list entries to import (0 imports all)
+}
+
+// ListImport is the outcome of ImportList
+type ListImport struct {
+	List            string           `json:"list"`
+	Videos          []*model.Video   `json:"videos"`           // Listed videos, saved unless they were stored already
+	CreatedChannels []*model.Channel `json:"created_channels"` // Channels of listed videos that were not stored yet
+	Skipped         []string         `json:"skipped"`          // IDs of deleted or private entries without a channel
+}
+
+// ytDlpListEntry is a flat entry of a playlist mixing videos of many channels
+type ytDlpListEntry struct {
+	ytDlpVideoInfo
+	Channel    string `json:"channel"`
+	Uploader   string `json:"uploader"` // Set instead of channel by some yt-dlp versions
+	ChannelURL string `json:"channel_url"`
+}
+
+// channel returns the channel the entry was uploaded by
+func (e *ytDlpListEntry) channel() *model.Channel {
+	name := e.Channel
+	if name == "" {
+		name = e.Uploader
+	}
+	url := e.ChannelURL
+	if url == "" {
+		url = "https://www.youtube.com/channel/" + e.ChannelID
+	}
+	return &model.Channel{ID: e.ChannelID, Name: name, URL: url}
+}
+
+// ImportList saves the videos of a personal playlist (watch later or liked videos) under their
+// own channels, creating the channels that are not stored yet. yt-dlp reads the playlist with
+// the cookies of a browser signed in to YouTube.
+func (s *youTubeService) ImportList(ctx context.Context, list string, opts ImportListOptions) (*ListImport, error) {
+	list = strings.ToUpper(list)
+	if list != ListWatchLater && list != ListLiked {
+		return nil, errors.New(errors.CodeInvalidArg, fmt.Sprintf("unsupported list %q (supported: %s, %s)", list, ListWatchLater, ListLiked))
+	}
+	if opts.CookiesFromBrowser == "" {
+		return nil, errors.New(errors.CodeInvalidArg, "a browser to read YouTube cookies from is required for personal lists")
+	}
+
+	entries, err := s.fetchList(ctx, list, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListImport{List: list, Videos: []*model.Video{}, CreatedChannels: []*model.Channel{}, Skipped: []string{}}
+	var videos []*model.Video
+	channels := make(map[string]*model.Channel)
+	for _, entry := range entries {
+		if entry.ChannelID == "" || !listedAvailable(entry.ytDlpVideoInfo) {
+			result.Skipped = append(result.Skipped, entry.ID)
+			continue
+		}
+		if _, ok := channels[entry.ChannelID]; !ok {
+			channels[entry.ChannelID] = entry.channel()
+		}
+		videos = append(videos, entry.video(entry.ChannelID))
+	}
+
+	if err := s.saveListed(ctx, videos, channels, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// saveListed saves videos of many channels under their own channels, creating the channels
+// (looked up in channels by ID) that are not stored yet, and records both in result
+func (s *youTubeService) saveListed(ctx context.Context, videos []*model.Video, channels map[string]*model.Channel, result *ListImport) error {
+	var channelIDs []string
+	byChannel := make(map[string][]*model.Video)
+	for _, video := range videos {
+		if _, ok := byChannel[video.ChannelID]; !ok {
+			channelIDs = append(channelIDs, video.ChannelID)
+		}
+		byChannel[video.ChannelID] = append(byChannel[video.ChannelID], video)
+		result.Videos = append(result.Videos, video)
+	}
+
+	for _, channelID := range channelIDs {
+		created, err := s.ensureChannel(ctx, channels[channelID])
+		if err != nil {
+			return err
+		}
+		if created {
+			result.CreatedChannels = append(result.CreatedChannels, channels[channelID])
+		}
+
+		// UpsertBatch filters known videos per channel
+		if err := s.videoRepo.UpsertBatch(ctx, byChannel[channelID]); err != nil {
+			return errors.Wrap(err, errors.CodeInternal, "failed to save videos to database")
+		}
+	}
+	return nil
+}
+
+// fetchList lists a personal playlist with yt-dlp flat extraction, authenticated with browser cookies
+func (s *youTubeService) fetchList(ctx context.Context, list string, opts ImportListOptions) ([]ytDlpListEntry, error) {
+	args := []string{
+		"--dump-json",
+		"--flat-playlist",
+		"--cookies-from-browser", opts.CookiesFromBrowser,
+	}
+	if opts.Limit > 0 {
+		args = append(args, "--playlist-end", fmt.Sprintf("%d", opts.Limit))
+	}
+	args = append(args, "https://www.youtube.com/playlist?list="+list)
+
+	if err := offline.Check("fetching a personal list with yt-dlp"); err != nil {
+		return nil, err
+	}
+	output, err := s.cmdRunner.Run(ctx, ytdlp.Binary, ytdlp.WithNetworkArgs(args...)...)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeExternal, fmt.Sprintf("failed to fetch list %s with yt-dlp (is %s signed in to YouTube?)", list, opts.CookiesFromBrowser))
+	}
+
+	var entries []ytDlpListEntry
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry ytDlpListEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, "failed to parse yt-dlp output")
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// ensureChannel creates channel unless a channel with its ID is stored, reporting whether it did
+func (s *youTubeService) ensureChannel(ctx context.Context, channel *model.Channel) (bool, error) {
+	saved, err := s.channelSaved(ctx, channel.ID)
+	if err != nil || saved {
+		return false, err
+	}
+
+	if err := s.channelRepo.Create(ctx, channel); err != nil {
+		return false, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to save channel %s to database", channel.ID))
+	}
+	return true, nil
+}
+
+// channelSaved reports whether a channel with the ID is stored
+func (s *youTubeService) channelSaved(ctx context.Context, channelID string) (bool, error) {
+	_, err := s.channelRepo.GetByID(ctx, channelID)
+	if err == nil {
+		return true, nil
+	}
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) && appErr.Code == errors.CodeNotFound {
+		return false, nil
+	}
+	return false, errors.Wrap(err, errors.CodeInternal, "failed to look up channel")
+}
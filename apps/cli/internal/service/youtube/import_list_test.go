@@ -0,0 +1,58 @@
+package youtube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+func TestYouTubeService_ImportList(t *testing.T) {
+	listArgs := []string{"--dump-json", "--flat-playlist", "--cookies-from-browser", "firefox", "https://www.youtube.com/playlist?list=WL"}
+	listing := `{"id": "video1", "title": "Known Channel", "url": "https://www.youtube.com/watch?v=video1", "channel_id": "UCknown", "channel": "Known"}
+{"id": "video2", "title": "New Channel", "url": "https://www.youtube.com/watch?v=video2", "channel_id": "UCnew", "uploader": "Newcomer"}
+{"id": "video3", "title": "[Private video]"}
+{"id": "video4", "title": "Known Again", "url": "https://www.youtube.com/watch?v=video4", "channel_id": "UCknown", "channel": "Known"}`
+
+	mockRunner := new(mockCmdRunner)
+	mockChannelRepo := new(mockChannelRepository)
+	mockVideoRepo := new(mockVideoRepository)
+	mockRunner.On("Run", mock.Anything, "yt-dlp", listArgs).Return([]byte(listing), nil)
+	mockChannelRepo.On("GetByID", mock.Anything, "UCknown").Return(&model.Channel{ID: "UCknown"}, nil)
+	mockChannelRepo.On("GetByID", mock.Anything, "UCnew").Return((*model.Channel)(nil), errors.New(errors.CodeNotFound, "channel not found"))
+	mockChannelRepo.On("Create", mock.Anything, &model.Channel{ID: "UCnew", Name: "Newcomer", URL: "https://www.youtube.com/channel/UCnew"}).Return(nil)
+	mockVideoRepo.On("UpsertBatch", mock.Anything, []*model.Video{
+		{ID: "video1", ChannelID: "UCknown", Title: "Known Channel", URL: "https://www.youtube.com/watch?v=video1"},
+		{ID: "video4", ChannelID: "UCknown", Title: "Known Again", URL: "https://www.youtube.com/watch?v=video4"},
+	}).Return(nil)
+	mockVideoRepo.On("UpsertBatch", mock.Anything, []*model.Video{
+		{ID: "video2", ChannelID: "UCnew", Title: "New Channel", URL: "https://www.youtube.com/watch?v=video2"},
+	}).Return(nil)
+
+	service := NewYouTubeServiceWithRepositories(mockRunner, mockChannelRepo, mockVideoRepo)
+	result, err := service.ImportList(context.Background(), "wl", ImportListOptions{CookiesFromBrowser: "firefox"})
+	require.NoError(t, err)
+
+	assert.Equal(t, ListWatchLater, result.List)
+	assert.Len(t, result.Videos, 3)
+	require.Len(t, result.CreatedChannels, 1)
+	assert.Equal(t, "UCnew", result.CreatedChannels[0].ID)
+	assert.Equal(t, []string{"video3"}, result.Skipped)
+	mockChannelRepo.AssertExpectations(t)
+	mockVideoRepo.AssertExpectations(t)
+}
+
+func TestYouTubeService_ImportList_InvalidInput(t *testing.T) {
+	service := NewYouTubeServiceWithRepositories(new(mockCmdRunner), nil, nil)
+
+	_, err := service.ImportList(context.Background(), "PL123", ImportListOptions{CookiesFromBrowser: "firefox"})
+	assert.ErrorContains(t, err, "unsupported list")
+
+	_, err = service.ImportList(context.Background(), ListLiked, ImportListOptions{})
+	assert.ErrorContains(t, err, "browser")
+}
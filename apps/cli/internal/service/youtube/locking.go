@@ -0,0 +1,104 @@
+package youtube
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/lock"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+// lockingService serializes channel syncs across processes
+type lockingService struct {
+	YouTubeService
+	locker lock.Locker
+}
+
+// NewLockingService wraps a YouTube service so that only one process at a time
+// syncs the videos of a channel
+func NewLockingService(inner YouTubeService, locker lock.Locker) YouTubeService {
+	return &lockingService{YouTubeService: inner, locker: locker}
+}
+
+// SaveChannelVideos holds the sync:<channel> lock while fetching and saving videos
+func (s *lockingService) SaveChannelVideos(ctx context.Context, channelID string, opts FetchOptions) ([]*model.Video, error) {
+	var videos []*model.Video
+	err := lock.WithLock(ctx, s.locker, lock.Key("sync", channelID), func() error {
+		var err error
+		videos, err = s.YouTubeService.SaveChannelVideos(ctx, channelID, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return videos, nil
+}
+
+// MergeChannels holds the sync locks of both channels, so no sync adds videos to the channel
+// being deleted. Locks are taken in key order to avoid deadlocking with a reversed merge.
+func (s *lockingService) MergeChannels(ctx context.Context, fromID, intoID string) (*ChannelMerge, error) {
+	if fromID == intoID {
+		return s.YouTubeService.MergeChannels(ctx, fromID, intoID)
+	}
+
+	first, second := lock.Key("sync", fromID), lock.Key("sync", intoID)
+	if second < first {
+		first, second = second, first
+	}
+
+	var result *ChannelMerge
+	err := lock.WithLock(ctx, s.locker, first, func() error {
+		return lock.WithLock(ctx, s.locker, second, func() error {
+			var err error
+			result, err = s.YouTubeService.MergeChannels(ctx, fromID, intoID)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// VerifyChannelVideos holds the sync:<channel> lock while checking and updating video statuses
+func (s *lockingService) VerifyChannelVideos(ctx context.Context, channelID string) (*VerifyResult, error) {
+	var result *VerifyResult
+	err := lock.WithLock(ctx, s.locker, lock.Key("sync", channelID), func() error {
+		var err error
+		result, err = s.YouTubeService.VerifyChannelVideos(ctx, channelID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ReconcileChannelVideos holds the sync:<channel> lock while saving videos and updating statuses
+func (s *lockingService) ReconcileChannelVideos(ctx context.Context, channelID string) (*Reconciliation, error) {
+	var result *Reconciliation
+	err := lock.WithLock(ctx, s.locker, lock.Key("sync", channelID), func() error {
+		var err error
+		result, err = s.YouTubeService.ReconcileChannelVideos(ctx, channelID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ImportList holds the import:<list> lock, so overlapping imports of the same list don't
+// create the same channels and insert the same videos
+func (s *lockingService) ImportList(ctx context.Context, list string, opts ImportListOptions) (*ListImport, error) {
+	var result *ListImport
+	err := lock.WithLock(ctx, s.locker, lock.Key("import", strings.ToUpper(list)), func() error {
+		var err error
+		result, err = s.YouTubeService.ImportList(ctx, list, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package youtube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Taichi-iskw/yt-lang/internal/lock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLocker records requested keys and refuses the ones marked as held
+type fakeLocker struct {
+	held     map[string]bool
+	keys     []string
+	unlocked int
+}
+
+func (l *fakeLocker) TryLock(ctx context.Context, key string) (lock.Lock, error) {
+	l.keys = append(l.keys, key)
+	if l.held[key] {
+		return nil, fmt.Errorf("%w: %s", lock.ErrLocked, key)
+	}
+	return fakeLock{l}, nil
+}
+
+type fakeLock struct{ locker *fakeLocker }
+
+func (l fakeLock) Unlock() error {
+	l.locker.unlocked++
+	return nil
+}
+
+// importListService stubs ImportList and records whether it ran
+type importListService struct {
+	YouTubeService
+	called bool
+}
+
+func (s *importListService) ImportList(ctx context.Context, list string, opts ImportListOptions) (*ListImport, error) {
+	s.called = true
+	return &ListImport{List: list}, nil
+}
+
+func TestLockingService_ImportList(t *testing.T) {
+	t.Run("imports while holding the lock", func(t *testing.T) {
+		inner := &importListService{}
+		locker := &fakeLocker{}
+		service := NewLockingService(inner, locker)
+
+		result, err := service.ImportList(context.Background(), "wl", ImportListOptions{CookiesFromBrowser: "firefox"})
+		require.NoError(t, err)
+		assert.Equal(t, "wl", result.List)
+		assert.True(t, inner.called)
+		assert.Equal(t, []string{"import:WL"}, locker.keys)
+		assert.Equal(t, 1, locker.unlocked)
+	})
+
+	t.Run("skips when another process holds the lock", func(t *testing.T) {
+		inner := &importListService{}
+		locker := &fakeLocker{held: map[string]bool{"import:LL": true}}
+		service := NewLockingService(inner, locker)
+
+		result, err := service.ImportList(context.Background(), "LL", ImportListOptions{CookiesFromBrowser: "firefox"})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, lock.ErrLocked))
+		assert.Nil(t, result)
+		assert.False(t, inner.called)
+	})
+}
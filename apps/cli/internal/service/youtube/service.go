@@ -2,11 +2,14 @@ package youtube
 
 import (
 	"context"
+	"time"
 
 	"github.com/Taichi-iskw/yt-lang/internal/model"
 	"github.com/Taichi-iskw/yt-lang/internal/repository/channel"
 	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
 	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+	"github.com/Taichi-iskw/yt-lang/internal/videourl"
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
 )
 
 // YouTubeService is interface for YouTube operations
@@ -14,9 +17,51 @@ type YouTubeService interface {
 	FetchChannelInfo(ctx context.Context, channelURL string) (*model.Channel, error)
 	SaveChannelInfo(ctx context.Context, channelURL string) (*model.Channel, error)
 	ListChannels(ctx context.Context, limit, offset int) ([]*model.Channel, error)
-	FetchChannelVideos(ctx context.Context, channelID string, limit int) ([]*model.Video, error)
-	SaveChannelVideos(ctx context.Context, channelID string, limit int) ([]*model.Video, error)
+	CountChannels(ctx context.Context) (int, error)
+	RefreshChannel(ctx context.Context, channelID string) (*ChannelRefresh, error)
+	MergeChannels(ctx context.Context, fromID, intoID string) (*ChannelMerge, error)
+	FetchChannelVideos(ctx context.Context, channelID string, opts FetchOptions) ([]*model.Video, error)
+	SaveChannelVideos(ctx context.Context, channelID string, opts FetchOptions) ([]*model.Video, error)
 	ListVideos(ctx context.Context, channelID string, limit, offset int) ([]*model.Video, error)
+	IterateVideos(ctx context.Context, channelID string, fn func(video *model.Video) error) error
+	CountVideosByChannel(ctx context.Context, channelID string) (int, error)
+	VerifyChannelVideos(ctx context.Context, channelID string) (*VerifyResult, error)
+	CheckVideoCount(ctx context.Context, channelID string) (*VideoCountDrift, error)
+	ReconcileChannelVideos(ctx context.Context, channelID string) (*Reconciliation, error)
+	AnnotateVideo(ctx context.Context, videoID string, opts AnnotateOptions) (*model.Video, error)
+	ListRatedVideos(ctx context.Context, channelID string, minRating int) ([]*model.Video, error)
+	ImportList(ctx context.Context, list string, opts ImportListOptions) (*ListImport, error)
+	Discover(ctx context.Context, query string, opts DiscoverOptions) (*Discovery, error)
+	SaveDiscovered(ctx context.Context, videos []*DiscoveredVideo) (*ListImport, error)
+	ListCaptions(ctx context.Context, videoID string) (*CaptionListing, error)
+	AddVideo(ctx context.Context, rawURL string) (*AddedVideo, error)
+}
+
+// FetchOptions limits which of a channel's videos are fetched
+type FetchOptions struct {
+	Limit int // Newest videos to fetch (0 fetches all)
+
+	// PublishedAfter skips videos uploaded before this date (yt-dlp --dateafter, inclusive).
+	// Flat listings only carry approximate dates, and videos without one are kept.
+	PublishedAfter time.Time
+
+	// PageSize is the number of videos SaveChannelVideos fetches and saves at a time when Limit
+	// is 0; defaults to DefaultSavePageSize
+	PageSize int
+
+	// OnPage is called by SaveChannelVideos after each saved page
+	OnPage func(page SavePage)
+
+	// OnChanged is called by SaveChannelVideos with the saved videos of a page whose title,
+	// duration or upload date changed on YouTube; their transcriptions were marked stale
+	OnChanged func(changes []*model.VideoChange)
+}
+
+// SavePage reports the progress of SaveChannelVideos after a page was saved
+type SavePage struct {
+	Number  int // 1-based page number
+	Fetched int // Videos fetched in this page
+	Saved   int // Videos fetched and saved in all pages so far
 }
 
 // youTubeService implements YouTubeService
@@ -24,11 +69,15 @@ type youTubeService struct {
 	cmdRunner   common.CmdRunner
 	channelRepo channel.Repository
 	videoRepo   video.Repository
+	detector    *ytdlp.Detector // Optional; without it only baseline yt-dlp arguments are used
 }
 
 // NewYouTubeService creates a new YouTubeService
 func NewYouTubeService() YouTubeService {
-	return NewYouTubeServiceWithCmdRunner(common.NewCmdRunner())
+	return &youTubeService{
+		cmdRunner: common.NewCmdRunner(),
+		detector:  ytdlp.DefaultDetector(),
+	}
 }
 
 // NewYouTubeServiceWithCmdRunner creates a new YouTubeService with custom CmdRunner (for testing)
@@ -47,6 +96,17 @@ func NewYouTubeServiceWithRepositories(cmdRunner common.CmdRunner, channelRepo c
 	}
 }
 
+// NewYouTubeServiceWithDetector creates a new YouTubeService that adapts yt-dlp arguments to
+// the capabilities reported by detector
+func NewYouTubeServiceWithDetector(cmdRunner common.CmdRunner, channelRepo channel.Repository, videoRepo video.Repository, detector *ytdlp.Detector) YouTubeService {
+	return &youTubeService{
+		cmdRunner:   cmdRunner,
+		channelRepo: channelRepo,
+		videoRepo:   videoRepo,
+		detector:    detector,
+	}
+}
+
 // ytDlpChannelInfo represents yt-dlp JSON output structure for channel info
 type ytDlpChannelInfo struct {
 	ID         string `json:"id"`
@@ -58,9 +118,56 @@ type ytDlpChannelInfo struct {
 
 // ytDlpVideoInfo represents yt-dlp JSON output structure for video info
 type ytDlpVideoInfo struct {
-	ID        string  `json:"id"`
-	Title     string  `json:"title"`
-	ChannelID string  `json:"channel_id"`
-	URL       string  `json:"webpage_url"`
-	Duration  float64 `json:"duration"`
+	ID           string  `json:"id"`
+	Title        string  `json:"title"`
+	ChannelID    string  `json:"channel_id"`
+	URL          string  `json:"webpage_url"`
+	FlatURL      string  `json:"url"` // Flat playlist entries of some yt-dlp versions only set url
+	Duration     float64 `json:"duration"`
+	Availability string  `json:"availability"` // public, unlisted, private, needs_auth, ... (may be empty)
+	UploadDate   string  `json:"upload_date"`  // YYYYMMDD (may be empty in flat listings)
+	Timestamp    float64 `json:"timestamp"`    // Unix time, set instead of upload_date by some listings
+
+	// Chapters are only reported with the full metadata of a single video, never in listings
+	Chapters []model.VideoChapter `json:"chapters"`
+}
+
+// uploadDate returns the publication date, whichever field the listing filled, or nil when unknown
+func (v *ytDlpVideoInfo) uploadDate() *time.Time {
+	if date, err := time.Parse("20060102", v.UploadDate); err == nil {
+		return &date
+	}
+	if v.Timestamp > 0 {
+		t := time.Unix(int64(v.Timestamp), 0).UTC()
+		date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return &date
+	}
+	return nil
+}
+
+// video converts a listing entry to a video of channelID
+func (v *ytDlpVideoInfo) video(channelID string) *model.Video {
+	return &model.Video{
+		ID:         v.ID,
+		ChannelID:  channelID,
+		Title:      v.Title,
+		URL:        v.pageURL(),
+		Duration:   v.Duration,
+		UploadDate: v.uploadDate(),
+	}
+}
+
+// pageURL returns the canonical watch URL of the video, so shorts and other URL forms listed
+// by yt-dlp are stored alike, or the listed URL as is when it is not a video URL. Flat playlist
+// entries carry the channel page as webpage_url and the video (or only its ID) as url.
+func (v *ytDlpVideoInfo) pageURL() string {
+	for _, listed := range []string{v.URL, v.FlatURL} {
+		if canonical, err := videourl.Canonicalize(listed); err == nil {
+			return canonical
+		}
+	}
+	if v.URL != "" {
+		return v.URL
+	}
+	return v.FlatURL
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/repository/video"
 	"github.com/Taichi-iskw/yt-lang/internal/service/common"
 )
 
@@ -62,6 +63,26 @@ func (m *mockChannelRepository) List(ctx context.Context, limit, offset int) ([]
 	return args.Get(0).([]*model.Channel), args.Error(1)
 }
 
+func (m *mockChannelRepository) Merge(ctx context.Context, fromID, intoID string) (int, error) {
+	args := m.Called(ctx, fromID, intoID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockChannelRepository) Count(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockChannelRepository) GetLanguages(ctx context.Context, id string) ([]string, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *mockChannelRepository) SetLanguages(ctx context.Context, id string, languages []string) error {
+	args := m.Called(ctx, id, languages)
+	return args.Error(0)
+}
+
 // mockVideoRepository is a mock implementation of VideoRepository for testing
 type mockVideoRepository struct {
 	mock.Mock
@@ -77,16 +98,55 @@ func (m *mockVideoRepository) CreateBatch(ctx context.Context, videos []*model.V
 	return args.Error(0)
 }
 
+func (m *mockVideoRepository) ListTranscriptionCandidates(ctx context.Context, filter video.CandidateFilter) ([]*model.Video, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Video), args.Error(1)
+}
+
+func (m *mockVideoRepository) PlanTranscriptionCandidates(ctx context.Context, filter video.CandidateFilter) ([]*video.PlannedVideo, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*video.PlannedVideo), args.Error(1)
+}
+
 func (m *mockVideoRepository) UpsertBatch(ctx context.Context, videos []*model.Video) error {
 	args := m.Called(ctx, videos)
 	return args.Error(0)
 }
 
+// DetectChanges reports no changes unless a test expects the call, so tests of saving
+// videos don't all have to
+func (m *mockVideoRepository) DetectChanges(ctx context.Context, videos []*model.Video) ([]*model.VideoChange, error) {
+	for _, call := range m.ExpectedCalls {
+		if call.Method == "DetectChanges" {
+			args := m.Called(ctx, videos)
+			changes, _ := args.Get(0).([]*model.VideoChange)
+			return changes, args.Error(1)
+		}
+	}
+	return nil, nil
+}
+
 func (m *mockVideoRepository) GetByID(ctx context.Context, id string) (*model.Video, error) {
 	args := m.Called(ctx, id)
 	return args.Get(0).(*model.Video), args.Error(1)
 }
 
+func (m *mockVideoRepository) CountByChannelID(ctx context.Context, channelID string) (int, error) {
+	args := m.Called(ctx, channelID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockVideoRepository) CountByChannelIDAndStatus(ctx context.Context, channelID string, status string) (int, error) {
+	args := m.Called(ctx, channelID, status)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *mockVideoRepository) GetByChannelID(ctx context.Context, channelID string, limit, offset int) ([]*model.Video, error) {
 	args := m.Called(ctx, channelID, limit, offset)
 	return args.Get(0).([]*model.Video), args.Error(1)
@@ -97,6 +157,50 @@ func (m *mockVideoRepository) Update(ctx context.Context, video *model.Video) er
 	return args.Error(0)
 }
 
+func (m *mockVideoRepository) UpdateStatus(ctx context.Context, id string, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *mockVideoRepository) SetDuplicateOf(ctx context.Context, id string, originalID string) error {
+	args := m.Called(ctx, id, originalID)
+	return args.Error(0)
+}
+
+func (m *mockVideoRepository) UpdateAnnotation(ctx context.Context, id string, rating *int, note *string, clear bool) error {
+	args := m.Called(ctx, id, rating, note, clear)
+	return args.Error(0)
+}
+
+func (m *mockVideoRepository) SetChapters(ctx context.Context, id string, chapters []model.VideoChapter) error {
+	args := m.Called(ctx, id, chapters)
+	return args.Error(0)
+}
+
+func (m *mockVideoRepository) GetChapters(ctx context.Context, id string) ([]model.VideoChapter, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.VideoChapter), args.Error(1)
+}
+
+func (m *mockVideoRepository) ListByMinRating(ctx context.Context, channelID string, minRating int) ([]*model.Video, error) {
+	args := m.Called(ctx, channelID, minRating)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Video), args.Error(1)
+}
+
+func (m *mockVideoRepository) ListPruneCandidates(ctx context.Context, filter video.PruneFilter) ([]*model.Video, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Video), args.Error(1)
+}
+
 func (m *mockVideoRepository) Delete(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -0,0 +1,133 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+const verifyPageSize = 500 // Stored videos loaded per repository call while verifying
+
+// unavailableAvailability lists yt-dlp availability values of videos that can't be processed
+var unavailableAvailability = map[string]bool{
+	"private":         true,
+	"premium_only":    true,
+	"subscriber_only": true,
+	"needs_auth":      true,
+}
+
+// unavailableTitles are placeholder titles yt-dlp reports for removed playlist entries
+var unavailableTitles = map[string]bool{
+	"[Private video]": true,
+	"[Deleted video]": true,
+}
+
+// VideoCheck is the verification outcome of one stored video
+type VideoCheck struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Previous string `json:"previous"`
+	Changed  bool   `json:"changed"`
+}
+
+// VerifyResult summarizes an availability check of a channel's stored videos
+type VerifyResult struct {
+	ChannelID   string        `json:"channel_id"`
+	Checked     int           `json:"checked"`
+	Available   int           `json:"available"`
+	Unavailable int           `json:"unavailable"`
+	Changed     int           `json:"changed"`
+	Videos      []*VideoCheck `json:"videos"`
+}
+
+// VerifyChannelVideos checks the stored videos of a channel against a flat listing of the
+// channel and records each video's availability status. Videos missing from the listing,
+// or listed as private/deleted, are marked unavailable; videos that reappear are restored.
+func (s *youTubeService) VerifyChannelVideos(ctx context.Context, channelID string) (*VerifyResult, error) {
+	entries, err := s.fetchFlatPlaylist(ctx, channelID, FetchOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.storedChannelVideos(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	return s.verifyAgainstListing(ctx, channelID, entries, stored)
+}
+
+// storedChannelVideos loads every stored video of a channel
+func (s *youTubeService) storedChannelVideos(ctx context.Context, channelID string) ([]*model.Video, error) {
+	var stored []*model.Video
+	for offset := 0; ; offset += verifyPageSize {
+		videos, err := s.videoRepo.GetByChannelID(ctx, channelID, verifyPageSize, offset)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, "failed to list stored videos")
+		}
+		stored = append(stored, videos...)
+		if len(videos) < verifyPageSize {
+			break
+		}
+	}
+	return stored, nil
+}
+
+// listedAvailable reports whether a flat listing entry is a video that can still be processed
+func listedAvailable(entry ytDlpVideoInfo) bool {
+	return !unavailableAvailability[entry.Availability] && !unavailableTitles[entry.Title]
+}
+
+// verifyAgainstListing records the availability of stored videos from a flat listing of their channel
+func (s *youTubeService) verifyAgainstListing(ctx context.Context, channelID string, entries []ytDlpVideoInfo, stored []*model.Video) (*VerifyResult, error) {
+	listed := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		listed[entry.ID] = listedAvailable(entry)
+	}
+
+	// An empty listing more likely means yt-dlp or YouTube failed than that every video is gone
+	if len(entries) == 0 && len(stored) > 0 {
+		return nil, errors.New(errors.CodeExternal, fmt.Sprintf("yt-dlp listed no videos for channel %s; refusing to mark %d stored videos unavailable", channelID, len(stored)))
+	}
+
+	result := &VerifyResult{ChannelID: channelID, Videos: []*VideoCheck{}}
+	for _, video := range stored {
+		status := model.VideoStatusUnavailable
+		if listed[video.ID] {
+			status = model.VideoStatusAvailable
+		}
+
+		previous := video.Status
+		if previous == "" {
+			previous = model.VideoStatusAvailable
+		}
+
+		check := &VideoCheck{
+			ID:       video.ID,
+			Title:    video.Title,
+			Status:   status,
+			Previous: previous,
+			Changed:  status != previous,
+		}
+
+		// Record every check so checked_at reflects this run
+		if err := s.videoRepo.UpdateStatus(ctx, video.ID, status); err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to update status of video %s", video.ID))
+		}
+
+		result.Checked++
+		if status == model.VideoStatusAvailable {
+			result.Available++
+		} else {
+			result.Unavailable++
+		}
+		if check.Changed {
+			result.Changed++
+		}
+		result.Videos = append(result.Videos, check)
+	}
+
+	return result, nil
+}
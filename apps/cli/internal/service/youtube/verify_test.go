@@ -0,0 +1,116 @@
+package youtube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+func TestYouTubeService_VerifyChannelVideos(t *testing.T) {
+	channelID := "UC123456789abcdef"
+	listArgs := []string{"--dump-json", "--flat-playlist", "https://www.youtube.com/channel/" + channelID}
+
+	tests := []struct {
+		name           string
+		listing        string
+		stored         []*model.Video
+		videoRepoSetup func(*mockVideoRepository)
+		wantStatuses   map[string]string
+		wantChanged    int
+		wantError      bool
+		errorContains  string
+	}{
+		{
+			name: "marks missing and private videos unavailable",
+			listing: `{"id": "video1", "title": "Still Here", "availability": "public"}
+{"id": "video2", "title": "[Private video]"}
+{"id": "video4", "title": "Restored", "availability": null}`,
+			stored: []*model.Video{
+				{ID: "video1", Title: "Still Here", Status: model.VideoStatusAvailable},
+				{ID: "video2", Title: "Gone Private", Status: model.VideoStatusAvailable},
+				{ID: "video3", Title: "Deleted", Status: model.VideoStatusAvailable},
+				{ID: "video4", Title: "Restored", Status: model.VideoStatusUnavailable},
+			},
+			videoRepoSetup: func(m *mockVideoRepository) {
+				m.On("UpdateStatus", mock.Anything, "video1", model.VideoStatusAvailable).Return(nil)
+				m.On("UpdateStatus", mock.Anything, "video2", model.VideoStatusUnavailable).Return(nil)
+				m.On("UpdateStatus", mock.Anything, "video3", model.VideoStatusUnavailable).Return(nil)
+				m.On("UpdateStatus", mock.Anything, "video4", model.VideoStatusAvailable).Return(nil)
+			},
+			wantStatuses: map[string]string{
+				"video1": model.VideoStatusAvailable,
+				"video2": model.VideoStatusUnavailable,
+				"video3": model.VideoStatusUnavailable,
+				"video4": model.VideoStatusAvailable,
+			},
+			wantChanged: 3,
+		},
+		{
+			name:    "empty listing is not trusted",
+			listing: "",
+			stored: []*model.Video{
+				{ID: "video1", Title: "Still Here"},
+			},
+			videoRepoSetup: func(m *mockVideoRepository) {},
+			wantError:      true,
+			errorContains:  "refusing to mark 1 stored videos unavailable",
+		},
+		{
+			name:    "status update fails",
+			listing: `{"id": "video1", "title": "Still Here"}`,
+			stored: []*model.Video{
+				{ID: "video1", Title: "Still Here"},
+			},
+			videoRepoSetup: func(m *mockVideoRepository) {
+				m.On("UpdateStatus", mock.Anything, "video1", model.VideoStatusAvailable).Return(assert.AnError)
+			},
+			wantError:     true,
+			errorContains: "failed to update status of video video1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRunner := new(mockCmdRunner)
+			mockVideoRepo := new(mockVideoRepository)
+
+			mockRunner.On("Run", mock.Anything, "yt-dlp", listArgs).Return([]byte(tt.listing), nil)
+			mockVideoRepo.On("GetByChannelID", mock.Anything, channelID, verifyPageSize, 0).Return(tt.stored, nil)
+			tt.videoRepoSetup(mockVideoRepo)
+
+			service := NewYouTubeServiceWithRepositories(mockRunner, nil, mockVideoRepo)
+			result, err := service.VerifyChannelVideos(context.Background(), channelID)
+
+			if tt.wantError {
+				require.Error(t, err)
+				assert.Nil(t, result)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.stored), result.Checked)
+			assert.Equal(t, tt.wantChanged, result.Changed)
+			for _, check := range result.Videos {
+				assert.Equal(t, tt.wantStatuses[check.ID], check.Status, check.ID)
+			}
+			assert.Equal(t, result.Checked, result.Available+result.Unavailable)
+
+			mockRunner.AssertExpectations(t)
+			mockVideoRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestYouTubeService_VerifyChannelVideos_InvalidChannel(t *testing.T) {
+	service := NewYouTubeServiceWithRepositories(new(mockCmdRunner), nil, new(mockVideoRepository))
+
+	_, err := service.VerifyChannelVideos(context.Background(), "invalid-format")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid channel ID format")
+}
@@ -8,18 +8,61 @@ import (
 
 	"github.com/Taichi-iskw/yt-lang/internal/errors"
 	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/offline"
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
+)
+
+const (
+	// listPageSize is the number of stored videos loaded per repository call by IterateVideos
+	listPageSize = 500
+
+	// DefaultSavePageSize is the number of videos SaveChannelVideos fetches and saves per page
+	// when saving a whole channel
+	DefaultSavePageSize = 200
 )
 
 // FetchChannelVideos fetches video list from YouTube channel ID using yt-dlp
-func (s *youTubeService) FetchChannelVideos(ctx context.Context, channelID string, limit int) ([]*model.Video, error) {
+func (s *youTubeService) FetchChannelVideos(ctx context.Context, channelID string, opts FetchOptions) ([]*model.Video, error) {
+	entries, err := s.fetchFlatPlaylist(ctx, channelID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	videos := make([]*model.Video, 0, len(entries))
+	for _, ytInfo := range entries {
+		// Use the input channel ID (we know it's correct)
+		// If yt-dlp returns a different channel_id, we trust our input more
+		videoChannelID := channelID
+		if ytInfo.ChannelID != "" && ytInfo.ChannelID != channelID {
+			// Log the discrepancy but use our input channel ID
+			// In a real implementation, you might want to log this
+		}
+
+		// Convert to our model
+		videos = append(videos, ytInfo.video(videoChannelID))
+	}
+
+	return videos, nil
+}
+
+// fetchFlatPlaylist lists a channel's videos with yt-dlp flat extraction (no per-video requests)
+func (s *youTubeService) fetchFlatPlaylist(ctx context.Context, channelID string, opts FetchOptions) ([]ytDlpVideoInfo, error) {
+	entries, _, err := s.fetchFlatPlaylistRange(ctx, channelID, opts, 1, opts.Limit)
+	return entries, err
+}
+
+// fetchFlatPlaylistRange lists the channel's videos from playlist position start (1-based) to end
+// (0 lists to the end of the channel). It also returns the number of entries yt-dlp listed
+// before the PublishedAfter filter, which tells callers paging through a channel when it ends.
+func (s *youTubeService) fetchFlatPlaylistRange(ctx context.Context, channelID string, opts FetchOptions, start, end int) ([]ytDlpVideoInfo, int, error) {
 	// Input validation
 	if channelID == "" {
-		return nil, errors.New(errors.CodeInvalidArg, "channel ID is required")
+		return nil, 0, errors.New(errors.CodeInvalidArg, "channel ID is required")
 	}
 
 	// Validate channel ID format (must start with UC)
 	if !strings.HasPrefix(channelID, "UC") {
-		return nil, errors.New(errors.CodeInvalidArg, "invalid channel ID format (must start with UC)")
+		return nil, 0, errors.New(errors.CodeInvalidArg, "invalid channel ID format (must start with UC)")
 	}
 
 	// Build yt-dlp command arguments with channel ID
@@ -27,75 +70,146 @@ func (s *youTubeService) FetchChannelVideos(ctx context.Context, channelID strin
 	args := []string{
 		"--dump-json",
 		"--flat-playlist",
-		channelURL,
+	}
+	if start > 1 {
+		args = append(args, "--playlist-start", fmt.Sprintf("%d", start))
+	}
+
+	// Add the end of the range if specified (0 means no limit - fetch all videos)
+	if end > 0 {
+		args = append(args, "--playlist-end", fmt.Sprintf("%d", end))
+		// Without it, yt-dlp fetches every playlist page before applying the limit
+		if s.detector.Supports(ctx, ytdlp.CapLazyPlaylist) {
+			args = append(args, "--lazy-playlist")
+		}
 	}
 
-	// Add limit if specified (0 means no limit - fetch all videos)
-	if limit > 0 {
-		// Insert limit arguments after --dump-json and --flat-playlist
-		limitArgs := []string{"--playlist-end", fmt.Sprintf("%d", limit)}
-		args = append(args[:2], append(limitArgs, args[2:]...)...)
+	// Flat entries have no upload date unless approximate dates are requested from the channel tab
+	if !opts.PublishedAfter.IsZero() {
+		args = append(args,
+			"--dateafter", opts.PublishedAfter.Format("20060102"),
+			"--extractor-args", "youtubetab:approximate_date",
+		)
 	}
+	args = append(args, channelURL)
 
-	output, err := s.cmdRunner.Run(ctx, "yt-dlp", args...)
+	if err := offline.Check("fetching channel videos with yt-dlp"); err != nil {
+		return nil, 0, err
+	}
+	output, err := s.cmdRunner.Run(ctx, ytdlp.Binary, ytdlp.WithNetworkArgs(args...)...)
 	if err != nil {
-		return nil, errors.Wrap(err, errors.CodeExternal, "failed to fetch channel videos with yt-dlp")
+		return nil, 0, errors.Wrap(err, errors.CodeExternal, "failed to fetch channel videos with yt-dlp")
 	}
 
 	// Parse JSON response (yt-dlp outputs one JSON object per line)
 	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	videos := make([]*model.Video, 0, len(lines))
+	entries := make([]ytDlpVideoInfo, 0, len(lines))
 
+	listed := 0
 	for _, line := range lines {
 		if line == "" {
 			continue
 		}
+		listed++
 
 		var ytInfo ytDlpVideoInfo
 		if err := json.Unmarshal([]byte(line), &ytInfo); err != nil {
-			return nil, errors.Wrap(err, errors.CodeInternal, "failed to parse yt-dlp output")
-		}
-
-		// Use the input channel ID (we know it's correct)
-		// If yt-dlp returns a different channel_id, we trust our input more
-		videoChannelID := channelID
-		if ytInfo.ChannelID != "" && ytInfo.ChannelID != channelID {
-			// Log the discrepancy but use our input channel ID
-			// In a real implementation, you might want to log this
+			return nil, 0, errors.Wrap(err, errors.CodeInternal, "failed to parse yt-dlp output")
 		}
-
-		// Convert to our model
-		video := &model.Video{
-			ID:        ytInfo.ID,
-			ChannelID: videoChannelID,
-			Title:     ytInfo.Title,
-			URL:       ytInfo.URL,
-			Duration:  ytInfo.Duration,
+		// yt-dlp versions without approximate dates ignore --dateafter for flat entries
+		if date := ytInfo.uploadDate(); date != nil && date.Before(opts.PublishedAfter) {
+			continue
 		}
-		videos = append(videos, video)
+		entries = append(entries, ytInfo)
 	}
 
-	return videos, nil
+	return entries, listed, nil
 }
 
-// SaveChannelVideos fetches channel videos from YouTube channel ID and saves them to database
-func (s *youTubeService) SaveChannelVideos(ctx context.Context, channelID string, limit int) ([]*model.Video, error) {
+// SaveChannelVideos fetches channel videos from YouTube channel ID and saves them to database.
+// Without a limit the channel is fetched in pages of opts.PageSize videos, each saved before the
+// next is fetched, so an interrupted fetch of a large channel keeps the pages it got.
+func (s *youTubeService) SaveChannelVideos(ctx context.Context, channelID string, opts FetchOptions) ([]*model.Video, error) {
 	// Note: We assume the channel already exists in database with this channel ID
 	// In a complete implementation, you might want to verify this first
+	if opts.Limit > 0 {
+		videos, err := s.FetchChannelVideos(ctx, channelID, opts)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.saveVideoPage(ctx, videos, opts); err != nil {
+			return nil, err
+		}
+		if opts.OnPage != nil {
+			opts.OnPage(SavePage{Number: 1, Fetched: len(videos), Saved: len(videos)})
+		}
+		return videos, nil
+	}
 
-	// Fetch videos from the channel
-	videos, err := s.FetchChannelVideos(ctx, channelID, limit)
-	if err != nil {
-		return nil, err
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultSavePageSize
+	}
+
+	var videos []*model.Video
+	for page := 1; ; page++ {
+		start := (page-1)*pageSize + 1
+		entries, listed, err := s.fetchFlatPlaylistRange(ctx, channelID, opts, start, start+pageSize-1)
+		if err != nil {
+			if len(videos) > 0 {
+				return nil, errors.Wrap(err, errors.CodeExternal, fmt.Sprintf("failed to fetch page %d (%d video(s) of earlier pages were saved)", page, len(videos)))
+			}
+			return nil, err
+		}
+
+		pageVideos := make([]*model.Video, 0, len(entries))
+		for _, ytInfo := range entries {
+			pageVideos = append(pageVideos, ytInfo.video(channelID))
+		}
+		if err := s.saveVideoPage(ctx, pageVideos, opts); err != nil {
+			return nil, err
+		}
+		videos = append(videos, pageVideos...)
+
+		if opts.OnPage != nil {
+			opts.OnPage(SavePage{Number: page, Fetched: len(pageVideos), Saved: len(videos)})
+		}
+		// Channels list newest first, so a page --dateafter cut short also ends the channel
+		if listed < pageSize {
+			return videos, nil
+		}
 	}
+}
 
-	// Save videos to database using upsert batch (handles duplicates)
-	err = s.videoRepo.UpsertBatch(ctx, videos)
+// saveVideoPage saves fetched videos using upsert batch (handles duplicates), first updating
+// the saved ones that changed on YouTube
+func (s *youTubeService) saveVideoPage(ctx context.Context, videos []*model.Video, opts FetchOptions) error {
+	listed := make([]*model.Video, 0, len(videos))
+	for _, video := range videos {
+		// Removed videos are listed under a placeholder title
+		if unavailableTitles[video.Title] {
+			continue
+		}
+		// Dates of --dateafter listings are approximate
+		if !opts.PublishedAfter.IsZero() {
+			undated := *video
+			undated.UploadDate = nil
+			video = &undated
+		}
+		listed = append(listed, video)
+	}
+	changes, err := s.videoRepo.DetectChanges(ctx, listed)
 	if err != nil {
-		return nil, errors.Wrap(err, errors.CodeInternal, "failed to save videos to database")
+		return errors.Wrap(err, errors.CodeInternal, "failed to detect changed videos")
+	}
+	if len(changes) > 0 && opts.OnChanged != nil {
+		opts.OnChanged(changes)
 	}
 
-	return videos, nil
+	if err := s.videoRepo.UpsertBatch(ctx, videos); err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to save videos to database")
+	}
+	return nil
 }
 
 // ListVideos retrieves videos for a specific channel with pagination
@@ -121,3 +235,40 @@ func (s *youTubeService) ListVideos(ctx context.Context, channelID string, limit
 
 	return videos, nil
 }
+
+// CountVideosByChannel returns the number of stored videos of a channel
+func (s *youTubeService) CountVideosByChannel(ctx context.Context, channelID string) (int, error) {
+	if channelID == "" {
+		return 0, errors.New(errors.CodeInvalidArg, "channel ID is required")
+	}
+
+	count, err := s.videoRepo.CountByChannelID(ctx, channelID)
+	if err != nil {
+		return 0, errors.Wrap(err, errors.CodeInternal, "failed to count videos")
+	}
+
+	return count, nil
+}
+
+// IterateVideos calls fn for every stored video of a channel, loading listPageSize videos per
+// repository call so whole channels can be streamed without holding them in memory
+func (s *youTubeService) IterateVideos(ctx context.Context, channelID string, fn func(video *model.Video) error) error {
+	if channelID == "" {
+		return errors.New(errors.CodeInvalidArg, "channel ID is required")
+	}
+
+	for offset := 0; ; offset += listPageSize {
+		videos, err := s.videoRepo.GetByChannelID(ctx, channelID, listPageSize, offset)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeInternal, "failed to list videos")
+		}
+		for _, video := range videos {
+			if err := fn(video); err != nil {
+				return err
+			}
+		}
+		if len(videos) < listPageSize {
+			return nil
+		}
+	}
+}
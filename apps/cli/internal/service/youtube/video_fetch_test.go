@@ -2,13 +2,16 @@ package youtube
 
 import (
 	"context"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/Taichi-iskw/yt-lang/internal/model"
+	"github.com/Taichi-iskw/yt-lang/internal/ytdlp"
 )
 
 func TestYouTubeService_FetchChannelVideos(t *testing.T) {
@@ -174,7 +177,7 @@ func TestYouTubeService_FetchChannelVideos(t *testing.T) {
 			tt.mockSetup(mockRunner)
 
 			service := NewYouTubeServiceWithCmdRunner(mockRunner)
-			videos, err := service.FetchChannelVideos(ctx, tt.channelID, tt.limit)
+			videos, err := service.FetchChannelVideos(ctx, tt.channelID, FetchOptions{Limit: tt.limit})
 
 			if tt.wantError {
 				require.Error(t, err)
@@ -199,3 +202,92 @@ func TestYouTubeService_FetchChannelVideos(t *testing.T) {
 		})
 	}
 }
+
+func TestYouTubeService_FetchChannelVideos_AdaptsToYtDlpVersion(t *testing.T) {
+	channelURL := "https://www.youtube.com/channel/UC123456789abcdef"
+	flatEntry := `{"id": "video1", "title": "Test Video", "url": "https://www.youtube.com/watch?v=video1", "duration": 60.0}`
+
+	tests := []struct {
+		name         string
+		version      string
+		expectedArgs []string
+	}{
+		{
+			name:         "lazy playlist on current yt-dlp",
+			version:      "2024.08.06",
+			expectedArgs: []string{"--dump-json", "--flat-playlist", "--playlist-end", "5", "--lazy-playlist", channelURL},
+		},
+		{
+			name:         "baseline arguments on old yt-dlp",
+			version:      "2022.06.29",
+			expectedArgs: []string{"--dump-json", "--flat-playlist", "--playlist-end", "5", channelURL},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRunner := new(mockCmdRunner)
+			mockRunner.On("Run", mock.Anything, "yt-dlp", []string{"--version"}).Return([]byte(tt.version+"\n"), nil).Once()
+			mockRunner.On("Run", mock.Anything, "yt-dlp", tt.expectedArgs).Return([]byte(flatEntry), nil)
+
+			detector := ytdlp.NewDetectorWithWarnings(mockRunner, io.Discard)
+			service := NewYouTubeServiceWithDetector(mockRunner, nil, nil, detector)
+
+			videos, err := service.FetchChannelVideos(context.Background(), "UC123456789abcdef", FetchOptions{Limit: 5})
+			require.NoError(t, err)
+			require.Len(t, videos, 1)
+			// Flat entries without webpage_url fall back to url
+			assert.Equal(t, "https://www.youtube.com/watch?v=video1", videos[0].URL)
+
+			mockRunner.AssertExpectations(t)
+		})
+	}
+}
+
+func TestYouTubeService_FetchChannelVideos_PassesNetworkOptions(t *testing.T) {
+	ytdlp.SetNetworkOptions(ytdlp.NetworkOptions{Proxy: "http://proxy.corp.example:8080", ForceIPv4: true})
+	defer ytdlp.SetNetworkOptions(ytdlp.NetworkOptions{})
+
+	channelURL := "https://www.youtube.com/channel/UC123456789abcdef"
+	expectedArgs := []string{"--proxy", "http://proxy.corp.example:8080", "--force-ipv4", "--dump-json", "--flat-playlist", channelURL}
+
+	mockRunner := new(mockCmdRunner)
+	mockRunner.On("Run", mock.Anything, "yt-dlp", expectedArgs).Return([]byte(`{"id": "video1", "title": "Test Video", "url": "https://www.youtube.com/watch?v=video1"}`), nil)
+
+	service := NewYouTubeServiceWithCmdRunner(mockRunner)
+	videos, err := service.FetchChannelVideos(context.Background(), "UC123456789abcdef", FetchOptions{})
+	require.NoError(t, err)
+	require.Len(t, videos, 1)
+
+	mockRunner.AssertExpectations(t)
+}
+
+func TestYouTubeService_FetchChannelVideos_PublishedAfter(t *testing.T) {
+	channelURL := "https://www.youtube.com/channel/UC123456789abcdef"
+	expectedArgs := []string{"--dump-json", "--flat-playlist", "--playlist-end", "10",
+		"--dateafter", "20240101", "--extractor-args", "youtubetab:approximate_date", channelURL}
+	output := `{"id": "new", "title": "New", "url": "https://www.youtube.com/watch?v=new", "upload_date": "20240310"}
+{"id": "stamped", "title": "Stamped", "url": "https://www.youtube.com/watch?v=stamped", "timestamp": 1706745600}
+{"id": "old", "title": "Old", "url": "https://www.youtube.com/watch?v=old", "upload_date": "20231231"}
+{"id": "undated", "title": "Undated", "url": "https://www.youtube.com/watch?v=undated"}`
+
+	mockRunner := new(mockCmdRunner)
+	mockRunner.On("Run", mock.Anything, "yt-dlp", expectedArgs).Return([]byte(output), nil)
+
+	service := NewYouTubeServiceWithCmdRunner(mockRunner)
+	videos, err := service.FetchChannelVideos(context.Background(), "UC123456789abcdef", FetchOptions{
+		Limit:          10,
+		PublishedAfter: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	// Older videos that slipped through yt-dlp are dropped; undated ones are kept
+	require.Len(t, videos, 3)
+	assert.Equal(t, "new", videos[0].ID)
+	assert.Equal(t, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), *videos[0].UploadDate)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), *videos[1].UploadDate)
+	assert.Equal(t, "undated", videos[2].ID)
+	assert.Nil(t, videos[2].UploadDate)
+
+	mockRunner.AssertExpectations(t)
+}
@@ -0,0 +1,91 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Taichi-iskw/yt-lang/internal/model"
+)
+
+func TestYouTubeService_IterateVideos(t *testing.T) {
+	channelID := "UC123456789abcdef"
+
+	page := func(start, n int) []*model.Video {
+		videos := make([]*model.Video, n)
+		for i := range videos {
+			videos[i] = &model.Video{ID: fmt.Sprintf("video%d", start+i), ChannelID: channelID}
+		}
+		return videos
+	}
+
+	t.Run("loads pages until a short page", func(t *testing.T) {
+		mockVideoRepo := new(mockVideoRepository)
+		mockVideoRepo.On("GetByChannelID", mock.Anything, channelID, listPageSize, 0).Return(page(0, listPageSize), nil)
+		mockVideoRepo.On("GetByChannelID", mock.Anything, channelID, listPageSize, listPageSize).Return(page(listPageSize, 3), nil)
+
+		service := NewYouTubeServiceWithRepositories(new(mockCmdRunner), nil, mockVideoRepo)
+
+		count := 0
+		err := service.IterateVideos(context.Background(), channelID, func(video *model.Video) error {
+			assert.Equal(t, fmt.Sprintf("video%d", count), video.ID)
+			count++
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, listPageSize+3, count)
+		mockVideoRepo.AssertExpectations(t)
+	})
+
+	t.Run("callback error stops iteration", func(t *testing.T) {
+		mockVideoRepo := new(mockVideoRepository)
+		mockVideoRepo.On("GetByChannelID", mock.Anything, channelID, listPageSize, 0).Return(page(0, listPageSize), nil)
+
+		service := NewYouTubeServiceWithRepositories(new(mockCmdRunner), nil, mockVideoRepo)
+
+		count := 0
+		err := service.IterateVideos(context.Background(), channelID, func(video *model.Video) error {
+			count++
+			return assert.AnError
+		})
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockVideoRepo := new(mockVideoRepository)
+		mockVideoRepo.On("GetByChannelID", mock.Anything, channelID, listPageSize, 0).Return([]*model.Video(nil), assert.AnError)
+
+		service := NewYouTubeServiceWithRepositories(new(mockCmdRunner), nil, mockVideoRepo)
+
+		err := service.IterateVideos(context.Background(), channelID, func(*model.Video) error { return nil })
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list videos")
+	})
+
+	t.Run("requires a channel ID", func(t *testing.T) {
+		service := NewYouTubeServiceWithRepositories(new(mockCmdRunner), nil, new(mockVideoRepository))
+		err := service.IterateVideos(context.Background(), "", func(*model.Video) error { return nil })
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "channel ID is required")
+	})
+}
+
+func TestYouTubeService_CountVideosByChannel(t *testing.T) {
+	mockVideoRepo := new(mockVideoRepository)
+	mockVideoRepo.On("CountByChannelID", mock.Anything, "UC123").Return(170, nil)
+
+	service := NewYouTubeServiceWithRepositories(new(mockCmdRunner), nil, mockVideoRepo)
+
+	count, err := service.CountVideosByChannel(context.Background(), "UC123")
+	require.NoError(t, err)
+	assert.Equal(t, 170, count)
+
+	_, err = service.CountVideosByChannel(context.Background(), "")
+	require.Error(t, err)
+	mockVideoRepo.AssertExpectations(t)
+}
@@ -107,7 +107,7 @@ func TestYouTubeService_SaveChannelVideosWithChannelID(t *testing.T) {
 			tt.videoRepoSetup(mockVideoRepo)
 
 			service := NewYouTubeServiceWithRepositories(mockRunner, nil, mockVideoRepo)
-			videos, err := service.SaveChannelVideos(ctx, tt.channelID, tt.limit)
+			videos, err := service.SaveChannelVideos(ctx, tt.channelID, FetchOptions{Limit: tt.limit})
 
 			if tt.wantError {
 				require.Error(t, err)
@@ -134,3 +134,61 @@ func TestYouTubeService_SaveChannelVideosWithChannelID(t *testing.T) {
 		})
 	}
 }
+
+func TestYouTubeService_SaveChannelVideos_Paged(t *testing.T) {
+	channelURL := "https://www.youtube.com/channel/UC123456789abcdef"
+	mockRunner := new(mockCmdRunner)
+	mockRunner.On("Run", mock.Anything, "yt-dlp", []string{"--dump-json", "--flat-playlist", "--playlist-end", "2", channelURL}).
+		Return([]byte(`{"id": "video1", "title": "Video 1"}
+{"id": "video2", "title": "Video 2"}`), nil)
+	mockRunner.On("Run", mock.Anything, "yt-dlp", []string{"--dump-json", "--flat-playlist", "--playlist-start", "3", "--playlist-end", "4", channelURL}).
+		Return([]byte(`{"id": "video3", "title": "Video 3"}`), nil)
+
+	// Each page is saved before the next is fetched
+	mockVideoRepo := new(mockVideoRepository)
+	mockVideoRepo.On("UpsertBatch", mock.Anything, mock.MatchedBy(func(videos []*model.Video) bool { return len(videos) == 2 })).Return(nil).Once()
+	mockVideoRepo.On("UpsertBatch", mock.Anything, mock.MatchedBy(func(videos []*model.Video) bool { return len(videos) == 1 })).Return(nil).Once()
+
+	var pages []SavePage
+	service := NewYouTubeServiceWithRepositories(mockRunner, nil, mockVideoRepo)
+	videos, err := service.SaveChannelVideos(context.Background(), "UC123456789abcdef", FetchOptions{
+		PageSize: 2,
+		OnPage:   func(page SavePage) { pages = append(pages, page) },
+	})
+	require.NoError(t, err)
+	require.Len(t, videos, 3)
+	assert.Equal(t, "UC123456789abcdef", videos[2].ChannelID)
+	assert.Equal(t, []SavePage{{Number: 1, Fetched: 2, Saved: 2}, {Number: 2, Fetched: 1, Saved: 3}}, pages)
+
+	mockRunner.AssertExpectations(t)
+	mockVideoRepo.AssertExpectations(t)
+}
+
+func TestYouTubeService_SaveChannelVideos_DetectsChanges(t *testing.T) {
+	channelURL := "https://www.youtube.com/channel/UC123456789abcdef"
+	mockRunner := new(mockCmdRunner)
+	mockRunner.On("Run", mock.Anything, "yt-dlp", []string{"--dump-json", "--flat-playlist", "--playlist-end", "2", channelURL}).
+		Return([]byte(`{"id": "video1", "title": "Video 1 (edited)", "duration": 280.0}
+{"id": "video2", "title": "[Private video]"}`), nil)
+
+	// Placeholder titles of removed videos are not compared
+	change := &model.VideoChange{VideoID: "video1", Title: "Video 1 (edited)", Fields: []string{"title", "duration"}}
+	mockVideoRepo := new(mockVideoRepository)
+	mockVideoRepo.On("DetectChanges", mock.Anything, mock.MatchedBy(func(videos []*model.Video) bool {
+		return len(videos) == 1 && videos[0].ID == "video1"
+	})).Return([]*model.VideoChange{change}, nil)
+	mockVideoRepo.On("UpsertBatch", mock.Anything, mock.AnythingOfType("[]*model.Video")).Return(nil)
+
+	var changed []*model.VideoChange
+	service := NewYouTubeServiceWithRepositories(mockRunner, nil, mockVideoRepo)
+	videos, err := service.SaveChannelVideos(context.Background(), "UC123456789abcdef", FetchOptions{
+		Limit:     2,
+		OnChanged: func(changes []*model.VideoChange) { changed = append(changed, changes...) },
+	})
+	require.NoError(t, err)
+	assert.Len(t, videos, 2)
+	assert.Equal(t, []*model.VideoChange{change}, changed)
+
+	mockRunner.AssertExpectations(t)
+	mockVideoRepo.AssertExpectations(t)
+}
@@ -0,0 +1,167 @@
+package subtitle
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Processor enforces Rules on a stream of cues. It holds back one cue so that a short cue
+// can be merged with, or extended up to, the one that follows it.
+type Processor struct {
+	rules   Rules
+	pending *Cue
+}
+
+// NewProcessor creates a processor for the given rules
+func NewProcessor(rules Rules) *Processor {
+	return &Processor{rules: rules}
+}
+
+// Push adds the text shown between start and end and returns the cues that are final.
+// Input must be in chronological order.
+func (p *Processor) Push(start, end time.Duration, text string) []Cue {
+	return p.PushVoice(start, end, "", text)
+}
+
+// PushVoice is Push for text said by speaker. Cues of different speakers are never merged.
+func (p *Processor) PushVoice(start, end time.Duration, speaker, text string) []Cue {
+	var out []Cue
+	for _, cue := range SplitCue(start, end, text, p.rules.MaxLineLength, p.rules.MaxLines) {
+		cue.Speaker = speaker
+		for _, piece := range p.splitByDuration(cue) {
+			if done, ok := p.accept(piece); ok {
+				out = append(out, done)
+			}
+		}
+	}
+	return out
+}
+
+// Flush returns the held-back cue, if any
+func (p *Processor) Flush() []Cue {
+	if p.pending == nil {
+		return nil
+	}
+
+	cue := *p.pending
+	p.pending = nil
+	if p.rules.MinDuration > 0 && cue.End-cue.Start < p.rules.MinDuration {
+		cue.End = cue.Start + p.rules.MinDuration
+	}
+	return []Cue{cue}
+}
+
+// Apply runs all cues through a processor for rules and returns the result
+func Apply(rules Rules, cues []Cue) []Cue {
+	p := NewProcessor(rules)
+	var out []Cue
+	for _, cue := range cues {
+		out = append(out, p.PushVoice(cue.Start, cue.End, cue.Speaker, cue.Text())...)
+	}
+	return append(out, p.Flush()...)
+}
+
+// accept queues next and returns the previously pending cue once it can no longer change
+func (p *Processor) accept(next Cue) (Cue, bool) {
+	if p.pending == nil {
+		p.pending = &next
+		return Cue{}, false
+	}
+
+	prev := *p.pending
+	if p.rules.MinDuration > 0 && prev.End-prev.Start < p.rules.MinDuration {
+		if merged, ok := p.merge(prev, next); ok {
+			p.pending = &merged
+			return Cue{}, false
+		}
+		// Extend the short cue into the pause before the next one
+		if extended := minDuration(prev.Start+p.rules.MinDuration, next.Start-p.rules.MinGap); extended > prev.End {
+			prev.End = extended
+		}
+	}
+
+	if p.rules.MinGap > 0 && next.Start-prev.End < p.rules.MinGap {
+		if trimmed := next.Start - p.rules.MinGap; trimmed > prev.Start {
+			prev.End = trimmed
+		}
+	}
+	if prev.End < prev.Start {
+		prev.End = prev.Start
+	}
+
+	p.pending = &next
+	return prev, true
+}
+
+// merge combines two cues if the result still satisfies the line and duration limits
+func (p *Processor) merge(a, b Cue) (Cue, bool) {
+	if a.Speaker != b.Speaker {
+		return Cue{}, false
+	}
+	if p.rules.MaxDuration > 0 && b.End-a.Start > p.rules.MaxDuration {
+		return Cue{}, false
+	}
+
+	lines := WrapLines(strings.Join(append(append([]string{}, a.Lines...), b.Lines...), " "), p.rules.MaxLineLength)
+	if p.rules.MaxLines > 0 && len(lines) > p.rules.MaxLines {
+		return Cue{}, false
+	}
+
+	return Cue{Start: a.Start, End: b.End, Lines: lines, Speaker: a.Speaker}, true
+}
+
+// splitByDuration divides a cue longer than MaxDuration into equal time slices, sharing the
+// words between them by character count. A cue with too few words to divide is shortened instead.
+func (p *Processor) splitByDuration(cue Cue) []Cue {
+	max := p.rules.MaxDuration
+	duration := cue.End - cue.Start
+	if max <= 0 || duration <= max {
+		return []Cue{cue}
+	}
+
+	parts := int((duration + max - 1) / max)
+	words := strings.Fields(cue.Text())
+	if len(words) < parts {
+		cue.End = cue.Start + max
+		return []Cue{cue}
+	}
+
+	total := 0
+	for _, word := range words {
+		total += utf8.RuneCountInString(word)
+	}
+
+	cues := make([]Cue, 0, parts)
+	consumed := 0
+	from := 0
+	for part := 1; part <= parts; part++ {
+		// Take words up to this slice's share, leaving at least one word per remaining slice
+		to := from
+		target := total * part / parts
+		for to < len(words) && (consumed < target || to == from) && len(words)-to > parts-part {
+			consumed += utf8.RuneCountInString(words[to])
+			to++
+		}
+		if part == parts {
+			to = len(words)
+		}
+
+		cues = append(cues, Cue{
+			Start:   cue.Start + duration*time.Duration(part-1)/time.Duration(parts),
+			End:     cue.Start + duration*time.Duration(part)/time.Duration(parts),
+			Lines:   WrapLines(strings.Join(words[from:to], " "), p.rules.MaxLineLength),
+			Speaker: cue.Speaker,
+		})
+		from = to
+	}
+
+	return cues
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,185 @@
+package subtitle
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cue(start, end time.Duration, text string) Cue {
+	return Cue{Start: start, End: end, Lines: []string{text}}
+}
+
+func TestApply(t *testing.T) {
+	netflix, err := ResolveRules("netflix", nil)
+	require.NoError(t, err)
+
+	t.Run("merges short cues that fit together", func(t *testing.T) {
+		out := Apply(netflix, []Cue{
+			cue(0, 300*time.Millisecond, "Hi"),
+			cue(300*time.Millisecond, 2*time.Second, "there"),
+		})
+
+		require.Len(t, out, 1)
+		assert.Equal(t, time.Duration(0), out[0].Start)
+		assert.Equal(t, 2*time.Second, out[0].End)
+		assert.Equal(t, "Hi there", out[0].Text())
+	})
+
+	t.Run("extends short cue that cannot merge", func(t *testing.T) {
+		long := strings.Repeat("x", 40) + " " + strings.Repeat("y", 40)
+		out := Apply(netflix, []Cue{
+			cue(0, 200*time.Millisecond, "Short"),
+			cue(3*time.Second, 5*time.Second, long),
+		})
+
+		require.Len(t, out, 2)
+		assert.Equal(t, 833*time.Millisecond, out[0].End)
+		assert.Equal(t, 3*time.Second, out[1].Start)
+	})
+
+	t.Run("extension respects the gap before the next cue", func(t *testing.T) {
+		long := strings.Repeat("x", 40) + " " + strings.Repeat("y", 40)
+		out := Apply(netflix, []Cue{
+			cue(0, 200*time.Millisecond, "Short"),
+			cue(500*time.Millisecond, 3*time.Second, long),
+		})
+
+		require.Len(t, out, 2)
+		assert.Equal(t, 417*time.Millisecond, out[0].End)
+	})
+
+	t.Run("splits cues longer than max duration", func(t *testing.T) {
+		out := Apply(netflix, []Cue{
+			cue(0, 20*time.Second, "one two three four five six"),
+		})
+
+		require.Len(t, out, 3)
+		assert.Equal(t, time.Duration(0), out[0].Start)
+		assert.Equal(t, 20*time.Second, out[2].End)
+		for i, c := range out {
+			assert.LessOrEqual(t, c.End-c.Start, 7*time.Second+netflix.MinGap)
+			if i > 0 {
+				assert.GreaterOrEqual(t, c.Start-out[i-1].End, netflix.MinGap)
+			}
+		}
+		assert.Equal(t, "one two three four five six", strings.Join([]string{out[0].Text(), out[1].Text(), out[2].Text()}, " "))
+	})
+
+	t.Run("enforces line limits", func(t *testing.T) {
+		out := Apply(netflix, []Cue{
+			cue(0, 6*time.Second, strings.Repeat("subtitle ", 30)),
+		})
+
+		require.NotEmpty(t, out)
+		for _, c := range out {
+			assert.LessOrEqual(t, len(c.Lines), netflix.MaxLines)
+			for _, line := range c.Lines {
+				assert.LessOrEqual(t, utf8.RuneCountInString(line), netflix.MaxLineLength)
+			}
+		}
+	})
+
+	t.Run("trims overlap to keep minimum gap", func(t *testing.T) {
+		out := Apply(netflix, []Cue{
+			cue(0, 2*time.Second, "First line of dialogue"),
+			cue(2*time.Second, 4*time.Second, "Second line of dialogue"),
+		})
+
+		require.Len(t, out, 2)
+		assert.Equal(t, 2*time.Second-83*time.Millisecond, out[0].End)
+	})
+
+	t.Run("last short cue is extended on flush", func(t *testing.T) {
+		out := Apply(netflix, []Cue{cue(time.Second, 1100*time.Millisecond, "Bye")})
+
+		require.Len(t, out, 1)
+		assert.Equal(t, time.Second+833*time.Millisecond, out[0].End)
+	})
+
+	t.Run("default style only wraps", func(t *testing.T) {
+		out := Apply(DefaultRules(), []Cue{
+			cue(0, 100*time.Millisecond, "a"),
+			cue(100*time.Millisecond, 200*time.Millisecond, "b"),
+		})
+
+		require.Len(t, out, 2)
+		assert.Equal(t, 100*time.Millisecond, out[0].End)
+	})
+}
+
+func TestResolveRules(t *testing.T) {
+	custom := map[string]Rules{
+		"tight":   {MaxLineLength: 16, MaxLines: 1},
+		"netflix": {MaxLineLength: 37, MaxLines: 2},
+	}
+
+	tests := []struct {
+		name     string
+		style    string
+		custom   map[string]Rules
+		expected Rules
+		wantErr  bool
+	}{
+		{name: "empty selects default", style: "", expected: DefaultRules()},
+		{name: "built-in preset", style: "Netflix", expected: presets["netflix"]},
+		{name: "custom style", style: "tight", custom: custom, expected: custom["tight"]},
+		{name: "custom overrides preset", style: "netflix", custom: custom, expected: custom["netflix"]},
+		{name: "unknown style", style: "bogus", custom: custom, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ResolveRules(tt.style, tt.custom)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "default, netflix, tight")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rules)
+		})
+	}
+}
+
+func TestProcessor_PushVoice(t *testing.T) {
+	netflix, err := ResolveRules("netflix", nil)
+	require.NoError(t, err)
+
+	t.Run("short cues of different speakers are not merged", func(t *testing.T) {
+		p := NewProcessor(netflix)
+		out := p.PushVoice(0, 300*time.Millisecond, "A", "Hi")
+		out = append(out, p.PushVoice(300*time.Millisecond, 2*time.Second, "B", "there")...)
+		out = append(out, p.Flush()...)
+
+		require.Len(t, out, 2)
+		assert.Equal(t, "A", out[0].Speaker)
+		assert.Equal(t, "B", out[1].Speaker)
+	})
+
+	t.Run("short cues of one speaker are merged", func(t *testing.T) {
+		out := Apply(netflix, []Cue{
+			{Start: 0, End: 300 * time.Millisecond, Lines: []string{"Hi"}, Speaker: "A"},
+			{Start: 300 * time.Millisecond, End: 2 * time.Second, Lines: []string{"there"}, Speaker: "A"},
+		})
+
+		require.Len(t, out, 1)
+		assert.Equal(t, "Hi there", out[0].Text())
+		assert.Equal(t, "A", out[0].Speaker)
+	})
+
+	t.Run("split cues keep the speaker", func(t *testing.T) {
+		p := NewProcessor(netflix)
+		out := p.PushVoice(0, 20*time.Second, "A", "one two three four five six")
+		out = append(out, p.Flush()...)
+
+		require.Greater(t, len(out), 1)
+		for _, cue := range out {
+			assert.Equal(t, "A", cue.Speaker)
+		}
+	})
+}
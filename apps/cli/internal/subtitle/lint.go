@@ -0,0 +1,167 @@
+package subtitle
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
+)
+
+const (
+	// DefaultMaxCharsPerSecond is the common reading speed limit for adult viewers
+	DefaultMaxCharsPerSecond = 20.0
+	// DefaultMaxCueDuration is the longest a cue stays on screen when the style sets no maximum
+	DefaultMaxCueDuration = 7 * time.Second
+)
+
+// Lint issue codes
+const (
+	IssueSyntax       = "syntax"        // The file cannot be parsed
+	IssueEncoding     = "encoding"      // Invalid UTF-8, UTF-16, replacement characters or mojibake
+	IssueOrder        = "order"         // A cue starts before the previous cue
+	IssueOverlap      = "overlap"       // A cue starts before the previous cue ends
+	IssueDuration     = "duration"      // A cue is empty in time or stays on screen too long
+	IssueReadingSpeed = "reading_speed" // A cue has more characters per second than viewers can read
+	IssueLineLength   = "line_length"   // A cue line has too many characters
+	IssueLineCount    = "line_count"    // A cue has too many lines
+)
+
+// LintOptions are the limits Lint checks cues against. Zero values disable a check.
+type LintOptions struct {
+	MaxDuration       time.Duration
+	MaxCharsPerSecond float64
+	MaxLineLength     int
+	MaxLines          int
+}
+
+// LintOptionsFor returns lint limits from a subtitle style: its line limits and maximum duration
+// (DefaultMaxCueDuration when it sets none), and the default reading speed
+func LintOptionsFor(rules Rules) LintOptions {
+	opts := LintOptions{
+		MaxDuration:       rules.MaxDuration,
+		MaxCharsPerSecond: DefaultMaxCharsPerSecond,
+		MaxLineLength:     rules.MaxLineLength,
+		MaxLines:          rules.MaxLines,
+	}
+	if opts.MaxDuration == 0 {
+		opts.MaxDuration = DefaultMaxCueDuration
+	}
+	return opts
+}
+
+// Issue is a problem found by Lint
+type Issue struct {
+	Code    string `json:"code"`
+	Cue     int    `json:"cue,omitempty"`  // 1-based cue number; 0 for problems of the file
+	Line    int    `json:"line,omitempty"` // 1-based line of the file, for encoding problems
+	Time    string `json:"time,omitempty"` // Start time of the cue (HH:MM:SS,mmm)
+	Message string `json:"message"`
+}
+
+// LintReport lists the problems of a subtitle file or cue list
+type LintReport struct {
+	Cues   int     `json:"cues"`
+	Issues []Issue `json:"issues"`
+}
+
+// OK reports whether no problems were found
+func (r *LintReport) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// mojibakeMarkers are the sequences left when UTF-8 text is decoded as Latin-1 or Windows-1252
+// and encoded again, e.g. "Ã©" for "é" and "â€™" for "’"
+var mojibakeMarkers = []string{"Ã¡", "Ã©", "Ã­", "Ã³", "Ãº", "Ã±", "Ã¼", "Ã§", "â€", "Â¿", "Â¡"}
+
+// Lint checks an SRT or WebVTT file for encoding problems and, when it parses, its cues with LintCues
+func Lint(data []byte, opts LintOptions) *LintReport {
+	if bytes.HasPrefix(data, []byte{0xFF, 0xFE}) || bytes.HasPrefix(data, []byte{0xFE, 0xFF}) {
+		return &LintReport{Issues: []Issue{{Code: IssueEncoding, Message: "file is UTF-16 encoded; convert it to UTF-8"}}}
+	}
+
+	issues := encodingIssues(data)
+	cues, err := Parse(bytes.NewReader(data))
+	if err != nil {
+		return &LintReport{Issues: append(issues, Issue{Code: IssueSyntax, Message: err.Error()})}
+	}
+
+	report := LintCues(cues, opts)
+	report.Issues = append(issues, report.Issues...)
+	return report
+}
+
+// LintCues checks cues in order for ordering, overlaps and the limits of opts
+func LintCues(cues []Cue, opts LintOptions) *LintReport {
+	report := &LintReport{Cues: len(cues), Issues: []Issue{}}
+	add := func(i int, code, message string) {
+		report.Issues = append(report.Issues, Issue{Code: code, Cue: i + 1, Time: timecode.FormatSRT(cues[i].Start), Message: message})
+	}
+
+	for i, cue := range cues {
+		if i > 0 {
+			previous := cues[i-1]
+			switch {
+			case cue.Start < previous.Start:
+				add(i, IssueOrder, fmt.Sprintf("starts before the previous cue (%s)", timecode.FormatSRT(previous.Start)))
+			case cue.Start < previous.End:
+				add(i, IssueOverlap, fmt.Sprintf("starts %s before the previous cue ends (%s)", (previous.End-cue.Start).Round(time.Millisecond), timecode.FormatSRT(previous.End)))
+			}
+		}
+
+		duration := cue.End - cue.Start
+		chars := utf8.RuneCountInString(strings.Join(cue.Lines, " "))
+		switch {
+		case duration <= 0:
+			add(i, IssueDuration, "has no duration")
+		case opts.MaxDuration > 0 && duration > opts.MaxDuration:
+			add(i, IssueDuration, fmt.Sprintf("lasts %s (maximum %s)", duration.Round(time.Millisecond), opts.MaxDuration))
+		}
+		if duration > 0 && opts.MaxCharsPerSecond > 0 {
+			if cps := float64(chars) / duration.Seconds(); cps > opts.MaxCharsPerSecond {
+				add(i, IssueReadingSpeed, fmt.Sprintf("%.1f characters per second (maximum %g)", cps, opts.MaxCharsPerSecond))
+			}
+		}
+
+		if opts.MaxLines > 0 && len(cue.Lines) > opts.MaxLines {
+			add(i, IssueLineCount, fmt.Sprintf("%d lines (maximum %d)", len(cue.Lines), opts.MaxLines))
+		}
+		if opts.MaxLineLength > 0 {
+			for n, line := range cue.Lines {
+				if length := utf8.RuneCountInString(line); length > opts.MaxLineLength {
+					add(i, IssueLineLength, fmt.Sprintf("line %d has %d characters (maximum %d)", n+1, length, opts.MaxLineLength))
+				}
+			}
+		}
+	}
+	return report
+}
+
+// encodingIssues reports the lines of data that are not valid UTF-8, hold replacement
+// characters from a lossy conversion, or look like mojibake
+func encodingIssues(data []byte) []Issue {
+	var issues []Issue
+	for i, line := range strings.Split(string(data), "\n") {
+		switch {
+		case !utf8.ValidString(line):
+			issues = append(issues, Issue{Code: IssueEncoding, Line: i + 1, Message: "invalid UTF-8; the file may use a legacy encoding such as Windows-1252 or Shift_JIS"})
+		case strings.ContainsRune(line, utf8.RuneError):
+			issues = append(issues, Issue{Code: IssueEncoding, Line: i + 1, Message: "replacement character (U+FFFD); text was lost in an earlier conversion"})
+		case containsMojibake(line):
+			issues = append(issues, Issue{Code: IssueEncoding, Line: i + 1, Message: "looks like mojibake (UTF-8 decoded as Latin-1)"})
+		}
+	}
+	return issues
+}
+
+// containsMojibake reports whether line holds a typical mojibake sequence
+func containsMojibake(line string) bool {
+	for _, marker := range mojibakeMarkers {
+		if strings.Contains(line, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package subtitle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func issueCodes(report *LintReport) []string {
+	codes := make([]string, len(report.Issues))
+	for i, issue := range report.Issues {
+		codes[i] = issue.Code
+	}
+	return codes
+}
+
+func TestLintCues(t *testing.T) {
+	opts := LintOptionsFor(presets["netflix"])
+
+	t.Run("clean", func(t *testing.T) {
+		report := LintCues([]Cue{
+			cue(0, 2*time.Second, "Hola a todos"),
+			cue(2*time.Second, 4*time.Second, "Bienvenidos"),
+		}, opts)
+		assert.True(t, report.OK())
+		assert.Equal(t, 2, report.Cues)
+	})
+
+	t.Run("problems", func(t *testing.T) {
+		report := LintCues([]Cue{
+			cue(0, 3*time.Second, "Hola"),
+			cue(2500*time.Millisecond, 4*time.Second, "Overlapping"),
+			cue(1*time.Second, 2*time.Second, "Out of order"),
+			cue(5*time.Second, 15*time.Second, "Too long"),
+			cue(16*time.Second, 17*time.Second, "This cue has far too many characters to read in one second"),
+			cue(18*time.Second, 18*time.Second, "Instant"),
+			{Start: 20 * time.Second, End: 24 * time.Second, Lines: []string{"one", "two", "three"}},
+		}, opts)
+
+		assert.Equal(t, []string{IssueOverlap, IssueOrder, IssueDuration, IssueReadingSpeed, IssueLineLength, IssueDuration, IssueLineCount}, issueCodes(report))
+		assert.Equal(t, Issue{Code: IssueOverlap, Cue: 2, Time: "00:00:02,500", Message: "starts 500ms before the previous cue ends (00:00:03,000)"}, report.Issues[0])
+		assert.Equal(t, "lasts 10s (maximum 7s)", report.Issues[2].Message)
+		assert.Equal(t, "58.0 characters per second (maximum 20)", report.Issues[3].Message)
+	})
+
+	t.Run("zero limits disable checks", func(t *testing.T) {
+		report := LintCues([]Cue{cue(0, time.Minute, "A long and wordy cue that nobody limits")}, LintOptions{})
+		assert.True(t, report.OK())
+	})
+}
+
+func TestLint(t *testing.T) {
+	opts := LintOptionsFor(DefaultRules())
+
+	t.Run("valid file", func(t *testing.T) {
+		report := Lint([]byte("1\n00:00:01,000 --> 00:00:03,000\n¿Qué tal?\n"), opts)
+		assert.True(t, report.OK())
+		assert.Equal(t, 1, report.Cues)
+	})
+
+	t.Run("encoding problems", func(t *testing.T) {
+		data := []byte("1\n00:00:01,000 --> 00:00:03,000\nCaf\xe9\n\n2\n00:00:04,000 --> 00:00:06,000\nÂ¿QuÃ© tal?\n")
+		report := Lint(data, opts)
+		require.Len(t, report.Issues, 2)
+		assert.Equal(t, Issue{Code: IssueEncoding, Line: 3, Message: report.Issues[0].Message}, report.Issues[0])
+		assert.Contains(t, report.Issues[1].Message, "mojibake")
+		assert.Equal(t, 7, report.Issues[1].Line)
+		assert.Equal(t, 2, report.Cues)
+	})
+
+	t.Run("UTF-16", func(t *testing.T) {
+		report := Lint([]byte{0xFF, 0xFE, '1', 0}, opts)
+		assert.Equal(t, []string{IssueEncoding}, issueCodes(report))
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		report := Lint([]byte("1\n00:00:05,000 --> 00:00:03,000\nBackwards\n"), opts)
+		assert.Equal(t, []string{IssueSyntax}, issueCodes(report))
+		assert.False(t, report.OK())
+	})
+}
@@ -0,0 +1,134 @@
+package subtitle
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
+)
+
+// markupPattern matches the inline tags of SRT (<i>, <font ...>) and WebVTT (<v Speaker>, <c.x>, <00:01.000>)
+var markupPattern = regexp.MustCompile(`<[^>]*>`)
+
+// Parse reads an SRT or WebVTT file (detected by the WEBVTT signature) and returns its cues
+// in file order. Markup is stripped from the cue text, and cues left without text are skipped.
+func Parse(r io.Reader) ([]Cue, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read subtitles: %w", err)
+	}
+
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	blocks := splitBlocks(text)
+	vtt := len(blocks) > 0 && isVTTSignature(blocks[0][0])
+	if vtt {
+		blocks = blocks[1:]
+	}
+
+	var cues []Cue
+	for i, block := range blocks {
+		if vtt && isVTTMetadata(block[0]) {
+			continue
+		}
+
+		// The timing line follows an optional sequence number (SRT) or identifier (WebVTT)
+		timing := 0
+		if !strings.Contains(block[0], "-->") {
+			timing = 1
+		}
+		if timing >= len(block) || !strings.Contains(block[timing], "-->") {
+			return nil, fmt.Errorf("cue %d: missing timing line", i+1)
+		}
+
+		start, end, err := parseTiming(block[timing])
+		if err != nil {
+			return nil, fmt.Errorf("cue %d: %w", i+1, err)
+		}
+
+		var lines []string
+		for _, line := range block[timing+1:] {
+			line = strings.TrimSpace(html.UnescapeString(markupPattern.ReplaceAllString(line, "")))
+			if line != "" {
+				lines = append(lines, line)
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		cues = append(cues, Cue{Start: start, End: end, Lines: lines})
+	}
+	return cues, nil
+}
+
+// splitBlocks splits text into blocks of non-empty lines separated by blank lines
+func splitBlocks(text string) [][]string {
+	var blocks [][]string
+	var current []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(current) > 0 {
+				blocks = append(blocks, current)
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		blocks = append(blocks, current)
+	}
+	return blocks
+}
+
+// isVTTSignature reports whether line is the WebVTT file signature ("WEBVTT", optionally followed by a title)
+func isVTTSignature(line string) bool {
+	return line == "WEBVTT" || strings.HasPrefix(line, "WEBVTT ") || strings.HasPrefix(line, "WEBVTT\t")
+}
+
+// isVTTMetadata reports whether a WebVTT block starting with line is a comment or style/region definition
+func isVTTMetadata(line string) bool {
+	for _, keyword := range []string{"NOTE", "STYLE", "REGION"} {
+		if line == keyword || strings.HasPrefix(line, keyword+" ") || strings.HasPrefix(line, keyword+"\t") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTiming parses "start --> end", ignoring WebVTT cue settings after the end time
+func parseTiming(line string) (start, end time.Duration, err error) {
+	from, to, _ := strings.Cut(line, "-->")
+	fields := strings.Fields(to)
+	if len(fields) == 0 {
+		return 0, 0, fmt.Errorf("missing end time in %q", line)
+	}
+
+	start, err = parseTimestamp(from)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err = parseTimestamp(fields[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	if end < start {
+		return 0, 0, fmt.Errorf("end time %s is before start time %s", strings.TrimSpace(fields[0]), strings.TrimSpace(from))
+	}
+	return start, end, nil
+}
+
+// parseTimestamp parses an SRT (HH:MM:SS,mmm) or WebVTT ([HH:]MM:SS.mmm) timestamp
+func parseTimestamp(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, ":") {
+		return 0, fmt.Errorf("invalid timestamp %q", s)
+	}
+	return timecode.ParseOffset(strings.Replace(s, ",", ".", 1))
+}
@@ -0,0 +1,69 @@
+package subtitle
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("SRT", func(t *testing.T) {
+		input := "\ufeff1\r\n00:00:01,000 --> 00:00:02,500\r\nHello <i>world</i>\r\n\r\n" +
+			"2\r\n00:00:03,000 --> 00:00:05,250\r\nTwo\r\nlines &amp; more\r\n\r\n"
+
+		cues, err := Parse(strings.NewReader(input))
+		require.NoError(t, err)
+		assert.Equal(t, []Cue{
+			{Start: time.Second, End: 2500 * time.Millisecond, Lines: []string{"Hello world"}},
+			{Start: 3 * time.Second, End: 5250 * time.Millisecond, Lines: []string{"Two", "lines & more"}},
+		}, cues)
+	})
+
+	t.Run("WebVTT", func(t *testing.T) {
+		input := "WEBVTT - Lesson 1\nKind: captions\n\n" +
+			"NOTE exported from the editor\n\n" +
+			"STYLE\n::cue { color: yellow }\n\n" +
+			"intro\n00:01.000 --> 00:02.000 align:start position:10%\n<v Teacher>Good morning\n\n" +
+			"01:00:00.000 --> 01:00:01.500\nこんにちは\n"
+
+		cues, err := Parse(strings.NewReader(input))
+		require.NoError(t, err)
+		assert.Equal(t, []Cue{
+			{Start: time.Second, End: 2 * time.Second, Lines: []string{"Good morning"}},
+			{Start: time.Hour, End: time.Hour + 1500*time.Millisecond, Lines: []string{"こんにちは"}},
+		}, cues)
+	})
+
+	t.Run("skips cues without text", func(t *testing.T) {
+		input := "1\n00:00:01,000 --> 00:00:02,000\n<i></i>\n\n2\n00:00:03,000 --> 00:00:04,000\nKept\n"
+
+		cues, err := Parse(strings.NewReader(input))
+		require.NoError(t, err)
+		require.Len(t, cues, 1)
+		assert.Equal(t, "Kept", cues[0].Text())
+	})
+
+	t.Run("end before start", func(t *testing.T) {
+		input := "1\n00:00:05,000 --> 00:00:04,000\nBackwards\n"
+
+		_, err := Parse(strings.NewReader(input))
+		assert.ErrorContains(t, err, "cue 1")
+	})
+
+	t.Run("missing timing line", func(t *testing.T) {
+		input := "1\n00:00:01,000 --> 00:00:02,000\nFine\n\n2\njust text\n"
+
+		_, err := Parse(strings.NewReader(input))
+		assert.ErrorContains(t, err, "cue 2: missing timing line")
+	})
+
+	t.Run("invalid timestamp", func(t *testing.T) {
+		input := "1\n00:00:xx,000 --> 00:00:02,000\nBroken\n"
+
+		_, err := Parse(strings.NewReader(input))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,77 @@
+package subtitle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultStyle is the style used when none is selected
+const DefaultStyle = "default"
+
+// Rules are the cue constraints enforced by the Processor. Zero values disable a rule.
+type Rules struct {
+	MaxLineLength int           `yaml:"max_line_length"` // characters per line
+	MaxLines      int           `yaml:"max_lines"`       // lines per cue
+	MinDuration   time.Duration `yaml:"min_duration"`    // shorter cues are merged or extended
+	MaxDuration   time.Duration `yaml:"max_duration"`    // longer cues are split
+	MinGap        time.Duration `yaml:"min_gap"`         // minimum pause between consecutive cues
+}
+
+// presets are the built-in styles selectable by name
+var presets = map[string]Rules{
+	DefaultStyle: {
+		MaxLineLength: DefaultMaxLineLength,
+		MaxLines:      DefaultMaxLines,
+	},
+	// Netflix Timed Text Style Guide: 42 characters, 2 lines, 5/6 s to 7 s per event,
+	// 2 frames (at 24 fps) between events
+	"netflix": {
+		MaxLineLength: 42,
+		MaxLines:      2,
+		MinDuration:   833 * time.Millisecond,
+		MaxDuration:   7 * time.Second,
+		MinGap:        83 * time.Millisecond,
+	},
+}
+
+// DefaultRules returns the rules of the default style
+func DefaultRules() Rules {
+	return presets[DefaultStyle]
+}
+
+// ResolveRules returns the rules for a style name. Custom styles take precedence over
+// built-in presets of the same name; an empty name selects the default style.
+func ResolveRules(style string, custom map[string]Rules) (Rules, error) {
+	style = strings.ToLower(strings.TrimSpace(style))
+	if style == "" {
+		style = DefaultStyle
+	}
+
+	if rules, ok := custom[style]; ok {
+		return rules, nil
+	}
+	if rules, ok := presets[style]; ok {
+		return rules, nil
+	}
+
+	return Rules{}, fmt.Errorf("unknown subtitle style: %s (available: %s)", style, strings.Join(StyleNames(custom), ", "))
+}
+
+// StyleNames lists built-in and custom style names in sorted order
+func StyleNames(custom map[string]Rules) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for name := range presets {
+		seen[name] = true
+		names = append(names, name)
+	}
+	for name := range custom {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,126 @@
+package subtitle
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultMaxLineLength is the common broadcast limit of characters per subtitle line
+	DefaultMaxLineLength = 42
+	// DefaultMaxLines is the common limit of lines per subtitle cue
+	DefaultMaxLines = 2
+)
+
+// Cue is a single subtitle cue with its display window and wrapped lines
+type Cue struct {
+	Start   time.Duration
+	End     time.Duration
+	Lines   []string
+	Speaker string // Who speaks, when diarization identified it; written as a WebVTT voice tag
+}
+
+// Text returns the cue lines joined by newlines
+func (c Cue) Text() string {
+	return strings.Join(c.Lines, "\n")
+}
+
+// WrapLines wraps text into lines of at most maxChars characters, breaking at spaces where
+// possible. Words longer than maxChars (and text without spaces, e.g. Japanese) are broken
+// at character boundaries.
+func WrapLines(text string, maxChars int) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil
+	}
+	if maxChars <= 0 {
+		return []string{strings.Join(words, " ")}
+	}
+
+	var lines []string
+	var current strings.Builder
+	currentLen := 0
+
+	flush := func() {
+		if currentLen > 0 {
+			lines = append(lines, current.String())
+			current.Reset()
+			currentLen = 0
+		}
+	}
+
+	for _, word := range words {
+		for utf8.RuneCountInString(word) > maxChars {
+			flush()
+			runes := []rune(word)
+			lines = append(lines, string(runes[:maxChars]))
+			word = string(runes[maxChars:])
+		}
+
+		wordLen := utf8.RuneCountInString(word)
+		if currentLen > 0 && currentLen+1+wordLen > maxChars {
+			flush()
+		}
+		if currentLen > 0 {
+			current.WriteByte(' ')
+			currentLen++
+		}
+		current.WriteString(word)
+		currentLen += wordLen
+	}
+	flush()
+
+	return lines
+}
+
+// SplitCue wraps text to maxChars per line and splits it into as many cues of at most
+// maxLines lines as needed. The original [start, end] window is divided between the cues
+// in proportion to their character counts, so the cues stay contiguous.
+func SplitCue(start, end time.Duration, text string, maxChars, maxLines int) []Cue {
+	lines := WrapLines(text, maxChars)
+	if len(lines) == 0 {
+		return nil
+	}
+	if maxLines <= 0 {
+		maxLines = len(lines)
+	}
+
+	var groups [][]string
+	for i := 0; i < len(lines); i += maxLines {
+		j := i + maxLines
+		if j > len(lines) {
+			j = len(lines)
+		}
+		groups = append(groups, lines[i:j])
+	}
+
+	if len(groups) == 1 {
+		return []Cue{{Start: start, End: end, Lines: groups[0]}}
+	}
+
+	total := 0
+	sizes := make([]int, len(groups))
+	for i, group := range groups {
+		for _, line := range group {
+			sizes[i] += utf8.RuneCountInString(line)
+		}
+		total += sizes[i]
+	}
+
+	duration := end - start
+	cues := make([]Cue, 0, len(groups))
+	cursor := start
+	consumed := 0
+	for i, group := range groups {
+		consumed += sizes[i]
+		cueEnd := start + time.Duration(int64(duration)*int64(consumed)/int64(total)).Round(time.Millisecond)
+		if i == len(groups)-1 {
+			cueEnd = end
+		}
+		cues = append(cues, Cue{Start: cursor, End: cueEnd, Lines: group})
+		cursor = cueEnd
+	}
+
+	return cues
+}
@@ -0,0 +1,92 @@
+package subtitle
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		maxChars int
+		expected []string
+	}{
+		{
+			name:     "short text stays on one line",
+			text:     "Hello world",
+			maxChars: 42,
+			expected: []string{"Hello world"},
+		},
+		{
+			name:     "breaks at spaces",
+			text:     "the quick brown fox jumps over the lazy dog",
+			maxChars: 20,
+			expected: []string{"the quick brown fox", "jumps over the lazy", "dog"},
+		},
+		{
+			name:     "normalizes whitespace",
+			text:     "  spaced \n  out  ",
+			maxChars: 42,
+			expected: []string{"spaced out"},
+		},
+		{
+			name:     "text without spaces breaks at characters",
+			text:     "これは字幕の改行テストです",
+			maxChars: 5,
+			expected: []string{"これは字幕", "の改行テス", "トです"},
+		},
+		{
+			name:     "empty text",
+			text:     "   ",
+			maxChars: 42,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, WrapLines(tt.text, tt.maxChars))
+		})
+	}
+}
+
+func TestSplitCue(t *testing.T) {
+	t.Run("fits in one cue", func(t *testing.T) {
+		cues := SplitCue(time.Second, 3*time.Second, "Hello world", DefaultMaxLineLength, DefaultMaxLines)
+		require.Len(t, cues, 1)
+		assert.Equal(t, time.Second, cues[0].Start)
+		assert.Equal(t, 3*time.Second, cues[0].End)
+		assert.Equal(t, "Hello world", cues[0].Text())
+	})
+
+	t.Run("long text splits into contiguous cues", func(t *testing.T) {
+		text := strings.Repeat("word ", 40)
+		start, end := 10*time.Second, 20*time.Second
+
+		cues := SplitCue(start, end, text, DefaultMaxLineLength, DefaultMaxLines)
+		require.Greater(t, len(cues), 1)
+
+		assert.Equal(t, start, cues[0].Start)
+		assert.Equal(t, end, cues[len(cues)-1].End)
+		for i, cue := range cues {
+			assert.LessOrEqual(t, len(cue.Lines), DefaultMaxLines)
+			for _, line := range cue.Lines {
+				assert.LessOrEqual(t, utf8.RuneCountInString(line), DefaultMaxLineLength)
+			}
+			assert.Less(t, cue.Start, cue.End)
+			if i > 0 {
+				assert.Equal(t, cues[i-1].End, cue.Start)
+			}
+		}
+	})
+
+	t.Run("empty text yields no cues", func(t *testing.T) {
+		assert.Empty(t, SplitCue(0, time.Second, "", DefaultMaxLineLength, DefaultMaxLines))
+	})
+}
@@ -0,0 +1,91 @@
+package subtitle
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/timecode"
+)
+
+// vttCueSettings are the WebVTT cue setting names
+var vttCueSettings = map[string]bool{"vertical": true, "line": true, "position": true, "size": true, "align": true, "region": true}
+
+// VTTOptions are WebVTT-only presentation hints: where players place cues and how they style them
+type VTTOptions struct {
+	Settings string `yaml:"settings"` // cue settings of every cue, e.g. "line:85% align:center"
+	Style    string `yaml:"style"`    // CSS of a STYLE block, e.g. "::cue { color: yellow; }"
+}
+
+// Validate checks that the settings are WebVTT cue settings and that the style fits in a block
+func (o VTTOptions) Validate() error {
+	for _, setting := range strings.Fields(o.Settings) {
+		name, value, ok := strings.Cut(setting, ":")
+		if !ok || value == "" || !vttCueSettings[name] {
+			return fmt.Errorf("invalid WebVTT cue setting %q (settings: align, line, position, region, size, vertical)", setting)
+		}
+	}
+	if strings.Contains(o.Style, "-->") {
+		return fmt.Errorf("WebVTT style must not contain \"-->\"")
+	}
+	return nil
+}
+
+// WriteSRTCue writes a cue as an SRT block with the given sequence number
+func WriteSRTCue(w io.Writer, sequence int, cue Cue) error {
+	_, err := fmt.Fprintf(w, "%d\n%s --> %s\n%s\n\n",
+		sequence, timecode.FormatSRT(cue.Start), timecode.FormatSRT(cue.End), cue.Text())
+	return err
+}
+
+// WriteVTTHeader writes the WebVTT file signature
+func WriteVTTHeader(w io.Writer) error {
+	_, err := fmt.Fprint(w, "WEBVTT\n\n")
+	return err
+}
+
+// WriteVTTNote writes a NOTE block, a comment players ignore, with one line per entry
+func WriteVTTNote(w io.Writer, lines ...string) error {
+	_, err := fmt.Fprintf(w, "NOTE\n%s\n\n", strings.Join(lines, "\n"))
+	return err
+}
+
+// WriteVTTStyle writes a STYLE block; it must come before the first cue. Blank lines, which would
+// end the block early, are dropped.
+func WriteVTTStyle(w io.Writer, css string) error {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(css), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	_, err := fmt.Fprintf(w, "STYLE\n%s\n\n", strings.Join(lines, "\n"))
+	return err
+}
+
+// WriteVTTCue writes a cue as a WebVTT block, its text in a voice tag when the speaker is known
+func WriteVTTCue(w io.Writer, cue Cue) error {
+	return WriteVTTCueWithSettings(w, cue, "")
+}
+
+// WriteVTTCueWithSettings writes a cue as a WebVTT block with cue settings after its timing
+func WriteVTTCueWithSettings(w io.Writer, cue Cue, settings string) error {
+	timing := timecode.FormatVTT(cue.Start) + " --> " + timecode.FormatVTT(cue.End)
+	if settings = strings.Join(strings.Fields(settings), " "); settings != "" {
+		timing += " " + settings
+	}
+	text := cue.Text()
+	if cue.Speaker != "" {
+		text = "<v " + escapeVTT(cue.Speaker) + ">" + text
+	}
+	_, err := fmt.Fprintf(w, "%s\n%s\n\n", timing, text)
+	return err
+}
+
+// escapeVTT escapes the characters WebVTT reserves for markup
+func escapeVTT(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,46 @@
+package subtitle
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteVTT(t *testing.T) {
+	var b strings.Builder
+	WriteVTTHeader(&b)
+	WriteVTTNote(&b, "video: jNQXAC9IVRw", "transcription: 3f2b8c1e")
+	WriteVTTStyle(&b, "::cue {\n  color: yellow;\n\n}\n")
+	WriteVTTCueWithSettings(&b, Cue{Start: time.Second, End: 3 * time.Second, Lines: []string{"All right,", "so here we are"}, Speaker: "Jawed <host>"}, " line:85%   align:center ")
+	WriteVTTCue(&b, Cue{Start: 3 * time.Second, End: 4 * time.Second, Lines: []string{"in front of the elephants"}})
+
+	assert.Equal(t, `WEBVTT
+
+NOTE
+video: jNQXAC9IVRw
+transcription: 3f2b8c1e
+
+STYLE
+::cue {
+  color: yellow;
+}
+
+00:00:01.000 --> 00:00:03.000 line:85% align:center
+<v Jawed &lt;host&gt;>All right,
+so here we are
+
+00:00:03.000 --> 00:00:04.000
+in front of the elephants
+
+`, b.String())
+}
+
+func TestVTTOptions_Validate(t *testing.T) {
+	assert.NoError(t, VTTOptions{}.Validate())
+	assert.NoError(t, VTTOptions{Settings: "line:85% position:50% align:center", Style: "::cue { color: white; }"}.Validate())
+	assert.ErrorContains(t, VTTOptions{Settings: "line:85% colour:red"}.Validate(), `invalid WebVTT cue setting "colour:red"`)
+	assert.ErrorContains(t, VTTOptions{Settings: "align"}.Validate(), `invalid WebVTT cue setting "align"`)
+	assert.ErrorContains(t, VTTOptions{Style: "::cue --> {}"}.Validate(), "-->")
+}
@@ -0,0 +1,117 @@
+package timecode
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseInterval parses PostgreSQL interval text as stored for segments ("HH:MM:SS[.ffffff]",
+// optionally prefixed with "N day(s)") into a duration
+func ParseInterval(interval string) (time.Duration, error) {
+	s := strings.TrimSpace(interval)
+	if s == "" {
+		return 0, fmt.Errorf("empty interval")
+	}
+
+	var days int
+	if fields := strings.Fields(s); len(fields) == 3 && strings.HasPrefix(fields[1], "day") {
+		d, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return 0, fmt.Errorf("invalid interval %q: %w", interval, err)
+		}
+		days = d
+		s = fields[2]
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid interval %q: expected HH:MM:SS", interval)
+	}
+
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid interval %q: %w", interval, err)
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid interval %q: %w", interval, err)
+	}
+	seconds, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid interval %q: %w", interval, err)
+	}
+
+	total := time.Duration(days)*24*time.Hour +
+		time.Duration(hours)*time.Hour +
+		time.Duration(minutes)*time.Minute +
+		time.Duration(seconds*float64(time.Second)+0.5)
+	return total.Round(time.Millisecond), nil
+}
+
+// ParseOffset parses a position in a video given as HH:MM:SS[.fff], MM:SS[.fff] or seconds
+func ParseOffset(offset string) (time.Duration, error) {
+	s := strings.TrimSpace(offset)
+	if s == "" {
+		return 0, fmt.Errorf("empty offset")
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("invalid offset %q: expected HH:MM:SS, MM:SS or seconds", offset)
+	}
+
+	seconds, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+	if err != nil || seconds < 0 {
+		return 0, fmt.Errorf("invalid offset %q: expected HH:MM:SS, MM:SS or seconds", offset)
+	}
+	total := time.Duration(seconds*float64(time.Second) + 0.5)
+
+	unit := time.Minute
+	for i := len(parts) - 2; i >= 0; i-- {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid offset %q: expected HH:MM:SS, MM:SS or seconds", offset)
+		}
+		total += time.Duration(n) * unit
+		unit *= 60
+	}
+	return total.Round(time.Millisecond), nil
+}
+
+// FormatInterval formats a duration as interval text accepted by PostgreSQL (HH:MM:SS.mmm)
+func FormatInterval(d time.Duration) string {
+	return format(d, ".")
+}
+
+// FormatSRT formats a duration as an SRT timestamp (HH:MM:SS,mmm)
+func FormatSRT(d time.Duration) string {
+	return format(d, ",")
+}
+
+// FormatVTT formats a duration as a WebVTT timestamp (HH:MM:SS.mmm)
+func FormatVTT(d time.Duration) string {
+	return format(d, ".")
+}
+
+// FromSeconds converts fractional seconds (as reported by Whisper) to a duration
+func FromSeconds(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second)).Round(time.Millisecond)
+}
+
+// format renders HH:MM:SS<sep>mmm, clamping negative durations to zero
+func format(d time.Duration, sep string) string {
+	if d < 0 {
+		d = 0
+	}
+	d = d.Round(time.Millisecond)
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+	seconds := d / time.Second
+	d -= seconds * time.Second
+	millis := d / time.Millisecond
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", hours, minutes, seconds, sep, millis)
+}
@@ -0,0 +1,77 @@
+package timecode
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "whole seconds", input: "00:00:06", want: 6 * time.Second},
+		{name: "fractional seconds", input: "00:00:02.5", want: 2500 * time.Millisecond},
+		{name: "microsecond precision", input: "01:02:03.123456", want: time.Hour + 2*time.Minute + 3123*time.Millisecond},
+		{name: "over a day", input: "1 day 02:00:00", want: 26 * time.Hour},
+		{name: "empty", input: "", wantErr: true},
+		{name: "malformed", input: "12.5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseInterval(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseOffset(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "hours minutes seconds", input: "00:05:00", want: 5 * time.Minute},
+		{name: "minutes seconds", input: "15:30", want: 15*time.Minute + 30*time.Second},
+		{name: "seconds", input: "90", want: 90 * time.Second},
+		{name: "fractional seconds", input: "01:00:02.5", want: time.Hour + 2500*time.Millisecond},
+		{name: "empty", input: "", wantErr: true},
+		{name: "too many fields", input: "1:00:00:00", wantErr: true},
+		{name: "negative", input: "-5", wantErr: true},
+		{name: "not a number", input: "5m", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOffset(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	d := time.Hour + 2*time.Minute + 3*time.Second + 45*time.Millisecond
+
+	assert.Equal(t, "01:02:03,045", FormatSRT(d))
+	assert.Equal(t, "01:02:03.045", FormatVTT(d))
+	assert.Equal(t, "01:02:03.045", FormatInterval(d))
+	assert.Equal(t, "00:00:00,000", FormatSRT(-time.Second))
+	assert.Equal(t, 2500*time.Millisecond, FromSeconds(2.5))
+}
@@ -0,0 +1,72 @@
+package tmpl
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// porcelainEscapes keeps each porcelain field free of the field and record separators
+var porcelainEscapes = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`, "\x00", `\0`)
+
+// porcelainField renders a value as a porcelain field: nil pointers as nothing, times in RFC
+// 3339 (UTC), floats without exponent, and backslashes, tabs, newlines and NULs escaped
+func porcelainField(v any) string {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return ""
+	}
+
+	var s string
+	switch x := value.Interface().(type) {
+	case time.Time:
+		s = x.UTC().Format(time.RFC3339)
+	case float64:
+		s = strconv.FormatFloat(x, 'f', -1, 64)
+	default:
+		s = fmt.Sprint(x)
+	}
+	return porcelainEscapes.Replace(s)
+}
+
+// Porcelain returns the Template of --porcelain output: the given struct fields of each item
+// in that order, tab-separated, with no header, one record per line or, with nul, per NUL byte.
+// The fields of a command are a stable interface for scripts; only ever append to them.
+func Porcelain(fields []string, nul bool) *Template {
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = "{{field ." + field + "}}"
+	}
+	end := "\n"
+	if nul {
+		end = "\x00"
+	}
+	t := template.Must(template.New("porcelain").Funcs(template.FuncMap{"field": porcelainField}).Parse(strings.Join(columns, "\t") + end))
+	return &Template{t: t, source: "--porcelain"}
+}
+
+// FromFlags returns the Template of the output flags of a list or get command: --template
+// (text), --porcelain with the command's porcelain fields, and -z (nul); nil when neither
+// --template nor --porcelain is given
+func FromFlags(text string, porcelain, nul bool, fields []string) (*Template, error) {
+	switch {
+	case text != "" && porcelain:
+		return nil, fmt.Errorf("--template cannot be combined with --porcelain")
+	case nul && !porcelain:
+		return nil, fmt.Errorf("-z only applies to --porcelain")
+	case porcelain:
+		return Porcelain(fields, nul), nil
+	case text != "":
+		return Parse(text)
+	}
+	return nil, nil
+}
@@ -0,0 +1,68 @@
+package tmpl
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type porcelainItem struct {
+	ID        string
+	Title     string
+	Duration  float64
+	Note      *string
+	CreatedAt time.Time
+	Done      *time.Time
+}
+
+func TestPorcelain(t *testing.T) {
+	note := "tabs\there\nand a \\ backslash"
+	created := time.Date(2026, 3, 1, 9, 30, 0, 0, time.FixedZone("JST", 9*60*60))
+	items := []porcelainItem{
+		{ID: "video1", Title: "First", Duration: 212.5, Note: &note, CreatedAt: created, Done: &created},
+		{ID: "video2", Title: "Second", Duration: 1e7},
+	}
+	fields := []string{"ID", "Title", "Duration", "Note", "CreatedAt", "Done"}
+
+	render := func(nul bool) string {
+		var buf bytes.Buffer
+		for _, it := range items {
+			require.NoError(t, Porcelain(fields, nul).Execute(&buf, it))
+		}
+		return buf.String()
+	}
+
+	first := "video1\tFirst\t212.5\ttabs\\there\\nand a \\\\ backslash\t2026-03-01T00:30:00Z\t2026-03-01T00:30:00Z"
+	second := "video2\tSecond\t10000000\t\t0001-01-01T00:00:00Z\t"
+	assert.Equal(t, first+"\n"+second+"\n", render(false))
+	assert.Equal(t, first+"\x00"+second+"\x00", render(true))
+}
+
+func TestFromFlags(t *testing.T) {
+	fields := []string{"ID"}
+
+	rendered, err := FromFlags("", false, false, fields)
+	require.NoError(t, err)
+	assert.Nil(t, rendered)
+
+	rendered, err = FromFlags("{{.Title}}", false, false, fields)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, rendered.Execute(&buf, porcelainItem{ID: "video1", Title: "First"}))
+	assert.Equal(t, "First\n", buf.String())
+
+	rendered, err = FromFlags("", true, true, fields)
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, rendered.Execute(&buf, porcelainItem{ID: "video1"}))
+	assert.Equal(t, "video1\x00", buf.String())
+
+	_, err = FromFlags("{{.ID}}", true, false, fields)
+	assert.ErrorContains(t, err, "--template cannot be combined with --porcelain")
+
+	_, err = FromFlags("", false, true, fields)
+	assert.ErrorContains(t, err, "-z only applies to --porcelain")
+}
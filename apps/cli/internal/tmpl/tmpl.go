@@ -0,0 +1,64 @@
+package tmpl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// escapes turns the \t and \n typed in a shell argument into tabs and newlines
+var escapes = strings.NewReplacer(`\t`, "\t", `\n`, "\n")
+
+// funcs are the functions available to templates besides the text/template builtins
+var funcs = template.FuncMap{
+	// json renders a value as compact JSON, e.g. {{json .}} to see every field
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// Template renders command output items with a Go template, as given to --template.
+// Fields are those of the Go structs (e.g. {{.ID}} {{.Title}}); pointer fields print their value.
+type Template struct {
+	t      *template.Template
+	source string // Where the template came from, for error messages
+}
+
+// Parse parses a --template value. Each rendered item ends with a newline unless the template
+// already ends with one.
+func Parse(text string) (*Template, error) {
+	text = escapes.Replace(text)
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+
+	t, err := template.New("output").Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --template: %w", err)
+	}
+	return &Template{t: t, source: "--template"}, nil
+}
+
+// ParseDocument parses a multi-line template read from a file, such as a study sheet template.
+// Unlike Parse it keeps the text as written; name appears in error messages.
+func ParseDocument(name, text string) (*Template, error) {
+	t, err := template.New(name).Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template %s: %w", name, err)
+	}
+	return &Template{t: t, source: "template " + name}, nil
+}
+
+// Execute renders one item to w
+func (t *Template) Execute(w io.Writer, item any) error {
+	if err := t.t.Execute(w, item); err != nil {
+		return fmt.Errorf("failed to render %s: %w", t.source, err)
+	}
+	return nil
+}
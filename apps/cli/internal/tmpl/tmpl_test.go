@@ -0,0 +1,49 @@
+package tmpl
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	ID    string
+	Title string
+	Tags  []string
+	Note  *string
+}
+
+func TestTemplate(t *testing.T) {
+	note := "slow speech"
+	items := []item{
+		{ID: "video1", Title: "First", Tags: []string{"a", "b"}, Note: &note},
+		{ID: "video2", Title: "Second"},
+	}
+
+	render := func(text string) string {
+		tmpl, err := Parse(text)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		for _, it := range items {
+			require.NoError(t, tmpl.Execute(&buf, it))
+		}
+		return buf.String()
+	}
+
+	assert.Equal(t, "video1 First\nvideo2 Second\n", render("{{.ID}} {{.Title}}"))
+	assert.Equal(t, "video1\tFIRST\nvideo2\tSECOND\n", render(`{{.ID}}\t{{upper .Title}}`))
+	assert.Equal(t, "a,b\n\n", render("{{join .Tags \",\"}}\n"))
+	assert.Equal(t, "slow speech\n\n", render("{{with .Note}}{{.}}{{end}}"))
+	assert.Equal(t, "\n"+`{"ID":"video2","Title":"Second","Tags":null,"Note":null}`+"\n", render("{{if not .Note}}{{json .}}{{end}}"))
+}
+
+func TestTemplate_Errors(t *testing.T) {
+	_, err := Parse("{{.ID")
+	assert.ErrorContains(t, err, "invalid --template")
+
+	tmpl, err := Parse("{{.Missing}}")
+	require.NoError(t, err)
+	assert.ErrorContains(t, tmpl.Execute(&bytes.Buffer{}, item{}), "failed to render --template")
+}
@@ -0,0 +1,86 @@
+package videourl
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// watchURL is the canonical form of a video URL, completed with the video ID
+const watchURL = "https://www.youtube.com/watch?v="
+
+// idPattern matches YouTube video IDs
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+
+// youtubeHosts are the hosts serving watch pages and player paths, without "www."
+var youtubeHosts = map[string]bool{
+	"youtube.com":          true,
+	"m.youtube.com":        true,
+	"music.youtube.com":    true,
+	"youtube-nocookie.com": true,
+}
+
+// pathPrefixes are the paths followed by a video ID: shorts, live streams and players
+var pathPrefixes = []string{"/shorts/", "/live/", "/embed/", "/v/", "/e/"}
+
+// ValidID reports whether id has the form of a YouTube video ID
+func ValidID(id string) bool {
+	return idPattern.MatchString(id)
+}
+
+// Canonical returns the canonical watch URL of a video ID
+func Canonical(id string) string {
+	return watchURL + id
+}
+
+// VideoID extracts the video ID from a YouTube video URL: watch pages (with any extra
+// parameters), youtu.be short links, shorts, live and embed URLs. A bare video ID is accepted too.
+func VideoID(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if ValidID(raw) {
+		return raw, nil
+	}
+
+	// Links are often pasted without a scheme (youtu.be/ID)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid video URL %q: %w", raw, err)
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	var id string
+	switch {
+	case host == "youtu.be":
+		id = strings.Trim(u.Path, "/")
+	case youtubeHosts[host] && u.Path == "/watch":
+		id = u.Query().Get("v")
+	case youtubeHosts[host]:
+		for _, prefix := range pathPrefixes {
+			if rest, ok := strings.CutPrefix(u.Path, prefix); ok {
+				id, _, _ = strings.Cut(rest, "/")
+				break
+			}
+		}
+	default:
+		return "", fmt.Errorf("not a YouTube video URL: %s", raw)
+	}
+
+	if !ValidID(id) {
+		return "", fmt.Errorf("no video ID in URL: %s", raw)
+	}
+	return id, nil
+}
+
+// Canonicalize returns the canonical watch URL (https://www.youtube.com/watch?v=ID) of any
+// form of YouTube video URL accepted by VideoID, so the same video is always stored alike
+func Canonicalize(raw string) (string, error) {
+	id, err := VideoID(raw)
+	if err != nil {
+		return "", err
+	}
+	return Canonical(id), nil
+}
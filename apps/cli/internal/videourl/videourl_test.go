@@ -0,0 +1,51 @@
+package videourl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalize(t *testing.T) {
+	const want = "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+
+	for _, raw := range []string{
+		"dQw4w9WgXcQ",
+		"https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+		"https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=42s&list=PL123&index=2",
+		"https://www.youtube.com/watch?feature=share&v=dQw4w9WgXcQ",
+		"http://youtube.com/watch?v=dQw4w9WgXcQ",
+		"https://m.youtube.com/watch?v=dQw4w9WgXcQ",
+		"https://music.youtube.com/watch?v=dQw4w9WgXcQ&si=abc",
+		"https://youtu.be/dQw4w9WgXcQ",
+		"https://youtu.be/dQw4w9WgXcQ?si=Xyz123&t=10",
+		"youtu.be/dQw4w9WgXcQ",
+		"https://www.youtube.com/shorts/dQw4w9WgXcQ",
+		"https://youtube.com/shorts/dQw4w9WgXcQ?feature=share",
+		"https://www.youtube.com/live/dQw4w9WgXcQ?si=abc",
+		"https://www.youtube.com/embed/dQw4w9WgXcQ",
+		"https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ?rel=0",
+		"  https://WWW.YOUTUBE.COM/watch?v=dQw4w9WgXcQ  ",
+	} {
+		got, err := Canonicalize(raw)
+		require.NoError(t, err, raw)
+		assert.Equal(t, want, got, raw)
+	}
+}
+
+func TestCanonicalize_Invalid(t *testing.T) {
+	for _, raw := range []string{
+		"",
+		"dQw4w9WgXc",
+		"https://www.youtube.com/watch?v=short",
+		"https://www.youtube.com/channel/UC123456789abcdef",
+		"https://www.youtube.com/playlist?list=PL123",
+		"https://vimeo.com/123456789",
+		"https://example.com/watch?v=dQw4w9WgXcQ",
+		"https://youtu.be/",
+	} {
+		_, err := Canonicalize(raw)
+		assert.Error(t, err, raw)
+	}
+}
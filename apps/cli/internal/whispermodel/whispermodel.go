@@ -0,0 +1,275 @@
+// Package whispermodel manages the model files of the whisper CLI, so large models can be
+// downloaded ahead of time instead of by the first transcription that needs them.
+package whispermodel
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/offline"
+)
+
+// baseURL is where the whisper CLI downloads its models from
+const baseURL = "https://openaipublic.azureedge.net/main/whisper/models/"
+
+// spec is a downloadable model: its file is <name>.pt under a directory named by its SHA-256
+type spec struct {
+	sha256     string
+	approxSize int64 // Download size, for display before downloading
+}
+
+// specs lists the models known to the whisper CLI
+var specs = map[string]spec{
+	"tiny.en":        {"d3dd57d32accea0b295c96e26691aa14d8822fac7d9d27d5dc00b4ca2826dd03", 75_571_315},
+	"tiny":           {"65147644a518d12f04e32d6f3b26facc3f8dd46e5390956a9424a650c0ce22b9", 75_572_083},
+	"base.en":        {"25a8566e1d0c1e2231d1c762132cd20e0f96a85d16145c3a00adf5d1ac670ead", 145_261_783},
+	"base":           {"ed3a0b6b1c0edf879ad9b11b1af5a0e6ab5db9205f891f668f8b0e6c6326e34e", 145_262_807},
+	"small.en":       {"f953ad0fd29cacd07d5a9eda5624af0f6bcf2258be67c92b79389873d91e0872", 483_615_683},
+	"small":          {"9ecf779972d90ba49c06d968637d720dd632c55bbf19d441fb42bf17a411e794", 483_617_219},
+	"medium.en":      {"d7440d1dc186f76616474e0ff0b3b6b879abc9d1a4926b7adfa41db2d497ab4f", 1_528_006_491},
+	"medium":         {"345ae4da62f9b3d59415adc60127b97c714f32e89e936602e85993674d08dcb1", 1_528_008_539},
+	"large-v1":       {"e4b87e7e0bf463eb8e6956e646f1e277e901512310def2c24bf0e11bd3c28e9a", 3_086_999_982},
+	"large-v2":       {"81f7c96c852ee8fc832187b0132e569d6c3065a3252ed18e56effd0b6a73e524", 3_086_999_982},
+	"large-v3":       {"e5b1a55b89c1367dacf97e3e19bfd829a01529dbfdeefa8caeb59b3f1b81dadb", 3_087_371_615},
+	"large-v3-turbo": {"aff26ae408abcba5fbf8813c21e62b0941638c5f6eebfb145be0c9839262a19a", 1_617_824_864},
+}
+
+// aliases maps model names the whisper CLI accepts to the model whose file they use
+var aliases = map[string]string{
+	"large": "large-v3",
+	"turbo": "large-v3-turbo",
+}
+
+// Model is a whisper model and its local file
+type Model struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Size       int64  `json:"size"` // Size of the local file, or approximate download size when not downloaded
+	Downloaded bool   `json:"downloaded"`
+}
+
+// DefaultDir returns the directory the whisper CLI keeps its models in when --model_dir is not
+// given: $XDG_CACHE_HOME/whisper, or ~/.cache/whisper
+func DefaultDir() (string, error) {
+	if cache := os.Getenv("XDG_CACHE_HOME"); cache != "" {
+		return filepath.Join(cache, "whisper"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".cache", "whisper"), nil
+}
+
+// Resolve returns the canonical name of a model, following aliases such as large
+func Resolve(name string) (string, error) {
+	if canonical, ok := aliases[name]; ok {
+		return canonical, nil
+	}
+	if _, ok := specs[name]; ok {
+		return name, nil
+	}
+	return "", errors.New(errors.CodeInvalidArg, fmt.Sprintf("unknown whisper model %q (known: %s)", name, strings.Join(Names(), ", ")))
+}
+
+// Names returns the canonical model names, smallest first
+func Names() []string {
+	names := make([]string, 0, len(specs))
+	for name := range specs {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if specs[names[i]].approxSize != specs[names[j]].approxSize {
+			return specs[names[i]].approxSize < specs[names[j]].approxSize
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// modelURL returns the download URL of a canonical model
+func modelURL(name string) string {
+	return baseURL + specs[name].sha256 + "/" + name + ".pt"
+}
+
+// List returns every known model with its local file in dir
+func List(dir string) ([]Model, error) {
+	var models []Model
+	for _, name := range Names() {
+		model := Model{Name: name, Path: filepath.Join(dir, name+".pt"), Size: specs[name].approxSize}
+		info, err := os.Stat(model.Path)
+		switch {
+		case err == nil:
+			model.Size = info.Size()
+			model.Downloaded = true
+		case !os.IsNotExist(err):
+			return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to check %s", model.Path))
+		}
+		models = append(models, model)
+	}
+	return models, nil
+}
+
+// DownloadSize returns the approximate download size of a model, 0 when it is downloaded in dir
+// already
+func DownloadSize(dir, name string) (int64, error) {
+	canonical, err := Resolve(name)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := os.Stat(filepath.Join(dir, canonical+".pt")); err == nil {
+		return 0, nil
+	}
+	return specs[canonical].approxSize, nil
+}
+
+// Downloader downloads model files into a directory
+type Downloader struct {
+	client *http.Client
+	urlFor func(name string) string
+}
+
+// NewDownloader creates a Downloader. Model files are large, so the client has no timeout;
+// cancel the context to stop a download.
+func NewDownloader() *Downloader {
+	return NewDownloaderWithClient(&http.Client{}, modelURL)
+}
+
+// NewDownloaderWithClient creates a Downloader with a custom client and download URLs (for testing)
+func NewDownloaderWithClient(client *http.Client, urlFor func(name string) string) *Downloader {
+	return &Downloader{client: client, urlFor: urlFor}
+}
+
+// Download fetches a model into dir unless a file with the expected checksum is there already,
+// verifying the checksum before the file replaces any previous one. progress, when not nil,
+// receives the number of bytes written so far and the expected total (-1 when unknown).
+func (d *Downloader) Download(ctx context.Context, dir, name string, progress func(written, total int64)) (*Model, error) {
+	canonical, err := Resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	want := specs[canonical].sha256
+	target := filepath.Join(dir, canonical+".pt")
+
+	if sum, err := fileSHA256(target); err == nil && sum == want {
+		return stat(canonical, target)
+	}
+
+	if err := offline.Check("downloading whisper model " + canonical); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to create model directory")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.urlFor(canonical), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to create download request")
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeExternal, fmt.Sprintf("failed to download whisper model %s", canonical))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New(errors.CodeExternal, fmt.Sprintf("failed to download whisper model %s: %s", canonical, resp.Status))
+	}
+
+	// Download next to the target so the final rename stays on one filesystem
+	tmp, err := os.CreateTemp(dir, canonical+".pt.partial-*")
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to create download file")
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	body := io.Reader(resp.Body)
+	if progress != nil {
+		body = &progressReader{reader: resp.Body, total: resp.ContentLength, progress: progress}
+	}
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), body); err != nil {
+		tmp.Close()
+		return nil, errors.Wrap(err, errors.CodeExternal, fmt.Sprintf("failed to download whisper model %s", canonical))
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to write model file")
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != want {
+		return nil, errors.New(errors.CodeExternal, fmt.Sprintf("downloaded whisper model %s has checksum %s, expected %s", canonical, sum, want))
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to move model file into place")
+	}
+
+	return stat(canonical, target)
+}
+
+// Remove deletes the local file of a model from dir, returning the model as it was
+func Remove(dir, name string) (*Model, error) {
+	canonical, err := Resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	target := filepath.Join(dir, canonical+".pt")
+	model, err := stat(canonical, target)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Remove(target); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to remove %s", target))
+	}
+	return model, nil
+}
+
+// stat describes a downloaded model file
+func stat(name, path string) (*Model, error) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("whisper model %s is not downloaded", name))
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to check %s", path))
+	}
+	return &Model{Name: name, Path: path, Size: info.Size(), Downloaded: true}, nil
+}
+
+// fileSHA256 returns the hex SHA-256 of a file
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// progressReader reports the bytes read through it
+type progressReader struct {
+	reader   io.Reader
+	written  int64
+	total    int64
+	progress func(written, total int64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.written += int64(n)
+	if n > 0 {
+		r.progress(r.written, r.total)
+	}
+	return n, err
+}
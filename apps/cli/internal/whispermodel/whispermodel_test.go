@@ -0,0 +1,114 @@
+package whispermodel
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useTestModel registers a model named "test" whose file has content
+func useTestModel(t *testing.T, content string) {
+	sum := sha256.Sum256([]byte(content))
+	specs["test"] = spec{sha256: hex.EncodeToString(sum[:]), approxSize: int64(len(content))}
+	t.Cleanup(func() { delete(specs, "test") })
+}
+
+func TestResolve(t *testing.T) {
+	name, err := Resolve("large")
+	require.NoError(t, err)
+	assert.Equal(t, "large-v3", name)
+
+	name, err = Resolve("base")
+	require.NoError(t, err)
+	assert.Equal(t, "base", name)
+
+	_, err = Resolve("huge")
+	assert.ErrorContains(t, err, "unknown whisper model")
+}
+
+func TestDownload(t *testing.T) {
+	useTestModel(t, "model weights")
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte("model weights"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	downloader := NewDownloaderWithClient(server.Client(), func(name string) string { return server.URL + "/" + name + ".pt" })
+
+	var written int64
+	model, err := downloader.Download(context.Background(), dir, "test", func(n, total int64) { written = n })
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "test.pt"), model.Path)
+	assert.Equal(t, int64(len("model weights")), model.Size)
+	assert.Equal(t, model.Size, written)
+
+	// A verified file is not downloaded again
+	_, err = downloader.Download(context.Background(), dir, "test", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no partial files are left behind")
+}
+
+func TestDownload_ChecksumMismatch(t *testing.T) {
+	useTestModel(t, "model weights")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("truncated"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	downloader := NewDownloaderWithClient(server.Client(), func(name string) string { return server.URL + "/" + name + ".pt" })
+
+	_, err := downloader.Download(context.Background(), dir, "test", nil)
+	assert.ErrorContains(t, err, "checksum")
+	_, err = os.Stat(filepath.Join(dir, "test.pt"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestListAndRemove(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tiny.pt"), []byte("tiny"), 0644))
+
+	models, err := List(dir)
+	require.NoError(t, err)
+	require.Len(t, models, len(specs))
+	assert.Equal(t, Model{Name: "tiny.en", Path: filepath.Join(dir, "tiny.en.pt"), Size: specs["tiny.en"].approxSize}, models[0])
+	assert.Equal(t, Model{Name: "tiny", Path: filepath.Join(dir, "tiny.pt"), Size: 4, Downloaded: true}, models[1])
+
+	removed, err := Remove(dir, "tiny")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), removed.Size)
+
+	_, err = Remove(dir, "tiny")
+	assert.ErrorContains(t, err, "not downloaded")
+}
+
+func TestDownloadSize(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tiny.pt"), []byte("tiny"), 0644))
+
+	size, err := DownloadSize(dir, "tiny")
+	require.NoError(t, err)
+	assert.Zero(t, size, "downloaded already")
+
+	size, err = DownloadSize(dir, "large")
+	require.NoError(t, err)
+	assert.Equal(t, specs["large-v3"].approxSize, size)
+
+	_, err = DownloadSize(dir, "huge")
+	assert.Error(t, err)
+}
@@ -0,0 +1,94 @@
+package ytdlp
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+)
+
+// proxySchemes lists the proxy URL schemes yt-dlp accepts
+var proxySchemes = []string{"http", "https", "socks4", "socks4a", "socks5", "socks5h"}
+
+// NetworkOptions holds network settings passed to every yt-dlp invocation
+type NetworkOptions struct {
+	Proxy     string // --proxy URL, e.g. socks5://127.0.0.1:1080 (empty uses yt-dlp's default, including *_PROXY env vars)
+	ForceIPv4 bool   // --force-ipv4
+	ForceIPv6 bool   // --force-ipv6
+	UserAgent string // --user-agent
+}
+
+// Validate checks that the options can be passed to yt-dlp
+func (o NetworkOptions) Validate() error {
+	if o.ForceIPv4 && o.ForceIPv6 {
+		return errors.New(errors.CodeInvalidArg, "force IPv4 and force IPv6 cannot be used together")
+	}
+	if o.Proxy == "" {
+		return nil
+	}
+
+	u, err := url.Parse(o.Proxy)
+	if err != nil || u.Host == "" {
+		return errors.New(errors.CodeInvalidArg, "invalid proxy URL (expected scheme://host:port)")
+	}
+	for _, scheme := range proxySchemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return nil
+		}
+	}
+	return errors.New(errors.CodeInvalidArg, fmt.Sprintf("unsupported proxy scheme %q (supported: %s)", u.Scheme, strings.Join(proxySchemes, ", ")))
+}
+
+// Args returns the yt-dlp arguments for the options
+func (o NetworkOptions) Args() []string {
+	var args []string
+	if o.Proxy != "" {
+		args = append(args, "--proxy", o.Proxy)
+	}
+	if o.ForceIPv4 {
+		args = append(args, "--force-ipv4")
+	}
+	if o.ForceIPv6 {
+		args = append(args, "--force-ipv6")
+	}
+	if o.UserAgent != "" {
+		args = append(args, "--user-agent", o.UserAgent)
+	}
+	return args
+}
+
+// RedactedProxy returns the proxy URL with any password masked, for display
+func (o NetworkOptions) RedactedProxy() string {
+	u, err := url.Parse(o.Proxy)
+	if err != nil || u.User == nil {
+		return o.Proxy
+	}
+	return u.Redacted()
+}
+
+var (
+	network   NetworkOptions
+	networkMu sync.RWMutex
+)
+
+// SetNetworkOptions sets the network options used by every yt-dlp invocation of the process.
+// The CLI calls it once at startup from the config file and global flags.
+func SetNetworkOptions(opts NetworkOptions) {
+	networkMu.Lock()
+	defer networkMu.Unlock()
+	network = opts
+}
+
+// Network returns the process-wide network options
+func Network() NetworkOptions {
+	networkMu.RLock()
+	defer networkMu.RUnlock()
+	return network
+}
+
+// WithNetworkArgs prepends the process-wide network arguments to args
+func WithNetworkArgs(args ...string) []string {
+	return append(Network().Args(), args...)
+}
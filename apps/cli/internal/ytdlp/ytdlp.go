@@ -0,0 +1,183 @@
+package ytdlp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Taichi-iskw/yt-lang/internal/errors"
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+)
+
+// Binary is the yt-dlp executable name
+const Binary = common.ToolYtDlp
+
+// MinimumVersion is the oldest yt-dlp release known to work with current YouTube pages
+const MinimumVersion = "2023.03.04"
+
+// Optional yt-dlp features, used only when the installed version supports them
+const (
+	CapLazyPlaylist  = "lazy_playlist"  // --lazy-playlist: stop fetching playlist pages once --playlist-end is reached
+	CapPrintFilepath = "print_filepath" // --print after_move:filepath: report the final path of a download
+)
+
+// Capabilities lists every optional feature in display order
+var Capabilities = []string{CapLazyPlaylist, CapPrintFilepath}
+
+// capabilitySince maps each feature to the first release that has it
+var capabilitySince = map[string]string{
+	CapLazyPlaylist:  "2022.11.11",
+	CapPrintFilepath: "2022.04.08",
+}
+
+// CapabilitySince returns the first yt-dlp release that has an optional feature
+func CapabilitySince(capability string) string {
+	return capabilitySince[capability]
+}
+
+// Version is a yt-dlp release version: YYYY.MM.DD with an optional build number
+// (nightly builds append a fourth component)
+type Version struct {
+	parts [4]int
+	raw   string
+}
+
+// ParseVersion parses yt-dlp --version output such as "2024.08.06" or "2024.08.06.232914"
+func ParseVersion(s string) (Version, error) {
+	raw := strings.TrimSpace(s)
+	fields := strings.Split(raw, ".")
+	if len(fields) < 3 || len(fields) > 4 {
+		return Version{}, fmt.Errorf("unrecognized yt-dlp version %q", raw)
+	}
+
+	v := Version{raw: raw}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("unrecognized yt-dlp version %q", raw)
+		}
+		v.parts[i] = n
+	}
+	return v, nil
+}
+
+// String returns the version as reported by yt-dlp
+func (v Version) String() string {
+	return v.raw
+}
+
+// AtLeast reports whether v is the same as or newer than other. Unparseable versions compare as newer.
+func (v Version) AtLeast(other string) bool {
+	o, err := ParseVersion(other)
+	if err != nil {
+		return true
+	}
+	for i := range v.parts {
+		if v.parts[i] != o.parts[i] {
+			return v.parts[i] > o.parts[i]
+		}
+	}
+	return true
+}
+
+// Info describes the installed yt-dlp
+type Info struct {
+	Path           string          `json:"path"` // Resolved executable path (empty when not found in PATH)
+	Version        string          `json:"version"`
+	MinimumVersion string          `json:"minimum_version"`
+	Outdated       bool            `json:"outdated"`     // Older than MinimumVersion
+	Capabilities   map[string]bool `json:"capabilities"` // Optional feature -> supported
+}
+
+// Supports reports whether the installed yt-dlp has an optional feature
+func (i *Info) Supports(capability string) bool {
+	return i != nil && i.Capabilities[capability]
+}
+
+// Detector runs yt-dlp --version once and caches the result for the rest of the run
+type Detector struct {
+	cmdRunner common.CmdRunner
+	warnings  io.Writer // Receives the outdated version warning
+
+	once sync.Once
+	info *Info
+	err  error
+}
+
+var (
+	defaultDetector     *Detector
+	defaultDetectorOnce sync.Once
+)
+
+// NewDetector creates a new Detector that warns on stderr
+func NewDetector(cmdRunner common.CmdRunner) *Detector {
+	return NewDetectorWithWarnings(cmdRunner, os.Stderr)
+}
+
+// NewDetectorWithWarnings creates a new Detector that writes warnings to w (for testing)
+func NewDetectorWithWarnings(cmdRunner common.CmdRunner, w io.Writer) *Detector {
+	return &Detector{cmdRunner: cmdRunner, warnings: w}
+}
+
+// DefaultDetector returns the process-wide detector, so every service in a run shares one detection
+func DefaultDetector() *Detector {
+	defaultDetectorOnce.Do(func() {
+		defaultDetector = NewDetector(common.NewCmdRunner())
+	})
+	return defaultDetector
+}
+
+// Detect returns the installed yt-dlp version and capabilities, running yt-dlp only on the first call
+func (d *Detector) Detect(ctx context.Context) (*Info, error) {
+	d.once.Do(func() {
+		d.info, d.err = d.detect(ctx)
+		if d.err == nil && d.info.Outdated {
+			fmt.Fprintf(d.warnings, "Warning: yt-dlp %s is older than the minimum supported version %s; fetching and downloading may fail (update with `yt-dlp -U`)\n",
+				d.info.Version, d.info.MinimumVersion)
+		}
+	})
+	return d.info, d.err
+}
+
+// Supports reports whether the installed yt-dlp has an optional feature. A nil detector
+// or a failed detection supports nothing, so callers fall back to baseline arguments.
+func (d *Detector) Supports(ctx context.Context, capability string) bool {
+	if d == nil {
+		return false
+	}
+	info, err := d.Detect(ctx)
+	if err != nil {
+		return false
+	}
+	return info.Supports(capability)
+}
+
+func (d *Detector) detect(ctx context.Context) (*Info, error) {
+	output, err := d.cmdRunner.Run(ctx, Binary, "--version")
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeExternal, "yt-dlp is not installed or not found in PATH")
+	}
+
+	version, err := ParseVersion(string(output))
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeExternal, "failed to detect yt-dlp version")
+	}
+
+	info := &Info{
+		Version:        version.String(),
+		MinimumVersion: MinimumVersion,
+		Outdated:       !version.AtLeast(MinimumVersion),
+		Capabilities:   make(map[string]bool, len(Capabilities)),
+	}
+	for _, capability := range Capabilities {
+		info.Capabilities[capability] = version.AtLeast(capabilitySince[capability])
+	}
+	if path, err := common.LookTool(Binary); err == nil {
+		info.Path = path
+	}
+	return info, nil
+}
@@ -0,0 +1,87 @@
+package ytdlp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Taichi-iskw/yt-lang/internal/service/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// versionRunner answers yt-dlp --version and counts the calls
+type versionRunner struct {
+	output string
+	err    error
+	calls  int
+}
+
+func (r *versionRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	r.calls++
+	return []byte(r.output), r.err
+}
+
+func (r *versionRunner) Start(ctx context.Context, name string, args ...string) (common.Process, error) {
+	return nil, errors.New("not supported")
+}
+
+func TestParseVersion(t *testing.T) {
+	v, err := ParseVersion("2024.08.06\n")
+	require.NoError(t, err)
+	assert.Equal(t, "2024.08.06", v.String())
+	assert.True(t, v.AtLeast("2024.08.06"))
+	assert.True(t, v.AtLeast("2023.12.30"))
+	assert.False(t, v.AtLeast("2024.08.07"))
+
+	nightly, err := ParseVersion("2024.08.06.232914")
+	require.NoError(t, err)
+	assert.True(t, nightly.AtLeast("2024.08.06"))
+	assert.False(t, v.AtLeast(nightly.String()))
+
+	for _, invalid := range []string{"", "2024", "v2024.08.06", "2024.08.06.1.2"} {
+		_, err := ParseVersion(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestDetector_Detect(t *testing.T) {
+	t.Run("detects capabilities once per run", func(t *testing.T) {
+		runner := &versionRunner{output: "2022.06.29\n"}
+		var warnings bytes.Buffer
+		detector := NewDetectorWithWarnings(runner, &warnings)
+
+		info, err := detector.Detect(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "2022.06.29", info.Version)
+		assert.True(t, info.Outdated)
+		assert.True(t, info.Supports(CapPrintFilepath))
+		assert.False(t, info.Supports(CapLazyPlaylist))
+
+		assert.False(t, detector.Supports(context.Background(), CapLazyPlaylist))
+		assert.Equal(t, 1, runner.calls)
+		assert.Contains(t, warnings.String(), "older than the minimum supported version "+MinimumVersion)
+	})
+
+	t.Run("current version supports everything without warning", func(t *testing.T) {
+		var warnings bytes.Buffer
+		detector := NewDetectorWithWarnings(&versionRunner{output: "2024.08.06"}, &warnings)
+
+		for _, capability := range Capabilities {
+			assert.True(t, detector.Supports(context.Background(), capability), capability)
+		}
+		assert.Empty(t, warnings.String())
+	})
+
+	t.Run("missing binary supports nothing", func(t *testing.T) {
+		detector := NewDetectorWithWarnings(&versionRunner{err: errors.New("executable file not found")}, &bytes.Buffer{})
+
+		_, err := detector.Detect(context.Background())
+		require.Error(t, err)
+		assert.False(t, detector.Supports(context.Background(), CapLazyPlaylist))
+
+		var nilDetector *Detector
+		assert.False(t, nilDetector.Supports(context.Background(), CapLazyPlaylist))
+	})
+}
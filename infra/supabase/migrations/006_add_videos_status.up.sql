@@ -0,0 +1,10 @@
+-- Track whether videos are still available on YouTube (deleted or privated videos become 'unavailable')
+ALTER TABLE videos
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'available', -- available, unavailable
+    ADD COLUMN IF NOT EXISTS checked_at TIMESTAMP WITH TIME ZONE;              -- Last availability check
+
+ALTER TABLE videos
+    ADD CONSTRAINT check_videos_status CHECK (status IN ('available', 'unavailable'));
+
+-- Batch pipelines list a channel's videos filtered by status
+CREATE INDEX IF NOT EXISTS idx_videos_channel_status ON videos(channel_id, status);
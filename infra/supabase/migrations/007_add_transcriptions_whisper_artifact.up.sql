@@ -0,0 +1,4 @@
+-- Reference to the raw whisper JSON output kept in the artifact store (gzip-compressed),
+-- so transcriptions can be reprocessed without running whisper again
+ALTER TABLE transcriptions
+    ADD COLUMN IF NOT EXISTS whisper_artifact VARCHAR(500); -- Artifact store key, e.g. 'whisper/<id>.json.gz'
@@ -0,0 +1,4 @@
+-- Mark translations a person reviewed (accepted or corrected in translation interactive),
+-- so datasets can be restricted to human-approved pairs
+ALTER TABLE translations
+    ADD COLUMN IF NOT EXISTS approved BOOLEAN NOT NULL DEFAULT FALSE;
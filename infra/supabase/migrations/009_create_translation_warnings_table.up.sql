@@ -0,0 +1,22 @@
+-- Create translation_warnings table for alignment QA findings on translated segments
+-- Warnings are replaced per transcription and target language each time translation qa runs
+CREATE TABLE IF NOT EXISTS translation_warnings (
+    id SERIAL PRIMARY KEY,
+    transcription_segment_id UUID NOT NULL, -- Foreign key to transcription_segments.id
+    target_language VARCHAR(10) NOT NULL,   -- Target language of the checked translation
+    code VARCHAR(50) NOT NULL,              -- Check that failed: empty_translation, untranslated_copy, length_ratio
+    message TEXT NOT NULL,                  -- Human readable detail
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    -- Foreign key constraint to transcription_segments
+    CONSTRAINT fk_translation_warnings_transcription_segment_id
+        FOREIGN KEY (transcription_segment_id)
+        REFERENCES transcription_segments(id)
+        ON DELETE CASCADE,
+
+    -- One warning per check per segment and language
+    CONSTRAINT unique_translation_warning_per_segment_lang_code
+        UNIQUE(transcription_segment_id, target_language, code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_translation_warnings_segment_lang ON translation_warnings(transcription_segment_id, target_language);
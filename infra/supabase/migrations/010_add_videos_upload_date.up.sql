@@ -0,0 +1,5 @@
+-- Record when videos were published so periodic syncs and batches can skip older uploads
+ALTER TABLE videos
+    ADD COLUMN IF NOT EXISTS upload_date DATE; -- From yt-dlp upload_date (approximate for flat playlist listings); NULL when unknown
+
+CREATE INDEX IF NOT EXISTS idx_videos_channel_upload_date ON videos(channel_id, upload_date);
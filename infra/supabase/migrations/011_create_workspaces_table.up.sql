@@ -0,0 +1,60 @@
+-- Workspaces partition one database between users or projects
+CREATE TABLE IF NOT EXISTS workspaces (
+    name VARCHAR(100) PRIMARY KEY,                     -- Workspace name (e.g., "default", "research")
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+INSERT INTO workspaces (name) VALUES ('default') ON CONFLICT (name) DO NOTHING;
+
+-- The CLI selects its workspace per connection (SELECT set_config('ytlang.workspace', ...));
+-- connections that don't select one, such as migrations, use 'default'
+CREATE OR REPLACE FUNCTION current_workspace() RETURNS VARCHAR AS $$
+    SELECT COALESCE(NULLIF(current_setting('ytlang.workspace', true), ''), 'default')
+$$ LANGUAGE sql STABLE;
+
+-- Channels, videos and transcriptions carry their workspace, so the same YouTube channel can be
+-- saved in several workspaces. Segments, translations and warnings belong to a transcription and
+-- are partitioned through it.
+ALTER TABLE transcriptions DROP CONSTRAINT IF EXISTS transcriptions_video_id_fkey;
+ALTER TABLE transcriptions DROP CONSTRAINT IF EXISTS transcriptions_video_id_language_key;
+ALTER TABLE videos DROP CONSTRAINT IF EXISTS fk_videos_channel_id;
+ALTER TABLE videos DROP CONSTRAINT IF EXISTS videos_pkey;
+ALTER TABLE videos DROP CONSTRAINT IF EXISTS videos_url_key;
+ALTER TABLE channels DROP CONSTRAINT IF EXISTS channels_pkey;
+ALTER TABLE channels DROP CONSTRAINT IF EXISTS channels_url_key;
+
+ALTER TABLE channels
+    ADD COLUMN IF NOT EXISTS workspace VARCHAR(100) NOT NULL DEFAULT current_workspace()
+        REFERENCES workspaces(name) ON DELETE CASCADE;
+ALTER TABLE videos
+    ADD COLUMN IF NOT EXISTS workspace VARCHAR(100) NOT NULL DEFAULT current_workspace();
+ALTER TABLE transcriptions
+    ADD COLUMN IF NOT EXISTS workspace VARCHAR(100) NOT NULL DEFAULT current_workspace();
+
+ALTER TABLE channels
+    ADD CONSTRAINT channels_pkey PRIMARY KEY (workspace, id),
+    ADD CONSTRAINT channels_url_key UNIQUE (workspace, url);
+
+ALTER TABLE videos
+    ADD CONSTRAINT videos_pkey PRIMARY KEY (workspace, id),
+    ADD CONSTRAINT videos_url_key UNIQUE (workspace, url),
+    ADD CONSTRAINT fk_videos_channel_id
+        FOREIGN KEY (workspace, channel_id)
+        REFERENCES channels(workspace, id)
+        ON DELETE CASCADE;
+
+ALTER TABLE transcriptions
+    ADD CONSTRAINT transcriptions_video_id_language_key UNIQUE (workspace, video_id, language),
+    ADD CONSTRAINT transcriptions_video_id_fkey
+        FOREIGN KEY (workspace, video_id)
+        REFERENCES videos(workspace, id)
+        ON DELETE CASCADE;
+
+-- Lookups by ID now go through the workspace-leading keys
+DROP INDEX IF EXISTS idx_videos_channel_id;
+DROP INDEX IF EXISTS idx_videos_channel_status;
+DROP INDEX IF EXISTS idx_videos_channel_upload_date;
+DROP INDEX IF EXISTS idx_transcriptions_video_id;
+DROP INDEX IF EXISTS idx_transcriptions_video_lang;
+CREATE INDEX IF NOT EXISTS idx_videos_workspace_channel_status ON videos(workspace, channel_id, status);
+CREATE INDEX IF NOT EXISTS idx_videos_workspace_channel_upload_date ON videos(workspace, channel_id, upload_date);
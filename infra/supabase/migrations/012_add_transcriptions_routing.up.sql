@@ -0,0 +1,6 @@
+-- How the whisper model and language of a transcription were chosen when model routing is
+-- configured (transcription.routing): sampled language, routed model and the matching rule
+ALTER TABLE transcriptions
+    ADD COLUMN IF NOT EXISTS routed_language VARCHAR(10), -- Language passed to whisper
+    ADD COLUMN IF NOT EXISTS routed_model VARCHAR(50),    -- Whisper model used, e.g. 'medium'
+    ADD COLUMN IF NOT EXISTS routing_reason TEXT;         -- e.g. 'sample detected ja; rule ja -> medium'
@@ -0,0 +1,4 @@
+-- Where the segments of a transcription came from: 'whisper' (speech recognition) or
+-- 'import' (an existing SRT/VTT subtitle file, transcription import)
+ALTER TABLE transcriptions
+    ADD COLUMN IF NOT EXISTS source VARCHAR(20) NOT NULL DEFAULT 'whisper';
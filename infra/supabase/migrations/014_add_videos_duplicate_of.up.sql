@@ -0,0 +1,4 @@
+-- Link re-uploads and mirrors found by `video dedupe` to the video they duplicate, so batch
+-- transcription processes each talk once
+ALTER TABLE videos
+    ADD COLUMN IF NOT EXISTS duplicate_of VARCHAR(255); -- ID of the original video in the same workspace; NULL when not a duplicate
@@ -0,0 +1,6 @@
+-- Personal annotations for curating study material (video annotate, video list --min-rating)
+ALTER TABLE videos
+    ADD COLUMN IF NOT EXISTS rating SMALLINT CHECK (rating BETWEEN 1 AND 5), -- 1-5 stars; NULL when unrated
+    ADD COLUMN IF NOT EXISTS note TEXT;                                       -- Free-form note, e.g. 'great for B1 learners'
+
+CREATE INDEX IF NOT EXISTS idx_videos_workspace_rating ON videos(workspace, rating) WHERE rating IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Most likely spoken languages reported by whisper, to spot multilingual or music-heavy videos
+ALTER TABLE transcriptions
+    ADD COLUMN IF NOT EXISTS language_candidates JSONB; -- e.g. [{"language": "ja", "probability": 0.62}, ...]; NULL when not reported
@@ -0,0 +1,4 @@
+-- Dominant spoken languages inferred from a channel's transcriptions (channel languages), used as
+-- the default language when transcribing its videos
+ALTER TABLE channels
+    ADD COLUMN IF NOT EXISTS languages TEXT[]; -- Most common first, e.g. {ja,en}; NULL until inferred
@@ -0,0 +1,4 @@
+-- Provider settings a translation was made with (translation create --provider-opt), so
+-- results can be compared and reproduced
+ALTER TABLE translations
+    ADD COLUMN IF NOT EXISTS provider_options JSONB; -- e.g. {"openai.temperature": "0.2"}; NULL when none were given
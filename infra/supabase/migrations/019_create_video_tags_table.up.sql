@@ -0,0 +1,19 @@
+-- Topic tags of videos, proposed from their transcripts by video autotag (TF-IDF keywords).
+-- The tags of a video are replaced each time it is tagged; video list --tag finds the videos of a topic.
+CREATE TABLE IF NOT EXISTS video_tags (
+    workspace VARCHAR(100) NOT NULL DEFAULT current_workspace(),
+    video_id VARCHAR(255) NOT NULL,  -- Foreign key to videos.id
+    tag VARCHAR(255) NOT NULL,       -- Lower-cased keyword, e.g. 'paella'
+    score REAL NOT NULL,             -- TF-IDF weight of the keyword in the video's transcript
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    PRIMARY KEY (workspace, video_id, tag),
+
+    -- Tags are removed with their video
+    CONSTRAINT fk_video_tags_video_id
+        FOREIGN KEY (workspace, video_id)
+        REFERENCES videos(workspace, id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_video_tags_workspace_tag ON video_tags(workspace, tag);
@@ -0,0 +1,4 @@
+-- Whisper runs of the fallback ladder: when the output of a run looks pathological (repetition
+-- loops, low confidence), the audio is transcribed again with a larger beam or model
+ALTER TABLE transcriptions
+    ADD COLUMN IF NOT EXISTS whisper_attempts JSONB; -- e.g. [{"model": "base", "issues": [...]}, {"model": "base", "beam_size": 5, "chosen": true}]; NULL without fallback
@@ -0,0 +1,30 @@
+-- Batch runs: every transcription create-batch invocation records its videos and the outcome of
+-- each, so runs list/show can inspect past runs and create-batch --resume retries the failed ones
+CREATE TABLE IF NOT EXISTS runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workspace VARCHAR(100) NOT NULL DEFAULT current_workspace()
+        REFERENCES workspaces(name) ON DELETE CASCADE,
+    command VARCHAR(100) NOT NULL,                   -- e.g. 'transcription create-batch'
+    target VARCHAR(255) NOT NULL,                    -- What the run processes, e.g. a channel ID
+    options JSONB NOT NULL DEFAULT '{}',             -- Settings a resumed run reuses, e.g. {"language": "ja", "model": "base"}
+    status VARCHAR(20) NOT NULL DEFAULT 'running',   -- Status: 'running', 'completed', 'failed'
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_runs_workspace_created_at ON runs(workspace, created_at DESC);
+
+-- The items of a run, in processing order. Runs interrupted mid-item leave it 'running'.
+CREATE TABLE IF NOT EXISTS run_items (
+    run_id UUID NOT NULL REFERENCES runs(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,                       -- 0-based processing order
+    item_id VARCHAR(255) NOT NULL,                   -- e.g. a video ID
+    title TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',   -- Status: 'pending', 'running', 'completed', 'failed'
+    result_id VARCHAR(255),                          -- What the item produced, e.g. a transcription ID
+    error_message TEXT,
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE,
+
+    PRIMARY KEY (run_id, position)
+);
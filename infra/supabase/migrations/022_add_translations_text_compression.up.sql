@@ -0,0 +1,5 @@
+-- Long translated texts are stored gzip-compressed by the translation repository. Postgres only
+-- compresses (TOASTs) values of rows over about 2 kB, which single segments rarely reach.
+-- Rows saved before compression keep their text in translated_text and read unchanged.
+ALTER TABLE translations
+    ADD COLUMN IF NOT EXISTS translated_text_gz BYTEA; -- gzip of the text when set; translated_text is then empty
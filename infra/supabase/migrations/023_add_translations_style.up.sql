@@ -0,0 +1,10 @@
+-- Style a translation was adapted to (translation create --style), so a transcription can have
+-- simple, formal and casual variants next to its plain translation in the same language
+ALTER TABLE translations
+    ADD COLUMN IF NOT EXISTS style VARCHAR(20) NOT NULL DEFAULT ''; -- simple, formal, casual; empty for plain translations
+
+ALTER TABLE translations
+    DROP CONSTRAINT IF EXISTS unique_translation_per_segment_lang_source;
+ALTER TABLE translations
+    ADD CONSTRAINT unique_translation_per_segment_lang_source_style
+        UNIQUE (transcription_segment_id, target_language, source, style);
@@ -0,0 +1,14 @@
+-- Phrases whisper emits on silence or music ("Thanks for watching!"), added to the configured list
+-- with transcription hallucinations add. Segments consisting only of one are flagged or removed
+-- when a transcription is saved.
+CREATE TABLE IF NOT EXISTS hallucination_phrases (
+    workspace VARCHAR(100) NOT NULL DEFAULT current_workspace(),
+    phrase VARCHAR(500) NOT NULL,  -- As entered; matched regardless of case and punctuation
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    PRIMARY KEY (workspace, phrase)
+);
+
+-- Segments of a transcription that matched the list when it was saved
+ALTER TABLE transcriptions
+    ADD COLUMN IF NOT EXISTS hallucinations JSONB; -- e.g. {"mode": "flag", "phrases": {"Thanks for watching!": 2}, "segments": [41, 87]}; NULL when none matched
@@ -0,0 +1,18 @@
+-- When the watch daemon syncs each channel: an interval ('@every 12h') or a cron expression
+-- ('0 6 * * *'), set with channel schedule. The next run is computed from the last one.
+CREATE TABLE IF NOT EXISTS channel_schedules (
+    workspace VARCHAR(100) NOT NULL DEFAULT current_workspace(),
+    channel_id VARCHAR(255) NOT NULL,     -- Foreign key to channels.id
+    spec VARCHAR(100) NOT NULL,           -- e.g. '@every 12h', '0 6 * * 1-5'
+    last_run_at TIMESTAMP WITH TIME ZONE, -- Start of the last sync; NULL until the first one
+    last_error TEXT,                      -- Why the last sync failed; NULL when it succeeded
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    PRIMARY KEY (workspace, channel_id),
+
+    -- Schedules are removed with their channel
+    CONSTRAINT fk_channel_schedules_channel_id
+        FOREIGN KEY (workspace, channel_id)
+        REFERENCES channels(workspace, id)
+        ON DELETE CASCADE
+);
@@ -0,0 +1,19 @@
+-- Embeddings of segments for search semantic, computed with search index by the model configured
+-- under embeddings. They need the pgvector extension; on servers without it the columns are not
+-- created and search semantic reports that pgvector is missing, while everything else works.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_available_extensions WHERE name = 'vector') THEN
+        CREATE EXTENSION IF NOT EXISTS vector;
+
+        -- Untyped vectors: the dimension depends on the model, and embeddings of one model are only
+        -- compared with each other
+        ALTER TABLE transcription_segments
+            ADD COLUMN IF NOT EXISTS embedding vector,
+            ADD COLUMN IF NOT EXISTS embedding_model VARCHAR(100); -- e.g. 'text-embedding-3-small'; NULL without an embedding
+
+        CREATE INDEX IF NOT EXISTS idx_transcription_segments_embedding_model
+            ON transcription_segments(embedding_model) WHERE embedding IS NOT NULL;
+    END IF;
+END
+$$;
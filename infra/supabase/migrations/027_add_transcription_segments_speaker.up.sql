@@ -0,0 +1,4 @@
+-- Who speaks each segment, when the transcriber diarized the audio (e.g. whisperX writes
+-- "speaker": "SPEAKER_00" per segment). Written as WebVTT voice tags by subtitle exports.
+ALTER TABLE transcription_segments
+    ADD COLUMN IF NOT EXISTS speaker VARCHAR(100); -- NULL when the speaker is unknown
@@ -0,0 +1,15 @@
+-- Local usage stats: one row per command run, recorded only when usage_stats.enabled is set in
+-- the config file, and never sent anywhere. Arguments and error messages are not kept, only
+-- what stats usage needs to show where time goes.
+CREATE TABLE IF NOT EXISTS command_usage (
+    id BIGSERIAL PRIMARY KEY,
+    workspace VARCHAR(100) NOT NULL DEFAULT current_workspace()
+        REFERENCES workspaces(name) ON DELETE CASCADE,
+    command VARCHAR(200) NOT NULL,         -- Command path without the program name, e.g. 'transcription create'
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    duration_ms BIGINT NOT NULL,
+    succeeded BOOLEAN NOT NULL,
+    error_code VARCHAR(50)                 -- Code of a failure, e.g. 'EXTERNAL_ERROR'; NULL on success or when unknown
+);
+
+CREATE INDEX IF NOT EXISTS idx_command_usage_workspace_started_at ON command_usage(workspace, started_at DESC);
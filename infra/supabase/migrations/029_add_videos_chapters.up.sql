@@ -0,0 +1,4 @@
+-- Chapters of a video as YouTube lists them, recorded by video add, so translation create
+-- --per-chapter can translate long videos chapter by chapter
+ALTER TABLE videos
+    ADD COLUMN IF NOT EXISTS chapters JSONB; -- [{"title": "Intro", "start_time": 0, "end_time": 95.5}, ...]; NULL when unknown
@@ -0,0 +1,9 @@
+-- Tokens sent to each translation provider per day, so the daily quotas of translation.providers
+-- hold across runs. Quotas belong to provider accounts, not workspaces, so usage is shared by all
+-- workspaces.
+CREATE TABLE IF NOT EXISTS translation_provider_usage (
+    provider VARCHAR(50) NOT NULL,         -- Provider name from the config file, as recorded in translations.source
+    day DATE NOT NULL,                     -- UTC day the tokens were sent
+    tokens BIGINT NOT NULL DEFAULT 0,      -- Estimated input tokens of the batches translated
+    PRIMARY KEY (provider, day)
+);
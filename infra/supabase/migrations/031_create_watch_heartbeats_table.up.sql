@@ -0,0 +1,14 @@
+-- Heartbeats of watch run daemons, written after every check for due channels, so watch health
+-- can tell a running daemon from a wedged or stopped one. One row per host: a restarted daemon
+-- replaces the heartbeat of the previous one.
+CREATE TABLE IF NOT EXISTS watch_heartbeats (
+    workspace VARCHAR(100) NOT NULL DEFAULT current_workspace()
+        REFERENCES workspaces(name) ON DELETE CASCADE,
+    host VARCHAR(255) NOT NULL,                       -- Hostname of the daemon
+    pid INTEGER NOT NULL,                             -- Process ID of the daemon
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,     -- When the daemon started
+    last_loop_at TIMESTAMP WITH TIME ZONE NOT NULL,   -- Start of the daemon's last check
+    queue_depth INTEGER NOT NULL DEFAULT 0,           -- Channels due at the start of the last check
+
+    PRIMARY KEY (workspace, host)
+);
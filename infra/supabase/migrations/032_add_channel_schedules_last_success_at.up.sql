@@ -0,0 +1,4 @@
+-- When each scheduled channel last synced successfully, kept when later syncs fail, so watch
+-- health shows how long a failing channel has gone without new videos
+ALTER TABLE channel_schedules
+    ADD COLUMN IF NOT EXISTS last_success_at TIMESTAMP WITH TIME ZONE; -- NULL until a sync succeeds
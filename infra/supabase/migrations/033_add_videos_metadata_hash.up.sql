@@ -0,0 +1,4 @@
+-- Hash of the title, duration and upload date of each video as last listed, so channel syncs
+-- notice videos re-edited on YouTube after they were saved
+ALTER TABLE videos
+    ADD COLUMN IF NOT EXISTS metadata_hash VARCHAR(64); -- SHA-256 hex; NULL until the first sync after this migration
@@ -0,0 +1,5 @@
+-- Transcriptions of videos whose metadata changed on YouTube after they were saved, so
+-- transcription list --stale shows what to transcribe again
+ALTER TABLE transcriptions
+    ADD COLUMN IF NOT EXISTS stale_at TIMESTAMP WITH TIME ZONE, -- When the change was noticed; NULL when the transcription is current
+    ADD COLUMN IF NOT EXISTS stale_reason TEXT;                 -- e.g. 'title, duration changed on YouTube'
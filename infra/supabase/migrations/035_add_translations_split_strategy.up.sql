@@ -0,0 +1,4 @@
+-- How the batch translation of each segment was split back into segments, so resumed and
+-- repeated translations can tell which segments needed a fallback
+ALTER TABLE translations
+    ADD COLUMN IF NOT EXISTS split_strategy VARCHAR(32) NOT NULL DEFAULT ''; -- separator, normalized_separator, sentences, individual; empty when not batch translated
@@ -0,0 +1,23 @@
+-- HNSW indexes for the nearest segments of search semantic, which otherwise compares the query
+-- with every embedding. The embedding column is untyped, so each index casts it to one common
+-- dimension and covers only the embeddings of that dimension; search semantic casts the same way.
+-- Embeddings of other dimensions are scanned, like those over the 2000 dimensions pgvector can
+-- index (e.g. text-embedding-3-large).
+-- Skipped without the embedding column (no pgvector) or with a pgvector older than 0.5 (no hnsw).
+DO $$
+DECLARE
+    dims INTEGER;
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns
+               WHERE table_name = 'transcription_segments' AND column_name = 'embedding')
+        AND EXISTS (SELECT 1 FROM pg_am WHERE amname = 'hnsw') THEN
+        FOREACH dims IN ARRAY ARRAY[384, 768, 1024, 1536] LOOP -- e.g. all-MiniLM-L6-v2, nomic-embed-text, bge-m3, text-embedding-3-small
+            EXECUTE format(
+                'CREATE INDEX IF NOT EXISTS idx_transcription_segments_embedding_%s
+                    ON transcription_segments USING hnsw ((embedding::vector(%s)) vector_cosine_ops)
+                    WHERE vector_dims(embedding) = %s',
+                dims, dims, dims);
+        END LOOP;
+    END IF;
+END
+$$;
@@ -0,0 +1,4 @@
+-- The chapter each translation of translation create --per-chapter was made in, linking the
+-- chapter items of the run to the translations they produced
+ALTER TABLE translations
+    ADD COLUMN IF NOT EXISTS chapter VARCHAR(64) NOT NULL DEFAULT ''; -- Time range of the chapter, e.g. '00:00:00.000-00:10:00.000'; empty when not translated per chapter